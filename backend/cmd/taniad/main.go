@@ -2,29 +2,77 @@ package main
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"runtime/debug"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/asaskevich/EventBus"
+	"github.com/getsentry/sentry-go"
 	"github.com/go-sql-driver/mysql"
 	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/labstack/gommon/bytes"
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/spf13/pflag"
 	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/areacrops"
+	assetsqueryinmemory "github.com/usetania/tania-core/src/assets/query/inmemory"
+	assetsquerymysql "github.com/usetania/tania-core/src/assets/query/mysql"
+	assetsquerysqlite "github.com/usetania/tania-core/src/assets/query/sqlite"
 	assetsserver "github.com/usetania/tania-core/src/assets/server"
 	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/auth"
+	"github.com/usetania/tania-core/src/bodyencryption"
+	"github.com/usetania/tania-core/src/changefeed"
+	"github.com/usetania/tania-core/src/clientip"
+	"github.com/usetania/tania-core/src/customfield"
+	dashboardserver "github.com/usetania/tania-core/src/dashboard/server"
+	"github.com/usetania/tania-core/src/demo"
+	"github.com/usetania/tania-core/src/devicesignature"
+	"github.com/usetania/tania-core/src/energy"
+	"github.com/usetania/tania-core/src/envalert"
 	"github.com/usetania/tania-core/src/eventbus"
+	"github.com/usetania/tania-core/src/farmimport"
+	"github.com/usetania/tania-core/src/farmscope"
+	"github.com/usetania/tania-core/src/featureflags"
+	"github.com/usetania/tania-core/src/geoip"
+	growthqueryinmemory "github.com/usetania/tania-core/src/growth/query/inmemory"
+	growthquerymysql "github.com/usetania/tania-core/src/growth/query/mysql"
+	growthquerysqlite "github.com/usetania/tania-core/src/growth/query/sqlite"
 	growthserver "github.com/usetania/tania-core/src/growth/server"
 	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/httperror"
+	"github.com/usetania/tania-core/src/idempotency"
+	"github.com/usetania/tania-core/src/info"
+	"github.com/usetania/tania-core/src/integration"
 	locationserver "github.com/usetania/tania-core/src/location/server"
+	"github.com/usetania/tania-core/src/maintenance"
+	"github.com/usetania/tania-core/src/media"
+	"github.com/usetania/tania-core/src/notification"
+	"github.com/usetania/tania-core/src/orphans"
+	"github.com/usetania/tania-core/src/presence"
+	"github.com/usetania/tania-core/src/reportmail"
+	"github.com/usetania/tania-core/src/requestlog"
+	"github.com/usetania/tania-core/src/retention"
+	"github.com/usetania/tania-core/src/schema"
+	"github.com/usetania/tania-core/src/signing"
+	"github.com/usetania/tania-core/src/slowquery"
+	redisstorage "github.com/usetania/tania-core/src/storage/redis"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
 	tasksserver "github.com/usetania/tania-core/src/tasks/server"
 	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+	"github.com/usetania/tania-core/src/units"
 	userserver "github.com/usetania/tania-core/src/user/server"
 )
 
@@ -36,24 +84,126 @@ func main() {
 
 	e := echo.New()
 
+	// The 5xx errors only answer their cause and stack trace with the verbose errors, they're always logged.
+	e.HTTPErrorHandler = httperror.Handler(e, *config.Config.VerboseErrors, log.Default())
+
+	// The IP of the client is only taken from the X-Forwarded-For of the trusted proxies, by c.RealIP() too,
+	// so the request log and the location of the requests see the IP the whitelist does.
+	clientIPs, err := clientip.NewResolver(*config.Config.TrustedProxies)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	e.IPExtractor = clientIPs.IP
+
 	// Initialize DB.
 	log.Println("Using " + *config.Config.TaniaPersistenceEngine + " persistence engine")
 
 	// InMemory DB will always be initialized.
 	inMem := initInMemory()
 
-	var db *sql.DB
+	var db, archiveDB *sql.DB
 
 	switch *config.Config.TaniaPersistenceEngine {
 	case config.DBSqlite:
 		db = initSqlite()
+		archiveDB = initSqliteArchive()
 	case config.DBMysql:
 		db = initMysql()
+		archiveDB = initMysqlArchive(db)
+	}
+
+	// The events of the inmemory engine are kept in the Redis streams when there's a Redis.
+	eventStreams := initEventStreams()
+
+	// The photos no area or crop references anymore are quarantined, then deleted after the grace period.
+	photoReferences := initPhotoReferences(db, inMem)
+
+	orphanCleaner, err := orphans.NewCleaner(photoReferences, []orphans.Folder{
+		{Name: orphans.FolderAreas, Path: *config.Config.UploadPathArea},
+		{Name: orphans.FolderCrops, Path: *config.Config.UploadPathCrop},
+	}, *config.Config.OrphanQuarantinePath, *config.Config.OrphanGraceDays)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The maintenance mode is kept in the database, so it survives a restart and the commands can enter it.
+	maintenanceSwitch, err := maintenance.NewSwitch(initMaintenanceModeStore(db, inMem))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// `taniad cleanup-orphans` runs the cleanup once, without serving.
+	if pflag.Arg(0) == "cleanup-orphans" {
+		err = cleanupOrphans(orphanCleaner)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	// `taniad rebuild-area-crops` regenerates the AREA_CROP_CURRENT table from the crops,
+	// `taniad check-area-crops` fails when the table doesn't match them.
+	if pflag.Arg(0) == "rebuild-area-crops" || pflag.Arg(0) == "check-area-crops" {
+		err = runAreaCrops(pflag.Arg(0), db, maintenanceSwitch)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	// The features shipped dark are turned on and off by the feature flags, reloaded on SIGHUP.
+	featureFlags, err := featureflags.NewRegistry(featureflags.Flags(), config.Config.FeatureFlags)
+	if err != nil {
+		log.Fatal(err)
 	}
 
+	reloadFeatureFlagsOnSIGHUP(featureFlags)
+
 	// Initialize Event Bus
 	bus := eventbus.NewSimpleEventBus(EventBus.New())
 
+	// The modules register what they enable, it's listed by GET /api/info.
+	features := info.NewRegistry()
+	mqttEnabled := *config.Config.MQTTBrokerURL != "" && featureFlags.Enabled(featureflags.MQTT)
+	features.RegisterFeature("mqtt_events", mqttEnabled)
+	features.RegisterFeature("slow_query_log", db != nil && slowQueryThreshold() > 0)
+
+	// The calls to the external services go through a circuit breaker each, listed by GET /api/admin/circuit-breakers.
+	breakers := integration.NewRegistry(
+		*config.Config.CircuitBreakerThreshold,
+		time.Duration(*config.Config.CircuitBreakerReset)*time.Second,
+	)
+
+	// And through the corporate proxy when there's one.
+	proxy, err := integration.NewProxyTransport(*config.Config.HTTPProxyURL, *config.Config.HTTPProxyCAPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	features.RegisterFeature("http_proxy", proxy != nil)
+
+	if mqttEnabled {
+		mqttPublisher, err := notification.NewMQTTEventPublisher(
+			*config.Config.MQTTBrokerURL,
+			*config.Config.MQTTQoS,
+			*config.Config.MQTTRetain,
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		go mqttPublisher.Connect()
+
+		bus.SubscribeAll(mqttPublisher.Publish)
+	}
+
+	changeFeedStore := initChangeFeedStore(db, inMem)
+	bus.SubscribeAll(changefeed.NewProjection(changeFeedStore).Handle)
+	features.RegisterFeature("change_feed", true)
+
 	// Initialize Server
 	farmServer, err := assetsserver.NewFarmServer(
 		db,
@@ -65,8 +215,22 @@ func main() {
 		inMem.reservoirReadStorage,
 		inMem.materialEventStorage,
 		inMem.materialReadStorage,
+		inMem.farmCertificationEventStorage,
+		inMem.farmCertificationReadStorage,
+		inMem.customFieldDefinitionEventStorage,
+		inMem.customFieldDefinitionReadStorage,
+		inMem.customFieldValueStorage,
+		inMem.stocktakeEventStorage,
+		inMem.stocktakeReadStorage,
+		inMem.equipmentEventStorage,
+		inMem.equipmentReadStorage,
+		inMem.nutrientRecipeEventStorage,
+		inMem.nutrientRecipeReadStorage,
+		inMem.farmBoundaryEventStorage,
 		inMem.cropReadStorage,
+		inMem.taskReadStorage,
 		bus,
+		featureFlags,
 	)
 	if err != nil {
 		e.Logger.Fatal(err)
@@ -74,33 +238,187 @@ func main() {
 
 	taskServer, err := tasksserver.NewTaskServer(
 		db,
+		archiveDB,
 		bus,
 		inMem.cropReadStorage,
 		inMem.areaReadStorage,
 		inMem.materialReadStorage,
 		inMem.reservoirReadStorage,
+		inMem.equipmentReadStorage,
 		inMem.taskEventStorage,
 		inMem.taskReadStorage,
+		inMem.taskArchiveStorage,
+		inMem.taskTemplateEventStorage,
+		inMem.taskTemplateReadStorage,
+		inMem.taskCatalogEventStorage,
+		inMem.prunedStorage,
+		inMem.customFieldValueStorage,
+		inMem.customFieldDefinitionReadStorage,
+		featureFlags,
+		maintenanceSwitch.Paused,
 	)
 	if err != nil {
 		e.Logger.Fatal(err)
 	}
 
+	taskNotifier, err := initTaskNotifier(breakers, proxy, initWebhookDeliveryStore(db, inMem))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	taskNotifier.Webhook.Enabled = func() bool {
+		return featureFlags.Enabled(featureflags.Webhooks)
+	}
+
+	taskServer.StartNotifications(taskNotifier)
+
+	if *config.Config.PagerDutyIntegrationKey != "" {
+		pagerDuty := notification.NewPagerDutyNotifier(*config.Config.PagerDutyIntegrationKey)
+		pagerDuty.Client.Transport = breakers.Breaker("pagerduty").Transport(proxy)
+
+		taskServer.StartEscalations(pagerDuty)
+	}
+
+	features.RegisterFeature("pagerduty_escalation", *config.Config.PagerDutyIntegrationKey != "")
+
+	// The catalog of the webhook events is generated from the payloads the notifications are posted as.
+	webhookEventTypes, err := initWebhookEventTypes()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	features.RegisterFeature("task_notifications", true)
+
 	growthServer, err := growthserver.NewGrowthServer(
 		db,
 		bus,
 		inMem.cropEventStorage,
 		inMem.cropReadStorage,
 		inMem.cropActivityStorage,
+		inMem.cropInputScheduleEventStorage,
+		inMem.cropInputScheduleReadStorage,
+		inMem.microclimateSampleStorage,
+		inMem.dispatchScheduleEventStorage,
+		inMem.dispatchScheduleReadStorage,
+		inMem.insurancePolicyEventStorage,
+		inMem.insurancePolicyReadStorage,
+		inMem.harvestLotEventStorage,
+		inMem.harvestLotReadStorage,
 		inMem.areaReadStorage,
 		inMem.materialReadStorage,
 		inMem.farmReadStorage,
 		inMem.taskReadStorage,
+		inMem.prunedStorage,
+		inMem.customFieldValueStorage,
+		inMem.customFieldDefinitionReadStorage,
+		inMem.energyReadingStorage,
+		inMem.environmentAlertRuleStorage,
+		inMem.photoHashStorage,
+		inMem.signingKeyStorage,
+		featureFlags,
 	)
 	if err != nil {
 		e.Logger.Fatal(err)
 	}
 
+	// The input schedule tasks are created by the tasks module.
+	growthServer.StartInputScheduler(taskServer, maintenanceSwitch.Paused)
+	features.RegisterFeature("crop_input_schedules", true)
+	features.RegisterJob("input_scheduler", true)
+
+	// The dispatch tasks are created by the tasks module too.
+	growthServer.DispatchTaskCreator = taskServer
+	features.RegisterFeature("dispatch_schedules", true)
+
+	growthServer.StartEnvironmentAlertNotifications(taskNotifier)
+	features.RegisterFeature("environment_alerts", true)
+
+	// The renewal tasks are created by the tasks module from the published events.
+	farmServer.StartCertificationScheduler(maintenanceSwitch.Paused)
+	features.RegisterFeature("farm_certifications", true)
+	features.RegisterJob("certification_renewal", true)
+
+	// The maintenance tasks are created by the tasks module from the published events.
+	farmServer.StartEquipmentMaintenanceScheduler(maintenanceSwitch.Paused)
+	features.RegisterFeature("equipment", true)
+	features.RegisterJob("equipment_maintenance", true)
+	features.RegisterFeature("custom_fields", true)
+	features.RegisterFeature("gdd_harvest_prediction", true)
+	features.RegisterFeature("energy_tracking", true)
+	features.RegisterFeature("stocktakes", true)
+	features.RegisterFeature("worksheets", true)
+
+	presenceRegistry, err := initPresenceRegistry(db)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	// DashboardServer must be created after the servers whose read models it counts.
+	dashboardServer, err := dashboardserver.NewDashboardServer(
+		db,
+		archiveDB,
+		bus,
+		inMem.farmReadStorage,
+		inMem.areaReadStorage,
+		inMem.reservoirReadStorage,
+		inMem.materialReadStorage,
+		inMem.materialEventStorage,
+		inMem.farmCertificationReadStorage,
+		inMem.cropReadStorage,
+		inMem.cropActivityStorage,
+		inMem.microclimateSampleStorage,
+		inMem.taskReadStorage,
+		inMem.taskArchiveStorage,
+		inMem.customFieldValueStorage,
+		inMem.customFieldDefinitionReadStorage,
+		inMem.reportMailStorage,
+		notification.NewSMTPNotifier(
+			*config.Config.SMTPHost,
+			*config.Config.SMTPPort,
+			*config.Config.SMTPUsername,
+			*config.Config.SMTPPassword,
+			*config.Config.SMTPFrom,
+		),
+		changeFeedStore,
+		initImportStore(db, inMem, eventStreams),
+		presenceRegistry,
+	)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	if eventStreams != nil {
+		farmServer.UseEventStreams(eventStreams)
+		growthServer.UseEventStreams(eventStreams)
+		taskServer.UseEventStreams(eventStreams)
+		dashboardServer.UseEventStreams(eventStreams)
+	}
+
+	features.RegisterFeature("redis_event_streams", eventStreams != nil)
+
+	// The generated tasks are balanced with the effort of the farm's tasks the dashboard counts.
+	taskServer.StartWorkloadBalancing(dashboardServer)
+	features.RegisterFeature("workload_balancing", true)
+
+	// The farms have no calendar of their own yet, they all operate in the business hours of the config.
+	if len(config.Config.TaskBusinessDays) > 0 {
+		businessHours, err := tasksdomain.NewBusinessHoursConfig(config.Config.TaskBusinessDays,
+			*config.Config.TaskBusinessHoursStart, *config.Config.TaskBusinessHoursEnd, time.Local)
+		if err != nil {
+			e.Logger.Fatal(err)
+		}
+
+		taskServer.StartBusinessHoursAdjustment(tasksserver.ConfigFarmCalendar{Hours: businessHours})
+	}
+
+	features.RegisterFeature("business_hours", len(config.Config.TaskBusinessDays) > 0)
+	features.RegisterFeature("notes_search", true)
+
+	// The scheduler runs without an SMTP host too, its failed sends stay visible on the deliveries.
+	dashboardServer.StartReportScheduler(maintenanceSwitch.Paused)
+	features.RegisterFeature("report_emails", *config.Config.SMTPHost != "")
+	features.RegisterJob("report_scheduler", true)
+
 	userServer, err := userserver.NewUserServer(db, bus)
 	if err != nil {
 		e.Logger.Fatal(err)
@@ -116,23 +434,141 @@ func main() {
 		e.Logger.Fatal(err)
 	}
 
+	if *config.Config.RetentionYears > 0 {
+		pruner, err := retention.NewPruner(
+			initRetentionStore(db, inMem),
+			*config.Config.RetentionYears,
+			*config.Config.RetentionDryRun,
+			*config.Config.RetentionArchivePath,
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		pruner.Start(maintenanceSwitch.Paused)
+	}
+
+	features.RegisterJob("retention", *config.Config.RetentionYears > 0)
+
+	// The archiver always runs, the farms with an archival policy archive their tasks without the days.
+	taskServer.StartArchiver(*config.Config.TaskArchiveAfterDays, maintenanceSwitch.Paused)
+
+	features.RegisterFeature("task_archive", true)
+	features.RegisterJob("task_archival", true)
+
+	features.RegisterJob("task_due", *config.Config.TaskDueCheckMinutes > 0)
+
+	if *config.Config.OrphanCleanupHours > 0 {
+		orphanCleaner.Start(time.Duration(*config.Config.OrphanCleanupHours)*time.Hour, maintenanceSwitch.Paused)
+	}
+
+	features.RegisterJob("orphan_cleanup", *config.Config.OrphanCleanupHours > 0)
+	features.RegisterJobReport("orphan_cleanup", func() (interface{}, bool) {
+		return orphanCleaner.LastReport()
+	})
+
+	growthServer.PhotoReferences = photoReferences
+	farmServer.PhotoReferences = photoReferences
+
 	// Initialize user
 	err = initUser(authServer)
 	if err != nil {
 		e.Logger.Fatal(err)
 	}
 
+	sentryEnabled, err := initSentry(*config.Config.SentryDSN, breakers, proxy)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	features.RegisterFeature("sentry", sentryEnabled)
+	features.RegisterFeature("validate_only", true)
+	features.RegisterFeature("role_permissions", true)
+
+	maxUploadSize, err := bytes.Parse(*config.Config.MaxUploadSize)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
 	// Initialize Echo Middleware
-	e.Use(middleware.Recover())
+	e.Use(recoverMiddleware(sentryEnabled))
 	e.Use(headerNoCache)
-	e.Use(logMiddleware())
+
+	// The responses carry the message of the maintenance notice during its window.
+	maintenanceNoticeStorage := maintenance.CreateMaintenanceNoticeStorage()
+	e.Use(maintenance.Middleware(maintenanceNoticeStorage))
+
+	// During the maintenance mode the requests changing data are refused, except the sign in and leaving the mode.
+	// The mode a command enters is seen by the server within a few seconds.
+	maintenanceSwitch.StartReloads()
+	e.Use(maintenance.WriteGuard(maintenanceSwitch, "/api/authorize", "/api/auth/session-key", "/api/admin/maintenance"))
+
+	redactor, err := requestlog.NewRedactor(config.Config.LogExcludedFields)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	e.Use(logMiddleware(redactor))
+
+	// The location has to be saved before the request log is written, so its middleware comes after.
+	if *config.Config.GeoIPDBPath != "" {
+		geoIPReader, err := geoip.Open(*config.Config.GeoIPDBPath)
+		if err != nil {
+			e.Logger.Fatal(err)
+		}
+
+		defer geoIPReader.Close()
+
+		e.Use(geoip.Middleware(geoIPReader))
+	}
+
+	features.RegisterFeature("geoip", *config.Config.GeoIPDBPath != "")
 	e.Use(middleware.RequestID())
 
+	// The bodies are decrypted before the request log keeps them, so it can redact their fields.
+	bodyEncryptionStorage := bodyencryption.CreateSessionStorage(
+		time.Duration(*config.Config.BodyEncryptionTTL) * time.Minute,
+	)
+	if *config.Config.BodyEncryptionEnabled {
+		e.Use(bodyencryption.Middleware(bodyEncryptionStorage, "/api/info", "/api/health"))
+	}
+
+	features.RegisterFeature("body_encryption", *config.Config.BodyEncryptionEnabled)
+	e.Use(requestlog.Middleware())
+
+	// Every JSON response hides its redacted task fields from the roles the redact tag names, the tasks are
+	// returned by the sync, the boards and the dashboard as well. The exports and the notes search use its role.
+	fieldRedactor, err := auth.NewRedactor(authServer, taskstorage.TaskRead{}, tasksdomain.TaskCreated{},
+		tasksdomain.TaskDescriptionChanged{}, tasksdomain.TaskFields{})
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	e.Use(fieldRedactor.Middleware())
+	dashboardServer.Redactor = fieldRedactor
+	dashboardServer.MaxUploadSize = maxUploadSize
+
 	APIMiddlewares := []echo.MiddlewareFunc{}
 	if !*config.Config.DemoMode {
 		APIMiddlewares = append(APIMiddlewares, tokenValidationWithConfig(db))
 	}
 
+	// The keys are scoped to the user of the token, so their middleware comes after its validation.
+	idempotencyTTL := time.Duration(*config.Config.IdempotencyKeyTTL) * time.Hour
+	APIMiddlewares = append(APIMiddlewares, idempotency.Middleware(initIdempotencyStore(db, inMem), idempotencyTTL))
+
+	features.RegisterFeature("idempotency_keys", true)
+
+	// The whitelist is turned on by its CIDRs too, and lets no one through when it's on without them.
+	ipWhitelist, err := clientip.Whitelist(
+		*config.Config.AdminAllowedCIDR,
+		*config.Config.AdminIPWhitelist || strings.TrimSpace(*config.Config.AdminAllowedCIDR) != "",
+		clientIPs,
+	)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
 	// HTTP routing
 	API := e.Group("api")
 	API.Use(middleware.CORS())
@@ -141,33 +577,145 @@ func main() {
 	authGroup := API.Group("/")
 	authServer.Mount(authGroup)
 
+	// The info is public, so the clients can check the server before they log in.
+	infoServer := info.NewServer(features, maxUploadSize)
+	infoServer.Maintenance = maintenanceSwitch
+	infoServer.FeatureFlags = featureFlags
+	infoGroup := API.Group("/info")
+	infoServer.Mount(infoGroup)
+
+	// The readiness is public too, for the load balancers, and fails during the maintenance window.
+	maintenanceServer := maintenance.NewServer(maintenanceNoticeStorage, maintenanceSwitch)
+	healthGroup := API.Group("/health")
+	maintenanceServer.MountHealth(healthGroup)
+
+	// The session keys are negotiated before the login, so their exchange is public.
+	if *config.Config.BodyEncryptionEnabled {
+		bodyencryption.NewServer(bodyEncryptionStorage).Mount(API.Group("/auth"))
+	}
+
+	// The writes need a permission of the role of the user, so their middleware comes after the token validation.
+	policy := auth.DefaultPolicy()
+	requiring := func(fallback auth.Permission, rules ...auth.RoutePermission) []echo.MiddlewareFunc {
+		return append(append([]echo.MiddlewareFunc{}, APIMiddlewares...),
+			auth.RequireRoutePermissions(policy, authServer, fallback, rules))
+	}
+
+	permissionGroup := API.Group("/auth", APIMiddlewares...)
+	auth.NewServer(policy, authServer).Mount(permissionGroup)
+
 	locationGroup := API.Group("/locations", APIMiddlewares...)
 	locationServer.Mount(locationGroup)
 
-	farmGroup := API.Group("/farms", APIMiddlewares...)
+	// The sensors sign their samples with the secret of a device, on top of the token of their user.
+	deviceRegistry := initDeviceRegistry(db, inMem)
+	growthServer.SampleSignature = devicesignature.Middleware(deviceRegistry, devicesignature.NewNonceCache())
+
+	features.RegisterFeature("sensor_signature", true)
+
+	farmGroup := API.Group("/farms", requiring(auth.PermissionManageFarms, auth.FarmRoutePermissions()...)...)
 	farmServer.Mount(farmGroup)
 	growthServer.Mount(farmGroup)
+	dashboardServer.Mount(farmGroup)
 
-	taskGroup := API.Group("/tasks", APIMiddlewares...)
+	// The imports run in the maintenance mode, the farm can't change while its events are saved.
+	importGroup := API.Group("/import", append(requiring(auth.PermissionManageFarms),
+		maintenanceSwitch.Hold("Importing a farm", importModeDuration))...)
+	dashboardServer.MountImport(importGroup)
+
+	taskGroup := API.Group("/tasks", requiring(auth.PermissionManageTasks, auth.TaskRoutePermissions()...)...)
 	taskServer.Mount(taskGroup)
 
-	userGroup := API.Group("/user", APIMiddlewares...)
+	taskTemplateGroup := API.Group("/task-templates", requiring(auth.PermissionManageTasks)...)
+	taskServer.MountTaskTemplates(taskTemplateGroup)
+
+	taskCatalogGroup := API.Group("/task-catalogs", requiring(auth.PermissionManageFarms)...)
+	taskServer.MountTaskCatalogs(taskCatalogGroup)
+	taskServer.MountTaskArchivalPolicy(taskCatalogGroup)
+
+	userGroup := API.Group("/user", requiring(auth.PermissionManageUsers)...)
 	userServer.Mount(userGroup)
 
+	unitsGroup := API.Group("/units", APIMiddlewares...)
+	units.NewServer().Mount(unitsGroup)
+
+	webhookGroup := API.Group("/webhooks", append(requiring(auth.PermissionManageFarms),
+		featureFlags.Require(featureflags.Webhooks))...)
+	notification.NewWebhookServer(webhookEventTypes, taskNotifier.Webhook).Mount(webhookGroup)
+
+	syncGroup := API.Group("/sync", APIMiddlewares...)
+	changefeed.NewServer(changeFeedStore).Mount(syncGroup)
+
+	adminGroup := API.Group("/admin", append(append([]echo.MiddlewareFunc{ipWhitelist}, APIMiddlewares...),
+		auth.RequirePermission(policy, authServer, auth.PermissionManageFarms))...)
+	dashboardServer.MountAdmin(adminGroup)
+	growthServer.MountAdmin(adminGroup)
+	integration.NewServer(breakers).Mount(adminGroup)
+	fieldRedactor.Mount(adminGroup)
+	maintenanceServer.Mount(adminGroup)
+	devicesignature.NewServer(deviceRegistry).Mount(adminGroup)
+	infoServer.MountAdmin(adminGroup)
+
 	e.Static("/", "public")
 
+	if *config.Config.DemoMode {
+		initDemo(e, API, farmServer)
+	}
+
 	// Start Server
 	e.Logger.Fatal(e.Start(":" + *config.Config.AppPort))
 }
 
-func initUser(authServer *userserver.AuthServer) error {
-	defaultUsername := "tania"
-	defaultPassword := "tania"
+// The user created at the first start, the owner of the demo farms.
+const (
+	defaultUsername = "tania"
+	defaultPassword = "tania"
+)
+
+// initDemo seeds the demo farms, unless they are seeded already, then makes the API read only.
+// The sign in is still allowed, with the credentials GET /api/demo-info lists.
+func initDemo(e *echo.Echo, api *echo.Group, farmServer *assetsserver.FarmServer) {
+	seed, err := demo.LoadSeed(*config.Config.DemoSeedPath)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		log.Println("No demo seed file at ", *config.Config.DemoSeedPath)
+	case err != nil:
+		e.Logger.Fatal(err)
+	default:
+		seeded, err := seed.Apply(e, "/api")
+		if err != nil {
+			e.Logger.Fatal(err)
+		}
+
+		if seeded {
+			log.Println("Demo farms seeded from ", *config.Config.DemoSeedPath)
+		}
+	}
 
-	_, _, err := authServer.RegisterNewUser(defaultUsername, defaultPassword, defaultPassword)
+	e.Use(demo.ReadOnly("/api/authorize"))
+
+	demoInfoGroup := api.Group("/demo-info")
+	demo.NewServer(farmServer.FarmReadQuery, demo.Credentials{
+		Username: defaultUsername,
+		Password: defaultPassword,
+	}).Mount(demoInfoGroup)
+}
+
+// initUser creates the initial user as an owner. The initial user created before the roles is made an owner too,
+// the other users without a role are workers.
+func initUser(authServer *userserver.AuthServer) error {
+	_, _, err := authServer.RegisterNewUser(
+		defaultUsername, defaultPassword, defaultPassword, auth.RoleOwner, actor.System("initial_user"),
+	)
 	if err != nil {
 		log.Println("User ", defaultUsername, " has already created")
 
+		roleErr := authServer.AssignMissingRole(defaultUsername, auth.RoleOwner, actor.System("initial_user"))
+		if roleErr != nil {
+			log.Println(roleErr)
+		}
+
 		return err
 	}
 
@@ -189,19 +737,55 @@ func headerNoCache(next echo.HandlerFunc) echo.HandlerFunc {
 }
 
 type InMemory struct {
-	farmEventStorage      *assetsstorage.FarmEventStorage
-	farmReadStorage       *assetsstorage.FarmReadStorage
-	areaEventStorage      *assetsstorage.AreaEventStorage
-	areaReadStorage       *assetsstorage.AreaReadStorage
-	reservoirEventStorage *assetsstorage.ReservoirEventStorage
-	reservoirReadStorage  *assetsstorage.ReservoirReadStorage
-	materialEventStorage  *assetsstorage.MaterialEventStorage
-	materialReadStorage   *assetsstorage.MaterialReadStorage
-	cropEventStorage      *growthstorage.CropEventStorage
-	cropReadStorage       *growthstorage.CropReadStorage
-	cropActivityStorage   *growthstorage.CropActivityStorage
-	taskEventStorage      *taskstorage.TaskEventStorage
-	taskReadStorage       *taskstorage.TaskReadStorage
+	farmEventStorage                  *assetsstorage.FarmEventStorage
+	farmReadStorage                   *assetsstorage.FarmReadStorage
+	areaEventStorage                  *assetsstorage.AreaEventStorage
+	areaReadStorage                   *assetsstorage.AreaReadStorage
+	reservoirEventStorage             *assetsstorage.ReservoirEventStorage
+	reservoirReadStorage              *assetsstorage.ReservoirReadStorage
+	materialEventStorage              *assetsstorage.MaterialEventStorage
+	materialReadStorage               *assetsstorage.MaterialReadStorage
+	farmCertificationEventStorage     *assetsstorage.FarmCertificationEventStorage
+	farmCertificationReadStorage      *assetsstorage.FarmCertificationReadStorage
+	customFieldDefinitionEventStorage *assetsstorage.CustomFieldDefinitionEventStorage
+	customFieldDefinitionReadStorage  *assetsstorage.CustomFieldDefinitionReadStorage
+	customFieldValueStorage           *customfield.ValueStorage
+	stocktakeEventStorage             *assetsstorage.StocktakeEventStorage
+	stocktakeReadStorage              *assetsstorage.StocktakeReadStorage
+	equipmentEventStorage             *assetsstorage.EquipmentEventStorage
+	equipmentReadStorage              *assetsstorage.EquipmentReadStorage
+	nutrientRecipeEventStorage        *assetsstorage.NutrientRecipeEventStorage
+	nutrientRecipeReadStorage         *assetsstorage.NutrientRecipeReadStorage
+	farmBoundaryEventStorage          *assetsstorage.FarmBoundaryEventStorage
+	cropEventStorage                  *growthstorage.CropEventStorage
+	cropReadStorage                   *growthstorage.CropReadStorage
+	cropActivityStorage               *growthstorage.CropActivityStorage
+	cropInputScheduleEventStorage     *growthstorage.CropInputScheduleEventStorage
+	cropInputScheduleReadStorage      *growthstorage.CropInputScheduleReadStorage
+	microclimateSampleStorage         *growthstorage.MicroclimateSampleStorage
+	dispatchScheduleEventStorage      *growthstorage.DispatchScheduleEventStorage
+	dispatchScheduleReadStorage       *growthstorage.DispatchScheduleReadStorage
+	insurancePolicyEventStorage       *growthstorage.InsurancePolicyEventStorage
+	insurancePolicyReadStorage        *growthstorage.InsurancePolicyReadStorage
+	harvestLotEventStorage            *growthstorage.HarvestLotEventStorage
+	harvestLotReadStorage             *growthstorage.HarvestLotReadStorage
+	energyReadingStorage              *energy.EnergyReadingStorage
+	environmentAlertRuleStorage       *envalert.RuleStorage
+	photoHashStorage                  *media.PhotoHashStorage
+	signingKeyStorage                 *signing.KeyStorage
+	webhookDeliveryStorage            *notification.WebhookDeliveryStorage
+	taskEventStorage                  *taskstorage.TaskEventStorage
+	taskReadStorage                   *taskstorage.TaskReadStorage
+	taskArchiveStorage                *taskstorage.TaskArchiveStorage
+	taskTemplateEventStorage          *taskstorage.TaskTemplateEventStorage
+	taskTemplateReadStorage           *taskstorage.TaskTemplateReadStorage
+	taskCatalogEventStorage           *taskstorage.TaskCatalogEventStorage
+	prunedStorage                     *retention.PrunedStorage
+	changeLogStorage                  *changefeed.ChangeLogStorage
+	reportMailStorage                 *reportmail.ReportMailStorage
+	idempotencyRecordStorage          *idempotency.RecordStorage
+	maintenanceModeStorage            *maintenance.ModeStorage
+	deviceStorage                     *devicesignature.DeviceStorage
 }
 
 func initInMemory() *InMemory {
@@ -218,48 +802,509 @@ func initInMemory() *InMemory {
 		materialEventStorage: assetsstorage.CreateMaterialEventStorage(),
 		materialReadStorage:  assetsstorage.CreateMaterialReadStorage(),
 
+		farmCertificationEventStorage: assetsstorage.CreateFarmCertificationEventStorage(),
+		farmCertificationReadStorage:  assetsstorage.CreateFarmCertificationReadStorage(),
+
+		customFieldDefinitionEventStorage: assetsstorage.CreateCustomFieldDefinitionEventStorage(),
+		customFieldDefinitionReadStorage:  assetsstorage.CreateCustomFieldDefinitionReadStorage(),
+		customFieldValueStorage:           customfield.CreateValueStorage(),
+		stocktakeEventStorage:             assetsstorage.CreateStocktakeEventStorage(),
+		stocktakeReadStorage:              assetsstorage.CreateStocktakeReadStorage(),
+		equipmentEventStorage:             assetsstorage.CreateEquipmentEventStorage(),
+		equipmentReadStorage:              assetsstorage.CreateEquipmentReadStorage(),
+		nutrientRecipeEventStorage:        assetsstorage.CreateNutrientRecipeEventStorage(),
+		nutrientRecipeReadStorage:         assetsstorage.CreateNutrientRecipeReadStorage(),
+		farmBoundaryEventStorage:          assetsstorage.CreateFarmBoundaryEventStorage(),
+
 		cropEventStorage:    growthstorage.CreateCropEventStorage(),
 		cropReadStorage:     growthstorage.CreateCropReadStorage(),
 		cropActivityStorage: growthstorage.CreateCropActivityStorage(),
 
-		taskEventStorage: taskstorage.CreateTaskEventStorage(),
-		taskReadStorage:  taskstorage.CreateTaskReadStorage(),
+		cropInputScheduleEventStorage: growthstorage.CreateCropInputScheduleEventStorage(),
+		cropInputScheduleReadStorage:  growthstorage.CreateCropInputScheduleReadStorage(),
+		microclimateSampleStorage:     growthstorage.CreateMicroclimateSampleStorage(),
+		dispatchScheduleEventStorage:  growthstorage.CreateDispatchScheduleEventStorage(),
+		dispatchScheduleReadStorage:   growthstorage.CreateDispatchScheduleReadStorage(),
+		insurancePolicyEventStorage:   growthstorage.CreateInsurancePolicyEventStorage(),
+		insurancePolicyReadStorage:    growthstorage.CreateInsurancePolicyReadStorage(),
+		harvestLotEventStorage:        growthstorage.CreateHarvestLotEventStorage(),
+		harvestLotReadStorage:         growthstorage.CreateHarvestLotReadStorage(),
+
+		energyReadingStorage:        energy.CreateEnergyReadingStorage(),
+		environmentAlertRuleStorage: envalert.CreateRuleStorage(),
+		photoHashStorage:            media.CreatePhotoHashStorage(),
+		signingKeyStorage:           signing.CreateKeyStorage(),
+		webhookDeliveryStorage:      notification.CreateWebhookDeliveryStorage(),
+
+		taskEventStorage:   taskstorage.CreateTaskEventStorage(),
+		taskReadStorage:    taskstorage.CreateTaskReadStorage(),
+		taskArchiveStorage: taskstorage.CreateTaskArchiveStorage(),
+
+		taskTemplateEventStorage: taskstorage.CreateTaskTemplateEventStorage(),
+		taskTemplateReadStorage:  taskstorage.CreateTaskTemplateReadStorage(),
+
+		taskCatalogEventStorage: taskstorage.CreateTaskCatalogEventStorage(),
+
+		prunedStorage: retention.CreatePrunedStorage(),
+
+		changeLogStorage: changefeed.CreateChangeLogStorage(),
+
+		reportMailStorage: reportmail.CreateReportMailStorage(),
+
+		idempotencyRecordStorage: idempotency.CreateRecordStorage(),
+
+		maintenanceModeStorage: maintenance.CreateModeStorage(),
+
+		deviceStorage: devicesignature.CreateDeviceStorage(),
+	}
+}
+
+func initRetentionStore(db *sql.DB, inMem *InMemory) retention.Store {
+	switch *config.Config.TaniaPersistenceEngine {
+	case config.DBSqlite:
+		return retention.NewStoreSqlite(db)
+	case config.DBMysql:
+		return retention.NewStoreMysql(db)
+	default:
+		return retention.NewStoreInMemory(
+			inMem.prunedStorage,
+			inMem.cropEventStorage,
+			inMem.cropReadStorage,
+			inMem.cropActivityStorage,
+			inMem.taskEventStorage,
+			inMem.taskReadStorage,
+		)
+	}
+}
+
+// initPhotoReferences reads the references of the photos from the read models of the engine.
+func initPhotoReferences(db *sql.DB, inMem *InMemory) orphans.References {
+	switch *config.Config.TaniaPersistenceEngine {
+	case config.DBSqlite:
+		return orphans.ReadModelReferences{
+			FarmReadQuery: assetsquerysqlite.NewFarmReadQuerySqlite(db),
+			AreaReadQuery: assetsquerysqlite.NewAreaReadQuerySqlite(db),
+			CropReadQuery: growthquerysqlite.NewCropReadQuerySqlite(db),
+		}
+	case config.DBMysql:
+		return orphans.ReadModelReferences{
+			FarmReadQuery: assetsquerymysql.NewFarmReadQueryMysql(db),
+			AreaReadQuery: assetsquerymysql.NewAreaReadQueryMysql(db),
+			CropReadQuery: growthquerymysql.NewCropReadQueryMysql(db),
+		}
+	default:
+		return orphans.ReadModelReferences{
+			FarmReadQuery: assetsqueryinmemory.NewFarmReadQueryInMemory(inMem.farmReadStorage),
+			AreaReadQuery: assetsqueryinmemory.NewAreaReadQueryInMemory(inMem.areaReadStorage),
+			CropReadQuery: growthqueryinmemory.NewCropReadQueryInMemory(inMem.cropReadStorage),
+		}
+	}
+}
+
+// cleanupOrphans runs the orphan cleanup of the cleanup-orphans command. The in-memory read models
+// are empty outside of the server, every photo would look like an orphan.
+func cleanupOrphans(cleaner *orphans.Cleaner) error {
+	engine := *config.Config.TaniaPersistenceEngine
+	if engine != config.DBSqlite && engine != config.DBMysql {
+		return errors.New("cleanup-orphans needs the sqlite or mysql persistence engine")
 	}
+
+	_, err := cleaner.Run(time.Now())
+
+	return err
+}
+
+// reloadFeatureFlagsOnSIGHUP reads the feature flags again on every SIGHUP and logs what they change.
+// A configuration which doesn't parse keeps the flags as they are.
+func reloadFeatureFlagsOnSIGHUP(featureFlags *featureflags.Registry) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+
+	go func() {
+		for range hangups {
+			values, err := config.ReadFeatureFlags()
+			if err != nil {
+				log.Println("Feature flags reload failed", err)
+
+				continue
+			}
+
+			changes, err := featureFlags.Reload(values)
+			if err != nil {
+				log.Println("Feature flags reload failed", err)
+
+				continue
+			}
+
+			if len(changes) == 0 {
+				log.Println("Feature flags reloaded, nothing changed")
+			}
+
+			for _, v := range changes {
+				log.Println("Feature flags reloaded.", v)
+			}
+		}
+	}()
+}
+
+// The time boxes of the maintenance mode of the imports and the rebuilds, in case they never return.
+const (
+	importModeDuration  = 15 * time.Minute
+	rebuildModeDuration = time.Hour
+)
+
+// runAreaCrops runs the rebuild-area-crops and check-area-crops commands, the table is only in the SQL engines.
+// The rebuild runs in the maintenance mode, so no crop changes while the table is replaced.
+func runAreaCrops(command string, db *sql.DB, maintenanceSwitch *maintenance.Switch) error {
+	var store areacrops.Store
+
+	crops := areacrops.ReadModelCrops{}
+
+	switch *config.Config.TaniaPersistenceEngine {
+	case config.DBSqlite:
+		store = areacrops.NewStoreSqlite(db)
+		crops.FarmReadQuery = assetsquerysqlite.NewFarmReadQuerySqlite(db)
+		crops.CropReadQuery = growthquerysqlite.NewCropReadQuerySqlite(db)
+	case config.DBMysql:
+		store = areacrops.NewStoreMysql(db)
+		crops.FarmReadQuery = assetsquerymysql.NewFarmReadQueryMysql(db)
+		crops.CropReadQuery = growthquerymysql.NewCropReadQueryMysql(db)
+	default:
+		return errors.New(command + " needs the sqlite or mysql persistence engine")
+	}
+
+	if command == "rebuild-area-crops" {
+		return maintenanceSwitch.During("Rebuilding the crops in the areas", rebuildModeDuration, func() error {
+			total, err := areacrops.Rebuild(store, crops)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("Rebuilt %d rows of the crops in the areas", total)
+
+			return nil
+		})
+	}
+
+	mismatches, err := areacrops.Check(store, crops)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range mismatches {
+		log.Println(v)
+	}
+
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%d rows of the crops in the areas don't match the crops, run rebuild-area-crops",
+			len(mismatches))
+	}
+
+	log.Println("The crops in the areas match the crops")
+
+	return nil
+}
+
+func initChangeFeedStore(db *sql.DB, inMem *InMemory) changefeed.Store {
+	switch *config.Config.TaniaPersistenceEngine {
+	case config.DBSqlite:
+		return changefeed.NewStoreSqlite(db)
+	case config.DBMysql:
+		return changefeed.NewStoreMysql(db)
+	default:
+		return changefeed.NewStoreInMemory(inMem.changeLogStorage)
+	}
+}
+
+func initMaintenanceModeStore(db *sql.DB, inMem *InMemory) maintenance.ModeStore {
+	switch *config.Config.TaniaPersistenceEngine {
+	case config.DBSqlite:
+		return maintenance.NewModeStoreSqlite(db)
+	case config.DBMysql:
+		return maintenance.NewModeStoreMysql(db)
+	default:
+		return maintenance.NewModeStoreInMemory(inMem.maintenanceModeStorage)
+	}
+}
+
+func initDeviceRegistry(db *sql.DB, inMem *InMemory) devicesignature.Registry {
+	switch *config.Config.TaniaPersistenceEngine {
+	case config.DBSqlite:
+		return devicesignature.NewRegistrySqlite(db)
+	case config.DBMysql:
+		return devicesignature.NewRegistryMysql(db)
+	default:
+		return devicesignature.NewRegistryInMemory(inMem.deviceStorage)
+	}
+}
+
+func initIdempotencyStore(db *sql.DB, inMem *InMemory) idempotency.Store {
+	switch *config.Config.TaniaPersistenceEngine {
+	case config.DBSqlite:
+		return idempotency.NewStoreSqlite(db)
+	case config.DBMysql:
+		return idempotency.NewStoreMysql(db)
+	default:
+		return idempotency.NewStoreInMemory(inMem.idempotencyRecordStorage)
+	}
+}
+
+func initWebhookDeliveryStore(db *sql.DB, inMem *InMemory) notification.WebhookDeliveryStore {
+	switch *config.Config.TaniaPersistenceEngine {
+	case config.DBSqlite:
+		return notification.NewWebhookDeliveryStoreSqlite(db)
+	case config.DBMysql:
+		return notification.NewWebhookDeliveryStoreMysql(db)
+	default:
+		return notification.NewWebhookDeliveryStoreInMemory(inMem.webhookDeliveryStorage)
+	}
+}
+
+func initImportStore(
+	db *sql.DB, inMem *InMemory, eventStreams *redisstorage.RedisStreamsEventStorage,
+) farmimport.Store {
+	switch *config.Config.TaniaPersistenceEngine {
+	case config.DBSqlite:
+		return farmimport.NewStoreSqlite(db)
+	case config.DBMysql:
+		return farmimport.NewStoreMysql(db)
+	}
+
+	inMemory := farmimport.NewStoreInMemory(
+		inMem.farmEventStorage,
+		inMem.farmCertificationEventStorage,
+		inMem.reservoirEventStorage,
+		inMem.areaEventStorage,
+		inMem.materialEventStorage,
+		inMem.cropEventStorage,
+		inMem.taskEventStorage,
+		customfield.NewStoreInMemory(inMem.customFieldValueStorage, inMem.customFieldDefinitionReadStorage),
+	)
+
+	if eventStreams != nil {
+		return farmimport.NewStoreRedis(eventStreams, inMemory)
+	}
+
+	return inMemory
+}
+
+// initEventStreams connects to the Redis of the event streams, there's none when its URL is empty.
+// Only the inmemory engine keeps its events there, the SQL engines have their event tables.
+func initEventStreams() *redisstorage.RedisStreamsEventStorage {
+	if *config.Config.RedisURL == "" {
+		return nil
+	}
+
+	if *config.Config.TaniaPersistenceEngine != config.DBInmemory {
+		log.Fatal("redis_url is only used by the inmemory persistence engine")
+	}
+
+	eventStreams, err := redisstorage.NewRedisStreamsEventStorage(*config.Config.RedisURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return eventStreams
+}
+
+// initPresenceRegistry keeps the presence in memory, and in the database too when it's persisted,
+// so the silent gateways are still known after a restart.
+func initPresenceRegistry(db *sql.DB) (*presence.Registry, error) {
+	ttl := time.Duration(*config.Config.PresenceTTL) * time.Second
+
+	if !*config.Config.PresencePersisted {
+		return presence.NewRegistry(ttl, nil)
+	}
+
+	switch *config.Config.TaniaPersistenceEngine {
+	case config.DBSqlite:
+		return presence.NewRegistry(ttl, presence.NewStoreSqlite(db))
+	case config.DBMysql:
+		return presence.NewRegistry(ttl, presence.NewStoreMysql(db))
+	default:
+		return presence.NewRegistry(ttl, nil)
+	}
+}
+
+func initWebhookEventTypes() ([]notification.WebhookEventType, error) {
+	taskEventTypes, err := notification.TaskWebhookEventTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	alertEventTypes, err := envalert.WebhookEventTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(taskEventTypes, alertEventTypes...), nil
+}
+
+// initTaskNotifier routes the task notifications with the routing file, the default routing without it.
+// The webhook and the SMS go through their circuit breakers, then the proxy when it's not nil.
+// The webhook posts are signed with its secret and recorded in the store, for their replays.
+func initTaskNotifier(
+	breakers *integration.Registry,
+	proxy http.RoundTripper,
+	deliveries notification.WebhookDeliveryStore,
+) (*notification.TaskNotifier, error) {
+	routing, err := notification.LoadNotificationRoutingConfig(*config.Config.NotificationRoutingPath)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := notification.NewWebhookDispatcher(*config.Config.NotificationWebhookURL, routing)
+	webhook.Client.Transport = breakers.Breaker("webhook").Transport(proxy)
+	webhook.Secret = *config.Config.WebhookSecret
+	webhook.Deliveries = deliveries
+
+	sms := notification.NewTwilioSMSSender(
+		*config.Config.TwilioAccountSID,
+		*config.Config.TwilioAuthToken,
+		*config.Config.TwilioFromNumber,
+		config.Config.NotificationSMSTo,
+		routing,
+	)
+	sms.Client.Transport = breakers.Breaker("twilio").Transport(proxy)
+
+	notifier := &notification.TaskNotifier{
+		Webhook: webhook,
+		SMS:     sms,
+	}
+
+	// Without an SMTP host the urgent tasks would log a failed send each, there is just no email channel.
+	if *config.Config.SMTPHost != "" {
+		mailer := notification.NewSMTPNotifier(
+			*config.Config.SMTPHost,
+			*config.Config.SMTPPort,
+			*config.Config.SMTPUsername,
+			*config.Config.SMTPPassword,
+			*config.Config.SMTPFrom,
+		)
+
+		notifier.Mail = notification.NewMailSender(mailer, config.Config.NotificationEmailTo, routing)
+	}
+
+	return notifier, nil
 }
 
 func initMysql() *sql.DB {
+	db := openMysql(*config.Config.MysqlDbname)
+
+	execMysqlDDL(db, "database/mysql/ddl.sql")
+
+	if *config.Config.MysqlNativeSlowLog {
+		enableMysqlSlowLog(db)
+	}
+
+	return db
+}
+
+// enableMysqlSlowLog turns the slow query log of the server on, with the threshold of Tania when it has one.
+// Setting the globals needs the SUPER or SYSTEM_VARIABLES_ADMIN privilege, without it Tania starts anyway.
+func enableMysqlSlowLog(db *sql.DB) {
+	globals := [][2]interface{}{
+		{"slow_query_log_file", *config.Config.MysqlSlowLogFile},
+		{"slow_query_log", "ON"},
+	}
+
+	if threshold := slowQueryThreshold(); threshold > 0 {
+		globals = append(globals, [2]interface{}{"long_query_time", threshold.Seconds()})
+	}
+
+	for _, v := range globals {
+		_, err := db.Exec(fmt.Sprintf("SET GLOBAL %s = ?", v[0]), v[1])
+		if err != nil {
+			log.Println("MySQL slow query log cannot be enabled", err)
+
+			return
+		}
+	}
+
+	log.Println("MySQL slow query log written to", *config.Config.MysqlSlowLogFile)
+}
+
+// initMysqlArchive creates the database of the archived tasks next to the main database.
+func initMysqlArchive(db *sql.DB) *sql.DB {
+	dbname := *config.Config.MysqlArchiveDbname
+
+	_, err := db.Exec("CREATE DATABASE IF NOT EXISTS `" + dbname + "`" +
+		" CHARACTER SET " + *config.Config.MysqlCharset + " COLLATE " + *config.Config.MysqlCollation)
+	if err != nil {
+		panic(err)
+	}
+
+	archiveDB := openMysql(dbname)
+
+	execMysqlDDL(archiveDB, "database/mysql/archive_ddl.sql")
+
+	return archiveDB
+}
+
+func openMysql(dbname string) *sql.DB {
 	host := *config.Config.MysqlHost
 	port := *config.Config.MysqlPort
-	dbname := *config.Config.MysqlDbname
 	user := *config.Config.MysqlUsername
 	pwd := *config.Config.MysqlPassword
+	charset := *config.Config.MysqlCharset
+	collation := *config.Config.MysqlCollation
 
-	dsn := user + ":" + pwd + "@(" + host + ":" + port + ")/" + dbname + "?parseTime=true&clientFoundRows=true"
+	// The charset and collation are set explicitly, the defaults of MySQL < 8.0 can't store every language.
+	dsn := user + ":" + pwd + "@(" + host + ":" + port + ")/" + dbname + "?parseTime=true&clientFoundRows=true" +
+		"&charset=" + charset + "&collation=" + collation
 
-	db, err := sql.Open("mysql", dsn)
+	db, err := slowquery.Open("mysql", dsn, slowQueryThreshold(), log.Default())
 	if err != nil {
 		panic(err)
 	}
 
 	log.Println("Using MySQL at ", host, ":", port, "/", dbname)
 
-	ddl, err := os.ReadFile("database/mysql/ddl.sql")
+	return db
+}
+
+func execMysqlDDL(db *sql.DB, path string) {
+	ddl, err := os.ReadFile(path)
 	if err != nil {
 		panic(err)
 	}
 
-	sqls := string(ddl)
+	sqls := schema.MysqlDDL(string(ddl), *config.Config.MysqlCharset, *config.Config.MysqlCollation)
+
+	// The tables created by an older DDL get its new columns first.
+	err = schema.MigrateMysql(db, sqls)
+	if err != nil {
+		panic(err)
+	}
+
+	conversions, err := schema.MysqlConversions(db, sqls, *config.Config.MysqlCharset, *config.Config.MysqlCollation)
+	if err != nil {
+		panic(err)
+	}
 
 	// We need to split the DDL query by `;` and execute it one by one.
 	// Because sql.DB.Exec() from mysql driver cannot executes multiple query at once
 	// and it will give weird syntax error messages.
-	splitted := strings.Split(sqls, ";")
+	failed := execMysqlStatements(db, append(conversions, strings.Split(sqls, ";")...))
+	if failed > 0 {
+		log.Println("DDL file executed,", failed, "queries failed")
 
-	for _, v := range splitted {
+		return
+	}
+
+	log.Println("DDL file executed")
+}
+
+// execMysqlStatements runs the statements one by one and returns how many failed. A failing statement is logged
+// and the next ones still run, so a table which can't be created doesn't leave the tables after it missing.
+func execMysqlStatements(db *sql.DB, statements []string) int {
+	failed := 0
+
+	for _, v := range statements {
 		trimmed := strings.TrimSpace(v)
 
 		if len(trimmed) > 0 {
-			_, err = db.Exec(v)
+			_, err := db.Exec(v)
 
 			if err != nil {
 				var me *mysql.MySQLError
@@ -269,44 +1314,59 @@ func initMysql() *sql.DB {
 
 				// http://dev.mysql.com/doc/refman/5.7/en/error-messages-server.html
 				// We will skip error duplicate key name in database (code: 1061),
-				// because CREATE INDEX doesn't have IF NOT EXISTS clause,
-				// otherwise we will stop the loop and print the error
-				if me.Number == 1061 {
-				} else {
+				// because CREATE INDEX doesn't have IF NOT EXISTS clause.
+				if me.Number != 1061 {
 					log.Println(err)
 
-					return db
+					failed++
 				}
 			}
 		}
 	}
 
-	log.Println("DDL file executed")
+	return failed
+}
 
-	return db
+func slowQueryThreshold() time.Duration {
+	return time.Duration(*config.Config.DBSlowQueryThresholdMs) * time.Millisecond
 }
 
 func initSqlite() *sql.DB {
-	if _, err := os.Stat(*config.Config.SqlitePath); os.IsNotExist(err) {
-		log.Println("Creating database file ", *config.Config.SqlitePath)
+	return openSqlite(*config.Config.SqlitePath, "database/sqlite/ddl.sql")
+}
+
+// initSqliteArchive opens the database file of the archived tasks.
+func initSqliteArchive() *sql.DB {
+	return openSqlite(*config.Config.SqliteArchivePath, "database/sqlite/archive_ddl.sql")
+}
+
+func openSqlite(path, ddlPath string) *sql.DB {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		log.Println("Creating database file ", path)
 	}
 
-	db, err := sql.Open("sqlite3", *config.Config.SqlitePath)
+	db, err := slowquery.Open("sqlite3", path, slowQueryThreshold(), log.Default())
 	if err != nil {
 		panic(err)
 	}
 
-	log.Println("Using SQLite at ", *config.Config.SqlitePath)
+	log.Println("Using SQLite at ", path)
 
-	log.Println("Executing DDL file for ", *config.Config.SqlitePath)
+	log.Println("Executing DDL file for ", path)
 
-	ddl, err := os.ReadFile("database/sqlite/ddl.sql")
+	ddl, err := os.ReadFile(ddlPath)
 	if err != nil {
 		panic(err)
 	}
 
 	sql := string(ddl)
 
+	// The tables created by an older DDL get its new columns first.
+	err = schema.MigrateSqlite(db, sql)
+	if err != nil {
+		panic(err)
+	}
+
 	_, err = db.Exec(sql)
 	if err != nil {
 		panic(err)
@@ -339,7 +1399,12 @@ func tokenValidationWithConfig(db *sql.DB) echo.MiddlewareFunc {
 				return c.JSON(http.StatusUnauthorized, map[string]string{"data": "Unauthorized"})
 			}
 
+			// SQLite returns the TEXT uid as a string.
 			ubyte, ok := uid.([]byte)
+			if text, isText := uid.(string); isText {
+				ubyte, ok = []byte(text), true
+			}
+
 			if !ok {
 				return c.JSON(http.StatusInternalServerError, map[string]string{"data": "Error user UID type assertion"})
 			}
@@ -356,14 +1421,102 @@ func tokenValidationWithConfig(db *sql.DB) echo.MiddlewareFunc {
 				return c.JSON(http.StatusInternalServerError, map[string]error{"data": err})
 			}
 
-			c.Set("USER_UID", userUID)
+			c.Set(farmscope.UserKey, userUID)
 
 			return next(c)
 		}
 	}
 }
 
-func logMiddleware() echo.MiddlewareFunc {
+// initSentry initializes the Sentry client when a DSN is configured. The reports go through its circuit breaker,
+// then the proxy when it's not nil.
+func initSentry(dsn string, breakers *integration.Registry, proxy http.RoundTripper) (bool, error) {
+	if dsn == "" {
+		return false, nil
+	}
+
+	err := sentry.Init(sentry.ClientOptions{
+		Dsn:              dsn,
+		AttachStacktrace: true,
+		HTTPTransport:    breakers.Breaker("sentry").Transport(proxy),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// recoverMiddleware answers 500 when a handler panics and reports the panic to Sentry when it's enabled.
+// The stack trace is logged by the error handler, with the panic.
+func recoverMiddleware(sentryEnabled bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			defer func() {
+				r := recover()
+				if r == nil {
+					return
+				}
+
+				err, ok := r.(error)
+				if !ok {
+					err = fmt.Errorf("%v", r)
+				}
+
+				// The aborted responses are handled by net/http itself.
+				if errors.Is(err, http.ErrAbortHandler) {
+					panic(r)
+				}
+
+				requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+				log.Println("Panic recovered, request_id:", requestID, "error:", err)
+
+				if sentryEnabled {
+					capturePanic(c, requestID, err)
+				}
+
+				c.Error(httperror.Panic{Err: err, Stack: debug.Stack()})
+			}()
+
+			return next(c)
+		}
+	}
+}
+
+func capturePanic(c echo.Context, requestID string, err error) {
+	hub := sentry.CurrentHub().Clone()
+
+	hub.ConfigureScope(func(scope *sentry.Scope) {
+		scope.SetRequest(c.Request())
+		scope.SetTag("request_id", requestID)
+
+		if userUID, ok := c.Get(farmscope.UserKey).(uuid.UUID); ok {
+			scope.SetUser(sentry.User{ID: userUID.String()})
+		}
+
+		params := map[string]interface{}{}
+		for i, name := range c.ParamNames() {
+			if i < len(c.ParamValues()) {
+				params[name] = c.ParamValues()[i]
+			}
+		}
+
+		scope.SetContext("echo", sentry.Context{
+			"path":         c.Path(),
+			"method":       c.Request().Method,
+			"uri":          c.Request().RequestURI,
+			"real_ip":      c.RealIP(),
+			"params":       params,
+			"query_string": c.QueryString(),
+		})
+	})
+
+	hub.RecoverWithContext(c.Request().Context(), err)
+	hub.Flush(2 * time.Second)
+}
+
+func logMiddleware(redactor *requestlog.Redactor) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			req := c.Request()
@@ -388,21 +1541,32 @@ func logMiddleware() echo.MiddlewareFunc {
 				"roundtrip_human": stop.Sub(start).String(),
 			}
 
-			// We will add log Form Values and Query String if...
-			if res.Status == http.StatusInternalServerError {
-				if !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
-					qs := c.QueryString()
+			if country, ok := c.Get(geoip.ContextCountry).(string); ok {
+				fields["country"] = country
+			}
 
-					forms, err := c.FormParams()
-					if err != nil {
-						c.Error(err)
-					}
+			if city, ok := c.Get(geoip.ContextCity).(string); ok {
+				fields["city"] = city
+			}
 
-					fields["query_string"] = qs
-					fields["form_values"] = forms
+			// We will add the Query String and the Body to the log if...
+			if res.Status == http.StatusInternalServerError {
+				fields["query_string"] = redactor.Values(c.QueryParams())
+
+				if body, ok := redactor.Body(c); ok {
+					fields["body"] = body
 				}
 			}
 
+			line, err := json.Marshal(fields)
+			if err != nil {
+				c.Logger().Error(err)
+
+				return nil
+			}
+
+			log.Println(string(line))
+
 			return nil
 		}
 	}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"log"
 
 	"github.com/spf13/pflag"
@@ -16,19 +17,102 @@ const (
 )
 
 type Configuration struct {
-	AppPort                *string   `mapstructure:"app_port"`
-	DemoMode               *bool     `mapstructure:"demo_mode"`
-	UploadPathArea         *string   `mapstructure:"upload_path_area"`
-	UploadPathCrop         *string   `mapstructure:"upload_path_crop"`
-	TaniaPersistenceEngine *string   `mapstructure:"tania_persistence_engine"`
-	SqlitePath             *string   `mapstructure:"sqlite_path"`
-	MysqlHost              *string   `mapstructure:"mysql_host"`
-	MysqlPort              *string   `mapstructure:"mysql_port"`
-	MysqlDbname            *string   `mapstructure:"mysql_dbname"`
-	MysqlUsername          *string   `mapstructure:"mysql_username"`
-	MysqlPassword          *string   `mapstructure:"mysql_password"`
-	RedirectURI            []*string `mapstructure:"redirect_uri"`
-	ClientID               *string   `mapstructure:"client_id"`
+	AppPort                 *string   `mapstructure:"app_port"`
+	DemoMode                *bool     `mapstructure:"demo_mode"`
+	DemoSeedPath            *string   `mapstructure:"demo_seed_path"`
+	UploadPathArea          *string   `mapstructure:"upload_path_area"`
+	UploadPathCrop          *string   `mapstructure:"upload_path_crop"`
+	TaniaPersistenceEngine  *string   `mapstructure:"tania_persistence_engine"`
+	SqlitePath              *string   `mapstructure:"sqlite_path"`
+	RedisURL                *string   `mapstructure:"redis_url"`
+	SqliteArchivePath       *string   `mapstructure:"sqlite_archive_path"`
+	MysqlHost               *string   `mapstructure:"mysql_host"`
+	MysqlPort               *string   `mapstructure:"mysql_port"`
+	MysqlDbname             *string   `mapstructure:"mysql_dbname"`
+	MysqlArchiveDbname      *string   `mapstructure:"mysql_archive_dbname"`
+	MysqlUsername           *string   `mapstructure:"mysql_username"`
+	MysqlPassword           *string   `mapstructure:"mysql_password"`
+	MysqlCharset            *string   `mapstructure:"mysql_charset"`
+	MysqlCollation          *string   `mapstructure:"mysql_collation"`
+	DBSlowQueryThresholdMs  *int      `mapstructure:"db_slow_query_threshold_ms"`
+	MysqlNativeSlowLog      *bool     `mapstructure:"mysql_native_slow_log"`
+	MysqlSlowLogFile        *string   `mapstructure:"mysql_slow_log_file"`
+	RedirectURI             []*string `mapstructure:"redirect_uri"`
+	ClientID                *string   `mapstructure:"client_id"`
+	AuthProvider            *string   `mapstructure:"auth_provider"`
+	LDAPURL                 *string   `mapstructure:"ldap_url"`
+	LDAPUserBaseDN          *string   `mapstructure:"ldap_user_base_dn"`
+	LDAPUserAttribute       *string   `mapstructure:"ldap_user_attribute"`
+	LDAPRoleAttribute       *string   `mapstructure:"ldap_role_attribute"`
+	LDAPBindFormat          *string   `mapstructure:"ldap_bind_format"`
+	MQTTBrokerURL           *string   `mapstructure:"mqtt_broker_url"`
+	MQTTQoS                 *int      `mapstructure:"mqtt_qos"`
+	MQTTRetain              *bool     `mapstructure:"mqtt_retain"`
+	SMTPHost                *string   `mapstructure:"smtp_host"`
+	SMTPPort                *string   `mapstructure:"smtp_port"`
+	SMTPUsername            *string   `mapstructure:"smtp_username"`
+	SMTPPassword            *string   `mapstructure:"smtp_password"`
+	SMTPFrom                *string   `mapstructure:"smtp_from"`
+	NotificationRoutingPath *string   `mapstructure:"notification_routing_path"`
+	NotificationEmailTo     []string  `mapstructure:"notification_email_to"`
+	NotificationWebhookURL  *string   `mapstructure:"notification_webhook_url"`
+	WebhookSecret           *string   `mapstructure:"notification_webhook_secret"`
+	NotificationSMSTo       []string  `mapstructure:"notification_sms_to"`
+	TwilioAccountSID        *string   `mapstructure:"twilio_account_sid"`
+	TwilioAuthToken         *string   `mapstructure:"twilio_auth_token"`
+	TwilioFromNumber        *string   `mapstructure:"twilio_from_number"`
+	PagerDutyIntegrationKey *string   `mapstructure:"pagerduty_integration_key"`
+	CircuitBreakerThreshold *int      `mapstructure:"circuit_breaker_failure_threshold"`
+	CircuitBreakerReset     *int      `mapstructure:"circuit_breaker_reset_timeout_seconds"`
+	HTTPProxyURL            *string   `mapstructure:"http_proxy_url"`
+	HTTPProxyCAPath         *string   `mapstructure:"http_proxy_ca_path"`
+	LowStockThreshold       *float64  `mapstructure:"low_stock_threshold"`
+	EnergyAlertThreshold    *float64  `mapstructure:"energy_alert_threshold"`
+	AnomalyZThreshold       *float64  `mapstructure:"anomaly_z_threshold"`
+	SanitationCheck         *string   `mapstructure:"sanitation_check"`
+	RetentionYears          *int      `mapstructure:"retention_years"`
+	RetentionDryRun         *bool     `mapstructure:"retention_dry_run"`
+	RetentionArchivePath    *string   `mapstructure:"retention_archive_path"`
+	TaskArchiveAfterDays    *int      `mapstructure:"task_archive_after_days"`
+	WorkloadWindowDays      *int      `mapstructure:"workload_window_days"`
+	TaskDefaultEffort       *int      `mapstructure:"task_default_effort_minutes"`
+	TaskBusinessDays        []string  `mapstructure:"task_business_days"`
+	TaskBusinessHoursStart  *string   `mapstructure:"task_business_hours_start"`
+	TaskBusinessHoursEnd    *string   `mapstructure:"task_business_hours_end"`
+	AdminAllowedCIDR        *string   `mapstructure:"admin_allowed_cidr"`
+	GeoIPDBPath             *string   `mapstructure:"geoip_db_path"`
+	SentryDSN               *string   `mapstructure:"sentry_dsn"`
+	MaxUploadSize           *string   `mapstructure:"max_upload_size"`
+	TenantID                *string   `mapstructure:"tenant_id"`
+	LogExcludedFields       []string  `mapstructure:"log_excluded_fields"`
+	PresenceTTL             *int      `mapstructure:"presence_ttl_seconds"`
+	PresencePersisted       *bool     `mapstructure:"presence_persisted"`
+	PresenceStaleGateway    *int      `mapstructure:"presence_stale_gateway_minutes"`
+	OrphanQuarantinePath    *string   `mapstructure:"orphan_quarantine_path"`
+	OrphanGraceDays         *int      `mapstructure:"orphan_grace_days"`
+	OrphanCleanupHours      *int      `mapstructure:"orphan_cleanup_interval_hours"`
+	IdempotencyKeyTTL       *int      `mapstructure:"idempotency_key_ttl_hours"`
+	BodyEncryptionEnabled   *bool     `mapstructure:"body_encryption_enabled"`
+	BodyEncryptionTTL       *int      `mapstructure:"body_encryption_session_ttl_minutes"`
+	ReplayStaleReads        *bool     `mapstructure:"replay_stale_reads"`
+
+	// TaskProgressAutoComplete completes a task once its recorded progress reaches its target quantity.
+	TaskProgressAutoComplete *bool `mapstructure:"task_progress_auto_complete"`
+
+	// TaskDueCheckMinutes is how often the open tasks past their due date are set as due, zero turns it off.
+	TaskDueCheckMinutes *int `mapstructure:"task_due_check_minutes"`
+
+	// VerboseErrors answers the 5xx errors with their cause and stack trace, it's true by default in demo mode.
+	VerboseErrors *bool `mapstructure:"verbose_errors"`
+
+	// TrustedProxies are the CIDRs of the proxies the X-Forwarded-For header of the requests is read from.
+	TrustedProxies *string `mapstructure:"trusted_proxies"`
+
+	// AdminIPWhitelist only lets the admin_allowed_cidr IPs call the admin endpoints, even when it's empty.
+	AdminIPWhitelist *bool `mapstructure:"admin_ip_whitelist"`
+
+	// FeatureFlags maps the names of the flags to true or false.
+	FeatureFlags map[string]string `mapstructure:"feature_flags"`
 }
 
 /*
@@ -50,7 +134,12 @@ func InitViperConfig() error {
 	pflag.String("app_port", "8080", "Tania server port")
 
 	// Demo Mode
-	pflag.Bool("demo_mode", true, "Switch for the demo mode. This will bypass auth check and use hardcoded token demo")
+	pflag.Bool(
+		"demo_mode",
+		false,
+		"Switch for the demo mode. This will bypass auth check, seed the demo farms and refuse the changes to them",
+	)
+	pflag.String("demo_seed_path", "database/demo/seed.json", "Path of the API requests seeding the demo farms")
 
 	// Persistence Config
 	pflag.String(
@@ -61,13 +150,25 @@ func InitViperConfig() error {
 
 	// Persistence Config - SQLite
 	pflag.String("sqlite_path", "tania.db", "Path of sqlite file db")
+	pflag.String("sqlite_archive_path", "tania_archive.db", "Path of sqlite file db of the archived tasks")
 
 	// Persistence Config - MySQL
 	pflag.String("mysql_host", "127.0.0.1", "Mysql Host")
 	pflag.String("mysql_port", "3306", "Mysql Port")
 	pflag.String("mysql_dbname", "tania", "Mysql DBName")
+	pflag.String("mysql_archive_dbname", "tania_archive", "Mysql DBName of the archived tasks")
 	pflag.String("mysql_username", "root", "Mysql username")
 	pflag.String("mysql_password", "root", "Mysql password")
+	pflag.String("mysql_charset", "utf8mb4", "Mysql connection charset")
+	pflag.String("mysql_collation", "utf8mb4_unicode_ci", "Mysql connection collation")
+
+	// Persistence Config - Redis Streams of the inmemory events. Leave the URL empty to keep them in memory.
+	pflag.String("redis_url", "", "Redis URL of the event streams of the inmemory engine, e.g. redis://127.0.0.1:6379/0")
+
+	// Slow query log of the SQL databases. Zero milliseconds disables it.
+	pflag.Int("db_slow_query_threshold_ms", 0, "Log the SQL queries taking at least this many milliseconds")
+	pflag.Bool("mysql_native_slow_log", false, "Also turn the slow query log of the MySQL server on at the start")
+	pflag.String("mysql_slow_log_file", "tania-slow.log", "File the MySQL server writes its slow query log to")
 
 	// Local Upload Path
 	pflag.String("upload_path_area", "uploads/areas", "Upload path for the Area photo")
@@ -81,6 +182,161 @@ func InitViperConfig() error {
 	)
 	pflag.String("client_id", "f0ece679-3f53-463e-b624-73e83049d6ac", "OAuth2 Implicit Grant Client ID for frontend")
 
+	// Provider checking the credentials of the authorize requests. The LDAP users get a local user on their first login.
+	pflag.String("auth_provider", "local", "Provider of the logins. Available providers: local, ldap")
+	pflag.String("ldap_url", "ldap://127.0.0.1:389", "LDAP server URL, ldaps:// for TLS")
+	pflag.String("ldap_user_base_dn", "", "Base DN the LDAP users are searched under, e.g. ou=people,dc=example,dc=com")
+	pflag.String("ldap_user_attribute", "uid", "LDAP attribute holding the username")
+	pflag.String("ldap_role_attribute", "memberOf", "LDAP attribute of the user naming its owner, manager or worker role")
+	pflag.String(
+		"ldap_bind_format",
+		"",
+		"Bind DN format of the users, e.g. %s@example.com. Empty binds as <user attribute>=<username>,<base DN>",
+	)
+
+	// MQTT Event Publishing. Leave the broker URL empty to disable it.
+	pflag.String("mqtt_broker_url", "", "MQTT broker URL to publish the domain events to, e.g. tcp://127.0.0.1:1883")
+	pflag.Int("mqtt_qos", 0, "MQTT QoS level of the published events. Available levels: 0, 1, 2")
+	pflag.Bool("mqtt_retain", false, "Publish the events as MQTT retained messages")
+
+	// SMTP server of the report emails. Leave the host empty to disable the sends.
+	pflag.String("smtp_host", "", "SMTP host the scheduled report emails are sent through")
+	pflag.String("smtp_port", "587", "SMTP port")
+	pflag.String("smtp_username", "", "SMTP username. Leave it empty when the server doesn't need authentication")
+	pflag.String("smtp_password", "", "SMTP password")
+	pflag.String("smtp_from", "tania@localhost", "Sender address of the report emails")
+
+	// Task notifications, routed per task priority to the channels configured below.
+	pflag.String(
+		"notification_routing_path",
+		"data/notification_routing.json",
+		"Path of the JSON file mapping the task priorities to their channels: email, webhook, sms",
+	)
+	pflag.StringSlice("notification_email_to", []string{}, "Comma separated addresses mailed the task notifications")
+	pflag.String("notification_webhook_url", "", "URL the task notifications are posted to as JSON")
+	pflag.String("notification_webhook_secret", "", "Secret the webhook posts are signed with, unsigned when empty")
+	pflag.StringSlice("notification_sms_to", []string{}, "Comma separated phone numbers texted the task notifications")
+	pflag.String("twilio_account_sid", "", "Twilio account SID of the task SMS. Leave it empty to disable the SMS")
+	pflag.String("twilio_auth_token", "", "Twilio auth token")
+	pflag.String("twilio_from_number", "", "Twilio phone number the task notification SMS are sent from")
+	pflag.String(
+		"pagerduty_integration_key",
+		"",
+		"PagerDuty Events API v2 integration key the urgent due tasks open incidents with. Leave it empty to disable",
+	)
+
+	// Circuit breakers of the external services: the webhook, Twilio, PagerDuty and Sentry.
+	pflag.Int(
+		"circuit_breaker_failure_threshold",
+		5,
+		"Consecutive failed calls opening the circuit of an external service",
+	)
+	pflag.Int(
+		"circuit_breaker_reset_timeout_seconds",
+		30,
+		"Seconds an open circuit fails the calls fast before a trial call",
+	)
+
+	// Proxy of the calls to the external services. Leave it empty to call them directly.
+	pflag.String(
+		"http_proxy_url",
+		"",
+		"HTTP or HTTPS proxy the webhook, Twilio, PagerDuty and Sentry calls go through, the NO_PROXY hosts excepted",
+	)
+	pflag.String("http_proxy_ca_path", "", "PEM file of the proxy's CA certificates, trusted with the system ones")
+
+	// Dashboard
+	pflag.Float64("low_stock_threshold", 5, "Materials with this quantity or less are counted as low stock")
+
+	// Energy. Zero turns the alert off.
+	pflag.Float64("energy_alert_threshold", 0, "Alert when the kWh consumed by a farm in a day goes above this")
+
+	// Microclimate anomalies. Zero turns the detection off.
+	pflag.Float64("anomaly_z_threshold", 3.0, "Z-score of a temperature sample against the last 24 hours flagging it")
+
+	// Seeding into an area whose last crop was dumped for disease and wasn't sanitized since.
+	pflag.String("sanitation_check", "warn", "Seeding an area needing a sanitation is: warn, block or off")
+
+	// Retention of old histories. Zero years keeps everything.
+	pflag.Int("retention_years", 0, "Archive and delete the events of archived crops and closed tasks older than this")
+	pflag.Bool("retention_dry_run", false, "Only log what the retention job would archive and delete")
+	pflag.String("retention_archive_path", "archives", "Folder of the compressed event archives")
+
+	// Task archival. Zero days keeps the closed tasks in the task list, but for the farms with an archival policy.
+	pflag.Int("task_archive_after_days", 90, "Move the tasks completed or cancelled more than this many days ago to the archive")
+
+	// Workload balancing of the generated tasks, against the daily effort budget of their farm.
+	pflag.Int("workload_window_days", 3, "Days before its due date a generated task can be moved to when its day is full")
+	pflag.Int("task_default_effort_minutes", 30, "Effort counted for the tasks without estimated minutes")
+
+	// Business hours of the farms. The new tasks due outside them are moved to the start of the next business day.
+	pflag.StringSlice("task_business_days", []string{}, "Days the farms operate, like MON,TUE,WED,THU,FRI. Empty disables")
+	pflag.String("task_business_hours_start", "08:00", "Time the business days start at")
+	pflag.String("task_business_hours_end", "17:00", "Time the business days end at")
+
+	// Admin endpoints. Leave it empty to allow every IP, unless admin_ip_whitelist is true.
+	pflag.String("admin_allowed_cidr", "", "Comma separated IP ranges allowed to call the admin endpoints, e.g. 192.168.1.0/24,10.0.0.0/8")
+
+	// Location of the request IPs in the request log. Leave it empty to disable it.
+	pflag.String("geoip_db_path", "", "Path of the MaxMind GeoLite2-City database the request IPs are located with")
+
+	// Error reporting. Leave it empty to disable Sentry.
+	pflag.String("sentry_dsn", "", "Sentry DSN the recovered panics are reported to")
+
+	// Upload limit the clients are told about by GET /api/info.
+	pflag.String("max_upload_size", "10M", "Maximum size of an uploaded file, e.g. 512K, 10M")
+
+	// Multi-tenant event bus. Leave it empty for a single tenant deployment.
+	pflag.String("tenant_id", "", "UUID of the tenant, its events are published on the tenant.{tenant_id}. topics of the bus")
+
+	// Request log. The bodies are only logged with the failed requests, without these fields.
+	pflag.StringSlice(
+		"log_excluded_fields",
+		[]string{"$.password", "$.confirm_password", "$.old_password", "$.new_password", "$.confirm_new_password",
+			"$.email", "$.phone"},
+		"JSON paths of the request body fields redacted from the request log, e.g. $.password,$.workers[*].email",
+	)
+
+	// Presence of the users and the gateways of the farms, from their heartbeats.
+	pflag.Int("presence_ttl_seconds", 90, "Seconds an identity stays online after its last heartbeat")
+	pflag.Bool("presence_persisted", false, "Keep the presence in the database, so it survives the restarts")
+	pflag.Int("presence_stale_gateway_minutes", 15, "Minutes of silence after which a gateway is warned about")
+
+	// Cleanup of the photos no area or crop references anymore, also run by `taniad cleanup-orphans`.
+	pflag.String("orphan_quarantine_path", "uploads/quarantine", "Folder the photos no area or crop references go to")
+	pflag.Int("orphan_grace_days", 7, "Days a quarantined photo is kept before it's deleted")
+	pflag.Int("orphan_cleanup_interval_hours", 0, "Hours between the orphan photo cleanups, 0 disables them")
+
+	// Responses of the create requests replayed to the clients retrying them with the same Idempotency-Key.
+	pflag.Int("idempotency_key_ttl_hours", 24, "Hours the response of a request is replayed for its idempotency key")
+
+	// Encryption of the request and response bodies, for the deployments whose TLS ends at a load balancer.
+	pflag.Bool("body_encryption_enabled", false, "Require the request bodies encrypted with a /api/auth/session-key key")
+	pflag.Int("body_encryption_session_ttl_minutes", 60, "Minutes a body encryption session key can be used")
+
+	// Progress of the piecework tasks, recorded with POST /api/tasks/:id/progress.
+	pflag.Bool("task_progress_auto_complete", true, "Complete a task once its progress reaches its target quantity")
+
+	// Crop lists during a replay of the crop read model, off they answer 503 until it's done.
+	pflag.Bool("replay_stale_reads", true, "Serve the last crop lists, flagged stale, during a replay of the crops")
+
+	// Signatures of the sensors, the devices are registered with POST /api/admin/devices.
+
+	// Due tasks, the open tasks past their due date are set as due by the task_due job.
+	pflag.Int("task_due_check_minutes", 5, "Minutes between the checks of the tasks past their due date, 0 disables it")
+
+	// Errors of the API, their detail is always logged. Without a value it follows demo_mode.
+	pflag.Bool("verbose_errors", false, "Answer the cause and the stack trace of the internal errors")
+
+	// Client IPs, the X-Forwarded-For header of the other clients is ignored.
+	pflag.String("trusted_proxies", "", "Comma separated IP ranges of the proxies trusted with X-Forwarded-For")
+
+	// Admin IP whitelist, also turned on by a non-empty admin_allowed_cidr.
+	pflag.Bool("admin_ip_whitelist", false, "Only allow the admin_allowed_cidr IPs to call the admin endpoints")
+
+	// Features shipped dark, turned on and off again on SIGHUP. The flags are listed in the README.
+	pflag.StringToString("feature_flags", map[string]string{}, "Feature flags turned on or off, e.g. webhooks=false")
+
 	pflag.Parse()
 
 	err := v.BindPFlags(pflag.CommandLine)
@@ -115,7 +371,46 @@ func InitViperConfig() error {
 		return err
 	}
 
+	// The demo shows the detail of the errors to the developers trying Tania, unless it's set.
+	if !v.IsSet("verbose_errors") {
+		verboseErrors := *c.DemoMode
+		c.VerboseErrors = &verboseErrors
+	}
+
 	Config = c
 
 	return nil
 }
+
+// ReadFeatureFlags reads the feature flags again from the flags, the environment and the config file,
+// for their reload. Unlike at the start, a config file which doesn't parse is an error.
+func ReadFeatureFlags() (map[string]string, error) {
+	v := viper.New()
+
+	v.AutomaticEnv()
+
+	err := v.BindPFlags(pflag.CommandLine)
+	if err != nil {
+		return nil, err
+	}
+
+	v.SetConfigType("json")
+	v.SetConfigName("conf")
+	v.AddConfigPath("./")
+
+	var notFound viper.ConfigFileNotFoundError
+
+	err = v.ReadInConfig()
+	if err != nil && !errors.As(err, &notFound) {
+		return nil, err
+	}
+
+	c := Configuration{}
+
+	err = v.Unmarshal(&c)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.FeatureFlags, nil
+}
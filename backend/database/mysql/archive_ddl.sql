@@ -0,0 +1,41 @@
+-- TASK ARCHIVE --
+-- The archived tasks are kept in their own database, out of the task list queries.
+
+CREATE TABLE IF NOT EXISTS `TASK_ARCHIVE` (
+    `UID` BINARY(16) PRIMARY KEY,
+    `TITLE` VARCHAR(255),
+    `DESCRIPTION` TEXT,
+    `CREATED_DATE` DATETIME,
+    `DUE_DATE` DATETIME,
+    `COMPLETED_DATE` DATETIME,
+    `CANCELLED_DATE` DATETIME,
+    `PRIORITY` VARCHAR(255),
+    `STATUS` VARCHAR(255),
+    `DOMAIN_CODE` VARCHAR(255),
+    `DOMAIN_DATA_MATERIAL_ID` BINARY(16),
+    `DOMAIN_DATA_AREA_ID` BINARY(16),
+    `DOMAIN_DATA_CROP_ID` BINARY(16),
+    `CATEGORY` VARCHAR(255),
+    `IS_DUE` TINYINT(1),
+    `ASSET_ID` BINARY(16),
+    `SHORT_CODE` VARCHAR(20),
+    `COST_CENTRE_ID` BINARY(16),
+    `COMPLETED_BY` BINARY(16),
+    `LABOUR_MINUTES` INT,
+    `MATERIAL_QUANTITY` DOUBLE,
+    `ESTIMATED_MINUTES` INT,
+    `DEPENDS_ON` TEXT,
+    `AFFECTED_AREA_IDS` TEXT,
+    `PER_AREA_PROGRESS` TEXT,
+    `DUE_DATE_ADJUSTED` TINYINT(1),
+    `TARGET_QUANTITY` DOUBLE,
+    `TARGET_UNIT` VARCHAR(255),
+    `COMPLETED_QUANTITY` DOUBLE,
+    `RECURRENCE` TEXT,
+    `RECURRENCE_OF` BINARY(16),
+    `SHORT_CODE_FARM_UID` BINARY(16),
+    `ARCHIVED_DATE` DATETIME
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `TASK_ARCHIVE_CREATED_DATE_INDEX` ON `TASK_ARCHIVE` (`CREATED_DATE`);
+CREATE FULLTEXT INDEX `TASK_ARCHIVE_DESCRIPTION_FULLTEXT_INDEX` ON `TASK_ARCHIVE` (`DESCRIPTION`);
@@ -6,7 +6,7 @@ CREATE TABLE IF NOT EXISTS `FARM_EVENT` (
     `VERSION` INT,
     `CREATED_DATE` DATETIME,
     `EVENT` JSON
-) ENGINE=InnoDB;
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE INDEX `FARM_EVENT_FARM_UID_INDEX` ON `FARM_EVENT` (`FARM_UID`);
 
@@ -19,11 +19,158 @@ CREATE TABLE IF NOT EXISTS `FARM_READ` (
     `COUNTRY` VARCHAR(255),
     `CITY` VARCHAR(255),
     `IS_ACTIVE` INT,
-    `CREATED_DATE` DATETIME
-) ENGINE=InnoDB;
+    `CREATED_DATE` DATETIME,
+    `AREA_WALK_ORDER` TEXT,
+    `HARVEST_GRADES` TEXT,
+    `SEASONS` TEXT,
+    `WITHHOLDING_POLICY` VARCHAR(20),
+    `DAILY_EFFORT_BUDGET_MINUTES` INT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE UNIQUE INDEX `FARM_READ_UID_UNIQUE_INDEX` ON `FARM_READ` (`UID`);
 
+-- FARM CERTIFICATION --
+
+CREATE TABLE IF NOT EXISTS `FARM_CERTIFICATION_EVENT` (
+    `ID` INT PRIMARY KEY AUTO_INCREMENT,
+    `FARM_CERTIFICATION_UID` BINARY(16),
+    `VERSION` INT,
+    `CREATED_DATE` DATETIME,
+    `EVENT` JSON
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `FARM_CERTIFICATION_EVENT_UID_INDEX` ON `FARM_CERTIFICATION_EVENT` (`FARM_CERTIFICATION_UID`);
+
+CREATE TABLE IF NOT EXISTS `FARM_CERTIFICATION_READ` (
+    `UID` BINARY(16) PRIMARY KEY,
+    `FARM_UID` BINARY(16),
+    `CERTIFICATION_TYPE` VARCHAR(255),
+    `CERTIFYING_BODY` VARCHAR(255),
+    `ISSUED_DATE` DATETIME,
+    `EXPIRY_DATE` DATETIME,
+    `CERTIFICATE_NUMBER` VARCHAR(255),
+    `STATUS` VARCHAR(255),
+    `IS_RENEWAL_DUE` TINYINT(1),
+    `REVOKED_DATE` DATETIME,
+    `CREATED_DATE` DATETIME
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `FARM_CERTIFICATION_READ_FARM_UID_INDEX` ON `FARM_CERTIFICATION_READ` (`FARM_UID`);
+
+-- FARM BOUNDARY --
+
+CREATE TABLE IF NOT EXISTS `FARM_BOUNDARY_EVENT` (
+    `ID` INT PRIMARY KEY AUTO_INCREMENT,
+    `FARM_UID` BINARY(16),
+    `VERSION` INT,
+    `CREATED_DATE` DATETIME,
+    `EVENT` JSON
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `FARM_BOUNDARY_EVENT_FARM_UID_INDEX` ON `FARM_BOUNDARY_EVENT` (`FARM_UID`);
+
+-- EQUIPMENT --
+
+CREATE TABLE IF NOT EXISTS `EQUIPMENT_EVENT` (
+    `ID` INT PRIMARY KEY AUTO_INCREMENT,
+    `EQUIPMENT_UID` BINARY(16),
+    `VERSION` INT,
+    `CREATED_DATE` DATETIME,
+    `EVENT` JSON
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `EQUIPMENT_EVENT_UID_INDEX` ON `EQUIPMENT_EVENT` (`EQUIPMENT_UID`);
+
+CREATE TABLE IF NOT EXISTS `EQUIPMENT_READ` (
+    `UID` BINARY(16) PRIMARY KEY,
+    `FARM_UID` BINARY(16),
+    `NAME` VARCHAR(255),
+    `TYPE` VARCHAR(255),
+    `LOCATION_TYPE` VARCHAR(255),
+    `LOCATION_UID` BINARY(16),
+    `PURCHASE_DATE` DATETIME,
+    `NOTES` TEXT,
+    `MAINTENANCE_INTERVAL_DAYS` INT,
+    `NEXT_MAINTENANCE_DATE` DATETIME,
+    `GEO_POINT` JSON,
+    `IS_DELETED` TINYINT(1),
+    `CREATED_DATE` DATETIME
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `EQUIPMENT_READ_FARM_UID_INDEX` ON `EQUIPMENT_READ` (`FARM_UID`);
+
+-- NUTRIENT RECIPE --
+
+CREATE TABLE IF NOT EXISTS `NUTRIENT_RECIPE_EVENT` (
+    `ID` INT PRIMARY KEY AUTO_INCREMENT,
+    `NUTRIENT_RECIPE_UID` BINARY(16),
+    `VERSION` INT,
+    `CREATED_DATE` DATETIME,
+    `EVENT` JSON
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `NUTRIENT_RECIPE_EVENT_UID_INDEX` ON `NUTRIENT_RECIPE_EVENT` (`NUTRIENT_RECIPE_UID`);
+
+CREATE TABLE IF NOT EXISTS `NUTRIENT_RECIPE_READ` (
+    `UID` BINARY(16) PRIMARY KEY,
+    `FARM_UID` BINARY(16),
+    `NAME` VARCHAR(255),
+    `TARGET_EC` FLOAT,
+    `TARGET_PH` FLOAT,
+    `INGREDIENTS` TEXT,
+    `IS_DELETED` TINYINT(1),
+    `CREATED_DATE` DATETIME
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `NUTRIENT_RECIPE_READ_FARM_UID_INDEX` ON `NUTRIENT_RECIPE_READ` (`FARM_UID`);
+
+-- CUSTOM FIELD DEFINITION --
+
+CREATE TABLE IF NOT EXISTS `CUSTOM_FIELD_DEFINITION_EVENT` (
+    `ID` INT PRIMARY KEY AUTO_INCREMENT,
+    `CUSTOM_FIELD_DEFINITION_UID` BINARY(16),
+    `VERSION` INT,
+    `CREATED_DATE` DATETIME,
+    `EVENT` JSON
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `CUSTOM_FIELD_DEFINITION_EVENT_UID_INDEX` ON `CUSTOM_FIELD_DEFINITION_EVENT` (`CUSTOM_FIELD_DEFINITION_UID`);
+
+CREATE TABLE IF NOT EXISTS `CUSTOM_FIELD_DEFINITION_READ` (
+    `UID` BINARY(16) PRIMARY KEY,
+    `FARM_UID` BINARY(16),
+    `ENTITY_TYPE` VARCHAR(255),
+    `FIELD_KEY` VARCHAR(255),
+    `FIELD_TYPE` VARCHAR(255),
+    `IS_REQUIRED` TINYINT(1),
+    `IS_DELETED` TINYINT(1),
+    `CREATED_DATE` DATETIME,
+    `LABEL` VARCHAR(255),
+    `OPTIONS` TEXT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `CUSTOM_FIELD_DEFINITION_READ_FARM_UID_INDEX` ON `CUSTOM_FIELD_DEFINITION_READ` (`FARM_UID`);
+
+CREATE TABLE IF NOT EXISTS `CUSTOM_FIELD_VALUE_EVENT` (
+    `ID` INT PRIMARY KEY AUTO_INCREMENT,
+    `ENTITY_UID` BINARY(16),
+    `VERSION` INT,
+    `CREATED_DATE` DATETIME,
+    `EVENT` JSON
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `CUSTOM_FIELD_VALUE_EVENT_ENTITY_UID_INDEX` ON `CUSTOM_FIELD_VALUE_EVENT` (`ENTITY_UID`);
+
+CREATE TABLE IF NOT EXISTS `CUSTOM_FIELD_VALUE_READ` (
+    `ENTITY_UID` BINARY(16) PRIMARY KEY,
+    `FARM_UID` BINARY(16),
+    `ENTITY_TYPE` VARCHAR(255),
+    `FIELD_VALUES` TEXT,
+    `UPDATED_DATE` DATETIME
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `CUSTOM_FIELD_VALUE_READ_FARM_UID_INDEX` ON `CUSTOM_FIELD_VALUE_READ` (`FARM_UID`);
+
 -- RESERVOIR --
 
 CREATE TABLE IF NOT EXISTS `RESERVOIR_EVENT` (
@@ -32,7 +179,7 @@ CREATE TABLE IF NOT EXISTS `RESERVOIR_EVENT` (
     `VERSION` INT,
     `CREATED_DATE` DATETIME,
     `EVENT` JSON
-) ENGINE=InnoDB;
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE INDEX `RESERVOIR_EVENT_RESERVOIR_UID_INDEX` ON `RESERVOIR_EVENT` (`RESERVOIR_UID`);
 
@@ -44,7 +191,7 @@ CREATE TABLE IF NOT EXISTS `RESERVOIR_READ` (
     `FARM_UID` BINARY(16),
     `FARM_NAME` VARCHAR(255),
     `CREATED_DATE` DATETIME
-) ENGINE=InnoDB;
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE INDEX `RESERVOIR_READ_UID_UNIQUE_INDEX` ON `RESERVOIR_READ` (`UID`);
 
@@ -54,10 +201,11 @@ CREATE TABLE IF NOT EXISTS `RESERVOIR_READ_NOTES` (
     `CONTENT` TEXT,
     `CREATED_DATE` DATETIME,
     FOREIGN KEY(`RESERVOIR_UID`) REFERENCES `RESERVOIR_READ`(`UID`)
-) ENGINE=InnoDB;
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE UNIQUE INDEX `RESERVOIR_READ_NOTES_UID_UNIQUE_INDEX` ON `RESERVOIR_READ_NOTES` (`UID`);
 CREATE INDEX `RESERVOIR_READ_NOTES_RESERVOIR_UID_INDEX` ON `RESERVOIR_READ_NOTES` (`RESERVOIR_UID`);
+CREATE FULLTEXT INDEX `RESERVOIR_READ_NOTES_CONTENT_FULLTEXT_INDEX` ON `RESERVOIR_READ_NOTES` (`CONTENT`);
 
 -- AREA --
 
@@ -67,7 +215,7 @@ CREATE TABLE IF NOT EXISTS `AREA_EVENT` (
     `VERSION` INT,
     `CREATED_DATE` DATETIME,
     `EVENT` JSON
-) ENGINE=InnoDB;
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE INDEX `FARM_EVENT_AREA_UID_INDEX` ON `AREA_EVENT` (`AREA_UID`);
 
@@ -87,8 +235,14 @@ CREATE TABLE IF NOT EXISTS `AREA_READ` (
     `RESERVOIR_UID` BINARY(16),
     `RESERVOIR_NAME` VARCHAR(255),
     `FARM_UID` BINARY(16),
-    `FARM_NAME` VARCHAR(255)
-) ENGINE=InnoDB;
+    `FARM_NAME` VARCHAR(255),
+    `CUSTOM_FIELDS` JSON,
+    `LAYOUT` JSON,
+    `BED_MAP` JSON,
+    `GEO_POINT` JSON,
+    `SANITATIONS` JSON,
+    `LAST_SANITIZED_DATE` DATETIME
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE UNIQUE INDEX `AREA_READ_UID_UNIQUE_INDEX` ON `AREA_READ` (`UID`);
 CREATE INDEX `AREA_READ_RESERVOIR_UID_INDEX` ON `AREA_READ` (`RESERVOIR_UID`);
@@ -100,10 +254,11 @@ CREATE TABLE IF NOT EXISTS `AREA_READ_NOTES` (
     `CONTENT` TEXT,
     `CREATED_DATE` DATETIME,
     FOREIGN KEY(`AREA_UID`) REFERENCES `AREA_READ`(`UID`)
-) ENGINE=InnoDB;
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE UNIQUE INDEX `AREA_READ_NOTES_UID_UNIQUE_INDEX` ON `AREA_READ_NOTES` (`UID`);
 CREATE INDEX `AREA_READ_NOTES_AREA_UID_INDEX` ON `AREA_READ_NOTES` (`AREA_UID`);
+CREATE FULLTEXT INDEX `AREA_READ_NOTES_CONTENT_FULLTEXT_INDEX` ON `AREA_READ_NOTES` (`CONTENT`);
 
 -- MATERIAL --
 
@@ -113,7 +268,7 @@ CREATE TABLE IF NOT EXISTS `MATERIAL_EVENT` (
     `VERSION` INT,
     `CREATED_DATE` DATETIME,
     `EVENT` JSON
-);
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE INDEX `MATERIAL_EVENT_MATERIAL_UID_INDEX` ON `MATERIAL_EVENT` (`MATERIAL_UID`);
 
@@ -129,11 +284,36 @@ CREATE TABLE IF NOT EXISTS `MATERIAL_READ` (
     `EXPIRATION_DATE` VARCHAR(255),
     `NOTES` VARCHAR(255),
     `PRODUCED_BY` VARCHAR(255),
-    `CREATED_DATE` DATETIME
-);
+    `CREATED_DATE` DATETIME,
+    `GDD_TO_MATURITY` DOUBLE NULL,
+    `PRE_HARVEST_INTERVAL_DAYS` INT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE INDEX `MATERIAL_READ_UID_UNIQUE_INDEX` ON `MATERIAL_READ` (`UID`);
 
+-- STOCKTAKE --
+
+CREATE TABLE IF NOT EXISTS `STOCKTAKE_EVENT` (
+    `ID` INT PRIMARY KEY AUTO_INCREMENT,
+    `STOCKTAKE_UID` BINARY(16),
+    `VERSION` INT,
+    `CREATED_DATE` DATETIME,
+    `EVENT` JSON
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `STOCKTAKE_EVENT_STOCKTAKE_UID_INDEX` ON `STOCKTAKE_EVENT` (`STOCKTAKE_UID`);
+
+CREATE TABLE IF NOT EXISTS `STOCKTAKE_READ` (
+    `UID` BINARY(16) PRIMARY KEY,
+    `FARM_UID` BINARY(16),
+    `STATUS` VARCHAR(255),
+    `LINES` JSON,
+    `OPENED_DATE` DATETIME,
+    `CLOSED_DATE` DATETIME
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `STOCKTAKE_READ_FARM_UID_INDEX` ON `STOCKTAKE_READ` (`FARM_UID`);
+
 -- CROP --
 
 CREATE TABLE IF NOT EXISTS `CROP_EVENT` (
@@ -142,7 +322,7 @@ CREATE TABLE IF NOT EXISTS `CROP_EVENT` (
     `VERSION` INT,
     `CREATED_DATE` DATETIME,
     `EVENT` JSON
-);
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE INDEX `CROP_EVENT_CROP_UID_INDEX` ON `CROP_EVENT` (`CROP_UID`);
 
@@ -171,8 +351,15 @@ CREATE TABLE IF NOT EXISTS `CROP_READ` (
     `INITIAL_AREA_LAST_PESTICIDED` DATETIME,
     `INITIAL_AREA_LAST_PRUNED` DATETIME,
     `INITIAL_AREA_CREATED_DATE` DATETIME,
-    `INITIAL_AREA_LAST_UPDATED` DATETIME
-);
+    `INITIAL_AREA_LAST_UPDATED` DATETIME,
+    `SHORT_CODE` VARCHAR(20),
+    `SEEDS_SOWN` INT,
+    `GERMINATED_QUANTITY` INT,
+    `GERMINATION_DATE` DATETIME,
+    `MERGED_INTO_UID` BINARY(16)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `CROP_READ_INITIAL_AREA_UID_INDEX` ON `CROP_READ` (`INITIAL_AREA_UID`);
 
 CREATE TABLE IF NOT EXISTS `CROP_READ_PHOTO` (
     `UID` BINARY(16) PRIMARY KEY,
@@ -183,8 +370,11 @@ CREATE TABLE IF NOT EXISTS `CROP_READ_PHOTO` (
     `WIDTH` INT,
     `HEIGHT` INT,
     `DESCRIPTION` TEXT,
+    `STATUS` VARCHAR(20),
+    `THUMBNAIL_FILENAME` VARCHAR(255),
+    `METADATA` TEXT,
     FOREIGN KEY(`CROP_UID`) REFERENCES `CROP_READ`(`UID`)
-);
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE TABLE IF NOT EXISTS `CROP_READ_MOVED_AREA` (
     `ID` INT PRIMARY KEY AUTO_INCREMENT,
@@ -200,7 +390,22 @@ CREATE TABLE IF NOT EXISTS `CROP_READ_MOVED_AREA` (
     `CREATED_DATE` DATETIME,
     `LAST_UPDATED` DATETIME,
     FOREIGN KEY(`CROP_UID`) REFERENCES `CROP_READ`(`UID`)
-);
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `CROP_READ_MOVED_AREA_AREA_UID_INDEX` ON `CROP_READ_MOVED_AREA` (`AREA_UID`);
+
+CREATE TABLE IF NOT EXISTS `AREA_CROP_CURRENT` (
+    `ID` INT PRIMARY KEY AUTO_INCREMENT,
+    `AREA_UID` BINARY(16),
+    `CROP_UID` BINARY(16),
+    `FARM_UID` BINARY(16),
+    `QUANTITY` INT,
+    `PLACED_DATE` DATETIME,
+    `LAST_UPDATED` DATETIME
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `AREA_CROP_CURRENT_AREA_UID_INDEX` ON `AREA_CROP_CURRENT` (`AREA_UID`);
+CREATE INDEX `AREA_CROP_CURRENT_CROP_UID_INDEX` ON `AREA_CROP_CURRENT` (`CROP_UID`);
 
 CREATE TABLE IF NOT EXISTS `CROP_READ_HARVESTED_STORAGE` (
     `ID` INT PRIMARY KEY AUTO_INCREMENT,
@@ -212,7 +417,7 @@ CREATE TABLE IF NOT EXISTS `CROP_READ_HARVESTED_STORAGE` (
     `CREATED_DATE` DATETIME,
     `LAST_UPDATED` DATETIME,
     FOREIGN KEY(`CROP_UID`) REFERENCES `CROP_READ`(`UID`)
-);
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE TABLE IF NOT EXISTS `CROP_READ_TRASH` (
     `ID` INT PRIMARY KEY AUTO_INCREMENT,
@@ -223,7 +428,20 @@ CREATE TABLE IF NOT EXISTS `CROP_READ_TRASH` (
     `CREATED_DATE` DATETIME,
     `LAST_UPDATED` DATETIME,
     FOREIGN KEY(`CROP_UID`) REFERENCES `CROP_READ`(`UID`)
-);
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `CROP_READ_NURSERY_STAGE` (
+    `UID` BINARY(16) PRIMARY KEY,
+    `CROP_UID` BINARY(16),
+    `AREA_UID` BINARY(16),
+    `AREA_NAME` VARCHAR(255),
+    `START_DATE` DATETIME,
+    `EXPECTED_TRANSPLANT_DATE` DATETIME,
+    `ACTUAL_TRANSPLANT_DATE` DATETIME,
+    FOREIGN KEY(`CROP_UID`) REFERENCES `CROP_READ`(`UID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `CROP_READ_NURSERY_STAGE_CROP_UID_INDEX` ON `CROP_READ_NURSERY_STAGE` (`CROP_UID`);
 
 CREATE TABLE IF NOT EXISTS `CROP_READ_NOTES` (
     `UID` BINARY(16) PRIMARY KEY,
@@ -231,10 +449,11 @@ CREATE TABLE IF NOT EXISTS `CROP_READ_NOTES` (
     `CONTENT` TEXT,
     `CREATED_DATE` DATETIME,
     FOREIGN KEY(`CROP_UID`) REFERENCES `CROP_READ`(`UID`)
-);
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE UNIQUE INDEX `CROP_READ_NOTES_UID_UNIQUE_INDEX` ON `CROP_READ_NOTES` (`UID`);
 CREATE INDEX `CROP_READ_NOTES_CROP_UID_INDEX` ON `CROP_READ_NOTES` (`CROP_UID`);
+CREATE FULLTEXT INDEX `CROP_READ_NOTES_CONTENT_FULLTEXT_INDEX` ON `CROP_READ_NOTES` (`CONTENT`);
 
 
 CREATE TABLE IF NOT EXISTS `CROP_ACTIVITY` (
@@ -247,7 +466,127 @@ CREATE TABLE IF NOT EXISTS `CROP_ACTIVITY` (
     `CREATED_DATE` DATETIME,
     `DESCRIPTION` TEXT,
     FOREIGN KEY(`CROP_UID`) REFERENCES `CROP_READ`(`UID`)
-);
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `CROP_INPUT_SCHEDULE_EVENT` (
+    `ID` INT PRIMARY KEY AUTO_INCREMENT,
+    `CROP_INPUT_SCHEDULE_UID` BINARY(16),
+    `VERSION` INT,
+    `CREATED_DATE` DATETIME,
+    `EVENT` JSON
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `CROP_INPUT_SCHEDULE_EVENT_UID_INDEX` ON `CROP_INPUT_SCHEDULE_EVENT` (`CROP_INPUT_SCHEDULE_UID`);
+
+CREATE TABLE IF NOT EXISTS `CROP_INPUT_SCHEDULE_READ` (
+    `UID` BINARY(16) PRIMARY KEY,
+    `CROP_UID` BINARY(16),
+    `MATERIAL_UID` BINARY(16),
+    `PLANNED_DATE` DATETIME,
+    `QUANTITY` DOUBLE,
+    `UNIT` VARCHAR(255),
+    `APPLICATION_METHOD` VARCHAR(255),
+    `STATUS` VARCHAR(255),
+    `TASK_UID` BINARY(16),
+    `APPLIED_DATE` DATETIME,
+    `CREATED_DATE` DATETIME
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `CROP_INPUT_SCHEDULE_READ_CROP_UID_INDEX` ON `CROP_INPUT_SCHEDULE_READ` (`CROP_UID`);
+CREATE INDEX `CROP_INPUT_SCHEDULE_READ_TASK_UID_INDEX` ON `CROP_INPUT_SCHEDULE_READ` (`TASK_UID`);
+
+CREATE TABLE IF NOT EXISTS `DISPATCH_SCHEDULE_EVENT` (
+    `ID` INT PRIMARY KEY AUTO_INCREMENT,
+    `DISPATCH_SCHEDULE_UID` BINARY(16),
+    `VERSION` INT,
+    `CREATED_DATE` DATETIME,
+    `EVENT` JSON
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `DISPATCH_SCHEDULE_EVENT_UID_INDEX` ON `DISPATCH_SCHEDULE_EVENT` (`DISPATCH_SCHEDULE_UID`);
+
+CREATE TABLE IF NOT EXISTS `DISPATCH_SCHEDULE_READ` (
+    `UID` BINARY(16) PRIMARY KEY,
+    `FARM_UID` BINARY(16),
+    `HARVEST_IDS` TEXT,
+    `VEHICLE_ID` VARCHAR(255),
+    `DRIVER_NAME` VARCHAR(255),
+    `PICKUP_TIME` DATETIME,
+    `DESTINATION_ADDRESS` TEXT,
+    `ESTIMATED_DELIVERY_TIME` DATETIME,
+    `STATUS` VARCHAR(255),
+    `TASK_UID` BINARY(16),
+    `COMPLETED_DATE` DATETIME,
+    `CREATED_DATE` DATETIME
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `DISPATCH_SCHEDULE_READ_FARM_UID_INDEX` ON `DISPATCH_SCHEDULE_READ` (`FARM_UID`);
+CREATE INDEX `DISPATCH_SCHEDULE_READ_TASK_UID_INDEX` ON `DISPATCH_SCHEDULE_READ` (`TASK_UID`);
+
+CREATE TABLE IF NOT EXISTS `INSURANCE_POLICY_EVENT` (
+    `ID` INT PRIMARY KEY AUTO_INCREMENT,
+    `INSURANCE_POLICY_UID` BINARY(16),
+    `VERSION` INT,
+    `CREATED_DATE` DATETIME,
+    `EVENT` JSON
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `INSURANCE_POLICY_EVENT_UID_INDEX` ON `INSURANCE_POLICY_EVENT` (`INSURANCE_POLICY_UID`);
+
+CREATE TABLE IF NOT EXISTS `INSURANCE_POLICY_READ` (
+    `UID` BINARY(16) PRIMARY KEY,
+    `FARM_UID` BINARY(16),
+    `POLICY_NUMBER` VARCHAR(255),
+    `PROVIDER` VARCHAR(255),
+    `CROP_IDS` TEXT,
+    `COVERAGE_AMOUNT` DOUBLE,
+    `CURRENCY` VARCHAR(3),
+    `PREMIUM_AMOUNT` DOUBLE,
+    `START_DATE` DATETIME,
+    `END_DATE` DATETIME,
+    `CLAIMS` TEXT,
+    `IS_DELETED` TINYINT(1),
+    `CREATED_DATE` DATETIME
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `INSURANCE_POLICY_READ_FARM_UID_INDEX` ON `INSURANCE_POLICY_READ` (`FARM_UID`);
+
+CREATE TABLE IF NOT EXISTS `HARVEST_LOT_EVENT` (
+    `ID` INT PRIMARY KEY AUTO_INCREMENT,
+    `HARVEST_LOT_UID` BINARY(16),
+    `VERSION` INT,
+    `CREATED_DATE` DATETIME,
+    `EVENT` JSON
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `HARVEST_LOT_EVENT_UID_INDEX` ON `HARVEST_LOT_EVENT` (`HARVEST_LOT_UID`);
+
+CREATE TABLE IF NOT EXISTS `HARVEST_LOT_READ` (
+    `UID` BINARY(16) PRIMARY KEY,
+    `FARM_UID` BINARY(16),
+    `LOT_NUMBER` VARCHAR(255),
+    `CROP_UID` BINARY(16),
+    `BATCH_ID` VARCHAR(255),
+    `AREA_UID` BINARY(16),
+    `HARVEST_DATE` DATETIME,
+    `GRADE` VARCHAR(255),
+    `GRAM_QUANTITY` DOUBLE,
+    `REMAINING_GRAM_QUANTITY` DOUBLE,
+    `STATUS` VARCHAR(20),
+    `SHIPMENTS` TEXT,
+    `CREATED_DATE` DATETIME
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `HARVEST_LOT_READ_FARM_UID_INDEX` ON `HARVEST_LOT_READ` (`FARM_UID`);
+
+CREATE TABLE IF NOT EXISTS `MICROCLIMATE_SAMPLE` (
+    `UID` BINARY(16) PRIMARY KEY,
+    `AREA_UID` BINARY(16),
+    `TEMPERATURE` DOUBLE,
+    `RECORDED_DATE` DATETIME
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `MICROCLIMATE_SAMPLE_AREA_UID_INDEX` ON `MICROCLIMATE_SAMPLE` (`AREA_UID`);
 
 -- TASK --
 
@@ -257,7 +596,7 @@ CREATE TABLE IF NOT EXISTS `TASK_EVENT` (
     `VERSION` INT,
     `CREATED_DATE` DATETIME,
     `EVENT` JSON
-);
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE INDEX `TASK_EVENT_TASK_UID_INDEX` ON `TASK_EVENT` (`TASK_UID`);
 
@@ -277,10 +616,69 @@ CREATE TABLE IF NOT EXISTS `TASK_READ` (
     `DOMAIN_DATA_CROP_ID` BINARY(16),
     `CATEGORY` VARCHAR(255),
     `IS_DUE` TINYINT(1),
-    `ASSET_ID` BINARY(16)
-);
+    `ASSET_ID` BINARY(16),
+    `SHORT_CODE` VARCHAR(20),
+    `COST_CENTRE_ID` BINARY(16),
+    `COMPLETED_BY` BINARY(16),
+    `LABOUR_MINUTES` INT,
+    `MATERIAL_QUANTITY` DOUBLE,
+    `ESTIMATED_MINUTES` INT,
+    `DEPENDS_ON` TEXT,
+    `AFFECTED_AREA_IDS` TEXT,
+    `PER_AREA_PROGRESS` TEXT,
+    `DUE_DATE_ADJUSTED` TINYINT(1),
+    `TARGET_QUANTITY` DOUBLE,
+    `TARGET_UNIT` VARCHAR(255),
+    `COMPLETED_QUANTITY` DOUBLE,
+    `RECURRENCE` TEXT,
+    `RECURRENCE_OF` BINARY(16),
+    `SHORT_CODE_FARM_UID` BINARY(16)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE INDEX `TASK_READ_UID_UNIQUE_INDEX` ON `TASK_READ` (`UID`);
+CREATE INDEX `TASK_READ_ASSET_ID_INDEX` ON `TASK_READ` (`ASSET_ID`);
+CREATE UNIQUE INDEX `TASK_READ_SHORT_CODE_UNIQUE_INDEX` ON `TASK_READ` (`SHORT_CODE_FARM_UID`, `SHORT_CODE`);
+CREATE FULLTEXT INDEX `TASK_READ_DESCRIPTION_FULLTEXT_INDEX` ON `TASK_READ` (`DESCRIPTION`);
+
+CREATE TABLE IF NOT EXISTS `TASK_READ_AREA` (
+    `TASK_UID` BINARY(16),
+    `AREA_UID` BINARY(16),
+    PRIMARY KEY (`TASK_UID`, `AREA_UID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `TASK_READ_AREA_AREA_UID_INDEX` ON `TASK_READ_AREA` (`AREA_UID`);
+
+-- TASK TEMPLATE --
+
+CREATE TABLE IF NOT EXISTS `TASK_TEMPLATE_EVENT` (
+    `ID` INT PRIMARY KEY AUTO_INCREMENT,
+    `TASK_TEMPLATE_UID` BINARY(16),
+    `VERSION` INT,
+    `CREATED_DATE` DATETIME,
+    `EVENT` JSON
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `TASK_TEMPLATE_EVENT_TASK_TEMPLATE_UID_INDEX` ON `TASK_TEMPLATE_EVENT` (`TASK_TEMPLATE_UID`);
+
+CREATE TABLE IF NOT EXISTS `TASK_TEMPLATE_READ` (
+    `UID` BINARY(16) PRIMARY KEY,
+    `NAME` VARCHAR(255),
+    `PARENT_TEMPLATE_UID` BINARY(16),
+    `ITEMS` JSON,
+    `CREATED_DATE` DATETIME
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- TASK CATALOG --
+
+CREATE TABLE IF NOT EXISTS `TASK_CATALOG_EVENT` (
+    `ID` INT PRIMARY KEY AUTO_INCREMENT,
+    `FARM_UID` BINARY(16),
+    `VERSION` INT,
+    `CREATED_DATE` DATETIME,
+    `EVENT` JSON
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `TASK_CATALOG_EVENT_FARM_UID_INDEX` ON `TASK_CATALOG_EVENT` (`FARM_UID`);
 
 -- USER --
 
@@ -290,7 +688,7 @@ CREATE TABLE IF NOT EXISTS `USER_EVENT` (
     `VERSION` INT,
     `CREATED_DATE` DATETIME,
     `EVENT` JSON
-);
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE INDEX `USER_EVENT_USER_UID_INDEX` ON `USER_EVENT` (`USER_UID`);
 
@@ -299,18 +697,199 @@ CREATE TABLE IF NOT EXISTS `USER_READ` (
     `USERNAME` VARCHAR(255),
     `PASSWORD` TEXT,
     `CREATED_DATE` DATETIME,
-    `LAST_UPDATED` DATETIME
-);
+    `LAST_UPDATED` DATETIME,
+    `HOURLY_RATE` DOUBLE,
+    `ROLE` VARCHAR(20)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE INDEX `USER_READ_UID_UNIQUE_INDEX` ON `USER_READ` (`UID`);
 
 CREATE TABLE IF NOT EXISTS `USER_AUTH` (
     `USER_UID` BINARY(16) PRIMARY KEY,
-    `ACCESS_TOKEN` VARCHAR(255),
+    `ACCESS_TOKEN` VARBINARY(255),
     `TOKEN_EXPIRES` INT,
     `CREATED_DATE` DATETIME,
     `LAST_UPDATED` DATETIME
-);
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 CREATE UNIQUE INDEX `USER_AUTH_USER_UID_UNIQUE_INDEX` ON `USER_AUTH` (`USER_UID`);
-CREATE UNIQUE INDEX `USER_AUTH_ACCESS_TOKEN_UNIQUE_INDEX` ON `USER_AUTH` (`ACCESS_TOKEN`);
\ No newline at end of file
+CREATE UNIQUE INDEX `USER_AUTH_ACCESS_TOKEN_UNIQUE_INDEX` ON `USER_AUTH` (`ACCESS_TOKEN`);
+
+-- SHORT CODE --
+
+CREATE TABLE IF NOT EXISTS `SHORT_CODE_SEQUENCE` (
+    `PREFIX` VARCHAR(10),
+    `SCOPE_UID` BINARY(16),
+    `LAST_VALUE` INT,
+    PRIMARY KEY(`PREFIX`, `SCOPE_UID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- RETENTION --
+
+CREATE TABLE IF NOT EXISTS `PRUNED_AGGREGATE` (
+    `AGGREGATE` VARCHAR(10),
+    `UID` BINARY(16),
+    `ARCHIVE_FILE` VARCHAR(255),
+    `LAST_EVENT_DATE` DATETIME,
+    `PRUNED_DATE` DATETIME,
+    PRIMARY KEY(`AGGREGATE`, `UID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- CHANGE FEED --
+
+CREATE TABLE IF NOT EXISTS `CHANGE_LOG` (
+    `SEQUENCE` BIGINT NOT NULL AUTO_INCREMENT,
+    `FARM_UID` BINARY(16),
+    `ENTITY_TYPE` VARCHAR(20) NOT NULL,
+    `ENTITY_UID` BINARY(16) NOT NULL,
+    `CHANGE_TYPE` VARCHAR(10) NOT NULL,
+    `CREATED_DATE` DATETIME NOT NULL,
+    PRIMARY KEY (`SEQUENCE`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `CHANGE_LOG_FARM_UID_SEQUENCE_INDEX` ON `CHANGE_LOG` (`FARM_UID`, `SEQUENCE`);
+CREATE INDEX `CHANGE_LOG_ENTITY_UID_INDEX` ON `CHANGE_LOG` (`ENTITY_UID`);
+CREATE INDEX `CHANGE_LOG_FARM_UID_CREATED_DATE_INDEX` ON `CHANGE_LOG` (`FARM_UID`, `CREATED_DATE`);
+
+-- REPORT MAIL --
+
+CREATE TABLE IF NOT EXISTS `REPORT_SUBSCRIPTION` (
+    `UID` BINARY(16) NOT NULL,
+    `FARM_UID` BINARY(16) NOT NULL,
+    `REPORT_TYPE` VARCHAR(20) NOT NULL,
+    `FORMAT` VARCHAR(10) NOT NULL,
+    `DAY_OF_WEEK` VARCHAR(10) NOT NULL,
+    `TIME_OF_DAY` VARCHAR(5) NOT NULL,
+    `TIMEZONE` VARCHAR(64) NOT NULL,
+    `RECIPIENTS` TEXT NOT NULL,
+    `CREATED_DATE` DATETIME NOT NULL,
+    `NEXT_RUN_DATE` DATETIME NOT NULL,
+    PRIMARY KEY (`UID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `REPORT_SUBSCRIPTION_FARM_UID_INDEX` ON `REPORT_SUBSCRIPTION` (`FARM_UID`);
+CREATE INDEX `REPORT_SUBSCRIPTION_NEXT_RUN_DATE_INDEX` ON `REPORT_SUBSCRIPTION` (`NEXT_RUN_DATE`);
+
+CREATE TABLE IF NOT EXISTS `REPORT_DELIVERY` (
+    `UID` BINARY(16) NOT NULL,
+    `SUBSCRIPTION_UID` BINARY(16) NOT NULL,
+    `FARM_UID` BINARY(16) NOT NULL,
+    `SCHEDULED_DATE` DATETIME NOT NULL,
+    `IS_MANUAL` TINYINT(1),
+    `STATUS` VARCHAR(10) NOT NULL,
+    `ATTEMPTS` INT,
+    `LAST_ERROR` TEXT,
+    `NEXT_ATTEMPT_DATE` DATETIME,
+    `SENT_DATE` DATETIME,
+    PRIMARY KEY (`UID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `REPORT_DELIVERY_SUBSCRIPTION_UID_INDEX` ON `REPORT_DELIVERY` (`SUBSCRIPTION_UID`);
+CREATE INDEX `REPORT_DELIVERY_STATUS_NEXT_ATTEMPT_DATE_INDEX` ON `REPORT_DELIVERY` (`STATUS`, `NEXT_ATTEMPT_DATE`);
+
+-- ENERGY --
+
+CREATE TABLE IF NOT EXISTS `ENERGY_READING` (
+    `UID` BINARY(16) NOT NULL,
+    `FARM_UID` BINARY(16) NOT NULL,
+    `METER_ID` VARCHAR(50) NOT NULL,
+    `KWH` DOUBLE NOT NULL,
+    `RECORDED_AT` DATETIME NOT NULL,
+    `TARIFF_RATE` DOUBLE NOT NULL,
+    `CURRENCY` VARCHAR(10) NOT NULL,
+    PRIMARY KEY (`UID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `ENERGY_READING_FARM_UID_RECORDED_AT_INDEX` ON `ENERGY_READING` (`FARM_UID`, `RECORDED_AT`);
+
+-- ENVIRONMENT ALERTS --
+
+CREATE TABLE IF NOT EXISTS `ENVIRONMENT_ALERT_RULE` (
+    `UID` BINARY(16) NOT NULL,
+    `AREA_UID` BINARY(16) NOT NULL,
+    `KIND` VARCHAR(20) NOT NULL,
+    `DIRECTION` VARCHAR(10) NOT NULL,
+    `THRESHOLD` DOUBLE NOT NULL,
+    `WINDOW_MINUTES` INT NOT NULL,
+    `HYSTERESIS` DOUBLE NOT NULL,
+    `PRIORITY` VARCHAR(10) NOT NULL,
+    `IS_TRIGGERED` BOOLEAN,
+    `CREATED_DATE` DATETIME NOT NULL,
+    PRIMARY KEY (`UID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- CROP PHOTO HASHES --
+
+CREATE TABLE IF NOT EXISTS `CROP_PHOTO_HASH` (
+    `PHOTO_UID` BINARY(16) NOT NULL,
+    `CROP_UID` BINARY(16) NOT NULL,
+    `HASH` CHAR(16) NOT NULL,
+    `CREATED_DATE` DATETIME NOT NULL,
+    PRIMARY KEY (`PHOTO_UID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `CROP_PHOTO_HASH_CROP_UID_INDEX` ON `CROP_PHOTO_HASH` (`CROP_UID`);
+
+-- FARM SIGNING KEYS --
+
+CREATE TABLE IF NOT EXISTS `FARM_SIGNING_KEY` (
+    `FARM_UID` BINARY(16) NOT NULL,
+    `PRIVATE_KEY` TEXT NOT NULL,
+    `CREATED_DATE` DATETIME NOT NULL,
+    PRIMARY KEY (`FARM_UID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `PRESENCE` (
+    `FARM_UID` BINARY(16) NOT NULL,
+    `KIND` VARCHAR(20) NOT NULL,
+    `IDENTITY` VARCHAR(100) NOT NULL,
+    `NAME` VARCHAR(200) NOT NULL,
+    `FIRST_SEEN` DATETIME NOT NULL,
+    `LAST_SEEN` DATETIME NOT NULL,
+    PRIMARY KEY (`FARM_UID`, `KIND`, `IDENTITY`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `WEBHOOK_DELIVERY` (
+    `UID` BINARY(16) NOT NULL,
+    `WEBHOOK_ID` VARCHAR(100) NOT NULL,
+    `EVENT_TYPE` VARCHAR(100) NOT NULL,
+    `PAYLOAD` TEXT NOT NULL,
+    `REPLAY_OF_UID` BINARY(16) NULL,
+    `STATUS` VARCHAR(20) NOT NULL,
+    `STATUS_CODE` INT NOT NULL,
+    `ERROR` TEXT NOT NULL,
+    `CREATED_DATE` DATETIME(6) NOT NULL,
+    PRIMARY KEY (`UID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `WEBHOOK_DELIVERY_WEBHOOK_ID_INDEX` ON `WEBHOOK_DELIVERY` (`WEBHOOK_ID`, `CREATED_DATE`);
+
+CREATE TABLE IF NOT EXISTS `IDEMPOTENCY_RECORD` (
+    `IDEMPOTENCY_KEY` VARBINARY(300) NOT NULL,
+    `REQUEST_HASH` CHAR(64) NOT NULL,
+    `COMPLETED` TINYINT(1) NOT NULL,
+    `STATUS_CODE` INT NOT NULL,
+    `CONTENT_TYPE` VARCHAR(200) NOT NULL,
+    `BODY` MEDIUMBLOB NOT NULL,
+    `CREATED_DATE` DATETIME NOT NULL,
+    `EXPIRES_DATE` DATETIME NOT NULL,
+    PRIMARY KEY (`IDEMPOTENCY_KEY`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX `IDEMPOTENCY_RECORD_EXPIRES_DATE_INDEX` ON `IDEMPOTENCY_RECORD` (`EXPIRES_DATE`);
+
+CREATE TABLE IF NOT EXISTS `MAINTENANCE_MODE` (
+    `ID` INT NOT NULL,
+    `REASON` TEXT NOT NULL,
+    `STARTED_DATE` DATETIME NOT NULL,
+    `ENDS_AT` DATETIME NOT NULL,
+    PRIMARY KEY (`ID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `DEVICE_REGISTRY` (
+    `ID` VARBINARY(255) NOT NULL,
+    `NAME` VARCHAR(255) NOT NULL,
+    `SECRET` VARCHAR(255) NOT NULL,
+    `CREATED_DATE` DATETIME NOT NULL,
+    PRIMARY KEY (`ID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
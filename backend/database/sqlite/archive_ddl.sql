@@ -0,0 +1,39 @@
+-- TASK ARCHIVE --
+-- The archived tasks are kept in their own database file, out of the task list queries.
+
+CREATE TABLE IF NOT EXISTS "TASK_ARCHIVE" (
+    "UID" BLOB PRIMARY KEY,
+    "TITLE" TEXT,
+    "DESCRIPTION" TEXT,
+    "CREATED_DATE" TEXT,
+    "DUE_DATE" TEXT,
+    "COMPLETED_DATE" TEXT,
+    "CANCELLED_DATE" TEXT,
+    "PRIORITY" TEXT,
+    "STATUS" TEXT,
+    "DOMAIN_CODE" TEXT,
+    "DOMAIN_DATA_MATERIAL_ID" TEXT,
+    "DOMAIN_DATA_AREA_ID" TEXT,
+    "CATEGORY" TEXT,
+    "IS_DUE" BOOLEAN,
+    "ASSET_ID" TEXT,
+    "SHORT_CODE" TEXT,
+    "COST_CENTRE_ID" TEXT,
+    "COMPLETED_BY" TEXT,
+    "LABOUR_MINUTES" INTEGER,
+    "MATERIAL_QUANTITY" REAL,
+    "ESTIMATED_MINUTES" INTEGER,
+    "DEPENDS_ON" TEXT,
+    "AFFECTED_AREA_IDS" TEXT,
+    "PER_AREA_PROGRESS" TEXT,
+    "DUE_DATE_ADJUSTED" BOOLEAN,
+    "TARGET_QUANTITY" REAL,
+    "TARGET_UNIT" TEXT,
+    "COMPLETED_QUANTITY" REAL,
+    "RECURRENCE" TEXT,
+    "RECURRENCE_OF" TEXT,
+    "SHORT_CODE_FARM_UID" TEXT,
+    "ARCHIVED_DATE" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS "TASK_ARCHIVE_CREATED_DATE_INDEX" ON "TASK_ARCHIVE" ("CREATED_DATE");
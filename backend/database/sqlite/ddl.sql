@@ -19,11 +19,158 @@ CREATE TABLE IF NOT EXISTS "FARM_READ" (
     "COUNTRY" TEXT,
     "CITY" TEXT,
     "IS_ACTIVE" INTEGER,
-    "CREATED_DATE" TEXT
+    "CREATED_DATE" TEXT,
+    "AREA_WALK_ORDER" TEXT,
+    "HARVEST_GRADES" TEXT,
+    "SEASONS" TEXT,
+    "WITHHOLDING_POLICY" TEXT,
+    "DAILY_EFFORT_BUDGET_MINUTES" INTEGER
 );
 
 CREATE UNIQUE INDEX IF NOT EXISTS "FARM_READ_UID_UNIQUE_INDEX" ON "FARM_READ" ("UID");
 
+-- FARM CERTIFICATION --
+
+CREATE TABLE IF NOT EXISTS "FARM_CERTIFICATION_EVENT" (
+    "ID" INTEGER PRIMARY KEY,
+    "FARM_CERTIFICATION_UID" BLOB,
+    "VERSION" INTEGER,
+    "CREATED_DATE" TEXT,
+    "EVENT" JSON
+);
+
+CREATE INDEX IF NOT EXISTS "FARM_CERTIFICATION_EVENT_UID_INDEX" ON "FARM_CERTIFICATION_EVENT" ("FARM_CERTIFICATION_UID");
+
+CREATE TABLE IF NOT EXISTS "FARM_CERTIFICATION_READ" (
+    "UID" BLOB PRIMARY KEY,
+    "FARM_UID" BLOB,
+    "CERTIFICATION_TYPE" TEXT,
+    "CERTIFYING_BODY" TEXT,
+    "ISSUED_DATE" TEXT,
+    "EXPIRY_DATE" TEXT,
+    "CERTIFICATE_NUMBER" TEXT,
+    "STATUS" TEXT,
+    "IS_RENEWAL_DUE" BOOLEAN,
+    "REVOKED_DATE" TEXT,
+    "CREATED_DATE" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS "FARM_CERTIFICATION_READ_FARM_UID_INDEX" ON "FARM_CERTIFICATION_READ" ("FARM_UID");
+
+-- FARM BOUNDARY --
+
+CREATE TABLE IF NOT EXISTS "FARM_BOUNDARY_EVENT" (
+    "ID" INTEGER PRIMARY KEY,
+    "FARM_UID" BLOB,
+    "VERSION" INTEGER,
+    "CREATED_DATE" TEXT,
+    "EVENT" JSON
+);
+
+CREATE INDEX IF NOT EXISTS "FARM_BOUNDARY_EVENT_FARM_UID_INDEX" ON "FARM_BOUNDARY_EVENT" ("FARM_UID");
+
+-- EQUIPMENT --
+
+CREATE TABLE IF NOT EXISTS "EQUIPMENT_EVENT" (
+    "ID" INTEGER PRIMARY KEY,
+    "EQUIPMENT_UID" BLOB,
+    "VERSION" INTEGER,
+    "CREATED_DATE" TEXT,
+    "EVENT" JSON
+);
+
+CREATE INDEX IF NOT EXISTS "EQUIPMENT_EVENT_UID_INDEX" ON "EQUIPMENT_EVENT" ("EQUIPMENT_UID");
+
+CREATE TABLE IF NOT EXISTS "EQUIPMENT_READ" (
+    "UID" BLOB PRIMARY KEY,
+    "FARM_UID" BLOB,
+    "NAME" TEXT,
+    "TYPE" TEXT,
+    "LOCATION_TYPE" TEXT,
+    "LOCATION_UID" TEXT,
+    "PURCHASE_DATE" TEXT,
+    "NOTES" TEXT,
+    "MAINTENANCE_INTERVAL_DAYS" INTEGER,
+    "NEXT_MAINTENANCE_DATE" TEXT,
+    "GEO_POINT" TEXT,
+    "IS_DELETED" BOOLEAN,
+    "CREATED_DATE" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS "EQUIPMENT_READ_FARM_UID_INDEX" ON "EQUIPMENT_READ" ("FARM_UID");
+
+-- NUTRIENT RECIPE --
+
+CREATE TABLE IF NOT EXISTS "NUTRIENT_RECIPE_EVENT" (
+    "ID" INTEGER PRIMARY KEY,
+    "NUTRIENT_RECIPE_UID" BLOB,
+    "VERSION" INTEGER,
+    "CREATED_DATE" TEXT,
+    "EVENT" JSON
+);
+
+CREATE INDEX IF NOT EXISTS "NUTRIENT_RECIPE_EVENT_UID_INDEX" ON "NUTRIENT_RECIPE_EVENT" ("NUTRIENT_RECIPE_UID");
+
+CREATE TABLE IF NOT EXISTS "NUTRIENT_RECIPE_READ" (
+    "UID" BLOB PRIMARY KEY,
+    "FARM_UID" BLOB,
+    "NAME" TEXT,
+    "TARGET_EC" REAL,
+    "TARGET_PH" REAL,
+    "INGREDIENTS" TEXT,
+    "IS_DELETED" BOOLEAN,
+    "CREATED_DATE" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS "NUTRIENT_RECIPE_READ_FARM_UID_INDEX" ON "NUTRIENT_RECIPE_READ" ("FARM_UID");
+
+-- CUSTOM FIELD DEFINITION --
+
+CREATE TABLE IF NOT EXISTS "CUSTOM_FIELD_DEFINITION_EVENT" (
+    "ID" INTEGER PRIMARY KEY,
+    "CUSTOM_FIELD_DEFINITION_UID" BLOB,
+    "VERSION" INTEGER,
+    "CREATED_DATE" TEXT,
+    "EVENT" JSON
+);
+
+CREATE INDEX IF NOT EXISTS "CUSTOM_FIELD_DEFINITION_EVENT_UID_INDEX" ON "CUSTOM_FIELD_DEFINITION_EVENT" ("CUSTOM_FIELD_DEFINITION_UID");
+
+CREATE TABLE IF NOT EXISTS "CUSTOM_FIELD_DEFINITION_READ" (
+    "UID" BLOB PRIMARY KEY,
+    "FARM_UID" BLOB,
+    "ENTITY_TYPE" TEXT,
+    "FIELD_KEY" TEXT,
+    "FIELD_TYPE" TEXT,
+    "IS_REQUIRED" BOOLEAN,
+    "IS_DELETED" BOOLEAN,
+    "CREATED_DATE" TEXT,
+    "LABEL" TEXT,
+    "OPTIONS" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS "CUSTOM_FIELD_DEFINITION_READ_FARM_UID_INDEX" ON "CUSTOM_FIELD_DEFINITION_READ" ("FARM_UID");
+
+CREATE TABLE IF NOT EXISTS "CUSTOM_FIELD_VALUE_EVENT" (
+    "ID" INTEGER PRIMARY KEY,
+    "ENTITY_UID" BLOB,
+    "VERSION" INTEGER,
+    "CREATED_DATE" TEXT,
+    "EVENT" JSON
+);
+
+CREATE INDEX IF NOT EXISTS "CUSTOM_FIELD_VALUE_EVENT_ENTITY_UID_INDEX" ON "CUSTOM_FIELD_VALUE_EVENT" ("ENTITY_UID");
+
+CREATE TABLE IF NOT EXISTS "CUSTOM_FIELD_VALUE_READ" (
+    "ENTITY_UID" BLOB PRIMARY KEY,
+    "FARM_UID" BLOB,
+    "ENTITY_TYPE" TEXT,
+    "FIELD_VALUES" TEXT,
+    "UPDATED_DATE" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS "CUSTOM_FIELD_VALUE_READ_FARM_UID_INDEX" ON "CUSTOM_FIELD_VALUE_READ" ("FARM_UID");
+
 -- AREA --
 
 CREATE TABLE IF NOT EXISTS "AREA_EVENT" (
@@ -52,7 +199,13 @@ CREATE TABLE IF NOT EXISTS "AREA_READ" (
     "RESERVOIR_UID" BLOB,
     "RESERVOIR_NAME" TEXT,
     "FARM_UID" BLOB,
-    "FARM_NAME" TEXT
+    "FARM_NAME" TEXT,
+    "CUSTOM_FIELDS" TEXT,
+    "LAYOUT" TEXT,
+    "BED_MAP" TEXT,
+    "GEO_POINT" TEXT,
+    "SANITATIONS" TEXT,
+    "LAST_SANITIZED_DATE" TEXT
 );
 
 CREATE UNIQUE INDEX IF NOT EXISTS "AREA_READ_UID_UNIQUE_INDEX" ON "AREA_READ" ("UID");
@@ -129,11 +282,36 @@ CREATE TABLE IF NOT EXISTS "MATERIAL_READ" (
     "EXPIRATION_DATE" TEXT,
     "NOTES" TEXT,
     "PRODUCED_BY" TEXT,
-    "CREATED_DATE" TEXT
+    "CREATED_DATE" TEXT,
+    "GDD_TO_MATURITY" REAL,
+    "PRE_HARVEST_INTERVAL_DAYS" INTEGER
 );
 
 CREATE INDEX IF NOT EXISTS "MATERIAL_READ_UID_UNIQUE_INDEX" ON "MATERIAL_READ" ("UID");
 
+-- STOCKTAKE --
+
+CREATE TABLE IF NOT EXISTS "STOCKTAKE_EVENT" (
+    "ID" INTEGER PRIMARY KEY,
+    "STOCKTAKE_UID" BLOB,
+    "VERSION" INTEGER,
+    "CREATED_DATE" TEXT,
+    "EVENT" JSON
+);
+
+CREATE INDEX IF NOT EXISTS "STOCKTAKE_EVENT_STOCKTAKE_UID_INDEX" ON "STOCKTAKE_EVENT" ("STOCKTAKE_UID");
+
+CREATE TABLE IF NOT EXISTS "STOCKTAKE_READ" (
+    "UID" BLOB PRIMARY KEY,
+    "FARM_UID" BLOB,
+    "STATUS" TEXT,
+    "LINES" TEXT,
+    "OPENED_DATE" TEXT,
+    "CLOSED_DATE" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS "STOCKTAKE_READ_FARM_UID_INDEX" ON "STOCKTAKE_READ" ("FARM_UID");
+
 -- CROP --
 
 CREATE TABLE IF NOT EXISTS "CROP_EVENT" (
@@ -171,9 +349,16 @@ CREATE TABLE IF NOT EXISTS "CROP_READ" (
     "INITIAL_AREA_LAST_PESTICIDED" TEXT,
     "INITIAL_AREA_LAST_PRUNED" TEXT,
     "INITIAL_AREA_CREATED_DATE" TEXT,
-    "INITIAL_AREA_LAST_UPDATED" TEXT
+    "INITIAL_AREA_LAST_UPDATED" TEXT,
+    "SHORT_CODE" TEXT,
+    "SEEDS_SOWN" INTEGER,
+    "GERMINATED_QUANTITY" INTEGER,
+    "GERMINATION_DATE" TEXT,
+    "MERGED_INTO_UID" BLOB
 );
 
+CREATE INDEX IF NOT EXISTS "CROP_READ_INITIAL_AREA_UID_INDEX" ON "CROP_READ" ("INITIAL_AREA_UID");
+
 CREATE TABLE IF NOT EXISTS "CROP_READ_PHOTO" (
     "UID" BLOB PRIMARY KEY,
     "CROP_UID" BLOB,
@@ -183,6 +368,9 @@ CREATE TABLE IF NOT EXISTS "CROP_READ_PHOTO" (
     "WIDTH" INTEGER,
     "HEIGHT" INTEGER,
     "DESCRIPTION" TEXT,
+    "STATUS" TEXT,
+    "THUMBNAIL_FILENAME" TEXT,
+    "METADATA" TEXT,
     FOREIGN KEY("CROP_UID") REFERENCES "CROP_READ"("UID")
 );
 
@@ -202,6 +390,21 @@ CREATE TABLE IF NOT EXISTS "CROP_READ_MOVED_AREA" (
     FOREIGN KEY("CROP_UID") REFERENCES "CROP_READ"("UID")
 );
 
+CREATE INDEX IF NOT EXISTS "CROP_READ_MOVED_AREA_AREA_UID_INDEX" ON "CROP_READ_MOVED_AREA" ("AREA_UID");
+
+CREATE TABLE IF NOT EXISTS "AREA_CROP_CURRENT" (
+    "ID" INTEGER PRIMARY KEY,
+    "AREA_UID" BLOB,
+    "CROP_UID" BLOB,
+    "FARM_UID" BLOB,
+    "QUANTITY" INTEGER,
+    "PLACED_DATE" TEXT,
+    "LAST_UPDATED" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS "AREA_CROP_CURRENT_AREA_UID_INDEX" ON "AREA_CROP_CURRENT" ("AREA_UID");
+CREATE INDEX IF NOT EXISTS "AREA_CROP_CURRENT_CROP_UID_INDEX" ON "AREA_CROP_CURRENT" ("CROP_UID");
+
 CREATE TABLE IF NOT EXISTS "CROP_READ_HARVESTED_STORAGE" (
     "ID" INTEGER PRIMARY KEY,
     "CROP_UID" BLOB,
@@ -225,6 +428,19 @@ CREATE TABLE IF NOT EXISTS "CROP_READ_TRASH" (
     FOREIGN KEY("CROP_UID") REFERENCES "CROP_READ"("UID")
 );
 
+CREATE TABLE IF NOT EXISTS "CROP_READ_NURSERY_STAGE" (
+    "UID" BLOB PRIMARY KEY,
+    "CROP_UID" BLOB,
+    "AREA_UID" BLOB,
+    "AREA_NAME" TEXT,
+    "START_DATE" TEXT,
+    "EXPECTED_TRANSPLANT_DATE" TEXT,
+    "ACTUAL_TRANSPLANT_DATE" TEXT,
+    FOREIGN KEY("CROP_UID") REFERENCES "CROP_READ"("UID")
+);
+
+CREATE INDEX IF NOT EXISTS "CROP_READ_NURSERY_STAGE_CROP_UID_INDEX" ON "CROP_READ_NURSERY_STAGE" ("CROP_UID");
+
 CREATE TABLE IF NOT EXISTS "CROP_READ_NOTES" (
     "UID" BLOB PRIMARY KEY,
     "CROP_UID" BLOB,
@@ -249,6 +465,126 @@ CREATE TABLE IF NOT EXISTS "CROP_ACTIVITY" (
     FOREIGN KEY("CROP_UID") REFERENCES "CROP_READ"("UID")
 );
 
+CREATE TABLE IF NOT EXISTS "CROP_INPUT_SCHEDULE_EVENT" (
+    "ID" INTEGER PRIMARY KEY,
+    "CROP_INPUT_SCHEDULE_UID" BLOB,
+    "VERSION" INTEGER,
+    "CREATED_DATE" TEXT,
+    "EVENT" BLOB
+);
+
+CREATE INDEX IF NOT EXISTS "CROP_INPUT_SCHEDULE_EVENT_UID_INDEX" ON "CROP_INPUT_SCHEDULE_EVENT" ("CROP_INPUT_SCHEDULE_UID");
+
+CREATE TABLE IF NOT EXISTS "CROP_INPUT_SCHEDULE_READ" (
+    "UID" BLOB PRIMARY KEY,
+    "CROP_UID" BLOB,
+    "MATERIAL_UID" BLOB,
+    "PLANNED_DATE" TEXT,
+    "QUANTITY" REAL,
+    "UNIT" TEXT,
+    "APPLICATION_METHOD" TEXT,
+    "STATUS" TEXT,
+    "TASK_UID" BLOB,
+    "APPLIED_DATE" TEXT,
+    "CREATED_DATE" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS "CROP_INPUT_SCHEDULE_READ_CROP_UID_INDEX" ON "CROP_INPUT_SCHEDULE_READ" ("CROP_UID");
+CREATE INDEX IF NOT EXISTS "CROP_INPUT_SCHEDULE_READ_TASK_UID_INDEX" ON "CROP_INPUT_SCHEDULE_READ" ("TASK_UID");
+
+CREATE TABLE IF NOT EXISTS "DISPATCH_SCHEDULE_EVENT" (
+    "ID" INTEGER PRIMARY KEY,
+    "DISPATCH_SCHEDULE_UID" BLOB,
+    "VERSION" INTEGER,
+    "CREATED_DATE" TEXT,
+    "EVENT" BLOB
+);
+
+CREATE INDEX IF NOT EXISTS "DISPATCH_SCHEDULE_EVENT_UID_INDEX" ON "DISPATCH_SCHEDULE_EVENT" ("DISPATCH_SCHEDULE_UID");
+
+CREATE TABLE IF NOT EXISTS "DISPATCH_SCHEDULE_READ" (
+    "UID" BLOB PRIMARY KEY,
+    "FARM_UID" BLOB,
+    "HARVEST_IDS" TEXT,
+    "VEHICLE_ID" TEXT,
+    "DRIVER_NAME" TEXT,
+    "PICKUP_TIME" TEXT,
+    "DESTINATION_ADDRESS" TEXT,
+    "ESTIMATED_DELIVERY_TIME" TEXT,
+    "STATUS" TEXT,
+    "TASK_UID" BLOB,
+    "COMPLETED_DATE" TEXT,
+    "CREATED_DATE" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS "DISPATCH_SCHEDULE_READ_FARM_UID_INDEX" ON "DISPATCH_SCHEDULE_READ" ("FARM_UID");
+CREATE INDEX IF NOT EXISTS "DISPATCH_SCHEDULE_READ_TASK_UID_INDEX" ON "DISPATCH_SCHEDULE_READ" ("TASK_UID");
+
+CREATE TABLE IF NOT EXISTS "INSURANCE_POLICY_EVENT" (
+    "ID" INTEGER PRIMARY KEY,
+    "INSURANCE_POLICY_UID" BLOB,
+    "VERSION" INTEGER,
+    "CREATED_DATE" TEXT,
+    "EVENT" BLOB
+);
+
+CREATE INDEX IF NOT EXISTS "INSURANCE_POLICY_EVENT_UID_INDEX" ON "INSURANCE_POLICY_EVENT" ("INSURANCE_POLICY_UID");
+
+CREATE TABLE IF NOT EXISTS "INSURANCE_POLICY_READ" (
+    "UID" BLOB PRIMARY KEY,
+    "FARM_UID" BLOB,
+    "POLICY_NUMBER" TEXT,
+    "PROVIDER" TEXT,
+    "CROP_IDS" TEXT,
+    "COVERAGE_AMOUNT" REAL,
+    "CURRENCY" TEXT,
+    "PREMIUM_AMOUNT" REAL,
+    "START_DATE" TEXT,
+    "END_DATE" TEXT,
+    "CLAIMS" TEXT,
+    "IS_DELETED" BOOLEAN,
+    "CREATED_DATE" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS "INSURANCE_POLICY_READ_FARM_UID_INDEX" ON "INSURANCE_POLICY_READ" ("FARM_UID");
+
+CREATE TABLE IF NOT EXISTS "HARVEST_LOT_EVENT" (
+    "ID" INTEGER PRIMARY KEY,
+    "HARVEST_LOT_UID" BLOB,
+    "VERSION" INTEGER,
+    "CREATED_DATE" TEXT,
+    "EVENT" BLOB
+);
+
+CREATE INDEX IF NOT EXISTS "HARVEST_LOT_EVENT_UID_INDEX" ON "HARVEST_LOT_EVENT" ("HARVEST_LOT_UID");
+
+CREATE TABLE IF NOT EXISTS "HARVEST_LOT_READ" (
+    "UID" BLOB PRIMARY KEY,
+    "FARM_UID" BLOB,
+    "LOT_NUMBER" TEXT,
+    "CROP_UID" BLOB,
+    "BATCH_ID" TEXT,
+    "AREA_UID" BLOB,
+    "HARVEST_DATE" TEXT,
+    "GRADE" TEXT,
+    "GRAM_QUANTITY" REAL,
+    "REMAINING_GRAM_QUANTITY" REAL,
+    "STATUS" TEXT,
+    "SHIPMENTS" TEXT,
+    "CREATED_DATE" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS "HARVEST_LOT_READ_FARM_UID_INDEX" ON "HARVEST_LOT_READ" ("FARM_UID");
+
+CREATE TABLE IF NOT EXISTS "MICROCLIMATE_SAMPLE" (
+    "UID" BLOB PRIMARY KEY,
+    "AREA_UID" BLOB,
+    "TEMPERATURE" REAL,
+    "RECORDED_DATE" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS "MICROCLIMATE_SAMPLE_AREA_UID_INDEX" ON "MICROCLIMATE_SAMPLE" ("AREA_UID");
+
 -- TASK --
 
 CREATE TABLE IF NOT EXISTS "TASK_EVENT" (
@@ -276,10 +612,68 @@ CREATE TABLE IF NOT EXISTS "TASK_READ" (
     "DOMAIN_DATA_AREA_ID" TEXT,
     "CATEGORY" TEXT,
     "IS_DUE" BOOLEAN,
-    "ASSET_ID" TEXT
+    "ASSET_ID" TEXT,
+    "SHORT_CODE" TEXT,
+    "COST_CENTRE_ID" TEXT,
+    "COMPLETED_BY" TEXT,
+    "LABOUR_MINUTES" INTEGER,
+    "MATERIAL_QUANTITY" REAL,
+    "ESTIMATED_MINUTES" INTEGER,
+    "DEPENDS_ON" TEXT,
+    "AFFECTED_AREA_IDS" TEXT,
+    "PER_AREA_PROGRESS" TEXT,
+    "DUE_DATE_ADJUSTED" BOOLEAN,
+    "TARGET_QUANTITY" REAL,
+    "TARGET_UNIT" TEXT,
+    "COMPLETED_QUANTITY" REAL,
+    "RECURRENCE" TEXT,
+    "RECURRENCE_OF" TEXT,
+    "SHORT_CODE_FARM_UID" TEXT
 );
 
 CREATE INDEX IF NOT EXISTS "TASK_READ_UID_UNIQUE_INDEX" ON "TASK_READ" ("UID");
+CREATE INDEX IF NOT EXISTS "TASK_READ_ASSET_ID_INDEX" ON "TASK_READ" ("ASSET_ID");
+CREATE UNIQUE INDEX IF NOT EXISTS "TASK_READ_SHORT_CODE_UNIQUE_INDEX" ON "TASK_READ" ("SHORT_CODE_FARM_UID", "SHORT_CODE");
+
+CREATE TABLE IF NOT EXISTS "TASK_READ_AREA" (
+    "TASK_UID" TEXT,
+    "AREA_UID" TEXT,
+    PRIMARY KEY ("TASK_UID", "AREA_UID")
+);
+
+CREATE INDEX IF NOT EXISTS "TASK_READ_AREA_AREA_UID_INDEX" ON "TASK_READ_AREA" ("AREA_UID");
+
+-- TASK TEMPLATE --
+
+CREATE TABLE IF NOT EXISTS "TASK_TEMPLATE_EVENT" (
+    "ID" INTEGER PRIMARY KEY,
+    "TASK_TEMPLATE_UID" BLOB,
+    "VERSION" INTEGER,
+    "CREATED_DATE" TEXT,
+    "EVENT" BLOB
+);
+
+CREATE INDEX IF NOT EXISTS "TASK_TEMPLATE_EVENT_TASK_TEMPLATE_UID_INDEX" ON "TASK_TEMPLATE_EVENT" ("TASK_TEMPLATE_UID");
+
+CREATE TABLE IF NOT EXISTS "TASK_TEMPLATE_READ" (
+    "UID" BLOB PRIMARY KEY,
+    "NAME" TEXT,
+    "PARENT_TEMPLATE_UID" BLOB,
+    "ITEMS" JSON,
+    "CREATED_DATE" TEXT
+);
+
+-- TASK CATALOG --
+
+CREATE TABLE IF NOT EXISTS "TASK_CATALOG_EVENT" (
+    "ID" INTEGER PRIMARY KEY,
+    "FARM_UID" BLOB,
+    "VERSION" INTEGER,
+    "CREATED_DATE" TEXT,
+    "EVENT" BLOB
+);
+
+CREATE INDEX IF NOT EXISTS "TASK_CATALOG_EVENT_FARM_UID_INDEX" ON "TASK_CATALOG_EVENT" ("FARM_UID");
 
 -- USER --
 
@@ -298,7 +692,9 @@ CREATE TABLE IF NOT EXISTS "USER_READ" (
     "USERNAME" TEXT,
     "PASSWORD" BLOB,
     "CREATED_DATE" TEXT,
-    "LAST_UPDATED" TEXT
+    "LAST_UPDATED" TEXT,
+    "HOURLY_RATE" REAL,
+    "ROLE" TEXT
 );
 
 CREATE INDEX IF NOT EXISTS "USER_READ_UID_UNIQUE_INDEX" ON "USER_READ" ("UID");
@@ -312,4 +708,172 @@ CREATE TABLE IF NOT EXISTS "USER_AUTH" (
 );
 
 CREATE UNIQUE INDEX IF NOT EXISTS "USER_AUTH_USER_UID_UNIQUE_INDEX" ON "USER_AUTH" ("USER_UID");
-CREATE UNIQUE INDEX IF NOT EXISTS "USER_AUTH_ACCESS_TOKEN_UNIQUE_INDEX" ON "USER_AUTH" ("ACCESS_TOKEN");
\ No newline at end of file
+CREATE UNIQUE INDEX IF NOT EXISTS "USER_AUTH_ACCESS_TOKEN_UNIQUE_INDEX" ON "USER_AUTH" ("ACCESS_TOKEN");
+
+-- SHORT CODE --
+
+CREATE TABLE IF NOT EXISTS "SHORT_CODE_SEQUENCE" (
+    "PREFIX" TEXT,
+    "SCOPE_UID" BLOB,
+    "LAST_VALUE" INTEGER,
+    PRIMARY KEY("PREFIX", "SCOPE_UID")
+);
+
+-- RETENTION --
+
+CREATE TABLE IF NOT EXISTS "PRUNED_AGGREGATE" (
+    "AGGREGATE" TEXT,
+    "UID" BLOB,
+    "ARCHIVE_FILE" TEXT,
+    "LAST_EVENT_DATE" TEXT,
+    "PRUNED_DATE" TEXT,
+    PRIMARY KEY("AGGREGATE", "UID")
+);
+
+-- CHANGE FEED --
+
+CREATE TABLE IF NOT EXISTS "CHANGE_LOG" (
+    "SEQUENCE" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "FARM_UID" BLOB,
+    "ENTITY_TYPE" TEXT NOT NULL,
+    "ENTITY_UID" BLOB NOT NULL,
+    "CHANGE_TYPE" TEXT NOT NULL,
+    "CREATED_DATE" TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "CHANGE_LOG_FARM_UID_SEQUENCE_INDEX" ON "CHANGE_LOG" ("FARM_UID", "SEQUENCE");
+CREATE INDEX IF NOT EXISTS "CHANGE_LOG_ENTITY_UID_INDEX" ON "CHANGE_LOG" ("ENTITY_UID");
+CREATE INDEX IF NOT EXISTS "CHANGE_LOG_FARM_UID_CREATED_DATE_INDEX" ON "CHANGE_LOG" ("FARM_UID", "CREATED_DATE");
+
+-- REPORT MAIL --
+
+CREATE TABLE IF NOT EXISTS "REPORT_SUBSCRIPTION" (
+    "UID" BLOB PRIMARY KEY,
+    "FARM_UID" BLOB NOT NULL,
+    "REPORT_TYPE" TEXT NOT NULL,
+    "FORMAT" TEXT NOT NULL,
+    "DAY_OF_WEEK" TEXT NOT NULL,
+    "TIME_OF_DAY" TEXT NOT NULL,
+    "TIMEZONE" TEXT NOT NULL,
+    "RECIPIENTS" TEXT NOT NULL,
+    "CREATED_DATE" TEXT NOT NULL,
+    "NEXT_RUN_DATE" TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "REPORT_SUBSCRIPTION_FARM_UID_INDEX" ON "REPORT_SUBSCRIPTION" ("FARM_UID");
+CREATE INDEX IF NOT EXISTS "REPORT_SUBSCRIPTION_NEXT_RUN_DATE_INDEX" ON "REPORT_SUBSCRIPTION" ("NEXT_RUN_DATE");
+
+CREATE TABLE IF NOT EXISTS "REPORT_DELIVERY" (
+    "UID" BLOB PRIMARY KEY,
+    "SUBSCRIPTION_UID" BLOB NOT NULL,
+    "FARM_UID" BLOB NOT NULL,
+    "SCHEDULED_DATE" TEXT NOT NULL,
+    "IS_MANUAL" BOOLEAN,
+    "STATUS" TEXT NOT NULL,
+    "ATTEMPTS" INTEGER,
+    "LAST_ERROR" TEXT,
+    "NEXT_ATTEMPT_DATE" TEXT,
+    "SENT_DATE" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS "REPORT_DELIVERY_SUBSCRIPTION_UID_INDEX" ON "REPORT_DELIVERY" ("SUBSCRIPTION_UID");
+CREATE INDEX IF NOT EXISTS "REPORT_DELIVERY_STATUS_NEXT_ATTEMPT_DATE_INDEX" ON "REPORT_DELIVERY" ("STATUS", "NEXT_ATTEMPT_DATE");
+
+-- ENERGY --
+
+CREATE TABLE IF NOT EXISTS "ENERGY_READING" (
+    "UID" BLOB PRIMARY KEY,
+    "FARM_UID" BLOB NOT NULL,
+    "METER_ID" TEXT NOT NULL,
+    "KWH" REAL NOT NULL,
+    "RECORDED_AT" TEXT NOT NULL,
+    "TARIFF_RATE" REAL NOT NULL,
+    "CURRENCY" TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "ENERGY_READING_FARM_UID_RECORDED_AT_INDEX" ON "ENERGY_READING" ("FARM_UID", "RECORDED_AT");
+
+-- ENVIRONMENT ALERTS --
+
+CREATE TABLE IF NOT EXISTS "ENVIRONMENT_ALERT_RULE" (
+    "UID" BLOB PRIMARY KEY,
+    "AREA_UID" BLOB NOT NULL,
+    "KIND" TEXT NOT NULL,
+    "DIRECTION" TEXT NOT NULL,
+    "THRESHOLD" REAL NOT NULL,
+    "WINDOW_MINUTES" INTEGER NOT NULL,
+    "HYSTERESIS" REAL NOT NULL,
+    "PRIORITY" TEXT NOT NULL,
+    "IS_TRIGGERED" BOOLEAN,
+    "CREATED_DATE" TEXT NOT NULL
+);
+
+-- CROP PHOTO HASHES --
+
+CREATE TABLE IF NOT EXISTS "CROP_PHOTO_HASH" (
+    "PHOTO_UID" BLOB PRIMARY KEY,
+    "CROP_UID" BLOB NOT NULL,
+    "HASH" TEXT NOT NULL,
+    "CREATED_DATE" TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "CROP_PHOTO_HASH_CROP_UID_INDEX" ON "CROP_PHOTO_HASH" ("CROP_UID");
+
+-- FARM SIGNING KEYS --
+
+CREATE TABLE IF NOT EXISTS "FARM_SIGNING_KEY" (
+    "FARM_UID" BLOB PRIMARY KEY,
+    "PRIVATE_KEY" TEXT NOT NULL,
+    "CREATED_DATE" TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "PRESENCE" (
+    "FARM_UID" BLOB NOT NULL,
+    "KIND" TEXT NOT NULL,
+    "IDENTITY" TEXT NOT NULL,
+    "NAME" TEXT NOT NULL,
+    "FIRST_SEEN" TEXT NOT NULL,
+    "LAST_SEEN" TEXT NOT NULL,
+    PRIMARY KEY ("FARM_UID", "KIND", "IDENTITY")
+);
+
+CREATE TABLE IF NOT EXISTS "WEBHOOK_DELIVERY" (
+    "UID" BLOB PRIMARY KEY,
+    "WEBHOOK_ID" TEXT NOT NULL,
+    "EVENT_TYPE" TEXT NOT NULL,
+    "PAYLOAD" TEXT NOT NULL,
+    "REPLAY_OF_UID" BLOB NULL,
+    "STATUS" TEXT NOT NULL,
+    "STATUS_CODE" INTEGER NOT NULL,
+    "ERROR" TEXT NOT NULL,
+    "CREATED_DATE" TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "WEBHOOK_DELIVERY_WEBHOOK_ID_INDEX" ON "WEBHOOK_DELIVERY" ("WEBHOOK_ID", "CREATED_DATE");
+
+CREATE TABLE IF NOT EXISTS "IDEMPOTENCY_RECORD" (
+    "IDEMPOTENCY_KEY" TEXT PRIMARY KEY,
+    "REQUEST_HASH" TEXT NOT NULL,
+    "COMPLETED" INTEGER NOT NULL,
+    "STATUS_CODE" INTEGER NOT NULL,
+    "CONTENT_TYPE" TEXT NOT NULL,
+    "BODY" BLOB NOT NULL,
+    "CREATED_DATE" TEXT NOT NULL,
+    "EXPIRES_DATE" TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "IDEMPOTENCY_RECORD_EXPIRES_DATE_INDEX" ON "IDEMPOTENCY_RECORD" ("EXPIRES_DATE");
+
+CREATE TABLE IF NOT EXISTS "MAINTENANCE_MODE" (
+    "ID" INTEGER PRIMARY KEY,
+    "REASON" TEXT NOT NULL,
+    "STARTED_DATE" TEXT NOT NULL,
+    "ENDS_AT" TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "DEVICE_REGISTRY" (
+    "ID" TEXT PRIMARY KEY,
+    "NAME" TEXT NOT NULL,
+    "SECRET" TEXT NOT NULL,
+    "CREATED_DATE" TEXT NOT NULL
+);
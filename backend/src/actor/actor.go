@@ -0,0 +1,61 @@
+// Package actor tells who triggered the events: the user or the API key of the request, or the background job,
+// with the source it came from. The actor is kept on the envelope of the stored events, next to their version.
+package actor
+
+import (
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/farmscope"
+)
+
+// The sources of the actors. The clients tell theirs with the HeaderSource header, the web one without it.
+const (
+	SourceWeb    = "web"
+	SourceMobile = "mobile"
+	SourceMQTT   = "mqtt"
+	SourceSystem = "system"
+)
+
+const (
+	// HeaderSource is the header the clients tell their source with, web, mobile or mqtt.
+	HeaderSource = "X-Tania-Source"
+
+	// APIKeyKey is the context key of the ID of the API key the request is authenticated with.
+	APIKeyKey = "API_KEY_ID"
+)
+
+// Actor triggered an event. The requests are triggered by a user or an API key, the background jobs by the
+// system with the name of the job. The events stored before the actors were recorded have none.
+type Actor struct {
+	UserUID  *uuid.UUID `json:"user_id,omitempty"`
+	APIKeyID string     `json:"api_key_id,omitempty"`
+	Job      string     `json:"job,omitempty"`
+	Source   string     `json:"source"`
+}
+
+// FromContext is the actor of the request, its user or its API key.
+func FromContext(c echo.Context) *Actor {
+	a := &Actor{Source: SourceWeb}
+
+	if userUID, ok := c.Get(farmscope.UserKey).(uuid.UUID); ok {
+		a.UserUID = &userUID
+	}
+
+	if keyID, ok := c.Get(APIKeyKey).(string); ok {
+		a.APIKeyID = keyID
+	}
+
+	switch source := strings.ToLower(strings.TrimSpace(c.Request().Header.Get(HeaderSource))); source {
+	case SourceMobile, SourceMQTT:
+		a.Source = source
+	}
+
+	return a
+}
+
+// System is the actor of the background job, like the scheduler or a subscriber reacting to another event.
+func System(job string) *Actor {
+	return &Actor{Job: job, Source: SourceSystem}
+}
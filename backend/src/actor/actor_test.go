@@ -0,0 +1,60 @@
+package actor_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/farmscope"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+	// Given
+	e := echo.New()
+	userUID, _ := uuid.NewV4()
+
+	web := e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), httptest.NewRecorder())
+	web.Set(farmscope.UserKey, userUID)
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(actor.HeaderSource, " MQTT ")
+	mqtt := e.NewContext(req, httptest.NewRecorder())
+	mqtt.Set(actor.APIKeyKey, "K3Y")
+
+	req = httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(actor.HeaderSource, "system")
+	unknown := e.NewContext(req, httptest.NewRecorder())
+
+	// When
+	webActor := actor.FromContext(web)
+	mqttActor := actor.FromContext(mqtt)
+	unknownActor := actor.FromContext(unknown)
+
+	// Then
+	assert.Equal(t, &userUID, webActor.UserUID)
+	assert.Equal(t, actor.SourceWeb, webActor.Source)
+
+	assert.Nil(t, mqttActor.UserUID)
+	assert.Equal(t, "K3Y", mqttActor.APIKeyID)
+	assert.Equal(t, actor.SourceMQTT, mqttActor.Source)
+
+	// The requests cannot pass themselves off as a background job.
+	assert.Equal(t, actor.SourceWeb, unknownActor.Source)
+}
+
+func TestSystem(t *testing.T) {
+	t.Parallel()
+	// Given
+	// When
+	a := actor.System("input_scheduler")
+
+	// Then
+	assert.Nil(t, a.UserUID)
+	assert.Equal(t, "input_scheduler", a.Job)
+	assert.Equal(t, actor.SourceSystem, a.Source)
+}
@@ -0,0 +1,180 @@
+// Package areacrops regenerates and checks the AREA_CROP_CURRENT table of the SQL engines, the plants of the
+// active crops by area the crop projection keeps up to date as the crops are seeded, moved, harvested and dumped.
+package areacrops
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/gofrs/uuid"
+	assetsquery "github.com/usetania/tania-core/src/assets/query"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+	growthquery "github.com/usetania/tania-core/src/growth/query"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+)
+
+// Store reads and replaces the rows of the AREA_CROP_CURRENT table.
+type Store interface {
+	FindAll() ([]growthstorage.AreaCropCurrent, error)
+	ReplaceAll(rows []growthstorage.AreaCropCurrent) error
+}
+
+// Crops lists the active crops of every farm.
+type Crops interface {
+	FindAllActive() ([]growthstorage.CropRead, error)
+}
+
+// Mismatch is an area of a crop the table and the derivation from the crops disagree on.
+// The quantity is 0 on the side missing the row.
+type Mismatch struct {
+	AreaUID         uuid.UUID `json:"area_id"`
+	CropUID         uuid.UUID `json:"crop_id"`
+	StoredQuantity  int       `json:"stored_quantity"`
+	DerivedQuantity int       `json:"derived_quantity"`
+}
+
+func (m Mismatch) String() string {
+	return fmt.Sprintf("area %s crop %s: %d plants in the table, %d in the crop",
+		m.AreaUID, m.CropUID, m.StoredQuantity, m.DerivedQuantity)
+}
+
+// Derive returns the rows of the table from scratch, the areas each crop has plants in.
+func Derive(crops []growthstorage.CropRead) []growthstorage.AreaCropCurrent {
+	rows := []growthstorage.AreaCropCurrent{}
+
+	for _, v := range crops {
+		rows = append(rows, growthstorage.AreaCropsCurrent(v)...)
+	}
+
+	return rows
+}
+
+// Compare returns the mismatches between the stored rows and the derived ones, by area then crop.
+func Compare(stored, derived []growthstorage.AreaCropCurrent) []Mismatch {
+	type key struct {
+		areaUID uuid.UUID
+		cropUID uuid.UUID
+	}
+
+	quantities := map[key]*Mismatch{}
+
+	for _, v := range stored {
+		k := key{areaUID: v.AreaUID, cropUID: v.CropUID}
+		if _, ok := quantities[k]; !ok {
+			quantities[k] = &Mismatch{AreaUID: v.AreaUID, CropUID: v.CropUID}
+		}
+
+		quantities[k].StoredQuantity += v.Quantity
+	}
+
+	for _, v := range derived {
+		k := key{areaUID: v.AreaUID, cropUID: v.CropUID}
+		if _, ok := quantities[k]; !ok {
+			quantities[k] = &Mismatch{AreaUID: v.AreaUID, CropUID: v.CropUID}
+		}
+
+		quantities[k].DerivedQuantity += v.Quantity
+	}
+
+	mismatches := []Mismatch{}
+
+	for _, v := range quantities {
+		if v.StoredQuantity != v.DerivedQuantity {
+			mismatches = append(mismatches, *v)
+		}
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		if mismatches[i].AreaUID != mismatches[j].AreaUID {
+			return mismatches[i].AreaUID.String() < mismatches[j].AreaUID.String()
+		}
+
+		return mismatches[i].CropUID.String() < mismatches[j].CropUID.String()
+	})
+
+	return mismatches
+}
+
+// Rebuild replaces the rows of the table by the ones derived from the crops, it returns how many there are.
+func Rebuild(store Store, crops Crops) (int, error) {
+	active, err := crops.FindAllActive()
+	if err != nil {
+		return 0, err
+	}
+
+	rows := Derive(active)
+
+	err = store.ReplaceAll(rows)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(rows), nil
+}
+
+// Check compares the rows of the table with the ones derived from the crops.
+func Check(store Store, crops Crops) ([]Mismatch, error) {
+	stored, err := store.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := crops.FindAllActive()
+	if err != nil {
+		return nil, err
+	}
+
+	return Compare(stored, Derive(active)), nil
+}
+
+// ReadModelCrops lists the active crops of every farm from the crop read model.
+type ReadModelCrops struct {
+	FarmReadQuery assetsquery.FarmRead
+	CropReadQuery growthquery.CropReadQuery
+}
+
+func (r ReadModelCrops) FindAllActive() ([]growthstorage.CropRead, error) {
+	result := <-r.FarmReadQuery.FindAll()
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	farms, ok := result.Result.([]assetsstorage.FarmRead)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	crops := []growthstorage.CropRead{}
+
+	for _, farm := range farms {
+		cropResult := <-r.CropReadQuery.CountAllCropsByFarm(farm.UID, growthdomain.CropActive)
+		if cropResult.Error != nil {
+			return nil, cropResult.Error
+		}
+
+		total, ok := cropResult.Result.(int)
+		if !ok {
+			return nil, errors.New("internal server error. error type assertion")
+		}
+
+		if total == 0 {
+			continue
+		}
+
+		cropResult = <-r.CropReadQuery.FindAllCropsByFarm(farm.UID, growthdomain.CropActive, 1, total)
+		if cropResult.Error != nil {
+			return nil, cropResult.Error
+		}
+
+		active, ok := cropResult.Result.([]growthstorage.CropRead)
+		if !ok {
+			return nil, errors.New("internal server error. error type assertion")
+		}
+
+		crops = append(crops, active...)
+	}
+
+	return crops, nil
+}
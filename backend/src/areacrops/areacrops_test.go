@@ -0,0 +1,108 @@
+package areacrops_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/areacrops"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+)
+
+type storeMock struct {
+	rows []growthstorage.AreaCropCurrent
+}
+
+func (s *storeMock) FindAll() ([]growthstorage.AreaCropCurrent, error) {
+	return s.rows, nil
+}
+
+func (s *storeMock) ReplaceAll(rows []growthstorage.AreaCropCurrent) error {
+	s.rows = rows
+
+	return nil
+}
+
+type cropsMock struct {
+	crops []growthstorage.CropRead
+}
+
+func (c cropsMock) FindAllActive() ([]growthstorage.CropRead, error) {
+	return c.crops, nil
+}
+
+func TestDerive(t *testing.T) {
+	t.Parallel()
+	// Given
+	bedUID, _ := uuid.NewV4()
+	greenhouseUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	archivedUID, _ := uuid.NewV4()
+	seeded := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+	moved := seeded.AddDate(0, 0, 14)
+
+	crops := []growthstorage.CropRead{{
+		UID:         cropUID,
+		Status:      growthdomain.CropActive,
+		InitialArea: growthstorage.InitialArea{AreaUID: bedUID, CurrentQuantity: 4, CreatedDate: seeded},
+		MovedArea: []growthstorage.MovedArea{
+			{AreaUID: greenhouseUID, CurrentQuantity: 0, CreatedDate: moved},
+			{AreaUID: bedUID, CurrentQuantity: 2, CreatedDate: moved, LastUpdated: moved},
+		},
+	}, {
+		UID:         archivedUID,
+		Status:      growthdomain.CropArchived,
+		InitialArea: growthstorage.InitialArea{AreaUID: bedUID, CurrentQuantity: 6, CreatedDate: seeded},
+	}}
+
+	// When
+	rows := Derive(crops)
+
+	// Then
+	assert.Equal(t, []growthstorage.AreaCropCurrent{{
+		AreaUID:     bedUID,
+		CropUID:     cropUID,
+		Quantity:    6,
+		PlacedDate:  seeded,
+		LastUpdated: moved,
+	}}, rows)
+}
+
+func TestRebuildAndCheck(t *testing.T) {
+	t.Parallel()
+	// Given
+	bedUID, _ := uuid.NewV4()
+	greenhouseUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	crops := cropsMock{crops: []growthstorage.CropRead{{
+		UID:         cropUID,
+		Status:      growthdomain.CropActive,
+		InitialArea: growthstorage.InitialArea{AreaUID: bedUID, CurrentQuantity: 4},
+		MovedArea:   []growthstorage.MovedArea{{AreaUID: greenhouseUID, CurrentQuantity: 3}},
+	}}}
+	store := &storeMock{rows: []growthstorage.AreaCropCurrent{
+		{AreaUID: bedUID, CropUID: cropUID, Quantity: 7},
+	}}
+
+	// When
+	mismatches, err := Check(store, crops)
+
+	// Then
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []Mismatch{
+		{AreaUID: bedUID, CropUID: cropUID, StoredQuantity: 7, DerivedQuantity: 4},
+		{AreaUID: greenhouseUID, CropUID: cropUID, StoredQuantity: 0, DerivedQuantity: 3},
+	}, mismatches)
+
+	// When
+	total, err := Rebuild(store, crops)
+	mismatches, checkErr := Check(store, crops)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 2, total)
+	assert.Nil(t, checkErr)
+	assert.Empty(t, mismatches)
+}
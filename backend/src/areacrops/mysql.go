@@ -0,0 +1,85 @@
+package areacrops
+
+import (
+	"database/sql"
+
+	"github.com/gofrs/uuid"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+)
+
+type StoreMysql struct {
+	DB *sql.DB
+}
+
+func NewStoreMysql(db *sql.DB) Store {
+	return &StoreMysql{DB: db}
+}
+
+func (s *StoreMysql) FindAll() ([]growthstorage.AreaCropCurrent, error) {
+	rows, err := s.DB.Query(`SELECT AREA_UID, CROP_UID, FARM_UID, QUANTITY, PLACED_DATE, LAST_UPDATED
+		FROM AREA_CROP_CURRENT`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []growthstorage.AreaCropCurrent{}
+
+	for rows.Next() {
+		var areaUID, cropUID, farmUID []byte
+
+		row := growthstorage.AreaCropCurrent{}
+
+		err := rows.Scan(&areaUID, &cropUID, &farmUID, &row.Quantity, &row.PlacedDate, &row.LastUpdated)
+		if err != nil {
+			return nil, err
+		}
+
+		row.AreaUID, err = uuid.FromBytes(areaUID)
+		if err != nil {
+			return nil, err
+		}
+
+		row.CropUID, err = uuid.FromBytes(cropUID)
+		if err != nil {
+			return nil, err
+		}
+
+		row.FarmUID, err = uuid.FromBytes(farmUID)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+func (s *StoreMysql) ReplaceAll(rows []growthstorage.AreaCropCurrent) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM AREA_CROP_CURRENT`)
+	if err != nil {
+		tx.Rollback()
+
+		return err
+	}
+
+	for _, v := range rows {
+		_, err = tx.Exec(`INSERT INTO AREA_CROP_CURRENT
+			(AREA_UID, CROP_UID, FARM_UID, QUANTITY, PLACED_DATE, LAST_UPDATED)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			v.AreaUID.Bytes(), v.CropUID.Bytes(), v.FarmUID.Bytes(), v.Quantity, v.PlacedDate, v.LastUpdated)
+		if err != nil {
+			tx.Rollback()
+
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,97 @@
+package areacrops
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+)
+
+type StoreSqlite struct {
+	DB *sql.DB
+}
+
+func NewStoreSqlite(db *sql.DB) Store {
+	return &StoreSqlite{DB: db}
+}
+
+func (s *StoreSqlite) FindAll() ([]growthstorage.AreaCropCurrent, error) {
+	rows, err := s.DB.Query(`SELECT AREA_UID, CROP_UID, FARM_UID, QUANTITY, PLACED_DATE, LAST_UPDATED
+		FROM AREA_CROP_CURRENT`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []growthstorage.AreaCropCurrent{}
+
+	for rows.Next() {
+		var areaUID, cropUID, farmUID, placedDate, lastUpdated string
+
+		row := growthstorage.AreaCropCurrent{}
+
+		err := rows.Scan(&areaUID, &cropUID, &farmUID, &row.Quantity, &placedDate, &lastUpdated)
+		if err != nil {
+			return nil, err
+		}
+
+		row.AreaUID, err = uuid.FromString(areaUID)
+		if err != nil {
+			return nil, err
+		}
+
+		row.CropUID, err = uuid.FromString(cropUID)
+		if err != nil {
+			return nil, err
+		}
+
+		row.FarmUID, err = uuid.FromString(farmUID)
+		if err != nil {
+			return nil, err
+		}
+
+		row.PlacedDate, err = time.Parse(time.RFC3339, placedDate)
+		if err != nil {
+			return nil, err
+		}
+
+		row.LastUpdated, err = time.Parse(time.RFC3339, lastUpdated)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+func (s *StoreSqlite) ReplaceAll(rows []growthstorage.AreaCropCurrent) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM AREA_CROP_CURRENT`)
+	if err != nil {
+		tx.Rollback()
+
+		return err
+	}
+
+	for _, v := range rows {
+		_, err = tx.Exec(`INSERT INTO AREA_CROP_CURRENT
+			(AREA_UID, CROP_UID, FARM_UID, QUANTITY, PLACED_DATE, LAST_UPDATED)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			v.AreaUID, v.CropUID, v.FarmUID, v.Quantity,
+			v.PlacedDate.Format(time.RFC3339), v.LastUpdated.Format(time.RFC3339))
+		if err != nil {
+			tx.Rollback()
+
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -19,6 +19,8 @@ func (w *AreaEventWrapper) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	w.Actor = wrapper.Actor
+
 	mapped, ok := wrapper.EventData.(map[string]interface{})
 	if !ok {
 		return errors.New("error type assertion")
@@ -46,10 +48,24 @@ func (w *AreaEventWrapper) UnmarshalJSON(b []byte) error {
 		e = domain.AreaReservoirChanged{}
 	case "AreaPhotoAdded":
 		e = domain.AreaPhotoAdded{}
+	case "AreaPhotoRemoved":
+		e = domain.AreaPhotoRemoved{}
 	case "AreaNoteAdded":
 		e = domain.AreaNoteAdded{}
 	case "AreaNoteRemoved":
 		e = domain.AreaNoteRemoved{}
+	case "AreaCustomFieldsChanged":
+		e = domain.AreaCustomFieldsChanged{}
+	case "AreaBedMapResized":
+		e = domain.AreaBedMapResized{}
+	case "AreaBedCropPlaced":
+		e = domain.AreaBedCropPlaced{}
+	case "AreaLayoutChanged":
+		e = domain.AreaLayoutChanged{}
+	case "AreaGeoPointChanged":
+		e = domain.AreaGeoPointChanged{}
+	case "AreaSanitized":
+		e = domain.AreaSanitized{}
 	}
 
 	_, err = Decode(f, &mapped, &e)
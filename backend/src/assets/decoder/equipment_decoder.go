@@ -0,0 +1,59 @@
+package decoder
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/usetania/tania-core/src/assets/domain"
+)
+
+type EquipmentEventWrapper EventWrapper
+
+func (w *EquipmentEventWrapper) UnmarshalJSON(b []byte) error {
+	wrapper := EventWrapper{}
+
+	err := json.Unmarshal(b, &wrapper)
+	if err != nil {
+		return err
+	}
+
+	w.Actor = wrapper.Actor
+
+	mapped, ok := wrapper.EventData.(map[string]interface{})
+	if !ok {
+		return errors.New("error type assertion")
+	}
+
+	f := mapstructure.ComposeDecodeHookFunc(
+		UIDHook(),
+		TimeHook(time.RFC3339),
+	)
+
+	var e interface{}
+
+	switch wrapper.EventName {
+	case "EquipmentCreated":
+		e = domain.EquipmentCreated{}
+	case "EquipmentUpdated":
+		e = domain.EquipmentUpdated{}
+	case "EquipmentMaintenanceChanged":
+		e = domain.EquipmentMaintenanceChanged{}
+	case "EquipmentMaintenanceDue":
+		e = domain.EquipmentMaintenanceDue{}
+	case "EquipmentGeoPointChanged":
+		e = domain.EquipmentGeoPointChanged{}
+	case "EquipmentDeleted":
+		e = domain.EquipmentDeleted{}
+	}
+
+	_, err = Decode(f, &mapped, &e)
+	if err != nil {
+		return err
+	}
+
+	w.EventData = e
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package decoder
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/usetania/tania-core/src/assets/domain"
+)
+
+type FarmCertificationEventWrapper EventWrapper
+
+func (w *FarmCertificationEventWrapper) UnmarshalJSON(b []byte) error {
+	wrapper := EventWrapper{}
+
+	err := json.Unmarshal(b, &wrapper)
+	if err != nil {
+		return err
+	}
+
+	w.Actor = wrapper.Actor
+
+	mapped, ok := wrapper.EventData.(map[string]interface{})
+	if !ok {
+		return errors.New("error type assertion")
+	}
+
+	f := mapstructure.ComposeDecodeHookFunc(
+		UIDHook(),
+		TimeHook(time.RFC3339),
+	)
+
+	var e interface{}
+
+	switch wrapper.EventName {
+	case "CertificationGranted":
+		e = domain.CertificationGranted{}
+	case "CertificationRenewed":
+		e = domain.CertificationRenewed{}
+	case "CertificationRevoked":
+		e = domain.CertificationRevoked{}
+	case "CertificationRenewalDue":
+		e = domain.CertificationRenewalDue{}
+	}
+
+	_, err = Decode(f, &mapped, &e)
+	if err != nil {
+		return err
+	}
+
+	w.EventData = e
+
+	return nil
+}
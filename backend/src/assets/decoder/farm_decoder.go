@@ -19,6 +19,8 @@ func (w *FarmEventWrapper) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	w.Actor = wrapper.Actor
+
 	mapped, ok := wrapper.EventData.(map[string]interface{})
 	if !ok {
 		return errors.New("error type assertion")
@@ -78,6 +80,56 @@ func (w *FarmEventWrapper) UnmarshalJSON(b []byte) error {
 			return err
 		}
 
+		w.EventData = e
+
+	case "FarmAreaWalkOrderChanged":
+		e := domain.FarmAreaWalkOrderChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.EventData = e
+
+	case "FarmHarvestGradesChanged":
+		e := domain.FarmHarvestGradesChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.EventData = e
+
+	case "FarmSeasonsChanged":
+		e := domain.FarmSeasonsChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.EventData = e
+
+	case "FarmWithholdingPolicyChanged":
+		e := domain.FarmWithholdingPolicyChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.EventData = e
+
+	case "FarmDailyEffortBudgetChanged":
+		e := domain.FarmDailyEffortBudgetChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
 		w.EventData = e
 	}
 
@@ -19,6 +19,8 @@ func (w *MaterialEventWrapper) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	w.Actor = wrapper.Actor
+
 	mapped, ok := wrapper.EventData.(map[string]interface{})
 	if !ok {
 		return errors.New("error type assertion")
@@ -71,6 +73,36 @@ func (w *MaterialEventWrapper) UnmarshalJSON(b []byte) error {
 
 		w.EventData = e
 
+	case "MaterialStockCorrected":
+		e := domain.MaterialStockCorrected{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.EventData = e
+
+	case "MaterialStockAdded":
+		e := domain.MaterialStockAdded{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.EventData = e
+
+	case "MaterialStockDeducted":
+		e := domain.MaterialStockDeducted{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.EventData = e
+
 	case "MaterialTypeChanged":
 		e := domain.MaterialTypeChanged{}
 
@@ -109,6 +141,26 @@ func (w *MaterialEventWrapper) UnmarshalJSON(b []byte) error {
 			return err
 		}
 
+		w.EventData = e
+
+	case "MaterialGDDToMaturityChanged":
+		e := domain.MaterialGDDToMaturityChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.EventData = e
+
+	case "MaterialPreHarvestIntervalChanged":
+		e := domain.MaterialPreHarvestIntervalChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
 		w.EventData = e
 	}
 
@@ -19,6 +19,8 @@ func (w *ReservoirEventWrapper) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	w.Actor = wrapper.Actor
+
 	mapped, ok := wrapper.EventData.(map[string]interface{})
 	if !ok {
 		return errors.New("error type assertion")
@@ -43,6 +45,10 @@ func (w *ReservoirEventWrapper) UnmarshalJSON(b []byte) error {
 		e = domain.ReservoirNoteAdded{}
 	case "ReservoirNoteRemoved":
 		e = domain.ReservoirNoteRemoved{}
+	case "ReservoirMeasured":
+		e = domain.ReservoirMeasured{}
+	case "ReservoirDosed":
+		e = domain.ReservoirDosed{}
 	}
 
 	_, err = Decode(f, &mapped, &e)
@@ -1,10 +1,12 @@
 package domain
 
 import (
+	"math"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/usetania/tania-core/src/helper/validationhelper"
+	"github.com/usetania/tania-core/src/units"
 )
 
 type Area struct {
@@ -18,6 +20,12 @@ type Area struct {
 	Notes        map[uuid.UUID]AreaNote `json:"-"`
 	ReservoirUID uuid.UUID              `json:"-"`
 	FarmUID      uuid.UUID              `json:"-"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Layout       *AreaLayout            `json:"layout"`
+	BedMap       *BedMap                `json:"bed_map"`
+	GeoPoint     *GeoPoint              `json:"geo_point"`
+
+	LastSanitizedDate *time.Time `json:"last_sanitized_date"`
 
 	// Events
 	Version            int
@@ -28,6 +36,7 @@ type AreaService interface {
 	FindFarmByID(farmUID uuid.UUID) (AreaFarmServiceResult, error)
 	FindReservoirByID(reservoirUID uuid.UUID) (AreaReservoirServiceResult, error)
 	CountCropsByAreaID(areaUID uuid.UUID) (int, error)
+	FindCustomFieldsByFarm(farmUID uuid.UUID, entityType string) ([]CustomFieldSchema, error)
 }
 
 type AreaFarmServiceResult struct {
@@ -95,8 +104,8 @@ func GetAreaLocation(code string) AreaLocation {
 }
 
 const (
-	SquareMeter = "m2"
-	Hectare     = "Ha"
+	SquareMeter = units.SquareMeter
+	Hectare     = units.Hectare
 )
 
 type AreaUnit struct {
@@ -105,10 +114,12 @@ type AreaUnit struct {
 }
 
 func AreaUnits() []AreaUnit {
-	return []AreaUnit{
-		{Symbol: SquareMeter, Label: "Square Meter"},
-		{Symbol: Hectare, Label: "Hectare"},
+	areaUnits := []AreaUnit{}
+	for _, v := range units.AreaSize() {
+		areaUnits = append(areaUnits, AreaUnit{Symbol: v.Code, Label: v.Label})
 	}
+
+	return areaUnits
 }
 
 func GetAreaUnit(symbol string) AreaUnit {
@@ -134,6 +145,16 @@ type AreaPhoto struct {
 	Height   int    `json:"height"`
 }
 
+// AreaLayout is the place of the area on the farm map, in the coordinates of the farm.
+// The rotation is in degrees, around the top left corner.
+type AreaLayout struct {
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Width    float64 `json:"width"`
+	Height   float64 `json:"height"`
+	Rotation float64 `json:"rotation"`
+}
+
 type AreaNote struct {
 	UID         uuid.UUID `json:"uid"`
 	Content     string    `json:"content"`
@@ -156,6 +177,7 @@ func (a *Area) Transition(event interface{}) {
 		a.CreatedDate = e.CreatedDate
 		a.FarmUID = e.FarmUID
 		a.ReservoirUID = e.ReservoirUID
+		a.CustomFields = e.CustomFields
 
 	case AreaNameChanged:
 		a.Name = e.Name
@@ -181,6 +203,9 @@ func (a *Area) Transition(event interface{}) {
 			Height:   e.Height,
 		}
 
+	case AreaPhotoRemoved:
+		a.Photo = AreaPhoto{}
+
 	case AreaNoteAdded:
 		if len(a.Notes) == 0 {
 			a.Notes = make(map[uuid.UUID]AreaNote)
@@ -194,6 +219,34 @@ func (a *Area) Transition(event interface{}) {
 
 	case AreaNoteRemoved:
 		delete(a.Notes, e.UID)
+
+	case AreaCustomFieldsChanged:
+		a.CustomFields = e.CustomFields
+
+	case AreaLayoutChanged:
+		a.Layout = e.Layout
+
+	case AreaGeoPointChanged:
+		a.GeoPoint = e.GeoPoint
+
+	case AreaSanitized:
+		if a.LastSanitizedDate == nil || e.SanitizedDate.After(*a.LastSanitizedDate) {
+			sanitizedDate := e.SanitizedDate
+			a.LastSanitizedDate = &sanitizedDate
+		}
+
+	case AreaBedMapResized:
+		bedMap := BedMap{}
+		if a.BedMap != nil {
+			bedMap = *a.BedMap
+		}
+
+		bedMap = bedMap.Resized(e.Rows, e.Columns)
+		a.BedMap = &bedMap
+
+	case AreaBedCropPlaced:
+		bedMap := a.BedMap.WithCrop(e.Row, e.Column, e.CropUID, e.Status)
+		a.BedMap = &bedMap
 	}
 }
 
@@ -205,7 +258,8 @@ func CreateArea(
 	name string,
 	areaType string,
 	size AreaSize,
-	locationCode string) (*Area, error,
+	locationCode string,
+	customFields map[string]interface{}) (*Area, error,
 ) {
 	err := validateAreaName(name)
 	if err != nil {
@@ -242,6 +296,11 @@ func CreateArea(
 		return nil, err
 	}
 
+	validatedCustomFields, err := validateAreaCustomFields(areaService, farm.UID, customFields)
+	if err != nil {
+		return nil, err
+	}
+
 	uid, err := uuid.NewV4()
 	if err != nil {
 		return nil, err
@@ -256,6 +315,7 @@ func CreateArea(
 		FarmUID:      farm.UID,
 		ReservoirUID: reservoir.UID,
 		CreatedDate:  time.Now(),
+		CustomFields: validatedCustomFields,
 	}
 
 	initial.TrackChange(AreaCreated{
@@ -267,6 +327,7 @@ func CreateArea(
 		FarmUID:      initial.FarmUID,
 		ReservoirUID: initial.ReservoirUID,
 		CreatedDate:  initial.CreatedDate,
+		CustomFields: initial.CustomFields,
 	})
 
 	return initial, nil
@@ -348,6 +409,64 @@ func (a *Area) ChangeReservoir(reservoirUID uuid.UUID) error {
 	return nil
 }
 
+// ChangeCustomFields replaces the values of the custom fields defined for the areas of the farm.
+// The values of the retired fields are kept.
+func (a *Area) ChangeCustomFields(areaService AreaService, customFields map[string]interface{}) error {
+	fields, err := areaService.FindCustomFieldsByFarm(a.FarmUID, CustomFieldEntityArea)
+	if err != nil {
+		return err
+	}
+
+	validated, err := ValidateCustomFieldValues(fields, customFields)
+	if err != nil {
+		return err
+	}
+
+	validated = KeepRetiredCustomFieldValues(fields, a.CustomFields, validated)
+
+	a.TrackChange(AreaCustomFieldsChanged{
+		AreaUID:      a.UID,
+		CustomFields: validated,
+	})
+
+	return nil
+}
+
+// ChangeLayout places the area on the farm map. The areas may overlap, but their size can't be negative.
+func (a *Area) ChangeLayout(layout AreaLayout) error {
+	for _, v := range []float64{layout.X, layout.Y, layout.Width, layout.Height, layout.Rotation} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return AreaError{Code: AreaErrorInvalidLayoutCode}
+		}
+	}
+
+	if layout.Width < 0 || layout.Height < 0 {
+		return AreaError{Code: AreaErrorLayoutNegativeSizeCode}
+	}
+
+	a.TrackChange(AreaLayoutChanged{
+		AreaUID: a.UID,
+		Layout:  &layout,
+	})
+
+	return nil
+}
+
+// ChangeGeoPoint sets the GPS coordinate of the area. Whether it's inside the farm boundary is only a warning.
+func (a *Area) ChangeGeoPoint(point GeoPoint) error {
+	err := point.Validate()
+	if err != nil {
+		return err
+	}
+
+	a.TrackChange(AreaGeoPointChanged{
+		AreaUID:  a.UID,
+		GeoPoint: &point,
+	})
+
+	return nil
+}
+
 // TODO: Do file type validation here.
 func (a *Area) ChangePhoto(photo AreaPhoto) error {
 	a.TrackChange(AreaPhotoAdded{
@@ -362,6 +481,21 @@ func (a *Area) ChangePhoto(photo AreaPhoto) error {
 	return nil
 }
 
+// RemovePhoto removes the photo of the area. The event keeps the filename
+// so the file can be removed from the storage afterwards.
+func (a *Area) RemovePhoto() error {
+	if a.Photo.Filename == "" {
+		return AreaError{Code: AreaErrorPhotoNotFoundCode}
+	}
+
+	a.TrackChange(AreaPhotoRemoved{
+		AreaUID:  a.UID,
+		Filename: a.Photo.Filename,
+	})
+
+	return nil
+}
+
 func (a *Area) AddNewNote(content string) error {
 	if content == "" {
 		return AreaError{Code: AreaNoteErrorInvalidContent}
@@ -407,6 +541,19 @@ func (a *Area) RemoveNote(uid uuid.UUID) error {
 	return nil
 }
 
+func validateAreaCustomFields(
+	areaService AreaService,
+	farmUID uuid.UUID,
+	customFields map[string]interface{},
+) (map[string]interface{}, error) {
+	fields, err := areaService.FindCustomFieldsByFarm(farmUID, CustomFieldEntityArea)
+	if err != nil {
+		return nil, err
+	}
+
+	return ValidateCustomFieldValues(fields, customFields)
+}
+
 func validateAreaName(name string) error {
 	if name == "" {
 		return AreaError{AreaErrorNameEmptyCode}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// BedCellStatus is the status of a cell of the planting bed map.
+type BedCellStatus string
+
+const (
+	BedCellEmpty    BedCellStatus = "EMPTY"
+	BedCellOccupied BedCellStatus = "OCCUPIED"
+	BedCellReserved BedCellStatus = "RESERVED"
+)
+
+// BedMapMaxSize is the most rows and columns of a bed map.
+const BedMapMaxSize = 100
+
+// BedMap is the grid of the planting beds of an area, with the crop of each cell, by row then column from 0.
+// The reserved cells are kept for a crop which isn't planted in them yet.
+type BedMap struct {
+	Rows     int               `json:"rows"`
+	Columns  int               `json:"columns"`
+	Cells    [][]BedCellStatus `json:"cells"`
+	CropUIDs [][]*uuid.UUID    `json:"crop_ids"`
+}
+
+// Resized returns the map with the size, the cells in both keeping their crop.
+func (m BedMap) Resized(rows, columns int) BedMap {
+	resized := BedMap{
+		Rows:     rows,
+		Columns:  columns,
+		Cells:    make([][]BedCellStatus, rows),
+		CropUIDs: make([][]*uuid.UUID, rows),
+	}
+
+	for i := 0; i < rows; i++ {
+		resized.Cells[i] = make([]BedCellStatus, columns)
+		resized.CropUIDs[i] = make([]*uuid.UUID, columns)
+
+		for j := 0; j < columns; j++ {
+			resized.Cells[i][j] = m.StatusAt(i, j)
+			resized.CropUIDs[i][j] = m.CropAt(i, j)
+		}
+	}
+
+	return resized
+}
+
+// WithCrop returns the map with the crop in the cell.
+func (m BedMap) WithCrop(row, column int, cropUID uuid.UUID, status BedCellStatus) BedMap {
+	placed := m.Resized(m.Rows, m.Columns)
+	placed.Cells[row][column] = status
+	placed.CropUIDs[row][column] = &cropUID
+
+	return placed
+}
+
+// StatusAt returns the status of the cell, the cells outside of the map are empty.
+func (m BedMap) StatusAt(row, column int) BedCellStatus {
+	if !m.Contains(row, column) || row >= len(m.Cells) || column >= len(m.Cells[row]) || m.Cells[row][column] == "" {
+		return BedCellEmpty
+	}
+
+	return m.Cells[row][column]
+}
+
+// CropAt returns the crop of the cell, nil when the cell is empty.
+func (m BedMap) CropAt(row, column int) *uuid.UUID {
+	if !m.Contains(row, column) || row >= len(m.CropUIDs) || column >= len(m.CropUIDs[row]) ||
+		m.CropUIDs[row][column] == nil {
+		return nil
+	}
+
+	cropUID := *m.CropUIDs[row][column]
+
+	return &cropUID
+}
+
+func (m BedMap) Contains(row, column int) bool {
+	return row >= 0 && row < m.Rows && column >= 0 && column < m.Columns
+}
+
+// ResizeBedMap sets the number of rows and columns of the bed map of the area.
+// The map can't be shrunk over the cells with a crop.
+func (a *Area) ResizeBedMap(rows, columns int) error {
+	if rows < 1 || columns < 1 || rows > BedMapMaxSize || columns > BedMapMaxSize {
+		return AreaError{Code: AreaErrorBedMapInvalidSizeCode}
+	}
+
+	if a.BedMap != nil {
+		for i := 0; i < a.BedMap.Rows; i++ {
+			for j := 0; j < a.BedMap.Columns; j++ {
+				if (i >= rows || j >= columns) && a.BedMap.StatusAt(i, j) != BedCellEmpty {
+					return AreaError{Code: AreaErrorBedMapCropOutsideCode}
+				}
+			}
+		}
+	}
+
+	a.TrackChange(AreaBedMapResized{
+		AreaUID: a.UID,
+		Rows:    rows,
+		Columns: columns,
+	})
+
+	return nil
+}
+
+// PlaceCrop assigns the crop to the cell of the bed map. A cell taken by another crop is a collision,
+// the crop of a reserved cell is placed in it to occupy it.
+func (a *Area) PlaceCrop(cropUID uuid.UUID, row, column int, status BedCellStatus) error {
+	if a.BedMap == nil {
+		return AreaError{Code: AreaErrorBedMapNotDefinedCode}
+	}
+
+	if status != BedCellOccupied && status != BedCellReserved {
+		return AreaError{Code: AreaErrorBedCellInvalidStatusCode}
+	}
+
+	if !a.BedMap.Contains(row, column) {
+		return AreaError{Code: AreaErrorBedCellOutOfRangeCode}
+	}
+
+	current := a.BedMap.CropAt(row, column)
+	if current != nil && *current != cropUID {
+		return AreaError{Code: AreaErrorBedCellTakenCode}
+	}
+
+	if current != nil && a.BedMap.StatusAt(row, column) == status {
+		return nil
+	}
+
+	a.TrackChange(AreaBedCropPlaced{
+		AreaUID: a.UID,
+		CropUID: cropUID,
+		Row:     row,
+		Column:  column,
+		Status:  status,
+	})
+
+	return nil
+}
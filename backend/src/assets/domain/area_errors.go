@@ -21,6 +21,24 @@ const (
 	AreaNoteErrorInvalidContent
 	AreaNoteErrorInvalidID
 	AreaNoteErrorNotFound
+
+	AreaErrorInvalidLayoutCode
+	AreaErrorLayoutNegativeSizeCode
+
+	AreaErrorBedMapInvalidSizeCode
+	AreaErrorBedMapCropOutsideCode
+	AreaErrorBedMapNotDefinedCode
+	AreaErrorBedCellInvalidStatusCode
+	AreaErrorBedCellOutOfRangeCode
+	AreaErrorBedCellTakenCode
+
+	AreaErrorNamePatternInvalidCode
+	AreaErrorNamePatternTooManyCode
+
+	AreaErrorPhotoNotFoundCode
+
+	AreaErrorSanitationInvalidMethodCode
+	AreaErrorSanitationInvalidDateCode
 )
 
 // AreaError is a custom error from Go built-in error.
@@ -62,6 +80,32 @@ func (e AreaError) Error() string {
 		return "Invalid crop note content"
 	case AreaNoteErrorNotFound:
 		return "Area note not found"
+	case AreaErrorInvalidLayoutCode:
+		return "Area layout must be numbers"
+	case AreaErrorLayoutNegativeSizeCode:
+		return "Area layout width and height cannot be negative"
+	case AreaErrorBedMapInvalidSizeCode:
+		return "Bed map rows and columns must be from 1 to 100"
+	case AreaErrorBedMapCropOutsideCode:
+		return "Bed map cannot be shrunk over the cells with a crop"
+	case AreaErrorBedMapNotDefinedCode:
+		return "Area has no bed map"
+	case AreaErrorBedCellInvalidStatusCode:
+		return "Bed cell status must be occupied or reserved"
+	case AreaErrorBedCellOutOfRangeCode:
+		return "Bed cell is outside of the bed map"
+	case AreaErrorBedCellTakenCode:
+		return "Bed cell is already taken by another crop"
+	case AreaErrorNamePatternInvalidCode:
+		return "Area name pattern ranges must be like {A..F} or {1..8}"
+	case AreaErrorNamePatternTooManyCode:
+		return "Area name pattern cannot expand to more than 500 areas"
+	case AreaErrorPhotoNotFoundCode:
+		return "Area has no photo"
+	case AreaErrorSanitationInvalidMethodCode:
+		return "Sanitation method is invalid"
+	case AreaErrorSanitationInvalidDateCode:
+		return "Sanitation date cannot be empty or in the future"
 	default:
 		return "Unrecognized Area Error Code"
 	}
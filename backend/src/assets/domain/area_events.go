@@ -15,6 +15,7 @@ type AreaCreated struct {
 	FarmUID      uuid.UUID
 	ReservoirUID uuid.UUID
 	CreatedDate  time.Time
+	CustomFields map[string]interface{}
 }
 
 type AreaNameChanged struct {
@@ -51,6 +52,11 @@ type AreaPhotoAdded struct {
 	Height   int
 }
 
+type AreaPhotoRemoved struct {
+	AreaUID  uuid.UUID
+	Filename string
+}
+
 type AreaNoteAdded struct {
 	AreaUID     uuid.UUID
 	UID         uuid.UUID
@@ -62,3 +68,41 @@ type AreaNoteRemoved struct {
 	AreaUID uuid.UUID
 	UID     uuid.UUID
 }
+
+type AreaCustomFieldsChanged struct {
+	AreaUID      uuid.UUID
+	CustomFields map[string]interface{}
+}
+
+type AreaBedMapResized struct {
+	AreaUID uuid.UUID
+	Rows    int
+	Columns int
+}
+
+type AreaBedCropPlaced struct {
+	AreaUID uuid.UUID
+	CropUID uuid.UUID
+	Row     int
+	Column  int
+	Status  BedCellStatus
+}
+
+type AreaLayoutChanged struct {
+	AreaUID uuid.UUID
+	Layout  *AreaLayout
+}
+
+type AreaGeoPointChanged struct {
+	AreaUID  uuid.UUID
+	GeoPoint *GeoPoint
+}
+
+type AreaSanitized struct {
+	AreaUID       uuid.UUID
+	UID           uuid.UUID
+	Method        string
+	Materials     []AreaSanitationMaterial
+	Notes         string
+	SanitizedDate time.Time
+}
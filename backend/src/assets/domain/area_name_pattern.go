@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// AreaBulkMaxCount is the most areas created at once, by a name pattern or a list.
+const AreaBulkMaxCount = 500
+
+var areaNamePatternRange = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// ExpandAreaNamePattern expands the ranges of the pattern into the names of the areas, `Bench {A..F} Row {1..8}`
+// into `Bench A Row 1` to `Bench F Row 8`. The ranges are of letters or of numbers, a range may count down,
+// and `{01..12}` pads the numbers with zeros. The last range changes first.
+func ExpandAreaNamePattern(pattern string) ([]string, error) {
+	names := []string{""}
+	last := 0
+
+	for _, match := range areaNamePatternRange.FindAllStringSubmatchIndex(pattern, -1) {
+		values, err := expandAreaNameRange(pattern[match[2]:match[3]])
+		if err != nil {
+			return nil, err
+		}
+
+		if len(names)*len(values) > AreaBulkMaxCount {
+			return nil, AreaError{AreaErrorNamePatternTooManyCode}
+		}
+
+		literal := pattern[last:match[0]]
+		if strings.ContainsAny(literal, "{}") {
+			return nil, AreaError{AreaErrorNamePatternInvalidCode}
+		}
+
+		expanded := make([]string, 0, len(names)*len(values))
+
+		for _, name := range names {
+			for _, value := range values {
+				expanded = append(expanded, name+literal+value)
+			}
+		}
+
+		names = expanded
+		last = match[1]
+	}
+
+	literal := pattern[last:]
+	if strings.ContainsAny(literal, "{}") {
+		return nil, AreaError{AreaErrorNamePatternInvalidCode}
+	}
+
+	for i := range names {
+		names[i] += literal
+	}
+
+	return names, nil
+}
+
+// expandAreaNameRange returns the values of a range like `A..F` or `1..8`.
+func expandAreaNameRange(value string) ([]string, error) {
+	bounds := strings.Split(value, "..")
+	if len(bounds) != 2 {
+		return nil, AreaError{AreaErrorNamePatternInvalidCode}
+	}
+
+	from, fromErr := strconv.Atoi(bounds[0])
+	to, toErr := strconv.Atoi(bounds[1])
+
+	if fromErr == nil && toErr == nil {
+		if from < 0 || to < 0 {
+			return nil, AreaError{AreaErrorNamePatternInvalidCode}
+		}
+
+		// A bound written with a leading zero sets the width of all the numbers.
+		width := 0
+		if (len(bounds[0]) > 1 && bounds[0][0] == '0') || (len(bounds[1]) > 1 && bounds[1][0] == '0') {
+			width = len(bounds[0])
+			if len(bounds[1]) > width {
+				width = len(bounds[1])
+			}
+		}
+
+		return areaNameRange(from, to, func(v int) string {
+			number := strconv.Itoa(v)
+			if len(number) < width {
+				number = strings.Repeat("0", width-len(number)) + number
+			}
+
+			return number
+		})
+	}
+
+	if isAreaNameLetter(bounds[0]) && isAreaNameLetter(bounds[1]) && isUpper(bounds[0]) == isUpper(bounds[1]) {
+		return areaNameRange(int(bounds[0][0]), int(bounds[1][0]), func(v int) string {
+			return string(rune(v))
+		})
+	}
+
+	return nil, AreaError{AreaErrorNamePatternInvalidCode}
+}
+
+func areaNameRange(from, to int, format func(int) string) ([]string, error) {
+	step := 1
+	if to < from {
+		step = -1
+	}
+
+	count := (to-from)*step + 1
+	if count > AreaBulkMaxCount {
+		return nil, AreaError{AreaErrorNamePatternTooManyCode}
+	}
+
+	values := make([]string, 0, count)
+	for v := from; v != to+step; v += step {
+		values = append(values, format(v))
+	}
+
+	return values, nil
+}
+
+func isAreaNameLetter(value string) bool {
+	return len(value) == 1 && (('A' <= value[0] && value[0] <= 'Z') || ('a' <= value[0] && value[0] <= 'z'))
+}
+
+func isUpper(value string) bool {
+	return 'A' <= value[0] && value[0] <= 'Z'
+}
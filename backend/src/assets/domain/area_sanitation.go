@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// The methods of cleaning an area between its crops.
+const (
+	SanitationMethodCleaning     = "CLEANING"
+	SanitationMethodDisinfection = "DISINFECTION"
+	SanitationMethodSteaming     = "STEAMING"
+	SanitationMethodSolarization = "SOLARIZATION"
+	SanitationMethodFlooding     = "FLOODING"
+	SanitationMethodFallow       = "FALLOW"
+)
+
+// StockDeductionReasonSanitation is the reason of the stock deductions made when an area is sanitized.
+const StockDeductionReasonSanitation = "SANITATION"
+
+// AreaSanitationMaterial is the quantity of a material used by a sanitation, in the unit of the material.
+// The shortfall is the part of it the stock of the material didn't have.
+type AreaSanitationMaterial struct {
+	MaterialUID  uuid.UUID
+	MaterialName string
+	Quantity     float32
+	QuantityUnit string
+	Shortfall    float32
+}
+
+func SanitationMethods() []string {
+	return []string{
+		SanitationMethodCleaning,
+		SanitationMethodDisinfection,
+		SanitationMethodSteaming,
+		SanitationMethodSolarization,
+		SanitationMethodFlooding,
+		SanitationMethodFallow,
+	}
+}
+
+// Sanitize records the cleaning of the area on the date, which can't be in the future.
+func (a *Area) Sanitize(
+	method string, sanitizedDate time.Time, materials []AreaSanitationMaterial, notes string,
+) error {
+	valid := false
+
+	for _, v := range SanitationMethods() {
+		if v == method {
+			valid = true
+		}
+	}
+
+	if !valid {
+		return AreaError{Code: AreaErrorSanitationInvalidMethodCode}
+	}
+
+	if sanitizedDate.IsZero() || sanitizedDate.After(time.Now()) {
+		return AreaError{Code: AreaErrorSanitationInvalidDateCode}
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+
+	if materials == nil {
+		materials = []AreaSanitationMaterial{}
+	}
+
+	a.TrackChange(AreaSanitized{
+		AreaUID:       a.UID,
+		UID:           uid,
+		Method:        method,
+		Materials:     materials,
+		Notes:         notes,
+		SanitizedDate: sanitizedDate,
+	})
+
+	return nil
+}
+
+// DaysSinceSanitation is the number of whole days from the last sanitation of the area, nil when it has none.
+func DaysSinceSanitation(lastSanitizedDate *time.Time, now time.Time) *int {
+	if lastSanitizedDate == nil {
+		return nil
+	}
+
+	days := int(now.Sub(*lastSanitizedDate).Hours() / 24)
+	if days < 0 {
+		days = 0
+	}
+
+	return &days
+}
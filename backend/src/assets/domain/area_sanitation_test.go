@@ -0,0 +1,74 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/assets/domain"
+)
+
+func TestAreaSanitize(t *testing.T) {
+	t.Parallel()
+	// Given
+	areaUID, _ := uuid.NewV4()
+	materialUID, _ := uuid.NewV4()
+	area := &Area{UID: areaUID}
+	lastWeek := time.Now().AddDate(0, 0, -7)
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	// When
+	err := area.Sanitize(SanitationMethodDisinfection, yesterday, []AreaSanitationMaterial{
+		{MaterialUID: materialUID, MaterialName: "Bleach", Quantity: 2, QuantityUnit: "LITRE"},
+	}, "Beds 1 to 4")
+	errOlder := area.Sanitize(SanitationMethodFallow, lastWeek, nil, "")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Nil(t, errOlder)
+	assert.Len(t, area.UncommittedChanges, 2)
+
+	sanitized, ok := area.UncommittedChanges[0].(AreaSanitized)
+	assert.True(t, ok)
+	assert.Equal(t, areaUID, sanitized.AreaUID)
+	assert.Equal(t, "Bleach", sanitized.Materials[0].MaterialName)
+	assert.Empty(t, area.UncommittedChanges[1].(AreaSanitized).Materials)
+
+	assert.Equal(t, yesterday, *area.LastSanitizedDate)
+}
+
+func TestInvalidAreaSanitize(t *testing.T) {
+	t.Parallel()
+	// Given
+	area := &Area{}
+
+	// When
+	methodErr := area.Sanitize("WISHING", time.Now(), nil, "")
+	emptyDateErr := area.Sanitize(SanitationMethodCleaning, time.Time{}, nil, "")
+	futureErr := area.Sanitize(SanitationMethodCleaning, time.Now().AddDate(0, 0, 1), nil, "")
+
+	// Then
+	assert.Equal(t, AreaError{Code: AreaErrorSanitationInvalidMethodCode}, methodErr)
+	assert.Equal(t, AreaError{Code: AreaErrorSanitationInvalidDateCode}, emptyDateErr)
+	assert.Equal(t, AreaError{Code: AreaErrorSanitationInvalidDateCode}, futureErr)
+	assert.Empty(t, area.UncommittedChanges)
+	assert.Nil(t, area.LastSanitizedDate)
+}
+
+func TestDaysSinceSanitation(t *testing.T) {
+	t.Parallel()
+	// Given
+	now := time.Date(2026, time.October, 14, 8, 0, 0, 0, time.UTC)
+	tenDaysAgo := now.Add(-10*24*time.Hour - time.Hour)
+	later := now.Add(time.Hour)
+
+	// When
+	days := DaysSinceSanitation(&tenDaysAgo, now)
+	ahead := DaysSinceSanitation(&later, now)
+
+	// Then
+	assert.Equal(t, 10, *days)
+	assert.Equal(t, 0, *ahead)
+	assert.Nil(t, DaysSinceSanitation(nil, now))
+}
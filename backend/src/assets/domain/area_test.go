@@ -1,6 +1,7 @@
 package domain_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/gofrs/uuid"
@@ -31,6 +32,12 @@ func (m *AreaServiceMock) CountCropsByAreaID(areaUID uuid.UUID) (int, error) {
 	return args.Get(0).(int), nil
 }
 
+func (m *AreaServiceMock) FindCustomFieldsByFarm(farmUID uuid.UUID, entityType string) ([]CustomFieldSchema, error) {
+	args := m.Called(farmUID, entityType)
+
+	return args.Get(0).([]CustomFieldSchema), nil
+}
+
 type countCropsResult struct {
 	AreaUID uuid.UUID
 	Count   int
@@ -60,6 +67,7 @@ func TestCreateArea(t *testing.T) {
 		AreaTypeSeeding,
 		AreaSize{Unit: GetAreaUnit(SquareMeter), Value: float32(10)},
 		AreaLocationIndoor,
+		nil,
 	)
 
 	// Then
@@ -126,7 +134,7 @@ func TestInvalidCreateArea(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		_, err := CreateArea(areaService, test.FarmUID, test.ReservoirUID, test.Name, test.Type, test.Size, test.Location)
+		_, err := CreateArea(areaService, test.FarmUID, test.ReservoirUID, test.Name, test.Type, test.Size, test.Location, nil)
 
 		assert.Equal(t, test.ExpectedError, err)
 	}
@@ -156,6 +164,7 @@ func TestAreaCreateRemoveNote(t *testing.T) {
 		AreaTypeSeeding,
 		AreaSize{Unit: GetAreaUnit(SquareMeter), Value: float32(10)},
 		AreaLocationIndoor,
+		nil,
 	)
 
 	noteContent := "This is my new note"
@@ -218,6 +227,7 @@ func TestAreaChangePhoto(t *testing.T) {
 		AreaTypeSeeding,
 		AreaSize{Unit: GetAreaUnit(SquareMeter), Value: float32(10)},
 		AreaLocationIndoor,
+		nil,
 	)
 
 	photo := AreaPhoto{
@@ -242,8 +252,94 @@ func TestAreaChangePhoto(t *testing.T) {
 	assert.Equal(t, photo.Filename, event.Filename)
 }
 
+func TestAreaChangeLayout(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	reservoirUID, _ := uuid.NewV4()
+	areaService := mockAreaService(AreaFarmServiceResult{UID: farmUID}, AreaReservoirServiceResult{UID: reservoirUID})
+
+	area, areaErr := CreateArea(
+		areaService,
+		farmUID,
+		reservoirUID,
+		"My Area 1",
+		AreaTypeGrowing,
+		AreaSize{Unit: GetAreaUnit(SquareMeter), Value: float32(10)},
+		AreaLocationOutdoor,
+		nil,
+	)
+
+	// When
+	layoutErr := area.ChangeLayout(AreaLayout{X: -4, Y: 2.5, Width: 3, Height: 1.2, Rotation: 90})
+	negativeErr := area.ChangeLayout(AreaLayout{Width: -1, Height: 1})
+	nanErr := area.ChangeLayout(AreaLayout{X: math.NaN(), Width: 1, Height: 1})
+
+	// Then
+	assert.Nil(t, areaErr)
+	assert.Nil(t, layoutErr)
+	assert.Equal(t, AreaError{Code: AreaErrorLayoutNegativeSizeCode}, negativeErr)
+	assert.Equal(t, AreaError{Code: AreaErrorInvalidLayoutCode}, nanErr)
+	assert.Equal(t, AreaLayout{X: -4, Y: 2.5, Width: 3, Height: 1.2, Rotation: 90}, *area.Layout)
+	assert.Len(t, area.UncommittedChanges, 2)
+
+	event, ok := area.UncommittedChanges[1].(AreaLayoutChanged)
+	assert.True(t, ok)
+	assert.Equal(t, area.UID, event.AreaUID)
+}
+
+func TestAreaBedMap(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	reservoirUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	otherCropUID, _ := uuid.NewV4()
+	areaService := mockAreaService(AreaFarmServiceResult{UID: farmUID}, AreaReservoirServiceResult{UID: reservoirUID})
+
+	area, areaErr := CreateArea(
+		areaService,
+		farmUID,
+		reservoirUID,
+		"My Area 1",
+		AreaTypeGrowing,
+		AreaSize{Unit: GetAreaUnit(SquareMeter), Value: float32(10)},
+		AreaLocationOutdoor,
+		nil,
+	)
+
+	// When
+	undefinedErr := area.PlaceCrop(cropUID, 0, 0, BedCellOccupied)
+	sizeErr := area.ResizeBedMap(0, 4)
+	resizeErr := area.ResizeBedMap(2, 4)
+	reserveErr := area.PlaceCrop(cropUID, 1, 3, BedCellReserved)
+	takenErr := area.PlaceCrop(otherCropUID, 1, 3, BedCellOccupied)
+	outsideErr := area.PlaceCrop(otherCropUID, 2, 0, BedCellOccupied)
+	occupyErr := area.PlaceCrop(cropUID, 1, 3, BedCellOccupied)
+	shrinkErr := area.ResizeBedMap(2, 3)
+	growErr := area.ResizeBedMap(3, 4)
+
+	// Then
+	assert.Nil(t, areaErr)
+	assert.Equal(t, AreaError{Code: AreaErrorBedMapNotDefinedCode}, undefinedErr)
+	assert.Equal(t, AreaError{Code: AreaErrorBedMapInvalidSizeCode}, sizeErr)
+	assert.Nil(t, resizeErr)
+	assert.Nil(t, reserveErr)
+	assert.Equal(t, AreaError{Code: AreaErrorBedCellTakenCode}, takenErr)
+	assert.Equal(t, AreaError{Code: AreaErrorBedCellOutOfRangeCode}, outsideErr)
+	assert.Nil(t, occupyErr)
+	assert.Equal(t, AreaError{Code: AreaErrorBedMapCropOutsideCode}, shrinkErr)
+	assert.Nil(t, growErr)
+	assert.Equal(t, 3, area.BedMap.Rows)
+	assert.Equal(t, BedCellOccupied, area.BedMap.Cells[1][3])
+	assert.Equal(t, cropUID, *area.BedMap.CropUIDs[1][3])
+	assert.Equal(t, BedCellEmpty, area.BedMap.Cells[2][3])
+	assert.Nil(t, area.BedMap.CropAt(0, 0))
+}
+
 func mockAreaService(results ...interface{}) *AreaServiceMock {
 	areaServiceMock := new(AreaServiceMock)
+	areaServiceMock.On("FindCustomFieldsByFarm", mock.Anything, CustomFieldEntityArea).Return([]CustomFieldSchema{})
 
 	for _, v := range results {
 		switch res := v.(type) {
@@ -258,3 +354,33 @@ func mockAreaService(results ...interface{}) *AreaServiceMock {
 
 	return areaServiceMock
 }
+
+func TestExpandAreaNamePattern(t *testing.T) {
+	t.Parallel()
+	// Given
+	pattern := "Bench {A..C} Row {1..2}"
+
+	// When
+	names, err := ExpandAreaNamePattern(pattern)
+	padded, paddedErr := ExpandAreaNamePattern("Bed {09..11}")
+	down, downErr := ExpandAreaNamePattern("Row {c..a}")
+	plain, plainErr := ExpandAreaNamePattern("North Field")
+	_, mixedErr := ExpandAreaNamePattern("Bench {A..3}")
+	_, unclosedErr := ExpandAreaNamePattern("Bench {A..C")
+	_, tooManyErr := ExpandAreaNamePattern("Bench {1..30} Row {1..30}")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"Bench A Row 1", "Bench A Row 2", "Bench B Row 1", "Bench B Row 2", "Bench C Row 1", "Bench C Row 2",
+	}, names)
+	assert.Nil(t, paddedErr)
+	assert.Equal(t, []string{"Bed 09", "Bed 10", "Bed 11"}, padded)
+	assert.Nil(t, downErr)
+	assert.Equal(t, []string{"Row c", "Row b", "Row a"}, down)
+	assert.Nil(t, plainErr)
+	assert.Equal(t, []string{"North Field"}, plain)
+	assert.Equal(t, AreaError{AreaErrorNamePatternInvalidCode}, mixedErr)
+	assert.Equal(t, AreaError{AreaErrorNamePatternInvalidCode}, unclosedErr)
+	assert.Equal(t, AreaError{AreaErrorNamePatternTooManyCode}, tooManyErr)
+}
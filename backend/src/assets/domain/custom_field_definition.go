@@ -0,0 +1,343 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	CustomFieldEntityArea     = "Area"
+	CustomFieldEntityCrop     = "Crop"
+	CustomFieldEntityMaterial = "Material"
+	CustomFieldEntityTask     = "Task"
+)
+
+const (
+	CustomFieldTypeText    = "text"
+	CustomFieldTypeNumber  = "number"
+	CustomFieldTypeBoolean = "boolean"
+	CustomFieldTypeDate    = "date"
+	CustomFieldTypeSelect  = "select"
+)
+
+// CustomFieldDateLayout is the layout of the values of the date fields.
+const CustomFieldDateLayout = "2006-01-02"
+
+// customFieldKeyPattern keeps the keys usable as JSON keys and query params.
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+func FindAllCustomFieldEntityTypes() []string {
+	return []string{CustomFieldEntityArea, CustomFieldEntityCrop, CustomFieldEntityMaterial, CustomFieldEntityTask}
+}
+
+func FindAllCustomFieldTypes() []string {
+	return []string{
+		CustomFieldTypeText, CustomFieldTypeNumber, CustomFieldTypeBoolean, CustomFieldTypeDate, CustomFieldTypeSelect,
+	}
+}
+
+// CustomFieldDefinition is a field a farm adds to its areas, crops, materials or tasks.
+type CustomFieldDefinition struct {
+	UID         uuid.UUID
+	FarmID      uuid.UUID
+	EntityType  string
+	FieldKey    string
+	Label       string
+	FieldType   string
+	Options     []string
+	Required    bool
+	IsDeleted   bool
+	CreatedDate time.Time
+
+	// Events
+	Version            int
+	UncommittedChanges []interface{}
+}
+
+// CustomFieldSchema is the part of a definition needed to validate the values.
+type CustomFieldSchema struct {
+	FieldKey  string
+	FieldType string
+	Options   []string
+	Required  bool
+}
+
+func DefineCustomField(
+	farmID uuid.UUID,
+	entityType, fieldKey, label, fieldType string,
+	options []string,
+	required bool,
+) (*CustomFieldDefinition, error) {
+	if !containsString(FindAllCustomFieldEntityTypes(), entityType) {
+		return nil, CustomFieldError{Code: CustomFieldErrorInvalidEntityTypeCode}
+	}
+
+	if !customFieldKeyPattern.MatchString(fieldKey) {
+		return nil, CustomFieldError{Code: CustomFieldErrorInvalidKeyCode, FieldKey: fieldKey}
+	}
+
+	if !containsString(FindAllCustomFieldTypes(), fieldType) {
+		return nil, CustomFieldError{Code: CustomFieldErrorInvalidTypeCode, FieldKey: fieldKey}
+	}
+
+	options, err := validateCustomFieldOptions(fieldKey, fieldType, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if label == "" {
+		label = fieldKey
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	initial := &CustomFieldDefinition{}
+
+	initial.TrackChange(FieldDefined{
+		UID:         uid,
+		FarmID:      farmID,
+		EntityType:  entityType,
+		FieldKey:    fieldKey,
+		Label:       label,
+		FieldType:   fieldType,
+		Options:     options,
+		Required:    required,
+		CreatedDate: time.Now(),
+	})
+
+	return initial, nil
+}
+
+// Update changes the label, the type and options of the field and whether it's required.
+// The key stays the same because the values are stored under it.
+func (d *CustomFieldDefinition) Update(label, fieldType string, options []string, required bool) error {
+	if d.IsDeleted {
+		return CustomFieldError{Code: CustomFieldErrorDeletedCode, FieldKey: d.FieldKey}
+	}
+
+	if !containsString(FindAllCustomFieldTypes(), fieldType) {
+		return CustomFieldError{Code: CustomFieldErrorInvalidTypeCode, FieldKey: d.FieldKey}
+	}
+
+	options, err := validateCustomFieldOptions(d.FieldKey, fieldType, options)
+	if err != nil {
+		return err
+	}
+
+	if label == "" {
+		label = d.FieldKey
+	}
+
+	d.TrackChange(FieldUpdated{
+		UID:        d.UID,
+		FarmID:     d.FarmID,
+		EntityType: d.EntityType,
+		FieldKey:   d.FieldKey,
+		Label:      label,
+		FieldType:  fieldType,
+		Options:    options,
+		Required:   required,
+	})
+
+	return nil
+}
+
+// Delete retires the field. The values already saved under its key are kept on the entities.
+func (d *CustomFieldDefinition) Delete() error {
+	if d.IsDeleted {
+		return CustomFieldError{Code: CustomFieldErrorDeletedCode, FieldKey: d.FieldKey}
+	}
+
+	d.TrackChange(FieldDeleted{
+		UID:        d.UID,
+		FarmID:     d.FarmID,
+		EntityType: d.EntityType,
+		FieldKey:   d.FieldKey,
+		FieldType:  d.FieldType,
+		Required:   d.Required,
+	})
+
+	return nil
+}
+
+// Event Tracking.
+func (d *CustomFieldDefinition) TrackChange(event interface{}) {
+	d.UncommittedChanges = append(d.UncommittedChanges, event)
+	d.Transition(event)
+}
+
+func (d *CustomFieldDefinition) Transition(event interface{}) {
+	switch e := event.(type) {
+	case FieldDefined:
+		d.UID = e.UID
+		d.FarmID = e.FarmID
+		d.EntityType = e.EntityType
+		d.FieldKey = e.FieldKey
+		d.Label = e.Label
+		d.FieldType = e.FieldType
+		d.Options = e.Options
+		d.Required = e.Required
+		d.CreatedDate = e.CreatedDate
+	case FieldUpdated:
+		d.Label = e.Label
+		d.FieldType = e.FieldType
+		d.Options = e.Options
+		d.Required = e.Required
+	case FieldDeleted:
+		d.IsDeleted = true
+	}
+}
+
+// ValidateCustomFieldValues checks the values against the fields defined for the entity
+// and returns them with the dates normalized. Unknown keys are rejected.
+func ValidateCustomFieldValues(fields []CustomFieldSchema, values map[string]interface{}) (map[string]interface{}, error) {
+	validated := make(map[string]interface{})
+
+	defined := make(map[string]CustomFieldSchema)
+	for _, v := range fields {
+		defined[v.FieldKey] = v
+	}
+
+	for key, value := range values {
+		field, ok := defined[key]
+		if !ok {
+			return nil, CustomFieldError{Code: CustomFieldErrorUndefinedCode, FieldKey: key}
+		}
+
+		if value == nil {
+			continue
+		}
+
+		switch field.FieldType {
+		case CustomFieldTypeText:
+			s, ok := value.(string)
+			if !ok {
+				return nil, CustomFieldError{Code: CustomFieldErrorInvalidValueCode, FieldKey: key}
+			}
+
+			if s == "" {
+				continue
+			}
+		case CustomFieldTypeNumber:
+			if _, ok := value.(float64); !ok {
+				return nil, CustomFieldError{Code: CustomFieldErrorInvalidValueCode, FieldKey: key}
+			}
+		case CustomFieldTypeBoolean:
+			if _, ok := value.(bool); !ok {
+				return nil, CustomFieldError{Code: CustomFieldErrorInvalidValueCode, FieldKey: key}
+			}
+		case CustomFieldTypeDate:
+			s, ok := value.(string)
+			if !ok {
+				return nil, CustomFieldError{Code: CustomFieldErrorInvalidValueCode, FieldKey: key}
+			}
+
+			date, err := time.Parse(CustomFieldDateLayout, s)
+			if err != nil {
+				return nil, CustomFieldError{Code: CustomFieldErrorInvalidValueCode, FieldKey: key}
+			}
+
+			value = date.Format(CustomFieldDateLayout)
+		case CustomFieldTypeSelect:
+			s, ok := value.(string)
+			if !ok || !containsString(field.Options, s) {
+				return nil, CustomFieldError{Code: CustomFieldErrorInvalidValueCode, FieldKey: key}
+			}
+		}
+
+		validated[key] = value
+	}
+
+	for _, v := range fields {
+		if _, ok := validated[v.FieldKey]; v.Required && !ok {
+			return nil, CustomFieldError{Code: CustomFieldErrorRequiredCode, FieldKey: v.FieldKey}
+		}
+	}
+
+	return validated, nil
+}
+
+// KeepRetiredCustomFieldValues adds the values of the retired fields to the validated values,
+// so replacing the values of an entity doesn't lose what was saved before a field was deleted.
+func KeepRetiredCustomFieldValues(
+	fields []CustomFieldSchema,
+	current, validated map[string]interface{},
+) map[string]interface{} {
+	defined := make(map[string]bool)
+	for _, v := range fields {
+		defined[v.FieldKey] = true
+	}
+
+	for key, value := range current {
+		if !defined[key] {
+			validated[key] = value
+		}
+	}
+
+	return validated
+}
+
+// MatchCustomFieldFilters tells whether the values match all the filters, by field key.
+// Only the text fields, which match when they contain the filter, and the select fields,
+// which match the exact option, can be filtered. Both ignore the case.
+func MatchCustomFieldFilters(fields []CustomFieldSchema, values map[string]interface{}, filters map[string]string) (bool, error) {
+	defined := make(map[string]CustomFieldSchema)
+	for _, v := range fields {
+		defined[v.FieldKey] = v
+	}
+
+	for key, filter := range filters {
+		field, ok := defined[key]
+		if !ok || (field.FieldType != CustomFieldTypeText && field.FieldType != CustomFieldTypeSelect) {
+			return false, CustomFieldError{Code: CustomFieldErrorInvalidFilterCode, FieldKey: key}
+		}
+
+		value, _ := values[key].(string)
+
+		if field.FieldType == CustomFieldTypeText &&
+			!strings.Contains(strings.ToLower(value), strings.ToLower(filter)) {
+			return false, nil
+		}
+
+		if field.FieldType == CustomFieldTypeSelect && !strings.EqualFold(value, filter) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// validateCustomFieldOptions checks the select fields have distinct options. The other types have none.
+func validateCustomFieldOptions(fieldKey, fieldType string, options []string) ([]string, error) {
+	if fieldType != CustomFieldTypeSelect {
+		return nil, nil
+	}
+
+	if len(options) == 0 {
+		return nil, CustomFieldError{Code: CustomFieldErrorInvalidOptionsCode, FieldKey: fieldKey}
+	}
+
+	for i, v := range options {
+		if v == "" || containsString(options[:i], v) {
+			return nil, CustomFieldError{Code: CustomFieldErrorInvalidOptionsCode, FieldKey: fieldKey}
+		}
+	}
+
+	return options, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,47 @@
+package domain
+
+// CustomFieldError is a custom error from Go built-in error.
+type CustomFieldError struct {
+	Code     int
+	FieldKey string
+}
+
+const (
+	CustomFieldErrorInvalidEntityTypeCode = iota
+	CustomFieldErrorInvalidKeyCode
+	CustomFieldErrorInvalidTypeCode
+	CustomFieldErrorDuplicateKeyCode
+	CustomFieldErrorDeletedCode
+	CustomFieldErrorUndefinedCode
+	CustomFieldErrorInvalidValueCode
+	CustomFieldErrorRequiredCode
+	CustomFieldErrorInvalidOptionsCode
+	CustomFieldErrorInvalidFilterCode
+)
+
+func (e CustomFieldError) Error() string {
+	switch e.Code {
+	case CustomFieldErrorInvalidEntityTypeCode:
+		return "Custom field entity type is invalid."
+	case CustomFieldErrorInvalidKeyCode:
+		return "Custom field key must be lowercase letters, digits and underscores, starting with a letter."
+	case CustomFieldErrorInvalidTypeCode:
+		return "Custom field type is invalid."
+	case CustomFieldErrorDuplicateKeyCode:
+		return "Custom field " + e.FieldKey + " is already defined."
+	case CustomFieldErrorDeletedCode:
+		return "Custom field " + e.FieldKey + " is already deleted."
+	case CustomFieldErrorUndefinedCode:
+		return "Custom field " + e.FieldKey + " is not defined."
+	case CustomFieldErrorInvalidValueCode:
+		return "Custom field " + e.FieldKey + " has an invalid value."
+	case CustomFieldErrorRequiredCode:
+		return "Custom field " + e.FieldKey + " is required."
+	case CustomFieldErrorInvalidOptionsCode:
+		return "Custom field " + e.FieldKey + " needs a list of distinct options."
+	case CustomFieldErrorInvalidFilterCode:
+		return "Custom field " + e.FieldKey + " cannot be filtered, only the text and select fields can."
+	default:
+		return "Unrecognized Custom Field Error Code"
+	}
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type FieldDefined struct {
+	UID         uuid.UUID
+	FarmID      uuid.UUID
+	EntityType  string
+	FieldKey    string
+	Label       string
+	FieldType   string
+	Options     []string
+	Required    bool
+	CreatedDate time.Time
+}
+
+type FieldUpdated struct {
+	UID        uuid.UUID
+	FarmID     uuid.UUID
+	EntityType string
+	FieldKey   string
+	Label      string
+	FieldType  string
+	Options    []string
+	Required   bool
+}
+
+type FieldDeleted struct {
+	UID        uuid.UUID
+	FarmID     uuid.UUID
+	EntityType string
+	FieldKey   string
+	FieldType  string
+	Required   bool
+}
@@ -0,0 +1,146 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/assets/domain"
+)
+
+func TestDefineCustomField(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+
+	// When
+	definition, err := DefineCustomField(farmUID, CustomFieldEntityArea, "soil_ph", "", CustomFieldTypeNumber, nil, false)
+
+	// Then
+	assert.Nil(t, err)
+	assert.IsType(t, FieldDefined{}, definition.UncommittedChanges[0])
+
+	// When
+	err = definition.Update("Soil pH", CustomFieldTypeText, nil, true)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "soil_ph", definition.FieldKey)
+	assert.Equal(t, "Soil pH", definition.Label)
+	assert.Equal(t, CustomFieldTypeText, definition.FieldType)
+	assert.True(t, definition.Required)
+
+	// When
+	err = definition.Delete()
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, definition.IsDeleted)
+	assert.Equal(t, CustomFieldError{Code: CustomFieldErrorDeletedCode, FieldKey: "soil_ph"},
+		definition.Update("", CustomFieldTypeText, nil, false))
+
+	// When
+	_, invalidKeyErr := DefineCustomField(farmUID, CustomFieldEntityArea, "Soil pH", "", CustomFieldTypeNumber, nil, false)
+	_, invalidTypeErr := DefineCustomField(farmUID, CustomFieldEntityCrop, "variety", "", "color", nil, false)
+	_, invalidEntityErr := DefineCustomField(farmUID, "Reservoir", "variety", "", CustomFieldTypeText, nil, false)
+	_, noOptionsErr := DefineCustomField(farmUID, CustomFieldEntityTask, "shift", "", CustomFieldTypeSelect, nil, false)
+	_, duplicateOptionsErr := DefineCustomField(
+		farmUID, CustomFieldEntityTask, "shift", "", CustomFieldTypeSelect, []string{"Day", "Day"}, false)
+	selectDefinition, selectErr := DefineCustomField(
+		farmUID, CustomFieldEntityMaterial, "lot_number", "", CustomFieldTypeSelect, []string{"A1", "B2"}, false)
+
+	// Then
+	assert.Equal(t, CustomFieldError{Code: CustomFieldErrorInvalidKeyCode, FieldKey: "Soil pH"}, invalidKeyErr)
+	assert.Equal(t, CustomFieldError{Code: CustomFieldErrorInvalidTypeCode, FieldKey: "variety"}, invalidTypeErr)
+	assert.Equal(t, CustomFieldError{Code: CustomFieldErrorInvalidEntityTypeCode}, invalidEntityErr)
+	assert.Equal(t, CustomFieldError{Code: CustomFieldErrorInvalidOptionsCode, FieldKey: "shift"}, noOptionsErr)
+	assert.Equal(t, CustomFieldError{Code: CustomFieldErrorInvalidOptionsCode, FieldKey: "shift"}, duplicateOptionsErr)
+	assert.Nil(t, selectErr)
+	assert.Equal(t, "lot_number", selectDefinition.Label)
+	assert.Equal(t, []string{"A1", "B2"}, selectDefinition.Options)
+}
+
+func TestValidateCustomFieldValues(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	fields := []CustomFieldSchema{
+		{FieldKey: "soil_type", FieldType: CustomFieldTypeText, Required: true},
+		{FieldKey: "soil_ph", FieldType: CustomFieldTypeNumber},
+		{FieldKey: "irrigated", FieldType: CustomFieldTypeBoolean},
+		{FieldKey: "last_tilled", FieldType: CustomFieldTypeDate},
+		{FieldKey: "bench_row", FieldType: CustomFieldTypeSelect, Options: []string{"North", "South"}},
+	}
+
+	// When
+	values, err := ValidateCustomFieldValues(fields, map[string]interface{}{
+		"soil_type": "Loam", "soil_ph": 6.5, "irrigated": true, "last_tilled": "2024-03-01", "irrigated_by": nil,
+	})
+
+	// Then
+	assert.Equal(t, CustomFieldError{Code: CustomFieldErrorUndefinedCode, FieldKey: "irrigated_by"}, err)
+	assert.Nil(t, values)
+
+	// When
+	values, err = ValidateCustomFieldValues(fields, map[string]interface{}{
+		"soil_type": "Loam", "soil_ph": 6.5, "irrigated": nil, "last_tilled": "2024-03-01",
+	})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"soil_type": "Loam", "soil_ph": 6.5, "last_tilled": "2024-03-01"}, values)
+
+	// When
+	_, requiredErr := ValidateCustomFieldValues(fields, map[string]interface{}{"soil_type": ""})
+	_, numberErr := ValidateCustomFieldValues(fields, map[string]interface{}{"soil_type": "Loam", "soil_ph": "6.5"})
+	_, dateErr := ValidateCustomFieldValues(fields, map[string]interface{}{"soil_type": "Loam", "last_tilled": "01/03/2024"})
+	_, selectErr := ValidateCustomFieldValues(fields, map[string]interface{}{"soil_type": "Loam", "bench_row": "East"})
+
+	// Then
+	assert.Equal(t, CustomFieldError{Code: CustomFieldErrorRequiredCode, FieldKey: "soil_type"}, requiredErr)
+	assert.Equal(t, CustomFieldError{Code: CustomFieldErrorInvalidValueCode, FieldKey: "soil_ph"}, numberErr)
+	assert.Equal(t, CustomFieldError{Code: CustomFieldErrorInvalidValueCode, FieldKey: "last_tilled"}, dateErr)
+	assert.Equal(t, CustomFieldError{Code: CustomFieldErrorInvalidValueCode, FieldKey: "bench_row"}, selectErr)
+}
+
+func TestKeepRetiredCustomFieldValues(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	fields := []CustomFieldSchema{{FieldKey: "soil_type", FieldType: CustomFieldTypeText}}
+	current := map[string]interface{}{"soil_type": "Loam", "soil_ph": 6.5}
+
+	// When
+	values := KeepRetiredCustomFieldValues(fields, current, map[string]interface{}{"soil_type": "Clay"})
+
+	// Then
+	assert.Equal(t, map[string]interface{}{"soil_type": "Clay", "soil_ph": 6.5}, values)
+}
+
+func TestMatchCustomFieldFilters(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	fields := []CustomFieldSchema{
+		{FieldKey: "lot_number", FieldType: CustomFieldTypeText},
+		{FieldKey: "bench_row", FieldType: CustomFieldTypeSelect, Options: []string{"North", "South"}},
+		{FieldKey: "soil_ph", FieldType: CustomFieldTypeNumber},
+	}
+	values := map[string]interface{}{"lot_number": "LOT-2024-17", "bench_row": "North", "soil_ph": 6.5}
+
+	// When
+	textMatch, textErr := MatchCustomFieldFilters(fields, values, map[string]string{"lot_number": "2024"})
+	selectMatch, _ := MatchCustomFieldFilters(fields, values, map[string]string{"bench_row": "north"})
+	partialSelectMatch, _ := MatchCustomFieldFilters(fields, values, map[string]string{"bench_row": "Nor"})
+	emptyMatch, _ := MatchCustomFieldFilters(fields, map[string]interface{}{}, map[string]string{"lot_number": "2024"})
+	_, numberErr := MatchCustomFieldFilters(fields, values, map[string]string{"soil_ph": "6.5"})
+
+	// Then
+	assert.Nil(t, textErr)
+	assert.True(t, textMatch)
+	assert.True(t, selectMatch)
+	assert.False(t, partialSelectMatch)
+	assert.False(t, emptyMatch)
+	assert.Equal(t, CustomFieldError{Code: CustomFieldErrorInvalidFilterCode, FieldKey: "soil_ph"}, numberErr)
+}
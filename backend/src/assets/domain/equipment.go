@@ -0,0 +1,331 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	EquipmentTypePump       = "PUMP"
+	EquipmentTypeFan        = "FAN"
+	EquipmentTypeDosingUnit = "DOSING_UNIT"
+	EquipmentTypeLighting   = "LIGHTING"
+	EquipmentTypeSensor     = "SENSOR"
+	EquipmentTypeTractor    = "TRACTOR"
+	EquipmentTypeOther      = "OTHER"
+)
+
+// The assets an equipment can be located at.
+const (
+	EquipmentLocationArea      = "AREA"
+	EquipmentLocationReservoir = "RESERVOIR"
+)
+
+// EquipmentMaintenanceNotice is how long before the maintenance date its task is created.
+const EquipmentMaintenanceNotice = 7 * 24 * time.Hour
+
+type EquipmentType struct {
+	Code  string `json:"code"`
+	Label string `json:"label"`
+}
+
+func FindAllEquipmentTypes() []EquipmentType {
+	return []EquipmentType{
+		{Code: EquipmentTypePump, Label: "Pump"},
+		{Code: EquipmentTypeFan, Label: "Fan"},
+		{Code: EquipmentTypeDosingUnit, Label: "Dosing Unit"},
+		{Code: EquipmentTypeLighting, Label: "Lighting"},
+		{Code: EquipmentTypeSensor, Label: "Sensor"},
+		{Code: EquipmentTypeTractor, Label: "Tractor"},
+		{Code: EquipmentTypeOther, Label: "Other"},
+	}
+}
+
+// EquipmentLocation is the area or the reservoir of the farm where the equipment is.
+type EquipmentLocation struct {
+	Type string    `json:"type"`
+	UID  uuid.UUID `json:"uid"`
+}
+
+// EquipmentMaintenance is the recurring maintenance of an equipment. A zero interval has no maintenance.
+type EquipmentMaintenance struct {
+	IntervalDays int
+	// NextDate is the date of the next maintenance whose task isn't created yet.
+	NextDate *time.Time
+}
+
+// Equipment is a device or a machine of the farm, like a pump or a dosing unit.
+type Equipment struct {
+	UID          uuid.UUID
+	FarmUID      uuid.UUID
+	Name         string
+	Type         string
+	Location     *EquipmentLocation
+	PurchaseDate *time.Time
+	Notes        string
+	Maintenance  EquipmentMaintenance
+	GeoPoint     *GeoPoint
+	IsDeleted    bool
+	CreatedDate  time.Time
+
+	// Events
+	Version            int
+	UncommittedChanges []interface{}
+}
+
+// EquipmentDetails are the fields of an equipment set on its creation and updates.
+type EquipmentDetails struct {
+	Name         string
+	Type         string
+	Location     *EquipmentLocation
+	PurchaseDate *time.Time
+	Notes        string
+}
+
+// CreateEquipment registers the equipment of the farm. Its first maintenance is the interval after today
+// when the next maintenance date isn't given.
+func CreateEquipment(
+	farmUID uuid.UUID,
+	details EquipmentDetails,
+	maintenanceIntervalDays int,
+	nextMaintenanceDate *time.Time,
+	now time.Time,
+) (*Equipment, error) {
+	details, err := validateEquipmentDetails(details)
+	if err != nil {
+		return nil, err
+	}
+
+	maintenance, err := newEquipmentMaintenance(maintenanceIntervalDays, nextMaintenanceDate, now)
+	if err != nil {
+		return nil, err
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	initial := &Equipment{}
+
+	initial.TrackChange(EquipmentCreated{
+		UID:                     uid,
+		FarmUID:                 farmUID,
+		Name:                    details.Name,
+		Type:                    details.Type,
+		Location:                details.Location,
+		PurchaseDate:            details.PurchaseDate,
+		Notes:                   details.Notes,
+		MaintenanceIntervalDays: maintenance.IntervalDays,
+		NextMaintenanceDate:     maintenance.NextDate,
+		CreatedDate:             now,
+	})
+
+	return initial, nil
+}
+
+func (e *Equipment) Update(details EquipmentDetails) error {
+	if e.IsDeleted {
+		return EquipmentError{EquipmentErrorDeletedCode}
+	}
+
+	details, err := validateEquipmentDetails(details)
+	if err != nil {
+		return err
+	}
+
+	e.TrackChange(EquipmentUpdated{
+		UID:          e.UID,
+		FarmUID:      e.FarmUID,
+		Name:         details.Name,
+		Type:         details.Type,
+		Location:     details.Location,
+		PurchaseDate: details.PurchaseDate,
+		Notes:        details.Notes,
+	})
+
+	return nil
+}
+
+// ChangeMaintenance replaces the maintenance interval. The next maintenance stays on its date unless one is given,
+// or is the interval after today when the equipment had no maintenance.
+func (e *Equipment) ChangeMaintenance(intervalDays int, nextDate *time.Time, now time.Time) error {
+	if e.IsDeleted {
+		return EquipmentError{EquipmentErrorDeletedCode}
+	}
+
+	if nextDate == nil && e.Maintenance.IntervalDays > 0 {
+		nextDate = e.Maintenance.NextDate
+	}
+
+	maintenance, err := newEquipmentMaintenance(intervalDays, nextDate, now)
+	if err != nil {
+		return err
+	}
+
+	e.TrackChange(EquipmentMaintenanceChanged{
+		UID:                     e.UID,
+		FarmUID:                 e.FarmUID,
+		MaintenanceIntervalDays: maintenance.IntervalDays,
+		NextMaintenanceDate:     maintenance.NextDate,
+	})
+
+	return nil
+}
+
+// ChangeGeoPoint sets the GPS coordinate of the equipment, the one of its area isn't precise enough for
+// the tractors and the sensors.
+func (e *Equipment) ChangeGeoPoint(point GeoPoint) error {
+	if e.IsDeleted {
+		return EquipmentError{EquipmentErrorDeletedCode}
+	}
+
+	err := point.Validate()
+	if err != nil {
+		return err
+	}
+
+	e.TrackChange(EquipmentGeoPointChanged{
+		UID:      e.UID,
+		FarmUID:  e.FarmUID,
+		GeoPoint: &point,
+	})
+
+	return nil
+}
+
+func (e *Equipment) Delete() error {
+	if e.IsDeleted {
+		return EquipmentError{EquipmentErrorDeletedCode}
+	}
+
+	e.TrackChange(EquipmentDeleted{
+		UID:     e.UID,
+		FarmUID: e.FarmUID,
+		Name:    e.Name,
+	})
+
+	return nil
+}
+
+// NeedsMaintenance tells whether the task of the next maintenance has to be created.
+func (e Equipment) NeedsMaintenance(now time.Time) bool {
+	return !e.IsDeleted &&
+		e.Maintenance.IntervalDays > 0 &&
+		e.Maintenance.NextDate != nil &&
+		!now.Before(e.Maintenance.NextDate.Add(-EquipmentMaintenanceNotice))
+}
+
+// RequestMaintenance schedules the task of the next maintenance and moves the next one an interval later.
+// The maintenances missed while the scheduler didn't run only get the one task, the cadence is kept from
+// the first date.
+func (e *Equipment) RequestMaintenance(now time.Time) {
+	if !e.NeedsMaintenance(now) {
+		return
+	}
+
+	dueDate := *e.Maintenance.NextDate
+	nextDate := dueDate
+
+	for !now.Before(nextDate.Add(-EquipmentMaintenanceNotice)) {
+		nextDate = nextDate.AddDate(0, 0, e.Maintenance.IntervalDays)
+	}
+
+	e.TrackChange(EquipmentMaintenanceDue{
+		UID:                 e.UID,
+		FarmUID:             e.FarmUID,
+		Name:                e.Name,
+		Type:                e.Type,
+		DueDate:             dueDate,
+		NextMaintenanceDate: nextDate,
+	})
+}
+
+// Event Tracking.
+func (e *Equipment) TrackChange(event interface{}) {
+	e.UncommittedChanges = append(e.UncommittedChanges, event)
+	e.Transition(event)
+}
+
+func (e *Equipment) Transition(event interface{}) {
+	switch ev := event.(type) {
+	case EquipmentCreated:
+		e.UID = ev.UID
+		e.FarmUID = ev.FarmUID
+		e.Name = ev.Name
+		e.Type = ev.Type
+		e.Location = ev.Location
+		e.PurchaseDate = ev.PurchaseDate
+		e.Notes = ev.Notes
+		e.Maintenance = EquipmentMaintenance{
+			IntervalDays: ev.MaintenanceIntervalDays,
+			NextDate:     ev.NextMaintenanceDate,
+		}
+		e.CreatedDate = ev.CreatedDate
+	case EquipmentUpdated:
+		e.Name = ev.Name
+		e.Type = ev.Type
+		e.Location = ev.Location
+		e.PurchaseDate = ev.PurchaseDate
+		e.Notes = ev.Notes
+	case EquipmentMaintenanceChanged:
+		e.Maintenance = EquipmentMaintenance{
+			IntervalDays: ev.MaintenanceIntervalDays,
+			NextDate:     ev.NextMaintenanceDate,
+		}
+	case EquipmentMaintenanceDue:
+		nextDate := ev.NextMaintenanceDate
+		e.Maintenance.NextDate = &nextDate
+	case EquipmentGeoPointChanged:
+		e.GeoPoint = ev.GeoPoint
+	case EquipmentDeleted:
+		e.IsDeleted = true
+	}
+}
+
+func validateEquipmentDetails(details EquipmentDetails) (EquipmentDetails, error) {
+	details.Name = strings.TrimSpace(details.Name)
+	details.Notes = strings.TrimSpace(details.Notes)
+
+	if details.Name == "" {
+		return EquipmentDetails{}, EquipmentError{EquipmentErrorNameEmptyCode}
+	}
+
+	found := false
+
+	for _, v := range FindAllEquipmentTypes() {
+		if v.Code == details.Type {
+			found = true
+		}
+	}
+
+	if !found {
+		return EquipmentDetails{}, EquipmentError{EquipmentErrorInvalidTypeCode}
+	}
+
+	if details.Location != nil &&
+		details.Location.Type != EquipmentLocationArea && details.Location.Type != EquipmentLocationReservoir {
+		return EquipmentDetails{}, EquipmentError{EquipmentErrorInvalidLocationCode}
+	}
+
+	return details, nil
+}
+
+func newEquipmentMaintenance(intervalDays int, nextDate *time.Time, now time.Time) (EquipmentMaintenance, error) {
+	if intervalDays < 0 {
+		return EquipmentMaintenance{}, EquipmentError{EquipmentErrorInvalidMaintenanceIntervalCode}
+	}
+
+	if intervalDays == 0 {
+		return EquipmentMaintenance{}, nil
+	}
+
+	if nextDate == nil {
+		date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, intervalDays)
+		nextDate = &date
+	}
+
+	return EquipmentMaintenance{IntervalDays: intervalDays, NextDate: nextDate}, nil
+}
@@ -0,0 +1,31 @@
+package domain
+
+// EquipmentError is a custom error from Go built-in error.
+type EquipmentError struct {
+	Code int
+}
+
+const (
+	EquipmentErrorNameEmptyCode = iota
+	EquipmentErrorInvalidTypeCode
+	EquipmentErrorInvalidLocationCode
+	EquipmentErrorInvalidMaintenanceIntervalCode
+	EquipmentErrorDeletedCode
+)
+
+func (e EquipmentError) Error() string {
+	switch e.Code {
+	case EquipmentErrorNameEmptyCode:
+		return "Equipment name is required."
+	case EquipmentErrorInvalidTypeCode:
+		return "Equipment type is invalid."
+	case EquipmentErrorInvalidLocationCode:
+		return "Equipment location must be an area or a reservoir."
+	case EquipmentErrorInvalidMaintenanceIntervalCode:
+		return "Equipment maintenance interval can't be negative."
+	case EquipmentErrorDeletedCode:
+		return "Equipment is already deleted."
+	default:
+		return "Unrecognized Equipment Error Code"
+	}
+}
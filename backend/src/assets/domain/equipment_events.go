@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type EquipmentCreated struct {
+	UID                     uuid.UUID
+	FarmUID                 uuid.UUID
+	Name                    string
+	Type                    string
+	Location                *EquipmentLocation
+	PurchaseDate            *time.Time
+	Notes                   string
+	MaintenanceIntervalDays int
+	NextMaintenanceDate     *time.Time
+	CreatedDate             time.Time
+}
+
+type EquipmentUpdated struct {
+	UID          uuid.UUID
+	FarmUID      uuid.UUID
+	Name         string
+	Type         string
+	Location     *EquipmentLocation
+	PurchaseDate *time.Time
+	Notes        string
+}
+
+type EquipmentMaintenanceChanged struct {
+	UID                     uuid.UUID
+	FarmUID                 uuid.UUID
+	MaintenanceIntervalDays int
+	NextMaintenanceDate     *time.Time
+}
+
+// EquipmentMaintenanceDue is published once per maintenance, when its task has to be created.
+type EquipmentMaintenanceDue struct {
+	UID                 uuid.UUID
+	FarmUID             uuid.UUID
+	Name                string
+	Type                string
+	DueDate             time.Time
+	NextMaintenanceDate time.Time
+}
+
+type EquipmentGeoPointChanged struct {
+	UID      uuid.UUID
+	FarmUID  uuid.UUID
+	GeoPoint *GeoPoint
+}
+
+type EquipmentDeleted struct {
+	UID     uuid.UUID
+	FarmUID uuid.UUID
+	Name    string
+}
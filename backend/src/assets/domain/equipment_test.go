@@ -0,0 +1,90 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/assets/domain"
+)
+
+func TestEquipmentMaintenance(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	nextDate := now.AddDate(0, 0, 10)
+
+	equipment, err := CreateEquipment(farmUID, EquipmentDetails{Name: " Main Pump ", Type: EquipmentTypePump},
+		30, &nextDate, now)
+	assert.Nil(t, err)
+
+	// When
+	equipment.RequestMaintenance(now)
+	early := len(equipment.UncommittedChanges)
+
+	equipment.RequestMaintenance(now.AddDate(0, 0, 4))
+	equipment.RequestMaintenance(now.AddDate(0, 0, 5))
+
+	// Then
+	assert.Equal(t, "Main Pump", equipment.Name)
+	assert.Equal(t, 1, early)
+	assert.Len(t, equipment.UncommittedChanges, 2)
+
+	due := equipment.UncommittedChanges[1].(EquipmentMaintenanceDue)
+	assert.Equal(t, nextDate, due.DueDate)
+	assert.Equal(t, nextDate.AddDate(0, 0, 30), *equipment.Maintenance.NextDate)
+
+	// When
+	equipment.RequestMaintenance(now.AddDate(0, 4, 0))
+
+	// Then
+	due = equipment.UncommittedChanges[2].(EquipmentMaintenanceDue)
+	assert.Equal(t, nextDate.AddDate(0, 0, 30), due.DueDate)
+	assert.Equal(t, nextDate.AddDate(0, 0, 120), *equipment.Maintenance.NextDate)
+
+	// When
+	err = equipment.ChangeMaintenance(0, nil, now)
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, equipment.NeedsMaintenance(now.AddDate(1, 0, 0)))
+
+	// When
+	err = equipment.Delete()
+	errAgain := equipment.Delete()
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, EquipmentError{EquipmentErrorDeletedCode}, errAgain)
+}
+
+func TestCreateEquipmentValidation(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+	now := time.Date(2024, 3, 1, 13, 30, 0, 0, time.UTC)
+	areaUID, _ := uuid.NewV4()
+
+	// When
+	_, errName := CreateEquipment(farmUID, EquipmentDetails{Name: " ", Type: EquipmentTypeFan}, 0, nil, now)
+	_, errType := CreateEquipment(farmUID, EquipmentDetails{Name: "Fan", Type: "BLENDER"}, 0, nil, now)
+	_, errLocation := CreateEquipment(farmUID, EquipmentDetails{
+		Name: "Fan", Type: EquipmentTypeFan, Location: &EquipmentLocation{Type: "CROP", UID: areaUID},
+	}, 0, nil, now)
+	_, errInterval := CreateEquipment(farmUID, EquipmentDetails{Name: "Fan", Type: EquipmentTypeFan}, -1, nil, now)
+	equipment, err := CreateEquipment(farmUID, EquipmentDetails{
+		Name: "Fan", Type: EquipmentTypeFan, Location: &EquipmentLocation{Type: EquipmentLocationArea, UID: areaUID},
+	}, 14, nil, now)
+
+	// Then
+	assert.Equal(t, EquipmentError{EquipmentErrorNameEmptyCode}, errName)
+	assert.Equal(t, EquipmentError{EquipmentErrorInvalidTypeCode}, errType)
+	assert.Equal(t, EquipmentError{EquipmentErrorInvalidLocationCode}, errLocation)
+	assert.Equal(t, EquipmentError{EquipmentErrorInvalidMaintenanceIntervalCode}, errInterval)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), *equipment.Maintenance.NextDate)
+}
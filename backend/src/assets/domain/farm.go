@@ -3,9 +3,13 @@
 package domain
 
 import (
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
 )
 
 type Farm struct {
@@ -19,11 +23,79 @@ type Farm struct {
 	IsActive    bool      `json:"is_active"`
 	CreatedDate time.Time `json:"created_date"`
 
+	AreaWalkOrder []uuid.UUID `json:"area_walk_order"`
+
+	// HarvestGrades are the quality grades the harvests of the farm are broken down into.
+	HarvestGrades []HarvestGrade `json:"harvest_grades"`
+
+	// Seasons are the seasons the analytics of the farm are grouped by, the ones of its hemisphere until it sets its own.
+	Seasons []timebuckethelper.Season `json:"seasons"`
+
+	// WithholdingPolicy is what a harvest inside the withholding period of a pesticide treatment does.
+	WithholdingPolicy string `json:"withholding_policy"`
+
+	// DailyEffortBudgetMinutes is the effort of the tasks the farm plans a day, zero when it isn't budgeted.
+	DailyEffortBudgetMinutes int `json:"daily_effort_budget_minutes"`
+
 	// Events
 	Version            int
 	UncommittedChanges []interface{}
 }
 
+// HarvestGrade is a quality grade of the harvested produce. Only the sellable grades count as sellable quantity.
+type HarvestGrade struct {
+	Code     string `json:"code"`
+	Sellable bool   `json:"sellable"`
+}
+
+// harvestGradeCodePattern keeps the grade codes short, they're typed in the harvest form.
+var harvestGradeCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{1,20}$`)
+
+// DefaultHarvestGrades are the grades of the farms which haven't defined theirs.
+func DefaultHarvestGrades() []HarvestGrade {
+	return []HarvestGrade{
+		{Code: "A", Sellable: true},
+		{Code: "B", Sellable: true},
+		{Code: "REJECT", Sellable: false},
+	}
+}
+
+// HarvestGradesOrDefault returns the default grades when the farm has none.
+func HarvestGradesOrDefault(grades []HarvestGrade) []HarvestGrade {
+	if len(grades) == 0 {
+		return DefaultHarvestGrades()
+	}
+
+	return grades
+}
+
+// SeasonsOrDefault returns the meteorological seasons of the hemisphere of the latitude when the farm has none.
+func SeasonsOrDefault(seasons []timebuckethelper.Season, latitude string) []timebuckethelper.Season {
+	if len(seasons) > 0 {
+		return seasons
+	}
+
+	value, err := strconv.ParseFloat(latitude, 64)
+
+	return timebuckethelper.DefaultSeasons(err == nil && value < 0)
+}
+
+const (
+	// WithholdingPolicyBlock refuses the harvests inside the withholding period.
+	WithholdingPolicyBlock = "BLOCK"
+	// WithholdingPolicyWarn lets the harvests inside the withholding period through with a recorded reason.
+	WithholdingPolicyWarn = "WARN"
+)
+
+// WithholdingPolicyOrDefault returns the blocking policy when the farm hasn't set one.
+func WithholdingPolicyOrDefault(policy string) string {
+	if policy == "" {
+		return WithholdingPolicyBlock
+	}
+
+	return policy
+}
+
 type FarmService interface {
 	GetCountryNameByCode() string
 }
@@ -64,6 +136,21 @@ func (f *Farm) Transition(event interface{}) {
 	case FarmRegionChanged:
 		f.Country = e.Country
 		f.City = e.City
+
+	case FarmAreaWalkOrderChanged:
+		f.AreaWalkOrder = e.AreaUIDs
+
+	case FarmHarvestGradesChanged:
+		f.HarvestGrades = e.Grades
+
+	case FarmSeasonsChanged:
+		f.Seasons = e.Seasons
+
+	case FarmWithholdingPolicyChanged:
+		f.WithholdingPolicy = e.Policy
+
+	case FarmDailyEffortBudgetChanged:
+		f.DailyEffortBudgetMinutes = e.Minutes
 	}
 }
 
@@ -179,3 +266,112 @@ func (f *Farm) ChangeRegion(country, city string) error {
 
 	return nil
 }
+
+// ChangeAreaWalkOrder sets the order the crew walks through the areas of the farm.
+// The areas left out are walked last.
+func (f *Farm) ChangeAreaWalkOrder(areaUIDs []uuid.UUID) error {
+	listed := map[uuid.UUID]bool{}
+
+	for _, v := range areaUIDs {
+		if listed[v] {
+			return FarmError{FarmErrorAreaWalkOrderDuplicateCode}
+		}
+
+		listed[v] = true
+	}
+
+	f.TrackChange(FarmAreaWalkOrderChanged{
+		FarmUID:  f.UID,
+		AreaUIDs: areaUIDs,
+	})
+
+	return nil
+}
+
+// ChangeHarvestGrades replaces the quality grades of the farm. The codes are uppercased,
+// so they match the grades of the harvests however they're typed.
+func (f *Farm) ChangeHarvestGrades(grades []HarvestGrade) error {
+	if len(grades) == 0 {
+		return FarmError{FarmErrorHarvestGradesEmptyCode}
+	}
+
+	listed := map[string]bool{}
+	changed := []HarvestGrade{}
+
+	for _, v := range grades {
+		code := strings.ToUpper(strings.TrimSpace(v.Code))
+		if !harvestGradeCodePattern.MatchString(code) {
+			return FarmError{FarmErrorHarvestGradeInvalidCode}
+		}
+
+		if listed[code] {
+			return FarmError{FarmErrorHarvestGradeDuplicateCode}
+		}
+
+		listed[code] = true
+
+		changed = append(changed, HarvestGrade{Code: code, Sellable: v.Sellable})
+	}
+
+	f.TrackChange(FarmHarvestGradesChanged{
+		FarmUID: f.UID,
+		Grades:  changed,
+	})
+
+	return nil
+}
+
+// ChangeSeasons replaces the seasons of the farm, no seasons go back to the ones of its hemisphere.
+func (f *Farm) ChangeSeasons(seasons []timebuckethelper.Season) error {
+	changed := []timebuckethelper.Season{}
+
+	for _, v := range seasons {
+		changed = append(changed, timebuckethelper.Season{
+			Name:       strings.TrimSpace(v.Name),
+			StartMonth: v.StartMonth,
+			StartDay:   v.StartDay,
+		})
+	}
+
+	if len(changed) > 0 && timebuckethelper.ValidateSeasons(changed) != nil {
+		return FarmError{FarmErrorSeasonsInvalidCode}
+	}
+
+	f.TrackChange(FarmSeasonsChanged{
+		FarmUID: f.UID,
+		Seasons: changed,
+	})
+
+	return nil
+}
+
+// ChangeWithholdingPolicy sets whether the harvests inside the withholding period are blocked or only warned about.
+func (f *Farm) ChangeWithholdingPolicy(policy string) error {
+	if policy != WithholdingPolicyBlock && policy != WithholdingPolicyWarn {
+		return FarmError{FarmErrorWithholdingPolicyInvalidCode}
+	}
+
+	f.TrackChange(FarmWithholdingPolicyChanged{
+		FarmUID: f.UID,
+		Policy:  policy,
+	})
+
+	return nil
+}
+
+// maxDailyEffortBudgetMinutes is a whole day of work.
+const maxDailyEffortBudgetMinutes = 24 * 60
+
+// ChangeDailyEffortBudget sets the minutes of the generated tasks the farm plans a day, zero removes the budget.
+func (f *Farm) ChangeDailyEffortBudget(minutes int) error {
+	if minutes < 0 || minutes > maxDailyEffortBudgetMinutes {
+		return FarmError{FarmErrorDailyEffortBudgetInvalidCode}
+	}
+
+	f.TrackChange(FarmDailyEffortBudgetChanged{
+		FarmUID: f.UID,
+		Minutes: minutes,
+	})
+
+	return nil
+}
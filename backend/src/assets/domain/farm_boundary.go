@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// The assets whose GPS coordinate is checked against the farm boundary.
+const (
+	BoundaryAssetArea      = "AREA"
+	BoundaryAssetEquipment = "EQUIPMENT"
+)
+
+// GeoPoint is a GPS coordinate, in decimal degrees.
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+func (p GeoPoint) Validate() error {
+	if math.IsNaN(p.Latitude) || p.Latitude < -90 || p.Latitude > 90 ||
+		math.IsNaN(p.Longitude) || p.Longitude < -180 || p.Longitude > 180 {
+		return FarmBoundaryError{FarmBoundaryErrorInvalidPointCode}
+	}
+
+	return nil
+}
+
+// FarmBoundary is the polygon of the farm on the map. A farm has at most one, so it's identified by the farm.
+type FarmBoundary struct {
+	FarmUID      uuid.UUID
+	Polygon      []GeoPoint
+	CreatedDate  time.Time
+	ModifiedDate *time.Time
+
+	// Events
+	Version            int
+	UncommittedChanges []interface{}
+}
+
+func DefineFarmBoundary(farmUID uuid.UUID, polygon []GeoPoint) (*FarmBoundary, error) {
+	err := validateBoundaryPolygon(polygon)
+	if err != nil {
+		return nil, err
+	}
+
+	initial := &FarmBoundary{}
+
+	initial.TrackChange(BoundaryDefined{
+		FarmID:      farmUID,
+		Polygon:     polygon,
+		CreatedDate: time.Now(),
+	})
+
+	return initial, nil
+}
+
+func (fb *FarmBoundary) Modify(polygon []GeoPoint) error {
+	err := validateBoundaryPolygon(polygon)
+	if err != nil {
+		return err
+	}
+
+	fb.TrackChange(BoundaryModified{
+		FarmID:       fb.FarmUID,
+		Polygon:      polygon,
+		ModifiedDate: time.Now(),
+	})
+
+	return nil
+}
+
+// Contains tells whether the point is inside the polygon, by casting a ray from it and counting the edges
+// it crosses. The polygon is small enough on the earth to be taken as flat.
+func (fb FarmBoundary) Contains(point GeoPoint) bool {
+	inside := false
+
+	for i, j := 0, len(fb.Polygon)-1; i < len(fb.Polygon); j, i = i, i+1 {
+		a, b := fb.Polygon[i], fb.Polygon[j]
+
+		if (a.Latitude > point.Latitude) != (b.Latitude > point.Latitude) &&
+			point.Longitude < (b.Longitude-a.Longitude)*(point.Latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+// Event Tracking.
+func (fb *FarmBoundary) TrackChange(event interface{}) {
+	fb.UncommittedChanges = append(fb.UncommittedChanges, event)
+	fb.Transition(event)
+}
+
+func (fb *FarmBoundary) Transition(event interface{}) {
+	switch e := event.(type) {
+	case BoundaryDefined:
+		fb.FarmUID = e.FarmID
+		fb.Polygon = e.Polygon
+		fb.CreatedDate = e.CreatedDate
+	case BoundaryModified:
+		modifiedDate := e.ModifiedDate
+		fb.Polygon = e.Polygon
+		fb.ModifiedDate = &modifiedDate
+	}
+}
+
+func validateBoundaryPolygon(polygon []GeoPoint) error {
+	if len(polygon) < 3 {
+		return FarmBoundaryError{FarmBoundaryErrorNotEnoughVerticesCode}
+	}
+
+	for _, v := range polygon {
+		err := v.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BoundaryAsset is an area or an equipment with a GPS coordinate.
+type BoundaryAsset struct {
+	Type  string    `json:"type"`
+	UID   uuid.UUID `json:"uid"`
+	Name  string    `json:"name"`
+	Point GeoPoint  `json:"point"`
+}
+
+// ContainmentValidator checks the GPS coordinates of the assets against the farm boundary. It doesn't
+// block the assets outside, it records a CoordinateOutsideBoundary warning on the boundary instead.
+type ContainmentValidator struct {
+	// Boundary is nil when the farm has none, then every coordinate is inside.
+	Boundary *FarmBoundary
+}
+
+// Validate tells whether the asset is inside the boundary, and tracks the warning when it isn't.
+func (v ContainmentValidator) Validate(asset BoundaryAsset) bool {
+	if v.Boundary == nil || v.Boundary.Contains(asset.Point) {
+		return true
+	}
+
+	v.Boundary.TrackChange(CoordinateOutsideBoundary{
+		FarmID:    v.Boundary.FarmUID,
+		AssetType: asset.Type,
+		AssetUID:  asset.UID,
+		Point:     asset.Point,
+	})
+
+	return false
+}
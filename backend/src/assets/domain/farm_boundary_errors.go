@@ -0,0 +1,22 @@
+package domain
+
+// FarmBoundaryError is a custom error from Go built-in error.
+type FarmBoundaryError struct {
+	Code int
+}
+
+const (
+	FarmBoundaryErrorNotEnoughVerticesCode = iota
+	FarmBoundaryErrorInvalidPointCode
+)
+
+func (e FarmBoundaryError) Error() string {
+	switch e.Code {
+	case FarmBoundaryErrorNotEnoughVerticesCode:
+		return "Farm boundary needs at least 3 vertices."
+	case FarmBoundaryErrorInvalidPointCode:
+		return "GPS coordinate needs a latitude between -90 and 90 and a longitude between -180 and 180."
+	default:
+		return "Unrecognized Farm Boundary Error Code"
+	}
+}
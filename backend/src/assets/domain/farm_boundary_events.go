@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type BoundaryDefined struct {
+	FarmID      uuid.UUID
+	Polygon     []GeoPoint
+	CreatedDate time.Time
+}
+
+type BoundaryModified struct {
+	FarmID       uuid.UUID
+	Polygon      []GeoPoint
+	ModifiedDate time.Time
+}
+
+// CoordinateOutsideBoundary is a warning, the asset is saved with its coordinate anyway.
+type CoordinateOutsideBoundary struct {
+	FarmID    uuid.UUID
+	AssetType string
+	AssetUID  uuid.UUID
+	Point     GeoPoint
+}
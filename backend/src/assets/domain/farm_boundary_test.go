@@ -0,0 +1,82 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/assets/domain"
+)
+
+func TestFarmBoundaryContainment(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+	areaUID, _ := uuid.NewV4()
+	equipmentUID, _ := uuid.NewV4()
+
+	// An L shaped farm, the top right corner is the neighbour's.
+	boundary, err := DefineFarmBoundary(farmUID, []GeoPoint{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 0, Longitude: 2},
+		{Latitude: 1, Longitude: 2},
+		{Latitude: 1, Longitude: 1},
+		{Latitude: 2, Longitude: 1},
+		{Latitude: 2, Longitude: 0},
+	})
+	assert.Nil(t, err)
+
+	validator := ContainmentValidator{Boundary: boundary}
+
+	// When
+	inside := validator.Validate(BoundaryAsset{
+		Type: BoundaryAssetArea, UID: areaUID, Point: GeoPoint{Latitude: 0.5, Longitude: 1.5},
+	})
+	outside := validator.Validate(BoundaryAsset{
+		Type: BoundaryAssetEquipment, UID: equipmentUID, Point: GeoPoint{Latitude: 1.5, Longitude: 1.5},
+	})
+
+	// Then
+	assert.True(t, inside)
+	assert.False(t, outside)
+	assert.Len(t, boundary.UncommittedChanges, 2)
+	assert.Equal(t, CoordinateOutsideBoundary{
+		FarmID:    farmUID,
+		AssetType: BoundaryAssetEquipment,
+		AssetUID:  equipmentUID,
+		Point:     GeoPoint{Latitude: 1.5, Longitude: 1.5},
+	}, boundary.UncommittedChanges[1])
+	assert.True(t, ContainmentValidator{}.Validate(BoundaryAsset{Point: GeoPoint{Latitude: 45, Longitude: 90}}))
+}
+
+func TestFarmBoundaryValidation(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+	triangle := []GeoPoint{{Latitude: 0, Longitude: 0}, {Latitude: 0, Longitude: 1}, {Latitude: 1, Longitude: 0}}
+
+	// When
+	_, errVertices := DefineFarmBoundary(farmUID, triangle[:2])
+	_, errPoint := DefineFarmBoundary(farmUID, append([]GeoPoint{{Latitude: 91, Longitude: 0}}, triangle...))
+	boundary, err := DefineFarmBoundary(farmUID, triangle)
+
+	// Then
+	assert.Equal(t, FarmBoundaryError{FarmBoundaryErrorNotEnoughVerticesCode}, errVertices)
+	assert.Equal(t, FarmBoundaryError{FarmBoundaryErrorInvalidPointCode}, errPoint)
+	assert.Nil(t, err)
+
+	// When
+	errModify := boundary.Modify(triangle[1:])
+	err = boundary.Modify([]GeoPoint{
+		{Latitude: 0, Longitude: 0}, {Latitude: 0, Longitude: 1}, {Latitude: 1, Longitude: 1}, {Latitude: 1, Longitude: 0},
+	})
+
+	// Then
+	assert.Equal(t, FarmBoundaryError{FarmBoundaryErrorNotEnoughVerticesCode}, errModify)
+	assert.Nil(t, err)
+	assert.Len(t, boundary.Polygon, 4)
+	assert.NotNil(t, boundary.ModifiedDate)
+	assert.True(t, boundary.Contains(GeoPoint{Latitude: 0.9, Longitude: 0.9}))
+}
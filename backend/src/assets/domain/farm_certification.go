@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	CertificationTypeOrganic   = "ORGANIC"
+	CertificationTypeGAP       = "GAP"
+	CertificationTypeGlobalGAP = "GLOBALGAP"
+)
+
+const (
+	CertificationStatusActive = "ACTIVE"
+	// CertificationStatusExpired is only computed on read, the expiry doesn't need an event.
+	CertificationStatusExpired = "EXPIRED"
+	CertificationStatusRevoked = "REVOKED"
+)
+
+// CertificationRenewalNotice is how long before the expiry the renewal task is created.
+const CertificationRenewalNotice = 60 * 24 * time.Hour
+
+type CertificationType struct {
+	Code  string `json:"code"`
+	Label string `json:"label"`
+}
+
+func FindAllCertificationTypes() []CertificationType {
+	return []CertificationType{
+		{Code: CertificationTypeOrganic, Label: "Organic"},
+		{Code: CertificationTypeGAP, Label: "GAP"},
+		{Code: CertificationTypeGlobalGAP, Label: "GlobalG.A.P."},
+	}
+}
+
+// FarmCertification is a certificate granted to a farm by a certifying body.
+type FarmCertification struct {
+	UID               uuid.UUID
+	FarmID            uuid.UUID
+	CertificationType string
+	CertifyingBody    string
+	IssuedDate        time.Time
+	ExpiryDate        time.Time
+	CertificateNumber string
+	Status            string
+	IsRenewalDue      bool
+	RevokedDate       *time.Time
+	CreatedDate       time.Time
+
+	// Events
+	Version            int
+	UncommittedChanges []interface{}
+}
+
+func GrantFarmCertification(
+	farmID uuid.UUID,
+	certificationType, certifyingBody, certificateNumber string,
+	issuedDate, expiryDate time.Time,
+) (*FarmCertification, error) {
+	err := validateFarmCertification(certificationType, certifyingBody, certificateNumber, issuedDate, expiryDate)
+	if err != nil {
+		return nil, err
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	initial := &FarmCertification{}
+
+	initial.TrackChange(CertificationGranted{
+		UID:               uid,
+		FarmID:            farmID,
+		CertificationType: certificationType,
+		CertifyingBody:    strings.TrimSpace(certifyingBody),
+		IssuedDate:        issuedDate,
+		ExpiryDate:        expiryDate,
+		CertificateNumber: strings.TrimSpace(certificateNumber),
+		CreatedDate:       time.Now(),
+	})
+
+	return initial, nil
+}
+
+// Renew replaces the certificate after a new audit. The type stays the same.
+func (fc *FarmCertification) Renew(certifyingBody, certificateNumber string, issuedDate, expiryDate time.Time) error {
+	if fc.Status == CertificationStatusRevoked {
+		return FarmCertificationError{FarmCertificationErrorRevokedCode}
+	}
+
+	err := validateFarmCertification(fc.CertificationType, certifyingBody, certificateNumber, issuedDate, expiryDate)
+	if err != nil {
+		return err
+	}
+
+	fc.TrackChange(CertificationRenewed{
+		UID:               fc.UID,
+		FarmID:            fc.FarmID,
+		CertificationType: fc.CertificationType,
+		CertifyingBody:    strings.TrimSpace(certifyingBody),
+		IssuedDate:        issuedDate,
+		ExpiryDate:        expiryDate,
+		CertificateNumber: strings.TrimSpace(certificateNumber),
+	})
+
+	return nil
+}
+
+func (fc *FarmCertification) Revoke(revokedDate time.Time) error {
+	if fc.Status == CertificationStatusRevoked {
+		return FarmCertificationError{FarmCertificationErrorRevokedCode}
+	}
+
+	fc.TrackChange(CertificationRevoked{
+		UID:               fc.UID,
+		FarmID:            fc.FarmID,
+		CertificationType: fc.CertificationType,
+		CertifyingBody:    fc.CertifyingBody,
+		IssuedDate:        fc.IssuedDate,
+		ExpiryDate:        fc.ExpiryDate,
+		CertificateNumber: fc.CertificateNumber,
+		RevokedDate:       revokedDate,
+	})
+
+	return nil
+}
+
+// NeedsRenewal tells whether the renewal has to be prepared and wasn't requested yet.
+func (fc FarmCertification) NeedsRenewal(now time.Time) bool {
+	return fc.Status == CertificationStatusActive &&
+		!fc.IsRenewalDue &&
+		!now.Before(fc.ExpiryDate.Add(-CertificationRenewalNotice))
+}
+
+func (fc *FarmCertification) RequestRenewal(now time.Time) {
+	if !fc.NeedsRenewal(now) {
+		return
+	}
+
+	fc.TrackChange(CertificationRenewalDue{
+		UID:               fc.UID,
+		FarmID:            fc.FarmID,
+		CertificationType: fc.CertificationType,
+		CertifyingBody:    fc.CertifyingBody,
+		ExpiryDate:        fc.ExpiryDate,
+		CertificateNumber: fc.CertificateNumber,
+	})
+}
+
+// Event Tracking.
+func (fc *FarmCertification) TrackChange(event interface{}) {
+	fc.UncommittedChanges = append(fc.UncommittedChanges, event)
+	fc.Transition(event)
+}
+
+func (fc *FarmCertification) Transition(event interface{}) {
+	switch e := event.(type) {
+	case CertificationGranted:
+		fc.UID = e.UID
+		fc.FarmID = e.FarmID
+		fc.CertificationType = e.CertificationType
+		fc.CertifyingBody = e.CertifyingBody
+		fc.IssuedDate = e.IssuedDate
+		fc.ExpiryDate = e.ExpiryDate
+		fc.CertificateNumber = e.CertificateNumber
+		fc.Status = CertificationStatusActive
+		fc.CreatedDate = e.CreatedDate
+	case CertificationRenewed:
+		fc.CertifyingBody = e.CertifyingBody
+		fc.IssuedDate = e.IssuedDate
+		fc.ExpiryDate = e.ExpiryDate
+		fc.CertificateNumber = e.CertificateNumber
+		fc.IsRenewalDue = false
+	case CertificationRevoked:
+		revokedDate := e.RevokedDate
+		fc.RevokedDate = &revokedDate
+		fc.Status = CertificationStatusRevoked
+	case CertificationRenewalDue:
+		fc.IsRenewalDue = true
+	}
+}
+
+func validateFarmCertification(
+	certificationType, certifyingBody, certificateNumber string,
+	issuedDate, expiryDate time.Time,
+) error {
+	found := false
+
+	for _, v := range FindAllCertificationTypes() {
+		if v.Code == certificationType {
+			found = true
+		}
+	}
+
+	if !found {
+		return FarmCertificationError{FarmCertificationErrorInvalidTypeCode}
+	}
+
+	if strings.TrimSpace(certifyingBody) == "" {
+		return FarmCertificationError{FarmCertificationErrorCertifyingBodyEmptyCode}
+	}
+
+	if strings.TrimSpace(certificateNumber) == "" {
+		return FarmCertificationError{FarmCertificationErrorCertificateNumberEmptyCode}
+	}
+
+	if !expiryDate.After(issuedDate) {
+		return FarmCertificationError{FarmCertificationErrorInvalidExpiryDateCode}
+	}
+
+	return nil
+}
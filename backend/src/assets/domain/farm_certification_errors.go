@@ -0,0 +1,31 @@
+package domain
+
+// FarmCertificationError is a custom error from Go built-in error.
+type FarmCertificationError struct {
+	Code int
+}
+
+const (
+	FarmCertificationErrorInvalidTypeCode = iota
+	FarmCertificationErrorCertifyingBodyEmptyCode
+	FarmCertificationErrorCertificateNumberEmptyCode
+	FarmCertificationErrorInvalidExpiryDateCode
+	FarmCertificationErrorRevokedCode
+)
+
+func (e FarmCertificationError) Error() string {
+	switch e.Code {
+	case FarmCertificationErrorInvalidTypeCode:
+		return "Certification type is invalid."
+	case FarmCertificationErrorCertifyingBodyEmptyCode:
+		return "Certifying body is required."
+	case FarmCertificationErrorCertificateNumberEmptyCode:
+		return "Certificate number is required."
+	case FarmCertificationErrorInvalidExpiryDateCode:
+		return "Certification expiry date must be after its issued date."
+	case FarmCertificationErrorRevokedCode:
+		return "Certification is already revoked."
+	default:
+		return "Unrecognized Farm Certification Error Code"
+	}
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type CertificationGranted struct {
+	UID               uuid.UUID
+	FarmID            uuid.UUID
+	CertificationType string
+	CertifyingBody    string
+	IssuedDate        time.Time
+	ExpiryDate        time.Time
+	CertificateNumber string
+	CreatedDate       time.Time
+}
+
+type CertificationRenewed struct {
+	UID               uuid.UUID
+	FarmID            uuid.UUID
+	CertificationType string
+	CertifyingBody    string
+	IssuedDate        time.Time
+	ExpiryDate        time.Time
+	CertificateNumber string
+}
+
+type CertificationRevoked struct {
+	UID               uuid.UUID
+	FarmID            uuid.UUID
+	CertificationType string
+	CertifyingBody    string
+	IssuedDate        time.Time
+	ExpiryDate        time.Time
+	CertificateNumber string
+	RevokedDate       time.Time
+}
+
+// CertificationRenewalDue is published once per certificate, when its renewal has to be prepared.
+type CertificationRenewalDue struct {
+	UID               uuid.UUID
+	FarmID            uuid.UUID
+	CertificationType string
+	CertifyingBody    string
+	ExpiryDate        time.Time
+	CertificateNumber string
+}
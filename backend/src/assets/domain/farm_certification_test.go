@@ -0,0 +1,76 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/assets/domain"
+)
+
+func TestFarmCertificationRenewal(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	issuedDate := now.AddDate(-1, 0, 0)
+
+	certification, err := GrantFarmCertification(
+		farmUID, CertificationTypeOrganic, "Control Union", "CU-123", issuedDate, now.AddDate(0, 3, 0))
+	assert.Nil(t, err)
+
+	// When
+	certification.RequestRenewal(now)
+	early := len(certification.UncommittedChanges)
+
+	certification.RequestRenewal(now.AddDate(0, 1, 15))
+	certification.RequestRenewal(now.AddDate(0, 1, 20))
+
+	// Then
+	assert.Equal(t, 1, early)
+	assert.Len(t, certification.UncommittedChanges, 2)
+	assert.IsType(t, CertificationRenewalDue{}, certification.UncommittedChanges[1])
+	assert.True(t, certification.IsRenewalDue)
+
+	// When
+	err = certification.Renew("Control Union", "CU-456", now, now.AddDate(1, 0, 0))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "CU-456", certification.CertificateNumber)
+	assert.False(t, certification.IsRenewalDue)
+	assert.False(t, certification.NeedsRenewal(now.AddDate(0, 3, 0)))
+
+	// When
+	err = certification.Revoke(now)
+	errAgain := certification.Revoke(now)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, CertificationStatusRevoked, certification.Status)
+	assert.Equal(t, FarmCertificationError{FarmCertificationErrorRevokedCode}, errAgain)
+	assert.False(t, certification.NeedsRenewal(now.AddDate(2, 0, 0)))
+}
+
+func TestGrantFarmCertificationValidation(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+	issuedDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiryDate := issuedDate.AddDate(1, 0, 0)
+
+	// When
+	_, errType := GrantFarmCertification(farmUID, "ISO", "Control Union", "CU-1", issuedDate, expiryDate)
+	_, errBody := GrantFarmCertification(farmUID, CertificationTypeGAP, " ", "CU-1", issuedDate, expiryDate)
+	_, errNumber := GrantFarmCertification(farmUID, CertificationTypeGAP, "Control Union", "", issuedDate, expiryDate)
+	_, errDates := GrantFarmCertification(farmUID, CertificationTypeGAP, "Control Union", "CU-1", expiryDate, issuedDate)
+
+	// Then
+	assert.Equal(t, FarmCertificationError{FarmCertificationErrorInvalidTypeCode}, errType)
+	assert.Equal(t, FarmCertificationError{FarmCertificationErrorCertifyingBodyEmptyCode}, errBody)
+	assert.Equal(t, FarmCertificationError{FarmCertificationErrorCertificateNumberEmptyCode}, errNumber)
+	assert.Equal(t, FarmCertificationError{FarmCertificationErrorInvalidExpiryDateCode}, errDates)
+}
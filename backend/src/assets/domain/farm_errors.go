@@ -23,6 +23,18 @@ const (
 	FarmErrorInvalidLongitudeValueCode
 	FarmErrorInvalidCountry
 	FarmErrorInvalidCity
+
+	FarmErrorAreaWalkOrderDuplicateCode
+
+	FarmErrorHarvestGradesEmptyCode
+	FarmErrorHarvestGradeInvalidCode
+	FarmErrorHarvestGradeDuplicateCode
+
+	FarmErrorSeasonsInvalidCode
+
+	FarmErrorWithholdingPolicyInvalidCode
+
+	FarmErrorDailyEffortBudgetInvalidCode
 )
 
 func (e FarmError) Error() string {
@@ -53,6 +65,20 @@ func (e FarmError) Error() string {
 		return "Invalid country"
 	case FarmErrorInvalidCity:
 		return "Invalid city"
+	case FarmErrorAreaWalkOrderDuplicateCode:
+		return "Area is listed more than once in the walk order"
+	case FarmErrorHarvestGradesEmptyCode:
+		return "Farm needs at least one harvest grade"
+	case FarmErrorHarvestGradeInvalidCode:
+		return "Harvest grade code should be up to 20 letters, digits, hyphens or underscores"
+	case FarmErrorHarvestGradeDuplicateCode:
+		return "Harvest grade is listed more than once"
+	case FarmErrorSeasonsInvalidCode:
+		return "Seasons need unique names up to 30 characters and unique start days, not on February 29"
+	case FarmErrorWithholdingPolicyInvalidCode:
+		return "Withholding policy should be BLOCK or WARN"
+	case FarmErrorDailyEffortBudgetInvalidCode:
+		return "Daily effort budget should be between 0 and 1440 minutes"
 	default:
 		return "Unrecognized location error code"
 	}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
 )
 
 type FarmCreated struct {
@@ -39,3 +40,28 @@ type FarmRegionChanged struct {
 	Country string
 	City    string
 }
+
+type FarmAreaWalkOrderChanged struct {
+	FarmUID  uuid.UUID
+	AreaUIDs []uuid.UUID
+}
+
+type FarmHarvestGradesChanged struct {
+	FarmUID uuid.UUID
+	Grades  []HarvestGrade
+}
+
+type FarmSeasonsChanged struct {
+	FarmUID uuid.UUID
+	Seasons []timebuckethelper.Season
+}
+
+type FarmWithholdingPolicyChanged struct {
+	FarmUID uuid.UUID
+	Policy  string
+}
+
+type FarmDailyEffortBudgetChanged struct {
+	FarmUID uuid.UUID
+	Minutes int
+}
@@ -2,9 +2,11 @@ package domain_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	. "github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
 )
 
 func TestCreateFarm(t *testing.T) {
@@ -100,3 +102,112 @@ func TestChangeRegion(t *testing.T) {
 	assert.Equal(t, farm.UID, event.FarmUID)
 	assert.Equal(t, farm.Country, event.Country)
 }
+
+func TestChangeHarvestGrades(t *testing.T) {
+	t.Parallel()
+	// Given
+	farm, farmErr := CreateFarm("my farm", "organic", "90.000", "100.000", "Indonesia", "Jakarta")
+
+	// When
+	err := farm.ChangeHarvestGrades([]HarvestGrade{{Code: "extra ", Sellable: true}, {Code: "cull"}})
+
+	emptyErr := farm.ChangeHarvestGrades([]HarvestGrade{})
+	invalidErr := farm.ChangeHarvestGrades([]HarvestGrade{{Code: "grade a"}})
+	duplicateErr := farm.ChangeHarvestGrades([]HarvestGrade{{Code: "A"}, {Code: "a"}})
+
+	// Then
+	assert.Nil(t, farmErr)
+	assert.Nil(t, err)
+	assert.Equal(t, []HarvestGrade{{Code: "EXTRA", Sellable: true}, {Code: "CULL"}}, farm.HarvestGrades)
+
+	event, ok := farm.UncommittedChanges[1].(FarmHarvestGradesChanged)
+	assert.True(t, ok)
+	assert.Equal(t, farm.UID, event.FarmUID)
+
+	assert.Equal(t, FarmError{FarmErrorHarvestGradesEmptyCode}, emptyErr)
+	assert.Equal(t, FarmError{FarmErrorHarvestGradeInvalidCode}, invalidErr)
+	assert.Equal(t, FarmError{FarmErrorHarvestGradeDuplicateCode}, duplicateErr)
+	assert.Len(t, farm.UncommittedChanges, 2)
+}
+
+func TestChangeSeasons(t *testing.T) {
+	t.Parallel()
+	// Given
+	farm, farmErr := CreateFarm("my farm", "organic", "-33.86", "151.20", "Australia", "Sydney")
+
+	// When
+	err := farm.ChangeSeasons([]timebuckethelper.Season{
+		{Name: " Wet ", StartMonth: time.November, StartDay: 1},
+		{Name: "Dry", StartMonth: time.May, StartDay: 1},
+	})
+
+	invalidErr := farm.ChangeSeasons([]timebuckethelper.Season{{Name: "Leap", StartMonth: time.February, StartDay: 29}})
+	duplicateErr := farm.ChangeSeasons([]timebuckethelper.Season{
+		{Name: "Wet", StartMonth: time.November, StartDay: 1},
+		{Name: "wet", StartMonth: time.May, StartDay: 1},
+	})
+
+	// Then
+	assert.Nil(t, farmErr)
+	assert.Nil(t, err)
+	assert.Equal(t, []timebuckethelper.Season{
+		{Name: "Wet", StartMonth: time.November, StartDay: 1},
+		{Name: "Dry", StartMonth: time.May, StartDay: 1},
+	}, farm.Seasons)
+	assert.Equal(t, farm.Seasons, SeasonsOrDefault(farm.Seasons, farm.Latitude))
+
+	event, ok := farm.UncommittedChanges[1].(FarmSeasonsChanged)
+	assert.True(t, ok)
+	assert.Equal(t, farm.UID, event.FarmUID)
+
+	assert.Equal(t, FarmError{FarmErrorSeasonsInvalidCode}, invalidErr)
+	assert.Equal(t, FarmError{FarmErrorSeasonsInvalidCode}, duplicateErr)
+	assert.Len(t, farm.UncommittedChanges, 2)
+
+	// When
+	err = farm.ChangeSeasons(nil)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Empty(t, farm.Seasons)
+	assert.Equal(t, "Summer", SeasonsOrDefault(farm.Seasons, farm.Latitude)[3].Name)
+	assert.Equal(t, "Winter", SeasonsOrDefault(farm.Seasons, "48.85")[3].Name)
+}
+
+func TestChangeWithholdingPolicy(t *testing.T) {
+	t.Parallel()
+	// Given
+	farm, farmErr := CreateFarm("my farm", "organic", "-33.86", "151.20", "Australia", "Sydney")
+	defaultPolicy := WithholdingPolicyOrDefault(farm.WithholdingPolicy)
+
+	// When
+	err := farm.ChangeWithholdingPolicy(WithholdingPolicyWarn)
+	invalidErr := farm.ChangeWithholdingPolicy("IGNORE")
+
+	// Then
+	assert.Nil(t, farmErr)
+	assert.Nil(t, err)
+	assert.Equal(t, WithholdingPolicyBlock, defaultPolicy)
+	assert.Equal(t, WithholdingPolicyWarn, farm.WithholdingPolicy)
+	assert.Equal(t, FarmError{FarmErrorWithholdingPolicyInvalidCode}, invalidErr)
+	assert.Len(t, farm.UncommittedChanges, 2)
+}
+
+func TestChangeDailyEffortBudget(t *testing.T) {
+	t.Parallel()
+	// Given
+	farm, farmErr := CreateFarm("my farm", "organic", "-33.86", "151.20", "Australia", "Sydney")
+
+	// When
+	err := farm.ChangeDailyEffortBudget(480)
+	negativeErr := farm.ChangeDailyEffortBudget(-30)
+	tooLongErr := farm.ChangeDailyEffortBudget(24*60 + 1)
+
+	// Then
+	assert.Nil(t, farmErr)
+	assert.Nil(t, err)
+	assert.Equal(t, 480, farm.DailyEffortBudgetMinutes)
+	assert.Equal(t, FarmError{FarmErrorDailyEffortBudgetInvalidCode}, negativeErr)
+	assert.Equal(t, FarmError{FarmErrorDailyEffortBudgetInvalidCode}, tooLongErr)
+	assert.Len(t, farm.UncommittedChanges, 2)
+}
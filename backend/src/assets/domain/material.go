@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/units"
 )
 
 type Material struct {
@@ -16,8 +17,15 @@ type Material struct {
 	ExpirationDate *time.Time       `json:"expiration_date"`
 	Notes          *string          `json:"notes"`
 	ProducedBy     *string          `json:"produced_by"`
+	GDDToMaturity  *float64         `json:"gdd_to_maturity"`
 	CreatedDate    time.Time        `json:"created_date"`
 
+	// PreHarvestIntervalDays are the days a crop can't be harvested after it's treated with the material.
+	PreHarvestIntervalDays *int `json:"pre_harvest_interval_days"`
+
+	// Lots are the stock left of each lot of the material, the oldest first.
+	Lots []MaterialLot `json:"lots"`
+
 	// Events
 	Version            int
 	UncommittedChanges []interface{}
@@ -64,15 +72,15 @@ func GetCurrencyCode(currencyCode string) (string, error) {
 }
 
 const (
-	MaterialUnitSeeds      = "SEEDS"
-	MaterialUnitPackets    = "PACKETS"
-	MaterialUnitGram       = "GRAM"
-	MaterialUnitKilogram   = "KILOGRAM"
-	MaterialUnitBags       = "BAGS"
-	MaterialUnitBottles    = "BOTTLES"
-	MaterialUnitCubicMetre = "CUBIC_METRE"
-	MaterialUnitPieces     = "PIECES"
-	MaterialUnitUnits      = "UNITS"
+	MaterialUnitSeeds      = units.MaterialSeeds
+	MaterialUnitPackets    = units.MaterialPackets
+	MaterialUnitGram       = units.MaterialGram
+	MaterialUnitKilogram   = units.MaterialKilogram
+	MaterialUnitBags       = units.MaterialBags
+	MaterialUnitBottles    = units.MaterialBottles
+	MaterialUnitCubicMetre = units.MaterialCubicMetre
+	MaterialUnitPieces     = units.MaterialPieces
+	MaterialUnitUnits      = units.MaterialUnits
 )
 
 type MaterialQuantity struct {
@@ -86,49 +94,17 @@ type MaterialQuantityUnit struct {
 }
 
 func MaterialQuantityUnits(materialTypeCode string) []MaterialQuantityUnit {
-	switch materialTypeCode {
-	case MaterialTypeSeedCode:
-		return []MaterialQuantityUnit{
-			{Code: MaterialUnitSeeds, Label: "Seeds"},
-			{Code: MaterialUnitPackets, Label: "Packets"},
-			{Code: MaterialUnitGram, Label: "Gram"},
-			{Code: MaterialUnitKilogram, Label: "Kilogram"},
-		}
-	case MaterialTypeAgrochemicalCode:
-		return []MaterialQuantityUnit{
-			{Code: MaterialUnitPackets, Label: "Packets"},
-			{Code: MaterialUnitBottles, Label: "Bottles"},
-			{Code: MaterialUnitBags, Label: "Bags"},
-		}
-	case MaterialTypeGrowingMediumCode:
-		return []MaterialQuantityUnit{
-			{Code: MaterialUnitBags, Label: "Bags"},
-			{Code: MaterialUnitCubicMetre, Label: "Cubic Metre"},
-		}
-	case MaterialTypeLabelAndCropSupportCode:
-		return []MaterialQuantityUnit{
-			{Code: MaterialUnitPieces, Label: "Pieces"},
-		}
-	case MaterialTypeSeedingContainerCode:
-		return []MaterialQuantityUnit{
-			{Code: MaterialUnitPieces, Label: "Pieces"},
-		}
-	case MaterialTypePostHarvestSupplyCode:
-		return []MaterialQuantityUnit{
-			{Code: MaterialUnitPieces, Label: "Pieces"},
-		}
-	case MaterialTypePlantCode:
-		return []MaterialQuantityUnit{
-			{Code: MaterialUnitUnits, Label: "Units"},
-			{Code: MaterialUnitPackets, Label: "Packets"},
-		}
-	case MaterialTypeOtherCode:
-		return []MaterialQuantityUnit{
-			{Code: MaterialUnitPieces, Label: "Pieces"},
-		}
+	registry := units.Material(materialTypeCode)
+	if registry == nil {
+		return nil
 	}
 
-	return nil
+	quantityUnits := []MaterialQuantityUnit{}
+	for _, v := range registry {
+		quantityUnits = append(quantityUnits, MaterialQuantityUnit{Code: v.Code, Label: v.Label})
+	}
+
+	return quantityUnits
 }
 
 func GetMaterialQuantityUnit(materialTypeCode, code string) MaterialQuantityUnit {
@@ -157,8 +133,15 @@ func (m *Material) Transition(event interface{}) {
 		m.ExpirationDate = e.ExpirationDate
 		m.Notes = e.Notes
 		m.ProducedBy = e.ProducedBy
+		m.GDDToMaturity = e.GDDToMaturity
+		m.PreHarvestIntervalDays = e.PreHarvestIntervalDays
 		m.CreatedDate = e.CreatedDate
 
+		if e.Quantity.Value > 0 {
+			createdDate := e.CreatedDate
+			m.Lots = []MaterialLot{{LotNumber: e.LotNumber, Quantity: e.Quantity.Value, AddedDate: &createdDate}}
+		}
+
 	case MaterialNameChanged:
 		m.Name = e.Name
 
@@ -170,6 +153,26 @@ func (m *Material) Transition(event interface{}) {
 
 	case MaterialQuantityChanged:
 		m.Quantity = e.Quantity
+		m.Lots = reconcileLots(m.Lots, e.Quantity.Value)
+
+	case MaterialStockCorrected:
+		m.Quantity.Value = e.Quantity
+		m.Lots = reconcileLots(m.Lots, e.Quantity)
+
+	case MaterialStockAdded:
+		addedDate := e.AddedDate
+		m.Quantity.Value = e.Quantity
+		m.Lots = append(m.Lots, MaterialLot{LotNumber: e.LotNumber, Quantity: e.Added, AddedDate: &addedDate})
+
+	case MaterialStockDeducted:
+		m.Quantity.Value = e.Quantity
+
+		// The deductions recorded before the lots were allocated are taken from the oldest lots.
+		if e.Lots == nil {
+			m.Lots = reconcileLots(m.Lots, e.Quantity)
+		} else {
+			m.Lots = takeFromLots(m.Lots, e.Lots)
+		}
 
 	case MaterialExpirationDateChanged:
 		m.ExpirationDate = &e.ExpirationDate
@@ -179,6 +182,12 @@ func (m *Material) Transition(event interface{}) {
 
 	case MaterialProducedByChanged:
 		m.ProducedBy = &e.ProducedBy
+
+	case MaterialGDDToMaturityChanged:
+		m.GDDToMaturity = &e.GDDToMaturity
+
+	case MaterialPreHarvestIntervalChanged:
+		m.PreHarvestIntervalDays = &e.PreHarvestIntervalDays
 	}
 }
 
@@ -191,7 +200,10 @@ func CreateMaterial(
 	quantityUnit string,
 	expirationDate *time.Time,
 	notes *string,
-	producedBy *string) (*Material, error,
+	producedBy *string,
+	gddToMaturity *float64,
+	preHarvestIntervalDays *int,
+	lotNumber string) (*Material, error,
 ) {
 	uid, err := uuid.NewV4()
 	if err != nil {
@@ -217,6 +229,25 @@ func CreateMaterial(
 		return nil, err
 	}
 
+	if gddToMaturity != nil {
+		err = validateGDDToMaturity(*gddToMaturity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if preHarvestIntervalDays != nil {
+		err = validatePreHarvestInterval(*preHarvestIntervalDays)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	lotNumber, err = validateLotNumber(lotNumber)
+	if err != nil {
+		return nil, err
+	}
+
 	initial := &Material{
 		UID:          uid,
 		Name:         name,
@@ -229,7 +260,10 @@ func CreateMaterial(
 		ExpirationDate: expirationDate,
 		Notes:          notes,
 		ProducedBy:     producedBy,
+		GDDToMaturity:  gddToMaturity,
 		CreatedDate:    time.Now(),
+
+		PreHarvestIntervalDays: preHarvestIntervalDays,
 	}
 
 	initial.TrackChange(MaterialCreated{
@@ -241,7 +275,11 @@ func CreateMaterial(
 		ExpirationDate: initial.ExpirationDate,
 		Notes:          initial.Notes,
 		ProducedBy:     initial.ProducedBy,
+		GDDToMaturity:  initial.GDDToMaturity,
 		CreatedDate:    initial.CreatedDate,
+
+		PreHarvestIntervalDays: initial.PreHarvestIntervalDays,
+		LotNumber:              lotNumber,
 	})
 
 	return initial, nil
@@ -295,6 +333,66 @@ func (m *Material) ChangeQuantityUnit(quantity float32, quantityUnit string, mat
 	return nil
 }
 
+// CorrectStock sets the quantity to the counted one, keeping its unit.
+// Unlike the other quantity changes it can be zero, as a material can run out.
+func (m *Material) CorrectStock(quantity float32, reason string, stocktakeUID uuid.UUID) error {
+	if quantity < 0 {
+		return StocktakeError{Code: StocktakeErrorInvalidQuantityCode}
+	}
+
+	m.TrackChange(MaterialStockCorrected{
+		MaterialUID:      m.UID,
+		PreviousQuantity: m.Quantity.Value,
+		Quantity:         quantity,
+		QuantityUnit:     m.Quantity.Unit.Code,
+		Reason:           reason,
+		StocktakeUID:     stocktakeUID,
+	})
+
+	return nil
+}
+
+// DeductStock takes the used quantity out of the stock, in the unit of the material, and returns the shortfall.
+// The quantity is taken from the lot of the use, then from the oldest lots. The use is recorded even when
+// the stock is short, which leaves the material empty until it's corrected.
+func (m *Material) DeductStock(quantity float32, use StockUse) (float32, error) {
+	lotNumber, err := validateLotNumber(use.LotNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	lots, err := AllocateLots(m.Lots, quantity, lotNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	remaining := m.Quantity.Value - quantity
+	shortfall := float32(0)
+
+	if remaining < 0 {
+		shortfall = -remaining
+		remaining = 0
+	}
+
+	m.TrackChange(MaterialStockDeducted{
+		MaterialUID:      m.UID,
+		PreviousQuantity: m.Quantity.Value,
+		Quantity:         remaining,
+		Deducted:         quantity,
+		Shortfall:        shortfall,
+		QuantityUnit:     m.Quantity.Unit.Code,
+		Reason:           use.Reason,
+		ReferenceUID:     use.ReferenceUID,
+		FarmUID:          use.FarmUID,
+		UsedDate:         use.UsedDate,
+		Lots:             lots,
+		Areas:            use.Areas,
+		Crops:            use.Crops,
+	})
+
+	return shortfall, nil
+}
+
 func (m *Material) ChangeType(materialType MaterialType) error {
 	if materialType == nil {
 		return MaterialError{MaterialErrorInvalidMaterialType}
@@ -329,6 +427,52 @@ func (m *Material) ChangeProducedBy(producedBy string) {
 	})
 }
 
+// ChangeGDDToMaturity sets the growing degree days the crops of the material need to mature.
+func (m *Material) ChangeGDDToMaturity(gddToMaturity float64) error {
+	err := validateGDDToMaturity(gddToMaturity)
+	if err != nil {
+		return err
+	}
+
+	m.TrackChange(MaterialGDDToMaturityChanged{
+		MaterialUID:   m.UID,
+		GDDToMaturity: gddToMaturity,
+	})
+
+	return nil
+}
+
+func validateGDDToMaturity(gddToMaturity float64) error {
+	if gddToMaturity <= 0 {
+		return MaterialError{MaterialErrorInvalidGDDToMaturity}
+	}
+
+	return nil
+}
+
+// ChangePreHarvestInterval sets the days a crop can't be harvested after it's treated with the material.
+func (m *Material) ChangePreHarvestInterval(days int) error {
+	err := validatePreHarvestInterval(days)
+	if err != nil {
+		return err
+	}
+
+	m.TrackChange(MaterialPreHarvestIntervalChanged{
+		MaterialUID:            m.UID,
+		PreHarvestIntervalDays: days,
+	})
+
+	return nil
+}
+
+func validatePreHarvestInterval(days int) error {
+	if days < 0 {
+		return MaterialError{MaterialErrorInvalidPreHarvestInterval}
+	}
+
+	return nil
+}
+
 func validateQuantity(quantity float32) error {
 	if quantity <= 0 {
 		return errors.New("cannot be empty")
@@ -2,6 +2,12 @@ package domain
 
 const (
 	MaterialErrorInvalidMaterialType = iota
+	MaterialErrorInvalidGDDToMaturity
+	MaterialErrorStockFrozen
+	MaterialErrorInvalidPreHarvestInterval
+	MaterialErrorInvalidStockQuantity
+	MaterialErrorInvalidLotNumber
+	MaterialErrorLotNotFound
 )
 
 // MaterialError is a custom error from Go built-in error.
@@ -13,6 +19,18 @@ func (e MaterialError) Error() string {
 	switch e.Code {
 	case MaterialErrorInvalidMaterialType:
 		return "Invalid material type"
+	case MaterialErrorInvalidGDDToMaturity:
+		return "Growing degree days to maturity must be greater than zero"
+	case MaterialErrorStockFrozen:
+		return "Material quantity cannot be changed while it's counted by an open stocktake"
+	case MaterialErrorInvalidPreHarvestInterval:
+		return "Pre-harvest interval cannot be negative"
+	case MaterialErrorInvalidStockQuantity:
+		return "Added stock quantity must be greater than zero"
+	case MaterialErrorInvalidLotNumber:
+		return "Lot number cannot be longer than 64 characters"
+	case MaterialErrorLotNotFound:
+		return "Lot number is not in the stock of the material"
 	default:
 		return "Unrecognized Material Error Code"
 	}
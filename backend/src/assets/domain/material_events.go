@@ -15,7 +15,13 @@ type MaterialCreated struct {
 	ExpirationDate *time.Time
 	Notes          *string
 	ProducedBy     *string
+	GDDToMaturity  *float64
 	CreatedDate    time.Time
+
+	PreHarvestIntervalDays *int
+
+	// LotNumber is the lot of the initial quantity, empty when it has none.
+	LotNumber string
 }
 
 type MaterialNameChanged struct {
@@ -34,6 +40,46 @@ type MaterialQuantityChanged struct {
 	Quantity         MaterialQuantity
 }
 
+type MaterialStockCorrected struct {
+	MaterialUID      uuid.UUID
+	PreviousQuantity float32
+	Quantity         float32
+	QuantityUnit     string
+	Reason           string
+	StocktakeUID     uuid.UUID
+}
+
+// MaterialStockAdded is a delivery of the material, a new lot of its stock. The quantity is the stock after it.
+type MaterialStockAdded struct {
+	MaterialUID      uuid.UUID
+	PreviousQuantity float32
+	Quantity         float32
+	Added            float32
+	QuantityUnit     string
+	LotNumber        string
+	AddedDate        time.Time
+}
+
+// MaterialStockDeducted is a use of the material, the reference is what used it like the reservoir dosed.
+// The quantity is what's left, the shortfall is the part of the deducted quantity the stock didn't have.
+// The lots are the quantities taken from each lot, and the areas and the crops what the use reached.
+// The deductions recorded before the lots have none, and no farm.
+type MaterialStockDeducted struct {
+	MaterialUID      uuid.UUID
+	PreviousQuantity float32
+	Quantity         float32
+	Deducted         float32
+	Shortfall        float32
+	QuantityUnit     string
+	Reason           string
+	ReferenceUID     uuid.UUID
+	FarmUID          uuid.UUID
+	UsedDate         time.Time
+	Lots             []MaterialLotUsage
+	Areas            []MaterialUsageArea
+	Crops            []MaterialUsageCrop
+}
+
 type MaterialTypeChanged struct {
 	MaterialUID  uuid.UUID
 	MaterialType MaterialType
@@ -53,3 +99,13 @@ type MaterialProducedByChanged struct {
 	MaterialUID uuid.UUID
 	ProducedBy  string
 }
+
+type MaterialGDDToMaturityChanged struct {
+	MaterialUID   uuid.UUID
+	GDDToMaturity float64
+}
+
+type MaterialPreHarvestIntervalChanged struct {
+	MaterialUID            uuid.UUID
+	PreHarvestIntervalDays int
+}
@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// maxLotNumberLength is the length of the longest lot number, like the one printed on a bag of fertilizer.
+const maxLotNumberLength = 64
+
+// MaterialLot is the stock left of a lot of the material, in the order the lots were added. The stock added
+// without a lot number, or before the lots were recorded, is in the lots with an empty number.
+type MaterialLot struct {
+	LotNumber string     `json:"lot_number"`
+	Quantity  float32    `json:"quantity"`
+	AddedDate *time.Time `json:"added_date"`
+}
+
+// MaterialLotUsage is the quantity of a use of the material taken from a lot.
+type MaterialLotUsage struct {
+	LotNumber string  `json:"lot_number"`
+	Quantity  float32 `json:"quantity"`
+}
+
+// MaterialUsageArea is an area a use of the material reached, with its name on the day of the use.
+type MaterialUsageArea struct {
+	UID  uuid.UUID `json:"uid"`
+	Name string    `json:"name"`
+}
+
+// MaterialUsageCrop is a crop batch a use of the material reached, with its batch ID on the day of the use.
+type MaterialUsageCrop struct {
+	UID     uuid.UUID `json:"uid"`
+	BatchID string    `json:"batch_id"`
+	AreaUID uuid.UUID `json:"area_id"`
+}
+
+// StockUse is what a deduction of the stock is used for: the reason, the reservoir or the area it's used on,
+// and the areas and the crop batches of the farm it reached. The lot number is the lot the user took the
+// material from, the stock is taken from the oldest lots first without one.
+type StockUse struct {
+	Reason       string
+	ReferenceUID uuid.UUID
+	FarmUID      uuid.UUID
+	LotNumber    string
+	UsedDate     time.Time
+	Areas        []MaterialUsageArea
+	Crops        []MaterialUsageCrop
+}
+
+func validateLotNumber(lotNumber string) (string, error) {
+	lotNumber = strings.TrimSpace(lotNumber)
+	if len(lotNumber) > maxLotNumberLength {
+		return "", MaterialError{MaterialErrorInvalidLotNumber}
+	}
+
+	return lotNumber, nil
+}
+
+// AddStock adds the quantity to the stock of the material, in its unit, as a new lot.
+func (m *Material) AddStock(quantity float32, lotNumber string, addedDate time.Time) error {
+	if quantity <= 0 {
+		return MaterialError{MaterialErrorInvalidStockQuantity}
+	}
+
+	lotNumber, err := validateLotNumber(lotNumber)
+	if err != nil {
+		return err
+	}
+
+	m.TrackChange(MaterialStockAdded{
+		MaterialUID:      m.UID,
+		PreviousQuantity: m.Quantity.Value,
+		Quantity:         m.Quantity.Value + quantity,
+		Added:            quantity,
+		QuantityUnit:     m.Quantity.Unit.Code,
+		LotNumber:        lotNumber,
+		AddedDate:        addedDate,
+	})
+
+	return nil
+}
+
+// AllocateLots takes the quantity from the lots, from the lot with the number first when there's one, then
+// from the oldest lots. The quantity the lots don't have isn't allocated. The allocation only depends on the
+// lots, so the same ledger always allocates the same way.
+func AllocateLots(lots []MaterialLot, quantity float32, lotNumber string) ([]MaterialLotUsage, error) {
+	order := []int{}
+
+	if lotNumber != "" {
+		for i, v := range lots {
+			if v.LotNumber == lotNumber {
+				order = append(order, i)
+			}
+		}
+
+		if len(order) == 0 {
+			return nil, MaterialError{MaterialErrorLotNotFound}
+		}
+	}
+
+	for i, v := range lots {
+		if lotNumber == "" || v.LotNumber != lotNumber {
+			order = append(order, i)
+		}
+	}
+
+	usages := []MaterialLotUsage{}
+
+	for _, i := range order {
+		if quantity <= 0 {
+			break
+		}
+
+		taken := lots[i].Quantity
+		if taken > quantity {
+			taken = quantity
+		}
+
+		if taken <= 0 {
+			continue
+		}
+
+		quantity -= taken
+		usages = addLotUsage(usages, lots[i].LotNumber, taken)
+	}
+
+	return usages, nil
+}
+
+func addLotUsage(usages []MaterialLotUsage, lotNumber string, quantity float32) []MaterialLotUsage {
+	for i, v := range usages {
+		if v.LotNumber == lotNumber {
+			usages[i].Quantity += quantity
+
+			return usages
+		}
+	}
+
+	return append(usages, MaterialLotUsage{LotNumber: lotNumber, Quantity: quantity})
+}
+
+// takeFromLots removes the usages from the lots, from the oldest lot of each number, and drops the empty lots.
+func takeFromLots(lots []MaterialLot, usages []MaterialLotUsage) []MaterialLot {
+	for _, usage := range usages {
+		left := usage.Quantity
+
+		for i := range lots {
+			if left <= 0 {
+				break
+			}
+
+			if lots[i].LotNumber != usage.LotNumber {
+				continue
+			}
+
+			taken := lots[i].Quantity
+			if taken > left {
+				taken = left
+			}
+
+			lots[i].Quantity -= taken
+			left -= taken
+		}
+	}
+
+	remaining := []MaterialLot{}
+
+	for _, v := range lots {
+		if v.Quantity > 0 {
+			remaining = append(remaining, v)
+		}
+	}
+
+	return remaining
+}
+
+// reconcileLots sets the lots to the quantity the material was changed or corrected to. A lower quantity is
+// taken from the oldest lots, a higher one is added as stock without a lot number nor date.
+func reconcileLots(lots []MaterialLot, quantity float32) []MaterialLot {
+	total := float32(0)
+
+	for _, v := range lots {
+		total += v.Quantity
+	}
+
+	if quantity > total {
+		return append(lots, MaterialLot{Quantity: quantity - total})
+	}
+
+	usages, _ := AllocateLots(lots, total-quantity, "")
+
+	return takeFromLots(lots, usages)
+}
@@ -0,0 +1,138 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/assets/domain"
+)
+
+func TestMaterialLots(t *testing.T) {
+	t.Parallel()
+	// Given
+	mta, err := CreateMaterialTypeAgrochemical(ChemicalTypeFertilizer)
+	assert.Nil(t, err)
+
+	material, err := CreateMaterial("Calcium Nitrate", "5", MoneyEUR, mta, 10,
+		MaterialUnitBottles, nil, nil, nil, nil, nil, " CN-2024-01 ")
+	assert.Nil(t, err)
+
+	addedDate := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	// When
+	err = material.AddStock(5, "CN-2024-02", addedDate)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, float32(15), material.Quantity.Value)
+	assert.Len(t, material.Lots, 2)
+	assert.Equal(t, "CN-2024-01", material.Lots[0].LotNumber)
+	assert.Equal(t, "CN-2024-02", material.Lots[1].LotNumber)
+	assert.Equal(t, addedDate, *material.Lots[1].AddedDate)
+
+	// When
+	err = material.AddStock(0, "CN-2024-03", addedDate)
+
+	// Then
+	assert.Equal(t, MaterialError{MaterialErrorInvalidStockQuantity}, err)
+
+	// When
+	areaUID, _ := uuid.NewV4()
+	shortfall, err := material.DeductStock(12, StockUse{
+		Reason:       StockDeductionReasonSanitation,
+		ReferenceUID: areaUID,
+		Areas:        []MaterialUsageArea{{UID: areaUID, Name: "Bed One"}},
+	})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, float32(0), shortfall)
+	assert.Equal(t, float32(3), material.Quantity.Value)
+	assert.Equal(t, []MaterialLot{{LotNumber: "CN-2024-02", Quantity: 3, AddedDate: &addedDate}}, material.Lots)
+
+	deducted, ok := material.UncommittedChanges[len(material.UncommittedChanges)-1].(MaterialStockDeducted)
+	assert.True(t, ok)
+	assert.Equal(t, []MaterialLotUsage{
+		{LotNumber: "CN-2024-01", Quantity: 10},
+		{LotNumber: "CN-2024-02", Quantity: 2},
+	}, deducted.Lots)
+	assert.Equal(t, "Bed One", deducted.Areas[0].Name)
+}
+
+func TestMaterialLotNumberDeduction(t *testing.T) {
+	t.Parallel()
+	// Given
+	mta, err := CreateMaterialTypeAgrochemical(ChemicalTypeFertilizer)
+	assert.Nil(t, err)
+
+	material, err := CreateMaterial("Calcium Nitrate", "5", MoneyEUR, mta, 10,
+		MaterialUnitBottles, nil, nil, nil, nil, nil, "CN-2024-01")
+	assert.Nil(t, err)
+
+	err = material.AddStock(5, "CN-2024-02", time.Now())
+	assert.Nil(t, err)
+
+	// When
+	shortfall, err := material.DeductStock(7, StockUse{
+		Reason:    StockDeductionReasonDosing,
+		LotNumber: "CN-2024-02",
+	})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, float32(0), shortfall)
+	assert.Equal(t, float32(8), material.Quantity.Value)
+	assert.Len(t, material.Lots, 1)
+	assert.Equal(t, "CN-2024-01", material.Lots[0].LotNumber)
+	assert.Equal(t, float32(8), material.Lots[0].Quantity)
+
+	// When
+	_, err = material.DeductStock(1, StockUse{Reason: StockDeductionReasonDosing, LotNumber: "CN-2023-12"})
+
+	// Then
+	assert.Equal(t, MaterialError{MaterialErrorLotNotFound}, err)
+	assert.Equal(t, float32(8), material.Quantity.Value)
+}
+
+func TestMaterialLotsReconciled(t *testing.T) {
+	t.Parallel()
+	// Given
+	mta, err := CreateMaterialTypeAgrochemical(ChemicalTypeFertilizer)
+	assert.Nil(t, err)
+
+	material, err := CreateMaterial("Calcium Nitrate", "5", MoneyEUR, mta, 10,
+		MaterialUnitBottles, nil, nil, nil, nil, nil, "CN-2024-01")
+	assert.Nil(t, err)
+
+	err = material.AddStock(5, "CN-2024-02", time.Now())
+	assert.Nil(t, err)
+
+	// When
+	err = material.CorrectStock(12, "Counted", uuid.Nil)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Len(t, material.Lots, 2)
+	assert.Equal(t, float32(7), material.Lots[0].Quantity)
+	assert.Equal(t, float32(5), material.Lots[1].Quantity)
+
+	// When
+	err = material.CorrectStock(14, "Counted", uuid.Nil)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Len(t, material.Lots, 3)
+	assert.Equal(t, "", material.Lots[2].LotNumber)
+	assert.Equal(t, float32(2), material.Lots[2].Quantity)
+
+	// When
+	shortfall, err := material.DeductStock(20, StockUse{Reason: StockDeductionReasonDosing})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, float32(6), shortfall)
+	assert.Equal(t, float32(0), material.Quantity.Value)
+	assert.Empty(t, material.Lots)
+}
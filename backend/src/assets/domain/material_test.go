@@ -12,7 +12,8 @@ func TestCreateInventorySeed(t *testing.T) {
 	// Given
 	// When
 	mts, err1 := CreateMaterialTypeSeed(PlantTypeVegetable)
-	material1, err2 := CreateMaterial("Bayam Lu Hsieh", "12", MoneyEUR, mts, 20, MaterialUnitPackets, nil, nil, nil)
+	material1, err2 := CreateMaterial("Bayam Lu Hsieh", "12", MoneyEUR, mts, 20,
+		MaterialUnitPackets, nil, nil, nil, nil, nil, "")
 	tp, ok := material1.Type.(MaterialTypeSeed)
 
 	// Then
@@ -25,7 +26,8 @@ func TestCreateInventorySeed(t *testing.T) {
 
 	// When
 	mta, err1 := CreateMaterialTypeAgrochemical(ChemicalTypeDisinfectant)
-	material2, err2 := CreateMaterial("Green Disinfectant", "5", MoneyEUR, mta, 5, MaterialUnitPackets, nil, nil, nil)
+	material2, err2 := CreateMaterial("Green Disinfectant", "5", MoneyEUR, mta, 5,
+		MaterialUnitPackets, nil, nil, nil, nil, nil, "")
 	ta, ok := material2.Type.(MaterialTypeAgrochemical)
 
 	// Then
@@ -38,7 +40,8 @@ func TestCreateInventorySeed(t *testing.T) {
 
 	// When
 	mtsc, err1 := CreateMaterialTypeSeedingContainer(ContainerTypeTray)
-	material3, err2 := CreateMaterial("Soft Indoor Tray Pack", "10", MoneyEUR, mtsc, 10, MaterialUnitPieces, nil, nil, nil)
+	material3, err2 := CreateMaterial("Soft Indoor Tray Pack", "10", MoneyEUR, mtsc, 10,
+		MaterialUnitPieces, nil, nil, nil, nil, nil, "")
 	tsc, ok := material3.Type.(MaterialTypeSeedingContainer)
 
 	// Then
@@ -51,7 +54,8 @@ func TestCreateInventorySeed(t *testing.T) {
 
 	// When
 	mtgm := MaterialTypeGrowingMedium{}
-	material4, err1 := CreateMaterial("Organic Super Soil", "2", MoneyEUR, mtgm, 5, MaterialUnitBags, nil, nil, nil)
+	material4, err1 := CreateMaterial("Organic Super Soil", "2", MoneyEUR, mtgm, 5,
+		MaterialUnitBags, nil, nil, nil, nil, nil, "")
 	tgm, ok := material4.Type.(MaterialTypeGrowingMedium)
 
 	// Then
@@ -63,7 +67,9 @@ func TestCreateInventorySeed(t *testing.T) {
 
 	// When
 	mtl := MaterialTypeLabelAndCropSupport{}
-	material5, err1 := CreateMaterial("Clean Label", "5", MoneyEUR, mtl, 5, MaterialUnitPieces, nil, nil, nil)
+	material5, err1 := CreateMaterial("Clean Label", "5", MoneyEUR, mtl, 5, MaterialUnitPieces,
+		nil, nil, nil, nil, nil, "")
+
 	tl, ok := material5.Type.(MaterialTypeLabelAndCropSupport)
 
 	// Then
@@ -75,7 +81,8 @@ func TestCreateInventorySeed(t *testing.T) {
 
 	// When
 	mtph := MaterialTypePostHarvestSupply{}
-	material6, err1 := CreateMaterial("Warm Solid Plastic", "5", MoneyEUR, mtph, 5, MaterialUnitPieces, nil, nil, nil)
+	material6, err1 := CreateMaterial("Warm Solid Plastic", "5", MoneyEUR, mtph, 5,
+		MaterialUnitPieces, nil, nil, nil, nil, nil, "")
 	tph, ok := material6.Type.(MaterialTypePostHarvestSupply)
 
 	// Then
@@ -87,7 +94,8 @@ func TestCreateInventorySeed(t *testing.T) {
 
 	// When
 	mto := MaterialTypeOther{}
-	material7, err1 := CreateMaterial("Night Lamp Bright", "3", MoneyEUR, mto, 3, MaterialUnitPieces, nil, nil, nil)
+	material7, err1 := CreateMaterial("Night Lamp Bright", "3", MoneyEUR, mto, 3,
+		MaterialUnitPieces, nil, nil, nil, nil, nil, "")
 	mo, ok := material7.Type.(MaterialTypeOther)
 
 	// Then
@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// StockDeductionReasonDosing is the reason of the stock deductions made when a reservoir is dosed.
+const StockDeductionReasonDosing = "DOSING"
+
+// NutrientRecipeMaxEC is the highest target EC of a recipe, in mS/cm.
+const NutrientRecipeMaxEC = 10
+
+// NutrientRecipe is how a farm doses its reservoirs, the materials to add to the water
+// to reach the target EC and pH.
+type NutrientRecipe struct {
+	UID         uuid.UUID
+	FarmUID     uuid.UUID
+	Name        string
+	TargetEC    float32
+	TargetPH    float32
+	Ingredients []NutrientRecipeIngredient
+	IsDeleted   bool
+	CreatedDate time.Time
+
+	// Events
+	Version            int
+	UncommittedChanges []interface{}
+}
+
+// NutrientRecipeIngredient is the dose of a material for every PerLitres litres of water,
+// in the quantity unit of the material.
+type NutrientRecipeIngredient struct {
+	MaterialUID uuid.UUID
+	Dose        float32
+	PerLitres   float32
+}
+
+// NutrientDose is the quantity of a material a dosing adds to the water.
+type NutrientDose struct {
+	MaterialUID uuid.UUID
+	Quantity    float32
+}
+
+func CreateNutrientRecipe(
+	farmUID uuid.UUID,
+	name string,
+	targetEC, targetPH float32,
+	ingredients []NutrientRecipeIngredient,
+) (*NutrientRecipe, error) {
+	name = strings.TrimSpace(name)
+
+	err := validateNutrientRecipe(name, targetEC, targetPH, ingredients)
+	if err != nil {
+		return nil, err
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	initial := &NutrientRecipe{}
+
+	initial.TrackChange(NutrientRecipeCreated{
+		UID:         uid,
+		FarmUID:     farmUID,
+		Name:        name,
+		TargetEC:    targetEC,
+		TargetPH:    targetPH,
+		Ingredients: ingredients,
+		CreatedDate: time.Now(),
+	})
+
+	return initial, nil
+}
+
+// Update replaces the name, the targets and the ingredients of the recipe.
+// The dosings already done keep the quantities they were made with.
+func (r *NutrientRecipe) Update(name string, targetEC, targetPH float32, ingredients []NutrientRecipeIngredient) error {
+	if r.IsDeleted {
+		return NutrientRecipeError{Code: NutrientRecipeErrorDeletedCode}
+	}
+
+	name = strings.TrimSpace(name)
+
+	err := validateNutrientRecipe(name, targetEC, targetPH, ingredients)
+	if err != nil {
+		return err
+	}
+
+	r.TrackChange(NutrientRecipeUpdated{
+		UID:         r.UID,
+		FarmUID:     r.FarmUID,
+		Name:        name,
+		TargetEC:    targetEC,
+		TargetPH:    targetPH,
+		Ingredients: ingredients,
+	})
+
+	return nil
+}
+
+func (r *NutrientRecipe) Delete() error {
+	if r.IsDeleted {
+		return NutrientRecipeError{Code: NutrientRecipeErrorDeletedCode}
+	}
+
+	r.TrackChange(NutrientRecipeDeleted{
+		UID:     r.UID,
+		FarmUID: r.FarmUID,
+	})
+
+	return nil
+}
+
+// Doses returns the quantity of every material of the recipe for the volume of water, in litres.
+func (r NutrientRecipe) Doses(volumeLitres float32) []NutrientDose {
+	doses := []NutrientDose{}
+
+	for _, v := range r.Ingredients {
+		doses = append(doses, NutrientDose{
+			MaterialUID: v.MaterialUID,
+			Quantity:    v.Dose * volumeLitres / v.PerLitres,
+		})
+	}
+
+	return doses
+}
+
+// Event Tracking.
+func (r *NutrientRecipe) TrackChange(event interface{}) {
+	r.UncommittedChanges = append(r.UncommittedChanges, event)
+	r.Transition(event)
+}
+
+func (r *NutrientRecipe) Transition(event interface{}) {
+	switch e := event.(type) {
+	case NutrientRecipeCreated:
+		r.UID = e.UID
+		r.FarmUID = e.FarmUID
+		r.Name = e.Name
+		r.TargetEC = e.TargetEC
+		r.TargetPH = e.TargetPH
+		r.Ingredients = e.Ingredients
+		r.CreatedDate = e.CreatedDate
+	case NutrientRecipeUpdated:
+		r.Name = e.Name
+		r.TargetEC = e.TargetEC
+		r.TargetPH = e.TargetPH
+		r.Ingredients = e.Ingredients
+	case NutrientRecipeDeleted:
+		r.IsDeleted = true
+	}
+}
+
+func validateNutrientRecipe(name string, targetEC, targetPH float32, ingredients []NutrientRecipeIngredient) error {
+	if name == "" {
+		return NutrientRecipeError{Code: NutrientRecipeErrorNameEmptyCode}
+	}
+
+	if targetEC < 0 || targetEC > NutrientRecipeMaxEC {
+		return NutrientRecipeError{Code: NutrientRecipeErrorECInvalidCode}
+	}
+
+	if targetPH < 0 || targetPH > 14 {
+		return NutrientRecipeError{Code: NutrientRecipeErrorPHInvalidCode}
+	}
+
+	if len(ingredients) == 0 {
+		return NutrientRecipeError{Code: NutrientRecipeErrorNoIngredientsCode}
+	}
+
+	for i, v := range ingredients {
+		if v.MaterialUID == (uuid.UUID{}) || v.Dose <= 0 || v.PerLitres <= 0 {
+			return NutrientRecipeError{Code: NutrientRecipeErrorIngredientInvalidCode}
+		}
+
+		for _, w := range ingredients[:i] {
+			if w.MaterialUID == v.MaterialUID {
+				return NutrientRecipeError{Code: NutrientRecipeErrorDuplicateIngredientCode}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package domain
+
+// NutrientRecipeError is a custom error from Go built-in error.
+type NutrientRecipeError struct {
+	Code int
+}
+
+const (
+	NutrientRecipeErrorNameEmptyCode = iota
+	NutrientRecipeErrorECInvalidCode
+	NutrientRecipeErrorPHInvalidCode
+	NutrientRecipeErrorNoIngredientsCode
+	NutrientRecipeErrorIngredientInvalidCode
+	NutrientRecipeErrorDuplicateIngredientCode
+	NutrientRecipeErrorDeletedCode
+)
+
+func (e NutrientRecipeError) Error() string {
+	switch e.Code {
+	case NutrientRecipeErrorNameEmptyCode:
+		return "Nutrient recipe name is required."
+	case NutrientRecipeErrorECInvalidCode:
+		return "Nutrient recipe target EC must be between 0 and 10 mS/cm."
+	case NutrientRecipeErrorPHInvalidCode:
+		return "Nutrient recipe target pH must be between 0 and 14."
+	case NutrientRecipeErrorNoIngredientsCode:
+		return "Nutrient recipe needs at least one material."
+	case NutrientRecipeErrorIngredientInvalidCode:
+		return "Nutrient recipe material needs a positive dose per a positive volume."
+	case NutrientRecipeErrorDuplicateIngredientCode:
+		return "Nutrient recipe lists a material more than once."
+	case NutrientRecipeErrorDeletedCode:
+		return "Nutrient recipe is already deleted."
+	default:
+		return "Unrecognized Nutrient Recipe Error Code"
+	}
+}
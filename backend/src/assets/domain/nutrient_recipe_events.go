@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type NutrientRecipeCreated struct {
+	UID         uuid.UUID
+	FarmUID     uuid.UUID
+	Name        string
+	TargetEC    float32
+	TargetPH    float32
+	Ingredients []NutrientRecipeIngredient
+	CreatedDate time.Time
+}
+
+type NutrientRecipeUpdated struct {
+	UID         uuid.UUID
+	FarmUID     uuid.UUID
+	Name        string
+	TargetEC    float32
+	TargetPH    float32
+	Ingredients []NutrientRecipeIngredient
+}
+
+type NutrientRecipeDeleted struct {
+	UID     uuid.UUID
+	FarmUID uuid.UUID
+}
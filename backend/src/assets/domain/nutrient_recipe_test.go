@@ -0,0 +1,149 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/assets/domain"
+)
+
+func TestNutrientRecipe(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+	nitrogenUID, _ := uuid.NewV4()
+	calciumUID, _ := uuid.NewV4()
+
+	ingredients := []NutrientRecipeIngredient{
+		{MaterialUID: nitrogenUID, Dose: 2, PerLitres: 1},
+		{MaterialUID: calciumUID, Dose: 50, PerLitres: 100},
+	}
+
+	// When
+	recipe, err := CreateNutrientRecipe(farmUID, " Leafy greens ", 1.8, 6, ingredients)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "Leafy greens", recipe.Name)
+	assert.Equal(t, []NutrientDose{
+		{MaterialUID: nitrogenUID, Quantity: 40},
+		{MaterialUID: calciumUID, Quantity: 10},
+	}, recipe.Doses(20))
+
+	// When
+	_, errEC := CreateNutrientRecipe(farmUID, "Fruiting", 12, 6, ingredients)
+	_, errPH := CreateNutrientRecipe(farmUID, "Fruiting", 2, -1, ingredients)
+	_, errEmpty := CreateNutrientRecipe(farmUID, "Fruiting", 2, 6, nil)
+	_, errDose := CreateNutrientRecipe(farmUID, "Fruiting", 2, 6, []NutrientRecipeIngredient{
+		{MaterialUID: nitrogenUID, Dose: 2, PerLitres: 0},
+	})
+	_, errDuplicate := CreateNutrientRecipe(farmUID, "Fruiting", 2, 6, []NutrientRecipeIngredient{
+		{MaterialUID: nitrogenUID, Dose: 2, PerLitres: 1},
+		{MaterialUID: nitrogenUID, Dose: 1, PerLitres: 1},
+	})
+
+	// Then
+	assert.Equal(t, NutrientRecipeError{Code: NutrientRecipeErrorECInvalidCode}, errEC)
+	assert.Equal(t, NutrientRecipeError{Code: NutrientRecipeErrorPHInvalidCode}, errPH)
+	assert.Equal(t, NutrientRecipeError{Code: NutrientRecipeErrorNoIngredientsCode}, errEmpty)
+	assert.Equal(t, NutrientRecipeError{Code: NutrientRecipeErrorIngredientInvalidCode}, errDose)
+	assert.Equal(t, NutrientRecipeError{Code: NutrientRecipeErrorDuplicateIngredientCode}, errDuplicate)
+
+	// When
+	err = recipe.Update("Leafy greens", 2, 6.2, ingredients[:1])
+
+	// Then
+	assert.Nil(t, err)
+	assert.Len(t, recipe.Ingredients, 1)
+	assert.Equal(t, float32(6.2), recipe.TargetPH)
+
+	// When
+	err = recipe.Delete()
+	errAgain := recipe.Update("Leafy greens", 2, 6.2, ingredients)
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, recipe.IsDeleted)
+	assert.Equal(t, NutrientRecipeError{Code: NutrientRecipeErrorDeletedCode}, errAgain)
+}
+
+func TestMaterialDeductStock(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	dosingUID, _ := uuid.NewV4()
+	materialType, _ := CreateMaterialTypeAgrochemical(ChemicalTypeFertilizer)
+	material, err := CreateMaterial("Calcium nitrate", "4", MoneyEUR, materialType, 30, MaterialUnitBottles,
+		nil, nil, nil, nil, nil, "")
+	assert.Nil(t, err)
+
+	// When
+	shortfall, err := material.DeductStock(20, StockUse{Reason: StockDeductionReasonDosing, ReferenceUID: dosingUID})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, float32(0), shortfall)
+	assert.Equal(t, float32(10), material.Quantity.Value)
+
+	// When
+	shortfall, err = material.DeductStock(25, StockUse{Reason: StockDeductionReasonDosing, ReferenceUID: dosingUID})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, float32(15), shortfall)
+	assert.Equal(t, float32(0), material.Quantity.Value)
+
+	event := material.UncommittedChanges[2].(MaterialStockDeducted)
+	assert.Equal(t, float32(10), event.PreviousQuantity)
+	assert.Equal(t, float32(25), event.Deducted)
+	assert.Equal(t, dosingUID, event.ReferenceUID)
+}
+
+func TestReservoirMeasureAndDose(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+	materialUID, _ := uuid.NewV4()
+	serviceMock := mockReservoirService(farmUID, "My Farm")
+
+	reservoir, err := CreateReservoir(serviceMock, farmUID, "Main tank", BucketType, 100)
+	assert.Nil(t, err)
+
+	recipe, err := CreateNutrientRecipe(farmUID, "Leafy greens", 1.8, 6, []NutrientRecipeIngredient{
+		{MaterialUID: materialUID, Dose: 2, PerLitres: 1},
+	})
+	assert.Nil(t, err)
+
+	ec := float32(1.2)
+	ph := float32(15)
+
+	// When
+	err = reservoir.Measure(&ec, nil)
+	errEmpty := reservoir.Measure(nil, nil)
+	errPH := reservoir.Measure(nil, &ph)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, ReservoirError{Code: ReservoirErrorMeasurementEmptyCode}, errEmpty)
+	assert.Equal(t, ReservoirError{Code: ReservoirErrorPHInvalidCode}, errPH)
+
+	// When
+	doses := []ReservoirDose{{MaterialUID: materialUID, Quantity: 100, QuantityUnit: MaterialUnitBottles, Shortfall: 20}}
+	err = reservoir.Dose(*recipe, 50, doses)
+	errVolume := reservoir.Dose(*recipe, 0, doses)
+	errCapacity := reservoir.Dose(*recipe, 150, doses)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, ReservoirError{Code: ReservoirErrorDosingVolumeInvalidCode}, errVolume)
+	assert.Equal(t, ReservoirError{Code: ReservoirErrorBucketVolumeInvalidCode}, errCapacity)
+	assert.Len(t, reservoir.UncommittedChanges, 3)
+
+	dosed := reservoir.UncommittedChanges[2].(ReservoirDosed)
+	assert.Equal(t, recipe.UID, dosed.RecipeUID)
+	assert.Equal(t, "Leafy greens", dosed.RecipeName)
+	assert.Equal(t, doses, dosed.Doses)
+}
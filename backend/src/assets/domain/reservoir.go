@@ -236,6 +236,77 @@ func (r *Reservoir) RemoveNote(uid uuid.UUID) error {
 	return nil
 }
 
+// ReservoirDose is the quantity of a material added to the reservoir by a dosing, in the unit of the material.
+// The shortfall is the part of it the stock of the material didn't have.
+type ReservoirDose struct {
+	MaterialUID  uuid.UUID
+	MaterialName string
+	Quantity     float32
+	QuantityUnit string
+	Shortfall    float32
+}
+
+// Measure records the EC, in mS/cm, and the pH of the water. Either can be left out.
+func (r *Reservoir) Measure(ec, ph *float32) error {
+	if ec == nil && ph == nil {
+		return ReservoirError{Code: ReservoirErrorMeasurementEmptyCode}
+	}
+
+	if ec != nil && *ec < 0 {
+		return ReservoirError{Code: ReservoirErrorECInvalidCode}
+	}
+
+	if ph != nil && (*ph < 0 || *ph > 14) {
+		return ReservoirError{Code: ReservoirErrorPHInvalidCode}
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+
+	r.TrackChange(ReservoirMeasured{
+		ReservoirUID: r.UID,
+		UID:          uid,
+		EC:           ec,
+		PH:           ph,
+		MeasuredDate: time.Now(),
+	})
+
+	return nil
+}
+
+// Dose records the recipe added to the volume of water, in litres, with the doses of its materials.
+// A bucket can't be dosed for more water than it holds.
+func (r *Reservoir) Dose(recipe NutrientRecipe, volumeLitres float32, doses []ReservoirDose) error {
+	if volumeLitres <= 0 {
+		return ReservoirError{Code: ReservoirErrorDosingVolumeInvalidCode}
+	}
+
+	if bucket, ok := r.WaterSource.(Bucket); ok && volumeLitres > bucket.Capacity {
+		return ReservoirError{Code: ReservoirErrorBucketVolumeInvalidCode}
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+
+	r.TrackChange(ReservoirDosed{
+		ReservoirUID: r.UID,
+		UID:          uid,
+		RecipeUID:    recipe.UID,
+		RecipeName:   recipe.Name,
+		TargetEC:     recipe.TargetEC,
+		TargetPH:     recipe.TargetPH,
+		VolumeLitres: volumeLitres,
+		Doses:        doses,
+		DosedDate:    time.Now(),
+	})
+
+	return nil
+}
+
 func validateWaterSource(waterSourceType string, capacity float32) (WaterSource, error) {
 	var ws WaterSource
 
@@ -18,6 +18,9 @@ const (
 
 	ReservoirNoteErrorInvalidContent
 	ReservoirNoteErrorNotFound
+
+	ReservoirErrorMeasurementEmptyCode
+	ReservoirErrorDosingVolumeInvalidCode
 )
 
 // ReservoirError is a custom error from Go built-in error.
@@ -47,6 +50,10 @@ func (e ReservoirError) Error() string {
 		return "Reservoir bucket volume is invalid."
 	case ReservoirNoteErrorInvalidContent:
 		return "Invalid reservoir notes content"
+	case ReservoirErrorMeasurementEmptyCode:
+		return "Reservoir measurement needs an EC or a pH value."
+	case ReservoirErrorDosingVolumeInvalidCode:
+		return "Reservoir dosing volume must be positive."
 	default:
 		return "Unrecognized Reservoir Error Code"
 	}
@@ -35,3 +35,23 @@ type ReservoirNoteRemoved struct {
 	ReservoirUID uuid.UUID
 	UID          uuid.UUID
 }
+
+type ReservoirMeasured struct {
+	ReservoirUID uuid.UUID
+	UID          uuid.UUID
+	EC           *float32
+	PH           *float32
+	MeasuredDate time.Time
+}
+
+type ReservoirDosed struct {
+	ReservoirUID uuid.UUID
+	UID          uuid.UUID
+	RecipeUID    uuid.UUID
+	RecipeName   string
+	TargetEC     float32
+	TargetPH     float32
+	VolumeLitres float32
+	Doses        []ReservoirDose
+	DosedDate    time.Time
+}
@@ -13,6 +13,8 @@ type AreaServiceInMemory struct {
 	FarmReadQuery      query.FarmRead
 	ReservoirReadQuery query.ReservoirRead
 	CropReadQuery      query.CropRead
+
+	CustomFieldDefinitionReadQuery query.CustomFieldDefinitionRead
 }
 
 func (s AreaServiceInMemory) FindFarmByID(uid uuid.UUID) (domain.AreaFarmServiceResult, error) {
@@ -28,7 +30,7 @@ func (s AreaServiceInMemory) FindFarmByID(uid uuid.UUID) (domain.AreaFarmService
 		return domain.AreaFarmServiceResult{}, domain.AreaError{Code: domain.AreaErrorFarmNotFound}
 	}
 
-	if farm == (storage.FarmRead{}) {
+	if farm.UID == (uuid.UUID{}) {
 		return domain.AreaFarmServiceResult{}, domain.AreaError{Code: domain.AreaErrorFarmNotFound}
 	}
 
@@ -74,3 +76,27 @@ func (s AreaServiceInMemory) CountCropsByAreaID(areaUID uuid.UUID) (int, error)
 
 	return totals.TotalCropBatch, nil
 }
+
+func (s AreaServiceInMemory) FindCustomFieldsByFarm(farmUID uuid.UUID, entityType string) ([]domain.CustomFieldSchema, error) {
+	result := <-s.CustomFieldDefinitionReadQuery.FindAllByFarm(farmUID, entityType)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	definitions, ok := result.Result.([]storage.CustomFieldDefinitionRead)
+	if !ok {
+		return nil, errors.New("internal server error")
+	}
+
+	fields := []domain.CustomFieldSchema{}
+	for _, v := range definitions {
+		fields = append(fields, domain.CustomFieldSchema{
+			FieldKey:  v.FieldKey,
+			FieldType: v.FieldType,
+			Options:   v.Options,
+			Required:  v.Required,
+		})
+	}
+
+	return fields, nil
+}
@@ -24,7 +24,7 @@ func (s ReservoirServiceInMemory) FindFarmByID(uid uuid.UUID) (domain.ReservoirF
 		return domain.ReservoirFarmServiceResult{}, domain.ReservoirError{Code: domain.ReservoirErrorFarmNotFound}
 	}
 
-	if farm == (storage.FarmRead{}) {
+	if farm.UID == (uuid.UUID{}) {
 		return domain.ReservoirFarmServiceResult{}, domain.ReservoirError{Code: domain.ReservoirErrorFarmNotFound}
 	}
 
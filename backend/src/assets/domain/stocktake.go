@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	StocktakeStatusOpen   = "OPEN"
+	StocktakeStatusClosed = "CLOSED"
+)
+
+// StockCorrectionReasonStocktake is the reason of the stock corrections made when a stocktake is closed.
+const StockCorrectionReasonStocktake = "STOCKTAKE"
+
+// Stocktake is a physical count of the materials, compared to the quantities in the system when it was opened.
+// While it's open the quantities of its materials are frozen, so the counts aren't compared to a moving target.
+type Stocktake struct {
+	UID        uuid.UUID
+	FarmUID    uuid.UUID
+	Status     string
+	Lines      []StocktakeLine
+	OpenedDate time.Time
+	ClosedDate *time.Time
+
+	// Events
+	Version            int
+	UncommittedChanges []interface{}
+}
+
+// StocktakeLine is the expected quantity of a material and the counted one, once submitted.
+type StocktakeLine struct {
+	MaterialUID      uuid.UUID
+	MaterialName     string
+	ExpectedQuantity float32
+	QuantityUnit     string
+	CountedQuantity  *float32
+}
+
+// StocktakeVariance is the difference between the counted and the expected quantity of a material.
+type StocktakeVariance struct {
+	MaterialUID      uuid.UUID
+	MaterialName     string
+	ExpectedQuantity float32
+	CountedQuantity  float32
+	QuantityUnit     string
+}
+
+func (v StocktakeVariance) Difference() float32 {
+	return v.CountedQuantity - v.ExpectedQuantity
+}
+
+func OpenStocktake(farmUID uuid.UUID, lines []StocktakeLine) (*Stocktake, error) {
+	if len(lines) == 0 {
+		return nil, StocktakeError{Code: StocktakeErrorNoMaterialsCode}
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	initial := &Stocktake{}
+
+	initial.TrackChange(StocktakeOpened{
+		UID:        uid,
+		FarmUID:    farmUID,
+		Lines:      lines,
+		OpenedDate: time.Now(),
+	})
+
+	return initial, nil
+}
+
+// Count submits the counted quantity of a material. A material can be counted again until the stocktake is closed.
+func (s *Stocktake) Count(materialUID uuid.UUID, quantity float32) error {
+	if s.Status != StocktakeStatusOpen {
+		return StocktakeError{Code: StocktakeErrorClosedCode}
+	}
+
+	if !s.Lists(materialUID) {
+		return StocktakeError{Code: StocktakeErrorMaterialNotListedCode}
+	}
+
+	if quantity < 0 {
+		return StocktakeError{Code: StocktakeErrorInvalidQuantityCode}
+	}
+
+	s.TrackChange(StocktakeCounted{
+		UID:             s.UID,
+		MaterialUID:     materialUID,
+		CountedQuantity: quantity,
+		CountedDate:     time.Now(),
+	})
+
+	return nil
+}
+
+// Close ends the stocktake with the variances of the counted materials.
+// The materials which weren't counted keep their quantity.
+func (s *Stocktake) Close() error {
+	if s.Status != StocktakeStatusOpen {
+		return StocktakeError{Code: StocktakeErrorClosedCode}
+	}
+
+	s.TrackChange(StocktakeClosed{
+		UID:        s.UID,
+		FarmUID:    s.FarmUID,
+		Variances:  s.Variances(),
+		ClosedDate: time.Now(),
+	})
+
+	return nil
+}
+
+// Lists tells whether the material is counted by the stocktake.
+func (s Stocktake) Lists(materialUID uuid.UUID) bool {
+	for _, v := range s.Lines {
+		if v.MaterialUID == materialUID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Variances returns the counted materials whose quantity differs from the expected one.
+func (s Stocktake) Variances() []StocktakeVariance {
+	variances := []StocktakeVariance{}
+
+	for _, v := range s.Lines {
+		if v.CountedQuantity == nil || *v.CountedQuantity == v.ExpectedQuantity {
+			continue
+		}
+
+		variances = append(variances, StocktakeVariance{
+			MaterialUID:      v.MaterialUID,
+			MaterialName:     v.MaterialName,
+			ExpectedQuantity: v.ExpectedQuantity,
+			CountedQuantity:  *v.CountedQuantity,
+			QuantityUnit:     v.QuantityUnit,
+		})
+	}
+
+	return variances
+}
+
+// Event Tracking.
+func (s *Stocktake) TrackChange(event interface{}) {
+	s.UncommittedChanges = append(s.UncommittedChanges, event)
+	s.Transition(event)
+}
+
+func (s *Stocktake) Transition(event interface{}) {
+	switch e := event.(type) {
+	case StocktakeOpened:
+		s.UID = e.UID
+		s.FarmUID = e.FarmUID
+		s.Status = StocktakeStatusOpen
+		s.Lines = e.Lines
+		s.OpenedDate = e.OpenedDate
+	case StocktakeCounted:
+		for i, v := range s.Lines {
+			if v.MaterialUID == e.MaterialUID {
+				quantity := e.CountedQuantity
+				s.Lines[i].CountedQuantity = &quantity
+			}
+		}
+	case StocktakeClosed:
+		s.Status = StocktakeStatusClosed
+		s.ClosedDate = &e.ClosedDate
+	}
+}
@@ -0,0 +1,31 @@
+package domain
+
+// StocktakeError is a custom error from Go built-in error.
+type StocktakeError struct {
+	Code int
+}
+
+const (
+	StocktakeErrorNoMaterialsCode = iota
+	StocktakeErrorAlreadyOpenCode
+	StocktakeErrorClosedCode
+	StocktakeErrorMaterialNotListedCode
+	StocktakeErrorInvalidQuantityCode
+)
+
+func (e StocktakeError) Error() string {
+	switch e.Code {
+	case StocktakeErrorNoMaterialsCode:
+		return "There are no materials to count."
+	case StocktakeErrorAlreadyOpenCode:
+		return "The farm already has an open stocktake."
+	case StocktakeErrorClosedCode:
+		return "Stocktake is already closed."
+	case StocktakeErrorMaterialNotListedCode:
+		return "Material is not listed in the stocktake."
+	case StocktakeErrorInvalidQuantityCode:
+		return "Counted quantity cannot be negative."
+	default:
+		return "Unrecognized Stocktake Error Code"
+	}
+}
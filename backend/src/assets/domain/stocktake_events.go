@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type StocktakeOpened struct {
+	UID        uuid.UUID
+	FarmUID    uuid.UUID
+	Lines      []StocktakeLine
+	OpenedDate time.Time
+}
+
+type StocktakeCounted struct {
+	UID             uuid.UUID
+	MaterialUID     uuid.UUID
+	CountedQuantity float32
+	CountedDate     time.Time
+}
+
+type StocktakeClosed struct {
+	UID        uuid.UUID
+	FarmUID    uuid.UUID
+	Variances  []StocktakeVariance
+	ClosedDate time.Time
+}
@@ -0,0 +1,76 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/assets/domain"
+)
+
+func TestStocktakeVariances(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+	seedUID, _ := uuid.NewV4()
+	growingMediumUID, _ := uuid.NewV4()
+	fertilizerUID, _ := uuid.NewV4()
+	otherUID, _ := uuid.NewV4()
+
+	stocktake, err := OpenStocktake(farmUID, []StocktakeLine{
+		{MaterialUID: seedUID, MaterialName: "Tomato seeds", ExpectedQuantity: 100, QuantityUnit: "PACKETS"},
+		{MaterialUID: growingMediumUID, MaterialName: "Cocopeat", ExpectedQuantity: 20, QuantityUnit: "BAGS"},
+		{MaterialUID: fertilizerUID, MaterialName: "NPK", ExpectedQuantity: 5, QuantityUnit: "KG"},
+	})
+	assert.Nil(t, err)
+
+	// When
+	err = stocktake.Count(seedUID, 90)
+	assert.Nil(t, err)
+
+	err = stocktake.Count(seedUID, 95)
+	assert.Nil(t, err)
+
+	err = stocktake.Count(growingMediumUID, 20)
+	assert.Nil(t, err)
+
+	errNotListed := stocktake.Count(otherUID, 1)
+	errNegative := stocktake.Count(fertilizerUID, -1)
+
+	// Then
+	assert.Equal(t, StocktakeError{StocktakeErrorMaterialNotListedCode}, errNotListed)
+	assert.Equal(t, StocktakeError{StocktakeErrorInvalidQuantityCode}, errNegative)
+
+	variances := stocktake.Variances()
+	assert.Len(t, variances, 1)
+	assert.Equal(t, seedUID, variances[0].MaterialUID)
+	assert.Equal(t, float32(-5), variances[0].Difference())
+
+	// When
+	err = stocktake.Close()
+	errAgain := stocktake.Count(growingMediumUID, 19)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, StocktakeStatusClosed, stocktake.Status)
+	assert.NotNil(t, stocktake.ClosedDate)
+	assert.Equal(t, StocktakeError{StocktakeErrorClosedCode}, errAgain)
+
+	event, ok := stocktake.UncommittedChanges[len(stocktake.UncommittedChanges)-1].(StocktakeClosed)
+	assert.True(t, ok)
+	assert.Equal(t, variances, event.Variances)
+}
+
+func TestOpenStocktakeWithoutMaterials(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+
+	// When
+	_, err := OpenStocktake(farmUID, []StocktakeLine{})
+
+	// Then
+	assert.Equal(t, StocktakeError{StocktakeErrorNoMaterialsCode}, err)
+}
@@ -0,0 +1,84 @@
+package inmemory
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/query"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type AreaTaskReadQueryInMemory struct {
+	Storage *storage.TaskReadStorage
+}
+
+func NewAreaTaskReadQueryInMemory(s *storage.TaskReadStorage) query.AreaTaskRead {
+	return AreaTaskReadQueryInMemory{Storage: s}
+}
+
+func (s AreaTaskReadQueryInMemory) CountOverdueByArea(date time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		counts := map[uuid.UUID]int{}
+
+		for _, val := range s.Storage.TaskReadMap {
+			if val.Domain != tasksdomain.TaskDomainAreaCode || val.Status != tasksdomain.TaskStatusCreated ||
+				val.AssetID == nil || val.DueDate == nil || !val.DueDate.Before(date) {
+				continue
+			}
+
+			counts[*val.AssetID]++
+		}
+
+		result <- query.Result{Result: counts}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (s AreaTaskReadQueryInMemory) FindAllOpenByAssets(assetUIDs []uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		assets := map[uuid.UUID]bool{}
+		for _, v := range assetUIDs {
+			assets[v] = true
+		}
+
+		tasks := []query.AreaTaskResult{}
+
+		for _, val := range s.Storage.TaskReadMap {
+			if val.Status != tasksdomain.TaskStatusCreated || val.AssetID == nil || !assets[*val.AssetID] {
+				continue
+			}
+
+			tasks = append(tasks, query.AreaTaskResult{
+				UID:       val.UID,
+				ShortCode: val.ShortCode,
+				Title:     val.Title,
+				Priority:  val.Priority,
+				Status:    val.Status,
+				Domain:    val.Domain,
+				Category:  val.Category,
+				DueDate:   val.DueDate,
+				AssetID:   *val.AssetID,
+			})
+		}
+
+		result <- query.Result{Result: tasks}
+
+		close(result)
+	}()
+
+	return result
+}
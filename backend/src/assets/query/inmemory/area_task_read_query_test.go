@@ -0,0 +1,62 @@
+package inmemory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/query/inmemory"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+func TestFindAllOpenByAssets(t *testing.T) {
+	t.Parallel()
+	// Given
+	areaUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	otherAreaUID, _ := uuid.NewV4()
+	dueDate := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+	taskReadStorage := storage.CreateTaskReadStorage()
+	seed := func(title, status, domain string, assetID *uuid.UUID) uuid.UUID {
+		uid, _ := uuid.NewV4()
+		taskReadStorage.TaskReadMap[uid] = storage.TaskRead{
+			UID:     uid,
+			Title:   title,
+			Status:  status,
+			Domain:  domain,
+			DueDate: &dueDate,
+			AssetID: assetID,
+		}
+
+		return uid
+	}
+
+	weedUID := seed("Weed the beds", tasksdomain.TaskStatusCreated, tasksdomain.TaskDomainAreaCode, &areaUID)
+	pruneUID := seed("Prune the tomatoes", tasksdomain.TaskStatusCreated, tasksdomain.TaskDomainCropCode, &cropUID)
+	seed("Water the beds", tasksdomain.TaskStatusCompleted, tasksdomain.TaskDomainAreaCode, &areaUID)
+	seed("Mow the lawn", tasksdomain.TaskStatusCreated, tasksdomain.TaskDomainAreaCode, &otherAreaUID)
+	seed("Order the seeds", tasksdomain.TaskStatusCreated, tasksdomain.TaskDomainGeneralCode, nil)
+
+	areaTaskQuery := inmemory.NewAreaTaskReadQueryInMemory(taskReadStorage)
+
+	// When
+	result := <-areaTaskQuery.FindAllOpenByAssets([]uuid.UUID{areaUID, cropUID})
+
+	// Then
+	assert.Nil(t, result.Error)
+
+	tasks, ok := result.Result.([]query.AreaTaskResult)
+	assert.True(t, ok)
+	assert.Len(t, tasks, 2)
+
+	found := map[uuid.UUID]uuid.UUID{}
+	for _, v := range tasks {
+		found[v.UID] = v.AssetID
+	}
+
+	assert.Equal(t, map[uuid.UUID]uuid.UUID{weedUID: areaUID, pruneUID: cropUID}, found)
+}
@@ -3,6 +3,7 @@ package inmemory
 import (
 	"github.com/gofrs/uuid"
 	"github.com/usetania/tania-core/src/assets/query"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
 	"github.com/usetania/tania-core/src/growth/storage"
 )
 
@@ -72,7 +73,8 @@ func (q CropReadQueryInMemory) FindAllCropByArea(areaUID uuid.UUID) <-chan query
 					MovingDate:  val.InitialArea.CreatedDate,
 					CreatedDate: val.InitialArea.CreatedDate,
 					Inventory: query.Inventory{
-						UID: val.Inventory.UID,
+						UID:  val.Inventory.UID,
+						Name: val.Inventory.Name,
 					},
 					Container: query.Container{
 						Quantity: val.Container.Quantity,
@@ -95,7 +97,8 @@ func (q CropReadQueryInMemory) FindAllCropByArea(areaUID uuid.UUID) <-chan query
 						MovingDate:  val.InitialArea.CreatedDate,
 						CreatedDate: val.InitialArea.CreatedDate,
 						Inventory: query.Inventory{
-							UID: val.Inventory.UID,
+							UID:  val.Inventory.UID,
+							Name: val.Inventory.Name,
 						},
 						Container: query.Container{
 							Quantity: val.Container.Quantity,
@@ -116,3 +119,50 @@ func (q CropReadQueryInMemory) FindAllCropByArea(areaUID uuid.UUID) <-chan query
 
 	return result
 }
+
+func (q CropReadQueryInMemory) FindAllCurrentByArea(areaUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		q.Storage.Lock.RLock()
+		defer q.Storage.Lock.RUnlock()
+
+		crops := []query.AreaCurrentCropResult{}
+
+		for _, val := range q.Storage.CropReadMap {
+			if val.Status != growthdomain.CropActive {
+				continue
+			}
+
+			quantity := 0
+			if val.InitialArea.AreaUID == areaUID {
+				quantity += val.InitialArea.CurrentQuantity
+			}
+
+			for _, v := range val.MovedArea {
+				if v.AreaUID == areaUID {
+					quantity += v.CurrentQuantity
+				}
+			}
+
+			if quantity <= 0 {
+				continue
+			}
+
+			crops = append(crops, query.AreaCurrentCropResult{
+				CropUID:     val.UID,
+				BatchID:     val.BatchID,
+				ShortCode:   val.ShortCode,
+				VarietyName: val.Inventory.Name,
+				PlantType:   val.Inventory.PlantType,
+				Quantity:    quantity,
+			})
+		}
+
+		result <- query.Result{Result: crops}
+
+		close(result)
+	}()
+
+	return result
+}
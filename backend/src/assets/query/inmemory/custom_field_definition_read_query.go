@@ -0,0 +1,59 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type CustomFieldDefinitionReadQueryInMemory struct {
+	Storage *storage.CustomFieldDefinitionReadStorage
+}
+
+func NewCustomFieldDefinitionReadQueryInMemory(s *storage.CustomFieldDefinitionReadStorage) query.CustomFieldDefinitionRead {
+	return CustomFieldDefinitionReadQueryInMemory{Storage: s}
+}
+
+func (s CustomFieldDefinitionReadQueryInMemory) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		result <- query.Result{Result: s.Storage.CustomFieldDefinitionReadMap[uid]}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (s CustomFieldDefinitionReadQueryInMemory) FindAllByFarm(farmUID uuid.UUID, entityType string) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		definitions := []storage.CustomFieldDefinitionRead{}
+
+		for _, val := range s.Storage.CustomFieldDefinitionReadMap {
+			if val.FarmUID == farmUID && !val.IsDeleted && (entityType == "" || val.EntityType == entityType) {
+				definitions = append(definitions, val)
+			}
+		}
+
+		sort.Slice(definitions, func(i, j int) bool {
+			return definitions[i].CreatedDate.Before(definitions[j].CreatedDate)
+		})
+
+		result <- query.Result{Result: definitions}
+
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,74 @@
+package inmemory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type EquipmentReadQueryInMemory struct {
+	Storage *storage.EquipmentReadStorage
+}
+
+func NewEquipmentReadQueryInMemory(s *storage.EquipmentReadStorage) query.EquipmentRead {
+	return EquipmentReadQueryInMemory{Storage: s}
+}
+
+func (s EquipmentReadQueryInMemory) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		result <- query.Result{Result: s.Storage.EquipmentReadMap[uid]}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (s EquipmentReadQueryInMemory) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	return s.findAll(func(val storage.EquipmentRead) bool {
+		return val.FarmUID == farmUID
+	})
+}
+
+func (s EquipmentReadQueryInMemory) FindAllMaintenanceDue(maintenanceDate time.Time) <-chan query.Result {
+	return s.findAll(func(val storage.EquipmentRead) bool {
+		return val.MaintenanceIntervalDays > 0 &&
+			val.NextMaintenanceDate != nil &&
+			!val.NextMaintenanceDate.After(maintenanceDate)
+	})
+}
+
+func (s EquipmentReadQueryInMemory) findAll(match func(storage.EquipmentRead) bool) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		equipment := []storage.EquipmentRead{}
+
+		for _, val := range s.Storage.EquipmentReadMap {
+			if !val.IsDeleted && match(val) {
+				equipment = append(equipment, val)
+			}
+		}
+
+		sort.Slice(equipment, func(i, j int) bool {
+			return equipment[i].Name < equipment[j].Name
+		})
+
+		result <- query.Result{Result: equipment}
+
+		close(result)
+	}()
+
+	return result
+}
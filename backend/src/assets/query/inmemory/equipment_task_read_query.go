@@ -0,0 +1,52 @@
+package inmemory
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/query"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type EquipmentTaskReadQueryInMemory struct {
+	Storage *storage.TaskReadStorage
+}
+
+func NewEquipmentTaskReadQueryInMemory(s *storage.TaskReadStorage) query.EquipmentTaskRead {
+	return EquipmentTaskReadQueryInMemory{Storage: s}
+}
+
+func (s EquipmentTaskReadQueryInMemory) FindNextByEquipment(equipmentUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		next := query.EquipmentTaskResult{}
+
+		for _, val := range s.Storage.TaskReadMap {
+			if val.Domain != tasksdomain.TaskDomainEquipmentCode || val.Status != tasksdomain.TaskStatusCreated ||
+				val.AssetID == nil || *val.AssetID != equipmentUID {
+				continue
+			}
+
+			task := query.EquipmentTaskResult{
+				UID:       val.UID,
+				ShortCode: val.ShortCode,
+				Title:     val.Title,
+				Status:    val.Status,
+				DueDate:   val.DueDate,
+			}
+
+			if next.UID == (uuid.UUID{}) || task.IsDueBefore(next) {
+				next = task
+			}
+		}
+
+		result <- query.Result{Result: next}
+
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,44 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type FarmCertificationEventQueryInMemory struct {
+	Storage *storage.FarmCertificationEventStorage
+}
+
+func NewFarmCertificationEventQueryInMemory(s *storage.FarmCertificationEventStorage) query.FarmCertificationEvent {
+	return &FarmCertificationEventQueryInMemory{Storage: s}
+}
+
+func (f *FarmCertificationEventQueryInMemory) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		f.Storage.Lock.RLock()
+		defer f.Storage.Lock.RUnlock()
+
+		events := []storage.FarmCertificationEvent{}
+
+		for _, v := range f.Storage.FarmCertificationEvents {
+			if v.FarmCertificationUID == uid {
+				events = append(events, v)
+			}
+		}
+
+		sort.Slice(events, func(i, j int) bool {
+			return events[i].Version < events[j].Version
+		})
+
+		result <- query.Result{Result: events}
+
+		close(result)
+	}()
+
+	return result
+}
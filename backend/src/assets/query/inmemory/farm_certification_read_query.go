@@ -0,0 +1,73 @@
+package inmemory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type FarmCertificationReadQueryInMemory struct {
+	Storage *storage.FarmCertificationReadStorage
+}
+
+func NewFarmCertificationReadQueryInMemory(s *storage.FarmCertificationReadStorage) query.FarmCertificationRead {
+	return FarmCertificationReadQueryInMemory{Storage: s}
+}
+
+func (s FarmCertificationReadQueryInMemory) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		result <- query.Result{Result: s.Storage.FarmCertificationReadMap[uid]}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (s FarmCertificationReadQueryInMemory) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	return s.findAll(func(val storage.FarmCertificationRead) bool {
+		return val.FarmUID == farmUID
+	})
+}
+
+func (s FarmCertificationReadQueryInMemory) FindAllRenewalDue(expiryDate time.Time) <-chan query.Result {
+	return s.findAll(func(val storage.FarmCertificationRead) bool {
+		return val.Status == domain.CertificationStatusActive && !val.IsRenewalDue && !val.ExpiryDate.After(expiryDate)
+	})
+}
+
+func (s FarmCertificationReadQueryInMemory) findAll(match func(storage.FarmCertificationRead) bool) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		certifications := []storage.FarmCertificationRead{}
+
+		for _, val := range s.Storage.FarmCertificationReadMap {
+			if match(val) {
+				certifications = append(certifications, val)
+			}
+		}
+
+		sort.Slice(certifications, func(i, j int) bool {
+			return certifications[i].ExpiryDate.Before(certifications[j].ExpiryDate)
+		})
+
+		result <- query.Result{Result: certifications}
+
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,44 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type NutrientRecipeEventQueryInMemory struct {
+	Storage *storage.NutrientRecipeEventStorage
+}
+
+func NewNutrientRecipeEventQueryInMemory(s *storage.NutrientRecipeEventStorage) query.NutrientRecipeEvent {
+	return &NutrientRecipeEventQueryInMemory{Storage: s}
+}
+
+func (f *NutrientRecipeEventQueryInMemory) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		f.Storage.Lock.RLock()
+		defer f.Storage.Lock.RUnlock()
+
+		events := []storage.NutrientRecipeEvent{}
+
+		for _, v := range f.Storage.NutrientRecipeEvents {
+			if v.NutrientRecipeUID == uid {
+				events = append(events, v)
+			}
+		}
+
+		sort.Slice(events, func(i, j int) bool {
+			return events[i].Version < events[j].Version
+		})
+
+		result <- query.Result{Result: events}
+
+		close(result)
+	}()
+
+	return result
+}
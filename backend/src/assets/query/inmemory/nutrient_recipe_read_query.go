@@ -0,0 +1,59 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type NutrientRecipeReadQueryInMemory struct {
+	Storage *storage.NutrientRecipeReadStorage
+}
+
+func NewNutrientRecipeReadQueryInMemory(s *storage.NutrientRecipeReadStorage) query.NutrientRecipeRead {
+	return NutrientRecipeReadQueryInMemory{Storage: s}
+}
+
+func (s NutrientRecipeReadQueryInMemory) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		result <- query.Result{Result: s.Storage.NutrientRecipeReadMap[uid]}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (s NutrientRecipeReadQueryInMemory) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		recipes := []storage.NutrientRecipeRead{}
+
+		for _, val := range s.Storage.NutrientRecipeReadMap {
+			if val.FarmUID == farmUID && !val.IsDeleted {
+				recipes = append(recipes, val)
+			}
+		}
+
+		sort.Slice(recipes, func(i, j int) bool {
+			return recipes[i].CreatedDate.Before(recipes[j].CreatedDate)
+		})
+
+		result <- query.Result{Result: recipes}
+
+		close(result)
+	}()
+
+	return result
+}
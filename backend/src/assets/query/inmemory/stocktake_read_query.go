@@ -0,0 +1,72 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type StocktakeReadQueryInMemory struct {
+	Storage *storage.StocktakeReadStorage
+}
+
+func NewStocktakeReadQueryInMemory(s *storage.StocktakeReadStorage) query.StocktakeRead {
+	return StocktakeReadQueryInMemory{Storage: s}
+}
+
+func (s StocktakeReadQueryInMemory) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		result <- query.Result{Result: s.Storage.StocktakeReadMap[uid]}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (s StocktakeReadQueryInMemory) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	return s.findAll(func(stocktake storage.StocktakeRead) bool {
+		return stocktake.FarmUID == farmUID
+	})
+}
+
+func (s StocktakeReadQueryInMemory) FindAllOpen() <-chan query.Result {
+	return s.findAll(func(stocktake storage.StocktakeRead) bool {
+		return stocktake.Status == domain.StocktakeStatusOpen
+	})
+}
+
+func (s StocktakeReadQueryInMemory) findAll(match func(storage.StocktakeRead) bool) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		stocktakes := []storage.StocktakeRead{}
+
+		for _, val := range s.Storage.StocktakeReadMap {
+			if match(val) {
+				stocktakes = append(stocktakes, val)
+			}
+		}
+
+		sort.Slice(stocktakes, func(i, j int) bool {
+			return stocktakes[i].OpenedDate.After(stocktakes[j].OpenedDate)
+		})
+
+		result <- query.Result{Result: stocktakes}
+
+		close(result)
+	}()
+
+	return result
+}
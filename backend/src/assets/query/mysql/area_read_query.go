@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -36,6 +37,13 @@ type areaReadResult struct {
 	ReservoirName string
 	FarmUID       []byte
 	FarmName      string
+	CustomFields  sql.NullString
+	Layout        sql.NullString
+	BedMap        sql.NullString
+	GeoPoint      sql.NullString
+
+	Sanitations       sql.NullString
+	LastSanitizedDate sql.NullTime
 }
 
 type areaNotesReadResult struct {
@@ -70,6 +78,12 @@ func (s AreaReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
 			&rowsData.ReservoirName,
 			&rowsData.FarmUID,
 			&rowsData.FarmName,
+			&rowsData.CustomFields,
+			&rowsData.Layout,
+			&rowsData.BedMap,
+			&rowsData.GeoPoint,
+			&rowsData.Sanitations,
+			&rowsData.LastSanitizedDate,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -95,6 +109,31 @@ func (s AreaReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
 			result <- query.Result{Error: err}
 		}
 
+		customFields, err := decodeAreaCustomFields(rowsData.CustomFields)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		layout, err := decodeAreaLayout(rowsData.Layout)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		bedMap, err := decodeAreaBedMap(rowsData.BedMap)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		geoPoint, err := decodeGeoPoint(rowsData.GeoPoint)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		sanitations, err := decodeAreaSanitations(rowsData.Sanitations)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
 		rows, err := s.DB.Query("SELECT * FROM AREA_READ_NOTES WHERE AREA_UID = ?", uid.Bytes())
 		if err != nil {
 			result <- query.Result{Error: err}
@@ -161,6 +200,12 @@ func (s AreaReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
 				UID:  reservoirUID,
 				Name: rowsData.ReservoirName,
 			},
+			CustomFields:      customFields,
+			Layout:            layout,
+			BedMap:            bedMap,
+			GeoPoint:          geoPoint,
+			Sanitations:       sanitations,
+			LastSanitizedDate: decodeAreaLastSanitizedDate(rowsData.LastSanitizedDate),
 		}
 
 		result <- query.Result{Result: areaRead}
@@ -200,6 +245,12 @@ func (s AreaReadQueryMysql) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result
 				&rowsData.ReservoirName,
 				&rowsData.FarmUID,
 				&rowsData.FarmName,
+				&rowsData.CustomFields,
+				&rowsData.Layout,
+				&rowsData.BedMap,
+				&rowsData.GeoPoint,
+				&rowsData.Sanitations,
+				&rowsData.LastSanitizedDate,
 			); err != nil {
 				result <- query.Result{Error: err}
 			}
@@ -219,6 +270,31 @@ func (s AreaReadQueryMysql) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result
 				result <- query.Result{Error: err}
 			}
 
+			customFields, err := decodeAreaCustomFields(rowsData.CustomFields)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			layout, err := decodeAreaLayout(rowsData.Layout)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			bedMap, err := decodeAreaBedMap(rowsData.BedMap)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			geoPoint, err := decodeGeoPoint(rowsData.GeoPoint)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			sanitations, err := decodeAreaSanitations(rowsData.Sanitations)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
 			rows, err := s.DB.Query("SELECT * FROM AREA_READ_NOTES WHERE AREA_UID = ?", areaUID.Bytes())
 			if err != nil {
 				result <- query.Result{Error: err}
@@ -285,6 +361,12 @@ func (s AreaReadQueryMysql) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result
 					UID:  reservoirUID,
 					Name: rowsData.ReservoirName,
 				},
+				CustomFields:      customFields,
+				Layout:            layout,
+				BedMap:            bedMap,
+				GeoPoint:          geoPoint,
+				Sanitations:       sanitations,
+				LastSanitizedDate: decodeAreaLastSanitizedDate(rowsData.LastSanitizedDate),
 			})
 		}
 
@@ -320,6 +402,12 @@ func (s AreaReadQueryMysql) FindByIDAndFarm(areaUID, farmUID uuid.UUID) <-chan q
 			&rowsData.ReservoirName,
 			&rowsData.FarmUID,
 			&rowsData.FarmName,
+			&rowsData.CustomFields,
+			&rowsData.Layout,
+			&rowsData.BedMap,
+			&rowsData.GeoPoint,
+			&rowsData.Sanitations,
+			&rowsData.LastSanitizedDate,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -345,6 +433,31 @@ func (s AreaReadQueryMysql) FindByIDAndFarm(areaUID, farmUID uuid.UUID) <-chan q
 			result <- query.Result{Error: err}
 		}
 
+		customFields, err := decodeAreaCustomFields(rowsData.CustomFields)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		layout, err := decodeAreaLayout(rowsData.Layout)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		bedMap, err := decodeAreaBedMap(rowsData.BedMap)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		geoPoint, err := decodeGeoPoint(rowsData.GeoPoint)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		sanitations, err := decodeAreaSanitations(rowsData.Sanitations)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
 		rows, err := s.DB.Query("SELECT * FROM AREA_READ_NOTES WHERE AREA_UID = ?", areaUID.Bytes())
 		if err != nil {
 			result <- query.Result{Error: err}
@@ -411,6 +524,12 @@ func (s AreaReadQueryMysql) FindByIDAndFarm(areaUID, farmUID uuid.UUID) <-chan q
 				UID:  reservoirUID,
 				Name: rowsData.ReservoirName,
 			},
+			CustomFields:      customFields,
+			Layout:            layout,
+			BedMap:            bedMap,
+			GeoPoint:          geoPoint,
+			Sanitations:       sanitations,
+			LastSanitizedDate: decodeAreaLastSanitizedDate(rowsData.LastSanitizedDate),
 		}
 
 		result <- query.Result{Result: areaRead}
@@ -450,6 +569,12 @@ func (s AreaReadQueryMysql) FindAreasByReservoirID(reservoirUID uuid.UUID) <-cha
 				&rowsData.ReservoirName,
 				&rowsData.FarmUID,
 				&rowsData.FarmName,
+				&rowsData.CustomFields,
+				&rowsData.Layout,
+				&rowsData.BedMap,
+				&rowsData.GeoPoint,
+				&rowsData.Sanitations,
+				&rowsData.LastSanitizedDate,
 			); err != nil {
 				result <- query.Result{Error: err}
 			}
@@ -469,6 +594,31 @@ func (s AreaReadQueryMysql) FindAreasByReservoirID(reservoirUID uuid.UUID) <-cha
 				result <- query.Result{Error: err}
 			}
 
+			customFields, err := decodeAreaCustomFields(rowsData.CustomFields)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			layout, err := decodeAreaLayout(rowsData.Layout)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			bedMap, err := decodeAreaBedMap(rowsData.BedMap)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			geoPoint, err := decodeGeoPoint(rowsData.GeoPoint)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			sanitations, err := decodeAreaSanitations(rowsData.Sanitations)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
 			rows, err := s.DB.Query("SELECT * FROM AREA_READ_NOTES WHERE AREA_UID = ?", areaUID.Bytes())
 			if err != nil {
 				result <- query.Result{Error: err}
@@ -535,6 +685,12 @@ func (s AreaReadQueryMysql) FindAreasByReservoirID(reservoirUID uuid.UUID) <-cha
 					UID:  reservoirUID,
 					Name: rowsData.ReservoirName,
 				},
+				CustomFields:      customFields,
+				Layout:            layout,
+				BedMap:            bedMap,
+				GeoPoint:          geoPoint,
+				Sanitations:       sanitations,
+				LastSanitizedDate: decodeAreaLastSanitizedDate(rowsData.LastSanitizedDate),
 			})
 		}
 
@@ -563,3 +719,100 @@ func (s AreaReadQueryMysql) CountAreas(farmUID uuid.UUID) <-chan query.Result {
 
 	return result
 }
+
+// decodeAreaCustomFields decodes the JSON of the custom fields, which is NULL for the areas saved before they existed.
+func decodeAreaCustomFields(value sql.NullString) (map[string]interface{}, error) {
+	customFields := map[string]interface{}{}
+
+	if !value.Valid || value.String == "" {
+		return customFields, nil
+	}
+
+	err := json.Unmarshal([]byte(value.String), &customFields)
+	if err != nil {
+		return nil, err
+	}
+
+	if customFields == nil {
+		customFields = map[string]interface{}{}
+	}
+
+	return customFields, nil
+}
+
+// decodeAreaLayout decodes the JSON of the layout, which is NULL for the areas saved before it existed.
+func decodeAreaLayout(value sql.NullString) (*storage.AreaLayout, error) {
+	if !value.Valid || value.String == "" {
+		return nil, nil
+	}
+
+	var layout *storage.AreaLayout
+
+	err := json.Unmarshal([]byte(value.String), &layout)
+	if err != nil {
+		return nil, err
+	}
+
+	return layout, nil
+}
+
+// decodeAreaBedMap decodes the JSON of the bed map, which is NULL for the areas saved before it existed.
+func decodeAreaBedMap(value sql.NullString) (*storage.BedMap, error) {
+	if !value.Valid || value.String == "" {
+		return nil, nil
+	}
+
+	var bedMap *storage.BedMap
+
+	err := json.Unmarshal([]byte(value.String), &bedMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return bedMap, nil
+}
+
+// decodeGeoPoint decodes the JSON of a GPS coordinate, which is NULL for the assets without one.
+func decodeGeoPoint(value sql.NullString) (*domain.GeoPoint, error) {
+	if !value.Valid || value.String == "" {
+		return nil, nil
+	}
+
+	var point *domain.GeoPoint
+
+	err := json.Unmarshal([]byte(value.String), &point)
+	if err != nil {
+		return nil, err
+	}
+
+	return point, nil
+}
+
+// decodeAreaSanitations decodes the JSON of the sanitations, which is NULL for the areas saved before they existed.
+func decodeAreaSanitations(value sql.NullString) ([]storage.AreaSanitation, error) {
+	sanitations := []storage.AreaSanitation{}
+
+	if !value.Valid || value.String == "" {
+		return sanitations, nil
+	}
+
+	err := json.Unmarshal([]byte(value.String), &sanitations)
+	if err != nil {
+		return nil, err
+	}
+
+	if sanitations == nil {
+		sanitations = []storage.AreaSanitation{}
+	}
+
+	return sanitations, nil
+}
+
+// decodeAreaLastSanitizedDate is the date of the last sanitation, NULL for the areas never sanitized.
+func decodeAreaLastSanitizedDate(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+
+	return &value.Time
+}
@@ -0,0 +1,161 @@
+package mysql
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/query"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+type AreaTaskReadQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewAreaTaskReadQueryMysql(db *sql.DB) query.AreaTaskRead {
+	return AreaTaskReadQueryMysql{DB: db}
+}
+
+func (s AreaTaskReadQueryMysql) CountOverdueByArea(date time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		rows, err := s.DB.Query(`SELECT ASSET_ID, COUNT(*) FROM TASK_READ
+			WHERE DOMAIN_CODE = ? AND STATUS = ? AND ASSET_ID IS NOT NULL AND DUE_DATE < ?
+			GROUP BY ASSET_ID`,
+			tasksdomain.TaskDomainAreaCode, tasksdomain.TaskStatusCreated, date)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		counts := map[uuid.UUID]int{}
+
+		for rows.Next() {
+			rowsData := struct {
+				AssetID []byte
+				Count   int
+			}{}
+
+			err := rows.Scan(&rowsData.AssetID, &rowsData.Count)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			areaUID, err := uuid.FromBytes(rowsData.AssetID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			counts[areaUID] = rowsData.Count
+		}
+
+		result <- query.Result{Result: counts}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s AreaTaskReadQueryMysql) FindAllOpenByAssets(assetUIDs []uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		tasks := []query.AreaTaskResult{}
+
+		if len(assetUIDs) == 0 {
+			result <- query.Result{Result: tasks}
+			close(result)
+
+			return
+		}
+
+		args := []interface{}{tasksdomain.TaskStatusCreated}
+		for _, v := range assetUIDs {
+			args = append(args, v.Bytes())
+		}
+
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(assetUIDs)), ", ")
+
+		rows, err := s.DB.Query(`SELECT UID, SHORT_CODE, TITLE, PRIORITY, STATUS, DOMAIN_CODE, CATEGORY, DUE_DATE, ASSET_ID
+			FROM TASK_READ WHERE STATUS = ? AND ASSET_ID IN (`+placeholders+`)`, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			rowsData := struct {
+				UID       []byte
+				ShortCode sql.NullString
+				Title     string
+				Priority  string
+				Status    string
+				Domain    string
+				Category  string
+				DueDate   sql.NullTime
+				AssetID   []byte
+			}{}
+
+			err := rows.Scan(&rowsData.UID, &rowsData.ShortCode, &rowsData.Title, &rowsData.Priority,
+				&rowsData.Status, &rowsData.Domain, &rowsData.Category, &rowsData.DueDate, &rowsData.AssetID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			task := query.AreaTaskResult{
+				ShortCode: rowsData.ShortCode.String,
+				Title:     rowsData.Title,
+				Priority:  rowsData.Priority,
+				Status:    rowsData.Status,
+				Domain:    rowsData.Domain,
+				Category:  rowsData.Category,
+			}
+
+			task.UID, err = uuid.FromBytes(rowsData.UID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			task.AssetID, err = uuid.FromBytes(rowsData.AssetID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			if rowsData.DueDate.Valid {
+				dueDate := rowsData.DueDate.Time
+				task.DueDate = &dueDate
+			}
+
+			tasks = append(tasks, task)
+		}
+
+		result <- query.Result{Result: tasks}
+		close(result)
+	}()
+
+	return result
+}
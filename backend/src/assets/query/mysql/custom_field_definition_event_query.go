@@ -0,0 +1,89 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/decoder"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type CustomFieldDefinitionEventQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewCustomFieldDefinitionEventQueryMysql(db *sql.DB) query.CustomFieldDefinitionEvent {
+	return &CustomFieldDefinitionEventQueryMysql{DB: db}
+}
+
+func (f *CustomFieldDefinitionEventQueryMysql) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.CustomFieldDefinitionEvent{}
+
+		rows, err := f.DB.Query(`SELECT * FROM CUSTOM_FIELD_DEFINITION_EVENT
+			WHERE CUSTOM_FIELD_DEFINITION_UID = ? ORDER BY VERSION ASC`, uid.Bytes())
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		rowsData := struct {
+			ID                       int
+			CustomFieldDefinitionUID []byte
+			Version                  int
+			CreatedDate              time.Time
+			Event                    []byte
+		}{}
+
+		for rows.Next() {
+			err := rows.Scan(&rowsData.ID, &rowsData.CustomFieldDefinitionUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			wrapper := decoder.CustomFieldDefinitionEventWrapper{}
+
+			err = json.Unmarshal(rowsData.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			definitionUID, err := uuid.FromBytes(rowsData.CustomFieldDefinitionUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			createdDate := rowsData.CreatedDate
+
+			events = append(events, storage.CustomFieldDefinitionEvent{
+				CustomFieldDefinitionUID: definitionUID,
+				Version:                  rowsData.Version,
+				CreatedDate:              createdDate,
+				Event:                    wrapper.EventData,
+				Actor:                    wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,163 @@
+package mysql
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+const equipmentReadColumns = `UID, FARM_UID, NAME, TYPE, LOCATION_TYPE, LOCATION_UID, PURCHASE_DATE, NOTES,
+	MAINTENANCE_INTERVAL_DAYS, NEXT_MAINTENANCE_DATE, GEO_POINT, IS_DELETED, CREATED_DATE`
+
+type EquipmentReadQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewEquipmentReadQueryMysql(db *sql.DB) query.EquipmentRead {
+	return EquipmentReadQueryMysql{DB: db}
+}
+
+func (s EquipmentReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		res := <-s.findAll(`SELECT `+equipmentReadColumns+` FROM EQUIPMENT_READ WHERE UID = ?`, uid.Bytes())
+		if res.Error != nil {
+			result <- res
+			close(result)
+
+			return
+		}
+
+		equipment := storage.EquipmentRead{}
+		for _, v := range res.Result.([]storage.EquipmentRead) {
+			equipment = v
+		}
+
+		result <- query.Result{Result: equipment}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s EquipmentReadQueryMysql) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	return s.findAll(`SELECT `+equipmentReadColumns+`
+		FROM EQUIPMENT_READ WHERE FARM_UID = ? AND IS_DELETED = ? ORDER BY NAME ASC`, farmUID.Bytes(), false)
+}
+
+func (s EquipmentReadQueryMysql) FindAllMaintenanceDue(maintenanceDate time.Time) <-chan query.Result {
+	return s.findAll(`SELECT `+equipmentReadColumns+`
+		FROM EQUIPMENT_READ WHERE IS_DELETED = ? AND MAINTENANCE_INTERVAL_DAYS > 0 AND NEXT_MAINTENANCE_DATE <= ?
+		ORDER BY NAME ASC`, false, maintenanceDate)
+}
+
+func (s EquipmentReadQueryMysql) findAll(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		equipment := []storage.EquipmentRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			equipmentRead, err := populateEquipmentRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			equipment = append(equipment, equipmentRead)
+		}
+
+		result <- query.Result{Result: equipment}
+		close(result)
+	}()
+
+	return result
+}
+
+func populateEquipmentRead(rows *sql.Rows) (storage.EquipmentRead, error) {
+	rowsData := struct {
+		UID                     []byte
+		FarmUID                 []byte
+		Name                    string
+		Type                    string
+		LocationType            sql.NullString
+		LocationUID             []byte
+		PurchaseDate            sql.NullTime
+		Notes                   string
+		MaintenanceIntervalDays int
+		NextMaintenanceDate     sql.NullTime
+		GeoPoint                sql.NullString
+		IsDeleted               bool
+		CreatedDate             time.Time
+	}{}
+
+	err := rows.Scan(
+		&rowsData.UID, &rowsData.FarmUID, &rowsData.Name, &rowsData.Type, &rowsData.LocationType,
+		&rowsData.LocationUID, &rowsData.PurchaseDate, &rowsData.Notes, &rowsData.MaintenanceIntervalDays,
+		&rowsData.NextMaintenanceDate, &rowsData.GeoPoint, &rowsData.IsDeleted, &rowsData.CreatedDate,
+	)
+	if err != nil {
+		return storage.EquipmentRead{}, err
+	}
+
+	equipment := storage.EquipmentRead{
+		Name:                    rowsData.Name,
+		Type:                    rowsData.Type,
+		Notes:                   rowsData.Notes,
+		MaintenanceIntervalDays: rowsData.MaintenanceIntervalDays,
+		IsDeleted:               rowsData.IsDeleted,
+		CreatedDate:             rowsData.CreatedDate,
+	}
+
+	equipment.UID, err = uuid.FromBytes(rowsData.UID)
+	if err != nil {
+		return storage.EquipmentRead{}, err
+	}
+
+	equipment.FarmUID, err = uuid.FromBytes(rowsData.FarmUID)
+	if err != nil {
+		return storage.EquipmentRead{}, err
+	}
+
+	if rowsData.LocationType.Valid && len(rowsData.LocationUID) > 0 {
+		locationUID, err := uuid.FromBytes(rowsData.LocationUID)
+		if err != nil {
+			return storage.EquipmentRead{}, err
+		}
+
+		equipment.Location = &domain.EquipmentLocation{Type: rowsData.LocationType.String, UID: locationUID}
+	}
+
+	if rowsData.PurchaseDate.Valid {
+		purchaseDate := rowsData.PurchaseDate.Time
+		equipment.PurchaseDate = &purchaseDate
+	}
+
+	if rowsData.NextMaintenanceDate.Valid {
+		nextMaintenanceDate := rowsData.NextMaintenanceDate.Time
+		equipment.NextMaintenanceDate = &nextMaintenanceDate
+	}
+
+	equipment.GeoPoint, err = decodeGeoPoint(rowsData.GeoPoint)
+	if err != nil {
+		return storage.EquipmentRead{}, err
+	}
+
+	return equipment, nil
+}
@@ -0,0 +1,76 @@
+package mysql
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/query"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+type EquipmentTaskReadQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewEquipmentTaskReadQueryMysql(db *sql.DB) query.EquipmentTaskRead {
+	return EquipmentTaskReadQueryMysql{DB: db}
+}
+
+func (s EquipmentTaskReadQueryMysql) FindNextByEquipment(equipmentUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		rowsData := struct {
+			UID       []byte
+			ShortCode sql.NullString
+			Title     string
+			Status    string
+			DueDate   sql.NullTime
+		}{}
+
+		// The tasks without a due date are the last ones.
+		err := s.DB.QueryRow(`SELECT UID, SHORT_CODE, TITLE, STATUS, DUE_DATE
+			FROM TASK_READ WHERE DOMAIN_CODE = ? AND STATUS = ? AND ASSET_ID = ?
+			ORDER BY DUE_DATE IS NULL, DUE_DATE ASC LIMIT 1`,
+			tasksdomain.TaskDomainEquipmentCode, tasksdomain.TaskStatusCreated, equipmentUID.Bytes(),
+		).Scan(&rowsData.UID, &rowsData.ShortCode, &rowsData.Title, &rowsData.Status, &rowsData.DueDate)
+		if errors.Is(err, sql.ErrNoRows) {
+			result <- query.Result{Result: query.EquipmentTaskResult{}}
+			close(result)
+
+			return
+		}
+
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+
+		task := query.EquipmentTaskResult{
+			ShortCode: rowsData.ShortCode.String,
+			Title:     rowsData.Title,
+			Status:    rowsData.Status,
+		}
+
+		task.UID, err = uuid.FromBytes(rowsData.UID)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+
+		if rowsData.DueDate.Valid {
+			dueDate := rowsData.DueDate.Time
+			task.DueDate = &dueDate
+		}
+
+		result <- query.Result{Result: task}
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,91 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/decoder"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type FarmBoundaryEventQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewFarmBoundaryEventQueryMysql(db *sql.DB) query.FarmBoundaryEvent {
+	return &FarmBoundaryEventQueryMysql{DB: db}
+}
+
+func (f *FarmBoundaryEventQueryMysql) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.FarmBoundaryEvent{}
+
+		rows, err := f.DB.Query(`SELECT * FROM FARM_BOUNDARY_EVENT
+			WHERE FARM_UID = ? ORDER BY VERSION ASC`, uid.Bytes())
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		rowsData := struct {
+			ID          int
+			FarmUID     []byte
+			Version     int
+			CreatedDate time.Time
+			Event       []byte
+		}{}
+
+		for rows.Next() {
+			err := rows.Scan(
+				&rowsData.ID, &rowsData.FarmUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event,
+			)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			wrapper := decoder.FarmBoundaryEventWrapper{}
+
+			err = json.Unmarshal(rowsData.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			farmUID, err := uuid.FromBytes(rowsData.FarmUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			createdDate := rowsData.CreatedDate
+
+			events = append(events, storage.FarmBoundaryEvent{
+				FarmUID:     farmUID,
+				Version:     rowsData.Version,
+				CreatedDate: createdDate,
+				Event:       wrapper.EventData,
+				Actor:       wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,145 @@
+package mysql
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+const farmCertificationReadColumns = `UID, FARM_UID, CERTIFICATION_TYPE, CERTIFYING_BODY, ISSUED_DATE, EXPIRY_DATE,
+	CERTIFICATE_NUMBER, STATUS, IS_RENEWAL_DUE, REVOKED_DATE, CREATED_DATE`
+
+type FarmCertificationReadQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewFarmCertificationReadQueryMysql(db *sql.DB) query.FarmCertificationRead {
+	return FarmCertificationReadQueryMysql{DB: db}
+}
+
+func (s FarmCertificationReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		res := <-s.findAll(`SELECT `+farmCertificationReadColumns+`
+			FROM FARM_CERTIFICATION_READ WHERE UID = ?`, uid.Bytes())
+		if res.Error != nil {
+			result <- res
+			close(result)
+
+			return
+		}
+
+		certification := storage.FarmCertificationRead{}
+		for _, v := range res.Result.([]storage.FarmCertificationRead) {
+			certification = v
+		}
+
+		result <- query.Result{Result: certification}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s FarmCertificationReadQueryMysql) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	return s.findAll(`SELECT `+farmCertificationReadColumns+`
+		FROM FARM_CERTIFICATION_READ WHERE FARM_UID = ? ORDER BY EXPIRY_DATE ASC`, farmUID.Bytes())
+}
+
+func (s FarmCertificationReadQueryMysql) FindAllRenewalDue(expiryDate time.Time) <-chan query.Result {
+	return s.findAll(`SELECT `+farmCertificationReadColumns+`
+		FROM FARM_CERTIFICATION_READ WHERE STATUS = ? AND IS_RENEWAL_DUE = ? AND EXPIRY_DATE <= ?
+		ORDER BY EXPIRY_DATE ASC`, domain.CertificationStatusActive, false, expiryDate)
+}
+
+func (s FarmCertificationReadQueryMysql) findAll(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		certifications := []storage.FarmCertificationRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			certification, err := populateFarmCertificationRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			certifications = append(certifications, certification)
+		}
+
+		result <- query.Result{Result: certifications}
+		close(result)
+	}()
+
+	return result
+}
+
+func populateFarmCertificationRead(rows *sql.Rows) (storage.FarmCertificationRead, error) {
+	rowsData := struct {
+		UID               []byte
+		FarmUID           []byte
+		CertificationType string
+		CertifyingBody    string
+		IssuedDate        time.Time
+		ExpiryDate        time.Time
+		CertificateNumber string
+		Status            string
+		IsRenewalDue      bool
+		RevokedDate       sql.NullTime
+		CreatedDate       time.Time
+	}{}
+
+	err := rows.Scan(
+		&rowsData.UID, &rowsData.FarmUID, &rowsData.CertificationType, &rowsData.CertifyingBody,
+		&rowsData.IssuedDate, &rowsData.ExpiryDate, &rowsData.CertificateNumber, &rowsData.Status,
+		&rowsData.IsRenewalDue, &rowsData.RevokedDate, &rowsData.CreatedDate,
+	)
+	if err != nil {
+		return storage.FarmCertificationRead{}, err
+	}
+
+	certification := storage.FarmCertificationRead{
+		CertificationType: rowsData.CertificationType,
+		CertifyingBody:    rowsData.CertifyingBody,
+		IssuedDate:        rowsData.IssuedDate,
+		ExpiryDate:        rowsData.ExpiryDate,
+		CertificateNumber: rowsData.CertificateNumber,
+		Status:            rowsData.Status,
+		IsRenewalDue:      rowsData.IsRenewalDue,
+		CreatedDate:       rowsData.CreatedDate,
+	}
+
+	certification.UID, err = uuid.FromBytes(rowsData.UID)
+	if err != nil {
+		return storage.FarmCertificationRead{}, err
+	}
+
+	certification.FarmUID, err = uuid.FromBytes(rowsData.FarmUID)
+	if err != nil {
+		return storage.FarmCertificationRead{}, err
+	}
+
+	if rowsData.RevokedDate.Valid {
+		revokedDate := rowsData.RevokedDate.Time
+		certification.RevokedDate = &revokedDate
+	}
+
+	return certification, nil
+}
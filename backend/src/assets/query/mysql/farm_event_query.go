@@ -61,6 +61,7 @@ func (f *FarmEventQueryMysql) FindAllByID(uid uuid.UUID) <-chan query.Result {
 				Version:     rowsData.Version,
 				CreatedDate: rowsData.CreatedDate,
 				Event:       wrapper.EventData,
+				Actor:       wrapper.Actor,
 			})
 		}
 
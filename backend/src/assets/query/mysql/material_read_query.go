@@ -34,6 +34,9 @@ type materialReadResult struct {
 	Notes          sql.NullString
 	ProducedBy     sql.NullString
 	CreatedDate    time.Time
+	GDDToMaturity  sql.NullFloat64
+
+	PreHarvestIntervalDays sql.NullInt64
 }
 
 func (q MaterialReadQueryMysql) FindAll(materialType, materialTypeDetail string, page, limit int) <-chan query.Result {
@@ -102,6 +105,8 @@ func (q MaterialReadQueryMysql) FindAll(materialType, materialTypeDetail string,
 				&rowsData.Notes,
 				&rowsData.ProducedBy,
 				&rowsData.CreatedDate,
+				&rowsData.GDDToMaturity,
+				&rowsData.PreHarvestIntervalDays,
 			)
 
 			if err != nil {
@@ -179,6 +184,17 @@ func (q MaterialReadQueryMysql) FindAll(materialType, materialTypeDetail string,
 				producedBy = &rowsData.ProducedBy.String
 			}
 
+			var gddToMaturity *float64
+			if rowsData.GDDToMaturity.Valid {
+				gddToMaturity = &rowsData.GDDToMaturity.Float64
+			}
+
+			var preHarvestIntervalDays *int
+			if rowsData.PreHarvestIntervalDays.Valid {
+				days := int(rowsData.PreHarvestIntervalDays.Int64)
+				preHarvestIntervalDays = &days
+			}
+
 			materialReads = append(materialReads, storage.MaterialRead{
 				UID:          materialUID,
 				Name:         rowsData.Name,
@@ -191,7 +207,10 @@ func (q MaterialReadQueryMysql) FindAll(materialType, materialTypeDetail string,
 				ExpirationDate: mExpDate,
 				Notes:          notes,
 				ProducedBy:     producedBy,
+				GDDToMaturity:  gddToMaturity,
 				CreatedDate:    rowsData.CreatedDate,
+
+				PreHarvestIntervalDays: preHarvestIntervalDays,
 			})
 		}
 
@@ -272,6 +291,8 @@ func (q MaterialReadQueryMysql) FindByID(materialUID uuid.UUID) <-chan query.Res
 			&rowsData.Notes,
 			&rowsData.ProducedBy,
 			&rowsData.CreatedDate,
+			&rowsData.GDDToMaturity,
+			&rowsData.PreHarvestIntervalDays,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -353,6 +374,17 @@ func (q MaterialReadQueryMysql) FindByID(materialUID uuid.UUID) <-chan query.Res
 			producedBy = &rowsData.ProducedBy.String
 		}
 
+		var gddToMaturity *float64
+		if rowsData.GDDToMaturity.Valid {
+			gddToMaturity = &rowsData.GDDToMaturity.Float64
+		}
+
+		var preHarvestIntervalDays *int
+		if rowsData.PreHarvestIntervalDays.Valid {
+			days := int(rowsData.PreHarvestIntervalDays.Int64)
+			preHarvestIntervalDays = &days
+		}
+
 		materialRead = storage.MaterialRead{
 			UID:          materialUID,
 			Name:         rowsData.Name,
@@ -365,7 +397,10 @@ func (q MaterialReadQueryMysql) FindByID(materialUID uuid.UUID) <-chan query.Res
 			ExpirationDate: mExpDate,
 			Notes:          notes,
 			ProducedBy:     producedBy,
+			GDDToMaturity:  gddToMaturity,
 			CreatedDate:    rowsData.CreatedDate,
+
+			PreHarvestIntervalDays: preHarvestIntervalDays,
 		}
 
 		result <- query.Result{Result: materialRead}
@@ -0,0 +1,91 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/decoder"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type NutrientRecipeEventQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewNutrientRecipeEventQueryMysql(db *sql.DB) query.NutrientRecipeEvent {
+	return &NutrientRecipeEventQueryMysql{DB: db}
+}
+
+func (f *NutrientRecipeEventQueryMysql) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.NutrientRecipeEvent{}
+
+		rows, err := f.DB.Query(`SELECT * FROM NUTRIENT_RECIPE_EVENT
+			WHERE NUTRIENT_RECIPE_UID = ? ORDER BY VERSION ASC`, uid.Bytes())
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		rowsData := struct {
+			ID                int
+			NutrientRecipeUID []byte
+			Version           int
+			CreatedDate       time.Time
+			Event             []byte
+		}{}
+
+		for rows.Next() {
+			err := rows.Scan(
+				&rowsData.ID, &rowsData.NutrientRecipeUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event,
+			)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			wrapper := decoder.NutrientRecipeEventWrapper{}
+
+			err = json.Unmarshal(rowsData.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			recipeUID, err := uuid.FromBytes(rowsData.NutrientRecipeUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			createdDate := rowsData.CreatedDate
+
+			events = append(events, storage.NutrientRecipeEvent{
+				NutrientRecipeUID: recipeUID,
+				Version:           rowsData.Version,
+				CreatedDate:       createdDate,
+				Event:             wrapper.EventData,
+				Actor:             wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
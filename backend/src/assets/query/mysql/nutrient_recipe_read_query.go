@@ -0,0 +1,123 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+const nutrientRecipeReadColumns = `UID, FARM_UID, NAME, TARGET_EC, TARGET_PH, INGREDIENTS, IS_DELETED, CREATED_DATE`
+
+type NutrientRecipeReadQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewNutrientRecipeReadQueryMysql(db *sql.DB) query.NutrientRecipeRead {
+	return NutrientRecipeReadQueryMysql{DB: db}
+}
+
+func (s NutrientRecipeReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		res := <-s.findAll(`SELECT `+nutrientRecipeReadColumns+` FROM NUTRIENT_RECIPE_READ WHERE UID = ?`, uid.Bytes())
+		if res.Error != nil {
+			result <- res
+			close(result)
+
+			return
+		}
+
+		recipe := storage.NutrientRecipeRead{}
+		for _, v := range res.Result.([]storage.NutrientRecipeRead) {
+			recipe = v
+		}
+
+		result <- query.Result{Result: recipe}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s NutrientRecipeReadQueryMysql) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	return s.findAll(`SELECT `+nutrientRecipeReadColumns+`
+		FROM NUTRIENT_RECIPE_READ WHERE FARM_UID = ? AND IS_DELETED = ?
+		ORDER BY CREATED_DATE ASC`, farmUID.Bytes(), false)
+}
+
+func (s NutrientRecipeReadQueryMysql) findAll(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		recipes := []storage.NutrientRecipeRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			rowsData := struct {
+				UID         []byte
+				FarmUID     []byte
+				Name        string
+				TargetEC    float32
+				TargetPH    float32
+				Ingredients string
+				IsDeleted   bool
+				CreatedDate time.Time
+			}{}
+
+			err := rows.Scan(
+				&rowsData.UID, &rowsData.FarmUID, &rowsData.Name, &rowsData.TargetEC, &rowsData.TargetPH,
+				&rowsData.Ingredients, &rowsData.IsDeleted, &rowsData.CreatedDate,
+			)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			recipe := storage.NutrientRecipeRead{
+				Name:        rowsData.Name,
+				TargetEC:    rowsData.TargetEC,
+				TargetPH:    rowsData.TargetPH,
+				IsDeleted:   rowsData.IsDeleted,
+				CreatedDate: rowsData.CreatedDate,
+			}
+
+			err = json.Unmarshal([]byte(rowsData.Ingredients), &recipe.Ingredients)
+			if err == nil {
+				recipe.UID, err = uuid.FromBytes(rowsData.UID)
+			}
+
+			if err == nil {
+				recipe.FarmUID, err = uuid.FromBytes(rowsData.FarmUID)
+			}
+
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			recipes = append(recipes, recipe)
+		}
+
+		result <- query.Result{Result: recipes}
+		close(result)
+	}()
+
+	return result
+}
@@ -61,6 +61,7 @@ func (f *ReservoirEventQueryMysql) FindAllByID(uid uuid.UUID) <-chan query.Resul
 				Version:      rowsData.Version,
 				CreatedDate:  rowsData.CreatedDate,
 				Event:        wrapper.EventData,
+				Actor:        wrapper.Actor,
 			})
 		}
 
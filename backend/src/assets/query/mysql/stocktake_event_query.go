@@ -0,0 +1,89 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/decoder"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type StocktakeEventQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewStocktakeEventQueryMysql(db *sql.DB) query.StocktakeEvent {
+	return &StocktakeEventQueryMysql{DB: db}
+}
+
+func (f *StocktakeEventQueryMysql) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.StocktakeEvent{}
+
+		rows, err := f.DB.Query(`SELECT * FROM STOCKTAKE_EVENT
+			WHERE STOCKTAKE_UID = ? ORDER BY VERSION ASC`, uid.Bytes())
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		rowsData := struct {
+			ID           int
+			StocktakeUID []byte
+			Version      int
+			CreatedDate  time.Time
+			Event        []byte
+		}{}
+
+		for rows.Next() {
+			err := rows.Scan(&rowsData.ID, &rowsData.StocktakeUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			wrapper := decoder.StocktakeEventWrapper{}
+
+			err = json.Unmarshal(rowsData.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			stocktakeUID, err := uuid.FromBytes(rowsData.StocktakeUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			createdDate := rowsData.CreatedDate
+
+			events = append(events, storage.StocktakeEvent{
+				StocktakeUID: stocktakeUID,
+				Version:      rowsData.Version,
+				CreatedDate:  createdDate,
+				Event:        wrapper.EventData,
+				Actor:        wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
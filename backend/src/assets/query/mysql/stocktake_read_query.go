@@ -0,0 +1,125 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+const stocktakeReadColumns = `UID, FARM_UID, STATUS, LINES, OPENED_DATE, CLOSED_DATE`
+
+type StocktakeReadQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewStocktakeReadQueryMysql(db *sql.DB) query.StocktakeRead {
+	return StocktakeReadQueryMysql{DB: db}
+}
+
+func (s StocktakeReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		res := <-s.findAll(`SELECT `+stocktakeReadColumns+` FROM STOCKTAKE_READ WHERE UID = ?`, uid.Bytes())
+		if res.Error != nil {
+			result <- res
+			close(result)
+
+			return
+		}
+
+		stocktake := storage.StocktakeRead{}
+		for _, v := range res.Result.([]storage.StocktakeRead) {
+			stocktake = v
+		}
+
+		result <- query.Result{Result: stocktake}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s StocktakeReadQueryMysql) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	return s.findAll(`SELECT `+stocktakeReadColumns+` FROM STOCKTAKE_READ
+		WHERE FARM_UID = ? ORDER BY OPENED_DATE DESC`, farmUID.Bytes())
+}
+
+func (s StocktakeReadQueryMysql) FindAllOpen() <-chan query.Result {
+	return s.findAll(`SELECT `+stocktakeReadColumns+` FROM STOCKTAKE_READ
+		WHERE STATUS = ? ORDER BY OPENED_DATE DESC`, domain.StocktakeStatusOpen)
+}
+
+func (s StocktakeReadQueryMysql) findAll(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		stocktakes := []storage.StocktakeRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			rowsData := struct {
+				UID        []byte
+				FarmUID    []byte
+				Status     string
+				Lines      []byte
+				OpenedDate time.Time
+				ClosedDate sql.NullTime
+			}{}
+
+			err := rows.Scan(
+				&rowsData.UID, &rowsData.FarmUID, &rowsData.Status, &rowsData.Lines,
+				&rowsData.OpenedDate, &rowsData.ClosedDate,
+			)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			stocktake := storage.StocktakeRead{Status: rowsData.Status, OpenedDate: rowsData.OpenedDate}
+
+			if rowsData.ClosedDate.Valid {
+				closedDate := rowsData.ClosedDate.Time
+				stocktake.ClosedDate = &closedDate
+			}
+
+			stocktake.UID, err = uuid.FromBytes(rowsData.UID)
+			if err == nil {
+				stocktake.FarmUID, err = uuid.FromBytes(rowsData.FarmUID)
+			}
+
+			if err == nil {
+				err = json.Unmarshal(rowsData.Lines, &stocktake.Lines)
+			}
+
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			stocktakes = append(stocktakes, stocktake)
+		}
+
+		result <- query.Result{Result: stocktakes}
+		close(result)
+	}()
+
+	return result
+}
@@ -39,6 +39,8 @@ type AreaRead interface {
 type CropRead interface {
 	FindAllCropByArea(areaUID uuid.UUID) <-chan Result
 	CountCropsByArea(areaUID uuid.UUID) <-chan Result
+	// FindAllCurrentByArea finds the active crops which still have plants in the area, without their histories.
+	FindAllCurrentByArea(areaUID uuid.UUID) <-chan Result
 }
 
 type MaterialEvent interface {
@@ -51,6 +53,79 @@ type MaterialRead interface {
 	FindByID(materialUID uuid.UUID) <-chan Result
 }
 
+type FarmCertificationEvent interface {
+	FindAllByID(certificationUID uuid.UUID) <-chan Result
+}
+
+type FarmCertificationRead interface {
+	FindByID(certificationUID uuid.UUID) <-chan Result
+	FindAllByFarm(farmUID uuid.UUID) <-chan Result
+	// FindAllRenewalDue finds the active certifications expiring before the date whose renewal wasn't requested.
+	FindAllRenewalDue(expiryDate time.Time) <-chan Result
+}
+
+type CustomFieldDefinitionEvent interface {
+	FindAllByID(definitionUID uuid.UUID) <-chan Result
+}
+
+type CustomFieldDefinitionRead interface {
+	FindByID(definitionUID uuid.UUID) <-chan Result
+	// FindAllByFarm finds the fields of the farm which aren't deleted. An empty entity type finds them all.
+	FindAllByFarm(farmUID uuid.UUID, entityType string) <-chan Result
+}
+
+type StocktakeEvent interface {
+	FindAllByID(stocktakeUID uuid.UUID) <-chan Result
+}
+
+type StocktakeRead interface {
+	FindByID(stocktakeUID uuid.UUID) <-chan Result
+	FindAllByFarm(farmUID uuid.UUID) <-chan Result
+	// FindAllOpen finds the open stocktakes of every farm, as the materials aren't owned by a farm.
+	FindAllOpen() <-chan Result
+}
+
+type EquipmentEvent interface {
+	FindAllByID(equipmentUID uuid.UUID) <-chan Result
+}
+
+type EquipmentRead interface {
+	FindByID(equipmentUID uuid.UUID) <-chan Result
+	// FindAllByFarm finds the equipment of the farm which isn't deleted.
+	FindAllByFarm(farmUID uuid.UUID) <-chan Result
+	// FindAllMaintenanceDue finds the equipment whose next maintenance is before the date.
+	FindAllMaintenanceDue(maintenanceDate time.Time) <-chan Result
+}
+
+type NutrientRecipeEvent interface {
+	FindAllByID(recipeUID uuid.UUID) <-chan Result
+}
+
+// FarmBoundaryEvent finds the events of the boundary of a farm, the boundary is identified by its farm.
+type FarmBoundaryEvent interface {
+	FindAllByID(farmUID uuid.UUID) <-chan Result
+}
+
+type NutrientRecipeRead interface {
+	FindByID(recipeUID uuid.UUID) <-chan Result
+	// FindAllByFarm finds the recipes of the farm which aren't deleted.
+	FindAllByFarm(farmUID uuid.UUID) <-chan Result
+}
+
+// EquipmentTaskRead reads the maintenance tasks of the equipment from the read model of the tasks.
+type EquipmentTaskRead interface {
+	// FindNextByEquipment finds the open task of the equipment due first, an empty result when there is none.
+	FindNextByEquipment(equipmentUID uuid.UUID) <-chan Result
+}
+
+// AreaTaskRead reads the open tasks of the areas from the read model of the tasks.
+type AreaTaskRead interface {
+	// CountOverdueByArea counts the open tasks of each area due before the date, in a map by area UID.
+	CountOverdueByArea(date time.Time) <-chan Result
+	// FindAllOpenByAssets finds the open tasks whose asset is one of the assets, of any domain.
+	FindAllOpenByAssets(assetUIDs []uuid.UUID) <-chan Result
+}
+
 type Result struct {
 	Result interface{}
 	Error  error
@@ -122,3 +197,42 @@ type ContainerType struct {
 	Code string `json:"code"`
 	Cell int    `json:"cell"`
 }
+
+type EquipmentTaskResult struct {
+	UID       uuid.UUID  `json:"uid"`
+	ShortCode string     `json:"short_code"`
+	Title     string     `json:"title"`
+	Status    string     `json:"status"`
+	DueDate   *time.Time `json:"due_date"`
+}
+
+// IsDueBefore tells whether the task is due before the other, the tasks without a due date are the last ones.
+func (r EquipmentTaskResult) IsDueBefore(other EquipmentTaskResult) bool {
+	if r.DueDate == nil {
+		return false
+	}
+
+	return other.DueDate == nil || r.DueDate.Before(*other.DueDate)
+}
+
+// AreaCurrentCropResult is a crop with plants in an area, with the quantity the area holds.
+type AreaCurrentCropResult struct {
+	CropUID     uuid.UUID `json:"uid"`
+	BatchID     string    `json:"batch_id"`
+	ShortCode   string    `json:"short_code"`
+	VarietyName string    `json:"variety_name"`
+	PlantType   string    `json:"plant_type"`
+	Quantity    int       `json:"quantity"`
+}
+
+type AreaTaskResult struct {
+	UID       uuid.UUID  `json:"uid"`
+	ShortCode string     `json:"short_code"`
+	Title     string     `json:"title"`
+	Priority  string     `json:"priority"`
+	Status    string     `json:"status"`
+	Domain    string     `json:"domain"`
+	Category  string     `json:"category"`
+	DueDate   *time.Time `json:"due_date"`
+	AssetID   uuid.UUID  `json:"asset_id"`
+}
@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"encoding/json"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/decoder"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+	redisstorage "github.com/usetania/tania-core/src/storage/redis"
+)
+
+type AreaEventQueryRedis struct {
+	Storage *redisstorage.RedisStreamsEventStorage
+}
+
+func NewAreaEventQueryRedis(s *redisstorage.RedisStreamsEventStorage) query.AreaEvent {
+	return &AreaEventQueryRedis{Storage: s}
+}
+
+func (f *AreaEventQueryRedis) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		defer close(result)
+
+		streamEvents, err := f.Storage.Range(redisstorage.AggregateArea, uid)
+		if err != nil {
+			result <- query.Result{Error: err}
+
+			return
+		}
+
+		events := []storage.AreaEvent{}
+
+		for _, v := range streamEvents {
+			wrapper := decoder.AreaEventWrapper{}
+
+			err := json.Unmarshal(v.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+
+				return
+			}
+
+			events = append(events, storage.AreaEvent{
+				AreaUID:     uid,
+				Version:     v.Version,
+				CreatedDate: v.CreatedDate,
+				Event:       wrapper.EventData,
+				Actor:       wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+	}()
+
+	return result
+}
@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"encoding/json"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/decoder"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+	redisstorage "github.com/usetania/tania-core/src/storage/redis"
+)
+
+type MaterialEventQueryRedis struct {
+	Storage *redisstorage.RedisStreamsEventStorage
+}
+
+func NewMaterialEventQueryRedis(s *redisstorage.RedisStreamsEventStorage) query.MaterialEvent {
+	return &MaterialEventQueryRedis{Storage: s}
+}
+
+func (f *MaterialEventQueryRedis) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		defer close(result)
+
+		streamEvents, err := f.Storage.Range(redisstorage.AggregateMaterial, uid)
+		if err != nil {
+			result <- query.Result{Error: err}
+
+			return
+		}
+
+		events := []storage.MaterialEvent{}
+
+		for _, v := range streamEvents {
+			wrapper := decoder.MaterialEventWrapper{}
+
+			err := json.Unmarshal(v.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+
+				return
+			}
+
+			events = append(events, storage.MaterialEvent{
+				MaterialUID: uid,
+				Version:     v.Version,
+				CreatedDate: v.CreatedDate,
+				Event:       wrapper.EventData,
+				Actor:       wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+	}()
+
+	return result
+}
@@ -66,6 +66,7 @@ func (f *AreaEventQuerySqlite) FindAllByID(uid uuid.UUID) <-chan query.Result {
 				Version:     rowsData.Version,
 				CreatedDate: createdDate,
 				Event:       wrapper.EventData,
+				Actor:       wrapper.Actor,
 			})
 		}
 
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -36,6 +37,13 @@ type areaReadResult struct {
 	ReservoirName string
 	FarmUID       string
 	FarmName      string
+	CustomFields  sql.NullString
+	Layout        sql.NullString
+	BedMap        sql.NullString
+	GeoPoint      sql.NullString
+
+	Sanitations       sql.NullString
+	LastSanitizedDate sql.NullString
 }
 
 type areaNotesReadResult struct {
@@ -70,6 +78,12 @@ func (s AreaReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
 			&rowsData.ReservoirName,
 			&rowsData.FarmUID,
 			&rowsData.FarmName,
+			&rowsData.CustomFields,
+			&rowsData.Layout,
+			&rowsData.BedMap,
+			&rowsData.GeoPoint,
+			&rowsData.Sanitations,
+			&rowsData.LastSanitizedDate,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -100,6 +114,36 @@ func (s AreaReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
 			result <- query.Result{Error: err}
 		}
 
+		customFields, err := decodeAreaCustomFields(rowsData.CustomFields)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		layout, err := decodeAreaLayout(rowsData.Layout)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		bedMap, err := decodeAreaBedMap(rowsData.BedMap)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		geoPoint, err := decodeGeoPoint(rowsData.GeoPoint)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		sanitations, err := decodeAreaSanitations(rowsData.Sanitations)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		lastSanitizedDate, err := decodeAreaLastSanitizedDate(rowsData.LastSanitizedDate)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
 		rows, err := s.DB.Query("SELECT * FROM AREA_READ_NOTES WHERE AREA_UID = ?", uid)
 		if err != nil {
 			result <- query.Result{Error: err}
@@ -171,6 +215,12 @@ func (s AreaReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
 				UID:  reservoirUID,
 				Name: rowsData.ReservoirName,
 			},
+			CustomFields:      customFields,
+			Layout:            layout,
+			BedMap:            bedMap,
+			GeoPoint:          geoPoint,
+			Sanitations:       sanitations,
+			LastSanitizedDate: lastSanitizedDate,
 		}
 
 		result <- query.Result{Result: areaRead}
@@ -210,6 +260,12 @@ func (s AreaReadQuerySqlite) FindAllByFarm(farmUID uuid.UUID) <-chan query.Resul
 				&rowsData.ReservoirName,
 				&rowsData.FarmUID,
 				&rowsData.FarmName,
+				&rowsData.CustomFields,
+				&rowsData.Layout,
+				&rowsData.BedMap,
+				&rowsData.GeoPoint,
+				&rowsData.Sanitations,
+				&rowsData.LastSanitizedDate,
 			); err != nil {
 				result <- query.Result{Error: err}
 			}
@@ -234,6 +290,36 @@ func (s AreaReadQuerySqlite) FindAllByFarm(farmUID uuid.UUID) <-chan query.Resul
 				result <- query.Result{Error: err}
 			}
 
+			customFields, err := decodeAreaCustomFields(rowsData.CustomFields)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			layout, err := decodeAreaLayout(rowsData.Layout)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			bedMap, err := decodeAreaBedMap(rowsData.BedMap)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			geoPoint, err := decodeGeoPoint(rowsData.GeoPoint)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			sanitations, err := decodeAreaSanitations(rowsData.Sanitations)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			lastSanitizedDate, err := decodeAreaLastSanitizedDate(rowsData.LastSanitizedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
 			rows, err := s.DB.Query("SELECT * FROM AREA_READ_NOTES WHERE AREA_UID = ?", areaUID)
 			if err != nil {
 				result <- query.Result{Error: err}
@@ -305,6 +391,12 @@ func (s AreaReadQuerySqlite) FindAllByFarm(farmUID uuid.UUID) <-chan query.Resul
 					UID:  reservoirUID,
 					Name: rowsData.ReservoirName,
 				},
+				CustomFields:      customFields,
+				Layout:            layout,
+				BedMap:            bedMap,
+				GeoPoint:          geoPoint,
+				Sanitations:       sanitations,
+				LastSanitizedDate: lastSanitizedDate,
 			})
 		}
 
@@ -340,6 +432,12 @@ func (s AreaReadQuerySqlite) FindByIDAndFarm(areaUID, farmUID uuid.UUID) <-chan
 			&rowsData.ReservoirName,
 			&rowsData.FarmUID,
 			&rowsData.FarmName,
+			&rowsData.CustomFields,
+			&rowsData.Layout,
+			&rowsData.BedMap,
+			&rowsData.GeoPoint,
+			&rowsData.Sanitations,
+			&rowsData.LastSanitizedDate,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -370,6 +468,36 @@ func (s AreaReadQuerySqlite) FindByIDAndFarm(areaUID, farmUID uuid.UUID) <-chan
 			result <- query.Result{Error: err}
 		}
 
+		customFields, err := decodeAreaCustomFields(rowsData.CustomFields)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		layout, err := decodeAreaLayout(rowsData.Layout)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		bedMap, err := decodeAreaBedMap(rowsData.BedMap)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		geoPoint, err := decodeGeoPoint(rowsData.GeoPoint)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		sanitations, err := decodeAreaSanitations(rowsData.Sanitations)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		lastSanitizedDate, err := decodeAreaLastSanitizedDate(rowsData.LastSanitizedDate)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
 		rows, err := s.DB.Query("SELECT * FROM AREA_READ_NOTES WHERE AREA_UID = ?", areaUID)
 		if err != nil {
 			result <- query.Result{Error: err}
@@ -441,6 +569,12 @@ func (s AreaReadQuerySqlite) FindByIDAndFarm(areaUID, farmUID uuid.UUID) <-chan
 				UID:  reservoirUID,
 				Name: rowsData.ReservoirName,
 			},
+			CustomFields:      customFields,
+			Layout:            layout,
+			BedMap:            bedMap,
+			GeoPoint:          geoPoint,
+			Sanitations:       sanitations,
+			LastSanitizedDate: lastSanitizedDate,
 		}
 
 		result <- query.Result{Result: areaRead}
@@ -480,6 +614,12 @@ func (s AreaReadQuerySqlite) FindAreasByReservoirID(reservoirUID uuid.UUID) <-ch
 				&rowsData.ReservoirName,
 				&rowsData.FarmUID,
 				&rowsData.FarmName,
+				&rowsData.CustomFields,
+				&rowsData.Layout,
+				&rowsData.BedMap,
+				&rowsData.GeoPoint,
+				&rowsData.Sanitations,
+				&rowsData.LastSanitizedDate,
 			); err != nil {
 				result <- query.Result{Error: err}
 			}
@@ -504,6 +644,36 @@ func (s AreaReadQuerySqlite) FindAreasByReservoirID(reservoirUID uuid.UUID) <-ch
 				result <- query.Result{Error: err}
 			}
 
+			customFields, err := decodeAreaCustomFields(rowsData.CustomFields)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			layout, err := decodeAreaLayout(rowsData.Layout)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			bedMap, err := decodeAreaBedMap(rowsData.BedMap)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			geoPoint, err := decodeGeoPoint(rowsData.GeoPoint)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			sanitations, err := decodeAreaSanitations(rowsData.Sanitations)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			lastSanitizedDate, err := decodeAreaLastSanitizedDate(rowsData.LastSanitizedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
 			rows, err := s.DB.Query("SELECT * FROM AREA_READ_NOTES WHERE AREA_UID = ?", areaUID)
 			if err != nil {
 				result <- query.Result{Error: err}
@@ -575,6 +745,12 @@ func (s AreaReadQuerySqlite) FindAreasByReservoirID(reservoirUID uuid.UUID) <-ch
 					UID:  reservoirUID,
 					Name: rowsData.ReservoirName,
 				},
+				CustomFields:      customFields,
+				Layout:            layout,
+				BedMap:            bedMap,
+				GeoPoint:          geoPoint,
+				Sanitations:       sanitations,
+				LastSanitizedDate: lastSanitizedDate,
 			})
 		}
 
@@ -603,3 +779,105 @@ func (s AreaReadQuerySqlite) CountAreas(farmUID uuid.UUID) <-chan query.Result {
 
 	return result
 }
+
+// decodeAreaCustomFields decodes the JSON of the custom fields, which is NULL for the areas saved before they existed.
+func decodeAreaCustomFields(value sql.NullString) (map[string]interface{}, error) {
+	customFields := map[string]interface{}{}
+
+	if !value.Valid || value.String == "" {
+		return customFields, nil
+	}
+
+	err := json.Unmarshal([]byte(value.String), &customFields)
+	if err != nil {
+		return nil, err
+	}
+
+	if customFields == nil {
+		customFields = map[string]interface{}{}
+	}
+
+	return customFields, nil
+}
+
+// decodeAreaLayout decodes the JSON of the layout, which is NULL for the areas saved before it existed.
+func decodeAreaLayout(value sql.NullString) (*storage.AreaLayout, error) {
+	if !value.Valid || value.String == "" {
+		return nil, nil
+	}
+
+	var layout *storage.AreaLayout
+
+	err := json.Unmarshal([]byte(value.String), &layout)
+	if err != nil {
+		return nil, err
+	}
+
+	return layout, nil
+}
+
+// decodeAreaBedMap decodes the JSON of the bed map, which is NULL for the areas saved before it existed.
+func decodeAreaBedMap(value sql.NullString) (*storage.BedMap, error) {
+	if !value.Valid || value.String == "" {
+		return nil, nil
+	}
+
+	var bedMap *storage.BedMap
+
+	err := json.Unmarshal([]byte(value.String), &bedMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return bedMap, nil
+}
+
+// decodeGeoPoint decodes the JSON of a GPS coordinate, which is NULL for the assets without one.
+func decodeGeoPoint(value sql.NullString) (*domain.GeoPoint, error) {
+	if !value.Valid || value.String == "" {
+		return nil, nil
+	}
+
+	var point *domain.GeoPoint
+
+	err := json.Unmarshal([]byte(value.String), &point)
+	if err != nil {
+		return nil, err
+	}
+
+	return point, nil
+}
+
+// decodeAreaSanitations decodes the JSON of the sanitations, which is NULL for the areas saved before they existed.
+func decodeAreaSanitations(value sql.NullString) ([]storage.AreaSanitation, error) {
+	sanitations := []storage.AreaSanitation{}
+
+	if !value.Valid || value.String == "" {
+		return sanitations, nil
+	}
+
+	err := json.Unmarshal([]byte(value.String), &sanitations)
+	if err != nil {
+		return nil, err
+	}
+
+	if sanitations == nil {
+		sanitations = []storage.AreaSanitation{}
+	}
+
+	return sanitations, nil
+}
+
+// decodeAreaLastSanitizedDate parses the date of the last sanitation, which is NULL for the areas never sanitized.
+func decodeAreaLastSanitizedDate(value sql.NullString) (*time.Time, error) {
+	if !value.Valid || value.String == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, value.String)
+	if err != nil {
+		return nil, err
+	}
+
+	return &date, nil
+}
@@ -0,0 +1,184 @@
+package sqlite
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/query"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+type AreaTaskReadQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewAreaTaskReadQuerySqlite(db *sql.DB) query.AreaTaskRead {
+	return AreaTaskReadQuerySqlite{DB: db}
+}
+
+func (s AreaTaskReadQuerySqlite) CountOverdueByArea(date time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		rows, err := s.DB.Query(`SELECT ASSET_ID, DUE_DATE FROM TASK_READ
+			WHERE DOMAIN_CODE = ? AND STATUS = ? AND ASSET_ID IS NOT NULL AND DUE_DATE IS NOT NULL`,
+			tasksdomain.TaskDomainAreaCode, tasksdomain.TaskStatusCreated)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		counts := map[uuid.UUID]int{}
+
+		// The due dates are stored as RFC3339 text, so they are compared after parsing.
+		for rows.Next() {
+			rowsData := struct {
+				AssetID string
+				DueDate string
+			}{}
+
+			err := rows.Scan(&rowsData.AssetID, &rowsData.DueDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			if rowsData.DueDate == "" {
+				continue
+			}
+
+			dueDate, err := time.Parse(time.RFC3339, rowsData.DueDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			if !dueDate.Before(date) {
+				continue
+			}
+
+			areaUID, err := uuid.FromString(rowsData.AssetID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			counts[areaUID]++
+		}
+
+		result <- query.Result{Result: counts}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s AreaTaskReadQuerySqlite) FindAllOpenByAssets(assetUIDs []uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		tasks := []query.AreaTaskResult{}
+
+		if len(assetUIDs) == 0 {
+			result <- query.Result{Result: tasks}
+			close(result)
+
+			return
+		}
+
+		args := []interface{}{tasksdomain.TaskStatusCreated}
+		for _, v := range assetUIDs {
+			args = append(args, v)
+		}
+
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(assetUIDs)), ", ")
+
+		rows, err := s.DB.Query(`SELECT UID, SHORT_CODE, TITLE, PRIORITY, STATUS, DOMAIN_CODE, CATEGORY, DUE_DATE, ASSET_ID
+			FROM TASK_READ WHERE STATUS = ? AND ASSET_ID IN (`+placeholders+`)`, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			rowsData := struct {
+				UID       string
+				ShortCode sql.NullString
+				Title     string
+				Priority  string
+				Status    string
+				Domain    string
+				Category  string
+				DueDate   sql.NullString
+				AssetID   string
+			}{}
+
+			err := rows.Scan(&rowsData.UID, &rowsData.ShortCode, &rowsData.Title, &rowsData.Priority,
+				&rowsData.Status, &rowsData.Domain, &rowsData.Category, &rowsData.DueDate, &rowsData.AssetID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			task := query.AreaTaskResult{
+				ShortCode: rowsData.ShortCode.String,
+				Title:     rowsData.Title,
+				Priority:  rowsData.Priority,
+				Status:    rowsData.Status,
+				Domain:    rowsData.Domain,
+				Category:  rowsData.Category,
+			}
+
+			task.UID, err = uuid.FromString(rowsData.UID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			task.AssetID, err = uuid.FromString(rowsData.AssetID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			if rowsData.DueDate.Valid && rowsData.DueDate.String != "" {
+				dueDate, err := time.Parse(time.RFC3339, rowsData.DueDate.String)
+				if err != nil {
+					result <- query.Result{Error: err}
+					close(result)
+
+					return
+				}
+
+				task.DueDate = &dueDate
+			}
+
+			tasks = append(tasks, task)
+		}
+
+		result <- query.Result{Result: tasks}
+		close(result)
+	}()
+
+	return result
+}
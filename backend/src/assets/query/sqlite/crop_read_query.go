@@ -60,29 +60,25 @@ type cropReadMovedAreaResult struct {
 	LastUpdated     string
 }
 
+// CountCropsByArea counts the active crops which have plants in the area, from the AREA_CROP_CURRENT table.
 func (q CropReadQuerySqlite) CountCropsByArea(areaUID uuid.UUID) <-chan query.Result {
 	result := make(chan query.Result)
 
 	go func() {
-		var totalCropBatchInitial, totalPlantInitial sql.NullInt64
+		var totalCropBatch, totalPlant sql.NullInt64
 
-		err := q.DB.QueryRow(`SELECT COUNT(UID), SUM(INITIAL_AREA_CURRENT_QUANTITY)
-			FROM CROP_READ WHERE INITIAL_AREA_UID = ?`, areaUID).Scan(&totalCropBatchInitial, &totalPlantInitial)
+		err := q.DB.QueryRow(`SELECT COUNT(DISTINCT CROP_UID), SUM(QUANTITY)
+			FROM AREA_CROP_CURRENT WHERE AREA_UID = ?`, areaUID).Scan(&totalCropBatch, &totalPlant)
 		if err != nil {
 			result <- query.Result{Error: err}
-		}
-
-		var totalCropBatchMoved, totalPlantMoved sql.NullInt64
-		err = q.DB.QueryRow(`SELECT COUNT(CROP_UID), SUM(CURRENT_QUANTITY)
-			FROM CROP_READ_MOVED_AREA WHERE AREA_UID = ?`, areaUID).Scan(&totalCropBatchMoved, &totalPlantMoved)
+			close(result)
 
-		if err != nil {
-			result <- query.Result{Error: err}
+			return
 		}
 
 		result <- query.Result{Result: query.CountAreaCropResult{
-			PlantQuantity:  int(totalPlantInitial.Int64) + int(totalPlantMoved.Int64),
-			TotalCropBatch: int(totalCropBatchInitial.Int64) + int(totalCropBatchMoved.Int64),
+			PlantQuantity:  int(totalPlant.Int64),
+			TotalCropBatch: int(totalCropBatch.Int64),
 		}}
 
 		close(result)
@@ -133,7 +129,8 @@ func (q CropReadQuerySqlite) FindAllCropByArea(areaUID uuid.UUID) <-chan query.R
 				MovingDate:  cropRead.InitialArea.CreatedDate,
 				CreatedDate: cropRead.InitialArea.CreatedDate,
 				Inventory: query.Inventory{
-					UID: cropRead.Inventory.UID,
+					UID:  cropRead.Inventory.UID,
+					Name: cropRead.Inventory.Name,
 				},
 				Container: query.Container{
 					Quantity: cropRead.Container.Quantity,
@@ -187,7 +184,8 @@ func (q CropReadQuerySqlite) FindAllCropByArea(areaUID uuid.UUID) <-chan query.R
 					MovingDate:  val.CreatedDate,
 					CreatedDate: val.CreatedDate,
 					Inventory: query.Inventory{
-						UID: cropRead.Inventory.UID,
+						UID:  cropRead.Inventory.UID,
+						Name: cropRead.Inventory.Name,
 					},
 					Container: query.Container{
 						Quantity: cropRead.Container.Quantity,
@@ -457,3 +455,67 @@ func (q CropReadQuerySqlite) populateCropMovedArea(uid uuid.UUID, cropRead *stor
 
 	return nil
 }
+
+func (q CropReadQuerySqlite) FindAllCurrentByArea(areaUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		rows, err := q.DB.Query(`SELECT CROP_READ.UID, BATCH_ID, SHORT_CODE, INVENTORY_NAME, INVENTORY_PLANT_TYPE,
+				AREA_CROP_CURRENT.QUANTITY
+			FROM AREA_CROP_CURRENT
+			JOIN CROP_READ ON CROP_READ.UID = AREA_CROP_CURRENT.CROP_UID
+			WHERE AREA_CROP_CURRENT.AREA_UID = ?
+			ORDER BY AREA_CROP_CURRENT.PLACED_DATE ASC`, areaUID)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		crops := []query.AreaCurrentCropResult{}
+
+		for rows.Next() {
+			rowsData := struct {
+				UID       string
+				BatchID   string
+				ShortCode sql.NullString
+				Variety   string
+				PlantType string
+				Quantity  int
+			}{}
+
+			err := rows.Scan(&rowsData.UID, &rowsData.BatchID, &rowsData.ShortCode, &rowsData.Variety,
+				&rowsData.PlantType, &rowsData.Quantity)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			cropUID, err := uuid.FromString(rowsData.UID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			crops = append(crops, query.AreaCurrentCropResult{
+				CropUID:     cropUID,
+				BatchID:     rowsData.BatchID,
+				ShortCode:   rowsData.ShortCode.String,
+				VarietyName: rowsData.Variety,
+				PlantType:   rowsData.PlantType,
+				Quantity:    rowsData.Quantity,
+			})
+		}
+
+		result <- query.Result{Result: crops}
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,139 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+const customFieldDefinitionReadColumns = `UID, FARM_UID, ENTITY_TYPE, FIELD_KEY, FIELD_TYPE, IS_REQUIRED, IS_DELETED, CREATED_DATE,
+	LABEL, OPTIONS`
+
+type CustomFieldDefinitionReadQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewCustomFieldDefinitionReadQuerySqlite(db *sql.DB) query.CustomFieldDefinitionRead {
+	return CustomFieldDefinitionReadQuerySqlite{DB: db}
+}
+
+func (s CustomFieldDefinitionReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		res := <-s.findAll(`SELECT `+customFieldDefinitionReadColumns+`
+			FROM CUSTOM_FIELD_DEFINITION_READ WHERE UID = ?`, uid)
+		if res.Error != nil {
+			result <- res
+			close(result)
+
+			return
+		}
+
+		definition := storage.CustomFieldDefinitionRead{}
+		for _, v := range res.Result.([]storage.CustomFieldDefinitionRead) {
+			definition = v
+		}
+
+		result <- query.Result{Result: definition}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s CustomFieldDefinitionReadQuerySqlite) FindAllByFarm(farmUID uuid.UUID, entityType string) <-chan query.Result {
+	if entityType == "" {
+		return s.findAll(`SELECT `+customFieldDefinitionReadColumns+`
+			FROM CUSTOM_FIELD_DEFINITION_READ WHERE FARM_UID = ? AND IS_DELETED = ?
+			ORDER BY CREATED_DATE ASC`, farmUID, false)
+	}
+
+	return s.findAll(`SELECT `+customFieldDefinitionReadColumns+`
+		FROM CUSTOM_FIELD_DEFINITION_READ WHERE FARM_UID = ? AND ENTITY_TYPE = ? AND IS_DELETED = ?
+		ORDER BY CREATED_DATE ASC`, farmUID, entityType, false)
+}
+
+func (s CustomFieldDefinitionReadQuerySqlite) findAll(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		definitions := []storage.CustomFieldDefinitionRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			rowsData := struct {
+				UID         string
+				FarmUID     string
+				EntityType  string
+				FieldKey    string
+				FieldType   string
+				Required    bool
+				IsDeleted   bool
+				Label       string
+				Options     string
+				CreatedDate string
+			}{}
+
+			err := rows.Scan(
+				&rowsData.UID, &rowsData.FarmUID, &rowsData.EntityType, &rowsData.FieldKey,
+				&rowsData.FieldType, &rowsData.Required, &rowsData.IsDeleted, &rowsData.CreatedDate,
+				&rowsData.Label, &rowsData.Options,
+			)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			definition := storage.CustomFieldDefinitionRead{
+				EntityType: rowsData.EntityType,
+				FieldKey:   rowsData.FieldKey,
+				FieldType:  rowsData.FieldType,
+				Required:   rowsData.Required,
+				IsDeleted:  rowsData.IsDeleted,
+				Label:      rowsData.Label,
+			}
+
+			err = json.Unmarshal([]byte(rowsData.Options), &definition.Options)
+			if err == nil {
+				definition.UID, err = uuid.FromString(rowsData.UID)
+			}
+
+			if err == nil {
+				definition.FarmUID, err = uuid.FromString(rowsData.FarmUID)
+			}
+
+			if err == nil {
+				definition.CreatedDate, err = time.Parse(time.RFC3339, rowsData.CreatedDate)
+			}
+
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			definitions = append(definitions, definition)
+		}
+
+		result <- query.Result{Result: definitions}
+		close(result)
+	}()
+
+	return result
+}
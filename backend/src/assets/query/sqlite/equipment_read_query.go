@@ -0,0 +1,200 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+const equipmentReadColumns = `UID, FARM_UID, NAME, TYPE, LOCATION_TYPE, LOCATION_UID, PURCHASE_DATE, NOTES,
+	MAINTENANCE_INTERVAL_DAYS, NEXT_MAINTENANCE_DATE, GEO_POINT, IS_DELETED, CREATED_DATE`
+
+type EquipmentReadQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewEquipmentReadQuerySqlite(db *sql.DB) query.EquipmentRead {
+	return EquipmentReadQuerySqlite{DB: db}
+}
+
+func (s EquipmentReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		res := <-s.findAll(`SELECT `+equipmentReadColumns+` FROM EQUIPMENT_READ WHERE UID = ?`, uid)
+		if res.Error != nil {
+			result <- res
+			close(result)
+
+			return
+		}
+
+		equipment := storage.EquipmentRead{}
+		for _, v := range res.Result.([]storage.EquipmentRead) {
+			equipment = v
+		}
+
+		result <- query.Result{Result: equipment}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s EquipmentReadQuerySqlite) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	return s.findAll(`SELECT `+equipmentReadColumns+`
+		FROM EQUIPMENT_READ WHERE FARM_UID = ? AND IS_DELETED = ? ORDER BY NAME ASC`, farmUID, false)
+}
+
+func (s EquipmentReadQuerySqlite) FindAllMaintenanceDue(maintenanceDate time.Time) <-chan query.Result {
+	// The dates are stored as RFC3339 text, which only sorts correctly within the same offset,
+	// so the comparison is done after parsing.
+	result := make(chan query.Result)
+
+	go func() {
+		res := <-s.findAll(`SELECT `+equipmentReadColumns+`
+			FROM EQUIPMENT_READ WHERE IS_DELETED = ? AND MAINTENANCE_INTERVAL_DAYS > 0
+			AND NEXT_MAINTENANCE_DATE IS NOT NULL ORDER BY NAME ASC`, false)
+		if res.Error != nil {
+			result <- res
+			close(result)
+
+			return
+		}
+
+		equipment := []storage.EquipmentRead{}
+
+		for _, v := range res.Result.([]storage.EquipmentRead) {
+			if !v.NextMaintenanceDate.After(maintenanceDate) {
+				equipment = append(equipment, v)
+			}
+		}
+
+		result <- query.Result{Result: equipment}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s EquipmentReadQuerySqlite) findAll(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		equipment := []storage.EquipmentRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			equipmentRead, err := populateEquipmentRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			equipment = append(equipment, equipmentRead)
+		}
+
+		result <- query.Result{Result: equipment}
+		close(result)
+	}()
+
+	return result
+}
+
+func populateEquipmentRead(rows *sql.Rows) (storage.EquipmentRead, error) {
+	rowsData := struct {
+		UID                     string
+		FarmUID                 string
+		Name                    string
+		Type                    string
+		LocationType            sql.NullString
+		LocationUID             sql.NullString
+		PurchaseDate            sql.NullString
+		Notes                   string
+		MaintenanceIntervalDays int
+		NextMaintenanceDate     sql.NullString
+		GeoPoint                sql.NullString
+		IsDeleted               bool
+		CreatedDate             string
+	}{}
+
+	err := rows.Scan(
+		&rowsData.UID, &rowsData.FarmUID, &rowsData.Name, &rowsData.Type, &rowsData.LocationType,
+		&rowsData.LocationUID, &rowsData.PurchaseDate, &rowsData.Notes, &rowsData.MaintenanceIntervalDays,
+		&rowsData.NextMaintenanceDate, &rowsData.GeoPoint, &rowsData.IsDeleted, &rowsData.CreatedDate,
+	)
+	if err != nil {
+		return storage.EquipmentRead{}, err
+	}
+
+	equipment := storage.EquipmentRead{
+		Name:                    rowsData.Name,
+		Type:                    rowsData.Type,
+		Notes:                   rowsData.Notes,
+		MaintenanceIntervalDays: rowsData.MaintenanceIntervalDays,
+		IsDeleted:               rowsData.IsDeleted,
+	}
+
+	equipment.UID, err = uuid.FromString(rowsData.UID)
+	if err != nil {
+		return storage.EquipmentRead{}, err
+	}
+
+	equipment.FarmUID, err = uuid.FromString(rowsData.FarmUID)
+	if err != nil {
+		return storage.EquipmentRead{}, err
+	}
+
+	if rowsData.LocationType.Valid && rowsData.LocationUID.Valid {
+		locationUID, err := uuid.FromString(rowsData.LocationUID.String)
+		if err != nil {
+			return storage.EquipmentRead{}, err
+		}
+
+		equipment.Location = &domain.EquipmentLocation{Type: rowsData.LocationType.String, UID: locationUID}
+	}
+
+	if rowsData.PurchaseDate.Valid && rowsData.PurchaseDate.String != "" {
+		purchaseDate, err := time.Parse(time.RFC3339, rowsData.PurchaseDate.String)
+		if err != nil {
+			return storage.EquipmentRead{}, err
+		}
+
+		equipment.PurchaseDate = &purchaseDate
+	}
+
+	if rowsData.NextMaintenanceDate.Valid && rowsData.NextMaintenanceDate.String != "" {
+		nextMaintenanceDate, err := time.Parse(time.RFC3339, rowsData.NextMaintenanceDate.String)
+		if err != nil {
+			return storage.EquipmentRead{}, err
+		}
+
+		equipment.NextMaintenanceDate = &nextMaintenanceDate
+	}
+
+	equipment.GeoPoint, err = decodeGeoPoint(rowsData.GeoPoint)
+	if err != nil {
+		return storage.EquipmentRead{}, err
+	}
+
+	equipment.CreatedDate, err = time.Parse(time.RFC3339, rowsData.CreatedDate)
+	if err != nil {
+		return storage.EquipmentRead{}, err
+	}
+
+	return equipment, nil
+}
@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/query"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+type EquipmentTaskReadQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewEquipmentTaskReadQuerySqlite(db *sql.DB) query.EquipmentTaskRead {
+	return EquipmentTaskReadQuerySqlite{DB: db}
+}
+
+func (s EquipmentTaskReadQuerySqlite) FindNextByEquipment(equipmentUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		rows, err := s.DB.Query(`SELECT UID, SHORT_CODE, TITLE, STATUS, DUE_DATE
+			FROM TASK_READ WHERE DOMAIN_CODE = ? AND STATUS = ? AND ASSET_ID = ?`,
+			tasksdomain.TaskDomainEquipmentCode, tasksdomain.TaskStatusCreated, equipmentUID)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		next := query.EquipmentTaskResult{}
+
+		// The due dates are stored as RFC3339 text, so the first one is found after parsing.
+		for rows.Next() {
+			rowsData := struct {
+				UID       string
+				ShortCode sql.NullString
+				Title     string
+				Status    string
+				DueDate   sql.NullString
+			}{}
+
+			err := rows.Scan(&rowsData.UID, &rowsData.ShortCode, &rowsData.Title, &rowsData.Status, &rowsData.DueDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			task := query.EquipmentTaskResult{
+				ShortCode: rowsData.ShortCode.String,
+				Title:     rowsData.Title,
+				Status:    rowsData.Status,
+			}
+
+			task.UID, err = uuid.FromString(rowsData.UID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			if rowsData.DueDate.Valid && rowsData.DueDate.String != "" {
+				dueDate, err := time.Parse(time.RFC3339, rowsData.DueDate.String)
+				if err != nil {
+					result <- query.Result{Error: err}
+					close(result)
+
+					return
+				}
+
+				task.DueDate = &dueDate
+			}
+
+			if next.UID == (uuid.UUID{}) || task.IsDueBefore(next) {
+				next = task
+			}
+		}
+
+		result <- query.Result{Result: next}
+		close(result)
+	}()
+
+	return result
+}
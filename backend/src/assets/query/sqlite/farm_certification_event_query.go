@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/decoder"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type FarmCertificationEventQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewFarmCertificationEventQuerySqlite(db *sql.DB) query.FarmCertificationEvent {
+	return &FarmCertificationEventQuerySqlite{DB: db}
+}
+
+func (f *FarmCertificationEventQuerySqlite) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.FarmCertificationEvent{}
+
+		rows, err := f.DB.Query(`SELECT * FROM FARM_CERTIFICATION_EVENT
+			WHERE FARM_CERTIFICATION_UID = ? ORDER BY VERSION ASC`, uid)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		rowsData := struct {
+			ID                   int
+			FarmCertificationUID string
+			Version              int
+			CreatedDate          string
+			Event                []byte
+		}{}
+
+		for rows.Next() {
+			err := rows.Scan(&rowsData.ID, &rowsData.FarmCertificationUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			wrapper := decoder.FarmCertificationEventWrapper{}
+
+			err = json.Unmarshal(rowsData.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			certificationUID, err := uuid.FromString(rowsData.FarmCertificationUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			createdDate, err := time.Parse(time.RFC3339, rowsData.CreatedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			events = append(events, storage.FarmCertificationEvent{
+				FarmCertificationUID: certificationUID,
+				Version:              rowsData.Version,
+				CreatedDate:          createdDate,
+				Event:                wrapper.EventData,
+				Actor:                wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,186 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+const farmCertificationReadColumns = `UID, FARM_UID, CERTIFICATION_TYPE, CERTIFYING_BODY, ISSUED_DATE, EXPIRY_DATE,
+	CERTIFICATE_NUMBER, STATUS, IS_RENEWAL_DUE, REVOKED_DATE, CREATED_DATE`
+
+type FarmCertificationReadQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewFarmCertificationReadQuerySqlite(db *sql.DB) query.FarmCertificationRead {
+	return FarmCertificationReadQuerySqlite{DB: db}
+}
+
+func (s FarmCertificationReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		res := <-s.findAll(`SELECT `+farmCertificationReadColumns+`
+			FROM FARM_CERTIFICATION_READ WHERE UID = ?`, uid)
+		if res.Error != nil {
+			result <- res
+			close(result)
+
+			return
+		}
+
+		certification := storage.FarmCertificationRead{}
+		for _, v := range res.Result.([]storage.FarmCertificationRead) {
+			certification = v
+		}
+
+		result <- query.Result{Result: certification}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s FarmCertificationReadQuerySqlite) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	return s.findAll(`SELECT `+farmCertificationReadColumns+`
+		FROM FARM_CERTIFICATION_READ WHERE FARM_UID = ? ORDER BY EXPIRY_DATE ASC`, farmUID)
+}
+
+func (s FarmCertificationReadQuerySqlite) FindAllRenewalDue(expiryDate time.Time) <-chan query.Result {
+	// The dates are stored as RFC3339 text, which only sorts correctly within the same offset,
+	// so the comparison is done after parsing.
+	result := make(chan query.Result)
+
+	go func() {
+		res := <-s.findAll(`SELECT `+farmCertificationReadColumns+`
+			FROM FARM_CERTIFICATION_READ WHERE STATUS = ? AND IS_RENEWAL_DUE = ?
+			ORDER BY EXPIRY_DATE ASC`, domain.CertificationStatusActive, false)
+		if res.Error != nil {
+			result <- res
+			close(result)
+
+			return
+		}
+
+		certifications := []storage.FarmCertificationRead{}
+
+		for _, v := range res.Result.([]storage.FarmCertificationRead) {
+			if !v.ExpiryDate.After(expiryDate) {
+				certifications = append(certifications, v)
+			}
+		}
+
+		result <- query.Result{Result: certifications}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s FarmCertificationReadQuerySqlite) findAll(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		certifications := []storage.FarmCertificationRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			certification, err := populateFarmCertificationRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			certifications = append(certifications, certification)
+		}
+
+		result <- query.Result{Result: certifications}
+		close(result)
+	}()
+
+	return result
+}
+
+func populateFarmCertificationRead(rows *sql.Rows) (storage.FarmCertificationRead, error) {
+	rowsData := struct {
+		UID               string
+		FarmUID           string
+		CertificationType string
+		CertifyingBody    string
+		IssuedDate        string
+		ExpiryDate        string
+		CertificateNumber string
+		Status            string
+		IsRenewalDue      bool
+		RevokedDate       sql.NullString
+		CreatedDate       string
+	}{}
+
+	err := rows.Scan(
+		&rowsData.UID, &rowsData.FarmUID, &rowsData.CertificationType, &rowsData.CertifyingBody,
+		&rowsData.IssuedDate, &rowsData.ExpiryDate, &rowsData.CertificateNumber, &rowsData.Status,
+		&rowsData.IsRenewalDue, &rowsData.RevokedDate, &rowsData.CreatedDate,
+	)
+	if err != nil {
+		return storage.FarmCertificationRead{}, err
+	}
+
+	certification := storage.FarmCertificationRead{
+		CertificationType: rowsData.CertificationType,
+		CertifyingBody:    rowsData.CertifyingBody,
+		CertificateNumber: rowsData.CertificateNumber,
+		Status:            rowsData.Status,
+		IsRenewalDue:      rowsData.IsRenewalDue,
+	}
+
+	certification.UID, err = uuid.FromString(rowsData.UID)
+	if err != nil {
+		return storage.FarmCertificationRead{}, err
+	}
+
+	certification.FarmUID, err = uuid.FromString(rowsData.FarmUID)
+	if err != nil {
+		return storage.FarmCertificationRead{}, err
+	}
+
+	certification.IssuedDate, err = time.Parse(time.RFC3339, rowsData.IssuedDate)
+	if err != nil {
+		return storage.FarmCertificationRead{}, err
+	}
+
+	certification.ExpiryDate, err = time.Parse(time.RFC3339, rowsData.ExpiryDate)
+	if err != nil {
+		return storage.FarmCertificationRead{}, err
+	}
+
+	certification.CreatedDate, err = time.Parse(time.RFC3339, rowsData.CreatedDate)
+	if err != nil {
+		return storage.FarmCertificationRead{}, err
+	}
+
+	if rowsData.RevokedDate.Valid && rowsData.RevokedDate.String != "" {
+		revokedDate, err := time.Parse(time.RFC3339, rowsData.RevokedDate.String)
+		if err != nil {
+			return storage.FarmCertificationRead{}, err
+		}
+
+		certification.RevokedDate = &revokedDate
+	}
+
+	return certification, nil
+}
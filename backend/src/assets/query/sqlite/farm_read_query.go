@@ -2,12 +2,15 @@ package sqlite
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/domain"
 	"github.com/usetania/tania-core/src/assets/query"
 	"github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
 )
 
 type FarmReadQuerySqlite struct {
@@ -19,15 +22,21 @@ func NewFarmReadQuerySqlite(db *sql.DB) query.FarmRead {
 }
 
 type farmReadResult struct {
-	UID         string
-	Name        string
-	Latitude    string
-	Longitude   string
-	Type        string
-	Country     string
-	City        string
-	IsActive    int
-	CreatedDate string
+	UID           string
+	Name          string
+	Latitude      string
+	Longitude     string
+	Type          string
+	Country       string
+	City          string
+	IsActive      int
+	CreatedDate   string
+	AreaWalkOrder sql.NullString
+	HarvestGrades sql.NullString
+	Seasons       sql.NullString
+
+	WithholdingPolicy        sql.NullString
+	DailyEffortBudgetMinutes sql.NullInt64
 }
 
 func (s FarmReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
@@ -47,6 +56,11 @@ func (s FarmReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
 			&rowsData.City,
 			&rowsData.IsActive,
 			&rowsData.CreatedDate,
+			&rowsData.AreaWalkOrder,
+			&rowsData.HarvestGrades,
+			&rowsData.Seasons,
+			&rowsData.WithholdingPolicy,
+			&rowsData.DailyEffortBudgetMinutes,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -67,6 +81,21 @@ func (s FarmReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
 			result <- query.Result{Error: err}
 		}
 
+		areaWalkOrder, err := decodeAreaWalkOrder(rowsData.AreaWalkOrder)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		harvestGrades, err := decodeHarvestGrades(rowsData.HarvestGrades)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		seasons, err := decodeSeasons(rowsData.Seasons)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
 		farmRead = storage.FarmRead{
 			UID:         farmUID,
 			Name:        rowsData.Name,
@@ -77,6 +106,13 @@ func (s FarmReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
 			City:        rowsData.City,
 			IsActive:    rowsData.IsActive != 0,
 			CreatedDate: createdDate,
+
+			AreaWalkOrder: areaWalkOrder,
+			HarvestGrades: harvestGrades,
+			Seasons:       seasons,
+
+			WithholdingPolicy:        rowsData.WithholdingPolicy.String,
+			DailyEffortBudgetMinutes: int(rowsData.DailyEffortBudgetMinutes.Int64),
 		}
 
 		result <- query.Result{Result: farmRead}
@@ -109,6 +145,11 @@ func (s FarmReadQuerySqlite) FindAll() <-chan query.Result {
 				&rowsData.City,
 				&rowsData.IsActive,
 				&rowsData.CreatedDate,
+				&rowsData.AreaWalkOrder,
+				&rowsData.HarvestGrades,
+				&rowsData.Seasons,
+				&rowsData.WithholdingPolicy,
+				&rowsData.DailyEffortBudgetMinutes,
 			)
 
 			if err != nil {
@@ -125,6 +166,21 @@ func (s FarmReadQuerySqlite) FindAll() <-chan query.Result {
 				result <- query.Result{Error: err}
 			}
 
+			areaWalkOrder, err := decodeAreaWalkOrder(rowsData.AreaWalkOrder)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			harvestGrades, err := decodeHarvestGrades(rowsData.HarvestGrades)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			seasons, err := decodeSeasons(rowsData.Seasons)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
 			farmReads = append(farmReads, storage.FarmRead{
 				UID:         farmUID,
 				Name:        rowsData.Name,
@@ -135,6 +191,13 @@ func (s FarmReadQuerySqlite) FindAll() <-chan query.Result {
 				City:        rowsData.City,
 				IsActive:    rowsData.IsActive != 0,
 				CreatedDate: createdDate,
+
+				AreaWalkOrder: areaWalkOrder,
+				HarvestGrades: harvestGrades,
+				Seasons:       seasons,
+
+				WithholdingPolicy:        rowsData.WithholdingPolicy.String,
+				DailyEffortBudgetMinutes: int(rowsData.DailyEffortBudgetMinutes.Int64),
 			})
 		}
 
@@ -144,3 +207,49 @@ func (s FarmReadQuerySqlite) FindAll() <-chan query.Result {
 
 	return result
 }
+
+// decodeAreaWalkOrder decodes the JSON list of the area walk order column, which is empty until it's set.
+func decodeAreaWalkOrder(value sql.NullString) ([]uuid.UUID, error) {
+	areaUIDs := []uuid.UUID{}
+
+	if !value.Valid || value.String == "" {
+		return areaUIDs, nil
+	}
+
+	err := json.Unmarshal([]byte(value.String), &areaUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return areaUIDs, nil
+}
+
+// decodeHarvestGrades decodes the JSON list of the harvest grades column, the farms without one have the default grades.
+func decodeHarvestGrades(value sql.NullString) ([]domain.HarvestGrade, error) {
+	grades := []domain.HarvestGrade{}
+
+	if value.Valid && value.String != "" {
+		err := json.Unmarshal([]byte(value.String), &grades)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return domain.HarvestGradesOrDefault(grades), nil
+}
+
+// decodeSeasons decodes the JSON list of the seasons column, which is empty until the farm sets its seasons.
+func decodeSeasons(value sql.NullString) ([]timebuckethelper.Season, error) {
+	seasons := []timebuckethelper.Season{}
+
+	if !value.Valid || value.String == "" || value.String == "null" {
+		return seasons, nil
+	}
+
+	err := json.Unmarshal([]byte(value.String), &seasons)
+	if err != nil {
+		return nil, err
+	}
+
+	return seasons, nil
+}
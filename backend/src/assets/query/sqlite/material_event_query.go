@@ -66,6 +66,7 @@ func (f *MaterialEventQuerySqlite) FindAllByID(uid uuid.UUID) <-chan query.Resul
 				Version:     rowsData.Version,
 				CreatedDate: createdDate,
 				Event:       wrapper.EventData,
+				Actor:       wrapper.Actor,
 			})
 		}
 
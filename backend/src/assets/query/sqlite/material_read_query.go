@@ -34,6 +34,9 @@ type materialReadResult struct {
 	Notes          sql.NullString
 	ProducedBy     sql.NullString
 	CreatedDate    string
+	GDDToMaturity  sql.NullFloat64
+
+	PreHarvestIntervalDays sql.NullInt64
 }
 
 func (q MaterialReadQuerySqlite) FindAll(materialType, materialTypeDetail string, page, limit int) <-chan query.Result {
@@ -103,6 +106,8 @@ func (q MaterialReadQuerySqlite) FindAll(materialType, materialTypeDetail string
 				&rowsData.Notes,
 				&rowsData.ProducedBy,
 				&rowsData.CreatedDate,
+				&rowsData.GDDToMaturity,
+				&rowsData.PreHarvestIntervalDays,
 			)
 
 			if err != nil {
@@ -185,6 +190,17 @@ func (q MaterialReadQuerySqlite) FindAll(materialType, materialTypeDetail string
 				producedBy = &rowsData.ProducedBy.String
 			}
 
+			var gddToMaturity *float64
+			if rowsData.GDDToMaturity.Valid {
+				gddToMaturity = &rowsData.GDDToMaturity.Float64
+			}
+
+			var preHarvestIntervalDays *int
+			if rowsData.PreHarvestIntervalDays.Valid {
+				days := int(rowsData.PreHarvestIntervalDays.Int64)
+				preHarvestIntervalDays = &days
+			}
+
 			materialReads = append(materialReads, storage.MaterialRead{
 				UID:          materialUID,
 				Name:         rowsData.Name,
@@ -197,7 +213,10 @@ func (q MaterialReadQuerySqlite) FindAll(materialType, materialTypeDetail string
 				ExpirationDate: mExpDate,
 				Notes:          notes,
 				ProducedBy:     producedBy,
+				GDDToMaturity:  gddToMaturity,
 				CreatedDate:    mCreatedDate,
+
+				PreHarvestIntervalDays: preHarvestIntervalDays,
 			})
 		}
 
@@ -278,6 +297,8 @@ func (q MaterialReadQuerySqlite) FindByID(materialUID uuid.UUID) <-chan query.Re
 			&rowsData.Notes,
 			&rowsData.ProducedBy,
 			&rowsData.CreatedDate,
+			&rowsData.GDDToMaturity,
+			&rowsData.PreHarvestIntervalDays,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -364,6 +385,17 @@ func (q MaterialReadQuerySqlite) FindByID(materialUID uuid.UUID) <-chan query.Re
 			producedBy = &rowsData.ProducedBy.String
 		}
 
+		var gddToMaturity *float64
+		if rowsData.GDDToMaturity.Valid {
+			gddToMaturity = &rowsData.GDDToMaturity.Float64
+		}
+
+		var preHarvestIntervalDays *int
+		if rowsData.PreHarvestIntervalDays.Valid {
+			days := int(rowsData.PreHarvestIntervalDays.Int64)
+			preHarvestIntervalDays = &days
+		}
+
 		materialRead = storage.MaterialRead{
 			UID:          materialUID,
 			Name:         rowsData.Name,
@@ -376,7 +408,10 @@ func (q MaterialReadQuerySqlite) FindByID(materialUID uuid.UUID) <-chan query.Re
 			ExpirationDate: mExpDate,
 			Notes:          notes,
 			ProducedBy:     producedBy,
+			GDDToMaturity:  gddToMaturity,
 			CreatedDate:    mCreatedDate,
+
+			PreHarvestIntervalDays: preHarvestIntervalDays,
 		}
 
 		result <- query.Result{Result: materialRead}
@@ -0,0 +1,126 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+const nutrientRecipeReadColumns = `UID, FARM_UID, NAME, TARGET_EC, TARGET_PH, INGREDIENTS, IS_DELETED, CREATED_DATE`
+
+type NutrientRecipeReadQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewNutrientRecipeReadQuerySqlite(db *sql.DB) query.NutrientRecipeRead {
+	return NutrientRecipeReadQuerySqlite{DB: db}
+}
+
+func (s NutrientRecipeReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		res := <-s.findAll(`SELECT `+nutrientRecipeReadColumns+` FROM NUTRIENT_RECIPE_READ WHERE UID = ?`, uid)
+		if res.Error != nil {
+			result <- res
+			close(result)
+
+			return
+		}
+
+		recipe := storage.NutrientRecipeRead{}
+		for _, v := range res.Result.([]storage.NutrientRecipeRead) {
+			recipe = v
+		}
+
+		result <- query.Result{Result: recipe}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s NutrientRecipeReadQuerySqlite) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	return s.findAll(`SELECT `+nutrientRecipeReadColumns+`
+		FROM NUTRIENT_RECIPE_READ WHERE FARM_UID = ? AND IS_DELETED = ?
+		ORDER BY CREATED_DATE ASC`, farmUID, false)
+}
+
+func (s NutrientRecipeReadQuerySqlite) findAll(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		recipes := []storage.NutrientRecipeRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			rowsData := struct {
+				UID         string
+				FarmUID     string
+				Name        string
+				TargetEC    float32
+				TargetPH    float32
+				Ingredients string
+				IsDeleted   bool
+				CreatedDate string
+			}{}
+
+			err := rows.Scan(
+				&rowsData.UID, &rowsData.FarmUID, &rowsData.Name, &rowsData.TargetEC, &rowsData.TargetPH,
+				&rowsData.Ingredients, &rowsData.IsDeleted, &rowsData.CreatedDate,
+			)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			recipe := storage.NutrientRecipeRead{
+				Name:      rowsData.Name,
+				TargetEC:  rowsData.TargetEC,
+				TargetPH:  rowsData.TargetPH,
+				IsDeleted: rowsData.IsDeleted,
+			}
+
+			err = json.Unmarshal([]byte(rowsData.Ingredients), &recipe.Ingredients)
+			if err == nil {
+				recipe.UID, err = uuid.FromString(rowsData.UID)
+			}
+
+			if err == nil {
+				recipe.FarmUID, err = uuid.FromString(rowsData.FarmUID)
+			}
+
+			if err == nil {
+				recipe.CreatedDate, err = time.Parse(time.RFC3339, rowsData.CreatedDate)
+			}
+
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			recipes = append(recipes, recipe)
+		}
+
+		result <- query.Result{Result: recipes}
+		close(result)
+	}()
+
+	return result
+}
@@ -66,6 +66,7 @@ func (f *ReservoirEventQuerySqlite) FindAllByID(uid uuid.UUID) <-chan query.Resu
 				Version:      rowsData.Version,
 				CreatedDate:  createdDate,
 				Event:        wrapper.EventData,
+				Actor:        wrapper.Actor,
 			})
 		}
 
@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/decoder"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type StocktakeEventQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewStocktakeEventQuerySqlite(db *sql.DB) query.StocktakeEvent {
+	return &StocktakeEventQuerySqlite{DB: db}
+}
+
+func (f *StocktakeEventQuerySqlite) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.StocktakeEvent{}
+
+		rows, err := f.DB.Query(`SELECT * FROM STOCKTAKE_EVENT
+			WHERE STOCKTAKE_UID = ? ORDER BY VERSION ASC`, uid)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		rowsData := struct {
+			ID           int
+			StocktakeUID string
+			Version      int
+			CreatedDate  string
+			Event        []byte
+		}{}
+
+		for rows.Next() {
+			err := rows.Scan(&rowsData.ID, &rowsData.StocktakeUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			wrapper := decoder.StocktakeEventWrapper{}
+
+			err = json.Unmarshal(rowsData.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			stocktakeUID, err := uuid.FromString(rowsData.StocktakeUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			createdDate, err := time.Parse(time.RFC3339, rowsData.CreatedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			events = append(events, storage.StocktakeEvent{
+				StocktakeUID: stocktakeUID,
+				Version:      rowsData.Version,
+				CreatedDate:  createdDate,
+				Event:        wrapper.EventData,
+				Actor:        wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+const stocktakeReadColumns = `UID, FARM_UID, STATUS, LINES, OPENED_DATE, CLOSED_DATE`
+
+type StocktakeReadQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewStocktakeReadQuerySqlite(db *sql.DB) query.StocktakeRead {
+	return StocktakeReadQuerySqlite{DB: db}
+}
+
+func (s StocktakeReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		res := <-s.findAll(`SELECT `+stocktakeReadColumns+` FROM STOCKTAKE_READ WHERE UID = ?`, uid)
+		if res.Error != nil {
+			result <- res
+			close(result)
+
+			return
+		}
+
+		stocktake := storage.StocktakeRead{}
+		for _, v := range res.Result.([]storage.StocktakeRead) {
+			stocktake = v
+		}
+
+		result <- query.Result{Result: stocktake}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s StocktakeReadQuerySqlite) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	return s.findAll(`SELECT `+stocktakeReadColumns+` FROM STOCKTAKE_READ
+		WHERE FARM_UID = ? ORDER BY OPENED_DATE DESC`, farmUID)
+}
+
+func (s StocktakeReadQuerySqlite) FindAllOpen() <-chan query.Result {
+	return s.findAll(`SELECT `+stocktakeReadColumns+` FROM STOCKTAKE_READ
+		WHERE STATUS = ? ORDER BY OPENED_DATE DESC`, domain.StocktakeStatusOpen)
+}
+
+func (s StocktakeReadQuerySqlite) findAll(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		stocktakes := []storage.StocktakeRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			rowsData := struct {
+				UID        string
+				FarmUID    string
+				Status     string
+				Lines      string
+				OpenedDate string
+				ClosedDate sql.NullString
+			}{}
+
+			err := rows.Scan(
+				&rowsData.UID, &rowsData.FarmUID, &rowsData.Status, &rowsData.Lines,
+				&rowsData.OpenedDate, &rowsData.ClosedDate,
+			)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			stocktake := storage.StocktakeRead{Status: rowsData.Status}
+
+			stocktake.UID, err = uuid.FromString(rowsData.UID)
+			if err == nil {
+				stocktake.FarmUID, err = uuid.FromString(rowsData.FarmUID)
+			}
+
+			if err == nil {
+				err = json.Unmarshal([]byte(rowsData.Lines), &stocktake.Lines)
+			}
+
+			if err == nil {
+				stocktake.OpenedDate, err = time.Parse(time.RFC3339, rowsData.OpenedDate)
+			}
+
+			if err == nil && rowsData.ClosedDate.Valid {
+				var closedDate time.Time
+
+				closedDate, err = time.Parse(time.RFC3339, rowsData.ClosedDate.String)
+				stocktake.ClosedDate = &closedDate
+			}
+
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			stocktakes = append(stocktakes, stocktake)
+		}
+
+		result <- query.Result{Result: stocktakes}
+		close(result)
+	}()
+
+	return result
+}
@@ -2,6 +2,7 @@ package inmemory
 
 import (
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/assets/repository"
 	"github.com/usetania/tania-core/src/assets/storage"
 )
@@ -14,7 +15,9 @@ func NewAreaEventRepositoryInMemory(s *storage.AreaEventStorage) repository.Area
 	return &AreaEventRepositoryInMemory{Storage: s}
 }
 
-func (f *AreaEventRepositoryInMemory) Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error {
+func (f *AreaEventRepositoryInMemory) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
 	result := make(chan error)
 
 	go func() {
@@ -28,6 +31,7 @@ func (f *AreaEventRepositoryInMemory) Save(uid uuid.UUID, latestVersion int, eve
 				AreaUID: uid,
 				Version: latestVersion,
 				Event:   v,
+				Actor:   by,
 			})
 		}
 
@@ -0,0 +1,47 @@
+package inmemory
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type CustomFieldDefinitionEventRepositoryInMemory struct {
+	Storage *storage.CustomFieldDefinitionEventStorage
+}
+
+func NewCustomFieldDefinitionEventRepositoryInMemory(s *storage.CustomFieldDefinitionEventStorage) repository.CustomFieldDefinitionEvent {
+	return &CustomFieldDefinitionEventRepositoryInMemory{Storage: s}
+}
+
+func (f *CustomFieldDefinitionEventRepositoryInMemory) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		for _, v := range events {
+			latestVersion++
+
+			f.Storage.CustomFieldDefinitionEvents = append(f.Storage.CustomFieldDefinitionEvents, storage.CustomFieldDefinitionEvent{
+				CustomFieldDefinitionUID: uid,
+				Version:                  latestVersion,
+				CreatedDate:              time.Now(),
+				Event:                    v,
+				Actor:                    by,
+			})
+		}
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,31 @@
+package inmemory
+
+import (
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type CustomFieldDefinitionReadRepositoryInMemory struct {
+	Storage *storage.CustomFieldDefinitionReadStorage
+}
+
+func NewCustomFieldDefinitionReadRepositoryInMemory(s *storage.CustomFieldDefinitionReadStorage) repository.CustomFieldDefinitionRead {
+	return &CustomFieldDefinitionReadRepositoryInMemory{Storage: s}
+}
+
+func (f *CustomFieldDefinitionReadRepositoryInMemory) Save(definitionRead *storage.CustomFieldDefinitionRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		f.Storage.CustomFieldDefinitionReadMap[definitionRead.UID] = *definitionRead
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,50 @@
+package inmemory
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type FarmBoundaryEventRepositoryInMemory struct {
+	Storage *storage.FarmBoundaryEventStorage
+}
+
+func NewFarmBoundaryEventRepositoryInMemory(s *storage.FarmBoundaryEventStorage) repository.FarmBoundaryEvent {
+	return &FarmBoundaryEventRepositoryInMemory{Storage: s}
+}
+
+func (f *FarmBoundaryEventRepositoryInMemory) Save(
+	uid uuid.UUID,
+	latestVersion int,
+	events []interface{},
+	by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		for _, v := range events {
+			latestVersion++
+
+			f.Storage.FarmBoundaryEvents = append(f.Storage.FarmBoundaryEvents, storage.FarmBoundaryEvent{
+				FarmUID:     uid,
+				Version:     latestVersion,
+				CreatedDate: time.Now(),
+				Event:       v,
+				Actor:       by,
+			})
+		}
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,47 @@
+package inmemory
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type FarmCertificationEventRepositoryInMemory struct {
+	Storage *storage.FarmCertificationEventStorage
+}
+
+func NewFarmCertificationEventRepositoryInMemory(s *storage.FarmCertificationEventStorage) repository.FarmCertificationEvent {
+	return &FarmCertificationEventRepositoryInMemory{Storage: s}
+}
+
+func (f *FarmCertificationEventRepositoryInMemory) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		for _, v := range events {
+			latestVersion++
+
+			f.Storage.FarmCertificationEvents = append(f.Storage.FarmCertificationEvents, storage.FarmCertificationEvent{
+				FarmCertificationUID: uid,
+				Version:              latestVersion,
+				CreatedDate:          time.Now(),
+				Event:                v,
+				Actor:                by,
+			})
+		}
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
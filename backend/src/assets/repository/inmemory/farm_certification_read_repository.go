@@ -0,0 +1,31 @@
+package inmemory
+
+import (
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type FarmCertificationReadRepositoryInMemory struct {
+	Storage *storage.FarmCertificationReadStorage
+}
+
+func NewFarmCertificationReadRepositoryInMemory(s *storage.FarmCertificationReadStorage) repository.FarmCertificationRead {
+	return &FarmCertificationReadRepositoryInMemory{Storage: s}
+}
+
+func (f *FarmCertificationReadRepositoryInMemory) Save(certificationRead *storage.FarmCertificationRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		f.Storage.FarmCertificationReadMap[certificationRead.UID] = *certificationRead
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
@@ -2,6 +2,7 @@ package inmemory
 
 import (
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/assets/repository"
 	"github.com/usetania/tania-core/src/assets/storage"
 )
@@ -15,7 +16,9 @@ func NewFarmEventRepositoryInMemory(s *storage.FarmEventStorage) repository.Farm
 }
 
 // Save is to save.
-func (f *FarmEventRepositoryInMemory) Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error {
+func (f *FarmEventRepositoryInMemory) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
 	result := make(chan error)
 
 	go func() {
@@ -29,6 +32,7 @@ func (f *FarmEventRepositoryInMemory) Save(uid uuid.UUID, latestVersion int, eve
 				FarmUID: uid,
 				Version: latestVersion,
 				Event:   v,
+				Actor:   by,
 			})
 		}
 
@@ -3,7 +3,9 @@ package inmemory_test
 import (
 	"testing"
 
+	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/assets/domain"
 	"github.com/usetania/tania-core/src/assets/repository/inmemory"
 	"github.com/usetania/tania-core/src/assets/storage"
@@ -20,12 +22,15 @@ func TestFarmEventInMemorySave(t *testing.T) {
 	farm1, farmErr1 := domain.CreateFarm("My Farm 1", "organic", "10.000", "11.000", "ID", "JK")
 	farm2, farmErr2 := domain.CreateFarm("My Farm 2", "organic", "10.000", "11.000", "ID", "JK")
 
+	userUID, _ := uuid.NewV4()
+	by := &actor.Actor{UserUID: &userUID, Source: actor.SourceMobile}
+
 	// When
 	var err1, err2 error
 
 	go func() {
-		err1 = <-repo.Save(farm1.UID, farm1.Version, farm1.UncommittedChanges)
-		err2 = <-repo.Save(farm2.UID, farm2.Version, farm2.UncommittedChanges)
+		err1 = <-repo.Save(farm1.UID, farm1.Version, farm1.UncommittedChanges, by)
+		err2 = <-repo.Save(farm2.UID, farm2.Version, farm2.UncommittedChanges, nil)
 
 		done <- true
 	}()
@@ -37,4 +42,7 @@ func TestFarmEventInMemorySave(t *testing.T) {
 
 	assert.Nil(t, err1)
 	assert.Nil(t, err2)
+
+	assert.Equal(t, by, farmEventStorage.FarmEvents[0].Actor)
+	assert.Nil(t, farmEventStorage.FarmEvents[len(farmEventStorage.FarmEvents)-1].Actor)
 }
@@ -2,6 +2,7 @@ package inmemory
 
 import (
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/assets/repository"
 	"github.com/usetania/tania-core/src/assets/storage"
 )
@@ -14,7 +15,9 @@ func NewMaterialEventRepositoryInMemory(s *storage.MaterialEventStorage) reposit
 	return &MaterialEventRepositoryInMemory{Storage: s}
 }
 
-func (f *MaterialEventRepositoryInMemory) Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error {
+func (f *MaterialEventRepositoryInMemory) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
 	result := make(chan error)
 
 	go func() {
@@ -28,6 +31,7 @@ func (f *MaterialEventRepositoryInMemory) Save(uid uuid.UUID, latestVersion int,
 				MaterialUID: uid,
 				Version:     latestVersion,
 				Event:       v,
+				Actor:       by,
 			})
 		}
 
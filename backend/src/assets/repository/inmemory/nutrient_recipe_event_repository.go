@@ -0,0 +1,50 @@
+package inmemory
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type NutrientRecipeEventRepositoryInMemory struct {
+	Storage *storage.NutrientRecipeEventStorage
+}
+
+func NewNutrientRecipeEventRepositoryInMemory(s *storage.NutrientRecipeEventStorage) repository.NutrientRecipeEvent {
+	return &NutrientRecipeEventRepositoryInMemory{Storage: s}
+}
+
+func (f *NutrientRecipeEventRepositoryInMemory) Save(
+	uid uuid.UUID,
+	latestVersion int,
+	events []interface{},
+	by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		for _, v := range events {
+			latestVersion++
+
+			f.Storage.NutrientRecipeEvents = append(f.Storage.NutrientRecipeEvents, storage.NutrientRecipeEvent{
+				NutrientRecipeUID: uid,
+				Version:           latestVersion,
+				CreatedDate:       time.Now(),
+				Event:             v,
+				Actor:             by,
+			})
+		}
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,31 @@
+package inmemory
+
+import (
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type NutrientRecipeReadRepositoryInMemory struct {
+	Storage *storage.NutrientRecipeReadStorage
+}
+
+func NewNutrientRecipeReadRepositoryInMemory(s *storage.NutrientRecipeReadStorage) repository.NutrientRecipeRead {
+	return &NutrientRecipeReadRepositoryInMemory{Storage: s}
+}
+
+func (f *NutrientRecipeReadRepositoryInMemory) Save(recipeRead *storage.NutrientRecipeRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		f.Storage.NutrientRecipeReadMap[recipeRead.UID] = *recipeRead
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
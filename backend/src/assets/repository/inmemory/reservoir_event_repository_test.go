@@ -45,8 +45,8 @@ func TestReservoirEventInMemorySave(t *testing.T) {
 	var err1, err2 error
 
 	go func() {
-		err1 = <-repo.Save(reservoir1.UID, reservoir1.Version, reservoir1.UncommittedChanges)
-		err2 = <-repo.Save(reservoir2.UID, reservoir2.Version, reservoir2.UncommittedChanges)
+		err1 = <-repo.Save(reservoir1.UID, reservoir1.Version, reservoir1.UncommittedChanges, nil)
+		err2 = <-repo.Save(reservoir2.UID, reservoir2.Version, reservoir2.UncommittedChanges, nil)
 
 		done <- true
 	}()
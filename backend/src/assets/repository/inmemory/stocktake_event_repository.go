@@ -0,0 +1,47 @@
+package inmemory
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type StocktakeEventRepositoryInMemory struct {
+	Storage *storage.StocktakeEventStorage
+}
+
+func NewStocktakeEventRepositoryInMemory(s *storage.StocktakeEventStorage) repository.StocktakeEvent {
+	return &StocktakeEventRepositoryInMemory{Storage: s}
+}
+
+func (f *StocktakeEventRepositoryInMemory) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		for _, v := range events {
+			latestVersion++
+
+			f.Storage.StocktakeEvents = append(f.Storage.StocktakeEvents, storage.StocktakeEvent{
+				StocktakeUID: uid,
+				Version:      latestVersion,
+				CreatedDate:  time.Now(),
+				Event:        v,
+				Actor:        by,
+			})
+		}
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,31 @@
+package inmemory
+
+import (
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type StocktakeReadRepositoryInMemory struct {
+	Storage *storage.StocktakeReadStorage
+}
+
+func NewStocktakeReadRepositoryInMemory(s *storage.StocktakeReadStorage) repository.StocktakeRead {
+	return &StocktakeReadRepositoryInMemory{Storage: s}
+}
+
+func (f *StocktakeReadRepositoryInMemory) Save(stocktakeRead *storage.StocktakeRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		f.Storage.StocktakeReadMap[stocktakeRead.UID] = *stocktakeRead
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"database/sql"
+	"encoding/json"
 
 	"github.com/usetania/tania-core/src/assets/repository"
 	"github.com/usetania/tania-core/src/assets/storage"
@@ -26,17 +27,44 @@ func (f *AreaReadRepositoryMysql) Save(areaRead *storage.AreaRead) <-chan error
 			result <- err
 		}
 
+		customFields, err := json.Marshal(areaRead.CustomFields)
+		if err != nil {
+			result <- err
+		}
+
+		layout, err := json.Marshal(areaRead.Layout)
+		if err != nil {
+			result <- err
+		}
+
+		bedMap, err := json.Marshal(areaRead.BedMap)
+		if err != nil {
+			result <- err
+		}
+
+		geoPoint, err := json.Marshal(areaRead.GeoPoint)
+		if err != nil {
+			result <- err
+		}
+
+		sanitations, err := json.Marshal(areaRead.Sanitations)
+		if err != nil {
+			result <- err
+		}
+
 		if count > 0 {
 			_, err := f.DB.Exec(`UPDATE AREA_READ SET
 				NAME = ?, SIZE_UNIT = ?, SIZE = ?, TYPE = ?, LOCATION = ?,
 				PHOTO_FILENAME = ?, PHOTO_MIMETYPE = ?, PHOTO_SIZE = ?, PHOTO_WIDTH = ?, PHOTO_HEIGHT = ?,
-				CREATED_DATE = ?, FARM_UID = ?, FARM_NAME = ?, RESERVOIR_UID = ?, RESERVOIR_NAME = ?
+				CREATED_DATE = ?, FARM_UID = ?, FARM_NAME = ?, RESERVOIR_UID = ?, RESERVOIR_NAME = ?, CUSTOM_FIELDS = ?,
+				LAYOUT = ?, BED_MAP = ?, GEO_POINT = ?, SANITATIONS = ?, LAST_SANITIZED_DATE = ?
 				WHERE UID = ?`,
 				areaRead.Name, areaRead.Size.Unit.Symbol, areaRead.Size.Value, areaRead.Type,
 				areaRead.Location.Code, areaRead.Photo.Filename, areaRead.Photo.MimeType,
 				areaRead.Photo.Size, areaRead.Photo.Width, areaRead.Photo.Height, areaRead.CreatedDate,
 				areaRead.Farm.UID.Bytes(), areaRead.Farm.Name, areaRead.Reservoir.UID.Bytes(),
-				areaRead.Reservoir.Name, areaRead.UID.Bytes(),
+				areaRead.Reservoir.Name, string(customFields), string(layout), string(bedMap), string(geoPoint),
+				string(sanitations), areaRead.LastSanitizedDate, areaRead.UID.Bytes(),
 			)
 			if err != nil {
 				result <- err
@@ -61,12 +89,15 @@ func (f *AreaReadRepositoryMysql) Save(areaRead *storage.AreaRead) <-chan error
 		} else {
 			_, err := f.DB.Exec(`INSERT INTO AREA_READ
 				(UID, NAME, SIZE_UNIT, SIZE, TYPE, LOCATION, PHOTO_FILENAME, PHOTO_MIMETYPE,
-				PHOTO_SIZE, PHOTO_WIDTH, PHOTO_HEIGHT, CREATED_DATE, FARM_UID, FARM_NAME, RESERVOIR_UID, RESERVOIR_NAME)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				PHOTO_SIZE, PHOTO_WIDTH, PHOTO_HEIGHT, CREATED_DATE, FARM_UID, FARM_NAME, RESERVOIR_UID, RESERVOIR_NAME,
+				CUSTOM_FIELDS, LAYOUT, BED_MAP, GEO_POINT, SANITATIONS, LAST_SANITIZED_DATE)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 				areaRead.UID.Bytes(), areaRead.Name, areaRead.Size.Unit.Symbol, areaRead.Size.Value, areaRead.Type,
 				areaRead.Location.Code, areaRead.Photo.Filename, areaRead.Photo.MimeType,
 				areaRead.Photo.Size, areaRead.Photo.Width, areaRead.Photo.Height, areaRead.CreatedDate,
-				areaRead.Farm.UID.Bytes(), areaRead.Farm.Name, areaRead.Reservoir.UID.Bytes(), areaRead.Reservoir.Name)
+				areaRead.Farm.UID.Bytes(), areaRead.Farm.Name, areaRead.Reservoir.UID.Bytes(), areaRead.Reservoir.Name,
+				string(customFields), string(layout), string(bedMap), string(geoPoint),
+				string(sanitations), areaRead.LastSanitizedDate)
 			if err != nil {
 				result <- err
 			}
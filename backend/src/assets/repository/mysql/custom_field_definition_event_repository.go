@@ -0,0 +1,59 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/decoder"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/helper/structhelper"
+)
+
+type CustomFieldDefinitionEventRepositoryMysql struct {
+	DB *sql.DB
+}
+
+func NewCustomFieldDefinitionEventRepositoryMysql(db *sql.DB) repository.CustomFieldDefinitionEvent {
+	return &CustomFieldDefinitionEventRepositoryMysql{DB: db}
+}
+
+func (f *CustomFieldDefinitionEventRepositoryMysql) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		for _, v := range events {
+			latestVersion++
+
+			stmt, err := f.DB.Prepare(`INSERT INTO CUSTOM_FIELD_DEFINITION_EVENT
+				(CUSTOM_FIELD_DEFINITION_UID, VERSION, CREATED_DATE, EVENT)
+				VALUES (?, ?, ?, ?)`)
+			if err != nil {
+				result <- err
+			}
+
+			e, err := json.Marshal(decoder.EventWrapper{
+				EventName: structhelper.GetName(v),
+				EventData: v,
+				Actor:     by,
+			})
+			if err != nil {
+				result <- err
+			}
+
+			_, err = stmt.Exec(uid.Bytes(), latestVersion, time.Now(), e)
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,65 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type CustomFieldDefinitionReadRepositoryMysql struct {
+	DB *sql.DB
+}
+
+func NewCustomFieldDefinitionReadRepositoryMysql(db *sql.DB) repository.CustomFieldDefinitionRead {
+	return &CustomFieldDefinitionReadRepositoryMysql{DB: db}
+}
+
+func (f *CustomFieldDefinitionReadRepositoryMysql) Save(definitionRead *storage.CustomFieldDefinitionRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		count := 0
+
+		options, err := json.Marshal(definitionRead.Options)
+		if err != nil {
+			result <- err
+		}
+
+		err = f.DB.QueryRow(`SELECT COUNT(*) FROM CUSTOM_FIELD_DEFINITION_READ WHERE UID = ?`,
+			definitionRead.UID.Bytes()).Scan(&count)
+		if err != nil {
+			result <- err
+		}
+
+		if count > 0 {
+			_, err = f.DB.Exec(`UPDATE CUSTOM_FIELD_DEFINITION_READ SET
+				FARM_UID = ?, ENTITY_TYPE = ?, FIELD_KEY = ?, FIELD_TYPE = ?, IS_REQUIRED = ?, IS_DELETED = ?, CREATED_DATE = ?,
+				LABEL = ?, OPTIONS = ?
+				WHERE UID = ?`,
+				definitionRead.FarmUID.Bytes(), definitionRead.EntityType, definitionRead.FieldKey, definitionRead.FieldType,
+				definitionRead.Required, definitionRead.IsDeleted, definitionRead.CreatedDate,
+				definitionRead.Label, string(options),
+				definitionRead.UID.Bytes())
+			if err != nil {
+				result <- err
+			}
+		} else {
+			_, err = f.DB.Exec(`INSERT INTO CUSTOM_FIELD_DEFINITION_READ
+				(UID, FARM_UID, ENTITY_TYPE, FIELD_KEY, FIELD_TYPE, IS_REQUIRED, IS_DELETED, CREATED_DATE, LABEL, OPTIONS)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				definitionRead.UID.Bytes(), definitionRead.FarmUID.Bytes(), definitionRead.EntityType, definitionRead.FieldKey,
+				definitionRead.FieldType, definitionRead.Required, definitionRead.IsDeleted,
+				definitionRead.CreatedDate, definitionRead.Label, string(options))
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type EquipmentReadRepositoryMysql struct {
+	DB *sql.DB
+}
+
+func NewEquipmentReadRepositoryMysql(db *sql.DB) repository.EquipmentRead {
+	return &EquipmentReadRepositoryMysql{DB: db}
+}
+
+func (f *EquipmentReadRepositoryMysql) Save(equipmentRead *storage.EquipmentRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		count := 0
+
+		err := f.DB.QueryRow(`SELECT COUNT(*) FROM EQUIPMENT_READ WHERE UID = ?`,
+			equipmentRead.UID.Bytes()).Scan(&count)
+		if err != nil {
+			result <- err
+		}
+
+		var locationType *string
+
+		var locationUID []byte
+
+		if equipmentRead.Location != nil {
+			locationType = &equipmentRead.Location.Type
+			locationUID = equipmentRead.Location.UID.Bytes()
+		}
+
+		geoPoint, err := json.Marshal(equipmentRead.GeoPoint)
+		if err != nil {
+			result <- err
+		}
+
+		if count > 0 {
+			_, err = f.DB.Exec(`UPDATE EQUIPMENT_READ SET
+				FARM_UID = ?, NAME = ?, TYPE = ?, LOCATION_TYPE = ?, LOCATION_UID = ?, PURCHASE_DATE = ?, NOTES = ?,
+				MAINTENANCE_INTERVAL_DAYS = ?, NEXT_MAINTENANCE_DATE = ?, GEO_POINT = ?, IS_DELETED = ?,
+				CREATED_DATE = ?
+				WHERE UID = ?`,
+				equipmentRead.FarmUID.Bytes(), equipmentRead.Name, equipmentRead.Type, locationType, locationUID,
+				equipmentRead.PurchaseDate, equipmentRead.Notes, equipmentRead.MaintenanceIntervalDays,
+				equipmentRead.NextMaintenanceDate, string(geoPoint), equipmentRead.IsDeleted, equipmentRead.CreatedDate,
+				equipmentRead.UID.Bytes())
+			if err != nil {
+				result <- err
+			}
+		} else {
+			_, err = f.DB.Exec(`INSERT INTO EQUIPMENT_READ
+				(UID, FARM_UID, NAME, TYPE, LOCATION_TYPE, LOCATION_UID, PURCHASE_DATE, NOTES,
+				MAINTENANCE_INTERVAL_DAYS, NEXT_MAINTENANCE_DATE, GEO_POINT, IS_DELETED, CREATED_DATE)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				equipmentRead.UID.Bytes(), equipmentRead.FarmUID.Bytes(), equipmentRead.Name, equipmentRead.Type,
+				locationType, locationUID, equipmentRead.PurchaseDate, equipmentRead.Notes,
+				equipmentRead.MaintenanceIntervalDays, equipmentRead.NextMaintenanceDate, string(geoPoint),
+				equipmentRead.IsDeleted, equipmentRead.CreatedDate)
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
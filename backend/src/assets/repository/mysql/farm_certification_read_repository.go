@@ -0,0 +1,62 @@
+package mysql
+
+import (
+	"database/sql"
+
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type FarmCertificationReadRepositoryMysql struct {
+	DB *sql.DB
+}
+
+func NewFarmCertificationReadRepositoryMysql(db *sql.DB) repository.FarmCertificationRead {
+	return &FarmCertificationReadRepositoryMysql{DB: db}
+}
+
+func (f *FarmCertificationReadRepositoryMysql) Save(certificationRead *storage.FarmCertificationRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		count := 0
+
+		err := f.DB.QueryRow(`SELECT COUNT(*) FROM FARM_CERTIFICATION_READ WHERE UID = ?`,
+			certificationRead.UID.Bytes()).Scan(&count)
+		if err != nil {
+			result <- err
+		}
+
+		if count > 0 {
+			_, err = f.DB.Exec(`UPDATE FARM_CERTIFICATION_READ SET
+				FARM_UID = ?, CERTIFICATION_TYPE = ?, CERTIFYING_BODY = ?, ISSUED_DATE = ?, EXPIRY_DATE = ?,
+				CERTIFICATE_NUMBER = ?, STATUS = ?, IS_RENEWAL_DUE = ?, REVOKED_DATE = ?, CREATED_DATE = ?
+				WHERE UID = ?`,
+				certificationRead.FarmUID.Bytes(), certificationRead.CertificationType, certificationRead.CertifyingBody,
+				certificationRead.IssuedDate, certificationRead.ExpiryDate, certificationRead.CertificateNumber,
+				certificationRead.Status, certificationRead.IsRenewalDue, certificationRead.RevokedDate,
+				certificationRead.CreatedDate,
+				certificationRead.UID.Bytes())
+			if err != nil {
+				result <- err
+			}
+		} else {
+			_, err = f.DB.Exec(`INSERT INTO FARM_CERTIFICATION_READ
+				(UID, FARM_UID, CERTIFICATION_TYPE, CERTIFYING_BODY, ISSUED_DATE, EXPIRY_DATE,
+				CERTIFICATE_NUMBER, STATUS, IS_RENEWAL_DUE, REVOKED_DATE, CREATED_DATE)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				certificationRead.UID.Bytes(), certificationRead.FarmUID.Bytes(), certificationRead.CertificationType,
+				certificationRead.CertifyingBody, certificationRead.IssuedDate, certificationRead.ExpiryDate,
+				certificationRead.CertificateNumber, certificationRead.Status, certificationRead.IsRenewalDue,
+				certificationRead.RevokedDate, certificationRead.CreatedDate)
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/assets/decoder"
 	"github.com/usetania/tania-core/src/assets/domain"
 	"github.com/usetania/tania-core/src/assets/repository"
@@ -20,7 +21,9 @@ func NewMaterialEventRepositoryMysql(db *sql.DB) repository.MaterialEvent {
 	return &MaterialEventRepositoryMysql{DB: db}
 }
 
-func (f *MaterialEventRepositoryMysql) Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error {
+func (f *MaterialEventRepositoryMysql) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
 	result := make(chan error)
 
 	go func() {
@@ -59,6 +62,7 @@ func (f *MaterialEventRepositoryMysql) Save(uid uuid.UUID, latestVersion int, ev
 			e, err := json.Marshal(decoder.EventWrapper{
 				EventName: structhelper.GetName(eTemp),
 				EventData: eTemp,
+				Actor:     by,
 			})
 			if err != nil {
 				result <- err
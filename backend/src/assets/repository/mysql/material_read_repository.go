@@ -49,7 +49,7 @@ func (f *MaterialReadRepositoryMysql) Save(materialRead *storage.MaterialRead) <
 			_, err = f.DB.Exec(`UPDATE MATERIAL_READ SET
 				NAME = ?, PRICE_PER_UNIT = ?, CURRENCY_CODE = ?, TYPE = ?, TYPE_DATA = ?,
 				QUANTITY = ?, QUANTITY_UNIT = ?, EXPIRATION_DATE = ?, NOTES = ?,
-				PRODUCED_BY = ?, CREATED_DATE = ?
+				PRODUCED_BY = ?, CREATED_DATE = ?, GDD_TO_MATURITY = ?, PRE_HARVEST_INTERVAL_DAYS = ?
 				WHERE UID = ?`,
 				materialRead.Name,
 				materialRead.PricePerUnit.Amount,
@@ -62,6 +62,8 @@ func (f *MaterialReadRepositoryMysql) Save(materialRead *storage.MaterialRead) <
 				materialRead.Notes,
 				materialRead.ProducedBy,
 				materialRead.CreatedDate,
+				materialRead.GDDToMaturity,
+				materialRead.PreHarvestIntervalDays,
 				materialRead.UID.Bytes())
 
 			if err != nil {
@@ -70,8 +72,9 @@ func (f *MaterialReadRepositoryMysql) Save(materialRead *storage.MaterialRead) <
 		} else {
 			_, err = f.DB.Exec(`INSERT INTO MATERIAL_READ
 				(UID, NAME, PRICE_PER_UNIT, CURRENCY_CODE, TYPE, TYPE_DATA, QUANTITY,
-				QUANTITY_UNIT, EXPIRATION_DATE, NOTES, PRODUCED_BY, CREATED_DATE)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				QUANTITY_UNIT, EXPIRATION_DATE, NOTES, PRODUCED_BY, CREATED_DATE, GDD_TO_MATURITY,
+				PRE_HARVEST_INTERVAL_DAYS)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 				materialRead.UID.Bytes(),
 				materialRead.Name,
 				materialRead.PricePerUnit.Amount,
@@ -83,7 +86,9 @@ func (f *MaterialReadRepositoryMysql) Save(materialRead *storage.MaterialRead) <
 				expirationDate,
 				materialRead.Notes,
 				materialRead.ProducedBy,
-				materialRead.CreatedDate)
+				materialRead.CreatedDate,
+				materialRead.GDDToMaturity,
+				materialRead.PreHarvestIntervalDays)
 
 			if err != nil {
 				result <- err
@@ -0,0 +1,62 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type NutrientRecipeReadRepositoryMysql struct {
+	DB *sql.DB
+}
+
+func NewNutrientRecipeReadRepositoryMysql(db *sql.DB) repository.NutrientRecipeRead {
+	return &NutrientRecipeReadRepositoryMysql{DB: db}
+}
+
+func (f *NutrientRecipeReadRepositoryMysql) Save(recipeRead *storage.NutrientRecipeRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		count := 0
+
+		ingredients, err := json.Marshal(recipeRead.Ingredients)
+		if err != nil {
+			result <- err
+		}
+
+		err = f.DB.QueryRow(`SELECT COUNT(*) FROM NUTRIENT_RECIPE_READ WHERE UID = ?`,
+			recipeRead.UID.Bytes()).Scan(&count)
+		if err != nil {
+			result <- err
+		}
+
+		if count > 0 {
+			_, err = f.DB.Exec(`UPDATE NUTRIENT_RECIPE_READ SET
+				FARM_UID = ?, NAME = ?, TARGET_EC = ?, TARGET_PH = ?, INGREDIENTS = ?, IS_DELETED = ?, CREATED_DATE = ?
+				WHERE UID = ?`,
+				recipeRead.FarmUID.Bytes(), recipeRead.Name, recipeRead.TargetEC, recipeRead.TargetPH, string(ingredients),
+				recipeRead.IsDeleted, recipeRead.CreatedDate,
+				recipeRead.UID.Bytes())
+			if err != nil {
+				result <- err
+			}
+		} else {
+			_, err = f.DB.Exec(`INSERT INTO NUTRIENT_RECIPE_READ
+				(UID, FARM_UID, NAME, TARGET_EC, TARGET_PH, INGREDIENTS, IS_DELETED, CREATED_DATE)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+				recipeRead.UID.Bytes(), recipeRead.FarmUID.Bytes(), recipeRead.Name, recipeRead.TargetEC,
+				recipeRead.TargetPH, string(ingredients), recipeRead.IsDeleted, recipeRead.CreatedDate)
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
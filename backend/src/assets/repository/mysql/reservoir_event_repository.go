@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/assets/decoder"
 	"github.com/usetania/tania-core/src/assets/repository"
 	"github.com/usetania/tania-core/src/helper/structhelper"
@@ -19,7 +20,9 @@ func NewReservoirEventRepositoryMysql(db *sql.DB) repository.ReservoirEvent {
 	return &ReservoirEventRepositoryMysql{DB: db}
 }
 
-func (f *ReservoirEventRepositoryMysql) Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error {
+func (f *ReservoirEventRepositoryMysql) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
 	result := make(chan error)
 
 	go func() {
@@ -36,6 +39,7 @@ func (f *ReservoirEventRepositoryMysql) Save(uid uuid.UUID, latestVersion int, e
 			e, err := json.Marshal(decoder.EventWrapper{
 				EventName: structhelper.GetName(v),
 				EventData: v,
+				Actor:     by,
 			})
 			if err != nil {
 				panic(err)
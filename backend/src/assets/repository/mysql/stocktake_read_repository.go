@@ -0,0 +1,61 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type StocktakeReadRepositoryMysql struct {
+	DB *sql.DB
+}
+
+func NewStocktakeReadRepositoryMysql(db *sql.DB) repository.StocktakeRead {
+	return &StocktakeReadRepositoryMysql{DB: db}
+}
+
+func (f *StocktakeReadRepositoryMysql) Save(stocktakeRead *storage.StocktakeRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		count := 0
+
+		err := f.DB.QueryRow(`SELECT COUNT(*) FROM STOCKTAKE_READ WHERE UID = ?`, stocktakeRead.UID.Bytes()).Scan(&count)
+		if err != nil {
+			result <- err
+		}
+
+		lines, err := json.Marshal(stocktakeRead.Lines)
+		if err != nil {
+			result <- err
+		}
+
+		if count > 0 {
+			_, err = f.DB.Exec(`UPDATE STOCKTAKE_READ SET
+				FARM_UID = ?, STATUS = ?, LINES = ?, OPENED_DATE = ?, CLOSED_DATE = ?
+				WHERE UID = ?`,
+				stocktakeRead.FarmUID.Bytes(), stocktakeRead.Status, lines,
+				stocktakeRead.OpenedDate, stocktakeRead.ClosedDate,
+				stocktakeRead.UID.Bytes())
+			if err != nil {
+				result <- err
+			}
+		} else {
+			_, err = f.DB.Exec(`INSERT INTO STOCKTAKE_READ
+				(UID, FARM_UID, STATUS, LINES, OPENED_DATE, CLOSED_DATE)
+				VALUES (?, ?, ?, ?, ?, ?)`,
+				stocktakeRead.UID.Bytes(), stocktakeRead.FarmUID.Bytes(), stocktakeRead.Status, lines,
+				stocktakeRead.OpenedDate, stocktakeRead.ClosedDate)
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,57 @@
+package redis
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/decoder"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/helper/structhelper"
+	redisstorage "github.com/usetania/tania-core/src/storage/redis"
+)
+
+type FarmEventRepositoryRedis struct {
+	Storage *redisstorage.RedisStreamsEventStorage
+}
+
+func NewFarmEventRepositoryRedis(s *redisstorage.RedisStreamsEventStorage) repository.FarmEvent {
+	return &FarmEventRepositoryRedis{Storage: s}
+}
+
+func (f *FarmEventRepositoryRedis) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		streamEvents := []redisstorage.StreamEvent{}
+		now := time.Now()
+
+		for i, v := range events {
+			e, err := json.Marshal(decoder.EventWrapper{
+				EventName: structhelper.GetName(v),
+				EventData: v,
+				Actor:     by,
+			})
+			if err != nil {
+				result <- err
+				close(result)
+
+				return
+			}
+
+			streamEvents = append(streamEvents, redisstorage.StreamEvent{
+				Version:     latestVersion + i + 1,
+				CreatedDate: now,
+				Event:       e,
+			})
+		}
+
+		result <- f.Storage.Append(redisstorage.AggregateFarm, uid, latestVersion, streamEvents)
+		close(result)
+	}()
+
+	return result
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/assets/domain"
 	"github.com/usetania/tania-core/src/assets/storage"
 )
@@ -14,7 +15,7 @@ type Result struct {
 }
 
 type FarmEvent interface {
-	Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
 }
 
 type FarmRead interface {
@@ -32,7 +33,7 @@ func NewFarmFromHistory(events []storage.FarmEvent) *domain.Farm {
 }
 
 type AreaEvent interface {
-	Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
 }
 
 type AreaRead interface {
@@ -50,7 +51,7 @@ func NewAreaFromHistory(events []storage.AreaEvent) *domain.Area {
 }
 
 type ReservoirEvent interface {
-	Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
 }
 
 type ReservoirRead interface {
@@ -68,7 +69,7 @@ func NewReservoirFromHistory(events []storage.ReservoirEvent) *domain.Reservoir
 }
 
 type MaterialEvent interface {
-	Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
 }
 
 func NewMaterialFromHistory(events []storage.MaterialEvent) *domain.Material {
@@ -93,3 +94,107 @@ func (w MaterialEventTypeWrapper) Code() string {
 type MaterialRead interface {
 	Save(materialRead *storage.MaterialRead) <-chan error
 }
+
+type FarmCertificationEvent interface {
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
+}
+
+type FarmCertificationRead interface {
+	Save(certificationRead *storage.FarmCertificationRead) <-chan error
+}
+
+func NewFarmCertificationFromHistory(events []storage.FarmCertificationEvent) *domain.FarmCertification {
+	state := &domain.FarmCertification{}
+	for _, v := range events {
+		state.Transition(v.Event)
+		state.Version++
+	}
+
+	return state
+}
+
+type CustomFieldDefinitionEvent interface {
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
+}
+
+type CustomFieldDefinitionRead interface {
+	Save(definitionRead *storage.CustomFieldDefinitionRead) <-chan error
+}
+
+func NewCustomFieldDefinitionFromHistory(events []storage.CustomFieldDefinitionEvent) *domain.CustomFieldDefinition {
+	state := &domain.CustomFieldDefinition{}
+	for _, v := range events {
+		state.Transition(v.Event)
+		state.Version++
+	}
+
+	return state
+}
+
+type StocktakeEvent interface {
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
+}
+
+type StocktakeRead interface {
+	Save(stocktakeRead *storage.StocktakeRead) <-chan error
+}
+
+func NewStocktakeFromHistory(events []storage.StocktakeEvent) *domain.Stocktake {
+	state := &domain.Stocktake{}
+	for _, v := range events {
+		state.Transition(v.Event)
+		state.Version++
+	}
+
+	return state
+}
+
+type EquipmentEvent interface {
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
+}
+
+type EquipmentRead interface {
+	Save(equipmentRead *storage.EquipmentRead) <-chan error
+}
+
+func NewEquipmentFromHistory(events []storage.EquipmentEvent) *domain.Equipment {
+	state := &domain.Equipment{}
+	for _, v := range events {
+		state.Transition(v.Event)
+		state.Version++
+	}
+
+	return state
+}
+
+type NutrientRecipeEvent interface {
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
+}
+
+type NutrientRecipeRead interface {
+	Save(recipeRead *storage.NutrientRecipeRead) <-chan error
+}
+
+func NewNutrientRecipeFromHistory(events []storage.NutrientRecipeEvent) *domain.NutrientRecipe {
+	state := &domain.NutrientRecipe{}
+	for _, v := range events {
+		state.Transition(v.Event)
+		state.Version++
+	}
+
+	return state
+}
+
+type FarmBoundaryEvent interface {
+	Save(farmUID uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
+}
+
+func NewFarmBoundaryFromHistory(events []storage.FarmBoundaryEvent) *domain.FarmBoundary {
+	state := &domain.FarmBoundary{}
+	for _, v := range events {
+		state.Transition(v.Event)
+		state.Version++
+	}
+
+	return state
+}
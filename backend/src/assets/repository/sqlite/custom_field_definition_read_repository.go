@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type CustomFieldDefinitionReadRepositorySqlite struct {
+	DB *sql.DB
+}
+
+func NewCustomFieldDefinitionReadRepositorySqlite(db *sql.DB) repository.CustomFieldDefinitionRead {
+	return &CustomFieldDefinitionReadRepositorySqlite{DB: db}
+}
+
+func (f *CustomFieldDefinitionReadRepositorySqlite) Save(definitionRead *storage.CustomFieldDefinitionRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		count := 0
+
+		options, err := json.Marshal(definitionRead.Options)
+		if err != nil {
+			result <- err
+		}
+
+		err = f.DB.QueryRow(`SELECT COUNT(*) FROM CUSTOM_FIELD_DEFINITION_READ WHERE UID = ?`,
+			definitionRead.UID).Scan(&count)
+		if err != nil {
+			result <- err
+		}
+
+		if count > 0 {
+			_, err = f.DB.Exec(`UPDATE CUSTOM_FIELD_DEFINITION_READ SET
+				FARM_UID = ?, ENTITY_TYPE = ?, FIELD_KEY = ?, FIELD_TYPE = ?, IS_REQUIRED = ?, IS_DELETED = ?, CREATED_DATE = ?,
+				LABEL = ?, OPTIONS = ?
+				WHERE UID = ?`,
+				definitionRead.FarmUID, definitionRead.EntityType, definitionRead.FieldKey, definitionRead.FieldType,
+				definitionRead.Required, definitionRead.IsDeleted, definitionRead.CreatedDate.Format(time.RFC3339),
+				definitionRead.Label, string(options),
+				definitionRead.UID)
+			if err != nil {
+				result <- err
+			}
+		} else {
+			_, err = f.DB.Exec(`INSERT INTO CUSTOM_FIELD_DEFINITION_READ
+				(UID, FARM_UID, ENTITY_TYPE, FIELD_KEY, FIELD_TYPE, IS_REQUIRED, IS_DELETED, CREATED_DATE, LABEL, OPTIONS)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				definitionRead.UID, definitionRead.FarmUID, definitionRead.EntityType, definitionRead.FieldKey,
+				definitionRead.FieldType, definitionRead.Required, definitionRead.IsDeleted,
+				definitionRead.CreatedDate.Format(time.RFC3339), definitionRead.Label, string(options))
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
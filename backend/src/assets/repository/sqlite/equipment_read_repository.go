@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type EquipmentReadRepositorySqlite struct {
+	DB *sql.DB
+}
+
+func NewEquipmentReadRepositorySqlite(db *sql.DB) repository.EquipmentRead {
+	return &EquipmentReadRepositorySqlite{DB: db}
+}
+
+func (f *EquipmentReadRepositorySqlite) Save(equipmentRead *storage.EquipmentRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		count := 0
+
+		err := f.DB.QueryRow(`SELECT COUNT(*) FROM EQUIPMENT_READ WHERE UID = ?`, equipmentRead.UID).Scan(&count)
+		if err != nil {
+			result <- err
+		}
+
+		var locationType *string
+
+		var locationUID *uuid.UUID
+
+		if equipmentRead.Location != nil {
+			locationType = &equipmentRead.Location.Type
+			locationUID = &equipmentRead.Location.UID
+		}
+
+		var purchaseDate *string
+
+		if equipmentRead.PurchaseDate != nil {
+			d := equipmentRead.PurchaseDate.Format(time.RFC3339)
+			purchaseDate = &d
+		}
+
+		var nextMaintenanceDate *string
+
+		if equipmentRead.NextMaintenanceDate != nil {
+			d := equipmentRead.NextMaintenanceDate.Format(time.RFC3339)
+			nextMaintenanceDate = &d
+		}
+
+		geoPoint, err := json.Marshal(equipmentRead.GeoPoint)
+		if err != nil {
+			result <- err
+		}
+
+		if count > 0 {
+			_, err = f.DB.Exec(`UPDATE EQUIPMENT_READ SET
+				FARM_UID = ?, NAME = ?, TYPE = ?, LOCATION_TYPE = ?, LOCATION_UID = ?, PURCHASE_DATE = ?, NOTES = ?,
+				MAINTENANCE_INTERVAL_DAYS = ?, NEXT_MAINTENANCE_DATE = ?, GEO_POINT = ?, IS_DELETED = ?,
+				CREATED_DATE = ?
+				WHERE UID = ?`,
+				equipmentRead.FarmUID, equipmentRead.Name, equipmentRead.Type, locationType, locationUID,
+				purchaseDate, equipmentRead.Notes, equipmentRead.MaintenanceIntervalDays, nextMaintenanceDate,
+				string(geoPoint), equipmentRead.IsDeleted, equipmentRead.CreatedDate.Format(time.RFC3339),
+				equipmentRead.UID)
+			if err != nil {
+				result <- err
+			}
+		} else {
+			_, err = f.DB.Exec(`INSERT INTO EQUIPMENT_READ
+				(UID, FARM_UID, NAME, TYPE, LOCATION_TYPE, LOCATION_UID, PURCHASE_DATE, NOTES,
+				MAINTENANCE_INTERVAL_DAYS, NEXT_MAINTENANCE_DATE, GEO_POINT, IS_DELETED, CREATED_DATE)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				equipmentRead.UID, equipmentRead.FarmUID, equipmentRead.Name, equipmentRead.Type, locationType,
+				locationUID, purchaseDate, equipmentRead.Notes, equipmentRead.MaintenanceIntervalDays,
+				nextMaintenanceDate, string(geoPoint), equipmentRead.IsDeleted, equipmentRead.CreatedDate.Format(time.RFC3339))
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type FarmCertificationReadRepositorySqlite struct {
+	DB *sql.DB
+}
+
+func NewFarmCertificationReadRepositorySqlite(db *sql.DB) repository.FarmCertificationRead {
+	return &FarmCertificationReadRepositorySqlite{DB: db}
+}
+
+func (f *FarmCertificationReadRepositorySqlite) Save(certificationRead *storage.FarmCertificationRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		count := 0
+
+		err := f.DB.QueryRow(`SELECT COUNT(*) FROM FARM_CERTIFICATION_READ WHERE UID = ?`,
+			certificationRead.UID).Scan(&count)
+		if err != nil {
+			result <- err
+		}
+
+		var revokedDate string
+		if certificationRead.RevokedDate != nil {
+			revokedDate = certificationRead.RevokedDate.Format(time.RFC3339)
+		}
+
+		if count > 0 {
+			_, err = f.DB.Exec(`UPDATE FARM_CERTIFICATION_READ SET
+				FARM_UID = ?, CERTIFICATION_TYPE = ?, CERTIFYING_BODY = ?, ISSUED_DATE = ?, EXPIRY_DATE = ?,
+				CERTIFICATE_NUMBER = ?, STATUS = ?, IS_RENEWAL_DUE = ?, REVOKED_DATE = ?, CREATED_DATE = ?
+				WHERE UID = ?`,
+				certificationRead.FarmUID, certificationRead.CertificationType, certificationRead.CertifyingBody,
+				certificationRead.IssuedDate.Format(time.RFC3339), certificationRead.ExpiryDate.Format(time.RFC3339),
+				certificationRead.CertificateNumber, certificationRead.Status, certificationRead.IsRenewalDue,
+				revokedDate, certificationRead.CreatedDate.Format(time.RFC3339),
+				certificationRead.UID)
+			if err != nil {
+				result <- err
+			}
+		} else {
+			_, err = f.DB.Exec(`INSERT INTO FARM_CERTIFICATION_READ
+				(UID, FARM_UID, CERTIFICATION_TYPE, CERTIFYING_BODY, ISSUED_DATE, EXPIRY_DATE,
+				CERTIFICATE_NUMBER, STATUS, IS_RENEWAL_DUE, REVOKED_DATE, CREATED_DATE)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				certificationRead.UID, certificationRead.FarmUID, certificationRead.CertificationType,
+				certificationRead.CertifyingBody, certificationRead.IssuedDate.Format(time.RFC3339),
+				certificationRead.ExpiryDate.Format(time.RFC3339), certificationRead.CertificateNumber,
+				certificationRead.Status, certificationRead.IsRenewalDue, revokedDate,
+				certificationRead.CreatedDate.Format(time.RFC3339))
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/assets/decoder"
 	"github.com/usetania/tania-core/src/assets/repository"
 	"github.com/usetania/tania-core/src/helper/structhelper"
@@ -19,7 +20,9 @@ func NewFarmEventRepositorySqlite(db *sql.DB) repository.FarmEvent {
 	return &FarmEventRepositorySqlite{DB: db}
 }
 
-func (f *FarmEventRepositorySqlite) Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error {
+func (f *FarmEventRepositorySqlite) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
 	result := make(chan error)
 
 	go func() {
@@ -34,6 +37,7 @@ func (f *FarmEventRepositorySqlite) Save(uid uuid.UUID, latestVersion int, event
 			e, err := json.Marshal(decoder.EventWrapper{
 				EventName: structhelper.GetName(v),
 				EventData: v,
+				Actor:     by,
 			})
 			if err != nil {
 				result <- err
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/usetania/tania-core/src/assets/repository"
@@ -27,23 +28,43 @@ func (f *FarmReadRepositorySqlite) Save(farmRead *storage.FarmRead) <-chan error
 			result <- err
 		}
 
+		areaWalkOrder, err := json.Marshal(farmRead.AreaWalkOrder)
+		if err != nil {
+			result <- err
+		}
+
+		harvestGrades, err := json.Marshal(farmRead.HarvestGrades)
+		if err != nil {
+			result <- err
+		}
+
+		seasons, err := json.Marshal(farmRead.Seasons)
+		if err != nil {
+			result <- err
+		}
+
 		if count > 0 {
 			_, err := f.DB.Exec(`UPDATE FARM_READ SET
 				NAME = ?, LATITUDE = ?, LONGITUDE = ?, TYPE = ?, COUNTRY = ?, CITY = ?,
-				IS_ACTIVE = ?, CREATED_DATE = ?
+				IS_ACTIVE = ?, CREATED_DATE = ?, AREA_WALK_ORDER = ?, HARVEST_GRADES = ?,
+				SEASONS = ?, WITHHOLDING_POLICY = ?, DAILY_EFFORT_BUDGET_MINUTES = ?
 				WHERE UID = ?`,
 				farmRead.Name, farmRead.Latitude, farmRead.Longitude, farmRead.Type,
 				farmRead.Country, farmRead.City, farmRead.IsActive, farmRead.CreatedDate.Format(time.RFC3339),
-				farmRead.UID)
+				string(areaWalkOrder), string(harvestGrades), string(seasons), farmRead.WithholdingPolicy,
+				farmRead.DailyEffortBudgetMinutes, farmRead.UID)
 			if err != nil {
 				result <- err
 			}
 		} else {
 			_, err := f.DB.Exec(`INSERT INTO FARM_READ
-				(UID, NAME, LATITUDE, LONGITUDE, TYPE, COUNTRY, CITY, IS_ACTIVE, CREATED_DATE)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				(UID, NAME, LATITUDE, LONGITUDE, TYPE, COUNTRY, CITY, IS_ACTIVE, CREATED_DATE, AREA_WALK_ORDER,
+				HARVEST_GRADES, SEASONS, WITHHOLDING_POLICY, DAILY_EFFORT_BUDGET_MINUTES)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 				farmRead.UID, farmRead.Name, farmRead.Latitude, farmRead.Longitude, farmRead.Type,
-				farmRead.Country, farmRead.City, farmRead.IsActive, farmRead.CreatedDate.Format(time.RFC3339))
+				farmRead.Country, farmRead.City, farmRead.IsActive, farmRead.CreatedDate.Format(time.RFC3339),
+				string(areaWalkOrder), string(harvestGrades), string(seasons), farmRead.WithholdingPolicy,
+				farmRead.DailyEffortBudgetMinutes)
 			if err != nil {
 				result <- err
 			}
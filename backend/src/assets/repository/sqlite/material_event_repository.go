@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/assets/decoder"
 	"github.com/usetania/tania-core/src/assets/domain"
 	"github.com/usetania/tania-core/src/assets/repository"
@@ -20,7 +21,9 @@ func NewMaterialEventRepositorySqlite(db *sql.DB) repository.MaterialEvent {
 	return &MaterialEventRepositorySqlite{DB: db}
 }
 
-func (f *MaterialEventRepositorySqlite) Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error {
+func (f *MaterialEventRepositorySqlite) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
 	result := make(chan error)
 
 	go func() {
@@ -59,6 +62,7 @@ func (f *MaterialEventRepositorySqlite) Save(uid uuid.UUID, latestVersion int, e
 			e, err := json.Marshal(decoder.EventWrapper{
 				EventName: structhelper.GetName(eTemp),
 				EventData: eTemp,
+				Actor:     by,
 			})
 			if err != nil {
 				result <- err
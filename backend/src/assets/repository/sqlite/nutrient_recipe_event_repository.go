@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/decoder"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/helper/structhelper"
+)
+
+type NutrientRecipeEventRepositorySqlite struct {
+	DB *sql.DB
+}
+
+func NewNutrientRecipeEventRepositorySqlite(db *sql.DB) repository.NutrientRecipeEvent {
+	return &NutrientRecipeEventRepositorySqlite{DB: db}
+}
+
+func (f *NutrientRecipeEventRepositorySqlite) Save(
+	uid uuid.UUID,
+	latestVersion int,
+	events []interface{},
+	by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		for _, v := range events {
+			latestVersion++
+
+			stmt, err := f.DB.Prepare(`INSERT INTO NUTRIENT_RECIPE_EVENT
+				(NUTRIENT_RECIPE_UID, VERSION, CREATED_DATE, EVENT)
+				VALUES (?, ?, ?, ?)`)
+			if err != nil {
+				result <- err
+			}
+
+			e, err := json.Marshal(decoder.EventWrapper{
+				EventName: structhelper.GetName(v),
+				EventData: v,
+				Actor:     by,
+			})
+			if err != nil {
+				result <- err
+			}
+
+			_, err = stmt.Exec(uid, latestVersion, time.Now().Format(time.RFC3339), e)
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
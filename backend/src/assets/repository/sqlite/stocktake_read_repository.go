@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type StocktakeReadRepositorySqlite struct {
+	DB *sql.DB
+}
+
+func NewStocktakeReadRepositorySqlite(db *sql.DB) repository.StocktakeRead {
+	return &StocktakeReadRepositorySqlite{DB: db}
+}
+
+func (f *StocktakeReadRepositorySqlite) Save(stocktakeRead *storage.StocktakeRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		count := 0
+
+		err := f.DB.QueryRow(`SELECT COUNT(*) FROM STOCKTAKE_READ WHERE UID = ?`, stocktakeRead.UID).Scan(&count)
+		if err != nil {
+			result <- err
+		}
+
+		lines, err := json.Marshal(stocktakeRead.Lines)
+		if err != nil {
+			result <- err
+		}
+
+		var closedDate *string
+		if stocktakeRead.ClosedDate != nil {
+			d := stocktakeRead.ClosedDate.Format(time.RFC3339)
+			closedDate = &d
+		}
+
+		if count > 0 {
+			_, err = f.DB.Exec(`UPDATE STOCKTAKE_READ SET
+				FARM_UID = ?, STATUS = ?, LINES = ?, OPENED_DATE = ?, CLOSED_DATE = ?
+				WHERE UID = ?`,
+				stocktakeRead.FarmUID, stocktakeRead.Status, string(lines),
+				stocktakeRead.OpenedDate.Format(time.RFC3339), closedDate,
+				stocktakeRead.UID)
+			if err != nil {
+				result <- err
+			}
+		} else {
+			_, err = f.DB.Exec(`INSERT INTO STOCKTAKE_READ
+				(UID, FARM_UID, STATUS, LINES, OPENED_DATE, CLOSED_DATE)
+				VALUES (?, ?, ?, ?, ?, ?)`,
+				stocktakeRead.UID, stocktakeRead.FarmUID, stocktakeRead.Status, string(lines),
+				stocktakeRead.OpenedDate.Format(time.RFC3339), closedDate)
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,259 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+// BedMapView is the bed map of an area with the crops placed in its cells.
+type BedMapView struct {
+	storage.BedMap
+	Crops []BedMapCrop `json:"crops"`
+}
+
+type BedMapCrop struct {
+	UID         uuid.UUID `json:"uid"`
+	BatchID     string    `json:"batch_id"`
+	VarietyName string    `json:"variety_name"`
+}
+
+// BedCellTakenError is returned with 409 with the crop already in the cell.
+type BedCellTakenError struct {
+	RequestValidationError
+	CropUID uuid.UUID `json:"crop_id"`
+}
+
+// GetAreaBedMap returns the bed map of the area, with no rows nor columns when it has none.
+func (s *FarmServer) GetAreaBedMap(c echo.Context) error {
+	areaRead, err := s.findAreaRead(c.Param("area_id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	view, err := s.mapToBedMapView(areaRead.UID, areaRead.BedMap)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]BedMapView)
+	data["data"] = view
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// ResizeAreaBedMap sets the rows and columns of the bed map of the area.
+func (s *FarmServer) ResizeAreaBedMap(c echo.Context) error {
+	rows, err := strconv.Atoi(c.FormValue("rows"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(Numeric, "rows"))
+	}
+
+	columns, err := strconv.Atoi(c.FormValue("columns"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(Numeric, "columns"))
+	}
+
+	area, err := s.findAreaFromHistory(c.Param("area_id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	err = area.ResizeBedMap(rows, columns)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	return s.saveAreaBedMap(c, area)
+}
+
+// PlaceAreaBedCrop assigns the crop_id crop of the area to the cell of the row and column params, from 0.
+// The cell is occupied unless the status param is RESERVED.
+func (s *FarmServer) PlaceAreaBedCrop(c echo.Context) error {
+	cropUID, err := uuid.FromString(c.FormValue("crop_id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(ParseFailed, "crop_id"))
+	}
+
+	row, err := strconv.Atoi(c.FormValue("row"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(Numeric, "row"))
+	}
+
+	column, err := strconv.Atoi(c.FormValue("col"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(Numeric, "col"))
+	}
+
+	status := domain.BedCellOccupied
+	if value := c.FormValue("status"); value != "" {
+		status = domain.BedCellStatus(strings.ToUpper(value))
+	}
+
+	area, err := s.findAreaFromHistory(c.Param("area_id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	crops, err := s.findAreaCrops(area.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if _, ok := crops[cropUID]; !ok {
+		return Error(c, NewRequestValidationError(NotFound, "crop_id"))
+	}
+
+	if area.BedMap != nil {
+		if current := area.BedMap.CropAt(row, column); current != nil && *current != cropUID {
+			return Error(c, BedCellTakenError{
+				RequestValidationError: NewRequestValidationError(BedCellTaken, "crop_id"),
+				CropUID:                *current,
+			})
+		}
+	}
+
+	// PROCESS //
+	err = area.PlaceCrop(cropUID, row, column, status)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	return s.saveAreaBedMap(c, area)
+}
+
+func (s *FarmServer) saveAreaBedMap(c echo.Context, area *domain.Area) error {
+	// PERSIST //
+	err := <-s.AreaEventRepo.Save(area.UID, area.Version, area.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(area)
+
+	view, err := s.mapToBedMapView(area.UID, mapToBedMap(area.BedMap))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]BedMapView)
+	data["data"] = view
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *FarmServer) mapToBedMapView(areaUID uuid.UUID, bedMap *storage.BedMap) (BedMapView, error) {
+	view := BedMapView{Crops: []BedMapCrop{}}
+	if bedMap == nil {
+		view.Cells = [][]domain.BedCellStatus{}
+		view.CropUIDs = [][]*uuid.UUID{}
+
+		return view, nil
+	}
+
+	view.BedMap = *bedMap
+
+	crops, err := s.findAreaCrops(areaUID)
+	if err != nil {
+		return BedMapView{}, err
+	}
+
+	placed := map[uuid.UUID]bool{}
+
+	for _, row := range bedMap.CropUIDs {
+		for _, cropUID := range row {
+			if cropUID == nil || placed[*cropUID] {
+				continue
+			}
+
+			placed[*cropUID] = true
+
+			// The crops moved out keep their cells until they are placed again.
+			crop := BedMapCrop{UID: *cropUID}
+			if v, ok := crops[*cropUID]; ok {
+				crop.BatchID = v.BatchID
+				crop.VarietyName = v.Inventory.Name
+			}
+
+			view.Crops = append(view.Crops, crop)
+		}
+	}
+
+	return view, nil
+}
+
+// findAreaCrops returns the crops in the area by UID.
+func (s *FarmServer) findAreaCrops(areaUID uuid.UUID) (map[uuid.UUID]query.AreaCropResult, error) {
+	result := <-s.CropReadQuery.FindAllCropByArea(areaUID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	crops, ok := result.Result.([]query.AreaCropResult)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	cropsByUID := map[uuid.UUID]query.AreaCropResult{}
+	for _, v := range crops {
+		cropsByUID[v.CropUID] = v
+	}
+
+	return cropsByUID, nil
+}
+
+func (s *FarmServer) findAreaRead(param string) (storage.AreaRead, error) {
+	areaUID, err := uuid.FromString(param)
+	if err != nil {
+		return storage.AreaRead{}, NewRequestValidationError(NotFound, "area_id")
+	}
+
+	result := <-s.AreaReadQuery.FindByID(areaUID)
+	if result.Error != nil {
+		return storage.AreaRead{}, result.Error
+	}
+
+	areaRead, ok := result.Result.(storage.AreaRead)
+	if !ok {
+		return storage.AreaRead{}, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if areaRead.UID == (uuid.UUID{}) {
+		return storage.AreaRead{}, NewRequestValidationError(NotFound, "area_id")
+	}
+
+	return areaRead, nil
+}
+
+func (s *FarmServer) findAreaFromHistory(param string) (*domain.Area, error) {
+	areaUID, err := uuid.FromString(param)
+	if err != nil {
+		return nil, NewRequestValidationError(NotFound, "area_id")
+	}
+
+	result := <-s.AreaEventQuery.FindAllByID(areaUID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	events, ok := result.Result.([]storage.AreaEvent)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	area := repository.NewAreaFromHistory(events)
+	if area.UID == (uuid.UUID{}) {
+		return nil, NewRequestValidationError(NotFound, "area_id")
+	}
+
+	return area, nil
+}
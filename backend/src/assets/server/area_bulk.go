@@ -0,0 +1,258 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/dryrun"
+	"github.com/usetania/tania-core/src/helper/stringhelper"
+)
+
+// BulkArea is an area of the areas form value of SaveBulkAreas, with the fields of the form of SaveArea.
+type BulkArea struct {
+	Name         string                 `json:"name"`
+	Size         json.Number            `json:"size"`
+	SizeUnit     string                 `json:"size_unit"`
+	Type         string                 `json:"type"`
+	Location     string                 `json:"location"`
+	ReservoirID  string                 `json:"reservoir_id"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+}
+
+// BulkAreaResult is an area created by SaveBulkAreas, the UID is left out of a validation only request.
+type BulkAreaResult struct {
+	UID  *uuid.UUID `json:"uid,omitempty"`
+	Name string     `json:"name"`
+}
+
+// SaveBulkAreas creates the areas of the areas form value, a JSON array of BulkArea, or else the areas named by
+// the name_pattern form value, like `Bench {A..F} Row {1..8}`, sharing the other form values of SaveArea.
+// Every area is validated, and checked against the names of the farm unless force=true, before any is created.
+// The validation only request returns the names of the areas without creating them.
+func (s *FarmServer) SaveBulkAreas(c echo.Context) error {
+	validation := RequestValidation{}
+
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	farm, err := validation.ValidateFarm(*s, farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	bulkAreas, err := parseBulkAreas(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	areas := []*domain.Area{}
+	reservoirs := map[string]storage.ReservoirRead{}
+	names := map[string]string{}
+
+	for i, v := range bulkAreas {
+		field := fmt.Sprintf("areas[%d]", i)
+
+		normalized := stringhelper.NormalizeName(v.Name)
+		if repeated, ok := names[normalized]; ok {
+			rve := NewRequestValidationError(Repeated, field+".name")
+			rve.ErrorMessage = fmt.Sprintf("%s is named like %s.", field, repeated)
+
+			return Error(c, rve)
+		}
+
+		names[normalized] = field
+
+		area, err := s.createBulkArea(farm, v, reservoirs)
+		if err != nil {
+			return Error(c, bulkAreaError(field, v.Name, err))
+		}
+
+		areas = append(areas, area)
+	}
+
+	if c.QueryParam("force") != "true" {
+		err = s.validateBulkAreaDuplicates(farm.UID, areas)
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
+	data := make(map[string][]BulkAreaResult)
+	results := []BulkAreaResult{}
+
+	if dryrun.IsRequested(c) {
+		for _, v := range areas {
+			results = append(results, BulkAreaResult{Name: v.Name})
+		}
+
+		data["data"] = results
+
+		return c.JSON(http.StatusOK, data)
+	}
+
+	for _, v := range areas {
+		err = <-s.AreaEventRepo.Save(v.UID, v.Version, v.UncommittedChanges, actor.FromContext(c))
+		if err != nil {
+			return Error(c, err)
+		}
+
+		s.publishUncommittedEvents(v)
+
+		uid := v.UID
+		results = append(results, BulkAreaResult{UID: &uid, Name: v.Name})
+	}
+
+	data["data"] = results
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// parseBulkAreas returns the areas of the areas form value, or of the name pattern with the other form values.
+func parseBulkAreas(c echo.Context) ([]BulkArea, error) {
+	bulkAreas := []BulkArea{}
+
+	if value := c.FormValue("areas"); value != "" {
+		err := json.Unmarshal([]byte(value), &bulkAreas)
+		if err != nil {
+			return nil, NewRequestValidationError(ParseFailed, "areas")
+		}
+	} else {
+		pattern := c.FormValue("name_pattern")
+		if pattern == "" {
+			return nil, NewRequestValidationError(Required, "name_pattern")
+		}
+
+		names, err := domain.ExpandAreaNamePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		customFields, _, err := parseCustomFields(c)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range names {
+			bulkAreas = append(bulkAreas, BulkArea{
+				Name:         v,
+				Size:         json.Number(c.FormValue("size")),
+				SizeUnit:     c.FormValue("size_unit"),
+				Type:         c.FormValue("type"),
+				Location:     c.FormValue("location"),
+				ReservoirID:  c.FormValue("reservoir_id"),
+				CustomFields: customFields,
+			})
+		}
+	}
+
+	if len(bulkAreas) == 0 {
+		return nil, NewRequestValidationError(Required, "areas")
+	}
+
+	if len(bulkAreas) > domain.AreaBulkMaxCount {
+		return nil, domain.AreaError{Code: domain.AreaErrorNamePatternTooManyCode}
+	}
+
+	return bulkAreas, nil
+}
+
+// createBulkArea validates the area like SaveArea and creates it without saving it.
+func (s *FarmServer) createBulkArea(
+	farm storage.FarmRead, bulkArea BulkArea, reservoirs map[string]storage.ReservoirRead,
+) (*domain.Area, error) {
+	validation := RequestValidation{}
+
+	reservoir, ok := reservoirs[bulkArea.ReservoirID]
+	if !ok {
+		reservoirUID, err := uuid.FromString(bulkArea.ReservoirID)
+		if err != nil {
+			return nil, NewRequestValidationError(ParseFailed, "reservoir_id")
+		}
+
+		reservoir, err = validation.ValidateReservoir(*s, reservoirUID)
+		if err != nil {
+			return nil, err
+		}
+
+		reservoirs[bulkArea.ReservoirID] = reservoir
+	}
+
+	size, err := validation.ValidateAreaSize(bulkArea.Size.String(), bulkArea.SizeUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := validation.ValidateAreaLocation(bulkArea.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.CreateArea(
+		s.AreaService,
+		farm.UID,
+		reservoir.UID,
+		bulkArea.Name,
+		bulkArea.Type,
+		size,
+		location,
+		bulkArea.CustomFields,
+	)
+}
+
+// validateBulkAreaDuplicates checks the names of the areas against the areas of the farm, like ValidateAreaDuplicate.
+func (s *FarmServer) validateBulkAreaDuplicates(farmUID uuid.UUID, areas []*domain.Area) error {
+	result := <-s.AreaReadQuery.FindAllByFarm(farmUID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	existing, ok := result.Result.([]storage.AreaRead)
+	if !ok {
+		return errors.New("internal server error")
+	}
+
+	for i, area := range areas {
+		candidates := []DuplicateCandidate{}
+
+		for _, v := range existing {
+			if stringhelper.IsSimilarName(area.Name, v.Name) {
+				candidates = append(candidates, DuplicateCandidate{UID: v.UID, Name: v.Name})
+			}
+		}
+
+		if err := possibleDuplicate(candidates); err != nil {
+			return bulkAreaError(fmt.Sprintf("areas[%d]", i), area.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// bulkAreaError tells which of the areas is invalid, in the field name of the validation errors
+// or else in the message.
+func bulkAreaError(field, name string, err error) error {
+	var pde PossibleDuplicateError
+	if errors.As(err, &pde) {
+		pde.FieldName = field + "." + pde.FieldName
+
+		return pde
+	}
+
+	var rve RequestValidationError
+	if errors.As(err, &rve) {
+		rve.FieldName = field + "." + rve.FieldName
+
+		return rve
+	}
+
+	return fmt.Errorf("%s %q: %w", field, name, err)
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+// The occupancy and alert statuses of the areas on the farm map.
+const (
+	AreaOccupancyEmpty    = "EMPTY"
+	AreaOccupancyOccupied = "OCCUPIED"
+
+	AreaAlertNone    = "NONE"
+	AreaAlertOverdue = "OVERDUE_TASKS"
+)
+
+// FarmMapArea is an area drawn on the farm map. The areas without a layout aren't placed yet.
+type FarmMapArea struct {
+	UID       uuid.UUID           `json:"uid"`
+	Name      string              `json:"name"`
+	Type      string              `json:"type"`
+	Layout    *storage.AreaLayout `json:"layout"`
+	Occupancy AreaOccupancy       `json:"occupancy"`
+	Alert     AreaAlert           `json:"alert"`
+}
+
+type AreaOccupancy struct {
+	Status              string `json:"status"`
+	TotalCropBatch      int    `json:"total_crop_batch"`
+	PlantQuantity       int    `json:"plant_quantity"`
+	DaysSinceSanitation *int   `json:"days_since_sanitation"`
+}
+
+// AreaAlert tells whether the area has open tasks past their due date.
+type AreaAlert struct {
+	Status       string `json:"status"`
+	OverdueTasks int    `json:"overdue_tasks"`
+}
+
+// ChangeAreaLayout places the area on the farm map, from the x, y, width, height and rotation params.
+// The rotation is 0 when it's not given.
+func (s *FarmServer) ChangeAreaLayout(c echo.Context) error {
+	areaUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	layout := domain.AreaLayout{}
+
+	for _, v := range []struct {
+		name     string
+		value    *float64
+		required bool
+	}{
+		{"x", &layout.X, true},
+		{"y", &layout.Y, true},
+		{"width", &layout.Width, true},
+		{"height", &layout.Height, true},
+		{"rotation", &layout.Rotation, false},
+	} {
+		value := c.FormValue(v.name)
+		if value == "" {
+			if v.required {
+				return Error(c, NewRequestValidationError(Required, v.name))
+			}
+
+			continue
+		}
+
+		*v.value, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			return Error(c, NewRequestValidationError(Numeric, v.name))
+		}
+	}
+
+	result := <-s.AreaEventQuery.FindAllByID(areaUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	events, ok := result.Result.([]storage.AreaEvent)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	area := repository.NewAreaFromHistory(events)
+	if area.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	// PROCESS //
+	err = area.ChangeLayout(layout)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.AreaEventRepo.Save(area.UID, area.Version, area.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(area)
+
+	detailArea, err := MapToDetailArea(s, *area)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]DetailArea)
+	data["data"] = detailArea
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// GetFarmMap returns the layout of all the areas of the farm with their occupancy and alert,
+// so the whole map is drawn from one call.
+func (s *FarmServer) GetFarmMap(c echo.Context) error {
+	farmRead, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	result := <-s.AreaReadQuery.FindAllByFarm(farmRead.UID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	areas, ok := result.Result.([]storage.AreaRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	result = <-s.AreaTaskReadQuery.CountOverdueByArea(time.Now())
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	overdue, ok := result.Result.(map[uuid.UUID]int)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	mapAreas := []FarmMapArea{}
+
+	for _, v := range areas {
+		result := <-s.CropReadQuery.CountCropsByArea(v.UID)
+		if result.Error != nil {
+			return Error(c, result.Error)
+		}
+
+		cropCount, ok := result.Result.(query.CountAreaCropResult)
+		if !ok {
+			return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+		}
+
+		occupancy := AreaOccupancy{
+			Status:              AreaOccupancyEmpty,
+			TotalCropBatch:      cropCount.TotalCropBatch,
+			PlantQuantity:       cropCount.PlantQuantity,
+			DaysSinceSanitation: domain.DaysSinceSanitation(v.LastSanitizedDate, time.Now()),
+		}
+		if cropCount.PlantQuantity > 0 {
+			occupancy.Status = AreaOccupancyOccupied
+		}
+
+		alert := AreaAlert{Status: AreaAlertNone, OverdueTasks: overdue[v.UID]}
+		if alert.OverdueTasks > 0 {
+			alert.Status = AreaAlertOverdue
+		}
+
+		mapAreas = append(mapAreas, FarmMapArea{
+			UID:       v.UID,
+			Name:      v.Name,
+			Type:      v.Type,
+			Layout:    v.Layout,
+			Occupancy: occupancy,
+			Alert:     alert,
+		})
+	}
+
+	data := make(map[string][]FarmMapArea)
+	data["data"] = mapAreas
+
+	return c.JSON(http.StatusOK, data)
+}
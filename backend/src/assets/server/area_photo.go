@@ -0,0 +1,115 @@
+package server
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/helper/stringhelper"
+	"github.com/usetania/tania-core/src/orphans"
+)
+
+// RemoveAreaPhoto removes the photo of the area, then its file. The areas aren't archived,
+// so this is the only way their photos go.
+func (s *FarmServer) RemoveAreaPhoto(c echo.Context) error {
+	data := make(map[string]DetailArea)
+
+	farmUID, err := uuid.FromString(c.Param("farm_id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	areaUID, err := uuid.FromString(c.Param("area_id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// Validate //
+	queryResult := <-s.AreaReadQuery.FindByIDAndFarm(areaUID, farmUID)
+	if queryResult.Error != nil {
+		return Error(c, queryResult.Error)
+	}
+
+	areaRead, ok := queryResult.Result.(storage.AreaRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	if areaRead.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "area_id"))
+	}
+
+	if areaRead.Photo.Filename == "" {
+		return Error(c, NewRequestValidationError(NotFound, "photo"))
+	}
+
+	// Process //
+	eventQueryResult := <-s.AreaEventQuery.FindAllByID(areaRead.UID)
+	if eventQueryResult.Error != nil {
+		return Error(c, eventQueryResult.Error)
+	}
+
+	events := eventQueryResult.Result.([]storage.AreaEvent)
+	area := repository.NewAreaFromHistory(events)
+
+	err = area.RemovePhoto()
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// Persists //
+	resultSave := <-s.AreaEventRepo.Save(area.UID, area.Version, area.UncommittedChanges, actor.FromContext(c))
+	if resultSave != nil {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	// Publish //
+	s.publishUncommittedEvents(area)
+
+	s.removeAreaPhotoFiles(area.UncommittedChanges)
+
+	detailArea, err := MapToDetailArea(s, *area)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data["data"] = detailArea
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// removeAreaPhotoFiles removes the files of the removed photos. The files still referenced,
+// by another area whose photo was uploaded with the same name, stay.
+func (s *FarmServer) removeAreaPhotoFiles(changes []interface{}) {
+	filenames := []string{}
+
+	for _, v := range changes {
+		if e, ok := v.(domain.AreaPhotoRemoved); ok {
+			filenames = append(filenames, e.Filename)
+		}
+	}
+
+	if len(filenames) == 0 || s.PhotoReferences == nil {
+		return
+	}
+
+	unreferenced, err := orphans.Unreferenced(s.PhotoReferences, orphans.FolderAreas, filenames)
+	if err != nil {
+		log.Println("Finding the references of the removed area photos failed", err)
+
+		return
+	}
+
+	for _, v := range unreferenced {
+		err := s.File.Remove(stringhelper.Join(*config.Config.UploadPathArea, "/", v))
+		if err != nil {
+			log.Println("Removing the area photo", v, "failed", err)
+		}
+	}
+}
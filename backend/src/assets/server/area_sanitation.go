@@ -0,0 +1,184 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type areaSanitationMaterialForm struct {
+	MaterialID string  `json:"material_id"`
+	Quantity   float32 `json:"quantity"`
+	LotNumber  string  `json:"lot_number"`
+}
+
+// GetAreaSanitations lists the sanitations of the area, oldest first.
+func (s *FarmServer) GetAreaSanitations(c echo.Context) error {
+	areaRead, err := s.findAreaRead(c.Param("area_id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	sanitations := areaRead.Sanitations
+	if sanitations == nil {
+		sanitations = []storage.AreaSanitation{}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":                  sanitations,
+		"last_sanitized_date":   areaRead.LastSanitizedDate,
+		"days_since_sanitation": domain.DaysSinceSanitation(areaRead.LastSanitizedDate, time.Now()),
+	})
+}
+
+// SanitizeArea records the cleaning of the area with the method form value, on the date form value or today.
+// The materials form value is a JSON list of material_id, quantity and the optional lot_number it's taken from,
+// deducted from the stock of the materials. A material short of stock is deducted to zero, the sanitation is
+// still recorded with the shortfall and a warning.
+func (s *FarmServer) SanitizeArea(c echo.Context) error {
+	area, err := s.findAreaFromHistory(c.Param("area_id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	sanitizedDate := time.Now()
+
+	if value := c.FormValue("date"); value != "" {
+		sanitizedDate, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "date"))
+		}
+	}
+
+	forms := []areaSanitationMaterialForm{}
+
+	if value := c.FormValue("materials"); value != "" {
+		err = json.Unmarshal([]byte(value), &forms)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "materials"))
+		}
+	}
+
+	reachedAreas, reachedCrops, err := s.findStockUseReach([]storage.AreaRead{{UID: area.UID, Name: area.Name}})
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	materials := []*domain.Material{}
+	used := []domain.AreaSanitationMaterial{}
+	warnings := []string{}
+
+	for _, v := range forms {
+		materialUID, err := uuid.FromString(v.MaterialID)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "materials"))
+		}
+
+		if v.Quantity <= 0 {
+			return Error(c, NewRequestValidationError(Float, "materials"))
+		}
+
+		material, err := s.findMaterialFromHistory(materialUID)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		if material.UID != materialUID {
+			return Error(c, NewRequestValidationError(NotFound, "materials"))
+		}
+
+		err = s.validateMaterialNotCounted(material.UID)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		shortfall, err := material.DeductStock(v.Quantity, domain.StockUse{
+			Reason:       domain.StockDeductionReasonSanitation,
+			ReferenceUID: area.UID,
+			FarmUID:      area.FarmUID,
+			LotNumber:    v.LotNumber,
+			UsedDate:     sanitizedDate,
+			Areas:        reachedAreas,
+			Crops:        reachedCrops,
+		})
+		if err != nil {
+			return Error(c, err)
+		}
+
+		if shortfall > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s is short of stock by %g %s.",
+				material.Name, shortfall, material.Quantity.Unit.Code))
+		}
+
+		materials = append(materials, material)
+		used = append(used, domain.AreaSanitationMaterial{
+			MaterialUID:  material.UID,
+			MaterialName: material.Name,
+			Quantity:     v.Quantity,
+			QuantityUnit: material.Quantity.Unit.Code,
+			Shortfall:    shortfall,
+		})
+	}
+
+	err = area.Sanitize(strings.ToUpper(c.FormValue("method")), sanitizedDate, used, c.FormValue("notes"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	sanitized, ok := area.UncommittedChanges[len(area.UncommittedChanges)-1].(domain.AreaSanitized)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	// PERSIST //
+	err = <-s.AreaEventRepo.Save(area.UID, area.Version, area.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(area)
+
+	for _, v := range materials {
+		err = <-s.MaterialEventRepo.Save(v.UID, v.Version, v.UncommittedChanges, actor.FromContext(c))
+		if err != nil {
+			return Error(c, err)
+		}
+
+		s.publishUncommittedEvents(v)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":     MapToAreaSanitationRead(sanitized),
+		"warnings": warnings,
+	})
+}
+
+func MapToAreaSanitationRead(e domain.AreaSanitized) storage.AreaSanitation {
+	materials := []storage.AreaSanitationMaterial{}
+	for _, v := range e.Materials {
+		materials = append(materials, storage.AreaSanitationMaterial{
+			MaterialUID:  v.MaterialUID,
+			MaterialName: v.MaterialName,
+			Quantity:     v.Quantity,
+			QuantityUnit: v.QuantityUnit,
+			Shortfall:    v.Shortfall,
+		})
+	}
+
+	return storage.AreaSanitation{
+		UID:           e.UID,
+		Method:        e.Method,
+		Materials:     materials,
+		Notes:         e.Notes,
+		SanitizedDate: e.SanitizedDate,
+	}
+}
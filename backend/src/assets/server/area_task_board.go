@@ -0,0 +1,115 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/assets/query"
+)
+
+// AreaTaskBoard is the open tasks of an area and of the crops in it, by when they are due.
+// The tasks without a due date are upcoming.
+type AreaTaskBoard struct {
+	AreaUID  uuid.UUID       `json:"area_id"`
+	AreaName string          `json:"area_name"`
+	Overdue  []AreaBoardTask `json:"overdue"`
+	Today    []AreaBoardTask `json:"today"`
+	Upcoming []AreaBoardTask `json:"upcoming"`
+}
+
+// AreaBoardTask is an open task with the crop it's attached to, nil for the tasks of the area itself.
+type AreaBoardTask struct {
+	query.AreaTaskResult
+	Crop *query.AreaCurrentCropResult `json:"crop"`
+}
+
+// GetAreaTaskBoard returns the task board of the area. Today is the day of the server.
+func (s *FarmServer) GetAreaTaskBoard(c echo.Context) error {
+	areaRead, err := s.findAreaRead(c.Param("area_id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	result := <-s.CropReadQuery.FindAllCurrentByArea(areaRead.UID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	crops, ok := result.Result.([]query.AreaCurrentCropResult)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	assetUIDs := []uuid.UUID{areaRead.UID}
+	cropsByUID := map[uuid.UUID]query.AreaCurrentCropResult{}
+
+	for _, v := range crops {
+		assetUIDs = append(assetUIDs, v.CropUID)
+		cropsByUID[v.CropUID] = v
+	}
+
+	result = <-s.AreaTaskReadQuery.FindAllOpenByAssets(assetUIDs)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	tasks, ok := result.Result.([]query.AreaTaskResult)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	data := make(map[string]AreaTaskBoard)
+	data["data"] = NewAreaTaskBoard(areaRead.UID, areaRead.Name, tasks, cropsByUID, time.Now())
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// NewAreaTaskBoard sorts the tasks by due date into the buckets of the day of now.
+func NewAreaTaskBoard(
+	areaUID uuid.UUID,
+	areaName string,
+	tasks []query.AreaTaskResult,
+	crops map[uuid.UUID]query.AreaCurrentCropResult,
+	now time.Time,
+) AreaTaskBoard {
+	board := AreaTaskBoard{
+		AreaUID:  areaUID,
+		AreaName: areaName,
+		Overdue:  []AreaBoardTask{},
+		Today:    []AreaBoardTask{},
+		Upcoming: []AreaBoardTask{},
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].DueDate == nil || tasks[j].DueDate == nil {
+			return tasks[i].DueDate != nil
+		}
+
+		return tasks[i].DueDate.Before(*tasks[j].DueDate)
+	})
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	tomorrow := today.AddDate(0, 0, 1)
+
+	for _, v := range tasks {
+		task := AreaBoardTask{AreaTaskResult: v}
+
+		if crop, ok := crops[v.AssetID]; ok {
+			task.Crop = &crop
+		}
+
+		switch {
+		case v.DueDate == nil || !v.DueDate.Before(tomorrow):
+			board.Upcoming = append(board.Upcoming, task)
+		case v.DueDate.Before(today):
+			board.Overdue = append(board.Overdue, task)
+		default:
+			board.Today = append(board.Today, task)
+		}
+	}
+
+	return board
+}
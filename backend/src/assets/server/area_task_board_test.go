@@ -0,0 +1,64 @@
+package server_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/server"
+)
+
+func TestNewAreaTaskBoard(t *testing.T) {
+	t.Parallel()
+	// Given
+	areaUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+
+	location := time.FixedZone("WIB", 7*60*60)
+	now := time.Date(2026, time.March, 2, 15, 0, 0, 0, location)
+	yesterday := time.Date(2026, time.March, 1, 23, 0, 0, 0, location)
+	morning := time.Date(2026, time.March, 2, 0, 0, 0, 0, location)
+	evening := time.Date(2026, time.March, 2, 23, 59, 0, 0, location)
+	tomorrow := time.Date(2026, time.March, 3, 0, 0, 0, 0, location)
+
+	task := func(title string, dueDate *time.Time, assetID uuid.UUID) query.AreaTaskResult {
+		uid, _ := uuid.NewV4()
+
+		return query.AreaTaskResult{UID: uid, Title: title, DueDate: dueDate, AssetID: assetID}
+	}
+
+	tasks := []query.AreaTaskResult{
+		task("Order the trays", nil, areaUID),
+		task("Harvest the lettuce", &tomorrow, cropUID),
+		task("Prune the tomatoes", &evening, cropUID),
+		task("Weed the beds", &morning, areaUID),
+		task("Fix the fence", &yesterday, areaUID),
+	}
+	crops := map[uuid.UUID]query.AreaCurrentCropResult{
+		cropUID: {CropUID: cropUID, BatchID: "tom-mar02"},
+	}
+
+	// When
+	board := server.NewAreaTaskBoard(areaUID, "Greenhouse", tasks, crops, now)
+
+	// Then
+	titles := func(tasks []server.AreaBoardTask) []string {
+		titles := []string{}
+		for _, v := range tasks {
+			titles = append(titles, v.Title)
+		}
+
+		return titles
+	}
+
+	assert.Equal(t, areaUID, board.AreaUID)
+	assert.Equal(t, "Greenhouse", board.AreaName)
+	assert.Equal(t, []string{"Fix the fence"}, titles(board.Overdue))
+	assert.Equal(t, []string{"Weed the beds", "Prune the tomatoes"}, titles(board.Today))
+	assert.Equal(t, []string{"Harvest the lettuce", "Order the trays"}, titles(board.Upcoming))
+
+	assert.Nil(t, board.Today[0].Crop)
+	assert.Equal(t, "tom-mar02", board.Today[1].Crop.BatchID)
+}
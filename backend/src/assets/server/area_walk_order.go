@@ -0,0 +1,91 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+// ChangeAreaWalkOrder sets the order the crew walks through the areas of the farm,
+// from the comma separated area_ids. An empty area_ids clears the order.
+func (s *FarmServer) ChangeAreaWalkOrder(c echo.Context) error {
+	farmRead, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	areaUIDs := []uuid.UUID{}
+
+	for _, v := range strings.Split(c.FormValue("area_ids"), ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		areaUID, err := uuid.FromString(v)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "area_ids"))
+		}
+
+		err = s.validateFarmArea(areaUID, farmRead.UID)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		areaUIDs = append(areaUIDs, areaUID)
+	}
+
+	result := <-s.FarmEventQuery.FindAllByID(farmRead.UID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	events, ok := result.Result.([]storage.FarmEvent)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	farm := repository.NewFarmFromHistory(events)
+
+	// PROCESS //
+	err = farm.ChangeAreaWalkOrder(areaUIDs)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.FarmEventRepo.Save(farm.UID, farm.Version, farm.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(farm)
+
+	data := make(map[string]*storage.FarmRead)
+	data["data"] = MapToFarmRead(farm)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *FarmServer) validateFarmArea(areaUID, farmUID uuid.UUID) error {
+	result := <-s.AreaReadQuery.FindByIDAndFarm(areaUID, farmUID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	area, ok := result.Result.(storage.AreaRead)
+	if !ok {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if area.UID == (uuid.UUID{}) {
+		return NewRequestValidationError(NotFound, "area_ids")
+	}
+
+	return nil
+}
@@ -0,0 +1,503 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/helper/paginationhelper"
+)
+
+func (s *FarmServer) FindCustomFieldDefinitions(c echo.Context) error {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	entityType := c.QueryParam("entity_type")
+	if entityType != "" && !isCustomFieldEntityType(entityType) {
+		return Error(c, NewRequestValidationError(InvalidOption, "entity_type"))
+	}
+
+	result := <-s.CustomFieldDefinitionReadQuery.FindAllByFarm(farm.UID, entityType)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	definitions, ok := result.Result.([]storage.CustomFieldDefinitionRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	data := make(map[string][]storage.CustomFieldDefinitionRead)
+	data["data"] = definitions
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *FarmServer) SaveCustomFieldDefinition(c echo.Context) error {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	required, err := parseCustomFieldRequired(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	options, _, err := parseCustomFieldOptions(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	definition, err := domain.DefineCustomField(
+		farm.UID,
+		c.FormValue("entity_type"),
+		c.FormValue("field_key"),
+		c.FormValue("label"),
+		c.FormValue("field_type"),
+		options,
+		required,
+	)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = s.validateCustomFieldKeyDuplicate(*definition)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = s.saveCustomFieldDefinition(definition, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.CustomFieldDefinitionRead)
+	data["data"] = MapToCustomFieldDefinitionRead(*definition)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *FarmServer) UpdateCustomFieldDefinition(c echo.Context) error {
+	definition, err := s.findCustomFieldDefinition(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	label := c.FormValue("label")
+	if label == "" {
+		label = definition.Label
+	}
+
+	fieldType := c.FormValue("field_type")
+	if fieldType == "" {
+		fieldType = definition.FieldType
+	}
+
+	options, hasOptions, err := parseCustomFieldOptions(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if !hasOptions {
+		options = definition.Options
+	}
+
+	required := definition.Required
+	if c.FormValue("required") != "" {
+		required, err = parseCustomFieldRequired(c)
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
+	// PROCESS //
+	err = definition.Update(label, fieldType, options, required)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = s.saveCustomFieldDefinition(definition, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.CustomFieldDefinitionRead)
+	data["data"] = MapToCustomFieldDefinitionRead(*definition)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// RemoveCustomFieldDefinition retires the definition. The values already saved on the entities are kept.
+func (s *FarmServer) RemoveCustomFieldDefinition(c echo.Context) error {
+	definition, err := s.findCustomFieldDefinition(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	err = definition.Delete()
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = s.saveCustomFieldDefinition(definition, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.CustomFieldDefinitionRead)
+	data["data"] = MapToCustomFieldDefinitionRead(*definition)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// SaveMaterialCustomFields replaces the custom field values of the material. The materials belong to no farm,
+// so the values are checked against the fields defined by the farm of the farm_id form value.
+func (s *FarmServer) SaveMaterialCustomFields(c echo.Context) error {
+	materialUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	result := <-s.MaterialReadQuery.FindByID(materialUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	material, ok := result.Result.(storage.MaterialRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	if material.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	farm, err := s.findCustomFieldFarm(c, c.FormValue("farm_id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	values, hasValues, err := parseCustomFields(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if !hasValues {
+		return Error(c, NewRequestValidationError(Required, "custom_fields"))
+	}
+
+	// PROCESS AND PERSIST //
+	values, err = s.CustomFields.Change(farm.UID, domain.CustomFieldEntityMaterial, material.UID, values)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	detail := MapToMaterialFromRead(material)
+	detail.CustomFields = values
+
+	data := make(map[string]Material)
+	data["data"] = detail
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// getMaterialsByCustomFields lists the materials matching the `cf_` filters on the fields defined by the farm
+// of the farm_id query param. The filters apply after the query, so the page is taken from all the materials.
+func (s *FarmServer) getMaterialsByCustomFields(
+	c echo.Context,
+	materialType, materialTypeDetail string,
+	page, limit int,
+	filters map[string]string,
+) error {
+	farm, err := s.findCustomFieldFarm(c, c.QueryParam("farm_id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	matched, err := s.CustomFields.Filter(farm.UID, domain.CustomFieldEntityMaterial, filters)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	queryResult := <-s.MaterialReadQuery.FindAll(materialType, materialTypeDetail, 0, 0)
+	if queryResult.Error != nil {
+		return Error(c, queryResult.Error)
+	}
+
+	results, ok := queryResult.Result.([]storage.MaterialRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	materials := []Material{}
+
+	for _, v := range results {
+		if matched[v.UID] {
+			materials = append(materials, MapToMaterialFromRead(v))
+		}
+	}
+
+	start, end := paginationhelper.PageBounds(len(materials), page, limit)
+
+	data := make(map[string]interface{})
+	data["data"] = materials[start:end]
+	data["total"] = len(materials)
+	data["page"] = page
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// filterAreasByCustomFields keeps the areas whose values match the `cf_` filters.
+func (s *FarmServer) filterAreasByCustomFields(
+	farmUID uuid.UUID,
+	areas []storage.AreaRead,
+	filters map[string]string,
+) ([]storage.AreaRead, error) {
+	fields, err := s.AreaService.FindCustomFieldsByFarm(farmUID, domain.CustomFieldEntityArea)
+	if err != nil {
+		return nil, err
+	}
+
+	// The filters are checked once before the areas, so an invalid filter fails even without areas.
+	_, err = domain.MatchCustomFieldFilters(fields, map[string]interface{}{}, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []storage.AreaRead{}
+
+	for _, v := range areas {
+		ok, err := domain.MatchCustomFieldFilters(fields, v.CustomFields, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			filtered = append(filtered, v)
+		}
+	}
+
+	return filtered, nil
+}
+
+// findCustomFieldFarm finds the farm of the farm_id value, which the entities belonging to no farm
+// need to find their custom fields.
+func (s *FarmServer) findCustomFieldFarm(c echo.Context, value string) (storage.FarmRead, error) {
+	if value == "" {
+		return storage.FarmRead{}, NewRequestValidationError(Required, "farm_id")
+	}
+
+	farmUID, err := uuid.FromString(value)
+	if err != nil || !s.FarmScope.Allows(c, farmUID) {
+		return storage.FarmRead{}, NewRequestValidationError(NotFound, "farm_id")
+	}
+
+	result := <-s.FarmReadQuery.FindByID(farmUID)
+	if result.Error != nil {
+		return storage.FarmRead{}, result.Error
+	}
+
+	farm, ok := result.Result.(storage.FarmRead)
+	if !ok {
+		return storage.FarmRead{}, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if farm.UID == (uuid.UUID{}) {
+		return storage.FarmRead{}, NewRequestValidationError(NotFound, "farm_id")
+	}
+
+	return farm, nil
+}
+
+// parseCustomFields parses the custom_fields form value, a JSON object of the values by field key.
+// It tells whether the form value was sent, so the updates only change the values when it was.
+func parseCustomFields(c echo.Context) (map[string]interface{}, bool, error) {
+	value := c.FormValue("custom_fields")
+	if value == "" {
+		return nil, false, nil
+	}
+
+	customFields := map[string]interface{}{}
+
+	err := json.Unmarshal([]byte(value), &customFields)
+	if err != nil {
+		return nil, false, NewRequestValidationError(ParseFailed, "custom_fields")
+	}
+
+	return customFields, true, nil
+}
+
+// parseCustomFieldOptions parses the options form value of the select fields, a JSON array of strings.
+func parseCustomFieldOptions(c echo.Context) ([]string, bool, error) {
+	value := c.FormValue("options")
+	if value == "" {
+		return nil, false, nil
+	}
+
+	options := []string{}
+
+	err := json.Unmarshal([]byte(value), &options)
+	if err != nil {
+		return nil, false, NewRequestValidationError(ParseFailed, "options")
+	}
+
+	return options, true, nil
+}
+
+func parseCustomFieldRequired(c echo.Context) (bool, error) {
+	if c.FormValue("required") == "" {
+		return false, nil
+	}
+
+	required, err := strconv.ParseBool(c.FormValue("required"))
+	if err != nil {
+		return false, NewRequestValidationError(ParseFailed, "required")
+	}
+
+	return required, nil
+}
+
+func isCustomFieldEntityType(entityType string) bool {
+	for _, v := range domain.FindAllCustomFieldEntityTypes() {
+		if v == entityType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *FarmServer) validateCustomFieldKeyDuplicate(definition domain.CustomFieldDefinition) error {
+	result := <-s.CustomFieldDefinitionReadQuery.FindAllByFarm(definition.FarmID, definition.EntityType)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	definitions, ok := result.Result.([]storage.CustomFieldDefinitionRead)
+	if !ok {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	for _, v := range definitions {
+		if v.FieldKey == definition.FieldKey {
+			return domain.CustomFieldError{Code: domain.CustomFieldErrorDuplicateKeyCode, FieldKey: definition.FieldKey}
+		}
+	}
+
+	return nil
+}
+
+// findCustomFieldDefinition finds the definition of the field_id param and checks it belongs to the farm.
+func (s *FarmServer) findCustomFieldDefinition(c echo.Context) (*domain.CustomFieldDefinition, error) {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return nil, err
+	}
+
+	definitionUID, err := uuid.FromString(c.Param("field_id"))
+	if err != nil {
+		return nil, NewRequestValidationError(NotFound, "field_id")
+	}
+
+	definition, err := s.findCustomFieldDefinitionFromHistory(definitionUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if definition.UID != definitionUID || definition.FarmID != farm.UID || definition.IsDeleted {
+		return nil, NewRequestValidationError(NotFound, "field_id")
+	}
+
+	return definition, nil
+}
+
+func (s *FarmServer) findCustomFieldDefinitionFromHistory(uid uuid.UUID) (*domain.CustomFieldDefinition, error) {
+	result := <-s.CustomFieldDefinitionEventQuery.FindAllByID(uid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	events, ok := result.Result.([]storage.CustomFieldDefinitionEvent)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return repository.NewCustomFieldDefinitionFromHistory(events), nil
+}
+
+func (s *FarmServer) saveCustomFieldDefinition(definition *domain.CustomFieldDefinition, by *actor.Actor) error {
+	err := <-s.CustomFieldDefinitionEventRepo.Save(
+		definition.UID, definition.Version, definition.UncommittedChanges, by,
+	)
+	if err != nil {
+		return err
+	}
+
+	s.publishUncommittedEvents(definition)
+
+	return nil
+}
+
+func MapToCustomFieldDefinitionRead(definition domain.CustomFieldDefinition) storage.CustomFieldDefinitionRead {
+	return storage.CustomFieldDefinitionRead{
+		UID:         definition.UID,
+		FarmUID:     definition.FarmID,
+		EntityType:  definition.EntityType,
+		FieldKey:    definition.FieldKey,
+		Label:       definition.Label,
+		FieldType:   definition.FieldType,
+		Options:     definition.Options,
+		Required:    definition.Required,
+		IsDeleted:   definition.IsDeleted,
+		CreatedDate: definition.CreatedDate,
+	}
+}
+
+func (s *FarmServer) SaveToCustomFieldDefinitionReadModel(event interface{}) error {
+	var uid uuid.UUID
+
+	switch e := event.(type) {
+	case domain.FieldDefined:
+		uid = e.UID
+	case domain.FieldUpdated:
+		uid = e.UID
+	case domain.FieldDeleted:
+		uid = e.UID
+	default:
+		return errors.New("unknown custom field definition event")
+	}
+
+	definition, err := s.findCustomFieldDefinitionFromHistory(uid)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	definitionRead := MapToCustomFieldDefinitionRead(*definition)
+
+	err = <-s.CustomFieldDefinitionReadRepo.Save(&definitionRead)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/dryrun"
+)
+
+// validatable lets the handler be called with `?validate_only=true` to only validate the request.
+func (s *FarmServer) validatable(h func(*FarmServer, echo.Context) error) echo.HandlerFunc {
+	return dryrun.Handler(s, s.dryRun, h)
+}
+
+// dryRun copies the server with the event repositories and the event bus which drop the events.
+func (s *FarmServer) dryRun() *FarmServer {
+	dry := *s
+
+	dry.FarmEventRepo = dryrun.EventRepository{}
+	dry.ReservoirEventRepo = dryrun.EventRepository{}
+	dry.AreaEventRepo = dryrun.EventRepository{}
+	dry.MaterialEventRepo = dryrun.EventRepository{}
+	dry.FarmCertificationEventRepo = dryrun.EventRepository{}
+	dry.CustomFieldDefinitionEventRepo = dryrun.EventRepository{}
+	dry.StocktakeEventRepo = dryrun.EventRepository{}
+	dry.EquipmentEventRepo = dryrun.EventRepository{}
+	dry.NutrientRecipeEventRepo = dryrun.EventRepository{}
+	dry.FarmBoundaryEventRepo = dryrun.EventRepository{}
+	dry.EventBus = dryrun.EventBus{}
+	dry.CustomFields = s.CustomFields.WithoutSaving()
+
+	return &dry
+}
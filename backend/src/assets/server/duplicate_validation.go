@@ -0,0 +1,87 @@
+package server
+
+import (
+	"errors"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/helper/stringhelper"
+)
+
+// DuplicateCandidate is an existing entry whose name looks like the requested one.
+type DuplicateCandidate struct {
+	UID  uuid.UUID `json:"uid"`
+	Name string    `json:"name"`
+}
+
+// PossibleDuplicateError is returned with 409 so the client can show the candidates
+// and send the request again with force=true.
+type PossibleDuplicateError struct {
+	RequestValidationError
+	Candidates []DuplicateCandidate `json:"candidates"`
+}
+
+// ValidateAreaDuplicate checks the name against the other areas in the same farm.
+func (*RequestValidation) ValidateAreaDuplicate(s FarmServer, farmUID uuid.UUID, name string, force bool) error {
+	if force {
+		return nil
+	}
+
+	result := <-s.AreaReadQuery.FindAllByFarm(farmUID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	areas, ok := result.Result.([]storage.AreaRead)
+	if !ok {
+		return errors.New("internal server error")
+	}
+
+	candidates := []DuplicateCandidate{}
+
+	for _, v := range areas {
+		if stringhelper.IsSimilarName(name, v.Name) {
+			candidates = append(candidates, DuplicateCandidate{UID: v.UID, Name: v.Name})
+		}
+	}
+
+	return possibleDuplicate(candidates)
+}
+
+// ValidateMaterialDuplicate checks the name against the other materials.
+func (*RequestValidation) ValidateMaterialDuplicate(s FarmServer, name string, force bool) error {
+	if force {
+		return nil
+	}
+
+	result := <-s.MaterialReadQuery.FindAll("", "", 0, 0)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	materials, ok := result.Result.([]storage.MaterialRead)
+	if !ok {
+		return errors.New("internal server error")
+	}
+
+	candidates := []DuplicateCandidate{}
+
+	for _, v := range materials {
+		if stringhelper.IsSimilarName(name, v.Name) {
+			candidates = append(candidates, DuplicateCandidate{UID: v.UID, Name: v.Name})
+		}
+	}
+
+	return possibleDuplicate(candidates)
+}
+
+func possibleDuplicate(candidates []DuplicateCandidate) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	return PossibleDuplicateError{
+		RequestValidationError: NewRequestValidationError(PossibleDuplicate, "name"),
+		Candidates:             candidates,
+	}
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+// ChangeDailyEffortBudget sets the minutes of work the tasks generated for the farm are spread over a day,
+// `daily_minutes=0` removes the budget.
+func (s *FarmServer) ChangeDailyEffortBudget(c echo.Context) error {
+	farmRead, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if c.FormValue("daily_minutes") == "" {
+		return Error(c, NewRequestValidationError(Required, "daily_minutes"))
+	}
+
+	minutes, err := strconv.Atoi(c.FormValue("daily_minutes"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(Numeric, "daily_minutes"))
+	}
+
+	result := <-s.FarmEventQuery.FindAllByID(farmRead.UID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	events, ok := result.Result.([]storage.FarmEvent)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	farm := repository.NewFarmFromHistory(events)
+
+	// PROCESS //
+	err = farm.ChangeDailyEffortBudget(minutes)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.FarmEventRepo.Save(farm.UID, farm.Version, farm.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(farm)
+
+	data := make(map[string]*storage.FarmRead)
+	data["data"] = MapToFarmRead(farm)
+
+	return c.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,498 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/featureflags"
+)
+
+// equipmentMaintenanceInterval is how often the equipment close to its maintenance is checked.
+const equipmentMaintenanceInterval = time.Hour
+
+// EquipmentDetail is an equipment with its open maintenance task due first, if any.
+type EquipmentDetail struct {
+	storage.EquipmentRead
+	NextMaintenanceTask *query.EquipmentTaskResult `json:"next_maintenance_task"`
+}
+
+// StartEquipmentMaintenanceScheduler schedules the maintenance of the equipment close to its maintenance date
+// right away and then every hour. The maintenance tasks are created by the tasks module.
+// It skips its runs while paused is true or the equipment flag is off.
+func (s *FarmServer) StartEquipmentMaintenanceScheduler(paused func() bool) {
+	ticker := time.NewTicker(equipmentMaintenanceInterval)
+
+	go func() {
+		for {
+			switch {
+			case paused():
+				log.Println("Equipment maintenance scheduler paused by the maintenance mode")
+			case !s.Flags.Enabled(featureflags.Equipment):
+				// The equipment feature is off, the scheduler waits for it to be turned on again.
+			default:
+				s.requestEquipmentMaintenances(time.Now())
+			}
+
+			<-ticker.C
+		}
+	}()
+}
+
+func (s *FarmServer) requestEquipmentMaintenances(now time.Time) {
+	result := <-s.EquipmentReadQuery.FindAllMaintenanceDue(now.Add(domain.EquipmentMaintenanceNotice))
+	if result.Error != nil {
+		log.Println("Equipment maintenance check failed", result.Error)
+
+		return
+	}
+
+	equipment, ok := result.Result.([]storage.EquipmentRead)
+	if !ok {
+		log.Println(errors.New("internal server error. error type assertion"))
+
+		return
+	}
+
+	for _, v := range equipment {
+		e, err := s.findEquipmentFromHistory(v.UID)
+		if err != nil {
+			log.Println("Equipment", v.UID, "cannot be loaded", err)
+
+			continue
+		}
+
+		e.RequestMaintenance(now)
+
+		err = s.saveEquipment(e, actor.System("equipment_maintenance"))
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+func (*FarmServer) GetEquipmentTypes(c echo.Context) error {
+	return c.JSON(http.StatusOK, domain.FindAllEquipmentTypes())
+}
+
+func (s *FarmServer) FindFarmEquipment(c echo.Context) error {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	result := <-s.EquipmentReadQuery.FindAllByFarm(farm.UID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	equipment, ok := result.Result.([]storage.EquipmentRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	details := []EquipmentDetail{}
+
+	for _, v := range equipment {
+		detail, err := s.mapToEquipmentDetail(v)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		details = append(details, detail)
+	}
+
+	data := make(map[string][]EquipmentDetail)
+	data["data"] = details
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *FarmServer) FindEquipmentByID(c echo.Context) error {
+	e, err := s.findEquipment(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	detail, err := s.mapToEquipmentDetail(MapToEquipmentRead(*e))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]EquipmentDetail)
+	data["data"] = detail
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// SaveEquipment registers an equipment of the farm. The maintenance_interval_days schedules a maintenance task
+// every interval from the next_maintenance_date, or from today when it isn't given.
+func (s *FarmServer) SaveEquipment(c echo.Context) error {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	details, err := s.parseEquipmentDetails(c, farm.UID, domain.EquipmentDetails{})
+	if err != nil {
+		return Error(c, err)
+	}
+
+	intervalDays, nextDate, err := parseEquipmentMaintenance(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	e, err := domain.CreateEquipment(farm.UID, details, intervalDays, nextDate, time.Now())
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// An equipment whose first maintenance is close gets its task right away.
+	e.RequestMaintenance(time.Now())
+
+	// PERSIST //
+	err = s.saveEquipment(e, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.EquipmentRead)
+	data["data"] = MapToEquipmentRead(*e)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// UpdateEquipment changes the fields of the form, the other ones are kept. The maintenance is only changed
+// when the maintenance_interval_days is given, a zero interval stops the maintenance tasks.
+func (s *FarmServer) UpdateEquipment(c echo.Context) error {
+	e, err := s.findEquipment(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	details, err := s.parseEquipmentDetails(c, e.FarmUID, domain.EquipmentDetails{
+		Name:         e.Name,
+		Type:         e.Type,
+		Location:     e.Location,
+		PurchaseDate: e.PurchaseDate,
+		Notes:        e.Notes,
+	})
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	err = e.Update(details)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if c.FormValue("maintenance_interval_days") != "" {
+		intervalDays, nextDate, err := parseEquipmentMaintenance(c)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		err = e.ChangeMaintenance(intervalDays, nextDate, time.Now())
+		if err != nil {
+			return Error(c, err)
+		}
+
+		e.RequestMaintenance(time.Now())
+	}
+
+	// PERSIST //
+	err = s.saveEquipment(e, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.EquipmentRead)
+	data["data"] = MapToEquipmentRead(*e)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// RemoveEquipment deletes the equipment. Its maintenance tasks already created are kept.
+func (s *FarmServer) RemoveEquipment(c echo.Context) error {
+	e, err := s.findEquipment(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	err = e.Delete()
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = s.saveEquipment(e, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.EquipmentRead)
+	data["data"] = MapToEquipmentRead(*e)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// parseEquipmentDetails reads the details of the form over the current ones. The location is an area
+// or a reservoir of the farm.
+func (s *FarmServer) parseEquipmentDetails(
+	c echo.Context,
+	farmUID uuid.UUID,
+	details domain.EquipmentDetails,
+) (domain.EquipmentDetails, error) {
+	if name := c.FormValue("name"); name != "" {
+		details.Name = name
+	}
+
+	if equipmentType := c.FormValue("type"); equipmentType != "" {
+		details.Type = equipmentType
+	}
+
+	if notes := c.FormValue("notes"); notes != "" {
+		details.Notes = notes
+	}
+
+	if value := c.FormValue("purchase_date"); value != "" {
+		purchaseDate, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return domain.EquipmentDetails{}, NewRequestValidationError(ParseFailed, "purchase_date")
+		}
+
+		details.PurchaseDate = &purchaseDate
+	}
+
+	if locationType := c.FormValue("location_type"); locationType != "" {
+		location, err := s.findEquipmentLocation(farmUID, locationType, c.FormValue("location_id"))
+		if err != nil {
+			return domain.EquipmentDetails{}, err
+		}
+
+		details.Location = location
+	}
+
+	if details.Name == "" {
+		return domain.EquipmentDetails{}, NewRequestValidationError(Required, "name")
+	}
+
+	if details.Type == "" {
+		return domain.EquipmentDetails{}, NewRequestValidationError(Required, "type")
+	}
+
+	return details, nil
+}
+
+func (s *FarmServer) findEquipmentLocation(
+	farmUID uuid.UUID,
+	locationType, locationID string,
+) (*domain.EquipmentLocation, error) {
+	if locationID == "" {
+		return nil, NewRequestValidationError(Required, "location_id")
+	}
+
+	locationUID, err := uuid.FromString(locationID)
+	if err != nil {
+		return nil, NewRequestValidationError(ParseFailed, "location_id")
+	}
+
+	locationFarmUID := uuid.UUID{}
+
+	switch locationType {
+	case domain.EquipmentLocationArea:
+		result := <-s.AreaReadQuery.FindByID(locationUID)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		area, ok := result.Result.(storage.AreaRead)
+		if !ok {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		locationFarmUID = area.Farm.UID
+	case domain.EquipmentLocationReservoir:
+		result := <-s.ReservoirReadQuery.FindByID(locationUID)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		reservoir, ok := result.Result.(storage.ReservoirRead)
+		if !ok {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		locationFarmUID = reservoir.Farm.UID
+	default:
+		return nil, NewRequestValidationError(InvalidOption, "location_type")
+	}
+
+	if locationFarmUID != farmUID {
+		return nil, NewRequestValidationError(NotFound, "location_id")
+	}
+
+	return &domain.EquipmentLocation{Type: locationType, UID: locationUID}, nil
+}
+
+func parseEquipmentMaintenance(c echo.Context) (intervalDays int, nextDate *time.Time, err error) {
+	if value := c.FormValue("maintenance_interval_days"); value != "" {
+		intervalDays, err = strconv.Atoi(value)
+		if err != nil {
+			return 0, nil, NewRequestValidationError(ParseFailed, "maintenance_interval_days")
+		}
+	}
+
+	if value := c.FormValue("next_maintenance_date"); value != "" {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return 0, nil, NewRequestValidationError(ParseFailed, "next_maintenance_date")
+		}
+
+		nextDate = &date
+	}
+
+	return intervalDays, nextDate, nil
+}
+
+// findEquipment finds the equipment of the equipment_id param and checks it belongs to the farm.
+func (s *FarmServer) findEquipment(c echo.Context) (*domain.Equipment, error) {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return nil, err
+	}
+
+	equipmentUID, err := uuid.FromString(c.Param("equipment_id"))
+	if err != nil {
+		return nil, NewRequestValidationError(NotFound, "equipment_id")
+	}
+
+	e, err := s.findEquipmentFromHistory(equipmentUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.UID != equipmentUID || e.FarmUID != farm.UID || e.IsDeleted {
+		return nil, NewRequestValidationError(NotFound, "equipment_id")
+	}
+
+	return e, nil
+}
+
+func (s *FarmServer) findEquipmentFromHistory(uid uuid.UUID) (*domain.Equipment, error) {
+	result := <-s.EquipmentEventQuery.FindAllByID(uid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	events, ok := result.Result.([]storage.EquipmentEvent)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return repository.NewEquipmentFromHistory(events), nil
+}
+
+func (s *FarmServer) saveEquipment(e *domain.Equipment, by *actor.Actor) error {
+	if len(e.UncommittedChanges) == 0 {
+		return nil
+	}
+
+	err := <-s.EquipmentEventRepo.Save(e.UID, e.Version, e.UncommittedChanges, by)
+	if err != nil {
+		return err
+	}
+
+	s.publishUncommittedEvents(e)
+
+	return nil
+}
+
+func (s *FarmServer) mapToEquipmentDetail(equipment storage.EquipmentRead) (EquipmentDetail, error) {
+	detail := EquipmentDetail{EquipmentRead: equipment}
+
+	result := <-s.EquipmentTaskReadQuery.FindNextByEquipment(equipment.UID)
+	if result.Error != nil {
+		return EquipmentDetail{}, result.Error
+	}
+
+	task, ok := result.Result.(query.EquipmentTaskResult)
+	if !ok {
+		return EquipmentDetail{}, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if task.UID != (uuid.UUID{}) {
+		detail.NextMaintenanceTask = &task
+	}
+
+	return detail, nil
+}
+
+func MapToEquipmentRead(e domain.Equipment) storage.EquipmentRead {
+	return storage.EquipmentRead{
+		UID:                     e.UID,
+		FarmUID:                 e.FarmUID,
+		Name:                    e.Name,
+		Type:                    e.Type,
+		Location:                e.Location,
+		PurchaseDate:            e.PurchaseDate,
+		Notes:                   e.Notes,
+		MaintenanceIntervalDays: e.Maintenance.IntervalDays,
+		NextMaintenanceDate:     e.Maintenance.NextDate,
+		GeoPoint:                e.GeoPoint,
+		IsDeleted:               e.IsDeleted,
+		CreatedDate:             e.CreatedDate,
+	}
+}
+
+func (s *FarmServer) SaveToEquipmentReadModel(event interface{}) error {
+	var uid uuid.UUID
+
+	switch e := event.(type) {
+	case domain.EquipmentCreated:
+		uid = e.UID
+	case domain.EquipmentUpdated:
+		uid = e.UID
+	case domain.EquipmentMaintenanceChanged:
+		uid = e.UID
+	case domain.EquipmentMaintenanceDue:
+		uid = e.UID
+	case domain.EquipmentGeoPointChanged:
+		uid = e.UID
+	case domain.EquipmentDeleted:
+		uid = e.UID
+	default:
+		return errors.New("unknown equipment event")
+	}
+
+	e, err := s.findEquipmentFromHistory(uid)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	equipmentRead := MapToEquipmentRead(*e)
+
+	err = <-s.EquipmentReadRepo.Save(&equipmentRead)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	return nil
+}
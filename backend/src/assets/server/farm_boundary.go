@@ -0,0 +1,371 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+// FarmBoundaryRead is the boundary of a farm with the assets whose GPS coordinate is outside it.
+type FarmBoundaryRead struct {
+	FarmUID       uuid.UUID              `json:"farm_id"`
+	Polygon       []domain.GeoPoint      `json:"polygon"`
+	CreatedDate   time.Time              `json:"created_date"`
+	ModifiedDate  *time.Time             `json:"modified_date"`
+	AssetsOutside []domain.BoundaryAsset `json:"assets_outside"`
+}
+
+func (s *FarmServer) GetFarmBoundary(c echo.Context) error {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	boundary, err := s.findFarmBoundaryFromHistory(farm.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if boundary.FarmUID != farm.UID {
+		return Error(c, NewRequestValidationError(NotFound, "boundary"))
+	}
+
+	assets, err := s.findFarmGeoAssets(farm.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	outside := []domain.BoundaryAsset{}
+
+	for _, v := range assets {
+		if !boundary.Contains(v.Point) {
+			outside = append(outside, v)
+		}
+	}
+
+	data := make(map[string]FarmBoundaryRead)
+	data["data"] = MapToFarmBoundaryRead(*boundary, outside)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// SaveFarmBoundary defines the boundary of the farm or replaces its polygon. The polygon is a JSON list
+// like [{"latitude": -6.2, "longitude": 106.8}, ...]. The areas and the equipment outside the new polygon
+// are kept, they are returned in the warnings.
+func (s *FarmServer) SaveFarmBoundary(c echo.Context) error {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if c.FormValue("polygon") == "" {
+		return Error(c, NewRequestValidationError(Required, "polygon"))
+	}
+
+	polygon := []domain.GeoPoint{}
+
+	err = json.Unmarshal([]byte(c.FormValue("polygon")), &polygon)
+	if err != nil {
+		return Error(c, NewRequestValidationError(ParseFailed, "polygon"))
+	}
+
+	boundary, err := s.findFarmBoundaryFromHistory(farm.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	assets, err := s.findFarmGeoAssets(farm.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	if boundary.FarmUID != farm.UID {
+		boundary, err = domain.DefineFarmBoundary(farm.UID, polygon)
+	} else {
+		err = boundary.Modify(polygon)
+	}
+
+	if err != nil {
+		return Error(c, err)
+	}
+
+	validator := domain.ContainmentValidator{Boundary: boundary}
+	outside := []domain.BoundaryAsset{}
+	warnings := []string{}
+
+	for _, v := range assets {
+		if !validator.Validate(v) {
+			outside = append(outside, v)
+			warnings = append(warnings, boundaryWarning(v))
+		}
+	}
+
+	// PERSIST //
+	err = s.saveFarmBoundary(boundary, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":     MapToFarmBoundaryRead(*boundary, outside),
+		"warnings": warnings,
+	})
+}
+
+// ChangeAreaGeoPoint sets the GPS coordinate of the area from the latitude and longitude params.
+func (s *FarmServer) ChangeAreaGeoPoint(c echo.Context) error {
+	areaUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	point, err := parseGeoPoint(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	result := <-s.AreaEventQuery.FindAllByID(areaUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	events, ok := result.Result.([]storage.AreaEvent)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	area := repository.NewAreaFromHistory(events)
+	if area.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	// PROCESS //
+	err = area.ChangeGeoPoint(point)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	boundary, warnings, err := s.validateBoundaryAsset(area.FarmUID, domain.BoundaryAsset{
+		Type:  domain.BoundaryAssetArea,
+		UID:   area.UID,
+		Name:  area.Name,
+		Point: point,
+	})
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.AreaEventRepo.Save(area.UID, area.Version, area.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(area)
+
+	err = s.saveFarmBoundary(boundary, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	detailArea, err := MapToDetailArea(s, *area)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":     detailArea,
+		"warnings": warnings,
+	})
+}
+
+// ChangeEquipmentGeoPoint sets the GPS coordinate of the equipment from the latitude and longitude params.
+func (s *FarmServer) ChangeEquipmentGeoPoint(c echo.Context) error {
+	e, err := s.findEquipment(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	point, err := parseGeoPoint(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	err = e.ChangeGeoPoint(point)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	boundary, warnings, err := s.validateBoundaryAsset(e.FarmUID, domain.BoundaryAsset{
+		Type:  domain.BoundaryAssetEquipment,
+		UID:   e.UID,
+		Name:  e.Name,
+		Point: point,
+	})
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = s.saveEquipment(e, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = s.saveFarmBoundary(boundary, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":     MapToEquipmentRead(*e),
+		"warnings": warnings,
+	})
+}
+
+func parseGeoPoint(c echo.Context) (domain.GeoPoint, error) {
+	point := domain.GeoPoint{}
+
+	for _, v := range []struct {
+		name  string
+		value *float64
+	}{
+		{"latitude", &point.Latitude},
+		{"longitude", &point.Longitude},
+	} {
+		value := c.FormValue(v.name)
+		if value == "" {
+			return domain.GeoPoint{}, NewRequestValidationError(Required, v.name)
+		}
+
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return domain.GeoPoint{}, NewRequestValidationError(Numeric, v.name)
+		}
+
+		*v.value = parsed
+	}
+
+	return point, nil
+}
+
+// validateBoundaryAsset checks the asset against the boundary of its farm. The boundary is returned with
+// the warning event to save, it's nil when the farm has no boundary.
+func (s *FarmServer) validateBoundaryAsset(
+	farmUID uuid.UUID,
+	asset domain.BoundaryAsset,
+) (*domain.FarmBoundary, []string, error) {
+	boundary, err := s.findFarmBoundaryFromHistory(farmUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if boundary.FarmUID != farmUID {
+		return nil, []string{}, nil
+	}
+
+	warnings := []string{}
+	if !(domain.ContainmentValidator{Boundary: boundary}).Validate(asset) {
+		warnings = append(warnings, boundaryWarning(asset))
+	}
+
+	return boundary, warnings, nil
+}
+
+func boundaryWarning(asset domain.BoundaryAsset) string {
+	return fmt.Sprintf("The %s %s is outside the farm boundary.", strings.ToLower(asset.Type), asset.Name)
+}
+
+// findFarmGeoAssets finds the areas and the equipment of the farm which have a GPS coordinate.
+func (s *FarmServer) findFarmGeoAssets(farmUID uuid.UUID) ([]domain.BoundaryAsset, error) {
+	result := <-s.AreaReadQuery.FindAllByFarm(farmUID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	areas, ok := result.Result.([]storage.AreaRead)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	result = <-s.EquipmentReadQuery.FindAllByFarm(farmUID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	equipment, ok := result.Result.([]storage.EquipmentRead)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	assets := []domain.BoundaryAsset{}
+
+	for _, v := range areas {
+		if v.GeoPoint != nil {
+			assets = append(assets, domain.BoundaryAsset{
+				Type: domain.BoundaryAssetArea, UID: v.UID, Name: v.Name, Point: *v.GeoPoint,
+			})
+		}
+	}
+
+	for _, v := range equipment {
+		if v.GeoPoint != nil {
+			assets = append(assets, domain.BoundaryAsset{
+				Type: domain.BoundaryAssetEquipment, UID: v.UID, Name: v.Name, Point: *v.GeoPoint,
+			})
+		}
+	}
+
+	return assets, nil
+}
+
+func (s *FarmServer) findFarmBoundaryFromHistory(farmUID uuid.UUID) (*domain.FarmBoundary, error) {
+	result := <-s.FarmBoundaryEventQuery.FindAllByID(farmUID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	events, ok := result.Result.([]storage.FarmBoundaryEvent)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return repository.NewFarmBoundaryFromHistory(events), nil
+}
+
+func (s *FarmServer) saveFarmBoundary(boundary *domain.FarmBoundary, by *actor.Actor) error {
+	if boundary == nil || len(boundary.UncommittedChanges) == 0 {
+		return nil
+	}
+
+	err := <-s.FarmBoundaryEventRepo.Save(boundary.FarmUID, boundary.Version, boundary.UncommittedChanges, by)
+	if err != nil {
+		return err
+	}
+
+	s.publishUncommittedEvents(boundary)
+
+	return nil
+}
+
+func MapToFarmBoundaryRead(boundary domain.FarmBoundary, outside []domain.BoundaryAsset) FarmBoundaryRead {
+	return FarmBoundaryRead{
+		FarmUID:       boundary.FarmUID,
+		Polygon:       boundary.Polygon,
+		CreatedDate:   boundary.CreatedDate,
+		ModifiedDate:  boundary.ModifiedDate,
+		AssetsOutside: outside,
+	}
+}
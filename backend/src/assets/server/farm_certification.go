@@ -0,0 +1,359 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+// certificationRenewalInterval is how often the certifications close to their expiry are checked.
+const certificationRenewalInterval = time.Hour
+
+// StartCertificationScheduler requests the renewal of the certifications close to their expiry
+// right away and then every hour. The renewal tasks are created by the tasks module.
+// It skips its runs while paused is true.
+func (s *FarmServer) StartCertificationScheduler(paused func() bool) {
+	ticker := time.NewTicker(certificationRenewalInterval)
+
+	go func() {
+		for {
+			if paused() {
+				log.Println("Certification scheduler paused by the maintenance mode")
+			} else {
+				s.requestCertificationRenewals(time.Now())
+			}
+
+			<-ticker.C
+		}
+	}()
+}
+
+func (s *FarmServer) requestCertificationRenewals(now time.Time) {
+	result := <-s.FarmCertificationReadQuery.FindAllRenewalDue(now.Add(domain.CertificationRenewalNotice))
+	if result.Error != nil {
+		log.Println("Certification renewal check failed", result.Error)
+
+		return
+	}
+
+	certifications, ok := result.Result.([]storage.FarmCertificationRead)
+	if !ok {
+		log.Println(errors.New("internal server error. error type assertion"))
+
+		return
+	}
+
+	for _, v := range certifications {
+		certification, err := s.findFarmCertificationFromHistory(v.UID)
+		if err != nil {
+			log.Println("Certification", v.UID, "cannot be loaded", err)
+
+			continue
+		}
+
+		certification.RequestRenewal(now)
+
+		err = s.saveFarmCertification(certification, actor.System("certification_renewal"))
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+func (*FarmServer) GetCertificationTypes(c echo.Context) error {
+	return c.JSON(http.StatusOK, domain.FindAllCertificationTypes())
+}
+
+func (s *FarmServer) FindFarmCertifications(c echo.Context) error {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	result := <-s.FarmCertificationReadQuery.FindAllByFarm(farm.UID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	certifications, ok := result.Result.([]storage.FarmCertificationRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	now := time.Now()
+	for i := range certifications {
+		certifications[i] = MapToFarmCertificationStatus(certifications[i], now)
+	}
+
+	data := make(map[string][]storage.FarmCertificationRead)
+	data["data"] = certifications
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *FarmServer) SaveFarmCertification(c echo.Context) error {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	issuedDate, expiryDate, err := parseCertificationDates(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	certification, err := domain.GrantFarmCertification(
+		farm.UID,
+		c.FormValue("certification_type"),
+		c.FormValue("certifying_body"),
+		c.FormValue("certificate_number"),
+		issuedDate,
+		expiryDate,
+	)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// A certificate granted close to its expiry gets its renewal task right away.
+	certification.RequestRenewal(time.Now())
+
+	// PERSIST //
+	err = s.saveFarmCertification(certification, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.FarmCertificationRead)
+	data["data"] = MapToFarmCertificationStatus(MapToFarmCertificationRead(*certification), time.Now())
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *FarmServer) RenewFarmCertification(c echo.Context) error {
+	certification, err := s.findFarmCertification(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	issuedDate, expiryDate, err := parseCertificationDates(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	certifyingBody := c.FormValue("certifying_body")
+	if certifyingBody == "" {
+		certifyingBody = certification.CertifyingBody
+	}
+
+	// PROCESS //
+	err = certification.Renew(certifyingBody, c.FormValue("certificate_number"), issuedDate, expiryDate)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	certification.RequestRenewal(time.Now())
+
+	// PERSIST //
+	err = s.saveFarmCertification(certification, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.FarmCertificationRead)
+	data["data"] = MapToFarmCertificationStatus(MapToFarmCertificationRead(*certification), time.Now())
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *FarmServer) RevokeFarmCertification(c echo.Context) error {
+	certification, err := s.findFarmCertification(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	err = certification.Revoke(time.Now())
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = s.saveFarmCertification(certification, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.FarmCertificationRead)
+	data["data"] = MapToFarmCertificationRead(*certification)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func parseCertificationDates(c echo.Context) (issuedDate, expiryDate time.Time, err error) {
+	if c.FormValue("issued_date") == "" {
+		return time.Time{}, time.Time{}, NewRequestValidationError(Required, "issued_date")
+	}
+
+	issuedDate, err = time.Parse("2006-01-02", c.FormValue("issued_date"))
+	if err != nil {
+		return time.Time{}, time.Time{}, NewRequestValidationError(ParseFailed, "issued_date")
+	}
+
+	if c.FormValue("expiry_date") == "" {
+		return time.Time{}, time.Time{}, NewRequestValidationError(Required, "expiry_date")
+	}
+
+	expiryDate, err = time.Parse("2006-01-02", c.FormValue("expiry_date"))
+	if err != nil {
+		return time.Time{}, time.Time{}, NewRequestValidationError(ParseFailed, "expiry_date")
+	}
+
+	return issuedDate, expiryDate, nil
+}
+
+func (s *FarmServer) findFarm(c echo.Context) (storage.FarmRead, error) {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return storage.FarmRead{}, NewRequestValidationError(NotFound, "id")
+	}
+
+	result := <-s.FarmReadQuery.FindByID(farmUID)
+	if result.Error != nil {
+		return storage.FarmRead{}, result.Error
+	}
+
+	farm, ok := result.Result.(storage.FarmRead)
+	if !ok {
+		return storage.FarmRead{}, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if farm.UID == (uuid.UUID{}) {
+		return storage.FarmRead{}, NewRequestValidationError(NotFound, "id")
+	}
+
+	return farm, nil
+}
+
+// findFarmCertification finds the certification of the certification_id param and checks it belongs to the farm.
+func (s *FarmServer) findFarmCertification(c echo.Context) (*domain.FarmCertification, error) {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return nil, err
+	}
+
+	certificationUID, err := uuid.FromString(c.Param("certification_id"))
+	if err != nil {
+		return nil, NewRequestValidationError(NotFound, "certification_id")
+	}
+
+	certification, err := s.findFarmCertificationFromHistory(certificationUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if certification.UID != certificationUID || certification.FarmID != farm.UID {
+		return nil, NewRequestValidationError(NotFound, "certification_id")
+	}
+
+	return certification, nil
+}
+
+func (s *FarmServer) findFarmCertificationFromHistory(uid uuid.UUID) (*domain.FarmCertification, error) {
+	result := <-s.FarmCertificationEventQuery.FindAllByID(uid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	events, ok := result.Result.([]storage.FarmCertificationEvent)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return repository.NewFarmCertificationFromHistory(events), nil
+}
+
+func (s *FarmServer) saveFarmCertification(certification *domain.FarmCertification, by *actor.Actor) error {
+	if len(certification.UncommittedChanges) == 0 {
+		return nil
+	}
+
+	err := <-s.FarmCertificationEventRepo.Save(
+		certification.UID, certification.Version, certification.UncommittedChanges, by,
+	)
+	if err != nil {
+		return err
+	}
+
+	s.publishUncommittedEvents(certification)
+
+	return nil
+}
+
+func MapToFarmCertificationRead(certification domain.FarmCertification) storage.FarmCertificationRead {
+	return storage.FarmCertificationRead{
+		UID:               certification.UID,
+		FarmUID:           certification.FarmID,
+		CertificationType: certification.CertificationType,
+		CertifyingBody:    certification.CertifyingBody,
+		IssuedDate:        certification.IssuedDate,
+		ExpiryDate:        certification.ExpiryDate,
+		CertificateNumber: certification.CertificateNumber,
+		Status:            certification.Status,
+		IsRenewalDue:      certification.IsRenewalDue,
+		RevokedDate:       certification.RevokedDate,
+		CreatedDate:       certification.CreatedDate,
+	}
+}
+
+// MapToFarmCertificationStatus shows the active certifications past their expiry date as expired.
+func MapToFarmCertificationStatus(certification storage.FarmCertificationRead, now time.Time) storage.FarmCertificationRead {
+	if certification.Status == domain.CertificationStatusActive && certification.ExpiryDate.Before(now) {
+		certification.Status = domain.CertificationStatusExpired
+	}
+
+	return certification
+}
+
+func (s *FarmServer) SaveToFarmCertificationReadModel(event interface{}) error {
+	var uid uuid.UUID
+
+	switch e := event.(type) {
+	case domain.CertificationGranted:
+		uid = e.UID
+	case domain.CertificationRenewed:
+		uid = e.UID
+	case domain.CertificationRevoked:
+		uid = e.UID
+	case domain.CertificationRenewalDue:
+		uid = e.UID
+	default:
+		return errors.New("unknown farm certification event")
+	}
+
+	certification, err := s.findFarmCertificationFromHistory(uid)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	certificationRead := MapToFarmCertificationRead(*certification)
+
+	err = <-s.FarmCertificationReadRepo.Save(&certificationRead)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	return nil
+}
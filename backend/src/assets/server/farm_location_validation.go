@@ -0,0 +1,81 @@
+package server
+
+import (
+	"strconv"
+	"strings"
+
+	location "github.com/usetania/tania-core/src/location/server"
+)
+
+// RequestValidationErrors is the error of a request with several invalid fields.
+// Its first violation is the error of the response, like the other validation errors, and it lists them all.
+type RequestValidationErrors struct {
+	RequestValidationError
+	Errors []RequestValidationError `json:"errors"`
+}
+
+func NewRequestValidationErrors(violations []RequestValidationError) RequestValidationErrors {
+	return RequestValidationErrors{
+		RequestValidationError: violations[0],
+		Errors:                 violations,
+	}
+}
+
+func (e RequestValidationErrors) Error() string {
+	messages := []string{}
+	for _, v := range e.Errors {
+		messages = append(messages, v.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// validateFarmLocation checks the coordinates and the region of a farm against the locations dataset.
+// The pairs left empty aren't checked, like in a farm update changing only one of them.
+func validateFarmLocation(latitude, longitude, country, city string, required bool) error {
+	violations := []RequestValidationError{}
+
+	if required || latitude != "" || longitude != "" {
+		violations = append(violations, validateCoordinate("latitude", latitude, 90)...)
+		violations = append(violations, validateCoordinate("longitude", longitude, 180)...)
+	}
+
+	if required || country != "" || city != "" {
+		switch {
+		case country == "":
+			violations = append(violations, NewRequestValidationError(Required, "country"))
+		case !location.IsCountry(country):
+			violations = append(violations, NewRequestValidationError(InvalidOption, "country"))
+		}
+
+		switch {
+		case city == "":
+			violations = append(violations, NewRequestValidationError(Required, "city"))
+		case location.IsCountry(country) && !location.IsCityOf(country, city):
+			violations = append(violations, NewRequestValidationError(InvalidOption, "city"))
+		}
+	}
+
+	if len(violations) > 0 {
+		return NewRequestValidationErrors(violations)
+	}
+
+	return nil
+}
+
+func validateCoordinate(field, value string, bound float64) []RequestValidationError {
+	if value == "" {
+		return []RequestValidationError{NewRequestValidationError(Required, field)}
+	}
+
+	coordinate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return []RequestValidationError{NewRequestValidationError(Float, field)}
+	}
+
+	if coordinate < -bound || coordinate > bound {
+		return []RequestValidationError{NewRequestValidationError(OutOfRange, field)}
+	}
+
+	return nil
+}
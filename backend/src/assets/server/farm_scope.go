@@ -0,0 +1,180 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+// The resolvers below find the farm of the entity of a route param in its read model,
+// so the farm scoped routes can check it before the handler runs.
+
+func (s *FarmServer) farmScope(param string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity("", func(c echo.Context) (uuid.UUID, error) {
+		farmUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		result := <-s.FarmReadQuery.FindByID(farmUID)
+		if result.Error != nil {
+			return uuid.Nil, result.Error
+		}
+
+		farm, ok := result.Result.(storage.FarmRead)
+		if !ok {
+			return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		return farm.UID, nil
+	})
+}
+
+func (s *FarmServer) reservoirScope(param, farmParam string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity(farmParam, func(c echo.Context) (uuid.UUID, error) {
+		reservoirUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		result := <-s.ReservoirReadQuery.FindByID(reservoirUID)
+		if result.Error != nil {
+			return uuid.Nil, result.Error
+		}
+
+		reservoir, ok := result.Result.(storage.ReservoirRead)
+		if !ok {
+			return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		return reservoir.Farm.UID, nil
+	})
+}
+
+func (s *FarmServer) areaScope(param, farmParam string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity(farmParam, func(c echo.Context) (uuid.UUID, error) {
+		areaUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		result := <-s.AreaReadQuery.FindByID(areaUID)
+		if result.Error != nil {
+			return uuid.Nil, result.Error
+		}
+
+		area, ok := result.Result.(storage.AreaRead)
+		if !ok {
+			return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		return area.Farm.UID, nil
+	})
+}
+
+func (s *FarmServer) certificationScope(param, farmParam string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity(farmParam, func(c echo.Context) (uuid.UUID, error) {
+		certificationUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		result := <-s.FarmCertificationReadQuery.FindByID(certificationUID)
+		if result.Error != nil {
+			return uuid.Nil, result.Error
+		}
+
+		certification, ok := result.Result.(storage.FarmCertificationRead)
+		if !ok {
+			return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		return certification.FarmUID, nil
+	})
+}
+
+func (s *FarmServer) customFieldScope(param, farmParam string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity(farmParam, func(c echo.Context) (uuid.UUID, error) {
+		definitionUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		result := <-s.CustomFieldDefinitionReadQuery.FindByID(definitionUID)
+		if result.Error != nil {
+			return uuid.Nil, result.Error
+		}
+
+		definition, ok := result.Result.(storage.CustomFieldDefinitionRead)
+		if !ok {
+			return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		return definition.FarmUID, nil
+	})
+}
+
+func (s *FarmServer) stocktakeScope(param, farmParam string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity(farmParam, func(c echo.Context) (uuid.UUID, error) {
+		stocktakeUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		result := <-s.StocktakeReadQuery.FindByID(stocktakeUID)
+		if result.Error != nil {
+			return uuid.Nil, result.Error
+		}
+
+		stocktake, ok := result.Result.(storage.StocktakeRead)
+		if !ok {
+			return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		return stocktake.FarmUID, nil
+	})
+}
+
+func (s *FarmServer) equipmentScope(param, farmParam string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity(farmParam, func(c echo.Context) (uuid.UUID, error) {
+		equipmentUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		result := <-s.EquipmentReadQuery.FindByID(equipmentUID)
+		if result.Error != nil {
+			return uuid.Nil, result.Error
+		}
+
+		equipment, ok := result.Result.(storage.EquipmentRead)
+		if !ok {
+			return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		return equipment.FarmUID, nil
+	})
+}
+
+func (s *FarmServer) nutrientRecipeScope(param, farmParam string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity(farmParam, func(c echo.Context) (uuid.UUID, error) {
+		recipeUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		result := <-s.NutrientRecipeReadQuery.FindByID(recipeUID)
+		if result.Error != nil {
+			return uuid.Nil, result.Error
+		}
+
+		recipe, ok := result.Result.(storage.NutrientRecipeRead)
+		if !ok {
+			return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		return recipe.FarmUID, nil
+	})
+}
@@ -10,23 +10,32 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/assets/domain"
 	"github.com/usetania/tania-core/src/assets/domain/service"
 	"github.com/usetania/tania-core/src/assets/query"
 	queryInMem "github.com/usetania/tania-core/src/assets/query/inmemory"
 	queryMysql "github.com/usetania/tania-core/src/assets/query/mysql"
+	queryRedis "github.com/usetania/tania-core/src/assets/query/redis"
 	querySqlite "github.com/usetania/tania-core/src/assets/query/sqlite"
 	"github.com/usetania/tania-core/src/assets/repository"
 	repoInMem "github.com/usetania/tania-core/src/assets/repository/inmemory"
 	repoMysql "github.com/usetania/tania-core/src/assets/repository/mysql"
+	repoRedis "github.com/usetania/tania-core/src/assets/repository/redis"
 	repoSqlite "github.com/usetania/tania-core/src/assets/repository/sqlite"
 	"github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/customfield"
 	"github.com/usetania/tania-core/src/eventbus"
+	"github.com/usetania/tania-core/src/farmscope"
+	"github.com/usetania/tania-core/src/featureflags"
 	growthstorage "github.com/usetania/tania-core/src/growth/storage"
 	"github.com/usetania/tania-core/src/helper/imagehelper"
 	"github.com/usetania/tania-core/src/helper/paginationhelper"
 	"github.com/usetania/tania-core/src/helper/stringhelper"
 	"github.com/usetania/tania-core/src/helper/structhelper"
+	"github.com/usetania/tania-core/src/orphans"
+	redisstorage "github.com/usetania/tania-core/src/storage/redis"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
 )
 
 // FarmServer ties the routes and handlers with injected dependencies.
@@ -52,6 +61,46 @@ type FarmServer struct {
 	CropReadQuery       query.CropRead
 	File                File
 	EventBus            eventbus.TaniaEventBus
+	FarmScope           farmscope.Scope
+
+	FarmCertificationEventRepo  repository.FarmCertificationEvent
+	FarmCertificationEventQuery query.FarmCertificationEvent
+	FarmCertificationReadRepo   repository.FarmCertificationRead
+	FarmCertificationReadQuery  query.FarmCertificationRead
+
+	CustomFieldDefinitionEventRepo  repository.CustomFieldDefinitionEvent
+	CustomFieldDefinitionEventQuery query.CustomFieldDefinitionEvent
+	CustomFieldDefinitionReadRepo   repository.CustomFieldDefinitionRead
+	CustomFieldDefinitionReadQuery  query.CustomFieldDefinitionRead
+	CustomFields                    *customfield.Service
+
+	StocktakeEventRepo  repository.StocktakeEvent
+	StocktakeEventQuery query.StocktakeEvent
+	StocktakeReadRepo   repository.StocktakeRead
+	StocktakeReadQuery  query.StocktakeRead
+
+	EquipmentEventRepo     repository.EquipmentEvent
+	EquipmentEventQuery    query.EquipmentEvent
+	EquipmentReadRepo      repository.EquipmentRead
+	EquipmentReadQuery     query.EquipmentRead
+	EquipmentTaskReadQuery query.EquipmentTaskRead
+
+	NutrientRecipeEventRepo  repository.NutrientRecipeEvent
+	NutrientRecipeEventQuery query.NutrientRecipeEvent
+	NutrientRecipeReadRepo   repository.NutrientRecipeRead
+	NutrientRecipeReadQuery  query.NutrientRecipeRead
+
+	FarmBoundaryEventRepo  repository.FarmBoundaryEvent
+	FarmBoundaryEventQuery query.FarmBoundaryEvent
+
+	AreaTaskReadQuery query.AreaTaskRead
+
+	// PhotoReferences tells whether the file of a removed photo is still used,
+	// without it the file is left to the orphan cleanup.
+	PhotoReferences orphans.References
+
+	// Flags turns the equipment routes and the maintenance scheduler on and off.
+	Flags *featureflags.Registry
 }
 
 // NewFarmServer initializes FarmServer's dependencies and create new FarmServer struct.
@@ -65,12 +114,34 @@ func NewFarmServer(
 	reservoirReadStorage *storage.ReservoirReadStorage,
 	materialEventStorage *storage.MaterialEventStorage,
 	materialReadStorage *storage.MaterialReadStorage,
+	farmCertificationEventStorage *storage.FarmCertificationEventStorage,
+	farmCertificationReadStorage *storage.FarmCertificationReadStorage,
+	customFieldDefinitionEventStorage *storage.CustomFieldDefinitionEventStorage,
+	customFieldDefinitionReadStorage *storage.CustomFieldDefinitionReadStorage,
+	customFieldValueStorage *customfield.ValueStorage,
+	stocktakeEventStorage *storage.StocktakeEventStorage,
+	stocktakeReadStorage *storage.StocktakeReadStorage,
+	equipmentEventStorage *storage.EquipmentEventStorage,
+	equipmentReadStorage *storage.EquipmentReadStorage,
+	nutrientRecipeEventStorage *storage.NutrientRecipeEventStorage,
+	nutrientRecipeReadStorage *storage.NutrientRecipeReadStorage,
+	farmBoundaryEventStorage *storage.FarmBoundaryEventStorage,
 	cropReadStorage *growthstorage.CropReadStorage,
+	taskReadStorage *taskstorage.TaskReadStorage,
 	eventBus eventbus.TaniaEventBus,
+	flags *featureflags.Registry,
 ) (*FarmServer, error) {
+	// The topics of the bus are scoped to the tenant of the deployment.
+	eventBus, err := eventbus.ForTenant(eventBus)
+	if err != nil {
+		return nil, err
+	}
+
 	farmServer := &FarmServer{
-		File:     LocalFile{},
-		EventBus: eventBus,
+		File:      LocalFile{},
+		EventBus:  eventBus,
+		FarmScope: farmscope.NewScope(Error),
+		Flags:     flags,
 	}
 
 	switch *config.Config.TaniaPersistenceEngine {
@@ -95,6 +166,39 @@ func NewFarmServer(
 		farmServer.MaterialReadRepo = repoInMem.NewMaterialReadRepositoryInMemory(materialReadStorage)
 		farmServer.MaterialReadQuery = queryInMem.NewMaterialReadQueryInMemory(materialReadStorage)
 
+		farmServer.FarmCertificationEventRepo = repoInMem.NewFarmCertificationEventRepositoryInMemory(farmCertificationEventStorage)
+		farmServer.FarmCertificationEventQuery = queryInMem.NewFarmCertificationEventQueryInMemory(farmCertificationEventStorage)
+		farmServer.FarmCertificationReadRepo = repoInMem.NewFarmCertificationReadRepositoryInMemory(farmCertificationReadStorage)
+		farmServer.FarmCertificationReadQuery = queryInMem.NewFarmCertificationReadQueryInMemory(farmCertificationReadStorage)
+
+		farmServer.CustomFieldDefinitionEventRepo = repoInMem.NewCustomFieldDefinitionEventRepositoryInMemory(customFieldDefinitionEventStorage)
+		farmServer.CustomFieldDefinitionEventQuery = queryInMem.NewCustomFieldDefinitionEventQueryInMemory(customFieldDefinitionEventStorage)
+		farmServer.CustomFieldDefinitionReadRepo = repoInMem.NewCustomFieldDefinitionReadRepositoryInMemory(customFieldDefinitionReadStorage)
+		farmServer.CustomFieldDefinitionReadQuery = queryInMem.NewCustomFieldDefinitionReadQueryInMemory(customFieldDefinitionReadStorage)
+		farmServer.CustomFields = customfield.NewService(
+			customfield.NewStoreInMemory(customFieldValueStorage, customFieldDefinitionReadStorage), eventBus)
+
+		farmServer.StocktakeEventRepo = repoInMem.NewStocktakeEventRepositoryInMemory(stocktakeEventStorage)
+		farmServer.StocktakeEventQuery = queryInMem.NewStocktakeEventQueryInMemory(stocktakeEventStorage)
+		farmServer.StocktakeReadRepo = repoInMem.NewStocktakeReadRepositoryInMemory(stocktakeReadStorage)
+		farmServer.StocktakeReadQuery = queryInMem.NewStocktakeReadQueryInMemory(stocktakeReadStorage)
+
+		farmServer.EquipmentEventRepo = repoInMem.NewEquipmentEventRepositoryInMemory(equipmentEventStorage)
+		farmServer.EquipmentEventQuery = queryInMem.NewEquipmentEventQueryInMemory(equipmentEventStorage)
+		farmServer.EquipmentReadRepo = repoInMem.NewEquipmentReadRepositoryInMemory(equipmentReadStorage)
+		farmServer.EquipmentReadQuery = queryInMem.NewEquipmentReadQueryInMemory(equipmentReadStorage)
+		farmServer.EquipmentTaskReadQuery = queryInMem.NewEquipmentTaskReadQueryInMemory(taskReadStorage)
+
+		farmServer.NutrientRecipeEventRepo = repoInMem.NewNutrientRecipeEventRepositoryInMemory(nutrientRecipeEventStorage)
+		farmServer.NutrientRecipeEventQuery = queryInMem.NewNutrientRecipeEventQueryInMemory(nutrientRecipeEventStorage)
+		farmServer.NutrientRecipeReadRepo = repoInMem.NewNutrientRecipeReadRepositoryInMemory(nutrientRecipeReadStorage)
+		farmServer.NutrientRecipeReadQuery = queryInMem.NewNutrientRecipeReadQueryInMemory(nutrientRecipeReadStorage)
+
+		farmServer.FarmBoundaryEventRepo = repoInMem.NewFarmBoundaryEventRepositoryInMemory(farmBoundaryEventStorage)
+		farmServer.FarmBoundaryEventQuery = queryInMem.NewFarmBoundaryEventQueryInMemory(farmBoundaryEventStorage)
+
+		farmServer.AreaTaskReadQuery = queryInMem.NewAreaTaskReadQueryInMemory(taskReadStorage)
+
 		farmServer.CropReadQuery = queryInMem.NewCropReadQueryInMemory(cropReadStorage)
 
 		// TODO: AreaServiceInMemory should be renamed. It doesn't need InMemory name
@@ -102,6 +206,8 @@ func NewFarmServer(
 			FarmReadQuery:      farmServer.FarmReadQuery,
 			ReservoirReadQuery: farmServer.ReservoirReadQuery,
 			CropReadQuery:      farmServer.CropReadQuery,
+
+			CustomFieldDefinitionReadQuery: farmServer.CustomFieldDefinitionReadQuery,
 		}
 		// TODO: ReservoirServiceInMemory should be renamed. It doesn't need InMemory name
 		farmServer.ReservoirService = service.ReservoirServiceInMemory{
@@ -129,6 +235,38 @@ func NewFarmServer(
 		farmServer.MaterialReadRepo = repoSqlite.NewMaterialReadRepositorySqlite(db)
 		farmServer.MaterialReadQuery = querySqlite.NewMaterialReadQuerySqlite(db)
 
+		farmServer.FarmCertificationEventRepo = repoSqlite.NewFarmCertificationEventRepositorySqlite(db)
+		farmServer.FarmCertificationEventQuery = querySqlite.NewFarmCertificationEventQuerySqlite(db)
+		farmServer.FarmCertificationReadRepo = repoSqlite.NewFarmCertificationReadRepositorySqlite(db)
+		farmServer.FarmCertificationReadQuery = querySqlite.NewFarmCertificationReadQuerySqlite(db)
+
+		farmServer.CustomFieldDefinitionEventRepo = repoSqlite.NewCustomFieldDefinitionEventRepositorySqlite(db)
+		farmServer.CustomFieldDefinitionEventQuery = querySqlite.NewCustomFieldDefinitionEventQuerySqlite(db)
+		farmServer.CustomFieldDefinitionReadRepo = repoSqlite.NewCustomFieldDefinitionReadRepositorySqlite(db)
+		farmServer.CustomFieldDefinitionReadQuery = querySqlite.NewCustomFieldDefinitionReadQuerySqlite(db)
+		farmServer.CustomFields = customfield.NewService(customfield.NewStoreSqlite(db), eventBus)
+
+		farmServer.StocktakeEventRepo = repoSqlite.NewStocktakeEventRepositorySqlite(db)
+		farmServer.StocktakeEventQuery = querySqlite.NewStocktakeEventQuerySqlite(db)
+		farmServer.StocktakeReadRepo = repoSqlite.NewStocktakeReadRepositorySqlite(db)
+		farmServer.StocktakeReadQuery = querySqlite.NewStocktakeReadQuerySqlite(db)
+
+		farmServer.EquipmentEventRepo = repoSqlite.NewEquipmentEventRepositorySqlite(db)
+		farmServer.EquipmentEventQuery = querySqlite.NewEquipmentEventQuerySqlite(db)
+		farmServer.EquipmentReadRepo = repoSqlite.NewEquipmentReadRepositorySqlite(db)
+		farmServer.EquipmentReadQuery = querySqlite.NewEquipmentReadQuerySqlite(db)
+		farmServer.EquipmentTaskReadQuery = querySqlite.NewEquipmentTaskReadQuerySqlite(db)
+
+		farmServer.NutrientRecipeEventRepo = repoSqlite.NewNutrientRecipeEventRepositorySqlite(db)
+		farmServer.NutrientRecipeEventQuery = querySqlite.NewNutrientRecipeEventQuerySqlite(db)
+		farmServer.NutrientRecipeReadRepo = repoSqlite.NewNutrientRecipeReadRepositorySqlite(db)
+		farmServer.NutrientRecipeReadQuery = querySqlite.NewNutrientRecipeReadQuerySqlite(db)
+
+		farmServer.FarmBoundaryEventRepo = repoSqlite.NewFarmBoundaryEventRepositorySqlite(db)
+		farmServer.FarmBoundaryEventQuery = querySqlite.NewFarmBoundaryEventQuerySqlite(db)
+
+		farmServer.AreaTaskReadQuery = querySqlite.NewAreaTaskReadQuerySqlite(db)
+
 		farmServer.CropReadQuery = querySqlite.NewCropReadQuerySqlite(db)
 
 		// TODO: AreaServiceInMemory should be renamed. It doesn't need InMemory name
@@ -136,6 +274,8 @@ func NewFarmServer(
 			FarmReadQuery:      farmServer.FarmReadQuery,
 			ReservoirReadQuery: farmServer.ReservoirReadQuery,
 			CropReadQuery:      farmServer.CropReadQuery,
+
+			CustomFieldDefinitionReadQuery: farmServer.CustomFieldDefinitionReadQuery,
 		}
 		// TODO: ReservoirServiceInMemory should be renamed. It doesn't need InMemory name
 		farmServer.ReservoirService = service.ReservoirServiceInMemory{
@@ -163,6 +303,38 @@ func NewFarmServer(
 		farmServer.MaterialReadRepo = repoMysql.NewMaterialReadRepositoryMysql(db)
 		farmServer.MaterialReadQuery = queryMysql.NewMaterialReadQueryMysql(db)
 
+		farmServer.FarmCertificationEventRepo = repoMysql.NewFarmCertificationEventRepositoryMysql(db)
+		farmServer.FarmCertificationEventQuery = queryMysql.NewFarmCertificationEventQueryMysql(db)
+		farmServer.FarmCertificationReadRepo = repoMysql.NewFarmCertificationReadRepositoryMysql(db)
+		farmServer.FarmCertificationReadQuery = queryMysql.NewFarmCertificationReadQueryMysql(db)
+
+		farmServer.CustomFieldDefinitionEventRepo = repoMysql.NewCustomFieldDefinitionEventRepositoryMysql(db)
+		farmServer.CustomFieldDefinitionEventQuery = queryMysql.NewCustomFieldDefinitionEventQueryMysql(db)
+		farmServer.CustomFieldDefinitionReadRepo = repoMysql.NewCustomFieldDefinitionReadRepositoryMysql(db)
+		farmServer.CustomFieldDefinitionReadQuery = queryMysql.NewCustomFieldDefinitionReadQueryMysql(db)
+		farmServer.CustomFields = customfield.NewService(customfield.NewStoreMysql(db), eventBus)
+
+		farmServer.StocktakeEventRepo = repoMysql.NewStocktakeEventRepositoryMysql(db)
+		farmServer.StocktakeEventQuery = queryMysql.NewStocktakeEventQueryMysql(db)
+		farmServer.StocktakeReadRepo = repoMysql.NewStocktakeReadRepositoryMysql(db)
+		farmServer.StocktakeReadQuery = queryMysql.NewStocktakeReadQueryMysql(db)
+
+		farmServer.EquipmentEventRepo = repoMysql.NewEquipmentEventRepositoryMysql(db)
+		farmServer.EquipmentEventQuery = queryMysql.NewEquipmentEventQueryMysql(db)
+		farmServer.EquipmentReadRepo = repoMysql.NewEquipmentReadRepositoryMysql(db)
+		farmServer.EquipmentReadQuery = queryMysql.NewEquipmentReadQueryMysql(db)
+		farmServer.EquipmentTaskReadQuery = queryMysql.NewEquipmentTaskReadQueryMysql(db)
+
+		farmServer.NutrientRecipeEventRepo = repoMysql.NewNutrientRecipeEventRepositoryMysql(db)
+		farmServer.NutrientRecipeEventQuery = queryMysql.NewNutrientRecipeEventQueryMysql(db)
+		farmServer.NutrientRecipeReadRepo = repoMysql.NewNutrientRecipeReadRepositoryMysql(db)
+		farmServer.NutrientRecipeReadQuery = queryMysql.NewNutrientRecipeReadQueryMysql(db)
+
+		farmServer.FarmBoundaryEventRepo = repoMysql.NewFarmBoundaryEventRepositoryMysql(db)
+		farmServer.FarmBoundaryEventQuery = queryMysql.NewFarmBoundaryEventQueryMysql(db)
+
+		farmServer.AreaTaskReadQuery = queryMysql.NewAreaTaskReadQueryMysql(db)
+
 		farmServer.CropReadQuery = queryMysql.NewCropReadQueryMysql(db)
 
 		// TODO: AreaServiceInMemory should be renamed. It doesn't need InMemory name
@@ -170,6 +342,8 @@ func NewFarmServer(
 			FarmReadQuery:      farmServer.FarmReadQuery,
 			ReservoirReadQuery: farmServer.ReservoirReadQuery,
 			CropReadQuery:      farmServer.CropReadQuery,
+
+			CustomFieldDefinitionReadQuery: farmServer.CustomFieldDefinitionReadQuery,
 		}
 		// TODO: ReservoirServiceInMemory should be renamed. It doesn't need InMemory name
 		farmServer.ReservoirService = service.ReservoirServiceInMemory{
@@ -182,6 +356,19 @@ func NewFarmServer(
 	return farmServer, nil
 }
 
+// UseEventStreams keeps the events of the farms, the reservoirs, the areas and the materials in the Redis streams
+// instead of the in-memory storages.
+func (s *FarmServer) UseEventStreams(streams *redisstorage.RedisStreamsEventStorage) {
+	s.FarmEventRepo = repoRedis.NewFarmEventRepositoryRedis(streams)
+	s.FarmEventQuery = queryRedis.NewFarmEventQueryRedis(streams)
+	s.ReservoirEventRepo = repoRedis.NewReservoirEventRepositoryRedis(streams)
+	s.ReservoirEventQuery = queryRedis.NewReservoirEventQueryRedis(streams)
+	s.AreaEventRepo = repoRedis.NewAreaEventRepositoryRedis(streams)
+	s.AreaEventQuery = queryRedis.NewAreaEventQueryRedis(streams)
+	s.MaterialEventRepo = repoRedis.NewMaterialEventRepositoryRedis(streams)
+	s.MaterialEventQuery = queryRedis.NewMaterialEventQueryRedis(streams)
+}
+
 // InitSubscriber defines the mapping of which event this domain listen with their handler.
 func (s *FarmServer) InitSubscriber() {
 	s.EventBus.Subscribe("FarmCreated", s.SaveToFarmReadModel)
@@ -189,6 +376,11 @@ func (s *FarmServer) InitSubscriber() {
 	s.EventBus.Subscribe("FarmTypeChanged", s.SaveToFarmReadModel)
 	s.EventBus.Subscribe("FarmGeolocationChanged", s.SaveToFarmReadModel)
 	s.EventBus.Subscribe("FarmRegionChanged", s.SaveToFarmReadModel)
+	s.EventBus.Subscribe("FarmAreaWalkOrderChanged", s.SaveToFarmReadModel)
+	s.EventBus.Subscribe("FarmHarvestGradesChanged", s.SaveToFarmReadModel)
+	s.EventBus.Subscribe("FarmSeasonsChanged", s.SaveToFarmReadModel)
+	s.EventBus.Subscribe("FarmWithholdingPolicyChanged", s.SaveToFarmReadModel)
+	s.EventBus.Subscribe("FarmDailyEffortBudgetChanged", s.SaveToFarmReadModel)
 
 	s.EventBus.Subscribe("ReservoirCreated", s.SaveToReservoirReadModel)
 	s.EventBus.Subscribe("ReservoirNameChanged", s.SaveToReservoirReadModel)
@@ -203,8 +395,15 @@ func (s *FarmServer) InitSubscriber() {
 	s.EventBus.Subscribe("AreaLocationChanged", s.SaveToAreaReadModel)
 	s.EventBus.Subscribe("AreaReservoirChanged", s.SaveToAreaReadModel)
 	s.EventBus.Subscribe("AreaPhotoAdded", s.SaveToAreaReadModel)
+	s.EventBus.Subscribe("AreaPhotoRemoved", s.SaveToAreaReadModel)
 	s.EventBus.Subscribe("AreaNoteAdded", s.SaveToAreaReadModel)
 	s.EventBus.Subscribe("AreaNoteRemoved", s.SaveToAreaReadModel)
+	s.EventBus.Subscribe("AreaCustomFieldsChanged", s.SaveToAreaReadModel)
+	s.EventBus.Subscribe("AreaLayoutChanged", s.SaveToAreaReadModel)
+	s.EventBus.Subscribe("AreaGeoPointChanged", s.SaveToAreaReadModel)
+	s.EventBus.Subscribe("AreaSanitized", s.SaveToAreaReadModel)
+	s.EventBus.Subscribe("AreaBedMapResized", s.SaveToAreaReadModel)
+	s.EventBus.Subscribe("AreaBedCropPlaced", s.SaveToAreaReadModel)
 
 	s.EventBus.Subscribe("MaterialCreated", s.SaveToMaterialReadModel)
 	s.EventBus.Subscribe("MaterialNameChanged", s.SaveToMaterialReadModel)
@@ -214,6 +413,38 @@ func (s *FarmServer) InitSubscriber() {
 	s.EventBus.Subscribe("MaterialExpirationDateChanged", s.SaveToMaterialReadModel)
 	s.EventBus.Subscribe("MaterialNotesChanged", s.SaveToMaterialReadModel)
 	s.EventBus.Subscribe("MaterialProducedByChanged", s.SaveToMaterialReadModel)
+	s.EventBus.Subscribe("MaterialGDDToMaturityChanged", s.SaveToMaterialReadModel)
+	s.EventBus.Subscribe("MaterialPreHarvestIntervalChanged", s.SaveToMaterialReadModel)
+	s.EventBus.Subscribe("MaterialStockCorrected", s.SaveToMaterialReadModel)
+	s.EventBus.Subscribe("MaterialStockDeducted", s.SaveToMaterialReadModel)
+	s.EventBus.Subscribe("MaterialStockAdded", s.SaveToMaterialReadModel)
+
+	s.EventBus.Subscribe("CertificationGranted", s.SaveToFarmCertificationReadModel)
+	s.EventBus.Subscribe("CertificationRenewed", s.SaveToFarmCertificationReadModel)
+	s.EventBus.Subscribe("CertificationRevoked", s.SaveToFarmCertificationReadModel)
+	s.EventBus.Subscribe("CertificationRenewalDue", s.SaveToFarmCertificationReadModel)
+
+	s.EventBus.Subscribe("FieldDefined", s.SaveToCustomFieldDefinitionReadModel)
+	s.EventBus.Subscribe("FieldUpdated", s.SaveToCustomFieldDefinitionReadModel)
+	s.EventBus.Subscribe("FieldDeleted", s.SaveToCustomFieldDefinitionReadModel)
+
+	// The values of the crops, materials and tasks are saved here once for all the modules.
+	s.EventBus.Subscribe(customfield.ValuesChangedCode, customfield.NewProjection(s.CustomFields.Store).Handle)
+
+	s.EventBus.Subscribe("StocktakeOpened", s.SaveToStocktakeReadModel)
+	s.EventBus.Subscribe("StocktakeCounted", s.SaveToStocktakeReadModel)
+	s.EventBus.Subscribe("StocktakeClosed", s.SaveToStocktakeReadModel)
+
+	s.EventBus.Subscribe("EquipmentCreated", s.SaveToEquipmentReadModel)
+	s.EventBus.Subscribe("EquipmentUpdated", s.SaveToEquipmentReadModel)
+	s.EventBus.Subscribe("EquipmentMaintenanceChanged", s.SaveToEquipmentReadModel)
+	s.EventBus.Subscribe("EquipmentMaintenanceDue", s.SaveToEquipmentReadModel)
+	s.EventBus.Subscribe("EquipmentGeoPointChanged", s.SaveToEquipmentReadModel)
+	s.EventBus.Subscribe("EquipmentDeleted", s.SaveToEquipmentReadModel)
+
+	s.EventBus.Subscribe("NutrientRecipeCreated", s.SaveToNutrientRecipeReadModel)
+	s.EventBus.Subscribe("NutrientRecipeUpdated", s.SaveToNutrientRecipeReadModel)
+	s.EventBus.Subscribe("NutrientRecipeDeleted", s.SaveToNutrientRecipeReadModel)
 }
 
 // Mount defines the FarmServer's endpoints with its handlers.
@@ -223,30 +454,101 @@ func (s *FarmServer) Mount(g *echo.Group) {
 	g.GET("/inventories/materials/simple", s.GetMaterialsSimple)
 	g.GET("/inventories/plant_types", s.GetInventoryPlantTypes)
 	g.GET("/inventories/materials/available_plant_type", s.GetAvailableMaterialPlantType)
-	g.POST("/inventories/materials/:type", s.SaveMaterial)
-	g.PUT("/inventories/materials/:type/:id", s.UpdateMaterial)
+	g.POST("/inventories/materials/:type", s.validatable((*FarmServer).SaveMaterial))
+	g.PUT("/inventories/materials/:type/:id", s.validatable((*FarmServer).UpdateMaterial))
 	g.GET("/inventories/materials/:id", s.GetMaterialByID)
+	g.PUT("/inventories/materials/:id/custom_fields", s.validatable((*FarmServer).SaveMaterialCustomFields))
+	g.POST("/inventories/materials/:id/stock", s.validatable((*FarmServer).AddMaterialStock))
 
-	g.POST("", s.SaveFarm)
-	g.PUT("/:id", s.UpdateFarm)
+	g.POST("", s.validatable((*FarmServer).SaveFarm))
+	g.PUT("/:id", s.validatable((*FarmServer).UpdateFarm), s.farmScope("id"))
 	g.GET("", s.FindAllFarm)
-	g.GET("/:id", s.FindFarmByID)
-
-	g.POST("/:id/reservoirs", s.SaveReservoir)
-	g.PUT("/reservoirs/:id", s.UpdateReservoir)
-	g.POST("/reservoirs/:id/notes", s.SaveReservoirNotes)
-	g.DELETE("/reservoirs/:reservoir_id/notes/:note_id", s.RemoveReservoirNotes)
-	g.GET("/:id/reservoirs", s.GetFarmReservoirs)
-	g.GET("/:farm_id/reservoirs/:reservoir_id", s.GetReservoirsByID)
-
-	g.POST("/:id/areas", s.SaveArea)
-	g.PUT("/areas/:id", s.UpdateArea)
-	g.POST("/areas/:id/notes", s.SaveAreaNotes)
-	g.DELETE("/areas/:area_id/notes/:note_id", s.RemoveAreaNotes)
-	g.GET("/:id/areas/total", s.GetTotalAreas)
-	g.GET("/:id/areas", s.GetFarmAreas)
-	g.GET("/:farm_id/areas/:area_id", s.GetAreasByID)
-	g.GET("/:farm_id/areas/:area_id/photos", s.GetAreaPhotos)
+	g.PUT("/:id/area_walk_order", s.validatable((*FarmServer).ChangeAreaWalkOrder), s.farmScope("id"))
+	g.PUT("/:id/harvest_grades", s.validatable((*FarmServer).ChangeHarvestGrades), s.farmScope("id"))
+	g.PUT("/:id/seasons", s.validatable((*FarmServer).ChangeSeasons), s.farmScope("id"))
+	g.PUT("/:id/withholding_policy", s.validatable((*FarmServer).ChangeWithholdingPolicy), s.farmScope("id"))
+	g.PUT("/:id/effort_budget", s.validatable((*FarmServer).ChangeDailyEffortBudget), s.farmScope("id"))
+	g.GET("/:id", s.FindFarmByID, s.farmScope("id"))
+
+	g.POST("/:id/reservoirs", s.validatable((*FarmServer).SaveReservoir), s.farmScope("id"))
+	g.PUT("/reservoirs/:id", s.validatable((*FarmServer).UpdateReservoir), s.reservoirScope("id", ""))
+	g.POST("/reservoirs/:id/notes", s.validatable((*FarmServer).SaveReservoirNotes), s.reservoirScope("id", ""))
+	g.DELETE("/reservoirs/:reservoir_id/notes/:note_id", s.RemoveReservoirNotes, s.reservoirScope("reservoir_id", ""))
+	g.GET("/:id/reservoirs", s.GetFarmReservoirs, s.farmScope("id"))
+	g.GET("/:farm_id/reservoirs/:reservoir_id", s.GetReservoirsByID, s.reservoirScope("reservoir_id", "farm_id"))
+	g.GET("/:id/reservoirs/:reservoir_id/measurements", s.GetReservoirMeasurements,
+		s.reservoirScope("reservoir_id", "id"))
+	g.POST("/:id/reservoirs/:reservoir_id/measurements", s.validatable((*FarmServer).MeasureReservoir),
+		s.reservoirScope("reservoir_id", "id"))
+	g.POST("/:id/reservoirs/:reservoir_id/dose", s.validatable((*FarmServer).DoseReservoir),
+		s.reservoirScope("reservoir_id", "id"))
+
+	g.POST("/:id/areas", s.validatable((*FarmServer).SaveArea), s.farmScope("id"))
+	g.POST("/:id/areas/bulk", s.validatable((*FarmServer).SaveBulkAreas), s.farmScope("id"))
+	g.PUT("/areas/:id", s.validatable((*FarmServer).UpdateArea), s.areaScope("id", ""))
+	g.POST("/areas/:id/notes", s.validatable((*FarmServer).SaveAreaNotes), s.areaScope("id", ""))
+	g.DELETE("/areas/:area_id/notes/:note_id", s.RemoveAreaNotes, s.areaScope("area_id", ""))
+	g.GET("/:id/areas/total", s.GetTotalAreas, s.farmScope("id"))
+	g.GET("/:id/areas", s.GetFarmAreas, s.farmScope("id"))
+	g.GET("/:farm_id/areas/:area_id", s.GetAreasByID, s.areaScope("area_id", "farm_id"))
+	g.GET("/:farm_id/areas/:area_id/photos", s.GetAreaPhotos, s.areaScope("area_id", "farm_id"))
+	g.DELETE("/:farm_id/areas/:area_id/photos", s.RemoveAreaPhoto, s.areaScope("area_id", "farm_id"))
+	g.PUT("/:farm_id/areas/:id/layout", s.validatable((*FarmServer).ChangeAreaLayout), s.areaScope("id", "farm_id"))
+	g.PUT("/:farm_id/areas/:id/geo-point", s.validatable((*FarmServer).ChangeAreaGeoPoint),
+		s.areaScope("id", "farm_id"))
+	g.GET("/:id/map", s.GetFarmMap, s.farmScope("id"))
+	g.GET("/:id/areas/:area_id/tasks", s.GetAreaTaskBoard, s.areaScope("area_id", "id"))
+	g.GET("/:id/areas/:area_id/bed-map", s.GetAreaBedMap, s.areaScope("area_id", "id"))
+	g.GET("/:id/areas/:area_id/sanitations", s.GetAreaSanitations, s.areaScope("area_id", "id"))
+	g.POST("/:id/areas/:area_id/sanitations", s.validatable((*FarmServer).SanitizeArea), s.areaScope("area_id", "id"))
+	g.PUT("/:id/areas/:area_id/bed-map", s.validatable((*FarmServer).ResizeAreaBedMap), s.areaScope("area_id", "id"))
+	g.POST("/:id/areas/:area_id/bed-map/crop-placement", s.validatable((*FarmServer).PlaceAreaBedCrop),
+		s.areaScope("area_id", "id"))
+
+	g.GET("/certifications/types", s.GetCertificationTypes)
+	g.GET("/:id/certifications", s.FindFarmCertifications, s.farmScope("id"))
+	g.POST("/:id/certifications", s.validatable((*FarmServer).SaveFarmCertification), s.farmScope("id"))
+	g.POST("/:id/certifications/:certification_id/renew", s.validatable((*FarmServer).RenewFarmCertification),
+		s.certificationScope("certification_id", "id"))
+	g.POST("/:id/certifications/:certification_id/revoke", s.validatable((*FarmServer).RevokeFarmCertification),
+		s.certificationScope("certification_id", "id"))
+
+	g.GET("/:id/custom_fields", s.FindCustomFieldDefinitions, s.farmScope("id"))
+	g.POST("/:id/custom_fields", s.validatable((*FarmServer).SaveCustomFieldDefinition), s.farmScope("id"))
+	g.PUT("/:id/custom_fields/:field_id", s.validatable((*FarmServer).UpdateCustomFieldDefinition),
+		s.customFieldScope("field_id", "id"))
+	g.DELETE("/:id/custom_fields/:field_id", s.RemoveCustomFieldDefinition, s.customFieldScope("field_id", "id"))
+
+	g.GET("/:id/stocktakes", s.FindStocktakes, s.farmScope("id"))
+	g.POST("/:id/stocktakes", s.validatable((*FarmServer).OpenStocktake), s.farmScope("id"))
+	g.GET("/:id/stocktakes/:stocktake_id", s.FindStocktakeByID, s.stocktakeScope("stocktake_id", "id"))
+	g.GET("/:id/stocktakes/:stocktake_id/variances", s.GetStocktakeVariances, s.stocktakeScope("stocktake_id", "id"))
+	g.POST("/:id/stocktakes/:stocktake_id/counts", s.validatable((*FarmServer).CountStocktakeMaterial),
+		s.stocktakeScope("stocktake_id", "id"))
+	g.POST("/:id/stocktakes/:stocktake_id/close", s.validatable((*FarmServer).CloseStocktake),
+		s.stocktakeScope("stocktake_id", "id"))
+
+	equipment := s.Flags.Require(featureflags.Equipment)
+	g.GET("/equipment/types", s.GetEquipmentTypes, equipment)
+	g.GET("/:id/equipment", s.FindFarmEquipment, equipment, s.farmScope("id"))
+	g.POST("/:id/equipment", s.validatable((*FarmServer).SaveEquipment), equipment, s.farmScope("id"))
+	g.GET("/:id/equipment/:equipment_id", s.FindEquipmentByID, equipment, s.equipmentScope("equipment_id", "id"))
+	g.PUT("/:id/equipment/:equipment_id", s.validatable((*FarmServer).UpdateEquipment), equipment,
+		s.equipmentScope("equipment_id", "id"))
+	g.PUT("/:id/equipment/:equipment_id/geo-point", s.validatable((*FarmServer).ChangeEquipmentGeoPoint),
+		equipment, s.equipmentScope("equipment_id", "id"))
+	g.DELETE("/:id/equipment/:equipment_id", s.RemoveEquipment, equipment, s.equipmentScope("equipment_id", "id"))
+
+	g.GET("/:id/nutrient_recipes", s.FindNutrientRecipes, s.farmScope("id"))
+	g.POST("/:id/nutrient_recipes", s.validatable((*FarmServer).SaveNutrientRecipe), s.farmScope("id"))
+	g.GET("/:id/materials/:material_id/lots/:lot/usages", s.FindMaterialLotUsages, s.farmScope("id"))
+	g.GET("/:id/nutrient_recipes/:recipe_id", s.FindNutrientRecipeByID, s.nutrientRecipeScope("recipe_id", "id"))
+	g.PUT("/:id/nutrient_recipes/:recipe_id", s.validatable((*FarmServer).UpdateNutrientRecipe),
+		s.nutrientRecipeScope("recipe_id", "id"))
+	g.DELETE("/:id/nutrient_recipes/:recipe_id", s.RemoveNutrientRecipe, s.nutrientRecipeScope("recipe_id", "id"))
+
+	g.GET("/:id/boundary", s.GetFarmBoundary, s.farmScope("id"))
+	g.PUT("/:id/boundary", s.validatable((*FarmServer).SaveFarmBoundary), s.farmScope("id"))
 }
 
 // GetTypes is a FarmServer's handle to get farm types.
@@ -267,18 +569,30 @@ func (s FarmServer) FindAllFarm(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
 	}
 
-	data := make(map[string][]storage.FarmRead)
-	data["data"] = farms
+	// Only the farms of the user are listed.
+	userFarms := []storage.FarmRead{}
 
-	if len(farms) == 0 {
-		data["data"] = []storage.FarmRead{}
+	for _, v := range farms {
+		if s.FarmScope.Allows(c, v.UID) {
+			userFarms = append(userFarms, v)
+		}
 	}
 
+	data := make(map[string][]storage.FarmRead)
+	data["data"] = userFarms
+
 	return c.JSON(http.StatusOK, data)
 }
 
 // SaveFarm is a FarmServer's handler to save new Farm.
 func (s *FarmServer) SaveFarm(c echo.Context) error {
+	err := validateFarmLocation(
+		c.FormValue("latitude"), c.FormValue("longitude"), c.FormValue("country"), c.FormValue("city"), true,
+	)
+	if err != nil {
+		return Error(c, err)
+	}
+
 	farm, err := domain.CreateFarm(
 		c.FormValue("name"),
 		c.FormValue("farm_type"),
@@ -291,7 +605,7 @@ func (s *FarmServer) SaveFarm(c echo.Context) error {
 		return Error(c, err)
 	}
 
-	err = <-s.FarmEventRepo.Save(farm.UID, farm.Version, farm.UncommittedChanges)
+	err = <-s.FarmEventRepo.Save(farm.UID, farm.Version, farm.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -348,6 +662,11 @@ func (s *FarmServer) UpdateFarm(c echo.Context) error {
 		return Error(c, NewRequestValidationError(Required, "country"))
 	}
 
+	err = validateFarmLocation(latitude, longitude, country, city, false)
+	if err != nil {
+		return Error(c, err)
+	}
+
 	// Process //
 	queryResult = <-s.FarmEventQuery.FindAllByID(farmUID)
 	if queryResult.Error != nil {
@@ -389,7 +708,7 @@ func (s *FarmServer) UpdateFarm(c echo.Context) error {
 		}
 	}
 
-	err = <-s.FarmEventRepo.Save(farm.UID, farm.Version, farm.UncommittedChanges)
+	err = <-s.FarmEventRepo.Save(farm.UID, farm.Version, farm.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -418,8 +737,13 @@ func (s *FarmServer) FindFarmByID(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Internal server error")
 	}
 
-	data := make(map[string]storage.FarmRead)
-	data["data"] = farm
+	expanded, err := expand(c, farmExpansions(s, farm))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]FarmDetail)
+	data["data"] = FarmDetail{FarmRead: farm, Expanded: expanded}
 
 	return c.JSON(http.StatusOK, data)
 }
@@ -456,7 +780,7 @@ func (s *FarmServer) SaveReservoir(c echo.Context) error {
 	}
 
 	// Persists //
-	err = <-s.ReservoirEventRepo.Save(r.UID, r.Version, r.UncommittedChanges)
+	err = <-s.ReservoirEventRepo.Save(r.UID, r.Version, r.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -545,7 +869,9 @@ func (s *FarmServer) UpdateReservoir(c echo.Context) error {
 	}
 
 	// Persists //
-	resultSave := <-s.ReservoirEventRepo.Save(reservoir.UID, reservoir.Version, reservoir.UncommittedChanges)
+	resultSave := <-s.ReservoirEventRepo.Save(
+		reservoir.UID, reservoir.Version, reservoir.UncommittedChanges, actor.FromContext(c),
+	)
 	if resultSave != nil {
 		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
 	}
@@ -606,7 +932,9 @@ func (s *FarmServer) SaveReservoirNotes(c echo.Context) error {
 	}
 
 	// Persists //
-	resultSave := <-s.ReservoirEventRepo.Save(reservoir.UID, reservoir.Version, reservoir.UncommittedChanges)
+	resultSave := <-s.ReservoirEventRepo.Save(
+		reservoir.UID, reservoir.Version, reservoir.UncommittedChanges, actor.FromContext(c),
+	)
 	if resultSave != nil {
 		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
 	}
@@ -678,7 +1006,9 @@ func (s *FarmServer) RemoveReservoirNotes(c echo.Context) error {
 	}
 
 	// Persists //
-	err = <-s.ReservoirEventRepo.Save(reservoir.UID, reservoir.Version, reservoir.UncommittedChanges)
+	err = <-s.ReservoirEventRepo.Save(
+		reservoir.UID, reservoir.Version, reservoir.UncommittedChanges, actor.FromContext(c),
+	)
 	if err != nil {
 		return Error(c, err)
 	}
@@ -752,7 +1082,7 @@ func (s *FarmServer) GetReservoirsByID(c echo.Context) error {
 
 	data["data"], err = MapToReservoirReadFromRead(s, reservoir)
 	if err != nil {
-		Error(c, err)
+		return Error(c, err)
 	}
 
 	return c.JSON(http.StatusOK, data)
@@ -792,6 +1122,16 @@ func (s *FarmServer) SaveArea(c echo.Context) error {
 		return Error(c, err)
 	}
 
+	err = validation.ValidateAreaDuplicate(*s, farm.UID, c.FormValue("name"), c.QueryParam("force") == "true")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	customFields, _, err := parseCustomFields(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
 	// Process //
 	area, err := domain.CreateArea(
 		s.AreaService,
@@ -801,6 +1141,7 @@ func (s *FarmServer) SaveArea(c echo.Context) error {
 		c.FormValue("type"),
 		size,
 		location,
+		customFields,
 	)
 	if err != nil {
 		return Error(c, err)
@@ -832,7 +1173,7 @@ func (s *FarmServer) SaveArea(c echo.Context) error {
 	}
 
 	// Persists //
-	err = <-s.AreaEventRepo.Save(area.UID, area.Version, area.UncommittedChanges)
+	err = <-s.AreaEventRepo.Save(area.UID, area.Version, area.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -868,6 +1209,11 @@ func (s *FarmServer) UpdateArea(c echo.Context) error {
 	reservoirID := c.FormValue("reservoir_id")
 	photo, photoErr := c.FormFile("photo")
 
+	customFields, hasCustomFields, err := parseCustomFields(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
 	// Validate //
 	queryResult := <-s.AreaReadQuery.FindByID(areaUID)
 	if queryResult.Error != nil {
@@ -946,6 +1292,13 @@ func (s *FarmServer) UpdateArea(c echo.Context) error {
 		}
 	}
 
+	if hasCustomFields {
+		err = area.ChangeCustomFields(s.AreaService, customFields)
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
 	if photoErr == nil {
 		destPath := stringhelper.Join(*config.Config.UploadPathArea, "/", photo.Filename)
 		err = s.File.Upload(photo, destPath)
@@ -971,7 +1324,7 @@ func (s *FarmServer) UpdateArea(c echo.Context) error {
 	}
 
 	// Persists //
-	err = <-s.AreaEventRepo.Save(area.UID, area.Version, area.UncommittedChanges)
+	err = <-s.AreaEventRepo.Save(area.UID, area.Version, area.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -1032,7 +1385,7 @@ func (s *FarmServer) SaveAreaNotes(c echo.Context) error {
 	}
 
 	// Persists //
-	err = <-s.AreaEventRepo.Save(area.UID, area.Version, area.UncommittedChanges)
+	err = <-s.AreaEventRepo.Save(area.UID, area.Version, area.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -1106,7 +1459,7 @@ func (s *FarmServer) RemoveAreaNotes(c echo.Context) error {
 	}
 
 	// Persists //
-	resultSave := <-s.AreaEventRepo.Save(area.UID, area.Version, area.UncommittedChanges)
+	resultSave := <-s.AreaEventRepo.Save(area.UID, area.Version, area.UncommittedChanges, actor.FromContext(c))
 	if resultSave != nil {
 		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
 	}
@@ -1140,6 +1493,14 @@ func (s *FarmServer) GetFarmAreas(c echo.Context) error {
 		return Error(c, echo.NewHTTPError(http.StatusBadRequest, "Internal server error"))
 	}
 
+	filters := customfield.ParseFilters(c.QueryParams())
+	if len(filters) > 0 {
+		areas, err = s.filterAreasByCustomFields(farmUID, areas, filters)
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
 	areaList, err := MapToAreaList(s, areas)
 	if err != nil {
 		return Error(c, err)
@@ -1196,6 +1557,11 @@ func (s *FarmServer) GetAreasByID(c echo.Context) error {
 		return Error(c, err)
 	}
 
+	detailArea.Expanded, err = expand(c, areaExpansions(s, detailArea))
+	if err != nil {
+		return Error(c, err)
+	}
+
 	data := make(map[string]DetailArea)
 	data["data"] = detailArea
 
@@ -1295,6 +1661,11 @@ func (s *FarmServer) GetMaterials(c echo.Context) error {
 		return Error(c, err)
 	}
 
+	filters := customfield.ParseFilters(c.QueryParams())
+	if len(filters) > 0 {
+		return s.getMaterialsByCustomFields(c, materialType, materialTypeDetail, pageInt, limitInt, filters)
+	}
+
 	queryResult := <-s.MaterialReadQuery.FindAll(materialType, materialTypeDetail, pageInt, limitInt)
 	if queryResult.Error != nil {
 		return Error(c, queryResult.Error)
@@ -1393,6 +1764,16 @@ func (s *FarmServer) SaveMaterial(c echo.Context) error {
 		pb = &producedBy
 	}
 
+	gddToMaturity, err := parseGDDToMaturity(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	preHarvestIntervalDays, err := parsePreHarvestInterval(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
 	// Process //
 	var mt domain.MaterialType
 
@@ -1447,15 +1828,22 @@ func (s *FarmServer) SaveMaterial(c echo.Context) error {
 		}
 	}
 
+	validation := RequestValidation{}
+
+	err = validation.ValidateMaterialDuplicate(*s, name, c.QueryParam("force") == "true")
+	if err != nil {
+		return Error(c, err)
+	}
+
 	material, err := domain.CreateMaterial(
 		name, pricePerUnit, currencyCode, mt, float32(q), quantityUnit,
-		expDate, n, pb)
+		expDate, n, pb, gddToMaturity, preHarvestIntervalDays, c.FormValue("lot_number"))
 	if err != nil {
 		return Error(c, err)
 	}
 
 	// Persist //
-	err = <-s.MaterialEventRepo.Save(material.UID, material.Version, material.UncommittedChanges)
+	err = <-s.MaterialEventRepo.Save(material.UID, material.Version, material.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -1529,6 +1917,16 @@ func (s *FarmServer) UpdateMaterial(c echo.Context) error {
 		pb = &producedBy
 	}
 
+	gddToMaturity, err := parseGDDToMaturity(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	preHarvestIntervalDays, err := parsePreHarvestInterval(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
 	queryResult := <-s.MaterialReadQuery.FindByID(materialUID)
 	if queryResult.Error != nil {
 		return Error(c, queryResult.Error)
@@ -1631,6 +2029,13 @@ func (s *FarmServer) UpdateMaterial(c echo.Context) error {
 			return Error(c, err)
 		}
 
+		if float32(q) != material.Quantity.Value {
+			err = s.validateMaterialNotCounted(material.UID)
+			if err != nil {
+				return Error(c, err)
+			}
+		}
+
 		material.ChangeQuantityUnit(float32(q), quantityUnit, materialRead.Type)
 	}
 
@@ -1646,8 +2051,22 @@ func (s *FarmServer) UpdateMaterial(c echo.Context) error {
 		material.ChangeProducedBy(*pb)
 	}
 
+	if gddToMaturity != nil {
+		err = material.ChangeGDDToMaturity(*gddToMaturity)
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
+	if preHarvestIntervalDays != nil {
+		err = material.ChangePreHarvestInterval(*preHarvestIntervalDays)
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
 	// Persist //
-	err = <-s.MaterialEventRepo.Save(material.UID, material.Version, material.UncommittedChanges)
+	err = <-s.MaterialEventRepo.Save(material.UID, material.Version, material.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -1660,6 +2079,34 @@ func (s *FarmServer) UpdateMaterial(c echo.Context) error {
 	return c.JSON(http.StatusOK, data)
 }
 
+// parseGDDToMaturity parses the optional growing degree days the crops of the material need to mature.
+func parseGDDToMaturity(c echo.Context) (*float64, error) {
+	if c.FormValue("gdd_to_maturity") == "" {
+		return nil, nil
+	}
+
+	gddToMaturity, err := strconv.ParseFloat(c.FormValue("gdd_to_maturity"), 64)
+	if err != nil {
+		return nil, NewRequestValidationError(Float, "gdd_to_maturity")
+	}
+
+	return &gddToMaturity, nil
+}
+
+// parsePreHarvestInterval parses the optional days a crop can't be harvested after it's treated with the material.
+func parsePreHarvestInterval(c echo.Context) (*int, error) {
+	if c.FormValue("pre_harvest_interval_days") == "" {
+		return nil, nil
+	}
+
+	days, err := strconv.Atoi(c.FormValue("pre_harvest_interval_days"))
+	if err != nil {
+		return nil, NewRequestValidationError(Numeric, "pre_harvest_interval_days")
+	}
+
+	return &days, nil
+}
+
 func (s *FarmServer) GetMaterialByID(c echo.Context) error {
 	materialUID, err := uuid.FromString(c.Param("id"))
 	if err != nil {
@@ -1677,8 +2124,15 @@ func (s *FarmServer) GetMaterialByID(c echo.Context) error {
 		return Error(c, NewRequestValidationError(NotFound, "id"))
 	}
 
+	detail := MapToMaterialFromRead(materialRead)
+
+	detail.CustomFields, err = s.CustomFields.Find(materialRead.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
 	data := make(map[string]Material)
-	data["data"] = MapToMaterialFromRead(materialRead)
+	data["data"] = detail
 
 	return c.JSON(http.StatusOK, data)
 }
@@ -1724,5 +2178,35 @@ func (s *FarmServer) publishUncommittedEvents(entity interface{}) {
 			name := structhelper.GetName(v)
 			s.EventBus.Publish(name, v)
 		}
+	case *domain.FarmCertification:
+		for _, v := range e.UncommittedChanges {
+			name := structhelper.GetName(v)
+			s.EventBus.Publish(name, v)
+		}
+	case *domain.CustomFieldDefinition:
+		for _, v := range e.UncommittedChanges {
+			name := structhelper.GetName(v)
+			s.EventBus.Publish(name, v)
+		}
+	case *domain.Stocktake:
+		for _, v := range e.UncommittedChanges {
+			name := structhelper.GetName(v)
+			s.EventBus.Publish(name, v)
+		}
+	case *domain.Equipment:
+		for _, v := range e.UncommittedChanges {
+			name := structhelper.GetName(v)
+			s.EventBus.Publish(name, v)
+		}
+	case *domain.NutrientRecipe:
+		for _, v := range e.UncommittedChanges {
+			name := structhelper.GetName(v)
+			s.EventBus.Publish(name, v)
+		}
+	case *domain.FarmBoundary:
+		for _, v := range e.UncommittedChanges {
+			name := structhelper.GetName(v)
+			s.EventBus.Publish(name, v)
+		}
 	}
 }
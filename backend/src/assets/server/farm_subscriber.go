@@ -22,6 +22,7 @@ func (s *FarmServer) SaveToFarmReadModel(event interface{}) error {
 		farmRead.City = e.City
 		farmRead.IsActive = e.IsActive
 		farmRead.CreatedDate = e.CreatedDate
+		farmRead.HarvestGrades = domain.DefaultHarvestGrades()
 
 	case domain.FarmNameChanged:
 		queryResult := <-s.FarmReadQuery.FindByID(e.FarmUID)
@@ -84,6 +85,81 @@ func (s *FarmServer) SaveToFarmReadModel(event interface{}) error {
 
 		farm.Country = e.Country
 		farm.City = e.City
+
+	case domain.FarmAreaWalkOrderChanged:
+		queryResult := <-s.FarmReadQuery.FindByID(e.FarmUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		farm, ok := queryResult.Result.(storage.FarmRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		farmRead = &farm
+
+		farmRead.AreaWalkOrder = e.AreaUIDs
+
+	case domain.FarmHarvestGradesChanged:
+		queryResult := <-s.FarmReadQuery.FindByID(e.FarmUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		farm, ok := queryResult.Result.(storage.FarmRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		farmRead = &farm
+
+		farmRead.HarvestGrades = e.Grades
+
+	case domain.FarmSeasonsChanged:
+		queryResult := <-s.FarmReadQuery.FindByID(e.FarmUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		farm, ok := queryResult.Result.(storage.FarmRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		farmRead = &farm
+
+		farmRead.Seasons = e.Seasons
+
+	case domain.FarmWithholdingPolicyChanged:
+		queryResult := <-s.FarmReadQuery.FindByID(e.FarmUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		farm, ok := queryResult.Result.(storage.FarmRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		farmRead = &farm
+
+		farmRead.WithholdingPolicy = e.Policy
+
+	case domain.FarmDailyEffortBudgetChanged:
+		queryResult := <-s.FarmReadQuery.FindByID(e.FarmUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		farm, ok := queryResult.Result.(storage.FarmRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		farmRead = &farm
+
+		farmRead.DailyEffortBudgetMinutes = e.Minutes
 	}
 
 	err := <-s.FarmReadRepo.Save(farmRead)
@@ -264,6 +340,7 @@ func (s *FarmServer) SaveToAreaReadModel(event interface{}) error {
 			UID:  reservoir.UID,
 			Name: reservoir.Name,
 		}
+		areaRead.CustomFields = e.CustomFields
 
 	case domain.AreaNameChanged:
 		queryResult := <-s.AreaReadQuery.FindByID(e.AreaUID)
@@ -374,6 +451,21 @@ func (s *FarmServer) SaveToAreaReadModel(event interface{}) error {
 			Height:   e.Height,
 		}
 
+	case domain.AreaPhotoRemoved:
+		queryResult := <-s.AreaReadQuery.FindByID(e.AreaUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		area, ok := queryResult.Result.(storage.AreaRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		areaRead = &area
+
+		areaRead.Photo = storage.AreaPhoto{}
+
 	case domain.AreaNoteAdded:
 		queryResult := <-s.AreaReadQuery.FindByID(e.AreaUID)
 		if queryResult.Error != nil {
@@ -419,6 +511,112 @@ func (s *FarmServer) SaveToAreaReadModel(event interface{}) error {
 		}
 
 		areaRead.Notes = notes
+
+	case domain.AreaCustomFieldsChanged:
+		queryResult := <-s.AreaReadQuery.FindByID(e.AreaUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		area, ok := queryResult.Result.(storage.AreaRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		areaRead = &area
+
+		areaRead.CustomFields = e.CustomFields
+
+	case domain.AreaLayoutChanged:
+		queryResult := <-s.AreaReadQuery.FindByID(e.AreaUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		area, ok := queryResult.Result.(storage.AreaRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		areaRead = &area
+
+		layout := storage.AreaLayout(*e.Layout)
+		areaRead.Layout = &layout
+
+	case domain.AreaGeoPointChanged:
+		queryResult := <-s.AreaReadQuery.FindByID(e.AreaUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		area, ok := queryResult.Result.(storage.AreaRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		areaRead = &area
+		areaRead.GeoPoint = e.GeoPoint
+
+	case domain.AreaSanitized:
+		queryResult := <-s.AreaReadQuery.FindByID(e.AreaUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		area, ok := queryResult.Result.(storage.AreaRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		areaRead = &area
+		areaRead.Sanitations = append(areaRead.Sanitations, MapToAreaSanitationRead(e))
+
+		if areaRead.LastSanitizedDate == nil || e.SanitizedDate.After(*areaRead.LastSanitizedDate) {
+			sanitizedDate := e.SanitizedDate
+			areaRead.LastSanitizedDate = &sanitizedDate
+		}
+
+	case domain.AreaBedMapResized:
+		queryResult := <-s.AreaReadQuery.FindByID(e.AreaUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		area, ok := queryResult.Result.(storage.AreaRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		areaRead = &area
+
+		bedMap := domain.BedMap{}
+		if areaRead.BedMap != nil {
+			bedMap = domain.BedMap(*areaRead.BedMap)
+		}
+
+		resized := storage.BedMap(bedMap.Resized(e.Rows, e.Columns))
+		areaRead.BedMap = &resized
+
+	case domain.AreaBedCropPlaced:
+		queryResult := <-s.AreaReadQuery.FindByID(e.AreaUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		area, ok := queryResult.Result.(storage.AreaRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		areaRead = &area
+
+		bedMap := domain.BedMap{}
+		if areaRead.BedMap != nil {
+			bedMap = domain.BedMap(*areaRead.BedMap)
+		}
+
+		placed := storage.BedMap(bedMap.WithCrop(e.Row, e.Column, e.CropUID, e.Status))
+		areaRead.BedMap = &placed
 	}
 
 	err := <-s.AreaReadRepo.Save(areaRead)
@@ -442,6 +640,8 @@ func (s *FarmServer) SaveToMaterialReadModel(event interface{}) error {
 		materialRead.ExpirationDate = e.ExpirationDate
 		materialRead.Notes = e.Notes
 		materialRead.ProducedBy = e.ProducedBy
+		materialRead.GDDToMaturity = e.GDDToMaturity
+		materialRead.PreHarvestIntervalDays = e.PreHarvestIntervalDays
 		materialRead.CreatedDate = e.CreatedDate
 
 	case domain.MaterialNameChanged:
@@ -492,6 +692,51 @@ func (s *FarmServer) SaveToMaterialReadModel(event interface{}) error {
 			Value: e.Quantity.Value,
 		}
 
+	case domain.MaterialStockCorrected:
+		queryResult := <-s.MaterialReadQuery.FindByID(e.MaterialUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		material, ok := queryResult.Result.(storage.MaterialRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		materialRead = &material
+
+		materialRead.Quantity.Value = e.Quantity
+
+	case domain.MaterialStockAdded:
+		queryResult := <-s.MaterialReadQuery.FindByID(e.MaterialUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		material, ok := queryResult.Result.(storage.MaterialRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		materialRead = &material
+
+		materialRead.Quantity.Value = e.Quantity
+
+	case domain.MaterialStockDeducted:
+		queryResult := <-s.MaterialReadQuery.FindByID(e.MaterialUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		material, ok := queryResult.Result.(storage.MaterialRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		materialRead = &material
+
+		materialRead.Quantity.Value = e.Quantity
+
 	case domain.MaterialTypeChanged:
 		queryResult := <-s.MaterialReadQuery.FindByID(e.MaterialUID)
 		if queryResult.Error != nil {
@@ -551,6 +796,36 @@ func (s *FarmServer) SaveToMaterialReadModel(event interface{}) error {
 		materialRead = &material
 
 		materialRead.ProducedBy = &e.ProducedBy
+
+	case domain.MaterialGDDToMaturityChanged:
+		queryResult := <-s.MaterialReadQuery.FindByID(e.MaterialUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		material, ok := queryResult.Result.(storage.MaterialRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		materialRead = &material
+
+		materialRead.GDDToMaturity = &e.GDDToMaturity
+
+	case domain.MaterialPreHarvestIntervalChanged:
+		queryResult := <-s.MaterialReadQuery.FindByID(e.MaterialUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		material, ok := queryResult.Result.(storage.MaterialRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		materialRead = &material
+
+		materialRead.PreHarvestIntervalDays = &e.PreHarvestIntervalDays
 	}
 
 	err := <-s.MaterialReadRepo.Save(materialRead)
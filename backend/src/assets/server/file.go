@@ -13,6 +13,7 @@ import (
 type File interface {
 	GetFile(src string) ([]byte, error)
 	Upload(file *multipart.FileHeader, destPath string) error
+	Remove(srcPath string) error
 }
 
 type LocalFile struct{}
@@ -62,3 +63,13 @@ func (LocalFile) Upload(file *multipart.FileHeader, destPath string) error {
 
 	return nil
 }
+
+// Remove deletes the file. A file which is already gone isn't an error.
+func (LocalFile) Remove(srcPath string) error {
+	err := os.Remove(srcPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
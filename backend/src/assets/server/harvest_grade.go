@@ -0,0 +1,79 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+// ChangeHarvestGrades replaces the quality grades of the farm with the comma separated grades, in the order
+// they're shown. The sellable_grades are the ones among them which count toward the sellable quantity.
+func (s *FarmServer) ChangeHarvestGrades(c echo.Context) error {
+	farmRead, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if strings.TrimSpace(c.FormValue("grades")) == "" {
+		return Error(c, NewRequestValidationError(Required, "grades"))
+	}
+
+	sellable := map[string]bool{}
+
+	for _, v := range strings.Split(c.FormValue("sellable_grades"), ",") {
+		v = strings.ToUpper(strings.TrimSpace(v))
+		if v != "" {
+			sellable[v] = true
+		}
+	}
+
+	grades := []domain.HarvestGrade{}
+
+	for _, v := range strings.Split(c.FormValue("grades"), ",") {
+		code := strings.ToUpper(strings.TrimSpace(v))
+
+		grades = append(grades, domain.HarvestGrade{Code: code, Sellable: sellable[code]})
+
+		delete(sellable, code)
+	}
+
+	if len(sellable) > 0 {
+		return Error(c, NewRequestValidationError(InvalidOption, "sellable_grades"))
+	}
+
+	result := <-s.FarmEventQuery.FindAllByID(farmRead.UID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	events, ok := result.Result.([]storage.FarmEvent)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	farm := repository.NewFarmFromHistory(events)
+
+	// PROCESS //
+	err = farm.ChangeHarvestGrades(grades)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.FarmEventRepo.Save(farm.UID, farm.Version, farm.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(farm)
+
+	data := make(map[string]*storage.FarmRead)
+	data["data"] = MapToFarmRead(farm)
+
+	return c.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,220 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+// MaterialLotRead is a lot of the material, with what was received of it, what's left and its uses on the farm.
+type MaterialLotRead struct {
+	MaterialUID  uuid.UUID              `json:"material_id"`
+	MaterialName string                 `json:"material_name"`
+	LotNumber    string                 `json:"lot_number"`
+	QuantityUnit string                 `json:"quantity_unit"`
+	Received     float32                `json:"received"`
+	Remaining    float32                `json:"remaining"`
+	Usages       []MaterialLotUsageRead `json:"usages"`
+}
+
+// MaterialLotUsageRead is a use of the lot, the quantity taken from it and the areas and crop batches it reached.
+type MaterialLotUsageRead struct {
+	UsedDate     time.Time                  `json:"used_date"`
+	Reason       string                     `json:"reason"`
+	ReferenceUID uuid.UUID                  `json:"reference_id"`
+	Quantity     float32                    `json:"quantity"`
+	Areas        []domain.MaterialUsageArea `json:"areas"`
+	Crops        []domain.MaterialUsageCrop `json:"crops"`
+}
+
+// AddMaterialStock adds the quantity form value to the stock of the material, as a new lot with the optional
+// lot_number, received on the date form value or today.
+func (s *FarmServer) AddMaterialStock(c echo.Context) error {
+	materialUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	if c.FormValue("quantity") == "" {
+		return Error(c, NewRequestValidationError(Required, "quantity"))
+	}
+
+	quantity, err := strconv.ParseFloat(c.FormValue("quantity"), 32)
+	if err != nil {
+		return Error(c, NewRequestValidationError(Float, "quantity"))
+	}
+
+	addedDate := time.Now()
+
+	if value := c.FormValue("date"); value != "" {
+		addedDate, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "date"))
+		}
+	}
+
+	material, err := s.findMaterialFromHistory(materialUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if material.UID != materialUID {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	err = s.validateMaterialNotCounted(material.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	err = material.AddStock(float32(quantity), c.FormValue("lot_number"), addedDate)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.MaterialEventRepo.Save(material.UID, material.Version, material.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(material)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data": material.Lots,
+	})
+}
+
+// FindMaterialLotUsages lists the uses of the lot of the material on the farm, oldest first, with the areas
+// and the crop batches each use reached. It's answered from the ledger of the material, the stock additions
+// and the deductions with the lots they were taken from.
+func (s *FarmServer) FindMaterialLotUsages(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	materialUID, err := uuid.FromString(c.Param("material_id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "material_id"))
+	}
+
+	result := <-s.MaterialEventQuery.FindAllByID(materialUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	events, ok := result.Result.([]storage.MaterialEvent)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	if len(events) == 0 {
+		return Error(c, NewRequestValidationError(NotFound, "material_id"))
+	}
+
+	lotNumber := c.Param("lot")
+	lot := MaterialLotRead{MaterialUID: materialUID, LotNumber: lotNumber, Usages: []MaterialLotUsageRead{}}
+	found := false
+
+	for _, v := range events {
+		switch e := v.Event.(type) {
+		case domain.MaterialCreated:
+			lot.MaterialName = e.Name
+			lot.QuantityUnit = e.Quantity.Unit.Code
+
+			if e.LotNumber == lotNumber {
+				lot.Received += e.Quantity.Value
+				found = true
+			}
+
+		case domain.MaterialNameChanged:
+			lot.MaterialName = e.Name
+
+		case domain.MaterialStockAdded:
+			if e.LotNumber == lotNumber {
+				lot.Received += e.Added
+				found = true
+			}
+
+		case domain.MaterialStockDeducted:
+			if e.FarmUID != farmUID {
+				continue
+			}
+
+			for _, usage := range e.Lots {
+				if usage.LotNumber != lotNumber {
+					continue
+				}
+
+				usedDate := e.UsedDate
+				if usedDate.IsZero() {
+					usedDate = v.CreatedDate
+				}
+
+				lot.Usages = append(lot.Usages, MaterialLotUsageRead{
+					UsedDate:     usedDate,
+					Reason:       e.Reason,
+					ReferenceUID: e.ReferenceUID,
+					Quantity:     usage.Quantity,
+					Areas:        e.Areas,
+					Crops:        e.Crops,
+				})
+			}
+		}
+	}
+
+	if !found {
+		return Error(c, NewRequestValidationError(NotFound, "lot"))
+	}
+
+	for _, v := range repository.NewMaterialFromHistory(events).Lots {
+		if v.LotNumber == lotNumber {
+			lot.Remaining += v.Quantity
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]MaterialLotRead{"data": lot})
+}
+
+// findStockUseReach returns the areas and the crop batches with plants left in them, which a use of a material
+// on the areas reaches.
+func (s *FarmServer) findStockUseReach(areas []storage.AreaRead) (
+	[]domain.MaterialUsageArea, []domain.MaterialUsageCrop, error,
+) {
+	usageAreas := []domain.MaterialUsageArea{}
+	usageCrops := []domain.MaterialUsageCrop{}
+
+	for _, area := range areas {
+		usageAreas = append(usageAreas, domain.MaterialUsageArea{UID: area.UID, Name: area.Name})
+
+		result := <-s.CropReadQuery.FindAllCurrentByArea(area.UID)
+		if result.Error != nil {
+			return nil, nil, result.Error
+		}
+
+		crops, ok := result.Result.([]query.AreaCurrentCropResult)
+		if !ok {
+			return nil, nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		for _, v := range crops {
+			usageCrops = append(usageCrops, domain.MaterialUsageCrop{
+				UID:     v.CropUID,
+				BatchID: v.BatchID,
+				AreaUID: area.UID,
+			})
+		}
+	}
+
+	return usageAreas, usageCrops, nil
+}
@@ -0,0 +1,338 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+// nutrientRecipeIngredientForm is an ingredient of the ingredients form value.
+type nutrientRecipeIngredientForm struct {
+	MaterialID string  `json:"material_id"`
+	Dose       float32 `json:"dose"`
+	PerLitres  float32 `json:"per_litres"`
+}
+
+func (s *FarmServer) FindNutrientRecipes(c echo.Context) error {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	result := <-s.NutrientRecipeReadQuery.FindAllByFarm(farm.UID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	recipes, ok := result.Result.([]storage.NutrientRecipeRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	data := make(map[string][]storage.NutrientRecipeRead)
+	data["data"] = recipes
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *FarmServer) FindNutrientRecipeByID(c echo.Context) error {
+	recipe, err := s.findNutrientRecipe(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.NutrientRecipeRead)
+	data["data"] = MapToNutrientRecipeRead(*recipe)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// SaveNutrientRecipe saves a recipe of the target_ec, target_ph and the ingredients, a JSON list like
+// `[{"material_id": "...", "dose": 2, "per_litres": 10}]` of the dose of each material for the litres of water.
+func (s *FarmServer) SaveNutrientRecipe(c echo.Context) error {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	targetEC, err := parseNutrientRecipeTarget(c, "target_ec")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	targetPH, err := parseNutrientRecipeTarget(c, "target_ph")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if c.FormValue("ingredients") == "" {
+		return Error(c, NewRequestValidationError(Required, "ingredients"))
+	}
+
+	ingredients, err := s.parseNutrientRecipeIngredients(c.FormValue("ingredients"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	recipe, err := domain.CreateNutrientRecipe(farm.UID, c.FormValue("name"), targetEC, targetPH, ingredients)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = s.saveNutrientRecipe(recipe, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.NutrientRecipeRead)
+	data["data"] = MapToNutrientRecipeRead(*recipe)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// UpdateNutrientRecipe changes the values sent, the others stay the same.
+func (s *FarmServer) UpdateNutrientRecipe(c echo.Context) error {
+	recipe, err := s.findNutrientRecipe(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	name := c.FormValue("name")
+	if name == "" {
+		name = recipe.Name
+	}
+
+	targetEC := recipe.TargetEC
+	if c.FormValue("target_ec") != "" {
+		targetEC, err = parseNutrientRecipeTarget(c, "target_ec")
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
+	targetPH := recipe.TargetPH
+	if c.FormValue("target_ph") != "" {
+		targetPH, err = parseNutrientRecipeTarget(c, "target_ph")
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
+	ingredients := recipe.Ingredients
+	if c.FormValue("ingredients") != "" {
+		ingredients, err = s.parseNutrientRecipeIngredients(c.FormValue("ingredients"))
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
+	// PROCESS //
+	err = recipe.Update(name, targetEC, targetPH, ingredients)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = s.saveNutrientRecipe(recipe, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.NutrientRecipeRead)
+	data["data"] = MapToNutrientRecipeRead(*recipe)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *FarmServer) RemoveNutrientRecipe(c echo.Context) error {
+	recipe, err := s.findNutrientRecipe(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	err = recipe.Delete()
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = s.saveNutrientRecipe(recipe, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.NutrientRecipeRead)
+	data["data"] = MapToNutrientRecipeRead(*recipe)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func parseNutrientRecipeTarget(c echo.Context, field string) (float32, error) {
+	if c.FormValue(field) == "" {
+		return 0, NewRequestValidationError(Required, field)
+	}
+
+	value, err := strconv.ParseFloat(c.FormValue(field), 32)
+	if err != nil {
+		return 0, NewRequestValidationError(Float, field)
+	}
+
+	return float32(value), nil
+}
+
+// parseNutrientRecipeIngredients parses the ingredients and checks their materials exist.
+func (s *FarmServer) parseNutrientRecipeIngredients(value string) ([]domain.NutrientRecipeIngredient, error) {
+	forms := []nutrientRecipeIngredientForm{}
+
+	err := json.Unmarshal([]byte(value), &forms)
+	if err != nil {
+		return nil, NewRequestValidationError(ParseFailed, "ingredients")
+	}
+
+	ingredients := []domain.NutrientRecipeIngredient{}
+
+	for _, v := range forms {
+		materialUID, err := uuid.FromString(v.MaterialID)
+		if err != nil {
+			return nil, NewRequestValidationError(ParseFailed, "ingredients")
+		}
+
+		result := <-s.MaterialReadQuery.FindByID(materialUID)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		material, ok := result.Result.(storage.MaterialRead)
+		if !ok {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		if material.UID == (uuid.UUID{}) {
+			return nil, NewRequestValidationError(NotFound, "ingredients")
+		}
+
+		ingredients = append(ingredients, domain.NutrientRecipeIngredient{
+			MaterialUID: materialUID,
+			Dose:        v.Dose,
+			PerLitres:   v.PerLitres,
+		})
+	}
+
+	return ingredients, nil
+}
+
+// findNutrientRecipe finds the recipe of the recipe_id param and checks it belongs to the farm.
+func (s *FarmServer) findNutrientRecipe(c echo.Context) (*domain.NutrientRecipe, error) {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return nil, err
+	}
+
+	recipeUID, err := uuid.FromString(c.Param("recipe_id"))
+	if err != nil {
+		return nil, NewRequestValidationError(NotFound, "recipe_id")
+	}
+
+	recipe, err := s.findNutrientRecipeFromHistory(recipeUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if recipe.UID != recipeUID || recipe.FarmUID != farm.UID || recipe.IsDeleted {
+		return nil, NewRequestValidationError(NotFound, "recipe_id")
+	}
+
+	return recipe, nil
+}
+
+func (s *FarmServer) findNutrientRecipeFromHistory(uid uuid.UUID) (*domain.NutrientRecipe, error) {
+	result := <-s.NutrientRecipeEventQuery.FindAllByID(uid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	events, ok := result.Result.([]storage.NutrientRecipeEvent)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return repository.NewNutrientRecipeFromHistory(events), nil
+}
+
+func (s *FarmServer) saveNutrientRecipe(recipe *domain.NutrientRecipe, by *actor.Actor) error {
+	err := <-s.NutrientRecipeEventRepo.Save(recipe.UID, recipe.Version, recipe.UncommittedChanges, by)
+	if err != nil {
+		return err
+	}
+
+	s.publishUncommittedEvents(recipe)
+
+	return nil
+}
+
+func MapToNutrientRecipeRead(recipe domain.NutrientRecipe) storage.NutrientRecipeRead {
+	ingredients := []storage.NutrientRecipeIngredientRead{}
+	for _, v := range recipe.Ingredients {
+		ingredients = append(ingredients, storage.NutrientRecipeIngredientRead{
+			MaterialUID: v.MaterialUID,
+			Dose:        v.Dose,
+			PerLitres:   v.PerLitres,
+		})
+	}
+
+	return storage.NutrientRecipeRead{
+		UID:         recipe.UID,
+		FarmUID:     recipe.FarmUID,
+		Name:        recipe.Name,
+		TargetEC:    recipe.TargetEC,
+		TargetPH:    recipe.TargetPH,
+		Ingredients: ingredients,
+		IsDeleted:   recipe.IsDeleted,
+		CreatedDate: recipe.CreatedDate,
+	}
+}
+
+func (s *FarmServer) SaveToNutrientRecipeReadModel(event interface{}) error {
+	var uid uuid.UUID
+
+	switch e := event.(type) {
+	case domain.NutrientRecipeCreated:
+		uid = e.UID
+	case domain.NutrientRecipeUpdated:
+		uid = e.UID
+	case domain.NutrientRecipeDeleted:
+		uid = e.UID
+	default:
+		return errors.New("unknown nutrient recipe event")
+	}
+
+	recipe, err := s.findNutrientRecipeFromHistory(uid)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	recipeRead := MapToNutrientRecipeRead(*recipe)
+
+	err = <-s.NutrientRecipeReadRepo.Save(&recipeRead)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	return nil
+}
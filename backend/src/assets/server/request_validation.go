@@ -11,6 +11,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/farmscope"
 )
 
 const (
@@ -22,6 +23,11 @@ const (
 	ParseFailed   = "PARSE_FAILED"
 	InvalidOption = "INVALID_OPTION"
 	NotFound      = "NOT_FOUND"
+
+	PossibleDuplicate = "POSSIBLE_DUPLICATE"
+	BedCellTaken      = "BED_CELL_TAKEN"
+	Repeated          = "REPEATED"
+	OutOfRange        = "OUT_OF_RANGE"
 )
 
 // RequestValidation sanitizes request inputs and convert the input to its correct data type.
@@ -66,6 +72,14 @@ func Message(errorCode string) string {
 		return "This value is not available in options. Please give the correct options."
 	case NotFound:
 		return "Data not found."
+	case PossibleDuplicate:
+		return "A similar name already exists. Send force=true to create it anyway."
+	case BedCellTaken:
+		return "This bed cell is already taken by another crop."
+	case Repeated:
+		return "This value is repeated in the request."
+	case OutOfRange:
+		return "This value is out of the allowed range."
 	default:
 		return "Internal server error"
 	}
@@ -93,7 +107,7 @@ func Error(c echo.Context, err error) error {
 
 	log.Printf(
 		"user_uid: %v\nrequest_id: %v\nfile: %v\nline: %v\n",
-		c.Get("USER_UID"),
+		c.Get(farmscope.UserKey),
 		c.Response().Header().Get(echo.HeaderXRequestID),
 		file,
 		line,
@@ -123,6 +137,71 @@ func Error(c echo.Context, err error) error {
 		return c.JSON(http.StatusBadRequest, errorResponse)
 	}
 
+	var me domain.MaterialError
+	if errors.As(err, &me) {
+		errorResponse["error_code"] = strconv.Itoa(me.Code)
+
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	var fce domain.FarmCertificationError
+	if errors.As(err, &fce) {
+		errorResponse["error_code"] = strconv.Itoa(fce.Code)
+
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	var ee domain.EquipmentError
+	if errors.As(err, &ee) {
+		errorResponse["error_code"] = strconv.Itoa(ee.Code)
+
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	var nre domain.NutrientRecipeError
+	if errors.As(err, &nre) {
+		errorResponse["error_code"] = strconv.Itoa(nre.Code)
+
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	var fbe domain.FarmBoundaryError
+	if errors.As(err, &fbe) {
+		errorResponse["error_code"] = strconv.Itoa(fbe.Code)
+
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	var cfe domain.CustomFieldError
+	if errors.As(err, &cfe) {
+		errorResponse["field_name"] = cfe.FieldKey
+		errorResponse["error_code"] = strconv.Itoa(cfe.Code)
+
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	var ste domain.StocktakeError
+	if errors.As(err, &ste) {
+		errorResponse["error_code"] = strconv.Itoa(ste.Code)
+
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	var pde PossibleDuplicateError
+	if errors.As(err, &pde) {
+		return c.JSON(http.StatusConflict, pde)
+	}
+
+	var bcte BedCellTakenError
+	if errors.As(err, &bcte) {
+		return c.JSON(http.StatusConflict, bcte)
+	}
+
+	var rves RequestValidationErrors
+	if errors.As(err, &rves) {
+		return c.JSON(http.StatusBadRequest, rves)
+	}
+
 	var rve RequestValidationError
 	if errors.As(err, &rve) {
 		errorResponse["field_name"] = rve.FieldName
@@ -132,7 +211,8 @@ func Error(c echo.Context, err error) error {
 		return c.JSON(http.StatusBadRequest, rve)
 	}
 
-	return c.JSON(http.StatusInternalServerError, errorResponse)
+	// The other errors are answered by the error handler of the server, with their detail only when verbose.
+	return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error").SetInternal(err)
 }
 
 func getFileAndLineNumber() (string, int) {
@@ -0,0 +1,311 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+// GetReservoirMeasurements lists the EC and pH measurements and the dosings of the reservoir, oldest first.
+func (s *FarmServer) GetReservoirMeasurements(c echo.Context) error {
+	_, events, err := s.findFarmReservoir(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	measurements := []storage.ReservoirMeasurementRead{}
+
+	for _, v := range events {
+		switch e := v.Event.(type) {
+		case domain.ReservoirMeasured:
+			measurements = append(measurements, MapToReservoirMeasurementRead(e))
+		case domain.ReservoirDosed:
+			measurements = append(measurements, MapToReservoirDosingRead(e))
+		}
+	}
+
+	data := make(map[string][]storage.ReservoirMeasurementRead)
+	data["data"] = measurements
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// MeasureReservoir records the ec and ph form values, at least one of them is required.
+func (s *FarmServer) MeasureReservoir(c echo.Context) error {
+	reservoir, _, err := s.findFarmReservoir(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	ec, err := parseReservoirMeasurement(c, "ec")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	ph, err := parseReservoirMeasurement(c, "ph")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	err = reservoir.Measure(ec, ph)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	measured, ok := reservoir.UncommittedChanges[len(reservoir.UncommittedChanges)-1].(domain.ReservoirMeasured)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	// PERSIST //
+	err = <-s.ReservoirEventRepo.Save(
+		reservoir.UID, reservoir.Version, reservoir.UncommittedChanges, actor.FromContext(c),
+	)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(reservoir)
+
+	data := make(map[string]storage.ReservoirMeasurementRead)
+	data["data"] = MapToReservoirMeasurementRead(measured)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// DoseReservoir doses the volume form value, in litres, of the reservoir water with the recipe_id recipe.
+// The doses are deducted from the stock of the materials. A material short of stock is deducted to zero,
+// the dosing is still recorded with the shortfall and a warning. The optional lots form value is a JSON object
+// of the lot_number each material_id is taken from.
+func (s *FarmServer) DoseReservoir(c echo.Context) error {
+	reservoir, _, err := s.findFarmReservoir(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if c.FormValue("recipe_id") == "" {
+		return Error(c, NewRequestValidationError(Required, "recipe_id"))
+	}
+
+	recipeUID, err := uuid.FromString(c.FormValue("recipe_id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(ParseFailed, "recipe_id"))
+	}
+
+	recipe, err := s.findNutrientRecipeFromHistory(recipeUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if recipe.UID != recipeUID || recipe.FarmUID != reservoir.FarmUID || recipe.IsDeleted {
+		return Error(c, NewRequestValidationError(NotFound, "recipe_id"))
+	}
+
+	if c.FormValue("volume") == "" {
+		return Error(c, NewRequestValidationError(Required, "volume"))
+	}
+
+	volume, err := strconv.ParseFloat(c.FormValue("volume"), 32)
+	if err != nil {
+		return Error(c, NewRequestValidationError(Float, "volume"))
+	}
+
+	lots := map[string]string{}
+
+	if value := c.FormValue("lots"); value != "" {
+		err = json.Unmarshal([]byte(value), &lots)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "lots"))
+		}
+	}
+
+	queryResult := <-s.AreaReadQuery.FindAreasByReservoirID(reservoir.UID)
+	if queryResult.Error != nil {
+		return Error(c, queryResult.Error)
+	}
+
+	areas, ok := queryResult.Result.([]storage.AreaRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	reachedAreas, reachedCrops, err := s.findStockUseReach(areas)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	materials := []*domain.Material{}
+	doses := []domain.ReservoirDose{}
+	warnings := []string{}
+
+	for _, v := range recipe.Doses(float32(volume)) {
+		material, err := s.findMaterialFromHistory(v.MaterialUID)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		if material.UID != v.MaterialUID {
+			return Error(c, NewRequestValidationError(NotFound, "recipe_id"))
+		}
+
+		err = s.validateMaterialNotCounted(material.UID)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		shortfall, err := material.DeductStock(v.Quantity, domain.StockUse{
+			Reason:       domain.StockDeductionReasonDosing,
+			ReferenceUID: reservoir.UID,
+			FarmUID:      reservoir.FarmUID,
+			LotNumber:    lots[material.UID.String()],
+			UsedDate:     time.Now(),
+			Areas:        reachedAreas,
+			Crops:        reachedCrops,
+		})
+		if err != nil {
+			return Error(c, err)
+		}
+
+		if shortfall > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s is short of stock by %g %s.",
+				material.Name, shortfall, material.Quantity.Unit.Code))
+		}
+
+		materials = append(materials, material)
+		doses = append(doses, domain.ReservoirDose{
+			MaterialUID:  material.UID,
+			MaterialName: material.Name,
+			Quantity:     v.Quantity,
+			QuantityUnit: material.Quantity.Unit.Code,
+			Shortfall:    shortfall,
+		})
+	}
+
+	err = reservoir.Dose(*recipe, float32(volume), doses)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	dosed, ok := reservoir.UncommittedChanges[len(reservoir.UncommittedChanges)-1].(domain.ReservoirDosed)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	// PERSIST //
+	err = <-s.ReservoirEventRepo.Save(
+		reservoir.UID, reservoir.Version, reservoir.UncommittedChanges, actor.FromContext(c),
+	)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(reservoir)
+
+	for _, v := range materials {
+		err = <-s.MaterialEventRepo.Save(v.UID, v.Version, v.UncommittedChanges, actor.FromContext(c))
+		if err != nil {
+			return Error(c, err)
+		}
+
+		s.publishUncommittedEvents(v)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":     MapToReservoirDosingRead(dosed),
+		"warnings": warnings,
+	})
+}
+
+func parseReservoirMeasurement(c echo.Context, field string) (*float32, error) {
+	if c.FormValue(field) == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseFloat(c.FormValue(field), 32)
+	if err != nil {
+		return nil, NewRequestValidationError(Float, field)
+	}
+
+	result := float32(value)
+
+	return &result, nil
+}
+
+// findFarmReservoir finds the reservoir of the reservoir_id param with its history
+// and checks it belongs to the farm.
+func (s *FarmServer) findFarmReservoir(c echo.Context) (*domain.Reservoir, []storage.ReservoirEvent, error) {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reservoirUID, err := uuid.FromString(c.Param("reservoir_id"))
+	if err != nil {
+		return nil, nil, NewRequestValidationError(NotFound, "reservoir_id")
+	}
+
+	result := <-s.ReservoirEventQuery.FindAllByID(reservoirUID)
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+
+	events, ok := result.Result.([]storage.ReservoirEvent)
+	if !ok {
+		return nil, nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	reservoir := repository.NewReservoirFromHistory(events)
+	if reservoir.UID != reservoirUID || reservoir.FarmUID != farm.UID {
+		return nil, nil, NewRequestValidationError(NotFound, "reservoir_id")
+	}
+
+	return reservoir, events, nil
+}
+
+func MapToReservoirMeasurementRead(e domain.ReservoirMeasured) storage.ReservoirMeasurementRead {
+	return storage.ReservoirMeasurementRead{
+		UID:  e.UID,
+		Type: storage.ReservoirMeasurementTypeMeasurement,
+		Date: e.MeasuredDate,
+		EC:   e.EC,
+		PH:   e.PH,
+	}
+}
+
+func MapToReservoirDosingRead(e domain.ReservoirDosed) storage.ReservoirMeasurementRead {
+	doses := []storage.ReservoirDoseRead{}
+	for _, v := range e.Doses {
+		doses = append(doses, storage.ReservoirDoseRead{
+			MaterialUID:  v.MaterialUID,
+			MaterialName: v.MaterialName,
+			Quantity:     v.Quantity,
+			QuantityUnit: v.QuantityUnit,
+			Shortfall:    v.Shortfall,
+		})
+	}
+
+	return storage.ReservoirMeasurementRead{
+		UID:  e.UID,
+		Type: storage.ReservoirMeasurementTypeDosing,
+		Date: e.DosedDate,
+		Dosing: &storage.ReservoirDosingRead{
+			RecipeUID:    e.RecipeUID,
+			RecipeName:   e.RecipeName,
+			TargetEC:     e.TargetEC,
+			TargetPH:     e.TargetPH,
+			VolumeLitres: e.VolumeLitres,
+			Doses:        doses,
+		},
+	}
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
+)
+
+// ChangeSeasons replaces the seasons the analytics of the farm are grouped by with the comma separated seasons,
+// each a name and its start day like `Wet:11-01`. Empty seasons go back to the seasons of the farm's hemisphere.
+func (s *FarmServer) ChangeSeasons(c echo.Context) error {
+	farmRead, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	seasons := []timebuckethelper.Season{}
+
+	for _, v := range strings.Split(c.FormValue("seasons"), ",") {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+
+		separator := strings.LastIndex(v, ":")
+		if separator < 0 {
+			return Error(c, NewRequestValidationError(ParseFailed, "seasons"))
+		}
+
+		start, err := time.Parse("01-02", strings.TrimSpace(v[separator+1:]))
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "seasons"))
+		}
+
+		seasons = append(seasons, timebuckethelper.Season{
+			Name:       v[:separator],
+			StartMonth: start.Month(),
+			StartDay:   start.Day(),
+		})
+	}
+
+	result := <-s.FarmEventQuery.FindAllByID(farmRead.UID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	events, ok := result.Result.([]storage.FarmEvent)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	farm := repository.NewFarmFromHistory(events)
+
+	// PROCESS //
+	err = farm.ChangeSeasons(seasons)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.FarmEventRepo.Save(farm.UID, farm.Version, farm.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(farm)
+
+	data := make(map[string]*storage.FarmRead)
+	data["data"] = MapToFarmRead(farm)
+
+	return c.JSON(http.StatusOK, data)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/usetania/tania-core/src/assets/domain"
 	"github.com/usetania/tania-core/src/assets/query"
 	"github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/helper/expandhelper"
 )
 
 type (
@@ -36,6 +37,16 @@ type DetailArea struct {
 	TotalCropBatch int `json:"total_crop_batch"`
 	TotalVariety   int `json:"total_variety"`
 	PlantQuantity  int `json:"plant_quantity"`
+
+	DaysSinceSanitation *int `json:"days_since_sanitation"`
+
+	Expanded map[string]expandhelper.Expansion `json:"expanded,omitempty"`
+}
+
+// FarmDetail is the farm with the relations inlined by the expand param.
+type FarmDetail struct {
+	storage.FarmRead
+	Expanded map[string]expandhelper.Expansion `json:"expanded,omitempty"`
 }
 
 type DetailReservoir struct {
@@ -90,7 +101,13 @@ type Material struct {
 	ExpirationDate *time.Time       `json:"expiration_date,omitempty"`
 	Notes          *string          `json:"notes"`
 	ProducedBy     *string          `json:"produced_by"`
+	GDDToMaturity  *float64         `json:"gdd_to_maturity"`
 	CreatedDate    time.Time        `json:"created_date"`
+
+	PreHarvestIntervalDays *int `json:"pre_harvest_interval_days"`
+
+	// CustomFields are only in the detail of the material.
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
 type PricePerUnit struct {
@@ -152,6 +169,11 @@ func MapToFarmRead(farm *domain.Farm) *storage.FarmRead {
 	farmRead.City = farm.City
 	farmRead.CreatedDate = farm.CreatedDate
 	farmRead.IsActive = farm.IsActive
+	farmRead.AreaWalkOrder = farm.AreaWalkOrder
+	farmRead.HarvestGrades = domain.HarvestGradesOrDefault(farm.HarvestGrades)
+	farmRead.Seasons = farm.Seasons
+	farmRead.WithholdingPolicy = farm.WithholdingPolicy
+	farmRead.DailyEffortBudgetMinutes = farm.DailyEffortBudgetMinutes
 
 	return farmRead
 }
@@ -310,6 +332,13 @@ func MapToDetailAreaFromStorage(s *FarmServer, areaRead storage.AreaRead) (Detai
 	detailArea.CreatedDate = areaRead.CreatedDate
 	detailArea.Reservoir = areaRead.Reservoir
 	detailArea.Farm = areaRead.Farm
+	detailArea.CustomFields = areaRead.CustomFields
+	detailArea.Layout = areaRead.Layout
+	detailArea.BedMap = areaRead.BedMap
+	detailArea.GeoPoint = areaRead.GeoPoint
+	detailArea.Sanitations = areaRead.Sanitations
+	detailArea.LastSanitizedDate = areaRead.LastSanitizedDate
+	detailArea.DaysSinceSanitation = domain.DaysSinceSanitation(areaRead.LastSanitizedDate, time.Now())
 
 	queryResult := <-s.CropReadQuery.CountCropsByArea(areaRead.UID)
 	if queryResult.Error != nil {
@@ -361,6 +390,10 @@ func MapToDetailArea(s *FarmServer, area domain.Area) (DetailArea, error) {
 	areaRead.Photo = storage.AreaPhoto(area.Photo)
 	areaRead.Size = storage.AreaSize(area.Size)
 	areaRead.CreatedDate = area.CreatedDate
+	areaRead.CustomFields = area.CustomFields
+	areaRead.Layout = mapToAreaLayout(area.Layout)
+	areaRead.BedMap = mapToBedMap(area.BedMap)
+	areaRead.GeoPoint = area.GeoPoint
 
 	queryResult := <-s.ReservoirReadQuery.FindByID(area.ReservoirUID)
 	if queryResult.Error != nil {
@@ -516,6 +549,8 @@ func MapToMaterial(material domain.Material) Material {
 		m.ProducedBy = material.ProducedBy
 	}
 
+	m.GDDToMaturity = material.GDDToMaturity
+	m.PreHarvestIntervalDays = material.PreHarvestIntervalDays
 	m.CreatedDate = material.CreatedDate
 
 	return m
@@ -594,6 +629,8 @@ func MapToMaterialFromRead(material storage.MaterialRead) Material {
 		m.ProducedBy = material.ProducedBy
 	}
 
+	m.GDDToMaturity = material.GDDToMaturity
+	m.PreHarvestIntervalDays = material.PreHarvestIntervalDays
 	m.CreatedDate = material.CreatedDate
 
 	return m
@@ -726,3 +763,143 @@ func (rt ReservoirTap) MarshalJSON() ([]byte, error) {
 		Type: rt.Type(),
 	})
 }
+
+func mapToAreaLayout(layout *domain.AreaLayout) *storage.AreaLayout {
+	if layout == nil {
+		return nil
+	}
+
+	areaLayout := storage.AreaLayout(*layout)
+
+	return &areaLayout
+}
+
+func mapToBedMap(bedMap *domain.BedMap) *storage.BedMap {
+	if bedMap == nil {
+		return nil
+	}
+
+	areaBedMap := storage.BedMap(*bedMap)
+
+	return &areaBedMap
+}
+
+// farmExpansions are the relations the farm detail can inline with the expand param,
+// serialized like on their own endpoints.
+func farmExpansions(s *FarmServer, farm storage.FarmRead) expandhelper.Registry {
+	return expandhelper.Registry{
+		{Key: "areas", Expand: expandhelper.List(func() ([]AreaList, error) {
+			result := <-s.AreaReadQuery.FindAllByFarm(farm.UID)
+			if result.Error != nil {
+				return nil, result.Error
+			}
+
+			areas, ok := result.Result.([]storage.AreaRead)
+			if !ok {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+			}
+
+			return MapToAreaList(s, areas)
+		})},
+		{Key: "reservoirs", Expand: expandhelper.List(func() ([]storage.ReservoirRead, error) {
+			result := <-s.ReservoirReadQuery.FindAllByFarm(farm.UID)
+			if result.Error != nil {
+				return nil, result.Error
+			}
+
+			reservoirs, ok := result.Result.([]storage.ReservoirRead)
+			if !ok {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+			}
+
+			for i := range reservoirs {
+				r, err := MapToReservoirReadFromRead(s, reservoirs[i])
+				if err != nil {
+					return nil, err
+				}
+
+				reservoirs[i] = r
+			}
+
+			return reservoirs, nil
+		})},
+		{Key: "certifications", Expand: expandhelper.List(func() ([]storage.FarmCertificationRead, error) {
+			result := <-s.FarmCertificationReadQuery.FindAllByFarm(farm.UID)
+			if result.Error != nil {
+				return nil, result.Error
+			}
+
+			certifications, ok := result.Result.([]storage.FarmCertificationRead)
+			if !ok {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+			}
+
+			now := time.Now()
+			for i := range certifications {
+				certifications[i] = MapToFarmCertificationStatus(certifications[i], now)
+			}
+
+			return certifications, nil
+		})},
+		{Key: "equipment", Expand: expandhelper.List(func() ([]EquipmentDetail, error) {
+			result := <-s.EquipmentReadQuery.FindAllByFarm(farm.UID)
+			if result.Error != nil {
+				return nil, result.Error
+			}
+
+			equipment, ok := result.Result.([]storage.EquipmentRead)
+			if !ok {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+			}
+
+			details := []EquipmentDetail{}
+
+			for _, v := range equipment {
+				detail, err := s.mapToEquipmentDetail(v)
+				if err != nil {
+					return nil, err
+				}
+
+				details = append(details, detail)
+			}
+
+			return details, nil
+		})},
+	}
+}
+
+// areaExpansions are the relations the area detail can inline with the expand param. The crops are the ones
+// still growing in the area, and the tasks the open ones of the area.
+func areaExpansions(s *FarmServer, area DetailArea) expandhelper.Registry {
+	return expandhelper.Registry{
+		{Key: "crops", Expand: expandhelper.List(func() ([]query.AreaCurrentCropResult, error) {
+			result := <-s.CropReadQuery.FindAllCurrentByArea(area.UID)
+			if result.Error != nil {
+				return nil, result.Error
+			}
+
+			crops, ok := result.Result.([]query.AreaCurrentCropResult)
+			if !ok {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+			}
+
+			return crops, nil
+		})},
+		{Key: "notes", Limit: 20, Expand: expandhelper.List(func() ([]storage.AreaNote, error) {
+			return area.Notes, nil
+		})},
+		{Key: "tasks", Expand: expandhelper.List(func() ([]query.AreaTaskResult, error) {
+			result := <-s.AreaTaskReadQuery.FindAllOpenByAssets([]uuid.UUID{area.UID})
+			if result.Error != nil {
+				return nil, result.Error
+			}
+
+			tasks, ok := result.Result.([]query.AreaTaskResult)
+			if !ok {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+			}
+
+			return tasks, nil
+		})},
+	}
+}
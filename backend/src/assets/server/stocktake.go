@@ -0,0 +1,413 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+// StocktakeVarianceReport is the difference between the counted and the expected quantities of a stocktake.
+type StocktakeVarianceReport struct {
+	StocktakeUID uuid.UUID               `json:"stocktake_id"`
+	Status       string                  `json:"status"`
+	TotalCounted int                     `json:"total_counted"`
+	TotalListed  int                     `json:"total_listed"`
+	Variances    []StocktakeVarianceRead `json:"variances"`
+}
+
+type StocktakeVarianceRead struct {
+	MaterialUID      uuid.UUID `json:"material_id"`
+	MaterialName     string    `json:"material_name"`
+	ExpectedQuantity float32   `json:"expected_quantity"`
+	CountedQuantity  float32   `json:"counted_quantity"`
+	Difference       float32   `json:"difference"`
+	QuantityUnit     string    `json:"quantity_unit"`
+}
+
+func (s *FarmServer) FindStocktakes(c echo.Context) error {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	result := <-s.StocktakeReadQuery.FindAllByFarm(farm.UID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	stocktakes, ok := result.Result.([]storage.StocktakeRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	data := make(map[string][]storage.StocktakeRead)
+	data["data"] = stocktakes
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *FarmServer) FindStocktakeByID(c echo.Context) error {
+	stocktake, err := s.findStocktake(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.StocktakeRead)
+	data["data"] = MapToStocktakeRead(*stocktake)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// OpenStocktake opens a stocktake listing every material with its current quantity.
+func (s *FarmServer) OpenStocktake(c echo.Context) error {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = s.validateNoOpenStocktake(farm.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	result := <-s.MaterialReadQuery.FindAll("", "", 0, 0)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	materials, ok := result.Result.([]storage.MaterialRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	lines := []domain.StocktakeLine{}
+	for _, v := range materials {
+		lines = append(lines, domain.StocktakeLine{
+			MaterialUID:      v.UID,
+			MaterialName:     v.Name,
+			ExpectedQuantity: v.Quantity.Value,
+			QuantityUnit:     v.Quantity.Unit.Code,
+		})
+	}
+
+	// PROCESS //
+	stocktake, err := domain.OpenStocktake(farm.UID, lines)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = s.saveStocktake(stocktake, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.StocktakeRead)
+	data["data"] = MapToStocktakeRead(*stocktake)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// CountStocktakeMaterial submits the counted quantity of a material. The materials can be counted in separate requests.
+func (s *FarmServer) CountStocktakeMaterial(c echo.Context) error {
+	stocktake, err := s.findStocktake(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if c.FormValue("material_id") == "" {
+		return Error(c, NewRequestValidationError(Required, "material_id"))
+	}
+
+	materialUID, err := uuid.FromString(c.FormValue("material_id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(ParseFailed, "material_id"))
+	}
+
+	if c.FormValue("quantity") == "" {
+		return Error(c, NewRequestValidationError(Required, "quantity"))
+	}
+
+	quantity, err := strconv.ParseFloat(c.FormValue("quantity"), 32)
+	if err != nil {
+		return Error(c, NewRequestValidationError(Float, "quantity"))
+	}
+
+	// PROCESS //
+	err = stocktake.Count(materialUID, float32(quantity))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = s.saveStocktake(stocktake, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.StocktakeRead)
+	data["data"] = MapToStocktakeRead(*stocktake)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// CloseStocktake closes the stocktake and corrects the quantity of every material counted with a variance.
+func (s *FarmServer) CloseStocktake(c echo.Context) error {
+	stocktake, err := s.findStocktake(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	err = stocktake.Close()
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// The corrections are all made before anything is saved, so a failing one doesn't leave the stocktake half applied.
+	materials := []*domain.Material{}
+
+	for _, v := range stocktake.Variances() {
+		material, err := s.findMaterialFromHistory(v.MaterialUID)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		err = material.CorrectStock(v.CountedQuantity, domain.StockCorrectionReasonStocktake, stocktake.UID)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		materials = append(materials, material)
+	}
+
+	// PERSIST //
+	err = s.saveStocktake(stocktake, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	for _, v := range materials {
+		err = <-s.MaterialEventRepo.Save(v.UID, v.Version, v.UncommittedChanges, actor.FromContext(c))
+		if err != nil {
+			return Error(c, err)
+		}
+
+		s.publishUncommittedEvents(v)
+	}
+
+	data := make(map[string]StocktakeVarianceReport)
+	data["data"] = MapToStocktakeVarianceReport(*stocktake)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *FarmServer) GetStocktakeVariances(c echo.Context) error {
+	stocktake, err := s.findStocktake(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]StocktakeVarianceReport)
+	data["data"] = MapToStocktakeVarianceReport(*stocktake)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *FarmServer) validateNoOpenStocktake(farmUID uuid.UUID) error {
+	result := <-s.StocktakeReadQuery.FindAllByFarm(farmUID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	stocktakes, ok := result.Result.([]storage.StocktakeRead)
+	if !ok {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	for _, v := range stocktakes {
+		if v.Status == domain.StocktakeStatusOpen {
+			return domain.StocktakeError{Code: domain.StocktakeErrorAlreadyOpenCode}
+		}
+	}
+
+	return nil
+}
+
+// validateMaterialNotCounted checks the material isn't counted by an open stocktake,
+// as its quantity is frozen until the stocktake is closed.
+func (s *FarmServer) validateMaterialNotCounted(materialUID uuid.UUID) error {
+	result := <-s.StocktakeReadQuery.FindAllOpen()
+	if result.Error != nil {
+		return result.Error
+	}
+
+	stocktakes, ok := result.Result.([]storage.StocktakeRead)
+	if !ok {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	for _, stocktake := range stocktakes {
+		for _, v := range stocktake.Lines {
+			if v.MaterialUID == materialUID {
+				return domain.MaterialError{Code: domain.MaterialErrorStockFrozen}
+			}
+		}
+	}
+
+	return nil
+}
+
+// findStocktake finds the stocktake of the stocktake_id param and checks it belongs to the farm.
+func (s *FarmServer) findStocktake(c echo.Context) (*domain.Stocktake, error) {
+	farm, err := s.findFarm(c)
+	if err != nil {
+		return nil, err
+	}
+
+	stocktakeUID, err := uuid.FromString(c.Param("stocktake_id"))
+	if err != nil {
+		return nil, NewRequestValidationError(NotFound, "stocktake_id")
+	}
+
+	stocktake, err := s.findStocktakeFromHistory(stocktakeUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stocktake.UID != stocktakeUID || stocktake.FarmUID != farm.UID {
+		return nil, NewRequestValidationError(NotFound, "stocktake_id")
+	}
+
+	return stocktake, nil
+}
+
+func (s *FarmServer) findStocktakeFromHistory(uid uuid.UUID) (*domain.Stocktake, error) {
+	result := <-s.StocktakeEventQuery.FindAllByID(uid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	events, ok := result.Result.([]storage.StocktakeEvent)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return repository.NewStocktakeFromHistory(events), nil
+}
+
+func (s *FarmServer) findMaterialFromHistory(uid uuid.UUID) (*domain.Material, error) {
+	result := <-s.MaterialEventQuery.FindAllByID(uid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	events, ok := result.Result.([]storage.MaterialEvent)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return repository.NewMaterialFromHistory(events), nil
+}
+
+func (s *FarmServer) saveStocktake(stocktake *domain.Stocktake, by *actor.Actor) error {
+	err := <-s.StocktakeEventRepo.Save(stocktake.UID, stocktake.Version, stocktake.UncommittedChanges, by)
+	if err != nil {
+		return err
+	}
+
+	s.publishUncommittedEvents(stocktake)
+
+	return nil
+}
+
+func MapToStocktakeRead(stocktake domain.Stocktake) storage.StocktakeRead {
+	lines := []storage.StocktakeLineRead{}
+	for _, v := range stocktake.Lines {
+		lines = append(lines, storage.StocktakeLineRead{
+			MaterialUID:      v.MaterialUID,
+			MaterialName:     v.MaterialName,
+			ExpectedQuantity: v.ExpectedQuantity,
+			QuantityUnit:     v.QuantityUnit,
+			CountedQuantity:  v.CountedQuantity,
+		})
+	}
+
+	return storage.StocktakeRead{
+		UID:        stocktake.UID,
+		FarmUID:    stocktake.FarmUID,
+		Status:     stocktake.Status,
+		Lines:      lines,
+		OpenedDate: stocktake.OpenedDate,
+		ClosedDate: stocktake.ClosedDate,
+	}
+}
+
+func MapToStocktakeVarianceReport(stocktake domain.Stocktake) StocktakeVarianceReport {
+	report := StocktakeVarianceReport{
+		StocktakeUID: stocktake.UID,
+		Status:       stocktake.Status,
+		TotalListed:  len(stocktake.Lines),
+		Variances:    []StocktakeVarianceRead{},
+	}
+
+	for _, v := range stocktake.Lines {
+		if v.CountedQuantity != nil {
+			report.TotalCounted++
+		}
+	}
+
+	for _, v := range stocktake.Variances() {
+		report.Variances = append(report.Variances, StocktakeVarianceRead{
+			MaterialUID:      v.MaterialUID,
+			MaterialName:     v.MaterialName,
+			ExpectedQuantity: v.ExpectedQuantity,
+			CountedQuantity:  v.CountedQuantity,
+			Difference:       v.Difference(),
+			QuantityUnit:     v.QuantityUnit,
+		})
+	}
+
+	return report
+}
+
+func (s *FarmServer) SaveToStocktakeReadModel(event interface{}) error {
+	var uid uuid.UUID
+
+	switch e := event.(type) {
+	case domain.StocktakeOpened:
+		uid = e.UID
+	case domain.StocktakeCounted:
+		uid = e.UID
+	case domain.StocktakeClosed:
+		uid = e.UID
+	default:
+		return errors.New("unknown stocktake event")
+	}
+
+	stocktake, err := s.findStocktakeFromHistory(uid)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	stocktakeRead := MapToStocktakeRead(*stocktake)
+
+	err = <-s.StocktakeReadRepo.Save(&stocktakeRead)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	return nil
+}
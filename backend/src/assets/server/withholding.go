@@ -0,0 +1,56 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/assets/repository"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+// ChangeWithholdingPolicy sets whether the harvests of the crops still inside the withholding period
+// of a pesticide treatment are refused, `BLOCK`, or let through with a recorded reason, `WARN`.
+func (s *FarmServer) ChangeWithholdingPolicy(c echo.Context) error {
+	farmRead, err := s.findFarm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	policy := strings.ToUpper(strings.TrimSpace(c.FormValue("policy")))
+	if policy == "" {
+		return Error(c, NewRequestValidationError(Required, "policy"))
+	}
+
+	result := <-s.FarmEventQuery.FindAllByID(farmRead.UID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	events, ok := result.Result.([]storage.FarmEvent)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	farm := repository.NewFarmFromHistory(events)
+
+	// PROCESS //
+	err = farm.ChangeWithholdingPolicy(policy)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.FarmEventRepo.Save(farm.UID, farm.Version, farm.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(farm)
+
+	data := make(map[string]*storage.FarmRead)
+	data["data"] = MapToFarmRead(farm)
+
+	return c.JSON(http.StatusOK, data)
+}
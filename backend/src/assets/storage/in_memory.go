@@ -127,3 +127,183 @@ func CreateMaterialReadStorage() *MaterialReadStorage {
 
 	return &MaterialReadStorage{MaterialReadMap: make(map[uuid.UUID]MaterialRead), Lock: &rwMutex}
 }
+
+type FarmCertificationEventStorage struct {
+	Lock                    *deadlock.RWMutex
+	FarmCertificationEvents []FarmCertificationEvent
+}
+
+func CreateFarmCertificationEventStorage() *FarmCertificationEventStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("FARM CERTIFICATION EVENT STORAGE DEADLOCK!")
+	}
+
+	return &FarmCertificationEventStorage{Lock: &rwMutex}
+}
+
+type FarmCertificationReadStorage struct {
+	Lock                     *deadlock.RWMutex
+	FarmCertificationReadMap map[uuid.UUID]FarmCertificationRead
+}
+
+func CreateFarmCertificationReadStorage() *FarmCertificationReadStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("FARM CERTIFICATION READ STORAGE DEADLOCK!")
+	}
+
+	return &FarmCertificationReadStorage{
+		FarmCertificationReadMap: make(map[uuid.UUID]FarmCertificationRead),
+		Lock:                     &rwMutex,
+	}
+}
+
+type CustomFieldDefinitionEventStorage struct {
+	Lock                        *deadlock.RWMutex
+	CustomFieldDefinitionEvents []CustomFieldDefinitionEvent
+}
+
+func CreateCustomFieldDefinitionEventStorage() *CustomFieldDefinitionEventStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("CUSTOM FIELD DEFINITION EVENT STORAGE DEADLOCK!")
+	}
+
+	return &CustomFieldDefinitionEventStorage{Lock: &rwMutex}
+}
+
+type CustomFieldDefinitionReadStorage struct {
+	Lock                         *deadlock.RWMutex
+	CustomFieldDefinitionReadMap map[uuid.UUID]CustomFieldDefinitionRead
+}
+
+func CreateCustomFieldDefinitionReadStorage() *CustomFieldDefinitionReadStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("CUSTOM FIELD DEFINITION READ STORAGE DEADLOCK!")
+	}
+
+	return &CustomFieldDefinitionReadStorage{
+		CustomFieldDefinitionReadMap: make(map[uuid.UUID]CustomFieldDefinitionRead),
+		Lock:                         &rwMutex,
+	}
+}
+
+type StocktakeEventStorage struct {
+	Lock            *deadlock.RWMutex
+	StocktakeEvents []StocktakeEvent
+}
+
+func CreateStocktakeEventStorage() *StocktakeEventStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("STOCKTAKE EVENT STORAGE DEADLOCK!")
+	}
+
+	return &StocktakeEventStorage{Lock: &rwMutex}
+}
+
+type StocktakeReadStorage struct {
+	Lock             *deadlock.RWMutex
+	StocktakeReadMap map[uuid.UUID]StocktakeRead
+}
+
+func CreateStocktakeReadStorage() *StocktakeReadStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("STOCKTAKE READ STORAGE DEADLOCK!")
+	}
+
+	return &StocktakeReadStorage{
+		StocktakeReadMap: make(map[uuid.UUID]StocktakeRead),
+		Lock:             &rwMutex,
+	}
+}
+
+type EquipmentEventStorage struct {
+	Lock            *deadlock.RWMutex
+	EquipmentEvents []EquipmentEvent
+}
+
+func CreateEquipmentEventStorage() *EquipmentEventStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("EQUIPMENT EVENT STORAGE DEADLOCK!")
+	}
+
+	return &EquipmentEventStorage{Lock: &rwMutex}
+}
+
+type EquipmentReadStorage struct {
+	Lock             *deadlock.RWMutex
+	EquipmentReadMap map[uuid.UUID]EquipmentRead
+}
+
+func CreateEquipmentReadStorage() *EquipmentReadStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("EQUIPMENT READ STORAGE DEADLOCK!")
+	}
+
+	return &EquipmentReadStorage{
+		EquipmentReadMap: make(map[uuid.UUID]EquipmentRead),
+		Lock:             &rwMutex,
+	}
+}
+
+type NutrientRecipeEventStorage struct {
+	Lock                 *deadlock.RWMutex
+	NutrientRecipeEvents []NutrientRecipeEvent
+}
+
+func CreateNutrientRecipeEventStorage() *NutrientRecipeEventStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("NUTRIENT RECIPE EVENT STORAGE DEADLOCK!")
+	}
+
+	return &NutrientRecipeEventStorage{Lock: &rwMutex}
+}
+
+type NutrientRecipeReadStorage struct {
+	Lock                  *deadlock.RWMutex
+	NutrientRecipeReadMap map[uuid.UUID]NutrientRecipeRead
+}
+
+func CreateNutrientRecipeReadStorage() *NutrientRecipeReadStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("NUTRIENT RECIPE READ STORAGE DEADLOCK!")
+	}
+
+	return &NutrientRecipeReadStorage{
+		NutrientRecipeReadMap: make(map[uuid.UUID]NutrientRecipeRead),
+		Lock:                  &rwMutex,
+	}
+}
+
+type FarmBoundaryEventStorage struct {
+	Lock               *deadlock.RWMutex
+	FarmBoundaryEvents []FarmBoundaryEvent
+}
+
+func CreateFarmBoundaryEventStorage() *FarmBoundaryEventStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("FARM BOUNDARY EVENT STORAGE DEADLOCK!")
+	}
+
+	return &FarmBoundaryEventStorage{Lock: &rwMutex}
+}
@@ -4,7 +4,9 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
 )
 
 type FarmEvent struct {
@@ -12,6 +14,7 @@ type FarmEvent struct {
 	Version     int
 	CreatedDate time.Time
 	Event       interface{}
+	Actor       *actor.Actor
 }
 
 type FarmRead struct {
@@ -24,6 +27,20 @@ type FarmRead struct {
 	City        string    `json:"city"`
 	IsActive    bool      `json:"is_active"`
 	CreatedDate time.Time `json:"created_date"`
+
+	// AreaWalkOrder is the order the crew walks through the areas, the worksheets follow it.
+	AreaWalkOrder []uuid.UUID `json:"area_walk_order"`
+
+	// HarvestGrades are the grades of the harvests, the default ones until the farm sets its own.
+	HarvestGrades []domain.HarvestGrade `json:"harvest_grades"`
+
+	// Seasons are the seasons the farm set, empty for the default seasons of its hemisphere.
+	Seasons []timebuckethelper.Season `json:"seasons"`
+
+	// WithholdingPolicy is the policy the farm set, empty for the blocking default.
+	WithholdingPolicy string `json:"withholding_policy"`
+
+	DailyEffortBudgetMinutes int `json:"daily_effort_budget_minutes"`
 }
 
 type ReservoirEvent struct {
@@ -31,6 +48,7 @@ type ReservoirEvent struct {
 	Version      int
 	CreatedDate  time.Time
 	Event        interface{}
+	Actor        *actor.Actor
 }
 
 type ReservoirRead struct {
@@ -65,19 +83,43 @@ type AreaEvent struct {
 	Version     int
 	CreatedDate time.Time
 	Event       interface{}
+	Actor       *actor.Actor
 }
 
 type AreaRead struct {
-	UID         uuid.UUID     `json:"uid"`
-	Name        string        `json:"name"`
-	Size        AreaSize      `json:"size"`
-	Location    AreaLocation  `json:"location"`
-	Type        string        `json:"type"`
-	Photo       AreaPhoto     `json:"photo"`
-	CreatedDate time.Time     `json:"created_date"`
-	Notes       []AreaNote    `json:"notes"`
-	Farm        AreaFarm      `json:"farm"`
-	Reservoir   AreaReservoir `json:"reservoir"`
+	UID          uuid.UUID              `json:"uid"`
+	Name         string                 `json:"name"`
+	Size         AreaSize               `json:"size"`
+	Location     AreaLocation           `json:"location"`
+	Type         string                 `json:"type"`
+	Photo        AreaPhoto              `json:"photo"`
+	CreatedDate  time.Time              `json:"created_date"`
+	Notes        []AreaNote             `json:"notes"`
+	Farm         AreaFarm               `json:"farm"`
+	Reservoir    AreaReservoir          `json:"reservoir"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Layout       *AreaLayout            `json:"layout"`
+	BedMap       *BedMap                `json:"bed_map"`
+	GeoPoint     *domain.GeoPoint       `json:"geo_point"`
+
+	Sanitations       []AreaSanitation `json:"sanitations"`
+	LastSanitizedDate *time.Time       `json:"last_sanitized_date"`
+}
+
+type AreaSanitation struct {
+	UID           uuid.UUID                `json:"uid"`
+	Method        string                   `json:"method"`
+	Materials     []AreaSanitationMaterial `json:"materials"`
+	Notes         string                   `json:"notes"`
+	SanitizedDate time.Time                `json:"sanitized_date"`
+}
+
+type AreaSanitationMaterial struct {
+	MaterialUID  uuid.UUID `json:"material_id"`
+	MaterialName string    `json:"material_name"`
+	Quantity     float32   `json:"quantity"`
+	QuantityUnit string    `json:"quantity_unit"`
+	Shortfall    float32   `json:"shortfall"`
 }
 
 type AreaFarm struct {
@@ -96,6 +138,8 @@ type (
 	AreaType     domain.AreaType
 	AreaPhoto    domain.AreaPhoto
 	AreaNote     domain.AreaNote
+	AreaLayout   domain.AreaLayout
+	BedMap       domain.BedMap
 )
 
 type MaterialEvent struct {
@@ -103,6 +147,7 @@ type MaterialEvent struct {
 	Version     int
 	CreatedDate time.Time
 	Event       interface{}
+	Actor       *actor.Actor
 }
 
 type MaterialRead struct {
@@ -115,7 +160,11 @@ type MaterialRead struct {
 	Notes          *string          `json:"notes"`
 	IsExpense      *bool            `json:"is_expense"`
 	ProducedBy     *string          `json:"produced_by"`
+	GDDToMaturity  *float64         `json:"gdd_to_maturity"`
 	CreatedDate    time.Time        `json:"created_date"`
+
+	// PreHarvestIntervalDays are the days a crop can't be harvested after it's treated with the material.
+	PreHarvestIntervalDays *int `json:"pre_harvest_interval_days"`
 }
 
 type (
@@ -182,3 +231,160 @@ type Inventory struct {
 	PlantType string    `json:"plant_type"`
 	Name      string    `json:"name"`
 }
+
+type FarmCertificationEvent struct {
+	FarmCertificationUID uuid.UUID
+	Version              int
+	CreatedDate          time.Time
+	Event                interface{}
+	Actor                *actor.Actor
+}
+
+type FarmCertificationRead struct {
+	UID               uuid.UUID  `json:"uid"`
+	FarmUID           uuid.UUID  `json:"farm_id"`
+	CertificationType string     `json:"certification_type"`
+	CertifyingBody    string     `json:"certifying_body"`
+	IssuedDate        time.Time  `json:"issued_date"`
+	ExpiryDate        time.Time  `json:"expiry_date"`
+	CertificateNumber string     `json:"certificate_number"`
+	Status            string     `json:"status"`
+	IsRenewalDue      bool       `json:"is_renewal_due"`
+	RevokedDate       *time.Time `json:"revoked_date"`
+	CreatedDate       time.Time  `json:"created_date"`
+}
+
+type CustomFieldDefinitionEvent struct {
+	CustomFieldDefinitionUID uuid.UUID
+	Version                  int
+	CreatedDate              time.Time
+	Event                    interface{}
+	Actor                    *actor.Actor
+}
+
+type CustomFieldDefinitionRead struct {
+	UID         uuid.UUID `json:"uid"`
+	FarmUID     uuid.UUID `json:"farm_id"`
+	EntityType  string    `json:"entity_type"`
+	FieldKey    string    `json:"field_key"`
+	Label       string    `json:"label"`
+	FieldType   string    `json:"field_type"`
+	Options     []string  `json:"options"`
+	Required    bool      `json:"required"`
+	IsDeleted   bool      `json:"-"`
+	CreatedDate time.Time `json:"created_date"`
+}
+
+type StocktakeEvent struct {
+	StocktakeUID uuid.UUID
+	Version      int
+	CreatedDate  time.Time
+	Event        interface{}
+	Actor        *actor.Actor
+}
+
+type StocktakeRead struct {
+	UID        uuid.UUID           `json:"uid"`
+	FarmUID    uuid.UUID           `json:"farm_id"`
+	Status     string              `json:"status"`
+	Lines      []StocktakeLineRead `json:"lines"`
+	OpenedDate time.Time           `json:"opened_date"`
+	ClosedDate *time.Time          `json:"closed_date"`
+}
+
+type StocktakeLineRead struct {
+	MaterialUID      uuid.UUID `json:"material_id"`
+	MaterialName     string    `json:"material_name"`
+	ExpectedQuantity float32   `json:"expected_quantity"`
+	QuantityUnit     string    `json:"quantity_unit"`
+	CountedQuantity  *float32  `json:"counted_quantity"`
+}
+
+type EquipmentEvent struct {
+	EquipmentUID uuid.UUID
+	Version      int
+	CreatedDate  time.Time
+	Event        interface{}
+	Actor        *actor.Actor
+}
+
+type EquipmentRead struct {
+	UID                     uuid.UUID                 `json:"uid"`
+	FarmUID                 uuid.UUID                 `json:"farm_id"`
+	Name                    string                    `json:"name"`
+	Type                    string                    `json:"type"`
+	Location                *domain.EquipmentLocation `json:"location"`
+	PurchaseDate            *time.Time                `json:"purchase_date"`
+	Notes                   string                    `json:"notes"`
+	MaintenanceIntervalDays int                       `json:"maintenance_interval_days"`
+	NextMaintenanceDate     *time.Time                `json:"next_maintenance_date"`
+	GeoPoint                *domain.GeoPoint          `json:"geo_point"`
+	IsDeleted               bool                      `json:"-"`
+	CreatedDate             time.Time                 `json:"created_date"`
+}
+
+type NutrientRecipeEvent struct {
+	NutrientRecipeUID uuid.UUID
+	Version           int
+	CreatedDate       time.Time
+	Event             interface{}
+	Actor             *actor.Actor
+}
+
+type NutrientRecipeRead struct {
+	UID         uuid.UUID                      `json:"uid"`
+	FarmUID     uuid.UUID                      `json:"farm_id"`
+	Name        string                         `json:"name"`
+	TargetEC    float32                        `json:"target_ec"`
+	TargetPH    float32                        `json:"target_ph"`
+	Ingredients []NutrientRecipeIngredientRead `json:"ingredients"`
+	IsDeleted   bool                           `json:"-"`
+	CreatedDate time.Time                      `json:"created_date"`
+}
+
+type NutrientRecipeIngredientRead struct {
+	MaterialUID uuid.UUID `json:"material_id"`
+	Dose        float32   `json:"dose"`
+	PerLitres   float32   `json:"per_litres"`
+}
+
+const (
+	ReservoirMeasurementTypeMeasurement = "MEASUREMENT"
+	ReservoirMeasurementTypeDosing      = "DOSING"
+)
+
+// ReservoirMeasurementRead is a measurement of the water of a reservoir, or a dosing of it,
+// so the EC and pH can be read along with the dosings which changed them.
+type ReservoirMeasurementRead struct {
+	UID    uuid.UUID            `json:"uid"`
+	Type   string               `json:"type"`
+	Date   time.Time            `json:"date"`
+	EC     *float32             `json:"ec,omitempty"`
+	PH     *float32             `json:"ph,omitempty"`
+	Dosing *ReservoirDosingRead `json:"dosing,omitempty"`
+}
+
+type ReservoirDosingRead struct {
+	RecipeUID    uuid.UUID           `json:"recipe_id"`
+	RecipeName   string              `json:"recipe_name"`
+	TargetEC     float32             `json:"target_ec"`
+	TargetPH     float32             `json:"target_ph"`
+	VolumeLitres float32             `json:"volume_litres"`
+	Doses        []ReservoirDoseRead `json:"doses"`
+}
+
+type ReservoirDoseRead struct {
+	MaterialUID  uuid.UUID `json:"material_id"`
+	MaterialName string    `json:"material_name"`
+	Quantity     float32   `json:"quantity"`
+	QuantityUnit string    `json:"quantity_unit"`
+	Shortfall    float32   `json:"shortfall"`
+}
+
+type FarmBoundaryEvent struct {
+	FarmUID     uuid.UUID
+	Version     int
+	CreatedDate time.Time
+	Event       interface{}
+	Actor       *actor.Actor
+}
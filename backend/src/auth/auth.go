@@ -0,0 +1,142 @@
+// Package auth checks the permissions of the user roles.
+// The roles form a hierarchy, so a role has the permissions of the roles under it without being granted them.
+package auth
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+)
+
+type Role string
+
+const (
+	RoleOwner   Role = "OWNER"
+	RoleManager Role = "MANAGER"
+	RoleWorker  Role = "WORKER"
+)
+
+type Permission string
+
+const (
+	PermissionReadFarms       Permission = "farms:read"
+	PermissionCompleteTasks   Permission = "tasks:complete"
+	PermissionRecordCrops     Permission = "crops:record"
+	PermissionManageTasks     Permission = "tasks:manage"
+	PermissionManageCrops     Permission = "crops:manage"
+	PermissionManageAreas     Permission = "areas:manage"
+	PermissionManageInventory Permission = "inventory:manage"
+	PermissionManageFarms     Permission = "farms:manage"
+	PermissionManageUsers     Permission = "users:manage"
+)
+
+// Policy grants the permissions of each role and the roles each role inherits from.
+// The inheritance is a directed acyclic graph, a role may inherit from several roles.
+type Policy struct {
+	Grants   map[Role][]Permission
+	Inherits map[Role][]Role
+}
+
+// DefaultPolicy is the Owner > Manager > Worker hierarchy.
+func DefaultPolicy() Policy {
+	return Policy{
+		Grants: map[Role][]Permission{
+			RoleWorker: {
+				PermissionReadFarms,
+				PermissionCompleteTasks,
+				PermissionRecordCrops,
+			},
+			RoleManager: {
+				PermissionManageTasks,
+				PermissionManageCrops,
+				PermissionManageAreas,
+				PermissionManageInventory,
+			},
+			RoleOwner: {
+				PermissionManageFarms,
+				PermissionManageUsers,
+			},
+		},
+		Inherits: map[Role][]Role{
+			RoleOwner:   {RoleManager},
+			RoleManager: {RoleWorker},
+		},
+	}
+}
+
+// RequirePermission tells whether the role, or any role it inherits from, has the permission.
+func (p Policy) RequirePermission(role Role, permission Permission) bool {
+	found := false
+
+	p.walk(role, map[Role]bool{}, func(r Role) bool {
+		for _, v := range p.Grants[r] {
+			if v == permission {
+				found = true
+
+				return false
+			}
+		}
+
+		return true
+	})
+
+	return found
+}
+
+// Permissions returns the permissions of the role and of the roles it inherits from, sorted.
+func (p Policy) Permissions(role Role) []Permission {
+	unique := map[Permission]bool{}
+
+	p.walk(role, map[Role]bool{}, func(r Role) bool {
+		for _, v := range p.Grants[r] {
+			unique[v] = true
+		}
+
+		return true
+	})
+
+	permissions := []Permission{}
+	for v := range unique {
+		permissions = append(permissions, v)
+	}
+
+	sort.Slice(permissions, func(i, j int) bool {
+		return permissions[i] < permissions[j]
+	})
+
+	return permissions
+}
+
+// walk visits the role and the roles it inherits from until visit returns false.
+// A role inherited through several paths is visited once.
+func (p Policy) walk(role Role, visited map[Role]bool, visit func(Role) bool) bool {
+	if visited[role] {
+		return true
+	}
+
+	visited[role] = true
+
+	if !visit(role) {
+		return false
+	}
+
+	for _, v := range p.Inherits[role] {
+		if !p.walk(v, visited, visit) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Roles finds the role of a user.
+type Roles interface {
+	RoleOf(userUID uuid.UUID) Role
+}
+
+// AllOwners gives every user the owner role, like the users created before the roles.
+type AllOwners struct{}
+
+func (AllOwners) RoleOf(userUID uuid.UUID) Role {
+	return RoleOwner
+}
@@ -0,0 +1,93 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/auth"
+)
+
+type fixedRole auth.Role
+
+func (r fixedRole) RoleOf(userUID uuid.UUID) auth.Role {
+	return auth.Role(r)
+}
+
+func TestRequirePermissionWalksTheHierarchy(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	policy := auth.DefaultPolicy()
+
+	// When
+	ownerCompletes := policy.RequirePermission(auth.RoleOwner, auth.PermissionCompleteTasks)
+	managerCompletes := policy.RequirePermission(auth.RoleManager, auth.PermissionCompleteTasks)
+	managerManagesUsers := policy.RequirePermission(auth.RoleManager, auth.PermissionManageUsers)
+	workerManagesTasks := policy.RequirePermission(auth.RoleWorker, auth.PermissionManageTasks)
+	unknownReads := policy.RequirePermission(auth.Role("GUEST"), auth.PermissionReadFarms)
+
+	// Then
+	assert.True(t, ownerCompletes)
+	assert.True(t, managerCompletes)
+	assert.False(t, managerManagesUsers)
+	assert.False(t, workerManagesTasks)
+	assert.False(t, unknownReads)
+}
+
+func TestPermissionsOfSharedAncestors(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	policy := auth.Policy{
+		Grants: map[auth.Role][]auth.Permission{
+			"AGRONOMIST":    {auth.PermissionManageCrops},
+			"STOREKEEPER":   {auth.PermissionManageInventory},
+			auth.RoleWorker: {auth.PermissionReadFarms},
+		},
+		Inherits: map[auth.Role][]auth.Role{
+			auth.RoleManager: {"AGRONOMIST", "STOREKEEPER"},
+			"AGRONOMIST":     {auth.RoleWorker},
+			"STOREKEEPER":    {auth.RoleWorker},
+		},
+	}
+
+	// When
+	permissions := policy.Permissions(auth.RoleManager)
+
+	// Then
+	assert.Equal(t, []auth.Permission{
+		auth.PermissionManageCrops,
+		auth.PermissionReadFarms,
+		auth.PermissionManageInventory,
+	}, permissions)
+}
+
+func TestGetPermissions(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	e := echo.New()
+	auth.NewServer(auth.DefaultPolicy(), fixedRole(auth.RoleManager)).Mount(e.Group("/api/auth"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/permissions", nil)
+	rec := httptest.NewRecorder()
+
+	// When
+	e.ServeHTTP(rec, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	body := struct {
+		Data auth.EffectivePermissions `json:"data"`
+	}{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, auth.RoleManager, body.Data.Role)
+	assert.Contains(t, body.Data.Permissions, auth.PermissionCompleteTasks)
+	assert.NotContains(t, body.Data.Permissions, auth.PermissionManageFarms)
+}
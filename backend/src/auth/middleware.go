@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/farmscope"
+)
+
+// RoutePermission is the permission the writes to a route need. The Route is the echo pattern of the route,
+// like /api/tasks/:id/complete, or a prefix of the patterns when it ends with /*. An empty Method matches
+// every method.
+type RoutePermission struct {
+	Method     string
+	Route      string
+	Permission Permission
+}
+
+// RequirePermission refuses with 403 the requests of the users whose role doesn't have the permission.
+func RequirePermission(policy Policy, roles Roles, permission Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !permitted(c, policy, roles, permission) {
+				return forbidden(c)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequireRoutePermissions is RequirePermission for the writes of a group whose routes need different permissions.
+// The rule of the route wins over the prefixes, the longest prefix wins over the shorter ones, and the writes
+// no rule matches need the fallback permission. The reads go through.
+func RequireRoutePermissions(
+	policy Policy, roles Roles, fallback Permission, rules []RoutePermission,
+) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			method := c.Request().Method
+			if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions {
+				return next(c)
+			}
+
+			permission := routePermission(method, c.Path(), fallback, rules)
+			if !permitted(c, policy, roles, permission) {
+				return forbidden(c)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func routePermission(method, path string, fallback Permission, rules []RoutePermission) Permission {
+	permission := fallback
+	longest := -1
+
+	for _, v := range rules {
+		if v.Method != "" && v.Method != method {
+			continue
+		}
+
+		if v.Route == path {
+			return v.Permission
+		}
+
+		prefix := strings.TrimSuffix(v.Route, "*")
+		if prefix != v.Route && strings.HasPrefix(path, prefix) && len(prefix) > longest {
+			permission = v.Permission
+			longest = len(prefix)
+		}
+	}
+
+	return permission
+}
+
+func permitted(c echo.Context, policy Policy, roles Roles, permission Permission) bool {
+	// The demo mode has no authentication, so there is no user, and the roles give the nil uid its role.
+	userUID, _ := c.Get(farmscope.UserKey).(uuid.UUID)
+
+	return policy.RequirePermission(roles.RoleOf(userUID), permission)
+}
+
+func forbidden(c echo.Context) error {
+	return c.JSON(http.StatusForbidden, map[string]string{"data": "Forbidden"})
+}
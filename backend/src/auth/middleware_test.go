@@ -0,0 +1,118 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/auth"
+	"github.com/usetania/tania-core/src/farmscope"
+)
+
+func newPermissionServer(group string, middleware echo.MiddlewareFunc) *echo.Echo {
+	e := echo.New()
+	authenticated := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(farmscope.UserKey, uuid.Must(uuid.NewV4()))
+
+			return next(c)
+		}
+	}
+
+	g := e.Group(group, authenticated, middleware)
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+
+	g.GET("/:id", ok)
+	g.POST("", ok)
+	g.POST("/:id/areas", ok)
+	g.PUT("/:id/complete", ok)
+	g.POST("/crops/:id/water", ok)
+	g.POST("/crops/:id/move", ok)
+
+	return e
+}
+
+func serve(e *echo.Echo, method, target string) int {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+
+	return rec.Code
+}
+
+func TestRequireRoutePermissionsRefusesWorkers(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farms := auth.RequireRoutePermissions(auth.DefaultPolicy(), fixedRole(auth.RoleWorker),
+		auth.PermissionManageFarms, auth.FarmRoutePermissions())
+	e := newPermissionServer("/api/farms", farms)
+
+	// When
+	createFarm := serve(e, http.MethodPost, "/api/farms")
+	createArea := serve(e, http.MethodPost, "/api/farms/1/areas")
+	moveCrop := serve(e, http.MethodPost, "/api/farms/crops/1/move")
+	waterCrop := serve(e, http.MethodPost, "/api/farms/crops/1/water")
+	readFarm := serve(e, http.MethodGet, "/api/farms/1")
+
+	// Then
+	assert.Equal(t, http.StatusForbidden, createFarm)
+	assert.Equal(t, http.StatusForbidden, createArea)
+	assert.Equal(t, http.StatusForbidden, moveCrop)
+	assert.Equal(t, http.StatusOK, waterCrop)
+	assert.Equal(t, http.StatusOK, readFarm)
+}
+
+func TestRequireRoutePermissionsLetsManagersManage(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farms := auth.RequireRoutePermissions(auth.DefaultPolicy(), fixedRole(auth.RoleManager),
+		auth.PermissionManageFarms, auth.FarmRoutePermissions())
+	e := newPermissionServer("/api/farms", farms)
+
+	// When
+	createFarm := serve(e, http.MethodPost, "/api/farms")
+	createArea := serve(e, http.MethodPost, "/api/farms/1/areas")
+	moveCrop := serve(e, http.MethodPost, "/api/farms/crops/1/move")
+
+	// Then
+	assert.Equal(t, http.StatusForbidden, createFarm)
+	assert.Equal(t, http.StatusOK, createArea)
+	assert.Equal(t, http.StatusOK, moveCrop)
+}
+
+func TestRequireRoutePermissionsOfTasks(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	tasks := auth.RequireRoutePermissions(auth.DefaultPolicy(), fixedRole(auth.RoleWorker),
+		auth.PermissionManageTasks, auth.TaskRoutePermissions())
+	e := newPermissionServer("/api/tasks", tasks)
+
+	// When
+	createTask := serve(e, http.MethodPost, "/api/tasks")
+	completeTask := serve(e, http.MethodPut, "/api/tasks/1/complete")
+
+	// Then
+	assert.Equal(t, http.StatusForbidden, createTask)
+	assert.Equal(t, http.StatusOK, completeTask)
+}
+
+func TestRequirePermissionRefusesEveryRequest(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	admin := auth.RequirePermission(auth.DefaultPolicy(), fixedRole(auth.RoleWorker), auth.PermissionManageFarms)
+	e := newPermissionServer("/api/admin", admin)
+
+	// When
+	read := serve(e, http.MethodGet, "/api/admin/1")
+	write := serve(e, http.MethodPost, "/api/admin")
+
+	// Then
+	assert.Equal(t, http.StatusForbidden, read)
+	assert.Equal(t, http.StatusForbidden, write)
+}
@@ -0,0 +1,335 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/farmscope"
+)
+
+const (
+	// RedactTag marks a string field redacted for some roles, like `redact:"if:role!=Owner"`.
+	RedactTag = "redact"
+
+	// RedactedValue replaces the value of a redacted field in the responses.
+	RedactedValue = "[REDACTED]"
+)
+
+// RedactedField is a field marked with the redact tag, hidden from the roles its condition matches.
+type RedactedField struct {
+	Type      string `json:"type"`
+	Field     string `json:"field"`
+	Condition string `json:"condition"`
+
+	kind reflect.Kind
+}
+
+// RedactCondition is the parsed condition of a redact tag, a role compared with == or !=.
+type RedactCondition struct {
+	Role  Role
+	Equal bool
+}
+
+// ParseRedactCondition parses a redact tag like `if:role!=Owner` or `if:role==Worker`. The role is case
+// insensitive.
+func ParseRedactCondition(tag string) (RedactCondition, error) {
+	expression := strings.TrimSpace(tag)
+	if !strings.HasPrefix(expression, "if:") {
+		return RedactCondition{}, errors.New("redact condition should start with if: " + tag)
+	}
+
+	expression = strings.TrimPrefix(expression, "if:")
+
+	for _, operator := range []string{"!=", "=="} {
+		subject, role, found := strings.Cut(expression, operator)
+		if !found {
+			continue
+		}
+
+		role = strings.TrimSpace(role)
+
+		if strings.TrimSpace(subject) != "role" || role == "" {
+			return RedactCondition{}, errors.New("redact condition should compare the role: " + tag)
+		}
+
+		return RedactCondition{Role: Role(strings.ToUpper(role)), Equal: operator == "=="}, nil
+	}
+
+	return RedactCondition{}, errors.New("redact condition should use == or !=: " + tag)
+}
+
+// Redacts tells whether the field is redacted for the role.
+func (c RedactCondition) Redacts(role Role) bool {
+	return (role == c.Role) == c.Equal
+}
+
+// Redactor redacts the marked fields of the responses for the role of the authenticated user.
+type Redactor struct {
+	Roles Roles
+
+	// Values are the types listed by the redaction config, the responses are redacted whatever their type.
+	Values []interface{}
+}
+
+// NewRedactor checks the redact tags of the types of the values, listed by the redaction config.
+func NewRedactor(roles Roles, values ...interface{}) (*Redactor, error) {
+	r := &Redactor{Roles: roles, Values: values}
+
+	for _, v := range r.Fields() {
+		if v.kind != reflect.String {
+			return nil, errors.New("only the string fields can be redacted: " + v.Type + "." + v.Field)
+		}
+
+		_, err := ParseRedactCondition(v.Condition)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Mount defines the redaction config endpoint, on the admin group.
+func (r *Redactor) Mount(g *echo.Group) {
+	g.GET("/redaction-config", r.GetRedactionConfig)
+}
+
+// GetRedactionConfig lists the redacted fields with their conditions.
+func (r *Redactor) GetRedactionConfig(c echo.Context) error {
+	data := make(map[string][]RedactedField)
+	data["data"] = r.Fields()
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// Fields lists the redacted fields of the types of the values and of the structs they contain, sorted.
+func (r *Redactor) Fields() []RedactedField {
+	fields := []RedactedField{}
+	visited := map[reflect.Type]bool{}
+
+	for _, v := range r.Values {
+		fields = append(fields, redactedFields(reflect.TypeOf(v), visited)...)
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].Type != fields[j].Type {
+			return fields[i].Type < fields[j].Type
+		}
+
+		return fields[i].Field < fields[j].Field
+	})
+
+	return fields
+}
+
+// Middleware redacts the JSON responses of the handlers for the role of the authenticated user.
+func (r *Redactor) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return next(redactingContext{Context: c, roles: r.Roles})
+		}
+	}
+}
+
+// redactingContext redacts the values of the JSON responses. The role is read when the response is written,
+// once the user is authenticated.
+type redactingContext struct {
+	echo.Context
+	roles Roles
+}
+
+func (c redactingContext) JSON(code int, i interface{}) error {
+	return c.Context.JSON(code, Redact(i, roleOf(c.Context, c.roles)))
+}
+
+// RoleOf is the role of the authenticated user of the request, for the responses which aren't JSON.
+func (r *Redactor) RoleOf(c echo.Context) Role {
+	return roleOf(c, r.Roles)
+}
+
+func roleOf(c echo.Context, roles Roles) Role {
+	// The demo mode has no authentication, so there is no user.
+	userUID, _ := c.Get(farmscope.UserKey).(uuid.UUID)
+
+	return roles.RoleOf(userUID)
+}
+
+// Redact returns a copy of the value with the redacted string fields replaced for the role. A field with an
+// invalid condition is always redacted.
+func Redact(value interface{}, role Role) interface{} {
+	return redact(value, func(tag string) bool {
+		condition, err := ParseRedactCondition(tag)
+
+		return err != nil || condition.Redacts(role)
+	})
+}
+
+// RedactAll returns a copy of the value with all the redacted string fields replaced, for the payloads sent
+// without a user, like the MQTT events and the webhook posts.
+func RedactAll(value interface{}) interface{} {
+	return redact(value, func(string) bool {
+		return true
+	})
+}
+
+// Redacts tells whether the field of the struct, by its name in Go, is redacted for the role.
+func Redacts(value interface{}, field string, role Role) bool {
+	structField, ok := reflect.TypeOf(value).FieldByName(field)
+	if !ok {
+		return false
+	}
+
+	tag, ok := structField.Tag.Lookup(RedactTag)
+	if !ok {
+		return false
+	}
+
+	condition, err := ParseRedactCondition(tag)
+
+	return err != nil || condition.Redacts(role)
+}
+
+func redact(value interface{}, redacts func(tag string) bool) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	return redactValue(reflect.ValueOf(value), redacts).Interface()
+}
+
+func redactValue(v reflect.Value, redacts func(tag string) bool) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+
+		elem := redactValue(v.Elem(), redacts)
+
+		if v.Kind() == reflect.Interface {
+			copied := reflect.New(v.Type()).Elem()
+			copied.Set(elem)
+
+			return copied
+		}
+
+		copied := reflect.New(v.Type().Elem())
+		copied.Elem().Set(elem)
+
+		return copied
+
+	case reflect.Struct:
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			if tag, ok := field.Tag.Lookup(RedactTag); ok && field.Type.Kind() == reflect.String {
+				if redacts(tag) {
+					copied.Field(i).SetString(RedactedValue)
+				}
+
+				continue
+			}
+
+			copied.Field(i).Set(redactValue(v.Field(i), redacts))
+		}
+
+		return copied
+
+	case reflect.Slice:
+		if v.IsNil() || !holdsStructs(v.Type().Elem()) {
+			return v
+		}
+
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(redactValue(v.Index(i), redacts))
+		}
+
+		return copied
+
+	case reflect.Map:
+		if v.IsNil() || !holdsStructs(v.Type().Elem()) {
+			return v
+		}
+
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), redactValue(iter.Value(), redacts))
+		}
+
+		return copied
+
+	default:
+		return v
+	}
+}
+
+// holdsStructs tells whether the values of the type may hold a struct with redacted fields, the slices of bytes
+// or of ids are left as they are.
+func holdsStructs(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Interface, reflect.Ptr, reflect.Slice, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+func redactedFields(t reflect.Type, visited map[reflect.Type]bool) []RedactedField {
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Map) {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct || visited[t] {
+		return nil
+	}
+
+	visited[t] = true
+
+	fields := []RedactedField{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if tag, ok := field.Tag.Lookup(RedactTag); ok {
+			fields = append(fields, RedactedField{
+				Type:      t.String(),
+				Field:     jsonName(field),
+				Condition: tag,
+				kind:      field.Type.Kind(),
+			})
+
+			continue
+		}
+
+		fields = append(fields, redactedFields(field.Type, visited)...)
+	}
+
+	return fields
+}
+
+// jsonName is the name of the field in the responses.
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+
+	return name
+}
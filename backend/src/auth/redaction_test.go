@@ -0,0 +1,147 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/auth"
+)
+
+type redactedNote struct {
+	Title   string `json:"title"`
+	Content string `json:"content" redact:"if:role!=Owner"`
+}
+
+type redactedTask struct {
+	Title        string         `json:"title"`
+	Instructions string         `json:"instructions" redact:"if:role==Worker"`
+	Notes        []redactedNote `json:"notes"`
+	Details      interface{}    `json:"details"`
+}
+
+func TestParseRedactCondition(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	tags := []string{"if:role!=Owner", "if: role == worker", "role!=Owner", "if:user!=Owner", "if:role>Owner"}
+
+	// When
+	conditions := []auth.RedactCondition{}
+	errs := []error{}
+
+	for _, v := range tags {
+		condition, err := auth.ParseRedactCondition(v)
+		conditions = append(conditions, condition)
+		errs = append(errs, err)
+	}
+
+	// Then
+	assert.Nil(t, errs[0])
+	assert.Equal(t, auth.RedactCondition{Role: auth.RoleOwner, Equal: false}, conditions[0])
+	assert.True(t, conditions[0].Redacts(auth.RoleManager))
+	assert.False(t, conditions[0].Redacts(auth.RoleOwner))
+
+	assert.Nil(t, errs[1])
+	assert.True(t, conditions[1].Redacts(auth.RoleWorker))
+	assert.False(t, conditions[1].Redacts(auth.RoleManager))
+
+	assert.NotNil(t, errs[2])
+	assert.NotNil(t, errs[3])
+	assert.NotNil(t, errs[4])
+}
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	task := redactedTask{
+		Title:        "Spray",
+		Instructions: "EPA Reg. No. 100-1070",
+		Notes:        []redactedNote{{Title: "Dose", Content: "2 ml per litre"}},
+		Details:      &redactedNote{Title: "Label", Content: "Restricted use"},
+	}
+	data := map[string]interface{}{"data": []redactedTask{task}}
+
+	// When
+	ownerData := auth.Redact(data, auth.RoleOwner).(map[string]interface{})
+	workerData := auth.Redact(data, auth.RoleWorker).(map[string]interface{})
+
+	// Then
+	owner := ownerData["data"].([]redactedTask)[0]
+	assert.Equal(t, "EPA Reg. No. 100-1070", owner.Instructions)
+	assert.Equal(t, "2 ml per litre", owner.Notes[0].Content)
+	assert.Equal(t, "Restricted use", owner.Details.(*redactedNote).Content)
+
+	worker := workerData["data"].([]redactedTask)[0]
+	assert.Equal(t, "Spray", worker.Title)
+	assert.Equal(t, auth.RedactedValue, worker.Instructions)
+	assert.Equal(t, "Dose", worker.Notes[0].Title)
+	assert.Equal(t, auth.RedactedValue, worker.Notes[0].Content)
+	assert.Equal(t, auth.RedactedValue, worker.Details.(*redactedNote).Content)
+
+	// The value given is left as it is.
+	assert.Equal(t, "EPA Reg. No. 100-1070", task.Instructions)
+	assert.Equal(t, "Restricted use", task.Details.(*redactedNote).Content)
+}
+
+func TestRedactAll(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	task := redactedTask{Title: "Spray", Instructions: "EPA Reg. No. 100-1070"}
+
+	// When
+	redacted := auth.RedactAll(task).(redactedTask)
+
+	// Then
+	assert.Equal(t, "Spray", redacted.Title)
+	assert.Equal(t, auth.RedactedValue, redacted.Instructions)
+	assert.True(t, auth.Redacts(redactedTask{}, "Instructions", auth.RoleWorker))
+	assert.False(t, auth.Redacts(redactedTask{}, "Instructions", auth.RoleManager))
+	assert.False(t, auth.Redacts(redactedTask{}, "Title", auth.RoleWorker))
+}
+
+func TestRedactorMiddleware(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	redactor, err := auth.NewRedactor(fixedRole(auth.RoleManager), redactedTask{})
+	assert.Nil(t, err)
+
+	e := echo.New()
+	g := e.Group("/api/tasks", redactor.Middleware())
+	g.GET("", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]redactedNote{"data": {Title: "Dose", Content: "2 ml per litre"}})
+	})
+	redactor.Mount(e.Group("/api/admin"))
+
+	// When
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/tasks", nil))
+
+	configRec := httptest.NewRecorder()
+	e.ServeHTTP(configRec, httptest.NewRequest(http.MethodGet, "/api/admin/redaction-config", nil))
+
+	_, invalidErr := auth.NewRedactor(fixedRole(auth.RoleManager), struct {
+		Count int `redact:"if:role!=Owner"`
+	}{})
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"data": {"title": "Dose", "content": "[REDACTED]"}}`, rec.Body.String())
+
+	body := struct {
+		Data []auth.RedactedField `json:"data"`
+	}{}
+	assert.Nil(t, json.Unmarshal(configRec.Body.Bytes(), &body))
+	assert.Equal(t, []auth.RedactedField{
+		{Type: "auth_test.redactedNote", Field: "content", Condition: "if:role!=Owner"},
+		{Type: "auth_test.redactedTask", Field: "instructions", Condition: "if:role==Worker"},
+	}, body.Data)
+
+	assert.NotNil(t, invalidErr)
+}
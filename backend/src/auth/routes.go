@@ -0,0 +1,66 @@
+package auth
+
+import "net/http"
+
+// FarmRoutePermissions are the permissions of the writes to the /api/farms routes.
+// The writes they don't list, like the farm settings, need PermissionManageFarms.
+func FarmRoutePermissions() []RoutePermission {
+	return []RoutePermission{
+		// The records of the work in the field.
+		{http.MethodPost, "/api/farms/:id/energy-readings", PermissionRecordCrops},
+		{http.MethodPost, "/api/farms/crops/:id/harvest", PermissionRecordCrops},
+		{http.MethodPost, "/api/farms/crops/:id/water", PermissionRecordCrops},
+		{http.MethodPost, "/api/farms/crops/:id/germination", PermissionRecordCrops},
+		{http.MethodPost, "/api/farms/crops/:id/notes", PermissionRecordCrops},
+		{http.MethodDelete, "/api/farms/crops/:crop_id/notes/:note_id", PermissionRecordCrops},
+		{http.MethodPost, "/api/farms/crops/:id/photos", PermissionRecordCrops},
+		{http.MethodPost, "/api/farms/crops/:id/photos/bulk", PermissionRecordCrops},
+		{http.MethodPost, "/api/farms/crops/:crop_id/photos/:photo_id/retry", PermissionRecordCrops},
+		{http.MethodDelete, "/api/farms/crops/:crop_id/photos/:photo_id", PermissionRecordCrops},
+		{http.MethodPost, "/api/farms/:id/crops/:crop_id/germination-check", PermissionRecordCrops},
+		{http.MethodPost, "/api/farms/areas/:id/microclimate-samples", PermissionRecordCrops},
+		{http.MethodPost, "/api/farms/areas/:id/notes", PermissionRecordCrops},
+		{http.MethodDelete, "/api/farms/areas/:area_id/notes/:note_id", PermissionRecordCrops},
+		{http.MethodPost, "/api/farms/:id/areas/:area_id/sanitations", PermissionRecordCrops},
+		{http.MethodPost, "/api/farms/reservoirs/:id/notes", PermissionRecordCrops},
+		{http.MethodDelete, "/api/farms/reservoirs/:reservoir_id/notes/:note_id", PermissionRecordCrops},
+		{http.MethodPost, "/api/farms/:id/reservoirs/:reservoir_id/measurements", PermissionRecordCrops},
+		{http.MethodPost, "/api/farms/:id/reservoirs/:reservoir_id/dose", PermissionRecordCrops},
+		{http.MethodPost, "/api/farms/:id/presence", PermissionReadFarms},
+
+		// The crops.
+		{"", "/api/farms/crops/*", PermissionManageCrops},
+		{http.MethodPost, "/api/farms/areas/:id/crops", PermissionManageCrops},
+		{"", "/api/farms/:id/crops/*", PermissionManageCrops},
+		{http.MethodPost, "/api/farms/:id/dispatch-schedules", PermissionManageCrops},
+		{http.MethodPost, "/api/farms/:id/lots/:lot_id/shipments", PermissionManageCrops},
+		{"", "/api/farms/areas/:id/environment-alert-rules*", PermissionManageCrops},
+		{"", "/api/farms/:id/nutrient_recipes*", PermissionManageCrops},
+		{http.MethodPost, "/api/farms/:id/areas/:area_id/bed-map/crop-placement", PermissionManageCrops},
+
+		// The areas and the reservoirs.
+		{http.MethodPut, "/api/farms/:id/area_walk_order", PermissionManageAreas},
+		{http.MethodPost, "/api/farms/:id/areas", PermissionManageAreas},
+		{http.MethodPost, "/api/farms/:id/areas/bulk", PermissionManageAreas},
+		{http.MethodPut, "/api/farms/:id/areas/:area_id/bed-map", PermissionManageAreas},
+		{"", "/api/farms/areas/*", PermissionManageAreas},
+		{"", "/api/farms/:farm_id/areas/*", PermissionManageAreas},
+		{http.MethodPost, "/api/farms/:id/reservoirs", PermissionManageAreas},
+		{http.MethodPut, "/api/farms/reservoirs/:id", PermissionManageAreas},
+
+		// The inventory.
+		{"", "/api/farms/inventories/*", PermissionManageInventory},
+		{"", "/api/farms/:id/stocktakes*", PermissionManageInventory},
+		{"", "/api/farms/:id/equipment*", PermissionManageInventory},
+	}
+}
+
+// TaskRoutePermissions are the permissions of the writes to the /api/tasks routes.
+// The writes they don't list need PermissionManageTasks.
+func TaskRoutePermissions() []RoutePermission {
+	return []RoutePermission{
+		{http.MethodPut, "/api/tasks/:id/complete", PermissionCompleteTasks},
+		{http.MethodPost, "/api/tasks/:id/progress", PermissionCompleteTasks},
+		{http.MethodPut, "/api/tasks/:id/areas/:area_id/progress", PermissionCompleteTasks},
+	}
+}
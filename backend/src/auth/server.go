@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/farmscope"
+)
+
+// EffectivePermissions is the body of GET /api/auth/permissions.
+type EffectivePermissions struct {
+	Role        Role         `json:"role"`
+	Permissions []Permission `json:"permissions"`
+}
+
+type Server struct {
+	Policy Policy
+	Roles  Roles
+}
+
+func NewServer(policy Policy, roles Roles) *Server {
+	return &Server{
+		Policy: policy,
+		Roles:  roles,
+	}
+}
+
+// Mount defines the auth endpoints with their handlers.
+func (s *Server) Mount(g *echo.Group) {
+	g.GET("/permissions", s.GetPermissions)
+}
+
+// GetPermissions lists the permissions of the role of the authenticated user, with the inherited ones.
+func (s *Server) GetPermissions(c echo.Context) error {
+	// The demo mode has no authentication, so there is no user.
+	userUID, _ := c.Get(farmscope.UserKey).(uuid.UUID)
+
+	role := s.Roles.RoleOf(userUID)
+
+	data := make(map[string]EffectivePermissions)
+	data["data"] = EffectivePermissions{
+		Role:        role,
+		Permissions: s.Policy.Permissions(role),
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
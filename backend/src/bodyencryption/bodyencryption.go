@@ -0,0 +1,436 @@
+// Package bodyencryption encrypts the request and response bodies with AES-256-GCM, for the deployments
+// whose TLS ends at a load balancer. The key of each session is negotiated with an ECDH P-256 key exchange.
+package bodyencryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sasha-s/go-deadlock"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// HeaderSession is the header of the requests with the ID of their session,
+	// and of the responses whose body is encrypted with its key.
+	HeaderSession = "X-Tania-Session"
+	// HeaderContentType is the header of the encrypted responses with the content type of their plain body,
+	// their Content-Type being the one of the base64 text.
+	HeaderContentType = "X-Tania-Content-Type"
+
+	// SessionKeyPath is the path of the key exchange, the only request whose body is never encrypted.
+	SessionKeyPath = "/api/auth/session-key"
+	// APIPath is the prefix of the requests which have to be sent with a session.
+	APIPath = "/api/"
+
+	// KeyInfo is the HKDF info of the session keys.
+	KeyInfo = "tania body encryption"
+
+	// DefaultMaxSessions is the number of live sessions kept by default.
+	DefaultMaxSessions = 100000
+)
+
+var (
+	ErrInvalidPublicKey = errors.New("public key must be the base64 of an uncompressed P-256 point")
+	ErrDecryptionFailed = errors.New("body can't be decrypted with the session key")
+)
+
+type Session struct {
+	ID          string
+	Key         []byte
+	ExpiresDate time.Time
+
+	// The hash of the Authorization header of the first authenticated request of the session, empty before.
+	// The session only serves that token afterwards.
+	Token string
+}
+
+// SessionStorage keeps the sessions in memory, the clients negotiate a new key after a restart.
+// Beyond MaxSessions, the key exchange evicts the oldest session, the ones of no token first, so the
+// anonymous exchanges can't push the sessions of the signed in users out.
+type SessionStorage struct {
+	Lock        *deadlock.RWMutex
+	Sessions    map[string]Session
+	TTL         time.Duration
+	MaxSessions int
+
+	// The IDs of the sessions in the order they were created, and bound for the bound ones. They keep the IDs
+	// of the sessions bound, evicted or expired since, which are skipped.
+	anonymous []string
+	bound     []string
+}
+
+func CreateSessionStorage(ttl time.Duration) *SessionStorage {
+	return &SessionStorage{
+		Lock:        &deadlock.RWMutex{},
+		Sessions:    map[string]Session{},
+		TTL:         ttl,
+		MaxSessions: DefaultMaxSessions,
+	}
+}
+
+// Negotiate derives the key of a new session from the public key of the client, the base64 of its
+// uncompressed P-256 point. It returns the session with the public key of the server, in the same encoding.
+// The key is the HKDF-SHA256 of the shared secret, salted with the session ID.
+func (s *SessionStorage) Negotiate(clientPublicKey string, now time.Time) (Session, string, error) {
+	curve := ecdh.P256()
+
+	raw, err := base64.StdEncoding.DecodeString(clientPublicKey)
+	if err != nil {
+		return Session{}, "", ErrInvalidPublicKey
+	}
+
+	public, err := curve.NewPublicKey(raw)
+	if err != nil {
+		return Session{}, "", ErrInvalidPublicKey
+	}
+
+	private, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return Session{}, "", err
+	}
+
+	// The shared secret is the X coordinate of the shared point.
+	secret, err := private.ECDH(public)
+	if err != nil {
+		return Session{}, "", err
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return Session{}, "", err
+	}
+
+	key, err := DeriveKey(secret, uid.String())
+	if err != nil {
+		return Session{}, "", err
+	}
+
+	session := Session{ID: uid.String(), Key: key, ExpiresDate: now.Add(s.TTL)}
+
+	s.Lock.Lock()
+	defer s.Lock.Unlock()
+
+	s.dropExpired(now)
+
+	for len(s.Sessions) >= s.MaxSessions {
+		if !s.evictOldest() {
+			break
+		}
+	}
+
+	s.Sessions[session.ID] = session
+	s.anonymous = append(s.anonymous, session.ID)
+
+	return session, base64.StdEncoding.EncodeToString(private.PublicKey().Bytes()), nil
+}
+
+// Find returns the session of the ID unless it expired.
+func (s *SessionStorage) Find(id string, now time.Time) (Session, bool) {
+	s.Lock.RLock()
+	defer s.Lock.RUnlock()
+
+	session, ok := s.Sessions[id]
+	if !ok || !now.Before(session.ExpiresDate) {
+		return Session{}, false
+	}
+
+	return session, true
+}
+
+// Bind binds the session to the token of the Authorization header of its first authenticated request.
+// It tells whether the session serves the token, a session bound to another token doesn't.
+func (s *SessionStorage) Bind(id, authorization string) bool {
+	token := TokenHash(authorization)
+
+	s.Lock.Lock()
+	defer s.Lock.Unlock()
+
+	session, ok := s.Sessions[id]
+	if !ok {
+		return false
+	}
+
+	if session.Token == "" {
+		session.Token = token
+		s.Sessions[id] = session
+		s.bound = append(s.bound, id)
+	}
+
+	return session.Token == token
+}
+
+// TokenHash is the hash of the Authorization header a session is bound to.
+func TokenHash(authorization string) string {
+	sum := sha256.Sum256([]byte(authorization))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// dropExpired removes the expired sessions at the front of the queues, with the IDs which no longer are
+// the ones of a session of their queue.
+func (s *SessionStorage) dropExpired(now time.Time) {
+	for len(s.anonymous) > 0 {
+		session, ok := s.Sessions[s.anonymous[0]]
+		if ok && session.Token == "" && now.Before(session.ExpiresDate) {
+			break
+		}
+
+		if ok && session.Token == "" {
+			delete(s.Sessions, session.ID)
+		}
+
+		s.anonymous = s.anonymous[1:]
+	}
+
+	for len(s.bound) > 0 {
+		session, ok := s.Sessions[s.bound[0]]
+		if ok && now.Before(session.ExpiresDate) {
+			break
+		}
+
+		if ok {
+			delete(s.Sessions, session.ID)
+		}
+
+		s.bound = s.bound[1:]
+	}
+}
+
+// evictOldest removes the oldest session of no token, or else the session bound the earliest.
+// It tells whether there was one to remove.
+func (s *SessionStorage) evictOldest() bool {
+	for len(s.anonymous) > 0 {
+		session, ok := s.Sessions[s.anonymous[0]]
+		s.anonymous = s.anonymous[1:]
+
+		if ok && session.Token == "" {
+			delete(s.Sessions, session.ID)
+
+			return true
+		}
+	}
+
+	for len(s.bound) > 0 {
+		id := s.bound[0]
+		s.bound = s.bound[1:]
+
+		if _, ok := s.Sessions[id]; ok {
+			delete(s.Sessions, id)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeriveKey is the AES-256 key of the shared secret of the session.
+func DeriveKey(secret []byte, sessionID string) ([]byte, error) {
+	key := make([]byte, 32)
+
+	_, err := io.ReadFull(hkdf.New(sha256.New, secret, []byte(sessionID), []byte(KeyInfo)), key)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Seal encrypts the body with the key, into the base64 of a random 12 bytes nonce followed by the ciphertext.
+func Seal(key, body []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, body, nil)
+	encoded := make([]byte, base64.StdEncoding.EncodedLen(len(sealed)))
+	base64.StdEncoding.Encode(encoded, sealed)
+
+	return encoded, nil
+}
+
+// Open decrypts a body sealed with the key.
+func Open(key, body []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := make([]byte, base64.StdEncoding.DecodedLen(len(body)))
+
+	n, err := base64.StdEncoding.Decode(sealed, bytes.TrimSpace(body))
+	if err != nil || n < gcm.NonceSize() {
+		return nil, ErrDecryptionFailed
+	}
+
+	sealed = sealed[:n]
+
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+
+	return plain, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Middleware decrypts the body of the requests sent with the header of a session before they are routed,
+// and encrypts their response with the same key. An API request without a session is refused, the GETs too
+// as their responses are the farm data, apart from the key exchange and the public paths, like the health checks
+// of the load balancer. The public paths and the requests out of the API, like the web app, are sent as is.
+func Middleware(storage *SessionStorage, publicPaths ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			hasBody := req.Body != nil && req.Body != http.NoBody && req.ContentLength != 0
+
+			id := req.Header.Get(HeaderSession)
+			if id == "" {
+				if !sessionRequired(req.URL.Path, publicPaths) {
+					return next(c)
+				}
+
+				return errorResponse(c, http.StatusBadRequest, "BODY_ENCRYPTION_REQUIRED",
+					"The API requests have to be sent with a session key from "+SessionKeyPath+".")
+			}
+
+			session, ok := storage.Find(id, time.Now())
+			if !ok {
+				return errorResponse(c, http.StatusUnauthorized, "SESSION_KEY_EXPIRED",
+					"The session key is unknown or expired, negotiate a new one at "+SessionKeyPath+".")
+			}
+
+			// The session is bound to the token of its first authenticated request, the sign in comes before.
+			authorization := req.Header.Get(echo.HeaderAuthorization)
+			if authorization != "" && session.Token != TokenHash(authorization) && !storage.Bind(id, authorization) {
+				return errorResponse(c, http.StatusUnauthorized, "SESSION_TOKEN_MISMATCH",
+					"The session key is bound to another token, negotiate a new one at "+SessionKeyPath+".")
+			}
+
+			if hasBody {
+				body, err := io.ReadAll(req.Body)
+				if err != nil {
+					return err
+				}
+
+				plain, err := Open(session.Key, body)
+				if err != nil {
+					return errorResponse(c, http.StatusBadRequest, "BODY_DECRYPTION_FAILED", err.Error())
+				}
+
+				req.Body = io.NopCloser(bytes.NewReader(plain))
+				req.ContentLength = int64(len(plain))
+				req.Header.Del(echo.HeaderContentLength)
+			}
+
+			return encryptResponse(c, next, session)
+		}
+	}
+}
+
+// sessionRequired is whether the request of the path has to be sent with a session. The public paths cover the
+// paths under them.
+func sessionRequired(path string, publicPaths []string) bool {
+	path = strings.TrimSuffix(path, "/")
+	if path == SessionKeyPath || !strings.HasPrefix(path+"/", APIPath) {
+		return false
+	}
+
+	for _, v := range publicPaths {
+		if path == v || strings.HasPrefix(path, v+"/") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// encryptResponse buffers the response of the request to send it encrypted.
+func encryptResponse(c echo.Context, next echo.HandlerFunc, session Session) error {
+	res := c.Response()
+	writer := res.Writer
+	buffer := &bufferedWriter{ResponseWriter: writer, status: http.StatusOK}
+	res.Writer = buffer
+
+	// The error is turned into its response here, so it's encrypted like the others.
+	if err := next(c); err != nil {
+		c.Error(err)
+	}
+
+	res.Writer = writer
+
+	if buffer.body.Len() == 0 {
+		writer.WriteHeader(buffer.status)
+
+		return nil
+	}
+
+	sealed, err := Seal(session.Key, buffer.body.Bytes())
+	if err != nil {
+		return err
+	}
+
+	// The body is base64 text now, the clients read the type of what it seals from HeaderContentType.
+	writer.Header().Del(echo.HeaderContentLength)
+	writer.Header().Set(HeaderContentType, writer.Header().Get(echo.HeaderContentType))
+	writer.Header().Set(echo.HeaderContentType, echo.MIMETextPlainCharsetUTF8)
+	writer.Header().Set(HeaderSession, session.ID)
+	writer.WriteHeader(buffer.status)
+
+	_, err = writer.Write(sealed)
+
+	return err
+}
+
+// bufferedWriter keeps the status and the body of the response until it is encrypted.
+type bufferedWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func errorResponse(c echo.Context, status int, code, message string) error {
+	return c.JSON(status, map[string]string{
+		"field_name":    HeaderSession,
+		"error_code":    code,
+		"error_message": message,
+	})
+}
@@ -0,0 +1,235 @@
+package bodyencryption_test
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/bodyencryption"
+)
+
+func newTestApp(storage *SessionStorage) *echo.Echo {
+	e := echo.New()
+	e.Use(Middleware(storage, "/api/health"))
+
+	NewServer(storage).Mount(e.Group("/api/auth"))
+
+	e.POST("/api/tasks", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"title": c.FormValue("title")})
+	})
+	e.GET("/api/tasks", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"title": "Water the beds"})
+	})
+	e.GET("/api/health/ready", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+	})
+	e.GET("/index.html", func(c echo.Context) error {
+		return c.HTML(http.StatusOK, "<html></html>")
+	})
+
+	return e
+}
+
+// negotiate runs the key exchange like a client, and returns the session ID with the key it derived.
+func negotiate(t *testing.T, e *echo.Echo) (string, []byte) {
+	t.Helper()
+
+	private, _ := ecdh.P256().GenerateKey(rand.Reader)
+
+	form := url.Values{"public_key": {base64.StdEncoding.EncodeToString(private.PublicKey().Bytes())}}
+	req := httptest.NewRequest(http.MethodPost, SessionKeyPath, strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	response := map[string]SessionKey{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &response))
+
+	raw, _ := base64.StdEncoding.DecodeString(response["data"].PublicKey)
+	serverKey, err := ecdh.P256().NewPublicKey(raw)
+	assert.Nil(t, err)
+
+	secret, err := private.ECDH(serverKey)
+	assert.Nil(t, err)
+
+	key, err := DeriveKey(secret, response["data"].SessionID)
+	assert.Nil(t, err)
+
+	return response["data"].SessionID, key
+}
+
+func send(e *echo.Echo, method, sessionID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/tasks", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+
+	if sessionID != "" {
+		req.Header.Set(HeaderSession, sessionID)
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestEncryptedRequestAndResponse(t *testing.T) {
+	t.Parallel()
+	// Given
+	e := newTestApp(CreateSessionStorage(time.Hour))
+	sessionID, key := negotiate(t, e)
+
+	body, _ := Seal(key, []byte("title=Prune+the+tomatoes"))
+
+	// When
+	posted := send(e, http.MethodPost, sessionID, string(body))
+	listed := send(e, http.MethodGet, sessionID, "")
+
+	// Then
+	assert.Equal(t, http.StatusOK, posted.Code)
+	assert.Equal(t, sessionID, posted.Header().Get(HeaderSession))
+	assert.Equal(t, echo.MIMETextPlainCharsetUTF8, posted.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, echo.MIMEApplicationJSONCharsetUTF8, posted.Header().Get(HeaderContentType))
+	assert.NotContains(t, posted.Body.String(), "tomatoes")
+
+	plain, err := Open(key, posted.Body.Bytes())
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"title": "Prune the tomatoes"}`, string(plain))
+
+	plain, err = Open(key, listed.Body.Bytes())
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"title": "Water the beds"}`, string(plain))
+}
+
+func TestRefuseThePlainRequestsAndTheTamperedBodies(t *testing.T) {
+	t.Parallel()
+	// Given
+	e := newTestApp(CreateSessionStorage(time.Hour))
+	sessionID, key := negotiate(t, e)
+
+	otherKey := make([]byte, 32)
+	sealedWithOtherKey, _ := Seal(otherKey, []byte("title=Prune+the+tomatoes"))
+	sealed, _ := Seal(key, []byte("title=Prune+the+tomatoes"))
+
+	// When
+	plain := send(e, http.MethodPost, "", "title=Prune+the+tomatoes")
+	tampered := send(e, http.MethodPost, sessionID, string(sealedWithOtherKey))
+	unknown := send(e, http.MethodPost, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", string(sealed))
+	plainGet := send(e, http.MethodGet, "", "")
+	health := httptest.NewRecorder()
+	e.ServeHTTP(health, httptest.NewRequest(http.MethodGet, "/api/health/ready", nil))
+	page := httptest.NewRecorder()
+	e.ServeHTTP(page, httptest.NewRequest(http.MethodGet, "/index.html", nil))
+
+	// Then
+	assert.Equal(t, http.StatusBadRequest, plain.Code)
+	assert.Contains(t, plain.Body.String(), "BODY_ENCRYPTION_REQUIRED")
+	assert.Equal(t, http.StatusBadRequest, tampered.Code)
+	assert.Contains(t, tampered.Body.String(), "BODY_DECRYPTION_FAILED")
+	assert.Equal(t, http.StatusUnauthorized, unknown.Code)
+	assert.Contains(t, unknown.Body.String(), "SESSION_KEY_EXPIRED")
+	assert.Equal(t, http.StatusBadRequest, plainGet.Code)
+	assert.Contains(t, plainGet.Body.String(), "BODY_ENCRYPTION_REQUIRED")
+	assert.NotContains(t, plainGet.Body.String(), "Water the beds")
+	assert.JSONEq(t, `{"status": "ok"}`, health.Body.String())
+	assert.Equal(t, "<html></html>", page.Body.String())
+}
+
+func TestSessionsExpire(t *testing.T) {
+	t.Parallel()
+	// Given
+	storage := CreateSessionStorage(time.Hour)
+	private, _ := ecdh.P256().GenerateKey(rand.Reader)
+	now := time.Date(2026, time.October, 14, 8, 0, 0, 0, time.UTC)
+
+	// When
+	session, publicKey, err := storage.Negotiate(base64.StdEncoding.EncodeToString(private.PublicKey().Bytes()), now)
+	_, _, invalidErr := storage.Negotiate(base64.StdEncoding.EncodeToString([]byte("not a point")), now)
+
+	_, found := storage.Find(session.ID, now.Add(59*time.Minute))
+	_, foundExpired := storage.Find(session.ID, now.Add(time.Hour))
+
+	// Then
+	assert.Nil(t, err)
+	assert.NotEmpty(t, publicKey)
+	assert.Len(t, session.Key, 32)
+	assert.ErrorIs(t, invalidErr, ErrInvalidPublicKey)
+	assert.True(t, found)
+	assert.False(t, foundExpired)
+}
+
+func TestSessionsAreBoundToTheirToken(t *testing.T) {
+	t.Parallel()
+	// Given
+	e := newTestApp(CreateSessionStorage(time.Hour))
+	sessionID, _ := negotiate(t, e)
+
+	sendWithToken := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/tasks", nil)
+		req.Header.Set(HeaderSession, sessionID)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	// When
+	first := sendWithToken("first-token")
+	again := sendWithToken("first-token")
+	other := sendWithToken("other-token")
+
+	// Then
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusOK, again.Code)
+	assert.Equal(t, http.StatusUnauthorized, other.Code)
+	assert.Contains(t, other.Body.String(), "SESSION_TOKEN_MISMATCH")
+}
+
+func TestTheKeyExchangesEvictTheOldestAnonymousSessions(t *testing.T) {
+	t.Parallel()
+	// Given
+	storage := CreateSessionStorage(time.Hour)
+	storage.MaxSessions = 2
+	now := time.Date(2026, time.October, 14, 8, 0, 0, 0, time.UTC)
+
+	exchange := func() Session {
+		private, _ := ecdh.P256().GenerateKey(rand.Reader)
+		session, _, err := storage.Negotiate(base64.StdEncoding.EncodeToString(private.PublicKey().Bytes()), now)
+		assert.Nil(t, err)
+
+		return session
+	}
+
+	signedIn := exchange()
+	assert.True(t, storage.Bind(signedIn.ID, "Bearer token"))
+
+	oldest := exchange()
+
+	// When
+	newer := exchange()
+	newest := exchange()
+
+	// Then
+	_, signedInFound := storage.Find(signedIn.ID, now)
+	_, oldestFound := storage.Find(oldest.ID, now)
+	_, newerFound := storage.Find(newer.ID, now)
+	_, newestFound := storage.Find(newest.ID, now)
+
+	assert.True(t, signedInFound)
+	assert.False(t, oldestFound)
+	assert.False(t, newerFound)
+	assert.True(t, newestFound)
+	assert.Len(t, storage.Sessions, 2)
+}
@@ -0,0 +1,53 @@
+package bodyencryption
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SessionKey is the body of the key exchange response.
+type SessionKey struct {
+	SessionID   string    `json:"session_id"`
+	PublicKey   string    `json:"public_key"`
+	ExpiresDate time.Time `json:"expires_at"`
+}
+
+type Server struct {
+	Storage *SessionStorage
+}
+
+func NewServer(storage *SessionStorage) *Server {
+	return &Server{Storage: storage}
+}
+
+// Mount defines the key exchange endpoint. It's public, the clients negotiate the key before they log in.
+func (s *Server) Mount(g *echo.Group) {
+	g.POST("/session-key", s.NegotiateSessionKey)
+}
+
+// NegotiateSessionKey creates a session from the public_key form value of the client.
+func (s *Server) NegotiateSessionKey(c echo.Context) error {
+	session, publicKey, err := s.Storage.Negotiate(c.FormValue("public_key"), time.Now())
+	if errors.Is(err, ErrInvalidPublicKey) {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"field_name":    "public_key",
+			"error_code":    "INVALID_OPTION",
+			"error_message": err.Error(),
+		})
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]SessionKey{
+		"data": {
+			SessionID:   session.ID,
+			PublicKey:   publicKey,
+			ExpiresDate: session.ExpiresDate,
+		},
+	})
+}
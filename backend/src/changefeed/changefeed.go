@@ -0,0 +1,203 @@
+package changefeed
+
+import (
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+)
+
+// Entity types of the change log.
+const (
+	EntityFarm         = "FARM"
+	EntityReservoir    = "RESERVOIR"
+	EntityArea         = "AREA"
+	EntityMaterial     = "MATERIAL"
+	EntityCrop         = "CROP"
+	EntityTask         = "TASK"
+	EntityTaskTemplate = "TASK_TEMPLATE"
+)
+
+// Change types of the change log.
+const (
+	ChangeCreated  = "CREATED"
+	ChangeUpdated  = "UPDATED"
+	ChangeArchived = "ARCHIVED"
+	ChangeDeleted  = "DELETED"
+)
+
+// Change is one row of the change log.
+// A nil FarmUID means the entity isn't bound to a farm, so every farm receives it.
+type Change struct {
+	Sequence    int64
+	FarmUID     *uuid.UUID
+	EntityType  string
+	EntityUID   uuid.UUID
+	ChangeType  string
+	CreatedDate time.Time
+}
+
+type Store interface {
+	// Append saves the change and gives it the next sequence number.
+	Append(change Change) error
+
+	// FindFarmUID returns the farm recorded for the entity by a previous change, or nil.
+	FindFarmUID(entityUID uuid.UUID) (*uuid.UUID, error)
+
+	// FindSince returns up to limit changes of the farm, and the ones without farm,
+	// with a sequence greater than since, ordered by sequence.
+	FindSince(farmUID uuid.UUID, since int64, limit int) ([]Change, error)
+
+	// FindChangedSince returns the changes of the farm, and the ones without farm,
+	// made from the second of since on, ordered by sequence.
+	FindChangedSince(farmUID uuid.UUID, since time.Time) ([]Change, error)
+}
+
+// entityPrefixes maps the event names to their entity. Longer prefixes come first.
+var entityPrefixes = []struct { //nolint:gochecknoglobals
+	prefix     string
+	entityType string
+	uidField   string
+}{
+	{"TaskTemplate", EntityTaskTemplate, "TaskTemplateUID"},
+	{"Task", EntityTask, "TaskUID"},
+	{"CropBatch", EntityCrop, "CropUID"},
+	{"CropNursery", EntityCrop, "CropUID"},
+	{"CropGermination", EntityCrop, "CropUID"},
+	{"Farm", EntityFarm, "FarmUID"},
+	{"Reservoir", EntityReservoir, "ReservoirUID"},
+	{"Area", EntityArea, "AreaUID"},
+	{"Material", EntityMaterial, "MaterialUID"},
+}
+
+// createdEvents are the events creating an entity.
+// The others, like CropBatchNoteCreated, only update it.
+var createdEvents = map[string]bool{ //nolint:gochecknoglobals
+	"FarmCreated":         true,
+	"ReservoirCreated":    true,
+	"AreaCreated":         true,
+	"MaterialCreated":     true,
+	"CropBatchCreated":    true,
+	"TaskCreated":         true,
+	"TaskTemplateCreated": true,
+}
+
+// Projection fills the change log from the events of the bus.
+type Projection struct {
+	Store Store
+
+	// lock keeps the sequence numbers in commit order, so a client never reads
+	// a sequence while a lower one is still being written.
+	lock sync.Mutex
+}
+
+func NewProjection(store Store) *Projection {
+	return &Projection{Store: store}
+}
+
+// Handle is meant to be subscribed to all the events of the bus.
+func (p *Projection) Handle(eventName string, event interface{}) {
+	change, ok := describe(eventName, event)
+	if !ok {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if change.FarmUID == nil && change.EntityType != EntityMaterial &&
+		change.EntityType != EntityTask && change.EntityType != EntityTaskTemplate {
+		farmUID, err := p.Store.FindFarmUID(change.EntityUID)
+		if err != nil {
+			log.Printf("changefeed: cannot find the farm of %s %s: %v", change.EntityType, change.EntityUID, err)
+		}
+
+		// An unknown farm, like for entities created before the change log existed,
+		// leaves it nil so every farm receives the change rather than none.
+		change.FarmUID = farmUID
+	}
+
+	change.CreatedDate = time.Now()
+
+	if err := p.Store.Append(change); err != nil {
+		log.Printf("changefeed: cannot save the change of %s %s: %v", change.EntityType, change.EntityUID, err)
+	}
+}
+
+// describe finds the entity, its farm when the event carries it, and the change type of an event.
+func describe(eventName string, event interface{}) (Change, bool) {
+	v := reflect.Indirect(reflect.ValueOf(event))
+	if v.Kind() != reflect.Struct {
+		return Change{}, false
+	}
+
+	for _, e := range entityPrefixes {
+		if !strings.HasPrefix(eventName, e.prefix) {
+			continue
+		}
+
+		uid, ok := uuidField(v, e.uidField)
+		if !ok {
+			uid, ok = uuidField(v, "UID")
+		}
+
+		if !ok {
+			return Change{}, false
+		}
+
+		change := Change{
+			EntityType: e.entityType,
+			EntityUID:  uid,
+			ChangeType: changeType(eventName, v),
+		}
+
+		if e.entityType == EntityFarm {
+			change.FarmUID = &uid
+		} else if farmUID, ok := uuidField(v, "FarmUID"); ok {
+			change.FarmUID = &farmUID
+		}
+
+		return change, true
+	}
+
+	return Change{}, false
+}
+
+func changeType(eventName string, v reflect.Value) string {
+	if createdEvents[eventName] {
+		return ChangeCreated
+	}
+
+	switch eventName {
+	case "TaskCompleted", "TaskCancelled":
+		return ChangeArchived
+	case "TaskArchived":
+		// The archived tasks leave the read model of the tasks.
+		return ChangeDeleted
+	case "CropBatchHarvested", "CropBatchDumped":
+		if f := v.FieldByName("CropStatus"); f.IsValid() && f.Kind() == reflect.String &&
+			f.String() == growthdomain.CropArchived {
+			return ChangeArchived
+		}
+	}
+
+	return ChangeUpdated
+}
+
+func uuidField(v reflect.Value, name string) (uuid.UUID, bool) {
+	f := v.FieldByName(name)
+	if !f.IsValid() {
+		return uuid.UUID{}, false
+	}
+
+	uid, ok := f.Interface().(uuid.UUID)
+	if !ok || uid == (uuid.UUID{}) {
+		return uuid.UUID{}, false
+	}
+
+	return uid, true
+}
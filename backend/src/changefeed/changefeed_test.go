@@ -0,0 +1,102 @@
+package changefeed_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/changefeed"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+func TestFindPage(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	otherFarmUID, _ := uuid.NewV4()
+	areaUID, _ := uuid.NewV4()
+	otherAreaUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	noteUID, _ := uuid.NewV4()
+	taskUID, _ := uuid.NewV4()
+
+	store := changefeed.NewStoreInMemory(changefeed.CreateChangeLogStorage())
+	projection := changefeed.NewProjection(store)
+
+	projection.Handle("AreaCreated", assetsdomain.AreaCreated{UID: areaUID, FarmUID: farmUID})
+	projection.Handle("AreaCreated", assetsdomain.AreaCreated{UID: otherAreaUID, FarmUID: otherFarmUID})
+	projection.Handle("AreaNameChanged", assetsdomain.AreaNameChanged{AreaUID: areaUID})
+	projection.Handle("CropBatchCreated", growthdomain.CropBatchCreated{UID: cropUID, FarmUID: farmUID})
+	projection.Handle("TaskCreated", tasksdomain.TaskCreated{UID: taskUID})
+
+	// When
+	first, err := changefeed.FindPage(store, farmUID, "", 2)
+	retried, _ := changefeed.FindPage(store, farmUID, "", 2)
+
+	projection.Handle("CropBatchNoteCreated", growthdomain.CropBatchNoteCreated{UID: noteUID, CropUID: cropUID})
+	projection.Handle("CropBatchHarvested", growthdomain.CropBatchHarvested{UID: cropUID, CropStatus: growthdomain.CropArchived})
+
+	second, secondErr := changefeed.FindPage(store, farmUID, first.Cursor, 10)
+	_, invalidErr := changefeed.FindPage(store, farmUID, "abc", 10)
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, first.HasMore)
+	assert.Equal(t, first, retried)
+	assert.Equal(t, []uuid.UUID{areaUID}, first.Changes[changefeed.EntityArea].Created)
+	assert.Empty(t, first.Changes[changefeed.EntityArea].Updated)
+
+	assert.Nil(t, secondErr)
+	assert.False(t, second.HasMore)
+	assert.Equal(t, []uuid.UUID{cropUID}, second.Changes[changefeed.EntityCrop].Archived)
+	assert.Empty(t, second.Changes[changefeed.EntityCrop].Updated)
+	assert.Equal(t, []uuid.UUID{taskUID}, second.Changes[changefeed.EntityTask].Created)
+	assert.Equal(t, "7", second.Cursor)
+
+	assert.Equal(t, changefeed.ErrInvalidCursor, invalidErr)
+}
+
+func TestFindDelta(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	otherFarmUID, _ := uuid.NewV4()
+	areaUID, _ := uuid.NewV4()
+	otherAreaUID, _ := uuid.NewV4()
+	taskUID, _ := uuid.NewV4()
+
+	store := changefeed.NewStoreInMemory(changefeed.CreateChangeLogStorage())
+	projection := changefeed.NewProjection(store)
+
+	since := time.Now().Add(-time.Minute)
+
+	projection.Handle("AreaCreated", assetsdomain.AreaCreated{UID: areaUID, FarmUID: farmUID})
+	projection.Handle("AreaCreated", assetsdomain.AreaCreated{UID: otherAreaUID, FarmUID: otherFarmUID})
+	projection.Handle("TaskCreated", tasksdomain.TaskCreated{UID: taskUID})
+	projection.Handle("AreaNameChanged", assetsdomain.AreaNameChanged{AreaUID: areaUID})
+	projection.Handle("TaskArchived", tasksdomain.TaskArchived{UID: taskUID})
+
+	// When
+	delta, err := changefeed.FindDelta(store, farmUID, since)
+	later, _ := changefeed.FindDelta(store, farmUID, time.Now().Add(time.Minute))
+	_, tokenErr := changefeed.ParseSyncToken("yesterday")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Len(t, delta.Updated, 1)
+	assert.Equal(t, areaUID, delta.Updated[0].UID)
+	assert.Equal(t, changefeed.EntityArea, delta.Updated[0].EntityType)
+	assert.Len(t, delta.Deleted, 1)
+	assert.Equal(t, taskUID, delta.Deleted[0].UID)
+
+	token, err := changefeed.ParseSyncToken(delta.SyncToken)
+	assert.Nil(t, err)
+	assert.False(t, token.Before(since.Truncate(time.Second)))
+
+	assert.Empty(t, later.Updated)
+	assert.Empty(t, later.Deleted)
+	assert.Equal(t, changefeed.ErrInvalidSyncToken, tokenErr)
+}
@@ -0,0 +1,82 @@
+package changefeed
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+var ErrInvalidSyncToken = errors.New("invalid sync token")
+
+// DeltaEntity is an entity changed since the sync token, with the date of its last change.
+type DeltaEntity struct {
+	EntityType  string
+	UID         uuid.UUID
+	ChangedDate time.Time
+}
+
+// Delta is what changed since a sync token, by entity in the order of their first change.
+// SyncToken is the one to send next time.
+type Delta struct {
+	Updated   []DeltaEntity
+	Deleted   []DeltaEntity
+	SyncToken string
+}
+
+// ParseSyncToken reads a sync token, which is the RFC3339 date of the last change sent.
+func ParseSyncToken(token string) (time.Time, error) {
+	since, err := time.Parse(time.RFC3339, token)
+	if err != nil {
+		return time.Time{}, ErrInvalidSyncToken
+	}
+
+	return since, nil
+}
+
+// FindDelta reads the entities of the farm changed from the second of since on.
+// The changes of the second of the token are sent again next time, so the clients upsert the records
+// rather than missing the ones changed right after the token.
+func FindDelta(store Store, farmUID uuid.UUID, since time.Time) (Delta, error) {
+	changes, err := store.FindChangedSince(farmUID, since)
+	if err != nil {
+		return Delta{}, err
+	}
+
+	type entityKey struct {
+		entityType string
+		uid        uuid.UUID
+	}
+
+	order := []entityKey{}
+	last := map[entityKey]Change{}
+	token := since
+
+	for _, c := range changes {
+		key := entityKey{c.EntityType, c.EntityUID}
+		if _, ok := last[key]; !ok {
+			order = append(order, key)
+		}
+
+		last[key] = c
+
+		if c.CreatedDate.After(token) {
+			token = c.CreatedDate
+		}
+	}
+
+	delta := Delta{Updated: []DeltaEntity{}, Deleted: []DeltaEntity{}, SyncToken: token.Format(time.RFC3339)}
+
+	for _, key := range order {
+		c := last[key]
+		entity := DeltaEntity{EntityType: c.EntityType, UID: c.EntityUID, ChangedDate: c.CreatedDate}
+
+		if c.ChangeType == ChangeDeleted {
+			delta.Deleted = append(delta.Deleted, entity)
+		} else {
+			delta.Updated = append(delta.Updated, entity)
+		}
+	}
+
+	return delta, nil
+}
@@ -0,0 +1,91 @@
+package changefeed
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/sasha-s/go-deadlock"
+)
+
+type ChangeLogStorage struct {
+	Lock    *deadlock.RWMutex
+	Changes []Change
+}
+
+func CreateChangeLogStorage() *ChangeLogStorage {
+	return &ChangeLogStorage{Lock: &deadlock.RWMutex{}}
+}
+
+type StoreInMemory struct {
+	Storage *ChangeLogStorage
+}
+
+func NewStoreInMemory(s *ChangeLogStorage) Store {
+	return &StoreInMemory{Storage: s}
+}
+
+func (s *StoreInMemory) Append(change Change) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	change.Sequence = int64(len(s.Storage.Changes)) + 1
+
+	s.Storage.Changes = append(s.Storage.Changes, change)
+
+	return nil
+}
+
+func (s *StoreInMemory) FindFarmUID(entityUID uuid.UUID) (*uuid.UUID, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	for _, c := range s.Storage.Changes {
+		if c.EntityUID == entityUID && c.FarmUID != nil {
+			farmUID := *c.FarmUID
+
+			return &farmUID, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *StoreInMemory) FindSince(farmUID uuid.UUID, since int64, limit int) ([]Change, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	changes := []Change{}
+
+	// The sequence is the position in the slice plus one.
+	for i := int(since); i < len(s.Storage.Changes) && len(changes) < limit; i++ {
+		c := s.Storage.Changes[i]
+
+		if c.FarmUID == nil || *c.FarmUID == farmUID {
+			changes = append(changes, c)
+		}
+	}
+
+	return changes, nil
+}
+
+func (s *StoreInMemory) FindChangedSince(farmUID uuid.UUID, since time.Time) ([]Change, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	changes := []Change{}
+
+	// The dates are kept to the second, like in the database engines.
+	since = since.Truncate(time.Second)
+
+	for _, c := range s.Storage.Changes {
+		if c.CreatedDate.Truncate(time.Second).Before(since) {
+			continue
+		}
+
+		if c.FarmUID == nil || *c.FarmUID == farmUID {
+			changes = append(changes, c)
+		}
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,116 @@
+package changefeed
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type StoreMysql struct {
+	DB *sql.DB
+}
+
+func NewStoreMysql(db *sql.DB) Store {
+	return &StoreMysql{DB: db}
+}
+
+func (s *StoreMysql) Append(change Change) error {
+	var farmUID interface{}
+	if change.FarmUID != nil {
+		farmUID = change.FarmUID.Bytes()
+	}
+
+	_, err := s.DB.Exec(`INSERT INTO CHANGE_LOG (FARM_UID, ENTITY_TYPE, ENTITY_UID, CHANGE_TYPE, CREATED_DATE)
+		VALUES (?, ?, ?, ?, ?)`,
+		farmUID,
+		change.EntityType,
+		change.EntityUID.Bytes(),
+		change.ChangeType,
+		change.CreatedDate)
+
+	return err
+}
+
+func (s *StoreMysql) FindFarmUID(entityUID uuid.UUID) (*uuid.UUID, error) {
+	var farmUID []byte
+
+	err := s.DB.QueryRow(`SELECT FARM_UID FROM CHANGE_LOG
+		WHERE ENTITY_UID = ? AND FARM_UID IS NOT NULL LIMIT 1`, entityUID.Bytes()).Scan(&farmUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	uid, err := uuid.FromBytes(farmUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &uid, nil
+}
+
+func (s *StoreMysql) FindSince(farmUID uuid.UUID, since int64, limit int) ([]Change, error) {
+	rows, err := s.DB.Query(`SELECT SEQUENCE, FARM_UID, ENTITY_TYPE, ENTITY_UID, CHANGE_TYPE, CREATED_DATE
+		FROM CHANGE_LOG
+		WHERE (FARM_UID = ? OR FARM_UID IS NULL) AND SEQUENCE > ?
+		ORDER BY SEQUENCE ASC LIMIT ?`, farmUID.Bytes(), since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanMysqlChanges(rows)
+}
+
+func (s *StoreMysql) FindChangedSince(farmUID uuid.UUID, since time.Time) ([]Change, error) {
+	rows, err := s.DB.Query(`SELECT SEQUENCE, FARM_UID, ENTITY_TYPE, ENTITY_UID, CHANGE_TYPE, CREATED_DATE
+		FROM CHANGE_LOG
+		WHERE (FARM_UID = ? OR FARM_UID IS NULL) AND CREATED_DATE >= ?
+		ORDER BY SEQUENCE ASC`, farmUID.Bytes(), since)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanMysqlChanges(rows)
+}
+
+func scanMysqlChanges(rows *sql.Rows) ([]Change, error) {
+	defer rows.Close()
+
+	var err error
+
+	changes := []Change{}
+
+	for rows.Next() {
+		var rowFarmUID, entityUID []byte
+
+		c := Change{}
+
+		err = rows.Scan(&c.Sequence, &rowFarmUID, &c.EntityType, &entityUID, &c.ChangeType, &c.CreatedDate)
+		if err != nil {
+			return nil, err
+		}
+
+		if rowFarmUID != nil {
+			uid, err := uuid.FromBytes(rowFarmUID)
+			if err != nil {
+				return nil, err
+			}
+
+			c.FarmUID = &uid
+		}
+
+		c.EntityUID, err = uuid.FromBytes(entityUID)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
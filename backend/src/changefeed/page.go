@@ -0,0 +1,132 @@
+package changefeed
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	DefaultPageSize = 500
+	MaxPageSize     = 1000
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EntityChanges lists the entities of one type by what happened to them.
+// An entity is listed once, as deleted if it was deleted in the page, else as archived if it was archived,
+// else as created if it was created in the page, else as updated.
+type EntityChanges struct {
+	Created  []uuid.UUID `json:"created"`
+	Updated  []uuid.UUID `json:"updated"`
+	Archived []uuid.UUID `json:"archived"`
+	Deleted  []uuid.UUID `json:"deleted"`
+}
+
+// Page is what changed since a cursor. Cursor is the one to send next time.
+// When HasMore is true, the client should ask again right away with the new cursor.
+type Page struct {
+	Changes map[string]*EntityChanges `json:"changes"`
+	Cursor  string                    `json:"cursor"`
+	HasMore bool                      `json:"has_more"`
+}
+
+func changeRank(changeType string) int {
+	switch changeType {
+	case ChangeDeleted:
+		return 4
+	case ChangeArchived:
+		return 3
+	case ChangeCreated:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// FindPage reads the changes of the farm after the cursor. An empty cursor starts from the beginning.
+// The same cursor always gives the same changes, so a client can safely retry.
+func FindPage(store Store, farmUID uuid.UUID, cursor string, limit int) (Page, error) {
+	var since int64
+
+	if cursor != "" {
+		var err error
+
+		since, err = strconv.ParseInt(cursor, 10, 64)
+		if err != nil || since < 0 {
+			return Page{}, ErrInvalidCursor
+		}
+	}
+
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+
+	changes, err := store.FindSince(farmUID, since, limit+1)
+	if err != nil {
+		return Page{}, err
+	}
+
+	page := Page{Changes: map[string]*EntityChanges{}}
+
+	if len(changes) > limit {
+		page.HasMore = true
+		changes = changes[:limit]
+	}
+
+	type entity struct {
+		entityType string
+		changeType string
+	}
+
+	order := []uuid.UUID{}
+	entities := map[uuid.UUID]*entity{}
+
+	for _, c := range changes {
+		since = c.Sequence
+
+		e, ok := entities[c.EntityUID]
+		if !ok {
+			entities[c.EntityUID] = &entity{entityType: c.EntityType, changeType: c.ChangeType}
+			order = append(order, c.EntityUID)
+
+			continue
+		}
+
+		if changeRank(c.ChangeType) > changeRank(e.changeType) {
+			e.changeType = c.ChangeType
+		}
+	}
+
+	for _, uid := range order {
+		e := entities[uid]
+
+		ec, ok := page.Changes[e.entityType]
+		if !ok {
+			ec = &EntityChanges{
+				Created: []uuid.UUID{}, Updated: []uuid.UUID{}, Archived: []uuid.UUID{}, Deleted: []uuid.UUID{},
+			}
+			page.Changes[e.entityType] = ec
+		}
+
+		switch e.changeType {
+		case ChangeDeleted:
+			ec.Deleted = append(ec.Deleted, uid)
+		case ChangeArchived:
+			ec.Archived = append(ec.Archived, uid)
+		case ChangeCreated:
+			ec.Created = append(ec.Created, uid)
+		default:
+			ec.Updated = append(ec.Updated, uid)
+		}
+	}
+
+	page.Cursor = strconv.FormatInt(since, 10)
+
+	return page, nil
+}
@@ -0,0 +1,64 @@
+package changefeed
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Server exposes the change log to the clients syncing incrementally.
+type Server struct {
+	Store Store
+}
+
+func NewServer(store Store) *Server {
+	return &Server{Store: store}
+}
+
+// Mount defines the change feed endpoints with their handlers.
+func (s *Server) Mount(g *echo.Group) {
+	g.GET("", s.GetChanges)
+}
+
+// GetChanges answers GET /api/sync?farm_id=&since=&limit=.
+func (s *Server) GetChanges(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.QueryParam("farm_id"))
+	if err != nil {
+		return badRequest(c, "farm_id")
+	}
+
+	limit := 0
+
+	if l := c.QueryParam("limit"); l != "" {
+		limit, err = strconv.Atoi(l)
+		if err != nil {
+			return badRequest(c, "limit")
+		}
+	}
+
+	page, err := FindPage(s.Store, farmUID, c.QueryParam("since"), limit)
+	if errors.Is(err, ErrInvalidCursor) {
+		return badRequest(c, "since")
+	}
+
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"field_name":    "",
+			"error_code":    "",
+			"error_message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]Page{"data": page})
+}
+
+func badRequest(c echo.Context, field string) error {
+	return c.JSON(http.StatusBadRequest, map[string]string{
+		"field_name":    field,
+		"error_code":    "PARSE_FAILED",
+		"error_message": "Parsing failed. Make sure the input is correct.",
+	})
+}
@@ -0,0 +1,125 @@
+package changefeed
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type StoreSqlite struct {
+	DB *sql.DB
+}
+
+func NewStoreSqlite(db *sql.DB) Store {
+	return &StoreSqlite{DB: db}
+}
+
+func (s *StoreSqlite) Append(change Change) error {
+	var farmUID interface{}
+	if change.FarmUID != nil {
+		farmUID = change.FarmUID.String()
+	}
+
+	_, err := s.DB.Exec(`INSERT INTO CHANGE_LOG (FARM_UID, ENTITY_TYPE, ENTITY_UID, CHANGE_TYPE, CREATED_DATE)
+		VALUES (?, ?, ?, ?, ?)`,
+		farmUID,
+		change.EntityType,
+		change.EntityUID.String(),
+		change.ChangeType,
+		change.CreatedDate.Format(time.RFC3339))
+
+	return err
+}
+
+func (s *StoreSqlite) FindFarmUID(entityUID uuid.UUID) (*uuid.UUID, error) {
+	var farmUID string
+
+	err := s.DB.QueryRow(`SELECT FARM_UID FROM CHANGE_LOG
+		WHERE ENTITY_UID = ? AND FARM_UID IS NOT NULL LIMIT 1`, entityUID.String()).Scan(&farmUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	uid, err := uuid.FromString(farmUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &uid, nil
+}
+
+func (s *StoreSqlite) FindSince(farmUID uuid.UUID, since int64, limit int) ([]Change, error) {
+	rows, err := s.DB.Query(`SELECT SEQUENCE, FARM_UID, ENTITY_TYPE, ENTITY_UID, CHANGE_TYPE, CREATED_DATE
+		FROM CHANGE_LOG
+		WHERE (FARM_UID = ? OR FARM_UID IS NULL) AND SEQUENCE > ?
+		ORDER BY SEQUENCE ASC LIMIT ?`, farmUID.String(), since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanSqliteChanges(rows)
+}
+
+func (s *StoreSqlite) FindChangedSince(farmUID uuid.UUID, since time.Time) ([]Change, error) {
+	rows, err := s.DB.Query(`SELECT SEQUENCE, FARM_UID, ENTITY_TYPE, ENTITY_UID, CHANGE_TYPE, CREATED_DATE
+		FROM CHANGE_LOG
+		WHERE (FARM_UID = ? OR FARM_UID IS NULL) AND CREATED_DATE >= ?
+		ORDER BY SEQUENCE ASC`, farmUID.String(), since.In(time.Local).Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+
+	return scanSqliteChanges(rows)
+}
+
+func scanSqliteChanges(rows *sql.Rows) ([]Change, error) {
+	defer rows.Close()
+
+	var err error
+
+	changes := []Change{}
+
+	for rows.Next() {
+		var (
+			rowFarmUID  sql.NullString
+			entityUID   string
+			createdDate string
+		)
+
+		c := Change{}
+
+		err = rows.Scan(&c.Sequence, &rowFarmUID, &c.EntityType, &entityUID, &c.ChangeType, &createdDate)
+		if err != nil {
+			return nil, err
+		}
+
+		if rowFarmUID.Valid {
+			uid, err := uuid.FromString(rowFarmUID.String)
+			if err != nil {
+				return nil, err
+			}
+
+			c.FarmUID = &uid
+		}
+
+		c.EntityUID, err = uuid.FromString(entityUID)
+		if err != nil {
+			return nil, err
+		}
+
+		c.CreatedDate, err = time.Parse(time.RFC3339, createdDate)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
@@ -0,0 +1,130 @@
+// Package clientip resolves the IP of the client of a request. The X-Forwarded-For header is only read from
+// the trusted proxies, as any client can send one, so the admin IP whitelist and the location of the requests
+// can't be spoofed.
+package clientip
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Resolver resolves the IP of the client from the remote address of the request, or from its X-Forwarded-For
+// header when the remote address is one of the trusted proxies.
+type Resolver struct {
+	proxies []*net.IPNet
+}
+
+// ParseCIDRs parses the comma separated CIDRs, the empty ones are skipped.
+func ParseCIDRs(cidrs string) ([]*net.IPNet, error) {
+	networks := []*net.IPNet{}
+
+	for _, cidr := range strings.Split(cidrs, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+// NewResolver creates the resolver trusting the proxies of the comma separated CIDRs, none when it's empty.
+func NewResolver(trustedProxies string) (*Resolver, error) {
+	proxies, err := ParseCIDRs(trustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Resolver{proxies: proxies}, nil
+}
+
+// IP is the IP of the client of the request. The X-Forwarded-For entries are read from the last one, added by
+// the nearest proxy, and the first one which isn't a trusted proxy is the client. The entries before it are
+// sent by the client and can't be trusted.
+func (r *Resolver) IP(req *http.Request) string {
+	ip := remoteAddrIP(req.RemoteAddr)
+	if !r.trusted(ip) {
+		return ip
+	}
+
+	xff := req.Header.Get(echo.HeaderXForwardedFor)
+	if xff == "" {
+		return ip
+	}
+
+	entries := strings.Split(xff, ",")
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := strings.TrimSpace(entries[i])
+		if net.ParseIP(entry) == nil {
+			return ip
+		}
+
+		ip = entry
+		if !r.trusted(ip) {
+			return ip
+		}
+	}
+
+	return ip
+}
+
+func (r *Resolver) trusted(ip string) bool {
+	return contains(r.proxies, net.ParseIP(ip))
+}
+
+func remoteAddrIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+
+	return host
+}
+
+func contains(networks []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Whitelist only lets through the requests of the clients in one of the comma separated CIDRs. Without
+// enabled every client is let through, and an enabled whitelist without CIDRs lets none through.
+func Whitelist(cidrs string, enabled bool, resolver *Resolver) (echo.MiddlewareFunc, error) {
+	networks, err := ParseCIDRs(cidrs)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !enabled {
+				return next(c)
+			}
+
+			if contains(networks, net.ParseIP(resolver.IP(c.Request()))) {
+				return next(c)
+			}
+
+			return c.JSON(http.StatusForbidden, map[string]string{"data": "Forbidden"})
+		}
+	}, nil
+}
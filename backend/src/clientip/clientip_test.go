@@ -0,0 +1,90 @@
+package clientip_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/clientip"
+)
+
+func request(remoteAddr, xff string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/maintenance", nil)
+	req.RemoteAddr = remoteAddr
+
+	if xff != "" {
+		req.Header.Set(echo.HeaderXForwardedFor, xff)
+	}
+
+	return req
+}
+
+func whitelisted(t *testing.T, whitelist echo.MiddlewareFunc, req *http.Request) int {
+	t.Helper()
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+
+	err := whitelist(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})(e.NewContext(req, rec))
+	assert.Nil(t, err)
+
+	return rec.Code
+}
+
+func TestResolverIP(t *testing.T) {
+	t.Parallel()
+	// Given
+	resolver, err := NewResolver("172.16.0.0/12")
+	assert.Nil(t, err)
+
+	// When
+	spoofed := resolver.IP(request("203.0.113.7:51000", "10.0.0.1"))
+	proxied := resolver.IP(request("172.16.0.2:51000", "10.0.0.1, 198.51.100.4, 172.16.0.3"))
+	direct := resolver.IP(request("172.16.0.2:51000", ""))
+	invalid := resolver.IP(request("172.16.0.2:51000", "unknown"))
+
+	// Then
+	assert.Equal(t, "203.0.113.7", spoofed)
+	assert.Equal(t, "198.51.100.4", proxied)
+	assert.Equal(t, "172.16.0.2", direct)
+	assert.Equal(t, "172.16.0.2", invalid)
+}
+
+func TestWhitelist(t *testing.T) {
+	t.Parallel()
+	// Given
+	resolver, err := NewResolver("172.16.0.0/12")
+	assert.Nil(t, err)
+
+	whitelist, err := Whitelist("10.0.0.0/8", true, resolver)
+	assert.Nil(t, err)
+
+	empty, err := Whitelist("", true, resolver)
+	assert.Nil(t, err)
+
+	disabled, err := Whitelist("", false, resolver)
+	assert.Nil(t, err)
+
+	_, invalidErr := Whitelist("10.0.0.0/33", true, resolver)
+
+	// When
+	spoofed := whitelisted(t, whitelist, request("203.0.113.7:51000", "10.0.0.1"))
+	proxied := whitelisted(t, whitelist, request("172.16.0.2:51000", "10.0.0.1"))
+	outside := whitelisted(t, whitelist, request("172.16.0.2:51000", "198.51.100.4"))
+	direct := whitelisted(t, whitelist, request("10.1.2.3:51000", ""))
+	closed := whitelisted(t, empty, request("10.1.2.3:51000", ""))
+	open := whitelisted(t, disabled, request("203.0.113.7:51000", ""))
+
+	// Then
+	assert.Equal(t, http.StatusForbidden, spoofed)
+	assert.Equal(t, http.StatusOK, proxied)
+	assert.Equal(t, http.StatusForbidden, outside)
+	assert.Equal(t, http.StatusOK, direct)
+	assert.Equal(t, http.StatusForbidden, closed)
+	assert.Equal(t, http.StatusOK, open)
+	assert.NotNil(t, invalidErr)
+}
@@ -0,0 +1,109 @@
+// Package customfield keeps the values of the custom fields defined by the farms for their crops,
+// materials and tasks. The areas keep theirs on the area aggregate.
+package customfield
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+)
+
+const ValuesChangedCode = "CustomFieldValuesChanged"
+
+// FilterPrefix is the prefix of the list query params filtering on a custom field, e.g. `?cf_lot_number=A1`.
+const FilterPrefix = "cf_"
+
+// CustomFieldValuesChanged replaces the values of the custom fields of an entity.
+type CustomFieldValuesChanged struct {
+	EntityUID   uuid.UUID              `json:"entity_uid"`
+	FarmUID     uuid.UUID              `json:"farm_uid"`
+	EntityType  string                 `json:"entity_type"`
+	Values      map[string]interface{} `json:"values"`
+	ChangedDate time.Time              `json:"changed_date"`
+}
+
+// Values are the current values of the custom fields of an entity.
+type Values struct {
+	EntityUID   uuid.UUID
+	FarmUID     uuid.UUID
+	EntityType  string
+	Values      map[string]interface{}
+	UpdatedDate time.Time
+}
+
+type Store interface {
+	// FindFields returns the fields the farm defined for the entity type, without the retired ones.
+	FindFields(farmUID uuid.UUID, entityType string) ([]assetsdomain.CustomFieldSchema, error)
+
+	// AppendEvent saves the event as the next version of the entity.
+	AppendEvent(event CustomFieldValuesChanged) error
+
+	SaveValues(values Values) error
+
+	// FindValues returns the values of the entity, or nil when it has none.
+	FindValues(entityUID uuid.UUID) (*Values, error)
+	FindAllValues(farmUID uuid.UUID, entityType string) ([]Values, error)
+}
+
+// Projection saves the current values from the events of the bus.
+type Projection struct {
+	Store Store
+}
+
+func NewProjection(store Store) *Projection {
+	return &Projection{Store: store}
+}
+
+// Handle is meant to be subscribed to ValuesChangedCode.
+func (p *Projection) Handle(event interface{}) error {
+	e, ok := event.(CustomFieldValuesChanged)
+	if !ok {
+		return errors.New("unknown custom field values event")
+	}
+
+	err := p.Store.SaveValues(Values{
+		EntityUID:   e.EntityUID,
+		FarmUID:     e.FarmUID,
+		EntityType:  e.EntityType,
+		Values:      e.Values,
+		UpdatedDate: e.ChangedDate,
+	})
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	return nil
+}
+
+// ParseValues parses the `custom_fields` form value, a JSON object of the values by field key.
+func ParseValues(value string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+
+	err := json.Unmarshal([]byte(value), &values)
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// ParseFilters returns the custom field filters of the query params, by field key.
+func ParseFilters(params url.Values) map[string]string {
+	filters := make(map[string]string)
+
+	for key, v := range params {
+		if strings.HasPrefix(key, FilterPrefix) && len(v) > 0 && v[0] != "" {
+			filters[strings.TrimPrefix(key, FilterPrefix)] = v[0]
+		}
+	}
+
+	return filters
+}
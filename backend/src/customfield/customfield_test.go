@@ -0,0 +1,142 @@
+package customfield_test
+
+import (
+	"testing"
+	"time"
+
+	EventBus "github.com/asaskevich/EventBus"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/customfield"
+	"github.com/usetania/tania-core/src/eventbus"
+)
+
+func newTestService(farmUID uuid.UUID) (*customfield.Service, *assetsstorage.CustomFieldDefinitionReadStorage) {
+	definitions := assetsstorage.CreateCustomFieldDefinitionReadStorage()
+
+	for i, v := range []assetsstorage.CustomFieldDefinitionRead{
+		{FieldKey: "lot_number", FieldType: assetsdomain.CustomFieldTypeText},
+		{FieldKey: "grade", FieldType: assetsdomain.CustomFieldTypeSelect, Options: []string{"A", "B"}},
+		{FieldKey: "weight", FieldType: assetsdomain.CustomFieldTypeNumber},
+	} {
+		v.UID, _ = uuid.NewV4()
+		v.FarmUID = farmUID
+		v.EntityType = assetsdomain.CustomFieldEntityCrop
+		v.CreatedDate = time.Now().Add(time.Duration(i) * time.Second)
+
+		definitions.CustomFieldDefinitionReadMap[v.UID] = v
+	}
+
+	store := customfield.NewStoreInMemory(customfield.CreateValueStorage(), definitions)
+	bus := eventbus.NewSimpleEventBus(EventBus.New())
+	bus.Subscribe(customfield.ValuesChangedCode, customfield.NewProjection(store).Handle)
+
+	return customfield.NewService(store, bus), definitions
+}
+
+func TestChangeCustomFieldValues(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	service, definitions := newTestService(farmUID)
+
+	// When
+	values, err := service.Change(farmUID, assetsdomain.CustomFieldEntityCrop, cropUID, map[string]interface{}{
+		"lot_number": "L-12",
+		"grade":      "A",
+	})
+	found, findErr := service.Find(cropUID)
+
+	_, invalidErr := service.Change(farmUID, assetsdomain.CustomFieldEntityCrop, cropUID, map[string]interface{}{
+		"weight": "heavy",
+	})
+	_, optionErr := service.Change(farmUID, assetsdomain.CustomFieldEntityCrop, cropUID, map[string]interface{}{
+		"grade": "C",
+	})
+
+	for uid, v := range definitions.CustomFieldDefinitionReadMap {
+		if v.FieldKey == "lot_number" {
+			v.IsDeleted = true
+			definitions.CustomFieldDefinitionReadMap[uid] = v
+		}
+	}
+
+	retired, retiredErr := service.Change(farmUID, assetsdomain.CustomFieldEntityCrop, cropUID, map[string]interface{}{
+		"grade": "B",
+	})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"lot_number": "L-12", "grade": "A"}, values)
+	assert.Nil(t, findErr)
+	assert.Equal(t, values, found)
+
+	assert.Equal(t, assetsdomain.CustomFieldError{
+		Code: assetsdomain.CustomFieldErrorInvalidValueCode, FieldKey: "weight",
+	}, invalidErr)
+	assert.NotNil(t, optionErr)
+
+	assert.Nil(t, retiredErr)
+	assert.Equal(t, map[string]interface{}{"lot_number": "L-12", "grade": "B"}, retired)
+}
+
+func TestChangeCustomFieldValuesWithoutSaving(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	service, _ := newTestService(farmUID)
+
+	// When
+	values, err := service.WithoutSaving().Change(farmUID, assetsdomain.CustomFieldEntityCrop, cropUID,
+		map[string]interface{}{"grade": "A"})
+	found, _ := service.Find(cropUID)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"grade": "A"}, values)
+	assert.Empty(t, found)
+}
+
+func TestFilterCustomFieldValues(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	otherCropUID, _ := uuid.NewV4()
+	service, _ := newTestService(farmUID)
+
+	service.Change(farmUID, assetsdomain.CustomFieldEntityCrop, cropUID,
+		map[string]interface{}{"lot_number": "L-12", "grade": "A"})
+	service.Change(farmUID, assetsdomain.CustomFieldEntityCrop, otherCropUID,
+		map[string]interface{}{"lot_number": "L-13", "grade": "B"})
+
+	// When
+	text, textErr := service.Filter(farmUID, assetsdomain.CustomFieldEntityCrop, map[string]string{"lot_number": "l-1"})
+	grade, gradeErr := service.Filter(farmUID, assetsdomain.CustomFieldEntityCrop, map[string]string{"grade": "a"})
+	_, numberErr := service.Filter(farmUID, assetsdomain.CustomFieldEntityCrop, map[string]string{"weight": "1"})
+
+	// Then
+	assert.Nil(t, textErr)
+	assert.Equal(t, map[uuid.UUID]bool{cropUID: true, otherCropUID: true}, text)
+	assert.Nil(t, gradeErr)
+	assert.Equal(t, map[uuid.UUID]bool{cropUID: true}, grade)
+	assert.Equal(t, assetsdomain.CustomFieldError{
+		Code: assetsdomain.CustomFieldErrorInvalidFilterCode, FieldKey: "weight",
+	}, numberErr)
+}
+
+func TestParseFilters(t *testing.T) {
+	t.Parallel()
+	// Given
+	params := map[string][]string{"cf_grade": {"A"}, "cf_lot_number": {""}, "status": {"ACTIVE"}}
+
+	// When
+	filters := customfield.ParseFilters(params)
+
+	// Then
+	assert.Equal(t, map[string]string{"grade": "A"}, filters)
+}
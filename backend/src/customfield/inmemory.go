@@ -0,0 +1,124 @@
+package customfield
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/sasha-s/go-deadlock"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+)
+
+type Event struct {
+	EntityUID   uuid.UUID
+	Version     int
+	CreatedDate time.Time
+	Event       CustomFieldValuesChanged
+}
+
+type ValueStorage struct {
+	Lock   *deadlock.RWMutex
+	Events []Event
+	Values map[uuid.UUID]Values
+}
+
+func CreateValueStorage() *ValueStorage {
+	return &ValueStorage{Lock: &deadlock.RWMutex{}, Values: make(map[uuid.UUID]Values)}
+}
+
+type StoreInMemory struct {
+	Storage     *ValueStorage
+	Definitions *assetsstorage.CustomFieldDefinitionReadStorage
+}
+
+func NewStoreInMemory(s *ValueStorage, definitions *assetsstorage.CustomFieldDefinitionReadStorage) Store {
+	return &StoreInMemory{Storage: s, Definitions: definitions}
+}
+
+func (s *StoreInMemory) FindFields(farmUID uuid.UUID, entityType string) ([]assetsdomain.CustomFieldSchema, error) {
+	s.Definitions.Lock.RLock()
+	defer s.Definitions.Lock.RUnlock()
+
+	definitions := []assetsstorage.CustomFieldDefinitionRead{}
+
+	for _, v := range s.Definitions.CustomFieldDefinitionReadMap {
+		if v.FarmUID == farmUID && v.EntityType == entityType && !v.IsDeleted {
+			definitions = append(definitions, v)
+		}
+	}
+
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].CreatedDate.Before(definitions[j].CreatedDate)
+	})
+
+	fields := []assetsdomain.CustomFieldSchema{}
+	for _, v := range definitions {
+		fields = append(fields, assetsdomain.CustomFieldSchema{
+			FieldKey:  v.FieldKey,
+			FieldType: v.FieldType,
+			Options:   v.Options,
+			Required:  v.Required,
+		})
+	}
+
+	return fields, nil
+}
+
+func (s *StoreInMemory) AppendEvent(event CustomFieldValuesChanged) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	version := 0
+
+	for _, v := range s.Storage.Events {
+		if v.EntityUID == event.EntityUID && v.Version > version {
+			version = v.Version
+		}
+	}
+
+	s.Storage.Events = append(s.Storage.Events, Event{
+		EntityUID:   event.EntityUID,
+		Version:     version + 1,
+		CreatedDate: time.Now(),
+		Event:       event,
+	})
+
+	return nil
+}
+
+func (s *StoreInMemory) SaveValues(values Values) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	s.Storage.Values[values.EntityUID] = values
+
+	return nil
+}
+
+func (s *StoreInMemory) FindValues(entityUID uuid.UUID) (*Values, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	values, ok := s.Storage.Values[entityUID]
+	if !ok {
+		return nil, nil
+	}
+
+	return &values, nil
+}
+
+func (s *StoreInMemory) FindAllValues(farmUID uuid.UUID, entityType string) ([]Values, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	all := []Values{}
+
+	for _, v := range s.Storage.Values {
+		if v.FarmUID == farmUID && v.EntityType == entityType {
+			all = append(all, v)
+		}
+	}
+
+	return all, nil
+}
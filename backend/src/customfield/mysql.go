@@ -0,0 +1,160 @@
+package customfield
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	assetsdecoder "github.com/usetania/tania-core/src/assets/decoder"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+)
+
+type StoreMysql struct {
+	DB *sql.DB
+}
+
+func NewStoreMysql(db *sql.DB) Store {
+	return &StoreMysql{DB: db}
+}
+
+func (s *StoreMysql) FindFields(farmUID uuid.UUID, entityType string) ([]assetsdomain.CustomFieldSchema, error) {
+	rows, err := s.DB.Query(`SELECT FIELD_KEY, FIELD_TYPE, OPTIONS, IS_REQUIRED
+		FROM CUSTOM_FIELD_DEFINITION_READ WHERE FARM_UID = ? AND ENTITY_TYPE = ? AND IS_DELETED = ?
+		ORDER BY CREATED_DATE ASC`, farmUID.Bytes(), entityType, false)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fields := []assetsdomain.CustomFieldSchema{}
+
+	for rows.Next() {
+		field := assetsdomain.CustomFieldSchema{}
+		options := ""
+
+		err = rows.Scan(&field.FieldKey, &field.FieldType, &options, &field.Required)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(options), &field.Options)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, rows.Err()
+}
+
+func (s *StoreMysql) AppendEvent(event CustomFieldValuesChanged) error {
+	e, err := json.Marshal(assetsdecoder.EventWrapper{
+		EventName: ValuesChangedCode,
+		EventData: event,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.DB.Exec(`INSERT INTO CUSTOM_FIELD_VALUE_EVENT (ENTITY_UID, VERSION, CREATED_DATE, EVENT)
+		SELECT ?, COALESCE(MAX(VERSION), 0) + 1, ?, ? FROM CUSTOM_FIELD_VALUE_EVENT WHERE ENTITY_UID = ?`,
+		event.EntityUID.Bytes(), time.Now(), e, event.EntityUID.Bytes())
+
+	return err
+}
+
+func (s *StoreMysql) SaveValues(values Values) error {
+	fieldValues, err := json.Marshal(values.Values)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+
+	err = s.DB.QueryRow(`SELECT COUNT(*) FROM CUSTOM_FIELD_VALUE_READ WHERE ENTITY_UID = ?`,
+		values.EntityUID.Bytes()).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		_, err = s.DB.Exec(`UPDATE CUSTOM_FIELD_VALUE_READ SET
+			FARM_UID = ?, ENTITY_TYPE = ?, FIELD_VALUES = ?, UPDATED_DATE = ?
+			WHERE ENTITY_UID = ?`,
+			values.FarmUID.Bytes(), values.EntityType, string(fieldValues), values.UpdatedDate,
+			values.EntityUID.Bytes())
+
+		return err
+	}
+
+	_, err = s.DB.Exec(`INSERT INTO CUSTOM_FIELD_VALUE_READ
+		(ENTITY_UID, FARM_UID, ENTITY_TYPE, FIELD_VALUES, UPDATED_DATE)
+		VALUES (?, ?, ?, ?, ?)`,
+		values.EntityUID.Bytes(), values.FarmUID.Bytes(), values.EntityType, string(fieldValues),
+		values.UpdatedDate)
+
+	return err
+}
+
+func (s *StoreMysql) FindValues(entityUID uuid.UUID) (*Values, error) {
+	all, err := s.findAll(`SELECT ENTITY_UID, FARM_UID, ENTITY_TYPE, FIELD_VALUES, UPDATED_DATE
+		FROM CUSTOM_FIELD_VALUE_READ WHERE ENTITY_UID = ?`, entityUID.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(all) == 0 {
+		return nil, nil
+	}
+
+	return &all[0], nil
+}
+
+func (s *StoreMysql) FindAllValues(farmUID uuid.UUID, entityType string) ([]Values, error) {
+	return s.findAll(`SELECT ENTITY_UID, FARM_UID, ENTITY_TYPE, FIELD_VALUES, UPDATED_DATE
+		FROM CUSTOM_FIELD_VALUE_READ WHERE FARM_UID = ? AND ENTITY_TYPE = ?`, farmUID.Bytes(), entityType)
+}
+
+func (s *StoreMysql) findAll(sqlQuery string, args ...interface{}) ([]Values, error) {
+	rows, err := s.DB.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	all := []Values{}
+
+	for rows.Next() {
+		var (
+			entityUID   []byte
+			farmUID     []byte
+			fieldValues string
+		)
+
+		v := Values{}
+
+		err = rows.Scan(&entityUID, &farmUID, &v.EntityType, &fieldValues, &v.UpdatedDate)
+		if err != nil {
+			return nil, err
+		}
+
+		v.EntityUID, err = uuid.FromBytes(entityUID)
+		if err == nil {
+			v.FarmUID, err = uuid.FromBytes(farmUID)
+		}
+
+		if err == nil {
+			err = json.Unmarshal([]byte(fieldValues), &v.Values)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, v)
+	}
+
+	return all, rows.Err()
+}
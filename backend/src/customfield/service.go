@@ -0,0 +1,126 @@
+package customfield
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/eventbus"
+)
+
+// Service validates and saves the values of the custom fields of the entities.
+type Service struct {
+	Store    Store
+	EventBus eventbus.TaniaEventBus
+
+	dryRun bool
+}
+
+func NewService(store Store, bus eventbus.TaniaEventBus) *Service {
+	return &Service{Store: store, EventBus: bus}
+}
+
+// WithoutSaving returns a copy of the service which only validates the values.
+func (s *Service) WithoutSaving() *Service {
+	dry := *s
+	dry.dryRun = true
+
+	return &dry
+}
+
+// Change replaces the values of the entity after validating them against the fields the farm
+// defined for the entity type. The values of the retired fields are kept.
+func (s *Service) Change(
+	farmUID uuid.UUID,
+	entityType string,
+	entityUID uuid.UUID,
+	values map[string]interface{},
+) (map[string]interface{}, error) {
+	fields, err := s.Store.FindFields(farmUID, entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	validated, err := assetsdomain.ValidateCustomFieldValues(fields, values)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.Store.FindValues(entityUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if current != nil && current.FarmUID == farmUID {
+		validated = assetsdomain.KeepRetiredCustomFieldValues(fields, current.Values, validated)
+	}
+
+	if s.dryRun {
+		return validated, nil
+	}
+
+	event := CustomFieldValuesChanged{
+		EntityUID:   entityUID,
+		FarmUID:     farmUID,
+		EntityType:  entityType,
+		Values:      validated,
+		ChangedDate: time.Now(),
+	}
+
+	err = s.Store.AppendEvent(event)
+	if err != nil {
+		return nil, err
+	}
+
+	s.EventBus.Publish(ValuesChangedCode, event)
+
+	return validated, nil
+}
+
+// Find returns the values of the entity, empty when it has none.
+func (s *Service) Find(entityUID uuid.UUID) (map[string]interface{}, error) {
+	values, err := s.Store.FindValues(entityUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if values == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	return values.Values, nil
+}
+
+// Filter returns the entities of the farm whose values match all the filters.
+func (s *Service) Filter(farmUID uuid.UUID, entityType string, filters map[string]string) (map[uuid.UUID]bool, error) {
+	fields, err := s.Store.FindFields(farmUID, entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	// The filters are checked even when there are no values, so an invalid filter is never silently ignored.
+	_, err = assetsdomain.MatchCustomFieldFilters(fields, map[string]interface{}{}, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := s.Store.FindAllValues(farmUID, entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make(map[uuid.UUID]bool)
+
+	for _, v := range all {
+		ok, err := assetsdomain.MatchCustomFieldFilters(fields, v.Values, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			matched[v.EntityUID] = true
+		}
+	}
+
+	return matched, nil
+}
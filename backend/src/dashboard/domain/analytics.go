@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+// TaskDates are the dates of a task counted by the task analytics.
+type TaskDates struct {
+	CreatedDate   time.Time
+	DueDate       *time.Time
+	CompletedDate *time.Time
+	CancelledDate *time.Time
+	LabourMinutes int
+
+	// The units of work of a piecework task, like SEEDLINGS, and how many were done.
+	TargetUnit        string
+	CompletedQuantity float64
+}
+
+// TaskBucket is the tasks created, completed and cancelled in a bucket. The late ones are the tasks completed
+// after the day they were due, and the labour minutes are the ones of the tasks completed.
+type TaskBucket struct {
+	timebuckethelper.Bucket
+
+	Created       int `json:"created"`
+	Completed     int `json:"completed"`
+	CompletedLate int `json:"completed_late"`
+	Cancelled     int `json:"cancelled"`
+	LabourMinutes int `json:"labour_minutes"`
+
+	WorkRates []WorkRate `json:"work_rates"`
+}
+
+// WorkRate is the rate of work of the piecework tasks of a unit completed in a bucket, only counting the tasks
+// with both their completed quantity and their labour minutes recorded.
+type WorkRate struct {
+	Unit              string  `json:"unit"`
+	CompletedQuantity float64 `json:"completed_quantity"`
+	LabourMinutes     int     `json:"labour_minutes"`
+	UnitsPerHour      float64 `json:"units_per_hour"`
+}
+
+// BucketTasks counts the tasks in every bucket, a task is counted in the buckets of each of its dates.
+func BucketTasks(buckets []timebuckethelper.Bucket, tasks []TaskDates) []TaskBucket {
+	rows := make([]TaskBucket, len(buckets))
+	for i, v := range buckets {
+		rows[i] = TaskBucket{Bucket: v, WorkRates: []WorkRate{}}
+	}
+
+	for _, v := range tasks {
+		if i := timebuckethelper.Find(buckets, v.CreatedDate); i >= 0 {
+			rows[i].Created++
+		}
+
+		if v.CompletedDate != nil {
+			if i := timebuckethelper.Find(buckets, *v.CompletedDate); i >= 0 {
+				rows[i].Completed++
+				rows[i].LabourMinutes += v.LabourMinutes
+				rows[i].WorkRates = addWorkRate(rows[i].WorkRates, v)
+
+				if v.DueDate != nil && v.CompletedDate.After(v.DueDate.AddDate(0, 0, 1)) {
+					rows[i].CompletedLate++
+				}
+			}
+		}
+
+		if v.CancelledDate != nil {
+			if i := timebuckethelper.Find(buckets, *v.CancelledDate); i >= 0 {
+				rows[i].Cancelled++
+			}
+		}
+	}
+
+	return rows
+}
+
+// addWorkRate adds the work of the completed task to the rate of its unit, the rates are kept sorted by unit.
+func addWorkRate(rates []WorkRate, task TaskDates) []WorkRate {
+	if task.TargetUnit == "" || tasksdomain.UnitsPerHour(task.CompletedQuantity, task.LabourMinutes) == 0 {
+		return rates
+	}
+
+	i := sort.Search(len(rates), func(i int) bool {
+		return rates[i].Unit >= task.TargetUnit
+	})
+
+	if i == len(rates) || rates[i].Unit != task.TargetUnit {
+		rates = append(rates, WorkRate{})
+		copy(rates[i+1:], rates[i:])
+		rates[i] = WorkRate{Unit: task.TargetUnit}
+	}
+
+	rates[i].CompletedQuantity += task.CompletedQuantity
+	rates[i].LabourMinutes += task.LabourMinutes
+	rates[i].UnitsPerHour = math.Round(
+		tasksdomain.UnitsPerHour(rates[i].CompletedQuantity, rates[i].LabourMinutes)*100) / 100
+
+	return rates
+}
+
+// OccupiedPeriod is when a crop batch grew in an area, a zero end is a batch still growing in it.
+type OccupiedPeriod struct {
+	Start time.Time
+	End   time.Time
+}
+
+// AreaOccupancy is an area of the farm, available from its creation, with the periods crop batches grew in it.
+type AreaOccupancy struct {
+	CreatedDate time.Time
+	Occupied    []OccupiedPeriod
+}
+
+// UtilizationBucket is how much of the time the areas of the farm were growing crops in a bucket, in area days.
+// The utilization is the percentage of the available area days which were occupied.
+type UtilizationBucket struct {
+	timebuckethelper.Bucket
+
+	AreaCount         int     `json:"area_count"`
+	AvailableAreaDays float64 `json:"available_area_days"`
+	OccupiedAreaDays  float64 `json:"occupied_area_days"`
+	Utilization       float64 `json:"utilization"`
+}
+
+// BucketUtilization sums the area days of every bucket until now. The batches growing together in an area
+// occupy it once.
+func BucketUtilization(buckets []timebuckethelper.Bucket, areas []AreaOccupancy, now time.Time) []UtilizationBucket {
+	rows := make([]UtilizationBucket, len(buckets))
+
+	for i, bucket := range buckets {
+		available := time.Duration(0)
+		occupied := time.Duration(0)
+
+		rows[i] = UtilizationBucket{Bucket: bucket}
+
+		for _, area := range areas {
+			areaAvailable := bucket.Overlap(area.CreatedDate, now)
+			if areaAvailable <= 0 {
+				continue
+			}
+
+			rows[i].AreaCount++
+			available += areaAvailable
+
+			for _, v := range mergeOccupiedPeriods(area.Occupied, area.CreatedDate, now) {
+				occupied += bucket.Overlap(v.Start, v.End)
+			}
+		}
+
+		rows[i].AvailableAreaDays = areaDays(available)
+		rows[i].OccupiedAreaDays = areaDays(occupied)
+
+		if available > 0 {
+			rows[i].Utilization = math.Round(float64(occupied)*10000/float64(available)) / 100
+		}
+	}
+
+	return rows
+}
+
+// mergeOccupiedPeriods returns the periods from the area creation until now, with the overlapping ones merged.
+func mergeOccupiedPeriods(periods []OccupiedPeriod, createdDate, now time.Time) []OccupiedPeriod {
+	clipped := []OccupiedPeriod{}
+
+	for _, v := range periods {
+		if v.Start.Before(createdDate) {
+			v.Start = createdDate
+		}
+
+		if v.End.IsZero() || v.End.After(now) {
+			v.End = now
+		}
+
+		if v.Start.Before(v.End) {
+			clipped = append(clipped, v)
+		}
+	}
+
+	sort.Slice(clipped, func(i, j int) bool {
+		return clipped[i].Start.Before(clipped[j].Start)
+	})
+
+	merged := []OccupiedPeriod{}
+
+	for _, v := range clipped {
+		last := len(merged) - 1
+		if last >= 0 && !v.Start.After(merged[last].End) {
+			if v.End.After(merged[last].End) {
+				merged[last].End = v.End
+			}
+
+			continue
+		}
+
+		merged = append(merged, v)
+	}
+
+	return merged
+}
+
+func areaDays(duration time.Duration) float64 {
+	return math.Round(duration.Hours()/24*100) / 100
+}
@@ -0,0 +1,148 @@
+package domain_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
+)
+
+func weekBuckets(t *testing.T, timezone, weekStart, from, to string) []timebuckethelper.Bucket {
+	t.Helper()
+
+	params := url.Values{"interval": {"iso_week"}, "timezone": {timezone}, "week_start": {weekStart},
+		"from": {from}, "to": {to}}
+
+	query, err := timebuckethelper.ParseQuery(params.Get, timebuckethelper.IntervalMonth, nil)
+	assert.Nil(t, err)
+
+	buckets, err := query.Buckets(time.Time{}, time.Now())
+	assert.Nil(t, err)
+
+	return buckets
+}
+
+func TestBucketTasks(t *testing.T) {
+	t.Parallel()
+
+	for _, timezone := range []string{"UTC", "Australia/Sydney"} {
+		for _, weekStart := range []string{timebuckethelper.WeekStartMonday, timebuckethelper.WeekStartSunday} {
+			// Given
+			buckets := weekBuckets(t, timezone, weekStart, "2026-10-12", "2026-10-25")
+			location, _ := time.LoadLocation(timezone)
+
+			saturday := time.Date(2026, time.October, 17, 9, 0, 0, 0, location)
+			sunday := time.Date(2026, time.October, 18, 9, 0, 0, 0, location)
+			due := time.Date(2026, time.October, 16, 0, 0, 0, 0, location)
+
+			// When
+			rows := domain.BucketTasks(buckets, []domain.TaskDates{
+				{CreatedDate: saturday, DueDate: &due, CompletedDate: &sunday, LabourMinutes: 30},
+				{CreatedDate: sunday, CancelledDate: &sunday},
+				{CreatedDate: time.Date(2026, time.October, 1, 9, 0, 0, 0, location)},
+			})
+
+			// Then
+			assert.Equal(t, "2026-W42", rows[0].Label)
+			assert.Equal(t, 1, rows[1].CompletedLate+rows[0].CompletedLate)
+			assert.Equal(t, 30, rows[0].LabourMinutes+rows[1].LabourMinutes+rows[len(rows)-1].LabourMinutes)
+
+			if weekStart == timebuckethelper.WeekStartMonday {
+				assert.Len(t, rows, 2)
+				assert.Equal(t, 2, rows[0].Created)
+				assert.Equal(t, 1, rows[0].Completed)
+				assert.Equal(t, 1, rows[0].Cancelled)
+			} else {
+				// The Sunday weeks straddle both edges of the range.
+				assert.Len(t, rows, 3)
+				assert.True(t, rows[0].Partial)
+				assert.True(t, rows[2].Partial)
+				assert.Equal(t, 1, rows[0].Created)
+				assert.Equal(t, 1, rows[1].Created)
+				assert.Equal(t, 1, rows[1].Completed)
+				assert.Equal(t, 1, rows[1].Cancelled)
+			}
+		}
+	}
+}
+
+func TestBucketTaskWorkRates(t *testing.T) {
+	t.Parallel()
+	// Given
+	buckets := weekBuckets(t, "UTC", timebuckethelper.WeekStartMonday, "2026-10-12", "2026-10-18")
+	monday := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+	tuesday := time.Date(2026, time.October, 13, 9, 0, 0, 0, time.UTC)
+
+	// When
+	rows := domain.BucketTasks(buckets, []domain.TaskDates{
+		{CreatedDate: monday, CompletedDate: &monday, LabourMinutes: 60, TargetUnit: "SEEDLINGS", CompletedQuantity: 250},
+		{CreatedDate: monday, CompletedDate: &tuesday, LabourMinutes: 30, TargetUnit: "SEEDLINGS", CompletedQuantity: 150},
+		{CreatedDate: monday, CompletedDate: &tuesday, LabourMinutes: 45, TargetUnit: "M2", CompletedQuantity: 30},
+		{CreatedDate: monday, CompletedDate: &tuesday, TargetUnit: "M2", CompletedQuantity: 100},
+		{CreatedDate: monday, CompletedDate: &tuesday, LabourMinutes: 20},
+	})
+
+	// Then
+	assert.Len(t, rows, 1)
+	assert.Equal(t, []domain.WorkRate{
+		{Unit: "M2", CompletedQuantity: 30, LabourMinutes: 45, UnitsPerHour: 40},
+		{Unit: "SEEDLINGS", CompletedQuantity: 400, LabourMinutes: 90, UnitsPerHour: 266.67},
+	}, rows[0].WorkRates)
+}
+
+func TestBucketUtilization(t *testing.T) {
+	t.Parallel()
+
+	for _, timezone := range []string{"UTC", "America/New_York"} {
+		for _, weekStart := range []string{timebuckethelper.WeekStartMonday, timebuckethelper.WeekStartSunday} {
+			// Given
+			buckets := weekBuckets(t, timezone, weekStart, "2026-10-12", "2026-10-18")
+			location, _ := time.LoadLocation(timezone)
+
+			day := func(d int) time.Time {
+				return time.Date(2026, time.October, d, 0, 0, 0, 0, location)
+			}
+
+			areas := []domain.AreaOccupancy{
+				// Two batches growing together from the 13th occupy the area once, until the 15th.
+				{CreatedDate: day(1), Occupied: []domain.OccupiedPeriod{
+					{Start: day(13), End: day(15)},
+					{Start: day(14), End: day(15)},
+				}},
+				// Created on the 15th, with a batch still growing.
+				{CreatedDate: day(15), Occupied: []domain.OccupiedPeriod{{Start: day(10)}}},
+			}
+
+			// When
+			rows := domain.BucketUtilization(buckets, areas, day(17))
+
+			// Then
+			available := 0.0
+			occupied := 0.0
+
+			for _, v := range rows {
+				available += v.AvailableAreaDays
+				occupied += v.OccupiedAreaDays
+			}
+
+			// 5 days of the first area and 2 of the second until now.
+			assert.Equal(t, 7.0, available)
+			assert.Equal(t, 4.0, occupied)
+
+			if weekStart == timebuckethelper.WeekStartMonday {
+				assert.Len(t, rows, 1)
+				assert.False(t, rows[0].Partial)
+				assert.Equal(t, 2, rows[0].AreaCount)
+				assert.Equal(t, 57.14, rows[0].Utilization)
+			} else {
+				assert.Len(t, rows, 2)
+				assert.True(t, rows[1].Partial)
+				assert.Equal(t, 0, rows[1].AreaCount)
+				assert.Equal(t, 0.0, rows[1].Utilization)
+			}
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Anonymizer replaces the names and the free texts of the farm export, so it can be shared without the names
+// of the farm, its customers and suppliers. The same original always gets the same pseudonym, so the references
+// between the records still line up. The uids, codes, quantities and dates are kept as they are.
+type Anonymizer struct {
+	pseudonyms map[string]string
+	counters   map[string]int
+}
+
+func NewAnonymizer() *Anonymizer {
+	return &Anonymizer{
+		pseudonyms: map[string]string{},
+		counters:   map[string]int{},
+	}
+}
+
+// kindKeys are the keys whose records are of another kind than their parent, e.g. the initial area of a crop.
+//
+//nolint:gochecknoglobals
+var kindKeys = map[string]string{
+	"farm":              "Farm",
+	"farms":             "Farm",
+	"certifications":    "Certification",
+	"reservoir":         "Reservoir",
+	"reservoirs":        "Reservoir",
+	"area":              "Area",
+	"areas":             "Area",
+	"installed_to_area": "Area",
+	"initial_area":      "Area",
+	"moved_area":        "Area",
+	"material":          "Material",
+	"materials":         "Material",
+	"inventory":         "Material",
+	"crop":              "Crop",
+	"crops":             "Crop",
+	"task":              "Task",
+	"tasks":             "Task",
+	"photo":             "Photo",
+	"photos":            "Photo",
+}
+
+// nameKeys are the keys of the names, whose pseudonyms are numbered by kind. An empty kind is the kind of the record.
+//
+//nolint:gochecknoglobals
+var nameKeys = map[string]string{
+	"name":               "",
+	"title":              "",
+	"batch_id":           "Batch",
+	"city":               "City",
+	"produced_by":        "Supplier",
+	"certifying_body":    "Certifying Body",
+	"certificate_number": "Certificate",
+}
+
+// keptKeys are the keys of the codes, like the types and the units, which don't tell anything about the farm.
+//
+//nolint:gochecknoglobals
+var keptKeys = map[string]bool{
+	"type":               true,
+	"status":             true,
+	"code":               true,
+	"symbol":             true,
+	"unit":               true,
+	"quantity_unit":      true,
+	"currency":           true,
+	"currency_code":      true,
+	"plant_type":         true,
+	"domain":             true,
+	"category":           true,
+	"priority":           true,
+	"country":            true,
+	"mime_type":          true,
+	"entity_type":        true,
+	"field_type":         true,
+	"certification_type": true,
+	"short_code":         true,
+}
+
+// coordinateKeys are the keys of the coordinates, rounded to the degree.
+//
+//nolint:gochecknoglobals
+var coordinateKeys = map[string]bool{
+	"latitude":  true,
+	"longitude": true,
+	"gps_lat":   true,
+	"gps_lon":   true,
+}
+
+// cameraKeys are the keys of the camera a photo was taken with, in its EXIF metadata, which are dropped.
+//
+//nolint:gochecknoglobals
+var cameraKeys = map[string]bool{
+	"make":  true,
+	"model": true,
+}
+
+// Anonymize returns the anonymized copy of a decoded JSON value, whose records are of the kind, e.g. Area.
+func (a *Anonymizer) Anonymize(kind string, value interface{}) interface{} {
+	return a.anonymize(kind, "", value)
+}
+
+func (a *Anonymizer) anonymize(kind, key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// The keys are walked in order, so the same export always gets the same pseudonyms.
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		// The label of a code, like the unit of a quantity, is the same for every farm. The values of the custom
+		// fields are keyed by the codes of their fields, which are named by the farm.
+		_, hasCode := v["code"]
+		_, hasSymbol := v["symbol"]
+		isCode := len(v) == 2 && (hasCode || hasSymbol) && key != "values"
+
+		anonymized := make(map[string]interface{}, len(v))
+
+		for _, k := range keys {
+			if isCode && (k == "label" || k == "name") {
+				anonymized[k] = v[k]
+
+				continue
+			}
+
+			childKind := kind
+			if value, ok := kindKeys[k]; ok {
+				childKind = value
+			}
+
+			anonymized[k] = a.anonymize(childKind, k, v[k])
+		}
+
+		return anonymized
+	case []interface{}:
+		anonymized := make([]interface{}, len(v))
+		for i, child := range v {
+			anonymized[i] = a.anonymize(kind, key, child)
+		}
+
+		return anonymized
+	case string:
+		return a.anonymizeString(kind, key, v)
+	case json.Number:
+		// The GPS position of the EXIF metadata of the photos is a number.
+		if coordinateKeys[key] {
+			return json.Number(coarsenCoordinate(v.String()))
+		}
+	case float64:
+		if coordinateKeys[key] {
+			return math.Round(v)
+		}
+	}
+
+	return value
+}
+
+func (a *Anonymizer) anonymizeString(kind, key, value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch {
+	case key == "filename" || key == "thumbnail_filename":
+		return a.Filename(value)
+	case coordinateKeys[key]:
+		return coarsenCoordinate(value)
+	case cameraKeys[key]:
+		return ""
+	case strings.HasSuffix(key, "_name"):
+		return a.Name(nameKind(strings.TrimSuffix(key, "_name")), value)
+	}
+
+	if nameKind, ok := nameKeys[key]; ok {
+		if nameKind == "" {
+			nameKind = kind
+		}
+
+		return a.Name(nameKind, value)
+	}
+
+	if keptKeys[key] || isStructured(value) {
+		return value
+	}
+
+	return a.Text(value)
+}
+
+// Name returns the pseudonym of the name, numbered by kind like Area 3.
+func (a *Anonymizer) Name(kind, name string) string {
+	if kind == "" {
+		kind = "Item"
+	}
+
+	if pseudonym, ok := a.pseudonyms[name]; ok {
+		return pseudonym
+	}
+
+	a.counters[kind]++
+	pseudonym := fmt.Sprintf("%s %d", kind, a.counters[kind])
+	a.pseudonyms[name] = pseudonym
+
+	return pseudonym
+}
+
+// Filename returns the pseudonym of the uploaded file name, keeping its extension.
+func (a *Anonymizer) Filename(filename string) string {
+	if pseudonym, ok := a.pseudonyms[filename]; ok {
+		return pseudonym
+	}
+
+	a.counters["file"]++
+	pseudonym := fmt.Sprintf("file-%d%s", a.counters["file"], strings.ToLower(path.Ext(filename)))
+	a.pseudonyms[filename] = pseudonym
+
+	return pseudonym
+}
+
+//nolint:gochecknoglobals
+var placeholderWords = []string{
+	"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do",
+	"eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua", "enim",
+	"ad", "minim", "veniam", "quis", "nostrud", "exercitation", "ullamco", "laboris", "nisi", "aliquip",
+}
+
+// Text returns placeholder words for the free text, as many as it has up to 50.
+func (a *Anonymizer) Text(text string) string {
+	count := len(strings.Fields(text))
+	if count == 0 {
+		count = 1
+	}
+
+	if count > 50 {
+		count = 50
+	}
+
+	words := make([]string, count)
+
+	for i := range words {
+		hash := fnv.New32a()
+		_, _ = hash.Write([]byte(strconv.Itoa(i) + ":" + text))
+
+		words[i] = placeholderWords[hash.Sum32()%uint32(len(placeholderWords))]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// nameKind returns the kind of the prefix of a *_name key, e.g. Source Area for source_area.
+func nameKind(prefix string) string {
+	words := strings.Split(prefix, "_")
+	for i, v := range words {
+		if v != "" {
+			words[i] = strings.ToUpper(v[:1]) + v[1:]
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// coarsenCoordinate rounds the coordinate to the degree, about a hundred kilometres.
+func coarsenCoordinate(value string) string {
+	coordinate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return "0"
+	}
+
+	return strconv.FormatFloat(math.Round(coordinate), 'f', 0, 64)
+}
+
+// isStructured tells whether the string is a uid, a date or a number rather than a text.
+func isStructured(value string) bool {
+	if _, err := uuid.FromString(value); err == nil {
+		return true
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return true
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02", "15:04"} {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,140 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+)
+
+func TestAnonymizerKeepsTheReferencesLinedUp(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	export := map[string]interface{}{
+		"areas": []interface{}{map[string]interface{}{
+			"uid":      "81e3b44c-2c14-4a8b-b346-39a14fc76944",
+			"name":     "Zanzibar Field",
+			"location": map[string]interface{}{"code": "OUTDOOR", "name": "Outdoor"},
+			"size": map[string]interface{}{
+				"value": json.Number("1.5"),
+				"unit":  map[string]interface{}{"symbol": "Ha", "label": "Hectare"},
+			},
+		}},
+		"crops": []interface{}{map[string]interface{}{
+			"batch_id":     "xyl-bas-14oct",
+			"initial_area": map[string]interface{}{"name": "Zanzibar Field", "current_quantity": json.Number("10")},
+			"harvested_storage": []interface{}{map[string]interface{}{
+				"source_area_name": "Zanzibar Field",
+				"created_date":     "2026-10-14T07:14:41Z",
+			}},
+		}},
+		"custom_field_values": []interface{}{map[string]interface{}{
+			"values": map[string]interface{}{"code": "Vermilion Grocers", "name": "Umbra Market"},
+		}},
+	}
+
+	// When
+	anonymized, _ := domain.NewAnonymizer().Anonymize("", export).(map[string]interface{})
+
+	// Then
+	area := anonymized["areas"].([]interface{})[0].(map[string]interface{})
+	crop := anonymized["crops"].([]interface{})[0].(map[string]interface{})
+	harvest := crop["harvested_storage"].([]interface{})[0].(map[string]interface{})
+	values := anonymized["custom_field_values"].([]interface{})[0].(map[string]interface{})["values"]
+
+	assert.Equal(t, "Area 1", area["name"])
+	assert.Equal(t, "81e3b44c-2c14-4a8b-b346-39a14fc76944", area["uid"])
+	assert.Equal(t, map[string]interface{}{"code": "OUTDOOR", "name": "Outdoor"}, area["location"])
+	assert.Equal(t, map[string]interface{}{
+		"value": json.Number("1.5"),
+		"unit":  map[string]interface{}{"symbol": "Ha", "label": "Hectare"},
+	}, area["size"])
+	assert.Equal(t, "Batch 1", crop["batch_id"])
+	assert.Equal(t, map[string]interface{}{"name": "Area 1", "current_quantity": json.Number("10")}, crop["initial_area"])
+	assert.Equal(t, "Area 1", harvest["source_area_name"])
+	assert.Equal(t, "2026-10-14T07:14:41Z", harvest["created_date"])
+	assert.NotContains(t, values, "Vermilion Grocers")
+	assert.NotContains(t, values, "Umbra Market")
+}
+
+func TestAnonymizerText(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	anonymizer := domain.NewAnonymizer()
+
+	// When
+	text := anonymizer.Text("Ask for Mrs Kestrel at the back door")
+	again := anonymizer.Text("Ask for Mrs Kestrel at the back door")
+	other := anonymizer.Text("Call Mr Quixotic")
+
+	// Then
+	assert.Equal(t, text, again)
+	assert.Len(t, splitWords(text), 8)
+	assert.Len(t, splitWords(other), 3)
+	assert.NotContains(t, text, "Kestrel")
+}
+
+func TestAnonymizerCoordinatesAndFilenames(t *testing.T) {
+	t.Parallel()
+
+	// When
+	anonymized := domain.NewAnonymizer().Anonymize("Farm", map[string]interface{}{
+		"latitude":  "-7.7956",
+		"longitude": "110.3695",
+		"photo":     map[string]interface{}{"filename": "Secret-Orchard.JPG", "width": json.Number("40")},
+	})
+
+	// Then
+	assert.Equal(t, map[string]interface{}{
+		"latitude":  "-8",
+		"longitude": "110",
+		"photo":     map[string]interface{}{"filename": "file-1.jpg", "width": json.Number("40")},
+	}, anonymized)
+}
+
+func TestAnonymizerPhotoMetadata(t *testing.T) {
+	t.Parallel()
+
+	// When
+	anonymized := domain.NewAnonymizer().Anonymize("Crop", map[string]interface{}{
+		"photos": []interface{}{map[string]interface{}{"metadata": map[string]interface{}{
+			"date_taken": "2026-10-14T07:14:41Z",
+			"make":       "Kestrel Optics",
+			"model":      "KX-200 Orchard Edition",
+			"gps_lat":    json.Number("-7.795634"),
+			"gps_lon":    110.369512,
+		}}},
+	})
+
+	// Then
+	assert.Equal(t, map[string]interface{}{
+		"photos": []interface{}{map[string]interface{}{"metadata": map[string]interface{}{
+			"date_taken": "2026-10-14T07:14:41Z",
+			"make":       "",
+			"model":      "",
+			"gps_lat":    json.Number("-8"),
+			"gps_lon":    float64(110),
+		}}},
+	}, anonymized)
+}
+
+func splitWords(text string) []string {
+	words := []string{}
+	word := ""
+
+	for _, v := range text {
+		if v == ' ' {
+			words = append(words, word)
+			word = ""
+
+			continue
+		}
+
+		word += string(v)
+	}
+
+	return append(words, word)
+}
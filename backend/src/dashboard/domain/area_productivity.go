@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+)
+
+// The metrics the areas can be compared on.
+const (
+	ProductivityMetricYieldPerSqm        = "yield_per_sqm"
+	ProductivityMetricRevenuePerSqm      = "revenue_per_sqm"
+	ProductivityMetricTaskCompletionRate = "task_completion_rate"
+)
+
+func ProductivityMetrics() []string {
+	return []string{
+		ProductivityMetricYieldPerSqm,
+		ProductivityMetricRevenuePerSqm,
+		ProductivityMetricTaskCompletionRate,
+	}
+}
+
+// AreaProductivity is what an area produced and the work done on it over a period.
+type AreaProductivity struct {
+	AreaUID        uuid.UUID
+	AreaName       string
+	SizeM2         float64
+	YieldGram      float64
+	Revenue        float64
+	Tasks          int
+	CompletedTasks int
+}
+
+// YieldPerSqm is the yield in kilograms per square meter, zero for an area without a size.
+func (a AreaProductivity) YieldPerSqm() float64 {
+	if a.SizeM2 <= 0 {
+		return 0
+	}
+
+	return a.YieldGram / 1000 / a.SizeM2
+}
+
+// RevenuePerSqm is the revenue per square meter, zero for an area without a size.
+func (a AreaProductivity) RevenuePerSqm() float64 {
+	if a.SizeM2 <= 0 {
+		return 0
+	}
+
+	return a.Revenue / a.SizeM2
+}
+
+// TaskCompletionRate is the share of the tasks of the area completed, between 0 and 1. It's zero without tasks.
+func (a AreaProductivity) TaskCompletionRate() float64 {
+	if a.Tasks == 0 {
+		return 0
+	}
+
+	return float64(a.CompletedTasks) / float64(a.Tasks)
+}
+
+// Metric returns the value of the metric, an unknown metric is the yield per square meter.
+func (a AreaProductivity) Metric(metric string) float64 {
+	switch metric {
+	case ProductivityMetricRevenuePerSqm:
+		return a.RevenuePerSqm()
+	case ProductivityMetricTaskCompletionRate:
+		return a.TaskCompletionRate()
+	default:
+		return a.YieldPerSqm()
+	}
+}
+
+type RankedAreaProductivity struct {
+	AreaProductivity
+
+	Value float64
+	Rank  int
+}
+
+// RankAreaProductivity ranks the areas on the metric, the best one first with rank 1. The areas with the same
+// value share their rank and are sorted by name.
+func RankAreaProductivity(areas []AreaProductivity, metric string) []RankedAreaProductivity {
+	ranked := []RankedAreaProductivity{}
+	for _, v := range areas {
+		ranked = append(ranked, RankedAreaProductivity{AreaProductivity: v, Value: v.Metric(metric)})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Value != ranked[j].Value {
+			return ranked[i].Value > ranked[j].Value
+		}
+
+		if ranked[i].AreaName != ranked[j].AreaName {
+			return ranked[i].AreaName < ranked[j].AreaName
+		}
+
+		return ranked[i].AreaUID.String() < ranked[j].AreaUID.String()
+	})
+
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+
+		if i > 0 && ranked[i].Value == ranked[i-1].Value {
+			ranked[i].Rank = ranked[i-1].Rank
+		}
+	}
+
+	return ranked
+}
@@ -0,0 +1,62 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+)
+
+func TestRankAreaProductivity(t *testing.T) {
+	t.Parallel()
+	// Given
+	nurseryUID, _ := uuid.NewV4()
+	fieldUID, _ := uuid.NewV4()
+	greenhouseUID, _ := uuid.NewV4()
+	shedUID, _ := uuid.NewV4()
+
+	areas := []domain.AreaProductivity{
+		{AreaUID: nurseryUID, AreaName: "Nursery", SizeM2: 10, YieldGram: 20000, Revenue: 40, Tasks: 4, CompletedTasks: 1},
+		{AreaUID: fieldUID, AreaName: "Field", SizeM2: 100, YieldGram: 500000, Revenue: 500, Tasks: 2, CompletedTasks: 2},
+		{AreaUID: greenhouseUID, AreaName: "Greenhouse", SizeM2: 50, YieldGram: 250000, Revenue: 100},
+		{AreaUID: shedUID, AreaName: "Shed", YieldGram: 1000, Tasks: 1},
+	}
+
+	// When
+	byYield := domain.RankAreaProductivity(areas, domain.ProductivityMetricYieldPerSqm)
+	byRevenue := domain.RankAreaProductivity(areas, domain.ProductivityMetricRevenuePerSqm)
+	byCompletion := domain.RankAreaProductivity(areas, domain.ProductivityMetricTaskCompletionRate)
+
+	// Then
+	assert.Equal(t, []string{"Field", "Greenhouse", "Nursery", "Shed"}, rankedNames(byYield))
+	assert.Equal(t, []int{1, 1, 3, 4}, rankedRanks(byYield))
+	assert.Equal(t, 5.0, byYield[0].Value)
+	assert.Equal(t, 0.0, byYield[3].Value)
+
+	assert.Equal(t, []string{"Field", "Nursery", "Greenhouse", "Shed"}, rankedNames(byRevenue))
+	assert.Equal(t, []int{1, 2, 3, 4}, rankedRanks(byRevenue))
+	assert.Equal(t, 4.0, byRevenue[1].Value)
+
+	assert.Equal(t, []string{"Field", "Nursery", "Greenhouse", "Shed"}, rankedNames(byCompletion))
+	assert.Equal(t, []int{1, 2, 3, 3}, rankedRanks(byCompletion))
+	assert.Equal(t, 0.25, byCompletion[1].Value)
+}
+
+func rankedNames(ranked []domain.RankedAreaProductivity) []string {
+	names := []string{}
+	for _, v := range ranked {
+		names = append(names, v.AreaName)
+	}
+
+	return names
+}
+
+func rankedRanks(ranked []domain.RankedAreaProductivity) []int {
+	ranks := []int{}
+	for _, v := range ranked {
+		ranks = append(ranks, v.Rank)
+	}
+
+	return ranks
+}
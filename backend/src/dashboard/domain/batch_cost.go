@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// The inputs of a crop batch cost.
+const (
+	BatchCostSeed     = "SEED"
+	BatchCostMaterial = "MATERIAL"
+	BatchCostLabour   = "LABOUR"
+)
+
+// The periods the farm batch cost report is broken down into.
+const (
+	BatchCostPeriodWeek  = "WEEK"
+	BatchCostPeriodMonth = "MONTH"
+)
+
+// BatchCostLine is an input of the batch. The inputs whose cost isn't known are unpriced lines
+// with the reason, so a total missing them isn't taken for complete.
+type BatchCostLine struct {
+	Type           string     `json:"type"`
+	Description    string     `json:"description"`
+	TaskUID        *uuid.UUID `json:"task_id,omitempty"`
+	Quantity       float64    `json:"quantity"`
+	Unit           string     `json:"unit"`
+	UnitPrice      float64    `json:"unit_price"`
+	CurrencyCode   string     `json:"currency_code,omitempty"`
+	Cost           float64    `json:"cost"`
+	Priced         bool       `json:"priced"`
+	UnpricedReason string     `json:"unpriced_reason,omitempty"`
+}
+
+// PricedLine is the line of a quantity of an input bought at the unit price.
+func PricedLine(costType, description string, quantity float64, unit string, unitPrice float64,
+	currencyCode string,
+) BatchCostLine {
+	return BatchCostLine{
+		Type:         costType,
+		Description:  description,
+		Quantity:     quantity,
+		Unit:         unit,
+		UnitPrice:    unitPrice,
+		CurrencyCode: currencyCode,
+		Cost:         quantity * unitPrice,
+		Priced:       true,
+	}
+}
+
+func UnpricedLine(costType, description string, quantity float64, unit, reason string) BatchCostLine {
+	return BatchCostLine{
+		Type:           costType,
+		Description:    description,
+		Quantity:       quantity,
+		Unit:           unit,
+		UnpricedReason: reason,
+	}
+}
+
+// BatchCost is the cost of a crop batch, from its seeds to the materials and labour of its tasks.
+type BatchCost struct {
+	CropUID               uuid.UUID       `json:"crop_id"`
+	BatchID               string          `json:"batch_id"`
+	VarietyName           string          `json:"variety_name"`
+	SeedingDate           time.Time       `json:"seeding_date"`
+	SeedCost              float64         `json:"seed_cost"`
+	MaterialCost          float64         `json:"material_cost"`
+	LabourCost            float64         `json:"labour_cost"`
+	TotalCost             float64         `json:"total_cost"`
+	UnpricedLines         int             `json:"unpriced_lines"`
+	HarvestedGramQuantity float32         `json:"harvested_gram_quantity"`
+	CostPerKilogram       *float64        `json:"cost_per_kilogram"`
+	Lines                 []BatchCostLine `json:"lines"`
+}
+
+func NewBatchCost(cropUID uuid.UUID, batchID, varietyName string, seedingDate time.Time,
+	harvestedGramQuantity float32,
+) *BatchCost {
+	return &BatchCost{
+		CropUID:               cropUID,
+		BatchID:               batchID,
+		VarietyName:           varietyName,
+		SeedingDate:           seedingDate,
+		HarvestedGramQuantity: harvestedGramQuantity,
+		Lines:                 []BatchCostLine{},
+	}
+}
+
+// Add sums the line in the cost of its input. The cost per kilogram is of the priced lines only.
+func (b *BatchCost) Add(line BatchCostLine) {
+	b.Lines = append(b.Lines, line)
+
+	if !line.Priced {
+		b.UnpricedLines++
+
+		return
+	}
+
+	switch line.Type {
+	case BatchCostSeed:
+		b.SeedCost += line.Cost
+	case BatchCostMaterial:
+		b.MaterialCost += line.Cost
+	case BatchCostLabour:
+		b.LabourCost += line.Cost
+	}
+
+	b.TotalCost += line.Cost
+	b.CostPerKilogram = costPerKilogram(b.TotalCost, b.HarvestedGramQuantity)
+}
+
+// BatchCostRow is the cost of the batches of a variety seeded in a period.
+type BatchCostRow struct {
+	PeriodStart           string   `json:"period_start"`
+	VarietyName           string   `json:"variety_name"`
+	Batches               int      `json:"batches"`
+	SeedCost              float64  `json:"seed_cost"`
+	MaterialCost          float64  `json:"material_cost"`
+	LabourCost            float64  `json:"labour_cost"`
+	TotalCost             float64  `json:"total_cost"`
+	UnpricedLines         int      `json:"unpriced_lines"`
+	HarvestedGramQuantity float32  `json:"harvested_gram_quantity"`
+	CostPerKilogram       *float64 `json:"cost_per_kilogram"`
+}
+
+type batchCostRowKey struct {
+	periodStart string
+	varietyName string
+}
+
+// BatchCostReport sums the batch costs of the farm per variety and seeding period.
+type BatchCostReport struct {
+	period string
+	rows   map[batchCostRowKey]*BatchCostRow
+}
+
+// NewBatchCostReport starts the report of the period. An unknown period is a monthly report.
+func NewBatchCostReport(period string) *BatchCostReport {
+	if period != BatchCostPeriodWeek {
+		period = BatchCostPeriodMonth
+	}
+
+	return &BatchCostReport{period: period, rows: map[batchCostRowKey]*BatchCostRow{}}
+}
+
+// PeriodStart returns the first day of the week, from Monday, or of the month of the date.
+func (r *BatchCostReport) PeriodStart(date time.Time) time.Time {
+	if r.period == BatchCostPeriodWeek {
+		return time.Date(date.Year(), date.Month(), date.Day()-(int(date.Weekday())+6)%7, 0, 0, 0, 0, date.Location())
+	}
+
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+}
+
+func (r *BatchCostReport) Add(cost BatchCost) {
+	key := batchCostRowKey{
+		periodStart: r.PeriodStart(cost.SeedingDate).Format("2006-01-02"),
+		varietyName: cost.VarietyName,
+	}
+
+	row, ok := r.rows[key]
+	if !ok {
+		row = &BatchCostRow{PeriodStart: key.periodStart, VarietyName: key.varietyName}
+		r.rows[key] = row
+	}
+
+	row.Batches++
+	row.SeedCost += cost.SeedCost
+	row.MaterialCost += cost.MaterialCost
+	row.LabourCost += cost.LabourCost
+	row.TotalCost += cost.TotalCost
+	row.UnpricedLines += cost.UnpricedLines
+	row.HarvestedGramQuantity += cost.HarvestedGramQuantity
+	row.CostPerKilogram = costPerKilogram(row.TotalCost, row.HarvestedGramQuantity)
+}
+
+// Rows returns the rows by period, then by variety name.
+func (r *BatchCostReport) Rows() []BatchCostRow {
+	rows := []BatchCostRow{}
+	for _, v := range r.rows {
+		rows = append(rows, *v)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].PeriodStart != rows[j].PeriodStart {
+			return rows[i].PeriodStart < rows[j].PeriodStart
+		}
+
+		return rows[i].VarietyName < rows[j].VarietyName
+	})
+
+	return rows
+}
+
+// MaterialPrice is the price of a material from its date on.
+type MaterialPrice struct {
+	Date         time.Time
+	Amount       float64
+	CurrencyCode string
+}
+
+// PriceAt returns the price of the material at the date, from the prices by date.
+// The dates before the first price get the first one.
+func PriceAt(prices []MaterialPrice, date time.Time) (MaterialPrice, bool) {
+	if len(prices) == 0 {
+		return MaterialPrice{}, false
+	}
+
+	price := prices[0]
+
+	for _, v := range prices[1:] {
+		if v.Date.After(date) {
+			break
+		}
+
+		price = v
+	}
+
+	return price, true
+}
+
+func costPerKilogram(totalCost float64, gramQuantity float32) *float64 {
+	if gramQuantity <= 0 {
+		return nil
+	}
+
+	cost := totalCost / (float64(gramQuantity) / 1000)
+
+	return &cost
+}
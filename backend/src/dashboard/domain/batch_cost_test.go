@@ -0,0 +1,86 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/dashboard/domain"
+)
+
+func TestBatchCost(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	cropUID, _ := uuid.NewV4()
+	seedingDate := time.Date(2024, 3, 6, 9, 0, 0, 0, time.UTC)
+	cost := NewBatchCost(cropUID, "let-bu-6mar", "Butterhead", seedingDate, 0)
+
+	// When
+	cost.Add(PricedLine(BatchCostSeed, "Seed Butterhead", 100, "SEEDS", 0.05, "EUR"))
+	cost.Add(UnpricedLine(BatchCostMaterial, "Compost for Fertilize", 0, "KG", "no quantity"))
+
+	// Then
+	assert.InDelta(t, 5, cost.SeedCost, 0.0001)
+	assert.InDelta(t, 5, cost.TotalCost, 0.0001)
+	assert.Equal(t, 1, cost.UnpricedLines)
+	assert.Nil(t, cost.CostPerKilogram)
+
+	// When
+	cost.HarvestedGramQuantity = 2500
+	cost.Add(PricedLine(BatchCostLabour, "Harvest", 1.5, "HOURS", 10, ""))
+
+	// Then
+	assert.InDelta(t, 15, cost.LabourCost, 0.0001)
+	assert.InDelta(t, 20, cost.TotalCost, 0.0001)
+	assert.InDelta(t, 8, *cost.CostPerKilogram, 0.0001)
+	assert.Len(t, cost.Lines, 3)
+}
+
+func TestBatchCostReport(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	report := NewBatchCostReport(BatchCostPeriodWeek)
+	sunday := time.Date(2024, 3, 10, 18, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, 3, 11, 7, 0, 0, 0, time.UTC)
+
+	// When
+	report.Add(BatchCost{VarietyName: "Kale", SeedingDate: monday, TotalCost: 4, HarvestedGramQuantity: 1000})
+	report.Add(BatchCost{VarietyName: "Butterhead", SeedingDate: sunday, TotalCost: 2})
+	report.Add(BatchCost{VarietyName: "Butterhead", SeedingDate: sunday.AddDate(0, 0, -2), TotalCost: 3})
+	rows := report.Rows()
+
+	// Then
+	assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), report.PeriodStart(sunday))
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "2024-03-04", rows[0].PeriodStart)
+	assert.Equal(t, 2, rows[0].Batches)
+	assert.InDelta(t, 5, rows[0].TotalCost, 0.0001)
+	assert.Equal(t, "2024-03-11", rows[1].PeriodStart)
+	assert.InDelta(t, 4, *rows[1].CostPerKilogram, 0.0001)
+}
+
+func TestPriceAt(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	prices := []MaterialPrice{
+		{Date: march, Amount: 1, CurrencyCode: "EUR"},
+		{Date: march.AddDate(0, 1, 0), Amount: 2, CurrencyCode: "EUR"},
+	}
+
+	// When
+	before, _ := PriceAt(prices, march.AddDate(0, 0, -1))
+	between, _ := PriceAt(prices, march.AddDate(0, 0, 15))
+	after, _ := PriceAt(prices, march.AddDate(0, 2, 0))
+	_, found := PriceAt(nil, march)
+
+	// Then
+	assert.InDelta(t, 1, before.Amount, 0.0001)
+	assert.InDelta(t, 1, between.Amount, 0.0001)
+	assert.InDelta(t, 2, after.Amount, 0.0001)
+	assert.False(t, found)
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+)
+
+// CostCentreEntry is the work done on a completed task, allocated to the area of its cost centre.
+type CostCentreEntry struct {
+	CostCentreUID uuid.UUID
+	LabourMinutes int
+	HourlyRate    float64
+	MaterialCost  float64
+}
+
+type CostCentreTotal struct {
+	AreaUID      uuid.UUID
+	AreaName     string
+	LabourCost   float64
+	MaterialCost float64
+}
+
+func (t CostCentreTotal) TotalCost() float64 {
+	return t.LabourCost + t.MaterialCost
+}
+
+// CostCentreReport sums the labour and material costs of the tasks of a period per area.
+type CostCentreReport struct {
+	totals map[uuid.UUID]*CostCentreTotal
+}
+
+// NewCostCentreReport starts the report with every area of the farm, so the areas without costs are listed too.
+func NewCostCentreReport(areaNames map[uuid.UUID]string) *CostCentreReport {
+	report := &CostCentreReport{totals: map[uuid.UUID]*CostCentreTotal{}}
+
+	for uid, name := range areaNames {
+		report.totals[uid] = &CostCentreTotal{AreaUID: uid, AreaName: name}
+	}
+
+	return report
+}
+
+// Add allocates the entry to its area. The entries of the areas out of the report are left out.
+func (r *CostCentreReport) Add(entry CostCentreEntry) {
+	total, ok := r.totals[entry.CostCentreUID]
+	if !ok {
+		return
+	}
+
+	total.LabourCost += float64(entry.LabourMinutes) / 60 * entry.HourlyRate
+	total.MaterialCost += entry.MaterialCost
+}
+
+// Totals returns the totals of the areas by name.
+func (r *CostCentreReport) Totals() []CostCentreTotal {
+	totals := []CostCentreTotal{}
+	for _, v := range r.totals {
+		totals = append(totals, *v)
+	}
+
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].AreaName != totals[j].AreaName {
+			return totals[i].AreaName < totals[j].AreaName
+		}
+
+		return totals[i].AreaUID.String() < totals[j].AreaUID.String()
+	})
+
+	return totals
+}
@@ -0,0 +1,33 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+)
+
+func TestCostCentreReport(t *testing.T) {
+	t.Parallel()
+	// Given
+	nurseryUID, _ := uuid.NewV4()
+	fieldUID, _ := uuid.NewV4()
+	otherFarmAreaUID, _ := uuid.NewV4()
+
+	report := domain.NewCostCentreReport(map[uuid.UUID]string{nurseryUID: "Nursery", fieldUID: "Field"})
+
+	// When
+	report.Add(domain.CostCentreEntry{CostCentreUID: nurseryUID, LabourMinutes: 90, HourlyRate: 10})
+	report.Add(domain.CostCentreEntry{CostCentreUID: nurseryUID, LabourMinutes: 30, HourlyRate: 20, MaterialCost: 4})
+	report.Add(domain.CostCentreEntry{CostCentreUID: otherFarmAreaUID, LabourMinutes: 60, HourlyRate: 10})
+
+	totals := report.Totals()
+
+	// Then
+	assert.Equal(t, []domain.CostCentreTotal{
+		{AreaUID: fieldUID, AreaName: "Field"},
+		{AreaUID: nurseryUID, AreaName: "Nursery", LabourCost: 25, MaterialCost: 4},
+	}, totals)
+	assert.Equal(t, 29.0, totals[1].TotalCost())
+}
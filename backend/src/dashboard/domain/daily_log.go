@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+// DailyLog is what happened on the farm during a day, the log written and signed at its end.
+type DailyLog struct {
+	FarmName          string             `json:"farm_name"`
+	Date              string             `json:"date"`
+	Areas             []DailyLogArea     `json:"areas"`
+	CompletedTasks    []DailyLogTask     `json:"completed_tasks"`
+	ReservoirTasks    []DailyLogTask     `json:"reservoir_tasks"`
+	MaterialsConsumed []DailyLogMaterial `json:"materials_consumed"`
+}
+
+// DailyLogArea is an area with the crop activities done in it and the range of its readings.
+// The activities which aren't done in an area, like the photos, are in the area with the nil UID, listed last.
+type DailyLogArea struct {
+	AreaUID    uuid.UUID          `json:"area_id"`
+	Name       string             `json:"name"`
+	Activities []DailyLogActivity `json:"activities"`
+	Readings   *DailyLogReadings  `json:"readings"`
+}
+
+type DailyLogActivity struct {
+	CropUID      uuid.UUID `json:"crop_id"`
+	BatchID      string    `json:"batch_id"`
+	ActivityType string    `json:"activity_type"`
+	Summary      string    `json:"summary"`
+	CreatedDate  time.Time `json:"created_date"`
+
+	AreaUID  uuid.UUID `json:"-"`
+	AreaName string    `json:"-"`
+}
+
+// DailyLogReadings is the range of the temperatures recorded in an area, in celsius.
+type DailyLogReadings struct {
+	Count int     `json:"count"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+}
+
+type DailyLogSample struct {
+	AreaUID     uuid.UUID
+	Temperature float64
+}
+
+// DailyLogTask is a task completed on the day. The asset is the area, the crop batch or the reservoir
+// of the task.
+type DailyLogTask struct {
+	UID              uuid.UUID  `json:"uid"`
+	ShortCode        string     `json:"short_code"`
+	Title            string     `json:"title"`
+	Category         string     `json:"category"`
+	Domain           string     `json:"domain"`
+	AssetName        string     `json:"asset_name"`
+	CompletedDate    time.Time  `json:"completed_date"`
+	CompletedBy      *uuid.UUID `json:"completed_by"`
+	CompletedByName  string     `json:"completed_by_name"`
+	MaterialUID      *uuid.UUID `json:"material_id"`
+	MaterialName     string     `json:"material_name"`
+	MaterialUnit     string     `json:"material_unit"`
+	MaterialQuantity float64    `json:"material_quantity"`
+}
+
+// DailyLogMaterial is the quantity of a material the tasks of the day consumed.
+type DailyLogMaterial struct {
+	MaterialUID uuid.UUID `json:"material_id"`
+	Name        string    `json:"name"`
+	Unit        string    `json:"unit"`
+	Quantity    float64   `json:"quantity"`
+	Tasks       int       `json:"tasks"`
+}
+
+// BuildDailyLog groups the activities and the readings by area, splits the reservoir tasks from the others
+// and sums the materials they consumed. The samples of the areas out of the farm are skipped.
+func BuildDailyLog(
+	farmName string,
+	date time.Time,
+	areaNames map[uuid.UUID]string,
+	activities []DailyLogActivity,
+	samples []DailyLogSample,
+	tasks []DailyLogTask,
+) DailyLog {
+	dailyLog := DailyLog{
+		FarmName:          farmName,
+		Date:              date.Format("2006-01-02"),
+		Areas:             []DailyLogArea{},
+		CompletedTasks:    []DailyLogTask{},
+		ReservoirTasks:    []DailyLogTask{},
+		MaterialsConsumed: []DailyLogMaterial{},
+	}
+
+	areas := map[uuid.UUID]*DailyLogArea{}
+
+	area := func(uid uuid.UUID, name string) *DailyLogArea {
+		if v, ok := areas[uid]; ok {
+			return v
+		}
+
+		if known, ok := areaNames[uid]; ok {
+			name = known
+		}
+
+		areas[uid] = &DailyLogArea{AreaUID: uid, Name: name, Activities: []DailyLogActivity{}}
+
+		return areas[uid]
+	}
+
+	for _, v := range activities {
+		a := area(v.AreaUID, v.AreaName)
+		a.Activities = append(a.Activities, v)
+	}
+
+	for _, v := range samples {
+		if _, ok := areaNames[v.AreaUID]; !ok {
+			continue
+		}
+
+		a := area(v.AreaUID, "")
+
+		if a.Readings == nil {
+			a.Readings = &DailyLogReadings{Min: v.Temperature, Max: v.Temperature}
+		}
+
+		a.Readings.Count++
+
+		if v.Temperature < a.Readings.Min {
+			a.Readings.Min = v.Temperature
+		}
+
+		if v.Temperature > a.Readings.Max {
+			a.Readings.Max = v.Temperature
+		}
+	}
+
+	for _, v := range areas {
+		sort.SliceStable(v.Activities, func(i, j int) bool {
+			return v.Activities[i].CreatedDate.Before(v.Activities[j].CreatedDate)
+		})
+
+		dailyLog.Areas = append(dailyLog.Areas, *v)
+	}
+
+	sort.Slice(dailyLog.Areas, func(i, j int) bool {
+		a, b := dailyLog.Areas[i], dailyLog.Areas[j]
+
+		if (a.AreaUID == uuid.Nil) != (b.AreaUID == uuid.Nil) {
+			return b.AreaUID == uuid.Nil
+		}
+
+		return a.Name < b.Name
+	})
+
+	materials := map[uuid.UUID]*DailyLogMaterial{}
+
+	for _, v := range tasks {
+		if v.Domain == tasksdomain.TaskDomainReservoirCode {
+			dailyLog.ReservoirTasks = append(dailyLog.ReservoirTasks, v)
+		} else {
+			dailyLog.CompletedTasks = append(dailyLog.CompletedTasks, v)
+		}
+
+		if v.MaterialUID == nil || v.MaterialQuantity <= 0 {
+			continue
+		}
+
+		material, ok := materials[*v.MaterialUID]
+		if !ok {
+			material = &DailyLogMaterial{MaterialUID: *v.MaterialUID, Name: v.MaterialName, Unit: v.MaterialUnit}
+			materials[*v.MaterialUID] = material
+		}
+
+		material.Quantity += v.MaterialQuantity
+		material.Tasks++
+	}
+
+	for _, v := range [][]DailyLogTask{dailyLog.CompletedTasks, dailyLog.ReservoirTasks} {
+		sort.SliceStable(v, func(i, j int) bool {
+			return v[i].CompletedDate.Before(v[j].CompletedDate)
+		})
+	}
+
+	for _, v := range materials {
+		dailyLog.MaterialsConsumed = append(dailyLog.MaterialsConsumed, *v)
+	}
+
+	sort.Slice(dailyLog.MaterialsConsumed, func(i, j int) bool {
+		return dailyLog.MaterialsConsumed[i].Name < dailyLog.MaterialsConsumed[j].Name
+	})
+
+	return dailyLog
+}
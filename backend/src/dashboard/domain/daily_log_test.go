@@ -0,0 +1,90 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+func TestBuildDailyLog(t *testing.T) {
+	t.Parallel()
+	// Given
+	nurseryUID, _ := uuid.NewV4()
+	fieldUID, _ := uuid.NewV4()
+	otherFarmAreaUID, _ := uuid.NewV4()
+	fertilizerUID, _ := uuid.NewV4()
+	date := time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC)
+	areaNames := map[uuid.UUID]string{nurseryUID: "Nursery", fieldUID: "Field"}
+
+	activities := []domain.DailyLogActivity{
+		{BatchID: "bro-1", Summary: "Watered", AreaUID: nurseryUID, CreatedDate: date.Add(9 * time.Hour)},
+		{BatchID: "bro-1", Summary: "Photo", CreatedDate: date.Add(10 * time.Hour)},
+		{BatchID: "bro-1", Summary: "Seeded 20 TRAY", AreaUID: nurseryUID, CreatedDate: date.Add(8 * time.Hour)},
+	}
+
+	samples := []domain.DailyLogSample{
+		{AreaUID: fieldUID, Temperature: 21},
+		{AreaUID: fieldUID, Temperature: 14.5},
+		{AreaUID: fieldUID, Temperature: 27},
+		{AreaUID: otherFarmAreaUID, Temperature: 40},
+	}
+
+	tasks := []domain.DailyLogTask{
+		{
+			Title: "Dose the tank", Domain: tasksdomain.TaskDomainReservoirCode, CompletedDate: date.Add(11 * time.Hour),
+			MaterialUID: &fertilizerUID, MaterialName: "NPK", MaterialUnit: "KG", MaterialQuantity: 2,
+		},
+		{Title: "Weed", Domain: tasksdomain.TaskDomainAreaCode, CompletedDate: date.Add(12 * time.Hour)},
+		{
+			Title: "Fertilize", Domain: tasksdomain.TaskDomainCropCode, CompletedDate: date.Add(7 * time.Hour),
+			MaterialUID: &fertilizerUID, MaterialName: "NPK", MaterialUnit: "KG", MaterialQuantity: 0.5,
+		},
+	}
+
+	// When
+	dailyLog := domain.BuildDailyLog("My Farm", date, areaNames, activities, samples, tasks)
+
+	// Then
+	assert.Equal(t, "2026-10-14", dailyLog.Date)
+	assert.Len(t, dailyLog.Areas, 3)
+
+	assert.Equal(t, "Field", dailyLog.Areas[0].Name)
+	assert.Empty(t, dailyLog.Areas[0].Activities)
+	assert.Equal(t, &domain.DailyLogReadings{Count: 3, Min: 14.5, Max: 27}, dailyLog.Areas[0].Readings)
+
+	assert.Equal(t, "Nursery", dailyLog.Areas[1].Name)
+	assert.Equal(t, "Seeded 20 TRAY", dailyLog.Areas[1].Activities[0].Summary)
+	assert.Equal(t, "Watered", dailyLog.Areas[1].Activities[1].Summary)
+	assert.Nil(t, dailyLog.Areas[1].Readings)
+
+	assert.Equal(t, uuid.Nil, dailyLog.Areas[2].AreaUID)
+	assert.Equal(t, "Photo", dailyLog.Areas[2].Activities[0].Summary)
+
+	assert.Len(t, dailyLog.CompletedTasks, 2)
+	assert.Equal(t, "Fertilize", dailyLog.CompletedTasks[0].Title)
+	assert.Equal(t, "Weed", dailyLog.CompletedTasks[1].Title)
+	assert.Len(t, dailyLog.ReservoirTasks, 1)
+	assert.Equal(t, "Dose the tank", dailyLog.ReservoirTasks[0].Title)
+
+	assert.Equal(t, []domain.DailyLogMaterial{
+		{MaterialUID: fertilizerUID, Name: "NPK", Unit: "KG", Quantity: 2.5, Tasks: 2},
+	}, dailyLog.MaterialsConsumed)
+}
+
+func TestBuildDailyLogOfAnEmptyDay(t *testing.T) {
+	t.Parallel()
+
+	// When
+	dailyLog := domain.BuildDailyLog("My Farm", time.Now(), map[uuid.UUID]string{}, nil, nil, nil)
+
+	// Then
+	assert.Empty(t, dailyLog.Areas)
+	assert.NotNil(t, dailyLog.Areas)
+	assert.NotNil(t, dailyLog.CompletedTasks)
+	assert.NotNil(t, dailyLog.ReservoirTasks)
+	assert.NotNil(t, dailyLog.MaterialsConsumed)
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// Stats holds the dashboard counters. They are kept up to date incrementally by
+// remembering what every entity contributed, so an update only has to apply the difference.
+//
+// Tasks and materials don't belong to a farm, so their counters are shared by all the farms.
+type Stats struct {
+	Farms             map[uuid.UUID]FarmStats
+	OpenTasks         int
+	OverdueTasks      int
+	LowStockMaterials int
+
+	Crops     map[uuid.UUID]CropContribution
+	Tasks     map[uuid.UUID]TaskContribution
+	Materials map[uuid.UUID]MaterialContribution
+}
+
+type FarmStats struct {
+	ActiveBatches int
+	TotalPlants   int
+}
+
+type CropContribution struct {
+	FarmUID uuid.UUID
+	Active  bool
+	Plants  int
+}
+
+type TaskContribution struct {
+	Open    bool
+	Overdue bool
+}
+
+type MaterialContribution struct {
+	LowStock bool
+}
+
+func NewStats() Stats {
+	return Stats{
+		Farms:     make(map[uuid.UUID]FarmStats),
+		Crops:     make(map[uuid.UUID]CropContribution),
+		Tasks:     make(map[uuid.UUID]TaskContribution),
+		Materials: make(map[uuid.UUID]MaterialContribution),
+	}
+}
+
+// FarmStats returns the counters of a farm. Farms without any crop have zero counters.
+func (s Stats) FarmStats(farmUID uuid.UUID) FarmStats {
+	return s.Farms[farmUID]
+}
+
+func (s *Stats) SetCrop(uid uuid.UUID, c CropContribution) {
+	if old, ok := s.Crops[uid]; ok {
+		s.addCrop(old, -1)
+	}
+
+	s.Crops[uid] = c
+	s.addCrop(c, 1)
+}
+
+func (s *Stats) SetTask(uid uuid.UUID, t TaskContribution) {
+	if old, ok := s.Tasks[uid]; ok {
+		s.addTask(old, -1)
+	}
+
+	s.Tasks[uid] = t
+	s.addTask(t, 1)
+}
+
+func (s *Stats) SetMaterial(uid uuid.UUID, m MaterialContribution) {
+	if old, ok := s.Materials[uid]; ok && old.LowStock {
+		s.LowStockMaterials--
+	}
+
+	s.Materials[uid] = m
+
+	if m.LowStock {
+		s.LowStockMaterials++
+	}
+}
+
+func (s *Stats) addCrop(c CropContribution, sign int) {
+	if !c.Active {
+		return
+	}
+
+	farm := s.Farms[c.FarmUID]
+	farm.ActiveBatches += sign
+	farm.TotalPlants += sign * c.Plants
+	s.Farms[c.FarmUID] = farm
+}
+
+func (s *Stats) addTask(t TaskContribution, sign int) {
+	if t.Open {
+		s.OpenTasks += sign
+	}
+
+	if t.Overdue {
+		s.OverdueTasks += sign
+	}
+}
@@ -0,0 +1,43 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+)
+
+func TestStatsIncrementalUpdate(t *testing.T) {
+	t.Parallel()
+	// Given
+	stats := domain.NewStats()
+
+	farmUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	otherCropUID, _ := uuid.NewV4()
+	taskUID, _ := uuid.NewV4()
+	materialUID, _ := uuid.NewV4()
+
+	// When
+	stats.SetCrop(cropUID, domain.CropContribution{FarmUID: farmUID, Active: true, Plants: 20})
+	stats.SetCrop(otherCropUID, domain.CropContribution{FarmUID: farmUID, Active: true, Plants: 10})
+	stats.SetCrop(cropUID, domain.CropContribution{FarmUID: farmUID, Active: true, Plants: 15})
+	stats.SetCrop(otherCropUID, domain.CropContribution{FarmUID: farmUID, Active: false})
+
+	stats.SetTask(taskUID, domain.TaskContribution{Open: true})
+	stats.SetTask(taskUID, domain.TaskContribution{Open: true, Overdue: true})
+	openTasks, overdueTasks := stats.OpenTasks, stats.OverdueTasks
+	stats.SetTask(taskUID, domain.TaskContribution{})
+
+	stats.SetMaterial(materialUID, domain.MaterialContribution{LowStock: true})
+	stats.SetMaterial(materialUID, domain.MaterialContribution{LowStock: true})
+
+	// Then
+	assert.Equal(t, domain.FarmStats{ActiveBatches: 1, TotalPlants: 15}, stats.FarmStats(farmUID))
+	assert.Equal(t, 1, openTasks)
+	assert.Equal(t, 1, overdueTasks)
+	assert.Equal(t, 0, stats.OpenTasks)
+	assert.Equal(t, 0, stats.OverdueTasks)
+	assert.Equal(t, 1, stats.LowStockMaterials)
+}
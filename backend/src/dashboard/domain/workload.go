@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const workloadDateLayout = "2006-01-02"
+
+// ErrInvalidWorkloadWeek is a week which isn't an ISO week like 2026-W42.
+var ErrInvalidWorkloadWeek = errors.New("week should be an ISO week like 2026-W42")
+
+// TaskEffort is an open task of the farm due on a day, with the minutes it takes.
+type TaskEffort struct {
+	DueDate time.Time
+	Minutes int
+}
+
+// WorkloadDay is the effort of the open tasks due on a day against the daily budget of the farm,
+// zero when the farm has no budget.
+type WorkloadDay struct {
+	Date             string `json:"date"`
+	Tasks            int    `json:"tasks"`
+	ScheduledMinutes int    `json:"scheduled_minutes"`
+	BudgetMinutes    int    `json:"budget_minutes"`
+	OverBudget       bool   `json:"over_budget"`
+}
+
+// EffortMinutes is the estimate of a task, the default effort for the tasks which aren't estimated.
+func EffortMinutes(estimatedMinutes, defaultMinutes int) int {
+	if estimatedMinutes > 0 {
+		return estimatedMinutes
+	}
+
+	return defaultMinutes
+}
+
+// WorkloadDate is the day of the due date in the server timezone, the days of the workload are keyed by.
+func WorkloadDate(t time.Time) string {
+	return t.In(time.Local).Format(workloadDateLayout)
+}
+
+// ScheduledMinutes sums the effort of the tasks by the day they're due.
+func ScheduledMinutes(tasks []TaskEffort) map[string]int {
+	scheduled := map[string]int{}
+	for _, v := range tasks {
+		scheduled[WorkloadDate(v.DueDate)] += v.Minutes
+	}
+
+	return scheduled
+}
+
+// BuildWorkload returns the effort of the tasks due on each day from start against the budget.
+func BuildWorkload(start time.Time, days int, tasks []TaskEffort, budgetMinutes int) []WorkloadDay {
+	workload := make([]WorkloadDay, days)
+	index := map[string]int{}
+
+	for i := range workload {
+		date := WorkloadDate(start.AddDate(0, 0, i))
+		workload[i] = WorkloadDay{Date: date, BudgetMinutes: budgetMinutes}
+		index[date] = i
+	}
+
+	for _, v := range tasks {
+		i, ok := index[WorkloadDate(v.DueDate)]
+		if !ok {
+			continue
+		}
+
+		workload[i].Tasks++
+		workload[i].ScheduledMinutes += v.Minutes
+	}
+
+	for i := range workload {
+		workload[i].OverBudget = budgetMinutes > 0 && workload[i].ScheduledMinutes > budgetMinutes
+	}
+
+	return workload
+}
+
+// BalanceDueDate returns the due date of a generated task, moved to the closest earlier day of its window
+// which still has room in the budget for the minutes of the task. The window is the due day and the windowDays
+// before it, never before now. The due date is kept when it has room, without a budget, and when no day
+// of the window has room. The time of the day is kept.
+func BalanceDueDate(
+	dueDate time.Time,
+	minutes, budgetMinutes, windowDays int,
+	scheduled map[string]int,
+	now time.Time,
+) time.Time {
+	if budgetMinutes <= 0 {
+		return dueDate
+	}
+
+	for i := 0; i <= windowDays; i++ {
+		day := dueDate.AddDate(0, 0, -i)
+		if day.Before(now) {
+			break
+		}
+
+		if scheduled[WorkloadDate(day)]+minutes <= budgetMinutes {
+			return day
+		}
+	}
+
+	return dueDate
+}
+
+// WorkloadWeekStart returns the Monday of the ISO week, like 2026-W42, in the server timezone.
+// An empty week is the week of now.
+func WorkloadWeekStart(week string, now time.Time) (time.Time, error) {
+	if week == "" {
+		now = now.In(time.Local)
+		year, month, day := now.Date()
+
+		return time.Date(year, month, day-(int(now.Weekday())+6)%7, 0, 0, 0, 0, time.Local), nil
+	}
+
+	var year, number int
+
+	_, err := fmt.Sscanf(week, "%4d-W%2d", &year, &number)
+	if err != nil || len(week) != len("2026-W42") {
+		return time.Time{}, ErrInvalidWorkloadWeek
+	}
+
+	// The 4th of January is always in the first week.
+	january4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.Local)
+	start := january4.AddDate(0, 0, -(int(january4.Weekday())+6)%7+(number-1)*7)
+
+	isoYear, isoWeek := start.ISOWeek()
+	if isoYear != year || isoWeek != number {
+		return time.Time{}, ErrInvalidWorkloadWeek
+	}
+
+	return start, nil
+}
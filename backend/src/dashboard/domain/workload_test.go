@@ -0,0 +1,93 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+)
+
+func TestBalanceDueDate(t *testing.T) {
+	t.Parallel()
+	// Given
+	today := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.Local)
+	monday := time.Date(2026, time.October, 19, 8, 0, 0, 0, time.Local)
+	scheduled := map[string]int{
+		"2026-10-19": 450,
+		"2026-10-18": 480,
+		"2026-10-17": 400,
+		"2026-10-16": 480,
+		"2026-10-13": 0,
+	}
+
+	// When
+	fits := domain.BalanceDueDate(monday, 30, 480, 3, scheduled, today)
+	moved := domain.BalanceDueDate(monday, 60, 480, 3, scheduled, today)
+	full := domain.BalanceDueDate(monday, 90, 480, 3, scheduled, today)
+	noBudget := domain.BalanceDueDate(monday, 600, 0, 3, scheduled, today)
+	notBeforeToday := domain.BalanceDueDate(monday.AddDate(0, 0, -6), 600, 480, 3, scheduled, today)
+
+	// Then
+	assert.Equal(t, monday, fits)
+	assert.Equal(t, time.Date(2026, time.October, 17, 8, 0, 0, 0, time.Local), moved)
+	assert.Equal(t, monday, full)
+	assert.Equal(t, monday, noBudget)
+	assert.Equal(t, monday.AddDate(0, 0, -6), notBeforeToday)
+}
+
+func TestBalanceDueDateSpreadsTheGeneratedTasks(t *testing.T) {
+	t.Parallel()
+	// Given
+	today := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.Local)
+	monday := time.Date(2026, time.October, 19, 8, 0, 0, 0, time.Local)
+	tasks := []domain.TaskEffort{}
+
+	// When
+	for i := 0; i < 10; i++ {
+		dueDate := domain.BalanceDueDate(monday, 60, 120, 2, domain.ScheduledMinutes(tasks), today)
+		tasks = append(tasks, domain.TaskEffort{DueDate: dueDate, Minutes: 60})
+	}
+
+	workload := domain.BuildWorkload(monday.AddDate(0, 0, -2), 3, tasks, 120)
+
+	// Then
+	assert.Equal(t, []domain.WorkloadDay{
+		{Date: "2026-10-17", Tasks: 2, ScheduledMinutes: 120, BudgetMinutes: 120},
+		{Date: "2026-10-18", Tasks: 2, ScheduledMinutes: 120, BudgetMinutes: 120},
+		{Date: "2026-10-19", Tasks: 6, ScheduledMinutes: 360, BudgetMinutes: 120, OverBudget: true},
+	}, workload)
+}
+
+func TestEffortMinutes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 45, domain.EffortMinutes(45, 30))
+	assert.Equal(t, 30, domain.EffortMinutes(0, 30))
+}
+
+func TestWorkloadWeekStart(t *testing.T) {
+	t.Parallel()
+	// Given
+	now := time.Date(2026, time.October, 14, 15, 0, 0, 0, time.Local)
+
+	// When
+	current, currentErr := domain.WorkloadWeekStart("", now)
+	week, weekErr := domain.WorkloadWeekStart("2026-W42", now)
+	firstWeek, firstWeekErr := domain.WorkloadWeekStart("2026-W01", now)
+	week53, week53Err := domain.WorkloadWeekStart("2020-W53", now)
+	_, noWeek53Err := domain.WorkloadWeekStart("2025-W53", now)
+	_, invalidErr := domain.WorkloadWeekStart("2026-10-14", now)
+
+	// Then
+	assert.Nil(t, currentErr)
+	assert.Equal(t, time.Date(2026, time.October, 12, 0, 0, 0, 0, time.Local), current)
+	assert.Nil(t, weekErr)
+	assert.Equal(t, current, week)
+	assert.Nil(t, firstWeekErr)
+	assert.Equal(t, time.Date(2025, time.December, 29, 0, 0, 0, 0, time.Local), firstWeek)
+	assert.Nil(t, week53Err)
+	assert.Equal(t, time.Date(2020, time.December, 28, 0, 0, 0, 0, time.Local), week53)
+	assert.Equal(t, domain.ErrInvalidWorkloadWeek, noWeek53Err)
+	assert.Equal(t, domain.ErrInvalidWorkloadWeek, invalidErr)
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+// Worksheet is the printed sheet the crew works from, with the open tasks of a day grouped by area.
+type Worksheet struct {
+	FarmName string
+	Date     time.Time
+	Assignee string
+	Areas    []WorksheetArea
+}
+
+// WorksheetArea is an area of the sheet. The tasks which aren't done in an area are grouped in
+// the area with the nil UID, printed last.
+type WorksheetArea struct {
+	AreaUID uuid.UUID
+	Name    string
+	Tasks   []WorksheetTask
+}
+
+type WorksheetTask struct {
+	UID       uuid.UUID
+	ShortCode string
+	Title     string
+	Category  string
+	Priority  string
+	AreaUID   uuid.UUID
+	AreaName  string
+
+	// The crop of the crop tasks, with its quantity in the area.
+	BatchID       string
+	Quantity      int
+	ContainerType string
+}
+
+// BuildWorksheet groups the tasks by area, in the walk order of the farm.
+// The areas left out of the walk order come after the others, by name.
+func BuildWorksheet(farmName string, date time.Time, assignee string, walkOrder []uuid.UUID,
+	tasks []WorksheetTask,
+) Worksheet {
+	position := map[uuid.UUID]int{}
+	for i, v := range walkOrder {
+		position[v] = i
+	}
+
+	areas := map[uuid.UUID]*WorksheetArea{}
+
+	for _, v := range tasks {
+		area, ok := areas[v.AreaUID]
+		if !ok {
+			area = &WorksheetArea{AreaUID: v.AreaUID, Name: v.AreaName}
+			areas[v.AreaUID] = area
+		}
+
+		area.Tasks = append(area.Tasks, v)
+	}
+
+	worksheet := Worksheet{
+		FarmName: farmName,
+		Date:     date,
+		Assignee: assignee,
+		Areas:    []WorksheetArea{},
+	}
+
+	for _, v := range areas {
+		sort.SliceStable(v.Tasks, func(i, j int) bool {
+			return lessWorksheetTask(v.Tasks[i], v.Tasks[j])
+		})
+
+		worksheet.Areas = append(worksheet.Areas, *v)
+	}
+
+	sort.Slice(worksheet.Areas, func(i, j int) bool {
+		a, b := worksheet.Areas[i], worksheet.Areas[j]
+
+		if (a.AreaUID == uuid.Nil) != (b.AreaUID == uuid.Nil) {
+			return b.AreaUID == uuid.Nil
+		}
+
+		pa, aOrdered := position[a.AreaUID]
+		pb, bOrdered := position[b.AreaUID]
+
+		switch {
+		case aOrdered && bOrdered:
+			return pa < pb
+		case aOrdered != bOrdered:
+			return aOrdered
+		default:
+			return a.Name < b.Name
+		}
+	})
+
+	return worksheet
+}
+
+// lessWorksheetTask puts the urgent tasks first.
+func lessWorksheetTask(a, b WorksheetTask) bool {
+	if a.Priority != b.Priority {
+		return a.Priority == tasksdomain.TaskPriorityUrgent
+	}
+
+	return a.Title < b.Title
+}
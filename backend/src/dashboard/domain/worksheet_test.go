@@ -0,0 +1,42 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+)
+
+func TestBuildWorksheetFollowsTheWalkOrder(t *testing.T) {
+	t.Parallel()
+	// Given
+	nurseryUID, _ := uuid.NewV4()
+	greenhouseUID, _ := uuid.NewV4()
+	fieldUID, _ := uuid.NewV4()
+	orchardUID, _ := uuid.NewV4()
+
+	tasks := []domain.WorksheetTask{
+		{Title: "Check the pump"},
+		{Title: "Water", AreaUID: orchardUID, AreaName: "Orchard"},
+		{Title: "Weed", AreaUID: fieldUID, AreaName: "Field"},
+		{Title: "Prune", AreaUID: nurseryUID, AreaName: "Nursery"},
+		{Title: "Harvest", AreaUID: greenhouseUID, AreaName: "Greenhouse"},
+		{Title: "Spray", AreaUID: nurseryUID, AreaName: "Nursery", Priority: "URGENT"},
+	}
+
+	// When
+	worksheet := domain.BuildWorksheet("My Farm", time.Now(), "", []uuid.UUID{greenhouseUID, nurseryUID}, tasks)
+
+	// Then
+	names := []string{}
+	for _, v := range worksheet.Areas {
+		names = append(names, v.Name)
+	}
+
+	assert.Equal(t, []string{"Greenhouse", "Nursery", "Field", "Orchard", ""}, names)
+	assert.Equal(t, "Spray", worksheet.Areas[1].Tasks[0].Title)
+	assert.Equal(t, "Prune", worksheet.Areas[1].Tasks[1].Title)
+	assert.Equal(t, uuid.Nil, worksheet.Areas[4].AreaUID)
+}
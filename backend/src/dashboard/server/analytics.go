@@ -0,0 +1,164 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
+)
+
+// GetTaskReport counts the tasks of the farm created, completed and cancelled in every bucket of the interval
+// param, iso_week by default. The from and to dates are both included, without them the buckets run from the
+// first task until today.
+func (s *DashboardServer) GetTaskReport(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	bucketQuery, err := s.parseBucketQuery(c, farmUID, timebuckethelper.IntervalISOWeek)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	tasks, err := s.findAllExportTasks(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	dates := []domain.TaskDates{}
+	earliest := time.Time{}
+
+	for _, v := range tasks {
+		dates = append(dates, domain.TaskDates{
+			CreatedDate:   v.CreatedDate,
+			DueDate:       v.DueDate,
+			CompletedDate: v.CompletedDate,
+			CancelledDate: v.CancelledDate,
+			LabourMinutes: v.LabourMinutes,
+
+			TargetUnit:        v.TargetUnit,
+			CompletedQuantity: v.CompletedQuantity,
+		})
+
+		if earliest.IsZero() || v.CreatedDate.Before(earliest) {
+			earliest = v.CreatedDate
+		}
+	}
+
+	buckets, err := bucketQuery.Buckets(earliest, time.Now())
+	if err != nil {
+		return Error(c, NewRequestValidationError(InvalidOption, timebuckethelper.ParamInterval))
+	}
+
+	data := make(map[string]interface{})
+	data["data"] = domain.BucketTasks(buckets, dates)
+	data["bucketing"] = bucketQuery
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// GetUtilizationReport returns how much of the time the areas of the farm were growing crop batches in every
+// bucket of the interval param, month by default. Without the from and to dates the buckets run from the
+// creation of the first area until today.
+func (s *DashboardServer) GetUtilizationReport(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	bucketQuery, err := s.parseBucketQuery(c, farmUID, timebuckethelper.IntervalMonth)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	result := <-s.AreaReadQuery.FindAllByFarm(farmUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	areas, ok := result.Result.([]assetsstorage.AreaRead)
+	if !ok {
+		return Error(c, errors.New("internal server error. error type assertion"))
+	}
+
+	crops, err := s.findAllCropsByFarm(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// An area is occupied from the day a batch is sown or moved in it until it's emptied.
+	occupied := map[uuid.UUID][]domain.OccupiedPeriod{}
+	occupy := func(areaUID uuid.UUID, createdDate, lastUpdated time.Time, currentQuantity int) {
+		period := domain.OccupiedPeriod{Start: createdDate}
+		if currentQuantity == 0 {
+			period.End = lastUpdated
+		}
+
+		occupied[areaUID] = append(occupied[areaUID], period)
+	}
+
+	for _, crop := range crops {
+		occupy(crop.InitialArea.AreaUID, crop.InitialArea.CreatedDate, crop.InitialArea.LastUpdated,
+			crop.InitialArea.CurrentQuantity)
+
+		for _, v := range crop.MovedArea {
+			occupy(v.AreaUID, v.CreatedDate, v.LastUpdated, v.CurrentQuantity)
+		}
+	}
+
+	occupancies := []domain.AreaOccupancy{}
+	earliest := time.Time{}
+
+	for _, v := range areas {
+		occupancies = append(occupancies, domain.AreaOccupancy{CreatedDate: v.CreatedDate, Occupied: occupied[v.UID]})
+
+		if earliest.IsZero() || v.CreatedDate.Before(earliest) {
+			earliest = v.CreatedDate
+		}
+	}
+
+	now := time.Now()
+
+	buckets, err := bucketQuery.Buckets(earliest, now)
+	if err != nil {
+		return Error(c, NewRequestValidationError(InvalidOption, timebuckethelper.ParamInterval))
+	}
+
+	data := make(map[string]interface{})
+	data["data"] = domain.BucketUtilization(buckets, occupancies, now)
+	data["bucketing"] = bucketQuery
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// parseBucketQuery parses the interval, timezone, week_start, from and to params with the seasons of the farm.
+func (s *DashboardServer) parseBucketQuery(c echo.Context, farmUID uuid.UUID, defaultInterval string) (
+	timebuckethelper.Query, error,
+) {
+	farm, err := s.findSyncFarm(farmUID)
+	if err != nil {
+		return timebuckethelper.Query{}, err
+	}
+
+	seasons := assetsdomain.SeasonsOrDefault(farm.Seasons, farm.Latitude)
+
+	bucketQuery, err := timebuckethelper.ParseQuery(c.QueryParam, defaultInterval, seasons)
+
+	paramErr := timebuckethelper.ParamError{}
+	if errors.As(err, &paramErr) {
+		if paramErr.Unknown {
+			return timebuckethelper.Query{}, NewRequestValidationError(InvalidOption, paramErr.Param)
+		}
+
+		return timebuckethelper.Query{}, NewRequestValidationError(ParseFailed, paramErr.Param)
+	}
+
+	return bucketQuery, err
+}
@@ -0,0 +1,285 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/units"
+)
+
+// GetAreaProductivityComparison ranks the areas of the farm side by side on the metric param, yield_per_sqm by
+// default, between from and to, both included. The period is the current year until today by default.
+// The revenue_per_sqm metric needs the prices param, the price of a kilogram per plant type of the harvested
+// varieties like VEGETABLE:2.5,FRUIT:4, as the farm records no sales. Only the sellable grades are sold.
+func (s *DashboardServer) GetAreaProductivityComparison(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	metric := c.QueryParam("metric")
+	if metric == "" {
+		metric = domain.ProductivityMetricYieldPerSqm
+	}
+
+	validMetric := false
+
+	for _, v := range domain.ProductivityMetrics() {
+		if v == metric {
+			validMetric = true
+		}
+	}
+
+	if !validMetric {
+		return Error(c, NewRequestValidationError(InvalidOption, "metric"))
+	}
+
+	now := time.Now()
+	from := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	if value := c.QueryParam("from"); value != "" {
+		from, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "from"))
+		}
+	}
+
+	if value := c.QueryParam("to"); value != "" {
+		to, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "to"))
+		}
+	}
+
+	if to.Before(from) {
+		return Error(c, NewRequestValidationError(InvalidOption, "to"))
+	}
+
+	prices := map[string]float64{}
+
+	if metric == domain.ProductivityMetricRevenuePerSqm {
+		if c.QueryParam("prices") == "" {
+			return Error(c, NewRequestValidationError(Required, "prices"))
+		}
+
+		prices, err = parsePlantTypePrices(c.QueryParam("prices"))
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "prices"))
+		}
+	}
+
+	areas, err := s.findAreaProductivity(farmUID, from, to.AddDate(0, 0, 1), prices)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]interface{})
+	data["data"] = MapToAreaProductivityRows(domain.RankAreaProductivity(areas, metric))
+	data["metric"] = metric
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// parsePlantTypePrices parses the prices of a kilogram by plant type, like VEGETABLE:2.5,FRUIT:4.
+func parsePlantTypePrices(value string) (map[string]float64, error) {
+	prices := map[string]float64{}
+
+	for _, v := range strings.Split(value, ",") {
+		plantType, price, found := strings.Cut(v, ":")
+		if !found {
+			return nil, errors.New("price should be a plant type and a price: " + v)
+		}
+
+		plantType = strings.ToUpper(strings.TrimSpace(plantType))
+		if assetsdomain.GetPlantType(plantType) == (assetsdomain.PlantType{}) {
+			return nil, errors.New("unknown plant type: " + plantType)
+		}
+
+		amount, err := strconv.ParseFloat(strings.TrimSpace(price), 64)
+		if err != nil || amount < 0 {
+			return nil, errors.New("invalid price: " + price)
+		}
+
+		prices[plantType] = amount
+	}
+
+	return prices, nil
+}
+
+// findAreaProductivity sums the harvests and the tasks due in [from, to) per area of the farm. A task without a
+// due date counts in the period it was created in, the cancelled ones aren't counted.
+func (s *DashboardServer) findAreaProductivity(farmUID uuid.UUID, from, to time.Time, prices map[string]float64) (
+	[]domain.AreaProductivity, error,
+) {
+	farm, err := s.findSyncFarm(farmUID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := <-s.AreaReadQuery.FindAllByFarm(farmUID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	areas, ok := result.Result.([]assetsstorage.AreaRead)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	productivity := map[uuid.UUID]*domain.AreaProductivity{}
+
+	for _, v := range areas {
+		// An area in an unknown unit is left without a size, like the areas without one.
+		sizeM2, _ := units.AreaSize().ToCanonical(v.Size.Unit.Symbol, float64(v.Size.Value))
+
+		productivity[v.UID] = &domain.AreaProductivity{AreaUID: v.UID, AreaName: v.Name, SizeM2: sizeM2}
+	}
+
+	crops, err := s.findAllExportCrops(farmUID)
+	if err != nil {
+		return nil, err
+	}
+
+	farmCrops := map[uuid.UUID]growthstorage.CropRead{}
+	for _, v := range crops {
+		farmCrops[v.UID] = v
+	}
+
+	activityResult := <-s.CropActivityQuery.FindAllByDate(from, to)
+	if activityResult.Error != nil {
+		return nil, activityResult.Error
+	}
+
+	cropActivities, ok := activityResult.Result.([]growthstorage.CropActivity)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	grades := assetsdomain.HarvestGradesOrDefault(farm.HarvestGrades)
+	plantTypes := map[uuid.UUID]string{}
+
+	for _, v := range cropActivities {
+		harvest, ok := v.ActivityType.(growthstorage.HarvestActivity)
+		if !ok {
+			continue
+		}
+
+		crop, inFarm := farmCrops[v.UID]
+		area, found := productivity[harvest.SrcAreaUID]
+
+		if !inFarm || !found {
+			continue
+		}
+
+		area.YieldGram += float64(harvest.ProducedGramQuantity)
+
+		if len(prices) == 0 {
+			continue
+		}
+
+		plantType, found := plantTypes[crop.Inventory.UID]
+		if !found {
+			plantType, err = s.findCropPlantType(crop)
+			if err != nil {
+				return nil, err
+			}
+
+			plantTypes[crop.Inventory.UID] = plantType
+		}
+
+		sellableGram := sellableGramQuantity(harvest.ProducedGramQuantity, harvest.Grades, grades)
+		area.Revenue += sellableGram / 1000 * prices[plantType]
+	}
+
+	tasks, err := s.findAllExportTasks(farmUID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range tasks {
+		date := v.CreatedDate
+		if v.DueDate != nil {
+			date = *v.DueDate
+		}
+
+		if v.Status == tasksdomain.TaskStatusCancelled || date.Before(from) || !date.Before(to) {
+			continue
+		}
+
+		for _, areaUID := range v.AreaIDs() {
+			area, found := productivity[areaUID]
+			if !found {
+				continue
+			}
+
+			area.Tasks++
+
+			// The areas of a task spanning several are done once at 100%, before the task is completed.
+			if v.Status == tasksdomain.TaskStatusCompleted || v.PerAreaProgress[areaUID] >= 100 {
+				area.CompletedTasks++
+			}
+		}
+	}
+
+	rows := []domain.AreaProductivity{}
+	for _, v := range productivity {
+		rows = append(rows, *v)
+	}
+
+	return rows, nil
+}
+
+// findCropPlantType returns the plant type of the material the crop was sown from, the one the crop recorded
+// when the material is gone.
+func (s *DashboardServer) findCropPlantType(crop growthstorage.CropRead) (string, error) {
+	material, err := s.findMaterial(crop.Inventory.UID)
+	if err != nil {
+		return "", err
+	}
+
+	switch t := material.Type.(type) {
+	case assetsdomain.MaterialTypeSeed:
+		return t.PlantType.Code, nil
+	case assetsdomain.MaterialTypePlant:
+		return t.PlantType.Code, nil
+	default:
+		return crop.Inventory.PlantType, nil
+	}
+}
+
+// sellableGramQuantity is the part of the harvest in the sellable grades of the farm. The harvests without grades
+// are sellable, the grades the farm doesn't define anymore aren't.
+func sellableGramQuantity(producedGramQuantity float32, harvested []growthdomain.HarvestedGrade,
+	grades []assetsdomain.HarvestGrade,
+) float64 {
+	if len(harvested) == 0 {
+		return float64(producedGramQuantity)
+	}
+
+	sellable := map[string]bool{}
+	for _, v := range grades {
+		sellable[v.Code] = v.Sellable
+	}
+
+	total := 0.0
+
+	for _, v := range harvested {
+		if sellable[v.Grade] {
+			total += float64(v.GramQuantity)
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,398 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// GetCropBatchCost returns the seed, material and labour costs of the crop batch, line by line.
+func (s *DashboardServer) GetCropBatchCost(c echo.Context) error {
+	cropUID, err := uuid.FromString(c.Param("crop_id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "crop_id"))
+	}
+
+	crop, err := s.findCrop(cropUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if crop.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "crop_id"))
+	}
+
+	cost, err := s.newBatchCostCalculator().calculate(crop)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]domain.BatchCost)
+	data["data"] = *cost
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// GetFarmBatchCostReport sums the costs of the batches of the farm per variety and week or month of their seeding,
+// the period param. The from and to dates are both included, all the batches are reported without them.
+func (s *DashboardServer) GetFarmBatchCostReport(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	period := strings.ToUpper(c.QueryParam("period"))
+	if period == "" {
+		period = domain.BatchCostPeriodMonth
+	}
+
+	if period != domain.BatchCostPeriodWeek && period != domain.BatchCostPeriodMonth {
+		return Error(c, NewRequestValidationError(InvalidOption, "period"))
+	}
+
+	var from, to time.Time
+
+	if value := c.QueryParam("from"); value != "" {
+		from, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "from"))
+		}
+	}
+
+	if value := c.QueryParam("to"); value != "" {
+		to, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "to"))
+		}
+
+		to = to.AddDate(0, 0, 1)
+	}
+
+	crops, err := s.findAllCropsByFarm(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	calculator := s.newBatchCostCalculator()
+	report := domain.NewBatchCostReport(period)
+
+	for _, crop := range crops {
+		seedingDate := crop.InitialArea.CreatedDate.In(time.Local)
+		if (!from.IsZero() && seedingDate.Before(from)) || (!to.IsZero() && !seedingDate.Before(to)) {
+			continue
+		}
+
+		cost, err := calculator.calculate(crop)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		cost.SeedingDate = seedingDate
+		report.Add(*cost)
+	}
+
+	data := make(map[string][]domain.BatchCostRow)
+	data["data"] = report.Rows()
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// batchCostCalculator keeps the prices and rates found for the previous batches of a report.
+type batchCostCalculator struct {
+	server      *DashboardServer
+	materials   map[uuid.UUID]assetsstorage.MaterialRead
+	prices      map[uuid.UUID][]domain.MaterialPrice
+	hourlyRates map[uuid.UUID]float64
+}
+
+func (s *DashboardServer) newBatchCostCalculator() *batchCostCalculator {
+	return &batchCostCalculator{
+		server:      s,
+		materials:   map[uuid.UUID]assetsstorage.MaterialRead{},
+		prices:      map[uuid.UUID][]domain.MaterialPrice{},
+		hourlyRates: map[uuid.UUID]float64{},
+	}
+}
+
+// calculate prices the seeds at the seeding and the materials of the completed tasks of the crop
+// at their completion, and the labour of the tasks at the hourly rate of the users who completed them.
+func (b *batchCostCalculator) calculate(crop growthstorage.CropRead) (*domain.BatchCost, error) {
+	harvested := float32(0)
+	for _, v := range crop.HarvestedStorage {
+		harvested += v.ProducedGramQuantity
+	}
+
+	cost := domain.NewBatchCost(crop.UID, crop.BatchID, crop.Inventory.Name, crop.InitialArea.CreatedDate, harvested)
+
+	seedLine, err := b.seedLine(crop)
+	if err != nil {
+		return nil, err
+	}
+
+	cost.Add(seedLine)
+
+	result := <-b.server.TaskReadQuery.FindTasksWithFilter(map[string]string{
+		"status":   tasksdomain.TaskStatusCompleted,
+		"domain":   tasksdomain.TaskDomainCropCode,
+		"asset_id": crop.UID.String(),
+	}, 0, 0)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	tasks, ok := result.Result.([]taskstorage.TaskRead)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	for _, v := range tasks {
+		if v.AssetID == nil || *v.AssetID != crop.UID {
+			continue
+		}
+
+		if materialUID := taskMaterialID(v.DomainDetails); materialUID != nil {
+			line, err := b.materialLine(v, *materialUID)
+			if err != nil {
+				return nil, err
+			}
+
+			cost.Add(line)
+		}
+
+		line, err := b.labourLine(v)
+		if err != nil {
+			return nil, err
+		}
+
+		cost.Add(line)
+	}
+
+	return cost, nil
+}
+
+// seedLine prices the seeds of the batch, one per plant, when the seeds are bought by the seed.
+// The seeds of the other units aren't counted at the seeding.
+func (b *batchCostCalculator) seedLine(crop growthstorage.CropRead) (domain.BatchCostLine, error) {
+	description := "Seed " + crop.Inventory.Name
+	quantity := float64(crop.InitialArea.InitialQuantity)
+
+	material, found, err := b.findMaterial(crop.Inventory.UID)
+	if err != nil {
+		return domain.BatchCostLine{}, err
+	}
+
+	if !found {
+		return domain.UnpricedLine(domain.BatchCostSeed, description, quantity, assetsdomain.MaterialUnitSeeds,
+			"the seed material was not found"), nil
+	}
+
+	unit := material.Quantity.Unit.Code
+	if unit != assetsdomain.MaterialUnitSeeds {
+		return domain.UnpricedLine(domain.BatchCostSeed, description, 0, unit,
+			"the quantity of seeds bought in "+unit+" is not recorded at the seeding"), nil
+	}
+
+	price, err := b.priceAt(material, crop.InitialArea.CreatedDate)
+	if err != nil {
+		return domain.BatchCostLine{}, err
+	}
+
+	if price.Amount <= 0 {
+		return domain.UnpricedLine(domain.BatchCostSeed, description, quantity, unit,
+			"the seed material has no price"), nil
+	}
+
+	return domain.PricedLine(domain.BatchCostSeed, description, quantity, unit, price.Amount, price.CurrencyCode), nil
+}
+
+func (b *batchCostCalculator) materialLine(task taskstorage.TaskRead, materialUID uuid.UUID,
+) (domain.BatchCostLine, error) {
+	material, found, err := b.findMaterial(materialUID)
+	if err != nil {
+		return domain.BatchCostLine{}, err
+	}
+
+	if !found {
+		return b.taskLine(domain.UnpricedLine(domain.BatchCostMaterial, task.Title, task.MaterialQuantity, "",
+			"the material was not found"), task), nil
+	}
+
+	description := material.Name + " for " + task.Title
+	unit := material.Quantity.Unit.Code
+
+	if task.MaterialQuantity <= 0 {
+		return b.taskLine(domain.UnpricedLine(domain.BatchCostMaterial, description, 0, unit,
+			"the quantity used was not recorded on the completion"), task), nil
+	}
+
+	price, err := b.priceAt(material, *task.CompletedDate)
+	if err != nil {
+		return domain.BatchCostLine{}, err
+	}
+
+	if price.Amount <= 0 {
+		return b.taskLine(domain.UnpricedLine(domain.BatchCostMaterial, description, task.MaterialQuantity, unit,
+			"the material has no price"), task), nil
+	}
+
+	return b.taskLine(domain.PricedLine(domain.BatchCostMaterial, description, task.MaterialQuantity, unit,
+		price.Amount, price.CurrencyCode), task), nil
+}
+
+// labourLine prices the minutes recorded on the completion of the task, in hours.
+func (b *batchCostCalculator) labourLine(task taskstorage.TaskRead) (domain.BatchCostLine, error) {
+	hours := float64(task.LabourMinutes) / 60
+
+	if task.LabourMinutes <= 0 {
+		return b.taskLine(domain.UnpricedLine(domain.BatchCostLabour, task.Title, 0, "HOURS",
+			"the labour minutes were not recorded on the completion"), task), nil
+	}
+
+	if task.CompletedBy == nil {
+		return b.taskLine(domain.UnpricedLine(domain.BatchCostLabour, task.Title, hours, "HOURS",
+			"the user who completed the task is unknown"), task), nil
+	}
+
+	rate, ok := b.hourlyRates[*task.CompletedBy]
+	if !ok {
+		var err error
+
+		rate, err = b.server.findHourlyRate(*task.CompletedBy)
+		if err != nil {
+			return domain.BatchCostLine{}, err
+		}
+
+		b.hourlyRates[*task.CompletedBy] = rate
+	}
+
+	if rate <= 0 {
+		return b.taskLine(domain.UnpricedLine(domain.BatchCostLabour, task.Title, hours, "HOURS",
+			"the user who completed the task has no hourly rate"), task), nil
+	}
+
+	return b.taskLine(domain.PricedLine(domain.BatchCostLabour, task.Title, hours, "HOURS", rate, ""), task), nil
+}
+
+func (*batchCostCalculator) taskLine(line domain.BatchCostLine, task taskstorage.TaskRead) domain.BatchCostLine {
+	taskUID := task.UID
+	line.TaskUID = &taskUID
+
+	return line
+}
+
+func (b *batchCostCalculator) findMaterial(materialUID uuid.UUID) (assetsstorage.MaterialRead, bool, error) {
+	if material, ok := b.materials[materialUID]; ok {
+		return material, true, nil
+	}
+
+	result := <-b.server.MaterialReadQuery.FindByID(materialUID)
+	if result.Error != nil {
+		return assetsstorage.MaterialRead{}, false, result.Error
+	}
+
+	material, ok := result.Result.(assetsstorage.MaterialRead)
+	if !ok {
+		return assetsstorage.MaterialRead{}, false, errors.New("internal server error. error type assertion")
+	}
+
+	if material.UID == (uuid.UUID{}) {
+		return assetsstorage.MaterialRead{}, false, nil
+	}
+
+	b.materials[materialUID] = material
+
+	return material, true, nil
+}
+
+// priceAt finds the price of the material at the date from the history of its price changes.
+func (b *batchCostCalculator) priceAt(material assetsstorage.MaterialRead, date time.Time,
+) (domain.MaterialPrice, error) {
+	prices, ok := b.prices[material.UID]
+	if !ok {
+		var err error
+
+		prices, err = b.server.findMaterialPrices(material)
+		if err != nil {
+			return domain.MaterialPrice{}, err
+		}
+
+		b.prices[material.UID] = prices
+	}
+
+	price, _ := domain.PriceAt(prices, date)
+
+	return price, nil
+}
+
+// findMaterialPrices returns the prices of the material by date, its current one when the history is empty.
+func (s *DashboardServer) findMaterialPrices(material assetsstorage.MaterialRead) ([]domain.MaterialPrice, error) {
+	result := <-s.MaterialEventQuery.FindAllByID(material.UID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	events, ok := result.Result.([]assetsstorage.MaterialEvent)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	prices := []domain.MaterialPrice{}
+
+	for _, v := range events {
+		switch e := v.Event.(type) {
+		case assetsdomain.MaterialCreated:
+			prices = append(prices, materialPrice(e.CreatedDate, e.PricePerUnit))
+		case assetsdomain.MaterialPriceChanged:
+			prices = append(prices, materialPrice(v.CreatedDate, e.Price))
+		}
+	}
+
+	if len(prices) == 0 {
+		prices = append(prices, materialPrice(material.CreatedDate, assetsdomain.PricePerUnit(material.PricePerUnit)))
+	}
+
+	return prices, nil
+}
+
+// materialPrice reads the amount of the price, an amount which isn't a number has no price.
+func materialPrice(date time.Time, price assetsdomain.PricePerUnit) domain.MaterialPrice {
+	amount, err := strconv.ParseFloat(price.Amount, 64)
+	if err != nil {
+		amount = 0
+	}
+
+	return domain.MaterialPrice{Date: date, Amount: amount, CurrencyCode: price.CurrencyCode}
+}
+
+// cropScope checks the crop of the param belongs to the farm of the farm param.
+func (s *DashboardServer) cropScope(param, farmParam string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity(farmParam, func(c echo.Context) (uuid.UUID, error) {
+		cropUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		result := <-s.CropReadQuery.FindByID(cropUID)
+		if result.Error != nil {
+			return uuid.Nil, result.Error
+		}
+
+		crop, ok := result.Result.(growthstorage.CropRead)
+		if !ok {
+			return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		return crop.FarmUID, nil
+	})
+}
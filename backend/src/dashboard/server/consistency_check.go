@@ -0,0 +1,65 @@
+package server
+
+import (
+	"log"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+)
+
+// CheckConsistency recomputes the counters from scratch and compares them with the incremental ones.
+// Every drift is logged, then the recomputed counters replace the current ones.
+func (s *DashboardServer) CheckConsistency() ([]Drift, error) {
+	recomputed, err := s.computeStats()
+	if err != nil {
+		return nil, err
+	}
+
+	s.StatsStorage.Lock.Lock()
+	defer s.StatsStorage.Lock.Unlock()
+
+	drifts := findDrifts(s.StatsStorage.Stats, recomputed)
+
+	for _, v := range drifts {
+		log.Printf("Dashboard counter drift. farm_id: %v, counter: %s, current: %d, recomputed: %d",
+			v.FarmUID, v.Counter, v.Current, v.Recomputed)
+	}
+
+	s.StatsStorage.Stats = recomputed
+
+	return drifts, nil
+}
+
+func findDrifts(current, recomputed domain.Stats) []Drift {
+	drifts := []Drift{}
+
+	compare := func(farmUID *uuid.UUID, counter string, c, r int) {
+		if c != r {
+			drifts = append(drifts, Drift{FarmUID: farmUID, Counter: counter, Current: c, Recomputed: r})
+		}
+	}
+
+	compare(nil, "open_tasks", current.OpenTasks, recomputed.OpenTasks)
+	compare(nil, "overdue_tasks", current.OverdueTasks, recomputed.OverdueTasks)
+	compare(nil, "low_stock_materials", current.LowStockMaterials, recomputed.LowStockMaterials)
+
+	farmUIDs := map[uuid.UUID]bool{}
+	for uid := range current.Farms {
+		farmUIDs[uid] = true
+	}
+
+	for uid := range recomputed.Farms {
+		farmUIDs[uid] = true
+	}
+
+	for uid := range farmUIDs {
+		farmUID := uid
+		c := current.FarmStats(farmUID)
+		r := recomputed.FarmStats(farmUID)
+
+		compare(&farmUID, "active_batches", c.ActiveBatches, r.ActiveBatches)
+		compare(&farmUID, "total_plants", c.TotalPlants, r.TotalPlants)
+	}
+
+	return drifts
+}
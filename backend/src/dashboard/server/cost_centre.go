@@ -0,0 +1,202 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+	userstorage "github.com/usetania/tania-core/src/user/storage"
+)
+
+// GetCostCentreReport sums the labour and material costs of the tasks completed between from and to,
+// both included, per area of the farm. The period is the current month by default.
+func (s *DashboardServer) GetCostCentreReport(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	if value := c.QueryParam("from"); value != "" {
+		from, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "from"))
+		}
+	}
+
+	if value := c.QueryParam("to"); value != "" {
+		to, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "to"))
+		}
+	}
+
+	if to.Before(from) {
+		return Error(c, NewRequestValidationError(InvalidOption, "to"))
+	}
+
+	rows, err := s.findCostCentreRows(farmUID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string][]CostCentreRow)
+	data["data"] = rows
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// findCostCentreRows sums the costs of the tasks completed in [from, to) per area of the farm.
+func (s *DashboardServer) findCostCentreRows(farmUID uuid.UUID, from, to time.Time) ([]CostCentreRow, error) {
+	areaNames, err := s.findAreaNames(farmUID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := domain.NewCostCentreReport(areaNames)
+
+	entries, err := s.findCostCentreEntries(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range entries {
+		report.Add(v)
+	}
+
+	return MapToCostCentreRows(report.Totals()), nil
+}
+
+// findCostCentreEntries finds the costs of the tasks completed in [from, to) which have a cost centre.
+func (s *DashboardServer) findCostCentreEntries(from, to time.Time) ([]domain.CostCentreEntry, error) {
+	result := <-s.TaskReadQuery.FindTasksWithFilter(map[string]string{"status": tasksdomain.TaskStatusCompleted}, 0, 0)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	tasks, ok := result.Result.([]taskstorage.TaskRead)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	hourlyRates := map[uuid.UUID]float64{}
+	prices := map[uuid.UUID]float64{}
+	entries := []domain.CostCentreEntry{}
+
+	for _, v := range tasks {
+		if v.CostCentreID == nil || v.CompletedDate == nil {
+			continue
+		}
+
+		if v.CompletedDate.Before(from) || !v.CompletedDate.Before(to) {
+			continue
+		}
+
+		entry := domain.CostCentreEntry{
+			CostCentreUID: *v.CostCentreID,
+			LabourMinutes: v.LabourMinutes,
+		}
+
+		if v.CompletedBy != nil {
+			rate, ok := hourlyRates[*v.CompletedBy]
+			if !ok {
+				var err error
+
+				rate, err = s.findHourlyRate(*v.CompletedBy)
+				if err != nil {
+					return nil, err
+				}
+
+				hourlyRates[*v.CompletedBy] = rate
+			}
+
+			entry.HourlyRate = rate
+		}
+
+		if materialUID := taskMaterialID(v.DomainDetails); materialUID != nil && v.MaterialQuantity > 0 {
+			price, ok := prices[*materialUID]
+			if !ok {
+				var err error
+
+				price, err = s.findMaterialPrice(*materialUID)
+				if err != nil {
+					return nil, err
+				}
+
+				prices[*materialUID] = price
+			}
+
+			entry.MaterialCost = v.MaterialQuantity * price
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// findHourlyRate finds the hourly rate of the user. The users are only stored in the databases,
+// so there is no rate with the in memory engine.
+func (s *DashboardServer) findHourlyRate(userUID uuid.UUID) (float64, error) {
+	if s.UserReadQuery == nil {
+		return 0, nil
+	}
+
+	result := <-s.UserReadQuery.FindByID(userUID)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	user, ok := result.Result.(userstorage.UserRead)
+	if !ok {
+		return 0, errors.New("internal server error. error type assertion")
+	}
+
+	return user.HourlyRate, nil
+}
+
+func (s *DashboardServer) findMaterialPrice(materialUID uuid.UUID) (float64, error) {
+	result := <-s.MaterialReadQuery.FindByID(materialUID)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	material, ok := result.Result.(assetsstorage.MaterialRead)
+	if !ok {
+		return 0, errors.New("internal server error. error type assertion")
+	}
+
+	if material.UID == (uuid.UUID{}) {
+		return 0, nil
+	}
+
+	price, err := strconv.ParseFloat(material.PricePerUnit.Amount, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return price, nil
+}
+
+func taskMaterialID(details tasksdomain.TaskDomain) *uuid.UUID {
+	switch v := details.(type) {
+	case tasksdomain.TaskDomainArea:
+		return v.MaterialID
+	case tasksdomain.TaskDomainCrop:
+		return v.MaterialID
+	case tasksdomain.TaskDomainReservoir:
+		return v.MaterialID
+	}
+
+	return nil
+}
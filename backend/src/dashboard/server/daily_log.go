@@ -0,0 +1,471 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+	growthquery "github.com/usetania/tania-core/src/growth/query"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+	userstorage "github.com/usetania/tania-core/src/user/storage"
+)
+
+const (
+	DailyLogFormatJSON = "json"
+	DailyLogFormatPDF  = "pdf"
+)
+
+// GetDailyLog assembles what happened on the farm during the local day: the crop activities and the range
+// of the readings per area, the tasks completed with who completed them, the reservoir tasks and
+// the materials consumed. The `format` pdf prints it with the lines to sign it.
+func (s *DashboardServer) GetDailyLog(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	if value := c.QueryParam("date"); value != "" {
+		date, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "date"))
+		}
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = DailyLogFormatJSON
+	}
+
+	if format != DailyLogFormatJSON && format != DailyLogFormatPDF {
+		return Error(c, NewRequestValidationError(InvalidOption, "format"))
+	}
+
+	result := <-s.FarmReadQuery.FindByID(farmUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	farm, ok := result.Result.(assetsstorage.FarmRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	if farm.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	dailyLog, err := s.findDailyLog(farm, date, date.AddDate(0, 0, 1))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if format == DailyLogFormatPDF {
+		c.Response().Header().Set(echo.HeaderContentDisposition,
+			fmt.Sprintf("inline; filename=daily-log-%s.pdf", dailyLog.Date))
+
+		return c.Blob(http.StatusOK, "application/pdf", RenderDailyLogPDF(dailyLog))
+	}
+
+	data := make(map[string]domain.DailyLog)
+	data["data"] = dailyLog
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// findDailyLog reads the activities, the samples and the completed tasks of [from, to) once each.
+func (s *DashboardServer) findDailyLog(farm assetsstorage.FarmRead, from, to time.Time) (domain.DailyLog, error) {
+	areaNames, err := s.findAreaNames(farm.UID)
+	if err != nil {
+		return domain.DailyLog{}, err
+	}
+
+	activities, err := s.findDailyLogActivities(farm.UID, areaNames, from, to)
+	if err != nil {
+		return domain.DailyLog{}, err
+	}
+
+	result := <-s.MicroclimateSampleQuery.FindAllByDate(from, to)
+	if result.Error != nil {
+		return domain.DailyLog{}, result.Error
+	}
+
+	sampleReads, ok := result.Result.([]growthquery.MicroclimateSampleQueryResult)
+	if !ok {
+		return domain.DailyLog{}, errors.New("internal server error. error type assertion")
+	}
+
+	samples := []domain.DailyLogSample{}
+	for _, v := range sampleReads {
+		samples = append(samples, domain.DailyLogSample{AreaUID: v.AreaUID, Temperature: v.Temperature})
+	}
+
+	tasks, err := s.findDailyLogTasks(farm.UID, areaNames, from, to)
+	if err != nil {
+		return domain.DailyLog{}, err
+	}
+
+	return domain.BuildDailyLog(farm.Name, from, areaNames, activities, samples, tasks), nil
+}
+
+// findDailyLogActivities finds the activities of the crops of the farm, the archived ones included.
+func (s *DashboardServer) findDailyLogActivities(
+	farmUID uuid.UUID, areaNames map[uuid.UUID]string, from, to time.Time,
+) ([]domain.DailyLogActivity, error) {
+	crops, err := s.findAllExportCrops(farmUID)
+	if err != nil {
+		return nil, err
+	}
+
+	farmCrops := map[uuid.UUID]bool{}
+	for _, v := range crops {
+		farmCrops[v.UID] = true
+	}
+
+	// The task activities only keep the name of their area.
+	areaUIDs := map[string]uuid.UUID{}
+	for uid, name := range areaNames {
+		areaUIDs[name] = uid
+	}
+
+	result := <-s.CropActivityQuery.FindAllByDate(from, to)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	cropActivities, ok := result.Result.([]growthstorage.CropActivity)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	activities := []domain.DailyLogActivity{}
+
+	for _, v := range cropActivities {
+		if !farmCrops[v.UID] || v.ActivityType == nil {
+			continue
+		}
+
+		activity := domain.DailyLogActivity{
+			CropUID:      v.UID,
+			BatchID:      v.BatchID,
+			ActivityType: v.ActivityType.Code(),
+			CreatedDate:  v.CreatedDate,
+		}
+
+		areaName := ""
+
+		switch t := v.ActivityType.(type) {
+		case growthstorage.SeedActivity:
+			activity.AreaUID, areaName = t.AreaUID, t.AreaName
+			activity.Summary = fmt.Sprintf("Seeded %d %s", t.Quantity, v.ContainerType)
+		case growthstorage.MoveActivity:
+			activity.AreaUID, areaName = t.DstAreaUID, t.DstAreaName
+			activity.Summary = fmt.Sprintf("Moved %d %s from %s", t.Quantity, v.ContainerType, t.SrcAreaName)
+		case growthstorage.HarvestActivity:
+			activity.AreaUID, areaName = t.SrcAreaUID, t.SrcAreaName
+			activity.Summary = fmt.Sprintf("Harvested %d %s, %.0f g", t.Quantity, v.ContainerType,
+				t.ProducedGramQuantity)
+		case growthstorage.DumpActivity:
+			activity.AreaUID, areaName = t.SrcAreaUID, t.SrcAreaName
+			activity.Summary = fmt.Sprintf("Dumped %d %s", t.Quantity, v.ContainerType)
+		case growthstorage.WaterActivity:
+			activity.AreaUID, areaName = t.AreaUID, t.AreaName
+			activity.Summary = "Watered"
+		case growthstorage.TaskCropActivity:
+			activity.AreaUID, areaName = areaUIDs[t.AreaName], t.AreaName
+			activity.Summary = t.Title
+		case growthstorage.TaskNutrientActivity:
+			activity.AreaUID, areaName = areaUIDs[t.AreaName], t.AreaName
+			activity.Summary = "Applied " + t.MaterialName
+		case growthstorage.TaskPestControlActivity:
+			activity.AreaUID, areaName = areaUIDs[t.AreaName], t.AreaName
+			activity.Summary = "Applied " + t.MaterialName
+		case growthstorage.TaskSafetyActivity:
+			activity.AreaUID, areaName = areaUIDs[t.AreaName], t.AreaName
+			activity.Summary = t.Title
+		case growthstorage.TaskSanitationActivity:
+			activity.AreaUID, areaName = areaUIDs[t.AreaName], t.AreaName
+			activity.Summary = t.Title
+		case growthstorage.PhotoActivity:
+			activity.Summary = "Photo " + t.Description
+		case growthstorage.GerminationActivity:
+			activity.Summary = fmt.Sprintf("%d of %d seeds germinated", t.GerminatedQuantity, t.SeedsSown)
+		case growthstorage.WithholdingOverrideActivity:
+			activity.Summary = "Harvest before the safe harvest date: " + t.Reason
+		case growthstorage.MergeActivity:
+			activity.AreaUID, areaName = t.AreaUID, t.AreaName
+			activity.Summary = fmt.Sprintf("Merged %d %s of %s into %s", t.Quantity, v.ContainerType,
+				t.SourceBatchID, t.TargetBatchID)
+		case growthstorage.TransferActivity:
+			activity.Summary = fmt.Sprintf("Transferred to %s, certificate %s", t.RecipientName, t.CertificateNumber)
+		}
+
+		// An area out of the farm now keeps the name the activity recorded.
+		if activity.AreaUID != uuid.Nil {
+			activity.AreaName = areaName
+		}
+
+		activities = append(activities, activity)
+	}
+
+	return activities, nil
+}
+
+// findDailyLogTasks finds the tasks of the farm completed in [from, to). The general, finance and inventory
+// tasks don't belong to any farm, they are in the log of every farm.
+func (s *DashboardServer) findDailyLogTasks(
+	farmUID uuid.UUID, areaNames map[uuid.UUID]string, from, to time.Time,
+) ([]domain.DailyLogTask, error) {
+	result := <-s.TaskReadQuery.FindTasksWithFilter(map[string]string{"status": tasksdomain.TaskStatusCompleted}, 0, 0)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	taskReads, ok := result.Result.([]taskstorage.TaskRead)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	usernames := map[uuid.UUID]string{}
+	materials := map[uuid.UUID]assetsstorage.MaterialRead{}
+	tasks := []domain.DailyLogTask{}
+
+	for _, v := range taskReads {
+		if v.CompletedDate == nil || v.CompletedDate.Before(from) || !v.CompletedDate.Before(to) {
+			continue
+		}
+
+		assetName, inFarm, err := s.findDailyLogAsset(v, farmUID, areaNames)
+		if err != nil {
+			return nil, err
+		}
+
+		if !inFarm {
+			continue
+		}
+
+		task := domain.DailyLogTask{
+			UID:           v.UID,
+			ShortCode:     v.ShortCode,
+			Title:         v.Title,
+			Category:      v.Category,
+			Domain:        v.Domain,
+			AssetName:     assetName,
+			CompletedDate: *v.CompletedDate,
+			CompletedBy:   v.CompletedBy,
+		}
+
+		if v.CompletedBy != nil {
+			username, ok := usernames[*v.CompletedBy]
+			if !ok {
+				username, err = s.findUsername(*v.CompletedBy)
+				if err != nil {
+					return nil, err
+				}
+
+				usernames[*v.CompletedBy] = username
+			}
+
+			task.CompletedByName = username
+		}
+
+		if materialUID := taskMaterialID(v.DomainDetails); materialUID != nil {
+			material, ok := materials[*materialUID]
+			if !ok {
+				material, err = s.findMaterial(*materialUID)
+				if err != nil {
+					return nil, err
+				}
+
+				materials[*materialUID] = material
+			}
+
+			task.MaterialUID = materialUID
+			task.MaterialName = material.Name
+			task.MaterialUnit = material.Quantity.Unit.Code
+			task.MaterialQuantity = v.MaterialQuantity
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// findDailyLogAsset names the area, the crop batch or the reservoir of the task, and tells whether
+// it is in the farm.
+func (s *DashboardServer) findDailyLogAsset(
+	task taskstorage.TaskRead, farmUID uuid.UUID, areaNames map[uuid.UUID]string,
+) (string, bool, error) {
+	if task.AssetID == nil {
+		return "", true, nil
+	}
+
+	switch task.Domain {
+	case tasksdomain.TaskDomainAreaCode:
+		name, ok := areaNames[*task.AssetID]
+
+		return name, ok, nil
+
+	case tasksdomain.TaskDomainCropCode:
+		crop, err := s.findCrop(*task.AssetID)
+		if err != nil {
+			return "", false, err
+		}
+
+		return crop.BatchID, crop.FarmUID == farmUID, nil
+
+	case tasksdomain.TaskDomainReservoirCode:
+		result := <-s.ReservoirReadQuery.FindByID(*task.AssetID)
+		if result.Error != nil {
+			return "", false, result.Error
+		}
+
+		reservoir, ok := result.Result.(assetsstorage.ReservoirRead)
+		if !ok {
+			return "", false, errors.New("internal server error. error type assertion")
+		}
+
+		return reservoir.Name, reservoir.Farm.UID == farmUID, nil
+	}
+
+	return "", true, nil
+}
+
+// findUsername finds the username of the user. The users are only stored in the databases,
+// so there is no name with the in memory engine.
+func (s *DashboardServer) findUsername(userUID uuid.UUID) (string, error) {
+	if s.UserReadQuery == nil {
+		return "", nil
+	}
+
+	result := <-s.UserReadQuery.FindByID(userUID)
+	if result.Error != nil {
+		return "", result.Error
+	}
+
+	user, ok := result.Result.(userstorage.UserRead)
+	if !ok {
+		return "", errors.New("internal server error. error type assertion")
+	}
+
+	return user.Username, nil
+}
+
+func (s *DashboardServer) findMaterial(materialUID uuid.UUID) (assetsstorage.MaterialRead, error) {
+	result := <-s.MaterialReadQuery.FindByID(materialUID)
+	if result.Error != nil {
+		return assetsstorage.MaterialRead{}, result.Error
+	}
+
+	material, ok := result.Result.(assetsstorage.MaterialRead)
+	if !ok {
+		return assetsstorage.MaterialRead{}, errors.New("internal server error. error type assertion")
+	}
+
+	return material, nil
+}
+
+func dailyLogAreaName(area domain.DailyLogArea) string {
+	if area.AreaUID == uuid.Nil {
+		return "Other activities"
+	}
+
+	return area.Name
+}
+
+func dailyLogCompletedBy(task domain.DailyLogTask) string {
+	if task.CompletedByName != "" {
+		return task.CompletedByName
+	}
+
+	if task.CompletedBy != nil {
+		return task.CompletedBy.String()
+	}
+
+	return ""
+}
+
+func dailyLogMaterial(task domain.DailyLogTask) string {
+	if task.MaterialUID == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s %g %s", task.MaterialName, task.MaterialQuantity, task.MaterialUnit)
+}
+
+// RenderDailyLogPDF lays the daily log out on A4 pages, a table per area and per list of tasks,
+// with the lines to sign it at the end.
+func RenderDailyLogPDF(dailyLog domain.DailyLog) []byte {
+	sections := []reportSection{}
+
+	for _, area := range dailyLog.Areas {
+		title := dailyLogAreaName(area)
+		if area.Readings != nil {
+			title += fmt.Sprintf(" (%d readings, %.1f to %.1f °C)", area.Readings.Count, area.Readings.Min,
+				area.Readings.Max)
+		}
+
+		rows := [][]string{}
+		for _, v := range area.Activities {
+			rows = append(rows, []string{v.CreatedDate.In(time.Local).Format("15:04"), v.BatchID, v.ActivityType,
+				v.Summary})
+		}
+
+		sections = append(sections, reportSection{
+			Title:  title,
+			Header: []string{"Time", "Batch", "Activity", "Summary"},
+			Widths: []float64{50, 100, 110, 255},
+			Rows:   rows,
+			Empty:  "No crop activities.",
+		})
+	}
+
+	taskRows := func(tasks []domain.DailyLogTask) [][]string {
+		rows := [][]string{}
+		for _, v := range tasks {
+			rows = append(rows, []string{v.CompletedDate.In(time.Local).Format("15:04"), v.Title, v.AssetName,
+				dailyLogMaterial(v), dailyLogCompletedBy(v)})
+		}
+
+		return rows
+	}
+
+	taskHeader := []string{"Time", "Task", "Asset", "Material", "Completed by"}
+	taskWidths := []float64{50, 165, 100, 110, 90}
+
+	materialRows := [][]string{}
+	for _, v := range dailyLog.MaterialsConsumed {
+		materialRows = append(materialRows, []string{v.Name, fmt.Sprintf("%g %s", v.Quantity, v.Unit),
+			fmt.Sprintf("%d", v.Tasks)})
+	}
+
+	sections = append(sections,
+		reportSection{
+			Title: "Completed tasks", Header: taskHeader, Widths: taskWidths,
+			Rows: taskRows(dailyLog.CompletedTasks), Empty: "No tasks completed.",
+		},
+		reportSection{
+			Title: "Reservoir dosings and refills", Header: taskHeader, Widths: taskWidths,
+			Rows: taskRows(dailyLog.ReservoirTasks), Empty: "No reservoir tasks completed.",
+		},
+		reportSection{
+			Title: "Materials consumed", Header: []string{"Material", "Quantity", "Tasks"},
+			Widths: []float64{265, 150, 100}, Rows: materialRows, Empty: "No materials consumed.",
+		},
+	)
+
+	date, _ := time.ParseInLocation("2006-01-02", dailyLog.Date, time.Local)
+
+	return renderReportSectionsPDF(dailyLog.FarmName, "Daily log of "+date.Format("Monday, 2 January 2006"),
+		sections, []string{"Recorded by", "Checked by"})
+}
@@ -0,0 +1,432 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/config"
+	assetsquery "github.com/usetania/tania-core/src/assets/query"
+	assetsqueryInMem "github.com/usetania/tania-core/src/assets/query/inmemory"
+	assetsqueryMysql "github.com/usetania/tania-core/src/assets/query/mysql"
+	assetsqueryRedis "github.com/usetania/tania-core/src/assets/query/redis"
+	assetsquerySqlite "github.com/usetania/tania-core/src/assets/query/sqlite"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/auth"
+	"github.com/usetania/tania-core/src/changefeed"
+	"github.com/usetania/tania-core/src/customfield"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+	"github.com/usetania/tania-core/src/dashboard/storage"
+	"github.com/usetania/tania-core/src/eventbus"
+	"github.com/usetania/tania-core/src/farmimport"
+	"github.com/usetania/tania-core/src/farmscope"
+	growthquery "github.com/usetania/tania-core/src/growth/query"
+	growthqueryInMem "github.com/usetania/tania-core/src/growth/query/inmemory"
+	growthqueryMysql "github.com/usetania/tania-core/src/growth/query/mysql"
+	growthquerySqlite "github.com/usetania/tania-core/src/growth/query/sqlite"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/notesearch"
+	"github.com/usetania/tania-core/src/presence"
+	"github.com/usetania/tania-core/src/reportmail"
+	redisstorage "github.com/usetania/tania-core/src/storage/redis"
+	tasksquery "github.com/usetania/tania-core/src/tasks/query"
+	tasksqueryInMem "github.com/usetania/tania-core/src/tasks/query/inmemory"
+	tasksqueryMysql "github.com/usetania/tania-core/src/tasks/query/mysql"
+	tasksquerySqlite "github.com/usetania/tania-core/src/tasks/query/sqlite"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+	userquery "github.com/usetania/tania-core/src/user/query"
+	userqueryMysql "github.com/usetania/tania-core/src/user/query/mysql"
+	userquerySqlite "github.com/usetania/tania-core/src/user/query/sqlite"
+)
+
+// DashboardServer ties the routes and handlers with injected dependencies.
+type DashboardServer struct {
+	FarmReadQuery              assetsquery.FarmRead
+	AreaReadQuery              assetsquery.AreaRead
+	ReservoirReadQuery         assetsquery.ReservoirRead
+	MaterialReadQuery          assetsquery.MaterialRead
+	MaterialEventQuery         assetsquery.MaterialEvent
+	FarmCertificationReadQuery assetsquery.FarmCertificationRead
+	CropReadQuery              growthquery.CropReadQuery
+	CropActivityQuery          growthquery.CropActivityQuery
+	MicroclimateSampleQuery    growthquery.MicroclimateSampleQuery
+	TaskReadQuery              tasksquery.TaskRead
+	UserReadQuery              userquery.UserRead
+	StatsStorage               *storage.StatsStorage
+	EventBus                   eventbus.TaniaEventBus
+	FarmScope                  farmscope.Scope
+	ReportScheduler            *reportmail.Scheduler
+	ChangeFeedStore            changefeed.Store
+	CustomFieldStore           customfield.Store
+	ImportStore                farmimport.Store
+	NoteSearchStore            notesearch.Store
+	PresenceRegistry           *presence.Registry
+
+	// Redactor gives the role the exports and the notes search hide the redacted task fields from,
+	// nothing is hidden without it.
+	Redactor *auth.Redactor
+
+	// MaxUploadSize is the largest photo of an import archive, in bytes.
+	MaxUploadSize int64
+
+	plannedTasks *plannedTasks
+}
+
+// NewDashboardServer initializes DashboardServer's dependencies and create new DashboardServer struct.
+// It has to be created after the other servers, so their read models are
+// already updated when the dashboard subscribers receive an event.
+func NewDashboardServer(
+	db *sql.DB,
+	archiveDB *sql.DB,
+	bus eventbus.TaniaEventBus,
+	farmReadStorage *assetsstorage.FarmReadStorage,
+	areaReadStorage *assetsstorage.AreaReadStorage,
+	reservoirReadStorage *assetsstorage.ReservoirReadStorage,
+	materialReadStorage *assetsstorage.MaterialReadStorage,
+	materialEventStorage *assetsstorage.MaterialEventStorage,
+	farmCertificationReadStorage *assetsstorage.FarmCertificationReadStorage,
+	cropReadStorage *growthstorage.CropReadStorage,
+	cropActivityStorage *growthstorage.CropActivityStorage,
+	microclimateSampleStorage *growthstorage.MicroclimateSampleStorage,
+	taskReadStorage *taskstorage.TaskReadStorage,
+	taskArchiveStorage *taskstorage.TaskArchiveStorage,
+	customFieldValueStorage *customfield.ValueStorage,
+	customFieldDefinitionReadStorage *assetsstorage.CustomFieldDefinitionReadStorage,
+	reportMailStorage *reportmail.ReportMailStorage,
+	mailer reportmail.Mailer,
+	changeFeedStore changefeed.Store,
+	importStore farmimport.Store,
+	presenceRegistry *presence.Registry,
+) (*DashboardServer, error) {
+	bus, err := eventbus.ForTenant(bus)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboardServer := &DashboardServer{
+		StatsStorage:     storage.CreateStatsStorage(),
+		EventBus:         bus,
+		FarmScope:        farmscope.NewScope(Error),
+		ChangeFeedStore:  changeFeedStore,
+		ImportStore:      importStore,
+		PresenceRegistry: presenceRegistry,
+		plannedTasks:     newPlannedTasks(),
+	}
+
+	var reportMailStore reportmail.Store
+
+	switch *config.Config.TaniaPersistenceEngine {
+	case config.DBInmemory:
+		dashboardServer.FarmReadQuery = assetsqueryInMem.NewFarmReadQueryInMemory(farmReadStorage)
+		dashboardServer.AreaReadQuery = assetsqueryInMem.NewAreaReadQueryInMemory(areaReadStorage)
+		dashboardServer.ReservoirReadQuery = assetsqueryInMem.NewReservoirReadQueryInMemory(reservoirReadStorage)
+		dashboardServer.MaterialReadQuery = assetsqueryInMem.NewMaterialReadQueryInMemory(materialReadStorage)
+		dashboardServer.MaterialEventQuery = assetsqueryInMem.NewMaterialEventQueryInMemory(materialEventStorage)
+		dashboardServer.FarmCertificationReadQuery = assetsqueryInMem.NewFarmCertificationReadQueryInMemory(farmCertificationReadStorage)
+		dashboardServer.CropReadQuery = growthqueryInMem.NewCropReadQueryInMemory(cropReadStorage)
+		dashboardServer.CropActivityQuery = growthqueryInMem.NewCropActivityQueryInMemory(cropActivityStorage)
+		dashboardServer.MicroclimateSampleQuery = growthqueryInMem.NewMicroclimateSampleQueryInMemory(
+			microclimateSampleStorage)
+		dashboardServer.TaskReadQuery = tasksqueryInMem.NewTaskReadQueryInMemory(taskReadStorage)
+		dashboardServer.CustomFieldStore = customfield.NewStoreInMemory(customFieldValueStorage,
+			customFieldDefinitionReadStorage)
+		dashboardServer.NoteSearchStore = notesearch.NewStoreInMemory(areaReadStorage, reservoirReadStorage,
+			cropReadStorage, taskReadStorage, taskArchiveStorage)
+
+		reportMailStore = reportmail.NewStoreInMemory(reportMailStorage)
+
+	case config.DBSqlite:
+		dashboardServer.FarmReadQuery = assetsquerySqlite.NewFarmReadQuerySqlite(db)
+		dashboardServer.AreaReadQuery = assetsquerySqlite.NewAreaReadQuerySqlite(db)
+		dashboardServer.ReservoirReadQuery = assetsquerySqlite.NewReservoirReadQuerySqlite(db)
+		dashboardServer.MaterialReadQuery = assetsquerySqlite.NewMaterialReadQuerySqlite(db)
+		dashboardServer.MaterialEventQuery = assetsquerySqlite.NewMaterialEventQuerySqlite(db)
+		dashboardServer.FarmCertificationReadQuery = assetsquerySqlite.NewFarmCertificationReadQuerySqlite(db)
+		dashboardServer.CropReadQuery = growthquerySqlite.NewCropReadQuerySqlite(db)
+		dashboardServer.CropActivityQuery = growthquerySqlite.NewCropActivityQuerySqlite(db)
+		dashboardServer.MicroclimateSampleQuery = growthquerySqlite.NewMicroclimateSampleQuerySqlite(db)
+		dashboardServer.TaskReadQuery = tasksquerySqlite.NewTaskReadQuerySqlite(db)
+		dashboardServer.UserReadQuery = userquerySqlite.NewUserReadQuerySqlite(db)
+		dashboardServer.CustomFieldStore = customfield.NewStoreSqlite(db)
+		dashboardServer.NoteSearchStore = notesearch.NewStoreSqlite(db, archiveDB)
+
+		reportMailStore = reportmail.NewStoreSqlite(db)
+
+	case config.DBMysql:
+		dashboardServer.FarmReadQuery = assetsqueryMysql.NewFarmReadQueryMysql(db)
+		dashboardServer.AreaReadQuery = assetsqueryMysql.NewAreaReadQueryMysql(db)
+		dashboardServer.ReservoirReadQuery = assetsqueryMysql.NewReservoirReadQueryMysql(db)
+		dashboardServer.MaterialReadQuery = assetsqueryMysql.NewMaterialReadQueryMysql(db)
+		dashboardServer.MaterialEventQuery = assetsqueryMysql.NewMaterialEventQueryMysql(db)
+		dashboardServer.FarmCertificationReadQuery = assetsqueryMysql.NewFarmCertificationReadQueryMysql(db)
+		dashboardServer.CropReadQuery = growthqueryMysql.NewCropReadQueryMysql(db)
+		dashboardServer.CropActivityQuery = growthqueryMysql.NewCropActivityQueryMysql(db)
+		dashboardServer.MicroclimateSampleQuery = growthqueryMysql.NewMicroclimateSampleQueryMysql(db)
+		dashboardServer.TaskReadQuery = tasksqueryMysql.NewTaskReadQueryMysql(db)
+		dashboardServer.UserReadQuery = userqueryMysql.NewUserReadQueryMysql(db)
+		dashboardServer.CustomFieldStore = customfield.NewStoreMysql(db)
+		dashboardServer.NoteSearchStore = notesearch.NewStoreMysql(db, archiveDB)
+
+		reportMailStore = reportmail.NewStoreMysql(db)
+	}
+
+	// The dashboard server renders the mailed reports.
+	dashboardServer.ReportScheduler = reportmail.NewScheduler(reportMailStore, dashboardServer, mailer)
+
+	err = dashboardServer.RebuildStats()
+	if err != nil {
+		return nil, err
+	}
+
+	dashboardServer.InitSubscriber()
+
+	return dashboardServer, nil
+}
+
+// UseEventStreams reads the events of the materials from the Redis streams instead of the in-memory storage.
+func (s *DashboardServer) UseEventStreams(streams *redisstorage.RedisStreamsEventStorage) {
+	s.MaterialEventQuery = assetsqueryRedis.NewMaterialEventQueryRedis(streams)
+}
+
+// InitSubscriber defines the mapping of which event this domain listen with their handler.
+func (s *DashboardServer) InitSubscriber() {
+	s.EventBus.Subscribe("CropBatchCreated", s.UpdateCropStats)
+	s.EventBus.Subscribe("CropBatchContainerChanged", s.UpdateCropStats)
+	s.EventBus.Subscribe("CropBatchMoved", s.UpdateCropStats)
+	s.EventBus.Subscribe("CropBatchHarvested", s.UpdateCropStats)
+	s.EventBus.Subscribe("CropBatchDumped", s.UpdateCropStats)
+
+	s.EventBus.Subscribe("TaskCreated", s.UpdateTaskStats)
+	s.EventBus.Subscribe("TaskCompleted", s.UpdateTaskStats)
+	s.EventBus.Subscribe("TaskCancelled", s.UpdateTaskStats)
+	s.EventBus.Subscribe("TaskDue", s.UpdateTaskStats)
+
+	s.EventBus.Subscribe("MaterialCreated", s.UpdateMaterialStats)
+	s.EventBus.Subscribe("MaterialQuantityChanged", s.UpdateMaterialStats)
+	s.EventBus.Subscribe("MaterialStockCorrected", s.UpdateMaterialStats)
+	s.EventBus.Subscribe("MaterialStockAdded", s.UpdateMaterialStats)
+	s.EventBus.Subscribe("MaterialStockDeducted", s.UpdateMaterialStats)
+}
+
+// Mount defines the DashboardServer's endpoints with its handlers.
+func (s *DashboardServer) Mount(g *echo.Group) {
+	g.GET("/:id/dashboard", s.GetFarmDashboard, s.farmScope("id"))
+	g.GET("/:id/worksheet", s.GetWorksheet, s.farmScope("id"))
+	g.GET("/:id/daily_log", s.GetDailyLog, s.farmScope("id"))
+	g.GET("/:id/workload", s.GetWorkload, s.farmScope("id"))
+	g.GET("/:id/notes/search", s.SearchNotes, s.farmScope("id"))
+	g.GET("/:id/tasks/dependency-graph", s.GetTaskDependencyGraph, s.farmScope("id"))
+	g.GET("/:id/sync", s.GetFarmSync, s.farmScope("id"))
+	g.GET("/:id/export", s.GetFarmExport, s.farmScope("id"))
+	g.POST("/:id/presence", s.SavePresenceHeartbeat, s.farmScope("id"))
+	g.GET("/:id/presence", s.FindFarmPresence, s.farmScope("id"))
+	g.DELETE("/:id/presence/gateways/:device_id", s.RemovePresenceGateway, s.farmScope("id"))
+	g.GET("/:id/reports/cost-centre", s.GetCostCentreReport, s.farmScope("id"))
+	g.GET("/:id/reports/tasks", s.GetTaskReport, s.farmScope("id"))
+	g.GET("/:id/reports/utilization", s.GetUtilizationReport, s.farmScope("id"))
+	g.GET("/:id/areas/productivity-comparison", s.GetAreaProductivityComparison, s.farmScope("id"))
+	g.GET("/:id/crops/costs", s.GetFarmBatchCostReport, s.farmScope("id"))
+	g.GET("/:id/crops/:crop_id/costs", s.GetCropBatchCost, s.cropScope("crop_id", "id"))
+	g.POST("/:id/report_subscriptions", s.SaveReportSubscription, s.farmScope("id"))
+	g.GET("/:id/report_subscriptions", s.FindAllReportSubscriptions, s.farmScope("id"))
+	g.DELETE("/:id/report_subscriptions/:subscription_id", s.RemoveReportSubscription, s.farmScope("id"))
+	g.POST("/:id/report_subscriptions/:subscription_id/run", s.RunReportSubscription, s.farmScope("id"))
+	g.GET("/:id/report_subscriptions/:subscription_id/deliveries", s.FindReportSubscriptionDeliveries, s.farmScope("id"))
+}
+
+// farmScope checks the farm of the param is one of the user's before the handler runs.
+func (s *DashboardServer) farmScope(param string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity("", func(c echo.Context) (uuid.UUID, error) {
+		farmUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		result := <-s.FarmReadQuery.FindByID(farmUID)
+		if result.Error != nil {
+			return uuid.Nil, result.Error
+		}
+
+		farm, ok := result.Result.(assetsstorage.FarmRead)
+		if !ok {
+			return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		return farm.UID, nil
+	})
+}
+
+// MountImport defines the DashboardServer's import endpoints with its handlers.
+func (s *DashboardServer) MountImport(g *echo.Group) {
+	g.POST("", s.ImportFarm)
+}
+
+// MountAdmin defines the DashboardServer's admin endpoints with its handlers.
+func (s *DashboardServer) MountAdmin(g *echo.Group) {
+	g.POST("/dashboard/consistency_check", s.CheckStatsConsistency)
+}
+
+func (s *DashboardServer) GetFarmDashboard(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	result := <-s.FarmReadQuery.FindByID(farmUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	farm, ok := result.Result.(assetsstorage.FarmRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	if farm.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	dashboard, err := s.findFarmDashboard(farmUID, time.Now())
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]Dashboard)
+	data["data"] = dashboard
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *DashboardServer) findFarmDashboard(farmUID uuid.UUID, now time.Time) (Dashboard, error) {
+	result := <-s.FarmCertificationReadQuery.FindAllByFarm(farmUID)
+	if result.Error != nil {
+		return Dashboard{}, result.Error
+	}
+
+	certifications, ok := result.Result.([]assetsstorage.FarmCertificationRead)
+	if !ok {
+		return Dashboard{}, errors.New("internal server error. error type assertion")
+	}
+
+	s.StatsStorage.Lock.RLock()
+	dashboard := MapToDashboard(farmUID, s.StatsStorage.Stats)
+	s.StatsStorage.Lock.RUnlock()
+
+	dashboard.ExpiredCertifications = expiredCertifications(certifications, now)
+	dashboard.StaleGateways = s.PresenceRegistry.StaleGateways(farmUID, now, staleGatewayThreshold())
+
+	return dashboard, nil
+}
+
+// CheckStatsConsistency recomputes the counters from scratch, logs the drift and replaces the counters.
+func (s *DashboardServer) CheckStatsConsistency(c echo.Context) error {
+	drifts, err := s.CheckConsistency()
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string][]Drift)
+	data["data"] = drifts
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// RebuildStats regenerates all the counters from the other read models.
+func (s *DashboardServer) RebuildStats() error {
+	stats, err := s.computeStats()
+	if err != nil {
+		return err
+	}
+
+	s.StatsStorage.Lock.Lock()
+	s.StatsStorage.Stats = stats
+	s.StatsStorage.Lock.Unlock()
+
+	return nil
+}
+
+func (s *DashboardServer) computeStats() (domain.Stats, error) {
+	stats := domain.NewStats()
+
+	farmResult := <-s.FarmReadQuery.FindAll()
+	if farmResult.Error != nil {
+		return stats, farmResult.Error
+	}
+
+	farms, ok := farmResult.Result.([]assetsstorage.FarmRead)
+	if !ok {
+		return stats, errors.New("internal server error. error type assertion")
+	}
+
+	for _, farm := range farms {
+		crops, err := s.findAllCropsByFarm(farm.UID)
+		if err != nil {
+			return stats, err
+		}
+
+		for _, crop := range crops {
+			stats.SetCrop(crop.UID, cropContribution(crop))
+		}
+	}
+
+	taskResult := <-s.TaskReadQuery.FindAll(0, 0)
+	if taskResult.Error != nil {
+		return stats, taskResult.Error
+	}
+
+	tasks, ok := taskResult.Result.([]taskstorage.TaskRead)
+	if !ok {
+		return stats, errors.New("internal server error. error type assertion")
+	}
+
+	for _, task := range tasks {
+		stats.SetTask(task.UID, taskContribution(task))
+	}
+
+	materialResult := <-s.MaterialReadQuery.FindAll("", "", 0, 0)
+	if materialResult.Error != nil {
+		return stats, materialResult.Error
+	}
+
+	materials, ok := materialResult.Result.([]assetsstorage.MaterialRead)
+	if !ok {
+		return stats, errors.New("internal server error. error type assertion")
+	}
+
+	for _, material := range materials {
+		stats.SetMaterial(material.UID, materialContribution(material))
+	}
+
+	return stats, nil
+}
+
+func (s *DashboardServer) findAllCropsByFarm(farmUID uuid.UUID) ([]growthstorage.CropRead, error) {
+	result := <-s.CropReadQuery.CountAllCropsByFarm(farmUID, "")
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	total, ok := result.Result.(int)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	if total == 0 {
+		return []growthstorage.CropRead{}, nil
+	}
+
+	result = <-s.CropReadQuery.FindAllCropsByFarm(farmUID, "", 1, total)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	crops, ok := result.Result.([]growthstorage.CropRead)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	return crops, nil
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"errors"
+	"log"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/config"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+func (s *DashboardServer) UpdateCropStats(event interface{}) error {
+	var cropUID uuid.UUID
+
+	switch e := event.(type) {
+	case growthdomain.CropBatchCreated:
+		cropUID = e.UID
+	case growthdomain.CropBatchContainerChanged:
+		cropUID = e.UID
+	case growthdomain.CropBatchMoved:
+		cropUID = e.UID
+	case growthdomain.CropBatchHarvested:
+		cropUID = e.UID
+	case growthdomain.CropBatchDumped:
+		cropUID = e.UID
+	default:
+		return errors.New("unknown crop event")
+	}
+
+	result := <-s.CropReadQuery.FindByID(cropUID)
+	if result.Error != nil {
+		log.Println(result.Error)
+
+		return result.Error
+	}
+
+	crop, ok := result.Result.(growthstorage.CropRead)
+	if !ok {
+		return errors.New("internal server error. error type assertion")
+	}
+
+	s.StatsStorage.Lock.Lock()
+	s.StatsStorage.Stats.SetCrop(crop.UID, cropContribution(crop))
+	s.StatsStorage.Lock.Unlock()
+
+	return nil
+}
+
+func (s *DashboardServer) UpdateTaskStats(event interface{}) error {
+	var taskUID uuid.UUID
+
+	switch e := event.(type) {
+	case tasksdomain.TaskCreated:
+		taskUID = e.UID
+	case tasksdomain.TaskCompleted:
+		taskUID = e.UID
+	case tasksdomain.TaskCancelled:
+		taskUID = e.UID
+	case tasksdomain.TaskDue:
+		taskUID = e.UID
+	default:
+		return errors.New("unknown task event")
+	}
+
+	result := <-s.TaskReadQuery.FindByID(taskUID)
+	if result.Error != nil {
+		log.Println(result.Error)
+
+		return result.Error
+	}
+
+	task, ok := result.Result.(taskstorage.TaskRead)
+	if !ok {
+		return errors.New("internal server error. error type assertion")
+	}
+
+	s.StatsStorage.Lock.Lock()
+	s.StatsStorage.Stats.SetTask(task.UID, taskContribution(task))
+	s.StatsStorage.Lock.Unlock()
+
+	return nil
+}
+
+func (s *DashboardServer) UpdateMaterialStats(event interface{}) error {
+	var materialUID uuid.UUID
+
+	switch e := event.(type) {
+	case assetsdomain.MaterialCreated:
+		materialUID = e.UID
+	case assetsdomain.MaterialQuantityChanged:
+		materialUID = e.MaterialUID
+	case assetsdomain.MaterialStockCorrected:
+		materialUID = e.MaterialUID
+	case assetsdomain.MaterialStockDeducted:
+		materialUID = e.MaterialUID
+	case assetsdomain.MaterialStockAdded:
+		materialUID = e.MaterialUID
+	default:
+		return errors.New("unknown material event")
+	}
+
+	result := <-s.MaterialReadQuery.FindByID(materialUID)
+	if result.Error != nil {
+		log.Println(result.Error)
+
+		return result.Error
+	}
+
+	material, ok := result.Result.(assetsstorage.MaterialRead)
+	if !ok {
+		return errors.New("internal server error. error type assertion")
+	}
+
+	s.StatsStorage.Lock.Lock()
+	s.StatsStorage.Stats.SetMaterial(material.UID, materialContribution(material))
+	s.StatsStorage.Lock.Unlock()
+
+	return nil
+}
+
+func cropContribution(crop growthstorage.CropRead) domain.CropContribution {
+	plants := crop.InitialArea.CurrentQuantity
+	for _, v := range crop.MovedArea {
+		plants += v.CurrentQuantity
+	}
+
+	return domain.CropContribution{
+		FarmUID: crop.FarmUID,
+		Active:  crop.Status != growthdomain.CropArchived,
+		Plants:  plants,
+	}
+}
+
+func taskContribution(task taskstorage.TaskRead) domain.TaskContribution {
+	open := task.Status == tasksdomain.TaskStatusCreated
+
+	return domain.TaskContribution{
+		Open:    open,
+		Overdue: open && task.IsDue,
+	}
+}
+
+func materialContribution(material assetsstorage.MaterialRead) domain.MaterialContribution {
+	return domain.MaterialContribution{
+		LowStock: float64(material.Quantity.Value) <= *config.Config.LowStockThreshold,
+	}
+}
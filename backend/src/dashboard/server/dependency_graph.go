@@ -0,0 +1,99 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+// DependencyGraph is the graph of the task dependencies, in the nodes and edges lists Cytoscape.js
+// and Vis.js load.
+type DependencyGraph struct {
+	Nodes []DependencyNode `json:"nodes"`
+	Edges []DependencyEdge `json:"edges"`
+}
+
+type DependencyNode struct {
+	ID         uuid.UUID `json:"id"`
+	Title      string    `json:"title"`
+	Status     string    `json:"status"`
+	Priority   string    `json:"priority"`
+	IsCritical bool      `json:"is_critical"`
+}
+
+// DependencyEdge goes from the task depended on to the task depending on it.
+type DependencyEdge struct {
+	From uuid.UUID `json:"from"`
+	To   uuid.UUID `json:"to"`
+}
+
+// GetTaskDependencyGraph returns the open tasks of the farm with their dependencies. With `include_completed=true`
+// the completed and cancelled tasks are added, with their past dependencies. The critical path, the chain of
+// dependent open tasks taking the most minutes, is flagged on its nodes.
+func (s *DashboardServer) GetTaskDependencyGraph(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	tasks, err := s.findAllExportTasks(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	includeCompleted := c.QueryParam("include_completed") == "true"
+
+	graph := DependencyGraph{Nodes: []DependencyNode{}, Edges: []DependencyEdge{}}
+	included := map[uuid.UUID]bool{}
+	open := []tasksdomain.TaskDependencyNode{}
+
+	for _, v := range tasks {
+		closed := v.Status == tasksdomain.TaskStatusCompleted || v.Status == tasksdomain.TaskStatusCancelled
+		if closed && !includeCompleted {
+			continue
+		}
+
+		included[v.UID] = true
+
+		if !closed {
+			open = append(open, tasksdomain.TaskDependencyNode{
+				UID:       v.UID,
+				DependsOn: v.DependsOn,
+				Minutes:   domain.EffortMinutes(v.EstimatedMinutes, defaultEffortMinutes()),
+			})
+		}
+	}
+
+	critical := map[uuid.UUID]bool{}
+	for _, v := range tasksdomain.CriticalPath(open) {
+		critical[v] = true
+	}
+
+	for _, v := range tasks {
+		if !included[v.UID] {
+			continue
+		}
+
+		graph.Nodes = append(graph.Nodes, DependencyNode{
+			ID:         v.UID,
+			Title:      v.Title,
+			Status:     v.Status,
+			Priority:   v.Priority,
+			IsCritical: critical[v.UID],
+		})
+
+		for _, dependency := range v.DependsOn {
+			if included[dependency] {
+				graph.Edges = append(graph.Edges, DependencyEdge{From: dependency, To: v.UID})
+			}
+		}
+	}
+
+	data := make(map[string]DependencyGraph)
+	data["data"] = graph
+
+	return c.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,464 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/config"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/auth"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/helper/imagehelper"
+	"github.com/usetania/tania-core/src/helper/stringhelper"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// ExportSchemaVersion is the version of the layout of the farm export archives, bumped when it changes.
+// The version 2 added the material types.
+const ExportSchemaVersion = 2
+
+// The files of the farm export archive. The photos are under ExportPhotoFolder.
+const (
+	ExportManifestFile = "manifest.json"
+	ExportFarmFile     = "farm.json"
+	ExportPhotoFolder  = "photos"
+)
+
+// ExportManifest describes the export archive, the photo files in it and the count of each record.
+type ExportManifest struct {
+	SchemaVersion int            `json:"schema_version"`
+	FarmUID       uuid.UUID      `json:"farm_id"`
+	Anonymized    bool           `json:"anonymized"`
+	ExportedDate  time.Time      `json:"exported_date"`
+	Counts        map[string]int `json:"counts"`
+	Photos        []ExportPhoto  `json:"photos"`
+}
+
+// ExportPhoto is a photo file of the archive, of the area or the crop of the entity uid.
+// The photo uid is only set for the crop photos.
+type ExportPhoto struct {
+	EntityType string     `json:"entity_type"`
+	EntityUID  uuid.UUID  `json:"entity_id"`
+	PhotoUID   *uuid.UUID `json:"photo_id"`
+	Path       string     `json:"path"`
+	MimeType   string     `json:"mime_type"`
+}
+
+// FarmExport is the farm with all its records, as they are in the read models.
+type FarmExport struct {
+	Farm              assetsstorage.FarmRead                `json:"farm"`
+	Certifications    []assetsstorage.FarmCertificationRead `json:"certifications"`
+	Reservoirs        []assetsstorage.ReservoirRead         `json:"reservoirs"`
+	Areas             []assetsstorage.AreaRead              `json:"areas"`
+	Materials         []assetsstorage.MaterialRead          `json:"materials"`
+	MaterialTypes     []ExportMaterialType                  `json:"material_types"`
+	Crops             []growthstorage.CropRead              `json:"crops"`
+	Tasks             []taskstorage.TaskRead                `json:"tasks"`
+	CustomFieldValues []ExportCustomFieldValues             `json:"custom_field_values"`
+}
+
+// ExportMaterialType is the type code of a material, which the type of the material record doesn't encode.
+type ExportMaterialType struct {
+	MaterialUID uuid.UUID `json:"material_id"`
+	Code        string    `json:"code"`
+}
+
+// ExportCustomFieldValues are the values of the custom fields of a crop, a material or a task.
+// The areas keep theirs in the area record.
+type ExportCustomFieldValues struct {
+	EntityType  string                 `json:"entity_type"`
+	EntityUID   uuid.UUID              `json:"entity_id"`
+	Values      map[string]interface{} `json:"values"`
+	UpdatedDate time.Time              `json:"updated_date"`
+}
+
+// GetFarmExport returns the zip archive of the farm with its records and photos. With anonymize=true, the names,
+// notes and other texts are replaced by pseudonyms and the photos by placeholders, keeping the quantities,
+// dates and references, so it can be shared with the developers.
+func (s *DashboardServer) GetFarmExport(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	anonymize := false
+
+	if value := c.QueryParam("anonymize"); value != "" {
+		anonymize, err = strconv.ParseBool(value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "anonymize"))
+		}
+	}
+
+	export, err := s.findFarmExport(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if export.Farm.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	if s.Redactor != nil {
+		tasks, ok := auth.Redact(export.Tasks, s.Redactor.RoleOf(c)).([]taskstorage.TaskRead)
+		if !ok {
+			return Error(c, errors.New("internal server error. error type assertion"))
+		}
+
+		export.Tasks = tasks
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, "application/zip")
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf(`attachment; filename="farm-%s.zip"`, farmUID))
+	c.Response().WriteHeader(http.StatusOK)
+
+	// The archive is streamed, an error past this point can only cut it short.
+	return WriteFarmExport(c.Response(), export, anonymize, time.Now())
+}
+
+// WriteFarmExport writes the zip archive of the farm export, anonymized or not.
+func WriteFarmExport(w io.Writer, export FarmExport, anonymize bool, now time.Time) error {
+	archive := zip.NewWriter(w)
+
+	manifest := ExportManifest{
+		SchemaVersion: ExportSchemaVersion,
+		FarmUID:       export.Farm.UID,
+		Anonymized:    anonymize,
+		ExportedDate:  now,
+		Counts: map[string]int{
+			"certifications":      len(export.Certifications),
+			"reservoirs":          len(export.Reservoirs),
+			"areas":               len(export.Areas),
+			"area_sanitations":    countAreaSanitations(export.Areas),
+			"materials":           len(export.Materials),
+			"crops":               len(export.Crops),
+			"tasks":               len(export.Tasks),
+			"custom_field_values": len(export.CustomFieldValues),
+		},
+		Photos: []ExportPhoto{},
+	}
+
+	for _, v := range export.Areas {
+		if v.Photo.Filename == "" {
+			continue
+		}
+
+		photo, ok, err := writeExportPhoto(archive, ExportPhoto{
+			EntityType: assetsdomain.CustomFieldEntityArea,
+			EntityUID:  v.UID,
+			Path:       path.Join(ExportPhotoFolder, "areas", v.UID.String()),
+			MimeType:   v.Photo.MimeType,
+		}, stringhelper.Join(*config.Config.UploadPathArea, "/", v.Photo.Filename), v.Photo.Width, v.Photo.Height,
+			anonymize)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			manifest.Photos = append(manifest.Photos, photo)
+		}
+	}
+
+	for _, v := range export.Crops {
+		for _, p := range v.Photos {
+			photoUID := p.UID
+
+			photo, ok, err := writeExportPhoto(archive, ExportPhoto{
+				EntityType: assetsdomain.CustomFieldEntityCrop,
+				EntityUID:  v.UID,
+				PhotoUID:   &photoUID,
+				Path:       path.Join(ExportPhotoFolder, "crops", v.UID.String(), p.UID.String()),
+				MimeType:   p.MimeType,
+			}, stringhelper.Join(*config.Config.UploadPathCrop, "/", p.Filename), p.Width, p.Height, anonymize)
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				manifest.Photos = append(manifest.Photos, photo)
+			}
+		}
+	}
+
+	var farm interface{} = export
+
+	if anonymize {
+		anonymized, err := anonymizeFarmExport(export)
+		if err != nil {
+			return err
+		}
+
+		farm = anonymized
+	}
+
+	if err := writeExportJSON(archive, ExportFarmFile, farm); err != nil {
+		return err
+	}
+
+	if err := writeExportJSON(archive, ExportManifestFile, manifest); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+func countAreaSanitations(areas []assetsstorage.AreaRead) int {
+	total := 0
+	for _, v := range areas {
+		total += len(v.Sanitations)
+	}
+
+	return total
+}
+
+// anonymizeFarmExport runs the export through the anonymizer, as decoded JSON so every field of the read models
+// is walked, including the ones added to them later.
+func anonymizeFarmExport(export FarmExport) (interface{}, error) {
+	raw, err := json.Marshal(export)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var decoded interface{}
+
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	anonymized := domain.NewAnonymizer().Anonymize("", decoded)
+
+	return anonymized, nil
+}
+
+// writeExportPhoto adds the photo file, or its placeholder when anonymized, to the archive with its extension.
+// A photo whose file is missing, like an upload which failed, is left out of the archive, false.
+func writeExportPhoto(archive *zip.Writer, photo ExportPhoto, srcPath string, width, height int, anonymize bool) (
+	ExportPhoto, bool, error,
+) {
+	var (
+		content []byte
+		err     error
+	)
+
+	if anonymize {
+		content, photo.MimeType, err = imagehelper.Placeholder(width, height, photo.MimeType)
+		if err != nil {
+			return ExportPhoto{}, false, err
+		}
+	} else {
+		content, err = os.ReadFile(srcPath)
+		if errors.Is(err, os.ErrNotExist) {
+			return ExportPhoto{}, false, nil
+		}
+
+		if err != nil {
+			return ExportPhoto{}, false, err
+		}
+	}
+
+	photo.Path += photoExtension(photo.MimeType)
+
+	file, err := archive.Create(photo.Path)
+	if err != nil {
+		return ExportPhoto{}, false, err
+	}
+
+	_, err = file.Write(content)
+
+	return photo, err == nil, err
+}
+
+func photoExtension(mimeType string) string {
+	switch mimeType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	}
+
+	return ""
+}
+
+func writeExportJSON(archive *zip.Writer, name string, value interface{}) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
+
+// findFarmExport finds the farm with its records. The materials aren't owned by a farm, so they're all exported.
+func (s *DashboardServer) findFarmExport(farmUID uuid.UUID) (FarmExport, error) {
+	farm, err := s.findSyncFarm(farmUID)
+	if err != nil || farm.UID == (uuid.UUID{}) {
+		return FarmExport{}, err
+	}
+
+	export := FarmExport{Farm: farm, CustomFieldValues: []ExportCustomFieldValues{}}
+
+	result := <-s.FarmCertificationReadQuery.FindAllByFarm(farmUID)
+	if result.Error != nil {
+		return FarmExport{}, result.Error
+	}
+
+	export.Certifications, _ = result.Result.([]assetsstorage.FarmCertificationRead)
+
+	result = <-s.ReservoirReadQuery.FindAllByFarm(farmUID)
+	if result.Error != nil {
+		return FarmExport{}, result.Error
+	}
+
+	export.Reservoirs, _ = result.Result.([]assetsstorage.ReservoirRead)
+
+	result = <-s.AreaReadQuery.FindAllByFarm(farmUID)
+	if result.Error != nil {
+		return FarmExport{}, result.Error
+	}
+
+	export.Areas, _ = result.Result.([]assetsstorage.AreaRead)
+
+	result = <-s.MaterialReadQuery.FindAll("", "", 0, 0)
+	if result.Error != nil {
+		return FarmExport{}, result.Error
+	}
+
+	export.Materials, _ = result.Result.([]assetsstorage.MaterialRead)
+	export.MaterialTypes = []ExportMaterialType{}
+
+	for _, v := range export.Materials {
+		if v.Type != nil {
+			export.MaterialTypes = append(export.MaterialTypes, ExportMaterialType{MaterialUID: v.UID, Code: v.Type.Code()})
+		}
+	}
+
+	export.Crops, err = s.findAllExportCrops(farmUID)
+	if err != nil {
+		return FarmExport{}, err
+	}
+
+	export.Tasks, err = s.findAllExportTasks(farmUID)
+	if err != nil {
+		return FarmExport{}, err
+	}
+
+	for _, entityType := range []string{
+		assetsdomain.CustomFieldEntityCrop, assetsdomain.CustomFieldEntityMaterial, assetsdomain.CustomFieldEntityTask,
+	} {
+		values, err := s.CustomFieldStore.FindAllValues(farmUID, entityType)
+		if err != nil {
+			return FarmExport{}, err
+		}
+
+		for _, v := range values {
+			export.CustomFieldValues = append(export.CustomFieldValues, ExportCustomFieldValues{
+				EntityType:  v.EntityType,
+				EntityUID:   v.EntityUID,
+				Values:      v.Values,
+				UpdatedDate: v.UpdatedDate,
+			})
+		}
+	}
+
+	return export, nil
+}
+
+// findAllExportCrops finds the crops of the farm, the archived ones included.
+func (s *DashboardServer) findAllExportCrops(farmUID uuid.UUID) ([]growthstorage.CropRead, error) {
+	crops, err := s.findAllCropsByFarm(farmUID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := <-s.CropReadQuery.CountAllArchivedCropsByFarm(farmUID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	total, ok := result.Result.(int)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	if total == 0 {
+		return crops, nil
+	}
+
+	result = <-s.CropReadQuery.FindAllCropsArchives(farmUID, 1, total)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	archives, ok := result.Result.([]growthstorage.CropRead)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	found := map[uuid.UUID]bool{}
+	for _, v := range crops {
+		found[v.UID] = true
+	}
+
+	for _, v := range archives {
+		if !found[v.UID] {
+			crops = append(crops, v)
+		}
+	}
+
+	return crops, nil
+}
+
+// findAllExportTasks finds the tasks on the assets of the farm and the ones without asset.
+func (s *DashboardServer) findAllExportTasks(farmUID uuid.UUID) ([]taskstorage.TaskRead, error) {
+	result := <-s.TaskReadQuery.FindAll(0, 0)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	tasks, ok := result.Result.([]taskstorage.TaskRead)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	areaNames, err := s.findAreaNames(farmUID)
+	if err != nil {
+		return nil, err
+	}
+
+	farmTasks := []taskstorage.TaskRead{}
+
+	for _, v := range tasks {
+		inFarm, err := s.taskInFarm(v, farmUID, areaNames)
+		if err != nil {
+			return nil, err
+		}
+
+		if inFarm {
+			farmTasks = append(farmTasks, v)
+		}
+	}
+
+	return farmTasks, nil
+}
@@ -0,0 +1,120 @@
+package server_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/config"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/dashboard/server"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+func TestWriteFarmExportAnonymized(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	uploadPath := t.TempDir()
+	config.Config.UploadPathArea = &uploadPath
+	config.Config.UploadPathCrop = &uploadPath
+
+	photo := []byte("the orchard behind the barn")
+	assert.Nil(t, os.WriteFile(filepath.Join(uploadPath, "Secret-Orchard.png"), photo, 0o600))
+
+	farmUID, _ := uuid.NewV4()
+	areaUID, _ := uuid.NewV4()
+	notes := "Bought from the Halloran cooperative"
+	supplier := "Halloran Seeds"
+	now := time.Date(2026, time.October, 14, 8, 0, 0, 0, time.UTC)
+
+	export := server.FarmExport{
+		Farm: assetsstorage.FarmRead{
+			UID: farmUID, Name: "Wildmere Farm", City: "Ravensholt", Latitude: "-7.7956", Longitude: "110.3695",
+			Type: "organic", Country: "ID",
+		},
+		Areas: []assetsstorage.AreaRead{{
+			UID:   areaUID,
+			Name:  "Secret Orchard",
+			Farm:  assetsstorage.AreaFarm{UID: farmUID, Name: "Wildmere Farm"},
+			Notes: []assetsstorage.AreaNote{{Content: "Ask for Mrs Kestrel at the back door"}},
+			Photo: assetsstorage.AreaPhoto{Filename: "Secret-Orchard.png", MimeType: "image/png", Width: 40, Height: 30},
+		}},
+		Materials: []assetsstorage.MaterialRead{{Name: "Golden Pearl Tomato", Notes: &notes, ProducedBy: &supplier}},
+		Crops: []growthstorage.CropRead{{
+			BatchID:     "gol-pea-14oct",
+			InitialArea: growthstorage.InitialArea{AreaUID: areaUID, Name: "Secret Orchard"},
+		}},
+		Tasks: []taskstorage.TaskRead{{Title: "Call Mr Quixotic", Description: "About the Halloran invoice"}},
+		CustomFieldValues: []server.ExportCustomFieldValues{{
+			EntityType: assetsdomain.CustomFieldEntityTask,
+			Values:     map[string]interface{}{"buyer": "Vermilion Grocers"},
+		}},
+	}
+	originals := []string{
+		"Wildmere", "Ravensholt", "7.7956", "110.3695", "Secret", "Orchard", "Kestrel", "Halloran",
+		"Golden Pearl", "gol-pea", "Quixotic", "Vermilion",
+	}
+
+	// When
+	plain := &bytes.Buffer{}
+	anonymized := &bytes.Buffer{}
+	errPlain := server.WriteFarmExport(plain, export, false, now)
+	errAnonymized := server.WriteFarmExport(anonymized, export, true, now)
+
+	// Then
+	assert.Nil(t, errPlain)
+	assert.Nil(t, errAnonymized)
+
+	plainFiles := readExportArchive(t, plain.Bytes())
+	anonymizedFiles := readExportArchive(t, anonymized.Bytes())
+
+	assert.Equal(t, photo, plainFiles["photos/areas/"+areaUID.String()+".png"])
+	assert.NotEqual(t, photo, anonymizedFiles["photos/areas/"+areaUID.String()+".png"])
+
+	for _, v := range originals {
+		assert.Contains(t, string(plainFiles[server.ExportFarmFile]), v)
+	}
+
+	for name, content := range anonymizedFiles {
+		for _, v := range originals {
+			assert.NotContains(t, string(content), v, name)
+		}
+	}
+
+	manifest := server.ExportManifest{}
+	assert.Nil(t, json.Unmarshal(anonymizedFiles[server.ExportManifestFile], &manifest))
+	assert.Equal(t, server.ExportSchemaVersion, manifest.SchemaVersion)
+	assert.True(t, manifest.Anonymized)
+	assert.Equal(t, farmUID, manifest.FarmUID)
+	assert.Len(t, manifest.Photos, 1)
+}
+
+func readExportArchive(t *testing.T, archive []byte) map[string][]byte {
+	t.Helper()
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	assert.Nil(t, err)
+
+	files := map[string][]byte{}
+
+	for _, v := range reader.File {
+		file, err := v.Open()
+		assert.Nil(t, err)
+
+		files[v.Name], err = io.ReadAll(file)
+		assert.Nil(t, err)
+		file.Close()
+	}
+
+	return files
+}
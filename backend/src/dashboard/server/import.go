@@ -0,0 +1,1359 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/actor"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/customfield"
+	"github.com/usetania/tania-core/src/farmimport"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/helper/stringhelper"
+	"github.com/usetania/tania-core/src/helper/structhelper"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// ImportReport is the result of a farm import, with the new uid of each record by its uid in the archive.
+type ImportReport struct {
+	FarmUID       uuid.UUID                          `json:"farm_id"`
+	SourceFarmUID uuid.UUID                          `json:"source_farm_id"`
+	Counts        map[string]int                     `json:"counts"`
+	IDs           map[string]map[uuid.UUID]uuid.UUID `json:"ids"`
+	Photos        int                                `json:"photos"`
+	Warnings      []string                           `json:"warnings"`
+}
+
+// FarmImport is the farm of an export archive under fresh uids, as the event streams of its records
+// and the photo files to restore.
+type FarmImport struct {
+	Report  ImportReport
+	Streams []farmimport.Stream
+	Photos  []ImportPhoto
+}
+
+// ImportPhoto is a photo of the archive, saved under the filename in the upload folder of its entity type.
+type ImportPhoto struct {
+	EntityType string
+	Filename   string
+	Content    []byte
+}
+
+// importedFarm is the farm file of the archive. The interface fields of the read models are kept as JSON
+// and decoded by their type code.
+type importedFarm struct {
+	Farm              assetsstorage.FarmRead                `json:"farm"`
+	Certifications    []assetsstorage.FarmCertificationRead `json:"certifications"`
+	Reservoirs        []assetsstorage.ReservoirRead         `json:"reservoirs"`
+	Areas             []assetsstorage.AreaRead              `json:"areas"`
+	Materials         []importedMaterial                    `json:"materials"`
+	MaterialTypes     []ExportMaterialType                  `json:"material_types"`
+	Crops             []growthstorage.CropRead              `json:"crops"`
+	Tasks             []importedTask                        `json:"tasks"`
+	CustomFieldValues []ExportCustomFieldValues             `json:"custom_field_values"`
+}
+
+type importedMaterial struct {
+	assetsstorage.MaterialRead
+	Type json.RawMessage `json:"type"`
+}
+
+type importedTask struct {
+	taskstorage.TaskRead
+	DomainDetails json.RawMessage `json:"domain_details"`
+}
+
+// ImportFarm creates a new farm from the export archive, with fresh uids for the farm and all its records.
+// The references between the records, like the assets of the tasks, are moved to the new uids, and the photos
+// are restored into the upload folders. The SQL engines save the records in one transaction, so a failed import
+// leaves nothing behind. The in memory engine can't roll back, its failed imports list the records already saved.
+func (s *DashboardServer) ImportFarm(c echo.Context) error {
+	file, err := c.FormFile("archive")
+	if err != nil {
+		return Error(c, NewRequestValidationError(Required, "archive"))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return Error(c, err)
+	}
+	defer src.Close()
+
+	archive, err := io.ReadAll(src)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	farmImport, err := ReadFarmImport(archive, s.MaxUploadSize)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	paths, err := saveImportPhotos(farmImport.Photos)
+	if err != nil {
+		removeImportPhotos(paths)
+
+		return Error(c, err)
+	}
+
+	err = s.ImportStore.Append(farmImport.Streams, actor.FromContext(c))
+	if err != nil {
+		return s.importFailed(c, farmImport, paths, err)
+	}
+
+	// PUBLISH //
+	s.publishImportStreams(farmImport.Streams)
+
+	data := make(map[string]ImportReport)
+	data["data"] = farmImport.Report
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// importFailed answers the failed import. The photos are removed when the records were rolled back.
+// Otherwise the records already saved are published, so they show up and can be removed, and listed.
+func (s *DashboardServer) importFailed(c echo.Context, farmImport *FarmImport, paths []string, err error) error {
+	appendErr := &farmimport.AppendError{}
+	if !errors.As(err, &appendErr) {
+		removeImportPhotos(paths)
+
+		return Error(c, err)
+	}
+
+	log.Printf("error_message: %v\n", appendErr.Error())
+
+	if appendErr.RolledBack {
+		removeImportPhotos(paths)
+	} else {
+		s.publishImportStreams(farmImport.Streams[:len(appendErr.Appended)])
+	}
+
+	return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+		"error_message": appendErr.Error(),
+		"farm_id":       farmImport.Report.FarmUID,
+		"rolled_back":   appendErr.RolledBack,
+		"appended":      appendErr.Appended,
+	})
+}
+
+// publishImportStreams publishes the events in the order of the streams, so the read models of the records
+// referenced by the others, like the areas of the crops, are saved first.
+func (s *DashboardServer) publishImportStreams(streams []farmimport.Stream) {
+	for _, stream := range streams {
+		for _, v := range stream.Events {
+			s.EventBus.Publish(structhelper.GetName(v), v)
+		}
+	}
+}
+
+func saveImportPhotos(photos []ImportPhoto) ([]string, error) {
+	paths := []string{}
+
+	for _, v := range photos {
+		folder := *config.Config.UploadPathCrop
+		if v.EntityType == assetsdomain.CustomFieldEntityArea {
+			folder = *config.Config.UploadPathArea
+		}
+
+		if err := os.MkdirAll(folder, os.ModePerm); err != nil {
+			return paths, err
+		}
+
+		destPath := stringhelper.Join(folder, "/", v.Filename)
+
+		// The filenames are new uids, an existing file is never overwritten.
+		file, err := os.OpenFile(destPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			return paths, err
+		}
+
+		paths = append(paths, destPath)
+
+		_, err = file.Write(v.Content)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+
+		if err != nil {
+			return paths, err
+		}
+	}
+
+	return paths, nil
+}
+
+func removeImportPhotos(paths []string) {
+	for _, v := range paths {
+		if err := os.Remove(v); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// ReadFarmImport reads the export archive, of the current schema version only, into the events of a new farm.
+// Its photos are images of at most maxPhotoSize bytes.
+func ReadFarmImport(archive []byte, maxPhotoSize int64) (*FarmImport, error) {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, NewRequestValidationError(ParseFailed, "archive")
+	}
+
+	manifest := ExportManifest{}
+	if err := readImportJSON(reader, ExportManifestFile, &manifest); err != nil {
+		return nil, err
+	}
+
+	if manifest.SchemaVersion != ExportSchemaVersion {
+		return nil, NewRequestValidationError(InvalidOption, "schema_version")
+	}
+
+	farm := importedFarm{}
+	if err := readImportJSON(reader, ExportFarmFile, &farm); err != nil {
+		return nil, err
+	}
+
+	if farm.Farm.UID == (uuid.UUID{}) || farm.Farm.UID != manifest.FarmUID {
+		return nil, NewRequestValidationError(ParseFailed, "archive")
+	}
+
+	im := &farmImporter{
+		archive:      reader,
+		manifest:     manifest,
+		ids:          map[uuid.UUID]uuid.UUID{},
+		maxPhotoSize: maxPhotoSize,
+		report: ImportReport{
+			SourceFarmUID: farm.Farm.UID,
+			IDs:           map[string]map[uuid.UUID]uuid.UUID{},
+			Warnings:      []string{},
+		},
+	}
+
+	if err := im.assignIDs(farm); err != nil {
+		return nil, err
+	}
+
+	if err := im.importFarm(farm); err != nil {
+		return nil, err
+	}
+
+	im.report.Counts = map[string]int{
+		"certifications":      len(im.report.IDs["certifications"]),
+		"reservoirs":          len(im.report.IDs["reservoirs"]),
+		"areas":               len(im.report.IDs["areas"]),
+		"materials":           len(im.report.IDs["materials"]),
+		"crops":               len(im.report.IDs["crops"]),
+		"tasks":               len(im.report.IDs["tasks"]),
+		"custom_field_values": im.customFieldValues,
+	}
+	im.report.Photos = len(im.photos)
+
+	return &FarmImport{Report: im.report, Streams: im.streams, Photos: im.photos}, nil
+}
+
+func readImportJSON(reader *zip.Reader, name string, value interface{}) error {
+	file, err := reader.Open(name)
+	if err != nil {
+		return NewRequestValidationError(ParseFailed, "archive")
+	}
+	defer file.Close()
+
+	if err := json.NewDecoder(file).Decode(value); err != nil {
+		return NewRequestValidationError(ParseFailed, "archive")
+	}
+
+	return nil
+}
+
+// farmImporter builds the event streams of the imported farm, with the new uid of every record of the archive.
+type farmImporter struct {
+	archive  *zip.Reader
+	manifest ExportManifest
+	ids      map[uuid.UUID]uuid.UUID
+	report   ImportReport
+	streams  []farmimport.Stream
+	photos   []ImportPhoto
+
+	maxPhotoSize      int64
+	customFieldValues int
+}
+
+// assignIDs gives a new uid to every record of the archive first, so the references between them
+// can be moved whatever the order of the records.
+func (im *farmImporter) assignIDs(farm importedFarm) error {
+	add := func(kind string, uids ...uuid.UUID) error {
+		for _, v := range uids {
+			if _, ok := im.ids[v]; ok || v == (uuid.UUID{}) {
+				return NewRequestValidationError(ParseFailed, "archive")
+			}
+
+			uid, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+
+			if im.report.IDs[kind] == nil {
+				im.report.IDs[kind] = map[uuid.UUID]uuid.UUID{}
+			}
+
+			im.ids[v] = uid
+			im.report.IDs[kind][v] = uid
+		}
+
+		return nil
+	}
+
+	if err := add("farms", farm.Farm.UID); err != nil {
+		return err
+	}
+
+	im.report.FarmUID = im.ids[farm.Farm.UID]
+
+	for _, v := range farm.Certifications {
+		if err := add("certifications", v.UID); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range farm.Reservoirs {
+		if err := add("reservoirs", v.UID); err != nil {
+			return err
+		}
+
+		for _, n := range v.Notes {
+			if err := add("reservoir_notes", n.UID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, v := range farm.Areas {
+		if err := add("areas", v.UID); err != nil {
+			return err
+		}
+
+		for _, n := range v.Notes {
+			if err := add("area_notes", n.UID); err != nil {
+				return err
+			}
+		}
+
+		for _, n := range v.Sanitations {
+			if err := add("area_sanitations", n.UID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, v := range farm.Materials {
+		if err := add("materials", v.UID); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range farm.Crops {
+		if err := add("crops", v.UID); err != nil {
+			return err
+		}
+
+		for _, n := range v.Notes {
+			if err := add("crop_notes", n.UID); err != nil {
+				return err
+			}
+		}
+
+		for _, p := range v.Photos {
+			if err := add("crop_photos", p.UID); err != nil {
+				return err
+			}
+		}
+
+		for _, n := range v.NurseryStages {
+			if err := add("nursery_stages", n.UID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, v := range farm.Tasks {
+		if err := add("tasks", v.UID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ref returns the new uid of the record referenced in the archive.
+func (im *farmImporter) ref(uid uuid.UUID) (uuid.UUID, bool) {
+	newUID, ok := im.ids[uid]
+
+	return newUID, ok
+}
+
+// optionalRef moves an optional reference, which is dropped with a warning when its record isn't in the archive.
+func (im *farmImporter) optionalRef(uid *uuid.UUID, warning string) *uuid.UUID {
+	if uid == nil {
+		return nil
+	}
+
+	newUID, ok := im.ref(*uid)
+	if !ok {
+		im.report.Warnings = append(im.report.Warnings, warning)
+
+		return nil
+	}
+
+	return &newUID
+}
+
+func (im *farmImporter) addStream(aggregate string, uid uuid.UUID, events []interface{}) {
+	im.streams = append(im.streams, farmimport.Stream{Aggregate: aggregate, UID: uid, Events: events})
+}
+
+// findPhoto returns the photo file of the entity in the archive, false when it was left out of it.
+// The file is an image of at most the upload size, whatever its declared size and mime type.
+func (im *farmImporter) findPhoto(entityType string, entityUID uuid.UUID, photoUID *uuid.UUID) (
+	ExportPhoto, []byte, bool, error,
+) {
+	for _, v := range im.manifest.Photos {
+		if v.EntityType != entityType || v.EntityUID != entityUID {
+			continue
+		}
+
+		if photoUID != nil && (v.PhotoUID == nil || *v.PhotoUID != *photoUID) {
+			continue
+		}
+
+		file, err := im.archive.Open(v.Path)
+		if err != nil {
+			return ExportPhoto{}, nil, false, NewRequestValidationError(ParseFailed, "archive")
+		}
+
+		content, err := im.readPhoto(file)
+		file.Close()
+
+		if err != nil {
+			return ExportPhoto{}, nil, false, err
+		}
+
+		v.MimeType = http.DetectContentType(content)
+		if photoExtension(v.MimeType) == "" {
+			return ExportPhoto{}, nil, false, NewRequestValidationError(InvalidOption, "photos")
+		}
+
+		return v, content, true, nil
+	}
+
+	return ExportPhoto{}, nil, false, nil
+}
+
+// readPhoto reads the photo file up to the upload size. The size in the header of the archive is checked first,
+// and the reading stops past the upload size in case the header lies.
+func (im *farmImporter) readPhoto(file fs.File) ([]byte, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, NewRequestValidationError(ParseFailed, "archive")
+	}
+
+	if info.Size() > im.maxPhotoSize {
+		return nil, NewRequestValidationError(InvalidOption, "photos")
+	}
+
+	content, err := io.ReadAll(io.LimitReader(file, im.maxPhotoSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(content)) > im.maxPhotoSize {
+		return nil, NewRequestValidationError(InvalidOption, "photos")
+	}
+
+	return content, nil
+}
+
+func (im *farmImporter) importFarm(farm importedFarm) error {
+	farmUID := im.report.FarmUID
+
+	farmEvents := []interface{}{assetsdomain.FarmCreated{
+		UID:         farmUID,
+		Name:        farm.Farm.Name,
+		Type:        farm.Farm.Type,
+		Latitude:    farm.Farm.Latitude,
+		Longitude:   farm.Farm.Longitude,
+		Country:     farm.Farm.Country,
+		City:        farm.Farm.City,
+		IsActive:    farm.Farm.IsActive,
+		CreatedDate: farm.Farm.CreatedDate,
+	}}
+
+	walkOrder := []uuid.UUID{}
+
+	for _, v := range farm.Farm.AreaWalkOrder {
+		if uid, ok := im.ref(v); ok {
+			walkOrder = append(walkOrder, uid)
+		}
+	}
+
+	if len(walkOrder) > 0 {
+		farmEvents = append(farmEvents, assetsdomain.FarmAreaWalkOrderChanged{FarmUID: farmUID, AreaUIDs: walkOrder})
+	}
+
+	// The read model has the default grades until the farm sets its own.
+	grades := farm.Farm.HarvestGrades
+	if len(grades) > 0 && !reflect.DeepEqual(grades, assetsdomain.DefaultHarvestGrades()) {
+		farmEvents = append(farmEvents, assetsdomain.FarmHarvestGradesChanged{FarmUID: farmUID, Grades: grades})
+	}
+
+	if len(farm.Farm.Seasons) > 0 {
+		farmEvents = append(farmEvents, assetsdomain.FarmSeasonsChanged{FarmUID: farmUID, Seasons: farm.Farm.Seasons})
+	}
+
+	if farm.Farm.WithholdingPolicy != "" {
+		farmEvents = append(farmEvents, assetsdomain.FarmWithholdingPolicyChanged{
+			FarmUID: farmUID, Policy: farm.Farm.WithholdingPolicy,
+		})
+	}
+
+	if farm.Farm.DailyEffortBudgetMinutes > 0 {
+		farmEvents = append(farmEvents, assetsdomain.FarmDailyEffortBudgetChanged{
+			FarmUID: farmUID, Minutes: farm.Farm.DailyEffortBudgetMinutes,
+		})
+	}
+
+	im.addStream(farmimport.AggregateFarm, farmUID, farmEvents)
+
+	im.importCertifications(farm.Certifications)
+	im.importReservoirs(farm.Reservoirs)
+
+	if err := im.importAreas(farm.Areas); err != nil {
+		return err
+	}
+
+	if err := im.importMaterials(farm.Materials, farm.MaterialTypes); err != nil {
+		return err
+	}
+
+	if err := im.importCrops(farm.Crops); err != nil {
+		return err
+	}
+
+	if err := im.importTasks(farm.Tasks); err != nil {
+		return err
+	}
+
+	im.importCustomFieldValues(farm.CustomFieldValues)
+
+	return nil
+}
+
+func (im *farmImporter) importCertifications(certifications []assetsstorage.FarmCertificationRead) {
+	for _, v := range certifications {
+		uid, _ := im.ref(v.UID)
+
+		events := []interface{}{assetsdomain.CertificationGranted{
+			UID:               uid,
+			FarmID:            im.report.FarmUID,
+			CertificationType: v.CertificationType,
+			CertifyingBody:    v.CertifyingBody,
+			IssuedDate:        v.IssuedDate,
+			ExpiryDate:        v.ExpiryDate,
+			CertificateNumber: v.CertificateNumber,
+			CreatedDate:       v.CreatedDate,
+		}}
+
+		if v.RevokedDate != nil {
+			events = append(events, assetsdomain.CertificationRevoked{
+				UID:               uid,
+				FarmID:            im.report.FarmUID,
+				CertificationType: v.CertificationType,
+				CertifyingBody:    v.CertifyingBody,
+				IssuedDate:        v.IssuedDate,
+				ExpiryDate:        v.ExpiryDate,
+				CertificateNumber: v.CertificateNumber,
+				RevokedDate:       *v.RevokedDate,
+			})
+		}
+
+		im.addStream(farmimport.AggregateFarmCertification, uid, events)
+	}
+}
+
+func (im *farmImporter) importReservoirs(reservoirs []assetsstorage.ReservoirRead) {
+	for _, v := range reservoirs {
+		uid, _ := im.ref(v.UID)
+
+		var waterSource assetsdomain.WaterSource = assetsdomain.Tap{}
+		if v.WaterSource.Type == assetsdomain.BucketType {
+			waterSource = assetsdomain.Bucket{Capacity: v.WaterSource.Capacity}
+		}
+
+		events := []interface{}{assetsdomain.ReservoirCreated{
+			UID:         uid,
+			Name:        v.Name,
+			WaterSource: waterSource,
+			FarmUID:     im.report.FarmUID,
+			CreatedDate: v.CreatedDate,
+		}}
+
+		for _, n := range v.Notes {
+			noteUID, _ := im.ref(n.UID)
+
+			events = append(events, assetsdomain.ReservoirNoteAdded{
+				ReservoirUID: uid,
+				UID:          noteUID,
+				Content:      n.Content,
+				CreatedDate:  n.CreatedDate,
+			})
+		}
+
+		im.addStream(farmimport.AggregateReservoir, uid, events)
+	}
+}
+
+func (im *farmImporter) importAreas(areas []assetsstorage.AreaRead) error {
+	for _, v := range areas {
+		uid, _ := im.ref(v.UID)
+
+		reservoirUID := uuid.UUID{}
+		if v.Reservoir.UID != (uuid.UUID{}) {
+			reservoir := im.optionalRef(&v.Reservoir.UID,
+				fmt.Sprintf("The reservoir of the area %s isn't in the archive.", v.UID))
+			if reservoir != nil {
+				reservoirUID = *reservoir
+			}
+		}
+
+		events := []interface{}{assetsdomain.AreaCreated{
+			UID:          uid,
+			Name:         v.Name,
+			Type:         assetsdomain.GetAreaType(v.Type),
+			Location:     assetsdomain.AreaLocation(v.Location),
+			Size:         assetsdomain.AreaSize(v.Size),
+			FarmUID:      im.report.FarmUID,
+			ReservoirUID: reservoirUID,
+			CreatedDate:  v.CreatedDate,
+			CustomFields: v.CustomFields,
+		}}
+
+		if v.Photo.Filename != "" {
+			photo, content, ok, err := im.findPhoto(assetsdomain.CustomFieldEntityArea, v.UID, nil)
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				filename := uid.String() + photoExtension(photo.MimeType)
+
+				im.photos = append(im.photos, ImportPhoto{
+					EntityType: assetsdomain.CustomFieldEntityArea,
+					Filename:   filename,
+					Content:    content,
+				})
+
+				events = append(events, assetsdomain.AreaPhotoAdded{
+					AreaUID:  uid,
+					Filename: filename,
+					MimeType: photo.MimeType,
+					Size:     len(content),
+					Width:    v.Photo.Width,
+					Height:   v.Photo.Height,
+				})
+			} else {
+				im.report.Warnings = append(im.report.Warnings,
+					fmt.Sprintf("The photo of the area %s isn't in the archive.", v.UID))
+			}
+		}
+
+		for _, n := range v.Notes {
+			noteUID, _ := im.ref(n.UID)
+
+			events = append(events, assetsdomain.AreaNoteAdded{
+				AreaUID:     uid,
+				UID:         noteUID,
+				Content:     n.Content,
+				CreatedDate: n.CreatedDate,
+			})
+		}
+
+		if v.Layout != nil {
+			events = append(events, assetsdomain.AreaLayoutChanged{
+				AreaUID: uid,
+				Layout:  (*assetsdomain.AreaLayout)(v.Layout),
+			})
+		}
+
+		if v.GeoPoint != nil {
+			events = append(events, assetsdomain.AreaGeoPointChanged{AreaUID: uid, GeoPoint: v.GeoPoint})
+		}
+
+		if v.BedMap != nil {
+			events = append(events, im.bedMapEvents(uid, assetsdomain.BedMap(*v.BedMap))...)
+		}
+
+		for _, n := range v.Sanitations {
+			sanitationUID, _ := im.ref(n.UID)
+			materials := []assetsdomain.AreaSanitationMaterial{}
+
+			for _, m := range n.Materials {
+				materialUID := uuid.UUID{}
+
+				material := im.optionalRef(&m.MaterialUID,
+					fmt.Sprintf("The material %s of a sanitation of the area %s isn't in the archive.", m.MaterialUID, v.UID))
+				if material != nil {
+					materialUID = *material
+				}
+
+				materials = append(materials, assetsdomain.AreaSanitationMaterial{
+					MaterialUID:  materialUID,
+					MaterialName: m.MaterialName,
+					Quantity:     m.Quantity,
+					QuantityUnit: m.QuantityUnit,
+					Shortfall:    m.Shortfall,
+				})
+			}
+
+			events = append(events, assetsdomain.AreaSanitized{
+				AreaUID:       uid,
+				UID:           sanitationUID,
+				Method:        n.Method,
+				Materials:     materials,
+				Notes:         n.Notes,
+				SanitizedDate: n.SanitizedDate,
+			})
+		}
+
+		im.addStream(farmimport.AggregateArea, uid, events)
+	}
+
+	return nil
+}
+
+// bedMapEvents sizes the bed map and places the crops of its cells, the reserved cells without a crop.
+func (im *farmImporter) bedMapEvents(areaUID uuid.UUID, bedMap assetsdomain.BedMap) []interface{} {
+	events := []interface{}{assetsdomain.AreaBedMapResized{AreaUID: areaUID, Rows: bedMap.Rows, Columns: bedMap.Columns}}
+
+	for row, cells := range bedMap.Cells {
+		for column, status := range cells {
+			if status == assetsdomain.BedCellEmpty {
+				continue
+			}
+
+			cropUID := uuid.UUID{}
+
+			if row < len(bedMap.CropUIDs) && column < len(bedMap.CropUIDs[row]) {
+				crop := im.optionalRef(bedMap.CropUIDs[row][column],
+					fmt.Sprintf("The crop of the bed %d,%d of the area %s isn't in the archive.", row, column, areaUID))
+				if crop != nil {
+					cropUID = *crop
+				}
+			}
+
+			events = append(events, assetsdomain.AreaBedCropPlaced{
+				AreaUID: areaUID,
+				CropUID: cropUID,
+				Row:     row,
+				Column:  column,
+				Status:  status,
+			})
+		}
+	}
+
+	return events
+}
+
+func (im *farmImporter) importMaterials(materials []importedMaterial, types []ExportMaterialType) error {
+	typeCodes := map[uuid.UUID]string{}
+	for _, v := range types {
+		typeCodes[v.MaterialUID] = v.Code
+	}
+
+	for _, v := range materials {
+		uid, _ := im.ref(v.UID)
+
+		materialType, err := importMaterialType(typeCodes[v.UID], v.Type)
+		if err != nil {
+			return err
+		}
+
+		im.addStream(farmimport.AggregateMaterial, uid, []interface{}{assetsdomain.MaterialCreated{
+			UID:            uid,
+			Name:           v.Name,
+			PricePerUnit:   assetsdomain.PricePerUnit(v.PricePerUnit),
+			Type:           materialType,
+			Quantity:       assetsdomain.MaterialQuantity(v.Quantity),
+			ExpirationDate: v.ExpirationDate,
+			Notes:          v.Notes,
+			ProducedBy:     v.ProducedBy,
+			GDDToMaturity:  v.GDDToMaturity,
+			CreatedDate:    v.CreatedDate,
+
+			PreHarvestIntervalDays: v.PreHarvestIntervalDays,
+		}})
+	}
+
+	return nil
+}
+
+// importMaterialType decodes the type of the material from its code and the code of its plant, chemical
+// or container type.
+func importMaterialType(code string, raw json.RawMessage) (assetsdomain.MaterialType, error) {
+	subTypes := map[string]struct {
+		Code string `json:"code"`
+	}{}
+
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &subTypes); err != nil {
+			return nil, NewRequestValidationError(ParseFailed, "materials")
+		}
+	}
+
+	var (
+		materialType assetsdomain.MaterialType
+		err          error
+	)
+
+	switch code {
+	case assetsdomain.MaterialTypeSeedCode:
+		materialType, err = assetsdomain.CreateMaterialTypeSeed(subTypes["PlantType"].Code)
+	case assetsdomain.MaterialTypePlantCode:
+		materialType, err = assetsdomain.CreateMaterialTypePlant(subTypes["PlantType"].Code)
+	case assetsdomain.MaterialTypeAgrochemicalCode:
+		materialType, err = assetsdomain.CreateMaterialTypeAgrochemical(subTypes["ChemicalType"].Code)
+	case assetsdomain.MaterialTypeSeedingContainerCode:
+		materialType, err = assetsdomain.CreateMaterialTypeSeedingContainer(subTypes["ContainerType"].Code)
+	case assetsdomain.MaterialTypeGrowingMediumCode:
+		materialType = assetsdomain.MaterialTypeGrowingMedium{}
+	case assetsdomain.MaterialTypeLabelAndCropSupportCode:
+		materialType = assetsdomain.MaterialTypeLabelAndCropSupport{}
+	case assetsdomain.MaterialTypePostHarvestSupplyCode:
+		materialType = assetsdomain.MaterialTypePostHarvestSupply{}
+	case assetsdomain.MaterialTypeOtherCode:
+		materialType = assetsdomain.MaterialTypeOther{}
+	default:
+		return nil, NewRequestValidationError(InvalidOption, "material_types")
+	}
+
+	if err != nil {
+		return nil, NewRequestValidationError(InvalidOption, "material_types")
+	}
+
+	return materialType, nil
+}
+
+// importCrops replays the batches from their read models. The history of a batch isn't in the archive,
+// so it's replayed as one move per area it was moved to, one harvest and one dump per area they came from,
+// each carrying the final state of its areas, as its events do.
+func (im *farmImporter) importCrops(crops []growthstorage.CropRead) error {
+	for _, v := range crops {
+		uid, _ := im.ref(v.UID)
+
+		initialAreaUID, ok := im.ref(v.InitialArea.AreaUID)
+		if !ok {
+			return NewRequestValidationError(NotFound, "initial_area")
+		}
+
+		inventoryUID, ok := im.ref(v.Inventory.UID)
+		if !ok {
+			return NewRequestValidationError(NotFound, "inventory")
+		}
+
+		var containerType growthdomain.CropContainerType = growthdomain.Pot{}
+		if v.Container.Type == (growthdomain.Tray{}).Code() {
+			containerType = growthdomain.Tray{Cell: v.Container.Cell}
+		}
+
+		events := []interface{}{growthdomain.CropBatchCreated{
+			UID:            uid,
+			BatchID:        v.BatchID,
+			Status:         growthdomain.GetCropStatus(growthdomain.CropActive),
+			Type:           growthdomain.GetCropType(v.Type),
+			Container:      growthdomain.CropContainer{Quantity: v.Container.Quantity, Type: containerType},
+			InventoryUID:   inventoryUID,
+			FarmUID:        im.report.FarmUID,
+			CreatedDate:    v.InitialArea.CreatedDate,
+			InitialAreaUID: initialAreaUID,
+			Quantity:       v.InitialArea.InitialQuantity,
+		}}
+
+		if v.SeedsSown != nil {
+			events = append(events, growthdomain.CropBatchSeedsSownRecorded{UID: uid, SeedsSown: *v.SeedsSown})
+		}
+
+		nurseryEvents, err := im.nurseryEvents(uid, v.NurseryStages)
+		if err != nil {
+			return err
+		}
+
+		events = append(events, nurseryEvents...)
+
+		areaEvents, err := im.cropAreaEvents(uid, initialAreaUID, v)
+		if err != nil {
+			return err
+		}
+
+		events = append(events, areaEvents...)
+
+		if v.GerminatedQuantity != nil && v.GerminationDate != nil {
+			seedsSown := 0
+			if v.SeedsSown != nil {
+				seedsSown = *v.SeedsSown
+			}
+
+			events = append(events, growthdomain.CropGerminationRecorded{
+				UID:                uid,
+				BatchID:            v.BatchID,
+				FarmUID:            im.report.FarmUID,
+				InventoryUID:       inventoryUID,
+				SeedsSown:          seedsSown,
+				GerminatedQuantity: *v.GerminatedQuantity,
+				GerminationDate:    *v.GerminationDate,
+			})
+		}
+
+		for _, n := range v.Notes {
+			noteUID, _ := im.ref(n.UID)
+
+			events = append(events, growthdomain.CropBatchNoteCreated{
+				UID:         noteUID,
+				CropUID:     uid,
+				Content:     n.Content,
+				CreatedDate: n.CreatedDate,
+			})
+		}
+
+		photoEvents, err := im.cropPhotoEvents(uid, v)
+		if err != nil {
+			return err
+		}
+
+		events = append(events, photoEvents...)
+
+		im.addStream(farmimport.AggregateCrop, uid, events)
+	}
+
+	return nil
+}
+
+func (im *farmImporter) nurseryEvents(cropUID uuid.UUID, stages []growthstorage.NurseryStage) ([]interface{}, error) {
+	events := []interface{}{}
+
+	for _, v := range stages {
+		uid, _ := im.ref(v.UID)
+
+		areaUID, ok := im.ref(v.AreaUID)
+		if !ok {
+			return nil, NewRequestValidationError(NotFound, "nursery_area")
+		}
+
+		events = append(events, growthdomain.CropNurseryStageStarted{
+			UID:                    uid,
+			CropID:                 cropUID,
+			NurseryAreaID:          areaUID,
+			StartDate:              v.StartDate,
+			ExpectedTransplantDate: v.ExpectedTransplantDate,
+		})
+
+		if v.ActualTransplantDate != nil {
+			events = append(events, growthdomain.CropNurseryStageCompleted{
+				UID:                    uid,
+				CropID:                 cropUID,
+				NurseryAreaID:          areaUID,
+				StartDate:              v.StartDate,
+				ExpectedTransplantDate: v.ExpectedTransplantDate,
+				ActualTransplantDate:   v.ActualTransplantDate,
+			})
+		}
+	}
+
+	return events, nil
+}
+
+// cropAreaEvents replays the moves, waterings, harvests and dumps of the batch. The last harvest or dump
+// gives the batch its status.
+func (im *farmImporter) cropAreaEvents(uid, initialAreaUID uuid.UUID, crop growthstorage.CropRead) (
+	[]interface{}, error,
+) {
+	initialArea := importInitialArea(initialAreaUID, crop.InitialArea)
+	areas := map[uuid.UUID]interface{}{initialAreaUID: initialArea}
+	currentQuantities := map[uuid.UUID]int{initialAreaUID: initialArea.CurrentQuantity}
+
+	events := cropWateredEvent(uid, crop, initialAreaUID, crop.InitialArea.Name, crop.InitialArea.LastWatered)
+
+	for _, v := range crop.MovedArea {
+		areaUID, ok := im.ref(v.AreaUID)
+		if !ok {
+			return nil, NewRequestValidationError(NotFound, "moved_area")
+		}
+
+		movedArea := importMovedArea(areaUID, initialAreaUID, v)
+		areas[areaUID] = movedArea
+		currentQuantities[areaUID] = movedArea.CurrentQuantity
+
+		events = append(events, growthdomain.CropBatchMoved{
+			UID:                uid,
+			Quantity:           v.InitialQuantity,
+			SrcAreaUID:         initialAreaUID,
+			DstAreaUID:         areaUID,
+			MovedDate:          v.CreatedDate,
+			UpdatedSrcAreaCode: "INITIAL_AREA",
+			UpdatedSrcArea:     initialArea,
+			UpdatedDstAreaCode: "MOVED_AREA",
+			UpdatedDstArea:     movedArea,
+		})
+		events = append(events, cropWateredEvent(uid, crop, areaUID, v.Name, v.LastWatered)...)
+	}
+
+	closing := []interface{}{}
+
+	for _, v := range crop.HarvestedStorage {
+		areaUID, ok := im.ref(v.SourceAreaUID)
+		if !ok || areas[areaUID] == nil {
+			return nil, NewRequestValidationError(NotFound, "harvested_storage")
+		}
+
+		harvestType := growthdomain.HarvestTypePartial
+		if currentQuantities[areaUID] == 0 {
+			harvestType = growthdomain.HarvestTypeAll
+		}
+
+		closing = append(closing, growthdomain.CropBatchHarvested{
+			UID:                  uid,
+			CropStatus:           growthdomain.CropActive,
+			HarvestType:          harvestType,
+			HarvestedQuantity:    v.Quantity,
+			ProducedGramQuantity: v.ProducedGramQuantity,
+			UpdatedHarvestedStorage: growthdomain.HarvestedStorage{
+				Quantity:             v.Quantity,
+				ProducedGramQuantity: v.ProducedGramQuantity,
+				SourceAreaUID:        areaUID,
+				CreatedDate:          v.CreatedDate,
+				LastUpdated:          v.LastUpdated,
+			},
+			HarvestedArea:     areas[areaUID],
+			HarvestedAreaCode: cropAreaCode(areaUID, initialAreaUID),
+			HarvestDate:       v.LastUpdated,
+		})
+	}
+
+	for _, v := range crop.Trash {
+		areaUID, ok := im.ref(v.SourceAreaUID)
+		if !ok || areas[areaUID] == nil {
+			return nil, NewRequestValidationError(NotFound, "trash")
+		}
+
+		closing = append(closing, growthdomain.CropBatchDumped{
+			UID:        uid,
+			CropStatus: growthdomain.CropActive,
+			Quantity:   v.Quantity,
+			UpdatedTrash: growthdomain.Trash{
+				Quantity:      v.Quantity,
+				SourceAreaUID: areaUID,
+				CreatedDate:   v.CreatedDate,
+				LastUpdated:   v.LastUpdated,
+			},
+			DumpedArea:     areas[areaUID],
+			DumpedAreaCode: cropAreaCode(areaUID, initialAreaUID),
+			DumpDate:       v.LastUpdated,
+		})
+	}
+
+	if len(closing) > 0 {
+		switch e := closing[len(closing)-1].(type) {
+		case growthdomain.CropBatchHarvested:
+			e.CropStatus = crop.Status
+			closing[len(closing)-1] = e
+		case growthdomain.CropBatchDumped:
+			e.CropStatus = crop.Status
+			closing[len(closing)-1] = e
+		}
+	} else if crop.Status == growthdomain.CropArchived {
+		im.report.Warnings = append(im.report.Warnings,
+			fmt.Sprintf("The crop %s is archived without harvest or dump, it's imported as active.", crop.UID))
+	}
+
+	return append(events, closing...), nil
+}
+
+// cropWateredEvent keeps the last watering of an area of the batch.
+func cropWateredEvent(uid uuid.UUID, crop growthstorage.CropRead, areaUID uuid.UUID, areaName string,
+	lastWatered *time.Time,
+) []interface{} {
+	if lastWatered == nil {
+		return nil
+	}
+
+	return []interface{}{growthdomain.CropBatchWatered{
+		UID:           uid,
+		BatchID:       crop.BatchID,
+		ContainerType: crop.Container.Type,
+		AreaUID:       areaUID,
+		AreaName:      areaName,
+		WateringDate:  *lastWatered,
+	}}
+}
+
+func cropAreaCode(areaUID, initialAreaUID uuid.UUID) string {
+	if areaUID == initialAreaUID {
+		return "INITIAL_AREA"
+	}
+
+	return "MOVED_AREA"
+}
+
+func importInitialArea(areaUID uuid.UUID, area growthstorage.InitialArea) growthdomain.InitialArea {
+	return growthdomain.InitialArea{
+		AreaUID:         areaUID,
+		InitialQuantity: area.InitialQuantity,
+		CurrentQuantity: area.CurrentQuantity,
+		CreatedDate:     area.CreatedDate,
+		LastUpdated:     area.LastUpdated,
+		LastWatered:     importTime(area.LastWatered),
+		LastFertilized:  importTime(area.LastFertilized),
+		LastPruned:      importTime(area.LastPruned),
+		LastPesticided:  importTime(area.LastPesticided),
+	}
+}
+
+func importMovedArea(areaUID, sourceAreaUID uuid.UUID, area growthstorage.MovedArea) growthdomain.MovedArea {
+	return growthdomain.MovedArea{
+		AreaUID:         areaUID,
+		SourceAreaUID:   sourceAreaUID,
+		InitialQuantity: area.InitialQuantity,
+		CurrentQuantity: area.CurrentQuantity,
+		CreatedDate:     area.CreatedDate,
+		LastUpdated:     area.LastUpdated,
+		LastWatered:     importTime(area.LastWatered),
+		LastFertilized:  importTime(area.LastFertilized),
+		LastPruned:      importTime(area.LastPruned),
+		LastPesticided:  importTime(area.LastPesticided),
+	}
+}
+
+func importTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
+
+// cropPhotoEvents restores the photos of the batch, the thumbnails aren't in the archive so the photos
+// are shown in full.
+func (im *farmImporter) cropPhotoEvents(uid uuid.UUID, crop growthstorage.CropRead) ([]interface{}, error) {
+	events := []interface{}{}
+
+	for _, v := range crop.Photos {
+		photoUID := v.UID
+
+		photo, content, ok, err := im.findPhoto(assetsdomain.CustomFieldEntityCrop, crop.UID, &photoUID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			im.report.Warnings = append(im.report.Warnings,
+				fmt.Sprintf("The photo %s of the crop %s isn't in the archive.", v.UID, crop.UID))
+
+			continue
+		}
+
+		newPhotoUID, _ := im.ref(v.UID)
+		filename := newPhotoUID.String() + photoExtension(photo.MimeType)
+
+		im.photos = append(im.photos, ImportPhoto{
+			EntityType: assetsdomain.CustomFieldEntityCrop,
+			Filename:   filename,
+			Content:    content,
+		})
+
+		events = append(events, growthdomain.CropBatchPhotoCreated{
+			UID:         newPhotoUID,
+			CropUID:     uid,
+			Filename:    filename,
+			MimeType:    photo.MimeType,
+			Size:        len(content),
+			Width:       v.Width,
+			Height:      v.Height,
+			Description: v.Description,
+			Status:      growthdomain.CropPhotoStatusReady,
+			Metadata:    v.Metadata,
+		})
+	}
+
+	return events, nil
+}
+
+func (im *farmImporter) importTasks(tasks []importedTask) error {
+	for _, v := range tasks {
+		uid, _ := im.ref(v.UID)
+
+		domainDetails, err := im.importTaskDomain(v)
+		if err != nil {
+			return err
+		}
+
+		events := []interface{}{tasksdomain.TaskCreated{
+			UID:           uid,
+			Title:         v.Title,
+			Description:   v.Description,
+			CreatedDate:   v.CreatedDate,
+			DueDate:       v.DueDate,
+			Priority:      v.Priority,
+			Status:        tasksdomain.TaskStatusCreated,
+			Domain:        v.Domain,
+			DomainDetails: domainDetails,
+			Category:      v.Category,
+			IsDue:         v.IsDue,
+			AssetID:       im.optionalRef(v.AssetID, fmt.Sprintf("The asset of the task %s isn't in the archive.", v.UID)),
+			CostCentreID: im.optionalRef(v.CostCentreID,
+				fmt.Sprintf("The cost centre of the task %s isn't in the archive.", v.UID)),
+		}}
+
+		if v.EstimatedMinutes > 0 {
+			events = append(events, tasksdomain.TaskEstimatedMinutesChanged{UID: uid, EstimatedMinutes: v.EstimatedMinutes})
+		}
+
+		if v.TargetQuantity > 0 {
+			events = append(events, tasksdomain.TaskTargetChanged{
+				UID:            uid,
+				TargetQuantity: v.TargetQuantity,
+				TargetUnit:     v.TargetUnit,
+			})
+		}
+
+		// The series of a recurring task starts again from it, its previous tasks aren't linked.
+		if v.Recurrence != nil {
+			events = append(events, tasksdomain.TaskRecurrenceSet{UID: uid, Recurrence: v.Recurrence})
+		}
+
+		// The progress of an open task is imported as a single record.
+		if v.Status == tasksdomain.TaskStatusCreated && v.CompletedQuantity > 0 {
+			events = append(events, tasksdomain.TaskProgressRecorded{
+				UID:           uid,
+				Quantity:      v.CompletedQuantity,
+				LabourMinutes: v.LabourMinutes,
+				RecordedDate:  v.CreatedDate,
+			})
+		}
+
+		switch v.Status {
+		case tasksdomain.TaskStatusCompleted:
+			events = append(events, tasksdomain.TaskCompleted{
+				UID:               uid,
+				Status:            tasksdomain.TaskCompletedCode,
+				CompletedDate:     v.CompletedDate,
+				CompletedBy:       v.CompletedBy,
+				LabourMinutes:     v.LabourMinutes,
+				MaterialQuantity:  v.MaterialQuantity,
+				CompletedQuantity: v.CompletedQuantity,
+			})
+		case tasksdomain.TaskStatusCancelled:
+			events = append(events, tasksdomain.TaskCancelled{
+				UID:           uid,
+				Status:        tasksdomain.TaskCancelledCode,
+				CancelledDate: v.CancelledDate,
+			})
+		}
+
+		if v.ArchivedDate != nil {
+			events = append(events, tasksdomain.TaskArchived{UID: uid, ArchivedDate: *v.ArchivedDate})
+		}
+
+		im.addStream(farmimport.AggregateTask, uid, events)
+	}
+
+	return nil
+}
+
+// importTaskDomain decodes the details of the task by its domain, with their references moved.
+func (im *farmImporter) importTaskDomain(task importedTask) (tasksdomain.TaskDomain, error) {
+	decode := func(details interface{}) error {
+		if len(task.DomainDetails) == 0 || string(task.DomainDetails) == "null" {
+			return nil
+		}
+
+		if err := json.Unmarshal(task.DomainDetails, details); err != nil {
+			return NewRequestValidationError(ParseFailed, "domain_details")
+		}
+
+		return nil
+	}
+
+	warning := fmt.Sprintf("A record of the details of the task %s isn't in the archive.", task.UID)
+
+	switch task.Domain {
+	case tasksdomain.TaskDomainAreaCode:
+		details := tasksdomain.TaskDomainArea{}
+		err := decode(&details)
+		details.MaterialID = im.optionalRef(details.MaterialID, warning)
+
+		return details, err
+	case tasksdomain.TaskDomainCropCode:
+		details := tasksdomain.TaskDomainCrop{}
+		err := decode(&details)
+		details.MaterialID = im.optionalRef(details.MaterialID, warning)
+		details.AreaID = im.optionalRef(details.AreaID, warning)
+
+		return details, err
+	case tasksdomain.TaskDomainReservoirCode:
+		details := tasksdomain.TaskDomainReservoir{}
+		err := decode(&details)
+		details.MaterialID = im.optionalRef(details.MaterialID, warning)
+
+		return details, err
+	case tasksdomain.TaskDomainEquipmentCode:
+		// The equipment isn't exported.
+		details := tasksdomain.TaskDomainEquipment{}
+		err := decode(&details)
+		details.EquipmentID = im.optionalRef(details.EquipmentID, warning)
+
+		return details, err
+	case tasksdomain.TaskDomainFinanceCode:
+		return tasksdomain.TaskDomainFinance{}, nil
+	case tasksdomain.TaskDomainGeneralCode:
+		return tasksdomain.TaskDomainGeneral{}, nil
+	case tasksdomain.TaskDomainInventoryCode:
+		return tasksdomain.TaskDomainInventory{}, nil
+	}
+
+	return nil, NewRequestValidationError(InvalidOption, "domain")
+}
+
+func (im *farmImporter) importCustomFieldValues(values []ExportCustomFieldValues) {
+	for _, v := range values {
+		entityUID, ok := im.ref(v.EntityUID)
+		if !ok {
+			im.report.Warnings = append(im.report.Warnings,
+				fmt.Sprintf("The %s %s of the custom field values isn't in the archive.", v.EntityType, v.EntityUID))
+
+			continue
+		}
+
+		im.customFieldValues++
+
+		im.addStream(farmimport.AggregateCustomFieldValues, entityUID, []interface{}{customfield.CustomFieldValuesChanged{
+			EntityUID:   entityUID,
+			FarmUID:     im.report.FarmUID,
+			EntityType:  v.EntityType,
+			Values:      v.Values,
+			ChangedDate: v.UpdatedDate,
+		}})
+	}
+}
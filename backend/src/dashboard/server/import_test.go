@@ -0,0 +1,250 @@
+package server_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/dashboard/server"
+	"github.com/usetania/tania-core/src/farmimport"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// maxPhotoSize is the upload size of the imports of the tests.
+const maxPhotoSize = 1024
+
+func TestReadFarmImport(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+	areaUID, _ := uuid.NewV4()
+	materialUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	taskUID, _ := uuid.NewV4()
+	now := time.Date(2026, time.October, 14, 8, 0, 0, 0, time.UTC)
+
+	seed, err := assetsdomain.CreateMaterialTypeSeed(assetsdomain.PlantTypeVegetable)
+	require.Nil(t, err)
+
+	export := server.FarmExport{
+		Farm: assetsstorage.FarmRead{UID: farmUID, Name: "Wildmere Farm", AreaWalkOrder: []uuid.UUID{areaUID}},
+		Areas: []assetsstorage.AreaRead{{
+			UID: areaUID, Name: "North Bed", Type: assetsdomain.AreaTypeGrowing,
+			Farm: assetsstorage.AreaFarm{UID: farmUID, Name: "Wildmere Farm"},
+		}},
+		Materials:     []assetsstorage.MaterialRead{{UID: materialUID, Name: "Tomato", Type: seed}},
+		MaterialTypes: []server.ExportMaterialType{{MaterialUID: materialUID, Code: seed.Code()}},
+		Crops: []growthstorage.CropRead{{
+			UID: cropUID, BatchID: "tom-14oct", Status: growthdomain.CropActive, Type: "SEEDING",
+			Inventory:   growthstorage.Inventory{UID: materialUID},
+			InitialArea: growthstorage.InitialArea{AreaUID: areaUID, InitialQuantity: 10, CurrentQuantity: 10},
+		}},
+		Tasks: []taskstorage.TaskRead{{
+			UID: taskUID, Title: "Water the tomatoes", Status: tasksdomain.TaskStatusCompleted,
+			Domain:        tasksdomain.TaskDomainCropCode,
+			DomainDetails: tasksdomain.TaskDomainCrop{MaterialID: &materialUID, AreaID: &areaUID},
+			AssetID:       &cropUID,
+			CompletedDate: &now,
+		}},
+	}
+
+	archive := &bytes.Buffer{}
+	require.Nil(t, server.WriteFarmExport(archive, export, false, now))
+
+	// When
+	farmImport, err := server.ReadFarmImport(archive.Bytes(), maxPhotoSize)
+
+	// Then
+	require.Nil(t, err)
+
+	newFarmUID := farmImport.Report.FarmUID
+	newAreaUID := farmImport.Report.IDs["areas"][areaUID]
+	newMaterialUID := farmImport.Report.IDs["materials"][materialUID]
+	newCropUID := farmImport.Report.IDs["crops"][cropUID]
+
+	assert.NotEqual(t, farmUID, newFarmUID)
+	assert.Equal(t, farmUID, farmImport.Report.SourceFarmUID)
+	assert.Equal(t, 1, farmImport.Report.Counts["tasks"])
+	assert.Empty(t, farmImport.Report.Warnings)
+
+	streams := map[string]farmimport.Stream{}
+	for _, v := range farmImport.Streams {
+		streams[v.Aggregate] = v
+	}
+
+	assert.Equal(t, newAreaUID, streams[farmimport.AggregateArea].UID)
+	assert.Equal(t, newFarmUID, streams[farmimport.AggregateArea].Events[0].(assetsdomain.AreaCreated).FarmUID)
+
+	material := streams[farmimport.AggregateMaterial].Events[0].(assetsdomain.MaterialCreated)
+	assert.Equal(t, seed, material.Type)
+
+	crop := streams[farmimport.AggregateCrop].Events[0].(growthdomain.CropBatchCreated)
+	assert.Equal(t, newCropUID, crop.UID)
+	assert.Equal(t, newAreaUID, crop.InitialAreaUID)
+	assert.Equal(t, newMaterialUID, crop.InventoryUID)
+
+	taskEvents := streams[farmimport.AggregateTask].Events
+	task := taskEvents[0].(tasksdomain.TaskCreated)
+	assert.Equal(t, newCropUID, *task.AssetID)
+	assert.Equal(t, tasksdomain.TaskDomainCrop{MaterialID: &newMaterialUID, AreaID: &newAreaUID}, task.DomainDetails)
+	assert.Equal(t, tasksdomain.TaskCompletedCode, taskEvents[1].(tasksdomain.TaskCompleted).Status)
+}
+
+func TestReadFarmImportAreaSanitations(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+	areaUID, _ := uuid.NewV4()
+	sanitationUID, _ := uuid.NewV4()
+	materialUID, _ := uuid.NewV4()
+	missingMaterialUID, _ := uuid.NewV4()
+	now := time.Date(2026, time.October, 14, 8, 0, 0, 0, time.UTC)
+
+	chemical, err := assetsdomain.CreateMaterialTypeAgrochemical(assetsdomain.ChemicalTypeDisinfectant)
+	require.Nil(t, err)
+
+	export := server.FarmExport{
+		Farm: assetsstorage.FarmRead{UID: farmUID, Name: "Wildmere Farm"},
+		Areas: []assetsstorage.AreaRead{{
+			UID: areaUID, Name: "North Bed", Type: assetsdomain.AreaTypeGrowing,
+			Sanitations: []assetsstorage.AreaSanitation{{
+				UID: sanitationUID, Method: assetsdomain.SanitationMethodDisinfection, SanitizedDate: now,
+				Materials: []assetsstorage.AreaSanitationMaterial{
+					{MaterialUID: materialUID, MaterialName: "Bleach", Quantity: 2},
+					{MaterialUID: missingMaterialUID, MaterialName: "Lime", Quantity: 1},
+				},
+			}},
+		}},
+		Materials:     []assetsstorage.MaterialRead{{UID: materialUID, Name: "Bleach", Type: chemical}},
+		MaterialTypes: []server.ExportMaterialType{{MaterialUID: materialUID, Code: chemical.Code()}},
+	}
+
+	archive := &bytes.Buffer{}
+	require.Nil(t, server.WriteFarmExport(archive, export, false, now))
+
+	// When
+	farmImport, err := server.ReadFarmImport(archive.Bytes(), maxPhotoSize)
+
+	// Then
+	require.Nil(t, err)
+
+	var sanitized assetsdomain.AreaSanitized
+
+	for _, v := range farmImport.Streams {
+		if v.Aggregate != farmimport.AggregateArea {
+			continue
+		}
+
+		for _, e := range v.Events {
+			if event, ok := e.(assetsdomain.AreaSanitized); ok {
+				sanitized = event
+			}
+		}
+	}
+
+	assert.Equal(t, farmImport.Report.IDs["area_sanitations"][sanitationUID], sanitized.UID)
+	assert.Equal(t, farmImport.Report.IDs["areas"][areaUID], sanitized.AreaUID)
+	assert.Equal(t, now, sanitized.SanitizedDate)
+	assert.Len(t, sanitized.Materials, 2)
+	assert.Equal(t, farmImport.Report.IDs["materials"][materialUID], sanitized.Materials[0].MaterialUID)
+	assert.Equal(t, uuid.UUID{}, sanitized.Materials[1].MaterialUID)
+	assert.Equal(t, "Lime", sanitized.Materials[1].MaterialName)
+	assert.Len(t, farmImport.Report.Warnings, 1)
+}
+
+func TestReadFarmImportSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+
+	archive := &bytes.Buffer{}
+	writer := zip.NewWriter(archive)
+
+	file, err := writer.Create(server.ExportManifestFile)
+	require.Nil(t, err)
+	require.Nil(t, json.NewEncoder(file).Encode(server.ExportManifest{SchemaVersion: 1, FarmUID: farmUID}))
+	require.Nil(t, writer.Close())
+
+	// When
+	_, err = server.ReadFarmImport(archive.Bytes(), maxPhotoSize)
+
+	// Then
+	assert.Equal(t, server.NewRequestValidationError(server.InvalidOption, "schema_version"), err)
+}
+
+// areaPhotoArchive is the export archive of a farm with an area, whose photo file has the content.
+func areaPhotoArchive(t *testing.T, content []byte) []byte {
+	t.Helper()
+
+	farmUID, _ := uuid.NewV4()
+	areaUID, _ := uuid.NewV4()
+
+	archive := &bytes.Buffer{}
+	writer := zip.NewWriter(archive)
+
+	file, err := writer.Create(server.ExportManifestFile)
+	require.Nil(t, err)
+	require.Nil(t, json.NewEncoder(file).Encode(server.ExportManifest{
+		SchemaVersion: server.ExportSchemaVersion,
+		FarmUID:       farmUID,
+		Photos: []server.ExportPhoto{{
+			EntityType: assetsdomain.CustomFieldEntityArea, EntityUID: areaUID, Path: "photos/north.png",
+			MimeType: "image/gif",
+		}},
+	}))
+
+	file, err = writer.Create(server.ExportFarmFile)
+	require.Nil(t, err)
+	require.Nil(t, json.NewEncoder(file).Encode(server.FarmExport{
+		Farm: assetsstorage.FarmRead{UID: farmUID, Name: "Wildmere Farm"},
+		Areas: []assetsstorage.AreaRead{{
+			UID: areaUID, Name: "North Bed", Type: assetsdomain.AreaTypeGrowing,
+			Farm:  assetsstorage.AreaFarm{UID: farmUID, Name: "Wildmere Farm"},
+			Photo: assetsstorage.AreaPhoto{Filename: "north.png", MimeType: "image/gif"},
+		}},
+	}))
+
+	file, err = writer.Create("photos/north.png")
+	require.Nil(t, err)
+
+	_, err = file.Write(content)
+	require.Nil(t, err)
+	require.Nil(t, writer.Close())
+
+	return archive.Bytes()
+}
+
+func TestReadFarmImportPhotos(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...)
+	page := []byte("<html><script>alert(1)</script></html>")
+	large := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, maxPhotoSize)...)
+
+	// When
+	farmImport, err := server.ReadFarmImport(areaPhotoArchive(t, png), maxPhotoSize)
+	_, pageErr := server.ReadFarmImport(areaPhotoArchive(t, page), maxPhotoSize)
+	_, largeErr := server.ReadFarmImport(areaPhotoArchive(t, large), maxPhotoSize)
+
+	// Then
+	require.Nil(t, err)
+	assert.Len(t, farmImport.Photos, 1)
+	assert.Equal(t, png, farmImport.Photos[0].Content)
+	assert.Contains(t, farmImport.Photos[0].Filename, ".png")
+	assert.Equal(t, server.NewRequestValidationError(server.InvalidOption, "photos"), pageErr)
+	assert.Equal(t, server.NewRequestValidationError(server.InvalidOption, "photos"), largeErr)
+}
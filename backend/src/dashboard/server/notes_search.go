@@ -0,0 +1,137 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/auth"
+	"github.com/usetania/tania-core/src/helper/paginationhelper"
+	"github.com/usetania/tania-core/src/notesearch"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// NoteHit is a note found by the notes search, with the entity it's on. The task descriptions
+// have no note id.
+type NoteHit struct {
+	Entity      NoteEntity `json:"entity"`
+	NoteUID     *uuid.UUID `json:"note_id"`
+	Content     string     `json:"content"`
+	Highlight   string     `json:"highlight"`
+	CreatedDate time.Time  `json:"created_date"`
+}
+
+// NoteEntity is the entity of a note. The name of a crop is its batch id, the one of a task its title.
+type NoteEntity struct {
+	Type     string    `json:"type"`
+	UID      uuid.UUID `json:"uid"`
+	Name     string    `json:"name"`
+	Archived bool      `json:"archived"`
+}
+
+// SearchNotes returns the notes of the areas, reservoirs and crops of the farm, and the descriptions of its tasks,
+// containing all the words of the `q` param, the latest first. The archived crops and tasks are included.
+// The highlight is the HTML escaped fragment of the note around its first match, the matches in <mark> tags.
+func (s *DashboardServer) SearchNotes(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	terms, err := notesearch.Terms(c.QueryParam("q"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(Required, "q"))
+	}
+
+	page, limit, err := paginationhelper.ParsePagination(c.QueryParam("page"), c.QueryParam("limit"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	hits, err := s.findNoteHits(farmUID, terms, s.redactsTaskDescription(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	start, end := paginationhelper.PageBounds(len(hits), page, limit)
+
+	data := make(map[string]interface{})
+	data["data"] = hits[start:end]
+	data["total"] = len(hits)
+	data["page"] = page
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// findNoteHits leaves the task descriptions out when they are redacted, their match would tell their words.
+func (s *DashboardServer) findNoteHits(farmUID uuid.UUID, terms []string, redactsTasks bool) ([]NoteHit, error) {
+	found, err := s.NoteSearchStore.FindAll(farmUID, terms)
+	if err != nil {
+		return nil, err
+	}
+
+	areaNames, err := s.findAreaNames(farmUID)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := []NoteHit{}
+
+	for _, v := range found {
+		if !notesearch.Matches(v.Content, terms) {
+			continue
+		}
+
+		if v.EntityType == notesearch.EntityTask {
+			if redactsTasks {
+				continue
+			}
+
+			inFarm, err := s.taskInFarm(taskstorage.TaskRead{Domain: v.TaskDomain, AssetID: v.TaskAssetUID},
+				farmUID, areaNames)
+			if err != nil {
+				return nil, err
+			}
+
+			if !inFarm {
+				continue
+			}
+		}
+
+		hit := NoteHit{
+			Entity: NoteEntity{
+				Type:     v.EntityType,
+				UID:      v.EntityUID,
+				Name:     v.EntityName,
+				Archived: v.Archived,
+			},
+			Content:     v.Content,
+			Highlight:   notesearch.Highlight(v.Content, terms),
+			CreatedDate: v.CreatedDate,
+		}
+
+		if v.NoteUID != uuid.Nil {
+			noteUID := v.NoteUID
+			hit.NoteUID = &noteUID
+		}
+
+		hits = append(hits, hit)
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].CreatedDate.After(hits[j].CreatedDate)
+	})
+
+	return hits, nil
+}
+
+// redactsTaskDescription tells whether the task descriptions are redacted for the user of the request.
+func (s *DashboardServer) redactsTaskDescription(c echo.Context) bool {
+	if s.Redactor == nil {
+		return false
+	}
+
+	return auth.Redacts(taskstorage.TaskRead{}, "Description", s.Redactor.RoleOf(c))
+}
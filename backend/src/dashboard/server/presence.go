@@ -0,0 +1,102 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/farmscope"
+	"github.com/usetania/tania-core/src/presence"
+)
+
+// SavePresenceHeartbeat records the caller as active on the farm. The users post it with the kind user, the
+// gateways with the kind gateway, their device ID and name. The gateways authenticate with the token of a user
+// of the farm, there are no API keys for the devices.
+func (s *DashboardServer) SavePresenceHeartbeat(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	entry := presence.Entry{FarmUID: farmUID, Kind: c.FormValue("kind"), LastSeen: time.Now()}
+
+	switch entry.Kind {
+	case "", presence.KindUser:
+		userUID, _ := c.Get(farmscope.UserKey).(uuid.UUID)
+
+		entry.Kind = presence.KindUser
+		entry.ID = userUID.String()
+
+		entry.Name, err = s.findUsername(userUID)
+		if err != nil {
+			return Error(c, err)
+		}
+
+	case presence.KindGateway:
+		entry.ID = strings.TrimSpace(c.FormValue("device_id"))
+		if entry.ID == "" {
+			return Error(c, NewRequestValidationError(Required, "device_id"))
+		}
+
+		entry.Name = strings.TrimSpace(c.FormValue("name"))
+		if entry.Name == "" {
+			entry.Name = entry.ID
+		}
+
+	default:
+		return Error(c, NewRequestValidationError(InvalidOption, "kind"))
+	}
+
+	entry, err = s.PresenceRegistry.Heartbeat(entry)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]presence.Entry)
+	data["data"] = entry
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// FindFarmPresence lists the users and the gateways active on the farm, the last seen first.
+func (s *DashboardServer) FindFarmPresence(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string][]presence.Entry)
+	data["data"] = s.PresenceRegistry.Active(farmUID, time.Now())
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// RemovePresenceGateway forgets a gateway taken down, so the dashboard stops warning it went silent.
+func (s *DashboardServer) RemovePresenceGateway(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	entry, found, err := s.PresenceRegistry.Remove(farmUID, presence.KindGateway, c.Param("device_id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if !found {
+		return Error(c, NewRequestValidationError(NotFound, "device_id"))
+	}
+
+	data := make(map[string]presence.Entry)
+	data["data"] = entry
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// staleGatewayThreshold is how long a gateway is silent before the dashboard warns about it.
+func staleGatewayThreshold() time.Duration {
+	return time.Duration(*config.Config.PresenceStaleGateway) * time.Minute
+}
@@ -0,0 +1,383 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/gofrs/uuid"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+	"github.com/usetania/tania-core/src/helper/pdfhelper"
+	"github.com/usetania/tania-core/src/notification"
+	"github.com/usetania/tania-core/src/reportmail"
+)
+
+// costCentreReportDays is the period of the mailed cost centre report, the week before the send.
+const costCentreReportDays = 7
+
+// RenderReport renders the report of the subscription for the reportmail scheduler, as of the date
+// in the timezone of the subscription. The PDF reports are attached to a short HTML mail.
+func (s *DashboardServer) RenderReport(subscription reportmail.Subscription, date time.Time) (notification.Mail, error) {
+	loc, err := subscription.Location()
+	if err != nil {
+		return notification.Mail{}, err
+	}
+
+	date = date.In(loc)
+
+	result := <-s.FarmReadQuery.FindByID(subscription.FarmUID)
+	if result.Error != nil {
+		return notification.Mail{}, result.Error
+	}
+
+	farm, ok := result.Result.(assetsstorage.FarmRead)
+	if !ok {
+		return notification.Mail{}, errors.New("internal server error. error type assertion")
+	}
+
+	if farm.UID == (uuid.UUID{}) {
+		return notification.Mail{}, errors.New("farm of the report subscription not found")
+	}
+
+	var (
+		title string
+		html  string
+		pdf   []byte
+	)
+
+	switch subscription.ReportType {
+	case reportmail.ReportDashboard:
+		title = "Dashboard summary"
+
+		dashboard, err := s.findFarmDashboard(farm.UID, date)
+		if err != nil {
+			return notification.Mail{}, err
+		}
+
+		html, err = RenderDashboardHTML(farm.Name, date, dashboard)
+		if err != nil {
+			return notification.Mail{}, err
+		}
+
+		pdf = RenderDashboardPDF(farm.Name, date, dashboard)
+
+	case reportmail.ReportTasks:
+		title = "Worksheet"
+
+		tasks, err := s.findWorksheetTasks(farm.UID, date)
+		if err != nil {
+			return notification.Mail{}, err
+		}
+
+		worksheet := domain.BuildWorksheet(farm.Name, date, "", farm.AreaWalkOrder, tasks)
+
+		html, err = RenderWorksheetHTML(worksheet)
+		if err != nil {
+			return notification.Mail{}, err
+		}
+
+		pdf = RenderWorksheetPDF(worksheet)
+
+	case reportmail.ReportCostCentre:
+		title = "Cost centre report"
+
+		to := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+		from := to.AddDate(0, 0, -costCentreReportDays)
+
+		rows, err := s.findCostCentreRows(farm.UID, from, to)
+		if err != nil {
+			return notification.Mail{}, err
+		}
+
+		period := fmt.Sprintf("%s to %s", from.Format("2006-01-02"), to.AddDate(0, 0, -1).Format("2006-01-02"))
+
+		html, err = RenderCostCentreHTML(farm.Name, period, rows)
+		if err != nil {
+			return notification.Mail{}, err
+		}
+
+		pdf = RenderCostCentrePDF(farm.Name, period, rows)
+
+	default:
+		return notification.Mail{}, errors.New("unknown report type " + subscription.ReportType)
+	}
+
+	mail := notification.Mail{
+		Subject: fmt.Sprintf("%s - %s - %s", farm.Name, title, date.Format("2006-01-02")),
+		HTML:    html,
+	}
+
+	if subscription.Format == reportmail.FormatPDF {
+		mail.HTML = fmt.Sprintf("<p>The %s of %s is attached.</p>",
+			template.HTMLEscapeString(title), template.HTMLEscapeString(farm.Name))
+		mail.Attachments = []notification.Attachment{{
+			Filename:    fmt.Sprintf("%s-%s.pdf", subscription.ReportType, date.Format("2006-01-02")),
+			ContentType: "application/pdf",
+			Content:     pdf,
+		}}
+	}
+
+	return mail, nil
+}
+
+type reportLine struct {
+	Label string
+	Value string
+}
+
+func dashboardLines(dashboard Dashboard) []reportLine {
+	return []reportLine{
+		{"Open tasks", fmt.Sprint(dashboard.OpenTasks)},
+		{"Overdue tasks", fmt.Sprint(dashboard.OverdueTasks)},
+		{"Active batches", fmt.Sprint(dashboard.ActiveBatches)},
+		{"Total plants", fmt.Sprint(dashboard.TotalPlants)},
+		{"Low stock materials", fmt.Sprint(dashboard.LowStockMaterials)},
+		{"Expired certifications", fmt.Sprint(len(dashboard.ExpiredCertifications))},
+	}
+}
+
+//nolint:gochecknoglobals
+var reportStyle = template.CSS(`body { font-family: Helvetica, Arial, sans-serif; font-size: 11pt; }
+h1 { font-size: 16pt; margin: 0; }
+.meta { margin: 0.3em 0 1em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #000; padding: 6px 4px; text-align: left; }
+th { background: #eee; }
+.amount { text-align: right; }`)
+
+var dashboardTemplate = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.FarmName}} - {{.Date.Format "2006-01-02"}}</title>
+<style>{{.Style}}</style>
+</head>
+<body>
+<h1>{{.FarmName}}</h1>
+<div class="meta">Dashboard summary of {{.Date.Format "Monday, 2 January 2006"}}</div>
+<table>
+{{range .Lines}}<tr><th>{{.Label}}</th><td class="amount">{{.Value}}</td></tr>
+{{end}}</table>
+{{if .Certifications}}
+<p>Expired certifications:</p>
+<ul>
+{{range .Certifications}}<li>{{.CertificationType}} {{.CertificateNumber}}, expired on {{.ExpiryDate.Format "2006-01-02"}}</li>
+{{end}}</ul>
+{{end}}
+</body>
+</html>
+`))
+
+func RenderDashboardHTML(farmName string, date time.Time, dashboard Dashboard) (string, error) {
+	buf := bytes.Buffer{}
+
+	err := dashboardTemplate.Execute(&buf, map[string]interface{}{
+		"FarmName":       farmName,
+		"Date":           date,
+		"Style":          reportStyle,
+		"Lines":          dashboardLines(dashboard),
+		"Certifications": dashboard.ExpiredCertifications,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func RenderDashboardPDF(farmName string, date time.Time, dashboard Dashboard) []byte {
+	lines := dashboardLines(dashboard)
+
+	for _, v := range dashboard.ExpiredCertifications {
+		lines = append(lines, reportLine{
+			Label: "Expired " + v.CertificationType,
+			Value: v.ExpiryDate.Format("2006-01-02"),
+		})
+	}
+
+	return renderReportTablePDF(farmName, "Dashboard summary of "+date.Format("Monday, 2 January 2006"),
+		[]string{"", ""}, []float64{300, 100}, linesToRows(lines))
+}
+
+var costCentreTemplate = template.Must(template.New("cost_centre").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.FarmName}} - {{.Period}}</title>
+<style>{{.Style}}</style>
+</head>
+<body>
+<h1>{{.FarmName}}</h1>
+<div class="meta">Cost centre report from {{.Period}}</div>
+{{if .Rows}}<table>
+<tr><th>Area</th><th>Labor cost</th><th>Material cost</th><th>Total cost</th></tr>
+{{range .Rows}}<tr><td>{{.AreaName}}</td><td class="amount">{{printf "%.2f" .LaborCost}}</td><td class="amount">{{printf "%.2f" .MaterialCost}}</td><td class="amount">{{printf "%.2f" .TotalCost}}</td></tr>
+{{end}}</table>
+{{else}}
+<p>No costs in this period.</p>
+{{end}}
+</body>
+</html>
+`))
+
+func RenderCostCentreHTML(farmName, period string, rows []CostCentreRow) (string, error) {
+	buf := bytes.Buffer{}
+
+	err := costCentreTemplate.Execute(&buf, map[string]interface{}{
+		"FarmName": farmName,
+		"Period":   period,
+		"Style":    reportStyle,
+		"Rows":     rows,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func RenderCostCentrePDF(farmName, period string, rows []CostCentreRow) []byte {
+	values := [][]string{}
+
+	for _, v := range rows {
+		values = append(values, []string{
+			v.AreaName,
+			fmt.Sprintf("%.2f", v.LaborCost),
+			fmt.Sprintf("%.2f", v.MaterialCost),
+			fmt.Sprintf("%.2f", v.TotalCost),
+		})
+	}
+
+	return renderReportTablePDF(farmName, "Cost centre report from "+period,
+		[]string{"Area", "Labor cost", "Material cost", "Total cost"}, []float64{215, 100, 100, 100}, values)
+}
+
+func linesToRows(lines []reportLine) [][]string {
+	rows := [][]string{}
+	for _, v := range lines {
+		rows = append(rows, []string{v.Label, v.Value})
+	}
+
+	return rows
+}
+
+// renderReportTablePDF lays a titled table out on A4 pages. The header is skipped when all its titles are empty.
+func renderReportTablePDF(farmName, subtitle string, header []string, widths []float64, rows [][]string) []byte {
+	return renderReportSectionsPDF(farmName, subtitle, []reportSection{{
+		Header: header,
+		Widths: widths,
+		Rows:   rows,
+		Empty:  "No data in this period.",
+	}}, nil)
+}
+
+// reportSection is a table of a report, under its title when it has one.
+type reportSection struct {
+	Title  string
+	Header []string
+	Widths []float64
+	Rows   [][]string
+	Empty  string
+}
+
+// renderReportSectionsPDF lays the tables out one after the other on A4 pages, then a line to sign
+// for each of the signatures.
+func renderReportSectionsPDF(farmName, subtitle string, sections []reportSection, signatures []string) []byte {
+	const (
+		margin    = 40.0
+		rowHeight = 22.0
+	)
+
+	doc := pdfhelper.NewDocument()
+	doc.AddPage()
+
+	y := margin + 16
+
+	doc.Text(margin, y, 16, true, farmName)
+
+	y += 18
+
+	doc.Text(margin, y, 10, false, subtitle)
+
+	y += 16
+
+	for _, section := range sections {
+		row := func(values []string, bold bool) {
+			x := margin
+			for i, width := range section.Widths {
+				doc.Rect(x, y, width, rowHeight)
+				doc.Text(x+4, y+14, 9, bold, pdfhelper.Truncate(values[i], width-8, 9))
+
+				x += width
+			}
+
+			y += rowHeight
+		}
+
+		hasHeader := false
+
+		for _, v := range section.Header {
+			if v != "" {
+				hasHeader = true
+			}
+		}
+
+		if section.Title != "" {
+			if y+24+rowHeight*2 > pdfhelper.PageHeight-margin {
+				doc.AddPage()
+
+				y = margin
+			}
+
+			y += 18
+
+			doc.Text(margin, y, 12, true, section.Title)
+
+			y += 6
+		}
+
+		if hasHeader {
+			row(section.Header, true)
+		}
+
+		if len(section.Rows) == 0 {
+			doc.Text(margin, y+14, 10, false, section.Empty)
+
+			y += rowHeight
+		}
+
+		for _, v := range section.Rows {
+			if y+rowHeight > pdfhelper.PageHeight-margin {
+				doc.AddPage()
+
+				y = margin
+
+				if hasHeader {
+					row(section.Header, true)
+				}
+			}
+
+			row(v, false)
+		}
+	}
+
+	if len(signatures) > 0 && y+40*float64(len(signatures)) > pdfhelper.PageHeight-margin {
+		doc.AddPage()
+
+		y = margin
+	}
+
+	for _, v := range signatures {
+		y += 40
+
+		doc.Text(margin, y, 10, false, v+":")
+		doc.Line(margin+90, y, margin+300, y)
+		doc.Text(margin+320, y, 10, false, "Date:")
+		doc.Line(margin+355, y, margin+515, y)
+	}
+
+	return doc.Bytes()
+}
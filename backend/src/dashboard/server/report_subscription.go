@@ -0,0 +1,179 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/reportmail"
+)
+
+// ReportSubscription is a subscription with its latest delivery, so a failed send is visible in the list.
+type ReportSubscription struct {
+	reportmail.Subscription
+	LastDelivery *reportmail.Delivery `json:"last_delivery"`
+}
+
+// StartReportScheduler sends the reports of the subscriptions when they are due.
+// The scheduler waits while paused is true.
+func (s *DashboardServer) StartReportScheduler(paused func() bool) {
+	s.ReportScheduler.Start(paused)
+}
+
+// SaveReportSubscription subscribes the recipients, a comma separated list of addresses, to the weekly report.
+func (s *DashboardServer) SaveReportSubscription(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	for _, field := range []string{"report_type", "day_of_week", "time_of_day", "recipients"} {
+		if c.FormValue(field) == "" {
+			return Error(c, NewRequestValidationError(Required, field))
+		}
+	}
+
+	subscription, err := reportmail.NewSubscription(
+		farmUID,
+		c.FormValue("report_type"),
+		c.FormValue("format"),
+		c.FormValue("day_of_week"),
+		c.FormValue("time_of_day"),
+		c.FormValue("timezone"),
+		strings.Split(c.FormValue("recipients"), ","),
+		time.Now(),
+	)
+
+	var validationErr reportmail.ValidationError
+	if errors.As(err, &validationErr) {
+		return Error(c, NewRequestValidationError(InvalidOption, validationErr.Field))
+	}
+
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = s.ReportScheduler.Store.SaveSubscription(subscription)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]ReportSubscription)
+	data["data"] = ReportSubscription{Subscription: subscription}
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *DashboardServer) FindAllReportSubscriptions(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	subscriptions, err := s.ReportScheduler.Store.FindSubscriptionsByFarm(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	results := []ReportSubscription{}
+
+	for _, v := range subscriptions {
+		deliveries, err := s.ReportScheduler.Store.FindDeliveries(v.UID, 1)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		result := ReportSubscription{Subscription: v}
+		if len(deliveries) > 0 {
+			result.LastDelivery = &deliveries[0]
+		}
+
+		results = append(results, result)
+	}
+
+	data := make(map[string][]ReportSubscription)
+	data["data"] = results
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// RemoveReportSubscription deletes the subscription. Its pending retries are cancelled, so nothing is sent anymore.
+func (s *DashboardServer) RemoveReportSubscription(c echo.Context) error {
+	subscription, err := s.findReportSubscription(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = s.ReportScheduler.Delete(subscription.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]ReportSubscription)
+	data["data"] = ReportSubscription{Subscription: subscription}
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// RunReportSubscription sends the report right away, to check the subscription. The delivery is returned
+// with its error when the send failed, it's retried like the scheduled ones.
+func (s *DashboardServer) RunReportSubscription(c echo.Context) error {
+	subscription, err := s.findReportSubscription(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	delivery, err := s.ReportScheduler.RunNow(subscription, time.Now())
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]reportmail.Delivery)
+	data["data"] = delivery
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *DashboardServer) FindReportSubscriptionDeliveries(c echo.Context) error {
+	subscription, err := s.findReportSubscription(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	deliveries, err := s.ReportScheduler.FindDeliveries(subscription.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string][]reportmail.Delivery)
+	data["data"] = deliveries
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// findReportSubscription finds the subscription of the params, which has to belong to the farm of the route.
+func (s *DashboardServer) findReportSubscription(c echo.Context) (reportmail.Subscription, error) {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return reportmail.Subscription{}, err
+	}
+
+	uid, err := uuid.FromString(c.Param("subscription_id"))
+	if err != nil {
+		return reportmail.Subscription{}, NewRequestValidationError(NotFound, "subscription_id")
+	}
+
+	subscription, err := s.ReportScheduler.Store.FindSubscription(uid)
+	if err != nil {
+		return reportmail.Subscription{}, err
+	}
+
+	if subscription == nil || subscription.FarmUID != farmUID {
+		return reportmail.Subscription{}, NewRequestValidationError(NotFound, "subscription_id")
+	}
+
+	return *subscription, nil
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"math"
+	"time"
+
+	"github.com/gofrs/uuid"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+	"github.com/usetania/tania-core/src/presence"
+)
+
+type Dashboard struct {
+	FarmUID               uuid.UUID                             `json:"farm_id"`
+	OpenTasks             int                                   `json:"open_tasks"`
+	OverdueTasks          int                                   `json:"overdue_tasks"`
+	ActiveBatches         int                                   `json:"active_batches"`
+	TotalPlants           int                                   `json:"total_plants"`
+	LowStockMaterials     int                                   `json:"low_stock_materials"`
+	ExpiredCertifications []assetsstorage.FarmCertificationRead `json:"expired_certifications"`
+
+	// The gateways of the farm which reported before and went silent for longer than the threshold.
+	StaleGateways []presence.Entry `json:"stale_gateways"`
+}
+
+// Drift is a counter whose incremental value didn't match the recomputed one.
+// FarmUID is empty for the counters shared by all the farms.
+type Drift struct {
+	FarmUID    *uuid.UUID `json:"farm_id"`
+	Counter    string     `json:"counter"`
+	Current    int        `json:"current"`
+	Recomputed int        `json:"recomputed"`
+}
+
+type CostCentreRow struct {
+	AreaID       uuid.UUID `json:"area_id"`
+	AreaName     string    `json:"area_name"`
+	LaborCost    float64   `json:"labor_cost"`
+	MaterialCost float64   `json:"material_cost"`
+	TotalCost    float64   `json:"total_cost"`
+}
+
+func MapToCostCentreRows(totals []domain.CostCentreTotal) []CostCentreRow {
+	rows := []CostCentreRow{}
+
+	for _, v := range totals {
+		rows = append(rows, CostCentreRow{
+			AreaID:       v.AreaUID,
+			AreaName:     v.AreaName,
+			LaborCost:    roundCents(v.LabourCost),
+			MaterialCost: roundCents(v.MaterialCost),
+			TotalCost:    roundCents(v.TotalCost()),
+		})
+	}
+
+	return rows
+}
+
+// AreaProductivityRow is an area of the productivity comparison, the value is the one of the compared metric.
+type AreaProductivityRow struct {
+	AreaID       uuid.UUID `json:"area_id"`
+	AreaName     string    `json:"area_name"`
+	AreaSizeM2   float64   `json:"area_size_m2"`
+	TotalYieldKg float64   `json:"total_yield_kg"`
+	YieldPerSqm  float64   `json:"yield_per_sqm"`
+	Value        float64   `json:"value"`
+	Rank         int       `json:"rank"`
+}
+
+func MapToAreaProductivityRows(ranked []domain.RankedAreaProductivity) []AreaProductivityRow {
+	rows := []AreaProductivityRow{}
+
+	for _, v := range ranked {
+		rows = append(rows, AreaProductivityRow{
+			AreaID:       v.AreaUID,
+			AreaName:     v.AreaName,
+			AreaSizeM2:   v.SizeM2,
+			TotalYieldKg: roundGrams(v.YieldGram / 1000),
+			YieldPerSqm:  roundGrams(v.YieldPerSqm()),
+			Value:        roundGrams(v.Value),
+			Rank:         v.Rank,
+		})
+	}
+
+	return rows
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// roundGrams rounds the kilograms to the gram, and the rates to three decimals.
+func roundGrams(amount float64) float64 {
+	return math.Round(amount*1000) / 1000
+}
+
+func MapToDashboard(farmUID uuid.UUID, stats domain.Stats) Dashboard {
+	farm := stats.FarmStats(farmUID)
+
+	return Dashboard{
+		FarmUID:           farmUID,
+		OpenTasks:         stats.OpenTasks,
+		OverdueTasks:      stats.OverdueTasks,
+		ActiveBatches:     farm.ActiveBatches,
+		TotalPlants:       farm.TotalPlants,
+		LowStockMaterials: stats.LowStockMaterials,
+	}
+}
+
+// expiredCertifications lists the active certifications past their expiry date, which have to be renewed or revoked.
+func expiredCertifications(certifications []assetsstorage.FarmCertificationRead, now time.Time) []assetsstorage.FarmCertificationRead {
+	expired := []assetsstorage.FarmCertificationRead{}
+
+	for _, v := range certifications {
+		if v.Status == assetsdomain.CertificationStatusActive && v.ExpiryDate.Before(now) {
+			v.Status = assetsdomain.CertificationStatusExpired
+			expired = append(expired, v)
+		}
+	}
+
+	return expired
+}
@@ -0,0 +1,258 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/changefeed"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// FarmSync is what changed on the farm since the since param, for the mobile clients to merge in their copy.
+// SyncToken is the since param of the next sync.
+type FarmSync struct {
+	FarmsUpdated []SyncFarm     `json:"farms_updated"`
+	AreasUpdated []SyncArea     `json:"areas_updated"`
+	CropsUpdated []SyncCrop     `json:"crops_updated"`
+	TasksUpdated []SyncTask     `json:"tasks_updated"`
+	Deletions    []SyncDeletion `json:"deletions"`
+	SyncToken    string         `json:"sync_token"`
+}
+
+type SyncFarm struct {
+	assetsstorage.FarmRead
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SyncArea struct {
+	assetsstorage.AreaRead
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SyncCrop struct {
+	growthstorage.CropRead
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SyncTask struct {
+	taskstorage.TaskRead
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SyncDeletion is a record the clients remove from their copy.
+type SyncDeletion struct {
+	EntityType string    `json:"entity_type"`
+	UID        uuid.UUID `json:"uid"`
+	DeletedAt  time.Time `json:"deleted_at"`
+}
+
+// GetFarmSync returns the farm, areas, crops and tasks changed since the since param,
+// which is the sync token of the previous sync. Without since, it returns all of them.
+func (s *DashboardServer) GetFarmSync(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	since := time.Time{}
+
+	if value := c.QueryParam("since"); value != "" {
+		since, err = changefeed.ParseSyncToken(value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "since"))
+		}
+	}
+
+	delta, err := changefeed.FindDelta(s.ChangeFeedStore, farmUID, since)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	sync, err := s.findFarmSync(farmUID, delta)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]FarmSync)
+	data["data"] = sync
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *DashboardServer) findFarmSync(farmUID uuid.UUID, delta changefeed.Delta) (FarmSync, error) {
+	sync := FarmSync{
+		FarmsUpdated: []SyncFarm{},
+		AreasUpdated: []SyncArea{},
+		CropsUpdated: []SyncCrop{},
+		TasksUpdated: []SyncTask{},
+		Deletions:    []SyncDeletion{},
+		SyncToken:    delta.SyncToken,
+	}
+
+	for _, v := range delta.Deleted {
+		sync.Deletions = append(sync.Deletions, SyncDeletion{EntityType: v.EntityType, UID: v.UID, DeletedAt: v.ChangedDate})
+	}
+
+	areaNames, err := s.findAreaNames(farmUID)
+	if err != nil {
+		return FarmSync{}, err
+	}
+
+	for _, v := range delta.Updated {
+		// The records gone from the read models since their change are deleted for the clients.
+		found := true
+
+		switch v.EntityType {
+		case changefeed.EntityFarm:
+			farm, err := s.findSyncFarm(v.UID)
+			if err != nil {
+				return FarmSync{}, err
+			}
+
+			found = farm.UID == farmUID
+			if found {
+				sync.FarmsUpdated = append(sync.FarmsUpdated, SyncFarm{FarmRead: farm, UpdatedAt: v.ChangedDate})
+			}
+
+		case changefeed.EntityArea:
+			area, err := s.findSyncArea(v.UID)
+			if err != nil {
+				return FarmSync{}, err
+			}
+
+			found = area.UID != uuid.Nil && area.Farm.UID == farmUID
+			if found {
+				sync.AreasUpdated = append(sync.AreasUpdated, SyncArea{AreaRead: area, UpdatedAt: v.ChangedDate})
+			}
+
+		case changefeed.EntityCrop:
+			crop, err := s.findCrop(v.UID)
+			if err != nil {
+				return FarmSync{}, err
+			}
+
+			found = crop.UID != uuid.Nil && crop.FarmUID == farmUID
+			if found {
+				sync.CropsUpdated = append(sync.CropsUpdated, SyncCrop{CropRead: crop, UpdatedAt: v.ChangedDate})
+			}
+
+		case changefeed.EntityTask:
+			task, err := s.findSyncTask(v.UID)
+			if err != nil {
+				return FarmSync{}, err
+			}
+
+			if task.UID == uuid.Nil {
+				found = false
+
+				break
+			}
+
+			inFarm, err := s.taskInFarm(task, farmUID, areaNames)
+			if err != nil {
+				return FarmSync{}, err
+			}
+
+			if inFarm {
+				sync.TasksUpdated = append(sync.TasksUpdated, SyncTask{TaskRead: task, UpdatedAt: v.ChangedDate})
+			}
+
+		default:
+			// The other entities aren't synced by the mobile clients.
+			continue
+		}
+
+		if !found {
+			sync.Deletions = append(sync.Deletions, SyncDeletion{EntityType: v.EntityType, UID: v.UID, DeletedAt: v.ChangedDate})
+		}
+	}
+
+	return sync, nil
+}
+
+// taskInFarm tells whether the task is on an asset of the farm. The tasks without asset belong to every farm.
+func (s *DashboardServer) taskInFarm(task taskstorage.TaskRead, farmUID uuid.UUID, areaNames map[uuid.UUID]string) (
+	bool, error,
+) {
+	if task.AssetID == nil {
+		return true, nil
+	}
+
+	switch task.Domain {
+	case tasksdomain.TaskDomainAreaCode:
+		_, ok := areaNames[*task.AssetID]
+
+		return ok, nil
+
+	case tasksdomain.TaskDomainCropCode:
+		crop, err := s.findCrop(*task.AssetID)
+		if err != nil {
+			return false, err
+		}
+
+		return crop.FarmUID == farmUID, nil
+
+	case tasksdomain.TaskDomainReservoirCode:
+		result := <-s.ReservoirReadQuery.FindByID(*task.AssetID)
+		if result.Error != nil {
+			return false, result.Error
+		}
+
+		reservoir, ok := result.Result.(assetsstorage.ReservoirRead)
+		if !ok {
+			return false, errors.New("internal server error. error type assertion")
+		}
+
+		return reservoir.Farm.UID == farmUID, nil
+	}
+
+	return true, nil
+}
+
+func (s *DashboardServer) findSyncFarm(farmUID uuid.UUID) (assetsstorage.FarmRead, error) {
+	result := <-s.FarmReadQuery.FindByID(farmUID)
+	if result.Error != nil {
+		return assetsstorage.FarmRead{}, result.Error
+	}
+
+	farm, ok := result.Result.(assetsstorage.FarmRead)
+	if !ok {
+		return assetsstorage.FarmRead{}, errors.New("internal server error. error type assertion")
+	}
+
+	return farm, nil
+}
+
+func (s *DashboardServer) findSyncArea(areaUID uuid.UUID) (assetsstorage.AreaRead, error) {
+	result := <-s.AreaReadQuery.FindByID(areaUID)
+	if result.Error != nil {
+		return assetsstorage.AreaRead{}, result.Error
+	}
+
+	area, ok := result.Result.(assetsstorage.AreaRead)
+	if !ok {
+		return assetsstorage.AreaRead{}, errors.New("internal server error. error type assertion")
+	}
+
+	return area, nil
+}
+
+func (s *DashboardServer) findSyncTask(taskUID uuid.UUID) (taskstorage.TaskRead, error) {
+	result := <-s.TaskReadQuery.FindByID(taskUID)
+	if result.Error != nil {
+		return taskstorage.TaskRead{}, result.Error
+	}
+
+	task, ok := result.Result.(taskstorage.TaskRead)
+	if !ok {
+		return taskstorage.TaskRead{}, errors.New("internal server error. error type assertion")
+	}
+
+	return task, nil
+}
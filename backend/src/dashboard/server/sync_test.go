@@ -0,0 +1,80 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	assetsqueryInMem "github.com/usetania/tania-core/src/assets/query/inmemory"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/auth"
+	"github.com/usetania/tania-core/src/changefeed"
+	"github.com/usetania/tania-core/src/dashboard/server"
+	tasksqueryInMem "github.com/usetania/tania-core/src/tasks/query/inmemory"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type workerRoles struct{}
+
+func (workerRoles) RoleOf(uuid.UUID) auth.Role {
+	return auth.RoleWorker
+}
+
+func TestGetFarmSyncRedactsTheTasks(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+	taskUID, _ := uuid.NewV4()
+
+	taskReadStorage := taskstorage.CreateTaskReadStorage()
+	taskReadStorage.TaskReadMap[taskUID] = taskstorage.TaskRead{
+		UID:         taskUID,
+		Title:       "Spray the tomatoes",
+		Description: "Use the permit PX-4471 of the pesticide",
+	}
+
+	changeFeedStore := changefeed.NewStoreInMemory(changefeed.CreateChangeLogStorage())
+	assert.Nil(t, changeFeedStore.Append(changefeed.Change{
+		EntityType:  changefeed.EntityTask,
+		EntityUID:   taskUID,
+		ChangeType:  changefeed.ChangeCreated,
+		CreatedDate: time.Now(),
+	}))
+
+	s := &server.DashboardServer{
+		AreaReadQuery:   assetsqueryInMem.NewAreaReadQueryInMemory(assetsstorage.CreateAreaReadStorage()),
+		TaskReadQuery:   tasksqueryInMem.NewTaskReadQueryInMemory(taskReadStorage),
+		ChangeFeedStore: changeFeedStore,
+	}
+
+	redactor, err := auth.NewRedactor(workerRoles{}, taskstorage.TaskRead{})
+	assert.Nil(t, err)
+
+	e := echo.New()
+	e.Use(redactor.Middleware())
+	e.GET("/api/farms/:id/sync", s.GetFarmSync)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/farms/"+farmUID.String()+"/sync", nil)
+	rec := httptest.NewRecorder()
+
+	// When
+	e.ServeHTTP(rec, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "PX-4471")
+
+	body := struct {
+		Data server.FarmSync `json:"data"`
+	}{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Len(t, body.Data.TasksUpdated, 1)
+	assert.Equal(t, "Spray the tomatoes", body.Data.TasksUpdated[0].Title)
+	assert.Equal(t, auth.RedactedValue, body.Data.TasksUpdated[0].Description)
+}
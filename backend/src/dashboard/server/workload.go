@@ -0,0 +1,215 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// plannedTask is a generated task balanced onto a day of its farm.
+type plannedTask struct {
+	FarmUID uuid.UUID
+	DueDate time.Time
+	Minutes int
+}
+
+// plannedTasks are the balanced tasks until the task read model has them, as the generated tasks are saved
+// to it in the background. The lock also keeps two tasks from being balanced onto the last room of a day.
+type plannedTasks struct {
+	lock  sync.Mutex
+	tasks map[uuid.UUID]plannedTask
+}
+
+func newPlannedTasks() *plannedTasks {
+	return &plannedTasks{tasks: make(map[uuid.UUID]plannedTask)}
+}
+
+// GetWorkload returns the effort of the open tasks of the farm due on each day of the ISO week param,
+// the current week by default, against the daily effort budget of the farm.
+func (s *DashboardServer) GetWorkload(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	farm, err := s.findSyncFarm(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	start, err := domain.WorkloadWeekStart(c.QueryParam("week"), time.Now())
+	if err != nil {
+		return Error(c, NewRequestValidationError(ParseFailed, "week"))
+	}
+
+	s.plannedTasks.lock.Lock()
+	tasks, err := s.findWorkloadTasks(farm.UID, start)
+	s.plannedTasks.lock.Unlock()
+
+	if err != nil {
+		return Error(c, err)
+	}
+
+	isoYear, isoWeek := start.ISOWeek()
+
+	data := make(map[string]interface{})
+	data["data"] = domain.BuildWorkload(start, 7, tasks, farm.DailyEffortBudgetMinutes)
+	data["week"] = fmt.Sprintf("%d-W%02d", isoYear, isoWeek)
+	data["budget_minutes"] = farm.DailyEffortBudgetMinutes
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// BalanceDueDate moves the due date of a generated task to an earlier day of its window when the daily effort
+// budget of the farm is used up on its due date. The farm of the task without a farm UID is the farm of its crop
+// or area. The tasks without due date and the farms without budget keep the due date.
+func (s *DashboardServer) BalanceDueDate(farmUID uuid.UUID, task *tasksdomain.Task) (*time.Time, error) {
+	if task.DueDate == nil {
+		return nil, nil
+	}
+
+	s.plannedTasks.lock.Lock()
+	defer s.plannedTasks.lock.Unlock()
+
+	if farmUID == uuid.Nil {
+		var err error
+
+		farmUID, err = s.findTaskAssetFarmUID(task)
+		if err != nil || farmUID == uuid.Nil {
+			return task.DueDate, err
+		}
+	}
+
+	farm, err := s.findSyncFarm(farmUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if farm.DailyEffortBudgetMinutes <= 0 {
+		return task.DueDate, nil
+	}
+
+	windowDays := 0
+	if config.Config.WorkloadWindowDays != nil {
+		windowDays = *config.Config.WorkloadWindowDays
+	}
+
+	now := time.Now()
+
+	tasks, err := s.findWorkloadTasks(farm.UID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	minutes := domain.EffortMinutes(task.EstimatedMinutes, defaultEffortMinutes())
+	dueDate := domain.BalanceDueDate(
+		*task.DueDate, minutes, farm.DailyEffortBudgetMinutes, windowDays, domain.ScheduledMinutes(tasks), now)
+
+	s.plannedTasks.tasks[task.UID] = plannedTask{FarmUID: farm.UID, DueDate: dueDate, Minutes: minutes}
+
+	return &dueDate, nil
+}
+
+func defaultEffortMinutes() int {
+	if config.Config.TaskDefaultEffort == nil {
+		return 0
+	}
+
+	return *config.Config.TaskDefaultEffort
+}
+
+// findWorkloadTasks finds the open tasks of the farm due from the day of the time, with the planned tasks
+// the read model doesn't have yet. The caller holds the lock of the planned tasks.
+func (s *DashboardServer) findWorkloadTasks(farmUID uuid.UUID, from time.Time) ([]domain.TaskEffort, error) {
+	result := <-s.TaskReadQuery.FindTasksWithFilter(map[string]string{"status": tasksdomain.TaskStatusCreated}, 0, 0)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	taskReads, ok := result.Result.([]taskstorage.TaskRead)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	areaNames, err := s.findAreaNames(farmUID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromDate := domain.WorkloadDate(from)
+	tasks := []domain.TaskEffort{}
+
+	for _, v := range taskReads {
+		// The planned tasks are counted from the read model once it has them.
+		delete(s.plannedTasks.tasks, v.UID)
+
+		if v.DueDate == nil || domain.WorkloadDate(*v.DueDate) < fromDate {
+			continue
+		}
+
+		inFarm, err := s.taskInFarm(v, farmUID, areaNames)
+		if err != nil {
+			return nil, err
+		}
+
+		if inFarm {
+			tasks = append(tasks, domain.TaskEffort{
+				DueDate: *v.DueDate,
+				Minutes: domain.EffortMinutes(v.EstimatedMinutes, defaultEffortMinutes()),
+			})
+		}
+	}
+
+	today := domain.WorkloadDate(time.Now())
+
+	for uid, v := range s.plannedTasks.tasks {
+		date := domain.WorkloadDate(v.DueDate)
+		if date < today {
+			delete(s.plannedTasks.tasks, uid)
+
+			continue
+		}
+
+		if v.FarmUID == farmUID && date >= fromDate {
+			tasks = append(tasks, domain.TaskEffort{DueDate: v.DueDate, Minutes: v.Minutes})
+		}
+	}
+
+	return tasks, nil
+}
+
+// findTaskAssetFarmUID returns the farm of the crop or the area of the task, nil for the other tasks.
+func (s *DashboardServer) findTaskAssetFarmUID(task *tasksdomain.Task) (uuid.UUID, error) {
+	if task.AssetID == nil {
+		return uuid.Nil, nil
+	}
+
+	switch task.Domain {
+	case tasksdomain.TaskDomainCropCode:
+		crop, err := s.findCrop(*task.AssetID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		return crop.FarmUID, nil
+
+	case tasksdomain.TaskDomainAreaCode:
+		area, err := s.findSyncArea(*task.AssetID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		return area.Farm.UID, nil
+	}
+
+	return uuid.Nil, nil
+}
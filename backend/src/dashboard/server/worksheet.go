@@ -0,0 +1,405 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/helper/pdfhelper"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+const (
+	WorksheetFormatHTML = "html"
+	WorksheetFormatPDF  = "pdf"
+)
+
+// GetWorksheet prints the open tasks due on the date, grouped by area in the walk order of the farm.
+// The tasks aren't assigned to anybody yet, so the assignee is only printed on the sheet.
+func (s *DashboardServer) GetWorksheet(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	date := time.Now()
+
+	if value := c.QueryParam("date"); value != "" {
+		date, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "date"))
+		}
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = WorksheetFormatHTML
+	}
+
+	if format != WorksheetFormatHTML && format != WorksheetFormatPDF {
+		return Error(c, NewRequestValidationError(InvalidOption, "format"))
+	}
+
+	result := <-s.FarmReadQuery.FindByID(farmUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	farm, ok := result.Result.(assetsstorage.FarmRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	if farm.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	tasks, err := s.findWorksheetTasks(farm.UID, date)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	worksheet := domain.BuildWorksheet(farm.Name, date, c.QueryParam("assignee"), farm.AreaWalkOrder, tasks)
+
+	if format == WorksheetFormatPDF {
+		c.Response().Header().Set(echo.HeaderContentDisposition,
+			fmt.Sprintf("inline; filename=worksheet-%s.pdf", date.Format("2006-01-02")))
+
+		return c.Blob(http.StatusOK, "application/pdf", RenderWorksheetPDF(worksheet))
+	}
+
+	html, err := RenderWorksheetHTML(worksheet)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	return c.HTML(http.StatusOK, html)
+}
+
+// findWorksheetTasks finds the open tasks of the farm due on the date.
+// The general, finance and inventory tasks don't belong to any farm, they are printed with
+// the reservoir tasks after the areas.
+func (s *DashboardServer) findWorksheetTasks(farmUID uuid.UUID, date time.Time) ([]domain.WorksheetTask, error) {
+	areaNames, err := s.findAreaNames(farmUID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := <-s.ReservoirReadQuery.FindAllByFarm(farmUID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	reservoirs, ok := result.Result.([]assetsstorage.ReservoirRead)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	reservoirUIDs := map[uuid.UUID]bool{}
+	for _, v := range reservoirs {
+		reservoirUIDs[v.UID] = true
+	}
+
+	taskResult := <-s.TaskReadQuery.FindTasksWithFilter(map[string]string{"status": tasksdomain.TaskStatusCreated}, 0, 0)
+	if taskResult.Error != nil {
+		return nil, taskResult.Error
+	}
+
+	taskReads, ok := taskResult.Result.([]taskstorage.TaskRead)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	tasks := []domain.WorksheetTask{}
+
+	for _, v := range taskReads {
+		if v.DueDate == nil || !sameDay(*v.DueDate, date) {
+			continue
+		}
+
+		task := domain.WorksheetTask{
+			UID:       v.UID,
+			ShortCode: v.ShortCode,
+			Title:     v.Title,
+			Category:  v.Category,
+			Priority:  v.Priority,
+		}
+
+		switch v.Domain {
+		case tasksdomain.TaskDomainAreaCode:
+			if v.AssetID == nil {
+				continue
+			}
+
+			name, ok := areaNames[*v.AssetID]
+			if !ok {
+				continue
+			}
+
+			task.AreaUID = *v.AssetID
+			task.AreaName = name
+
+		case tasksdomain.TaskDomainCropCode:
+			if v.AssetID == nil {
+				continue
+			}
+
+			crop, err := s.findCrop(*v.AssetID)
+			if err != nil {
+				return nil, err
+			}
+
+			if crop.FarmUID != farmUID {
+				continue
+			}
+
+			areaUID := crop.InitialArea.AreaUID
+
+			if details, ok := v.DomainDetails.(tasksdomain.TaskDomainCrop); ok && details.AreaID != nil {
+				areaUID = *details.AreaID
+			}
+
+			if name, ok := areaNames[areaUID]; ok {
+				task.AreaUID = areaUID
+				task.AreaName = name
+			}
+
+			task.BatchID = crop.BatchID
+			task.Quantity = cropQuantityInArea(crop, areaUID)
+			task.ContainerType = crop.Container.Type
+
+		case tasksdomain.TaskDomainReservoirCode:
+			if v.AssetID == nil || !reservoirUIDs[*v.AssetID] {
+				continue
+			}
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// findAreaNames maps the areas of the farm to their name.
+func (s *DashboardServer) findAreaNames(farmUID uuid.UUID) (map[uuid.UUID]string, error) {
+	result := <-s.AreaReadQuery.FindAllByFarm(farmUID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	areas, ok := result.Result.([]assetsstorage.AreaRead)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	areaNames := map[uuid.UUID]string{}
+	for _, v := range areas {
+		areaNames[v.UID] = v.Name
+	}
+
+	return areaNames, nil
+}
+
+func (s *DashboardServer) findCrop(cropUID uuid.UUID) (growthstorage.CropRead, error) {
+	result := <-s.CropReadQuery.FindByID(cropUID)
+	if result.Error != nil {
+		return growthstorage.CropRead{}, result.Error
+	}
+
+	crop, ok := result.Result.(growthstorage.CropRead)
+	if !ok {
+		return growthstorage.CropRead{}, errors.New("internal server error. error type assertion")
+	}
+
+	return crop, nil
+}
+
+func cropQuantityInArea(crop growthstorage.CropRead, areaUID uuid.UUID) int {
+	if crop.InitialArea.AreaUID == areaUID {
+		return crop.InitialArea.CurrentQuantity
+	}
+
+	for _, v := range crop.MovedArea {
+		if v.AreaUID == areaUID {
+			return v.CurrentQuantity
+		}
+	}
+
+	return 0
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.In(time.Local).Date()
+	by, bm, bd := b.In(time.Local).Date()
+
+	return ay == by && am == bm && ad == bd
+}
+
+func worksheetAreaName(area domain.WorksheetArea) string {
+	if area.AreaUID == uuid.Nil {
+		return "Other tasks"
+	}
+
+	return area.Name
+}
+
+var worksheetTemplate = template.Must(template.New("worksheet").Funcs(template.FuncMap{
+	"areaName": worksheetAreaName,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.FarmName}} - {{.Date.Format "2006-01-02"}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; font-size: 11pt; margin: 1.5cm; }
+h1 { font-size: 16pt; margin: 0; }
+h2 { font-size: 12pt; margin: 1em 0 0.3em; }
+.meta { margin: 0.3em 0 1em; }
+table { width: 100%; border-collapse: collapse; page-break-inside: auto; }
+tr { page-break-inside: avoid; }
+th, td { border: 1px solid #000; padding: 6px 4px; text-align: left; vertical-align: top; }
+th { background: #eee; }
+.done { width: 1.5em; }
+.blank { width: 20%; }
+.small { font-size: 9pt; color: #555; }
+@media print { body { margin: 0; } th { -webkit-print-color-adjust: exact; } }
+</style>
+</head>
+<body>
+<h1>{{.FarmName}}</h1>
+<div class="meta">Date: {{.Date.Format "Monday, 2 January 2006"}} &nbsp; Assignee: {{if .Assignee}}{{.Assignee}}{{else}}____________________{{end}}</div>
+{{range .Areas}}
+<h2>{{areaName .}}</h2>
+<table>
+<tr><th class="done"></th><th>Task</th><th>Batch</th><th>Quantity</th><th class="blank">Result</th><th class="blank">Notes</th></tr>
+{{range .Tasks}}<tr>
+<td class="done">&#9744;</td>
+<td>{{.Title}}<div class="small">{{.ShortCode}} {{.Category}}{{if eq .Priority "URGENT"}} - URGENT{{end}}</div></td>
+<td>{{.BatchID}}</td>
+<td>{{if .BatchID}}{{.Quantity}} {{.ContainerType}}{{end}}</td>
+<td></td>
+<td></td>
+</tr>
+{{end}}</table>
+{{else}}
+<p>No open tasks for this day.</p>
+{{end}}
+</body>
+</html>
+`))
+
+func RenderWorksheetHTML(worksheet domain.Worksheet) (string, error) {
+	buf := bytes.Buffer{}
+
+	err := worksheetTemplate.Execute(&buf, worksheet)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// RenderWorksheetPDF lays the worksheet out on A4 pages, with a table per area.
+func RenderWorksheetPDF(worksheet domain.Worksheet) []byte {
+	const (
+		margin    = 40.0
+		rowHeight = 24.0
+	)
+
+	columns := []struct {
+		title string
+		width float64
+	}{
+		{"", 24}, {"Task", 181}, {"Batch", 80}, {"Quantity", 70}, {"Result", 80}, {"Notes", 80},
+	}
+
+	doc := pdfhelper.NewDocument()
+	doc.AddPage()
+
+	y := margin + 16
+
+	doc.Text(margin, y, 16, true, worksheet.FarmName)
+
+	y += 18
+
+	assignee := worksheet.Assignee
+	if assignee == "" {
+		assignee = "____________________"
+	}
+
+	doc.Text(margin, y, 10, false,
+		fmt.Sprintf("Date: %s    Assignee: %s", worksheet.Date.Format("Monday, 2 January 2006"), assignee))
+
+	y += 10
+
+	row := func(values []string, bold bool) {
+		x := margin
+		for i, v := range columns {
+			doc.Rect(x, y, v.width, rowHeight)
+			doc.Text(x+4, y+15, 9, bold, pdfhelper.Truncate(values[i], v.width-8, 9))
+
+			x += v.width
+		}
+
+		y += rowHeight
+	}
+
+	header := []string{}
+	for _, v := range columns {
+		header = append(header, v.title)
+	}
+
+	if len(worksheet.Areas) == 0 {
+		doc.Text(margin, y+24, 10, false, "No open tasks for this day.")
+	}
+
+	for _, area := range worksheet.Areas {
+		if y+32+rowHeight*2 > pdfhelper.PageHeight-margin {
+			doc.AddPage()
+
+			y = margin
+		}
+
+		y += 24
+
+		doc.Text(margin, y, 12, true, worksheetAreaName(area))
+
+		y += 6
+
+		row(header, true)
+
+		for _, task := range area.Tasks {
+			if y+rowHeight > pdfhelper.PageHeight-margin {
+				doc.AddPage()
+
+				y = margin
+
+				row(header, true)
+			}
+
+			title := task.Title
+			if task.Priority == tasksdomain.TaskPriorityUrgent {
+				title = "! " + title
+			}
+
+			quantity := ""
+			if task.BatchID != "" {
+				quantity = fmt.Sprintf("%d %s", task.Quantity, task.ContainerType)
+			}
+
+			doc.Rect(margin+7, y+7, 10, 10)
+			row([]string{"", title, task.BatchID, quantity, "", ""}, false)
+		}
+	}
+
+	return doc.Bytes()
+}
@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"log"
+	"time"
+
+	"github.com/sasha-s/go-deadlock"
+	"github.com/usetania/tania-core/src/dashboard/domain"
+)
+
+// StatsStorage keeps the dashboard counters in memory.
+// They are rebuilt from the other read models when the server starts.
+type StatsStorage struct {
+	Lock  *deadlock.RWMutex
+	Stats domain.Stats
+}
+
+func CreateStatsStorage() *StatsStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("DASHBOARD STATS STORAGE DEADLOCK!")
+	}
+
+	return &StatsStorage{Stats: domain.NewStats(), Lock: &rwMutex}
+}
@@ -0,0 +1,149 @@
+// Package demo makes Tania safe to demonstrate: the demo mode seeds sample farms through the API
+// and refuses every request changing them afterwards.
+package demo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderDemoMode is set on the refused requests, so the clients can tell them from a missing route.
+const HeaderDemoMode = "X-Demo-Mode"
+
+// Request is a request of the seed. The {{ref}} placeholders in the path and the form values are
+// replaced by the uid of the earlier request with that ref.
+type Request struct {
+	Ref    string            `json:"ref"`
+	Method string            `json:"method"`
+	Path   string            `json:"path"`
+	Form   map[string]string `json:"form"`
+}
+
+// Seed is the sample data of the demo, as the API requests creating it.
+type Seed struct {
+	Requests []Request `json:"requests"`
+}
+
+// LoadSeed reads the seed file of the path.
+func LoadSeed(path string) (Seed, error) {
+	seed := Seed{}
+
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return seed, err
+	}
+
+	err = json.Unmarshal(file, &seed)
+
+	return seed, err
+}
+
+// Apply sends the requests of the seed to the API handler, unless it has farms already,
+// so a restart doesn't seed the data twice.
+func (s Seed) Apply(handler http.Handler, prefix string) (bool, error) {
+	rec := serve(handler, http.MethodGet, prefix+"/farms", nil)
+	if rec.Code != http.StatusOK {
+		return false, fmt.Errorf("demo seed: listing the farms failed with %d", rec.Code)
+	}
+
+	farms := struct {
+		Data []json.RawMessage `json:"data"`
+	}{}
+
+	if err := json.Unmarshal(rec.Body.Bytes(), &farms); err != nil {
+		return false, err
+	}
+
+	if len(farms.Data) > 0 {
+		return false, nil
+	}
+
+	refs := map[string]string{}
+
+	for i, v := range s.Requests {
+		form := url.Values{}
+		for key, value := range v.Form {
+			form.Set(key, replaceRefs(value, refs))
+		}
+
+		path := prefix + replaceRefs(v.Path, refs)
+
+		rec := serve(handler, v.Method, path, form)
+		if rec.Code < http.StatusOK || rec.Code >= http.StatusMultipleChoices {
+			return false, fmt.Errorf("demo seed: request %d %s %s failed with %d %s",
+				i, v.Method, path, rec.Code, strings.TrimSpace(rec.Body.String()))
+		}
+
+		if v.Ref == "" {
+			continue
+		}
+
+		created := struct {
+			Data struct {
+				UID string `json:"uid"`
+			} `json:"data"`
+		}{}
+
+		if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil || created.Data.UID == "" {
+			return false, fmt.Errorf("demo seed: request %d %s %s returned no uid for %s", i, v.Method, path, v.Ref)
+		}
+
+		refs[v.Ref] = created.Data.UID
+	}
+
+	return true, nil
+}
+
+func replaceRefs(value string, refs map[string]string) string {
+	for ref, uid := range refs {
+		value = strings.ReplaceAll(value, "{{"+ref+"}}", uid)
+	}
+
+	return value
+}
+
+func serve(handler http.Handler, method, path string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+	if form != nil {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// ReadOnly refuses the POST, PUT, PATCH and DELETE requests with 405 Method Not Allowed,
+// except the ones to the allowed paths, like the sign in.
+func ReadOnly(allowedPaths ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			switch c.Request().Method {
+			case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			default:
+				return next(c)
+			}
+
+			path := strings.TrimSuffix(c.Request().URL.Path, "/")
+			for _, v := range allowedPaths {
+				if path == v {
+					return next(c)
+				}
+			}
+
+			c.Response().Header().Set(HeaderDemoMode, "true")
+
+			return c.JSON(http.StatusMethodNotAllowed, map[string]string{
+				"error": "Tania runs in the demo mode, its data can't be changed",
+			})
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package demo_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/demo"
+)
+
+func fakeAPI() (*echo.Echo, *[]string) {
+	e := echo.New()
+	farms := &[]string{}
+
+	e.GET("/api/farms", func(c echo.Context) error {
+		data := []map[string]string{}
+		for _, v := range *farms {
+			data = append(data, map[string]string{"uid": v})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{"data": data})
+	})
+	e.POST("/api/farms", func(c echo.Context) error {
+		*farms = append(*farms, "farm-"+c.FormValue("name"))
+
+		return c.JSON(http.StatusOK, map[string]interface{}{"data": map[string]string{"uid": "farm-" + c.FormValue("name")}})
+	})
+	e.POST("/api/farms/:id/areas", func(c echo.Context) error {
+		if c.Param("id") != "farm-Demo" || c.FormValue("reservoir_id") != "tank-farm-Demo" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "wrong refs"})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{"data": map[string]string{"uid": "area"}})
+	})
+	e.POST("/api/farms/:id/reservoirs", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]interface{}{"data": map[string]string{"uid": "tank-" + c.Param("id")}})
+	})
+
+	return e, farms
+}
+
+func TestSeedApply(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	e, farms := fakeAPI()
+	seed := demo.Seed{Requests: []demo.Request{
+		{Ref: "farm", Method: http.MethodPost, Path: "/farms", Form: map[string]string{"name": "Demo"}},
+		{Ref: "tank", Method: http.MethodPost, Path: "/farms/{{farm}}/reservoirs"},
+		{
+			Method: http.MethodPost,
+			Path:   "/farms/{{farm}}/areas",
+			Form:   map[string]string{"reservoir_id": "{{tank}}"},
+		},
+	}}
+
+	// When
+	seeded, err := seed.Apply(e, "/api")
+	seededAgain, errAgain := seed.Apply(e, "/api")
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, seeded)
+	assert.Nil(t, errAgain)
+	assert.False(t, seededAgain)
+	assert.Equal(t, []string{"farm-Demo"}, *farms)
+}
+
+func TestSeedApplyFailedRequest(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	e, _ := fakeAPI()
+	seed := demo.Seed{Requests: []demo.Request{
+		{Ref: "farm", Method: http.MethodPost, Path: "/farms", Form: map[string]string{"name": "Other"}},
+		{Method: http.MethodPost, Path: "/farms/{{farm}}/areas"},
+	}}
+
+	// When
+	seeded, err := seed.Apply(e, "/api")
+
+	// Then
+	assert.False(t, seeded)
+	assert.EqualError(t, err,
+		`demo seed: request 1 POST /api/farms/farm-Other/areas failed with 400 {"error":"wrong refs"}`)
+}
+
+func TestReadOnly(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	e := echo.New()
+	e.Use(demo.ReadOnly("/api/authorize"))
+	e.Any("/*", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+
+		return rec
+	}
+
+	// When
+	get := serve(http.MethodGet, "/api/farms")
+	signIn := serve(http.MethodPost, "/api/authorize")
+	post := serve(http.MethodPost, "/api/farms")
+	put := serve(http.MethodPut, "/api/farms/1")
+	patch := serve(http.MethodPatch, "/api/tasks/1")
+	deleted := serve(http.MethodDelete, "/api/farms/1/areas/1")
+
+	// Then
+	assert.Equal(t, http.StatusOK, get.Code)
+	assert.Equal(t, "", get.Header().Get(demo.HeaderDemoMode))
+	assert.Equal(t, http.StatusOK, signIn.Code)
+
+	for _, v := range []*httptest.ResponseRecorder{post, put, patch, deleted} {
+		assert.Equal(t, http.StatusMethodNotAllowed, v.Code)
+		assert.Equal(t, "true", v.Header().Get(demo.HeaderDemoMode))
+	}
+}
@@ -0,0 +1,72 @@
+package demo
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/assets/query"
+	"github.com/usetania/tania-core/src/assets/storage"
+)
+
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type Farm struct {
+	UID         uuid.UUID   `json:"uid"`
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Country     string      `json:"country"`
+	City        string      `json:"city"`
+	Credentials Credentials `json:"credentials"`
+}
+
+type Server struct {
+	FarmReadQuery query.FarmRead
+	Credentials   Credentials
+}
+
+// NewServer returns the server of the demo info, the credentials are the ones of the user owning the demo farms.
+func NewServer(farmReadQuery query.FarmRead, credentials Credentials) *Server {
+	return &Server{
+		FarmReadQuery: farmReadQuery,
+		Credentials:   credentials,
+	}
+}
+
+// Mount defines the demo info endpoint with its handler.
+func (s *Server) Mount(g *echo.Group) {
+	g.GET("", s.GetDemoInfo)
+}
+
+func (s *Server) GetDemoInfo(c echo.Context) error {
+	result := <-s.FarmReadQuery.FindAll()
+	if result.Error != nil {
+		return result.Error
+	}
+
+	farmReads, ok := result.Result.([]storage.FarmRead)
+	if !ok {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	farms := []Farm{}
+
+	for _, v := range farmReads {
+		farms = append(farms, Farm{
+			UID:         v.UID,
+			Name:        v.Name,
+			Type:        v.Type,
+			Country:     v.Country,
+			City:        v.City,
+			Credentials: s.Credentials,
+		})
+	}
+
+	data := make(map[string][]Farm)
+	data["data"] = farms
+
+	return c.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,230 @@
+// Package devicesignature verifies the requests of the IoT edge nodes, like the sensors submitting the
+// microclimate samples, are signed with the secret of a registered device, so their readings can't be spoofed.
+package devicesignature
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sasha-s/go-deadlock"
+)
+
+const (
+	// HeaderDeviceID is the header the devices send their ID with.
+	HeaderDeviceID = "X-Device-ID"
+
+	// HeaderSignature is the header of the HMAC-SHA256 of the signed payload with the secret of the device,
+	// in hexadecimal and optionally prefixed with sha256= like the signatures of the webhooks.
+	HeaderSignature = "HMAC-Signature"
+
+	// HeaderTimestamp is the header of the Unix time the request was signed at, in seconds.
+	HeaderTimestamp = "X-Device-Timestamp"
+
+	// HeaderNonce is the header of the random value the device signs the request with, used once.
+	HeaderNonce = "X-Device-Nonce"
+
+	// MaxClockSkew is how far the timestamp of a request can be from the time of the server.
+	MaxClockSkew = 5 * time.Minute
+
+	// MaxIDLength is the length of the longest device ID accepted, and of the longest nonce.
+	MaxIDLength = 255
+
+	// MinSecretLength is the length of the shortest secret accepted, a generated secret is twice as long.
+	MinSecretLength = 16
+)
+
+type ValidationError struct {
+	Field string
+}
+
+func (e ValidationError) Error() string {
+	return "invalid device " + e.Field
+}
+
+// Device is an edge node allowed to sign its requests with its secret.
+type Device struct {
+	ID          string    `json:"device_id"`
+	Name        string    `json:"name"`
+	Secret      string    `json:"-"`
+	CreatedDate time.Time `json:"created_date"`
+}
+
+// NewDevice validates the device. A secret is generated when it has none.
+func NewDevice(id, name, secret string, createdDate time.Time) (Device, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || len(id) > MaxIDLength {
+		return Device{}, ValidationError{Field: "device_id"}
+	}
+
+	if secret == "" {
+		generated, err := GenerateSecret()
+		if err != nil {
+			return Device{}, err
+		}
+
+		secret = generated
+	}
+
+	if len(secret) < MinSecretLength {
+		return Device{}, ValidationError{Field: "secret"}
+	}
+
+	return Device{ID: id, Name: strings.TrimSpace(name), Secret: secret, CreatedDate: createdDate}, nil
+}
+
+// GenerateSecret returns 32 random bytes in hexadecimal.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, 32)
+
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+// SignedPayload is what the device signs: the timestamp, a dot, the nonce, a dot and the request body.
+func SignedPayload(timestamp, nonce string, body []byte) []byte {
+	payload := []byte(timestamp + "." + nonce + ".")
+
+	return append(payload, body...)
+}
+
+// Signature is the HMAC-SHA256 of the payload with the secret, in hexadecimal.
+func Signature(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify tells if the signature is the one of the payload with the secret, compared in constant time.
+func Verify(secret string, payload []byte, signature string) bool {
+	signature = strings.TrimPrefix(strings.TrimSpace(signature), "sha256=")
+
+	decoded, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	expected, _ := hex.DecodeString(Signature(secret, payload))
+
+	return hmac.Equal(decoded, expected)
+}
+
+// NonceCache keeps the nonces of the signed requests of each device while their timestamp is within the clock
+// skew, so a captured request can't be sent again. It's in memory, the instances behind a load balancer each
+// have their own.
+type NonceCache struct {
+	Lock   *deadlock.Mutex
+	Nonces map[string]time.Time
+}
+
+func NewNonceCache() *NonceCache {
+	return &NonceCache{Lock: &deadlock.Mutex{}, Nonces: map[string]time.Time{}}
+}
+
+// Use records the nonce of the device signed at the time, and tells whether it wasn't used yet.
+func (n *NonceCache) Use(deviceID, nonce string, signedDate, now time.Time) bool {
+	n.Lock.Lock()
+	defer n.Lock.Unlock()
+
+	// A nonce older than the skew can't come back, its timestamp would be refused.
+	for k, v := range n.Nonces {
+		if now.Sub(v) > MaxClockSkew {
+			delete(n.Nonces, k)
+		}
+	}
+
+	key := deviceID + "\n" + nonce
+	if _, ok := n.Nonces[key]; ok {
+		return false
+	}
+
+	n.Nonces[key] = signedDate
+
+	return true
+}
+
+// Registry keeps the devices by their ID.
+type Registry interface {
+	// Save registers the device, or replaces the secret and the name of the device with its ID.
+	Save(device Device) error
+	// Find returns the device of the ID, false when it isn't registered.
+	Find(id string) (Device, bool, error)
+}
+
+// Middleware refuses with 401 the requests without the signature of a registered device, signed more than
+// MaxClockSkew away from now, or sent again with the nonce of a request already accepted.
+func Middleware(registry Registry, nonces *NonceCache) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			deviceID := strings.TrimSpace(c.Request().Header.Get(HeaderDeviceID))
+			signature := c.Request().Header.Get(HeaderSignature)
+			timestamp := strings.TrimSpace(c.Request().Header.Get(HeaderTimestamp))
+			nonce := strings.TrimSpace(c.Request().Header.Get(HeaderNonce))
+
+			if deviceID == "" || signature == "" || nonce == "" || len(nonce) > MaxIDLength {
+				return unauthorized(c)
+			}
+
+			seconds, err := strconv.ParseInt(timestamp, 10, 64)
+			if err != nil {
+				return unauthorized(c)
+			}
+
+			now := time.Now()
+			signedDate := time.Unix(seconds, 0)
+
+			if signedDate.Before(now.Add(-MaxClockSkew)) || signedDate.After(now.Add(MaxClockSkew)) {
+				return unauthorized(c)
+			}
+
+			device, found, err := registry.Find(deviceID)
+			if err != nil {
+				return err
+			}
+
+			if !found {
+				return unauthorized(c)
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return err
+			}
+
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			if !Verify(device.Secret, SignedPayload(timestamp, nonce, body), signature) {
+				return unauthorized(c)
+			}
+
+			// The nonce is only used up by a valid signature, the others can't fill the cache.
+			if !nonces.Use(device.ID, nonce, signedDate, now) {
+				return unauthorized(c)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// unauthorized doesn't tell an unregistered device from a wrong signature, so the IDs can't be probed.
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, map[string]string{
+		"field_name":    HeaderSignature,
+		"error_code":    "INVALID_DEVICE_SIGNATURE",
+		"error_message": "The request isn't signed by a registered device.",
+	})
+}
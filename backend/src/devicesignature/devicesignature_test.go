@@ -0,0 +1,175 @@
+package devicesignature_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/devicesignature"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func newTestApp(t *testing.T) *echo.Echo {
+	t.Helper()
+
+	registry := NewRegistryInMemory(CreateDeviceStorage())
+
+	device, err := NewDevice("greenhouse-1", "Greenhouse sensor", testSecret, time.Now())
+	assert.Nil(t, err)
+	assert.Nil(t, registry.Save(device))
+
+	e := echo.New()
+	e.POST("/samples", func(c echo.Context) error {
+		return c.String(http.StatusOK, c.FormValue("temperature"))
+	}, Middleware(registry, NewNonceCache()))
+
+	return e
+}
+
+func now() string {
+	return strconv.FormatInt(time.Now().Unix(), 10)
+}
+
+func sign(secret, timestamp, nonce, body string) string {
+	return Signature(secret, SignedPayload(timestamp, nonce, []byte(body)))
+}
+
+func post(e *echo.Echo, deviceID, timestamp, nonce, signature, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/samples", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderNonce, nonce)
+
+	if deviceID != "" {
+		req.Header.Set(HeaderDeviceID, deviceID)
+	}
+
+	if signature != "" {
+		req.Header.Set(HeaderSignature, signature)
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestAcceptTheRequestSignedByTheDevice(t *testing.T) {
+	t.Parallel()
+	// Given
+	e := newTestApp(t)
+	body := "temperature=21.5"
+
+	timestamp := now()
+
+	// When
+	signed := post(e, "greenhouse-1", timestamp, "n1", sign(testSecret, timestamp, "n1", body), body)
+	prefixed := post(e, "greenhouse-1", timestamp, "n2", "sha256="+sign(testSecret, timestamp, "n2", body), body)
+
+	// Then
+	assert.Equal(t, http.StatusOK, signed.Code)
+	assert.Equal(t, "21.5", signed.Body.String())
+	assert.Equal(t, http.StatusOK, prefixed.Code)
+}
+
+func TestRefuseTheRequestNotSignedByARegisteredDevice(t *testing.T) {
+	t.Parallel()
+	// Given
+	e := newTestApp(t)
+	body := "temperature=21.5"
+	timestamp := now()
+	signature := sign(testSecret, timestamp, "n1", body)
+
+	// When
+	tampered := post(e, "greenhouse-1", timestamp, "n1", signature, "temperature=35")
+	otherSignature := sign("another secret of the device", timestamp, "n1", body)
+	otherSecret := post(e, "greenhouse-1", timestamp, "n1", otherSignature, body)
+	unregistered := post(e, "greenhouse-2", timestamp, "n1", signature, body)
+	unsigned := post(e, "greenhouse-1", timestamp, "n1", "", body)
+	otherNonce := post(e, "greenhouse-1", timestamp, "n2", signature, body)
+
+	// Then
+	for _, v := range []*httptest.ResponseRecorder{tampered, otherSecret, unregistered, unsigned, otherNonce} {
+		assert.Equal(t, http.StatusUnauthorized, v.Code)
+		assert.Contains(t, v.Body.String(), `"error_code":"INVALID_DEVICE_SIGNATURE"`)
+	}
+}
+
+func TestRefuseTheReplayedRequest(t *testing.T) {
+	t.Parallel()
+	// Given
+	e := newTestApp(t)
+	body := "temperature=21.5"
+	timestamp := now()
+	signature := sign(testSecret, timestamp, "n1", body)
+
+	// When
+	first := post(e, "greenhouse-1", timestamp, "n1", signature, body)
+	replayed := post(e, "greenhouse-1", timestamp, "n1", signature, body)
+
+	// Then
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusUnauthorized, replayed.Code)
+}
+
+func TestRefuseTheRequestSignedOutsideTheClockSkew(t *testing.T) {
+	t.Parallel()
+	// Given
+	e := newTestApp(t)
+	body := "temperature=21.5"
+	stale := strconv.FormatInt(time.Now().Add(-MaxClockSkew-time.Minute).Unix(), 10)
+	future := strconv.FormatInt(time.Now().Add(MaxClockSkew+time.Minute).Unix(), 10)
+
+	// When
+	staleRec := post(e, "greenhouse-1", stale, "n1", sign(testSecret, stale, "n1", body), body)
+	futureRec := post(e, "greenhouse-1", future, "n2", sign(testSecret, future, "n2", body), body)
+	noTimestamp := post(e, "greenhouse-1", "", "n3", sign(testSecret, "", "n3", body), body)
+
+	// Then
+	for _, v := range []*httptest.ResponseRecorder{staleRec, futureRec, noTimestamp} {
+		assert.Equal(t, http.StatusUnauthorized, v.Code)
+	}
+}
+
+func TestNonceCacheForgetsTheNoncesOutsideTheClockSkew(t *testing.T) {
+	t.Parallel()
+	// Given
+	nonces := NewNonceCache()
+	signedDate := time.Now()
+
+	// When
+	first := nonces.Use("greenhouse-1", "n1", signedDate, signedDate)
+	reused := nonces.Use("greenhouse-1", "n1", signedDate, signedDate)
+	otherDevice := nonces.Use("greenhouse-2", "n1", signedDate, signedDate)
+	nonces.Use("greenhouse-1", "n2", signedDate, signedDate.Add(MaxClockSkew+time.Second))
+
+	// Then
+	assert.True(t, first)
+	assert.False(t, reused)
+	assert.True(t, otherDevice)
+	assert.Len(t, nonces.Nonces, 1)
+}
+
+func TestNewDevice(t *testing.T) {
+	t.Parallel()
+	// Given
+	now := time.Now()
+
+	// When
+	generated, generatedErr := NewDevice(" greenhouse-1 ", "", "", now)
+	_, idErr := NewDevice(" ", "", testSecret, now)
+	_, secretErr := NewDevice("greenhouse-1", "", "short", now)
+
+	// Then
+	assert.Nil(t, generatedErr)
+	assert.Equal(t, "greenhouse-1", generated.ID)
+	assert.Len(t, generated.Secret, 64)
+	assert.Equal(t, ValidationError{Field: "device_id"}, idErr)
+	assert.Equal(t, ValidationError{Field: "secret"}, secretErr)
+}
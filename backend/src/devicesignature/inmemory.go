@@ -0,0 +1,41 @@
+package devicesignature
+
+import (
+	"github.com/sasha-s/go-deadlock"
+)
+
+// DeviceStorage keeps the devices of the inmemory engine by their ID.
+type DeviceStorage struct {
+	Lock      *deadlock.RWMutex
+	DeviceMap map[string]Device
+}
+
+func CreateDeviceStorage() *DeviceStorage {
+	return &DeviceStorage{Lock: &deadlock.RWMutex{}, DeviceMap: map[string]Device{}}
+}
+
+type RegistryInMemory struct {
+	Storage *DeviceStorage
+}
+
+func NewRegistryInMemory(s *DeviceStorage) Registry {
+	return &RegistryInMemory{Storage: s}
+}
+
+func (s *RegistryInMemory) Save(device Device) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	s.Storage.DeviceMap[device.ID] = device
+
+	return nil
+}
+
+func (s *RegistryInMemory) Find(id string) (Device, bool, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	device, ok := s.Storage.DeviceMap[id]
+
+	return device, ok, nil
+}
@@ -0,0 +1,40 @@
+package devicesignature
+
+import (
+	"database/sql"
+	"errors"
+)
+
+type RegistryMysql struct {
+	DB *sql.DB
+}
+
+func NewRegistryMysql(db *sql.DB) Registry {
+	return &RegistryMysql{DB: db}
+}
+
+func (s *RegistryMysql) Save(device Device) error {
+	_, err := s.DB.Exec(`REPLACE INTO DEVICE_REGISTRY (ID, NAME, SECRET, CREATED_DATE) VALUES (?, ?, ?, ?)`,
+		device.ID,
+		device.Name,
+		device.Secret,
+		device.CreatedDate)
+
+	return err
+}
+
+func (s *RegistryMysql) Find(id string) (Device, bool, error) {
+	device := Device{}
+
+	err := s.DB.QueryRow(`SELECT ID, NAME, SECRET, CREATED_DATE FROM DEVICE_REGISTRY WHERE ID = ?`, id).
+		Scan(&device.ID, &device.Name, &device.Secret, &device.CreatedDate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Device{}, false, nil
+	}
+
+	if err != nil {
+		return Device{}, false, err
+	}
+
+	return device, true, nil
+}
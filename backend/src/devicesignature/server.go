@@ -0,0 +1,62 @@
+package devicesignature
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RegisteredDevice is the body of the registration response, the only one with the secret of the device.
+type RegisteredDevice struct {
+	Device
+	Secret string `json:"secret"`
+}
+
+type Server struct {
+	Registry Registry
+}
+
+func NewServer(registry Registry) *Server {
+	return &Server{Registry: registry}
+}
+
+// Mount defines the device registration admin endpoint.
+func (s *Server) Mount(g *echo.Group) {
+	g.POST("/devices", s.RegisterDevice)
+}
+
+// RegisterDevice registers the device of the device_id form value with its name and its secret, generated
+// when it's empty. Registering a device again replaces its secret.
+func (s *Server) RegisterDevice(c echo.Context) error {
+	device, err := NewDevice(c.FormValue("device_id"), c.FormValue("name"), c.FormValue("secret"), time.Now())
+
+	validationErr := ValidationError{}
+	if errors.As(err, &validationErr) {
+		message := "The device ID is required, up to " + strconv.Itoa(MaxIDLength) + " characters."
+		if validationErr.Field == "secret" {
+			message = "The secret needs at least " + strconv.Itoa(MinSecretLength) + " characters."
+		}
+
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"field_name":    validationErr.Field,
+			"error_code":    "INVALID_OPTION",
+			"error_message": message,
+		})
+	}
+
+	if err != nil {
+		return err
+	}
+
+	err = s.Registry.Save(device)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, map[string]RegisteredDevice{
+		"data": {Device: device, Secret: device.Secret},
+	})
+}
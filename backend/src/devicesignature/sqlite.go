@@ -0,0 +1,49 @@
+package devicesignature
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type RegistrySqlite struct {
+	DB *sql.DB
+}
+
+func NewRegistrySqlite(db *sql.DB) Registry {
+	return &RegistrySqlite{DB: db}
+}
+
+func (s *RegistrySqlite) Save(device Device) error {
+	_, err := s.DB.Exec(`INSERT OR REPLACE INTO DEVICE_REGISTRY (ID, NAME, SECRET, CREATED_DATE)
+		VALUES (?, ?, ?, ?)`,
+		device.ID,
+		device.Name,
+		device.Secret,
+		device.CreatedDate.Format(time.RFC3339))
+
+	return err
+}
+
+func (s *RegistrySqlite) Find(id string) (Device, bool, error) {
+	device := Device{}
+
+	var createdDate string
+
+	err := s.DB.QueryRow(`SELECT ID, NAME, SECRET, CREATED_DATE FROM DEVICE_REGISTRY WHERE ID = ?`, id).
+		Scan(&device.ID, &device.Name, &device.Secret, &createdDate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Device{}, false, nil
+	}
+
+	if err != nil {
+		return Device{}, false, err
+	}
+
+	device.CreatedDate, err = time.Parse(time.RFC3339, createdDate)
+	if err != nil {
+		return Device{}, false, err
+	}
+
+	return device, true, nil
+}
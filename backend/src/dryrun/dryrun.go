@@ -0,0 +1,86 @@
+// Package dryrun serves the create and update endpoints in validation only mode.
+// The handler runs with the same validation and reference checks as usual,
+// but its events are neither appended nor published, so no read model changes.
+package dryrun
+
+import (
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/shortcode"
+)
+
+const (
+	QueryParam = "validate_only"
+
+	// PreferValidate is the `Prefer` header preference requesting the validation only mode.
+	PreferValidate = "validate"
+
+	HeaderPreferenceApplied = "Preference-Applied"
+)
+
+// IsRequested reports whether the request asks for validation only,
+// with `?validate_only=true` or the `Prefer: validate` header.
+func IsRequested(c echo.Context) bool {
+	if c.QueryParam(QueryParam) == "true" {
+		return true
+	}
+
+	for _, header := range c.Request().Header.Values("Prefer") {
+		for _, preference := range strings.Split(header, ",") {
+			name := strings.SplitN(preference, ";", 2)[0]
+			if strings.EqualFold(strings.TrimSpace(name), PreferValidate) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Handler serves h with the live server, or with the copy returned by dry when validation only is requested.
+// The dry copy must swap every dependency with side effects, see EventRepository, EventBus and ShortCodeGenerator.
+func Handler[S any](live *S, dry func() *S, h func(*S, echo.Context) error) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !IsRequested(c) {
+			return h(live, c)
+		}
+
+		// The uploaded files would be written before they are validated, so they are left out.
+		if form, err := c.MultipartForm(); err == nil {
+			form.File = nil
+		}
+
+		c.Response().Header().Set(HeaderPreferenceApplied, PreferValidate)
+
+		return h(dry(), c)
+	}
+}
+
+// EventRepository drops the events instead of appending them.
+type EventRepository struct{}
+
+func (EventRepository) Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error {
+	result := make(chan error, 1)
+
+	result <- nil
+	close(result)
+
+	return result
+}
+
+// EventBus drops the events instead of publishing them, so neither the read models nor the subscribers see them.
+type EventBus struct{}
+
+func (EventBus) Publish(eventName string, event interface{}) {}
+
+func (EventBus) Subscribe(eventName string, handlerFunc interface{}) {}
+
+// ShortCodeGenerator returns a placeholder code, so the sequences are not consumed.
+type ShortCodeGenerator struct{}
+
+func (ShortCodeGenerator) Next(prefix string, scope uuid.UUID) (string, error) {
+	return shortcode.Format(prefix, 0), nil
+}
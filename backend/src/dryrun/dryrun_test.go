@@ -0,0 +1,352 @@
+package dryrun_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asaskevich/EventBus"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/usetania/tania-core/config"
+	assetsserver "github.com/usetania/tania-core/src/assets/server"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/customfield"
+	"github.com/usetania/tania-core/src/dryrun"
+	"github.com/usetania/tania-core/src/energy"
+	"github.com/usetania/tania-core/src/envalert"
+	"github.com/usetania/tania-core/src/eventbus"
+	"github.com/usetania/tania-core/src/featureflags"
+	growthserver "github.com/usetania/tania-core/src/growth/server"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/media"
+	"github.com/usetania/tania-core/src/retention"
+	"github.com/usetania/tania-core/src/signing"
+	tasksserver "github.com/usetania/tania-core/src/tasks/server"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type testApp struct {
+	echo      *echo.Echo
+	published int
+
+	farmEvents      *assetsstorage.FarmEventStorage
+	reservoirEvents *assetsstorage.ReservoirEventStorage
+	areaEvents      *assetsstorage.AreaEventStorage
+	materialEvents  *assetsstorage.MaterialEventStorage
+	certEvents      *assetsstorage.FarmCertificationEventStorage
+	fieldEvents     *assetsstorage.CustomFieldDefinitionEventStorage
+	stocktakeEvents *assetsstorage.StocktakeEventStorage
+	equipmentEvents *assetsstorage.EquipmentEventStorage
+	recipeEvents    *assetsstorage.NutrientRecipeEventStorage
+	boundaryEvents  *assetsstorage.FarmBoundaryEventStorage
+	cropEvents      *growthstorage.CropEventStorage
+	scheduleEvents  *growthstorage.CropInputScheduleEventStorage
+	taskEvents      *taskstorage.TaskEventStorage
+	templateEvents  *taskstorage.TaskTemplateEventStorage
+}
+
+func newTestApp(t *testing.T) *testApp {
+	t.Helper()
+
+	engine := config.DBInmemory
+	uploadPath := t.TempDir()
+	dueCheckMinutes := 0
+	config.Config.TaniaPersistenceEngine = &engine
+	config.Config.TaskDueCheckMinutes = &dueCheckMinutes
+	config.Config.UploadPathArea = &uploadPath
+	config.Config.UploadPathCrop = &uploadPath
+
+	app := &testApp{
+		echo:            echo.New(),
+		farmEvents:      assetsstorage.CreateFarmEventStorage(),
+		reservoirEvents: assetsstorage.CreateReservoirEventStorage(),
+		areaEvents:      assetsstorage.CreateAreaEventStorage(),
+		materialEvents:  assetsstorage.CreateMaterialEventStorage(),
+		certEvents:      assetsstorage.CreateFarmCertificationEventStorage(),
+		fieldEvents:     assetsstorage.CreateCustomFieldDefinitionEventStorage(),
+		stocktakeEvents: assetsstorage.CreateStocktakeEventStorage(),
+		equipmentEvents: assetsstorage.CreateEquipmentEventStorage(),
+		recipeEvents:    assetsstorage.CreateNutrientRecipeEventStorage(),
+		boundaryEvents:  assetsstorage.CreateFarmBoundaryEventStorage(),
+		cropEvents:      growthstorage.CreateCropEventStorage(),
+		scheduleEvents:  growthstorage.CreateCropInputScheduleEventStorage(),
+		taskEvents:      taskstorage.CreateTaskEventStorage(),
+		templateEvents:  taskstorage.CreateTaskTemplateEventStorage(),
+	}
+
+	bus := eventbus.NewSimpleEventBus(EventBus.New())
+	bus.SubscribeAll(func(string, interface{}) { app.published++ })
+
+	farmReadStorage := assetsstorage.CreateFarmReadStorage()
+	areaReadStorage := assetsstorage.CreateAreaReadStorage()
+	reservoirReadStorage := assetsstorage.CreateReservoirReadStorage()
+	materialReadStorage := assetsstorage.CreateMaterialReadStorage()
+	cropReadStorage := growthstorage.CreateCropReadStorage()
+	taskReadStorage := taskstorage.CreateTaskReadStorage()
+	prunedStorage := retention.CreatePrunedStorage()
+	fieldReadStorage := assetsstorage.CreateCustomFieldDefinitionReadStorage()
+	fieldValueStorage := customfield.CreateValueStorage()
+	equipmentReadStorage := assetsstorage.CreateEquipmentReadStorage()
+
+	flags, err := featureflags.NewRegistry(featureflags.Flags(), nil)
+	require.Nil(t, err)
+
+	farmServer, err := assetsserver.NewFarmServer(
+		nil,
+		app.farmEvents, farmReadStorage,
+		app.areaEvents, areaReadStorage,
+		app.reservoirEvents, reservoirReadStorage,
+		app.materialEvents, materialReadStorage,
+		app.certEvents, assetsstorage.CreateFarmCertificationReadStorage(),
+		app.fieldEvents, fieldReadStorage, fieldValueStorage,
+		app.stocktakeEvents, assetsstorage.CreateStocktakeReadStorage(),
+		app.equipmentEvents, equipmentReadStorage,
+		app.recipeEvents, assetsstorage.CreateNutrientRecipeReadStorage(),
+		app.boundaryEvents,
+		cropReadStorage, taskReadStorage,
+		bus, flags,
+	)
+	require.Nil(t, err)
+
+	taskServer, err := tasksserver.NewTaskServer(
+		nil, nil, bus,
+		cropReadStorage, areaReadStorage, materialReadStorage, reservoirReadStorage, equipmentReadStorage,
+		app.taskEvents, taskReadStorage, taskstorage.CreateTaskArchiveStorage(),
+		app.templateEvents, taskstorage.CreateTaskTemplateReadStorage(), taskstorage.CreateTaskCatalogEventStorage(),
+		prunedStorage, fieldValueStorage, fieldReadStorage, flags, func() bool { return false },
+	)
+	require.Nil(t, err)
+
+	growthServer, err := growthserver.NewGrowthServer(
+		nil, bus,
+		app.cropEvents, cropReadStorage, growthstorage.CreateCropActivityStorage(),
+		app.scheduleEvents, growthstorage.CreateCropInputScheduleReadStorage(),
+		growthstorage.CreateMicroclimateSampleStorage(),
+		growthstorage.CreateDispatchScheduleEventStorage(), growthstorage.CreateDispatchScheduleReadStorage(),
+		growthstorage.CreateInsurancePolicyEventStorage(), growthstorage.CreateInsurancePolicyReadStorage(),
+		growthstorage.CreateHarvestLotEventStorage(), growthstorage.CreateHarvestLotReadStorage(),
+		areaReadStorage, materialReadStorage, farmReadStorage, taskReadStorage,
+		prunedStorage, fieldValueStorage, fieldReadStorage,
+		energy.CreateEnergyReadingStorage(), envalert.CreateRuleStorage(), media.CreatePhotoHashStorage(),
+		signing.CreateKeyStorage(), flags,
+	)
+	require.Nil(t, err)
+
+	farmGroup := app.echo.Group("/api/farms")
+	farmServer.Mount(farmGroup)
+	growthServer.Mount(farmGroup)
+	taskServer.Mount(app.echo.Group("/api/tasks"))
+	taskServer.MountTaskTemplates(app.echo.Group("/api/task-templates"))
+
+	return app
+}
+
+func (app *testApp) eventCount() int {
+	return len(app.farmEvents.FarmEvents) +
+		len(app.reservoirEvents.ReservoirEvents) +
+		len(app.areaEvents.AreaEvents) +
+		len(app.materialEvents.MaterialEvents) +
+		len(app.certEvents.FarmCertificationEvents) +
+		len(app.fieldEvents.CustomFieldDefinitionEvents) +
+		len(app.stocktakeEvents.StocktakeEvents) +
+		len(app.equipmentEvents.EquipmentEvents) +
+		len(app.recipeEvents.NutrientRecipeEvents) +
+		len(app.boundaryEvents.FarmBoundaryEvents) +
+		len(app.cropEvents.CropEvents) +
+		len(app.scheduleEvents.CropInputScheduleEvents) +
+		len(app.taskEvents.TaskEvents) +
+		len(app.templateEvents.TaskTemplateEvents)
+}
+
+func (app *testApp) call(method, path string, form url.Values, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	rec := httptest.NewRecorder()
+	app.echo.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// create calls the live endpoint and returns the uid of the created entity.
+func (app *testApp) create(t *testing.T, path string, form url.Values) string {
+	t.Helper()
+
+	rec := app.call(http.MethodPost, path, form, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	body := struct {
+		Data struct {
+			UID string `json:"uid"`
+		} `json:"data"`
+	}{}
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+
+	return body.Data.UID
+}
+
+func TestValidateOnlyHasNoSideEffects(t *testing.T) {
+	// Given
+	app := newTestApp(t)
+
+	farmID := app.create(t, "/api/farms", url.Values{
+		"name": {"My Farm"}, "farm_type": {"organic"}, "latitude": {"1"}, "longitude": {"1"},
+		"country": {"ID"}, "city": {"Jawa Barat"},
+	})
+	reservoirID := app.create(t, "/api/farms/"+farmID+"/reservoirs", url.Values{
+		"name": {"Reservoir"}, "type": {"TAP"},
+	})
+	fieldID := app.create(t, "/api/farms/"+farmID+"/custom_fields", url.Values{
+		"entity_type": {"Area"}, "field_key": {"soil_ph"}, "field_type": {"number"},
+	})
+	areaForm := url.Values{
+		"name": {"Area One"}, "type": {"GROWING"}, "size": {"10"}, "size_unit": {"m2"},
+		"location": {"OUTDOOR"}, "reservoir_id": {reservoirID},
+	}
+	areaID := app.create(t, "/api/farms/"+farmID+"/areas", areaForm)
+	greenhouseID := app.create(t, "/api/farms/"+farmID+"/areas", url.Values{
+		"name": {"Greenhouse"}, "type": {"GROWING"}, "size": {"10"}, "size_unit": {"m2"},
+		"location": {"INDOOR"}, "reservoir_id": {reservoirID},
+	})
+	seedingAreaID := app.create(t, "/api/farms/"+farmID+"/areas", url.Values{
+		"name": {"Seeding One"}, "type": {"SEEDING"}, "size": {"10"}, "size_unit": {"m2"},
+		"location": {"INDOOR"}, "reservoir_id": {reservoirID},
+	})
+	materialForm := url.Values{
+		"name": {"Tomato"}, "price_per_unit": {"1"}, "currency_code": {"EUR"}, "quantity": {"2"},
+		"quantity_unit": {"PACKETS"}, "plant_type": {"HERB"},
+	}
+	materialID := app.create(t, "/api/farms/inventories/materials/seed", materialForm)
+	app.create(t, "/api/farms/inventories/materials/seed", url.Values{
+		"name": {"Basil"}, "price_per_unit": {"1"}, "currency_code": {"EUR"}, "quantity": {"2"},
+		"quantity_unit": {"PACKETS"}, "plant_type": {"HERB"},
+	})
+	cropForm := url.Values{
+		"crop_type": {"GROWING"}, "plant_type": {"HERB"}, "name": {"Tomato"},
+		"container_quantity": {"5"}, "container_type": {"POT"}, "container_cell": {"0"},
+	}
+	cropID := app.create(t, "/api/farms/areas/"+areaID+"/crops", cropForm)
+	scheduleForm := url.Values{
+		"material_id": {materialID}, "planned_date": {time.Now().AddDate(0, 0, 3).Format("2006-01-02")},
+		"quantity": {"2"}, "unit": {"KG"}, "application_method": {"FOLIAR_SPRAY"},
+	}
+	scheduleID := app.create(t, "/api/farms/"+farmID+"/crops/"+cropID+"/input-schedule", scheduleForm)
+	taskForm := url.Values{
+		"title": {"Check the tomatoes"}, "description": {"Look for aphids"}, "priority": {"NORMAL"},
+		"domain": {"GENERAL"}, "category": {"GENERAL"},
+	}
+	taskID := app.create(t, "/api/tasks", taskForm)
+	templateForm := url.Values{
+		"name":  {"Weekly"},
+		"items": {`[{"title":"Water","description":"","priority":"NORMAL","category":"GENERAL"}]`},
+	}
+	templateID := app.create(t, "/api/task-templates", templateForm)
+
+	noteForm := url.Values{"content": {"Looks fine"}}
+	endpoints := []struct {
+		method string
+		path   string
+		form   url.Values
+	}{
+		{http.MethodPost, "/api/farms", url.Values{
+			"name": {"Other Farm"}, "farm_type": {"organic"}, "latitude": {"1"}, "longitude": {"1"},
+			"country": {"ID"}, "city": {"Jawa Barat"},
+		}},
+		{http.MethodPut, "/api/farms/" + farmID, url.Values{"name": {"Renamed Farm"}}},
+		{http.MethodPost, "/api/farms/" + farmID + "/reservoirs", url.Values{"name": {"Other"}, "type": {"TAP"}}},
+		{http.MethodPut, "/api/farms/reservoirs/" + reservoirID, url.Values{"name": {"Renamed"}}},
+		{http.MethodPost, "/api/farms/reservoirs/" + reservoirID + "/notes", noteForm},
+		{http.MethodPost, "/api/farms/" + farmID + "/areas", url.Values{
+			"name": {"Area Two"}, "type": {"GROWING"}, "size": {"10"}, "size_unit": {"m2"},
+			"location": {"OUTDOOR"}, "reservoir_id": {reservoirID},
+		}},
+		{http.MethodPut, "/api/farms/areas/" + areaID, url.Values{"name": {"Renamed Area"}}},
+		{http.MethodPut, "/api/farms/areas/" + areaID, url.Values{"custom_fields": {`{"soil_ph":6.5}`}}},
+		{http.MethodPost, "/api/farms/areas/" + areaID + "/notes", noteForm},
+		{http.MethodPost, "/api/farms/" + farmID + "/certifications", url.Values{
+			"certification_type": {"ORGANIC"}, "certifying_body": {"Control Union"}, "certificate_number": {"CU-1"},
+			"issued_date": {"2024-01-01"}, "expiry_date": {time.Now().AddDate(0, 1, 0).Format("2006-01-02")},
+		}},
+		{http.MethodPost, "/api/farms/" + farmID + "/custom_fields", url.Values{
+			"entity_type": {"Area"}, "field_key": {"irrigated"}, "field_type": {"boolean"}, "required": {"true"},
+		}},
+		{http.MethodPut, "/api/farms/" + farmID + "/custom_fields/" + fieldID, url.Values{"required": {"true"}}},
+		{http.MethodPost, "/api/farms/inventories/materials/seed", url.Values{
+			"name": {"Pepper"}, "price_per_unit": {"1"}, "currency_code": {"EUR"}, "quantity": {"2"},
+			"quantity_unit": {"PACKETS"}, "plant_type": {"HERB"},
+		}},
+		{http.MethodPut, "/api/farms/inventories/materials/seed/" + materialID, url.Values{
+			"quantity": {"3"}, "quantity_unit": {"PACKETS"},
+		}},
+		{http.MethodPost, "/api/farms/areas/" + seedingAreaID + "/crops", url.Values{
+			"crop_type": {"SEEDING"}, "plant_type": {"HERB"}, "name": {"Basil"},
+			"container_quantity": {"5"}, "container_type": {"POT"}, "container_cell": {"0"},
+		}},
+		{http.MethodPut, "/api/farms/crops/" + cropID, url.Values{"container_quantity": {"4"}}},
+		{http.MethodPost, "/api/farms/crops/" + cropID + "/move", url.Values{
+			"source_area_id": {areaID}, "destination_area_id": {greenhouseID}, "quantity": {"2"},
+		}},
+		{http.MethodPost, "/api/farms/crops/" + cropID + "/harvest", url.Values{
+			"source_area_id": {areaID}, "harvest_type": {"PARTIAL"},
+			"produced_quantity": {"1"}, "produced_unit": {"Kg"},
+		}},
+		{http.MethodPost, "/api/farms/crops/" + cropID + "/dump", url.Values{
+			"source_area_id": {areaID}, "quantity": {"1"},
+		}},
+		{http.MethodPost, "/api/farms/crops/" + cropID + "/water", url.Values{
+			"source_area_id": {areaID}, "watering_date": {time.Now().Format("2006-01-02 15:04")},
+		}},
+		{http.MethodPost, "/api/farms/crops/" + cropID + "/notes", noteForm},
+		{http.MethodPost, "/api/farms/crops/" + cropID + "/nursery", url.Values{
+			"nursery_area_id":          {seedingAreaID},
+			"expected_transplant_date": {time.Now().AddDate(0, 0, 10).Format("2006-01-02")},
+		}},
+		{http.MethodPost, "/api/farms/" + farmID + "/crops/" + cropID + "/input-schedule", scheduleForm},
+		{http.MethodPut, "/api/farms/" + farmID + "/crops/" + cropID + "/input-schedule/" + scheduleID, scheduleForm},
+		{http.MethodPost, "/api/tasks", taskForm},
+		{http.MethodPut, "/api/tasks/" + taskID, url.Values{"title": {"Check the basil"}}},
+		{http.MethodPut, "/api/tasks/" + taskID + "/due", nil},
+		{http.MethodPut, "/api/tasks/" + taskID + "/complete", nil},
+		{http.MethodPut, "/api/tasks/" + taskID + "/cancel", nil},
+		{http.MethodPost, "/api/task-templates", templateForm},
+		{http.MethodPut, "/api/task-templates/" + templateID, url.Values{"name": {"Monthly"}}},
+	}
+
+	for _, endpoint := range endpoints {
+		events, published := app.eventCount(), app.published
+
+		// When
+		rec := app.call(endpoint.method, endpoint.path+"?validate_only=true", endpoint.form, nil)
+
+		// Then
+		assert.Equal(t, http.StatusOK, rec.Code, endpoint.method+" "+endpoint.path+" "+rec.Body.String())
+		assert.Equal(t, dryrun.PreferValidate, rec.Header().Get(dryrun.HeaderPreferenceApplied))
+		assert.Equal(t, events, app.eventCount(), endpoint.method+" "+endpoint.path)
+		assert.Equal(t, published, app.published, endpoint.method+" "+endpoint.path)
+	}
+
+	// When
+	events := app.eventCount()
+	invalid := app.call(http.MethodPost, "/api/farms/"+farmID+"/areas", url.Values{"name": {"A"}, "reservoir_id": {reservoirID}},
+		http.Header{"Prefer": {"return=minimal, validate"}})
+	invalidEvents := app.eventCount()
+	areaForm.Set("name", "Area Three")
+	live := app.call(http.MethodPost, "/api/farms/"+farmID+"/areas", areaForm, nil)
+
+	// Then
+	assert.Equal(t, http.StatusBadRequest, invalid.Code, invalid.Body.String())
+	assert.Equal(t, events, invalidEvents)
+	assert.Equal(t, http.StatusOK, live.Code, live.Body.String())
+	assert.Empty(t, live.Header().Get(dryrun.HeaderPreferenceApplied))
+	assert.Greater(t, app.eventCount(), events)
+}
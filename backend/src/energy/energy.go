@@ -0,0 +1,146 @@
+// Package energy records the electricity meter readings of the farms and reports their consumption and cost.
+package energy
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+)
+
+const (
+	EnergyReadingRecordedCode   = "EnergyReadingRecorded"
+	EnergyThresholdExceededCode = "EnergyThresholdExceeded"
+)
+
+// maxMeterIDLength keeps the meter ids to the labels printed on the meters.
+const maxMeterIDLength = 50
+
+// ValidationError is an invalid field of a new reading.
+type ValidationError struct {
+	Field string
+}
+
+func (e ValidationError) Error() string {
+	return "invalid energy reading " + e.Field
+}
+
+// EnergyReadingRecorded is the consumption of a meter of the farm since its previous reading,
+// billed at the tariff rate per kWh.
+type EnergyReadingRecorded struct {
+	UID        uuid.UUID `json:"uid"`
+	FarmID     uuid.UUID `json:"farm_id"`
+	MeterID    string    `json:"meter_id"`
+	KWh        float64   `json:"kwh"`
+	RecordedAt time.Time `json:"recorded_at"`
+	TariffRate float64   `json:"tariff_rate"`
+	Currency   string    `json:"currency"`
+}
+
+// Cost is the price of the consumption of the reading.
+func (r EnergyReadingRecorded) Cost() float64 {
+	return r.KWh * r.TariffRate
+}
+
+// EnergyThresholdExceeded is published once a day, with the reading which takes the consumption of the farm
+// on that day above the threshold.
+type EnergyThresholdExceeded struct {
+	FarmID    uuid.UUID `json:"farm_id"`
+	Date      string    `json:"date"`
+	KWh       float64   `json:"kwh"`
+	Threshold float64   `json:"threshold"`
+}
+
+type Store interface {
+	Save(reading EnergyReadingRecorded) error
+
+	// FindAllByFarm returns the readings of the farm recorded in [from, to), the earliest first.
+	// A zero from or to leaves that end of the range open.
+	FindAllByFarm(farmUID uuid.UUID, from, to time.Time) ([]EnergyReadingRecorded, error)
+}
+
+// NewReading validates the reading. A zero recorded time is now.
+func NewReading(
+	farmUID uuid.UUID,
+	meterID string,
+	kwh float64,
+	recordedAt time.Time,
+	tariffRate float64,
+	currency string,
+	now time.Time,
+) (EnergyReadingRecorded, error) {
+	meterID = strings.TrimSpace(meterID)
+	if meterID == "" || len(meterID) > maxMeterIDLength {
+		return EnergyReadingRecorded{}, ValidationError{Field: "meter_id"}
+	}
+
+	if kwh < 0 {
+		return EnergyReadingRecorded{}, ValidationError{Field: "kwh"}
+	}
+
+	if recordedAt.IsZero() {
+		recordedAt = now
+	}
+
+	if recordedAt.After(now) {
+		return EnergyReadingRecorded{}, ValidationError{Field: "recorded_at"}
+	}
+
+	if tariffRate < 0 {
+		return EnergyReadingRecorded{}, ValidationError{Field: "tariff_rate"}
+	}
+
+	currency, err := assetsdomain.GetCurrencyCode(strings.ToUpper(currency))
+	if err != nil {
+		return EnergyReadingRecorded{}, ValidationError{Field: "currency"}
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return EnergyReadingRecorded{}, err
+	}
+
+	return EnergyReadingRecorded{
+		UID:        uid,
+		FarmID:     farmUID,
+		MeterID:    meterID,
+		KWh:        kwh,
+		RecordedAt: recordedAt,
+		TariffRate: tariffRate,
+		Currency:   currency,
+	}, nil
+}
+
+// ThresholdExceeded returns the alert when the reading takes the consumption of its day above the threshold.
+// The day's readings include the new one, so the farm is alerted once a day. A threshold of zero is off.
+func ThresholdExceeded(reading EnergyReadingRecorded, dayReadings []EnergyReadingRecorded, threshold float64) (
+	*EnergyThresholdExceeded, bool,
+) {
+	if threshold <= 0 {
+		return nil, false
+	}
+
+	total := 0.0
+	for _, v := range dayReadings {
+		total += v.KWh
+	}
+
+	if total <= threshold || total-reading.KWh > threshold {
+		return nil, false
+	}
+
+	return &EnergyThresholdExceeded{
+		FarmID:    reading.FarmID,
+		Date:      reading.RecordedAt.Format("2006-01-02"),
+		KWh:       total,
+		Threshold: threshold,
+	}, true
+}
+
+// DayRange returns the start of the day of the time and of the next day, in the location of the time.
+func DayRange(t time.Time) (time.Time, time.Time) {
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+
+	return start, start.AddDate(0, 0, 1)
+}
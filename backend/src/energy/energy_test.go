@@ -0,0 +1,163 @@
+package energy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/energy"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
+)
+
+func newReading(t *testing.T, farmUID uuid.UUID, kwh float64, recordedAt time.Time) energy.EnergyReadingRecorded {
+	t.Helper()
+
+	reading, err := energy.NewReading(farmUID, "MAIN", kwh, recordedAt, 0.25, "eur", recordedAt)
+	assert.Nil(t, err)
+
+	return reading
+}
+
+func TestNewReading(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	now := time.Date(2026, time.October, 14, 10, 0, 0, 0, time.UTC)
+
+	// When
+	reading, err := energy.NewReading(farmUID, " MAIN ", 12.5, time.Time{}, 0.2, "eur", now)
+
+	_, meterErr := energy.NewReading(farmUID, " ", 12.5, now, 0.2, "EUR", now)
+	_, kwhErr := energy.NewReading(farmUID, "MAIN", -1, now, 0.2, "EUR", now)
+	_, futureErr := energy.NewReading(farmUID, "MAIN", 12.5, now.Add(time.Hour), 0.2, "EUR", now)
+	_, tariffErr := energy.NewReading(farmUID, "MAIN", 12.5, now, -0.2, "EUR", now)
+	_, currencyErr := energy.NewReading(farmUID, "MAIN", 12.5, now, 0.2, "XYZ", now)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "MAIN", reading.MeterID)
+	assert.Equal(t, "EUR", reading.Currency)
+	assert.Equal(t, now, reading.RecordedAt)
+	assert.InDelta(t, 2.5, reading.Cost(), 0.0001)
+
+	assert.Equal(t, energy.ValidationError{Field: "meter_id"}, meterErr)
+	assert.Equal(t, energy.ValidationError{Field: "kwh"}, kwhErr)
+	assert.Equal(t, energy.ValidationError{Field: "recorded_at"}, futureErr)
+	assert.Equal(t, energy.ValidationError{Field: "tariff_rate"}, tariffErr)
+	assert.Equal(t, energy.ValidationError{Field: "currency"}, currencyErr)
+}
+
+func TestThresholdExceededOnceADay(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	day := time.Date(2026, time.October, 14, 8, 0, 0, 0, time.UTC)
+
+	first := newReading(t, farmUID, 60, day)
+	second := newReading(t, farmUID, 50, day.Add(time.Hour))
+	third := newReading(t, farmUID, 30, day.Add(2*time.Hour))
+
+	// When
+	_, firstExceeded := energy.ThresholdExceeded(first, []energy.EnergyReadingRecorded{first}, 100)
+	alert, secondExceeded := energy.ThresholdExceeded(second, []energy.EnergyReadingRecorded{first, second}, 100)
+	_, thirdExceeded := energy.ThresholdExceeded(third, []energy.EnergyReadingRecorded{first, second, third}, 100)
+	_, offExceeded := energy.ThresholdExceeded(second, []energy.EnergyReadingRecorded{first, second}, 0)
+
+	// Then
+	assert.False(t, firstExceeded)
+	assert.True(t, secondExceeded)
+	assert.Equal(t, energy.EnergyThresholdExceeded{
+		FarmID: farmUID, Date: "2026-10-14", KWh: 110, Threshold: 100,
+	}, *alert)
+	assert.False(t, thirdExceeded)
+	assert.False(t, offExceeded)
+}
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	day := time.Date(2026, time.October, 13, 0, 0, 0, 0, time.UTC)
+
+	query, err := timebuckethelper.ParseQuery(func(name string) string {
+		return map[string]string{"interval": "day", "timezone": "UTC", "from": "2026-10-13", "to": "2026-10-14"}[name]
+	}, timebuckethelper.IntervalDay, nil)
+	assert.Nil(t, err)
+
+	buckets, err := query.Buckets(time.Time{}, day.AddDate(0, 0, 2))
+	assert.Nil(t, err)
+
+	report := energy.NewReport(buckets)
+
+	// When
+	assert.Nil(t, report.AddReading(newReading(t, farmUID, 10, day.Add(8*time.Hour))))
+	assert.Nil(t, report.AddReading(newReading(t, farmUID, 20, day.Add(20*time.Hour))))
+	assert.Nil(t, report.AddReading(newReading(t, farmUID, 40, day.Add(32*time.Hour))))
+	assert.Nil(t, report.AddReading(newReading(t, farmUID, 80, day.AddDate(0, 0, 3))))
+
+	report.AddHarvest(day.Add(10*time.Hour), 1500)
+	report.AddHarvest(day.AddDate(0, 0, -1), 5000)
+
+	summary := report.Summary()
+
+	// Then
+	assert.Equal(t, []energy.EnergyRow{
+		{Date: "2026-10-13", KWh: 30, Cost: 7.5},
+		{Date: "2026-10-14", KWh: 40, Cost: 10},
+	}, report.Rows())
+	assert.InDelta(t, 70, summary.KWh, 0.0001)
+	assert.InDelta(t, 17.5, summary.Cost, 0.0001)
+	assert.Equal(t, "EUR", summary.Currency)
+	assert.InDelta(t, 1.5, summary.HarvestedKg, 0.0001)
+	assert.InDelta(t, 17.5/1.5, *summary.CostPerKg, 0.0001)
+}
+
+func TestReportWithoutHarvests(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	day := time.Date(2026, time.October, 13, 0, 0, 0, 0, time.UTC)
+	report := energy.NewReport([]timebuckethelper.Bucket{{Label: "2026-10-13", Start: day, End: day.AddDate(0, 0, 1)}})
+
+	reading := newReading(t, farmUID, 10, day.Add(time.Hour))
+	otherCurrency := reading
+	otherCurrency.Currency = "USD"
+
+	// When
+	err := report.AddReading(reading)
+	mixedErr := report.AddReading(otherCurrency)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, energy.ErrMixedCurrencies, mixedErr)
+	assert.Nil(t, report.Summary().CostPerKg)
+}
+
+func TestStoreInMemory(t *testing.T) {
+	t.Parallel()
+	// Given
+	store := energy.NewStoreInMemory(energy.CreateEnergyReadingStorage())
+	farmUID, _ := uuid.NewV4()
+	otherFarmUID, _ := uuid.NewV4()
+	day := time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC)
+
+	late := newReading(t, farmUID, 2, day.Add(20*time.Hour))
+	early := newReading(t, farmUID, 1, day.Add(8*time.Hour))
+
+	assert.Nil(t, store.Save(late))
+	assert.Nil(t, store.Save(early))
+	assert.Nil(t, store.Save(newReading(t, farmUID, 3, day.AddDate(0, 0, 1))))
+	assert.Nil(t, store.Save(newReading(t, otherFarmUID, 4, day.Add(9*time.Hour))))
+
+	// When
+	start, end := energy.DayRange(day.Add(12 * time.Hour))
+	dayReadings, err := store.FindAllByFarm(farmUID, start, end)
+	all, allErr := store.FindAllByFarm(farmUID, time.Time{}, time.Time{})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []energy.EnergyReadingRecorded{early, late}, dayReadings)
+	assert.Nil(t, allErr)
+	assert.Len(t, all, 3)
+}
@@ -0,0 +1,60 @@
+package energy
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/sasha-s/go-deadlock"
+)
+
+type EnergyReadingStorage struct {
+	Lock           *deadlock.RWMutex
+	EnergyReadings []EnergyReadingRecorded
+}
+
+func CreateEnergyReadingStorage() *EnergyReadingStorage {
+	return &EnergyReadingStorage{Lock: &deadlock.RWMutex{}}
+}
+
+type StoreInMemory struct {
+	Storage *EnergyReadingStorage
+}
+
+func NewStoreInMemory(s *EnergyReadingStorage) Store {
+	return &StoreInMemory{Storage: s}
+}
+
+func (s *StoreInMemory) Save(reading EnergyReadingRecorded) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	s.Storage.EnergyReadings = append(s.Storage.EnergyReadings, reading)
+
+	return nil
+}
+
+func (s *StoreInMemory) FindAllByFarm(farmUID uuid.UUID, from, to time.Time) ([]EnergyReadingRecorded, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	readings := []EnergyReadingRecorded{}
+
+	for _, v := range s.Storage.EnergyReadings {
+		if v.FarmID != farmUID {
+			continue
+		}
+
+		if (!from.IsZero() && v.RecordedAt.Before(from)) || (!to.IsZero() && !v.RecordedAt.Before(to)) {
+			continue
+		}
+
+		readings = append(readings, v)
+	}
+
+	sort.SliceStable(readings, func(i, j int) bool {
+		return readings[i].RecordedAt.Before(readings[j].RecordedAt)
+	})
+
+	return readings, nil
+}
@@ -0,0 +1,78 @@
+package energy
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type StoreMysql struct {
+	DB *sql.DB
+}
+
+func NewStoreMysql(db *sql.DB) Store {
+	return &StoreMysql{DB: db}
+}
+
+func (s *StoreMysql) Save(reading EnergyReadingRecorded) error {
+	_, err := s.DB.Exec(`INSERT INTO ENERGY_READING (`+readingColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		reading.UID.Bytes(),
+		reading.FarmID.Bytes(),
+		reading.MeterID,
+		reading.KWh,
+		reading.RecordedAt,
+		reading.TariffRate,
+		reading.Currency)
+
+	return err
+}
+
+func (s *StoreMysql) FindAllByFarm(farmUID uuid.UUID, from, to time.Time) ([]EnergyReadingRecorded, error) {
+	query := `SELECT ` + readingColumns + ` FROM ENERGY_READING WHERE FARM_UID = ?`
+	args := []interface{}{farmUID.Bytes()}
+
+	if !from.IsZero() {
+		query += ` AND RECORDED_AT >= ?`
+		args = append(args, from)
+	}
+
+	if !to.IsZero() {
+		query += ` AND RECORDED_AT < ?`
+		args = append(args, to)
+	}
+
+	rows, err := s.DB.Query(query+` ORDER BY RECORDED_AT ASC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	readings := []EnergyReadingRecorded{}
+
+	for rows.Next() {
+		var uid, farmID []byte
+
+		v := EnergyReadingRecorded{}
+
+		err = rows.Scan(&uid, &farmID, &v.MeterID, &v.KWh, &v.RecordedAt, &v.TariffRate, &v.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		v.UID, err = uuid.FromBytes(uid)
+		if err != nil {
+			return nil, err
+		}
+
+		v.FarmID, err = uuid.FromBytes(farmID)
+		if err != nil {
+			return nil, err
+		}
+
+		readings = append(readings, v)
+	}
+
+	return readings, rows.Err()
+}
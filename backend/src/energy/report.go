@@ -0,0 +1,86 @@
+package energy
+
+import (
+	"errors"
+	"time"
+
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
+)
+
+// ErrMixedCurrencies is a report of readings billed in different currencies, their costs can't be summed up.
+var ErrMixedCurrencies = errors.New("the energy readings are billed in different currencies")
+
+// EnergyRow is the consumption of the farm and its cost in a bucket of the report.
+type EnergyRow struct {
+	Date string  `json:"date"`
+	KWh  float64 `json:"kwh"`
+	Cost float64 `json:"cost"`
+}
+
+// Summary totals the period of the report, with the cost of the energy per kg of the produce harvested in it.
+type Summary struct {
+	KWh         float64  `json:"kwh"`
+	Cost        float64  `json:"cost"`
+	Currency    string   `json:"currency"`
+	HarvestedKg float64  `json:"harvested_kg"`
+	CostPerKg   *float64 `json:"cost_per_kg"`
+}
+
+// Report sums the readings and the harvests in the buckets of the period, the ones outside are left out.
+type Report struct {
+	buckets []timebuckethelper.Bucket
+	rows    []EnergyRow
+	summary Summary
+}
+
+func NewReport(buckets []timebuckethelper.Bucket) *Report {
+	rows := make([]EnergyRow, len(buckets))
+	for i, v := range buckets {
+		rows[i] = EnergyRow{Date: v.Label}
+	}
+
+	return &Report{buckets: buckets, rows: rows}
+}
+
+func (r *Report) AddReading(reading EnergyReadingRecorded) error {
+	i := timebuckethelper.Find(r.buckets, reading.RecordedAt)
+	if i < 0 {
+		return nil
+	}
+
+	if r.summary.Currency != "" && r.summary.Currency != reading.Currency {
+		return ErrMixedCurrencies
+	}
+
+	r.summary.Currency = reading.Currency
+	r.rows[i].KWh += reading.KWh
+	r.rows[i].Cost += reading.Cost()
+	r.summary.KWh += reading.KWh
+	r.summary.Cost += reading.Cost()
+
+	return nil
+}
+
+func (r *Report) AddHarvest(harvestDate time.Time, producedGramQuantity float32) {
+	if timebuckethelper.Find(r.buckets, harvestDate) < 0 {
+		return
+	}
+
+	r.summary.HarvestedKg += float64(producedGramQuantity) / 1000
+}
+
+func (r *Report) Rows() []EnergyRow {
+	return r.rows
+}
+
+// Summary has no cost per kg when nothing was harvested in the period.
+func (r *Report) Summary() Summary {
+	summary := r.summary
+
+	if summary.HarvestedKg > 0 {
+		costPerKg := summary.Cost / summary.HarvestedKg
+		summary.CostPerKg = &costPerKg
+	}
+
+	return summary
+}
@@ -0,0 +1,89 @@
+package energy
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type StoreSqlite struct {
+	DB *sql.DB
+}
+
+func NewStoreSqlite(db *sql.DB) Store {
+	return &StoreSqlite{DB: db}
+}
+
+const readingColumns = `UID, FARM_UID, METER_ID, KWH, RECORDED_AT, TARIFF_RATE, CURRENCY`
+
+func (s *StoreSqlite) Save(reading EnergyReadingRecorded) error {
+	_, err := s.DB.Exec(`INSERT INTO ENERGY_READING (`+readingColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		reading.UID.String(),
+		reading.FarmID.String(),
+		reading.MeterID,
+		reading.KWh,
+		formatDateSqlite(reading.RecordedAt),
+		reading.TariffRate,
+		reading.Currency)
+
+	return err
+}
+
+func (s *StoreSqlite) FindAllByFarm(farmUID uuid.UUID, from, to time.Time) ([]EnergyReadingRecorded, error) {
+	query := `SELECT ` + readingColumns + ` FROM ENERGY_READING WHERE FARM_UID = ?`
+	args := []interface{}{farmUID.String()}
+
+	if !from.IsZero() {
+		query += ` AND RECORDED_AT >= ?`
+		args = append(args, formatDateSqlite(from))
+	}
+
+	if !to.IsZero() {
+		query += ` AND RECORDED_AT < ?`
+		args = append(args, formatDateSqlite(to))
+	}
+
+	rows, err := s.DB.Query(query+` ORDER BY RECORDED_AT ASC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	readings := []EnergyReadingRecorded{}
+
+	for rows.Next() {
+		var uid, farmID, recordedAt string
+
+		v := EnergyReadingRecorded{}
+
+		err = rows.Scan(&uid, &farmID, &v.MeterID, &v.KWh, &recordedAt, &v.TariffRate, &v.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		v.UID, err = uuid.FromString(uid)
+		if err != nil {
+			return nil, err
+		}
+
+		v.FarmID, err = uuid.FromString(farmID)
+		if err != nil {
+			return nil, err
+		}
+
+		v.RecordedAt, err = time.Parse(time.RFC3339, recordedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		readings = append(readings, v)
+	}
+
+	return readings, rows.Err()
+}
+
+func formatDateSqlite(date time.Time) string {
+	return date.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,237 @@
+// Package envalert alerts on the environment readings of the areas, above or below a threshold
+// or changing too fast over a window, as the readings arrive.
+package envalert
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/notification"
+)
+
+const (
+	KindAbsolute     = "ABSOLUTE"
+	KindRateOfChange = "RATE_OF_CHANGE"
+
+	DirectionRise = "RISE"
+	DirectionDrop = "DROP"
+
+	AreaEnvironmentAlertCode = "AreaEnvironmentAlert"
+
+	// WebhookEventAreaEnvironmentAlert is the type the alerts are posted to the webhook with.
+	WebhookEventAreaEnvironmentAlert = "area.environment_alert"
+)
+
+const (
+	// defaultHysteresis is the margin, in °C, the reading has to come back by before the rule fires again.
+	defaultHysteresis = 0.5
+
+	// maxWindowMinutes keeps the samples of the windows to a day of sensor input.
+	maxWindowMinutes = 24 * 60
+)
+
+// ValidationError is an invalid field of a new rule.
+type ValidationError struct {
+	Field string
+}
+
+func (e ValidationError) Error() string {
+	return "invalid environment alert rule " + e.Field
+}
+
+// Rule alerts on the temperature of the area. An absolute rule fires when the temperature rises above
+// the threshold or drops below it. A rate of change rule fires when the temperature rises or drops by more
+// than the threshold within the window. Once fired, the rule is triggered until the reading comes back
+// by the hysteresis, so a reading hovering around the threshold alerts once.
+type Rule struct {
+	UID           uuid.UUID `json:"uid"`
+	AreaUID       uuid.UUID `json:"area_id"`
+	Kind          string    `json:"kind"`
+	Direction     string    `json:"direction"`
+	Threshold     float64   `json:"threshold"`
+	WindowMinutes int       `json:"window_minutes"`
+	Hysteresis    float64   `json:"hysteresis"`
+	Priority      string    `json:"priority"`
+	Triggered     bool      `json:"triggered"`
+	CreatedDate   time.Time `json:"created_date"`
+}
+
+// AreaEnvironmentAlert is published when a rule of the area fires. The value is the temperature of the
+// absolute rules, and the rise or the drop within the window of the rate of change ones.
+type AreaEnvironmentAlert struct {
+	RuleUID       uuid.UUID `json:"rule_id"`
+	AreaUID       uuid.UUID `json:"area_id"`
+	AreaName      string    `json:"area_name"`
+	Kind          string    `json:"kind"`
+	Direction     string    `json:"direction"`
+	Threshold     float64   `json:"threshold"`
+	WindowMinutes int       `json:"window_minutes"`
+	Value         float64   `json:"value"`
+	Temperature   float64   `json:"temperature"`
+	Priority      string    `json:"priority"`
+	RecordedDate  time.Time `json:"recorded_date"`
+}
+
+// RoutingPriority is the priority of the rule, it picks the notification channels of the alert.
+func (a AreaEnvironmentAlert) RoutingPriority() string {
+	return a.Priority
+}
+
+// WebhookEvent is the type the alert is posted to the webhook with.
+func (a AreaEnvironmentAlert) WebhookEvent() string {
+	return WebhookEventAreaEnvironmentAlert
+}
+
+// WebhookEventTypes describes the webhook event of the alerts, for the catalog of the webhook events.
+func WebhookEventTypes() ([]notification.WebhookEventType, error) {
+	ruleUID, err := uuid.FromString("0d7f6b7e-3c1a-4e2b-8a52-6f3c2b9d1e40")
+	if err != nil {
+		return nil, err
+	}
+
+	areaUID, err := uuid.FromString("9a3e4c21-58b7-4d0f-b6a1-2c7e5f8d3b92")
+	if err != nil {
+		return nil, err
+	}
+
+	alert, err := notification.NewWebhookEventType(AreaEnvironmentAlert{
+		RuleUID:       ruleUID,
+		AreaUID:       areaUID,
+		AreaName:      "Greenhouse 1",
+		Kind:          KindRateOfChange,
+		Direction:     DirectionRise,
+		Threshold:     5,
+		WindowMinutes: 30,
+		Value:         6.2,
+		Temperature:   31.4,
+		Priority:      notification.PriorityUrgent,
+		RecordedDate:  time.Date(2026, time.October, 14, 13, 30, 0, 0, time.UTC),
+	}, "A temperature alert rule of an area fired. The value is the temperature of the absolute rules, "+
+		"the rise or the drop within the window of the rate of change ones.")
+	if err != nil {
+		return nil, err
+	}
+
+	return []notification.WebhookEventType{alert}, nil
+}
+
+// Text is the alert as a line of text, like the SMS and the log.
+func (a AreaEnvironmentAlert) Text() string {
+	area := a.AreaName
+	if area == "" {
+		area = a.AreaUID.String()
+	}
+
+	if a.Kind == KindRateOfChange {
+		verb := "rose"
+		if a.Direction == DirectionDrop {
+			verb = "dropped"
+		}
+
+		return fmt.Sprintf("[%s] %s: the temperature %s by %.1f°C in %d minutes, to %.1f°C",
+			a.Priority, area, verb, a.Value, a.WindowMinutes, a.Temperature)
+	}
+
+	side := "above"
+	if a.Direction == DirectionDrop {
+		side = "below"
+	}
+
+	return fmt.Sprintf("[%s] %s: the temperature is %.1f°C, %s %.1f°C", a.Priority, area, a.Temperature, side,
+		a.Threshold)
+}
+
+type Store interface {
+	// Save adds the rule, or replaces it.
+	Save(rule Rule) error
+	Remove(ruleUID uuid.UUID) error
+	FindAll() ([]Rule, error)
+}
+
+// NewRule validates the rule. A nil hysteresis is the default one, an empty priority is URGENT.
+// The window is only kept for the rate of change rules.
+func NewRule(
+	areaUID uuid.UUID,
+	kind, direction string,
+	threshold float64,
+	windowMinutes int,
+	hysteresis *float64,
+	priority string,
+	now time.Time,
+) (Rule, error) {
+	if kind != KindAbsolute && kind != KindRateOfChange {
+		return Rule{}, ValidationError{Field: "kind"}
+	}
+
+	if direction != DirectionRise && direction != DirectionDrop {
+		return Rule{}, ValidationError{Field: "direction"}
+	}
+
+	if kind == KindAbsolute {
+		windowMinutes = 0
+	}
+
+	if kind == KindRateOfChange {
+		if windowMinutes < 1 || windowMinutes > maxWindowMinutes {
+			return Rule{}, ValidationError{Field: "window_minutes"}
+		}
+
+		if threshold <= 0 {
+			return Rule{}, ValidationError{Field: "threshold"}
+		}
+	}
+
+	margin := defaultHysteresis
+	if hysteresis != nil {
+		margin = *hysteresis
+	}
+
+	// The rise or the drop of a rate of change rule can't come back below zero.
+	if margin < 0 || (kind == KindRateOfChange && margin >= threshold) {
+		return Rule{}, ValidationError{Field: "hysteresis"}
+	}
+
+	switch priority {
+	case "":
+		priority = notification.PriorityUrgent
+	case notification.PriorityUrgent, notification.PriorityNormal, notification.PriorityLow:
+	default:
+		return Rule{}, ValidationError{Field: "priority"}
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return Rule{}, err
+	}
+
+	return Rule{
+		UID:           uid,
+		AreaUID:       areaUID,
+		Kind:          kind,
+		Direction:     direction,
+		Threshold:     threshold,
+		WindowMinutes: windowMinutes,
+		Hysteresis:    margin,
+		Priority:      priority,
+		CreatedDate:   now,
+	}, nil
+}
+
+// fires tells whether the value is past the threshold of the rule.
+func (r Rule) fires(value float64) bool {
+	if r.Kind == KindAbsolute && r.Direction == DirectionDrop {
+		return value < r.Threshold
+	}
+
+	return value > r.Threshold
+}
+
+// clears tells whether the value came back from the threshold of the rule by the hysteresis.
+func (r Rule) clears(value float64) bool {
+	if r.Kind == KindAbsolute && r.Direction == DirectionDrop {
+		return value > r.Threshold+r.Hysteresis
+	}
+
+	return value < r.Threshold-r.Hysteresis
+}
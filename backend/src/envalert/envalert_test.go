@@ -0,0 +1,246 @@
+package envalert_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/usetania/tania-core/src/envalert"
+	"github.com/usetania/tania-core/src/notification"
+)
+
+func newRule(t *testing.T, areaUID uuid.UUID, kind, direction string, threshold float64, window int) envalert.Rule {
+	t.Helper()
+
+	rule, err := envalert.NewRule(areaUID, kind, direction, threshold, window, nil, "", time.Now())
+	require.Nil(t, err)
+
+	return rule
+}
+
+func TestNewRule(t *testing.T) {
+	t.Parallel()
+	// Given
+	areaUID, _ := uuid.NewV4()
+	now := time.Date(2026, time.October, 14, 10, 0, 0, 0, time.UTC)
+	big := 5.0
+
+	// When
+	rule, err := envalert.NewRule(areaUID, envalert.KindAbsolute, envalert.DirectionDrop, -2, 30, nil, "", now)
+
+	_, kindErr := envalert.NewRule(areaUID, "DELTA", envalert.DirectionDrop, 5, 30, nil, "", now)
+	_, directionErr := envalert.NewRule(areaUID, envalert.KindAbsolute, "UP", 5, 30, nil, "", now)
+	_, windowErr := envalert.NewRule(areaUID, envalert.KindRateOfChange, envalert.DirectionDrop, 5, 0, nil, "", now)
+	_, thresholdErr := envalert.NewRule(areaUID, envalert.KindRateOfChange, envalert.DirectionDrop, -5, 30, nil, "",
+		now)
+	_, hysteresisErr := envalert.NewRule(areaUID, envalert.KindRateOfChange, envalert.DirectionDrop, 5, 30, &big, "",
+		now)
+	_, priorityErr := envalert.NewRule(areaUID, envalert.KindAbsolute, envalert.DirectionRise, 35, 0, nil, "HIGH",
+		now)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 0, rule.WindowMinutes)
+	assert.InDelta(t, 0.5, rule.Hysteresis, 0.0001)
+	assert.Equal(t, notification.PriorityUrgent, rule.Priority)
+	assert.False(t, rule.Triggered)
+
+	assert.Equal(t, envalert.ValidationError{Field: "kind"}, kindErr)
+	assert.Equal(t, envalert.ValidationError{Field: "direction"}, directionErr)
+	assert.Equal(t, envalert.ValidationError{Field: "window_minutes"}, windowErr)
+	assert.Equal(t, envalert.ValidationError{Field: "threshold"}, thresholdErr)
+	assert.Equal(t, envalert.ValidationError{Field: "hysteresis"}, hysteresisErr)
+	assert.Equal(t, envalert.ValidationError{Field: "priority"}, priorityErr)
+}
+
+func TestEvaluateRateOfChangeWithHysteresis(t *testing.T) {
+	t.Parallel()
+	// Given
+	areaUID, _ := uuid.NewV4()
+	storage := envalert.CreateRuleStorage()
+	evaluator, err := envalert.NewEvaluator(envalert.NewStoreInMemory(storage))
+	require.Nil(t, err)
+
+	// A drop of more than 5°C in 30 minutes.
+	rule := newRule(t, areaUID, envalert.KindRateOfChange, envalert.DirectionDrop, 5, 30)
+	require.Nil(t, evaluator.Add(rule))
+
+	start := time.Date(2026, time.October, 14, 22, 0, 0, 0, time.UTC)
+	evaluate := func(minutes int, temperature float64) []envalert.AreaEnvironmentAlert {
+		alerts, err := evaluator.Evaluate(areaUID, temperature, start.Add(time.Duration(minutes)*time.Minute))
+		require.Nil(t, err)
+
+		return alerts
+	}
+
+	// When
+	slow := evaluate(0, 20)
+	slow = append(slow, evaluate(20, 17)...)
+	slow = append(slow, evaluate(40, 15)...)
+	slow = append(slow, evaluate(60, 13)...)
+
+	fast := evaluate(70, 18)
+	fast = append(fast, evaluate(80, 12.5)...)
+
+	// Still a drop of more than 4.5°C from 18°C, the rule stays triggered.
+	hovering := evaluate(85, 13.4)
+	hovering = append(hovering, evaluate(90, 12)...)
+
+	// The 18°C reading left the window, the drop is 1°C from 13.4°C.
+	cleared := evaluate(105, 12.4)
+	clearedRule := storage.RuleMap[rule.UID]
+
+	late := evaluate(10, 30)
+	late = append(late, evaluate(10, 0)...)
+
+	again := evaluate(110, 6)
+
+	// Then
+	assert.Empty(t, slow)
+
+	assert.Len(t, fast, 1)
+	assert.Equal(t, rule.UID, fast[0].RuleUID)
+	assert.InDelta(t, 5.5, fast[0].Value, 0.0001)
+	assert.InDelta(t, 12.5, fast[0].Temperature, 0.0001)
+	assert.Equal(t, notification.PriorityUrgent, fast[0].RoutingPriority())
+	assert.Equal(t, "[URGENT] "+areaUID.String()+": the temperature dropped by 5.5°C in 30 minutes, to 12.5°C",
+		fast[0].Text())
+
+	assert.Empty(t, hovering)
+	assert.Empty(t, cleared)
+	assert.False(t, clearedRule.Triggered)
+	assert.Empty(t, late)
+
+	assert.Len(t, again, 1)
+	assert.InDelta(t, 7.4, again[0].Value, 0.0001)
+	assert.True(t, storage.RuleMap[rule.UID].Triggered)
+}
+
+func TestEvaluateAbsolute(t *testing.T) {
+	t.Parallel()
+	// Given
+	areaUID, _ := uuid.NewV4()
+	otherAreaUID, _ := uuid.NewV4()
+	evaluator, err := envalert.NewEvaluator(envalert.NewStoreInMemory(envalert.CreateRuleStorage()))
+	require.Nil(t, err)
+
+	frost := newRule(t, areaUID, envalert.KindAbsolute, envalert.DirectionDrop, 2, 0)
+	heat := newRule(t, areaUID, envalert.KindAbsolute, envalert.DirectionRise, 35, 0)
+	require.Nil(t, evaluator.Add(frost))
+	require.Nil(t, evaluator.Add(heat))
+
+	now := time.Now()
+
+	// When
+	cold, _ := evaluator.Evaluate(areaUID, 1.5, now)
+	hovering, _ := evaluator.Evaluate(areaUID, 2.3, now.Add(time.Minute))
+	colder, _ := evaluator.Evaluate(areaUID, 1.9, now.Add(2*time.Minute))
+	warm, _ := evaluator.Evaluate(areaUID, 3, now.Add(3*time.Minute))
+	refrozen, _ := evaluator.Evaluate(areaUID, 1, now.Add(4*time.Minute))
+	hot, _ := evaluator.Evaluate(areaUID, 36, now.Add(5*time.Minute))
+	other, _ := evaluator.Evaluate(otherAreaUID, 50, now)
+
+	// Then
+	assert.Len(t, cold, 1)
+	assert.Equal(t, frost.UID, cold[0].RuleUID)
+	assert.Equal(t, "[URGENT] "+areaUID.String()+": the temperature is 1.5°C, below 2.0°C", cold[0].Text())
+	assert.Empty(t, hovering)
+	assert.Empty(t, colder)
+	assert.Empty(t, warm)
+	assert.Len(t, refrozen, 1)
+	assert.Len(t, hot, 1)
+	assert.Equal(t, heat.UID, hot[0].RuleUID)
+	assert.Empty(t, other)
+}
+
+func TestEvaluatorRules(t *testing.T) {
+	t.Parallel()
+	// Given
+	areaUID, _ := uuid.NewV4()
+	storage := envalert.CreateRuleStorage()
+	evaluator, err := envalert.NewEvaluator(envalert.NewStoreInMemory(storage))
+	require.Nil(t, err)
+
+	rule := newRule(t, areaUID, envalert.KindAbsolute, envalert.DirectionRise, 35, 0)
+	dryRule := newRule(t, areaUID, envalert.KindAbsolute, envalert.DirectionRise, 30, 0)
+
+	// When
+	addErr := evaluator.Add(rule)
+	dryErr := evaluator.WithoutSaving().Add(dryRule)
+	dryAlerts, _ := evaluator.WithoutSaving().Evaluate(areaUID, 40, time.Now())
+	loaded, loadErr := envalert.NewEvaluator(envalert.NewStoreInMemory(storage))
+
+	// Then
+	assert.Nil(t, addErr)
+	assert.Nil(t, dryErr)
+	assert.Empty(t, dryAlerts)
+	assert.Nil(t, loadErr)
+	assert.Equal(t, []envalert.Rule{rule}, evaluator.Rules(areaUID))
+	assert.Equal(t, []envalert.Rule{rule}, loaded.Rules(areaUID))
+
+	// When
+	otherAreaUID, _ := uuid.NewV4()
+	wrongArea, _ := evaluator.Remove(otherAreaUID, rule.UID)
+	removed, removeErr := evaluator.Remove(areaUID, rule.UID)
+
+	// Then
+	assert.Nil(t, wrongArea)
+	assert.Equal(t, &rule, removed)
+	assert.Nil(t, removeErr)
+	assert.Empty(t, evaluator.Rules(areaUID))
+	assert.Empty(t, storage.RuleMap)
+}
+
+func TestStoreSqlite(t *testing.T) {
+	t.Parallel()
+	// Given
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.Nil(t, err)
+	defer db.Close()
+
+	// Each connection to :memory: has its own database.
+	db.SetMaxOpenConns(1)
+
+	ddl, err := os.ReadFile("../../database/sqlite/ddl.sql")
+	require.Nil(t, err)
+
+	_, err = db.Exec(string(ddl))
+	require.Nil(t, err)
+
+	store := envalert.NewStoreSqlite(db)
+
+	areaUID, _ := uuid.NewV4()
+	createdDate := time.Date(2026, time.October, 14, 8, 0, 0, 0, time.UTC)
+	hysteresis := 1.0
+
+	rule, err := envalert.NewRule(areaUID, envalert.KindRateOfChange, envalert.DirectionRise, 4, 15, &hysteresis,
+		notification.PriorityNormal, createdDate)
+	require.Nil(t, err)
+
+	// When
+	saveErr := store.Save(rule)
+
+	rule.Triggered = true
+	replaceErr := store.Save(rule)
+
+	rules, findErr := store.FindAll()
+
+	// Then
+	assert.Nil(t, saveErr)
+	assert.Nil(t, replaceErr)
+	assert.Nil(t, findErr)
+	assert.Equal(t, []envalert.Rule{rule}, rules)
+
+	// When
+	removeErr := store.Remove(rule.UID)
+	rules, _ = store.FindAll()
+
+	// Then
+	assert.Nil(t, removeErr)
+	assert.Empty(t, rules)
+}
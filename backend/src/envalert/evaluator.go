@@ -0,0 +1,243 @@
+package envalert
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Evaluator keeps the rules of the areas with the samples of their windows, so a reading is evaluated
+// without a query. The triggered state of a rule is saved when it changes, the windows aren't:
+// they fill again with the readings after a restart.
+type Evaluator struct {
+	store  Store
+	state  *evaluatorState
+	dryRun bool
+}
+
+type evaluatorState struct {
+	lock  sync.Mutex
+	areas map[uuid.UUID]*areaState
+}
+
+// areaState is the rules of an area and the time of its latest reading.
+type areaState struct {
+	latest time.Time
+	rules  []*ruleState
+}
+
+// ruleState keeps the samples of the window of a rate of change rule which can still be its highest
+// reading, for a drop, or its lowest, for a rise. The first one is the edge of the window, so a reading
+// only walks the samples it replaces.
+type ruleState struct {
+	rule   Rule
+	window []sample
+}
+
+type sample struct {
+	temperature  float64
+	recordedDate time.Time
+}
+
+// NewEvaluator loads the rules of the store.
+func NewEvaluator(store Store) (*Evaluator, error) {
+	rules, err := store.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Evaluator{store: store, state: &evaluatorState{areas: map[uuid.UUID]*areaState{}}}
+
+	for _, v := range rules {
+		e.add(v)
+	}
+
+	return e, nil
+}
+
+// WithoutSaving returns a copy of the evaluator which neither changes the rules nor evaluates the readings.
+func (e *Evaluator) WithoutSaving() *Evaluator {
+	dry := *e
+	dry.dryRun = true
+
+	return &dry
+}
+
+// Rules returns the rules of the area, the oldest first.
+func (e *Evaluator) Rules(areaUID uuid.UUID) []Rule {
+	e.state.lock.Lock()
+	defer e.state.lock.Unlock()
+
+	rules := []Rule{}
+
+	if area, ok := e.state.areas[areaUID]; ok {
+		for _, v := range area.rules {
+			rules = append(rules, v.rule)
+		}
+	}
+
+	return rules
+}
+
+// Add saves the rule and evaluates it from the next reading of its area.
+func (e *Evaluator) Add(rule Rule) error {
+	if e.dryRun {
+		return nil
+	}
+
+	e.state.lock.Lock()
+	defer e.state.lock.Unlock()
+
+	err := e.store.Save(rule)
+	if err != nil {
+		return err
+	}
+
+	e.add(rule)
+
+	return nil
+}
+
+func (e *Evaluator) add(rule Rule) {
+	area, ok := e.state.areas[rule.AreaUID]
+	if !ok {
+		area = &areaState{}
+		e.state.areas[rule.AreaUID] = area
+	}
+
+	area.rules = append(area.rules, &ruleState{rule: rule})
+}
+
+// Remove removes the rule of the area and returns it, or nil when the area has no such rule.
+func (e *Evaluator) Remove(areaUID, ruleUID uuid.UUID) (*Rule, error) {
+	e.state.lock.Lock()
+	defer e.state.lock.Unlock()
+
+	area, ok := e.state.areas[areaUID]
+	if !ok {
+		return nil, nil
+	}
+
+	for i, v := range area.rules {
+		if v.rule.UID != ruleUID {
+			continue
+		}
+
+		rule := v.rule
+
+		if e.dryRun {
+			return &rule, nil
+		}
+
+		err := e.store.Remove(ruleUID)
+		if err != nil {
+			return nil, err
+		}
+
+		area.rules = append(area.rules[:i], area.rules[i+1:]...)
+
+		return &rule, nil
+	}
+
+	return nil, nil
+}
+
+// Evaluate adds the reading to the windows of the rules of the area, and returns the alerts of the rules
+// it triggers. A reading older than the latest one of the area is skipped, the windows only move forward.
+// The area name of the alerts is left to the caller.
+func (e *Evaluator) Evaluate(areaUID uuid.UUID, temperature float64, recordedDate time.Time) (
+	[]AreaEnvironmentAlert, error,
+) {
+	alerts := []AreaEnvironmentAlert{}
+
+	if e.dryRun {
+		return alerts, nil
+	}
+
+	e.state.lock.Lock()
+	defer e.state.lock.Unlock()
+
+	area, ok := e.state.areas[areaUID]
+	if !ok || recordedDate.Before(area.latest) {
+		return alerts, nil
+	}
+
+	area.latest = recordedDate
+
+	for _, v := range area.rules {
+		value := v.observe(temperature, recordedDate)
+
+		triggered := v.rule.Triggered
+
+		switch {
+		case !triggered && v.rule.fires(value):
+			triggered = true
+		case triggered && v.rule.clears(value):
+			triggered = false
+		}
+
+		if triggered == v.rule.Triggered {
+			continue
+		}
+
+		rule := v.rule
+		rule.Triggered = triggered
+
+		err := e.store.Save(rule)
+		if err != nil {
+			return alerts, err
+		}
+
+		v.rule = rule
+
+		if triggered {
+			alerts = append(alerts, AreaEnvironmentAlert{
+				RuleUID:       rule.UID,
+				AreaUID:       rule.AreaUID,
+				Kind:          rule.Kind,
+				Direction:     rule.Direction,
+				Threshold:     rule.Threshold,
+				WindowMinutes: rule.WindowMinutes,
+				Value:         value,
+				Temperature:   temperature,
+				Priority:      rule.Priority,
+				RecordedDate:  recordedDate,
+			})
+		}
+	}
+
+	return alerts, nil
+}
+
+// observe adds the reading to the window and returns the value the rule compares to its threshold.
+func (s *ruleState) observe(temperature float64, recordedDate time.Time) float64 {
+	if s.rule.Kind == KindAbsolute {
+		return temperature
+	}
+
+	drop := s.rule.Direction == DirectionDrop
+
+	// The samples the reading replaces can't be the edge of the window anymore, it's newer.
+	for len(s.window) > 0 {
+		last := s.window[len(s.window)-1].temperature
+		if (drop && last > temperature) || (!drop && last < temperature) {
+			break
+		}
+
+		s.window = s.window[:len(s.window)-1]
+	}
+
+	s.window = append(s.window, sample{temperature: temperature, recordedDate: recordedDate})
+
+	start := recordedDate.Add(-time.Duration(s.rule.WindowMinutes) * time.Minute)
+	for s.window[0].recordedDate.Before(start) {
+		s.window = s.window[1:]
+	}
+
+	if drop {
+		return s.window[0].temperature - temperature
+	}
+
+	return temperature - s.window[0].temperature
+}
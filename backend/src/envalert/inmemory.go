@@ -0,0 +1,59 @@
+package envalert
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/sasha-s/go-deadlock"
+)
+
+type RuleStorage struct {
+	Lock    *deadlock.RWMutex
+	RuleMap map[uuid.UUID]Rule
+}
+
+func CreateRuleStorage() *RuleStorage {
+	return &RuleStorage{Lock: &deadlock.RWMutex{}, RuleMap: map[uuid.UUID]Rule{}}
+}
+
+type StoreInMemory struct {
+	Storage *RuleStorage
+}
+
+func NewStoreInMemory(s *RuleStorage) Store {
+	return &StoreInMemory{Storage: s}
+}
+
+func (s *StoreInMemory) Save(rule Rule) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	s.Storage.RuleMap[rule.UID] = rule
+
+	return nil
+}
+
+func (s *StoreInMemory) Remove(ruleUID uuid.UUID) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	delete(s.Storage.RuleMap, ruleUID)
+
+	return nil
+}
+
+func (s *StoreInMemory) FindAll() ([]Rule, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	rules := []Rule{}
+	for _, v := range s.Storage.RuleMap {
+		rules = append(rules, v)
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].CreatedDate.Before(rules[j].CreatedDate)
+	})
+
+	return rules, nil
+}
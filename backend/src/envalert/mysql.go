@@ -0,0 +1,74 @@
+package envalert
+
+import (
+	"database/sql"
+
+	"github.com/gofrs/uuid"
+)
+
+type StoreMysql struct {
+	DB *sql.DB
+}
+
+func NewStoreMysql(db *sql.DB) Store {
+	return &StoreMysql{DB: db}
+}
+
+func (s *StoreMysql) Save(rule Rule) error {
+	_, err := s.DB.Exec(`REPLACE INTO ENVIRONMENT_ALERT_RULE (`+ruleColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rule.UID.Bytes(),
+		rule.AreaUID.Bytes(),
+		rule.Kind,
+		rule.Direction,
+		rule.Threshold,
+		rule.WindowMinutes,
+		rule.Hysteresis,
+		rule.Priority,
+		rule.Triggered,
+		rule.CreatedDate)
+
+	return err
+}
+
+func (s *StoreMysql) Remove(ruleUID uuid.UUID) error {
+	_, err := s.DB.Exec(`DELETE FROM ENVIRONMENT_ALERT_RULE WHERE UID = ?`, ruleUID.Bytes())
+
+	return err
+}
+
+func (s *StoreMysql) FindAll() ([]Rule, error) {
+	rows, err := s.DB.Query(`SELECT ` + ruleColumns + ` FROM ENVIRONMENT_ALERT_RULE ORDER BY CREATED_DATE ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []Rule{}
+
+	for rows.Next() {
+		var uid, areaUID []byte
+
+		v := Rule{}
+
+		err = rows.Scan(&uid, &areaUID, &v.Kind, &v.Direction, &v.Threshold, &v.WindowMinutes, &v.Hysteresis,
+			&v.Priority, &v.Triggered, &v.CreatedDate)
+		if err != nil {
+			return nil, err
+		}
+
+		v.UID, err = uuid.FromBytes(uid)
+		if err != nil {
+			return nil, err
+		}
+
+		v.AreaUID, err = uuid.FromBytes(areaUID)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, v)
+	}
+
+	return rules, rows.Err()
+}
@@ -0,0 +1,87 @@
+package envalert
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type StoreSqlite struct {
+	DB *sql.DB
+}
+
+func NewStoreSqlite(db *sql.DB) Store {
+	return &StoreSqlite{DB: db}
+}
+
+const ruleColumns = `UID, AREA_UID, KIND, DIRECTION, THRESHOLD, WINDOW_MINUTES, HYSTERESIS, PRIORITY,
+	IS_TRIGGERED, CREATED_DATE`
+
+func (s *StoreSqlite) Save(rule Rule) error {
+	_, err := s.DB.Exec(`INSERT OR REPLACE INTO ENVIRONMENT_ALERT_RULE (`+ruleColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rule.UID.String(),
+		rule.AreaUID.String(),
+		rule.Kind,
+		rule.Direction,
+		rule.Threshold,
+		rule.WindowMinutes,
+		rule.Hysteresis,
+		rule.Priority,
+		rule.Triggered,
+		formatDateSqlite(rule.CreatedDate))
+
+	return err
+}
+
+func (s *StoreSqlite) Remove(ruleUID uuid.UUID) error {
+	_, err := s.DB.Exec(`DELETE FROM ENVIRONMENT_ALERT_RULE WHERE UID = ?`, ruleUID.String())
+
+	return err
+}
+
+func (s *StoreSqlite) FindAll() ([]Rule, error) {
+	rows, err := s.DB.Query(`SELECT ` + ruleColumns + ` FROM ENVIRONMENT_ALERT_RULE ORDER BY CREATED_DATE ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []Rule{}
+
+	for rows.Next() {
+		var uid, areaUID, createdDate string
+
+		v := Rule{}
+
+		err = rows.Scan(&uid, &areaUID, &v.Kind, &v.Direction, &v.Threshold, &v.WindowMinutes, &v.Hysteresis,
+			&v.Priority, &v.Triggered, &createdDate)
+		if err != nil {
+			return nil, err
+		}
+
+		v.UID, err = uuid.FromString(uid)
+		if err != nil {
+			return nil, err
+		}
+
+		v.AreaUID, err = uuid.FromString(areaUID)
+		if err != nil {
+			return nil, err
+		}
+
+		v.CreatedDate, err = time.Parse(time.RFC3339, createdDate)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, v)
+	}
+
+	return rules, rows.Err()
+}
+
+func formatDateSqlite(date time.Time) string {
+	return date.UTC().Format(time.RFC3339)
+}
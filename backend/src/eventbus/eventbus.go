@@ -1,6 +1,8 @@
 package eventbus
 
 import (
+	"sync"
+
 	"github.com/asaskevich/EventBus"
 )
 
@@ -9,8 +11,15 @@ type TaniaEventBus interface {
 	Subscribe(eventName string, handlerFunc interface{})
 }
 
+// AllEventsHandler receives every event published to the bus, whatever its topic.
+// The event name is the event type, without the tenant prefix of the topic.
+type AllEventsHandler func(eventName string, event interface{})
+
 type SimpleEventBus struct {
 	bus EventBus.Bus
+
+	lock        sync.RWMutex
+	allHandlers []AllEventsHandler
 }
 
 func NewSimpleEventBus(bus EventBus.Bus) *SimpleEventBus {
@@ -19,8 +28,23 @@ func NewSimpleEventBus(bus EventBus.Bus) *SimpleEventBus {
 
 func (e *SimpleEventBus) Publish(eventName string, event interface{}) {
 	e.bus.Publish(eventName, event)
+
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	for _, handler := range e.allHandlers {
+		handler(EventType(eventName), event)
+	}
 }
 
 func (e *SimpleEventBus) Subscribe(eventName string, handler interface{}) {
 	e.bus.Subscribe(eventName, handler)
 }
+
+// SubscribeAll registers a handler for all the topics, including the ones nobody subscribed to yet.
+func (e *SimpleEventBus) SubscribeAll(handler AllEventsHandler) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.allHandlers = append(e.allHandlers, handler)
+}
@@ -0,0 +1,18 @@
+package eventbus
+
+import "log"
+
+// PublishFromHandler publishes the events raised by a handler of the bus. The bus stays locked by the event being
+// handled until its handlers return, so the events are published from another goroutine. A failing handler of
+// these events is logged, as there is no request to report it to.
+func PublishFromHandler(publish func()) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Println("Publishing the events of an event handler failed", r)
+			}
+		}()
+
+		publish()
+	}()
+}
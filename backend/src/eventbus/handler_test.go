@@ -0,0 +1,37 @@
+package eventbus_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asaskevich/EventBus"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/eventbus"
+)
+
+func TestPublishFromHandler(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	bus := NewSimpleEventBus(EventBus.New())
+	published := make(chan string, 1)
+
+	bus.Subscribe("TaskCreated", func(event interface{}) {
+		published <- event.(string)
+	})
+	bus.Subscribe("CropMoved", func(event interface{}) {
+		PublishFromHandler(func() { panic("handler failed") })
+		PublishFromHandler(func() { bus.Publish("TaskCreated", "Water the crop") })
+	})
+
+	// When
+	bus.Publish("CropMoved", "A1")
+
+	// Then
+	select {
+	case event := <-published:
+		assert.Equal(t, "Water the crop", event)
+	case <-time.After(time.Second):
+		assert.Fail(t, "the event of the handler wasn't published")
+	}
+}
@@ -0,0 +1,82 @@
+package eventbus
+
+import (
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/config"
+)
+
+const tenantTopicPrefix = "tenant."
+
+// BusTopicPrefix scopes the topic of the event type to the tenant, so the subscribers of a tenant
+// don't receive the events of the others sharing the bus. The nil tenant of a single tenant
+// deployment keeps the bare topic.
+func BusTopicPrefix(tenantID uuid.UUID, eventType string) string {
+	if tenantID == uuid.Nil {
+		return eventType
+	}
+
+	return tenantTopicPrefix + tenantID.String() + "." + eventType
+}
+
+// EventType is the event type of the topic, without its tenant prefix.
+func EventType(topic string) string {
+	if !strings.HasPrefix(topic, tenantTopicPrefix) {
+		return topic
+	}
+
+	rest := strings.TrimPrefix(topic, tenantTopicPrefix)
+
+	i := strings.Index(rest, ".")
+	if i < 0 {
+		return topic
+	}
+
+	if _, err := uuid.FromString(rest[:i]); err != nil {
+		return topic
+	}
+
+	return rest[i+1:]
+}
+
+// ConfiguredTenantID is the tenant_id of the deployment, nil when it's single tenant.
+func ConfiguredTenantID() (uuid.UUID, error) {
+	if config.Config.TenantID == nil || *config.Config.TenantID == "" {
+		return uuid.Nil, nil
+	}
+
+	return uuid.FromString(*config.Config.TenantID)
+}
+
+// TenantEventBus publishes and subscribes on the topics of its tenant.
+type TenantEventBus struct {
+	bus      TaniaEventBus
+	TenantID uuid.UUID
+}
+
+// ForTenant scopes the bus to the configured tenant.
+func ForTenant(bus TaniaEventBus) (TaniaEventBus, error) {
+	tenantID, err := ConfiguredTenantID()
+	if err != nil {
+		return nil, err
+	}
+
+	if tenantID == uuid.Nil {
+		return bus, nil
+	}
+
+	return NewTenantEventBus(bus, tenantID), nil
+}
+
+func NewTenantEventBus(bus TaniaEventBus, tenantID uuid.UUID) *TenantEventBus {
+	return &TenantEventBus{bus: bus, TenantID: tenantID}
+}
+
+func (e *TenantEventBus) Publish(eventName string, event interface{}) {
+	e.bus.Publish(BusTopicPrefix(e.TenantID, eventName), event)
+}
+
+func (e *TenantEventBus) Subscribe(eventName string, handler interface{}) {
+	e.bus.Subscribe(BusTopicPrefix(e.TenantID, eventName), handler)
+}
@@ -0,0 +1,56 @@
+package eventbus_test
+
+import (
+	"testing"
+
+	"github.com/asaskevich/EventBus"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/eventbus"
+)
+
+func TestBusTopicPrefix(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	tenantID, _ := uuid.NewV4()
+
+	// When
+	topic := BusTopicPrefix(tenantID, "FarmCreated")
+	bare := BusTopicPrefix(uuid.Nil, "FarmCreated")
+
+	// Then
+	assert.Equal(t, "tenant."+tenantID.String()+".FarmCreated", topic)
+	assert.Equal(t, "FarmCreated", bare)
+	assert.Equal(t, "FarmCreated", EventType(topic))
+	assert.Equal(t, "FarmCreated", EventType(bare))
+	assert.Equal(t, "tenant.x.FarmCreated", EventType("tenant.x.FarmCreated"))
+}
+
+func TestTenantEventBusIsolatesTenants(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	tenantA, _ := uuid.NewV4()
+	tenantB, _ := uuid.NewV4()
+
+	shared := NewSimpleEventBus(EventBus.New())
+	busA := NewTenantEventBus(shared, tenantA)
+	busB := NewTenantEventBus(shared, tenantB)
+
+	receivedA := 0
+	receivedB := 0
+	allNames := []string{}
+
+	busA.Subscribe("FarmCreated", func(interface{}) { receivedA++ })
+	busB.Subscribe("FarmCreated", func(interface{}) { receivedB++ })
+	shared.SubscribeAll(func(eventName string, _ interface{}) { allNames = append(allNames, eventName) })
+
+	// When
+	busA.Publish("FarmCreated", struct{}{})
+
+	// Then
+	assert.Equal(t, 1, receivedA)
+	assert.Equal(t, 0, receivedB)
+	assert.Equal(t, []string{"FarmCreated"}, allNames)
+}
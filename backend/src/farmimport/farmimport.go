@@ -0,0 +1,61 @@
+// Package farmimport saves the events of an imported farm. The SQL engines save them in one transaction,
+// so a failed import leaves nothing behind.
+package farmimport
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+)
+
+const (
+	AggregateFarm              = "FARM"
+	AggregateFarmCertification = "FARM_CERTIFICATION"
+	AggregateReservoir         = "RESERVOIR"
+	AggregateArea              = "AREA"
+	AggregateMaterial          = "MATERIAL"
+	AggregateCrop              = "CROP"
+	AggregateTask              = "TASK"
+	AggregateCustomFieldValues = "CUSTOM_FIELD_VALUES"
+)
+
+// Stream is the events of a new aggregate, saved from its first version.
+type Stream struct {
+	Aggregate string
+	UID       uuid.UUID
+	Events    []interface{}
+}
+
+// AppendedStream is a stream saved before an Append failed.
+type AppendedStream struct {
+	Aggregate string    `json:"aggregate"`
+	UID       uuid.UUID `json:"uid"`
+	Events    int       `json:"events"`
+}
+
+// AppendError is the error of a failed Append. When the store couldn't roll back, the streams saved before
+// the error are kept and listed, so they can be cleaned up.
+type AppendError struct {
+	Err        error
+	RolledBack bool
+	Appended   []AppendedStream
+}
+
+func (e *AppendError) Error() string {
+	if e.RolledBack {
+		return fmt.Sprintf("import rolled back: %v", e.Err)
+	}
+
+	return fmt.Sprintf("import failed after %d saved aggregates: %v", len(e.Appended), e.Err)
+}
+
+func (e *AppendError) Unwrap() error {
+	return e.Err
+}
+
+type Store interface {
+	// Append saves the streams in order, their events triggered by the actor of the import.
+	// It returns an *AppendError when one of them can't be saved.
+	Append(streams []Stream, by *actor.Actor) error
+}
@@ -0,0 +1,85 @@
+package farmimport_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/actor"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/customfield"
+	"github.com/usetania/tania-core/src/farmimport"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+func TestStoreInMemoryAppend(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+	areaUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+
+	farmEventStorage := assetsstorage.CreateFarmEventStorage()
+	areaEventStorage := assetsstorage.CreateAreaEventStorage()
+	cropEventStorage := growthstorage.CreateCropEventStorage()
+
+	store := farmimport.NewStoreInMemory(
+		farmEventStorage,
+		assetsstorage.CreateFarmCertificationEventStorage(),
+		assetsstorage.CreateReservoirEventStorage(),
+		areaEventStorage,
+		assetsstorage.CreateMaterialEventStorage(),
+		cropEventStorage,
+		taskstorage.CreateTaskEventStorage(),
+		customfield.NewStoreInMemory(customfield.CreateValueStorage(), assetsstorage.CreateCustomFieldDefinitionReadStorage()),
+	)
+
+	streams := []farmimport.Stream{
+		{
+			Aggregate: farmimport.AggregateFarm,
+			UID:       farmUID,
+			Events:    []interface{}{assetsdomain.FarmCreated{UID: farmUID}},
+		},
+		{
+			Aggregate: farmimport.AggregateArea,
+			UID:       areaUID,
+			Events: []interface{}{
+				assetsdomain.AreaCreated{UID: areaUID, FarmUID: farmUID},
+				assetsdomain.AreaNoteAdded{AreaUID: areaUID},
+			},
+		},
+	}
+
+	by := actor.System("farm_import")
+
+	// When
+	err := store.Append(append(streams, farmimport.Stream{
+		Aggregate: farmimport.AggregateCrop,
+		UID:       cropUID,
+		Events:    []interface{}{growthdomain.CropBatchCreated{UID: cropUID}},
+	}), by)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Len(t, farmEventStorage.FarmEvents, 1)
+	assert.Len(t, areaEventStorage.AreaEvents, 2)
+	assert.Equal(t, 2, areaEventStorage.AreaEvents[1].Version)
+	assert.Equal(t, cropUID, cropEventStorage.CropEvents[0].CropUID)
+	assert.Equal(t, by, cropEventStorage.CropEvents[0].Actor)
+
+	// When
+	err = store.Append(append(streams, farmimport.Stream{Aggregate: "GREENHOUSE", UID: cropUID}), by)
+
+	// Then
+	appendErr := &farmimport.AppendError{}
+	assert.True(t, errors.As(err, &appendErr))
+	assert.False(t, appendErr.RolledBack)
+	assert.Len(t, appendErr.Appended, 2)
+	assert.Equal(t, farmimport.AggregateArea, appendErr.Appended[1].Aggregate)
+	assert.Equal(t, 2, appendErr.Appended[1].Events)
+}
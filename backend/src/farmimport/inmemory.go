@@ -0,0 +1,150 @@
+package farmimport
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/usetania/tania-core/src/actor"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/customfield"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// StoreInMemory appends the streams to the event storages one by one. It can't roll back,
+// so the streams saved before an error are reported in the AppendError.
+type StoreInMemory struct {
+	FarmEventStorage              *assetsstorage.FarmEventStorage
+	FarmCertificationEventStorage *assetsstorage.FarmCertificationEventStorage
+	ReservoirEventStorage         *assetsstorage.ReservoirEventStorage
+	AreaEventStorage              *assetsstorage.AreaEventStorage
+	MaterialEventStorage          *assetsstorage.MaterialEventStorage
+	CropEventStorage              *growthstorage.CropEventStorage
+	TaskEventStorage              *taskstorage.TaskEventStorage
+	CustomFieldStore              customfield.Store
+}
+
+func NewStoreInMemory(
+	farmEventStorage *assetsstorage.FarmEventStorage,
+	farmCertificationEventStorage *assetsstorage.FarmCertificationEventStorage,
+	reservoirEventStorage *assetsstorage.ReservoirEventStorage,
+	areaEventStorage *assetsstorage.AreaEventStorage,
+	materialEventStorage *assetsstorage.MaterialEventStorage,
+	cropEventStorage *growthstorage.CropEventStorage,
+	taskEventStorage *taskstorage.TaskEventStorage,
+	customFieldStore customfield.Store,
+) Store {
+	return &StoreInMemory{
+		FarmEventStorage:              farmEventStorage,
+		FarmCertificationEventStorage: farmCertificationEventStorage,
+		ReservoirEventStorage:         reservoirEventStorage,
+		AreaEventStorage:              areaEventStorage,
+		MaterialEventStorage:          materialEventStorage,
+		CropEventStorage:              cropEventStorage,
+		TaskEventStorage:              taskEventStorage,
+		CustomFieldStore:              customFieldStore,
+	}
+}
+
+func (s *StoreInMemory) Append(streams []Stream, by *actor.Actor) error {
+	appended := []AppendedStream{}
+	now := time.Now()
+
+	for _, stream := range streams {
+		err := s.appendStream(stream, now, by)
+		if err != nil {
+			return &AppendError{Err: err, Appended: appended}
+		}
+
+		appended = append(appended, AppendedStream{
+			Aggregate: stream.Aggregate,
+			UID:       stream.UID,
+			Events:    len(stream.Events),
+		})
+	}
+
+	return nil
+}
+
+func (s *StoreInMemory) appendStream(stream Stream, now time.Time, by *actor.Actor) error {
+	switch stream.Aggregate {
+	case AggregateFarm:
+		s.FarmEventStorage.Lock.Lock()
+		defer s.FarmEventStorage.Lock.Unlock()
+
+		for i, v := range stream.Events {
+			s.FarmEventStorage.FarmEvents = append(s.FarmEventStorage.FarmEvents, assetsstorage.FarmEvent{
+				FarmUID: stream.UID, Version: i + 1, CreatedDate: now, Event: v, Actor: by,
+			})
+		}
+	case AggregateFarmCertification:
+		s.FarmCertificationEventStorage.Lock.Lock()
+		defer s.FarmCertificationEventStorage.Lock.Unlock()
+
+		for i, v := range stream.Events {
+			s.FarmCertificationEventStorage.FarmCertificationEvents = append(
+				s.FarmCertificationEventStorage.FarmCertificationEvents, assetsstorage.FarmCertificationEvent{
+					FarmCertificationUID: stream.UID, Version: i + 1, CreatedDate: now, Event: v, Actor: by,
+				})
+		}
+	case AggregateReservoir:
+		s.ReservoirEventStorage.Lock.Lock()
+		defer s.ReservoirEventStorage.Lock.Unlock()
+
+		for i, v := range stream.Events {
+			s.ReservoirEventStorage.ReservoirEvents = append(s.ReservoirEventStorage.ReservoirEvents,
+				assetsstorage.ReservoirEvent{ReservoirUID: stream.UID, Version: i + 1, CreatedDate: now, Event: v, Actor: by})
+		}
+	case AggregateArea:
+		s.AreaEventStorage.Lock.Lock()
+		defer s.AreaEventStorage.Lock.Unlock()
+
+		for i, v := range stream.Events {
+			s.AreaEventStorage.AreaEvents = append(s.AreaEventStorage.AreaEvents, assetsstorage.AreaEvent{
+				AreaUID: stream.UID, Version: i + 1, CreatedDate: now, Event: v, Actor: by,
+			})
+		}
+	case AggregateMaterial:
+		s.MaterialEventStorage.Lock.Lock()
+		defer s.MaterialEventStorage.Lock.Unlock()
+
+		for i, v := range stream.Events {
+			s.MaterialEventStorage.MaterialEvents = append(s.MaterialEventStorage.MaterialEvents,
+				assetsstorage.MaterialEvent{MaterialUID: stream.UID, Version: i + 1, CreatedDate: now, Event: v, Actor: by})
+		}
+	case AggregateCrop:
+		s.CropEventStorage.Lock.Lock()
+		defer s.CropEventStorage.Lock.Unlock()
+
+		for i, v := range stream.Events {
+			s.CropEventStorage.CropEvents = append(s.CropEventStorage.CropEvents, growthstorage.CropEvent{
+				CropUID: stream.UID, Version: i + 1, CreatedDate: now, Event: v, Actor: by,
+			})
+		}
+	case AggregateTask:
+		s.TaskEventStorage.Lock.Lock()
+		defer s.TaskEventStorage.Lock.Unlock()
+
+		for i, v := range stream.Events {
+			s.TaskEventStorage.TaskEvents = append(s.TaskEventStorage.TaskEvents, taskstorage.TaskEvent{
+				TaskUID: stream.UID, Version: i + 1, CreatedDate: now, Event: v, Actor: by,
+			})
+		}
+	case AggregateCustomFieldValues:
+		for _, v := range stream.Events {
+			e, ok := v.(customfield.CustomFieldValuesChanged)
+			if !ok {
+				return errors.New("the custom field values stream only holds CustomFieldValuesChanged events")
+			}
+
+			if err := s.CustomFieldStore.AppendEvent(e); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unknown aggregate %s", stream.Aggregate)
+	}
+
+	return nil
+}
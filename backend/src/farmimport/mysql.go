@@ -0,0 +1,21 @@
+package farmimport
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+)
+
+type StoreMysql struct {
+	DB *sql.DB
+}
+
+func NewStoreMysql(db *sql.DB) Store {
+	return &StoreMysql{DB: db}
+}
+
+func (s *StoreMysql) Append(streams []Stream, by *actor.Actor) error {
+	return appendStreams(s.DB, streams, by, func(uid uuid.UUID) interface{} { return uid.Bytes() }, time.Now())
+}
@@ -0,0 +1,68 @@
+package farmimport
+
+import (
+	"errors"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	assetsrepoRedis "github.com/usetania/tania-core/src/assets/repository/redis"
+	growthrepoRedis "github.com/usetania/tania-core/src/growth/repository/redis"
+	redisstorage "github.com/usetania/tania-core/src/storage/redis"
+	tasksrepoRedis "github.com/usetania/tania-core/src/tasks/repository/redis"
+)
+
+type eventRepository interface {
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
+}
+
+// StoreRedis appends the streams of the aggregates kept in the Redis streams there,
+// and the others, like the certifications and the custom field values, to the in-memory store.
+type StoreRedis struct {
+	Repositories map[string]eventRepository
+	InMemory     Store
+}
+
+func NewStoreRedis(streams *redisstorage.RedisStreamsEventStorage, inMemory Store) Store {
+	return &StoreRedis{
+		Repositories: map[string]eventRepository{
+			AggregateFarm:      assetsrepoRedis.NewFarmEventRepositoryRedis(streams),
+			AggregateReservoir: assetsrepoRedis.NewReservoirEventRepositoryRedis(streams),
+			AggregateArea:      assetsrepoRedis.NewAreaEventRepositoryRedis(streams),
+			AggregateMaterial:  assetsrepoRedis.NewMaterialEventRepositoryRedis(streams),
+			AggregateCrop:      growthrepoRedis.NewCropEventRepositoryRedis(streams),
+			AggregateTask:      tasksrepoRedis.NewTaskEventRepositoryRedis(streams),
+		},
+		InMemory: inMemory,
+	}
+}
+
+func (s *StoreRedis) Append(streams []Stream, by *actor.Actor) error {
+	appended := []AppendedStream{}
+
+	for _, stream := range streams {
+		var err error
+
+		if repo, ok := s.Repositories[stream.Aggregate]; ok {
+			err = <-repo.Save(stream.UID, 0, stream.Events, by)
+		} else {
+			err = s.InMemory.Append([]Stream{stream}, by)
+
+			var appendErr *AppendError
+			if errors.As(err, &appendErr) {
+				err = appendErr.Err
+			}
+		}
+
+		if err != nil {
+			return &AppendError{Err: err, Appended: appended}
+		}
+
+		appended = append(appended, AppendedStream{
+			Aggregate: stream.Aggregate,
+			UID:       stream.UID,
+			Events:    len(stream.Events),
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package farmimport
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	assetsdecoder "github.com/usetania/tania-core/src/assets/decoder"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	assetsrepository "github.com/usetania/tania-core/src/assets/repository"
+	growthdecoder "github.com/usetania/tania-core/src/growth/decoder"
+	"github.com/usetania/tania-core/src/helper/structhelper"
+	tasksdecoder "github.com/usetania/tania-core/src/tasks/decoder"
+)
+
+// eventTable returns the event table of the aggregate and its uid column.
+func eventTable(aggregate string) (table, uidColumn string, err error) {
+	switch aggregate {
+	case AggregateFarm:
+		return "FARM_EVENT", "FARM_UID", nil
+	case AggregateFarmCertification:
+		return "FARM_CERTIFICATION_EVENT", "FARM_CERTIFICATION_UID", nil
+	case AggregateReservoir:
+		return "RESERVOIR_EVENT", "RESERVOIR_UID", nil
+	case AggregateArea:
+		return "AREA_EVENT", "AREA_UID", nil
+	case AggregateMaterial:
+		return "MATERIAL_EVENT", "MATERIAL_UID", nil
+	case AggregateCrop:
+		return "CROP_EVENT", "CROP_UID", nil
+	case AggregateTask:
+		return "TASK_EVENT", "TASK_UID", nil
+	case AggregateCustomFieldValues:
+		return "CUSTOM_FIELD_VALUE_EVENT", "ENTITY_UID", nil
+	}
+
+	return "", "", fmt.Errorf("unknown aggregate %s", aggregate)
+}
+
+// encodeEvent encodes the event the way the event repository of its aggregate does,
+// so it's decoded by the same decoders.
+func encodeEvent(aggregate string, event interface{}, by *actor.Actor) ([]byte, error) {
+	switch aggregate {
+	case AggregateCrop:
+		return json.Marshal(growthdecoder.InterfaceWrapper{Name: structhelper.GetName(event), Data: event, Actor: by})
+	case AggregateTask:
+		return json.Marshal(tasksdecoder.InterfaceWrapper{Name: structhelper.GetName(event), Data: event, Actor: by})
+	case AggregateMaterial:
+		if e, ok := event.(assetsdomain.MaterialCreated); ok {
+			e.Type = assetsrepository.MaterialEventTypeWrapper{Type: e.Type.Code(), Data: e.Type}
+			event = e
+		}
+	}
+
+	return json.Marshal(assetsdecoder.EventWrapper{EventName: structhelper.GetName(event), EventData: event, Actor: by})
+}
+
+// appendStreams inserts the events of the streams in one transaction, rolled back on the first error.
+// The engines store the uids and the dates in their own formats.
+func appendStreams(
+	db *sql.DB, streams []Stream, by *actor.Actor, uidValue func(uuid.UUID) interface{}, createdDate interface{},
+) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return &AppendError{Err: err, RolledBack: true}
+	}
+
+	for _, stream := range streams {
+		err = appendStream(tx, stream, by, uidValue, createdDate)
+		if err != nil {
+			tx.Rollback()
+
+			return &AppendError{Err: err, RolledBack: true}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return &AppendError{Err: err, RolledBack: true}
+	}
+
+	return nil
+}
+
+func appendStream(
+	tx *sql.Tx, stream Stream, by *actor.Actor, uidValue func(uuid.UUID) interface{}, createdDate interface{},
+) error {
+	table, uidColumn, err := eventTable(stream.Aggregate)
+	if err != nil {
+		return err
+	}
+
+	for i, v := range stream.Events {
+		e, err := encodeEvent(stream.Aggregate, v, by)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`INSERT INTO `+table+` (`+uidColumn+`, VERSION, CREATED_DATE, EVENT) VALUES (?, ?, ?, ?)`,
+			uidValue(stream.UID), i+1, createdDate, e)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package farmimport
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+)
+
+type StoreSqlite struct {
+	DB *sql.DB
+}
+
+func NewStoreSqlite(db *sql.DB) Store {
+	return &StoreSqlite{DB: db}
+}
+
+func (s *StoreSqlite) Append(streams []Stream, by *actor.Actor) error {
+	return appendStreams(s.DB, streams, by, func(uid uuid.UUID) interface{} { return uid },
+		time.Now().Format(time.RFC3339))
+}
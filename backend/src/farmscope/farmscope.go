@@ -0,0 +1,88 @@
+// Package farmscope checks that the entity of a farm scoped route belongs to a farm the user can access.
+// The entities are looked up by UUID, so without this check a UUID from another farm would be served.
+package farmscope
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// UserKey is the context key of the authenticated user, set by the token validation middleware.
+const UserKey = "USER_UID"
+
+// Membership tells whether a user has access to a farm.
+type Membership interface {
+	IsMember(userUID, farmUID uuid.UUID) bool
+}
+
+// AllFarms gives every user access to every farm. There are no farm memberships yet, so it's the default.
+type AllFarms struct{}
+
+func (AllFarms) IsMember(userUID, farmUID uuid.UUID) bool {
+	return true
+}
+
+// Resolver finds the farm of the entity of the request in its read model.
+// It returns uuid.Nil when there is no such entity.
+type Resolver func(c echo.Context) (uuid.UUID, error)
+
+// Scope builds the middlewares of the farm scoped routes of a server.
+type Scope struct {
+	Membership Membership
+
+	// Error writes the response of the resolver errors.
+	Error func(c echo.Context, err error) error
+}
+
+func NewScope(errorHandler func(c echo.Context, err error) error) Scope {
+	return Scope{Membership: AllFarms{}, Error: errorHandler}
+}
+
+// Entity returns the middleware resolving the farm of the entity before the handler runs.
+// When farmParam is set, the entity also has to belong to the farm of that route param.
+// The entities of the other farms are answered with 404, as the missing ones, so their existence doesn't leak.
+func (s Scope) Entity(farmParam string, resolve Resolver) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			farmUID, err := resolve(c)
+			if err != nil {
+				return s.Error(c, err)
+			}
+
+			if !s.Allows(c, farmUID) {
+				return NotFound(c)
+			}
+
+			if farmParam != "" {
+				paramUID, err := uuid.FromString(c.Param(farmParam))
+				if err != nil || paramUID != farmUID {
+					return NotFound(c)
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// Allows tells whether the user of the request has access to the farm.
+func (s Scope) Allows(c echo.Context, farmUID uuid.UUID) bool {
+	if farmUID == uuid.Nil {
+		return false
+	}
+
+	// The demo mode has no authentication, so there is no user.
+	userUID, _ := c.Get(UserKey).(uuid.UUID)
+
+	return s.Membership.IsMember(userUID, farmUID)
+}
+
+func NotFound(c echo.Context) error {
+	return c.JSON(http.StatusNotFound, map[string]string{
+		"field_name":    "",
+		"error_code":    "NOT_FOUND",
+		"error_message": "Data not found.",
+	})
+}
@@ -0,0 +1,308 @@
+package farmscope_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asaskevich/EventBus"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/usetania/tania-core/config"
+	assetsserver "github.com/usetania/tania-core/src/assets/server"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/changefeed"
+	"github.com/usetania/tania-core/src/customfield"
+	dashboardserver "github.com/usetania/tania-core/src/dashboard/server"
+	"github.com/usetania/tania-core/src/energy"
+	"github.com/usetania/tania-core/src/envalert"
+	"github.com/usetania/tania-core/src/eventbus"
+	"github.com/usetania/tania-core/src/featureflags"
+	growthserver "github.com/usetania/tania-core/src/growth/server"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/media"
+	"github.com/usetania/tania-core/src/notification"
+	"github.com/usetania/tania-core/src/presence"
+	"github.com/usetania/tania-core/src/reportmail"
+	"github.com/usetania/tania-core/src/retention"
+	"github.com/usetania/tania-core/src/signing"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// membership gives access to the farms of the map, or to every farm while it's nil.
+type membership struct {
+	farms map[uuid.UUID]bool
+}
+
+func (m *membership) IsMember(userUID, farmUID uuid.UUID) bool {
+	return m.farms == nil || m.farms[farmUID]
+}
+
+func newTestEcho(t *testing.T, member *membership) *echo.Echo {
+	t.Helper()
+
+	engine := config.DBInmemory
+	uploadPath := t.TempDir()
+	lowStockThreshold := 0.0
+	config.Config.TaniaPersistenceEngine = &engine
+	config.Config.LowStockThreshold = &lowStockThreshold
+	config.Config.UploadPathArea = &uploadPath
+	config.Config.UploadPathCrop = &uploadPath
+
+	bus := eventbus.NewSimpleEventBus(EventBus.New())
+
+	farmReadStorage := assetsstorage.CreateFarmReadStorage()
+	areaReadStorage := assetsstorage.CreateAreaReadStorage()
+	reservoirReadStorage := assetsstorage.CreateReservoirReadStorage()
+	materialReadStorage := assetsstorage.CreateMaterialReadStorage()
+	materialEventStorage := assetsstorage.CreateMaterialEventStorage()
+	certificationReadStorage := assetsstorage.CreateFarmCertificationReadStorage()
+	cropReadStorage := growthstorage.CreateCropReadStorage()
+	taskReadStorage := taskstorage.CreateTaskReadStorage()
+	prunedStorage := retention.CreatePrunedStorage()
+	fieldReadStorage := assetsstorage.CreateCustomFieldDefinitionReadStorage()
+	fieldValueStorage := customfield.CreateValueStorage()
+
+	flags, err := featureflags.NewRegistry(featureflags.Flags(), nil)
+	require.Nil(t, err)
+
+	farmServer, err := assetsserver.NewFarmServer(
+		nil,
+		assetsstorage.CreateFarmEventStorage(), farmReadStorage,
+		assetsstorage.CreateAreaEventStorage(), areaReadStorage,
+		assetsstorage.CreateReservoirEventStorage(), reservoirReadStorage,
+		materialEventStorage, materialReadStorage,
+		assetsstorage.CreateFarmCertificationEventStorage(), certificationReadStorage,
+		assetsstorage.CreateCustomFieldDefinitionEventStorage(), fieldReadStorage, fieldValueStorage,
+		assetsstorage.CreateStocktakeEventStorage(), assetsstorage.CreateStocktakeReadStorage(),
+		assetsstorage.CreateEquipmentEventStorage(), assetsstorage.CreateEquipmentReadStorage(),
+		assetsstorage.CreateNutrientRecipeEventStorage(), assetsstorage.CreateNutrientRecipeReadStorage(),
+		assetsstorage.CreateFarmBoundaryEventStorage(),
+		cropReadStorage, taskReadStorage,
+		bus, flags,
+	)
+	require.Nil(t, err)
+
+	growthServer, err := growthserver.NewGrowthServer(
+		nil, bus,
+		growthstorage.CreateCropEventStorage(), cropReadStorage, growthstorage.CreateCropActivityStorage(),
+		growthstorage.CreateCropInputScheduleEventStorage(), growthstorage.CreateCropInputScheduleReadStorage(),
+		growthstorage.CreateMicroclimateSampleStorage(),
+		growthstorage.CreateDispatchScheduleEventStorage(), growthstorage.CreateDispatchScheduleReadStorage(),
+		growthstorage.CreateInsurancePolicyEventStorage(), growthstorage.CreateInsurancePolicyReadStorage(),
+		growthstorage.CreateHarvestLotEventStorage(), growthstorage.CreateHarvestLotReadStorage(),
+		areaReadStorage, materialReadStorage, farmReadStorage, taskReadStorage,
+		prunedStorage, fieldValueStorage, fieldReadStorage,
+		energy.CreateEnergyReadingStorage(), envalert.CreateRuleStorage(), media.CreatePhotoHashStorage(),
+		signing.CreateKeyStorage(), flags,
+	)
+	require.Nil(t, err)
+
+	presenceRegistry, err := presence.NewRegistry(time.Minute, nil)
+	require.Nil(t, err)
+
+	dashboardServer, err := dashboardserver.NewDashboardServer(
+		nil, nil, bus,
+		farmReadStorage, areaReadStorage, reservoirReadStorage,
+		materialReadStorage, materialEventStorage, certificationReadStorage, cropReadStorage,
+		growthstorage.CreateCropActivityStorage(), growthstorage.CreateMicroclimateSampleStorage(), taskReadStorage,
+		taskstorage.CreateTaskArchiveStorage(),
+		fieldValueStorage, fieldReadStorage,
+		reportmail.CreateReportMailStorage(), notification.NewSMTPNotifier("", "", "", "", ""),
+		changefeed.NewStoreInMemory(changefeed.CreateChangeLogStorage()), nil,
+		presenceRegistry,
+	)
+	require.Nil(t, err)
+
+	farmServer.FarmScope.Membership = member
+	growthServer.FarmScope.Membership = member
+	dashboardServer.FarmScope.Membership = member
+
+	e := echo.New()
+
+	farmGroup := e.Group("/api/farms")
+	farmServer.Mount(farmGroup)
+	growthServer.Mount(farmGroup)
+	dashboardServer.Mount(farmGroup)
+
+	return e
+}
+
+func call(e *echo.Echo, method, path string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func create(t *testing.T, e *echo.Echo, path string, form url.Values) string {
+	t.Helper()
+
+	rec := call(e, http.MethodPost, path, form)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	body := struct {
+		Data struct {
+			UID string `json:"uid"`
+		} `json:"data"`
+	}{}
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+
+	return body.Data.UID
+}
+
+func createFarm(t *testing.T, e *echo.Echo, name string) string {
+	t.Helper()
+
+	return create(t, e, "/api/farms", url.Values{
+		"name": {name}, "farm_type": {"organic"}, "latitude": {"1"}, "longitude": {"1"},
+		"country": {"ID"}, "city": {"Jawa Barat"},
+	})
+}
+
+func TestFarmScopedRoutesHideOtherFarms(t *testing.T) {
+	// Given
+	member := &membership{}
+	e := newTestEcho(t, member)
+
+	farmID := createFarm(t, e, "My Farm")
+	otherFarmID := createFarm(t, e, "Other Farm")
+	reservoirID := create(t, e, "/api/farms/"+otherFarmID+"/reservoirs", url.Values{
+		"name": {"Reservoir"}, "type": {"TAP"},
+	})
+	areaID := create(t, e, "/api/farms/"+otherFarmID+"/areas", url.Values{
+		"name": {"Area One"}, "type": {"GROWING"}, "size": {"10"}, "size_unit": {"m2"},
+		"location": {"OUTDOOR"}, "reservoir_id": {reservoirID},
+	})
+	materialID := create(t, e, "/api/farms/inventories/materials/seed", url.Values{
+		"name": {"Tomato"}, "price_per_unit": {"1"}, "currency_code": {"EUR"}, "quantity": {"2"},
+		"quantity_unit": {"PACKETS"}, "plant_type": {"HERB"},
+	})
+	cropID := create(t, e, "/api/farms/areas/"+areaID+"/crops", url.Values{
+		"crop_type": {"GROWING"}, "plant_type": {"HERB"}, "name": {"Tomato"},
+		"container_quantity": {"5"}, "container_type": {"POT"}, "container_cell": {"0"},
+	})
+	scheduleID := create(t, e, "/api/farms/"+otherFarmID+"/crops/"+cropID+"/input-schedule", url.Values{
+		"material_id": {materialID}, "planned_date": {time.Now().AddDate(0, 0, 3).Format("2006-01-02")},
+		"quantity": {"2"}, "unit": {"KG"}, "application_method": {"FOLIAR_SPRAY"},
+	})
+	certificationID := create(t, e, "/api/farms/"+otherFarmID+"/certifications", url.Values{
+		"certification_type": {"ORGANIC"}, "certifying_body": {"Control Union"}, "certificate_number": {"CU-1"},
+		"issued_date": {"2024-01-01"}, "expiry_date": {time.Now().AddDate(1, 0, 0).Format("2006-01-02")},
+	})
+	fieldID := create(t, e, "/api/farms/"+otherFarmID+"/custom_fields", url.Values{
+		"entity_type": {"Area"}, "field_key": {"soil_ph"}, "field_type": {"number"},
+	})
+
+	equipmentID := create(t, e, "/api/farms/"+otherFarmID+"/equipment", url.Values{
+		"name": {"Main Pump"}, "type": {"PUMP"}, "location_type": {"RESERVOIR"}, "location_id": {reservoirID},
+	})
+
+	member.farms = map[uuid.UUID]bool{uuid.FromStringOrNil(farmID): true}
+
+	// The :id param is the entity of the first path segment after /api/farms, or the farm.
+	idOf := map[string]string{"areas": areaID, "reservoirs": reservoirID, "crops": cropID}
+	params := map[string]string{
+		"farm_id":          farmID,
+		"area_id":          areaID,
+		"reservoir_id":     reservoirID,
+		"crop_id":          cropID,
+		"certification_id": certificationID,
+		"field_id":         fieldID,
+		"schedule_id":      scheduleID,
+		"equipment_id":     equipmentID,
+	}
+	unscoped := []string{"/api/farms/inventories/", "/api/farms/types", "/api/farms/certifications/types"}
+
+	tested := 0
+
+	for _, route := range e.Routes() {
+		if !strings.Contains(route.Path, ":") || hasAnyPrefix(route.Path, unscoped) {
+			continue
+		}
+
+		segments := strings.Split(route.Path, "/")
+		for i, segment := range segments {
+			if !strings.HasPrefix(segment, ":") {
+				continue
+			}
+
+			name := strings.TrimPrefix(segment, ":")
+
+			switch {
+			case name == "id" && idOf[segments[3]] != "":
+				segments[i] = idOf[segments[3]]
+			case name == "id":
+				segments[i] = otherFarmID
+			case params[name] != "":
+				segments[i] = params[name]
+			default:
+				segments[i] = uuid.Must(uuid.NewV4()).String()
+			}
+		}
+
+		path := strings.Join(segments, "/")
+
+		// When
+		rec := call(e, route.Method, path, url.Values{})
+
+		// Then
+		assert.Equal(t, http.StatusNotFound, rec.Code, route.Method+" "+route.Path+" "+rec.Body.String())
+
+		tested++
+	}
+
+	assert.Greater(t, tested, 40)
+
+	// When
+	member.farms = nil
+	mismatch := call(e, http.MethodGet, "/api/farms/"+farmID+"/areas/"+areaID, nil)
+	matching := call(e, http.MethodGet, "/api/farms/"+otherFarmID+"/areas/"+areaID, nil)
+
+	// Then
+	assert.Equal(t, http.StatusNotFound, mismatch.Code, mismatch.Body.String())
+	assert.Equal(t, http.StatusOK, matching.Code, matching.Body.String())
+}
+
+func TestFindAllFarmListsTheUserFarms(t *testing.T) {
+	// Given
+	member := &membership{}
+	e := newTestEcho(t, member)
+
+	farmID := createFarm(t, e, "My Farm")
+	createFarm(t, e, "Other Farm")
+
+	member.farms = map[uuid.UUID]bool{uuid.FromStringOrNil(farmID): true}
+
+	// When
+	rec := call(e, http.MethodGet, "/api/farms", nil)
+
+	// Then
+	body := struct {
+		Data []struct {
+			UID string `json:"uid"`
+		} `json:"data"`
+	}{}
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Data, 1)
+	assert.Equal(t, farmID, body.Data[0].UID)
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,199 @@
+// Package featureflags turns the features shipped dark on and off. The flags are read from the feature_flags
+// configuration at the start and again on SIGHUP. A disabled feature answers 404 on its routes and skips its
+// jobs, the flags read only at the start, like the ones connecting to a broker, need a restart.
+package featureflags
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sasha-s/go-deadlock"
+)
+
+// The flags of the modules.
+const (
+	Webhooks      = "webhooks"
+	MQTT          = "mqtt"
+	CropInsurance = "crop_insurance"
+	Equipment     = "equipment"
+	TaskTemplates = "task_templates"
+)
+
+// Flag is a feature and whether it's on when the configuration doesn't set it.
+type Flag struct {
+	Name    string
+	Default bool
+	// RestartRequired is set on the flags only read at the start.
+	RestartRequired bool
+}
+
+// Flags are the flags of the modules. The features released before the flags are on by default,
+// a new experimental feature is added off.
+func Flags() []Flag {
+	return []Flag{
+		{Name: Webhooks, Default: true},
+		{Name: MQTT, Default: true, RestartRequired: true},
+		{Name: CropInsurance, Default: true},
+		{Name: Equipment, Default: true},
+		{Name: TaskTemplates, Default: true},
+	}
+}
+
+type UnknownFlagError struct {
+	Name string
+}
+
+func (e UnknownFlagError) Error() string {
+	return "unknown feature flag " + e.Name
+}
+
+type InvalidValueError struct {
+	Name  string
+	Value string
+}
+
+func (e InvalidValueError) Error() string {
+	return fmt.Sprintf("invalid value %q of the feature flag %s, it should be true or false", e.Value, e.Name)
+}
+
+// Change is a flag a reload turned on or off.
+type Change struct {
+	Name            string
+	Enabled         bool
+	RestartRequired bool
+}
+
+func (c Change) String() string {
+	state := "off"
+	if c.Enabled {
+		state = "on"
+	}
+
+	if c.RestartRequired {
+		return fmt.Sprintf("%s: turned %s, restart Tania to apply it", c.Name, state)
+	}
+
+	return fmt.Sprintf("%s: turned %s", c.Name, state)
+}
+
+// Registry keeps the state of the flags.
+type Registry struct {
+	lock    deadlock.RWMutex
+	flags   map[string]Flag
+	enabled map[string]bool
+}
+
+// NewRegistry sets the flags from the values of the configuration, name to true or false.
+func NewRegistry(flags []Flag, values map[string]string) (*Registry, error) {
+	r := &Registry{flags: make(map[string]Flag), enabled: make(map[string]bool)}
+
+	for _, v := range flags {
+		r.flags[v.Name] = v
+	}
+
+	enabled, err := r.parse(values)
+	if err != nil {
+		return nil, err
+	}
+
+	r.enabled = enabled
+
+	return r, nil
+}
+
+func (r *Registry) parse(values map[string]string) (map[string]bool, error) {
+	enabled := make(map[string]bool)
+
+	for name, flag := range r.flags {
+		enabled[name] = flag.Default
+	}
+
+	for name, value := range values {
+		if _, ok := r.flags[name]; !ok {
+			return nil, UnknownFlagError{Name: name}
+		}
+
+		on, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, InvalidValueError{Name: name, Value: value}
+		}
+
+		enabled[name] = on
+	}
+
+	return enabled, nil
+}
+
+// Reload applies the values read again from the configuration, and returns the flags they change sorted by name.
+// The flags requiring a restart keep their state, their change is only returned. Invalid values change nothing.
+func (r *Registry) Reload(values map[string]string) ([]Change, error) {
+	enabled, err := r.parse(values)
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	changes := []Change{}
+
+	for name, on := range enabled {
+		if r.enabled[name] == on {
+			continue
+		}
+
+		flag := r.flags[name]
+		changes = append(changes, Change{Name: name, Enabled: on, RestartRequired: flag.RestartRequired})
+
+		if !flag.RestartRequired {
+			r.enabled[name] = on
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes, nil
+}
+
+// Enabled tells if the feature is on, false for an unknown one.
+func (r *Registry) Enabled(name string) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.enabled[name]
+}
+
+// EnabledNames returns the names of the features on, sorted.
+func (r *Registry) EnabledNames() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	names := []string{}
+
+	for name, on := range r.enabled {
+		if on {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Require answers 404 Not Found on the routes of the feature while it's off, like when it isn't mounted.
+func (r *Registry) Require(name string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !r.Enabled(name) {
+				return echo.ErrNotFound
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package featureflags_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/featureflags"
+)
+
+func TestNewRegistry(t *testing.T) {
+	t.Parallel()
+	// Given
+	values := map[string]string{Webhooks: "false", CropInsurance: "1"}
+
+	// When
+	registry, err := NewRegistry(Flags(), values)
+	_, unknownErr := NewRegistry(Flags(), map[string]string{"graphql": "true"})
+	_, invalidErr := NewRegistry(Flags(), map[string]string{MQTT: "maybe"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, registry.Enabled(Webhooks))
+	assert.True(t, registry.Enabled(CropInsurance))
+	assert.True(t, registry.Enabled(Equipment))
+	assert.False(t, registry.Enabled("graphql"))
+	assert.Equal(t, []string{CropInsurance, Equipment, MQTT, TaskTemplates}, registry.EnabledNames())
+
+	assert.Equal(t, UnknownFlagError{Name: "graphql"}, unknownErr)
+	assert.Equal(t, InvalidValueError{Name: MQTT, Value: "maybe"}, invalidErr)
+}
+
+func TestReload(t *testing.T) {
+	t.Parallel()
+	// Given
+	registry, _ := NewRegistry(Flags(), map[string]string{Equipment: "false"})
+
+	// When
+	changes, err := registry.Reload(map[string]string{MQTT: "false", Webhooks: "false"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []Change{
+		{Name: Equipment, Enabled: true},
+		{Name: MQTT, Enabled: false, RestartRequired: true},
+		{Name: Webhooks, Enabled: false},
+	}, changes)
+	assert.Equal(t, "mqtt: turned off, restart Tania to apply it", changes[1].String())
+
+	// The MQTT publisher keeps running until a restart.
+	assert.True(t, registry.Enabled(Equipment))
+	assert.True(t, registry.Enabled(MQTT))
+	assert.False(t, registry.Enabled(Webhooks))
+
+	// When
+	_, invalidErr := registry.Reload(map[string]string{Webhooks: "yes please"})
+
+	// Then
+	assert.Equal(t, InvalidValueError{Name: Webhooks, Value: "yes please"}, invalidErr)
+	assert.False(t, registry.Enabled(Webhooks))
+}
+
+func TestRequire(t *testing.T) {
+	t.Parallel()
+	// Given
+	registry, _ := NewRegistry(Flags(), map[string]string{Equipment: "false"})
+
+	e := echo.New()
+	e.GET("/equipment", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, registry.Require(Equipment))
+
+	// When
+	off := httptest.NewRecorder()
+	e.ServeHTTP(off, httptest.NewRequest(http.MethodGet, "/equipment", nil))
+
+	_, _ = registry.Reload(nil)
+
+	on := httptest.NewRecorder()
+	e.ServeHTTP(on, httptest.NewRequest(http.MethodGet, "/equipment", nil))
+
+	// Then
+	assert.Equal(t, http.StatusNotFound, off.Code)
+	assert.Equal(t, http.StatusOK, on.Code)
+}
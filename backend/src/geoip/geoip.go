@@ -0,0 +1,90 @@
+// Package geoip locates the remote IP of the requests with a MaxMind GeoLite2-City database,
+// so the request log shows where the requests come from.
+package geoip
+
+import (
+	"net"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/oschwald/geoip2-golang"
+)
+
+// The keys of the location in the echo context.
+const (
+	ContextCountry = "GEOIP_COUNTRY"
+	ContextCity    = "GEOIP_CITY"
+)
+
+// LookupTimeout is how long the middleware waits for the lookup once the handler is done.
+const LookupTimeout = 50 * time.Millisecond
+
+type Reader interface {
+	City(ip net.IP) (*geoip2.City, error)
+}
+
+type Location struct {
+	Country string
+	City    string
+}
+
+// Open opens the GeoLite2-City database of the path.
+func Open(path string) (*geoip2.Reader, error) {
+	return geoip2.Open(path)
+}
+
+// Lookup returns the location of the IP, empty for the private IPs and the IPs missing from the database.
+func Lookup(reader Reader, ip string) (Location, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.IsLoopback() || parsed.IsPrivate() {
+		return Location{}, nil
+	}
+
+	city, err := reader.City(parsed)
+	if err != nil {
+		return Location{}, err
+	}
+
+	return Location{
+		Country: city.Country.IsoCode,
+		City:    city.City.Names["en"],
+	}, nil
+}
+
+// Middleware looks the remote IP up while the handler runs and saves the location in the context. The IP is the one
+// of the IPExtractor of the server, which only reads the X-Forwarded-For header of the trusted proxies.
+// A lookup taking longer than the LookupTimeout is left out, so it doesn't delay the response.
+func Middleware(reader Reader) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			result := make(chan Location, 1)
+			ip := c.RealIP()
+
+			// An IP which can't be located is only left out of the log.
+			go func() {
+				location, _ := Lookup(reader, ip)
+
+				result <- location
+			}()
+
+			err := next(c)
+
+			timer := time.NewTimer(LookupTimeout)
+			defer timer.Stop()
+
+			select {
+			case location := <-result:
+				if location.Country != "" {
+					c.Set(ContextCountry, location.Country)
+				}
+
+				if location.City != "" {
+					c.Set(ContextCity, location.City)
+				}
+			case <-timer.C:
+			}
+
+			return err
+		}
+	}
+}
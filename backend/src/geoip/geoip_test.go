@@ -0,0 +1,117 @@
+package geoip_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/oschwald/geoip2-golang"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/clientip"
+	"github.com/usetania/tania-core/src/geoip"
+)
+
+type fakeReader struct {
+	Delay time.Duration
+}
+
+func (r fakeReader) City(ip net.IP) (*geoip2.City, error) {
+	time.Sleep(r.Delay)
+
+	if ip.String() != "203.0.113.7" {
+		return nil, errors.New("not found")
+	}
+
+	city := &geoip2.City{}
+	city.Country.IsoCode = "ID"
+	city.City.Names = map[string]string{"en": "Bandung"}
+
+	return city, nil
+}
+
+func serve(reader geoip.Reader, remoteAddr string) map[string]interface{} {
+	e := echo.New()
+	values := map[string]interface{}{}
+
+	handler := geoip.Middleware(reader)(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	_ = handler(c)
+
+	values[geoip.ContextCountry] = c.Get(geoip.ContextCountry)
+	values[geoip.ContextCity] = c.Get(geoip.ContextCity)
+
+	return values
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+	// Given
+	reader := fakeReader{}
+
+	// When
+	located := serve(reader, "203.0.113.7:5000")
+	unknown := serve(reader, "198.51.100.1:5000")
+	private := serve(reader, "192.168.1.10:5000")
+
+	// Then
+	assert.Equal(t, "ID", located[geoip.ContextCountry])
+	assert.Equal(t, "Bandung", located[geoip.ContextCity])
+	assert.Nil(t, unknown[geoip.ContextCountry])
+	assert.Nil(t, private[geoip.ContextCountry])
+}
+
+func TestMiddlewareTimeout(t *testing.T) {
+	t.Parallel()
+	// Given
+	reader := fakeReader{Delay: time.Second}
+
+	// When
+	start := time.Now()
+	values := serve(reader, "203.0.113.7:5000")
+
+	// Then
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Nil(t, values[geoip.ContextCountry])
+	assert.Nil(t, values[geoip.ContextCity])
+}
+
+func TestMiddlewareTrustedProxies(t *testing.T) {
+	t.Parallel()
+	// Given
+	resolver, err := clientip.NewResolver("10.0.0.0/8")
+	assert.Nil(t, err)
+
+	e := echo.New()
+	e.IPExtractor = resolver.IP
+
+	locate := func(remoteAddr, xff string) interface{} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(echo.HeaderXForwardedFor, xff)
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		_ = geoip.Middleware(fakeReader{})(func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})(c)
+
+		return c.Get(geoip.ContextCountry)
+	}
+
+	// When
+	proxied := locate("10.0.0.2:5000", "203.0.113.7")
+	spoofed := locate("198.51.100.1:5000", "203.0.113.7")
+
+	// Then
+	assert.Equal(t, "ID", proxied)
+	assert.Nil(t, spoofed)
+}
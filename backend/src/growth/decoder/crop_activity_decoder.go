@@ -19,6 +19,8 @@ func (w *CropActivityTypeWrapper) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	w.Actor = wrapper.Actor
+
 	mapped, ok := wrapper.Data.(map[string]interface{})
 	if !ok {
 		return errors.New("error type assertion")
@@ -131,6 +133,26 @@ func (w *CropActivityTypeWrapper) UnmarshalJSON(b []byte) error {
 
 		w.Data = a
 
+	case storage.GerminationActivityCode:
+		a := storage.GerminationActivity{}
+
+		_, err := Decode(f, &mapped, &a)
+		if err != nil {
+			return err
+		}
+
+		w.Data = a
+
+	case storage.WithholdingOverrideCode:
+		a := storage.WithholdingOverrideActivity{}
+
+		_, err := Decode(f, &mapped, &a)
+		if err != nil {
+			return err
+		}
+
+		w.Data = a
+
 	case storage.TaskSanitationActivityCode:
 		a := storage.TaskSanitationActivity{}
 
@@ -139,6 +161,26 @@ func (w *CropActivityTypeWrapper) UnmarshalJSON(b []byte) error {
 			return err
 		}
 
+		w.Data = a
+
+	case storage.MergeActivityCode:
+		a := storage.MergeActivity{}
+
+		_, err := Decode(f, &mapped, &a)
+		if err != nil {
+			return err
+		}
+
+		w.Data = a
+
+	case storage.TransferActivityCode:
+		a := storage.TransferActivity{}
+
+		_, err := Decode(f, &mapped, &a)
+		if err != nil {
+			return err
+		}
+
 		w.Data = a
 	}
 
@@ -20,6 +20,8 @@ func (w *CropEventWrapper) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	w.Actor = wrapper.Actor
+
 	mapped, ok := wrapper.Data.(map[string]interface{})
 	if !ok {
 		return errors.New("error type assertion")
@@ -327,6 +329,166 @@ func (w *CropEventWrapper) UnmarshalJSON(b []byte) error {
 
 		w.Data = e
 
+	case "CropBatchPhotoProcessed":
+		e := domain.CropBatchPhotoProcessed{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "CropBatchPhotoProcessingFailed":
+		e := domain.CropBatchPhotoProcessingFailed{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "CropBatchPhotoProcessingRetried":
+		e := domain.CropBatchPhotoProcessingRetried{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "CropBatchPhotoRemoved":
+		e := domain.CropBatchPhotoRemoved{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "CropNurseryStageStarted":
+		e := domain.CropNurseryStageStarted{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "CropNurseryStageCompleted":
+		e := domain.CropNurseryStageCompleted{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "CropGDDMaturityReached":
+		e := domain.CropGDDMaturityReached{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "CropBatchSeedsSownRecorded":
+		e := domain.CropBatchSeedsSownRecorded{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "CropGerminationRecorded":
+		e := domain.CropGerminationRecorded{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "GerminationChecked":
+		e := domain.GerminationChecked{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "LowGerminationAlert":
+		e := domain.LowGerminationAlert{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "CropMergedFrom":
+		e := domain.CropMergedFrom{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "CropMergedInto":
+		e := domain.CropMergedInto{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "CropTransferInitiated":
+		e := domain.CropTransferInitiated{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "CropBatchWithholdingOverridden":
+		e := domain.CropBatchWithholdingOverridden{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "CropBatchShortCodeAssigned":
+		e := domain.CropBatchShortCodeAssigned{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
 	case "CropBatchNoteCreated":
 		e := domain.CropBatchNoteCreated{}
 
@@ -0,0 +1,77 @@
+package decoder
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/usetania/tania-core/src/growth/domain"
+)
+
+type CropInputScheduleEventWrapper InterfaceWrapper
+
+func (w *CropInputScheduleEventWrapper) UnmarshalJSON(b []byte) error {
+	wrapper := InterfaceWrapper{}
+
+	err := json.Unmarshal(b, &wrapper)
+	if err != nil {
+		return err
+	}
+
+	w.Actor = wrapper.Actor
+
+	mapped, ok := wrapper.Data.(map[string]interface{})
+	if !ok {
+		return errors.New("error type assertion")
+	}
+
+	f := mapstructure.ComposeDecodeHookFunc(
+		UIDHook(),
+		TimeHook(time.RFC3339),
+	)
+
+	switch wrapper.Name {
+	case "InputScheduleCreated":
+		e := domain.InputScheduleCreated{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "InputScheduleModified":
+		e := domain.InputScheduleModified{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "InputScheduleTaskCreated":
+		e := domain.InputScheduleTaskCreated{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "InputScheduleApplied":
+		e := domain.InputScheduleApplied{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package decoder
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/usetania/tania-core/src/growth/domain"
+)
+
+type DispatchScheduleEventWrapper InterfaceWrapper
+
+func (w *DispatchScheduleEventWrapper) UnmarshalJSON(b []byte) error {
+	wrapper := InterfaceWrapper{}
+
+	err := json.Unmarshal(b, &wrapper)
+	if err != nil {
+		return err
+	}
+
+	w.Actor = wrapper.Actor
+
+	mapped, ok := wrapper.Data.(map[string]interface{})
+	if !ok {
+		return errors.New("error type assertion")
+	}
+
+	f := mapstructure.ComposeDecodeHookFunc(
+		UIDHook(),
+		TimeHook(time.RFC3339),
+	)
+
+	switch wrapper.Name {
+	case "DispatchScheduled":
+		e := domain.DispatchScheduled{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "DispatchTaskCreated":
+		e := domain.DispatchTaskCreated{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "DispatchCompleted":
+		e := domain.DispatchCompleted{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+	}
+
+	return nil
+}
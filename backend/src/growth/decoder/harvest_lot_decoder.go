@@ -0,0 +1,57 @@
+package decoder
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/usetania/tania-core/src/growth/domain"
+)
+
+type HarvestLotEventWrapper InterfaceWrapper
+
+func (w *HarvestLotEventWrapper) UnmarshalJSON(b []byte) error {
+	wrapper := InterfaceWrapper{}
+
+	err := json.Unmarshal(b, &wrapper)
+	if err != nil {
+		return err
+	}
+
+	w.Actor = wrapper.Actor
+
+	mapped, ok := wrapper.Data.(map[string]interface{})
+	if !ok {
+		return errors.New("error type assertion")
+	}
+
+	f := mapstructure.ComposeDecodeHookFunc(
+		UIDHook(),
+		TimeHook(time.RFC3339),
+	)
+
+	switch wrapper.Name {
+	case "HarvestLotCreated":
+		e := domain.HarvestLotCreated{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case "HarvestLotShipped":
+		e := domain.HarvestLotShipped{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+	}
+
+	return nil
+}
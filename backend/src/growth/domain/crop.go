@@ -1,17 +1,20 @@
 package domain
 
 import (
+	"math"
 	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/usetania/tania-core/src/growth/query"
 	"github.com/usetania/tania-core/src/helper/stringhelper"
+	"github.com/usetania/tania-core/src/units"
 )
 
 type Crop struct {
 	UID          uuid.UUID
 	BatchID      string
+	ShortCode    string
 	Status       CropStatus
 	Type         CropType
 	Container    CropContainer
@@ -25,6 +28,22 @@ type Crop struct {
 	HarvestedStorage []HarvestedStorage
 	Trash            []Trash
 
+	// Nursery stages the crop went through before being transplanted
+	NurseryStages []CropNurseryStage
+
+	// Date the accumulated growing degree days reached the maturity of the crop's material
+	GDDMaturityReachedDate *time.Time
+
+	// Seeds sown for the batch and the last count of them which germinated, nil when not recorded
+	SeedsSown   *int
+	Germination *CropGermination
+
+	// Batch the plants of the crop were merged into, nil when the crop wasn't merged
+	MergedIntoUID *uuid.UUID
+
+	// Transfers of the plants of the crop to other farms
+	Transfers []CropTransfer
+
 	// Fields to track care crop
 	LastFertilized time.Time
 	LastPruned     time.Time
@@ -57,6 +76,7 @@ type ServiceResult struct {
 // because not all the crop has harvested.
 const (
 	CropActive   = "ACTIVE"
+	CropNursery  = "NURSERY"
 	CropArchived = "ARCHIVED"
 )
 
@@ -68,6 +88,7 @@ type CropStatus struct {
 func CropStatuses() []CropStatus {
 	return []CropStatus{
 		{Code: CropActive, Label: "Active"},
+		{Code: CropNursery, Label: "Nursery"},
 		{Code: CropArchived, Label: "Archived"},
 	}
 }
@@ -201,8 +222,8 @@ func GetHarvestType(code string) HarvestType {
 }
 
 const (
-	Kg = "Kg"
-	Gr = "Gr"
+	Kg = units.Kilogram
+	Gr = units.Gram
 )
 
 type ProducedUnit struct {
@@ -211,10 +232,12 @@ type ProducedUnit struct {
 }
 
 func ProducedUnits() []ProducedUnit {
-	return []ProducedUnit{
-		{Code: Kg, Label: "kg"},
-		{Code: Gr, Label: "gr"},
+	producedUnits := []ProducedUnit{}
+	for _, v := range units.Harvest() {
+		producedUnits = append(producedUnits, ProducedUnit{Code: v.Code, Label: v.Label})
 	}
+
+	return producedUnits
 }
 
 func GetProducedUnit(code string) ProducedUnit {
@@ -227,6 +250,22 @@ func GetProducedUnit(code string) ProducedUnit {
 	return ProducedUnit{}
 }
 
+// gradeTotalTolerance is how far the sum of the grade quantities may be off the produced quantity,
+// so the rounding of the typed decimals isn't rejected.
+const gradeTotalTolerance = 0.005
+
+// GradeQuantity is the quantity of a harvest grade, in the produced unit of the harvest.
+type GradeQuantity struct {
+	Grade    string  `json:"grade"`
+	Quantity float32 `json:"quantity"`
+}
+
+// HarvestedGrade is the quantity of a harvest grade, converted to gram like the produced quantity.
+type HarvestedGrade struct {
+	Grade        string  `json:"grade"`
+	GramQuantity float32 `json:"gram_quantity"`
+}
+
 type CropNote struct {
 	UID         uuid.UUID `json:"uid"`
 	Content     string    `json:"content"`
@@ -234,15 +273,36 @@ type CropNote struct {
 }
 
 type CropPhoto struct {
-	UID         uuid.UUID `json:"uid"`
-	Filename    string    `json:"filename"`
-	MimeType    string    `json:"mime_type"`
-	Size        int       `json:"size"`
-	Width       int       `json:"width"`
-	Height      int       `json:"height"`
-	Description string    `json:"description"`
+	UID               uuid.UUID `json:"uid"`
+	Filename          string    `json:"filename"`
+	MimeType          string    `json:"mime_type"`
+	Size              int       `json:"size"`
+	Width             int       `json:"width"`
+	Height            int       `json:"height"`
+	Description       string    `json:"description"`
+	Status            string    `json:"status"`
+	ThumbnailFilename string    `json:"thumbnail_filename"`
+
+	Metadata CropPhotoMetadata `json:"metadata"`
+}
+
+// CropPhotoMetadata is the EXIF metadata of a crop photo, each field empty when the photo doesn't have it.
+type CropPhotoMetadata struct {
+	DateTaken *time.Time `json:"date_taken"`
+	Make      string     `json:"make"`
+	Model     string     `json:"model"`
+	GPSLat    *float64   `json:"gps_lat"`
+	GPSLon    *float64   `json:"gps_lon"`
 }
 
+// Photo processing status. A photo uploaded in bulk stays pending
+// until its thumbnail has been built by the background workers.
+const (
+	CropPhotoStatusPending = "pending"
+	CropPhotoStatusReady   = "ready"
+	CropPhotoStatusFailed  = "failed"
+)
+
 func (c *Crop) TrackChange(event interface{}) {
 	c.UncommittedChanges = append(c.UncommittedChanges, event)
 	c.Transition(event)
@@ -406,6 +466,12 @@ func (c *Crop) Transition(event interface{}) {
 		delete(c.Notes, e.UID)
 
 	case CropBatchPhotoCreated:
+		// Photos created before processing status existed are already usable.
+		status := e.Status
+		if status == "" {
+			status = CropPhotoStatusReady
+		}
+
 		c.Photos = append(c.Photos, CropPhoto{
 			UID:         e.UID,
 			Filename:    e.Filename,
@@ -414,6 +480,96 @@ func (c *Crop) Transition(event interface{}) {
 			Width:       e.Width,
 			Height:      e.Height,
 			Description: e.Description,
+			Status:      status,
+			Metadata:    e.Metadata,
+		})
+
+	case CropNurseryStageStarted:
+		c.NurseryStages = append(c.NurseryStages, CropNurseryStage{
+			UID:                    e.UID,
+			NurseryAreaUID:         e.NurseryAreaID,
+			StartDate:              e.StartDate,
+			ExpectedTransplantDate: e.ExpectedTransplantDate,
+			ActualTransplantDate:   e.ActualTransplantDate,
+		})
+
+		c.Status = GetCropStatus(CropNursery)
+
+	case CropNurseryStageCompleted:
+		for i, v := range c.NurseryStages {
+			if v.UID == e.UID {
+				c.NurseryStages[i].ActualTransplantDate = e.ActualTransplantDate
+			}
+		}
+
+		c.Status = GetCropStatus(CropActive)
+
+	case CropBatchPhotoProcessed:
+		for i, v := range c.Photos {
+			if v.UID == e.UID {
+				c.Photos[i].ThumbnailFilename = e.ThumbnailFilename
+				c.Photos[i].Width = e.Width
+				c.Photos[i].Height = e.Height
+				c.Photos[i].Status = e.Status
+			}
+		}
+
+	case CropBatchPhotoProcessingFailed:
+		for i, v := range c.Photos {
+			if v.UID == e.UID {
+				c.Photos[i].Status = e.Status
+			}
+		}
+
+	case CropBatchPhotoProcessingRetried:
+		for i, v := range c.Photos {
+			if v.UID == e.UID {
+				c.Photos[i].Status = e.Status
+			}
+		}
+
+	case CropBatchPhotoRemoved:
+		photos := []CropPhoto{}
+
+		for _, v := range c.Photos {
+			if v.UID != e.UID {
+				photos = append(photos, v)
+			}
+		}
+
+		c.Photos = photos
+
+	case CropBatchShortCodeAssigned:
+		c.ShortCode = e.ShortCode
+
+	case CropGDDMaturityReached:
+		c.GDDMaturityReachedDate = &e.ReachedDate
+
+	case CropBatchSeedsSownRecorded:
+		seedsSown := e.SeedsSown
+		c.SeedsSown = &seedsSown
+
+	case CropGerminationRecorded:
+		c.Germination = &CropGermination{Quantity: e.GerminatedQuantity, Date: e.GerminationDate}
+
+	case CropMergedFrom:
+		c.changeAreaQuantity(e.AreaUID, e.Quantity, e.MergedDate)
+
+	case CropMergedInto:
+		c.changeAreaQuantity(e.AreaUID, -e.Quantity, e.MergedDate)
+		c.Status = GetCropStatus(CropArchived)
+		c.MergedIntoUID = &e.TargetCropUID
+
+	case CropTransferInitiated:
+		c.Transfers = append(c.Transfers, CropTransfer{
+			UID:               e.TransferID,
+			FromFarmID:        e.FromFarmID,
+			ToFarmID:          e.ToFarmID,
+			TransferDate:      e.TransferDate,
+			TransferredBy:     e.TransferredBy,
+			RecipientName:     e.RecipientName,
+			RecipientContact:  e.RecipientContact,
+			CertificateNumber: e.CertificateNumber,
 		})
 	}
 }
@@ -653,6 +809,7 @@ func (c *Crop) Harvest(
 	harvestType string,
 	producedQuantity float32,
 	producedUnit ProducedUnit,
+	grades []GradeQuantity,
 	notes string,
 ) error {
 	// Validate //
@@ -696,6 +853,11 @@ func (c *Crop) Harvest(
 		return CropError{Code: CropHarvestErrorInvalidHarvestType}
 	}
 
+	err := validateGradeQuantities(grades, producedQuantity)
+	if err != nil {
+		return err
+	}
+
 	// Process //
 	harvestDate := time.Now()
 
@@ -762,13 +924,21 @@ func (c *Crop) Harvest(
 
 	// Calculate the produced harvest
 	// Produced Quantity always converted to gram
-	totalProduced := producedQuantity
-	if producedUnit.Code == Kg {
-		totalProduced = producedQuantity * 1000
+	toGram := float32(1)
+	if grams, ok := units.Harvest().ToCanonical(producedUnit.Code, 1); ok {
+		toGram = float32(grams)
 	}
 
+	totalProduced := producedQuantity * toGram
+
 	harvestedStorage.ProducedGramQuantity += totalProduced
 
+	harvestedGrades := []HarvestedGrade{}
+
+	for _, v := range grades {
+		harvestedGrades = append(harvestedGrades, HarvestedGrade{Grade: v.Grade, GramQuantity: v.Quantity * toGram})
+	}
+
 	// Check all the quantity in InitialArea and MovedArea,
 	// if its all empty then crop status is marked to archive
 	initialAreaEmpty := false
@@ -801,6 +971,10 @@ func (c *Crop) Harvest(
 	}
 
 	status := CropActive
+	if c.Status.Code == CropNursery {
+		status = CropNursery
+	}
+
 	if initialAreaEmpty && movedAreaEmpty {
 		status = CropArchived
 	}
@@ -816,13 +990,29 @@ func (c *Crop) Harvest(
 		HarvestedArea:           harvestedArea,
 		HarvestedAreaCode:       harvestedAreaCode,
 		HarvestDate:             harvestDate,
+		Grades:                  harvestedGrades,
 		Notes:                   notes,
 	})
 
 	return nil
 }
 
-func (c *Crop) Dump(cropService CropService, sourceAreaUID uuid.UUID, quantity int, notes string) error {
+// The reasons of dumping a crop batch. The areas whose last crop was dumped for disease need a sanitation.
+const (
+	DumpReasonDisease = "DISEASE"
+	DumpReasonPest    = "PEST"
+	DumpReasonDamage  = "DAMAGE"
+	DumpReasonOther   = "OTHER"
+)
+
+// Dump trashes the quantity of the crop in the source area. The reason is optional.
+func (c *Crop) Dump(cropService CropService, sourceAreaUID uuid.UUID, quantity int, notes, reason string) error {
+	switch reason {
+	case "", DumpReasonDisease, DumpReasonPest, DumpReasonDamage, DumpReasonOther:
+	default:
+		return CropError{Code: CropDumpErrorInvalidReason}
+	}
+
 	// Validate //
 	// Check if source area is exist in DB
 	serviceResult := cropService.FindAreaByID(sourceAreaUID)
@@ -956,6 +1146,10 @@ func (c *Crop) Dump(cropService CropService, sourceAreaUID uuid.UUID, quantity i
 	}
 
 	status := CropActive
+	if c.Status.Code == CropNursery {
+		status = CropNursery
+	}
+
 	if initialAreaEmpty && movedAreaEmpty {
 		status = CropArchived
 	}
@@ -970,6 +1164,7 @@ func (c *Crop) Dump(cropService CropService, sourceAreaUID uuid.UUID, quantity i
 		DumpedAreaCode: dumpedAreaCode,
 		DumpDate:       time.Now(),
 		Notes:          notes,
+		Reason:         reason,
 	})
 
 	return nil
@@ -1090,6 +1285,26 @@ func (c *Crop) ChangeInventory(cropService CropService, inventoryUID uuid.UUID)
 	return nil
 }
 
+// AssignShortCode gives the crop its human friendly code.
+// The code is permanent, so it cannot be reassigned.
+func (c *Crop) AssignShortCode(shortCode string) error {
+	if shortCode == "" {
+		return CropError{Code: CropErrorInvalidShortCode}
+	}
+
+	if c.ShortCode != "" {
+		return CropError{Code: CropErrorShortCodeAlreadyAssigned}
+	}
+
+	c.TrackChange(CropBatchShortCodeAssigned{
+		UID:       c.UID,
+		FarmUID:   c.FarmUID,
+		ShortCode: shortCode,
+	})
+
+	return nil
+}
+
 func (c *Crop) AddNewNote(content string) error {
 	if content == "" {
 		return CropError{Code: CropNoteErrorInvalidContent}
@@ -1137,7 +1352,9 @@ func (c *Crop) RemoveNote(uid uuid.UUID) error {
 	return nil
 }
 
-func (c *Crop) AddPhoto(filename, mimeType string, size, width, height int, description string) error {
+func (c *Crop) AddPhoto(
+	filename, mimeType string, size, width, height int, description string, metadata CropPhotoMetadata,
+) error {
 	if filename == "" {
 		return CropError{CropErrorPhotoInvalidFilename}
 	}
@@ -1168,6 +1385,133 @@ func (c *Crop) AddPhoto(filename, mimeType string, size, width, height int, desc
 		Width:       width,
 		Height:      height,
 		Description: description,
+		Status:      CropPhotoStatusReady,
+		Metadata:    metadata,
+	})
+
+	return nil
+}
+
+// AddPendingPhoto registers a stored original photo whose thumbnail
+// hasn't been processed yet. It returns the new photo's UID.
+func (c *Crop) AddPendingPhoto(
+	filename, mimeType string, size int, description string, metadata CropPhotoMetadata,
+) (uuid.UUID, error) {
+	if filename == "" {
+		return uuid.UUID{}, CropError{CropErrorPhotoInvalidFilename}
+	}
+
+	if mimeType == "" {
+		return uuid.UUID{}, CropError{CropErrorPhotoInvalidMimeType}
+	}
+
+	if size <= 0 {
+		return uuid.UUID{}, CropError{CropErrorPhotoInvalidSize}
+	}
+
+	if description == "" {
+		return uuid.UUID{}, CropError{CropErrorPhotoInvalidDescription}
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	c.TrackChange(CropBatchPhotoCreated{
+		UID:         uid,
+		CropUID:     c.UID,
+		Filename:    filename,
+		MimeType:    mimeType,
+		Size:        size,
+		Description: description,
+		Status:      CropPhotoStatusPending,
+		Metadata:    metadata,
+	})
+
+	return uid, nil
+}
+
+// FindPhotoByID returns the crop's photo with the given UID.
+func (c Crop) FindPhotoByID(photoUID uuid.UUID) (CropPhoto, error) {
+	for _, v := range c.Photos {
+		if v.UID == photoUID {
+			return v, nil
+		}
+	}
+
+	return CropPhoto{}, CropError{CropErrorPhotoNotFound}
+}
+
+func (c *Crop) MarkPhotoProcessed(photoUID uuid.UUID, thumbnailFilename string, width, height int) error {
+	if _, err := c.FindPhotoByID(photoUID); err != nil {
+		return err
+	}
+
+	if thumbnailFilename == "" {
+		return CropError{CropErrorPhotoInvalidFilename}
+	}
+
+	c.TrackChange(CropBatchPhotoProcessed{
+		UID:               photoUID,
+		CropUID:           c.UID,
+		ThumbnailFilename: thumbnailFilename,
+		Width:             width,
+		Height:            height,
+		Status:            CropPhotoStatusReady,
+	})
+
+	return nil
+}
+
+func (c *Crop) MarkPhotoProcessingFailed(photoUID uuid.UUID) error {
+	if _, err := c.FindPhotoByID(photoUID); err != nil {
+		return err
+	}
+
+	c.TrackChange(CropBatchPhotoProcessingFailed{
+		UID:     photoUID,
+		CropUID: c.UID,
+		Status:  CropPhotoStatusFailed,
+	})
+
+	return nil
+}
+
+// RetryPhotoProcessing puts a photo that isn't ready back into pending
+// so it can be processed again from its stored original.
+func (c *Crop) RetryPhotoProcessing(photoUID uuid.UUID) error {
+	photo, err := c.FindPhotoByID(photoUID)
+	if err != nil {
+		return err
+	}
+
+	if photo.Status == CropPhotoStatusReady {
+		return CropError{CropErrorPhotoAlreadyProcessed}
+	}
+
+	c.TrackChange(CropBatchPhotoProcessingRetried{
+		UID:     photoUID,
+		CropUID: c.UID,
+		Status:  CropPhotoStatusPending,
+	})
+
+	return nil
+}
+
+// RemovePhoto removes the photo from the crop. The event keeps the filenames
+// so the files can be removed from the storage afterwards.
+func (c *Crop) RemovePhoto(photoUID uuid.UUID) error {
+	photo, err := c.FindPhotoByID(photoUID)
+	if err != nil {
+		return err
+	}
+
+	c.TrackChange(CropBatchPhotoRemoved{
+		UID:               photoUID,
+		CropUID:           c.UID,
+		Filename:          photo.Filename,
+		ThumbnailFilename: photo.ThumbnailFilename,
 	})
 
 	return nil
@@ -1236,3 +1580,34 @@ func validateContainer(quantity int, containerType CropContainerType) error {
 
 	return nil
 }
+
+// validateGradeQuantities checks the optional grade breakdown of a harvest adds up to the produced quantity.
+// A harvest without grades is valid, like the ones recorded before the grades.
+func validateGradeQuantities(grades []GradeQuantity, producedQuantity float32) error {
+	if len(grades) == 0 {
+		return nil
+	}
+
+	listed := map[string]bool{}
+
+	var total float32
+
+	for _, v := range grades {
+		if v.Quantity <= 0 {
+			return CropError{Code: CropHarvestErrorInvalidGradeQuantity}
+		}
+
+		if listed[v.Grade] {
+			return CropError{Code: CropHarvestErrorDuplicateGrade}
+		}
+
+		listed[v.Grade] = true
+		total += v.Quantity
+	}
+
+	if math.Abs(float64(total-producedQuantity)) > gradeTotalTolerance {
+		return CropError{Code: CropHarvestErrorGradeTotalMismatch}
+	}
+
+	return nil
+}
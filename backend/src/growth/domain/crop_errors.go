@@ -38,11 +38,17 @@ const (
 	CropErrorInvalidBatchID
 	CropErrorBatchIDAlreadyCreated
 
+	// Crop short code errors.
+	CropErrorInvalidShortCode
+	CropErrorShortCodeAlreadyAssigned
+
 	// Crop Photo errros.
 	CropErrorPhotoInvalidFilename
 	CropErrorPhotoInvalidMimeType
 	CropErrorPhotoInvalidSize
 	CropErrorPhotoInvalidDescription
+	CropErrorPhotoNotFound
+	CropErrorPhotoAlreadyProcessed
 
 	CropContainerErrorInvalidType
 	CropContainerErrorInvalidQuantity
@@ -54,6 +60,92 @@ const (
 
 	CropNoteErrorInvalidContent
 	CropNoteErrorNotFound
+
+	CropNurseryErrorInvalidArea
+	CropNurseryErrorAreaNotFound
+	CropNurseryErrorInvalidAreaType
+	CropNurseryErrorInvalidExpectedTransplantDate
+	CropNurseryErrorInvalidTransplantDate
+	CropNurseryErrorAlreadyStarted
+	CropNurseryErrorNotStarted
+	CropNurseryErrorCropArchived
+
+	CropInputScheduleErrorCropArchived
+	CropInputScheduleErrorInvalidPlannedDate
+	CropInputScheduleErrorInvalidQuantity
+	CropInputScheduleErrorInvalidUnit
+	CropInputScheduleErrorInvalidApplicationMethod
+	CropInputScheduleErrorAlreadyScheduled
+	CropInputScheduleErrorNotFound
+
+	CropGDDErrorCropArchived
+	CropGDDErrorMaturityNotReached
+	CropGDDErrorMaturityAlreadyReached
+
+	CropHarvestErrorInvalidGradeQuantity
+	CropHarvestErrorDuplicateGrade
+	CropHarvestErrorGradeTotalMismatch
+	CropHarvestErrorWithholdingPeriod
+	CropHarvestErrorWithholdingOverrideReasonRequired
+
+	CropGerminationErrorInvalidSeedsSown
+	CropGerminationErrorSeedsSownAlreadyRecorded
+	CropGerminationErrorSeedsSownNotRecorded
+	CropGerminationErrorCropArchived
+	CropGerminationErrorInvalidQuantity
+	CropGerminationErrorAboveSeedsSown
+	CropGerminationErrorInvalidDate
+
+	CropMergeErrorSameCrop
+	CropMergeErrorCropArchived
+	CropMergeErrorDifferentVariety
+	CropMergeErrorDifferentFarm
+	CropMergeErrorNotInSingleArea
+	CropMergeErrorDifferentArea
+
+	CropTransferErrorCropMerged
+	CropTransferErrorInvalidDestinationFarm
+	CropTransferErrorInvalidDate
+	CropTransferErrorRecipientNameEmpty
+	CropTransferErrorRecipientContactEmpty
+	CropTransferErrorCertificateNumberEmpty
+	CropTransferErrorCertificateNumberUsed
+
+	DispatchScheduleErrorHarvestRequired
+	DispatchScheduleErrorDifferentFarm
+	DispatchScheduleErrorNotHarvested
+	DispatchScheduleErrorVehicleIDEmpty
+	DispatchScheduleErrorDriverNameEmpty
+	DispatchScheduleErrorDestinationEmpty
+	DispatchScheduleErrorInvalidDeliveryTime
+	DispatchScheduleErrorAlreadyCompleted
+
+	InsurancePolicyErrorPolicyNumberEmpty
+	InsurancePolicyErrorProviderEmpty
+	InsurancePolicyErrorCropRequired
+	InsurancePolicyErrorDifferentFarm
+	InsurancePolicyErrorInvalidCoverageAmount
+	InsurancePolicyErrorInvalidPremiumAmount
+	InsurancePolicyErrorInvalidCurrency
+	InsurancePolicyErrorInvalidEndDate
+	InsurancePolicyErrorRemoved
+	InsurancePolicyErrorCropNotInsured
+	InsurancePolicyErrorClaimOutOfPeriod
+	InsurancePolicyErrorInvalidClaimQuantity
+	InsurancePolicyErrorInvalidClaimReason
+	InsurancePolicyErrorClaimNotFound
+	InsurancePolicyErrorClaimAlreadySettled
+	InsurancePolicyErrorInvalidSettledAmount
+
+	CropDumpErrorInvalidReason
+
+	CropGerminationErrorInvalidExpectedRate
+
+	HarvestLotErrorInvalidQuantity
+	HarvestLotErrorInvalidShipmentType
+	HarvestLotErrorInvalidShipmentQuantity
+	HarvestLotErrorAlreadyShipped
+	HarvestLotErrorDifferentFarm
 )
 
 // CropError is a custom error from Go built-in error.
@@ -73,6 +165,10 @@ func (e CropError) Error() string {
 		return "Invalid crop batch ID"
 	case CropErrorBatchIDAlreadyCreated:
 		return "Crop batch ID already created"
+	case CropErrorInvalidShortCode:
+		return "Invalid crop short code"
+	case CropErrorShortCodeAlreadyAssigned:
+		return "Crop short code already assigned"
 
 	case CropMoveToAreaErrorInvalidSourceArea:
 		return "Crop source area is invalid"
@@ -128,6 +224,10 @@ func (e CropError) Error() string {
 		return "Invalid size"
 	case CropErrorPhotoInvalidDescription:
 		return "Invalid description"
+	case CropErrorPhotoNotFound:
+		return "Photo not found"
+	case CropErrorPhotoAlreadyProcessed:
+		return "Photo has already been processed"
 
 	case CropContainerErrorInvalidType:
 		return "Invalid crop container type"
@@ -147,6 +247,164 @@ func (e CropError) Error() string {
 		return "Invalid crop note content"
 	case CropNoteErrorNotFound:
 		return "Crop note not found"
+
+	case CropNurseryErrorInvalidArea:
+		return "Invalid nursery area"
+	case CropNurseryErrorAreaNotFound:
+		return "Nursery area not found"
+	case CropNurseryErrorInvalidAreaType:
+		return "Nursery area must be a seeding area"
+	case CropNurseryErrorInvalidExpectedTransplantDate:
+		return "Expected transplant date must be after the start date"
+	case CropNurseryErrorInvalidTransplantDate:
+		return "Transplant date cannot be before the nursery start date"
+	case CropNurseryErrorAlreadyStarted:
+		return "Crop is already in the nursery stage"
+	case CropNurseryErrorNotStarted:
+		return "Crop is not in the nursery stage"
+	case CropNurseryErrorCropArchived:
+		return "Archived crop cannot start a nursery stage"
+
+	case CropInputScheduleErrorCropArchived:
+		return "Archived crop cannot have input applications planned"
+	case CropInputScheduleErrorInvalidPlannedDate:
+		return "Planned date cannot be in the past"
+	case CropInputScheduleErrorInvalidQuantity:
+		return "Quantity must be greater than zero"
+	case CropInputScheduleErrorInvalidUnit:
+		return "Invalid quantity unit"
+	case CropInputScheduleErrorInvalidApplicationMethod:
+		return "Invalid application method"
+	case CropInputScheduleErrorAlreadyScheduled:
+		return "Input schedule cannot be modified once its task is created"
+	case CropInputScheduleErrorNotFound:
+		return "Input schedule not found"
+
+	case CropGDDErrorCropArchived:
+		return "Archived crop cannot reach maturity"
+	case CropGDDErrorMaturityNotReached:
+		return "Accumulated growing degree days have not reached the maturity yet"
+	case CropGDDErrorMaturityAlreadyReached:
+		return "Crop has already reached its growing degree days maturity"
+
+	case CropHarvestErrorInvalidGradeQuantity:
+		return "Harvest grade quantity should be more than zero"
+	case CropHarvestErrorDuplicateGrade:
+		return "Harvest grade is listed more than once"
+	case CropHarvestErrorGradeTotalMismatch:
+		return "Harvest grade quantities should sum up to the produced quantity"
+	case CropHarvestErrorWithholdingPeriod:
+		return "Crop is still inside the withholding period of a pesticide treatment"
+	case CropHarvestErrorWithholdingOverrideReasonRequired:
+		return "Harvesting inside the withholding period needs a reason"
+
+	case CropGerminationErrorInvalidSeedsSown:
+		return "Seeds sown should be more than zero"
+	case CropGerminationErrorSeedsSownAlreadyRecorded:
+		return "Seeds sown are already recorded"
+	case CropGerminationErrorSeedsSownNotRecorded:
+		return "Crop has no seeds sown recorded"
+	case CropGerminationErrorCropArchived:
+		return "Archived crop cannot record a germination"
+	case CropGerminationErrorInvalidQuantity:
+		return "Germinated quantity cannot be negative"
+	case CropGerminationErrorAboveSeedsSown:
+		return "Germinated quantity cannot be more than the seeds sown"
+	case CropGerminationErrorInvalidDate:
+		return "Germination date cannot be in the future"
+	case CropGerminationErrorInvalidExpectedRate:
+		return "Expected germination rate should be more than 0 and up to 100"
+
+	case CropMergeErrorSameCrop:
+		return "Crop cannot be merged into itself"
+	case CropMergeErrorCropArchived:
+		return "Archived crop cannot be merged"
+	case CropMergeErrorDifferentVariety:
+		return "Crops to merge must be of the same variety"
+	case CropMergeErrorDifferentFarm:
+		return "Crops to merge must be in the same farm"
+	case CropMergeErrorNotInSingleArea:
+		return "Crops to merge must have their plants in a single area"
+	case CropMergeErrorDifferentArea:
+		return "Crops to merge must be in the same area"
+
+	case CropTransferErrorCropMerged:
+		return "Crop merged into another batch cannot be transferred"
+	case CropTransferErrorInvalidDestinationFarm:
+		return "Crop must be transferred to another farm"
+	case CropTransferErrorInvalidDate:
+		return "Transfer date cannot be before the crop was created"
+	case CropTransferErrorRecipientNameEmpty:
+		return "Recipient name is required"
+	case CropTransferErrorRecipientContactEmpty:
+		return "Recipient contact is required"
+	case CropTransferErrorCertificateNumberEmpty:
+		return "Certificate number is required"
+	case CropTransferErrorCertificateNumberUsed:
+		return "Certificate number is already used by another transfer of the crop"
+
+	case DispatchScheduleErrorHarvestRequired:
+		return "Dispatch needs at least one harvest"
+	case DispatchScheduleErrorDifferentFarm:
+		return "Harvests to dispatch must be from the farm of the dispatch"
+	case DispatchScheduleErrorNotHarvested:
+		return "Crop to dispatch has not been harvested yet"
+	case DispatchScheduleErrorVehicleIDEmpty:
+		return "Vehicle ID is required"
+	case DispatchScheduleErrorDriverNameEmpty:
+		return "Driver name is required"
+	case DispatchScheduleErrorDestinationEmpty:
+		return "Destination address is required"
+	case DispatchScheduleErrorInvalidDeliveryTime:
+		return "Estimated delivery time must be after the pickup time"
+	case DispatchScheduleErrorAlreadyCompleted:
+		return "Dispatch is already completed"
+
+	case InsurancePolicyErrorPolicyNumberEmpty:
+		return "Policy number is required"
+	case InsurancePolicyErrorProviderEmpty:
+		return "Insurance provider is required"
+	case InsurancePolicyErrorCropRequired:
+		return "Insurance policy needs at least one crop"
+	case InsurancePolicyErrorDifferentFarm:
+		return "Insured crops must be from the farm of the policy"
+	case InsurancePolicyErrorInvalidCoverageAmount:
+		return "Coverage amount must be more than zero"
+	case InsurancePolicyErrorInvalidPremiumAmount:
+		return "Premium amount can't be negative"
+	case InsurancePolicyErrorInvalidCurrency:
+		return "Currency must be a three letters currency code"
+	case InsurancePolicyErrorInvalidEndDate:
+		return "End date must be after the start date"
+	case InsurancePolicyErrorRemoved:
+		return "Insurance policy is removed"
+	case InsurancePolicyErrorCropNotInsured:
+		return "Crop is not insured by the policy"
+	case InsurancePolicyErrorClaimOutOfPeriod:
+		return "Claim must be filed for a loss in the period of the policy"
+	case InsurancePolicyErrorInvalidClaimQuantity:
+		return "Claimed quantity can't be negative"
+	case InsurancePolicyErrorInvalidClaimReason:
+		return "Claim reason must be disease, pest, damage or other"
+	case InsurancePolicyErrorClaimNotFound:
+		return "Claim is not found"
+	case InsurancePolicyErrorClaimAlreadySettled:
+		return "Claim is already settled"
+	case InsurancePolicyErrorInvalidSettledAmount:
+		return "Settled amount must be between zero and the coverage left on the policy"
+	case CropDumpErrorInvalidReason:
+		return "Dump reason must be disease, pest, damage or other"
+
+	case HarvestLotErrorInvalidQuantity:
+		return "Lot quantity must be more than zero"
+	case HarvestLotErrorInvalidShipmentType:
+		return "Shipment type must be shipped or sold"
+	case HarvestLotErrorInvalidShipmentQuantity:
+		return "Shipped quantity must be more than zero and up to the quantity left in the lot"
+	case HarvestLotErrorAlreadyShipped:
+		return "Lot is already shipped entirely"
+	case HarvestLotErrorDifferentFarm:
+		return "Dispatch must be of the farm of the lot"
 	default:
 		return "Unrecognized Crop Error Code"
 	}
@@ -57,6 +57,7 @@ type CropBatchHarvested struct {
 	HarvestedArea           interface{}
 	HarvestedAreaCode       string // Values: INITIAL_AREA / MOVED_AREA
 	HarvestDate             time.Time
+	Grades                  []HarvestedGrade
 	Notes                   string
 }
 
@@ -69,6 +70,7 @@ type CropBatchDumped struct {
 	DumpedAreaCode string // Values: INITIAL_AREA / MOVED_AREA
 	DumpDate       time.Time
 	Notes          string
+	Reason         string // Values: DISEASE / PEST / DAMAGE / OTHER, empty for the dumps before it existed
 }
 
 type CropBatchWatered struct {
@@ -103,4 +105,152 @@ type CropBatchPhotoCreated struct {
 	Width       int
 	Height      int
 	Description string
+	Status      string
+	Metadata    CropPhotoMetadata
+}
+
+type CropBatchPhotoProcessed struct {
+	UID               uuid.UUID
+	CropUID           uuid.UUID
+	ThumbnailFilename string
+	Width             int
+	Height            int
+	Status            string
+}
+
+type CropBatchPhotoProcessingFailed struct {
+	UID     uuid.UUID
+	CropUID uuid.UUID
+	Status  string
+}
+
+type CropBatchPhotoProcessingRetried struct {
+	UID     uuid.UUID
+	CropUID uuid.UUID
+	Status  string
+}
+
+type CropBatchPhotoRemoved struct {
+	UID               uuid.UUID
+	CropUID           uuid.UUID
+	Filename          string
+	ThumbnailFilename string
+}
+
+type CropNurseryStageStarted struct {
+	UID                    uuid.UUID
+	CropID                 uuid.UUID
+	NurseryAreaID          uuid.UUID
+	StartDate              time.Time
+	ExpectedTransplantDate time.Time
+	ActualTransplantDate   *time.Time
+}
+
+type CropNurseryStageCompleted struct {
+	UID                    uuid.UUID
+	CropID                 uuid.UUID
+	NurseryAreaID          uuid.UUID
+	StartDate              time.Time
+	ExpectedTransplantDate time.Time
+	ActualTransplantDate   *time.Time
+}
+
+type CropBatchShortCodeAssigned struct {
+	UID       uuid.UUID
+	FarmUID   uuid.UUID
+	ShortCode string
+}
+
+type CropGDDMaturityReached struct {
+	UID            uuid.UUID
+	BatchID        string
+	FarmUID        uuid.UUID
+	AreaUID        uuid.UUID
+	InventoryUID   uuid.UUID
+	AccumulatedGDD float64
+	GDDToMaturity  float64
+	ReachedDate    time.Time
+}
+
+type CropBatchSeedsSownRecorded struct {
+	UID       uuid.UUID
+	SeedsSown int
+}
+
+type CropGerminationRecorded struct {
+	UID                uuid.UUID
+	BatchID            string
+	FarmUID            uuid.UUID
+	InventoryUID       uuid.UUID
+	SeedsSown          int
+	GerminatedQuantity int
+	GerminationDate    time.Time
+}
+
+// GerminationChecked compares the seeds of a batch which germinated with the germination rate expected of their
+// seed lot. The rates are percentages.
+type GerminationChecked struct {
+	UID                     uuid.UUID
+	BatchID                 string
+	FarmUID                 uuid.UUID
+	SeedsPlanted            int
+	SeedsGerminated         int
+	ActualGerminationRate   float64
+	ExpectedGerminationRate float64
+	CheckedAt               time.Time
+}
+
+// LowGerminationAlert is a germination check below 80% of the expected rate, the batch may need replanting.
+type LowGerminationAlert struct {
+	UID                     uuid.UUID
+	BatchID                 string
+	FarmUID                 uuid.UUID
+	AreaUID                 uuid.UUID
+	ActualGerminationRate   float64
+	ExpectedGerminationRate float64
+	CheckedAt               time.Time
+}
+
+// CropBatchWithholdingOverridden records a harvest let through before the safe harvest date of the batch.
+type CropBatchWithholdingOverridden struct {
+	UID             uuid.UUID
+	BatchID         string
+	SafeHarvestDate time.Time
+	Reason          string
+	OverriddenBy    uuid.UUID
+	OverriddenDate  time.Time
+}
+
+// CropMergedFrom adds the plants of a batch merged into the crop to its current area.
+type CropMergedFrom struct {
+	UID           uuid.UUID
+	SourceCropUID uuid.UUID
+	SourceBatchID string
+	AreaUID       uuid.UUID
+	Quantity      int
+	MergedDate    time.Time
+}
+
+// CropMergedInto archives a batch whose plants were merged into the target batch.
+type CropMergedInto struct {
+	UID           uuid.UUID
+	TargetCropUID uuid.UUID
+	TargetBatchID string
+	AreaUID       uuid.UUID
+	Quantity      int
+	MergedDate    time.Time
+}
+
+// CropTransferInitiated records the plants of the crop leaving its farm for another one, whose transfer
+// certificate has the certificate number.
+type CropTransferInitiated struct {
+	TransferID        uuid.UUID
+	CropID            uuid.UUID
+	FromFarmID        uuid.UUID
+	ToFarmID          uuid.UUID
+	TransferDate      time.Time
+	TransferredBy     uuid.UUID
+	RecipientName     string
+	RecipientContact  string
+	CertificateNumber string
 }
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+)
+
+// GDDBaseTemperature is the temperature in celsius under which the crops are considered not growing.
+const GDDBaseTemperature = 10.0
+
+// GDDAccumulator accumulates the growing degree days of a crop from the microclimate samples
+// of the areas it grew in, since its planting date.
+type GDDAccumulator struct {
+	SampleQuery     query.MicroclimateSampleQuery
+	BaseTemperature float64
+}
+
+func NewGDDAccumulator(sampleQuery query.MicroclimateSampleQuery) GDDAccumulator {
+	return GDDAccumulator{SampleQuery: sampleQuery, BaseTemperature: GDDBaseTemperature}
+}
+
+// DegreeDays returns the growing degree days of a day from its temperature range.
+func DegreeDays(tMax, tMin, tBase float64) float64 {
+	gdd := (tMax+tMin)/2 - tBase
+	if gdd < 0 {
+		return 0
+	}
+
+	return gdd
+}
+
+// AccumulateDegreeDays sums the degree days of the samples, taking the range of the samples of each day.
+func AccumulateDegreeDays(samples []query.MicroclimateSampleQueryResult, tBase float64) float64 {
+	type temperatureRange struct {
+		Max float64
+		Min float64
+	}
+
+	days := map[string]*temperatureRange{}
+
+	for _, v := range samples {
+		day := v.RecordedDate.Format("2006-01-02")
+
+		r, ok := days[day]
+		if !ok {
+			days[day] = &temperatureRange{Max: v.Temperature, Min: v.Temperature}
+
+			continue
+		}
+
+		if v.Temperature > r.Max {
+			r.Max = v.Temperature
+		}
+
+		if v.Temperature < r.Min {
+			r.Min = v.Temperature
+		}
+	}
+
+	total := 0.0
+	for _, r := range days {
+		total += DegreeDays(r.Max, r.Min, tBase)
+	}
+
+	return total
+}
+
+// Accumulate returns the growing degree days of the crop since its planting date.
+// A moved crop accumulates the samples of its initial area until the move, then the ones of the destination.
+func (a GDDAccumulator) Accumulate(crop Crop) (float64, error) {
+	samples := []query.MicroclimateSampleQueryResult{}
+	periods := crop.areaPeriods()
+
+	for i, period := range periods {
+		result := <-a.SampleQuery.FindAllByArea(period.AreaUID, period.Start)
+		if result.Error != nil {
+			return 0, result.Error
+		}
+
+		areaSamples, ok := result.Result.([]query.MicroclimateSampleQueryResult)
+		if !ok {
+			return 0, errors.New("internal server error. error type assertion")
+		}
+
+		for _, v := range areaSamples {
+			if i+1 < len(periods) && !v.RecordedDate.Before(periods[i+1].Start) {
+				break
+			}
+
+			samples = append(samples, v)
+		}
+	}
+
+	return AccumulateDegreeDays(samples, a.BaseTemperature), nil
+}
+
+// ReachGDDMaturity records that the accumulated growing degree days reached the maturity of the crop's material.
+func (c *Crop) ReachGDDMaturity(accumulatedGDD, gddToMaturity float64, reachedDate time.Time) error {
+	// Validate //
+	if c.Status.Code == CropArchived {
+		return CropError{Code: CropGDDErrorCropArchived}
+	}
+
+	if c.GDDMaturityReachedDate != nil {
+		return CropError{Code: CropGDDErrorMaturityAlreadyReached}
+	}
+
+	if gddToMaturity <= 0 || accumulatedGDD < gddToMaturity {
+		return CropError{Code: CropGDDErrorMaturityNotReached}
+	}
+
+	periods := c.areaPeriods()
+
+	// Process //
+	c.TrackChange(CropGDDMaturityReached{
+		UID:            c.UID,
+		BatchID:        c.BatchID,
+		FarmUID:        c.FarmUID,
+		AreaUID:        periods[len(periods)-1].AreaUID,
+		InventoryUID:   c.InventoryUID,
+		AccumulatedGDD: accumulatedGDD,
+		GDDToMaturity:  gddToMaturity,
+		ReachedDate:    reachedDate,
+	})
+
+	return nil
+}
+
+type cropAreaPeriod struct {
+	AreaUID uuid.UUID
+	Start   time.Time
+}
+
+// areaPeriods returns the areas the crop grew in, in the order it was moved to them.
+func (c Crop) areaPeriods() []cropAreaPeriod {
+	periods := []cropAreaPeriod{{AreaUID: c.InitialArea.AreaUID, Start: c.InitialArea.CreatedDate}}
+
+	moved := make([]MovedArea, len(c.MovedArea))
+	copy(moved, c.MovedArea)
+
+	sort.Slice(moved, func(i, j int) bool {
+		return moved[i].CreatedDate.Before(moved[j].CreatedDate)
+	})
+
+	for _, v := range moved {
+		periods = append(periods, cropAreaPeriod{AreaUID: v.AreaUID, Start: v.CreatedDate})
+	}
+
+	return periods
+}
@@ -0,0 +1,136 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+)
+
+// sampleQuery returns the samples of its areas recorded since the date, as the storages do.
+type sampleQuery map[uuid.UUID][]query.MicroclimateSampleQueryResult
+
+func (q sampleQuery) FindAllByArea(areaUID uuid.UUID, from time.Time) <-chan query.Result {
+	result := make(chan query.Result, 1)
+
+	samples := []query.MicroclimateSampleQueryResult{}
+
+	for _, v := range q[areaUID] {
+		if !v.RecordedDate.Before(from) {
+			samples = append(samples, v)
+		}
+	}
+
+	result <- query.Result{Result: samples}
+	close(result)
+
+	return result
+}
+
+func (q sampleQuery) FindAllByDate(from, to time.Time) <-chan query.Result {
+	result := make(chan query.Result, 1)
+
+	result <- query.Result{Result: []query.MicroclimateSampleQueryResult{}}
+	close(result)
+
+	return result
+}
+
+func TestDegreeDays(t *testing.T) {
+	t.Parallel()
+
+	// When
+	warm := DegreeDays(30, 20, GDDBaseTemperature)
+	cold := DegreeDays(8, 2, GDDBaseTemperature)
+
+	// Then
+	assert.Equal(t, 15.0, warm)
+	assert.Equal(t, 0.0, cold)
+}
+
+func TestGDDAccumulatorAccumulatesTheAreasOfTheCrop(t *testing.T) {
+	t.Parallel()
+	// Given
+	seedingAreaUID, _ := uuid.NewV4()
+	growingAreaUID, _ := uuid.NewV4()
+
+	plantingDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	movingDate := plantingDate.AddDate(0, 0, 2)
+
+	day := func(days, hour int) time.Time {
+		return plantingDate.AddDate(0, 0, days).Add(time.Duration(hour) * time.Hour)
+	}
+
+	samples := sampleQuery{
+		seedingAreaUID: {
+			// Before the planting date
+			{AreaUID: seedingAreaUID, Temperature: 40, RecordedDate: day(-1, 12)},
+			// (24 + 16) / 2 - 10 = 10
+			{AreaUID: seedingAreaUID, Temperature: 16, RecordedDate: day(0, 6)},
+			{AreaUID: seedingAreaUID, Temperature: 24, RecordedDate: day(0, 14)},
+			// (20 + 20) / 2 - 10 = 10
+			{AreaUID: seedingAreaUID, Temperature: 20, RecordedDate: day(1, 12)},
+			// After the crop was moved
+			{AreaUID: seedingAreaUID, Temperature: 40, RecordedDate: day(2, 12)},
+		},
+		growingAreaUID: {
+			// (30 + 20) / 2 - 10 = 15
+			{AreaUID: growingAreaUID, Temperature: 20, RecordedDate: day(2, 6)},
+			{AreaUID: growingAreaUID, Temperature: 30, RecordedDate: day(2, 14)},
+		},
+	}
+
+	crop := Crop{
+		Status:      GetCropStatus(CropActive),
+		InitialArea: InitialArea{AreaUID: seedingAreaUID, CreatedDate: plantingDate},
+		MovedArea:   []MovedArea{{AreaUID: growingAreaUID, SourceAreaUID: seedingAreaUID, CreatedDate: movingDate}},
+	}
+
+	// When
+	accumulated, err := NewGDDAccumulator(samples).Accumulate(crop)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 35.0, accumulated)
+}
+
+func TestReachGDDMaturity(t *testing.T) {
+	t.Parallel()
+	// Given
+	cropUID, _ := uuid.NewV4()
+	areaUID, _ := uuid.NewV4()
+	reachedDate := time.Now()
+
+	crop := &Crop{
+		UID:         cropUID,
+		Status:      GetCropStatus(CropActive),
+		InitialArea: InitialArea{AreaUID: areaUID, CreatedDate: reachedDate.AddDate(0, 0, -60)},
+	}
+
+	// When
+	err := crop.ReachGDDMaturity(900, 1000, reachedDate)
+
+	// Then
+	assert.Equal(t, CropError{Code: CropGDDErrorMaturityNotReached}, err)
+
+	// When
+	err = crop.ReachGDDMaturity(1010, 1000, reachedDate)
+
+	// Then
+	assert.Nil(t, err)
+
+	event, ok := crop.UncommittedChanges[0].(CropGDDMaturityReached)
+	assert.True(t, ok)
+	assert.Equal(t, areaUID, event.AreaUID)
+	assert.Equal(t, 1010.0, event.AccumulatedGDD)
+	assert.Equal(t, &reachedDate, crop.GDDMaturityReachedDate)
+
+	// When
+	err = crop.ReachGDDMaturity(1020, 1000, reachedDate)
+
+	// Then
+	assert.Equal(t, CropError{Code: CropGDDErrorMaturityAlreadyReached}, err)
+}
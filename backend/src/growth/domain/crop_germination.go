@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"time"
+)
+
+// LowGerminationRatio is the share of the expected germination rate below which a check raises an alert.
+const LowGerminationRatio = 0.8
+
+// CropGermination is the last count of the seeds of a batch which germinated.
+type CropGermination struct {
+	Quantity int
+	Date     time.Time
+}
+
+// GerminationRate returns the percentage of the sown seeds which germinated,
+// nil for the batches without seeds sown or germination recorded.
+func GerminationRate(seedsSown *int, germination *CropGermination) *float64 {
+	if seedsSown == nil || *seedsSown <= 0 || germination == nil {
+		return nil
+	}
+
+	rate := float64(germination.Quantity) * 100 / float64(*seedsSown)
+
+	return &rate
+}
+
+// RecordSeedsSown sets the number of seeds sown for the batch, apart from the plants it was created with.
+// It's only recorded when the batch is created.
+func (c *Crop) RecordSeedsSown(seedsSown int) error {
+	if seedsSown <= 0 {
+		return CropError{Code: CropGerminationErrorInvalidSeedsSown}
+	}
+
+	if c.SeedsSown != nil {
+		return CropError{Code: CropGerminationErrorSeedsSownAlreadyRecorded}
+	}
+
+	c.TrackChange(CropBatchSeedsSownRecorded{
+		UID:       c.UID,
+		SeedsSown: seedsSown,
+	})
+
+	return nil
+}
+
+// RecordGermination counts the seeds of the batch which germinated at the date. Each count replaces the
+// previous one, since it's the total germinated so far.
+func (c *Crop) RecordGermination(germinatedQuantity int, germinationDate time.Time) error {
+	// Validate //
+	if c.Status.Code == CropArchived {
+		return CropError{Code: CropGerminationErrorCropArchived}
+	}
+
+	if c.SeedsSown == nil {
+		return CropError{Code: CropGerminationErrorSeedsSownNotRecorded}
+	}
+
+	if germinatedQuantity < 0 {
+		return CropError{Code: CropGerminationErrorInvalidQuantity}
+	}
+
+	if germinatedQuantity > *c.SeedsSown {
+		return CropError{Code: CropGerminationErrorAboveSeedsSown}
+	}
+
+	if germinationDate.After(time.Now()) {
+		return CropError{Code: CropGerminationErrorInvalidDate}
+	}
+
+	// Process //
+	c.TrackChange(CropGerminationRecorded{
+		UID:                c.UID,
+		BatchID:            c.BatchID,
+		FarmUID:            c.FarmUID,
+		InventoryUID:       c.InventoryUID,
+		SeedsSown:          *c.SeedsSown,
+		GerminatedQuantity: germinatedQuantity,
+		GerminationDate:    germinationDate,
+	})
+
+	return nil
+}
+
+// CheckGermination records the seeds of the batch which germinated at the date, like RecordGermination, and
+// compares their rate with the expectedRate of the seed lot, a percentage. A rate below 80% of the expected one
+// raises a LowGerminationAlert.
+func (c *Crop) CheckGermination(seedsGerminated int, expectedRate float64, checkedAt time.Time) error {
+	// Validate //
+	if expectedRate <= 0 || expectedRate > 100 {
+		return CropError{Code: CropGerminationErrorInvalidExpectedRate}
+	}
+
+	// Process //
+	err := c.RecordGermination(seedsGerminated, checkedAt)
+	if err != nil {
+		return err
+	}
+
+	actualRate := *GerminationRate(c.SeedsSown, c.Germination)
+
+	c.TrackChange(GerminationChecked{
+		UID:                     c.UID,
+		BatchID:                 c.BatchID,
+		FarmUID:                 c.FarmUID,
+		SeedsPlanted:            *c.SeedsSown,
+		SeedsGerminated:         seedsGerminated,
+		ActualGerminationRate:   actualRate,
+		ExpectedGerminationRate: expectedRate,
+		CheckedAt:               checkedAt,
+	})
+
+	if actualRate < expectedRate*LowGerminationRatio {
+		c.TrackChange(LowGerminationAlert{
+			UID:                     c.UID,
+			BatchID:                 c.BatchID,
+			FarmUID:                 c.FarmUID,
+			AreaUID:                 c.InitialArea.AreaUID,
+			ActualGerminationRate:   actualRate,
+			ExpectedGerminationRate: expectedRate,
+			CheckedAt:               checkedAt,
+		})
+	}
+
+	return nil
+}
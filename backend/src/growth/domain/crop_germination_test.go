@@ -0,0 +1,114 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/growth/domain"
+)
+
+func TestCropRecordGermination(t *testing.T) {
+	t.Parallel()
+	// Given
+	cropUID, _ := uuid.NewV4()
+	germinationDate := time.Now().AddDate(0, 0, -1)
+
+	crop := &Crop{UID: cropUID, Status: GetCropStatus(CropActive)}
+	oldCrop := &Crop{UID: cropUID, Status: GetCropStatus(CropActive)}
+
+	// When
+	errSown := crop.RecordSeedsSown(50)
+	errAgain := crop.RecordSeedsSown(60)
+	errAbove := crop.RecordGermination(51, germinationDate)
+	errFuture := crop.RecordGermination(40, time.Now().AddDate(0, 0, 1))
+	errGerminated := crop.RecordGermination(40, germinationDate)
+	errOld := oldCrop.RecordGermination(10, germinationDate)
+
+	// Then
+	assert.Nil(t, errSown)
+	assert.Equal(t, CropError{Code: CropGerminationErrorSeedsSownAlreadyRecorded}, errAgain)
+	assert.Equal(t, CropError{Code: CropGerminationErrorAboveSeedsSown}, errAbove)
+	assert.Equal(t, CropError{Code: CropGerminationErrorInvalidDate}, errFuture)
+	assert.Nil(t, errGerminated)
+	assert.Equal(t, CropError{Code: CropGerminationErrorSeedsSownNotRecorded}, errOld)
+
+	assert.Equal(t, 50, *crop.SeedsSown)
+	assert.Equal(t, 40, crop.Germination.Quantity)
+	assert.Equal(t, 80.0, *GerminationRate(crop.SeedsSown, crop.Germination))
+	assert.Nil(t, GerminationRate(oldCrop.SeedsSown, oldCrop.Germination))
+
+	event, ok := crop.UncommittedChanges[1].(CropGerminationRecorded)
+	assert.True(t, ok)
+	assert.Equal(t, 50, event.SeedsSown)
+	assert.Equal(t, 40, event.GerminatedQuantity)
+}
+
+func TestCropCheckGermination(t *testing.T) {
+	t.Parallel()
+	// Given
+	cropUID, _ := uuid.NewV4()
+	areaUID, _ := uuid.NewV4()
+	checkedAt := time.Now().AddDate(0, 0, -1)
+
+	crop := &Crop{UID: cropUID, BatchID: "tom-5jan", Status: GetCropStatus(CropActive)}
+	crop.InitialArea = InitialArea{AreaUID: areaUID}
+	_ = crop.RecordSeedsSown(100)
+
+	// When
+	errRate := crop.CheckGermination(60, 0, checkedAt)
+	errFine := crop.CheckGermination(75, 90, checkedAt)
+	errLow := crop.CheckGermination(70, 90, checkedAt)
+
+	// Then
+	assert.Equal(t, CropError{Code: CropGerminationErrorInvalidExpectedRate}, errRate)
+	assert.Nil(t, errFine)
+	assert.Nil(t, errLow)
+	assert.Equal(t, 70, crop.Germination.Quantity)
+
+	// The seeds sown, then two checks of a germination recorded and checked, the last one low.
+	assert.Len(t, crop.UncommittedChanges, 6)
+
+	fine, ok := crop.UncommittedChanges[2].(GerminationChecked)
+	assert.True(t, ok)
+	assert.Equal(t, 100, fine.SeedsPlanted)
+	assert.Equal(t, 75, fine.SeedsGerminated)
+	assert.Equal(t, 75.0, fine.ActualGerminationRate)
+
+	alert, ok := crop.UncommittedChanges[5].(LowGerminationAlert)
+	assert.True(t, ok)
+	assert.Equal(t, cropUID, alert.UID)
+	assert.Equal(t, areaUID, alert.AreaUID)
+	assert.Equal(t, 70.0, alert.ActualGerminationRate)
+	assert.Equal(t, 90.0, alert.ExpectedGerminationRate)
+}
+
+func TestGerminationReport(t *testing.T) {
+	t.Parallel()
+	// Given
+	report := NewGerminationReport()
+
+	tomatoUID, _ := uuid.NewV4()
+	basilUID, _ := uuid.NewV4()
+
+	// When
+	report.Add(tomatoUID, "Tomato", "VEGETABLE", 100, 90)
+	report.Add(tomatoUID, "Tomato", "VEGETABLE", 300, 210)
+	report.Add(basilUID, "Basil", "HERB", 50, 50)
+
+	rows := report.Rows()
+
+	// Then
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "Basil", rows[0].MaterialName)
+	assert.Equal(t, 100.0, rows[0].GerminationRate)
+
+	assert.Equal(t, tomatoUID, rows[1].MaterialUID)
+	assert.Equal(t, 2, rows[1].TotalBatch)
+	assert.Equal(t, 400, rows[1].SeedsSown)
+	assert.Equal(t, 300, rows[1].GerminatedQuantity)
+	assert.Equal(t, 75.0, rows[1].GerminationRate)
+	assert.Equal(t, 70.0, rows[1].LowestRate)
+	assert.Equal(t, 90.0, rows[1].HighestRate)
+}
@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	InputScheduleStatusPlanned     = "PLANNED"
+	InputScheduleStatusTaskCreated = "TASK_CREATED"
+	InputScheduleStatusApplied     = "APPLIED"
+)
+
+const (
+	ApplicationMethodFoliarSpray   = "FOLIAR_SPRAY"
+	ApplicationMethodSoilDrench    = "SOIL_DRENCH"
+	ApplicationMethodFertigation   = "FERTIGATION"
+	ApplicationMethodBroadcast     = "BROADCAST"
+	ApplicationMethodSideDressing  = "SIDE_DRESSING"
+	ApplicationMethodSeedTreatment = "SEED_TREATMENT"
+)
+
+type ApplicationMethod struct {
+	Code  string `json:"code"`
+	Label string `json:"label"`
+}
+
+func FindAllApplicationMethods() []ApplicationMethod {
+	return []ApplicationMethod{
+		{Code: ApplicationMethodFoliarSpray, Label: "Foliar spray"},
+		{Code: ApplicationMethodSoilDrench, Label: "Soil drench"},
+		{Code: ApplicationMethodFertigation, Label: "Fertigation"},
+		{Code: ApplicationMethodBroadcast, Label: "Broadcast"},
+		{Code: ApplicationMethodSideDressing, Label: "Side dressing"},
+		{Code: ApplicationMethodSeedTreatment, Label: "Seed treatment"},
+	}
+}
+
+// CropInputSchedule is a planned application of a material on a crop.
+// A task is created when the planned date arrives, and the schedule is applied when the task is completed.
+type CropInputSchedule struct {
+	UID               uuid.UUID  `json:"uid"`
+	CropID            uuid.UUID  `json:"crop_id"`
+	MaterialID        uuid.UUID  `json:"material_id"`
+	PlannedDate       time.Time  `json:"planned_date"`
+	Quantity          float64    `json:"quantity"`
+	Unit              string     `json:"unit"`
+	ApplicationMethod string     `json:"application_method"`
+	Status            string     `json:"status"`
+	TaskID            *uuid.UUID `json:"task_id"`
+	AppliedDate       *time.Time `json:"applied_date"`
+	CreatedDate       time.Time  `json:"created_date"`
+
+	// Events
+	Version            int
+	UncommittedChanges []interface{}
+}
+
+func CreateCropInputSchedule(
+	cropService CropService,
+	crop Crop,
+	materialID uuid.UUID,
+	plannedDate time.Time,
+	quantity float64,
+	unit, applicationMethod string,
+) (*CropInputSchedule, error) {
+	if crop.Status.Code == CropArchived {
+		return nil, CropError{Code: CropInputScheduleErrorCropArchived}
+	}
+
+	err := validateInputSchedule(cropService, materialID, plannedDate, quantity, unit, applicationMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	initial := &CropInputSchedule{}
+
+	initial.TrackChange(InputScheduleCreated{
+		UID:               uid,
+		CropID:            crop.UID,
+		MaterialID:        materialID,
+		PlannedDate:       plannedDate,
+		Quantity:          quantity,
+		Unit:              unit,
+		ApplicationMethod: applicationMethod,
+		Status:            InputScheduleStatusPlanned,
+		CreatedDate:       time.Now(),
+	})
+
+	return initial, nil
+}
+
+// Modify replans the application. It's only possible until its task is created.
+func (s *CropInputSchedule) Modify(
+	cropService CropService,
+	materialID uuid.UUID,
+	plannedDate time.Time,
+	quantity float64,
+	unit, applicationMethod string,
+) error {
+	if s.Status != InputScheduleStatusPlanned {
+		return CropError{Code: CropInputScheduleErrorAlreadyScheduled}
+	}
+
+	err := validateInputSchedule(cropService, materialID, plannedDate, quantity, unit, applicationMethod)
+	if err != nil {
+		return err
+	}
+
+	s.TrackChange(InputScheduleModified{
+		UID:               s.UID,
+		CropID:            s.CropID,
+		MaterialID:        materialID,
+		PlannedDate:       plannedDate,
+		Quantity:          quantity,
+		Unit:              unit,
+		ApplicationMethod: applicationMethod,
+	})
+
+	return nil
+}
+
+// IsDue tells whether the task of the schedule has to be created.
+func (s CropInputSchedule) IsDue(now time.Time) bool {
+	return s.Status == InputScheduleStatusPlanned && !s.PlannedDate.After(now)
+}
+
+func (s *CropInputSchedule) AttachTask(taskID uuid.UUID) error {
+	if s.Status != InputScheduleStatusPlanned {
+		return CropError{Code: CropInputScheduleErrorAlreadyScheduled}
+	}
+
+	s.TrackChange(InputScheduleTaskCreated{
+		UID:    s.UID,
+		CropID: s.CropID,
+		TaskID: taskID,
+		Status: InputScheduleStatusTaskCreated,
+	})
+
+	return nil
+}
+
+func (s *CropInputSchedule) MarkApplied(appliedDate time.Time) error {
+	if s.Status != InputScheduleStatusTaskCreated || s.TaskID == nil {
+		return CropError{Code: CropInputScheduleErrorNotFound}
+	}
+
+	s.TrackChange(InputScheduleApplied{
+		UID:         s.UID,
+		CropID:      s.CropID,
+		TaskID:      *s.TaskID,
+		Status:      InputScheduleStatusApplied,
+		AppliedDate: appliedDate,
+	})
+
+	return nil
+}
+
+// Event Tracking.
+func (s *CropInputSchedule) TrackChange(event interface{}) {
+	s.UncommittedChanges = append(s.UncommittedChanges, event)
+	s.Transition(event)
+}
+
+func (s *CropInputSchedule) Transition(event interface{}) {
+	switch e := event.(type) {
+	case InputScheduleCreated:
+		s.UID = e.UID
+		s.CropID = e.CropID
+		s.MaterialID = e.MaterialID
+		s.PlannedDate = e.PlannedDate
+		s.Quantity = e.Quantity
+		s.Unit = e.Unit
+		s.ApplicationMethod = e.ApplicationMethod
+		s.Status = e.Status
+		s.CreatedDate = e.CreatedDate
+	case InputScheduleModified:
+		s.MaterialID = e.MaterialID
+		s.PlannedDate = e.PlannedDate
+		s.Quantity = e.Quantity
+		s.Unit = e.Unit
+		s.ApplicationMethod = e.ApplicationMethod
+	case InputScheduleTaskCreated:
+		taskID := e.TaskID
+		s.TaskID = &taskID
+		s.Status = e.Status
+	case InputScheduleApplied:
+		appliedDate := e.AppliedDate
+		s.AppliedDate = &appliedDate
+		s.Status = e.Status
+	}
+}
+
+func validateInputSchedule(
+	cropService CropService,
+	materialID uuid.UUID,
+	plannedDate time.Time,
+	quantity float64,
+	unit, applicationMethod string,
+) error {
+	serviceResult := cropService.FindMaterialByID(materialID)
+	if serviceResult.Error != nil {
+		return serviceResult.Error
+	}
+
+	year, month, day := time.Now().Date()
+	if plannedDate.Before(time.Date(year, month, day, 0, 0, 0, 0, plannedDate.Location())) {
+		return CropError{Code: CropInputScheduleErrorInvalidPlannedDate}
+	}
+
+	if quantity <= 0 {
+		return CropError{Code: CropInputScheduleErrorInvalidQuantity}
+	}
+
+	if unit == "" {
+		return CropError{Code: CropInputScheduleErrorInvalidUnit}
+	}
+
+	for _, v := range FindAllApplicationMethods() {
+		if v.Code == applicationMethod {
+			return nil
+		}
+	}
+
+	return CropError{Code: CropInputScheduleErrorInvalidApplicationMethod}
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type InputScheduleCreated struct {
+	UID               uuid.UUID
+	CropID            uuid.UUID
+	MaterialID        uuid.UUID
+	PlannedDate       time.Time
+	Quantity          float64
+	Unit              string
+	ApplicationMethod string
+	Status            string
+	CreatedDate       time.Time
+}
+
+type InputScheduleModified struct {
+	UID               uuid.UUID
+	CropID            uuid.UUID
+	MaterialID        uuid.UUID
+	PlannedDate       time.Time
+	Quantity          float64
+	Unit              string
+	ApplicationMethod string
+}
+
+type InputScheduleTaskCreated struct {
+	UID    uuid.UUID
+	CropID uuid.UUID
+	TaskID uuid.UUID
+	Status string
+}
+
+type InputScheduleApplied struct {
+	UID         uuid.UUID
+	CropID      uuid.UUID
+	TaskID      uuid.UUID
+	Status      string
+	AppliedDate time.Time
+}
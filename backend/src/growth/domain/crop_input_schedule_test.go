@@ -0,0 +1,87 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+)
+
+func TestCropInputScheduleLifecycle(t *testing.T) {
+	t.Parallel()
+	// Given
+	cropServiceMock := new(CropServiceMock)
+
+	materialUID, _ := uuid.NewV4()
+	cropServiceMock.On("FindMaterialByID", materialUID).Return(ServiceResult{
+		Result: query.CropMaterialQueryResult{UID: materialUID, Name: "NPK 16-16-16"},
+	})
+
+	cropUID, _ := uuid.NewV4()
+	crop := Crop{UID: cropUID, Status: GetCropStatus(CropActive)}
+
+	taskUID, _ := uuid.NewV4()
+	plannedDate := time.Now().AddDate(0, 0, 7)
+
+	// When
+	schedule, err := CreateCropInputSchedule(
+		cropServiceMock, crop, materialUID, plannedDate, 2.5, "KG", ApplicationMethodSideDressing)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, cropUID, schedule.CropID)
+	assert.Equal(t, InputScheduleStatusPlanned, schedule.Status)
+	assert.False(t, schedule.IsDue(time.Now()))
+	assert.True(t, schedule.IsDue(plannedDate))
+
+	// When
+	err = schedule.AttachTask(taskUID)
+	modifyErr := schedule.Modify(
+		cropServiceMock, materialUID, plannedDate, 3, "KG", ApplicationMethodSideDressing)
+	appliedErr := schedule.MarkApplied(plannedDate)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, CropError{Code: CropInputScheduleErrorAlreadyScheduled}, modifyErr)
+	assert.Nil(t, appliedErr)
+	assert.Equal(t, InputScheduleStatusApplied, schedule.Status)
+	assert.Equal(t, &taskUID, schedule.TaskID)
+	assert.Len(t, schedule.UncommittedChanges, 3)
+	assert.False(t, schedule.IsDue(plannedDate))
+}
+
+func TestCreateCropInputScheduleValidation(t *testing.T) {
+	t.Parallel()
+	// Given
+	cropServiceMock := new(CropServiceMock)
+
+	materialUID, _ := uuid.NewV4()
+	cropServiceMock.On("FindMaterialByID", materialUID).Return(ServiceResult{
+		Result: query.CropMaterialQueryResult{UID: materialUID},
+	})
+
+	cropUID, _ := uuid.NewV4()
+	crop := Crop{UID: cropUID, Status: GetCropStatus(CropActive)}
+	archivedCrop := Crop{UID: cropUID, Status: GetCropStatus(CropArchived)}
+
+	tomorrow := time.Now().AddDate(0, 0, 1)
+
+	// When
+	_, archivedErr := CreateCropInputSchedule(
+		cropServiceMock, archivedCrop, materialUID, tomorrow, 1, "L", ApplicationMethodFoliarSpray)
+	_, pastErr := CreateCropInputSchedule(
+		cropServiceMock, crop, materialUID, time.Now().AddDate(0, 0, -2), 1, "L", ApplicationMethodFoliarSpray)
+	_, quantityErr := CreateCropInputSchedule(
+		cropServiceMock, crop, materialUID, tomorrow, 0, "L", ApplicationMethodFoliarSpray)
+	_, methodErr := CreateCropInputSchedule(
+		cropServiceMock, crop, materialUID, tomorrow, 1, "L", "WATERING_CAN")
+
+	// Then
+	assert.Equal(t, CropError{Code: CropInputScheduleErrorCropArchived}, archivedErr)
+	assert.Equal(t, CropError{Code: CropInputScheduleErrorInvalidPlannedDate}, pastErr)
+	assert.Equal(t, CropError{Code: CropInputScheduleErrorInvalidQuantity}, quantityErr)
+	assert.Equal(t, CropError{Code: CropInputScheduleErrorInvalidApplicationMethod}, methodErr)
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// CurrentArea returns the area holding the plants left of the crop and their quantity,
+// false when they are spread over more than one area or none is left.
+func (c Crop) CurrentArea() (uuid.UUID, int, bool) {
+	areaUID := uuid.UUID{}
+	quantity := 0
+	areas := 0
+
+	if c.InitialArea.CurrentQuantity > 0 {
+		areaUID = c.InitialArea.AreaUID
+		quantity = c.InitialArea.CurrentQuantity
+		areas++
+	}
+
+	for _, v := range c.MovedArea {
+		if v.CurrentQuantity > 0 {
+			areaUID = v.AreaUID
+			quantity = v.CurrentQuantity
+			areas++
+		}
+	}
+
+	if areas != 1 {
+		return uuid.UUID{}, 0, false
+	}
+
+	return areaUID, quantity, true
+}
+
+// MergeFrom consolidates the plants left of the source batch onto the crop. Both have to be of the same variety,
+// in the same farm and in the same area. The plants are added to the current quantity of the crop only,
+// its initial quantity stays the plants it was seeded with so they are not counted twice. The source batch is
+// archived and points at the crop.
+func (c *Crop) MergeFrom(source *Crop, mergedDate time.Time) error {
+	// Validate //
+	if c.UID == source.UID {
+		return CropError{Code: CropMergeErrorSameCrop}
+	}
+
+	if c.Status.Code == CropArchived || source.Status.Code == CropArchived {
+		return CropError{Code: CropMergeErrorCropArchived}
+	}
+
+	if c.InventoryUID != source.InventoryUID {
+		return CropError{Code: CropMergeErrorDifferentVariety}
+	}
+
+	if c.FarmUID != source.FarmUID {
+		return CropError{Code: CropMergeErrorDifferentFarm}
+	}
+
+	areaUID, _, ok := c.CurrentArea()
+	if !ok {
+		return CropError{Code: CropMergeErrorNotInSingleArea}
+	}
+
+	sourceAreaUID, quantity, ok := source.CurrentArea()
+	if !ok {
+		return CropError{Code: CropMergeErrorNotInSingleArea}
+	}
+
+	if areaUID != sourceAreaUID {
+		return CropError{Code: CropMergeErrorDifferentArea}
+	}
+
+	// Process //
+	source.TrackChange(CropMergedInto{
+		UID:           source.UID,
+		TargetCropUID: c.UID,
+		TargetBatchID: c.BatchID,
+		AreaUID:       areaUID,
+		Quantity:      quantity,
+		MergedDate:    mergedDate,
+	})
+
+	c.TrackChange(CropMergedFrom{
+		UID:           c.UID,
+		SourceCropUID: source.UID,
+		SourceBatchID: source.BatchID,
+		AreaUID:       areaUID,
+		Quantity:      quantity,
+		MergedDate:    mergedDate,
+	})
+
+	return nil
+}
+
+// changeAreaQuantity adds the quantity to the current quantity of the area of the crop.
+func (c *Crop) changeAreaQuantity(areaUID uuid.UUID, quantity int, date time.Time) {
+	if c.InitialArea.AreaUID == areaUID {
+		c.InitialArea.CurrentQuantity += quantity
+		c.InitialArea.LastUpdated = date
+
+		return
+	}
+
+	for i, v := range c.MovedArea {
+		if v.AreaUID == areaUID {
+			c.MovedArea[i].CurrentQuantity += quantity
+			c.MovedArea[i].LastUpdated = date
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/growth/domain"
+)
+
+func TestCropMergeFrom(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	otherFarmUID, _ := uuid.NewV4()
+	varietyUID, _ := uuid.NewV4()
+	otherVarietyUID, _ := uuid.NewV4()
+	seedingAreaUID, _ := uuid.NewV4()
+	growingAreaUID, _ := uuid.NewV4()
+	mergedDate := time.Date(2026, time.October, 14, 8, 0, 0, 0, time.UTC)
+
+	newCrop := func(inventoryUID, farmUID uuid.UUID, initialQuantity, movedQuantity int) *Crop {
+		uid, _ := uuid.NewV4()
+
+		return &Crop{
+			UID:          uid,
+			BatchID:      "bat-" + uid.String()[:4],
+			Status:       GetCropStatus(CropActive),
+			InventoryUID: inventoryUID,
+			FarmUID:      farmUID,
+			InitialArea: InitialArea{
+				AreaUID:         seedingAreaUID,
+				InitialQuantity: 20,
+				CurrentQuantity: initialQuantity,
+			},
+			MovedArea: []MovedArea{{
+				AreaUID:         growingAreaUID,
+				InitialQuantity: 20 - initialQuantity,
+				CurrentQuantity: movedQuantity,
+			}},
+		}
+	}
+
+	target := newCrop(varietyUID, farmUID, 0, 12)
+	source := newCrop(varietyUID, farmUID, 0, 5)
+	seeding := newCrop(varietyUID, farmUID, 8, 0)
+	spread := newCrop(varietyUID, farmUID, 3, 4)
+	otherVariety := newCrop(otherVarietyUID, farmUID, 0, 5)
+	otherFarm := newCrop(varietyUID, otherFarmUID, 0, 5)
+
+	// When
+	errSame := target.MergeFrom(target, mergedDate)
+	errVariety := target.MergeFrom(otherVariety, mergedDate)
+	errFarm := target.MergeFrom(otherFarm, mergedDate)
+	errArea := target.MergeFrom(seeding, mergedDate)
+	errSpread := target.MergeFrom(spread, mergedDate)
+	err := target.MergeFrom(source, mergedDate)
+	errArchived := target.MergeFrom(source, mergedDate)
+
+	// Then
+	assert.Equal(t, CropError{Code: CropMergeErrorSameCrop}, errSame)
+	assert.Equal(t, CropError{Code: CropMergeErrorDifferentVariety}, errVariety)
+	assert.Equal(t, CropError{Code: CropMergeErrorDifferentFarm}, errFarm)
+	assert.Equal(t, CropError{Code: CropMergeErrorDifferentArea}, errArea)
+	assert.Equal(t, CropError{Code: CropMergeErrorNotInSingleArea}, errSpread)
+	assert.Nil(t, err)
+	assert.Equal(t, CropError{Code: CropMergeErrorCropArchived}, errArchived)
+
+	assert.Equal(t, 17, target.MovedArea[0].CurrentQuantity)
+	assert.Equal(t, 20, target.MovedArea[0].InitialQuantity)
+	assert.Equal(t, 20, target.InitialArea.InitialQuantity)
+	assert.Nil(t, target.MergedIntoUID)
+
+	assert.Equal(t, 0, source.MovedArea[0].CurrentQuantity)
+	assert.Equal(t, CropArchived, source.Status.Code)
+	assert.Equal(t, target.UID, *source.MergedIntoUID)
+
+	merged, ok := source.UncommittedChanges[0].(CropMergedInto)
+	assert.True(t, ok)
+	assert.Equal(t, CropMergedInto{
+		UID:           source.UID,
+		TargetCropUID: target.UID,
+		TargetBatchID: target.BatchID,
+		AreaUID:       growingAreaUID,
+		Quantity:      5,
+		MergedDate:    mergedDate,
+	}, merged)
+
+	mergedFrom, ok := target.UncommittedChanges[0].(CropMergedFrom)
+	assert.True(t, ok)
+	assert.Equal(t, source.UID, mergedFrom.SourceCropUID)
+	assert.Equal(t, 5, mergedFrom.Quantity)
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+)
+
+// CropNurseryStage is a period the crop spends in a nursery area
+// before it is transplanted to the main growing areas.
+type CropNurseryStage struct {
+	UID                    uuid.UUID  `json:"uid"`
+	NurseryAreaUID         uuid.UUID  `json:"nursery_area_id"`
+	StartDate              time.Time  `json:"start_date"`
+	ExpectedTransplantDate time.Time  `json:"expected_transplant_date"`
+	ActualTransplantDate   *time.Time `json:"actual_transplant_date"`
+}
+
+// CurrentNurseryStage returns the nursery stage the crop is in, if any.
+func (c Crop) CurrentNurseryStage() (CropNurseryStage, bool) {
+	if c.Status.Code != CropNursery {
+		return CropNurseryStage{}, false
+	}
+
+	for _, v := range c.NurseryStages {
+		if v.ActualTransplantDate == nil {
+			return v, true
+		}
+	}
+
+	return CropNurseryStage{}, false
+}
+
+func (c *Crop) StartNurseryStage(
+	cropService CropService,
+	nurseryAreaUID uuid.UUID,
+	startDate, expectedTransplantDate time.Time,
+) error {
+	// Validate //
+	if c.Status.Code == CropArchived {
+		return CropError{Code: CropNurseryErrorCropArchived}
+	}
+
+	if c.Status.Code == CropNursery {
+		return CropError{Code: CropNurseryErrorAlreadyStarted}
+	}
+
+	serviceResult := cropService.FindAreaByID(nurseryAreaUID)
+	if serviceResult.Error != nil {
+		return serviceResult.Error
+	}
+
+	area, ok := serviceResult.Result.(query.CropAreaQueryResult)
+	if !ok {
+		return CropError{Code: CropNurseryErrorInvalidArea}
+	}
+
+	if area.UID == (uuid.UUID{}) {
+		return CropError{Code: CropNurseryErrorAreaNotFound}
+	}
+
+	if area.Type != "SEEDING" {
+		return CropError{Code: CropNurseryErrorInvalidAreaType}
+	}
+
+	if !expectedTransplantDate.After(startDate) {
+		return CropError{Code: CropNurseryErrorInvalidExpectedTransplantDate}
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+
+	// Process //
+	c.TrackChange(CropNurseryStageStarted{
+		UID:                    uid,
+		CropID:                 c.UID,
+		NurseryAreaID:          area.UID,
+		StartDate:              startDate,
+		ExpectedTransplantDate: expectedTransplantDate,
+	})
+
+	return nil
+}
+
+func (c *Crop) CompleteNurseryStage(transplantDate time.Time) error {
+	// Validate //
+	stage, ok := c.CurrentNurseryStage()
+	if !ok {
+		return CropError{Code: CropNurseryErrorNotStarted}
+	}
+
+	if transplantDate.Before(stage.StartDate) {
+		return CropError{Code: CropNurseryErrorInvalidTransplantDate}
+	}
+
+	// Process //
+	c.TrackChange(CropNurseryStageCompleted{
+		UID:                    stage.UID,
+		CropID:                 c.UID,
+		NurseryAreaID:          stage.NurseryAreaUID,
+		StartDate:              stage.StartDate,
+		ExpectedTransplantDate: stage.ExpectedTransplantDate,
+		ActualTransplantDate:   &transplantDate,
+	})
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// The ways seeding an area which needs a sanitation is handled.
+const (
+	SanitationCheckWarn  = "warn"
+	SanitationCheckBlock = "block"
+	SanitationCheckOff   = "off"
+)
+
+// AreaNeedsSanitation tells whether the last dump from the area, among the dumps of its crops,
+// was for disease and no sanitation of the area was recorded since. The sanitations are dated by the day,
+// so one on the day of the dump counts.
+func AreaNeedsSanitation(areaUID uuid.UUID, dumps []CropBatchDumped, lastSanitizedDate *time.Time) bool {
+	var last *CropBatchDumped
+
+	for i, v := range dumps {
+		if v.UpdatedTrash.SourceAreaUID != areaUID {
+			continue
+		}
+
+		if last == nil || v.DumpDate.After(last.DumpDate) {
+			last = &dumps[i]
+		}
+	}
+
+	if last == nil || last.Reason != DumpReasonDisease {
+		return false
+	}
+
+	if lastSanitizedDate == nil {
+		return true
+	}
+
+	return lastSanitizedDate.Format("2006-01-02") < last.DumpDate.Format("2006-01-02")
+}
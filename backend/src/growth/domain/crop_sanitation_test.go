@@ -0,0 +1,69 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/growth/domain"
+)
+
+func dumpFrom(areaUID uuid.UUID, date time.Time, reason string) CropBatchDumped {
+	return CropBatchDumped{
+		UpdatedTrash: Trash{SourceAreaUID: areaUID},
+		DumpDate:     date,
+		Reason:       reason,
+	}
+}
+
+func TestAreaNeedsSanitation(t *testing.T) {
+	t.Parallel()
+	// Given
+	areaUID, _ := uuid.NewV4()
+	otherAreaUID, _ := uuid.NewV4()
+	dumpDate := time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC)
+	before := dumpDate.AddDate(0, 0, -2)
+	after := dumpDate.AddDate(0, 0, 2)
+	sameDay := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	diseased := []CropBatchDumped{
+		dumpFrom(areaUID, before, DumpReasonPest),
+		dumpFrom(areaUID, dumpDate, DumpReasonDisease),
+		dumpFrom(otherAreaUID, after, DumpReasonOther),
+	}
+	recovered := append([]CropBatchDumped{}, diseased...)
+	recovered = append(recovered, dumpFrom(areaUID, after, DumpReasonDamage))
+
+	// When
+	neverSanitized := AreaNeedsSanitation(areaUID, diseased, nil)
+	sanitizedBefore := AreaNeedsSanitation(areaUID, diseased, &before)
+	sanitizedAfter := AreaNeedsSanitation(areaUID, diseased, &after)
+	sanitizedSameDay := AreaNeedsSanitation(areaUID, diseased, &sameDay)
+	lastDumpNotDisease := AreaNeedsSanitation(areaUID, recovered, nil)
+	otherArea := AreaNeedsSanitation(otherAreaUID, diseased, nil)
+	noDumps := AreaNeedsSanitation(areaUID, nil, nil)
+
+	// Then
+	assert.True(t, neverSanitized)
+	assert.True(t, sanitizedBefore)
+	assert.False(t, sanitizedAfter)
+	assert.False(t, sanitizedSameDay)
+	assert.False(t, lastDumpNotDisease)
+	assert.False(t, otherArea)
+	assert.False(t, noDumps)
+}
+
+func TestDumpWithInvalidReason(t *testing.T) {
+	t.Parallel()
+	// Given
+	areaUID, _ := uuid.NewV4()
+	crop := &Crop{}
+
+	// When
+	err := crop.Dump(nil, areaUID, 1, "", "FLOOD")
+
+	// Then
+	assert.Equal(t, CropError{Code: CropDumpErrorInvalidReason}, err)
+	assert.Empty(t, crop.UncommittedChanges)
+}
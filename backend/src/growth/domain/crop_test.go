@@ -70,7 +70,7 @@ func TestCreateCropBatch(t *testing.T) {
 	// When
 	crop, _ := CreateCropBatch(cropServiceMock, areaAUID, CropTypeSeeding, inventoryUID, 20, containerType)
 	crop.MoveToArea(cropServiceMock, areaAUID, areaBUID, 15)
-	crop.Dump(cropServiceMock, areaBUID, 5, "Notes")
+	crop.Dump(cropServiceMock, areaBUID, 5, "Notes", "")
 	crop.Fertilize()
 	crop.Pesticide()
 	crop.Prune()
@@ -122,8 +122,8 @@ func TestHarvestCropBatch(t *testing.T) {
 	// When
 	crop, _ := CreateCropBatch(cropServiceMock, areaAUID, CropTypeSeeding, inventoryUID, 20, containerType)
 	crop.MoveToArea(cropServiceMock, areaAUID, areaBUID, 15)
-	err1 := crop.Harvest(cropServiceMock, areaBUID, HarvestTypePartial, 10, GetProducedUnit(Kg), "Notes")
-	err2 := crop.Harvest(cropServiceMock, areaAUID, HarvestTypePartial, 10, GetProducedUnit(Kg), "Notes")
+	err1 := crop.Harvest(cropServiceMock, areaBUID, HarvestTypePartial, 10, GetProducedUnit(Kg), nil, "Notes")
+	err2 := crop.Harvest(cropServiceMock, areaAUID, HarvestTypePartial, 10, GetProducedUnit(Kg), nil, "Notes")
 
 	// Then
 	cropServiceMock.AssertExpectations(t)
@@ -136,7 +136,7 @@ func TestHarvestCropBatch(t *testing.T) {
 	assert.NotNil(t, err2)
 
 	// When
-	crop.Harvest(cropServiceMock, areaBUID, HarvestTypeAll, 2000, GetProducedUnit(Gr), "Notes")
+	crop.Harvest(cropServiceMock, areaBUID, HarvestTypeAll, 2000, GetProducedUnit(Gr), nil, "Notes")
 
 	// Then
 	assert.Equal(t, 0, crop.MovedArea[0].CurrentQuantity)
@@ -144,6 +144,54 @@ func TestHarvestCropBatch(t *testing.T) {
 	assert.Equal(t, float32(12000), crop.HarvestedStorage[0].ProducedGramQuantity)
 }
 
+func TestHarvestCropBatchGrades(t *testing.T) {
+	t.Parallel()
+	// Given
+	cropServiceMock := new(CropServiceMock)
+
+	areaUID, _ := uuid.NewV4()
+	cropServiceMock.On("FindAreaByID", areaUID).Return(ServiceResult{
+		Result: query.CropAreaQueryResult{UID: areaUID, Type: "GROWING"},
+	})
+
+	inventoryUID, _ := uuid.NewV4()
+	cropServiceMock.On("FindMaterialByID", inventoryUID).Return(ServiceResult{
+		Result: query.CropMaterialQueryResult{
+			UID:  inventoryUID,
+			Name: "Tomato Super One",
+		},
+	})
+
+	date := strings.ToLower(time.Now().Format("2Jan"))
+	batchID := fmt.Sprintf("%s%s", "tom-sup-one-", date)
+	cropServiceMock.On("FindByBatchID", batchID).Return(ServiceResult{})
+
+	crop, _ := CreateCropBatch(cropServiceMock, areaUID, CropTypeSeeding, inventoryUID, 20, Tray{Cell: 15})
+
+	// When
+	err := crop.Harvest(cropServiceMock, areaUID, HarvestTypePartial, 1.5, GetProducedUnit(Kg),
+		[]GradeQuantity{{Grade: "A", Quantity: 1.2}, {Grade: "REJECT", Quantity: 0.3}}, "Notes")
+
+	mismatchErr := crop.Harvest(cropServiceMock, areaUID, HarvestTypePartial, 2, GetProducedUnit(Kg),
+		[]GradeQuantity{{Grade: "A", Quantity: 1.5}}, "Notes")
+	duplicateErr := crop.Harvest(cropServiceMock, areaUID, HarvestTypePartial, 2, GetProducedUnit(Kg),
+		[]GradeQuantity{{Grade: "A", Quantity: 1}, {Grade: "A", Quantity: 1}}, "Notes")
+	quantityErr := crop.Harvest(cropServiceMock, areaUID, HarvestTypePartial, 2, GetProducedUnit(Kg),
+		[]GradeQuantity{{Grade: "A", Quantity: 2}, {Grade: "B", Quantity: 0}}, "Notes")
+
+	// Then
+	assert.Nil(t, err)
+
+	event, ok := crop.UncommittedChanges[len(crop.UncommittedChanges)-1].(CropBatchHarvested)
+	assert.True(t, ok)
+	assert.Equal(t, float32(1500), event.ProducedGramQuantity)
+	assert.Equal(t, []HarvestedGrade{{Grade: "A", GramQuantity: 1200}, {Grade: "REJECT", GramQuantity: 300}}, event.Grades)
+
+	assert.Equal(t, CropError{Code: CropHarvestErrorGradeTotalMismatch}, mismatchErr)
+	assert.Equal(t, CropError{Code: CropHarvestErrorDuplicateGrade}, duplicateErr)
+	assert.Equal(t, CropError{Code: CropHarvestErrorInvalidGradeQuantity}, quantityErr)
+}
+
 func TestWaterCrop(t *testing.T) {
 	t.Parallel()
 	// Given
@@ -231,14 +279,14 @@ func TestCropHarvestArchiveStatus(t *testing.T) {
 	// When
 	crop, _ := CreateCropBatch(cropServiceMock, areaAUID, CropTypeSeeding, inventoryUID, 20, containerType)
 	crop.MoveToArea(cropServiceMock, areaAUID, areaBUID, 15)
-	crop.Harvest(cropServiceMock, areaBUID, HarvestTypeAll, 2000, GetProducedUnit(Gr), "Notes")
+	crop.Harvest(cropServiceMock, areaBUID, HarvestTypeAll, 2000, GetProducedUnit(Gr), nil, "Notes")
 
 	// Then
 	assert.Equal(t, crop.Status.Code, CropActive)
 
 	// When
 	crop.MoveToArea(cropServiceMock, areaAUID, areaBUID, 5)
-	crop.Harvest(cropServiceMock, areaBUID, HarvestTypeAll, 3000, GetProducedUnit(Gr), "Notes")
+	crop.Harvest(cropServiceMock, areaBUID, HarvestTypeAll, 3000, GetProducedUnit(Gr), nil, "Notes")
 
 	// Then
 	assert.Equal(t, crop.Status.Code, CropArchived)
@@ -279,14 +327,149 @@ func TestCropDumpArchiveStatus(t *testing.T) {
 	// When
 	crop, _ := CreateCropBatch(cropServiceMock, areaAUID, CropTypeSeeding, inventoryUID, 20, containerType)
 	crop.MoveToArea(cropServiceMock, areaAUID, areaBUID, 15)
-	crop.Dump(cropServiceMock, areaBUID, 15, "Notes")
+	crop.Dump(cropServiceMock, areaBUID, 15, "Notes", "")
 
 	// Then
 	assert.Equal(t, crop.Status.Code, CropActive)
 
 	// When
-	crop.Dump(cropServiceMock, areaAUID, 5, "Notes")
+	crop.Dump(cropServiceMock, areaAUID, 5, "Notes", "")
 
 	// Then
 	assert.Equal(t, crop.Status.Code, CropArchived)
 }
+
+func TestCropPhotoProcessing(t *testing.T) {
+	t.Parallel()
+	// Given
+	cropUID, _ := uuid.NewV4()
+	crop := &Crop{UID: cropUID}
+
+	// When
+	photoUID, errAdd := crop.AddPendingPhoto("photo.jpg", "image/jpeg", 1024, "Leaves", CropPhotoMetadata{})
+	errFailed := crop.MarkPhotoProcessingFailed(photoUID)
+	photoFailed, _ := crop.FindPhotoByID(photoUID)
+	errRetry := crop.RetryPhotoProcessing(photoUID)
+	photoRetried, _ := crop.FindPhotoByID(photoUID)
+	errProcessed := crop.MarkPhotoProcessed(photoUID, "thumbnails/photo.jpg", 640, 480)
+	photoReady, _ := crop.FindPhotoByID(photoUID)
+	errRetryReady := crop.RetryPhotoProcessing(photoUID)
+
+	// Then
+	assert.Nil(t, errAdd)
+	assert.Nil(t, errFailed)
+	assert.Nil(t, errRetry)
+	assert.Nil(t, errProcessed)
+	assert.Equal(t, CropPhotoStatusFailed, photoFailed.Status)
+	assert.Equal(t, CropPhotoStatusPending, photoRetried.Status)
+	assert.Equal(t, CropPhotoStatusReady, photoReady.Status)
+	assert.Equal(t, "thumbnails/photo.jpg", photoReady.ThumbnailFilename)
+	assert.Equal(t, 640, photoReady.Width)
+	assert.Equal(t, CropError{CropErrorPhotoAlreadyProcessed}, errRetryReady)
+}
+
+func TestCropPhotoMetadata(t *testing.T) {
+	t.Parallel()
+	// Given
+	cropUID, _ := uuid.NewV4()
+	crop := &Crop{UID: cropUID}
+
+	dateTaken := time.Date(2026, time.October, 12, 7, 30, 0, 0, time.UTC)
+	lat, lon := -6.2, 106.8
+	metadata := CropPhotoMetadata{DateTaken: &dateTaken, Make: "Canon", Model: "EOS 80D", GPSLat: &lat, GPSLon: &lon}
+
+	// When
+	err := crop.AddPhoto("photo.jpg", "image/jpeg", 1024, 640, 480, "Leaves", metadata)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Len(t, crop.Photos, 1)
+	assert.Equal(t, metadata, crop.Photos[0].Metadata)
+	assert.Equal(t, metadata, crop.UncommittedChanges[0].(CropBatchPhotoCreated).Metadata)
+}
+
+func TestCropRemovePhoto(t *testing.T) {
+	t.Parallel()
+	// Given
+	cropUID, _ := uuid.NewV4()
+	crop := &Crop{UID: cropUID}
+
+	photoUID, _ := crop.AddPendingPhoto("photo.jpg", "image/jpeg", 1024, "Leaves", CropPhotoMetadata{})
+	_ = crop.MarkPhotoProcessed(photoUID, "thumbnails/photo.jpg", 640, 480)
+	_ = crop.AddPhoto("other.jpg", "image/jpeg", 1024, 640, 480, "Fruits", CropPhotoMetadata{})
+
+	// When
+	err := crop.RemovePhoto(photoUID)
+	errAgain := crop.RemovePhoto(photoUID)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, CropError{CropErrorPhotoNotFound}, errAgain)
+	assert.Len(t, crop.Photos, 1)
+	assert.Equal(t, "other.jpg", crop.Photos[0].Filename)
+
+	removed := crop.UncommittedChanges[len(crop.UncommittedChanges)-1].(CropBatchPhotoRemoved)
+	assert.Equal(t, "photo.jpg", removed.Filename)
+	assert.Equal(t, "thumbnails/photo.jpg", removed.ThumbnailFilename)
+}
+
+func TestCropNurseryStage(t *testing.T) {
+	t.Parallel()
+	// Given
+	cropServiceMock := new(CropServiceMock)
+
+	seedingAreaUID, _ := uuid.NewV4()
+	growingAreaUID, _ := uuid.NewV4()
+	cropServiceMock.On("FindAreaByID", seedingAreaUID).Return(ServiceResult{
+		Result: query.CropAreaQueryResult{UID: seedingAreaUID, Type: "SEEDING"},
+	})
+	cropServiceMock.On("FindAreaByID", growingAreaUID).Return(ServiceResult{
+		Result: query.CropAreaQueryResult{UID: growingAreaUID, Type: "GROWING"},
+	})
+
+	cropUID, _ := uuid.NewV4()
+	crop := &Crop{UID: cropUID, Status: GetCropStatus(CropActive)}
+
+	startDate := time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)
+	expectedDate := startDate.AddDate(0, 0, 14)
+	transplantDate := startDate.AddDate(0, 0, 15)
+
+	// When
+	errGrowingArea := crop.StartNurseryStage(cropServiceMock, growingAreaUID, startDate, expectedDate)
+	errInvalidDate := crop.StartNurseryStage(cropServiceMock, seedingAreaUID, startDate, startDate)
+	errStart := crop.StartNurseryStage(cropServiceMock, seedingAreaUID, startDate, expectedDate)
+	statusInNursery := crop.Status.Code
+	errStartAgain := crop.StartNurseryStage(cropServiceMock, seedingAreaUID, startDate, expectedDate)
+	errComplete := crop.CompleteNurseryStage(transplantDate)
+	errCompleteAgain := crop.CompleteNurseryStage(transplantDate)
+
+	// Then
+	assert.Equal(t, CropError{Code: CropNurseryErrorInvalidAreaType}, errGrowingArea)
+	assert.Equal(t, CropError{Code: CropNurseryErrorInvalidExpectedTransplantDate}, errInvalidDate)
+	assert.Nil(t, errStart)
+	assert.Equal(t, CropNursery, statusInNursery)
+	assert.Equal(t, CropError{Code: CropNurseryErrorAlreadyStarted}, errStartAgain)
+	assert.Nil(t, errComplete)
+	assert.Equal(t, CropError{Code: CropNurseryErrorNotStarted}, errCompleteAgain)
+	assert.Equal(t, CropActive, crop.Status.Code)
+	assert.Len(t, crop.NurseryStages, 1)
+	assert.Equal(t, transplantDate, *crop.NurseryStages[0].ActualTransplantDate)
+}
+
+func TestCropAssignShortCode(t *testing.T) {
+	t.Parallel()
+	// Given
+	cropUID, _ := uuid.NewV4()
+	crop := &Crop{UID: cropUID}
+
+	// When
+	errEmpty := crop.AssignShortCode("")
+	errAssign := crop.AssignShortCode("C-387")
+	errReassign := crop.AssignShortCode("C-388")
+
+	// Then
+	assert.Equal(t, CropError{Code: CropErrorInvalidShortCode}, errEmpty)
+	assert.Nil(t, errAssign)
+	assert.Equal(t, CropError{Code: CropErrorShortCodeAlreadyAssigned}, errReassign)
+	assert.Equal(t, "C-387", crop.ShortCode)
+}
@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// CropTransfer is the plants of the crop leaving its farm for another one, with the certificate they travel with.
+type CropTransfer struct {
+	UID               uuid.UUID `json:"uid"`
+	FromFarmID        uuid.UUID `json:"from_farm_id"`
+	ToFarmID          uuid.UUID `json:"to_farm_id"`
+	TransferDate      time.Time `json:"transfer_date"`
+	TransferredBy     uuid.UUID `json:"transferred_by"`
+	RecipientName     string    `json:"recipient_name"`
+	RecipientContact  string    `json:"recipient_contact"`
+	CertificateNumber string    `json:"certificate_number"`
+}
+
+// The kinds of the steps of the traceability chain of a batch.
+const (
+	TraceabilitySeeded      = "SEEDED"
+	TraceabilityMoved       = "MOVED"
+	TraceabilityMergedFrom  = "MERGED_FROM"
+	TraceabilityHarvested   = "HARVESTED"
+	TraceabilityDumped      = "DUMPED"
+	TraceabilityTransferred = "TRANSFERRED"
+)
+
+// TraceabilityStep is a step of the history of a batch, from the material it was sown from to its transfers.
+// Only the fields of its kind are set.
+type TraceabilityStep struct {
+	Kind         string
+	Date         time.Time
+	AreaUID      uuid.UUID
+	DstAreaUID   uuid.UUID
+	Quantity     int
+	GramQuantity float32
+	InventoryUID uuid.UUID
+
+	// The batch merged into the crop, for a MERGED_FROM step.
+	SourceCropUID uuid.UUID
+	SourceBatchID string
+
+	// The farm and the certificate of a former transfer, for a TRANSFERRED step.
+	ToFarmID          uuid.UUID
+	CertificateNumber string
+}
+
+// FindTransfer returns the transfer of the crop, false when the crop has no such transfer.
+func (c Crop) FindTransfer(transferUID uuid.UUID) (CropTransfer, bool) {
+	for _, v := range c.Transfers {
+		if v.UID == transferUID {
+			return v, true
+		}
+	}
+
+	return CropTransfer{}, false
+}
+
+// InitiateTransfer records the plants of the crop leaving for another farm, to the recipient. The certificate
+// number is the one printed on the transfer certificate, it can't be used twice for the crop.
+// The crop stays in its areas, the receiving farm records the plants it gets.
+func (c *Crop) InitiateTransfer(
+	toFarmUID uuid.UUID,
+	transferDate time.Time,
+	transferredBy uuid.UUID,
+	recipientName, recipientContact, certificateNumber string,
+) error {
+	recipientName = strings.TrimSpace(recipientName)
+	recipientContact = strings.TrimSpace(recipientContact)
+	certificateNumber = strings.TrimSpace(certificateNumber)
+
+	// Validate //
+	if c.MergedIntoUID != nil {
+		return CropError{Code: CropTransferErrorCropMerged}
+	}
+
+	if toFarmUID == (uuid.UUID{}) || toFarmUID == c.FarmUID {
+		return CropError{Code: CropTransferErrorInvalidDestinationFarm}
+	}
+
+	// The transfer date is a day, the crop can leave on the day it was created.
+	year, month, day := c.InitialArea.CreatedDate.Date()
+	createdDay := time.Date(year, month, day, 0, 0, 0, 0, c.InitialArea.CreatedDate.Location())
+
+	if transferDate.IsZero() || transferDate.Before(createdDay) {
+		return CropError{Code: CropTransferErrorInvalidDate}
+	}
+
+	if recipientName == "" {
+		return CropError{Code: CropTransferErrorRecipientNameEmpty}
+	}
+
+	if recipientContact == "" {
+		return CropError{Code: CropTransferErrorRecipientContactEmpty}
+	}
+
+	if certificateNumber == "" {
+		return CropError{Code: CropTransferErrorCertificateNumberEmpty}
+	}
+
+	for _, v := range c.Transfers {
+		if v.CertificateNumber == certificateNumber {
+			return CropError{Code: CropTransferErrorCertificateNumberUsed}
+		}
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+
+	// Process //
+	c.TrackChange(CropTransferInitiated{
+		TransferID:        uid,
+		CropID:            c.UID,
+		FromFarmID:        c.FarmUID,
+		ToFarmID:          toFarmUID,
+		TransferDate:      transferDate,
+		TransferredBy:     transferredBy,
+		RecipientName:     recipientName,
+		RecipientContact:  recipientContact,
+		CertificateNumber: certificateNumber,
+	})
+
+	return nil
+}
+
+// CropTraceability lists the steps of the batch in the events of its history until the transfer, the transfer
+// excluded, so the chain of a certificate doesn't change with what happens to the batch after it.
+func CropTraceability(events []interface{}, transferUID uuid.UUID) []TraceabilityStep {
+	steps := []TraceabilityStep{}
+
+	for _, event := range events {
+		switch e := event.(type) {
+		case CropBatchCreated:
+			steps = append(steps, TraceabilityStep{
+				Kind:         TraceabilitySeeded,
+				Date:         e.CreatedDate,
+				AreaUID:      e.InitialAreaUID,
+				Quantity:     e.Quantity,
+				InventoryUID: e.InventoryUID,
+			})
+
+		case CropBatchInventoryChanged:
+			// The material of a batch is only changed to correct it, so the batch was sown from the new one.
+			for i, v := range steps {
+				if v.Kind == TraceabilitySeeded {
+					steps[i].InventoryUID = e.InventoryUID
+				}
+			}
+
+		case CropBatchMoved:
+			steps = append(steps, TraceabilityStep{
+				Kind:       TraceabilityMoved,
+				Date:       e.MovedDate,
+				AreaUID:    e.SrcAreaUID,
+				DstAreaUID: e.DstAreaUID,
+				Quantity:   e.Quantity,
+			})
+
+		case CropMergedFrom:
+			steps = append(steps, TraceabilityStep{
+				Kind:          TraceabilityMergedFrom,
+				Date:          e.MergedDate,
+				AreaUID:       e.AreaUID,
+				Quantity:      e.Quantity,
+				SourceCropUID: e.SourceCropUID,
+				SourceBatchID: e.SourceBatchID,
+			})
+
+		case CropBatchHarvested:
+			steps = append(steps, TraceabilityStep{
+				Kind:         TraceabilityHarvested,
+				Date:         e.HarvestDate,
+				AreaUID:      e.UpdatedHarvestedStorage.SourceAreaUID,
+				Quantity:     e.HarvestedQuantity,
+				GramQuantity: e.ProducedGramQuantity,
+			})
+
+		case CropBatchDumped:
+			steps = append(steps, TraceabilityStep{
+				Kind:     TraceabilityDumped,
+				Date:     e.DumpDate,
+				AreaUID:  e.UpdatedTrash.SourceAreaUID,
+				Quantity: e.Quantity,
+			})
+
+		case CropTransferInitiated:
+			if e.TransferID == transferUID {
+				return steps
+			}
+
+			steps = append(steps, TraceabilityStep{
+				Kind:              TraceabilityTransferred,
+				Date:              e.TransferDate,
+				ToFarmID:          e.ToFarmID,
+				CertificateNumber: e.CertificateNumber,
+			})
+		}
+	}
+
+	return steps
+}
@@ -0,0 +1,100 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/growth/domain"
+)
+
+func TestCropInitiateTransfer(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	toFarmUID, _ := uuid.NewV4()
+	userUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	createdDate := time.Date(2026, time.October, 14, 15, 0, 0, 0, time.UTC)
+	transferDate := time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC)
+
+	crop := &Crop{
+		UID:         cropUID,
+		FarmUID:     farmUID,
+		Status:      GetCropStatus(CropActive),
+		InitialArea: InitialArea{CreatedDate: createdDate, InitialQuantity: 20, CurrentQuantity: 20},
+	}
+
+	// When
+	errFarm := crop.InitiateTransfer(farmUID, transferDate, userUID, "Green Co", "+33 1 23 45 67 89", "TC-1")
+	errDate := crop.InitiateTransfer(toFarmUID, transferDate.AddDate(0, 0, -1), userUID, "Green Co", "x", "TC-1")
+	errRecipient := crop.InitiateTransfer(toFarmUID, transferDate, userUID, " ", "+33 1 23 45 67 89", "TC-1")
+	err := crop.InitiateTransfer(toFarmUID, transferDate, userUID, "Green Co", "+33 1 23 45 67 89", " TC-1 ")
+	errUsed := crop.InitiateTransfer(toFarmUID, transferDate, userUID, "Green Co", "+33 1 23 45 67 89", "TC-1")
+
+	// Then
+	assert.Equal(t, CropError{Code: CropTransferErrorInvalidDestinationFarm}, errFarm)
+	assert.Equal(t, CropError{Code: CropTransferErrorInvalidDate}, errDate)
+	assert.Equal(t, CropError{Code: CropTransferErrorRecipientNameEmpty}, errRecipient)
+	assert.Nil(t, err)
+	assert.Equal(t, CropError{Code: CropTransferErrorCertificateNumberUsed}, errUsed)
+
+	assert.Len(t, crop.UncommittedChanges, 1)
+	assert.Len(t, crop.Transfers, 1)
+
+	transfer, found := crop.FindTransfer(crop.Transfers[0].UID)
+
+	assert.True(t, found)
+	assert.Equal(t, farmUID, transfer.FromFarmID)
+	assert.Equal(t, toFarmUID, transfer.ToFarmID)
+	assert.Equal(t, userUID, transfer.TransferredBy)
+	assert.Equal(t, "TC-1", transfer.CertificateNumber)
+}
+
+func TestCropTraceabilityUntilTheTransfer(t *testing.T) {
+	t.Parallel()
+	// Given
+	cropUID, _ := uuid.NewV4()
+	sourceCropUID, _ := uuid.NewV4()
+	seedingAreaUID, _ := uuid.NewV4()
+	growingAreaUID, _ := uuid.NewV4()
+	materialUID, _ := uuid.NewV4()
+	correctedMaterialUID, _ := uuid.NewV4()
+	transferUID, _ := uuid.NewV4()
+	date := time.Date(2026, time.September, 1, 8, 0, 0, 0, time.UTC)
+
+	events := []interface{}{
+		CropBatchCreated{
+			UID: cropUID, InventoryUID: materialUID, InitialAreaUID: seedingAreaUID, Quantity: 20, CreatedDate: date,
+		},
+		CropBatchInventoryChanged{UID: cropUID, InventoryUID: correctedMaterialUID},
+		CropBatchMoved{
+			UID: cropUID, SrcAreaUID: seedingAreaUID, DstAreaUID: growingAreaUID, Quantity: 20,
+			MovedDate: date.AddDate(0, 0, 10),
+		},
+		CropMergedFrom{
+			UID: cropUID, SourceCropUID: sourceCropUID, SourceBatchID: "tom-2", AreaUID: growingAreaUID, Quantity: 5,
+			MergedDate: date.AddDate(0, 0, 12),
+		},
+		CropBatchWatered{UID: cropUID},
+		CropTransferInitiated{TransferID: transferUID, CropID: cropUID, TransferDate: date.AddDate(0, 0, 20)},
+		CropBatchDumped{UID: cropUID, Quantity: 2, DumpDate: date.AddDate(0, 0, 25)},
+	}
+
+	// When
+	steps := CropTraceability(events, transferUID)
+
+	// Then
+	assert.Equal(t, []TraceabilityStep{
+		{Kind: TraceabilitySeeded, Date: date, AreaUID: seedingAreaUID, Quantity: 20, InventoryUID: correctedMaterialUID},
+		{
+			Kind: TraceabilityMoved, Date: date.AddDate(0, 0, 10), AreaUID: seedingAreaUID, DstAreaUID: growingAreaUID,
+			Quantity: 20,
+		},
+		{
+			Kind: TraceabilityMergedFrom, Date: date.AddDate(0, 0, 12), AreaUID: growingAreaUID, Quantity: 5,
+			SourceCropUID: sourceCropUID, SourceBatchID: "tom-2",
+		},
+	}, steps)
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// PesticideApplication is a treatment of the batch with a material which has a pre-harvest interval.
+type PesticideApplication struct {
+	MaterialUID            uuid.UUID
+	AppliedDate            time.Time
+	PreHarvestIntervalDays int
+}
+
+// SafeHarvestDate returns the day the withholding period of the latest ending application is over,
+// nil when none of the applications hold the batch back.
+func SafeHarvestDate(applications []PesticideApplication) *time.Time {
+	var safeDate *time.Time
+
+	for _, v := range applications {
+		if v.PreHarvestIntervalDays <= 0 {
+			continue
+		}
+
+		date := v.AppliedDate.AddDate(0, 0, v.PreHarvestIntervalDays)
+		if safeDate == nil || date.After(*safeDate) {
+			safeDate = &date
+		}
+	}
+
+	return safeDate
+}
+
+// OverrideWithholding records who let the batch be harvested before its safe harvest date, and why.
+func (c *Crop) OverrideWithholding(safeHarvestDate time.Time, reason string, overriddenBy uuid.UUID) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return CropError{Code: CropHarvestErrorWithholdingOverrideReasonRequired}
+	}
+
+	c.TrackChange(CropBatchWithholdingOverridden{
+		UID:             c.UID,
+		BatchID:         c.BatchID,
+		SafeHarvestDate: safeHarvestDate,
+		Reason:          reason,
+		OverriddenBy:    overriddenBy,
+		OverriddenDate:  time.Now(),
+	})
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/growth/domain"
+)
+
+func TestSafeHarvestDate(t *testing.T) {
+	t.Parallel()
+	// Given
+	sprayUID, _ := uuid.NewV4()
+	soapUID, _ := uuid.NewV4()
+	appliedDate := time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC)
+
+	// When
+	safeDate := SafeHarvestDate([]PesticideApplication{
+		{MaterialUID: sprayUID, AppliedDate: appliedDate, PreHarvestIntervalDays: 7},
+		{MaterialUID: soapUID, AppliedDate: appliedDate.AddDate(0, 0, 3), PreHarvestIntervalDays: 1},
+		{MaterialUID: sprayUID, AppliedDate: appliedDate.AddDate(0, 0, -10), PreHarvestIntervalDays: 14},
+	})
+	noIntervalDate := SafeHarvestDate([]PesticideApplication{{MaterialUID: soapUID, AppliedDate: appliedDate}})
+
+	// Then
+	assert.Equal(t, time.Date(2026, time.October, 8, 9, 0, 0, 0, time.UTC), *safeDate)
+	assert.Nil(t, noIntervalDate)
+	assert.Nil(t, SafeHarvestDate(nil))
+}
+
+func TestCropOverrideWithholding(t *testing.T) {
+	t.Parallel()
+	// Given
+	cropUID, _ := uuid.NewV4()
+	userUID, _ := uuid.NewV4()
+	safeDate := time.Now().AddDate(0, 0, 2)
+
+	crop := &Crop{UID: cropUID, BatchID: "tom-14oct", Status: GetCropStatus(CropActive)}
+
+	// When
+	errReason := crop.OverrideWithholding(safeDate, "  ", userUID)
+	err := crop.OverrideWithholding(safeDate, " Lab residue test passed ", userUID)
+
+	// Then
+	assert.Equal(t, CropError{Code: CropHarvestErrorWithholdingOverrideReasonRequired}, errReason)
+	assert.Nil(t, err)
+	assert.Len(t, crop.UncommittedChanges, 1)
+
+	event, ok := crop.UncommittedChanges[0].(CropBatchWithholdingOverridden)
+	assert.True(t, ok)
+	assert.Equal(t, "Lab residue test passed", event.Reason)
+	assert.Equal(t, userUID, event.OverriddenBy)
+	assert.Equal(t, safeDate, event.SafeHarvestDate)
+}
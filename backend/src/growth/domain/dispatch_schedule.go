@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	DispatchStatusScheduled = "SCHEDULED"
+	DispatchStatusCompleted = "COMPLETED"
+)
+
+// DispatchSchedule is the pickup of harvested produce by a truck, and its delivery to the destination.
+// A harvest is identified by the crop batch it was harvested from, a truck often takes the produce of
+// several batches. The dispatch is completed with its task.
+type DispatchSchedule struct {
+	UID                   uuid.UUID   `json:"uid"`
+	FarmUID               uuid.UUID   `json:"farm_id"`
+	HarvestIDs            []uuid.UUID `json:"harvest_ids"`
+	VehicleID             string      `json:"vehicle_id"`
+	DriverName            string      `json:"driver_name"`
+	PickupTime            time.Time   `json:"pickup_time"`
+	DestinationAddress    string      `json:"destination_address"`
+	EstimatedDeliveryTime time.Time   `json:"estimated_delivery_time"`
+	Status                string      `json:"status"`
+	TaskID                *uuid.UUID  `json:"task_id"`
+	CompletedDate         *time.Time  `json:"completed_date"`
+	CreatedDate           time.Time   `json:"created_date"`
+
+	// Events
+	Version            int
+	UncommittedChanges []interface{}
+}
+
+// DispatchDetails are the truck, the driver and the times of a dispatch.
+type DispatchDetails struct {
+	VehicleID             string
+	DriverName            string
+	PickupTime            time.Time
+	DestinationAddress    string
+	EstimatedDeliveryTime time.Time
+}
+
+// CreateDispatchSchedule schedules the dispatch of the produce harvested from the crops of the farm.
+func CreateDispatchSchedule(
+	farmUID uuid.UUID,
+	harvested []Crop,
+	details DispatchDetails,
+	now time.Time,
+) (*DispatchSchedule, error) {
+	harvestIDs, err := validateDispatchHarvests(farmUID, harvested)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err = validateDispatchDetails(details)
+	if err != nil {
+		return nil, err
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	initial := &DispatchSchedule{}
+
+	initial.TrackChange(DispatchScheduled{
+		UID:                   uid,
+		FarmUID:               farmUID,
+		HarvestIDs:            harvestIDs,
+		VehicleID:             details.VehicleID,
+		DriverName:            details.DriverName,
+		PickupTime:            details.PickupTime,
+		DestinationAddress:    details.DestinationAddress,
+		EstimatedDeliveryTime: details.EstimatedDeliveryTime,
+		Status:                DispatchStatusScheduled,
+		CreatedDate:           now,
+	})
+
+	return initial, nil
+}
+
+func (s *DispatchSchedule) AttachTask(taskID uuid.UUID) error {
+	if s.Status != DispatchStatusScheduled {
+		return CropError{Code: DispatchScheduleErrorAlreadyCompleted}
+	}
+
+	s.TrackChange(DispatchTaskCreated{
+		UID:     s.UID,
+		FarmUID: s.FarmUID,
+		TaskID:  taskID,
+	})
+
+	return nil
+}
+
+func (s *DispatchSchedule) Complete(completedDate time.Time) error {
+	if s.Status != DispatchStatusScheduled {
+		return CropError{Code: DispatchScheduleErrorAlreadyCompleted}
+	}
+
+	s.TrackChange(DispatchCompleted{
+		UID:           s.UID,
+		FarmUID:       s.FarmUID,
+		HarvestIDs:    s.HarvestIDs,
+		VehicleID:     s.VehicleID,
+		DriverName:    s.DriverName,
+		Status:        DispatchStatusCompleted,
+		CompletedDate: completedDate,
+	})
+
+	return nil
+}
+
+// Event Tracking.
+func (s *DispatchSchedule) TrackChange(event interface{}) {
+	s.UncommittedChanges = append(s.UncommittedChanges, event)
+	s.Transition(event)
+}
+
+func (s *DispatchSchedule) Transition(event interface{}) {
+	switch e := event.(type) {
+	case DispatchScheduled:
+		s.UID = e.UID
+		s.FarmUID = e.FarmUID
+		s.HarvestIDs = e.HarvestIDs
+		s.VehicleID = e.VehicleID
+		s.DriverName = e.DriverName
+		s.PickupTime = e.PickupTime
+		s.DestinationAddress = e.DestinationAddress
+		s.EstimatedDeliveryTime = e.EstimatedDeliveryTime
+		s.Status = e.Status
+		s.CreatedDate = e.CreatedDate
+	case DispatchTaskCreated:
+		taskID := e.TaskID
+		s.TaskID = &taskID
+	case DispatchCompleted:
+		completedDate := e.CompletedDate
+		s.CompletedDate = &completedDate
+		s.Status = e.Status
+	}
+}
+
+// validateDispatchHarvests returns the IDs of the harvested crops, each one once.
+func validateDispatchHarvests(farmUID uuid.UUID, harvested []Crop) ([]uuid.UUID, error) {
+	if len(harvested) == 0 {
+		return nil, CropError{Code: DispatchScheduleErrorHarvestRequired}
+	}
+
+	harvestIDs := []uuid.UUID{}
+	found := map[uuid.UUID]bool{}
+
+	for _, v := range harvested {
+		if v.FarmUID != farmUID {
+			return nil, CropError{Code: DispatchScheduleErrorDifferentFarm}
+		}
+
+		if len(v.HarvestedStorage) == 0 {
+			return nil, CropError{Code: DispatchScheduleErrorNotHarvested}
+		}
+
+		if !found[v.UID] {
+			found[v.UID] = true
+			harvestIDs = append(harvestIDs, v.UID)
+		}
+	}
+
+	return harvestIDs, nil
+}
+
+func validateDispatchDetails(details DispatchDetails) (DispatchDetails, error) {
+	details.VehicleID = strings.TrimSpace(details.VehicleID)
+	details.DriverName = strings.TrimSpace(details.DriverName)
+	details.DestinationAddress = strings.TrimSpace(details.DestinationAddress)
+
+	if details.VehicleID == "" {
+		return DispatchDetails{}, CropError{Code: DispatchScheduleErrorVehicleIDEmpty}
+	}
+
+	if details.DriverName == "" {
+		return DispatchDetails{}, CropError{Code: DispatchScheduleErrorDriverNameEmpty}
+	}
+
+	if details.DestinationAddress == "" {
+		return DispatchDetails{}, CropError{Code: DispatchScheduleErrorDestinationEmpty}
+	}
+
+	if !details.EstimatedDeliveryTime.After(details.PickupTime) {
+		return DispatchDetails{}, CropError{Code: DispatchScheduleErrorInvalidDeliveryTime}
+	}
+
+	return details, nil
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type DispatchScheduled struct {
+	UID                   uuid.UUID
+	FarmUID               uuid.UUID
+	HarvestIDs            []uuid.UUID
+	VehicleID             string
+	DriverName            string
+	PickupTime            time.Time
+	DestinationAddress    string
+	EstimatedDeliveryTime time.Time
+	Status                string
+	CreatedDate           time.Time
+}
+
+type DispatchTaskCreated struct {
+	UID     uuid.UUID
+	FarmUID uuid.UUID
+	TaskID  uuid.UUID
+}
+
+type DispatchCompleted struct {
+	UID           uuid.UUID
+	FarmUID       uuid.UUID
+	HarvestIDs    []uuid.UUID
+	VehicleID     string
+	DriverName    string
+	Status        string
+	CompletedDate time.Time
+}
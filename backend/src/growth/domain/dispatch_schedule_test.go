@@ -0,0 +1,88 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/growth/domain"
+)
+
+func TestDispatchScheduleLifecycle(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	taskUID, _ := uuid.NewV4()
+	crop := Crop{UID: cropUID, FarmUID: farmUID, HarvestedStorage: []HarvestedStorage{{Quantity: 10}}}
+
+	pickupTime := time.Date(2026, time.October, 15, 8, 0, 0, 0, time.UTC)
+	details := DispatchDetails{
+		VehicleID:             " TRUCK-01 ",
+		DriverName:            "Ana",
+		PickupTime:            pickupTime,
+		DestinationAddress:    "Central Market, Hall 3",
+		EstimatedDeliveryTime: pickupTime.Add(3 * time.Hour),
+	}
+
+	// When
+	schedule, err := CreateDispatchSchedule(farmUID, []Crop{crop, crop}, details, pickupTime.AddDate(0, 0, -1))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []uuid.UUID{cropUID}, schedule.HarvestIDs)
+	assert.Equal(t, "TRUCK-01", schedule.VehicleID)
+	assert.Equal(t, DispatchStatusScheduled, schedule.Status)
+
+	// When
+	err = schedule.AttachTask(taskUID)
+	completeErr := schedule.Complete(pickupTime.Add(4 * time.Hour))
+	completeAgainErr := schedule.Complete(pickupTime.Add(5 * time.Hour))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Nil(t, completeErr)
+	assert.Equal(t, CropError{Code: DispatchScheduleErrorAlreadyCompleted}, completeAgainErr)
+	assert.Equal(t, &taskUID, schedule.TaskID)
+	assert.Equal(t, DispatchStatusCompleted, schedule.Status)
+	assert.Len(t, schedule.UncommittedChanges, 3)
+}
+
+func TestCreateDispatchScheduleValidation(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	otherFarmUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	harvested := Crop{UID: cropUID, FarmUID: farmUID, HarvestedStorage: []HarvestedStorage{{Quantity: 10}}}
+
+	pickupTime := time.Date(2026, time.October, 15, 8, 0, 0, 0, time.UTC)
+	details := DispatchDetails{
+		VehicleID:             "TRUCK-01",
+		DriverName:            "Ana",
+		PickupTime:            pickupTime,
+		DestinationAddress:    "Central Market, Hall 3",
+		EstimatedDeliveryTime: pickupTime.Add(3 * time.Hour),
+	}
+
+	noDriver := details
+	noDriver.DriverName = " "
+
+	early := details
+	early.EstimatedDeliveryTime = pickupTime
+
+	// When
+	_, errNoHarvest := CreateDispatchSchedule(farmUID, nil, details, pickupTime)
+	_, errOtherFarm := CreateDispatchSchedule(otherFarmUID, []Crop{harvested}, details, pickupTime)
+	_, errNotHarvested := CreateDispatchSchedule(farmUID, []Crop{{UID: cropUID, FarmUID: farmUID}}, details, pickupTime)
+	_, errNoDriver := CreateDispatchSchedule(farmUID, []Crop{harvested}, noDriver, pickupTime)
+	_, errEarly := CreateDispatchSchedule(farmUID, []Crop{harvested}, early, pickupTime)
+
+	// Then
+	assert.Equal(t, CropError{Code: DispatchScheduleErrorHarvestRequired}, errNoHarvest)
+	assert.Equal(t, CropError{Code: DispatchScheduleErrorDifferentFarm}, errOtherFarm)
+	assert.Equal(t, CropError{Code: DispatchScheduleErrorNotHarvested}, errNotHarvested)
+	assert.Equal(t, CropError{Code: DispatchScheduleErrorDriverNameEmpty}, errNoDriver)
+	assert.Equal(t, CropError{Code: DispatchScheduleErrorInvalidDeliveryTime}, errEarly)
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+)
+
+// GerminationRow is the germination of the batches of a seed material. The rate is of all the seeds sown
+// in the batches, the lowest and highest rates are of a single batch.
+type GerminationRow struct {
+	MaterialUID        uuid.UUID `json:"material_id"`
+	MaterialName       string    `json:"material_name"`
+	PlantType          string    `json:"plant_type"`
+	TotalBatch         int       `json:"total_batch"`
+	SeedsSown          int       `json:"seeds_sown"`
+	GerminatedQuantity int       `json:"germinated_quantity"`
+	GerminationRate    float64   `json:"germination_rate"`
+	LowestRate         float64   `json:"lowest_rate"`
+	HighestRate        float64   `json:"highest_rate"`
+}
+
+// GerminationReport sums the germination of the batches per seed material.
+type GerminationReport struct {
+	rows map[uuid.UUID]*GerminationRow
+}
+
+func NewGerminationReport() *GerminationReport {
+	return &GerminationReport{rows: map[uuid.UUID]*GerminationRow{}}
+}
+
+// Add sums a batch with its germination recorded.
+func (r *GerminationReport) Add(materialUID uuid.UUID, materialName, plantType string,
+	seedsSown, germinatedQuantity int,
+) {
+	if seedsSown <= 0 {
+		return
+	}
+
+	rate := float64(germinatedQuantity) * 100 / float64(seedsSown)
+
+	row, ok := r.rows[materialUID]
+	if !ok {
+		row = &GerminationRow{
+			MaterialUID:  materialUID,
+			MaterialName: materialName,
+			PlantType:    plantType,
+			LowestRate:   rate,
+			HighestRate:  rate,
+		}
+
+		r.rows[materialUID] = row
+	}
+
+	row.TotalBatch++
+	row.SeedsSown += seedsSown
+	row.GerminatedQuantity += germinatedQuantity
+	row.GerminationRate = float64(row.GerminatedQuantity) * 100 / float64(row.SeedsSown)
+
+	if rate < row.LowestRate {
+		row.LowestRate = rate
+	}
+
+	if rate > row.HighestRate {
+		row.HighestRate = rate
+	}
+}
+
+// Rows returns the rows by material name.
+func (r *GerminationReport) Rows() []GerminationRow {
+	rows := []GerminationRow{}
+
+	for _, v := range r.rows {
+		rows = append(rows, *v)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].MaterialName != rows[j].MaterialName {
+			return rows[i].MaterialName < rows[j].MaterialName
+		}
+
+		return rows[i].MaterialUID.String() < rows[j].MaterialUID.String()
+	})
+
+	return rows
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
+)
+
+// The periods the harvest grade report is broken down into.
+const (
+	HarvestGradePeriodWeek  = "WEEK"
+	HarvestGradePeriodMonth = "MONTH"
+)
+
+type HarvestGradeTotal struct {
+	Grade        string  `json:"grade"`
+	Sellable     bool    `json:"sellable"`
+	GramQuantity float32 `json:"gram_quantity"`
+	Percentage   float32 `json:"percentage"`
+}
+
+// HarvestGradeRow is the grade distribution of a variety in a bucket. The percentages are of the graded
+// quantity, the harvests recorded without grades are summed apart as ungraded. The period start is the first day
+// of the whole bucket.
+type HarvestGradeRow struct {
+	timebuckethelper.Bucket
+
+	PeriodStart          string              `json:"period_start"`
+	VarietyName          string              `json:"variety_name"`
+	ProducedGramQuantity float32             `json:"produced_gram_quantity"`
+	UngradedGramQuantity float32             `json:"ungraded_gram_quantity"`
+	SellableGramQuantity float32             `json:"sellable_gram_quantity"`
+	Grades               []HarvestGradeTotal `json:"grades"`
+}
+
+type harvestGradeRowKey struct {
+	label       string
+	varietyName string
+}
+
+// HarvestGradeReport sums the harvested grades of the crops per variety and bucket.
+type HarvestGradeReport struct {
+	query  timebuckethelper.Query
+	grades []assetsdomain.HarvestGrade
+	rows   map[harvestGradeRowKey]*HarvestGradeRow
+}
+
+// HarvestGradeInterval returns the interval of the period, an unknown period is a monthly one.
+func HarvestGradeInterval(period string) string {
+	if period == HarvestGradePeriodWeek {
+		return timebuckethelper.IntervalISOWeek
+	}
+
+	return timebuckethelper.IntervalMonth
+}
+
+// NewHarvestGradeReport starts the report by week, from Monday, or by month in the location of the harvest dates
+// with the grades of the farm. An unknown period is a monthly report.
+func NewHarvestGradeReport(period string, grades []assetsdomain.HarvestGrade) *HarvestGradeReport {
+	bucketer, _ := timebuckethelper.NewBucketer(HarvestGradeInterval(period), nil, time.Monday, nil)
+
+	return NewBucketedHarvestGradeReport(timebuckethelper.Query{Bucketer: bucketer}, grades)
+}
+
+// NewBucketedHarvestGradeReport starts the report grouped by the buckets of the query, with the grades of the farm.
+func NewBucketedHarvestGradeReport(query timebuckethelper.Query, grades []assetsdomain.HarvestGrade,
+) *HarvestGradeReport {
+	return &HarvestGradeReport{
+		query:  query,
+		grades: assetsdomain.HarvestGradesOrDefault(grades),
+		rows:   map[harvestGradeRowKey]*HarvestGradeRow{},
+	}
+}
+
+// PeriodStart returns the start of the whole bucket of the date.
+func (r *HarvestGradeReport) PeriodStart(date time.Time) time.Time {
+	return r.query.Bucketer.Bucket(date).Start
+}
+
+// Add sums a harvest. The harvests without grades count as sellable, like before the grades, and the grades
+// the farm doesn't define anymore count as not sellable.
+func (r *HarvestGradeReport) Add(varietyName string, harvestDate time.Time, producedGramQuantity float32,
+	grades []HarvestedGrade,
+) {
+	bucket := r.query.Bucket(harvestDate)
+	key := harvestGradeRowKey{
+		label:       bucket.Label,
+		varietyName: varietyName,
+	}
+
+	row, ok := r.rows[key]
+	if !ok {
+		row = &HarvestGradeRow{
+			Bucket:      bucket,
+			PeriodStart: r.PeriodStart(harvestDate).Format("2006-01-02"),
+			VarietyName: varietyName,
+		}
+
+		for _, v := range r.grades {
+			row.Grades = append(row.Grades, HarvestGradeTotal{Grade: v.Code, Sellable: v.Sellable})
+		}
+
+		r.rows[key] = row
+	}
+
+	row.ProducedGramQuantity += producedGramQuantity
+
+	if len(grades) == 0 {
+		row.UngradedGramQuantity += producedGramQuantity
+		row.SellableGramQuantity += producedGramQuantity
+
+		return
+	}
+
+	for _, v := range grades {
+		total := row.findGrade(v.Grade)
+		if total == nil {
+			row.Grades = append(row.Grades, HarvestGradeTotal{Grade: v.Grade})
+			total = &row.Grades[len(row.Grades)-1]
+		}
+
+		total.GramQuantity += v.GramQuantity
+
+		if total.Sellable {
+			row.SellableGramQuantity += v.GramQuantity
+		}
+	}
+}
+
+// Rows returns the rows by bucket, then by variety name.
+func (r *HarvestGradeReport) Rows() []HarvestGradeRow {
+	rows := []HarvestGradeRow{}
+
+	for _, v := range r.rows {
+		row := *v
+		row.Grades = append([]HarvestGradeTotal{}, v.Grades...)
+
+		graded := row.ProducedGramQuantity - row.UngradedGramQuantity
+		if graded > 0 {
+			for i := range row.Grades {
+				row.Grades[i].Percentage = row.Grades[i].GramQuantity * 100 / graded
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if !rows[i].Start.Equal(rows[j].Start) {
+			return rows[i].Start.Before(rows[j].Start)
+		}
+
+		return rows[i].VarietyName < rows[j].VarietyName
+	})
+
+	return rows
+}
+
+func (row *HarvestGradeRow) findGrade(grade string) *HarvestGradeTotal {
+	for i := range row.Grades {
+		if row.Grades[i].Grade == grade {
+			return &row.Grades[i]
+		}
+	}
+
+	return nil
+}
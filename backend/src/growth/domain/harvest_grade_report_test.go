@@ -0,0 +1,49 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	. "github.com/usetania/tania-core/src/growth/domain"
+)
+
+func TestHarvestGradeReport(t *testing.T) {
+	t.Parallel()
+	// Given
+	report := NewHarvestGradeReport(HarvestGradePeriodWeek, assetsdomain.DefaultHarvestGrades())
+
+	wednesday := time.Date(2026, time.October, 14, 9, 0, 0, 0, time.UTC)
+	sunday := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	nextMonday := time.Date(2026, time.October, 19, 9, 0, 0, 0, time.UTC)
+
+	// When
+	report.Add("Tomato", wednesday, 1000, []HarvestedGrade{
+		{Grade: "A", GramQuantity: 600},
+		{Grade: "REJECT", GramQuantity: 400},
+	})
+	report.Add("Tomato", sunday, 1000, []HarvestedGrade{{Grade: "B", GramQuantity: 1000}})
+	report.Add("Tomato", sunday, 500, nil)
+	report.Add("Basil", nextMonday, 200, []HarvestedGrade{{Grade: "EXTRA", GramQuantity: 200}})
+
+	rows := report.Rows()
+
+	// Then
+	assert.Len(t, rows, 2)
+
+	assert.Equal(t, "2026-10-12", rows[0].PeriodStart)
+	assert.Equal(t, "Tomato", rows[0].VarietyName)
+	assert.Equal(t, float32(2500), rows[0].ProducedGramQuantity)
+	assert.Equal(t, float32(500), rows[0].UngradedGramQuantity)
+	assert.Equal(t, float32(2100), rows[0].SellableGramQuantity)
+	assert.Equal(t, []HarvestGradeTotal{
+		{Grade: "A", Sellable: true, GramQuantity: 600, Percentage: 30},
+		{Grade: "B", Sellable: true, GramQuantity: 1000, Percentage: 50},
+		{Grade: "REJECT", Sellable: false, GramQuantity: 400, Percentage: 20},
+	}, rows[0].Grades)
+
+	assert.Equal(t, "2026-10-19", rows[1].PeriodStart)
+	assert.Equal(t, float32(0), rows[1].SellableGramQuantity)
+	assert.Equal(t, HarvestGradeTotal{Grade: "EXTRA", GramQuantity: 200, Percentage: 100}, rows[1].Grades[3])
+}
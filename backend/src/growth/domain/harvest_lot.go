@@ -0,0 +1,247 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	HarvestLotStatusInStock          = "IN_STOCK"
+	HarvestLotStatusPartiallyShipped = "PARTIALLY_SHIPPED"
+	HarvestLotStatusShipped          = "SHIPPED"
+	HarvestLotStatusSold             = "SOLD"
+
+	LotShipmentTypeShipped = "SHIPPED"
+	LotShipmentTypeSold    = "SOLD"
+)
+
+// lotQuantityTolerance is how far, in gram, the shipments may be off the quantity left in a lot,
+// so the rounding of the typed decimals doesn't leave a crumb in the lot.
+const lotQuantityTolerance = 0.005
+
+// HarvestLot is a crate or a container filled at a harvest, with the produce of one grade of the harvest.
+// It's numbered per farm, like L-12, and keeps the crop batch, the area and the date of the harvest it comes
+// from, so a delivery can be traced back to them. Its quantity is shipped or sold in one or more shipments.
+type HarvestLot struct {
+	UID          uuid.UUID     `json:"uid"`
+	FarmUID      uuid.UUID     `json:"farm_id"`
+	LotNumber    string        `json:"lot_number"`
+	CropUID      uuid.UUID     `json:"crop_id"`
+	BatchID      string        `json:"batch_id"`
+	AreaUID      uuid.UUID     `json:"area_id"`
+	HarvestDate  time.Time     `json:"harvest_date"`
+	Grade        string        `json:"grade"`
+	GramQuantity float64       `json:"gram_quantity"`
+	Shipments    []LotShipment `json:"shipments"`
+	CreatedDate  time.Time     `json:"created_date"`
+
+	// Events
+	Version            int
+	UncommittedChanges []interface{}
+}
+
+// LotShipment is a delivery of some of the quantity of a lot, shipped or sold. The sale reference is the number
+// of the invoice or the order the produce was sold with, and the dispatch is the truck which took it, if any.
+type LotShipment struct {
+	UID           uuid.UUID  `json:"uid"`
+	Type          string     `json:"type"`
+	GramQuantity  float64    `json:"gram_quantity"`
+	Customer      string     `json:"customer"`
+	SaleReference string     `json:"sale_reference"`
+	DispatchID    *uuid.UUID `json:"dispatch_id"`
+	ShippedDate   time.Time  `json:"shipped_date"`
+}
+
+// HarvestLotSource is the harvest a lot is filled at.
+type HarvestLotSource struct {
+	FarmUID     uuid.UUID
+	CropUID     uuid.UUID
+	BatchID     string
+	AreaUID     uuid.UUID
+	HarvestDate time.Time
+}
+
+// LotDelivery is the delivery of a shipment of a lot.
+type LotDelivery struct {
+	Type          string
+	GramQuantity  float64
+	Customer      string
+	SaleReference string
+	Dispatch      *DispatchSchedule
+	Date          time.Time
+}
+
+// HarvestLotContents returns the grades a harvest fills a lot each with. A harvest recorded without grades fills
+// a single lot, without a grade, with its produced quantity.
+func HarvestLotContents(harvest CropBatchHarvested) []HarvestedGrade {
+	if len(harvest.Grades) == 0 {
+		return []HarvestedGrade{{GramQuantity: harvest.ProducedGramQuantity}}
+	}
+
+	return harvest.Grades
+}
+
+// CreateHarvestLot creates the lot of the number with the quantity of a grade of the harvest.
+func CreateHarvestLot(
+	source HarvestLotSource,
+	lotNumber string,
+	grade string,
+	gramQuantity float64,
+	now time.Time,
+) (*HarvestLot, error) {
+	if gramQuantity <= 0 {
+		return nil, CropError{Code: HarvestLotErrorInvalidQuantity}
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	initial := &HarvestLot{}
+
+	initial.TrackChange(HarvestLotCreated{
+		UID:          uid,
+		FarmUID:      source.FarmUID,
+		LotNumber:    lotNumber,
+		CropUID:      source.CropUID,
+		BatchID:      source.BatchID,
+		AreaUID:      source.AreaUID,
+		HarvestDate:  source.HarvestDate,
+		Grade:        grade,
+		GramQuantity: gramQuantity,
+		CreatedDate:  now,
+	})
+
+	return initial, nil
+}
+
+// Ship delivers some of the quantity left in the lot. A lot split across several deliveries is shipped once per
+// delivery, the shipments never add up to more than the quantity of the lot.
+func (l *HarvestLot) Ship(delivery LotDelivery) (LotShipment, error) {
+	if l.RemainingGramQuantity() <= 0 {
+		return LotShipment{}, CropError{Code: HarvestLotErrorAlreadyShipped}
+	}
+
+	switch delivery.Type {
+	case LotShipmentTypeShipped, LotShipmentTypeSold:
+	default:
+		return LotShipment{}, CropError{Code: HarvestLotErrorInvalidShipmentType}
+	}
+
+	remaining := l.RemainingGramQuantity()
+	if delivery.GramQuantity <= 0 || delivery.GramQuantity > remaining+lotQuantityTolerance {
+		return LotShipment{}, CropError{Code: HarvestLotErrorInvalidShipmentQuantity}
+	}
+
+	// The last shipment takes what's left, so the shipments add up to the quantity of the lot.
+	if delivery.GramQuantity > remaining-lotQuantityTolerance {
+		delivery.GramQuantity = remaining
+	}
+
+	dispatchID := uuid.Nil
+
+	if delivery.Dispatch != nil {
+		if delivery.Dispatch.FarmUID != l.FarmUID {
+			return LotShipment{}, CropError{Code: HarvestLotErrorDifferentFarm}
+		}
+
+		dispatchID = delivery.Dispatch.UID
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return LotShipment{}, err
+	}
+
+	l.TrackChange(HarvestLotShipped{
+		UID:           l.UID,
+		FarmUID:       l.FarmUID,
+		LotNumber:     l.LotNumber,
+		ShipmentUID:   uid,
+		Type:          delivery.Type,
+		GramQuantity:  delivery.GramQuantity,
+		Customer:      strings.TrimSpace(delivery.Customer),
+		SaleReference: strings.TrimSpace(delivery.SaleReference),
+		DispatchID:    dispatchID,
+		ShippedDate:   delivery.Date,
+	})
+
+	return l.Shipments[len(l.Shipments)-1], nil
+}
+
+// RemainingGramQuantity is the quantity of the lot not shipped yet.
+func (l HarvestLot) RemainingGramQuantity() float64 {
+	remaining := l.GramQuantity
+
+	for _, v := range l.Shipments {
+		remaining -= v.GramQuantity
+	}
+
+	if remaining < lotQuantityTolerance {
+		return 0
+	}
+
+	return remaining
+}
+
+// Status is in stock until a first shipment, and shipped once it's all delivered. A lot is sold when all of
+// its shipments are sales.
+func (l HarvestLot) Status() string {
+	if len(l.Shipments) == 0 {
+		return HarvestLotStatusInStock
+	}
+
+	if l.RemainingGramQuantity() > 0 {
+		return HarvestLotStatusPartiallyShipped
+	}
+
+	for _, v := range l.Shipments {
+		if v.Type != LotShipmentTypeSold {
+			return HarvestLotStatusShipped
+		}
+	}
+
+	return HarvestLotStatusSold
+}
+
+// Event Tracking.
+func (l *HarvestLot) TrackChange(event interface{}) {
+	l.UncommittedChanges = append(l.UncommittedChanges, event)
+	l.Transition(event)
+}
+
+func (l *HarvestLot) Transition(event interface{}) {
+	switch e := event.(type) {
+	case HarvestLotCreated:
+		l.UID = e.UID
+		l.FarmUID = e.FarmUID
+		l.LotNumber = e.LotNumber
+		l.CropUID = e.CropUID
+		l.BatchID = e.BatchID
+		l.AreaUID = e.AreaUID
+		l.HarvestDate = e.HarvestDate
+		l.Grade = e.Grade
+		l.GramQuantity = e.GramQuantity
+		l.Shipments = []LotShipment{}
+		l.CreatedDate = e.CreatedDate
+	case HarvestLotShipped:
+		shipment := LotShipment{
+			UID:           e.ShipmentUID,
+			Type:          e.Type,
+			GramQuantity:  e.GramQuantity,
+			Customer:      e.Customer,
+			SaleReference: e.SaleReference,
+			ShippedDate:   e.ShippedDate,
+		}
+
+		if e.DispatchID != uuid.Nil {
+			dispatchID := e.DispatchID
+			shipment.DispatchID = &dispatchID
+		}
+
+		l.Shipments = append(l.Shipments, shipment)
+	}
+}
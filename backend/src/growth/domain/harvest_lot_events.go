@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type HarvestLotCreated struct {
+	UID          uuid.UUID
+	FarmUID      uuid.UUID
+	LotNumber    string
+	CropUID      uuid.UUID
+	BatchID      string
+	AreaUID      uuid.UUID
+	HarvestDate  time.Time
+	Grade        string
+	GramQuantity float64
+	CreatedDate  time.Time
+}
+
+// HarvestLotShipped is a delivery of some or all of the quantity left in a lot.
+// The DispatchID is nil when the delivery isn't a dispatch of the farm.
+type HarvestLotShipped struct {
+	UID           uuid.UUID
+	FarmUID       uuid.UUID
+	LotNumber     string
+	ShipmentUID   uuid.UUID
+	Type          string
+	GramQuantity  float64
+	Customer      string
+	SaleReference string
+	DispatchID    uuid.UUID
+	ShippedDate   time.Time
+}
@@ -0,0 +1,131 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/growth/domain"
+)
+
+func newTestHarvestLot(t *testing.T, gramQuantity float64) *HarvestLot {
+	t.Helper()
+
+	farmUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	areaUID, _ := uuid.NewV4()
+	harvestDate := time.Date(2026, time.October, 12, 7, 30, 0, 0, time.UTC)
+
+	lot, err := CreateHarvestLot(HarvestLotSource{
+		FarmUID:     farmUID,
+		CropUID:     cropUID,
+		BatchID:     "tom-12oct",
+		AreaUID:     areaUID,
+		HarvestDate: harvestDate,
+	}, "L-3", "A", gramQuantity, harvestDate)
+	assert.Nil(t, err)
+
+	return lot
+}
+
+func TestHarvestLotContents(t *testing.T) {
+	t.Parallel()
+	// Given
+	graded := CropBatchHarvested{
+		ProducedGramQuantity: 1500,
+		Grades:               []HarvestedGrade{{Grade: "A", GramQuantity: 1000}, {Grade: "B", GramQuantity: 500}},
+	}
+	ungraded := CropBatchHarvested{ProducedGramQuantity: 800}
+
+	// When
+	gradedContents := HarvestLotContents(graded)
+	ungradedContents := HarvestLotContents(ungraded)
+
+	// Then
+	assert.Equal(t, graded.Grades, gradedContents)
+	assert.Equal(t, []HarvestedGrade{{GramQuantity: 800}}, ungradedContents)
+}
+
+func TestCreateHarvestLot(t *testing.T) {
+	t.Parallel()
+	// When
+	lot := newTestHarvestLot(t, 1000)
+	_, invalidErr := CreateHarvestLot(HarvestLotSource{}, "L-4", "", 0, time.Now())
+
+	// Then
+	assert.Equal(t, "L-3", lot.LotNumber)
+	assert.Equal(t, "tom-12oct", lot.BatchID)
+	assert.Equal(t, float64(1000), lot.RemainingGramQuantity())
+	assert.Equal(t, HarvestLotStatusInStock, lot.Status())
+	assert.Len(t, lot.UncommittedChanges, 1)
+	assert.Equal(t, CropError{Code: HarvestLotErrorInvalidQuantity}, invalidErr)
+}
+
+func TestHarvestLotSplitAcrossDeliveries(t *testing.T) {
+	t.Parallel()
+	// Given
+	lot := newTestHarvestLot(t, 1000)
+	dispatch := &DispatchSchedule{UID: uuid.Must(uuid.NewV4()), FarmUID: lot.FarmUID}
+	otherFarmDispatch := &DispatchSchedule{UID: uuid.Must(uuid.NewV4()), FarmUID: uuid.Must(uuid.NewV4())}
+	date := lot.HarvestDate.Add(24 * time.Hour)
+
+	// When
+	first, err := lot.Ship(LotDelivery{
+		Type:         LotShipmentTypeShipped,
+		GramQuantity: 600,
+		Customer:     " Green Grocer ",
+		Dispatch:     dispatch,
+		Date:         date,
+	})
+	_, overErr := lot.Ship(LotDelivery{Type: LotShipmentTypeSold, GramQuantity: 400.5, Date: date})
+	_, typeErr := lot.Ship(LotDelivery{Type: "GIVEN", GramQuantity: 100, Date: date})
+	_, farmErr := lot.Ship(LotDelivery{
+		Type:         LotShipmentTypeSold,
+		GramQuantity: 100,
+		Dispatch:     otherFarmDispatch,
+		Date:         date,
+	})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "Green Grocer", first.Customer)
+	assert.Equal(t, &dispatch.UID, first.DispatchID)
+	assert.Equal(t, float64(400), lot.RemainingGramQuantity())
+	assert.Equal(t, HarvestLotStatusPartiallyShipped, lot.Status())
+	assert.Equal(t, CropError{Code: HarvestLotErrorInvalidShipmentQuantity}, overErr)
+	assert.Equal(t, CropError{Code: HarvestLotErrorInvalidShipmentType}, typeErr)
+	assert.Equal(t, CropError{Code: HarvestLotErrorDifferentFarm}, farmErr)
+
+	// When
+	second, err := lot.Ship(LotDelivery{
+		Type:          LotShipmentTypeSold,
+		GramQuantity:  399.999,
+		SaleReference: "INV-0042",
+		Date:          date,
+	})
+	_, shippedErr := lot.Ship(LotDelivery{Type: LotShipmentTypeSold, GramQuantity: 1, Date: date})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Nil(t, second.DispatchID)
+	assert.Equal(t, float64(400), second.GramQuantity)
+	assert.Equal(t, float64(1000), lot.Shipments[0].GramQuantity+lot.Shipments[1].GramQuantity)
+	assert.Equal(t, float64(0), lot.RemainingGramQuantity())
+	assert.Equal(t, HarvestLotStatusShipped, lot.Status())
+	assert.Equal(t, CropError{Code: HarvestLotErrorAlreadyShipped}, shippedErr)
+	assert.Len(t, lot.UncommittedChanges, 3)
+}
+
+func TestHarvestLotSold(t *testing.T) {
+	t.Parallel()
+	// Given
+	lot := newTestHarvestLot(t, 500)
+
+	// When
+	_, err := lot.Ship(LotDelivery{Type: LotShipmentTypeSold, GramQuantity: 500, Date: lot.HarvestDate})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, HarvestLotStatusSold, lot.Status())
+}
@@ -0,0 +1,399 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	InsuranceClaimStatusFiled   = "FILED"
+	InsuranceClaimStatusSettled = "SETTLED"
+)
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// InsurancePolicy is the insurance of crop batches of a farm against their loss, between the start and the end
+// dates of the policy, both included. The claims are filed for the losses of the insured batches, by hand or
+// when a batch is dumped, and they are settled with the amount the provider paid.
+type InsurancePolicy struct {
+	UID            uuid.UUID        `json:"uid"`
+	FarmUID        uuid.UUID        `json:"farm_id"`
+	PolicyNumber   string           `json:"policy_number"`
+	Provider       string           `json:"provider"`
+	CropIDs        []uuid.UUID      `json:"crop_ids"`
+	CoverageAmount float64          `json:"coverage_amount"`
+	Currency       string           `json:"currency"`
+	PremiumAmount  float64          `json:"premium_amount"`
+	StartDate      time.Time        `json:"start_date"`
+	EndDate        time.Time        `json:"end_date"`
+	Claims         []InsuranceClaim `json:"claims"`
+	IsRemoved      bool             `json:"-"`
+	CreatedDate    time.Time        `json:"created_date"`
+
+	// Events
+	Version            int
+	UncommittedChanges []interface{}
+}
+
+// InsuranceClaim is a claim for the loss of an insured crop batch. The automatic claims are the ones filed
+// when the batch was dumped.
+type InsuranceClaim struct {
+	UID           uuid.UUID  `json:"uid"`
+	CropUID       uuid.UUID  `json:"crop_id"`
+	Reason        string     `json:"reason"`
+	Quantity      int        `json:"quantity"`
+	Notes         string     `json:"notes"`
+	Automatic     bool       `json:"automatic"`
+	Status        string     `json:"status"`
+	FiledDate     time.Time  `json:"filed_date"`
+	SettledAmount *float64   `json:"settled_amount"`
+	SettledDate   *time.Time `json:"settled_date"`
+}
+
+// InsurancePolicyDetails are the provider, the amounts and the period of a policy.
+type InsurancePolicyDetails struct {
+	PolicyNumber   string
+	Provider       string
+	CoverageAmount float64
+	Currency       string
+	PremiumAmount  float64
+	StartDate      time.Time
+	EndDate        time.Time
+}
+
+// InsuranceLoss is the loss of an insured crop batch a claim is filed for.
+type InsuranceLoss struct {
+	CropUID   uuid.UUID
+	Reason    string
+	Quantity  int
+	Notes     string
+	Automatic bool
+	Date      time.Time
+}
+
+// CreateInsurancePolicy insures the crops of the farm with the policy of the details.
+func CreateInsurancePolicy(
+	farmUID uuid.UUID,
+	insured []Crop,
+	details InsurancePolicyDetails,
+	now time.Time,
+) (*InsurancePolicy, error) {
+	cropIDs, err := validateInsuredCrops(farmUID, insured)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err = validateInsurancePolicyDetails(details)
+	if err != nil {
+		return nil, err
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	initial := &InsurancePolicy{}
+
+	initial.TrackChange(PolicyCreated{
+		UID:            uid,
+		FarmUID:        farmUID,
+		PolicyNumber:   details.PolicyNumber,
+		Provider:       details.Provider,
+		CropIDs:        cropIDs,
+		CoverageAmount: details.CoverageAmount,
+		Currency:       details.Currency,
+		PremiumAmount:  details.PremiumAmount,
+		StartDate:      details.StartDate,
+		EndDate:        details.EndDate,
+		CreatedDate:    now,
+	})
+
+	return initial, nil
+}
+
+// Update replaces the insured crops and the details of the policy, its claims are kept.
+func (p *InsurancePolicy) Update(insured []Crop, details InsurancePolicyDetails) error {
+	if p.IsRemoved {
+		return CropError{Code: InsurancePolicyErrorRemoved}
+	}
+
+	cropIDs, err := validateInsuredCrops(p.FarmUID, insured)
+	if err != nil {
+		return err
+	}
+
+	details, err = validateInsurancePolicyDetails(details)
+	if err != nil {
+		return err
+	}
+
+	p.TrackChange(PolicyUpdated{
+		UID:            p.UID,
+		FarmUID:        p.FarmUID,
+		PolicyNumber:   details.PolicyNumber,
+		Provider:       details.Provider,
+		CropIDs:        cropIDs,
+		CoverageAmount: details.CoverageAmount,
+		Currency:       details.Currency,
+		PremiumAmount:  details.PremiumAmount,
+		StartDate:      details.StartDate,
+		EndDate:        details.EndDate,
+	})
+
+	return nil
+}
+
+func (p *InsurancePolicy) Remove() error {
+	if p.IsRemoved {
+		return CropError{Code: InsurancePolicyErrorRemoved}
+	}
+
+	p.TrackChange(PolicyRemoved{
+		UID:          p.UID,
+		FarmUID:      p.FarmUID,
+		PolicyNumber: p.PolicyNumber,
+	})
+
+	return nil
+}
+
+// IsActive tells if the date is in the period of the policy, the whole end date included.
+func (p InsurancePolicy) IsActive(date time.Time) bool {
+	return !p.IsRemoved && !date.Before(p.StartDate) && date.Before(p.EndDate.AddDate(0, 0, 1))
+}
+
+// Covers tells if the loss of the crop at the date is insured by the policy.
+func (p InsurancePolicy) Covers(cropUID uuid.UUID, date time.Time) bool {
+	return p.insures(cropUID) && p.IsActive(date)
+}
+
+// FileClaim files a claim for the loss of an insured crop in the period of the policy.
+func (p *InsurancePolicy) FileClaim(loss InsuranceLoss) (InsuranceClaim, error) {
+	if p.IsRemoved {
+		return InsuranceClaim{}, CropError{Code: InsurancePolicyErrorRemoved}
+	}
+
+	if !p.insures(loss.CropUID) {
+		return InsuranceClaim{}, CropError{Code: InsurancePolicyErrorCropNotInsured}
+	}
+
+	if !p.Covers(loss.CropUID, loss.Date) {
+		return InsuranceClaim{}, CropError{Code: InsurancePolicyErrorClaimOutOfPeriod}
+	}
+
+	if loss.Quantity < 0 {
+		return InsuranceClaim{}, CropError{Code: InsurancePolicyErrorInvalidClaimQuantity}
+	}
+
+	// The losses have the reasons of the dumps, which are optional.
+	switch loss.Reason {
+	case "", DumpReasonDisease, DumpReasonPest, DumpReasonDamage, DumpReasonOther:
+	default:
+		return InsuranceClaim{}, CropError{Code: InsurancePolicyErrorInvalidClaimReason}
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return InsuranceClaim{}, err
+	}
+
+	p.TrackChange(ClaimFiled{
+		UID:          p.UID,
+		FarmUID:      p.FarmUID,
+		PolicyNumber: p.PolicyNumber,
+		Provider:     p.Provider,
+		ClaimUID:     uid,
+		CropUID:      loss.CropUID,
+		Reason:       loss.Reason,
+		Quantity:     loss.Quantity,
+		Notes:        strings.TrimSpace(loss.Notes),
+		Automatic:    loss.Automatic,
+		FiledDate:    loss.Date,
+	})
+
+	return p.Claims[len(p.Claims)-1], nil
+}
+
+// SettleClaim settles a filed claim with the amount paid by the provider. The settled claims of a policy
+// can't be paid more than its coverage.
+func (p *InsurancePolicy) SettleClaim(claimUID uuid.UUID, amount float64, settledDate time.Time) error {
+	if p.IsRemoved {
+		return CropError{Code: InsurancePolicyErrorRemoved}
+	}
+
+	claim, ok := p.findClaim(claimUID)
+	if !ok {
+		return CropError{Code: InsurancePolicyErrorClaimNotFound}
+	}
+
+	if claim.Status == InsuranceClaimStatusSettled {
+		return CropError{Code: InsurancePolicyErrorClaimAlreadySettled}
+	}
+
+	if amount < 0 || amount > p.CoverageAmount-p.SettledAmount() {
+		return CropError{Code: InsurancePolicyErrorInvalidSettledAmount}
+	}
+
+	p.TrackChange(ClaimSettled{
+		UID:           p.UID,
+		FarmUID:       p.FarmUID,
+		PolicyNumber:  p.PolicyNumber,
+		Provider:      p.Provider,
+		ClaimUID:      claimUID,
+		CropUID:       claim.CropUID,
+		SettledAmount: amount,
+		Currency:      p.Currency,
+		SettledDate:   settledDate,
+	})
+
+	return nil
+}
+
+// SettledAmount is the amount paid for the settled claims of the policy.
+func (p InsurancePolicy) SettledAmount() float64 {
+	total := 0.0
+
+	for _, v := range p.Claims {
+		if v.SettledAmount != nil {
+			total += *v.SettledAmount
+		}
+	}
+
+	return total
+}
+
+// Event Tracking.
+func (p *InsurancePolicy) TrackChange(event interface{}) {
+	p.UncommittedChanges = append(p.UncommittedChanges, event)
+	p.Transition(event)
+}
+
+func (p *InsurancePolicy) Transition(event interface{}) {
+	switch e := event.(type) {
+	case PolicyCreated:
+		p.UID = e.UID
+		p.FarmUID = e.FarmUID
+		p.PolicyNumber = e.PolicyNumber
+		p.Provider = e.Provider
+		p.CropIDs = e.CropIDs
+		p.CoverageAmount = e.CoverageAmount
+		p.Currency = e.Currency
+		p.PremiumAmount = e.PremiumAmount
+		p.StartDate = e.StartDate
+		p.EndDate = e.EndDate
+		p.Claims = []InsuranceClaim{}
+		p.CreatedDate = e.CreatedDate
+	case PolicyUpdated:
+		p.PolicyNumber = e.PolicyNumber
+		p.Provider = e.Provider
+		p.CropIDs = e.CropIDs
+		p.CoverageAmount = e.CoverageAmount
+		p.Currency = e.Currency
+		p.PremiumAmount = e.PremiumAmount
+		p.StartDate = e.StartDate
+		p.EndDate = e.EndDate
+	case PolicyRemoved:
+		p.IsRemoved = true
+	case ClaimFiled:
+		p.Claims = append(p.Claims, InsuranceClaim{
+			UID:       e.ClaimUID,
+			CropUID:   e.CropUID,
+			Reason:    e.Reason,
+			Quantity:  e.Quantity,
+			Notes:     e.Notes,
+			Automatic: e.Automatic,
+			Status:    InsuranceClaimStatusFiled,
+			FiledDate: e.FiledDate,
+		})
+	case ClaimSettled:
+		for i, v := range p.Claims {
+			if v.UID == e.ClaimUID {
+				amount := e.SettledAmount
+				settledDate := e.SettledDate
+
+				p.Claims[i].Status = InsuranceClaimStatusSettled
+				p.Claims[i].SettledAmount = &amount
+				p.Claims[i].SettledDate = &settledDate
+			}
+		}
+	}
+}
+
+func (p InsurancePolicy) insures(cropUID uuid.UUID) bool {
+	for _, v := range p.CropIDs {
+		if v == cropUID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p InsurancePolicy) findClaim(claimUID uuid.UUID) (InsuranceClaim, bool) {
+	for _, v := range p.Claims {
+		if v.UID == claimUID {
+			return v, true
+		}
+	}
+
+	return InsuranceClaim{}, false
+}
+
+// validateInsuredCrops returns the IDs of the insured crops, each one once.
+func validateInsuredCrops(farmUID uuid.UUID, insured []Crop) ([]uuid.UUID, error) {
+	if len(insured) == 0 {
+		return nil, CropError{Code: InsurancePolicyErrorCropRequired}
+	}
+
+	cropIDs := []uuid.UUID{}
+	found := map[uuid.UUID]bool{}
+
+	for _, v := range insured {
+		if v.FarmUID != farmUID {
+			return nil, CropError{Code: InsurancePolicyErrorDifferentFarm}
+		}
+
+		if !found[v.UID] {
+			found[v.UID] = true
+			cropIDs = append(cropIDs, v.UID)
+		}
+	}
+
+	return cropIDs, nil
+}
+
+func validateInsurancePolicyDetails(details InsurancePolicyDetails) (InsurancePolicyDetails, error) {
+	details.PolicyNumber = strings.TrimSpace(details.PolicyNumber)
+	details.Provider = strings.TrimSpace(details.Provider)
+	details.Currency = strings.ToUpper(strings.TrimSpace(details.Currency))
+
+	if details.PolicyNumber == "" {
+		return InsurancePolicyDetails{}, CropError{Code: InsurancePolicyErrorPolicyNumberEmpty}
+	}
+
+	if details.Provider == "" {
+		return InsurancePolicyDetails{}, CropError{Code: InsurancePolicyErrorProviderEmpty}
+	}
+
+	if details.CoverageAmount <= 0 {
+		return InsurancePolicyDetails{}, CropError{Code: InsurancePolicyErrorInvalidCoverageAmount}
+	}
+
+	if details.PremiumAmount < 0 {
+		return InsurancePolicyDetails{}, CropError{Code: InsurancePolicyErrorInvalidPremiumAmount}
+	}
+
+	if !currencyCodePattern.MatchString(details.Currency) {
+		return InsurancePolicyDetails{}, CropError{Code: InsurancePolicyErrorInvalidCurrency}
+	}
+
+	if !details.EndDate.After(details.StartDate) {
+		return InsurancePolicyDetails{}, CropError{Code: InsurancePolicyErrorInvalidEndDate}
+	}
+
+	return details, nil
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type PolicyCreated struct {
+	UID            uuid.UUID
+	FarmUID        uuid.UUID
+	PolicyNumber   string
+	Provider       string
+	CropIDs        []uuid.UUID
+	CoverageAmount float64
+	Currency       string
+	PremiumAmount  float64
+	StartDate      time.Time
+	EndDate        time.Time
+	CreatedDate    time.Time
+}
+
+type PolicyUpdated struct {
+	UID            uuid.UUID
+	FarmUID        uuid.UUID
+	PolicyNumber   string
+	Provider       string
+	CropIDs        []uuid.UUID
+	CoverageAmount float64
+	Currency       string
+	PremiumAmount  float64
+	StartDate      time.Time
+	EndDate        time.Time
+}
+
+type PolicyRemoved struct {
+	UID          uuid.UUID
+	FarmUID      uuid.UUID
+	PolicyNumber string
+}
+
+type ClaimFiled struct {
+	UID          uuid.UUID
+	FarmUID      uuid.UUID
+	PolicyNumber string
+	Provider     string
+	ClaimUID     uuid.UUID
+	CropUID      uuid.UUID
+	Reason       string
+	Quantity     int
+	Notes        string
+	Automatic    bool
+	FiledDate    time.Time
+}
+
+type ClaimSettled struct {
+	UID           uuid.UUID
+	FarmUID       uuid.UUID
+	PolicyNumber  string
+	Provider      string
+	ClaimUID      uuid.UUID
+	CropUID       uuid.UUID
+	SettledAmount float64
+	Currency      string
+	SettledDate   time.Time
+}
@@ -0,0 +1,170 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/growth/domain"
+)
+
+func newTestInsurancePolicyDetails() InsurancePolicyDetails {
+	return InsurancePolicyDetails{
+		PolicyNumber:   " AGR-2026-0042 ",
+		Provider:       "Harvest Mutual",
+		CoverageAmount: 5000,
+		Currency:       "eur",
+		PremiumAmount:  240,
+		StartDate:      time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:        time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestInsurancePolicyLifecycle(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	otherCropUID, _ := uuid.NewV4()
+	crop := Crop{UID: cropUID, FarmUID: farmUID}
+	details := newTestInsurancePolicyDetails()
+	lossDate := time.Date(2026, time.December, 31, 16, 0, 0, 0, time.UTC)
+
+	// When
+	policy, err := CreateInsurancePolicy(farmUID, []Crop{crop, crop}, details, details.StartDate)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []uuid.UUID{cropUID}, policy.CropIDs)
+	assert.Equal(t, "AGR-2026-0042", policy.PolicyNumber)
+	assert.Equal(t, "EUR", policy.Currency)
+	assert.True(t, policy.Covers(cropUID, lossDate))
+	assert.False(t, policy.Covers(cropUID, lossDate.Add(8*time.Hour)))
+	assert.False(t, policy.Covers(otherCropUID, lossDate))
+
+	// When
+	claim, err := policy.FileClaim(InsuranceLoss{
+		CropUID:   cropUID,
+		Reason:    DumpReasonDisease,
+		Quantity:  4,
+		Automatic: true,
+		Date:      lossDate,
+	})
+	_, notInsuredErr := policy.FileClaim(InsuranceLoss{CropUID: otherCropUID, Date: lossDate})
+	_, outOfPeriodErr := policy.FileClaim(InsuranceLoss{CropUID: cropUID, Date: details.StartDate.AddDate(0, 0, -1)})
+	_, invalidReasonErr := policy.FileClaim(InsuranceLoss{CropUID: cropUID, Reason: "HAIL", Date: lossDate})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, InsuranceClaimStatusFiled, claim.Status)
+	assert.True(t, claim.Automatic)
+	assert.Equal(t, CropError{Code: InsurancePolicyErrorCropNotInsured}, notInsuredErr)
+	assert.Equal(t, CropError{Code: InsurancePolicyErrorClaimOutOfPeriod}, outOfPeriodErr)
+	assert.Equal(t, CropError{Code: InsurancePolicyErrorInvalidClaimReason}, invalidReasonErr)
+
+	// When
+	tooMuchErr := policy.SettleClaim(claim.UID, 5001, lossDate.AddDate(0, 0, 20))
+	err = policy.SettleClaim(claim.UID, 1200, lossDate.AddDate(0, 0, 20))
+	settledAgainErr := policy.SettleClaim(claim.UID, 100, lossDate.AddDate(0, 0, 21))
+	removeErr := policy.Remove()
+	_, removedErr := policy.FileClaim(InsuranceLoss{CropUID: cropUID, Date: lossDate})
+
+	// Then
+	assert.Equal(t, CropError{Code: InsurancePolicyErrorInvalidSettledAmount}, tooMuchErr)
+	assert.Nil(t, err)
+	assert.Equal(t, CropError{Code: InsurancePolicyErrorClaimAlreadySettled}, settledAgainErr)
+	assert.Nil(t, removeErr)
+	assert.Equal(t, CropError{Code: InsurancePolicyErrorRemoved}, removedErr)
+	assert.Equal(t, InsuranceClaimStatusSettled, policy.Claims[0].Status)
+	assert.Equal(t, 1200.0, policy.SettledAmount())
+	assert.True(t, policy.IsRemoved)
+	assert.Len(t, policy.UncommittedChanges, 4)
+}
+
+func TestCreateInsurancePolicyValidation(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	otherFarmUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	crop := Crop{UID: cropUID, FarmUID: farmUID}
+	now := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	noProvider := newTestInsurancePolicyDetails()
+	noProvider.Provider = " "
+
+	invalidCurrency := newTestInsurancePolicyDetails()
+	invalidCurrency.Currency = "EURO"
+
+	invalidEndDate := newTestInsurancePolicyDetails()
+	invalidEndDate.EndDate = invalidEndDate.StartDate
+
+	// When
+	_, noCropErr := CreateInsurancePolicy(farmUID, []Crop{}, newTestInsurancePolicyDetails(), now)
+	_, otherFarmErr := CreateInsurancePolicy(otherFarmUID, []Crop{crop}, newTestInsurancePolicyDetails(), now)
+	_, noProviderErr := CreateInsurancePolicy(farmUID, []Crop{crop}, noProvider, now)
+	_, invalidCurrencyErr := CreateInsurancePolicy(farmUID, []Crop{crop}, invalidCurrency, now)
+	_, invalidEndDateErr := CreateInsurancePolicy(farmUID, []Crop{crop}, invalidEndDate, now)
+
+	// Then
+	assert.Equal(t, CropError{Code: InsurancePolicyErrorCropRequired}, noCropErr)
+	assert.Equal(t, CropError{Code: InsurancePolicyErrorDifferentFarm}, otherFarmErr)
+	assert.Equal(t, CropError{Code: InsurancePolicyErrorProviderEmpty}, noProviderErr)
+	assert.Equal(t, CropError{Code: InsurancePolicyErrorInvalidCurrency}, invalidCurrencyErr)
+	assert.Equal(t, CropError{Code: InsurancePolicyErrorInvalidEndDate}, invalidEndDateErr)
+}
+
+func TestInsuranceSummary(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	otherCropUID, _ := uuid.NewV4()
+	crops := []Crop{{UID: cropUID, FarmUID: farmUID}, {UID: otherCropUID, FarmUID: farmUID}}
+	details := newTestInsurancePolicyDetails()
+	date := time.Date(2026, time.November, 10, 0, 0, 0, 0, time.UTC)
+
+	active, _ := CreateInsurancePolicy(farmUID, crops, details, details.StartDate)
+	claim, _ := active.FileClaim(InsuranceLoss{CropUID: cropUID, Quantity: 4, Date: date})
+	active.FileClaim(InsuranceLoss{CropUID: otherCropUID, Quantity: 2, Date: date})
+	active.SettleClaim(claim.UID, 800, date)
+
+	expiredDetails := newTestInsurancePolicyDetails()
+	expiredDetails.EndDate = time.Date(2026, time.October, 31, 0, 0, 0, 0, time.UTC)
+	expired, _ := CreateInsurancePolicy(farmUID, crops[:1], expiredDetails, details.StartDate)
+
+	removed, _ := CreateInsurancePolicy(farmUID, crops, details, details.StartDate)
+	removed.Remove()
+
+	otherCurrencyDetails := newTestInsurancePolicyDetails()
+	otherCurrencyDetails.Currency = "USD"
+	otherCurrency, _ := CreateInsurancePolicy(farmUID, crops[:1], otherCurrencyDetails, details.StartDate)
+
+	// When
+	summary := NewInsuranceSummary(date)
+	summary.AddPolicy(*active)
+	summary.AddPolicy(*expired)
+	summary.AddPolicy(*removed)
+	summary.AddPolicy(*otherCurrency)
+
+	// Then
+	assert.Equal(t, []InsuranceSummaryRow{{
+		Currency:       "EUR",
+		Policies:       2,
+		ActivePolicies: 1,
+		InsuredCrops:   2,
+		CoverageAmount: 5000,
+		PremiumAmount:  480,
+		Claims:         2,
+		OpenClaims:     1,
+		SettledAmount:  800,
+	}, {
+		Currency:       "USD",
+		Policies:       1,
+		ActivePolicies: 1,
+		InsuredCrops:   1,
+		CoverageAmount: 5000,
+		PremiumAmount:  240,
+	}}, summary.Rows())
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// InsuranceSummaryRow sums the policies of a farm in a currency. The coverage is the one of the policies
+// active at the date of the summary, the premiums are the ones of all the policies.
+type InsuranceSummaryRow struct {
+	Currency       string  `json:"currency"`
+	Policies       int     `json:"policies"`
+	ActivePolicies int     `json:"active_policies"`
+	InsuredCrops   int     `json:"insured_crops"`
+	CoverageAmount float64 `json:"coverage_amount"`
+	PremiumAmount  float64 `json:"premium_amount"`
+	Claims         int     `json:"claims"`
+	OpenClaims     int     `json:"open_claims"`
+	SettledAmount  float64 `json:"settled_amount"`
+}
+
+// InsuranceSummary sums the policies of a farm by currency, the amounts of different currencies aren't added.
+type InsuranceSummary struct {
+	date    time.Time
+	rows    map[string]*InsuranceSummaryRow
+	insured map[string]map[uuid.UUID]bool
+}
+
+// NewInsuranceSummary starts the summary of the policies at the date.
+func NewInsuranceSummary(date time.Time) *InsuranceSummary {
+	return &InsuranceSummary{
+		date:    date,
+		rows:    map[string]*InsuranceSummaryRow{},
+		insured: map[string]map[uuid.UUID]bool{},
+	}
+}
+
+// AddPolicy sums the policy, the removed ones are left out.
+func (s *InsuranceSummary) AddPolicy(policy InsurancePolicy) {
+	if policy.IsRemoved {
+		return
+	}
+
+	row, ok := s.rows[policy.Currency]
+	if !ok {
+		row = &InsuranceSummaryRow{Currency: policy.Currency}
+		s.rows[policy.Currency] = row
+		s.insured[policy.Currency] = map[uuid.UUID]bool{}
+	}
+
+	row.Policies++
+	row.PremiumAmount += policy.PremiumAmount
+
+	if policy.IsActive(s.date) {
+		row.ActivePolicies++
+		row.CoverageAmount += policy.CoverageAmount
+
+		for _, v := range policy.CropIDs {
+			s.insured[policy.Currency][v] = true
+		}
+	}
+
+	for _, v := range policy.Claims {
+		row.Claims++
+
+		if v.Status == InsuranceClaimStatusFiled {
+			row.OpenClaims++
+		}
+	}
+
+	row.SettledAmount += policy.SettledAmount()
+	row.InsuredCrops = len(s.insured[policy.Currency])
+}
+
+// Rows returns the rows in the order of their currency.
+func (s *InsuranceSummary) Rows() []InsuranceSummaryRow {
+	rows := []InsuranceSummaryRow{}
+
+	for _, v := range s.rows {
+		rows = append(rows, *v)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Currency < rows[j].Currency
+	})
+
+	return rows
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"time"
+
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
+)
+
+// LossRow is what the crops of the farm lost in a bucket, the plants dumped and the harvested quantity graded
+// in a grade which isn't sellable. The rejected percentage is of the produced quantity.
+type LossRow struct {
+	timebuckethelper.Bucket
+
+	DumpedQuantity       int     `json:"dumped_quantity"`
+	ProducedGramQuantity float32 `json:"produced_gram_quantity"`
+	RejectedGramQuantity float32 `json:"rejected_gram_quantity"`
+	RejectedPercentage   float32 `json:"rejected_percentage"`
+}
+
+// LossReport sums the losses of the crops in every bucket of the range, including the ones without losses.
+type LossReport struct {
+	buckets  []timebuckethelper.Bucket
+	rows     []LossRow
+	sellable map[string]bool
+}
+
+// NewLossReport starts the report of the buckets with the grades of the farm.
+func NewLossReport(buckets []timebuckethelper.Bucket, grades []assetsdomain.HarvestGrade) *LossReport {
+	report := &LossReport{buckets: buckets, sellable: map[string]bool{}}
+
+	for _, v := range buckets {
+		report.rows = append(report.rows, LossRow{Bucket: v})
+	}
+
+	for _, v := range assetsdomain.HarvestGradesOrDefault(grades) {
+		report.sellable[v.Code] = v.Sellable
+	}
+
+	return report
+}
+
+// AddDump sums the plants dumped on the date, the ones out of the buckets are left out.
+func (r *LossReport) AddDump(dumpDate time.Time, quantity int) {
+	i := timebuckethelper.Find(r.buckets, dumpDate)
+	if i < 0 {
+		return
+	}
+
+	r.rows[i].DumpedQuantity += quantity
+}
+
+// AddHarvest sums the harvest, the grades the farm doesn't define anymore count as not sellable
+// like in the harvest grade report.
+func (r *LossReport) AddHarvest(harvestDate time.Time, producedGramQuantity float32, grades []HarvestedGrade) {
+	i := timebuckethelper.Find(r.buckets, harvestDate)
+	if i < 0 {
+		return
+	}
+
+	r.rows[i].ProducedGramQuantity += producedGramQuantity
+
+	for _, v := range grades {
+		if !r.sellable[v.Grade] {
+			r.rows[i].RejectedGramQuantity += v.GramQuantity
+		}
+	}
+}
+
+// Rows returns the rows in the order of the buckets.
+func (r *LossReport) Rows() []LossRow {
+	rows := append([]LossRow{}, r.rows...)
+
+	for i, v := range rows {
+		if v.ProducedGramQuantity > 0 {
+			rows[i].RejectedPercentage = v.RejectedGramQuantity * 100 / v.ProducedGramQuantity
+		}
+	}
+
+	return rows
+}
@@ -0,0 +1,118 @@
+package domain_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	. "github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
+)
+
+func reportQuery(t *testing.T, params url.Values) timebuckethelper.Query {
+	t.Helper()
+
+	query, err := timebuckethelper.ParseQuery(params.Get, timebuckethelper.IntervalMonth, nil)
+	assert.Nil(t, err)
+
+	return query
+}
+
+func TestLossReport(t *testing.T) {
+	t.Parallel()
+
+	for _, timezone := range []string{"UTC", "Australia/Sydney"} {
+		for _, weekStart := range []string{timebuckethelper.WeekStartMonday, timebuckethelper.WeekStartSunday} {
+			// Given
+			query := reportQuery(t, url.Values{
+				"interval": {"iso_week"}, "timezone": {timezone}, "week_start": {weekStart},
+				"from": {"2026-10-14"}, "to": {"2026-10-27"},
+			})
+			location, _ := time.LoadLocation(timezone)
+
+			buckets, err := query.Buckets(time.Time{}, time.Now())
+			assert.Nil(t, err)
+
+			report := NewLossReport(buckets, assetsdomain.DefaultHarvestGrades())
+
+			// Saturday evening, then Sunday and Monday mornings.
+			saturday := time.Date(2026, time.October, 17, 20, 0, 0, 0, location)
+			sunday := time.Date(2026, time.October, 18, 8, 0, 0, 0, location)
+			monday := time.Date(2026, time.October, 19, 8, 0, 0, 0, location)
+
+			// When
+			report.AddDump(saturday, 5)
+			report.AddDump(sunday, 3)
+			report.AddDump(monday, 2)
+			report.AddDump(time.Date(2026, time.October, 13, 23, 0, 0, 0, location), 100)
+			report.AddHarvest(monday, 1000, []HarvestedGrade{
+				{Grade: "A", GramQuantity: 750},
+				{Grade: "REJECT", GramQuantity: 200},
+				{Grade: "GONE", GramQuantity: 50},
+			})
+
+			rows := report.Rows()
+
+			// Then
+			assert.Len(t, rows, 3)
+			assert.True(t, rows[0].Partial)
+			assert.True(t, rows[2].Partial)
+
+			// The weeks from Sunday are labelled with the ISO week of their Monday.
+			assert.Equal(t, []string{"2026-W42", "2026-W43", "2026-W44"},
+				[]string{rows[0].Label, rows[1].Label, rows[2].Label})
+
+			if weekStart == timebuckethelper.WeekStartMonday {
+				assert.Equal(t, 8, rows[0].DumpedQuantity)
+				assert.Equal(t, 2, rows[1].DumpedQuantity)
+			} else {
+				assert.Equal(t, 5, rows[0].DumpedQuantity)
+				assert.Equal(t, 5, rows[1].DumpedQuantity)
+			}
+
+			assert.Equal(t, float32(1000), rows[1].ProducedGramQuantity)
+			assert.Equal(t, float32(250), rows[1].RejectedGramQuantity)
+			assert.Equal(t, float32(25), rows[1].RejectedPercentage)
+			assert.Equal(t, 0, rows[2].DumpedQuantity)
+		}
+	}
+}
+
+func TestBucketedHarvestGradeReport(t *testing.T) {
+	t.Parallel()
+
+	for _, timezone := range []string{"UTC", "America/New_York"} {
+		for _, weekStart := range []string{timebuckethelper.WeekStartMonday, timebuckethelper.WeekStartSunday} {
+			// Given
+			query := reportQuery(t, url.Values{
+				"interval": {"iso_week"}, "timezone": {timezone}, "week_start": {weekStart}, "from": {"2026-10-14"},
+			})
+			report := NewBucketedHarvestGradeReport(query, assetsdomain.DefaultHarvestGrades())
+
+			// Sunday 02:00 in UTC is still Saturday in New York.
+			sunday := time.Date(2026, time.October, 18, 2, 0, 0, 0, time.UTC)
+
+			// When
+			report.Add("Tomato", sunday, 1000, []HarvestedGrade{{Grade: "A", GramQuantity: 1000}})
+
+			rows := report.Rows()
+
+			// Then
+			assert.Len(t, rows, 1)
+
+			if timezone == "UTC" && weekStart == timebuckethelper.WeekStartSunday {
+				assert.Equal(t, "2026-W43", rows[0].Label)
+				assert.Equal(t, "2026-10-18", rows[0].PeriodStart)
+				assert.False(t, rows[0].Partial)
+			} else {
+				assert.Equal(t, "2026-W42", rows[0].Label)
+				assert.True(t, rows[0].Partial)
+				assert.Equal(t, "2026-10-14", rows[0].Start.Format("2006-01-02"))
+			}
+
+			assert.Equal(t, timezone, rows[0].Start.Location().String())
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+)
+
+const (
+	// MicroclimateAnomalyWindow is how far back the samples a new sample is compared with go.
+	MicroclimateAnomalyWindow = 24 * time.Hour
+
+	// MicroclimateAnomalyMinSamples is how many samples the window needs for their deviation to mean something.
+	MicroclimateAnomalyMinSamples = 6
+)
+
+// MicroclimateSampleRecorded is published once a temperature sample of an area is saved.
+type MicroclimateSampleRecorded struct {
+	UID          uuid.UUID
+	AreaUID      uuid.UUID
+	Temperature  float64
+	RecordedDate time.Time
+}
+
+// MicroclimateAnomalyDetected is a temperature sample too far from the samples of its area in the 24 hours before
+// it, like the spike of a failing heater. The Z-score is positive above the mean and negative below it.
+type MicroclimateAnomalyDetected struct {
+	AreaUID           uuid.UUID
+	AreaName          string
+	FarmUID           uuid.UUID
+	Temperature       float64
+	Mean              float64
+	StandardDeviation float64
+	ZScore            float64
+	Threshold         float64
+	Samples           int
+	RecordedDate      time.Time
+}
+
+// AnomalyDetector flags the temperature samples whose absolute Z-score against the samples of their area
+// in the window before them is above the threshold.
+type AnomalyDetector struct {
+	SampleQuery query.MicroclimateSampleQuery
+	Threshold   float64
+}
+
+func NewAnomalyDetector(sampleQuery query.MicroclimateSampleQuery, threshold float64) AnomalyDetector {
+	return AnomalyDetector{SampleQuery: sampleQuery, Threshold: threshold}
+}
+
+// Detect returns the anomaly of the sample, false when the sample is in line with the window.
+func (d AnomalyDetector) Detect(sample MicroclimateSampleRecorded) (MicroclimateAnomalyDetected, bool, error) {
+	result := <-d.SampleQuery.FindAllByArea(sample.AreaUID, sample.RecordedDate.Add(-MicroclimateAnomalyWindow))
+	if result.Error != nil {
+		return MicroclimateAnomalyDetected{}, false, result.Error
+	}
+
+	samples, ok := result.Result.([]query.MicroclimateSampleQueryResult)
+	if !ok {
+		return MicroclimateAnomalyDetected{}, false, errors.New("internal server error. error type assertion")
+	}
+
+	anomaly, detected := DetectMicroclimateAnomaly(samples, sample, d.Threshold)
+
+	return anomaly, detected, nil
+}
+
+// DetectMicroclimateAnomaly computes the Z-score of the sample against the samples recorded in the window before it,
+// the ones after it and the sample itself are left out. A window with too few samples, or with the same temperature
+// in all of them, can't tell an anomaly.
+func DetectMicroclimateAnomaly(
+	samples []query.MicroclimateSampleQueryResult,
+	sample MicroclimateSampleRecorded,
+	threshold float64,
+) (MicroclimateAnomalyDetected, bool) {
+	// The SQL engines store the dates to the second, the sample comes back from them without its fraction.
+	to := sample.RecordedDate.Truncate(time.Second)
+	from := sample.RecordedDate.Add(-MicroclimateAnomalyWindow)
+	temperatures := []float64{}
+
+	for _, v := range samples {
+		if v.AreaUID == sample.AreaUID && !v.RecordedDate.Before(from) && v.RecordedDate.Before(to) {
+			temperatures = append(temperatures, v.Temperature)
+		}
+	}
+
+	if threshold <= 0 || len(temperatures) < MicroclimateAnomalyMinSamples {
+		return MicroclimateAnomalyDetected{}, false
+	}
+
+	mean := 0.0
+	for _, v := range temperatures {
+		mean += v
+	}
+
+	mean /= float64(len(temperatures))
+
+	variance := 0.0
+	for _, v := range temperatures {
+		variance += (v - mean) * (v - mean)
+	}
+
+	deviation := math.Sqrt(variance / float64(len(temperatures)-1))
+	if deviation == 0 {
+		return MicroclimateAnomalyDetected{}, false
+	}
+
+	zScore := (sample.Temperature - mean) / deviation
+	if math.Abs(zScore) <= threshold {
+		return MicroclimateAnomalyDetected{}, false
+	}
+
+	return MicroclimateAnomalyDetected{
+		AreaUID:           sample.AreaUID,
+		Temperature:       sample.Temperature,
+		Mean:              mean,
+		StandardDeviation: deviation,
+		ZScore:            zScore,
+		Threshold:         threshold,
+		Samples:           len(temperatures),
+		RecordedDate:      sample.RecordedDate,
+	}, true
+}
@@ -0,0 +1,98 @@
+package domain_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+)
+
+// greenhouseDay returns hourly samples of the day before the date, cycling between 20°C at night and 24°C at noon.
+func greenhouseDay(areaUID uuid.UUID, date time.Time) []query.MicroclimateSampleQueryResult {
+	samples := []query.MicroclimateSampleQueryResult{}
+
+	for i := 24; i > 0; i-- {
+		recordedDate := date.Add(-time.Duration(i) * time.Hour)
+
+		samples = append(samples, query.MicroclimateSampleQueryResult{
+			AreaUID:      areaUID,
+			Temperature:  22 - 2*math.Cos(float64(recordedDate.Hour())/24*2*math.Pi),
+			RecordedDate: recordedDate,
+		})
+	}
+
+	return samples
+}
+
+func TestAnomalyDetector(t *testing.T) {
+	t.Parallel()
+	// Given
+	areaUID, _ := uuid.NewV4()
+	date := time.Date(2026, time.October, 14, 12, 0, 0, 0, time.UTC)
+
+	// The samples of a broken heater two days before are out of the window.
+	samples := greenhouseDay(areaUID, date)
+	samples = append(samples, query.MicroclimateSampleQueryResult{
+		AreaUID: areaUID, Temperature: 45, RecordedDate: date.Add(-48 * time.Hour),
+	})
+
+	detector := NewAnomalyDetector(sampleQuery{areaUID: samples}, 3.0)
+
+	// When
+	spike, spikeDetected, err := detector.Detect(MicroclimateSampleRecorded{
+		AreaUID: areaUID, Temperature: 35, RecordedDate: date,
+	})
+	drop, dropDetected, dropErr := detector.Detect(MicroclimateSampleRecorded{
+		AreaUID: areaUID, Temperature: 8, RecordedDate: date,
+	})
+	_, usualDetected, usualErr := detector.Detect(MicroclimateSampleRecorded{
+		AreaUID: areaUID, Temperature: 23.5, RecordedDate: date,
+	})
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, spikeDetected)
+	assert.Equal(t, 24, spike.Samples)
+	assert.InDelta(t, 22, spike.Mean, 0.01)
+	assert.Greater(t, spike.ZScore, 3.0)
+	assert.Equal(t, 3.0, spike.Threshold)
+
+	assert.Nil(t, dropErr)
+	assert.True(t, dropDetected)
+	assert.Less(t, drop.ZScore, -3.0)
+
+	assert.Nil(t, usualErr)
+	assert.False(t, usualDetected)
+}
+
+func TestDetectMicroclimateAnomalyNeedsAWindow(t *testing.T) {
+	t.Parallel()
+	// Given
+	areaUID, _ := uuid.NewV4()
+	date := time.Date(2026, time.October, 14, 12, 0, 0, 0, time.UTC)
+	sample := MicroclimateSampleRecorded{AreaUID: areaUID, Temperature: 35, RecordedDate: date}
+
+	flat := []query.MicroclimateSampleQueryResult{}
+	for i := 1; i <= 12; i++ {
+		flat = append(flat, query.MicroclimateSampleQueryResult{
+			AreaUID: areaUID, Temperature: 21, RecordedDate: date.Add(-time.Duration(i) * time.Hour),
+		})
+	}
+
+	// When
+	// Five samples, a flat window, the detection turned off and samples all recorded after the sample.
+	_, fewDetected := DetectMicroclimateAnomaly(greenhouseDay(areaUID, date)[19:], sample, 3.0)
+	_, flatDetected := DetectMicroclimateAnomaly(flat, sample, 3.0)
+	_, offDetected := DetectMicroclimateAnomaly(greenhouseDay(areaUID, date), sample, 0)
+	_, laterDetected := DetectMicroclimateAnomaly(greenhouseDay(areaUID, date.Add(6*time.Hour))[18:], sample, 3.0)
+
+	// Then
+	assert.False(t, fewDetected)
+	assert.False(t, flatDetected)
+	assert.False(t, offDetected)
+	assert.False(t, laterDetected)
+}
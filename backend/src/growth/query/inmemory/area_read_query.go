@@ -32,6 +32,7 @@ func (s AreaReadQueryInMemory) FindByID(uid uuid.UUID) <-chan query.Result {
 				area.Type = val.Type
 				area.Location = val.Location.Code
 				area.FarmUID = val.Farm.UID
+				area.LastSanitizedDate = val.LastSanitizedDate
 			}
 		}
 
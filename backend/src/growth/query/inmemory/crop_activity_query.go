@@ -1,6 +1,9 @@
 package inmemory
 
 import (
+	"sort"
+	"time"
+
 	"github.com/gofrs/uuid"
 	"github.com/usetania/tania-core/src/growth/query"
 	"github.com/usetania/tania-core/src/growth/storage"
@@ -68,3 +71,30 @@ func (s CropActivityQueryInMemory) FindByCropIDAndActivityType(
 
 	return result
 }
+
+func (s CropActivityQueryInMemory) FindAllByDate(from, to time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		activities := []storage.CropActivity{}
+
+		for _, val := range s.Storage.CropActivityMap {
+			if !val.CreatedDate.Before(from) && val.CreatedDate.Before(to) {
+				activities = append(activities, val)
+			}
+		}
+
+		sort.SliceStable(activities, func(i, j int) bool {
+			return activities[i].CreatedDate.Before(activities[j].CreatedDate)
+		})
+
+		result <- query.Result{Result: activities}
+
+		close(result)
+	}()
+
+	return result
+}
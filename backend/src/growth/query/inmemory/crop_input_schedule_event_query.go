@@ -0,0 +1,42 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type CropInputScheduleEventQueryInMemory struct {
+	Storage *storage.CropInputScheduleEventStorage
+}
+
+func NewCropInputScheduleEventQueryInMemory(s *storage.CropInputScheduleEventStorage) query.CropInputScheduleEventQuery {
+	return &CropInputScheduleEventQueryInMemory{Storage: s}
+}
+
+func (f *CropInputScheduleEventQueryInMemory) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		f.Storage.Lock.RLock()
+		defer f.Storage.Lock.RUnlock()
+
+		events := []storage.CropInputScheduleEvent{}
+
+		for _, v := range f.Storage.CropInputScheduleEvents {
+			if v.CropInputScheduleUID == uid {
+				events = append(events, v)
+			}
+		}
+
+		sort.Slice(events, func(i, j int) bool {
+			return events[i].Version < events[j].Version
+		})
+
+		result <- query.Result{Result: events}
+	}()
+
+	return result
+}
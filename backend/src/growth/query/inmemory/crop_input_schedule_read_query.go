@@ -0,0 +1,121 @@
+package inmemory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type CropInputScheduleReadQueryInMemory struct {
+	Storage *storage.CropInputScheduleReadStorage
+}
+
+func NewCropInputScheduleReadQueryInMemory(s *storage.CropInputScheduleReadStorage) query.CropInputScheduleReadQuery {
+	return CropInputScheduleReadQueryInMemory{Storage: s}
+}
+
+func (s CropInputScheduleReadQueryInMemory) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		schedule := storage.CropInputScheduleRead{}
+
+		for _, val := range s.Storage.CropInputScheduleReadMap {
+			if val.UID == uid {
+				schedule = val
+			}
+		}
+
+		result <- query.Result{Result: schedule}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (s CropInputScheduleReadQueryInMemory) FindByTaskID(taskUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		schedule := storage.CropInputScheduleRead{}
+
+		for _, val := range s.Storage.CropInputScheduleReadMap {
+			if val.TaskUID != nil && *val.TaskUID == taskUID {
+				schedule = val
+			}
+		}
+
+		result <- query.Result{Result: schedule}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (s CropInputScheduleReadQueryInMemory) FindAllByCropID(cropUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		schedules := []storage.CropInputScheduleRead{}
+
+		for _, val := range s.Storage.CropInputScheduleReadMap {
+			if val.CropUID == cropUID {
+				schedules = append(schedules, val)
+			}
+		}
+
+		sortByPlannedDate(schedules)
+
+		result <- query.Result{Result: schedules}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (s CropInputScheduleReadQueryInMemory) FindAllDue(now time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		schedules := []storage.CropInputScheduleRead{}
+
+		for _, val := range s.Storage.CropInputScheduleReadMap {
+			if val.Status == domain.InputScheduleStatusPlanned && !val.PlannedDate.After(now) {
+				schedules = append(schedules, val)
+			}
+		}
+
+		sortByPlannedDate(schedules)
+
+		result <- query.Result{Result: schedules}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func sortByPlannedDate(schedules []storage.CropInputScheduleRead) {
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].PlannedDate.Before(schedules[j].PlannedDate)
+	})
+}
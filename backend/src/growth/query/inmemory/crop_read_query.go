@@ -2,6 +2,7 @@ package inmemory
 
 import (
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/domain"
 	"github.com/usetania/tania-core/src/growth/query"
 	"github.com/usetania/tania-core/src/growth/storage"
 )
@@ -60,6 +61,32 @@ func (s CropReadQueryInMemory) FindByBatchID(batchID string) <-chan query.Result
 	return result
 }
 
+func (s CropReadQueryInMemory) FindAllByShortCode(shortCode string) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		crops := []query.CropShortCodeQueryResult{}
+
+		for _, val := range s.Storage.CropReadMap {
+			if val.ShortCode == shortCode {
+				crops = append(crops, query.CropShortCodeQueryResult{
+					UID:     val.UID,
+					FarmUID: val.FarmUID,
+				})
+			}
+		}
+
+		result <- query.Result{Result: crops}
+
+		close(result)
+	}()
+
+	return result
+}
+
 func (s CropReadQueryInMemory) FindAllCropsByFarm(farmUID uuid.UUID, _ string, _, _ int) <-chan query.Result {
 	result := make(chan query.Result)
 
@@ -237,6 +264,7 @@ func (s CropReadQueryInMemory) FindAllCropsByArea(areaUID uuid.UUID) <-chan quer
 				crops = append(crops, query.CropAreaByAreaQueryResult{
 					UID:         val.UID,
 					BatchID:     val.BatchID,
+					ShortCode:   val.ShortCode,
 					CreatedDate: val.InitialArea.CreatedDate,
 					Area: query.Area{
 						UID:             val.InitialArea.AreaUID,
@@ -269,6 +297,7 @@ func (s CropReadQueryInMemory) FindAllCropsByArea(areaUID uuid.UUID) <-chan quer
 					crops = append(crops, query.CropAreaByAreaQueryResult{
 						UID:         val.UID,
 						BatchID:     val.BatchID,
+						ShortCode:   val.ShortCode,
 						CreatedDate: val.MovedArea[i].CreatedDate,
 						Area: query.Area{
 							UID:             val.MovedArea[i].AreaUID,
@@ -375,3 +404,29 @@ func (s CropReadQueryInMemory) CountTotalBatch(farmUID uuid.UUID) <-chan query.R
 
 	return result
 }
+
+// FindAllPendingPhotos lists the photos of every crop still waiting for their thumbnail.
+func (s CropReadQueryInMemory) FindAllPendingPhotos() <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		photos := []query.CropPendingPhotoQueryResult{}
+
+		for _, val := range s.Storage.CropReadMap {
+			for _, v := range val.Photos {
+				if v.Status == domain.CropPhotoStatusPending {
+					photos = append(photos, query.CropPendingPhotoQueryResult{CropUID: val.UID, PhotoUID: v.UID})
+				}
+			}
+		}
+
+		result <- query.Result{Result: photos}
+
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,42 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type DispatchScheduleEventQueryInMemory struct {
+	Storage *storage.DispatchScheduleEventStorage
+}
+
+func NewDispatchScheduleEventQueryInMemory(s *storage.DispatchScheduleEventStorage) query.DispatchScheduleEventQuery {
+	return &DispatchScheduleEventQueryInMemory{Storage: s}
+}
+
+func (f *DispatchScheduleEventQueryInMemory) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		f.Storage.Lock.RLock()
+		defer f.Storage.Lock.RUnlock()
+
+		events := []storage.DispatchScheduleEvent{}
+
+		for _, v := range f.Storage.DispatchScheduleEvents {
+			if v.DispatchScheduleUID == uid {
+				events = append(events, v)
+			}
+		}
+
+		sort.Slice(events, func(i, j int) bool {
+			return events[i].Version < events[j].Version
+		})
+
+		result <- query.Result{Result: events}
+	}()
+
+	return result
+}
@@ -0,0 +1,82 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type DispatchScheduleReadQueryInMemory struct {
+	Storage *storage.DispatchScheduleReadStorage
+}
+
+func NewDispatchScheduleReadQueryInMemory(s *storage.DispatchScheduleReadStorage) query.DispatchScheduleReadQuery {
+	return DispatchScheduleReadQueryInMemory{Storage: s}
+}
+
+func (s DispatchScheduleReadQueryInMemory) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		result <- query.Result{Result: s.Storage.DispatchScheduleReadMap[uid]}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (s DispatchScheduleReadQueryInMemory) FindByTaskID(taskUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		schedule := storage.DispatchScheduleRead{}
+
+		for _, val := range s.Storage.DispatchScheduleReadMap {
+			if val.TaskUID != nil && *val.TaskUID == taskUID {
+				schedule = val
+			}
+		}
+
+		result <- query.Result{Result: schedule}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (s DispatchScheduleReadQueryInMemory) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		schedules := []storage.DispatchScheduleRead{}
+
+		for _, val := range s.Storage.DispatchScheduleReadMap {
+			if val.FarmUID == farmUID {
+				schedules = append(schedules, val)
+			}
+		}
+
+		sort.Slice(schedules, func(i, j int) bool {
+			return schedules[i].PickupTime.Before(schedules[j].PickupTime)
+		})
+
+		result <- query.Result{Result: schedules}
+
+		close(result)
+	}()
+
+	return result
+}
@@ -2,6 +2,7 @@ package inmemory
 
 import (
 	"github.com/gofrs/uuid"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
 	"github.com/usetania/tania-core/src/assets/storage"
 	"github.com/usetania/tania-core/src/growth/query"
 )
@@ -27,6 +28,9 @@ func (s FarmReadQueryInMemory) FindByID(uid uuid.UUID) <-chan query.Result {
 			if val.UID == uid {
 				farm.UID = uid
 				farm.Name = val.Name
+				farm.HarvestGrades = assetsdomain.HarvestGradesOrDefault(val.HarvestGrades)
+				farm.Seasons = assetsdomain.SeasonsOrDefault(val.Seasons, val.Latitude)
+				farm.WithholdingPolicy = assetsdomain.WithholdingPolicyOrDefault(val.WithholdingPolicy)
 			}
 		}
 
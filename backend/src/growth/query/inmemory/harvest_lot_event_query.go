@@ -0,0 +1,42 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type HarvestLotEventQueryInMemory struct {
+	Storage *storage.HarvestLotEventStorage
+}
+
+func NewHarvestLotEventQueryInMemory(s *storage.HarvestLotEventStorage) query.HarvestLotEventQuery {
+	return &HarvestLotEventQueryInMemory{Storage: s}
+}
+
+func (f *HarvestLotEventQueryInMemory) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		f.Storage.Lock.RLock()
+		defer f.Storage.Lock.RUnlock()
+
+		events := []storage.HarvestLotEvent{}
+
+		for _, v := range f.Storage.HarvestLotEvents {
+			if v.HarvestLotUID == uid {
+				events = append(events, v)
+			}
+		}
+
+		sort.Slice(events, func(i, j int) bool {
+			return events[i].Version < events[j].Version
+		})
+
+		result <- query.Result{Result: events}
+	}()
+
+	return result
+}
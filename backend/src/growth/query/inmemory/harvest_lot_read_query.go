@@ -0,0 +1,73 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type HarvestLotReadQueryInMemory struct {
+	Storage *storage.HarvestLotReadStorage
+}
+
+func NewHarvestLotReadQueryInMemory(s *storage.HarvestLotReadStorage) query.HarvestLotReadQuery {
+	return HarvestLotReadQueryInMemory{Storage: s}
+}
+
+func (s HarvestLotReadQueryInMemory) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		result <- query.Result{Result: s.Storage.HarvestLotReadMap[uid]}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (s HarvestLotReadQueryInMemory) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		lots := []storage.HarvestLotRead{}
+
+		for _, val := range s.Storage.HarvestLotReadMap {
+			if val.FarmUID == farmUID {
+				lots = append(lots, val)
+			}
+		}
+
+		sortHarvestLots(lots)
+
+		result <- query.Result{Result: lots}
+
+		close(result)
+	}()
+
+	return result
+}
+
+// sortHarvestLots sorts the latest harvest first, the lots of a harvest by number.
+func sortHarvestLots(lots []storage.HarvestLotRead) {
+	sort.Slice(lots, func(i, j int) bool {
+		if !lots[i].HarvestDate.Equal(lots[j].HarvestDate) {
+			return lots[i].HarvestDate.After(lots[j].HarvestDate)
+		}
+
+		// L-9 comes before L-10.
+		if len(lots[i].LotNumber) != len(lots[j].LotNumber) {
+			return len(lots[i].LotNumber) < len(lots[j].LotNumber)
+		}
+
+		return lots[i].LotNumber < lots[j].LotNumber
+	})
+}
@@ -0,0 +1,42 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type InsurancePolicyEventQueryInMemory struct {
+	Storage *storage.InsurancePolicyEventStorage
+}
+
+func NewInsurancePolicyEventQueryInMemory(s *storage.InsurancePolicyEventStorage) query.InsurancePolicyEventQuery {
+	return &InsurancePolicyEventQueryInMemory{Storage: s}
+}
+
+func (f *InsurancePolicyEventQueryInMemory) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		f.Storage.Lock.RLock()
+		defer f.Storage.Lock.RUnlock()
+
+		events := []storage.InsurancePolicyEvent{}
+
+		for _, v := range f.Storage.InsurancePolicyEvents {
+			if v.InsurancePolicyUID == uid {
+				events = append(events, v)
+			}
+		}
+
+		sort.Slice(events, func(i, j int) bool {
+			return events[i].Version < events[j].Version
+		})
+
+		result <- query.Result{Result: events}
+	}()
+
+	return result
+}
@@ -0,0 +1,65 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type InsurancePolicyReadQueryInMemory struct {
+	Storage *storage.InsurancePolicyReadStorage
+}
+
+func NewInsurancePolicyReadQueryInMemory(s *storage.InsurancePolicyReadStorage) query.InsurancePolicyReadQuery {
+	return InsurancePolicyReadQueryInMemory{Storage: s}
+}
+
+func (s InsurancePolicyReadQueryInMemory) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		policy := storage.InsurancePolicyRead{}
+
+		if val, ok := s.Storage.InsurancePolicyReadMap[uid]; ok && !val.IsDeleted {
+			policy = val
+		}
+
+		result <- query.Result{Result: policy}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (s InsurancePolicyReadQueryInMemory) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		policies := []storage.InsurancePolicyRead{}
+
+		for _, val := range s.Storage.InsurancePolicyReadMap {
+			if val.FarmUID == farmUID && !val.IsDeleted {
+				policies = append(policies, val)
+			}
+		}
+
+		sort.Slice(policies, func(i, j int) bool {
+			return policies[i].StartDate.Before(policies[j].StartDate)
+		})
+
+		result <- query.Result{Result: policies}
+
+		close(result)
+	}()
+
+	return result
+}
@@ -29,6 +29,8 @@ func (q MaterialReadQueryInMemory) FindByID(inventoryUID uuid.UUID) <-chan query
 				ci.UID = val.UID
 				ci.Name = val.Name
 				ci.TypeCode = val.Type.Code()
+				ci.GDDToMaturity = val.GDDToMaturity
+				ci.PreHarvestIntervalDays = val.PreHarvestIntervalDays
 
 				// WARNING, domain leakage
 				switch v := val.Type.(type) {
@@ -66,6 +68,7 @@ func (q MaterialReadQueryInMemory) FindMaterialByPlantTypeCodeAndName(plantTypeC
 					ci.Name = val.Name
 					ci.TypeCode = val.Type.Code()
 					ci.PlantTypeCode = v.PlantType.Code
+					ci.GDDToMaturity = val.GDDToMaturity
 				}
 			case assetsdomain.MaterialTypePlant:
 				if v.PlantType.Code == plantTypeCode && val.Name == name {
@@ -73,6 +76,7 @@ func (q MaterialReadQueryInMemory) FindMaterialByPlantTypeCodeAndName(plantTypeC
 					ci.Name = val.Name
 					ci.TypeCode = val.Type.Code()
 					ci.PlantTypeCode = v.PlantType.Code
+					ci.GDDToMaturity = val.GDDToMaturity
 				}
 			}
 		}
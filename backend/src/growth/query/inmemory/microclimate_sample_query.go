@@ -0,0 +1,80 @@
+package inmemory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type MicroclimateSampleQueryInMemory struct {
+	Storage *storage.MicroclimateSampleStorage
+}
+
+func NewMicroclimateSampleQueryInMemory(s *storage.MicroclimateSampleStorage) query.MicroclimateSampleQuery {
+	return MicroclimateSampleQueryInMemory{Storage: s}
+}
+
+func (q MicroclimateSampleQueryInMemory) FindAllByArea(areaUID uuid.UUID, from time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		q.Storage.Lock.RLock()
+		defer q.Storage.Lock.RUnlock()
+
+		samples := []query.MicroclimateSampleQueryResult{}
+
+		for _, v := range q.Storage.MicroclimateSamples {
+			if v.AreaUID == areaUID && !v.RecordedDate.Before(from) {
+				samples = append(samples, query.MicroclimateSampleQueryResult{
+					AreaUID:      v.AreaUID,
+					Temperature:  v.Temperature,
+					RecordedDate: v.RecordedDate,
+				})
+			}
+		}
+
+		sort.Slice(samples, func(i, j int) bool {
+			return samples[i].RecordedDate.Before(samples[j].RecordedDate)
+		})
+
+		result <- query.Result{Result: samples}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (q MicroclimateSampleQueryInMemory) FindAllByDate(from, to time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		q.Storage.Lock.RLock()
+		defer q.Storage.Lock.RUnlock()
+
+		samples := []query.MicroclimateSampleQueryResult{}
+
+		for _, v := range q.Storage.MicroclimateSamples {
+			if !v.RecordedDate.Before(from) && v.RecordedDate.Before(to) {
+				samples = append(samples, query.MicroclimateSampleQueryResult{
+					AreaUID:      v.AreaUID,
+					Temperature:  v.Temperature,
+					RecordedDate: v.RecordedDate,
+				})
+			}
+		}
+
+		sort.Slice(samples, func(i, j int) bool {
+			return samples[i].RecordedDate.Before(samples[j].RecordedDate)
+		})
+
+		result <- query.Result{Result: samples}
+
+		close(result)
+	}()
+
+	return result
+}
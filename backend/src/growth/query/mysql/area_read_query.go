@@ -24,6 +24,8 @@ type areaReadResult struct {
 	Type     string
 	Location string
 	FarmUID  []byte
+
+	LastSanitizedDate sql.NullTime
 }
 
 func (s AreaReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
@@ -33,7 +35,7 @@ func (s AreaReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
 		areaQueryResult := query.CropAreaQueryResult{}
 		rowsData := areaReadResult{}
 
-		err := s.DB.QueryRow(`SELECT UID, NAME, SIZE, SIZE_UNIT, TYPE, LOCATION, FARM_UID
+		err := s.DB.QueryRow(`SELECT UID, NAME, SIZE, SIZE_UNIT, TYPE, LOCATION, FARM_UID, LAST_SANITIZED_DATE
 			FROM AREA_READ WHERE UID = ?`, uid.Bytes()).Scan(
 			&rowsData.UID,
 			&rowsData.Name,
@@ -42,6 +44,7 @@ func (s AreaReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
 			&rowsData.Type,
 			&rowsData.Location,
 			&rowsData.FarmUID,
+			&rowsData.LastSanitizedDate,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -70,6 +73,10 @@ func (s AreaReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
 		areaQueryResult.Location = rowsData.Location
 		areaQueryResult.FarmUID = farmUID
 
+		if rowsData.LastSanitizedDate.Valid {
+			areaQueryResult.LastSanitizedDate = &rowsData.LastSanitizedDate.Time
+		}
+
 		result <- query.Result{Result: areaQueryResult}
 		close(result)
 	}()
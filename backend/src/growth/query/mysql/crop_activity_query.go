@@ -78,6 +78,7 @@ func (s CropActivityQueryMysql) FindAllByCropID(uid uuid.UUID) <-chan query.Resu
 				ActivityType:  activityType,
 				CreatedDate:   rowsData.CreatedDate,
 				Description:   rowsData.Description,
+				Actor:         wrapper.Actor,
 			})
 		}
 
@@ -144,6 +145,7 @@ func (s CropActivityQueryMysql) FindByCropIDAndActivityType(
 				ActivityType:  activityType,
 				CreatedDate:   rowsData.CreatedDate,
 				Description:   rowsData.Description,
+				Actor:         wrapper.Actor,
 			}
 		}
 
@@ -153,3 +155,81 @@ func (s CropActivityQueryMysql) FindByCropIDAndActivityType(
 
 	return result
 }
+
+func (s CropActivityQueryMysql) FindAllByDate(from, to time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		cropActivities := []storage.CropActivity{}
+
+		rows, err := s.DB.Query(`SELECT CROP_UID, BATCH_ID, CONTAINER_TYPE, ACTIVITY_TYPE, CREATED_DATE, DESCRIPTION
+			FROM CROP_ACTIVITY WHERE CREATED_DATE >= ? AND CREATED_DATE < ? ORDER BY CREATED_DATE ASC`, from, to)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			rowsData := cropActivityResult{}
+
+			err = rows.Scan(
+				&rowsData.CropUID,
+				&rowsData.BatchID,
+				&rowsData.ContainerType,
+				&rowsData.ActivityType,
+				&rowsData.CreatedDate,
+				&rowsData.Description,
+			)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			wrapper := decoder.CropActivityTypeWrapper{}
+
+			err = json.Unmarshal(rowsData.ActivityType, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			activityType, ok := wrapper.Data.(storage.ActivityType)
+			if !ok {
+				result <- query.Result{Error: errors.New("error type assertion")}
+				close(result)
+
+				return
+			}
+
+			cropUID, err := uuid.FromBytes(rowsData.CropUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			cropActivities = append(cropActivities, storage.CropActivity{
+				UID:           cropUID,
+				BatchID:       rowsData.BatchID,
+				ContainerType: rowsData.ContainerType,
+				ActivityType:  activityType,
+				CreatedDate:   rowsData.CreatedDate,
+				Description:   rowsData.Description,
+				Actor:         wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: cropActivities}
+		close(result)
+	}()
+
+	return result
+}
@@ -58,6 +58,7 @@ func (f *CropEventQueryMysql) FindAllByCropID(uid uuid.UUID) <-chan query.Result
 				Version:     rowsData.Version,
 				CreatedDate: rowsData.CreatedDate,
 				Event:       wrapper.Data,
+				Actor:       wrapper.Actor,
 			})
 		}
 
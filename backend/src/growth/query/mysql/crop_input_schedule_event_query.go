@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/decoder"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type CropInputScheduleEventQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewCropInputScheduleEventQueryMysql(db *sql.DB) query.CropInputScheduleEventQuery {
+	return &CropInputScheduleEventQueryMysql{DB: db}
+}
+
+func (f *CropInputScheduleEventQueryMysql) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.CropInputScheduleEvent{}
+
+		rows, err := f.DB.Query("SELECT * FROM CROP_INPUT_SCHEDULE_EVENT WHERE CROP_INPUT_SCHEDULE_UID = ? ORDER BY VERSION ASC", uid.Bytes())
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		rowsData := struct {
+			ID                   int
+			CropInputScheduleUID []byte
+			Version              int
+			CreatedDate          time.Time
+			Event                []byte
+		}{}
+
+		for rows.Next() {
+			rows.Scan(&rowsData.ID, &rowsData.CropInputScheduleUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event)
+
+			wrapper := decoder.CropInputScheduleEventWrapper{}
+
+			err = json.Unmarshal(rowsData.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			scheduleUID, err := uuid.FromBytes(rowsData.CropInputScheduleUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			events = append(events, storage.CropInputScheduleEvent{
+				CropInputScheduleUID: scheduleUID,
+				Version:              rowsData.Version,
+				CreatedDate:          rowsData.CreatedDate,
+				Event:                wrapper.Data,
+				Actor:                wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
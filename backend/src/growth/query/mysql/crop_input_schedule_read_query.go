@@ -0,0 +1,174 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+const cropInputScheduleReadColumns = `UID, CROP_UID, MATERIAL_UID, PLANNED_DATE, QUANTITY, UNIT,
+	APPLICATION_METHOD, STATUS, TASK_UID, APPLIED_DATE, CREATED_DATE`
+
+type CropInputScheduleReadQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewCropInputScheduleReadQueryMysql(db *sql.DB) query.CropInputScheduleReadQuery {
+	return CropInputScheduleReadQueryMysql{DB: db}
+}
+
+func (s CropInputScheduleReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
+	return s.findOne(`SELECT `+cropInputScheduleReadColumns+`
+		FROM CROP_INPUT_SCHEDULE_READ WHERE UID = ?`, uid.Bytes())
+}
+
+func (s CropInputScheduleReadQueryMysql) FindByTaskID(taskUID uuid.UUID) <-chan query.Result {
+	return s.findOne(`SELECT `+cropInputScheduleReadColumns+`
+		FROM CROP_INPUT_SCHEDULE_READ WHERE TASK_UID = ?`, taskUID.Bytes())
+}
+
+func (s CropInputScheduleReadQueryMysql) FindAllByCropID(cropUID uuid.UUID) <-chan query.Result {
+	return s.findAll(`SELECT `+cropInputScheduleReadColumns+`
+		FROM CROP_INPUT_SCHEDULE_READ WHERE CROP_UID = ? ORDER BY PLANNED_DATE ASC`, cropUID.Bytes())
+}
+
+func (s CropInputScheduleReadQueryMysql) FindAllDue(now time.Time) <-chan query.Result {
+	return s.findAll(`SELECT `+cropInputScheduleReadColumns+`
+		FROM CROP_INPUT_SCHEDULE_READ WHERE STATUS = ? AND PLANNED_DATE <= ?
+		ORDER BY PLANNED_DATE ASC`, domain.InputScheduleStatusPlanned, now)
+}
+
+func (s CropInputScheduleReadQueryMysql) findOne(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		schedule := storage.CropInputScheduleRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			schedule, err = populateCropInputScheduleRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+		}
+
+		result <- query.Result{Result: schedule}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s CropInputScheduleReadQueryMysql) findAll(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		schedules := []storage.CropInputScheduleRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			schedule, err := populateCropInputScheduleRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			schedules = append(schedules, schedule)
+		}
+
+		result <- query.Result{Result: schedules}
+		close(result)
+	}()
+
+	return result
+}
+
+func populateCropInputScheduleRead(rows *sql.Rows) (storage.CropInputScheduleRead, error) {
+	rowsData := struct {
+		UID               []byte
+		CropUID           []byte
+		MaterialUID       []byte
+		PlannedDate       time.Time
+		Quantity          float64
+		Unit              string
+		ApplicationMethod string
+		Status            string
+		TaskUID           []byte
+		AppliedDate       sql.NullTime
+		CreatedDate       time.Time
+	}{}
+
+	err := rows.Scan(
+		&rowsData.UID, &rowsData.CropUID, &rowsData.MaterialUID, &rowsData.PlannedDate,
+		&rowsData.Quantity, &rowsData.Unit, &rowsData.ApplicationMethod, &rowsData.Status,
+		&rowsData.TaskUID, &rowsData.AppliedDate, &rowsData.CreatedDate,
+	)
+	if err != nil {
+		return storage.CropInputScheduleRead{}, err
+	}
+
+	schedule := storage.CropInputScheduleRead{
+		PlannedDate:       rowsData.PlannedDate,
+		Quantity:          rowsData.Quantity,
+		Unit:              rowsData.Unit,
+		ApplicationMethod: rowsData.ApplicationMethod,
+		Status:            rowsData.Status,
+		CreatedDate:       rowsData.CreatedDate,
+	}
+
+	schedule.UID, err = uuid.FromBytes(rowsData.UID)
+	if err != nil {
+		return storage.CropInputScheduleRead{}, err
+	}
+
+	schedule.CropUID, err = uuid.FromBytes(rowsData.CropUID)
+	if err != nil {
+		return storage.CropInputScheduleRead{}, err
+	}
+
+	schedule.MaterialUID, err = uuid.FromBytes(rowsData.MaterialUID)
+	if err != nil {
+		return storage.CropInputScheduleRead{}, err
+	}
+
+	if len(rowsData.TaskUID) > 0 {
+		taskUID, err := uuid.FromBytes(rowsData.TaskUID)
+		if err != nil {
+			return storage.CropInputScheduleRead{}, err
+		}
+
+		schedule.TaskUID = &taskUID
+	}
+
+	if rowsData.AppliedDate.Valid {
+		appliedDate := rowsData.AppliedDate.Time
+		schedule.AppliedDate = &appliedDate
+	}
+
+	return schedule, nil
+}
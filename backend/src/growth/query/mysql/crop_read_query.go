@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -46,17 +47,25 @@ type cropReadResult struct {
 	InitialAreaLastPruned      sql.NullString
 	InitialAreaCreatedDate     time.Time
 	InitialAreaLastUpdated     time.Time
+	ShortCode                  sql.NullString
+	SeedsSown                  sql.NullInt64
+	GerminatedQuantity         sql.NullInt64
+	GerminationDate            sql.NullTime
+	MergedIntoUID              []byte
 }
 
 type cropReadPhotoResult struct {
-	UID         []byte
-	CropUID     []byte
-	Filename    string
-	Mimetype    string
-	Size        int
-	Width       int
-	Height      int
-	Description string
+	UID               []byte
+	CropUID           []byte
+	Filename          string
+	Mimetype          string
+	Size              int
+	Width             int
+	Height            int
+	Description       string
+	Status            sql.NullString
+	ThumbnailFilename sql.NullString
+	Metadata          sql.NullString
 }
 
 type cropReadMovedAreaResult struct {
@@ -95,6 +104,16 @@ type cropReadTrashResult struct {
 	LastUpdated    time.Time
 }
 
+type cropReadNurseryStageResult struct {
+	UID                    []byte
+	CropUID                []byte
+	AreaUID                []byte
+	AreaName               string
+	StartDate              time.Time
+	ExpectedTransplantDate time.Time
+	ActualTransplantDate   sql.NullTime
+}
+
 type cropReadNotesResult struct {
 	UID         []byte
 	CropUID     []byte
@@ -138,6 +157,11 @@ func (s CropReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
 			result <- query.Result{Error: err}
 		}
 
+		err = s.populateCropNurseryStages(uid, &cropRead)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
 		result <- query.Result{Result: cropRead}
 		close(result)
 	}()
@@ -180,6 +204,48 @@ func (s CropReadQueryMysql) FindByBatchID(batchID string) <-chan query.Result {
 	return result
 }
 
+func (s CropReadQueryMysql) FindAllByShortCode(shortCode string) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		crops := []query.CropShortCodeQueryResult{}
+
+		rows, err := s.DB.Query(`SELECT UID, FARM_UID FROM CROP_READ WHERE SHORT_CODE = ?`, shortCode)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		for rows.Next() {
+			var uid, farmUID []byte
+
+			err := rows.Scan(&uid, &farmUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			cropUID, err := uuid.FromBytes(uid)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			cropFarmUID, err := uuid.FromBytes(farmUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			crops = append(crops, query.CropShortCodeQueryResult{
+				UID:     cropUID,
+				FarmUID: cropFarmUID,
+			})
+		}
+
+		result <- query.Result{Result: crops}
+		close(result)
+	}()
+
+	return result
+}
+
 func (s CropReadQueryMysql) FindAllCropsByFarm(farmUID uuid.UUID, status string, page, limit int) <-chan query.Result {
 	result := make(chan query.Result)
 
@@ -249,6 +315,11 @@ func (s CropReadQueryMysql) FindAllCropsByFarm(farmUID uuid.UUID, status string,
 				result <- query.Result{Error: err}
 			}
 
+			err = s.populateCropNurseryStages(cropUID, &cropRead)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
 			err = s.populateCropPhotos(cropUID, &cropRead)
 			if err != nil {
 				result <- query.Result{Error: err}
@@ -350,6 +421,11 @@ func (s CropReadQueryMysql) FindAllCropsArchives(farmUID uuid.UUID, page, limit
 				result <- query.Result{Error: err}
 			}
 
+			err = s.populateCropNurseryStages(cropUID, &cropRead)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
 			err = s.populateCropPhotos(cropUID, &cropRead)
 			if err != nil {
 				result <- query.Result{Error: err}
@@ -419,6 +495,7 @@ func (s CropReadQueryMysql) FindAllCropsByArea(areaUID uuid.UUID) <-chan query.R
 				crops = append(crops, query.CropAreaByAreaQueryResult{
 					UID:         cropRead.UID,
 					BatchID:     cropRead.BatchID,
+					ShortCode:   cropRead.ShortCode,
 					CreatedDate: cropRead.InitialArea.CreatedDate,
 					Area: query.Area{
 						UID:             cropRead.InitialArea.AreaUID,
@@ -484,6 +561,7 @@ func (s CropReadQueryMysql) FindAllCropsByArea(areaUID uuid.UUID) <-chan query.R
 					crops = append(crops, query.CropAreaByAreaQueryResult{
 						UID:         cropRead.UID,
 						BatchID:     cropRead.BatchID,
+						ShortCode:   cropRead.ShortCode,
 						CreatedDate: val.CreatedDate,
 						Area: query.Area{
 							UID:             val.AreaUID,
@@ -642,7 +720,8 @@ func (s CropReadQueryMysql) populateCrop(cropUID uuid.UUID, cropRead *storage.Cr
 		INITIAL_AREA_UID, INITIAL_AREA_NAME,
 		INITIAL_AREA_INITIAL_QUANTITY, INITIAL_AREA_CURRENT_QUANTITY,
 		INITIAL_AREA_LAST_WATERED, INITIAL_AREA_LAST_FERTILIZED, INITIAL_AREA_LAST_PESTICIDED,
-		INITIAL_AREA_LAST_PRUNED, INITIAL_AREA_CREATED_DATE, INITIAL_AREA_LAST_UPDATED
+		INITIAL_AREA_LAST_PRUNED, INITIAL_AREA_CREATED_DATE, INITIAL_AREA_LAST_UPDATED,
+		SHORT_CODE, SEEDS_SOWN, GERMINATED_QUANTITY, GERMINATION_DATE, MERGED_INTO_UID
 		FROM CROP_READ WHERE UID = ?`, cropUID.Bytes()).Scan(
 		&rowsData.UID,
 		&rowsData.BatchID,
@@ -669,6 +748,11 @@ func (s CropReadQueryMysql) populateCrop(cropUID uuid.UUID, cropRead *storage.Cr
 		&rowsData.InitialAreaLastPruned,
 		&rowsData.InitialAreaCreatedDate,
 		&rowsData.InitialAreaLastUpdated,
+		&rowsData.ShortCode,
+		&rowsData.SeedsSown,
+		&rowsData.GerminatedQuantity,
+		&rowsData.GerminationDate,
+		&rowsData.MergedIntoUID,
 	)
 
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -740,6 +824,7 @@ func (s CropReadQueryMysql) populateCrop(cropUID uuid.UUID, cropRead *storage.Cr
 
 	cropRead.UID = cropUID
 	cropRead.BatchID = rowsData.BatchID
+	cropRead.ShortCode = rowsData.ShortCode.String
 	cropRead.Status = rowsData.Status
 	cropRead.Type = rowsData.Type
 	cropRead.Container.Quantity = rowsData.ContainerQuantity
@@ -764,6 +849,32 @@ func (s CropReadQueryMysql) populateCrop(cropUID uuid.UUID, cropRead *storage.Cr
 	cropRead.InitialArea.CreatedDate = rowsData.InitialAreaCreatedDate
 	cropRead.InitialArea.LastUpdated = rowsData.InitialAreaLastUpdated
 
+	if rowsData.SeedsSown.Valid {
+		seedsSown := int(rowsData.SeedsSown.Int64)
+		cropRead.SeedsSown = &seedsSown
+	}
+
+	if rowsData.GerminatedQuantity.Valid {
+		germinatedQuantity := int(rowsData.GerminatedQuantity.Int64)
+		cropRead.GerminatedQuantity = &germinatedQuantity
+	}
+
+	if rowsData.GerminationDate.Valid {
+		date := rowsData.GerminationDate.Time
+		cropRead.GerminationDate = &date
+	}
+
+	cropRead.GerminationRate = storage.GerminationRate(cropRead.SeedsSown, cropRead.GerminatedQuantity)
+
+	if rowsData.MergedIntoUID != nil {
+		mergedIntoUID, err := uuid.FromBytes(rowsData.MergedIntoUID)
+		if err != nil {
+			return err
+		}
+
+		cropRead.MergedIntoUID = &mergedIntoUID
+	}
+
 	return nil
 }
 
@@ -787,6 +898,9 @@ func (s CropReadQueryMysql) populateCropPhotos(uid uuid.UUID, cropRead *storage.
 			&photoRowsData.Width,
 			&photoRowsData.Height,
 			&photoRowsData.Description,
+			&photoRowsData.Status,
+			&photoRowsData.ThumbnailFilename,
+			&photoRowsData.Metadata,
 		)
 
 		if err != nil {
@@ -798,14 +912,32 @@ func (s CropReadQueryMysql) populateCropPhotos(uid uuid.UUID, cropRead *storage.
 			return err
 		}
 
+		// The photos uploaded before their processing have no status, they were ready once saved.
+		status := domain.CropPhotoStatusReady
+		if photoRowsData.Status.Valid && photoRowsData.Status.String != "" {
+			status = photoRowsData.Status.String
+		}
+
+		metadata := domain.CropPhotoMetadata{}
+
+		if photoRowsData.Metadata.Valid && photoRowsData.Metadata.String != "" {
+			err = json.Unmarshal([]byte(photoRowsData.Metadata.String), &metadata)
+			if err != nil {
+				return err
+			}
+		}
+
 		photos = append(photos, storage.CropPhoto{
-			UID:         photoUID,
-			Filename:    photoRowsData.Filename,
-			MimeType:    photoRowsData.Mimetype,
-			Size:        photoRowsData.Size,
-			Width:       photoRowsData.Width,
-			Height:      photoRowsData.Height,
-			Description: photoRowsData.Description,
+			UID:               photoUID,
+			Filename:          photoRowsData.Filename,
+			MimeType:          photoRowsData.Mimetype,
+			Size:              photoRowsData.Size,
+			Width:             photoRowsData.Width,
+			Height:            photoRowsData.Height,
+			Description:       photoRowsData.Description,
+			Status:            status,
+			ThumbnailFilename: photoRowsData.ThumbnailFilename.String,
+			Metadata:          metadata,
 		})
 	}
 
@@ -998,6 +1130,62 @@ func (s CropReadQueryMysql) populateCropTrash(uid uuid.UUID, cropRead *storage.C
 	return nil
 }
 
+func (s CropReadQueryMysql) populateCropNurseryStages(uid uuid.UUID, cropRead *storage.CropRead) error {
+	stageRowsData := cropReadNurseryStageResult{}
+
+	rows, err := s.DB.Query(`SELECT * FROM CROP_READ_NURSERY_STAGE WHERE CROP_UID = ?
+		ORDER BY START_DATE`, uid.Bytes())
+	if err != nil {
+		return err
+	}
+
+	stages := []storage.NurseryStage{}
+
+	for rows.Next() {
+		err = rows.Scan(
+			&stageRowsData.UID,
+			&stageRowsData.CropUID,
+			&stageRowsData.AreaUID,
+			&stageRowsData.AreaName,
+			&stageRowsData.StartDate,
+			&stageRowsData.ExpectedTransplantDate,
+			&stageRowsData.ActualTransplantDate)
+		if err != nil {
+			return err
+		}
+
+		stageUID, err := uuid.FromBytes(stageRowsData.UID)
+		if err != nil {
+			return err
+		}
+
+		areaUID, err := uuid.FromBytes(stageRowsData.AreaUID)
+		if err != nil {
+			return err
+		}
+
+		var actualTransplantDate *time.Time
+
+		if stageRowsData.ActualTransplantDate.Valid {
+			date := stageRowsData.ActualTransplantDate.Time
+			actualTransplantDate = &date
+		}
+
+		stages = append(stages, storage.NurseryStage{
+			UID:                    stageUID,
+			AreaUID:                areaUID,
+			AreaName:               stageRowsData.AreaName,
+			StartDate:              stageRowsData.StartDate,
+			ExpectedTransplantDate: stageRowsData.ExpectedTransplantDate,
+			ActualTransplantDate:   actualTransplantDate,
+		})
+	}
+
+	cropRead.NurseryStages = stages
+
+	return nil
+}
+
 func (s CropReadQueryMysql) populateCropNotes(uid uuid.UUID, cropRead *storage.CropRead) error {
 	notesRowsData := cropReadNotesResult{}
 
@@ -1032,3 +1220,56 @@ func (s CropReadQueryMysql) populateCropNotes(uid uuid.UUID, cropRead *storage.C
 
 	return nil
 }
+
+// FindAllPendingPhotos lists the photos of every crop still waiting for their thumbnail.
+func (s CropReadQueryMysql) FindAllPendingPhotos() <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		defer close(result)
+
+		rows, err := s.DB.Query("SELECT CROP_UID, UID FROM CROP_READ_PHOTO WHERE STATUS = ?", domain.CropPhotoStatusPending)
+		if err != nil {
+			result <- query.Result{Error: err}
+
+			return
+		}
+
+		defer rows.Close()
+
+		photos := []query.CropPendingPhotoQueryResult{}
+
+		for rows.Next() {
+			var cropUID, photoUID []byte
+
+			err = rows.Scan(&cropUID, &photoUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+
+				return
+			}
+
+			photo := query.CropPendingPhotoQueryResult{}
+
+			photo.CropUID, err = uuid.FromBytes(cropUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+
+				return
+			}
+
+			photo.PhotoUID, err = uuid.FromBytes(photoUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+
+				return
+			}
+
+			photos = append(photos, photo)
+		}
+
+		result <- query.Result{Result: photos}
+	}()
+
+	return result
+}
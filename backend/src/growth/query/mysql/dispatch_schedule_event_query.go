@@ -0,0 +1,86 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/decoder"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type DispatchScheduleEventQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewDispatchScheduleEventQueryMysql(db *sql.DB) query.DispatchScheduleEventQuery {
+	return &DispatchScheduleEventQueryMysql{DB: db}
+}
+
+func (f *DispatchScheduleEventQueryMysql) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.DispatchScheduleEvent{}
+
+		rows, err := f.DB.Query(`SELECT DISPATCH_SCHEDULE_UID, VERSION, CREATED_DATE, EVENT
+			FROM DISPATCH_SCHEDULE_EVENT WHERE DISPATCH_SCHEDULE_UID = ? ORDER BY VERSION ASC`, uid.Bytes())
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		rowsData := struct {
+			DispatchScheduleUID []byte
+			Version             int
+			CreatedDate         time.Time
+			Event               []byte
+		}{}
+
+		for rows.Next() {
+			err = rows.Scan(&rowsData.DispatchScheduleUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			wrapper := decoder.DispatchScheduleEventWrapper{}
+
+			err = json.Unmarshal(rowsData.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			scheduleUID, err := uuid.FromBytes(rowsData.DispatchScheduleUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			events = append(events, storage.DispatchScheduleEvent{
+				DispatchScheduleUID: scheduleUID,
+				Version:             rowsData.Version,
+				CreatedDate:         rowsData.CreatedDate,
+				Event:               wrapper.Data,
+				Actor:               wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
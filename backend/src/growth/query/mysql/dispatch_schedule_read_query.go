@@ -0,0 +1,167 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+const dispatchScheduleReadColumns = `UID, FARM_UID, HARVEST_IDS, VEHICLE_ID, DRIVER_NAME, PICKUP_TIME,
+	DESTINATION_ADDRESS, ESTIMATED_DELIVERY_TIME, STATUS, TASK_UID, COMPLETED_DATE, CREATED_DATE`
+
+type DispatchScheduleReadQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewDispatchScheduleReadQueryMysql(db *sql.DB) query.DispatchScheduleReadQuery {
+	return DispatchScheduleReadQueryMysql{DB: db}
+}
+
+func (s DispatchScheduleReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
+	return s.findOne(`SELECT `+dispatchScheduleReadColumns+`
+		FROM DISPATCH_SCHEDULE_READ WHERE UID = ?`, uid.Bytes())
+}
+
+func (s DispatchScheduleReadQueryMysql) FindByTaskID(taskUID uuid.UUID) <-chan query.Result {
+	return s.findOne(`SELECT `+dispatchScheduleReadColumns+`
+		FROM DISPATCH_SCHEDULE_READ WHERE TASK_UID = ?`, taskUID.Bytes())
+}
+
+func (s DispatchScheduleReadQueryMysql) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		schedules := []storage.DispatchScheduleRead{}
+
+		rows, err := s.DB.Query(`SELECT `+dispatchScheduleReadColumns+`
+			FROM DISPATCH_SCHEDULE_READ WHERE FARM_UID = ? ORDER BY PICKUP_TIME ASC`, farmUID.Bytes())
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			schedule, err := populateDispatchScheduleRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			schedules = append(schedules, schedule)
+		}
+
+		result <- query.Result{Result: schedules}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s DispatchScheduleReadQueryMysql) findOne(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		schedule := storage.DispatchScheduleRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			schedule, err = populateDispatchScheduleRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+		}
+
+		result <- query.Result{Result: schedule}
+		close(result)
+	}()
+
+	return result
+}
+
+func populateDispatchScheduleRead(rows *sql.Rows) (storage.DispatchScheduleRead, error) {
+	rowsData := struct {
+		UID                   []byte
+		FarmUID               []byte
+		HarvestIDs            string
+		VehicleID             string
+		DriverName            string
+		PickupTime            time.Time
+		DestinationAddress    string
+		EstimatedDeliveryTime time.Time
+		Status                string
+		TaskUID               []byte
+		CompletedDate         sql.NullTime
+		CreatedDate           time.Time
+	}{}
+
+	err := rows.Scan(
+		&rowsData.UID, &rowsData.FarmUID, &rowsData.HarvestIDs, &rowsData.VehicleID, &rowsData.DriverName,
+		&rowsData.PickupTime, &rowsData.DestinationAddress, &rowsData.EstimatedDeliveryTime, &rowsData.Status,
+		&rowsData.TaskUID, &rowsData.CompletedDate, &rowsData.CreatedDate,
+	)
+	if err != nil {
+		return storage.DispatchScheduleRead{}, err
+	}
+
+	schedule := storage.DispatchScheduleRead{
+		HarvestIDs:            []uuid.UUID{},
+		VehicleID:             rowsData.VehicleID,
+		DriverName:            rowsData.DriverName,
+		PickupTime:            rowsData.PickupTime,
+		DestinationAddress:    rowsData.DestinationAddress,
+		EstimatedDeliveryTime: rowsData.EstimatedDeliveryTime,
+		Status:                rowsData.Status,
+		CreatedDate:           rowsData.CreatedDate,
+	}
+
+	schedule.UID, err = uuid.FromBytes(rowsData.UID)
+	if err != nil {
+		return storage.DispatchScheduleRead{}, err
+	}
+
+	schedule.FarmUID, err = uuid.FromBytes(rowsData.FarmUID)
+	if err != nil {
+		return storage.DispatchScheduleRead{}, err
+	}
+
+	err = json.Unmarshal([]byte(rowsData.HarvestIDs), &schedule.HarvestIDs)
+	if err != nil {
+		return storage.DispatchScheduleRead{}, err
+	}
+
+	if len(rowsData.TaskUID) > 0 {
+		taskUID, err := uuid.FromBytes(rowsData.TaskUID)
+		if err != nil {
+			return storage.DispatchScheduleRead{}, err
+		}
+
+		schedule.TaskUID = &taskUID
+	}
+
+	if rowsData.CompletedDate.Valid {
+		completedDate := rowsData.CompletedDate.Time
+		schedule.CompletedDate = &completedDate
+	}
+
+	return schedule, nil
+}
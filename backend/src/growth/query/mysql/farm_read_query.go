@@ -2,10 +2,13 @@ package sqlite
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 
 	"github.com/gofrs/uuid"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
 	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
 )
 
 type FarmReadQueryMysql struct {
@@ -17,8 +20,13 @@ func NewFarmReadQueryMysql(db *sql.DB) query.FarmReadQuery {
 }
 
 type farmReadResult struct {
-	UID  []byte
-	Name string
+	UID           []byte
+	Name          string
+	HarvestGrades sql.NullString
+	Latitude      string
+	Seasons       sql.NullString
+
+	WithholdingPolicy sql.NullString
 }
 
 func (s FarmReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
@@ -28,9 +36,16 @@ func (s FarmReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
 		farmRead := query.CropFarmQueryResult{}
 		rowsData := farmReadResult{}
 
-		err := s.DB.QueryRow("SELECT UID, NAME FROM FARM_READ WHERE UID = ?", uid.Bytes()).Scan(
+		err := s.DB.QueryRow(
+			`SELECT UID, NAME, HARVEST_GRADES, LATITUDE, SEASONS, WITHHOLDING_POLICY
+			FROM FARM_READ WHERE UID = ?`, uid.Bytes(),
+		).Scan(
 			&rowsData.UID,
 			&rowsData.Name,
+			&rowsData.HarvestGrades,
+			&rowsData.Latitude,
+			&rowsData.Seasons,
+			&rowsData.WithholdingPolicy,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -49,6 +64,29 @@ func (s FarmReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
 		farmRead.UID = farmUID
 		farmRead.Name = rowsData.Name
 
+		grades := []assetsdomain.HarvestGrade{}
+
+		if rowsData.HarvestGrades.Valid && rowsData.HarvestGrades.String != "" {
+			err = json.Unmarshal([]byte(rowsData.HarvestGrades.String), &grades)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+		}
+
+		farmRead.HarvestGrades = assetsdomain.HarvestGradesOrDefault(grades)
+
+		seasons := []timebuckethelper.Season{}
+
+		if rowsData.Seasons.Valid && rowsData.Seasons.String != "" {
+			err = json.Unmarshal([]byte(rowsData.Seasons.String), &seasons)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+		}
+
+		farmRead.Seasons = assetsdomain.SeasonsOrDefault(seasons, rowsData.Latitude)
+		farmRead.WithholdingPolicy = assetsdomain.WithholdingPolicyOrDefault(rowsData.WithholdingPolicy.String)
+
 		result <- query.Result{Result: farmRead}
 		close(result)
 	}()
@@ -0,0 +1,157 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+const harvestLotReadColumns = `UID, FARM_UID, LOT_NUMBER, CROP_UID, BATCH_ID, AREA_UID, HARVEST_DATE, GRADE,
+	GRAM_QUANTITY, REMAINING_GRAM_QUANTITY, STATUS, SHIPMENTS, CREATED_DATE`
+
+type HarvestLotReadQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewHarvestLotReadQueryMysql(db *sql.DB) query.HarvestLotReadQuery {
+	return HarvestLotReadQueryMysql{DB: db}
+}
+
+func (s HarvestLotReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		lot := storage.HarvestLotRead{}
+
+		rows, err := s.DB.Query(`SELECT `+harvestLotReadColumns+` FROM HARVEST_LOT_READ WHERE UID = ?`, uid.Bytes())
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			lot, err = populateHarvestLotRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+		}
+
+		result <- query.Result{Result: lot}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s HarvestLotReadQueryMysql) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		lots := []storage.HarvestLotRead{}
+
+		// L-9 comes before L-10.
+		rows, err := s.DB.Query(`SELECT `+harvestLotReadColumns+` FROM HARVEST_LOT_READ WHERE FARM_UID = ?
+			ORDER BY HARVEST_DATE DESC, CHAR_LENGTH(LOT_NUMBER) ASC, LOT_NUMBER ASC`, farmUID.Bytes())
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			lot, err := populateHarvestLotRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			lots = append(lots, lot)
+		}
+
+		result <- query.Result{Result: lots}
+		close(result)
+	}()
+
+	return result
+}
+
+func populateHarvestLotRead(rows *sql.Rows) (storage.HarvestLotRead, error) {
+	rowsData := struct {
+		UID          []byte
+		FarmUID      []byte
+		LotNumber    string
+		CropUID      []byte
+		BatchID      string
+		AreaUID      []byte
+		HarvestDate  time.Time
+		Grade        string
+		GramQuantity float64
+		Remaining    float64
+		Status       string
+		Shipments    string
+		CreatedDate  time.Time
+	}{}
+
+	err := rows.Scan(
+		&rowsData.UID, &rowsData.FarmUID, &rowsData.LotNumber, &rowsData.CropUID, &rowsData.BatchID,
+		&rowsData.AreaUID, &rowsData.HarvestDate, &rowsData.Grade, &rowsData.GramQuantity, &rowsData.Remaining,
+		&rowsData.Status, &rowsData.Shipments, &rowsData.CreatedDate,
+	)
+	if err != nil {
+		return storage.HarvestLotRead{}, err
+	}
+
+	lot := storage.HarvestLotRead{
+		LotNumber:    rowsData.LotNumber,
+		BatchID:      rowsData.BatchID,
+		HarvestDate:  rowsData.HarvestDate,
+		Grade:        rowsData.Grade,
+		GramQuantity: rowsData.GramQuantity,
+		Remaining:    rowsData.Remaining,
+		Status:       rowsData.Status,
+		Shipments:    []domain.LotShipment{},
+		CreatedDate:  rowsData.CreatedDate,
+	}
+
+	lot.UID, err = uuid.FromBytes(rowsData.UID)
+	if err != nil {
+		return storage.HarvestLotRead{}, err
+	}
+
+	lot.FarmUID, err = uuid.FromBytes(rowsData.FarmUID)
+	if err != nil {
+		return storage.HarvestLotRead{}, err
+	}
+
+	lot.CropUID, err = uuid.FromBytes(rowsData.CropUID)
+	if err != nil {
+		return storage.HarvestLotRead{}, err
+	}
+
+	lot.AreaUID, err = uuid.FromBytes(rowsData.AreaUID)
+	if err != nil {
+		return storage.HarvestLotRead{}, err
+	}
+
+	err = json.Unmarshal([]byte(rowsData.Shipments), &lot.Shipments)
+	if err != nil {
+		return storage.HarvestLotRead{}, err
+	}
+
+	return lot, nil
+}
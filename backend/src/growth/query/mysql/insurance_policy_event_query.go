@@ -0,0 +1,86 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/decoder"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type InsurancePolicyEventQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewInsurancePolicyEventQueryMysql(db *sql.DB) query.InsurancePolicyEventQuery {
+	return &InsurancePolicyEventQueryMysql{DB: db}
+}
+
+func (f *InsurancePolicyEventQueryMysql) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.InsurancePolicyEvent{}
+
+		rows, err := f.DB.Query(`SELECT INSURANCE_POLICY_UID, VERSION, CREATED_DATE, EVENT
+			FROM INSURANCE_POLICY_EVENT WHERE INSURANCE_POLICY_UID = ? ORDER BY VERSION ASC`, uid.Bytes())
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		rowsData := struct {
+			InsurancePolicyUID []byte
+			Version            int
+			CreatedDate        time.Time
+			Event              []byte
+		}{}
+
+		for rows.Next() {
+			err = rows.Scan(&rowsData.InsurancePolicyUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			wrapper := decoder.InsurancePolicyEventWrapper{}
+
+			err = json.Unmarshal(rowsData.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			policyUID, err := uuid.FromBytes(rowsData.InsurancePolicyUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			events = append(events, storage.InsurancePolicyEvent{
+				InsurancePolicyUID: policyUID,
+				Version:            rowsData.Version,
+				CreatedDate:        rowsData.CreatedDate,
+				Event:              wrapper.Data,
+				Actor:              wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,159 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+const insurancePolicyReadColumns = `UID, FARM_UID, POLICY_NUMBER, PROVIDER, CROP_IDS, COVERAGE_AMOUNT, CURRENCY,
+	PREMIUM_AMOUNT, START_DATE, END_DATE, CLAIMS, IS_DELETED, CREATED_DATE`
+
+type InsurancePolicyReadQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewInsurancePolicyReadQueryMysql(db *sql.DB) query.InsurancePolicyReadQuery {
+	return InsurancePolicyReadQueryMysql{DB: db}
+}
+
+func (s InsurancePolicyReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
+	return s.findOne(`SELECT `+insurancePolicyReadColumns+`
+		FROM INSURANCE_POLICY_READ WHERE UID = ? AND IS_DELETED = ?`, uid.Bytes(), false)
+}
+
+func (s InsurancePolicyReadQueryMysql) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		policies := []storage.InsurancePolicyRead{}
+
+		rows, err := s.DB.Query(`SELECT `+insurancePolicyReadColumns+`
+			FROM INSURANCE_POLICY_READ WHERE FARM_UID = ? AND IS_DELETED = ?
+			ORDER BY START_DATE ASC`, farmUID.Bytes(), false)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			policy, err := populateInsurancePolicyRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			policies = append(policies, policy)
+		}
+
+		result <- query.Result{Result: policies}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s InsurancePolicyReadQueryMysql) findOne(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		policy := storage.InsurancePolicyRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			policy, err = populateInsurancePolicyRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+		}
+
+		result <- query.Result{Result: policy}
+		close(result)
+	}()
+
+	return result
+}
+
+func populateInsurancePolicyRead(rows *sql.Rows) (storage.InsurancePolicyRead, error) {
+	rowsData := struct {
+		UID            []byte
+		FarmUID        []byte
+		PolicyNumber   string
+		Provider       string
+		CropIDs        string
+		CoverageAmount float64
+		Currency       string
+		PremiumAmount  float64
+		StartDate      time.Time
+		EndDate        time.Time
+		Claims         string
+		IsDeleted      bool
+		CreatedDate    time.Time
+	}{}
+
+	err := rows.Scan(
+		&rowsData.UID, &rowsData.FarmUID, &rowsData.PolicyNumber, &rowsData.Provider, &rowsData.CropIDs,
+		&rowsData.CoverageAmount, &rowsData.Currency, &rowsData.PremiumAmount, &rowsData.StartDate,
+		&rowsData.EndDate, &rowsData.Claims, &rowsData.IsDeleted, &rowsData.CreatedDate,
+	)
+	if err != nil {
+		return storage.InsurancePolicyRead{}, err
+	}
+
+	policy := storage.InsurancePolicyRead{
+		PolicyNumber:   rowsData.PolicyNumber,
+		Provider:       rowsData.Provider,
+		CropIDs:        []uuid.UUID{},
+		CoverageAmount: rowsData.CoverageAmount,
+		Currency:       rowsData.Currency,
+		PremiumAmount:  rowsData.PremiumAmount,
+		Claims:         []domain.InsuranceClaim{},
+		IsDeleted:      rowsData.IsDeleted,
+		StartDate:      rowsData.StartDate,
+		EndDate:        rowsData.EndDate,
+		CreatedDate:    rowsData.CreatedDate,
+	}
+
+	policy.UID, err = uuid.FromBytes(rowsData.UID)
+	if err != nil {
+		return storage.InsurancePolicyRead{}, err
+	}
+
+	policy.FarmUID, err = uuid.FromBytes(rowsData.FarmUID)
+	if err != nil {
+		return storage.InsurancePolicyRead{}, err
+	}
+
+	err = json.Unmarshal([]byte(rowsData.CropIDs), &policy.CropIDs)
+	if err != nil {
+		return storage.InsurancePolicyRead{}, err
+	}
+
+	err = json.Unmarshal([]byte(rowsData.Claims), &policy.Claims)
+	if err != nil {
+		return storage.InsurancePolicyRead{}, err
+	}
+
+	return policy, nil
+}
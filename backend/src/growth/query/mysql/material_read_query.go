@@ -17,10 +17,13 @@ func NewMaterialReadQueryMysql(db *sql.DB) query.MaterialReadQuery {
 }
 
 type materialReadResult struct {
-	UID      []byte
-	Name     string
-	Type     string
-	TypeData string
+	UID           []byte
+	Name          string
+	Type          string
+	TypeData      string
+	GDDToMaturity sql.NullFloat64
+
+	PreHarvestIntervalDays sql.NullInt64
 }
 
 func (q MaterialReadQueryMysql) FindByID(materialUID uuid.UUID) <-chan query.Result {
@@ -30,12 +33,14 @@ func (q MaterialReadQueryMysql) FindByID(materialUID uuid.UUID) <-chan query.Res
 		materialQueryResult := query.CropMaterialQueryResult{}
 		rowsData := materialReadResult{}
 
-		err := q.DB.QueryRow(`SELECT UID, NAME, TYPE, TYPE_DATA FROM MATERIAL_READ
-			WHERE UID = ?`, materialUID.Bytes()).Scan(
+		err := q.DB.QueryRow(`SELECT UID, NAME, TYPE, TYPE_DATA, GDD_TO_MATURITY, PRE_HARVEST_INTERVAL_DAYS
+			FROM MATERIAL_READ WHERE UID = ?`, materialUID.Bytes()).Scan(
 			&rowsData.UID,
 			&rowsData.Name,
 			&rowsData.Type,
 			&rowsData.TypeData,
+			&rowsData.GDDToMaturity,
+			&rowsData.PreHarvestIntervalDays,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -56,6 +61,15 @@ func (q MaterialReadQueryMysql) FindByID(materialUID uuid.UUID) <-chan query.Res
 		materialQueryResult.TypeCode = rowsData.Type
 		materialQueryResult.PlantTypeCode = rowsData.TypeData
 
+		if rowsData.GDDToMaturity.Valid {
+			materialQueryResult.GDDToMaturity = &rowsData.GDDToMaturity.Float64
+		}
+
+		if rowsData.PreHarvestIntervalDays.Valid {
+			days := int(rowsData.PreHarvestIntervalDays.Int64)
+			materialQueryResult.PreHarvestIntervalDays = &days
+		}
+
 		result <- query.Result{Result: materialQueryResult}
 		close(result)
 	}()
@@ -70,12 +84,13 @@ func (q MaterialReadQueryMysql) FindMaterialByPlantTypeCodeAndName(plantTypeCode
 		materialQueryResult := query.CropMaterialQueryResult{}
 		rowsData := materialReadResult{}
 
-		err := q.DB.QueryRow(`SELECT UID, NAME, TYPE, TYPE_DATA FROM MATERIAL_READ
+		err := q.DB.QueryRow(`SELECT UID, NAME, TYPE, TYPE_DATA, GDD_TO_MATURITY FROM MATERIAL_READ
 			WHERE TYPE_DATA = ? AND NAME = ?`, plantTypeCode, name).Scan(
 			&rowsData.UID,
 			&rowsData.Name,
 			&rowsData.Type,
 			&rowsData.TypeData,
+			&rowsData.GDDToMaturity,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -96,6 +111,10 @@ func (q MaterialReadQueryMysql) FindMaterialByPlantTypeCodeAndName(plantTypeCode
 		materialQueryResult.TypeCode = rowsData.Type
 		materialQueryResult.PlantTypeCode = rowsData.TypeData
 
+		if rowsData.GDDToMaturity.Valid {
+			materialQueryResult.GDDToMaturity = &rowsData.GDDToMaturity.Float64
+		}
+
 		result <- query.Result{Result: materialQueryResult}
 		close(result)
 	}()
@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+)
+
+type MicroclimateSampleQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewMicroclimateSampleQueryMysql(db *sql.DB) query.MicroclimateSampleQuery {
+	return MicroclimateSampleQueryMysql{DB: db}
+}
+
+func (q MicroclimateSampleQueryMysql) FindAllByArea(areaUID uuid.UUID, from time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		samples := []query.MicroclimateSampleQueryResult{}
+
+		rows, err := q.DB.Query(`SELECT TEMPERATURE, RECORDED_DATE FROM MICROCLIMATE_SAMPLE
+			WHERE AREA_UID = ? AND RECORDED_DATE >= ? ORDER BY RECORDED_DATE ASC`, areaUID.Bytes(), from)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			sample := query.MicroclimateSampleQueryResult{AreaUID: areaUID}
+
+			err := rows.Scan(&sample.Temperature, &sample.RecordedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			samples = append(samples, sample)
+		}
+
+		result <- query.Result{Result: samples}
+		close(result)
+	}()
+
+	return result
+}
+
+func (q MicroclimateSampleQueryMysql) FindAllByDate(from, to time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		samples := []query.MicroclimateSampleQueryResult{}
+
+		rows, err := q.DB.Query(`SELECT AREA_UID, TEMPERATURE, RECORDED_DATE FROM MICROCLIMATE_SAMPLE
+			WHERE RECORDED_DATE >= ? AND RECORDED_DATE < ? ORDER BY RECORDED_DATE ASC`, from, to)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			areaUID := []byte{}
+			sample := query.MicroclimateSampleQueryResult{}
+
+			err := rows.Scan(&areaUID, &sample.Temperature, &sample.RecordedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			sample.AreaUID, err = uuid.FromBytes(areaUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			samples = append(samples, sample)
+		}
+
+		result <- query.Result{Result: samples}
+		close(result)
+	}()
+
+	return result
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
 )
 
 type AreaReadQuery interface {
@@ -23,6 +25,7 @@ type CropEventQuery interface {
 type CropReadQuery interface {
 	FindByID(uid uuid.UUID) <-chan Result
 	FindByBatchID(batchID string) <-chan Result
+	FindAllByShortCode(shortCode string) <-chan Result
 	FindAllCropsByFarm(farmUID uuid.UUID, status string, page, limit int) <-chan Result
 	CountAllCropsByFarm(farmUID uuid.UUID, status string) <-chan Result
 	FindAllCropsByArea(areaUID uuid.UUID) <-chan Result
@@ -30,11 +33,60 @@ type CropReadQuery interface {
 	CountAllArchivedCropsByFarm(farmUID uuid.UUID) <-chan Result
 	FindCropsInformation(farmUID uuid.UUID) <-chan Result
 	CountTotalBatch(farmUID uuid.UUID) <-chan Result
+	FindAllPendingPhotos() <-chan Result
+}
+
+type CropInputScheduleEventQuery interface {
+	FindAllByID(uid uuid.UUID) <-chan Result
+}
+
+type CropInputScheduleReadQuery interface {
+	FindByID(uid uuid.UUID) <-chan Result
+	FindByTaskID(taskUID uuid.UUID) <-chan Result
+	FindAllByCropID(cropUID uuid.UUID) <-chan Result
+	FindAllDue(now time.Time) <-chan Result
+}
+
+type DispatchScheduleEventQuery interface {
+	FindAllByID(uid uuid.UUID) <-chan Result
+}
+
+type DispatchScheduleReadQuery interface {
+	FindByID(uid uuid.UUID) <-chan Result
+	FindByTaskID(taskUID uuid.UUID) <-chan Result
+
+	// FindAllByFarm returns the dispatches of the farm, the earliest pickup first.
+	FindAllByFarm(farmUID uuid.UUID) <-chan Result
+}
+
+type InsurancePolicyEventQuery interface {
+	FindAllByID(uid uuid.UUID) <-chan Result
+}
+
+type InsurancePolicyReadQuery interface {
+	FindByID(uid uuid.UUID) <-chan Result
+
+	// FindAllByFarm returns the policies of the farm which aren't removed, the earliest start first.
+	FindAllByFarm(farmUID uuid.UUID) <-chan Result
+}
+
+type HarvestLotEventQuery interface {
+	FindAllByID(uid uuid.UUID) <-chan Result
+}
+
+type HarvestLotReadQuery interface {
+	FindByID(uid uuid.UUID) <-chan Result
+
+	// FindAllByFarm returns the lots of the farm, the latest harvest first.
+	FindAllByFarm(farmUID uuid.UUID) <-chan Result
 }
 
 type CropActivityQuery interface {
 	FindAllByCropID(uid uuid.UUID) <-chan Result
 	FindByCropIDAndActivityType(uid uuid.UUID, activityType interface{}) <-chan Result
+
+	// FindAllByDate returns the activities of all the crops created in [from, to), the earliest first.
+	FindAllByDate(from, to time.Time) <-chan Result
 }
 
 type MaterialReadQuery interface {
@@ -42,6 +94,13 @@ type MaterialReadQuery interface {
 	FindMaterialByPlantTypeCodeAndName(plantType string, name string) <-chan Result
 }
 
+type MicroclimateSampleQuery interface {
+	FindAllByArea(areaUID uuid.UUID, from time.Time) <-chan Result
+
+	// FindAllByDate returns the samples of all the areas recorded in [from, to), the earliest first.
+	FindAllByDate(from, to time.Time) <-chan Result
+}
+
 type FarmReadQuery interface {
 	FindByID(farmUID uuid.UUID) <-chan Result
 }
@@ -53,6 +112,9 @@ type TaskReadQuery interface {
 type Result struct {
 	Result interface{}
 	Error  error
+
+	// Stale is set on the results served from a snapshot during a replay of the read model.
+	Stale bool
 }
 
 type CropMaterialQueryResult struct {
@@ -60,6 +122,10 @@ type CropMaterialQueryResult struct {
 	TypeCode      string    `json:"type"`
 	PlantTypeCode string    `json:"plant_type"`
 	Name          string    `json:"name"`
+	GDDToMaturity *float64  `json:"gdd_to_maturity"`
+
+	// PreHarvestIntervalDays are the days a crop can't be harvested after it's treated with the material.
+	PreHarvestIntervalDays *int `json:"pre_harvest_interval_days"`
 }
 
 type CropAreaQueryResult struct {
@@ -72,11 +138,14 @@ type CropAreaQueryResult struct {
 	Type     string    `json:"type"`
 	Location string    `json:"location"`
 	FarmUID  uuid.UUID `json:"farm_uid"`
+
+	LastSanitizedDate *time.Time `json:"last_sanitized_date"`
 }
 
 type CropAreaByAreaQueryResult struct {
 	UID         uuid.UUID `json:"uid"`
 	BatchID     string    `json:"batch_id"`
+	ShortCode   string    `json:"short_code"`
 	Inventory   Inventory `json:"inventory"`
 	CreatedDate time.Time `json:"seeding_date"`
 	Area        Area      `json:"area"`
@@ -117,8 +186,15 @@ type CropInformationQueryResult struct {
 }
 
 type CropFarmQueryResult struct {
-	UID  uuid.UUID
-	Name string
+	UID           uuid.UUID
+	Name          string
+	HarvestGrades []assetsdomain.HarvestGrade
+
+	// Seasons are the seasons the farm set, or the default ones of its hemisphere.
+	Seasons []timebuckethelper.Season
+
+	// WithholdingPolicy is the policy the farm set, or the blocking default.
+	WithholdingPolicy string
 }
 
 type CountTotalBatchQueryResult struct {
@@ -137,3 +213,23 @@ type CropTaskQueryResult struct {
 	MaterialUID uuid.UUID
 	AreaUID     uuid.UUID
 }
+
+// CropShortCodeQueryResult is a crop matching a short code.
+// Short codes are only unique within a farm, so there can be one per farm.
+type CropShortCodeQueryResult struct {
+	UID     uuid.UUID
+	FarmUID uuid.UUID
+}
+
+// CropPendingPhotoQueryResult is a photo of a crop still waiting for its thumbnail.
+type CropPendingPhotoQueryResult struct {
+	CropUID  uuid.UUID
+	PhotoUID uuid.UUID
+}
+
+// MicroclimateSampleQueryResult is a temperature reading of an area, in celsius.
+type MicroclimateSampleQueryResult struct {
+	AreaUID      uuid.UUID
+	Temperature  float64
+	RecordedDate time.Time
+}
@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"encoding/json"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/decoder"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+	redisstorage "github.com/usetania/tania-core/src/storage/redis"
+)
+
+type CropEventQueryRedis struct {
+	Storage *redisstorage.RedisStreamsEventStorage
+}
+
+func NewCropEventQueryRedis(s *redisstorage.RedisStreamsEventStorage) query.CropEventQuery {
+	return &CropEventQueryRedis{Storage: s}
+}
+
+func (f *CropEventQueryRedis) FindAllByCropID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		defer close(result)
+
+		streamEvents, err := f.Storage.Range(redisstorage.AggregateCrop, uid)
+		if err != nil {
+			result <- query.Result{Error: err}
+
+			return
+		}
+
+		events := []storage.CropEvent{}
+
+		for _, v := range streamEvents {
+			wrapper := decoder.CropEventWrapper{}
+
+			err := json.Unmarshal(v.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+
+				return
+			}
+
+			events = append(events, storage.CropEvent{
+				CropUID:     uid,
+				Version:     v.Version,
+				CreatedDate: v.CreatedDate,
+				Event:       wrapper.Data,
+				Actor:       wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+	}()
+
+	return result
+}
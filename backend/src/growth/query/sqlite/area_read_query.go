@@ -3,6 +3,7 @@ package sqlite
 import (
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/usetania/tania-core/src/growth/query"
@@ -24,6 +25,8 @@ type areaReadResult struct {
 	Type     string
 	Location string
 	FarmUID  string
+
+	LastSanitizedDate sql.NullString
 }
 
 func (s AreaReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
@@ -33,7 +36,7 @@ func (s AreaReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
 		areaQueryResult := query.CropAreaQueryResult{}
 		rowsData := areaReadResult{}
 
-		err := s.DB.QueryRow(`SELECT UID, NAME, SIZE, SIZE_UNIT, TYPE, LOCATION, FARM_UID
+		err := s.DB.QueryRow(`SELECT UID, NAME, SIZE, SIZE_UNIT, TYPE, LOCATION, FARM_UID, LAST_SANITIZED_DATE
 			FROM AREA_READ WHERE UID = ?`, uid).Scan(
 			&rowsData.UID,
 			&rowsData.Name,
@@ -42,6 +45,7 @@ func (s AreaReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
 			&rowsData.Type,
 			&rowsData.Location,
 			&rowsData.FarmUID,
+			&rowsData.LastSanitizedDate,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -70,6 +74,15 @@ func (s AreaReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
 		areaQueryResult.Location = rowsData.Location
 		areaQueryResult.FarmUID = farmUID
 
+		if rowsData.LastSanitizedDate.Valid && rowsData.LastSanitizedDate.String != "" {
+			lastSanitizedDate, err := time.Parse(time.RFC3339, rowsData.LastSanitizedDate.String)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			areaQueryResult.LastSanitizedDate = &lastSanitizedDate
+		}
+
 		result <- query.Result{Result: areaQueryResult}
 		close(result)
 	}()
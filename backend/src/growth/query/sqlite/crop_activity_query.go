@@ -83,6 +83,7 @@ func (s CropActivityQuerySqlite) FindAllByCropID(uid uuid.UUID) <-chan query.Res
 				ActivityType:  activityType,
 				CreatedDate:   createdDate,
 				Description:   rowsData.Description,
+				Actor:         wrapper.Actor,
 			})
 		}
 
@@ -154,6 +155,7 @@ func (s CropActivityQuerySqlite) FindByCropIDAndActivityType(
 				ActivityType:  activityType,
 				CreatedDate:   createdDate,
 				Description:   rowsData.Description,
+				Actor:         wrapper.Actor,
 			}
 		}
 
@@ -163,3 +165,94 @@ func (s CropActivityQuerySqlite) FindByCropIDAndActivityType(
 
 	return result
 }
+
+func (s CropActivityQuerySqlite) FindAllByDate(from, to time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		cropActivities := []storage.CropActivity{}
+
+		rows, err := s.DB.Query(`SELECT CROP_UID, BATCH_ID, CONTAINER_TYPE, ACTIVITY_TYPE, CREATED_DATE, DESCRIPTION
+			FROM CROP_ACTIVITY ORDER BY CREATED_DATE ASC`)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			rowsData := cropActivityResult{}
+
+			err = rows.Scan(
+				&rowsData.CropUID,
+				&rowsData.BatchID,
+				&rowsData.ContainerType,
+				&rowsData.ActivityType,
+				&rowsData.CreatedDate,
+				&rowsData.Description,
+			)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			createdDate, err := time.Parse(time.RFC3339, rowsData.CreatedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			// The text dates keep the offset they were created with, the range is checked on the parsed ones.
+			if createdDate.Before(from) || !createdDate.Before(to) {
+				continue
+			}
+
+			wrapper := decoder.CropActivityTypeWrapper{}
+
+			err = json.Unmarshal(rowsData.ActivityType, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			activityType, ok := wrapper.Data.(storage.ActivityType)
+			if !ok {
+				result <- query.Result{Error: errors.New("error type assertion")}
+				close(result)
+
+				return
+			}
+
+			cropUID, err := uuid.FromString(rowsData.CropUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			cropActivities = append(cropActivities, storage.CropActivity{
+				UID:           cropUID,
+				BatchID:       rowsData.BatchID,
+				ContainerType: rowsData.ContainerType,
+				ActivityType:  activityType,
+				CreatedDate:   createdDate,
+				Description:   rowsData.Description,
+				Actor:         wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: cropActivities}
+		close(result)
+	}()
+
+	return result
+}
@@ -63,6 +63,7 @@ func (f *CropEventQuerySqlite) FindAllByCropID(uid uuid.UUID) <-chan query.Resul
 				Version:     rowsData.Version,
 				CreatedDate: createdDate,
 				Event:       wrapper.Data,
+				Actor:       wrapper.Actor,
 			})
 		}
 
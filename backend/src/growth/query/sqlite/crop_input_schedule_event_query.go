@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/decoder"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type CropInputScheduleEventQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewCropInputScheduleEventQuerySqlite(db *sql.DB) query.CropInputScheduleEventQuery {
+	return &CropInputScheduleEventQuerySqlite{DB: db}
+}
+
+func (f *CropInputScheduleEventQuerySqlite) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.CropInputScheduleEvent{}
+
+		rows, err := f.DB.Query("SELECT * FROM CROP_INPUT_SCHEDULE_EVENT WHERE CROP_INPUT_SCHEDULE_UID = ? ORDER BY VERSION ASC", uid)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		rowsData := struct {
+			ID                   int
+			CropInputScheduleUID string
+			Version              int
+			CreatedDate          string
+			Event                []byte
+		}{}
+
+		for rows.Next() {
+			rows.Scan(&rowsData.ID, &rowsData.CropInputScheduleUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event)
+
+			wrapper := decoder.CropInputScheduleEventWrapper{}
+
+			err = json.Unmarshal(rowsData.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			scheduleUID, err := uuid.FromString(rowsData.CropInputScheduleUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			createdDate, err := time.Parse(time.RFC3339, rowsData.CreatedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			events = append(events, storage.CropInputScheduleEvent{
+				CropInputScheduleUID: scheduleUID,
+				Version:              rowsData.Version,
+				CreatedDate:          createdDate,
+				Event:                wrapper.Data,
+				Actor:                wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
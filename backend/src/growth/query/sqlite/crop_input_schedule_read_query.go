@@ -0,0 +1,211 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+const cropInputScheduleReadColumns = `UID, CROP_UID, MATERIAL_UID, PLANNED_DATE, QUANTITY, UNIT,
+	APPLICATION_METHOD, STATUS, TASK_UID, APPLIED_DATE, CREATED_DATE`
+
+type CropInputScheduleReadQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewCropInputScheduleReadQuerySqlite(db *sql.DB) query.CropInputScheduleReadQuery {
+	return CropInputScheduleReadQuerySqlite{DB: db}
+}
+
+func (s CropInputScheduleReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
+	return s.findOne(`SELECT `+cropInputScheduleReadColumns+`
+		FROM CROP_INPUT_SCHEDULE_READ WHERE UID = ?`, uid)
+}
+
+func (s CropInputScheduleReadQuerySqlite) FindByTaskID(taskUID uuid.UUID) <-chan query.Result {
+	return s.findOne(`SELECT `+cropInputScheduleReadColumns+`
+		FROM CROP_INPUT_SCHEDULE_READ WHERE TASK_UID = ?`, taskUID)
+}
+
+func (s CropInputScheduleReadQuerySqlite) FindAllByCropID(cropUID uuid.UUID) <-chan query.Result {
+	return s.findAll(`SELECT `+cropInputScheduleReadColumns+`
+		FROM CROP_INPUT_SCHEDULE_READ WHERE CROP_UID = ? ORDER BY PLANNED_DATE ASC`, cropUID)
+}
+
+func (s CropInputScheduleReadQuerySqlite) FindAllDue(now time.Time) <-chan query.Result {
+	// The dates are stored as RFC3339 text, which only sorts correctly within the same offset,
+	// so the comparison is done after parsing.
+	result := make(chan query.Result)
+
+	go func() {
+		schedules := []storage.CropInputScheduleRead{}
+
+		res := <-s.findAll(`SELECT `+cropInputScheduleReadColumns+`
+			FROM CROP_INPUT_SCHEDULE_READ WHERE STATUS = ? ORDER BY PLANNED_DATE ASC`,
+			domain.InputScheduleStatusPlanned)
+		if res.Error != nil {
+			result <- query.Result{Error: res.Error}
+			close(result)
+
+			return
+		}
+
+		for _, v := range res.Result.([]storage.CropInputScheduleRead) {
+			if !v.PlannedDate.After(now) {
+				schedules = append(schedules, v)
+			}
+		}
+
+		result <- query.Result{Result: schedules}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s CropInputScheduleReadQuerySqlite) findOne(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		schedule := storage.CropInputScheduleRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			schedule, err = populateCropInputScheduleRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+		}
+
+		result <- query.Result{Result: schedule}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s CropInputScheduleReadQuerySqlite) findAll(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		schedules := []storage.CropInputScheduleRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			schedule, err := populateCropInputScheduleRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			schedules = append(schedules, schedule)
+		}
+
+		result <- query.Result{Result: schedules}
+		close(result)
+	}()
+
+	return result
+}
+
+func populateCropInputScheduleRead(rows *sql.Rows) (storage.CropInputScheduleRead, error) {
+	rowsData := struct {
+		UID               string
+		CropUID           string
+		MaterialUID       string
+		PlannedDate       string
+		Quantity          float64
+		Unit              string
+		ApplicationMethod string
+		Status            string
+		TaskUID           sql.NullString
+		AppliedDate       sql.NullString
+		CreatedDate       string
+	}{}
+
+	err := rows.Scan(
+		&rowsData.UID, &rowsData.CropUID, &rowsData.MaterialUID, &rowsData.PlannedDate,
+		&rowsData.Quantity, &rowsData.Unit, &rowsData.ApplicationMethod, &rowsData.Status,
+		&rowsData.TaskUID, &rowsData.AppliedDate, &rowsData.CreatedDate,
+	)
+	if err != nil {
+		return storage.CropInputScheduleRead{}, err
+	}
+
+	schedule := storage.CropInputScheduleRead{
+		Quantity:          rowsData.Quantity,
+		Unit:              rowsData.Unit,
+		ApplicationMethod: rowsData.ApplicationMethod,
+		Status:            rowsData.Status,
+	}
+
+	schedule.UID, err = uuid.FromString(rowsData.UID)
+	if err != nil {
+		return storage.CropInputScheduleRead{}, err
+	}
+
+	schedule.CropUID, err = uuid.FromString(rowsData.CropUID)
+	if err != nil {
+		return storage.CropInputScheduleRead{}, err
+	}
+
+	schedule.MaterialUID, err = uuid.FromString(rowsData.MaterialUID)
+	if err != nil {
+		return storage.CropInputScheduleRead{}, err
+	}
+
+	schedule.PlannedDate, err = time.Parse(time.RFC3339, rowsData.PlannedDate)
+	if err != nil {
+		return storage.CropInputScheduleRead{}, err
+	}
+
+	schedule.CreatedDate, err = time.Parse(time.RFC3339, rowsData.CreatedDate)
+	if err != nil {
+		return storage.CropInputScheduleRead{}, err
+	}
+
+	if rowsData.TaskUID.Valid && rowsData.TaskUID.String != "" {
+		taskUID, err := uuid.FromString(rowsData.TaskUID.String)
+		if err != nil {
+			return storage.CropInputScheduleRead{}, err
+		}
+
+		schedule.TaskUID = &taskUID
+	}
+
+	if rowsData.AppliedDate.Valid && rowsData.AppliedDate.String != "" {
+		appliedDate, err := time.Parse(time.RFC3339, rowsData.AppliedDate.String)
+		if err != nil {
+			return storage.CropInputScheduleRead{}, err
+		}
+
+		schedule.AppliedDate = &appliedDate
+	}
+
+	return schedule, nil
+}
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -46,17 +47,25 @@ type cropReadResult struct {
 	InitialAreaLastPruned      sql.NullString
 	InitialAreaCreatedDate     string
 	InitialAreaLastUpdated     string
+	ShortCode                  sql.NullString
+	SeedsSown                  sql.NullInt64
+	GerminatedQuantity         sql.NullInt64
+	GerminationDate            sql.NullString
+	MergedIntoUID              sql.NullString
 }
 
 type cropReadPhotoResult struct {
-	UID         string
-	CropUID     string
-	Filename    string
-	Mimetype    string
-	Size        int
-	Width       int
-	Height      int
-	Description string
+	UID               string
+	CropUID           string
+	Filename          string
+	Mimetype          string
+	Size              int
+	Width             int
+	Height            int
+	Description       string
+	Status            sql.NullString
+	ThumbnailFilename sql.NullString
+	Metadata          sql.NullString
 }
 
 type cropReadMovedAreaResult struct {
@@ -95,6 +104,16 @@ type cropReadTrashResult struct {
 	LastUpdated    string
 }
 
+type cropReadNurseryStageResult struct {
+	UID                    string
+	CropUID                string
+	AreaUID                string
+	AreaName               string
+	StartDate              string
+	ExpectedTransplantDate string
+	ActualTransplantDate   sql.NullString
+}
+
 type cropReadNotesResult struct {
 	UID         string
 	CropUID     string
@@ -138,6 +157,11 @@ func (s CropReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
 			result <- query.Result{Error: err}
 		}
 
+		err = s.populateCropNurseryStages(uid, &cropRead)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
 		result <- query.Result{Result: cropRead}
 		close(result)
 	}()
@@ -180,6 +204,48 @@ func (s CropReadQuerySqlite) FindByBatchID(batchID string) <-chan query.Result {
 	return result
 }
 
+func (s CropReadQuerySqlite) FindAllByShortCode(shortCode string) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		crops := []query.CropShortCodeQueryResult{}
+
+		rows, err := s.DB.Query(`SELECT UID, FARM_UID FROM CROP_READ WHERE SHORT_CODE = ?`, shortCode)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		for rows.Next() {
+			var uid, farmUID string
+
+			err := rows.Scan(&uid, &farmUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			cropUID, err := uuid.FromString(uid)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			cropFarmUID, err := uuid.FromString(farmUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			crops = append(crops, query.CropShortCodeQueryResult{
+				UID:     cropUID,
+				FarmUID: cropFarmUID,
+			})
+		}
+
+		result <- query.Result{Result: crops}
+		close(result)
+	}()
+
+	return result
+}
+
 func (s CropReadQuerySqlite) FindAllCropsByFarm(farmUID uuid.UUID, status string, page, limit int) <-chan query.Result {
 	result := make(chan query.Result)
 
@@ -249,6 +315,11 @@ func (s CropReadQuerySqlite) FindAllCropsByFarm(farmUID uuid.UUID, status string
 				result <- query.Result{Error: err}
 			}
 
+			err = s.populateCropNurseryStages(cropUID, &cropRead)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
 			err = s.populateCropPhotos(cropUID, &cropRead)
 			if err != nil {
 				result <- query.Result{Error: err}
@@ -350,6 +421,11 @@ func (s CropReadQuerySqlite) FindAllCropsArchives(farmUID uuid.UUID, page, limit
 				result <- query.Result{Error: err}
 			}
 
+			err = s.populateCropNurseryStages(cropUID, &cropRead)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
 			err = s.populateCropPhotos(cropUID, &cropRead)
 			if err != nil {
 				result <- query.Result{Error: err}
@@ -419,6 +495,7 @@ func (s CropReadQuerySqlite) FindAllCropsByArea(areaUID uuid.UUID) <-chan query.
 				crops = append(crops, query.CropAreaByAreaQueryResult{
 					UID:         cropRead.UID,
 					BatchID:     cropRead.BatchID,
+					ShortCode:   cropRead.ShortCode,
 					CreatedDate: cropRead.InitialArea.CreatedDate,
 					Area: query.Area{
 						UID:             cropRead.InitialArea.AreaUID,
@@ -484,6 +561,7 @@ func (s CropReadQuerySqlite) FindAllCropsByArea(areaUID uuid.UUID) <-chan query.
 					crops = append(crops, query.CropAreaByAreaQueryResult{
 						UID:         cropRead.UID,
 						BatchID:     cropRead.BatchID,
+						ShortCode:   cropRead.ShortCode,
 						CreatedDate: val.CreatedDate,
 						Area: query.Area{
 							UID:             val.AreaUID,
@@ -642,7 +720,8 @@ func (s CropReadQuerySqlite) populateCrop(cropUID uuid.UUID, cropRead *storage.C
 		INITIAL_AREA_UID, INITIAL_AREA_NAME,
 		INITIAL_AREA_INITIAL_QUANTITY, INITIAL_AREA_CURRENT_QUANTITY,
 		INITIAL_AREA_LAST_WATERED, INITIAL_AREA_LAST_FERTILIZED, INITIAL_AREA_LAST_PESTICIDED,
-		INITIAL_AREA_LAST_PRUNED, INITIAL_AREA_CREATED_DATE, INITIAL_AREA_LAST_UPDATED
+		INITIAL_AREA_LAST_PRUNED, INITIAL_AREA_CREATED_DATE, INITIAL_AREA_LAST_UPDATED,
+		SHORT_CODE, SEEDS_SOWN, GERMINATED_QUANTITY, GERMINATION_DATE, MERGED_INTO_UID
 		FROM CROP_READ WHERE UID = ?`, cropUID).Scan(
 		&rowsData.UID,
 		&rowsData.BatchID,
@@ -669,6 +748,11 @@ func (s CropReadQuerySqlite) populateCrop(cropUID uuid.UUID, cropRead *storage.C
 		&rowsData.InitialAreaLastPruned,
 		&rowsData.InitialAreaCreatedDate,
 		&rowsData.InitialAreaLastUpdated,
+		&rowsData.ShortCode,
+		&rowsData.SeedsSown,
+		&rowsData.GerminatedQuantity,
+		&rowsData.GerminationDate,
+		&rowsData.MergedIntoUID,
 	)
 
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -750,6 +834,7 @@ func (s CropReadQuerySqlite) populateCrop(cropUID uuid.UUID, cropRead *storage.C
 
 	cropRead.UID = cropUID
 	cropRead.BatchID = rowsData.BatchID
+	cropRead.ShortCode = rowsData.ShortCode.String
 	cropRead.Status = rowsData.Status
 	cropRead.Type = rowsData.Type
 	cropRead.Container.Quantity = rowsData.ContainerQuantity
@@ -774,6 +859,36 @@ func (s CropReadQuerySqlite) populateCrop(cropUID uuid.UUID, cropRead *storage.C
 	cropRead.InitialArea.CreatedDate = initialAreaCreatedDate
 	cropRead.InitialArea.LastUpdated = initialAreaLastUpdated
 
+	if rowsData.SeedsSown.Valid {
+		seedsSown := int(rowsData.SeedsSown.Int64)
+		cropRead.SeedsSown = &seedsSown
+	}
+
+	if rowsData.GerminatedQuantity.Valid {
+		germinatedQuantity := int(rowsData.GerminatedQuantity.Int64)
+		cropRead.GerminatedQuantity = &germinatedQuantity
+	}
+
+	if rowsData.GerminationDate.Valid && rowsData.GerminationDate.String != "" {
+		date, err := time.Parse(time.RFC3339, rowsData.GerminationDate.String)
+		if err != nil {
+			return err
+		}
+
+		cropRead.GerminationDate = &date
+	}
+
+	cropRead.GerminationRate = storage.GerminationRate(cropRead.SeedsSown, cropRead.GerminatedQuantity)
+
+	if rowsData.MergedIntoUID.Valid && rowsData.MergedIntoUID.String != "" {
+		mergedIntoUID, err := uuid.FromString(rowsData.MergedIntoUID.String)
+		if err != nil {
+			return err
+		}
+
+		cropRead.MergedIntoUID = &mergedIntoUID
+	}
+
 	return nil
 }
 
@@ -797,6 +912,9 @@ func (s CropReadQuerySqlite) populateCropPhotos(uid uuid.UUID, cropRead *storage
 			&photoRowsData.Width,
 			&photoRowsData.Height,
 			&photoRowsData.Description,
+			&photoRowsData.Status,
+			&photoRowsData.ThumbnailFilename,
+			&photoRowsData.Metadata,
 		)
 
 		if err != nil {
@@ -808,14 +926,32 @@ func (s CropReadQuerySqlite) populateCropPhotos(uid uuid.UUID, cropRead *storage
 			return err
 		}
 
+		// The photos uploaded before their processing have no status, they were ready once saved.
+		status := domain.CropPhotoStatusReady
+		if photoRowsData.Status.Valid && photoRowsData.Status.String != "" {
+			status = photoRowsData.Status.String
+		}
+
+		metadata := domain.CropPhotoMetadata{}
+
+		if photoRowsData.Metadata.Valid && photoRowsData.Metadata.String != "" {
+			err = json.Unmarshal([]byte(photoRowsData.Metadata.String), &metadata)
+			if err != nil {
+				return err
+			}
+		}
+
 		photos = append(photos, storage.CropPhoto{
-			UID:         photoUID,
-			Filename:    photoRowsData.Filename,
-			MimeType:    photoRowsData.Mimetype,
-			Size:        photoRowsData.Size,
-			Width:       photoRowsData.Width,
-			Height:      photoRowsData.Height,
-			Description: photoRowsData.Description,
+			UID:               photoUID,
+			Filename:          photoRowsData.Filename,
+			MimeType:          photoRowsData.Mimetype,
+			Size:              photoRowsData.Size,
+			Width:             photoRowsData.Width,
+			Height:            photoRowsData.Height,
+			Description:       photoRowsData.Description,
+			Status:            status,
+			ThumbnailFilename: photoRowsData.ThumbnailFilename.String,
+			Metadata:          metadata,
 		})
 	}
 
@@ -1038,6 +1174,76 @@ func (s CropReadQuerySqlite) populateCropTrash(uid uuid.UUID, cropRead *storage.
 	return nil
 }
 
+func (s CropReadQuerySqlite) populateCropNurseryStages(uid uuid.UUID, cropRead *storage.CropRead) error {
+	stageRowsData := cropReadNurseryStageResult{}
+
+	rows, err := s.DB.Query(`SELECT * FROM CROP_READ_NURSERY_STAGE WHERE CROP_UID = ?
+		ORDER BY START_DATE`, uid)
+	if err != nil {
+		return err
+	}
+
+	stages := []storage.NurseryStage{}
+
+	for rows.Next() {
+		err = rows.Scan(
+			&stageRowsData.UID,
+			&stageRowsData.CropUID,
+			&stageRowsData.AreaUID,
+			&stageRowsData.AreaName,
+			&stageRowsData.StartDate,
+			&stageRowsData.ExpectedTransplantDate,
+			&stageRowsData.ActualTransplantDate)
+		if err != nil {
+			return err
+		}
+
+		stageUID, err := uuid.FromString(stageRowsData.UID)
+		if err != nil {
+			return err
+		}
+
+		areaUID, err := uuid.FromString(stageRowsData.AreaUID)
+		if err != nil {
+			return err
+		}
+
+		startDate, err := time.Parse(time.RFC3339, stageRowsData.StartDate)
+		if err != nil {
+			return err
+		}
+
+		expectedTransplantDate, err := time.Parse(time.RFC3339, stageRowsData.ExpectedTransplantDate)
+		if err != nil {
+			return err
+		}
+
+		var actualTransplantDate *time.Time
+
+		if stageRowsData.ActualTransplantDate.Valid && stageRowsData.ActualTransplantDate.String != "" {
+			date, err := time.Parse(time.RFC3339, stageRowsData.ActualTransplantDate.String)
+			if err != nil {
+				return err
+			}
+
+			actualTransplantDate = &date
+		}
+
+		stages = append(stages, storage.NurseryStage{
+			UID:                    stageUID,
+			AreaUID:                areaUID,
+			AreaName:               stageRowsData.AreaName,
+			StartDate:              startDate,
+			ExpectedTransplantDate: expectedTransplantDate,
+			ActualTransplantDate:   actualTransplantDate,
+		})
+	}
+
+	cropRead.NurseryStages = stages
+
+	return nil
+}
+
 func (s CropReadQuerySqlite) populateCropNotes(uid uuid.UUID, cropRead *storage.CropRead) error {
 	notesRowsData := cropReadNotesResult{}
 
@@ -1077,3 +1283,56 @@ func (s CropReadQuerySqlite) populateCropNotes(uid uuid.UUID, cropRead *storage.
 
 	return nil
 }
+
+// FindAllPendingPhotos lists the photos of every crop still waiting for their thumbnail.
+func (s CropReadQuerySqlite) FindAllPendingPhotos() <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		defer close(result)
+
+		rows, err := s.DB.Query("SELECT CROP_UID, UID FROM CROP_READ_PHOTO WHERE STATUS = ?", domain.CropPhotoStatusPending)
+		if err != nil {
+			result <- query.Result{Error: err}
+
+			return
+		}
+
+		defer rows.Close()
+
+		photos := []query.CropPendingPhotoQueryResult{}
+
+		for rows.Next() {
+			var cropUID, photoUID string
+
+			err = rows.Scan(&cropUID, &photoUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+
+				return
+			}
+
+			photo := query.CropPendingPhotoQueryResult{}
+
+			photo.CropUID, err = uuid.FromString(cropUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+
+				return
+			}
+
+			photo.PhotoUID, err = uuid.FromString(photoUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+
+				return
+			}
+
+			photos = append(photos, photo)
+		}
+
+		result <- query.Result{Result: photos}
+	}()
+
+	return result
+}
@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/decoder"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type DispatchScheduleEventQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewDispatchScheduleEventQuerySqlite(db *sql.DB) query.DispatchScheduleEventQuery {
+	return &DispatchScheduleEventQuerySqlite{DB: db}
+}
+
+func (f *DispatchScheduleEventQuerySqlite) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.DispatchScheduleEvent{}
+
+		rows, err := f.DB.Query(`SELECT DISPATCH_SCHEDULE_UID, VERSION, CREATED_DATE, EVENT
+			FROM DISPATCH_SCHEDULE_EVENT WHERE DISPATCH_SCHEDULE_UID = ? ORDER BY VERSION ASC`, uid)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		rowsData := struct {
+			DispatchScheduleUID string
+			Version             int
+			CreatedDate         string
+			Event               []byte
+		}{}
+
+		for rows.Next() {
+			err = rows.Scan(&rowsData.DispatchScheduleUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			wrapper := decoder.DispatchScheduleEventWrapper{}
+
+			err = json.Unmarshal(rowsData.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			scheduleUID, err := uuid.FromString(rowsData.DispatchScheduleUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			createdDate, err := time.Parse(time.RFC3339, rowsData.CreatedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			events = append(events, storage.DispatchScheduleEvent{
+				DispatchScheduleUID: scheduleUID,
+				Version:             rowsData.Version,
+				CreatedDate:         createdDate,
+				Event:               wrapper.Data,
+				Actor:               wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,189 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+const dispatchScheduleReadColumns = `UID, FARM_UID, HARVEST_IDS, VEHICLE_ID, DRIVER_NAME, PICKUP_TIME,
+	DESTINATION_ADDRESS, ESTIMATED_DELIVERY_TIME, STATUS, TASK_UID, COMPLETED_DATE, CREATED_DATE`
+
+type DispatchScheduleReadQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewDispatchScheduleReadQuerySqlite(db *sql.DB) query.DispatchScheduleReadQuery {
+	return DispatchScheduleReadQuerySqlite{DB: db}
+}
+
+func (s DispatchScheduleReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
+	return s.findOne(`SELECT `+dispatchScheduleReadColumns+`
+		FROM DISPATCH_SCHEDULE_READ WHERE UID = ?`, uid)
+}
+
+func (s DispatchScheduleReadQuerySqlite) FindByTaskID(taskUID uuid.UUID) <-chan query.Result {
+	return s.findOne(`SELECT `+dispatchScheduleReadColumns+`
+		FROM DISPATCH_SCHEDULE_READ WHERE TASK_UID = ?`, taskUID)
+}
+
+func (s DispatchScheduleReadQuerySqlite) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		schedules := []storage.DispatchScheduleRead{}
+
+		rows, err := s.DB.Query(`SELECT `+dispatchScheduleReadColumns+`
+			FROM DISPATCH_SCHEDULE_READ WHERE FARM_UID = ?`, farmUID)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			schedule, err := populateDispatchScheduleRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			schedules = append(schedules, schedule)
+		}
+
+		// The times are stored as RFC3339 text, which only sorts correctly within the same offset.
+		sort.Slice(schedules, func(i, j int) bool {
+			return schedules[i].PickupTime.Before(schedules[j].PickupTime)
+		})
+
+		result <- query.Result{Result: schedules}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s DispatchScheduleReadQuerySqlite) findOne(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		schedule := storage.DispatchScheduleRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			schedule, err = populateDispatchScheduleRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+		}
+
+		result <- query.Result{Result: schedule}
+		close(result)
+	}()
+
+	return result
+}
+
+func populateDispatchScheduleRead(rows *sql.Rows) (storage.DispatchScheduleRead, error) {
+	rowsData := struct {
+		UID                   string
+		FarmUID               string
+		HarvestIDs            string
+		VehicleID             string
+		DriverName            string
+		PickupTime            string
+		DestinationAddress    string
+		EstimatedDeliveryTime string
+		Status                string
+		TaskUID               sql.NullString
+		CompletedDate         sql.NullString
+		CreatedDate           string
+	}{}
+
+	err := rows.Scan(
+		&rowsData.UID, &rowsData.FarmUID, &rowsData.HarvestIDs, &rowsData.VehicleID, &rowsData.DriverName,
+		&rowsData.PickupTime, &rowsData.DestinationAddress, &rowsData.EstimatedDeliveryTime, &rowsData.Status,
+		&rowsData.TaskUID, &rowsData.CompletedDate, &rowsData.CreatedDate,
+	)
+	if err != nil {
+		return storage.DispatchScheduleRead{}, err
+	}
+
+	schedule := storage.DispatchScheduleRead{
+		HarvestIDs:         []uuid.UUID{},
+		VehicleID:          rowsData.VehicleID,
+		DriverName:         rowsData.DriverName,
+		DestinationAddress: rowsData.DestinationAddress,
+		Status:             rowsData.Status,
+	}
+
+	schedule.UID, err = uuid.FromString(rowsData.UID)
+	if err != nil {
+		return storage.DispatchScheduleRead{}, err
+	}
+
+	schedule.FarmUID, err = uuid.FromString(rowsData.FarmUID)
+	if err != nil {
+		return storage.DispatchScheduleRead{}, err
+	}
+
+	err = json.Unmarshal([]byte(rowsData.HarvestIDs), &schedule.HarvestIDs)
+	if err != nil {
+		return storage.DispatchScheduleRead{}, err
+	}
+
+	schedule.PickupTime, err = time.Parse(time.RFC3339, rowsData.PickupTime)
+	if err != nil {
+		return storage.DispatchScheduleRead{}, err
+	}
+
+	schedule.EstimatedDeliveryTime, err = time.Parse(time.RFC3339, rowsData.EstimatedDeliveryTime)
+	if err != nil {
+		return storage.DispatchScheduleRead{}, err
+	}
+
+	schedule.CreatedDate, err = time.Parse(time.RFC3339, rowsData.CreatedDate)
+	if err != nil {
+		return storage.DispatchScheduleRead{}, err
+	}
+
+	if rowsData.TaskUID.Valid && rowsData.TaskUID.String != "" {
+		taskUID, err := uuid.FromString(rowsData.TaskUID.String)
+		if err != nil {
+			return storage.DispatchScheduleRead{}, err
+		}
+
+		schedule.TaskUID = &taskUID
+	}
+
+	if rowsData.CompletedDate.Valid && rowsData.CompletedDate.String != "" {
+		completedDate, err := time.Parse(time.RFC3339, rowsData.CompletedDate.String)
+		if err != nil {
+			return storage.DispatchScheduleRead{}, err
+		}
+
+		schedule.CompletedDate = &completedDate
+	}
+
+	return schedule, nil
+}
@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/decoder"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type HarvestLotEventQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewHarvestLotEventQuerySqlite(db *sql.DB) query.HarvestLotEventQuery {
+	return &HarvestLotEventQuerySqlite{DB: db}
+}
+
+func (f *HarvestLotEventQuerySqlite) FindAllByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.HarvestLotEvent{}
+
+		rows, err := f.DB.Query(`SELECT HARVEST_LOT_UID, VERSION, CREATED_DATE, EVENT
+			FROM HARVEST_LOT_EVENT WHERE HARVEST_LOT_UID = ? ORDER BY VERSION ASC`, uid)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		rowsData := struct {
+			HarvestLotUID string
+			Version       int
+			CreatedDate   string
+			Event         []byte
+		}{}
+
+		for rows.Next() {
+			err = rows.Scan(&rowsData.HarvestLotUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			wrapper := decoder.HarvestLotEventWrapper{}
+
+			err = json.Unmarshal(rowsData.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			lotUID, err := uuid.FromString(rowsData.HarvestLotUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			createdDate, err := time.Parse(time.RFC3339, rowsData.CreatedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			events = append(events, storage.HarvestLotEvent{
+				HarvestLotUID: lotUID,
+				Version:       rowsData.Version,
+				CreatedDate:   createdDate,
+				Event:         wrapper.Data,
+				Actor:         wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,178 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+const harvestLotReadColumns = `UID, FARM_UID, LOT_NUMBER, CROP_UID, BATCH_ID, AREA_UID, HARVEST_DATE, GRADE,
+	GRAM_QUANTITY, REMAINING_GRAM_QUANTITY, STATUS, SHIPMENTS, CREATED_DATE`
+
+type HarvestLotReadQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewHarvestLotReadQuerySqlite(db *sql.DB) query.HarvestLotReadQuery {
+	return HarvestLotReadQuerySqlite{DB: db}
+}
+
+func (s HarvestLotReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		lot := storage.HarvestLotRead{}
+
+		rows, err := s.DB.Query(`SELECT `+harvestLotReadColumns+` FROM HARVEST_LOT_READ WHERE UID = ?`, uid)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			lot, err = populateHarvestLotRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+		}
+
+		result <- query.Result{Result: lot}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s HarvestLotReadQuerySqlite) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		lots := []storage.HarvestLotRead{}
+
+		rows, err := s.DB.Query(`SELECT `+harvestLotReadColumns+` FROM HARVEST_LOT_READ WHERE FARM_UID = ?`, farmUID)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			lot, err := populateHarvestLotRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			lots = append(lots, lot)
+		}
+
+		// The dates are stored as RFC3339 text, which only sorts correctly within the same offset.
+		sort.Slice(lots, func(i, j int) bool {
+			if !lots[i].HarvestDate.Equal(lots[j].HarvestDate) {
+				return lots[i].HarvestDate.After(lots[j].HarvestDate)
+			}
+
+			// L-9 comes before L-10.
+			if len(lots[i].LotNumber) != len(lots[j].LotNumber) {
+				return len(lots[i].LotNumber) < len(lots[j].LotNumber)
+			}
+
+			return lots[i].LotNumber < lots[j].LotNumber
+		})
+
+		result <- query.Result{Result: lots}
+		close(result)
+	}()
+
+	return result
+}
+
+func populateHarvestLotRead(rows *sql.Rows) (storage.HarvestLotRead, error) {
+	rowsData := struct {
+		UID          string
+		FarmUID      string
+		LotNumber    string
+		CropUID      string
+		BatchID      string
+		AreaUID      string
+		HarvestDate  string
+		Grade        string
+		GramQuantity float64
+		Remaining    float64
+		Status       string
+		Shipments    string
+		CreatedDate  string
+	}{}
+
+	err := rows.Scan(
+		&rowsData.UID, &rowsData.FarmUID, &rowsData.LotNumber, &rowsData.CropUID, &rowsData.BatchID,
+		&rowsData.AreaUID, &rowsData.HarvestDate, &rowsData.Grade, &rowsData.GramQuantity, &rowsData.Remaining,
+		&rowsData.Status, &rowsData.Shipments, &rowsData.CreatedDate,
+	)
+	if err != nil {
+		return storage.HarvestLotRead{}, err
+	}
+
+	lot := storage.HarvestLotRead{
+		LotNumber:    rowsData.LotNumber,
+		BatchID:      rowsData.BatchID,
+		Grade:        rowsData.Grade,
+		GramQuantity: rowsData.GramQuantity,
+		Remaining:    rowsData.Remaining,
+		Status:       rowsData.Status,
+		Shipments:    []domain.LotShipment{},
+	}
+
+	lot.UID, err = uuid.FromString(rowsData.UID)
+	if err != nil {
+		return storage.HarvestLotRead{}, err
+	}
+
+	lot.FarmUID, err = uuid.FromString(rowsData.FarmUID)
+	if err != nil {
+		return storage.HarvestLotRead{}, err
+	}
+
+	lot.CropUID, err = uuid.FromString(rowsData.CropUID)
+	if err != nil {
+		return storage.HarvestLotRead{}, err
+	}
+
+	lot.AreaUID, err = uuid.FromString(rowsData.AreaUID)
+	if err != nil {
+		return storage.HarvestLotRead{}, err
+	}
+
+	err = json.Unmarshal([]byte(rowsData.Shipments), &lot.Shipments)
+	if err != nil {
+		return storage.HarvestLotRead{}, err
+	}
+
+	lot.HarvestDate, err = time.Parse(time.RFC3339, rowsData.HarvestDate)
+	if err != nil {
+		return storage.HarvestLotRead{}, err
+	}
+
+	lot.CreatedDate, err = time.Parse(time.RFC3339, rowsData.CreatedDate)
+	if err != nil {
+		return storage.HarvestLotRead{}, err
+	}
+
+	return lot, nil
+}
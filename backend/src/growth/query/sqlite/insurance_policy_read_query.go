@@ -0,0 +1,176 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+const insurancePolicyReadColumns = `UID, FARM_UID, POLICY_NUMBER, PROVIDER, CROP_IDS, COVERAGE_AMOUNT, CURRENCY,
+	PREMIUM_AMOUNT, START_DATE, END_DATE, CLAIMS, IS_DELETED, CREATED_DATE`
+
+type InsurancePolicyReadQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewInsurancePolicyReadQuerySqlite(db *sql.DB) query.InsurancePolicyReadQuery {
+	return InsurancePolicyReadQuerySqlite{DB: db}
+}
+
+func (s InsurancePolicyReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
+	return s.findOne(`SELECT `+insurancePolicyReadColumns+`
+		FROM INSURANCE_POLICY_READ WHERE UID = ? AND IS_DELETED = ?`, uid, false)
+}
+
+func (s InsurancePolicyReadQuerySqlite) FindAllByFarm(farmUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		policies := []storage.InsurancePolicyRead{}
+
+		rows, err := s.DB.Query(`SELECT `+insurancePolicyReadColumns+`
+			FROM INSURANCE_POLICY_READ WHERE FARM_UID = ? AND IS_DELETED = ?`, farmUID, false)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			policy, err := populateInsurancePolicyRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			policies = append(policies, policy)
+		}
+
+		// The dates are stored as RFC3339 text, which only sorts correctly within the same offset.
+		sort.Slice(policies, func(i, j int) bool {
+			return policies[i].StartDate.Before(policies[j].StartDate)
+		})
+
+		result <- query.Result{Result: policies}
+		close(result)
+	}()
+
+	return result
+}
+
+func (s InsurancePolicyReadQuerySqlite) findOne(sqlQuery string, args ...interface{}) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		policy := storage.InsurancePolicyRead{}
+
+		rows, err := s.DB.Query(sqlQuery, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			policy, err = populateInsurancePolicyRead(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+		}
+
+		result <- query.Result{Result: policy}
+		close(result)
+	}()
+
+	return result
+}
+
+func populateInsurancePolicyRead(rows *sql.Rows) (storage.InsurancePolicyRead, error) {
+	rowsData := struct {
+		UID            string
+		FarmUID        string
+		PolicyNumber   string
+		Provider       string
+		CropIDs        string
+		CoverageAmount float64
+		Currency       string
+		PremiumAmount  float64
+		StartDate      string
+		EndDate        string
+		Claims         string
+		IsDeleted      bool
+		CreatedDate    string
+	}{}
+
+	err := rows.Scan(
+		&rowsData.UID, &rowsData.FarmUID, &rowsData.PolicyNumber, &rowsData.Provider, &rowsData.CropIDs,
+		&rowsData.CoverageAmount, &rowsData.Currency, &rowsData.PremiumAmount, &rowsData.StartDate,
+		&rowsData.EndDate, &rowsData.Claims, &rowsData.IsDeleted, &rowsData.CreatedDate,
+	)
+	if err != nil {
+		return storage.InsurancePolicyRead{}, err
+	}
+
+	policy := storage.InsurancePolicyRead{
+		PolicyNumber:   rowsData.PolicyNumber,
+		Provider:       rowsData.Provider,
+		CropIDs:        []uuid.UUID{},
+		CoverageAmount: rowsData.CoverageAmount,
+		Currency:       rowsData.Currency,
+		PremiumAmount:  rowsData.PremiumAmount,
+		Claims:         []domain.InsuranceClaim{},
+		IsDeleted:      rowsData.IsDeleted,
+	}
+
+	policy.UID, err = uuid.FromString(rowsData.UID)
+	if err != nil {
+		return storage.InsurancePolicyRead{}, err
+	}
+
+	policy.FarmUID, err = uuid.FromString(rowsData.FarmUID)
+	if err != nil {
+		return storage.InsurancePolicyRead{}, err
+	}
+
+	err = json.Unmarshal([]byte(rowsData.CropIDs), &policy.CropIDs)
+	if err != nil {
+		return storage.InsurancePolicyRead{}, err
+	}
+
+	err = json.Unmarshal([]byte(rowsData.Claims), &policy.Claims)
+	if err != nil {
+		return storage.InsurancePolicyRead{}, err
+	}
+
+	policy.StartDate, err = time.Parse(time.RFC3339, rowsData.StartDate)
+	if err != nil {
+		return storage.InsurancePolicyRead{}, err
+	}
+
+	policy.EndDate, err = time.Parse(time.RFC3339, rowsData.EndDate)
+	if err != nil {
+		return storage.InsurancePolicyRead{}, err
+	}
+
+	policy.CreatedDate, err = time.Parse(time.RFC3339, rowsData.CreatedDate)
+	if err != nil {
+		return storage.InsurancePolicyRead{}, err
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+)
+
+type MicroclimateSampleQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewMicroclimateSampleQuerySqlite(db *sql.DB) query.MicroclimateSampleQuery {
+	return MicroclimateSampleQuerySqlite{DB: db}
+}
+
+func (q MicroclimateSampleQuerySqlite) FindAllByArea(areaUID uuid.UUID, from time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		samples := []query.MicroclimateSampleQueryResult{}
+
+		// The text dates keep the offset they were recorded with, they are ordered by their julian day in UTC
+		// as the accumulation of the growing degree days stops at the first sample of the next area.
+		rows, err := q.DB.Query(`SELECT TEMPERATURE, RECORDED_DATE FROM MICROCLIMATE_SAMPLE
+			WHERE AREA_UID = ? ORDER BY julianday(RECORDED_DATE) ASC`, areaUID)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			temperature := 0.0
+			recordedDate := ""
+
+			err := rows.Scan(&temperature, &recordedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			date, err := time.Parse(time.RFC3339, recordedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			// The dates are stored as text with their offset, so they are compared once parsed.
+			if date.Before(from) {
+				continue
+			}
+
+			samples = append(samples, query.MicroclimateSampleQueryResult{
+				AreaUID:      areaUID,
+				Temperature:  temperature,
+				RecordedDate: date,
+			})
+		}
+
+		result <- query.Result{Result: samples}
+		close(result)
+	}()
+
+	return result
+}
+
+func (q MicroclimateSampleQuerySqlite) FindAllByDate(from, to time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		samples := []query.MicroclimateSampleQueryResult{}
+
+		rows, err := q.DB.Query(`SELECT AREA_UID, TEMPERATURE, RECORDED_DATE FROM MICROCLIMATE_SAMPLE
+			ORDER BY julianday(RECORDED_DATE) ASC`)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			areaUID := ""
+			temperature := 0.0
+			recordedDate := ""
+
+			err := rows.Scan(&areaUID, &temperature, &recordedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			date, err := time.Parse(time.RFC3339, recordedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			// The text dates keep the offset they were recorded with, the range is checked on the parsed ones.
+			if date.Before(from) || !date.Before(to) {
+				continue
+			}
+
+			uid, err := uuid.FromString(areaUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			samples = append(samples, query.MicroclimateSampleQueryResult{
+				AreaUID:      uid,
+				Temperature:  temperature,
+				RecordedDate: date,
+			})
+		}
+
+		result <- query.Result{Result: samples}
+		close(result)
+	}()
+
+	return result
+}
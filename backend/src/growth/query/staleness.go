@@ -0,0 +1,145 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/sasha-s/go-deadlock"
+)
+
+// ReplayingError is returned by the crop lists during a replay of the crop read model,
+// when they have no snapshot to serve.
+type ReplayingError struct{}
+
+func (ReplayingError) Error() string {
+	return "the crops are being replayed, retry later"
+}
+
+// StalenessAwareStorage keeps the crop lists up during a replay of the crop read model, while its rows are
+// rebuilt one event at a time and inconsistent. Out of a replay, it keeps the last result of each list built
+// without error. During a replay, the lists answer from it, flagged Stale, or with a ReplayingError when they
+// have none. The other queries are passed through to the read model.
+type StalenessAwareStorage struct {
+	CropReadQuery
+
+	// ServeSnapshots turns the snapshots off, the lists then answer a ReplayingError for the whole replay.
+	ServeSnapshots bool
+
+	lock      deadlock.RWMutex
+	replaying bool
+	snapshots map[string]Result
+}
+
+func NewStalenessAwareStorage(q CropReadQuery, serveSnapshots bool) *StalenessAwareStorage {
+	return &StalenessAwareStorage{
+		CropReadQuery:  q,
+		ServeSnapshots: serveSnapshots,
+		snapshots:      make(map[string]Result),
+	}
+}
+
+// IsReplaying tells if a replay is running.
+func (s *StalenessAwareStorage) IsReplaying() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.replaying
+}
+
+// StartReplay flags the read model as replaying, false when a replay is already running.
+func (s *StalenessAwareStorage) StartReplay() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.replaying {
+		return false
+	}
+
+	s.replaying = true
+
+	return true
+}
+
+// EndReplay flags the read model as consistent again, the lists are read from it again.
+func (s *StalenessAwareStorage) EndReplay() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.replaying = false
+}
+
+func (s *StalenessAwareStorage) FindAllCropsByFarm(farmUID uuid.UUID, status string, page, limit int) <-chan Result {
+	return s.snapshot(fmt.Sprintf("FindAllCropsByFarm:%s:%s:%d:%d", farmUID, status, page, limit), func() <-chan Result {
+		return s.CropReadQuery.FindAllCropsByFarm(farmUID, status, page, limit)
+	})
+}
+
+func (s *StalenessAwareStorage) CountAllCropsByFarm(farmUID uuid.UUID, status string) <-chan Result {
+	return s.snapshot(fmt.Sprintf("CountAllCropsByFarm:%s:%s", farmUID, status), func() <-chan Result {
+		return s.CropReadQuery.CountAllCropsByFarm(farmUID, status)
+	})
+}
+
+func (s *StalenessAwareStorage) FindAllCropsByArea(areaUID uuid.UUID) <-chan Result {
+	return s.snapshot(fmt.Sprintf("FindAllCropsByArea:%s", areaUID), func() <-chan Result {
+		return s.CropReadQuery.FindAllCropsByArea(areaUID)
+	})
+}
+
+func (s *StalenessAwareStorage) FindAllCropsArchives(farmUID uuid.UUID, page, limit int) <-chan Result {
+	return s.snapshot(fmt.Sprintf("FindAllCropsArchives:%s:%d:%d", farmUID, page, limit), func() <-chan Result {
+		return s.CropReadQuery.FindAllCropsArchives(farmUID, page, limit)
+	})
+}
+
+func (s *StalenessAwareStorage) CountAllArchivedCropsByFarm(farmUID uuid.UUID) <-chan Result {
+	return s.snapshot(fmt.Sprintf("CountAllArchivedCropsByFarm:%s", farmUID), func() <-chan Result {
+		return s.CropReadQuery.CountAllArchivedCropsByFarm(farmUID)
+	})
+}
+
+// snapshot runs the list out of a replay and keeps its result as the snapshot of the key.
+func (s *StalenessAwareStorage) snapshot(key string, find func() <-chan Result) <-chan Result {
+	result := make(chan Result, 1)
+
+	s.lock.RLock()
+	replaying := s.replaying
+	snapshot, found := s.snapshots[key]
+	s.lock.RUnlock()
+
+	if replaying {
+		if found && s.ServeSnapshots {
+			snapshot.Stale = true
+			result <- snapshot
+		} else {
+			result <- Result{Error: ReplayingError{}}
+		}
+
+		close(result)
+
+		return result
+	}
+
+	go func() {
+		found := <-find()
+		if found.Error == nil {
+			s.keep(key, found)
+		}
+
+		result <- found
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (s *StalenessAwareStorage) keep(key string, snapshot Result) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// The result read while a replay started may have a row the replay is rebuilding, it isn't kept.
+	if !s.replaying {
+		s.snapshots[key] = snapshot
+	}
+}
@@ -0,0 +1,76 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/growth/query"
+)
+
+type countingCropReadQuery struct {
+	CropReadQuery
+
+	total int
+}
+
+func (q *countingCropReadQuery) CountAllCropsByFarm(_ uuid.UUID, _ string) <-chan Result {
+	result := make(chan Result, 1)
+	result <- Result{Result: q.total}
+
+	close(result)
+
+	return result
+}
+
+func TestStalenessAwareStorage(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	otherFarmUID, _ := uuid.NewV4()
+
+	crops := &countingCropReadQuery{total: 3}
+	staleness := NewStalenessAwareStorage(crops, true)
+
+	// When
+	fresh := <-staleness.CountAllCropsByFarm(farmUID, "")
+
+	started := staleness.StartReplay()
+	startedAgain := staleness.StartReplay()
+
+	crops.total = 1
+
+	stale := <-staleness.CountAllCropsByFarm(farmUID, "")
+	unknown := <-staleness.CountAllCropsByFarm(otherFarmUID, "")
+
+	staleness.EndReplay()
+
+	replayed := <-staleness.CountAllCropsByFarm(farmUID, "")
+
+	// Then
+	assert.Equal(t, Result{Result: 3}, fresh)
+	assert.True(t, started)
+	assert.False(t, startedAgain)
+	assert.Equal(t, Result{Result: 3, Stale: true}, stale)
+	assert.Equal(t, Result{Error: ReplayingError{}}, unknown)
+	assert.False(t, staleness.IsReplaying())
+	assert.Equal(t, Result{Result: 1}, replayed)
+}
+
+func TestStalenessAwareStorageWithoutSnapshots(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+
+	staleness := NewStalenessAwareStorage(&countingCropReadQuery{total: 3}, false)
+	<-staleness.CountAllCropsByFarm(farmUID, "")
+
+	// When
+	staleness.StartReplay()
+
+	result := <-staleness.CountAllCropsByFarm(farmUID, "")
+
+	// Then
+	assert.True(t, staleness.IsReplaying())
+	assert.Equal(t, Result{Error: ReplayingError{}}, result)
+}
@@ -1,7 +1,10 @@
 package inmemory
 
 import (
+	"time"
+
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/growth/repository"
 	"github.com/usetania/tania-core/src/growth/storage"
 )
@@ -15,7 +18,9 @@ func NewCropEventRepositoryInMemory(s *storage.CropEventStorage) repository.Crop
 }
 
 // Save is to save.
-func (f *CropEventRepositoryInMemory) Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error {
+func (f *CropEventRepositoryInMemory) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
 	result := make(chan error)
 
 	go func() {
@@ -26,9 +31,11 @@ func (f *CropEventRepositoryInMemory) Save(uid uuid.UUID, latestVersion int, eve
 			latestVersion++
 
 			f.Storage.CropEvents = append(f.Storage.CropEvents, storage.CropEvent{
-				CropUID: uid,
-				Version: latestVersion,
-				Event:   v,
+				CropUID:     uid,
+				Version:     latestVersion,
+				CreatedDate: time.Now(),
+				Event:       v,
+				Actor:       by,
 			})
 		}
 
@@ -0,0 +1,48 @@
+package inmemory
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type CropInputScheduleEventRepositoryInMemory struct {
+	Storage *storage.CropInputScheduleEventStorage
+}
+
+func NewCropInputScheduleEventRepositoryInMemory(s *storage.CropInputScheduleEventStorage) repository.CropInputScheduleEvent {
+	return &CropInputScheduleEventRepositoryInMemory{Storage: s}
+}
+
+// Save is to save.
+func (f *CropInputScheduleEventRepositoryInMemory) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		for _, v := range events {
+			latestVersion++
+
+			f.Storage.CropInputScheduleEvents = append(f.Storage.CropInputScheduleEvents, storage.CropInputScheduleEvent{
+				CropInputScheduleUID: uid,
+				Version:              latestVersion,
+				CreatedDate:          time.Now(),
+				Event:                v,
+				Actor:                by,
+			})
+		}
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,32 @@
+package inmemory
+
+import (
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type CropInputScheduleReadRepositoryInMemory struct {
+	Storage *storage.CropInputScheduleReadStorage
+}
+
+func NewCropInputScheduleReadRepositoryInMemory(s *storage.CropInputScheduleReadStorage) repository.CropInputScheduleRead {
+	return &CropInputScheduleReadRepositoryInMemory{Storage: s}
+}
+
+// Save is to save.
+func (f *CropInputScheduleReadRepositoryInMemory) Save(scheduleRead *storage.CropInputScheduleRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		f.Storage.CropInputScheduleReadMap[scheduleRead.UID] = *scheduleRead
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
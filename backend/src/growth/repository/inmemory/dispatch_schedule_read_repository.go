@@ -0,0 +1,32 @@
+package inmemory
+
+import (
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type DispatchScheduleReadRepositoryInMemory struct {
+	Storage *storage.DispatchScheduleReadStorage
+}
+
+func NewDispatchScheduleReadRepositoryInMemory(s *storage.DispatchScheduleReadStorage) repository.DispatchScheduleRead {
+	return &DispatchScheduleReadRepositoryInMemory{Storage: s}
+}
+
+// Save is to save.
+func (f *DispatchScheduleReadRepositoryInMemory) Save(scheduleRead *storage.DispatchScheduleRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		f.Storage.DispatchScheduleReadMap[scheduleRead.UID] = *scheduleRead
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
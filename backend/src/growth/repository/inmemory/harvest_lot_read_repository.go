@@ -0,0 +1,32 @@
+package inmemory
+
+import (
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type HarvestLotReadRepositoryInMemory struct {
+	Storage *storage.HarvestLotReadStorage
+}
+
+func NewHarvestLotReadRepositoryInMemory(s *storage.HarvestLotReadStorage) repository.HarvestLotRead {
+	return &HarvestLotReadRepositoryInMemory{Storage: s}
+}
+
+// Save is to save.
+func (f *HarvestLotReadRepositoryInMemory) Save(lotRead *storage.HarvestLotRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		f.Storage.HarvestLotReadMap[lotRead.UID] = *lotRead
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,50 @@
+package inmemory
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type InsurancePolicyEventRepositoryInMemory struct {
+	Storage *storage.InsurancePolicyEventStorage
+}
+
+func NewInsurancePolicyEventRepositoryInMemory(
+	s *storage.InsurancePolicyEventStorage,
+) repository.InsurancePolicyEvent {
+	return &InsurancePolicyEventRepositoryInMemory{Storage: s}
+}
+
+// Save is to save.
+func (f *InsurancePolicyEventRepositoryInMemory) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		for _, v := range events {
+			latestVersion++
+
+			f.Storage.InsurancePolicyEvents = append(f.Storage.InsurancePolicyEvents, storage.InsurancePolicyEvent{
+				InsurancePolicyUID: uid,
+				Version:            latestVersion,
+				CreatedDate:        time.Now(),
+				Event:              v,
+				Actor:              by,
+			})
+		}
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,32 @@
+package inmemory
+
+import (
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type InsurancePolicyReadRepositoryInMemory struct {
+	Storage *storage.InsurancePolicyReadStorage
+}
+
+func NewInsurancePolicyReadRepositoryInMemory(s *storage.InsurancePolicyReadStorage) repository.InsurancePolicyRead {
+	return &InsurancePolicyReadRepositoryInMemory{Storage: s}
+}
+
+// Save is to save.
+func (f *InsurancePolicyReadRepositoryInMemory) Save(policyRead *storage.InsurancePolicyRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		f.Storage.InsurancePolicyReadMap[policyRead.UID] = *policyRead
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,31 @@
+package inmemory
+
+import (
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type MicroclimateSampleRepositoryInMemory struct {
+	Storage *storage.MicroclimateSampleStorage
+}
+
+func NewMicroclimateSampleRepositoryInMemory(s *storage.MicroclimateSampleStorage) repository.MicroclimateSample {
+	return &MicroclimateSampleRepositoryInMemory{Storage: s}
+}
+
+func (f *MicroclimateSampleRepositoryInMemory) Save(sample *storage.MicroclimateSample) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		f.Storage.MicroclimateSamples = append(f.Storage.MicroclimateSamples, *sample)
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
@@ -22,8 +22,9 @@ func (f *CropActivityRepositoryMysql) Save(cropActivity *storage.CropActivity, i
 
 	go func() {
 		at, err := json.Marshal(decoder.InterfaceWrapper{
-			Name: cropActivity.ActivityType.Code(),
-			Data: cropActivity.ActivityType,
+			Name:  cropActivity.ActivityType.Code(),
+			Data:  cropActivity.ActivityType,
+			Actor: cropActivity.Actor,
 		})
 		if err != nil {
 			result <- err
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/growth/decoder"
 	"github.com/usetania/tania-core/src/growth/repository"
 	"github.com/usetania/tania-core/src/helper/structhelper"
@@ -19,7 +20,9 @@ func NewCropEventRepositoryMysql(db *sql.DB) repository.CropEvent {
 	return &CropEventRepositoryMysql{DB: db}
 }
 
-func (f *CropEventRepositoryMysql) Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error {
+func (f *CropEventRepositoryMysql) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
 	result := make(chan error)
 
 	go func() {
@@ -32,8 +35,9 @@ func (f *CropEventRepositoryMysql) Save(uid uuid.UUID, latestVersion int, events
 			latestVersion++
 
 			e, err := json.Marshal(decoder.InterfaceWrapper{
-				Name: structhelper.GetName(v),
-				Data: v,
+				Name:  structhelper.GetName(v),
+				Data:  v,
+				Actor: by,
 			})
 			if err != nil {
 				result <- err
@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/growth/decoder"
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/helper/structhelper"
+)
+
+type CropInputScheduleEventRepositoryMysql struct {
+	DB *sql.DB
+}
+
+func NewCropInputScheduleEventRepositoryMysql(db *sql.DB) repository.CropInputScheduleEvent {
+	return &CropInputScheduleEventRepositoryMysql{DB: db}
+}
+
+func (f *CropInputScheduleEventRepositoryMysql) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		for _, v := range events {
+			stmt, err := f.DB.Prepare(`INSERT INTO CROP_INPUT_SCHEDULE_EVENT (CROP_INPUT_SCHEDULE_UID, VERSION, CREATED_DATE, EVENT) VALUES (?, ?, ?, ?)`)
+			if err != nil {
+				result <- err
+			}
+
+			latestVersion++
+
+			e, err := json.Marshal(decoder.InterfaceWrapper{
+				Name:  structhelper.GetName(v),
+				Data:  v,
+				Actor: by,
+			})
+			if err != nil {
+				result <- err
+			}
+
+			_, err = stmt.Exec(uid.Bytes(), latestVersion, time.Now(), e)
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
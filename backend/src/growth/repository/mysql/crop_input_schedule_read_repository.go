@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type CropInputScheduleReadRepositoryMysql struct {
+	DB *sql.DB
+}
+
+func NewCropInputScheduleReadRepositoryMysql(db *sql.DB) repository.CropInputScheduleRead {
+	return &CropInputScheduleReadRepositoryMysql{DB: db}
+}
+
+func (f *CropInputScheduleReadRepositoryMysql) Save(scheduleRead *storage.CropInputScheduleRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		count := 0
+
+		err := f.DB.QueryRow(`SELECT COUNT(*) FROM CROP_INPUT_SCHEDULE_READ WHERE UID = ?`,
+			scheduleRead.UID.Bytes()).Scan(&count)
+		if err != nil {
+			result <- err
+		}
+
+		var taskUID []byte
+		if scheduleRead.TaskUID != nil {
+			taskUID = scheduleRead.TaskUID.Bytes()
+		}
+
+		if count > 0 {
+			_, err = f.DB.Exec(`UPDATE CROP_INPUT_SCHEDULE_READ SET
+				CROP_UID = ?, MATERIAL_UID = ?, PLANNED_DATE = ?, QUANTITY = ?, UNIT = ?,
+				APPLICATION_METHOD = ?, STATUS = ?, TASK_UID = ?, APPLIED_DATE = ?, CREATED_DATE = ?
+				WHERE UID = ?`,
+				scheduleRead.CropUID.Bytes(), scheduleRead.MaterialUID.Bytes(), scheduleRead.PlannedDate,
+				scheduleRead.Quantity, scheduleRead.Unit, scheduleRead.ApplicationMethod, scheduleRead.Status,
+				taskUID, scheduleRead.AppliedDate, scheduleRead.CreatedDate,
+				scheduleRead.UID.Bytes())
+			if err != nil {
+				result <- err
+			}
+		} else {
+			_, err = f.DB.Exec(`INSERT INTO CROP_INPUT_SCHEDULE_READ
+				(UID, CROP_UID, MATERIAL_UID, PLANNED_DATE, QUANTITY, UNIT,
+				APPLICATION_METHOD, STATUS, TASK_UID, APPLIED_DATE, CREATED_DATE)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				scheduleRead.UID.Bytes(), scheduleRead.CropUID.Bytes(), scheduleRead.MaterialUID.Bytes(),
+				scheduleRead.PlannedDate, scheduleRead.Quantity, scheduleRead.Unit,
+				scheduleRead.ApplicationMethod, scheduleRead.Status, taskUID, scheduleRead.AppliedDate,
+				scheduleRead.CreatedDate)
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
@@ -2,6 +2,8 @@ package sqlite
 
 import (
 	"database/sql"
+	"encoding/json"
+	"strings"
 
 	"github.com/usetania/tania-core/src/growth/repository"
 	"github.com/usetania/tania-core/src/growth/storage"
@@ -26,6 +28,11 @@ func (f *CropReadRepositoryMysql) Save(cropRead *storage.CropRead) <-chan error
 			result <- err
 		}
 
+		var mergedIntoUID interface{}
+		if cropRead.MergedIntoUID != nil {
+			mergedIntoUID = cropRead.MergedIntoUID.Bytes()
+		}
+
 		if count > 0 {
 			_, err = f.DB.Exec(`UPDATE CROP_READ SET
 				BATCH_ID = ?, STATUS = ?, TYPE = ?,
@@ -37,7 +44,10 @@ func (f *CropReadRepositoryMysql) Save(cropRead *storage.CropRead) <-chan error
 				INITIAL_AREA_INITIAL_QUANTITY = ?, INITIAL_AREA_CURRENT_QUANTITY = ?,
 				INITIAL_AREA_LAST_WATERED = ?, INITIAL_AREA_LAST_FERTILIZED = ?,
 				INITIAL_AREA_LAST_PESTICIDED = ?, INITIAL_AREA_LAST_PRUNED = ?,
-				INITIAL_AREA_CREATED_DATE = ?, INITIAL_AREA_LAST_UPDATED = ?
+				INITIAL_AREA_CREATED_DATE = ?, INITIAL_AREA_LAST_UPDATED = ?,
+				SHORT_CODE = ?,
+				SEEDS_SOWN = ?, GERMINATED_QUANTITY = ?, GERMINATION_DATE = ?,
+				MERGED_INTO_UID = ?
 				WHERE UID = ?`,
 				cropRead.BatchID,
 				cropRead.Status,
@@ -63,19 +73,48 @@ func (f *CropReadRepositoryMysql) Save(cropRead *storage.CropRead) <-chan error
 				cropRead.InitialArea.LastPruned,
 				cropRead.InitialArea.CreatedDate,
 				cropRead.InitialArea.LastUpdated,
+				cropRead.ShortCode,
+				cropRead.SeedsSown,
+				cropRead.GerminatedQuantity,
+				cropRead.GerminationDate,
+				mergedIntoUID,
 				cropRead.UID.Bytes())
 
 			if err != nil {
 				result <- err
 			}
 
+			// The photos removed from the crop are the rows not in the read model anymore.
+			photoQuery := `DELETE FROM CROP_READ_PHOTO WHERE CROP_UID = ?`
+			photoArgs := []interface{}{cropRead.UID.Bytes()}
+
 			if len(cropRead.Photos) > 0 {
+				photoQuery += ` AND UID NOT IN (?` + strings.Repeat(`, ?`, len(cropRead.Photos)-1) + `)`
+
 				for _, v := range cropRead.Photos {
+					photoArgs = append(photoArgs, v.UID.Bytes())
+				}
+			}
+
+			_, err = f.DB.Exec(photoQuery, photoArgs...)
+			if err != nil {
+				result <- err
+			}
+
+			if len(cropRead.Photos) > 0 {
+				for _, v := range cropRead.Photos {
+					metadata, err := json.Marshal(v.Metadata)
+					if err != nil {
+						result <- err
+					}
+
 					res, err := f.DB.Exec(`UPDATE CROP_READ_PHOTO
 						SET FILENAME = ?, MIMETYPE = ?, SIZE = ?,
-						WIDTH = ?, HEIGHT = ?, DESCRIPTION = ?
+						WIDTH = ?, HEIGHT = ?, DESCRIPTION = ?,
+						STATUS = ?, THUMBNAIL_FILENAME = ?, METADATA = ?
 						WHERE UID = ?`,
-						v.Filename, v.MimeType, v.Size, v.Width, v.Height, v.Description, v.UID.Bytes())
+						v.Filename, v.MimeType, v.Size, v.Width, v.Height, v.Description,
+						v.Status, v.ThumbnailFilename, string(metadata), v.UID.Bytes())
 					if err != nil {
 						result <- err
 					}
@@ -87,9 +126,11 @@ func (f *CropReadRepositoryMysql) Save(cropRead *storage.CropRead) <-chan error
 
 					if rowsAffected == 0 {
 						f.DB.Exec(`INSERT INTO CROP_READ_PHOTO (
-							UID, CROP_UID, FILENAME, MIMETYPE, SIZE, WIDTH, HEIGHT, DESCRIPTION)
-							VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
-							v.UID.Bytes(), cropRead.UID.Bytes(), v.Filename, v.MimeType, v.Size, v.Width, v.Height, v.Description)
+							UID, CROP_UID, FILENAME, MIMETYPE, SIZE, WIDTH, HEIGHT, DESCRIPTION,
+							STATUS, THUMBNAIL_FILENAME, METADATA)
+							VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+							v.UID.Bytes(), cropRead.UID.Bytes(), v.Filename, v.MimeType, v.Size, v.Width, v.Height, v.Description,
+							v.Status, v.ThumbnailFilename, string(metadata))
 
 						if err != nil {
 							result <- err
@@ -203,6 +244,38 @@ func (f *CropReadRepositoryMysql) Save(cropRead *storage.CropRead) <-chan error
 				}
 			}
 
+			if len(cropRead.NurseryStages) > 0 {
+				for _, v := range cropRead.NurseryStages {
+					res, err := f.DB.Exec(`UPDATE CROP_READ_NURSERY_STAGE
+						SET AREA_UID = ?, AREA_NAME = ?, START_DATE = ?,
+						EXPECTED_TRANSPLANT_DATE = ?, ACTUAL_TRANSPLANT_DATE = ?
+						WHERE UID = ?`,
+						v.AreaUID.Bytes(), v.AreaName, v.StartDate,
+						v.ExpectedTransplantDate, v.ActualTransplantDate, v.UID.Bytes())
+					if err != nil {
+						result <- err
+					}
+
+					rowsAffected, err := res.RowsAffected()
+					if err != nil {
+						result <- err
+					}
+
+					if rowsAffected == 0 {
+						_, err = f.DB.Exec(`INSERT INTO CROP_READ_NURSERY_STAGE (
+							UID, CROP_UID, AREA_UID, AREA_NAME, START_DATE,
+							EXPECTED_TRANSPLANT_DATE, ACTUAL_TRANSPLANT_DATE)
+							VALUES (?, ?, ?, ?, ?, ?, ?)`,
+							v.UID.Bytes(), cropRead.UID.Bytes(), v.AreaUID.Bytes(), v.AreaName,
+							v.StartDate, v.ExpectedTransplantDate, v.ActualTransplantDate)
+
+						if err != nil {
+							result <- err
+						}
+					}
+				}
+			}
+
 			if len(cropRead.Notes) > 0 {
 				// Just delete them all then insert them all again.
 				// We can refactor it later.
@@ -228,8 +301,9 @@ func (f *CropReadRepositoryMysql) Save(cropRead *storage.CropRead) <-chan error
 				INITIAL_AREA_UID, INITIAL_AREA_NAME,
 				INITIAL_AREA_INITIAL_QUANTITY, INITIAL_AREA_CURRENT_QUANTITY,
 				INITIAL_AREA_LAST_WATERED, INITIAL_AREA_LAST_FERTILIZED, INITIAL_AREA_LAST_PESTICIDED,
-				INITIAL_AREA_LAST_PRUNED, INITIAL_AREA_CREATED_DATE, INITIAL_AREA_LAST_UPDATED)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				INITIAL_AREA_LAST_PRUNED, INITIAL_AREA_CREATED_DATE, INITIAL_AREA_LAST_UPDATED,
+				SHORT_CODE, SEEDS_SOWN, GERMINATED_QUANTITY, GERMINATION_DATE, MERGED_INTO_UID)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 				cropRead.UID.Bytes(),
 				cropRead.BatchID,
 				cropRead.Status,
@@ -254,16 +328,70 @@ func (f *CropReadRepositoryMysql) Save(cropRead *storage.CropRead) <-chan error
 				cropRead.InitialArea.LastPesticided,
 				cropRead.InitialArea.LastPruned,
 				cropRead.InitialArea.CreatedDate,
-				cropRead.InitialArea.LastUpdated)
+				cropRead.InitialArea.LastUpdated,
+				cropRead.ShortCode,
+				cropRead.SeedsSown,
+				cropRead.GerminatedQuantity,
+				cropRead.GerminationDate,
+				mergedIntoUID)
 
 			if err != nil {
 				result <- err
 			}
 		}
 
-		result <- nil
+		result <- f.saveAreaCropsCurrent(cropRead)
 		close(result)
 	}()
 
 	return result
 }
+
+// saveAreaCropsCurrent keeps the AREA_CROP_CURRENT rows of the crop in line with the areas it has plants in.
+// The rows of the areas the crop left, or of all of them once it's archived, are removed.
+func (f *CropReadRepositoryMysql) saveAreaCropsCurrent(cropRead *storage.CropRead) error {
+	current := storage.AreaCropsCurrent(*cropRead)
+
+	deleteQuery := `DELETE FROM AREA_CROP_CURRENT WHERE CROP_UID = ?`
+	deleteArgs := []interface{}{cropRead.UID.Bytes()}
+
+	if len(current) > 0 {
+		deleteQuery += ` AND AREA_UID NOT IN (?` + strings.Repeat(`, ?`, len(current)-1) + `)`
+
+		for _, v := range current {
+			deleteArgs = append(deleteArgs, v.AreaUID.Bytes())
+		}
+	}
+
+	_, err := f.DB.Exec(deleteQuery, deleteArgs...)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range current {
+		res, err := f.DB.Exec(`UPDATE AREA_CROP_CURRENT
+			SET FARM_UID = ?, QUANTITY = ?, PLACED_DATE = ?, LAST_UPDATED = ?
+			WHERE CROP_UID = ? AND AREA_UID = ?`,
+			v.FarmUID.Bytes(), v.Quantity, v.PlacedDate, v.LastUpdated, cropRead.UID.Bytes(), v.AreaUID.Bytes())
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			_, err = f.DB.Exec(`INSERT INTO AREA_CROP_CURRENT
+				(AREA_UID, CROP_UID, FARM_UID, QUANTITY, PLACED_DATE, LAST_UPDATED)
+				VALUES (?, ?, ?, ?, ?, ?)`,
+				v.AreaUID.Bytes(), cropRead.UID.Bytes(), v.FarmUID.Bytes(), v.Quantity, v.PlacedDate, v.LastUpdated)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type DispatchScheduleReadRepositoryMysql struct {
+	DB *sql.DB
+}
+
+func NewDispatchScheduleReadRepositoryMysql(db *sql.DB) repository.DispatchScheduleRead {
+	return &DispatchScheduleReadRepositoryMysql{DB: db}
+}
+
+func (f *DispatchScheduleReadRepositoryMysql) Save(scheduleRead *storage.DispatchScheduleRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		harvestIDs, err := json.Marshal(scheduleRead.HarvestIDs)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		var taskUID []byte
+		if scheduleRead.TaskUID != nil {
+			taskUID = scheduleRead.TaskUID.Bytes()
+		}
+
+		_, err = f.DB.Exec(`INSERT INTO DISPATCH_SCHEDULE_READ
+			(UID, FARM_UID, HARVEST_IDS, VEHICLE_ID, DRIVER_NAME, PICKUP_TIME, DESTINATION_ADDRESS,
+			ESTIMATED_DELIVERY_TIME, STATUS, TASK_UID, COMPLETED_DATE, CREATED_DATE)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE
+			FARM_UID = VALUES(FARM_UID), HARVEST_IDS = VALUES(HARVEST_IDS), VEHICLE_ID = VALUES(VEHICLE_ID),
+			DRIVER_NAME = VALUES(DRIVER_NAME), PICKUP_TIME = VALUES(PICKUP_TIME),
+			DESTINATION_ADDRESS = VALUES(DESTINATION_ADDRESS), ESTIMATED_DELIVERY_TIME = VALUES(ESTIMATED_DELIVERY_TIME),
+			STATUS = VALUES(STATUS), TASK_UID = VALUES(TASK_UID), COMPLETED_DATE = VALUES(COMPLETED_DATE),
+			CREATED_DATE = VALUES(CREATED_DATE)`,
+			scheduleRead.UID.Bytes(), scheduleRead.FarmUID.Bytes(), string(harvestIDs), scheduleRead.VehicleID,
+			scheduleRead.DriverName, scheduleRead.PickupTime, scheduleRead.DestinationAddress,
+			scheduleRead.EstimatedDeliveryTime, scheduleRead.Status, taskUID, scheduleRead.CompletedDate,
+			scheduleRead.CreatedDate)
+
+		result <- err
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/growth/decoder"
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/helper/structhelper"
+)
+
+type HarvestLotEventRepositoryMysql struct {
+	DB *sql.DB
+}
+
+func NewHarvestLotEventRepositoryMysql(db *sql.DB) repository.HarvestLotEvent {
+	return &HarvestLotEventRepositoryMysql{DB: db}
+}
+
+func (f *HarvestLotEventRepositoryMysql) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		for _, v := range events {
+			latestVersion++
+
+			e, err := json.Marshal(decoder.InterfaceWrapper{
+				Name:  structhelper.GetName(v),
+				Data:  v,
+				Actor: by,
+			})
+			if err != nil {
+				result <- err
+				close(result)
+
+				return
+			}
+
+			_, err = f.DB.Exec(`INSERT INTO HARVEST_LOT_EVENT
+				(HARVEST_LOT_UID, VERSION, CREATED_DATE, EVENT) VALUES (?, ?, ?, ?)`,
+				uid.Bytes(), latestVersion, time.Now(), e)
+			if err != nil {
+				result <- err
+				close(result)
+
+				return
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
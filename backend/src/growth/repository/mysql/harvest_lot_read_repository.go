@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type HarvestLotReadRepositoryMysql struct {
+	DB *sql.DB
+}
+
+func NewHarvestLotReadRepositoryMysql(db *sql.DB) repository.HarvestLotRead {
+	return &HarvestLotReadRepositoryMysql{DB: db}
+}
+
+func (f *HarvestLotReadRepositoryMysql) Save(lotRead *storage.HarvestLotRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		shipments, err := json.Marshal(lotRead.Shipments)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		_, err = f.DB.Exec(`INSERT INTO HARVEST_LOT_READ
+			(UID, FARM_UID, LOT_NUMBER, CROP_UID, BATCH_ID, AREA_UID, HARVEST_DATE, GRADE, GRAM_QUANTITY,
+			REMAINING_GRAM_QUANTITY, STATUS, SHIPMENTS, CREATED_DATE)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE
+			FARM_UID = VALUES(FARM_UID), LOT_NUMBER = VALUES(LOT_NUMBER), CROP_UID = VALUES(CROP_UID),
+			BATCH_ID = VALUES(BATCH_ID), AREA_UID = VALUES(AREA_UID), HARVEST_DATE = VALUES(HARVEST_DATE),
+			GRADE = VALUES(GRADE), GRAM_QUANTITY = VALUES(GRAM_QUANTITY),
+			REMAINING_GRAM_QUANTITY = VALUES(REMAINING_GRAM_QUANTITY), STATUS = VALUES(STATUS),
+			SHIPMENTS = VALUES(SHIPMENTS), CREATED_DATE = VALUES(CREATED_DATE)`,
+			lotRead.UID.Bytes(), lotRead.FarmUID.Bytes(), lotRead.LotNumber, lotRead.CropUID.Bytes(),
+			lotRead.BatchID, lotRead.AreaUID.Bytes(), lotRead.HarvestDate, lotRead.Grade, lotRead.GramQuantity,
+			lotRead.Remaining, lotRead.Status, string(shipments), lotRead.CreatedDate)
+
+		result <- err
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type InsurancePolicyReadRepositoryMysql struct {
+	DB *sql.DB
+}
+
+func NewInsurancePolicyReadRepositoryMysql(db *sql.DB) repository.InsurancePolicyRead {
+	return &InsurancePolicyReadRepositoryMysql{DB: db}
+}
+
+func (f *InsurancePolicyReadRepositoryMysql) Save(policyRead *storage.InsurancePolicyRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		cropIDs, err := json.Marshal(policyRead.CropIDs)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		claims, err := json.Marshal(policyRead.Claims)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		_, err = f.DB.Exec(`INSERT INTO INSURANCE_POLICY_READ
+			(UID, FARM_UID, POLICY_NUMBER, PROVIDER, CROP_IDS, COVERAGE_AMOUNT, CURRENCY, PREMIUM_AMOUNT,
+			START_DATE, END_DATE, CLAIMS, IS_DELETED, CREATED_DATE)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE
+			FARM_UID = VALUES(FARM_UID), POLICY_NUMBER = VALUES(POLICY_NUMBER), PROVIDER = VALUES(PROVIDER),
+			CROP_IDS = VALUES(CROP_IDS), COVERAGE_AMOUNT = VALUES(COVERAGE_AMOUNT), CURRENCY = VALUES(CURRENCY),
+			PREMIUM_AMOUNT = VALUES(PREMIUM_AMOUNT), START_DATE = VALUES(START_DATE), END_DATE = VALUES(END_DATE),
+			CLAIMS = VALUES(CLAIMS), IS_DELETED = VALUES(IS_DELETED), CREATED_DATE = VALUES(CREATED_DATE)`,
+			policyRead.UID.Bytes(), policyRead.FarmUID.Bytes(), policyRead.PolicyNumber, policyRead.Provider,
+			string(cropIDs), policyRead.CoverageAmount, policyRead.Currency, policyRead.PremiumAmount,
+			policyRead.StartDate, policyRead.EndDate, string(claims), policyRead.IsDeleted, policyRead.CreatedDate)
+
+		result <- err
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type MicroclimateSampleRepositoryMysql struct {
+	DB *sql.DB
+}
+
+func NewMicroclimateSampleRepositoryMysql(db *sql.DB) repository.MicroclimateSample {
+	return &MicroclimateSampleRepositoryMysql{DB: db}
+}
+
+func (f *MicroclimateSampleRepositoryMysql) Save(sample *storage.MicroclimateSample) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		_, err := f.DB.Exec(`INSERT INTO MICROCLIMATE_SAMPLE
+			(UID, AREA_UID, TEMPERATURE, RECORDED_DATE)
+			VALUES (?, ?, ?, ?)`,
+			sample.UID.Bytes(),
+			sample.AreaUID.Bytes(),
+			sample.Temperature,
+			sample.RecordedDate)
+		if err != nil {
+			result <- err
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
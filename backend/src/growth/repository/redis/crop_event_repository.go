@@ -0,0 +1,57 @@
+package redis
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/growth/decoder"
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/helper/structhelper"
+	redisstorage "github.com/usetania/tania-core/src/storage/redis"
+)
+
+type CropEventRepositoryRedis struct {
+	Storage *redisstorage.RedisStreamsEventStorage
+}
+
+func NewCropEventRepositoryRedis(s *redisstorage.RedisStreamsEventStorage) repository.CropEvent {
+	return &CropEventRepositoryRedis{Storage: s}
+}
+
+func (f *CropEventRepositoryRedis) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		streamEvents := []redisstorage.StreamEvent{}
+		now := time.Now()
+
+		for i, v := range events {
+			e, err := json.Marshal(decoder.InterfaceWrapper{
+				Name:  structhelper.GetName(v),
+				Data:  v,
+				Actor: by,
+			})
+			if err != nil {
+				result <- err
+				close(result)
+
+				return
+			}
+
+			streamEvents = append(streamEvents, redisstorage.StreamEvent{
+				Version:     latestVersion + i + 1,
+				CreatedDate: now,
+				Event:       e,
+			})
+		}
+
+		result <- f.Storage.Append(redisstorage.AggregateCrop, uid, latestVersion, streamEvents)
+		close(result)
+	}()
+
+	return result
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/growth/domain"
 	"github.com/usetania/tania-core/src/growth/storage"
 )
@@ -14,7 +15,7 @@ type Result struct {
 }
 
 type CropEvent interface {
-	Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
 }
 
 type CropRead interface {
@@ -34,3 +35,79 @@ func NewCropBatchFromHistory(events []storage.CropEvent) *domain.Crop {
 type CropActivity interface {
 	Save(cropActivity *storage.CropActivity, isUpdate bool) <-chan error
 }
+
+type CropInputScheduleEvent interface {
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
+}
+
+type CropInputScheduleRead interface {
+	Save(scheduleRead *storage.CropInputScheduleRead) <-chan error
+}
+
+func NewCropInputScheduleFromHistory(events []storage.CropInputScheduleEvent) *domain.CropInputSchedule {
+	state := &domain.CropInputSchedule{}
+	for _, v := range events {
+		state.Transition(v.Event)
+		state.Version++
+	}
+
+	return state
+}
+
+type DispatchScheduleEvent interface {
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
+}
+
+type DispatchScheduleRead interface {
+	Save(scheduleRead *storage.DispatchScheduleRead) <-chan error
+}
+
+func NewDispatchScheduleFromHistory(events []storage.DispatchScheduleEvent) *domain.DispatchSchedule {
+	state := &domain.DispatchSchedule{}
+	for _, v := range events {
+		state.Transition(v.Event)
+		state.Version++
+	}
+
+	return state
+}
+
+type InsurancePolicyEvent interface {
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
+}
+
+type InsurancePolicyRead interface {
+	Save(policyRead *storage.InsurancePolicyRead) <-chan error
+}
+
+func NewInsurancePolicyFromHistory(events []storage.InsurancePolicyEvent) *domain.InsurancePolicy {
+	state := &domain.InsurancePolicy{}
+	for _, v := range events {
+		state.Transition(v.Event)
+		state.Version++
+	}
+
+	return state
+}
+
+type HarvestLotEvent interface {
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
+}
+
+type HarvestLotRead interface {
+	Save(lotRead *storage.HarvestLotRead) <-chan error
+}
+
+func NewHarvestLotFromHistory(events []storage.HarvestLotEvent) *domain.HarvestLot {
+	state := &domain.HarvestLot{}
+	for _, v := range events {
+		state.Transition(v.Event)
+		state.Version++
+	}
+
+	return state
+}
+
+type MicroclimateSample interface {
+	Save(sample *storage.MicroclimateSample) <-chan error
+}
@@ -23,8 +23,9 @@ func (f *CropActivityRepositorySqlite) Save(cropActivity *storage.CropActivity,
 
 	go func() {
 		at, err := json.Marshal(decoder.InterfaceWrapper{
-			Name: cropActivity.ActivityType.Code(),
-			Data: cropActivity.ActivityType,
+			Name:  cropActivity.ActivityType.Code(),
+			Data:  cropActivity.ActivityType,
+			Actor: cropActivity.Actor,
 		})
 		if err != nil {
 			result <- err
@@ -0,0 +1,71 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type CropInputScheduleReadRepositorySqlite struct {
+	DB *sql.DB
+}
+
+func NewCropInputScheduleReadRepositorySqlite(db *sql.DB) repository.CropInputScheduleRead {
+	return &CropInputScheduleReadRepositorySqlite{DB: db}
+}
+
+func (f *CropInputScheduleReadRepositorySqlite) Save(scheduleRead *storage.CropInputScheduleRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		count := 0
+
+		err := f.DB.QueryRow(`SELECT COUNT(*) FROM CROP_INPUT_SCHEDULE_READ WHERE UID = ?`, scheduleRead.UID).Scan(&count)
+		if err != nil {
+			result <- err
+		}
+
+		var taskUID string
+		if scheduleRead.TaskUID != nil {
+			taskUID = scheduleRead.TaskUID.String()
+		}
+
+		var appliedDate string
+		if scheduleRead.AppliedDate != nil && !scheduleRead.AppliedDate.IsZero() {
+			appliedDate = scheduleRead.AppliedDate.Format(time.RFC3339)
+		}
+
+		if count > 0 {
+			_, err = f.DB.Exec(`UPDATE CROP_INPUT_SCHEDULE_READ SET
+				CROP_UID = ?, MATERIAL_UID = ?, PLANNED_DATE = ?, QUANTITY = ?, UNIT = ?,
+				APPLICATION_METHOD = ?, STATUS = ?, TASK_UID = ?, APPLIED_DATE = ?, CREATED_DATE = ?
+				WHERE UID = ?`,
+				scheduleRead.CropUID, scheduleRead.MaterialUID, scheduleRead.PlannedDate.Format(time.RFC3339),
+				scheduleRead.Quantity, scheduleRead.Unit, scheduleRead.ApplicationMethod, scheduleRead.Status,
+				taskUID, appliedDate, scheduleRead.CreatedDate.Format(time.RFC3339),
+				scheduleRead.UID)
+			if err != nil {
+				result <- err
+			}
+		} else {
+			_, err = f.DB.Exec(`INSERT INTO CROP_INPUT_SCHEDULE_READ
+				(UID, CROP_UID, MATERIAL_UID, PLANNED_DATE, QUANTITY, UNIT,
+				APPLICATION_METHOD, STATUS, TASK_UID, APPLIED_DATE, CREATED_DATE)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				scheduleRead.UID, scheduleRead.CropUID, scheduleRead.MaterialUID,
+				scheduleRead.PlannedDate.Format(time.RFC3339), scheduleRead.Quantity, scheduleRead.Unit,
+				scheduleRead.ApplicationMethod, scheduleRead.Status, taskUID, appliedDate,
+				scheduleRead.CreatedDate.Format(time.RFC3339))
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,56 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type DispatchScheduleReadRepositorySqlite struct {
+	DB *sql.DB
+}
+
+func NewDispatchScheduleReadRepositorySqlite(db *sql.DB) repository.DispatchScheduleRead {
+	return &DispatchScheduleReadRepositorySqlite{DB: db}
+}
+
+func (f *DispatchScheduleReadRepositorySqlite) Save(scheduleRead *storage.DispatchScheduleRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		harvestIDs, err := json.Marshal(scheduleRead.HarvestIDs)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		var taskUID string
+		if scheduleRead.TaskUID != nil {
+			taskUID = scheduleRead.TaskUID.String()
+		}
+
+		var completedDate string
+		if scheduleRead.CompletedDate != nil {
+			completedDate = scheduleRead.CompletedDate.Format(time.RFC3339)
+		}
+
+		_, err = f.DB.Exec(`INSERT OR REPLACE INTO DISPATCH_SCHEDULE_READ
+			(UID, FARM_UID, HARVEST_IDS, VEHICLE_ID, DRIVER_NAME, PICKUP_TIME, DESTINATION_ADDRESS,
+			ESTIMATED_DELIVERY_TIME, STATUS, TASK_UID, COMPLETED_DATE, CREATED_DATE)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			scheduleRead.UID, scheduleRead.FarmUID, string(harvestIDs), scheduleRead.VehicleID,
+			scheduleRead.DriverName, scheduleRead.PickupTime.Format(time.RFC3339), scheduleRead.DestinationAddress,
+			scheduleRead.EstimatedDeliveryTime.Format(time.RFC3339), scheduleRead.Status, taskUID, completedDate,
+			scheduleRead.CreatedDate.Format(time.RFC3339))
+
+		result <- err
+		close(result)
+	}()
+
+	return result
+}
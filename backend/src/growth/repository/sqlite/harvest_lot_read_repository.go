@@ -0,0 +1,45 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type HarvestLotReadRepositorySqlite struct {
+	DB *sql.DB
+}
+
+func NewHarvestLotReadRepositorySqlite(db *sql.DB) repository.HarvestLotRead {
+	return &HarvestLotReadRepositorySqlite{DB: db}
+}
+
+func (f *HarvestLotReadRepositorySqlite) Save(lotRead *storage.HarvestLotRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		shipments, err := json.Marshal(lotRead.Shipments)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		_, err = f.DB.Exec(`INSERT OR REPLACE INTO HARVEST_LOT_READ
+			(UID, FARM_UID, LOT_NUMBER, CROP_UID, BATCH_ID, AREA_UID, HARVEST_DATE, GRADE, GRAM_QUANTITY,
+			REMAINING_GRAM_QUANTITY, STATUS, SHIPMENTS, CREATED_DATE)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			lotRead.UID, lotRead.FarmUID, lotRead.LotNumber, lotRead.CropUID, lotRead.BatchID, lotRead.AreaUID,
+			lotRead.HarvestDate.Format(time.RFC3339), lotRead.Grade, lotRead.GramQuantity, lotRead.Remaining,
+			lotRead.Status, string(shipments), lotRead.CreatedDate.Format(time.RFC3339))
+
+		result <- err
+		close(result)
+	}()
+
+	return result
+}
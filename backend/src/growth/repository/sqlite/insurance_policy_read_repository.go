@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type InsurancePolicyReadRepositorySqlite struct {
+	DB *sql.DB
+}
+
+func NewInsurancePolicyReadRepositorySqlite(db *sql.DB) repository.InsurancePolicyRead {
+	return &InsurancePolicyReadRepositorySqlite{DB: db}
+}
+
+func (f *InsurancePolicyReadRepositorySqlite) Save(policyRead *storage.InsurancePolicyRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		cropIDs, err := json.Marshal(policyRead.CropIDs)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		claims, err := json.Marshal(policyRead.Claims)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		_, err = f.DB.Exec(`INSERT OR REPLACE INTO INSURANCE_POLICY_READ
+			(UID, FARM_UID, POLICY_NUMBER, PROVIDER, CROP_IDS, COVERAGE_AMOUNT, CURRENCY, PREMIUM_AMOUNT,
+			START_DATE, END_DATE, CLAIMS, IS_DELETED, CREATED_DATE)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			policyRead.UID, policyRead.FarmUID, policyRead.PolicyNumber, policyRead.Provider, string(cropIDs),
+			policyRead.CoverageAmount, policyRead.Currency, policyRead.PremiumAmount,
+			policyRead.StartDate.Format(time.RFC3339), policyRead.EndDate.Format(time.RFC3339), string(claims),
+			policyRead.IsDeleted, policyRead.CreatedDate.Format(time.RFC3339))
+
+		result <- err
+		close(result)
+	}()
+
+	return result
+}
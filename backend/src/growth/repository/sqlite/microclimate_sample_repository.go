@@ -0,0 +1,39 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+type MicroclimateSampleRepositorySqlite struct {
+	DB *sql.DB
+}
+
+func NewMicroclimateSampleRepositorySqlite(db *sql.DB) repository.MicroclimateSample {
+	return &MicroclimateSampleRepositorySqlite{DB: db}
+}
+
+func (f *MicroclimateSampleRepositorySqlite) Save(sample *storage.MicroclimateSample) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		_, err := f.DB.Exec(`INSERT INTO MICROCLIMATE_SAMPLE
+			(UID, AREA_UID, TEMPERATURE, RECORDED_DATE)
+			VALUES (?, ?, ?, ?)`,
+			sample.UID,
+			sample.AreaUID,
+			sample.Temperature,
+			sample.RecordedDate.Format(time.RFC3339))
+		if err != nil {
+			result <- err
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
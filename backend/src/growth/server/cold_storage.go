@@ -0,0 +1,31 @@
+package server
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/retention"
+)
+
+// findCropEvents returns the event history of the crop, or a cold storage error
+// when the retention job has already archived and pruned it.
+func (s *GrowthServer) findCropEvents(cropUID uuid.UUID) query.Result {
+	err := s.checkCropHistory(cropUID)
+	if err != nil {
+		return query.Result{Error: err}
+	}
+
+	return <-s.CropEventQuery.FindAllByCropID(cropUID)
+}
+
+func (s *GrowthServer) checkCropHistory(cropUID uuid.UUID) error {
+	pruned, err := s.PrunedQuery.FindPruned(retention.AggregateCrop, cropUID)
+	if err != nil {
+		return err
+	}
+
+	if pruned != nil {
+		return NewRequestValidationError(ColdStorage, "id")
+	}
+
+	return nil
+}
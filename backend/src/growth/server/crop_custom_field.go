@@ -0,0 +1,126 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/customfield"
+	"github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/helper/paginationhelper"
+)
+
+// SaveCropCustomFields replaces the custom field values of the crop, checked against the fields of its farm.
+func (s *GrowthServer) SaveCropCustomFields(c echo.Context) error {
+	cropUID, err := s.parseCropUID(c, "id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	result := <-s.CropReadQuery.FindByID(cropUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	crop, ok := result.Result.(storage.CropRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	if crop.UID != cropUID {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	value := c.FormValue("custom_fields")
+	if value == "" {
+		return Error(c, NewRequestValidationError(Required, "custom_fields"))
+	}
+
+	values, err := customfield.ParseValues(value)
+	if err != nil {
+		return Error(c, NewRequestValidationError(ParseFailed, "custom_fields"))
+	}
+
+	// PROCESS AND PERSIST //
+	values, err = s.CustomFields.Change(crop.FarmUID, assetsdomain.CustomFieldEntityCrop, crop.UID, values)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	safeHarvestDate, err := s.findSafeHarvestDate(crop.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]CropDetail)
+	data["data"] = CropDetail{CropRead: crop, CustomFields: values, SafeHarvestDate: safeHarvestDate}
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// findAllCropsByCustomFields lists the crops of the farm matching the `cf_` filters. The filters apply
+// after the query, so the page is taken from all the crops of the farm.
+func (s *GrowthServer) findAllCropsByCustomFields(
+	c echo.Context,
+	farmUID uuid.UUID,
+	status string,
+	page, limit int,
+	filters map[string]string,
+) error {
+	resultQuery := <-s.CropReadQuery.CountAllCropsByFarm(farmUID, status)
+	if resultQuery.Error != nil {
+		return Error(c, resultQuery.Error)
+	}
+
+	total, ok := resultQuery.Result.(int)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	resultQuery = <-s.CropReadQuery.FindAllCropsByFarm(farmUID, status, 1, total)
+	if resultQuery.Error != nil {
+		return Error(c, resultQuery.Error)
+	}
+
+	crops, ok := resultQuery.Result.([]storage.CropRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	crops, err := s.filterCropsByCustomFields(farmUID, crops, filters)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	start, end := paginationhelper.PageBounds(len(crops), page, limit)
+
+	data := make(map[string]interface{})
+	data["data"] = crops[start:end]
+	data["total_rows"] = len(crops)
+	data["page"] = page
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// filterCropsByCustomFields keeps the crops of the farm whose values match the `cf_` filters.
+func (s *GrowthServer) filterCropsByCustomFields(
+	farmUID uuid.UUID,
+	crops []storage.CropRead,
+	filters map[string]string,
+) ([]storage.CropRead, error) {
+	matched, err := s.CustomFields.Filter(farmUID, assetsdomain.CustomFieldEntityCrop, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []storage.CropRead{}
+
+	for _, v := range crops {
+		if matched[v.UID] {
+			filtered = append(filtered, v)
+		}
+	}
+
+	return filtered, nil
+}
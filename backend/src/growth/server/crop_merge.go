@@ -0,0 +1,99 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+// MergeCrop consolidates the plants left of the source_crop_id batch onto the crop. The source batch is archived
+// and its history points at the crop, its photos are removed too with purge_photos=true.
+func (s *GrowthServer) MergeCrop(c echo.Context) error {
+	cropUID, err := s.parseCropUID(c, "crop_id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	sourceCropID := c.FormValue("source_crop_id")
+	if sourceCropID == "" {
+		return Error(c, NewRequestValidationError(Required, "source_crop_id"))
+	}
+
+	sourceCropUID, err := uuid.FromString(sourceCropID)
+	if err != nil {
+		return Error(c, NewRequestValidationError(ParseFailed, "source_crop_id"))
+	}
+
+	purgePhotos, err := parsePurgePhotos(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// VALIDATE //
+	result := <-s.CropReadQuery.FindByID(sourceCropUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	sourceRead, ok := result.Result.(storage.CropRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	if sourceRead.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "source_crop_id"))
+	}
+
+	// PROCESS //
+	crop, err := s.findCropFromHistory(cropUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	source, err := s.findCropFromHistory(sourceCropUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = crop.MergeFrom(source, time.Now())
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = purgeArchivedCropPhotos(source, purgePhotos)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.CropEventRepo.Save(source.UID, source.Version, source.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(source)
+	s.publishUncommittedEvents(crop)
+
+	s.removeCropPhotoFiles(source.UncommittedChanges)
+
+	data := make(map[string]storage.CropRead)
+
+	cr, err := MapToCropRead(s, *crop)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data["data"] = cr
+
+	return c.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/helper/structhelper"
+	"github.com/usetania/tania-core/src/retention"
+)
+
+const (
+	// staleDataWarning is the Warning header of the crop lists served from their snapshot during a replay.
+	staleDataWarning = "stale-data"
+
+	// replayRetryAfter is the Retry-After header, in seconds, of the crop lists without a snapshot during a replay.
+	replayRetryAfter = "10"
+)
+
+// cropReadModelEvents are the events InitSubscriber saves to the crop read model, the ones a replay applies again.
+func cropReadModelEvents() map[string]bool {
+	return map[string]bool{
+		"CropBatchCreated":                true,
+		"CropBatchTypeChanged":            true,
+		"CropBatchInventoryChanged":       true,
+		"CropBatchContainerChanged":       true,
+		"CropBatchMoved":                  true,
+		"CropBatchHarvested":              true,
+		"CropBatchDumped":                 true,
+		"CropBatchWatered":                true,
+		"CropBatchNoteCreated":            true,
+		"CropBatchNoteRemoved":            true,
+		"CropBatchPhotoCreated":           true,
+		"CropNurseryStageStarted":         true,
+		"CropNurseryStageCompleted":       true,
+		"CropBatchPhotoProcessed":         true,
+		"CropBatchPhotoProcessingFailed":  true,
+		"CropBatchPhotoProcessingRetried": true,
+		"CropBatchPhotoRemoved":           true,
+		"CropBatchShortCodeAssigned":      true,
+		"CropBatchSeedsSownRecorded":      true,
+		"CropGerminationRecorded":         true,
+		"CropMergedFrom":                  true,
+		"CropMergedInto":                  true,
+	}
+}
+
+// MountAdmin defines the GrowthServer's admin endpoints with its handlers.
+func (s *GrowthServer) MountAdmin(g *echo.Group) {
+	g.POST("/farms/:id/crops/replay", s.ReplayFarmCrops)
+}
+
+// ReplayFarmCrops rebuilds the crop read model of the farm from the events of its crops, in the background.
+// It answers 202 Accepted with the number of crops replayed, or 409 Conflict while another replay runs.
+func (s *GrowthServer) ReplayFarmCrops(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	farm, err := s.findCropFarm(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// The crops are listed before the replay starts, the lists answer from their snapshots during it.
+	crops, err := s.findAllFarmCrops(farm.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if !s.CropReadStaleness.StartReplay() {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"field_name":    "",
+			"error_code":    "REPLAY_RUNNING",
+			"error_message": "The crops are already being replayed",
+		})
+	}
+
+	go func() {
+		defer s.CropReadStaleness.EndReplay()
+
+		replayed, err := s.replayCrops(crops)
+		if err != nil {
+			log.Println("Replaying the crops of the farm", farm.UID, "failed", err)
+
+			return
+		}
+
+		log.Printf("Replayed the events of %d crops of the farm %s", replayed, farm.UID)
+	}()
+
+	data := make(map[string]interface{})
+	data["data"] = map[string]interface{}{"farm_id": farm.UID, "crops": len(crops)}
+
+	return c.JSON(http.StatusAccepted, data)
+}
+
+// replayCrops saves the events of the crops to the crop read model again, in order. The crops pruned
+// to the cold storage keep their row, their events aren't there anymore.
+func (s *GrowthServer) replayCrops(crops []storage.CropRead) (int, error) {
+	events := cropReadModelEvents()
+	replayed := 0
+
+	for _, crop := range crops {
+		pruned, err := s.PrunedQuery.FindPruned(retention.AggregateCrop, crop.UID)
+		if err != nil {
+			return replayed, err
+		}
+
+		if pruned != nil {
+			continue
+		}
+
+		result := <-s.CropEventQuery.FindAllByCropID(crop.UID)
+		if result.Error != nil {
+			return replayed, result.Error
+		}
+
+		cropEvents, ok := result.Result.([]storage.CropEvent)
+		if !ok {
+			return replayed, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		for _, v := range cropEvents {
+			if events[structhelper.GetName(v.Event)] {
+				err = s.SaveToCropReadModel(v.Event)
+				if err != nil {
+					return replayed, err
+				}
+			}
+		}
+
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+// markStale sets the Warning header on the response of the results served from their snapshot.
+func markStale(c echo.Context, results ...query.Result) {
+	for _, v := range results {
+		if v.Stale {
+			c.Response().Header().Set("Warning", staleDataWarning)
+
+			return
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+// checkAreaSanitation applies the sanitation_check config to seeding the area. It returns the warnings
+// of the response, or an error when the area needs a sanitation and seeding it is blocked.
+// The dumps looked at are the ones of the crops seeded in the area.
+func (s *GrowthServer) checkAreaSanitation(area query.CropAreaQueryResult) ([]string, error) {
+	mode := domain.SanitationCheckWarn
+	if config.Config.SanitationCheck != nil && *config.Config.SanitationCheck != "" {
+		mode = *config.Config.SanitationCheck
+	}
+
+	if mode == domain.SanitationCheckOff {
+		return []string{}, nil
+	}
+
+	result := <-s.CropReadQuery.FindAllCropsByArea(area.UID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	crops, ok := result.Result.([]query.CropAreaByAreaQueryResult)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	dumps := []domain.CropBatchDumped{}
+
+	for _, v := range crops {
+		result := <-s.CropEventQuery.FindAllByCropID(v.UID)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		events, ok := result.Result.([]storage.CropEvent)
+		if !ok {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		for _, e := range events {
+			if dumped, ok := e.Event.(domain.CropBatchDumped); ok {
+				dumps = append(dumps, dumped)
+			}
+		}
+	}
+
+	if !domain.AreaNeedsSanitation(area.UID, dumps, area.LastSanitizedDate) {
+		return []string{}, nil
+	}
+
+	if mode == domain.SanitationCheckBlock {
+		return nil, NewRequestValidationError(SanitationRequired, "id")
+	}
+
+	return []string{area.Name + " had a crop dumped for disease and wasn't sanitized since."}, nil
+}
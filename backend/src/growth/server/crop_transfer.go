@@ -0,0 +1,394 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/farmscope"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/helper/pdfhelper"
+	"github.com/usetania/tania-core/src/shortcode"
+	"github.com/usetania/tania-core/src/signing"
+)
+
+// signatureChunkLength is the characters of the signature printed per line of the certificate.
+const signatureChunkLength = 64
+
+// InitiateCropTransfer records the plants of the crop leaving the farm for the to_farm_id farm, given to the
+// recipient_name reached at the recipient_contact, on the transfer_date, today when it's not given.
+// The certificate_number is the next TC code of the farm when it's not given.
+func (s *GrowthServer) InitiateCropTransfer(c echo.Context) error {
+	cropUID, err := s.parseCropUID(c, "crop_id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	toFarmID := c.FormValue("to_farm_id")
+	if toFarmID == "" {
+		return Error(c, NewRequestValidationError(Required, "to_farm_id"))
+	}
+
+	toFarmUID, err := uuid.FromString(toFarmID)
+	if err != nil {
+		return Error(c, NewRequestValidationError(ParseFailed, "to_farm_id"))
+	}
+
+	transferDate := time.Now()
+
+	if value := c.FormValue("transfer_date"); value != "" {
+		transferDate, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "transfer_date"))
+		}
+	}
+
+	// VALIDATE //
+	result := <-s.FarmReadQuery.FindByID(toFarmUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	toFarm, ok := result.Result.(query.CropFarmQueryResult)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	if toFarm.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "to_farm_id"))
+	}
+
+	// PROCESS //
+	crop, err := s.findCropFromHistory(cropUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	certificateNumber := c.FormValue("certificate_number")
+	if strings.TrimSpace(certificateNumber) == "" {
+		certificateNumber, err = s.ShortCodeGenerator.Next(shortcode.TransferCertificatePrefix, crop.FarmUID)
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
+	userUID, _ := c.Get(farmscope.UserKey).(uuid.UUID)
+
+	err = crop.InitiateTransfer(toFarmUID, transferDate, userUID,
+		c.FormValue("recipient_name"), c.FormValue("recipient_contact"), certificateNumber)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(crop)
+
+	data := make(map[string]domain.CropTransfer)
+	data["data"] = crop.Transfers[len(crop.Transfers)-1]
+
+	return c.JSON(http.StatusCreated, data)
+}
+
+// GetCropTransferCertificate prints the certificate of the transfer of the crop, with the traceability chain of the
+// batch and of the batches merged into it, signed with the key of the farm. The chain stops at the transfer, so the
+// certificate prints the same whenever it's downloaded, only its signature differs.
+func (s *GrowthServer) GetCropTransferCertificate(c echo.Context) error {
+	cropUID, err := s.parseCropUID(c, "crop_id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	transferUID, err := uuid.FromString(c.Param("transfer_id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(ParseFailed, "transfer_id"))
+	}
+
+	events, err := s.findCropEventHistory(cropUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	crop := &domain.Crop{}
+	transfer, found := domain.CropTransfer{}, false
+
+	// The crop is rebuilt as it was when it left, for the plants it had.
+	for _, v := range events {
+		crop.Transition(v)
+
+		if transfer, found = crop.FindTransfer(transferUID); found {
+			break
+		}
+	}
+
+	if !found {
+		return Error(c, NewRequestValidationError(NotFound, "transfer_id"))
+	}
+
+	lines, err := s.transferCertificateLines(crop, transfer, events)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	signature, err := s.Signer.Sign(transfer.FromFarmID, []byte(strings.Join(lines, "\n")))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=\"transfer-certificate-%s.pdf\"", transfer.CertificateNumber))
+
+	return c.Blob(http.StatusOK, "application/pdf", renderTransferCertificatePDF(lines, signature))
+}
+
+// GetFarmSigningKey returns the public key the documents the farm issues are verified with.
+func (s *GrowthServer) GetFarmSigningKey(c echo.Context) error {
+	farm, err := s.findCropFarm(uuid.FromStringOrNil(c.Param("id")))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	key, err := s.Signer.Key(farm.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	publicKey, err := key.PublicKeyPEM()
+	if err != nil {
+		return Error(c, err)
+	}
+
+	fingerprint, err := key.Fingerprint()
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]interface{})
+	data["data"] = map[string]interface{}{
+		"farm_id":         farm.UID,
+		"algorithm":       signing.Algorithm,
+		"key_fingerprint": fingerprint,
+		"public_key":      publicKey,
+		"created_date":    key.CreatedDate,
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *GrowthServer) findCropEventHistory(cropUID uuid.UUID) ([]interface{}, error) {
+	eventQueryResult := s.findCropEvents(cropUID)
+	if eventQueryResult.Error != nil {
+		return nil, eventQueryResult.Error
+	}
+
+	cropEvents, ok := eventQueryResult.Result.([]storage.CropEvent)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	events := []interface{}{}
+	for _, v := range cropEvents {
+		events = append(events, v.Event)
+	}
+
+	return events, nil
+}
+
+// transferCertificateLines are the lines of the certificate, the payload its signature is over.
+func (s *GrowthServer) transferCertificateLines(crop *domain.Crop, transfer domain.CropTransfer,
+	events []interface{},
+) ([]string, error) {
+	fromFarm, err := s.findCropFarm(transfer.FromFarmID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The farm the crop went to may have been removed since, the certificate keeps its UID.
+	toFarmResult := <-s.FarmReadQuery.FindByID(transfer.ToFarmID)
+	if toFarmResult.Error != nil {
+		return nil, toFarmResult.Error
+	}
+
+	toFarm, _ := toFarmResult.Result.(query.CropFarmQueryResult)
+
+	plants := crop.InitialArea.CurrentQuantity
+	for _, v := range crop.MovedArea {
+		plants += v.CurrentQuantity
+	}
+
+	lines := []string{
+		"Certificate number: " + transfer.CertificateNumber,
+		"Transfer: " + transfer.UID.String(),
+		"Transfer date: " + transfer.TransferDate.Format("2 January 2006"),
+		fmt.Sprintf("From farm: %s (%s)", fromFarm.Name, transfer.FromFarmID),
+		fmt.Sprintf("To farm: %s (%s)", toFarm.Name, transfer.ToFarmID),
+		"Recipient: " + transfer.RecipientName,
+		"Recipient contact: " + transfer.RecipientContact,
+		"Transferred by: " + transfer.TransferredBy.String(),
+		fmt.Sprintf("Batch: %s (%s)", crop.BatchID, crop.UID),
+		fmt.Sprintf("Variety: %s", s.traceabilityMaterialName(crop.InventoryUID)),
+		fmt.Sprintf("Plants at transfer: %d", plants),
+		"",
+		"Traceability chain",
+	}
+
+	chain, err := s.traceabilityChainLines(crop, domain.CropTraceability(events, transfer.UID), map[uuid.UUID]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(lines, chain...), nil
+}
+
+// traceabilityChainLines prints the steps of the batch, then the chains of the batches merged into it, each batch
+// once.
+func (s *GrowthServer) traceabilityChainLines(crop *domain.Crop, steps []domain.TraceabilityStep,
+	printed map[uuid.UUID]bool,
+) ([]string, error) {
+	printed[crop.UID] = true
+
+	lines := []string{fmt.Sprintf("Batch %s (%s)", crop.BatchID, crop.UID)}
+	merged := []uuid.UUID{}
+
+	for _, v := range steps {
+		date := v.Date.Format("2006-01-02")
+
+		switch v.Kind {
+		case domain.TraceabilitySeeded:
+			lines = append(lines, fmt.Sprintf("%s  Seeded %d plants of %s in %s", date, v.Quantity,
+				s.traceabilityMaterialName(v.InventoryUID), s.traceabilityAreaName(v.AreaUID)))
+		case domain.TraceabilityMoved:
+			lines = append(lines, fmt.Sprintf("%s  Moved %d plants from %s to %s", date, v.Quantity,
+				s.traceabilityAreaName(v.AreaUID), s.traceabilityAreaName(v.DstAreaUID)))
+		case domain.TraceabilityMergedFrom:
+			lines = append(lines, fmt.Sprintf("%s  Merged %d plants of batch %s in %s", date, v.Quantity,
+				v.SourceBatchID, s.traceabilityAreaName(v.AreaUID)))
+			merged = append(merged, v.SourceCropUID)
+		case domain.TraceabilityHarvested:
+			// A partial harvest picks the produce, the plants stay.
+			harvested := fmt.Sprintf("%.0f g", v.GramQuantity)
+			if v.Quantity > 0 {
+				harvested = fmt.Sprintf("%d plants, %.0f g,", v.Quantity, v.GramQuantity)
+			}
+
+			lines = append(lines, fmt.Sprintf("%s  Harvested %s in %s", date, harvested, s.traceabilityAreaName(v.AreaUID)))
+		case domain.TraceabilityDumped:
+			lines = append(lines, fmt.Sprintf("%s  Dumped %d plants in %s", date, v.Quantity,
+				s.traceabilityAreaName(v.AreaUID)))
+		case domain.TraceabilityTransferred:
+			lines = append(lines, fmt.Sprintf("%s  Transferred to farm %s, certificate %s", date, v.ToFarmID,
+				v.CertificateNumber))
+		}
+	}
+
+	for _, v := range merged {
+		if printed[v] {
+			continue
+		}
+
+		source, err := s.findCropFromHistory(v)
+		if err != nil {
+			return nil, err
+		}
+
+		events, err := s.findCropEventHistory(v)
+		if err != nil {
+			return nil, err
+		}
+
+		sourceLines, err := s.traceabilityChainLines(source, domain.CropTraceability(events, uuid.Nil), printed)
+		if err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, sourceLines...)
+	}
+
+	return lines, nil
+}
+
+// traceabilityMaterialName is the name and the plant type of the material, its UID when it's gone.
+func (s *GrowthServer) traceabilityMaterialName(materialUID uuid.UUID) string {
+	material, ok := (<-s.MaterialReadQuery.FindByID(materialUID)).Result.(query.CropMaterialQueryResult)
+	if !ok || material.UID == (uuid.UUID{}) {
+		return "material " + materialUID.String()
+	}
+
+	return fmt.Sprintf("%s (%s)", material.Name, strings.ToLower(material.PlantTypeCode))
+}
+
+// traceabilityAreaName is the name of the area, its UID when it's gone.
+func (s *GrowthServer) traceabilityAreaName(areaUID uuid.UUID) string {
+	area, ok := (<-s.AreaReadQuery.FindByID(areaUID)).Result.(query.CropAreaQueryResult)
+	if !ok || area.UID == (uuid.UUID{}) {
+		return "area " + areaUID.String()
+	}
+
+	return area.Name
+}
+
+// renderTransferCertificatePDF lays the lines of the certificate out on A4 pages, followed by the signature.
+func renderTransferCertificatePDF(lines []string, signature signing.Signature) []byte {
+	const (
+		margin     = 40.0
+		lineHeight = 15.0
+	)
+
+	doc := pdfhelper.NewDocument()
+	doc.AddPage()
+
+	y := margin + 18
+
+	doc.Text(margin, y, 18, true, "Crop Transfer Certificate")
+	doc.Line(margin, y+8, pdfhelper.PageWidth-margin, y+8)
+
+	y += 20
+
+	text := func(value string, size float64, bold bool) {
+		y += lineHeight
+
+		if y > pdfhelper.PageHeight-margin {
+			doc.AddPage()
+
+			y = margin + lineHeight
+		}
+
+		doc.Text(margin, y, size, bold, pdfhelper.Truncate(value, pdfhelper.PageWidth-margin*2, size))
+	}
+
+	for _, v := range lines {
+		text(v, 10, v == "Traceability chain" || strings.HasPrefix(v, "Batch "))
+	}
+
+	y += 10
+
+	text("Digital signature", 12, true)
+	text("Algorithm: "+signature.Algorithm, 9, false)
+	text("Key fingerprint: "+signature.KeyFingerprint, 9, false)
+
+	for i := 0; i < len(signature.Value); i += signatureChunkLength {
+		end := i + signatureChunkLength
+		if end > len(signature.Value) {
+			end = len(signature.Value)
+		}
+
+		text(signature.Value[i:end], 9, false)
+	}
+
+	text("The signature is over the lines of the certificate from its number to the end of the chain, joined by", 8,
+		false)
+	text("new lines. The public key of the farm is at GET /api/farms/:id/signing-key.", 8, false)
+
+	return doc.Bytes()
+}
@@ -0,0 +1,332 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/eventbus"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+	taskevents "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+// DispatchTaskCreator creates the task of a scheduled dispatch and returns its ID.
+// It's implemented by the tasks module and injected after both servers are created.
+type DispatchTaskCreator interface {
+	CreateDispatchTask(schedule domain.DispatchSchedule, by *actor.Actor) (uuid.UUID, error)
+}
+
+// FindDispatchSchedules lists the dispatches of the farm picked up between the from and to dates, both included.
+// The harvest_id param only keeps the dispatches of the harvest of that crop.
+func (s *GrowthServer) FindDispatchSchedules(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	farm, err := s.findCropFarm(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	var from, to time.Time
+
+	if value := c.QueryParam("from"); value != "" {
+		from, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "from"))
+		}
+	}
+
+	if value := c.QueryParam("to"); value != "" {
+		to, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "to"))
+		}
+
+		to = to.AddDate(0, 0, 1)
+	}
+
+	harvestUID := uuid.Nil
+
+	if value := c.QueryParam("harvest_id"); value != "" {
+		harvestUID, err = uuid.FromString(value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "harvest_id"))
+		}
+	}
+
+	result := <-s.DispatchScheduleReadQuery.FindAllByFarm(farm.UID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	schedules, ok := result.Result.([]storage.DispatchScheduleRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	found := []storage.DispatchScheduleRead{}
+
+	for _, v := range schedules {
+		if (!from.IsZero() && v.PickupTime.Before(from)) || (!to.IsZero() && !v.PickupTime.Before(to)) {
+			continue
+		}
+
+		if harvestUID != uuid.Nil && !containsUID(v.HarvestIDs, harvestUID) {
+			continue
+		}
+
+		found = append(found, v)
+	}
+
+	data := make(map[string][]storage.DispatchScheduleRead)
+	data["data"] = found
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// SaveDispatchSchedule schedules the dispatch of the harvests of the comma separated harvest_ids, the IDs of
+// the harvested crops, and creates its task. The times are in RFC3339.
+func (s *GrowthServer) SaveDispatchSchedule(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	farm, err := s.findCropFarm(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	details, err := parseDispatchDetails(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	harvested := []domain.Crop{}
+
+	for _, v := range strings.Split(c.FormValue("harvest_ids"), ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		cropUID, err := uuid.FromString(v)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "harvest_ids"))
+		}
+
+		crop, err := s.findCropFromHistory(cropUID)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		if crop.UID != cropUID {
+			return Error(c, NewRequestValidationError(NotFound, "harvest_ids"))
+		}
+
+		harvested = append(harvested, *crop)
+	}
+
+	// PROCESS //
+	schedule, err := domain.CreateDispatchSchedule(farm.UID, harvested, details, time.Now())
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if s.DispatchTaskCreator != nil {
+		taskUID, err := s.DispatchTaskCreator.CreateDispatchTask(*schedule, actor.FromContext(c))
+		if err != nil {
+			return Error(c, err)
+		}
+
+		err = schedule.AttachTask(taskUID)
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
+	// PERSIST //
+	err = <-s.DispatchScheduleEventRepo.Save(schedule.UID, 0, schedule.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(schedule)
+
+	data := make(map[string]storage.DispatchScheduleRead)
+	data["data"] = MapToDispatchScheduleRead(*schedule)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func parseDispatchDetails(c echo.Context) (domain.DispatchDetails, error) {
+	details := domain.DispatchDetails{
+		VehicleID:          c.FormValue("vehicle_id"),
+		DriverName:         c.FormValue("driver_name"),
+		DestinationAddress: c.FormValue("destination_address"),
+	}
+
+	if c.FormValue("pickup_time") == "" {
+		return domain.DispatchDetails{}, NewRequestValidationError(Required, "pickup_time")
+	}
+
+	pickupTime, err := time.Parse(time.RFC3339, c.FormValue("pickup_time"))
+	if err != nil {
+		return domain.DispatchDetails{}, NewRequestValidationError(ParseFailed, "pickup_time")
+	}
+
+	if c.FormValue("estimated_delivery_time") == "" {
+		return domain.DispatchDetails{}, NewRequestValidationError(Required, "estimated_delivery_time")
+	}
+
+	deliveryTime, err := time.Parse(time.RFC3339, c.FormValue("estimated_delivery_time"))
+	if err != nil {
+		return domain.DispatchDetails{}, NewRequestValidationError(ParseFailed, "estimated_delivery_time")
+	}
+
+	details.PickupTime = pickupTime
+	details.EstimatedDeliveryTime = deliveryTime
+
+	return details, nil
+}
+
+func containsUID(uids []uuid.UUID, uid uuid.UUID) bool {
+	for _, v := range uids {
+		if v == uid {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *GrowthServer) findDispatchScheduleFromHistory(uid uuid.UUID) (*domain.DispatchSchedule, error) {
+	result := <-s.DispatchScheduleEventQuery.FindAllByID(uid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	events, ok := result.Result.([]storage.DispatchScheduleEvent)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return repository.NewDispatchScheduleFromHistory(events), nil
+}
+
+func MapToDispatchScheduleRead(schedule domain.DispatchSchedule) storage.DispatchScheduleRead {
+	return storage.DispatchScheduleRead{
+		UID:                   schedule.UID,
+		FarmUID:               schedule.FarmUID,
+		HarvestIDs:            schedule.HarvestIDs,
+		VehicleID:             schedule.VehicleID,
+		DriverName:            schedule.DriverName,
+		PickupTime:            schedule.PickupTime,
+		DestinationAddress:    schedule.DestinationAddress,
+		EstimatedDeliveryTime: schedule.EstimatedDeliveryTime,
+		Status:                schedule.Status,
+		TaskUID:               schedule.TaskID,
+		CompletedDate:         schedule.CompletedDate,
+		CreatedDate:           schedule.CreatedDate,
+	}
+}
+
+func (s *GrowthServer) SaveToDispatchScheduleReadModel(event interface{}) error {
+	var uid uuid.UUID
+
+	switch e := event.(type) {
+	case domain.DispatchScheduled:
+		uid = e.UID
+	case domain.DispatchTaskCreated:
+		uid = e.UID
+	case domain.DispatchCompleted:
+		uid = e.UID
+	default:
+		return errors.New("unknown dispatch schedule event")
+	}
+
+	schedule, err := s.findDispatchScheduleFromHistory(uid)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	scheduleRead := MapToDispatchScheduleRead(*schedule)
+
+	err = <-s.DispatchScheduleReadRepo.Save(&scheduleRead)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	return nil
+}
+
+// CompleteDispatchOfTask completes the dispatch of a completed task.
+//
+// TODO:
+// We cannot listen to this events without refer to the original struct.
+// This is considered as domain boundary leak.
+func (s *GrowthServer) CompleteDispatchOfTask(event interface{}) error {
+	e, ok := event.(taskevents.TaskCompleted)
+	if !ok {
+		return errors.New("unknown task event")
+	}
+
+	result := <-s.DispatchScheduleReadQuery.FindByTaskID(e.UID)
+	if result.Error != nil {
+		log.Println(result.Error)
+
+		return result.Error
+	}
+
+	scheduleRead, ok := result.Result.(storage.DispatchScheduleRead)
+	if !ok || scheduleRead.UID == (uuid.UUID{}) {
+		// Most of the tasks are not created from a dispatch.
+		return nil
+	}
+
+	schedule, err := s.findDispatchScheduleFromHistory(scheduleRead.UID)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	completedDate := time.Now()
+	if e.CompletedDate != nil {
+		completedDate = *e.CompletedDate
+	}
+
+	err = schedule.Complete(completedDate)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	err = <-s.DispatchScheduleEventRepo.Save(
+		schedule.UID, schedule.Version, schedule.UncommittedChanges, actor.System("dispatch_completion"),
+	)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	eventbus.PublishFromHandler(func() { s.publishUncommittedEvents(schedule) })
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/dryrun"
+	"github.com/usetania/tania-core/src/energy"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+// validatable lets the handler be called with `?validate_only=true` to only validate the request.
+func (s *GrowthServer) validatable(h func(*GrowthServer, echo.Context) error) echo.HandlerFunc {
+	return dryrun.Handler(s, s.dryRun, h)
+}
+
+// dryRun copies the server with the event repositories and the event bus which drop the events.
+// The short code generator, the microclimate sample repository, the energy store and the environment alerts
+// are swapped too, so nothing is consumed or stored.
+func (s *GrowthServer) dryRun() *GrowthServer {
+	dry := *s
+
+	dry.CropEventRepo = dryrun.EventRepository{}
+	dry.CropInputScheduleEventRepo = dryrun.EventRepository{}
+	dry.InsurancePolicyEventRepo = dryrun.EventRepository{}
+	dry.HarvestLotEventRepo = dryrun.EventRepository{}
+	dry.EventBus = dryrun.EventBus{}
+	dry.ShortCodeGenerator = dryrun.ShortCodeGenerator{}
+	dry.MicroclimateSampleRepo = dryMicroclimateSampleRepository{}
+	dry.EnergyStore = dryEnergyStore{s.EnergyStore}
+	dry.CustomFields = s.CustomFields.WithoutSaving()
+	dry.EnvironmentAlerts = s.EnvironmentAlerts.WithoutSaving()
+
+	return &dry
+}
+
+// dryMicroclimateSampleRepository drops the samples instead of storing them.
+type dryMicroclimateSampleRepository struct{}
+
+func (dryMicroclimateSampleRepository) Save(sample *storage.MicroclimateSample) <-chan error {
+	result := make(chan error, 1)
+
+	result <- nil
+	close(result)
+
+	return result
+}
+
+// dryEnergyStore drops the readings instead of storing them, the stored ones are still found.
+type dryEnergyStore struct {
+	energy.Store
+}
+
+func (dryEnergyStore) Save(reading energy.EnergyReadingRecorded) error {
+	return nil
+}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/energy"
+	"github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/helper/structhelper"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
+)
+
+// SaveEnergyReading records the kWh consumed by a meter of the farm since its previous reading,
+// and alerts when it takes the consumption of the farm on that day above the `energy_alert_threshold`.
+func (s *GrowthServer) SaveEnergyReading(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	for _, v := range []string{"meter_id", "kwh", "tariff_rate", "currency"} {
+		if c.FormValue(v) == "" {
+			return Error(c, NewRequestValidationError(Required, v))
+		}
+	}
+
+	kwh, err := strconv.ParseFloat(c.FormValue("kwh"), 64)
+	if err != nil {
+		return Error(c, NewRequestValidationError(Float, "kwh"))
+	}
+
+	tariffRate, err := strconv.ParseFloat(c.FormValue("tariff_rate"), 64)
+	if err != nil {
+		return Error(c, NewRequestValidationError(Float, "tariff_rate"))
+	}
+
+	recordedAt := time.Time{}
+
+	if c.FormValue("recorded_at") != "" {
+		recordedAt, err = time.Parse(time.RFC3339, c.FormValue("recorded_at"))
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "recorded_at"))
+		}
+	}
+
+	reading, err := energy.NewReading(
+		farmUID, c.FormValue("meter_id"), kwh, recordedAt, tariffRate, c.FormValue("currency"), time.Now())
+
+	validationErr := energy.ValidationError{}
+	if errors.As(err, &validationErr) {
+		return Error(c, NewRequestValidationError(InvalidOption, validationErr.Field))
+	}
+
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = s.EnergyStore.Save(reading)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.EventBus.Publish(structhelper.GetName(reading), reading)
+
+	err = s.checkEnergyThreshold(reading)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]energy.EnergyReadingRecorded)
+	data["data"] = reading
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// checkEnergyThreshold publishes the alert of the day of the reading, once, when the reading takes it above.
+func (s *GrowthServer) checkEnergyThreshold(reading energy.EnergyReadingRecorded) error {
+	if config.Config.EnergyAlertThreshold == nil {
+		return nil
+	}
+
+	start, end := energy.DayRange(reading.RecordedAt)
+
+	dayReadings, err := s.EnergyStore.FindAllByFarm(reading.FarmID, start, end)
+	if err != nil {
+		return err
+	}
+
+	// The dry run doesn't store the reading.
+	found := false
+
+	for _, v := range dayReadings {
+		found = found || v.UID == reading.UID
+	}
+
+	if !found {
+		dayReadings = append(dayReadings, reading)
+	}
+
+	alert, exceeded := energy.ThresholdExceeded(reading, dayReadings, *config.Config.EnergyAlertThreshold)
+	if exceeded {
+		s.EventBus.Publish(structhelper.GetName(*alert), *alert)
+	}
+
+	return nil
+}
+
+// GetEnergyReport returns the kWh and the cost of the energy consumed by the farm in every bucket of the
+// group_by param, day by default, with the cost per kg of the produce harvested in the period.
+// The from and to dates are both included, without them the buckets run from the first reading until today.
+func (s *GrowthServer) GetEnergyReport(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	farm, err := s.findCropFarm(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	param := func(name string) string {
+		if name == timebuckethelper.ParamInterval {
+			return c.QueryParam("group_by")
+		}
+
+		return c.QueryParam(name)
+	}
+
+	bucketQuery, err := parseBucketParams(param, timebuckethelper.IntervalDay, farm.Seasons)
+
+	validationErr := RequestValidationError{}
+	if errors.As(err, &validationErr) && validationErr.FieldName == timebuckethelper.ParamInterval {
+		validationErr.FieldName = "group_by"
+		err = validationErr
+	}
+
+	if err != nil {
+		return Error(c, err)
+	}
+
+	from, to := time.Time{}, time.Time{}
+	if bucketQuery.From != nil {
+		from = *bucketQuery.From
+	}
+
+	if bucketQuery.To != nil {
+		to = *bucketQuery.To
+	}
+
+	readings, err := s.EnergyStore.FindAllByFarm(farm.UID, from, to)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	earliest := time.Time{}
+	if len(readings) > 0 {
+		earliest = readings[0].RecordedAt
+	}
+
+	buckets, err := bucketQuery.Buckets(earliest, time.Now())
+	if err != nil {
+		return Error(c, NewRequestValidationError(InvalidOption, "group_by"))
+	}
+
+	report := energy.NewReport(buckets)
+
+	for _, v := range readings {
+		// The only error is a reading in another currency.
+		err = report.AddReading(v)
+		if err != nil {
+			return Error(c, NewRequestValidationError(InvalidOption, "currency"))
+		}
+	}
+
+	crops, err := s.findAllFarmCrops(farm.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	for _, crop := range crops {
+		result := <-s.CropActivityQuery.FindAllByCropID(crop.UID)
+		if result.Error != nil {
+			return Error(c, result.Error)
+		}
+
+		activities, ok := result.Result.([]storage.CropActivity)
+		if !ok {
+			return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+		}
+
+		for _, v := range activities {
+			if activity, ok := v.ActivityType.(storage.HarvestActivity); ok {
+				report.AddHarvest(activity.HarvestDate, activity.ProducedGramQuantity)
+			}
+		}
+	}
+
+	data := make(map[string]interface{})
+	data["data"] = report.Rows()
+	data["summary"] = report.Summary()
+	data["bucketing"] = bucketQuery
+
+	return c.JSON(http.StatusOK, data)
+}
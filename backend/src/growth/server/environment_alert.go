@@ -0,0 +1,170 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/envalert"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/helper/structhelper"
+	"github.com/usetania/tania-core/src/notification"
+)
+
+// EnvironmentAlertNotifier sends the environment alerts through the channels of their priority.
+type EnvironmentAlertNotifier interface {
+	Notify(notification notification.Notification)
+}
+
+// StartEnvironmentAlertNotifications notifies the alerts of the rules triggered by the samples.
+func (s *GrowthServer) StartEnvironmentAlertNotifications(notifier EnvironmentAlertNotifier) {
+	s.EnvironmentAlertNotifier = notifier
+
+	s.EventBus.Subscribe(envalert.AreaEnvironmentAlertCode, s.NotifyEnvironmentAlert)
+}
+
+// NotifyEnvironmentAlert sends the notification of the alert in the background, so the slow channels
+// don't hold the sensor input.
+func (s *GrowthServer) NotifyEnvironmentAlert(event interface{}) error {
+	alert, ok := event.(envalert.AreaEnvironmentAlert)
+	if !ok {
+		return nil
+	}
+
+	go s.EnvironmentAlertNotifier.Notify(alert)
+
+	return nil
+}
+
+// FindEnvironmentAlertRules returns the alert rules of the area, the oldest first.
+func (s *GrowthServer) FindEnvironmentAlertRules(c echo.Context) error {
+	areaUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	data := make(map[string][]envalert.Rule)
+	data["data"] = s.EnvironmentAlerts.Rules(areaUID)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// SaveEnvironmentAlertRule adds an alert rule on the temperature of the area. The `kind` is ABSOLUTE,
+// a `threshold` to rise above or `direction` DROP below, or RATE_OF_CHANGE, a rise or a drop of more than
+// the `threshold` within `window_minutes`. The `hysteresis` and the `priority` are optional.
+func (s *GrowthServer) SaveEnvironmentAlertRule(c echo.Context) error {
+	areaUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	for _, v := range []string{"kind", "direction", "threshold"} {
+		if c.FormValue(v) == "" {
+			return Error(c, NewRequestValidationError(Required, v))
+		}
+	}
+
+	threshold, err := strconv.ParseFloat(c.FormValue("threshold"), 64)
+	if err != nil {
+		return Error(c, NewRequestValidationError(Float, "threshold"))
+	}
+
+	windowMinutes := 0
+
+	if c.FormValue("window_minutes") != "" {
+		windowMinutes, err = strconv.Atoi(c.FormValue("window_minutes"))
+		if err != nil {
+			return Error(c, NewRequestValidationError(Numeric, "window_minutes"))
+		}
+	}
+
+	var hysteresis *float64
+
+	if c.FormValue("hysteresis") != "" {
+		value, err := strconv.ParseFloat(c.FormValue("hysteresis"), 64)
+		if err != nil {
+			return Error(c, NewRequestValidationError(Float, "hysteresis"))
+		}
+
+		hysteresis = &value
+	}
+
+	rule, err := envalert.NewRule(areaUID, c.FormValue("kind"), c.FormValue("direction"), threshold, windowMinutes,
+		hysteresis, c.FormValue("priority"), time.Now())
+
+	validationErr := envalert.ValidationError{}
+	if errors.As(err, &validationErr) {
+		return Error(c, NewRequestValidationError(InvalidOption, validationErr.Field))
+	}
+
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = s.EnvironmentAlerts.Add(rule)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]envalert.Rule)
+	data["data"] = rule
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *GrowthServer) RemoveEnvironmentAlertRule(c echo.Context) error {
+	areaUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	ruleUID, err := uuid.FromString(c.Param("rule_id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "rule_id"))
+	}
+
+	rule, err := s.EnvironmentAlerts.Remove(areaUID, ruleUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if rule == nil {
+		return Error(c, NewRequestValidationError(NotFound, "rule_id"))
+	}
+
+	data := make(map[string]envalert.Rule)
+	data["data"] = *rule
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// checkEnvironmentAlerts evaluates the alert rules of the area with the sample, and publishes the alerts
+// of the rules it triggers.
+func (s *GrowthServer) checkEnvironmentAlerts(areaUID uuid.UUID, temperature float64, recordedDate time.Time) {
+	alerts, err := s.EnvironmentAlerts.Evaluate(areaUID, temperature, recordedDate)
+	if err != nil {
+		log.Println("Environment alerts of area", areaUID, "cannot be evaluated", err)
+	}
+
+	if len(alerts) == 0 {
+		return
+	}
+
+	areaName := ""
+
+	result := <-s.AreaReadQuery.FindByID(areaUID)
+	if area, ok := result.Result.(query.CropAreaQueryResult); result.Error == nil && ok {
+		areaName = area.Name
+	}
+
+	for _, v := range alerts {
+		v.AreaName = areaName
+
+		s.EventBus.Publish(structhelper.GetName(v), v)
+	}
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+// The resolvers below find the farm of the entity of a route param in its read model,
+// so the farm scoped routes can check it before the handler runs.
+
+func (s *GrowthServer) farmScope(param string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity("", func(c echo.Context) (uuid.UUID, error) {
+		farmUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		result := <-s.FarmReadQuery.FindByID(farmUID)
+		if result.Error != nil {
+			return uuid.Nil, result.Error
+		}
+
+		farm, ok := result.Result.(query.CropFarmQueryResult)
+		if !ok {
+			return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		return farm.UID, nil
+	})
+}
+
+func (s *GrowthServer) areaScope(param string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity("", func(c echo.Context) (uuid.UUID, error) {
+		areaUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		result := <-s.AreaReadQuery.FindByID(areaUID)
+		if result.Error != nil {
+			return uuid.Nil, result.Error
+		}
+
+		area, ok := result.Result.(query.CropAreaQueryResult)
+		if !ok {
+			return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		return area.FarmUID, nil
+	})
+}
+
+func (s *GrowthServer) cropScope(param, farmParam string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity(farmParam, func(c echo.Context) (uuid.UUID, error) {
+		cropUID, err := s.parseCropUID(c, param)
+		if err != nil {
+			// A short code shared by several farms needs the farm_id query param, which is worth telling.
+			var rve RequestValidationError
+			if errors.As(err, &rve) && rve.ErrorCode == Required {
+				return uuid.Nil, err
+			}
+
+			return uuid.Nil, nil
+		}
+
+		return s.farmOfCrop(cropUID)
+	})
+}
+
+func (s *GrowthServer) inputScheduleScope(param, farmParam string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity(farmParam, func(c echo.Context) (uuid.UUID, error) {
+		scheduleUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		result := <-s.CropInputScheduleReadQuery.FindByID(scheduleUID)
+		if result.Error != nil {
+			return uuid.Nil, result.Error
+		}
+
+		schedule, ok := result.Result.(storage.CropInputScheduleRead)
+		if !ok {
+			return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		if schedule.UID == (uuid.UUID{}) {
+			return uuid.Nil, nil
+		}
+
+		return s.farmOfCrop(schedule.CropUID)
+	})
+}
+
+func (s *GrowthServer) insurancePolicyScope(param, farmParam string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity(farmParam, func(c echo.Context) (uuid.UUID, error) {
+		policyUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		result := <-s.InsurancePolicyReadQuery.FindByID(policyUID)
+		if result.Error != nil {
+			return uuid.Nil, result.Error
+		}
+
+		policy, ok := result.Result.(storage.InsurancePolicyRead)
+		if !ok {
+			return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		return policy.FarmUID, nil
+	})
+}
+
+func (s *GrowthServer) harvestLotScope(param, farmParam string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity(farmParam, func(c echo.Context) (uuid.UUID, error) {
+		lotUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		result := <-s.HarvestLotReadQuery.FindByID(lotUID)
+		if result.Error != nil {
+			return uuid.Nil, result.Error
+		}
+
+		lot, ok := result.Result.(storage.HarvestLotRead)
+		if !ok {
+			return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		return lot.FarmUID, nil
+	})
+}
+
+func (s *GrowthServer) farmOfCrop(cropUID uuid.UUID) (uuid.UUID, error) {
+	result := <-s.CropReadQuery.FindByID(cropUID)
+	if errors.Is(result.Error, sql.ErrNoRows) {
+		return uuid.Nil, nil
+	}
+
+	if result.Error != nil {
+		return uuid.Nil, result.Error
+	}
+
+	crop, ok := result.Result.(storage.CropRead)
+	if !ok {
+		return uuid.Nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return crop.FarmUID, nil
+}
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/helper/structhelper"
+)
+
+// CropGDDProgress is the growing degree days a crop accumulated towards the maturity of its material.
+type CropGDDProgress struct {
+	CropUID         uuid.UUID  `json:"crop_id"`
+	BatchID         string     `json:"batch_id"`
+	BaseTemperature float64    `json:"base_temperature"`
+	AccumulatedGDD  float64    `json:"accumulated_gdd"`
+	GDDToMaturity   *float64   `json:"gdd_to_maturity"`
+	Progress        *float64   `json:"progress"`
+	IsMature        bool       `json:"is_mature"`
+	ReachedDate     *time.Time `json:"reached_date"`
+
+	// SafeHarvestDate is the day the crop can be harvested after its pesticide treatments, nil when there's none.
+	SafeHarvestDate *time.Time `json:"safe_harvest_date"`
+}
+
+// sampleMiddlewares check the signature of the device before the area of the sample, when it's required.
+func (s *GrowthServer) sampleMiddlewares() []echo.MiddlewareFunc {
+	if s.SampleSignature == nil {
+		return []echo.MiddlewareFunc{s.areaScope("id")}
+	}
+
+	return []echo.MiddlewareFunc{s.SampleSignature, s.areaScope("id")}
+}
+
+func (s *GrowthServer) SaveMicroclimateSample(c echo.Context) error {
+	areaUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	if c.FormValue("temperature") == "" {
+		return Error(c, NewRequestValidationError(Required, "temperature"))
+	}
+
+	temperature, err := strconv.ParseFloat(c.FormValue("temperature"), 64)
+	if err != nil {
+		return Error(c, NewRequestValidationError(Float, "temperature"))
+	}
+
+	recordedDate := time.Now()
+
+	if c.FormValue("recorded_date") != "" {
+		recordedDate, err = time.Parse(time.RFC3339, c.FormValue("recorded_date"))
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "recorded_date"))
+		}
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return Error(c, err)
+	}
+
+	sample := storage.MicroclimateSample{
+		UID:          uid,
+		AreaUID:      areaUID,
+		Temperature:  temperature,
+		RecordedDate: recordedDate,
+	}
+
+	// PERSIST //
+	err = <-s.MicroclimateSampleRepo.Save(&sample)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// The crops of the area may have reached their maturity with this sample.
+	s.checkAreaGDDMaturity(areaUID, time.Now())
+	s.checkEnvironmentAlerts(areaUID, temperature, recordedDate)
+
+	recorded := domain.MicroclimateSampleRecorded{
+		UID:          sample.UID,
+		AreaUID:      sample.AreaUID,
+		Temperature:  sample.Temperature,
+		RecordedDate: sample.RecordedDate,
+	}
+	s.EventBus.Publish(structhelper.GetName(recorded), recorded)
+
+	data := make(map[string]storage.MicroclimateSample)
+	data["data"] = sample
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *GrowthServer) GetCropGDDProgress(c echo.Context) error {
+	cropRead, err := s.findFarmCrop(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	crop, err := s.findCropFromHistory(cropRead.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	material, err := s.findMaterialOfCrop(*crop)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	accumulator := domain.NewGDDAccumulator(s.MicroclimateSampleQuery)
+
+	accumulated, err := accumulator.Accumulate(*crop)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	safeHarvestDate, err := s.findSafeHarvestDate(crop.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	progress := CropGDDProgress{
+		CropUID:         crop.UID,
+		BatchID:         crop.BatchID,
+		BaseTemperature: accumulator.BaseTemperature,
+		AccumulatedGDD:  accumulated,
+		GDDToMaturity:   material.GDDToMaturity,
+		IsMature:        crop.GDDMaturityReachedDate != nil,
+		ReachedDate:     crop.GDDMaturityReachedDate,
+		SafeHarvestDate: safeHarvestDate,
+	}
+
+	if material.GDDToMaturity != nil {
+		percentage := accumulated / *material.GDDToMaturity * 100
+		if percentage > 100 {
+			percentage = 100
+		}
+
+		progress.Progress = &percentage
+	}
+
+	data := make(map[string]CropGDDProgress)
+	data["data"] = progress
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// checkAreaGDDMaturity checks the maturity of the crops of the area.
+// A crop failing the check is logged, so it doesn't stop the others.
+func (s *GrowthServer) checkAreaGDDMaturity(areaUID uuid.UUID, now time.Time) {
+	result := <-s.CropReadQuery.FindAllCropsByArea(areaUID)
+	if result.Error != nil {
+		log.Println("GDD maturity check failed", result.Error)
+
+		return
+	}
+
+	crops, ok := result.Result.([]query.CropAreaByAreaQueryResult)
+	if !ok {
+		log.Println(errors.New("internal server error. error type assertion"))
+
+		return
+	}
+
+	for _, v := range crops {
+		err := s.checkGDDMaturity(v.UID, now)
+		if err != nil {
+			log.Println("GDD maturity of crop", v.UID, "cannot be checked", err)
+		}
+	}
+}
+
+func (s *GrowthServer) checkGDDMaturity(cropUID uuid.UUID, now time.Time) error {
+	crop, err := s.findCropFromHistory(cropUID)
+	if err != nil {
+		return err
+	}
+
+	if crop.Status.Code == domain.CropArchived || crop.GDDMaturityReachedDate != nil {
+		return nil
+	}
+
+	material, err := s.findMaterialOfCrop(*crop)
+	if err != nil {
+		return err
+	}
+
+	if material.GDDToMaturity == nil {
+		return nil
+	}
+
+	accumulated, err := domain.NewGDDAccumulator(s.MicroclimateSampleQuery).Accumulate(*crop)
+	if err != nil {
+		return err
+	}
+
+	if accumulated < *material.GDDToMaturity {
+		return nil
+	}
+
+	// PROCESS //
+	err = crop.ReachGDDMaturity(accumulated, *material.GDDToMaturity, now)
+	if err != nil {
+		return err
+	}
+
+	// PERSIST //
+	err = <-s.CropEventRepo.Save(
+		crop.UID, crop.Version, crop.UncommittedChanges, actor.System("gdd_harvest_prediction"),
+	)
+	if err != nil {
+		return err
+	}
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(crop)
+
+	return nil
+}
+
+func (s *GrowthServer) findMaterialOfCrop(crop domain.Crop) (query.CropMaterialQueryResult, error) {
+	result := <-s.MaterialReadQuery.FindByID(crop.InventoryUID)
+	if result.Error != nil {
+		return query.CropMaterialQueryResult{}, result.Error
+	}
+
+	material, ok := result.Result.(query.CropMaterialQueryResult)
+	if !ok {
+		return query.CropMaterialQueryResult{}, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return material, nil
+}
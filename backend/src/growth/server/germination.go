@@ -0,0 +1,226 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+// RecordCropGermination counts the germinated_quantity of the seeds sown for the crop at the germination_date,
+// today when it's not given.
+func (s *GrowthServer) RecordCropGermination(c echo.Context) error {
+	cropUID, err := s.parseCropUID(c, "id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	germinatedQuantity, err := strconv.Atoi(c.FormValue("germinated_quantity"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(Numeric, "germinated_quantity"))
+	}
+
+	germinationDate := time.Now()
+
+	if value := c.FormValue("germination_date"); value != "" {
+		germinationDate, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "germination_date"))
+		}
+	}
+
+	// VALIDATE //
+	result := <-s.CropReadQuery.FindByID(cropUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	cropRead, ok := result.Result.(storage.CropRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	if cropRead.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	// PROCESS //
+	eventQueryResult := s.findCropEvents(cropUID)
+	if eventQueryResult.Error != nil {
+		return Error(c, eventQueryResult.Error)
+	}
+
+	events, ok := eventQueryResult.Result.([]storage.CropEvent)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	crop := repository.NewCropBatchFromHistory(events)
+
+	err = crop.RecordGermination(germinatedQuantity, germinationDate)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(crop)
+
+	data := make(map[string]storage.CropRead)
+
+	cr, err := MapToCropRead(s, *crop)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data["data"] = cr
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// GerminationCheck is the result of a germination check, its rates are percentages.
+type GerminationCheck struct {
+	CropUID                 uuid.UUID `json:"crop_id"`
+	SeedsPlanted            int       `json:"seeds_planted"`
+	SeedsGerminated         int       `json:"seeds_germinated"`
+	ActualGerminationRate   float64   `json:"actual_germination_rate"`
+	ExpectedGerminationRate float64   `json:"expected_germination_rate"`
+	Low                     bool      `json:"low"`
+	CheckedAt               time.Time `json:"checked_at"`
+}
+
+// CheckCropGermination counts the seeds_germinated of the crop at the checked_at date, today when it's not given,
+// and compares their rate with the expected_germination_rate of the seed lot, the percentage on its packet.
+// A low germination creates a task to assess its causes.
+func (s *GrowthServer) CheckCropGermination(c echo.Context) error {
+	cropUID, err := s.parseCropUID(c, "crop_id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	seedsGerminated, err := strconv.Atoi(c.FormValue("seeds_germinated"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(Numeric, "seeds_germinated"))
+	}
+
+	expectedRate, err := strconv.ParseFloat(c.FormValue("expected_germination_rate"), 64)
+	if err != nil {
+		return Error(c, NewRequestValidationError(Numeric, "expected_germination_rate"))
+	}
+
+	checkedAt := time.Now()
+
+	if value := c.FormValue("checked_at"); value != "" {
+		checkedAt, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "checked_at"))
+		}
+	}
+
+	// VALIDATE //
+	result := <-s.CropReadQuery.FindByID(cropUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	cropRead, ok := result.Result.(storage.CropRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	if cropRead.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "crop_id"))
+	}
+
+	// PROCESS //
+	eventQueryResult := s.findCropEvents(cropUID)
+	if eventQueryResult.Error != nil {
+		return Error(c, eventQueryResult.Error)
+	}
+
+	events, ok := eventQueryResult.Result.([]storage.CropEvent)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	crop := repository.NewCropBatchFromHistory(events)
+
+	err = crop.CheckGermination(seedsGerminated, expectedRate, checkedAt)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	check := GerminationCheck{CropUID: crop.UID}
+
+	for _, v := range crop.UncommittedChanges {
+		switch e := v.(type) {
+		case domain.GerminationChecked:
+			check.SeedsPlanted = e.SeedsPlanted
+			check.SeedsGerminated = e.SeedsGerminated
+			check.ActualGerminationRate = e.ActualGerminationRate
+			check.ExpectedGerminationRate = e.ExpectedGerminationRate
+			check.CheckedAt = e.CheckedAt
+		case domain.LowGerminationAlert:
+			check.Low = true
+		}
+	}
+
+	// PERSIST //
+	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(crop)
+
+	data := make(map[string]GerminationCheck)
+	data["data"] = check
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// GetGerminationReport returns the germination rates of the crops of the farm per seed material.
+// The batches without seeds sown or germination recorded, like the ones created before, are left out.
+func (s *GrowthServer) GetGerminationReport(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	farm, err := s.findCropFarm(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	crops, err := s.findAllFarmCrops(farm.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	report := domain.NewGerminationReport()
+
+	for _, v := range crops {
+		if v.SeedsSown == nil || v.GerminatedQuantity == nil {
+			continue
+		}
+
+		report.Add(v.Inventory.UID, v.Inventory.Name, v.Inventory.PlantType, *v.SeedsSown, *v.GerminatedQuantity)
+	}
+
+	data := make(map[string][]domain.GerminationRow)
+	data["data"] = report.Rows()
+
+	return c.JSON(http.StatusOK, data)
+}
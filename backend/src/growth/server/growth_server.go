@@ -2,48 +2,119 @@ package server
 
 import (
 	"database/sql"
+	"mime/multipart"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/actor"
 	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/customfield"
+	"github.com/usetania/tania-core/src/energy"
+	"github.com/usetania/tania-core/src/envalert"
 	"github.com/usetania/tania-core/src/eventbus"
+	"github.com/usetania/tania-core/src/farmscope"
+	"github.com/usetania/tania-core/src/featureflags"
 	"github.com/usetania/tania-core/src/growth/domain"
 	"github.com/usetania/tania-core/src/growth/domain/service"
 	"github.com/usetania/tania-core/src/growth/query"
 	queryInMem "github.com/usetania/tania-core/src/growth/query/inmemory"
 	queryMysql "github.com/usetania/tania-core/src/growth/query/mysql"
+	queryRedis "github.com/usetania/tania-core/src/growth/query/redis"
 	querySqlite "github.com/usetania/tania-core/src/growth/query/sqlite"
 	"github.com/usetania/tania-core/src/growth/repository"
 	repoInMem "github.com/usetania/tania-core/src/growth/repository/inmemory"
 	repoMysql "github.com/usetania/tania-core/src/growth/repository/mysql"
+	repoRedis "github.com/usetania/tania-core/src/growth/repository/redis"
 	repoSqlite "github.com/usetania/tania-core/src/growth/repository/sqlite"
 	"github.com/usetania/tania-core/src/growth/storage"
 	"github.com/usetania/tania-core/src/helper/imagehelper"
 	"github.com/usetania/tania-core/src/helper/paginationhelper"
 	"github.com/usetania/tania-core/src/helper/stringhelper"
 	"github.com/usetania/tania-core/src/helper/structhelper"
+	"github.com/usetania/tania-core/src/media"
+	"github.com/usetania/tania-core/src/orphans"
+	"github.com/usetania/tania-core/src/retention"
+	"github.com/usetania/tania-core/src/shortcode"
+	"github.com/usetania/tania-core/src/signing"
+	redisstorage "github.com/usetania/tania-core/src/storage/redis"
 	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
 )
 
 // GrowthServer ties the routes and handlers with injected dependencies.
 type GrowthServer struct {
-	CropEventRepo     repository.CropEvent
-	CropEventQuery    query.CropEventQuery
-	CropReadRepo      repository.CropRead
-	CropReadQuery     query.CropReadQuery
-	CropActivityRepo  repository.CropActivity
-	CropActivityQuery query.CropActivityQuery
-	CropService       domain.CropService
-	AreaReadQuery     query.AreaReadQuery
-	MaterialReadQuery query.MaterialReadQuery
-	FarmReadQuery     query.FarmReadQuery
-	TaskReadQuery     query.TaskReadQuery
-	EventBus          eventbus.TaniaEventBus
-	File              File
+	CropEventRepo      repository.CropEvent
+	CropEventQuery     query.CropEventQuery
+	CropReadRepo       repository.CropRead
+	CropReadQuery      query.CropReadQuery
+	CropActivityRepo   repository.CropActivity
+	CropActivityQuery  query.CropActivityQuery
+	CropService        domain.CropService
+	AreaReadQuery      query.AreaReadQuery
+	MaterialReadQuery  query.MaterialReadQuery
+	FarmReadQuery      query.FarmReadQuery
+	TaskReadQuery      query.TaskReadQuery
+	EventBus           eventbus.TaniaEventBus
+	File               File
+	PhotoProcessor     *PhotoProcessor
+	ShortCodeGenerator shortcode.Generator
+	PrunedQuery        retention.PrunedQuery
+	FarmScope          farmscope.Scope
+	CustomFields       *customfield.Service
+
+	CropInputScheduleEventRepo  repository.CropInputScheduleEvent
+	CropInputScheduleEventQuery query.CropInputScheduleEventQuery
+	CropInputScheduleReadRepo   repository.CropInputScheduleRead
+	CropInputScheduleReadQuery  query.CropInputScheduleReadQuery
+	InputScheduleTaskCreator    InputScheduleTaskCreator
+
+	MicroclimateSampleRepo  repository.MicroclimateSample
+	MicroclimateSampleQuery query.MicroclimateSampleQuery
+
+	DispatchScheduleEventRepo  repository.DispatchScheduleEvent
+	DispatchScheduleEventQuery query.DispatchScheduleEventQuery
+	DispatchScheduleReadRepo   repository.DispatchScheduleRead
+	DispatchScheduleReadQuery  query.DispatchScheduleReadQuery
+	DispatchTaskCreator        DispatchTaskCreator
+
+	InsurancePolicyEventRepo  repository.InsurancePolicyEvent
+	InsurancePolicyEventQuery query.InsurancePolicyEventQuery
+	InsurancePolicyReadRepo   repository.InsurancePolicyRead
+	InsurancePolicyReadQuery  query.InsurancePolicyReadQuery
+
+	HarvestLotEventRepo  repository.HarvestLotEvent
+	HarvestLotEventQuery query.HarvestLotEventQuery
+	HarvestLotReadRepo   repository.HarvestLotRead
+	HarvestLotReadQuery  query.HarvestLotReadQuery
+
+	EnergyStore energy.Store
+
+	EnvironmentAlerts        *envalert.Evaluator
+	EnvironmentAlertNotifier EnvironmentAlertNotifier
+
+	PhotoHashStore media.PhotoHashStore
+	PhotoHasher    media.PerceptualHasher
+
+	// PhotoReferences tells whether the files of the removed photos are still used,
+	// without it they are left to the orphan cleanup.
+	PhotoReferences orphans.References
+
+	Signer *signing.Signer
+
+	// Flags turns the crop insurance routes and claims on and off.
+	Flags *featureflags.Registry
+
+	// SampleSignature verifies the microclimate samples are signed by a registered device, nil when they
+	// aren't signed.
+	SampleSignature echo.MiddlewareFunc
+
+	// CropReadStaleness is the CropReadQuery, it serves the crop lists from their snapshot during a replay.
+	CropReadStaleness *query.StalenessAwareStorage
 }
 
 // NewGrowthServer initializes GrowthServer's dependencies and create new GrowthServer struct.
@@ -53,14 +124,43 @@ func NewGrowthServer(
 	cropEventStorage *storage.CropEventStorage,
 	cropReadStorage *storage.CropReadStorage,
 	cropActivityStorage *storage.CropActivityStorage,
+	cropInputScheduleEventStorage *storage.CropInputScheduleEventStorage,
+	cropInputScheduleReadStorage *storage.CropInputScheduleReadStorage,
+	microclimateSampleStorage *storage.MicroclimateSampleStorage,
+	dispatchEventStorage *storage.DispatchScheduleEventStorage,
+	dispatchReadStorage *storage.DispatchScheduleReadStorage,
+	insurancePolicyEventStorage *storage.InsurancePolicyEventStorage,
+	insurancePolicyReadStorage *storage.InsurancePolicyReadStorage,
+	harvestLotEventStorage *storage.HarvestLotEventStorage,
+	harvestLotReadStorage *storage.HarvestLotReadStorage,
 	areaReadStorage *assetsstorage.AreaReadStorage,
 	materialReadStorage *assetsstorage.MaterialReadStorage,
 	farmReadStorage *assetsstorage.FarmReadStorage,
 	taskReadStorage *taskstorage.TaskReadStorage,
+	prunedStorage *retention.PrunedStorage,
+	customFieldValueStorage *customfield.ValueStorage,
+	customFieldDefinitionStorage *assetsstorage.CustomFieldDefinitionReadStorage,
+	energyReadingStorage *energy.EnergyReadingStorage,
+	environmentAlertRuleStorage *envalert.RuleStorage,
+	photoHashStorage *media.PhotoHashStorage,
+	signingKeyStorage *signing.KeyStorage,
+	flags *featureflags.Registry,
 ) (*GrowthServer, error) {
+	// The topics of the bus are scoped to the tenant of the deployment.
+	bus, err := eventbus.ForTenant(bus)
+	if err != nil {
+		return nil, err
+	}
+
+	var environmentAlertStore envalert.Store
+
 	growthServer := &GrowthServer{
-		File:     LocalFile{},
-		EventBus: bus,
+		File:           LocalFile{},
+		EventBus:       bus,
+		PhotoProcessor: NewPhotoProcessor(),
+		FarmScope:      farmscope.NewScope(Error),
+		PhotoHasher:    media.NewPerceptualHasher(),
+		Flags:          flags,
 	}
 
 	switch *config.Config.TaniaPersistenceEngine {
@@ -71,11 +171,43 @@ func NewGrowthServer(
 		growthServer.CropReadQuery = queryInMem.NewCropReadQueryInMemory(cropReadStorage)
 		growthServer.CropActivityRepo = repoInMem.NewCropActivityRepositoryInMemory(cropActivityStorage)
 		growthServer.CropActivityQuery = queryInMem.NewCropActivityQueryInMemory(cropActivityStorage)
+		growthServer.CropInputScheduleEventRepo = repoInMem.NewCropInputScheduleEventRepositoryInMemory(cropInputScheduleEventStorage)
+		growthServer.CropInputScheduleEventQuery = queryInMem.NewCropInputScheduleEventQueryInMemory(cropInputScheduleEventStorage)
+		growthServer.CropInputScheduleReadRepo = repoInMem.NewCropInputScheduleReadRepositoryInMemory(cropInputScheduleReadStorage)
+		growthServer.CropInputScheduleReadQuery = queryInMem.NewCropInputScheduleReadQueryInMemory(cropInputScheduleReadStorage)
+		growthServer.MicroclimateSampleRepo = repoInMem.NewMicroclimateSampleRepositoryInMemory(microclimateSampleStorage)
+		growthServer.MicroclimateSampleQuery = queryInMem.NewMicroclimateSampleQueryInMemory(microclimateSampleStorage)
+		growthServer.DispatchScheduleEventRepo = repoInMem.NewDispatchScheduleEventRepositoryInMemory(dispatchEventStorage)
+		growthServer.DispatchScheduleEventQuery = queryInMem.NewDispatchScheduleEventQueryInMemory(dispatchEventStorage)
+		growthServer.DispatchScheduleReadRepo = repoInMem.NewDispatchScheduleReadRepositoryInMemory(dispatchReadStorage)
+		growthServer.DispatchScheduleReadQuery = queryInMem.NewDispatchScheduleReadQueryInMemory(dispatchReadStorage)
+		growthServer.InsurancePolicyEventRepo = repoInMem.NewInsurancePolicyEventRepositoryInMemory(
+			insurancePolicyEventStorage,
+		)
+		growthServer.InsurancePolicyEventQuery = queryInMem.NewInsurancePolicyEventQueryInMemory(
+			insurancePolicyEventStorage,
+		)
+		growthServer.InsurancePolicyReadRepo = repoInMem.NewInsurancePolicyReadRepositoryInMemory(
+			insurancePolicyReadStorage,
+		)
+		growthServer.InsurancePolicyReadQuery = queryInMem.NewInsurancePolicyReadQueryInMemory(insurancePolicyReadStorage)
+		growthServer.HarvestLotEventRepo = repoInMem.NewHarvestLotEventRepositoryInMemory(harvestLotEventStorage)
+		growthServer.HarvestLotEventQuery = queryInMem.NewHarvestLotEventQueryInMemory(harvestLotEventStorage)
+		growthServer.HarvestLotReadRepo = repoInMem.NewHarvestLotReadRepositoryInMemory(harvestLotReadStorage)
+		growthServer.HarvestLotReadQuery = queryInMem.NewHarvestLotReadQueryInMemory(harvestLotReadStorage)
+		growthServer.EnergyStore = energy.NewStoreInMemory(energyReadingStorage)
+		environmentAlertStore = envalert.NewStoreInMemory(environmentAlertRuleStorage)
+		growthServer.PhotoHashStore = media.NewPhotoHashStoreInMemory(photoHashStorage)
+		growthServer.Signer = signing.NewSigner(signing.NewStoreInMemory(signingKeyStorage))
 
 		growthServer.AreaReadQuery = queryInMem.NewAreaReadQueryInMemory(areaReadStorage)
 		growthServer.MaterialReadQuery = queryInMem.NewMaterialReadQueryInMemory(materialReadStorage)
 		growthServer.FarmReadQuery = queryInMem.NewFarmReadQueryInMemory(farmReadStorage)
 		growthServer.TaskReadQuery = queryInMem.NewTaskReadQueryInMemory(taskReadStorage)
+		growthServer.ShortCodeGenerator = shortcode.NewGeneratorInMemory()
+		growthServer.PrunedQuery = retention.NewPrunedQueryInMemory(prunedStorage)
+		growthServer.CustomFields = customfield.NewService(
+			customfield.NewStoreInMemory(customFieldValueStorage, customFieldDefinitionStorage), bus)
 
 		// TODO: CropServiceInMemory should be renamed. It doesn't need InMemory name
 		growthServer.CropService = service.CropServiceInMemory{
@@ -90,11 +222,36 @@ func NewGrowthServer(
 		growthServer.CropReadQuery = querySqlite.NewCropReadQuerySqlite(db)
 		growthServer.CropActivityRepo = repoSqlite.NewCropActivityRepositorySqlite(db)
 		growthServer.CropActivityQuery = querySqlite.NewCropActivityQuerySqlite(db)
+		growthServer.CropInputScheduleEventRepo = repoSqlite.NewCropInputScheduleEventRepositorySqlite(db)
+		growthServer.CropInputScheduleEventQuery = querySqlite.NewCropInputScheduleEventQuerySqlite(db)
+		growthServer.CropInputScheduleReadRepo = repoSqlite.NewCropInputScheduleReadRepositorySqlite(db)
+		growthServer.CropInputScheduleReadQuery = querySqlite.NewCropInputScheduleReadQuerySqlite(db)
+		growthServer.MicroclimateSampleRepo = repoSqlite.NewMicroclimateSampleRepositorySqlite(db)
+		growthServer.MicroclimateSampleQuery = querySqlite.NewMicroclimateSampleQuerySqlite(db)
+		growthServer.DispatchScheduleEventRepo = repoSqlite.NewDispatchScheduleEventRepositorySqlite(db)
+		growthServer.DispatchScheduleEventQuery = querySqlite.NewDispatchScheduleEventQuerySqlite(db)
+		growthServer.DispatchScheduleReadRepo = repoSqlite.NewDispatchScheduleReadRepositorySqlite(db)
+		growthServer.DispatchScheduleReadQuery = querySqlite.NewDispatchScheduleReadQuerySqlite(db)
+		growthServer.InsurancePolicyEventRepo = repoSqlite.NewInsurancePolicyEventRepositorySqlite(db)
+		growthServer.InsurancePolicyEventQuery = querySqlite.NewInsurancePolicyEventQuerySqlite(db)
+		growthServer.InsurancePolicyReadRepo = repoSqlite.NewInsurancePolicyReadRepositorySqlite(db)
+		growthServer.InsurancePolicyReadQuery = querySqlite.NewInsurancePolicyReadQuerySqlite(db)
+		growthServer.HarvestLotEventRepo = repoSqlite.NewHarvestLotEventRepositorySqlite(db)
+		growthServer.HarvestLotEventQuery = querySqlite.NewHarvestLotEventQuerySqlite(db)
+		growthServer.HarvestLotReadRepo = repoSqlite.NewHarvestLotReadRepositorySqlite(db)
+		growthServer.HarvestLotReadQuery = querySqlite.NewHarvestLotReadQuerySqlite(db)
+		growthServer.EnergyStore = energy.NewStoreSqlite(db)
+		environmentAlertStore = envalert.NewStoreSqlite(db)
+		growthServer.PhotoHashStore = media.NewPhotoHashStoreSqlite(db)
+		growthServer.Signer = signing.NewSigner(signing.NewStoreSqlite(db))
 
 		growthServer.AreaReadQuery = querySqlite.NewAreaReadQuerySqlite(db)
 		growthServer.MaterialReadQuery = querySqlite.NewMaterialReadQuerySqlite(db)
 		growthServer.FarmReadQuery = querySqlite.NewFarmReadQuerySqlite(db)
 		growthServer.TaskReadQuery = querySqlite.NewTaskReadQuerySqlite(db)
+		growthServer.ShortCodeGenerator = shortcode.NewGeneratorSqlite(db)
+		growthServer.PrunedQuery = retention.NewStoreSqlite(db)
+		growthServer.CustomFields = customfield.NewService(customfield.NewStoreSqlite(db), bus)
 
 		// TODO: CropServiceInMemory should be renamed. It doesn't need InMemory name
 		growthServer.CropService = service.CropServiceInMemory{
@@ -110,11 +267,36 @@ func NewGrowthServer(
 		growthServer.CropReadQuery = queryMysql.NewCropReadQueryMysql(db)
 		growthServer.CropActivityRepo = repoMysql.NewCropActivityRepositoryMysql(db)
 		growthServer.CropActivityQuery = queryMysql.NewCropActivityQueryMysql(db)
+		growthServer.CropInputScheduleEventRepo = repoMysql.NewCropInputScheduleEventRepositoryMysql(db)
+		growthServer.CropInputScheduleEventQuery = queryMysql.NewCropInputScheduleEventQueryMysql(db)
+		growthServer.CropInputScheduleReadRepo = repoMysql.NewCropInputScheduleReadRepositoryMysql(db)
+		growthServer.CropInputScheduleReadQuery = queryMysql.NewCropInputScheduleReadQueryMysql(db)
+		growthServer.MicroclimateSampleRepo = repoMysql.NewMicroclimateSampleRepositoryMysql(db)
+		growthServer.MicroclimateSampleQuery = queryMysql.NewMicroclimateSampleQueryMysql(db)
+		growthServer.DispatchScheduleEventRepo = repoMysql.NewDispatchScheduleEventRepositoryMysql(db)
+		growthServer.DispatchScheduleEventQuery = queryMysql.NewDispatchScheduleEventQueryMysql(db)
+		growthServer.DispatchScheduleReadRepo = repoMysql.NewDispatchScheduleReadRepositoryMysql(db)
+		growthServer.DispatchScheduleReadQuery = queryMysql.NewDispatchScheduleReadQueryMysql(db)
+		growthServer.InsurancePolicyEventRepo = repoMysql.NewInsurancePolicyEventRepositoryMysql(db)
+		growthServer.InsurancePolicyEventQuery = queryMysql.NewInsurancePolicyEventQueryMysql(db)
+		growthServer.InsurancePolicyReadRepo = repoMysql.NewInsurancePolicyReadRepositoryMysql(db)
+		growthServer.InsurancePolicyReadQuery = queryMysql.NewInsurancePolicyReadQueryMysql(db)
+		growthServer.HarvestLotEventRepo = repoMysql.NewHarvestLotEventRepositoryMysql(db)
+		growthServer.HarvestLotEventQuery = queryMysql.NewHarvestLotEventQueryMysql(db)
+		growthServer.HarvestLotReadRepo = repoMysql.NewHarvestLotReadRepositoryMysql(db)
+		growthServer.HarvestLotReadQuery = queryMysql.NewHarvestLotReadQueryMysql(db)
+		growthServer.EnergyStore = energy.NewStoreMysql(db)
+		environmentAlertStore = envalert.NewStoreMysql(db)
+		growthServer.PhotoHashStore = media.NewPhotoHashStoreMysql(db)
+		growthServer.Signer = signing.NewSigner(signing.NewStoreMysql(db))
 
 		growthServer.AreaReadQuery = queryMysql.NewAreaReadQueryMysql(db)
 		growthServer.MaterialReadQuery = queryMysql.NewMaterialReadQueryMysql(db)
 		growthServer.FarmReadQuery = queryMysql.NewFarmReadQueryMysql(db)
 		growthServer.TaskReadQuery = queryMysql.NewTaskReadQueryMysql(db)
+		growthServer.ShortCodeGenerator = shortcode.NewGeneratorMysql(db)
+		growthServer.PrunedQuery = retention.NewStoreMysql(db)
+		growthServer.CustomFields = customfield.NewService(customfield.NewStoreMysql(db), bus)
 
 		// TODO: CropServiceInMemory should be renamed. It doesn't need InMemory name
 		growthServer.CropService = service.CropServiceInMemory{
@@ -124,11 +306,32 @@ func NewGrowthServer(
 		}
 	}
 
+	// The crop lists are read through the staleness wrapper, the crop service reads the read model itself.
+	growthServer.CropReadStaleness = query.NewStalenessAwareStorage(
+		growthServer.CropReadQuery,
+		config.Config.ReplayStaleReads == nil || *config.Config.ReplayStaleReads,
+	)
+	growthServer.CropReadQuery = growthServer.CropReadStaleness
+
+	growthServer.EnvironmentAlerts, err = envalert.NewEvaluator(environmentAlertStore)
+	if err != nil {
+		return nil, err
+	}
+
 	growthServer.InitSubscriber()
+	growthServer.StartPhotoWorkers(photoWorkerCount)
+
+	go growthServer.requeuePendingPhotos()
 
 	return growthServer, nil
 }
 
+// UseEventStreams keeps the events of the crops in the Redis streams instead of the in-memory storage.
+func (s *GrowthServer) UseEventStreams(streams *redisstorage.RedisStreamsEventStorage) {
+	s.CropEventRepo = repoRedis.NewCropEventRepositoryRedis(streams)
+	s.CropEventQuery = queryRedis.NewCropEventQueryRedis(streams)
+}
+
 // InitSubscriber defines the mapping of which event this domain listen with their handler.
 func (s *GrowthServer) InitSubscriber() {
 	s.EventBus.Subscribe("CropBatchCreated", s.SaveToCropReadModel)
@@ -150,29 +353,125 @@ func (s *GrowthServer) InitSubscriber() {
 	s.EventBus.Subscribe("CropBatchNoteRemoved", s.SaveToCropReadModel)
 	s.EventBus.Subscribe("CropBatchPhotoCreated", s.SaveToCropReadModel)
 	s.EventBus.Subscribe("CropBatchPhotoCreated", s.SaveToCropActivityReadModel)
+	s.EventBus.Subscribe("CropNurseryStageStarted", s.SaveToCropReadModel)
+	s.EventBus.Subscribe("CropNurseryStageCompleted", s.SaveToCropReadModel)
+	s.EventBus.Subscribe("CropBatchPhotoProcessed", s.SaveToCropReadModel)
+	s.EventBus.Subscribe("CropBatchPhotoProcessingFailed", s.SaveToCropReadModel)
+	s.EventBus.Subscribe("CropBatchPhotoProcessingRetried", s.SaveToCropReadModel)
+	s.EventBus.Subscribe("CropBatchPhotoRemoved", s.SaveToCropReadModel)
+	s.EventBus.Subscribe("CropBatchShortCodeAssigned", s.SaveToCropReadModel)
+	s.EventBus.Subscribe("CropBatchSeedsSownRecorded", s.SaveToCropReadModel)
+	s.EventBus.Subscribe("CropGerminationRecorded", s.SaveToCropReadModel)
+	s.EventBus.Subscribe("CropGerminationRecorded", s.SaveToCropActivityReadModel)
+	s.EventBus.Subscribe("CropBatchWithholdingOverridden", s.SaveToCropActivityReadModel)
+	s.EventBus.Subscribe("CropMergedFrom", s.SaveToCropReadModel)
+	s.EventBus.Subscribe("CropMergedFrom", s.SaveToCropActivityReadModel)
+	s.EventBus.Subscribe("CropMergedInto", s.SaveToCropReadModel)
+	s.EventBus.Subscribe("CropMergedInto", s.SaveToCropActivityReadModel)
+	s.EventBus.Subscribe("CropTransferInitiated", s.SaveToCropActivityReadModel)
+
+	s.EventBus.Subscribe("InputScheduleCreated", s.SaveToCropInputScheduleReadModel)
+	s.EventBus.Subscribe("InputScheduleModified", s.SaveToCropInputScheduleReadModel)
+	s.EventBus.Subscribe("InputScheduleTaskCreated", s.SaveToCropInputScheduleReadModel)
+	s.EventBus.Subscribe("InputScheduleApplied", s.SaveToCropInputScheduleReadModel)
 
 	s.EventBus.Subscribe("TaskCompleted", s.SaveToCropActivityReadModel)
+	s.EventBus.Subscribe("TaskCompleted", s.MarkInputScheduleApplied)
+
+	s.EventBus.Subscribe("DispatchScheduled", s.SaveToDispatchScheduleReadModel)
+	s.EventBus.Subscribe("DispatchTaskCreated", s.SaveToDispatchScheduleReadModel)
+	s.EventBus.Subscribe("DispatchCompleted", s.SaveToDispatchScheduleReadModel)
+	s.EventBus.Subscribe("TaskCompleted", s.CompleteDispatchOfTask)
+
+	s.EventBus.Subscribe("PolicyCreated", s.SaveToInsurancePolicyReadModel)
+	s.EventBus.Subscribe("PolicyUpdated", s.SaveToInsurancePolicyReadModel)
+	s.EventBus.Subscribe("PolicyRemoved", s.SaveToInsurancePolicyReadModel)
+	s.EventBus.Subscribe("ClaimFiled", s.SaveToInsurancePolicyReadModel)
+	s.EventBus.Subscribe("ClaimSettled", s.SaveToInsurancePolicyReadModel)
+	s.EventBus.Subscribe("CropBatchDumped", s.FileInsuranceClaimOfDump)
+
+	s.EventBus.Subscribe("CropBatchHarvested", s.CreateHarvestLots)
+	s.EventBus.Subscribe("HarvestLotCreated", s.SaveToHarvestLotReadModel)
+	s.EventBus.Subscribe("HarvestLotShipped", s.SaveToHarvestLotReadModel)
+
+	s.EventBus.Subscribe("MicroclimateSampleRecorded", s.DetectMicroclimateAnomaly)
 }
 
 // Mount defines the GrowthServer's endpoints with its handlers.
 func (s *GrowthServer) Mount(g *echo.Group) {
-	g.GET("/:id/crops", s.FindAllCrops)
-	g.GET("/:id/crops/archives", s.FindAllCropArchives)
-	g.GET("/:id/crops/total_batch", s.GetBatchQuantity)
-	g.GET("/areas/:id/crops", s.FindAllCropsByArea)
-	g.POST("/areas/:id/crops", s.SaveAreaCropBatch)
-	g.PUT("/crops/:id", s.UpdateCropBatch)
-	g.GET("/crops/:id", s.FindCropByID)
-	g.POST("/crops/:id/move", s.MoveCrop)
-	g.POST("/crops/:id/harvest", s.HarvestCrop)
-	g.POST("/crops/:id/dump", s.DumpCrop)
-	g.POST("/crops/:id/water", s.WaterCrop)
-	g.POST("/crops/:id/notes", s.SaveCropNotes)
-	g.DELETE("/crops/:crop_id/notes/:note_id", s.RemoveCropNotes)
-	g.POST("/crops/:id/photos", s.UploadCropPhotos)
-	g.GET("/crops/:crop_id/photos/:photo_id", s.GetCropPhotos)
-	g.GET("/crops/:id/activities", s.GetCropActivities)
-	g.GET("/:id/crops/information", s.GetCropsInformation)
+	g.GET("/:id/crops", s.FindAllCrops, s.farmScope("id"))
+	g.GET("/:id/crops/archives", s.FindAllCropArchives, s.farmScope("id"))
+	g.GET("/:id/crops/total_batch", s.GetBatchQuantity, s.farmScope("id"))
+	g.GET("/:id/crops/harvest_grades", s.GetHarvestGradeReport, s.farmScope("id"))
+	g.GET("/:id/crops/germination", s.GetGerminationReport, s.farmScope("id"))
+	g.GET("/:id/crops/losses", s.GetLossReport, s.farmScope("id"))
+	g.POST("/:id/energy-readings", s.validatable((*GrowthServer).SaveEnergyReading), s.farmScope("id"))
+	g.GET("/:id/energy-reports", s.GetEnergyReport, s.farmScope("id"))
+	g.GET("/areas/:id/crops", s.FindAllCropsByArea, s.areaScope("id"))
+	g.POST("/areas/:id/crops", s.validatable((*GrowthServer).SaveAreaCropBatch), s.areaScope("id"))
+	g.PUT("/crops/:id", s.validatable((*GrowthServer).UpdateCropBatch), s.cropScope("id", ""))
+	g.GET("/crops/:id", s.FindCropByID, s.cropScope("id", ""))
+	g.PUT("/crops/:id/custom_fields", s.validatable((*GrowthServer).SaveCropCustomFields), s.cropScope("id", ""))
+	g.POST("/crops/:id/move", s.validatable((*GrowthServer).MoveCrop), s.cropScope("id", ""))
+	g.POST("/crops/:id/harvest", s.validatable((*GrowthServer).HarvestCrop), s.cropScope("id", ""))
+	g.POST("/crops/:id/dump", s.validatable((*GrowthServer).DumpCrop), s.cropScope("id", ""))
+	g.POST("/crops/:id/water", s.validatable((*GrowthServer).WaterCrop), s.cropScope("id", ""))
+	g.POST("/crops/:id/germination", s.validatable((*GrowthServer).RecordCropGermination), s.cropScope("id", ""))
+	g.POST("/crops/:id/nursery", s.validatable((*GrowthServer).StartCropNurseryStage), s.cropScope("id", ""))
+	g.POST("/crops/:id/nursery/complete", s.validatable((*GrowthServer).CompleteCropNurseryStage), s.cropScope("id", ""))
+	g.POST("/crops/:id/notes", s.validatable((*GrowthServer).SaveCropNotes), s.cropScope("id", ""))
+	g.DELETE("/crops/:crop_id/notes/:note_id", s.RemoveCropNotes, s.cropScope("crop_id", ""))
+	g.POST("/crops/:id/photos", s.UploadCropPhotos, s.cropScope("id", ""))
+	g.POST("/crops/:id/photos/bulk", s.UploadBulkCropPhotos, s.cropScope("id", ""))
+	g.GET("/crops/:crop_id/photos/:photo_id", s.GetCropPhotos, s.cropScope("crop_id", ""))
+	g.GET("/crops/:crop_id/photos/:photo_id/thumbnail", s.GetCropPhotoThumbnail, s.cropScope("crop_id", ""))
+	g.GET("/crops/:crop_id/photos/:photo_id/status", s.GetCropPhotoStatus, s.cropScope("crop_id", ""))
+	g.POST("/crops/:crop_id/photos/:photo_id/retry", s.RetryCropPhotoProcessing, s.cropScope("crop_id", ""))
+	g.DELETE("/crops/:crop_id/photos/:photo_id", s.RemoveCropPhoto, s.cropScope("crop_id", ""))
+	g.GET("/crops/:id/activities", s.GetCropActivities, s.cropScope("id", ""))
+	g.GET("/:id/crops/information", s.GetCropsInformation, s.farmScope("id"))
+	g.GET("/:id/crops/:crop_id/input-schedule", s.FindCropInputSchedule, s.cropScope("crop_id", "id"))
+	g.POST("/:id/crops/:crop_id/merge", s.validatable((*GrowthServer).MergeCrop), s.cropScope("crop_id", "id"))
+	g.POST("/:id/crops/:crop_id/germination-check", s.validatable((*GrowthServer).CheckCropGermination),
+		s.cropScope("crop_id", "id"))
+	g.POST("/:id/crops/:crop_id/input-schedule", s.validatable((*GrowthServer).SaveCropInputSchedule),
+		s.cropScope("crop_id", "id"))
+	g.PUT("/:id/crops/:crop_id/input-schedule/:schedule_id", s.validatable((*GrowthServer).UpdateCropInputSchedule),
+		s.cropScope("crop_id", "id"), s.inputScheduleScope("schedule_id", "id"))
+	g.GET("/:id/crops/:crop_id/gdd-progress", s.GetCropGDDProgress, s.cropScope("crop_id", "id"))
+	g.POST("/:id/crops/:crop_id/transfers", s.validatable((*GrowthServer).InitiateCropTransfer),
+		s.cropScope("crop_id", "id"))
+	g.GET("/:id/crops/:crop_id/transfer-certificate/:transfer_id", s.GetCropTransferCertificate,
+		s.cropScope("crop_id", "id"))
+	g.GET("/:id/signing-key", s.GetFarmSigningKey, s.farmScope("id"))
+	g.GET("/:id/dispatch-schedules", s.FindDispatchSchedules, s.farmScope("id"))
+	g.POST("/:id/dispatch-schedules", s.SaveDispatchSchedule, s.farmScope("id"))
+	insurance := s.Flags.Require(featureflags.CropInsurance)
+	g.GET("/:id/insurance-policies", s.FindInsurancePolicies, insurance, s.farmScope("id"))
+	g.POST("/:id/insurance-policies", s.validatable((*GrowthServer).SaveInsurancePolicy), insurance,
+		s.farmScope("id"))
+	g.GET("/:id/insurance-policies/:policy_id", s.FindInsurancePolicyByID, insurance,
+		s.insurancePolicyScope("policy_id", "id"))
+	g.PUT("/:id/insurance-policies/:policy_id", s.validatable((*GrowthServer).UpdateInsurancePolicy), insurance,
+		s.insurancePolicyScope("policy_id", "id"))
+	g.DELETE("/:id/insurance-policies/:policy_id", s.RemoveInsurancePolicy, insurance,
+		s.insurancePolicyScope("policy_id", "id"))
+	g.POST("/:id/insurance-policies/:policy_id/claims", s.validatable((*GrowthServer).FileInsuranceClaim), insurance,
+		s.insurancePolicyScope("policy_id", "id"))
+	g.PUT("/:id/insurance-policies/:policy_id/claims/:claim_id/settle",
+		s.validatable((*GrowthServer).SettleInsuranceClaim), insurance, s.insurancePolicyScope("policy_id", "id"))
+	g.GET("/:id/insurance-summary", s.GetInsuranceSummary, insurance, s.farmScope("id"))
+	g.GET("/:id/lots", s.FindHarvestLots, s.farmScope("id"))
+	g.GET("/:id/lots/:lot_id", s.FindHarvestLotByID, s.harvestLotScope("lot_id", "id"))
+	g.POST("/:id/lots/:lot_id/shipments", s.validatable((*GrowthServer).ShipHarvestLot),
+		s.harvestLotScope("lot_id", "id"))
+	g.GET("/:id/lots/:lot_id/trace", s.TraceHarvestLot, s.harvestLotScope("lot_id", "id"))
+	g.POST("/areas/:id/microclimate-samples", s.validatable((*GrowthServer).SaveMicroclimateSample),
+		s.sampleMiddlewares()...)
+	g.GET("/areas/:id/environment-alert-rules", s.FindEnvironmentAlertRules, s.areaScope("id"))
+	g.POST("/areas/:id/environment-alert-rules", s.validatable((*GrowthServer).SaveEnvironmentAlertRule),
+		s.areaScope("id"))
+	g.DELETE("/areas/:id/environment-alert-rules/:rule_id", s.RemoveEnvironmentAlertRule, s.areaScope("id"))
 }
 
 func (s *GrowthServer) SaveAreaCropBatch(c echo.Context) error {
@@ -194,6 +493,16 @@ func (s *GrowthServer) SaveAreaCropBatch(c echo.Context) error {
 		return Error(c, err)
 	}
 
+	// The seeds sown are optional, apart from the plants in the containers.
+	seedsSown := 0
+
+	if value := c.FormValue("seeds_sown"); value != "" {
+		seedsSown, err = strconv.Atoi(value)
+		if err != nil || seedsSown <= 0 {
+			return Error(c, NewRequestValidationError(Numeric, "seeds_sown"))
+		}
+	}
+
 	// Validate //
 	areaUID, err := uuid.FromString(areaID)
 	if err != nil {
@@ -231,6 +540,11 @@ func (s *GrowthServer) SaveAreaCropBatch(c echo.Context) error {
 		return Error(c, NewRequestValidationError(NotFound, "container_type"))
 	}
 
+	warnings, err := s.checkAreaSanitation(area)
+	if err != nil {
+		return Error(c, err)
+	}
+
 	// Process //
 	cropBatch, err := domain.CreateCropBatch(
 		s.CropService,
@@ -244,8 +558,25 @@ func (s *GrowthServer) SaveAreaCropBatch(c echo.Context) error {
 		return Error(c, err)
 	}
 
+	if seedsSown > 0 {
+		err = cropBatch.RecordSeedsSown(seedsSown)
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
+	shortCode, err := s.ShortCodeGenerator.Next(shortcode.CropPrefix, cropBatch.FarmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = cropBatch.AssignShortCode(shortCode)
+	if err != nil {
+		return Error(c, err)
+	}
+
 	// Persists //
-	err = <-s.CropEventRepo.Save(cropBatch.UID, 0, cropBatch.UncommittedChanges)
+	err = <-s.CropEventRepo.Save(cropBatch.UID, 0, cropBatch.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -253,20 +584,19 @@ func (s *GrowthServer) SaveAreaCropBatch(c echo.Context) error {
 	// Trigger Events
 	s.publishUncommittedEvents(cropBatch)
 
-	data := make(map[string]storage.CropRead)
-
 	cr, err := MapToCropRead(s, *cropBatch)
 	if err != nil {
 		return Error(c, err)
 	}
 
-	data["data"] = cr
-
-	return c.JSON(http.StatusOK, data)
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":     cr,
+		"warnings": warnings,
+	})
 }
 
 func (s *GrowthServer) UpdateCropBatch(c echo.Context) error {
-	cropUID, err := uuid.FromString(c.Param("id"))
+	cropUID, err := s.parseCropUID(c, "id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -324,7 +654,7 @@ func (s *GrowthServer) UpdateCropBatch(c echo.Context) error {
 	}
 
 	// Process //
-	eventQueryResult := <-s.CropEventQuery.FindAllByCropID(cropUID)
+	eventQueryResult := s.findCropEvents(cropUID)
 	if eventQueryResult.Error != nil {
 		return Error(c, eventQueryResult.Error)
 	}
@@ -375,7 +705,7 @@ func (s *GrowthServer) UpdateCropBatch(c echo.Context) error {
 	}
 
 	// Persist //
-	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges)
+	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -396,7 +726,7 @@ func (s *GrowthServer) UpdateCropBatch(c echo.Context) error {
 }
 
 func (s *GrowthServer) FindCropByID(c echo.Context) error {
-	cropUID, err := uuid.FromString(c.Param("id"))
+	cropUID, err := s.parseCropUID(c, "id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -416,14 +746,34 @@ func (s *GrowthServer) FindCropByID(c echo.Context) error {
 		return Error(c, NewRequestValidationError(NotFound, "id"))
 	}
 
-	data := make(map[string]storage.CropRead)
-	data["data"] = crop
+	customFields, err := s.CustomFields.Find(crop.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	safeHarvestDate, err := s.findSafeHarvestDate(crop.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	expanded, err := expand(c, cropExpansions(s, crop))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]CropDetail)
+	data["data"] = CropDetail{
+		CropRead:        crop,
+		CustomFields:    customFields,
+		SafeHarvestDate: safeHarvestDate,
+		Expanded:        expanded,
+	}
 
 	return c.JSON(http.StatusOK, data)
 }
 
 func (s *GrowthServer) MoveCrop(c echo.Context) error {
-	cropUID, err := uuid.FromString(c.Param("id"))
+	cropUID, err := s.parseCropUID(c, "id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -463,7 +813,7 @@ func (s *GrowthServer) MoveCrop(c echo.Context) error {
 	}
 
 	// PROCESS //
-	eventQueryResult := <-s.CropEventQuery.FindAllByCropID(cropUID)
+	eventQueryResult := s.findCropEvents(cropUID)
 	if eventQueryResult.Error != nil {
 		return Error(c, eventQueryResult.Error)
 	}
@@ -478,7 +828,7 @@ func (s *GrowthServer) MoveCrop(c echo.Context) error {
 	}
 
 	// PERSIST //
-	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges)
+	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -499,7 +849,7 @@ func (s *GrowthServer) MoveCrop(c echo.Context) error {
 }
 
 func (s *GrowthServer) HarvestCrop(c echo.Context) error {
-	cropUID, err := uuid.FromString(c.Param("id"))
+	cropUID, err := s.parseCropUID(c, "id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -549,8 +899,18 @@ func (s *GrowthServer) HarvestCrop(c echo.Context) error {
 		return Error(c, NewRequestValidationError(InvalidOption, "produced_unit"))
 	}
 
+	grades, err := s.parseHarvestGrades(c.FormValue("grades"), cropRead.FarmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	purgePhotos, err := parsePurgePhotos(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
 	// PROCESS //
-	eventQueryResult := <-s.CropEventQuery.FindAllByCropID(cropUID)
+	eventQueryResult := s.findCropEvents(cropUID)
 	if eventQueryResult.Error != nil {
 		return Error(c, eventQueryResult.Error)
 	}
@@ -559,13 +919,23 @@ func (s *GrowthServer) HarvestCrop(c echo.Context) error {
 
 	crop := repository.NewCropBatchFromHistory(events)
 
-	err = crop.Harvest(s.CropService, srcAreaUID, harvestType, float32(prodQty), prodUnit, notes)
+	err = s.checkWithholding(c, crop, time.Now())
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = crop.Harvest(s.CropService, srcAreaUID, harvestType, float32(prodQty), prodUnit, grades, notes)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = purgeArchivedCropPhotos(crop, purgePhotos)
 	if err != nil {
 		return Error(c, err)
 	}
 
 	// PERSIST //
-	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges)
+	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -573,6 +943,8 @@ func (s *GrowthServer) HarvestCrop(c echo.Context) error {
 	// TRIGGER EVENTS
 	s.publishUncommittedEvents(crop)
 
+	s.removeCropPhotoFiles(crop.UncommittedChanges)
+
 	data := make(map[string]storage.CropRead)
 
 	cr, err := MapToCropRead(s, *crop)
@@ -586,7 +958,7 @@ func (s *GrowthServer) HarvestCrop(c echo.Context) error {
 }
 
 func (s *GrowthServer) DumpCrop(c echo.Context) error {
-	cropUID, err := uuid.FromString(c.Param("id"))
+	cropUID, err := s.parseCropUID(c, "id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -594,6 +966,7 @@ func (s *GrowthServer) DumpCrop(c echo.Context) error {
 	srcAreaID := c.FormValue("source_area_id")
 	quantity := c.FormValue("quantity")
 	notes := c.FormValue("notes")
+	reason := strings.ToUpper(c.FormValue("reason"))
 
 	// VALIDATE //
 	result := <-s.CropReadQuery.FindByID(cropUID)
@@ -620,8 +993,13 @@ func (s *GrowthServer) DumpCrop(c echo.Context) error {
 		return Error(c, err)
 	}
 
+	purgePhotos, err := parsePurgePhotos(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
 	// PROCESS //
-	eventQueryResult := <-s.CropEventQuery.FindAllByCropID(cropUID)
+	eventQueryResult := s.findCropEvents(cropUID)
 	if eventQueryResult.Error != nil {
 		return Error(c, eventQueryResult.Error)
 	}
@@ -630,13 +1008,18 @@ func (s *GrowthServer) DumpCrop(c echo.Context) error {
 
 	crop := repository.NewCropBatchFromHistory(events)
 
-	err = crop.Dump(s.CropService, srcAreaUID, qty, notes)
+	err = crop.Dump(s.CropService, srcAreaUID, qty, notes, reason)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = purgeArchivedCropPhotos(crop, purgePhotos)
 	if err != nil {
 		return Error(c, err)
 	}
 
 	// PERSIST //
-	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges)
+	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -644,6 +1027,8 @@ func (s *GrowthServer) DumpCrop(c echo.Context) error {
 	// TRIGGER EVENTS
 	s.publishUncommittedEvents(crop)
 
+	s.removeCropPhotoFiles(crop.UncommittedChanges)
+
 	data := make(map[string]storage.CropRead)
 
 	cr, err := MapToCropRead(s, *crop)
@@ -657,7 +1042,7 @@ func (s *GrowthServer) DumpCrop(c echo.Context) error {
 }
 
 func (s *GrowthServer) WaterCrop(c echo.Context) error {
-	cropUID, err := uuid.FromString(c.Param("id"))
+	cropUID, err := s.parseCropUID(c, "id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -691,7 +1076,7 @@ func (s *GrowthServer) WaterCrop(c echo.Context) error {
 	}
 
 	// PROCESS //
-	eventQueryResult := <-s.CropEventQuery.FindAllByCropID(cropUID)
+	eventQueryResult := s.findCropEvents(cropUID)
 	if eventQueryResult.Error != nil {
 		return Error(c, eventQueryResult.Error)
 	}
@@ -706,7 +1091,149 @@ func (s *GrowthServer) WaterCrop(c echo.Context) error {
 	}
 
 	// PERSIST //
-	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges)
+	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(crop)
+
+	data := make(map[string]storage.CropRead)
+
+	cr, err := MapToCropRead(s, *crop)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data["data"] = cr
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *GrowthServer) StartCropNurseryStage(c echo.Context) error {
+	cropUID, err := s.parseCropUID(c, "id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	nurseryAreaID := c.FormValue("nursery_area_id")
+	startDate := c.FormValue("start_date")
+	expectedTransplantDate := c.FormValue("expected_transplant_date")
+
+	// VALIDATE //
+	result := <-s.CropReadQuery.FindByID(cropUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	cropRead, ok := result.Result.(storage.CropRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusBadRequest, "Internal server error"))
+	}
+
+	if cropRead.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	nurseryAreaUID, err := uuid.FromString(nurseryAreaID)
+	if err != nil {
+		return Error(c, NewRequestValidationError(Required, "nursery_area_id"))
+	}
+
+	sDate := time.Now()
+	if startDate != "" {
+		sDate, err = time.Parse("2006-01-02", startDate)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "start_date"))
+		}
+	}
+
+	if expectedTransplantDate == "" {
+		return Error(c, NewRequestValidationError(Required, "expected_transplant_date"))
+	}
+
+	etDate, err := time.Parse("2006-01-02", expectedTransplantDate)
+	if err != nil {
+		return Error(c, NewRequestValidationError(ParseFailed, "expected_transplant_date"))
+	}
+
+	// PROCESS //
+	crop, err := s.findCropFromHistory(cropUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = crop.StartNurseryStage(s.CropService, nurseryAreaUID, sDate, etDate)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(crop)
+
+	data := make(map[string]storage.CropRead)
+
+	cr, err := MapToCropRead(s, *crop)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data["data"] = cr
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *GrowthServer) CompleteCropNurseryStage(c echo.Context) error {
+	cropUID, err := s.parseCropUID(c, "id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	transplantDate := c.FormValue("transplant_date")
+
+	// VALIDATE //
+	result := <-s.CropReadQuery.FindByID(cropUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	cropRead, ok := result.Result.(storage.CropRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusBadRequest, "Internal server error"))
+	}
+
+	if cropRead.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	tDate := time.Now()
+	if transplantDate != "" {
+		tDate, err = time.Parse("2006-01-02", transplantDate)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "transplant_date"))
+		}
+	}
+
+	// PROCESS //
+	crop, err := s.findCropFromHistory(cropUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = crop.CompleteNurseryStage(tDate)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -727,7 +1254,7 @@ func (s *GrowthServer) WaterCrop(c echo.Context) error {
 }
 
 func (s *GrowthServer) SaveCropNotes(c echo.Context) error {
-	cropUID, err := uuid.FromString(c.Param("id"))
+	cropUID, err := s.parseCropUID(c, "id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -754,7 +1281,7 @@ func (s *GrowthServer) SaveCropNotes(c echo.Context) error {
 	}
 
 	// Process //
-	eventQueryResult := <-s.CropEventQuery.FindAllByCropID(cropUID)
+	eventQueryResult := s.findCropEvents(cropUID)
 	if eventQueryResult.Error != nil {
 		return Error(c, eventQueryResult.Error)
 	}
@@ -769,7 +1296,7 @@ func (s *GrowthServer) SaveCropNotes(c echo.Context) error {
 	}
 
 	// Persists //
-	resultSave := <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges)
+	resultSave := <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
 	if resultSave != nil {
 		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
 	}
@@ -790,7 +1317,7 @@ func (s *GrowthServer) SaveCropNotes(c echo.Context) error {
 }
 
 func (s *GrowthServer) RemoveCropNotes(c echo.Context) error {
-	cropUID, err := uuid.FromString(c.Param("crop_id"))
+	cropUID, err := s.parseCropUID(c, "crop_id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -816,7 +1343,7 @@ func (s *GrowthServer) RemoveCropNotes(c echo.Context) error {
 	}
 
 	// Process //
-	eventQueryResult := <-s.CropEventQuery.FindAllByCropID(cropUID)
+	eventQueryResult := s.findCropEvents(cropUID)
 	if eventQueryResult.Error != nil {
 		return Error(c, eventQueryResult.Error)
 	}
@@ -831,7 +1358,7 @@ func (s *GrowthServer) RemoveCropNotes(c echo.Context) error {
 	}
 
 	// Persists //
-	resultSave := <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges)
+	resultSave := <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
 	if resultSave != nil {
 		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
 	}
@@ -882,12 +1409,19 @@ func (s *GrowthServer) FindAllCrops(c echo.Context) error {
 		return Error(c, err)
 	}
 
+	filters := customfield.ParseFilters(c.QueryParams())
+	if len(filters) > 0 {
+		return s.findAllCropsByCustomFields(c, farm.UID, status, pageInt, limitInt, filters)
+	}
+
 	// Process //
 	resultQuery := <-s.CropReadQuery.FindAllCropsByFarm(farm.UID, status, pageInt, limitInt)
 	if resultQuery.Error != nil {
 		return Error(c, resultQuery.Error)
 	}
 
+	markStale(c, resultQuery)
+
 	crops, ok := resultQuery.Result.([]storage.CropRead)
 	if !ok {
 		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
@@ -898,6 +1432,8 @@ func (s *GrowthServer) FindAllCrops(c echo.Context) error {
 		return Error(c, resultQuery.Error)
 	}
 
+	markStale(c, resultQuery)
+
 	total, ok := resultQuery.Result.(int)
 	if !ok {
 		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
@@ -946,6 +1482,8 @@ func (s *GrowthServer) FindAllCropArchives(c echo.Context) error {
 		return Error(c, resultQuery.Error)
 	}
 
+	markStale(c, resultQuery)
+
 	crops, ok := resultQuery.Result.([]storage.CropRead)
 	if !ok {
 		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
@@ -956,6 +1494,8 @@ func (s *GrowthServer) FindAllCropArchives(c echo.Context) error {
 		return Error(c, resultQuery.Error)
 	}
 
+	markStale(c, resultQuery)
+
 	total, ok := resultQuery.Result.(int)
 	if !ok {
 		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
@@ -1001,6 +1541,8 @@ func (s *GrowthServer) FindAllCropsByArea(c echo.Context) error {
 		return Error(c, resultQuery.Error)
 	}
 
+	markStale(c, resultQuery)
+
 	crops, ok := resultQuery.Result.([]query.CropAreaByAreaQueryResult)
 	if !ok {
 		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
@@ -1065,7 +1607,7 @@ func (s *GrowthServer) GetBatchQuantity(c echo.Context) error {
 func (s *GrowthServer) UploadCropPhotos(c echo.Context) error {
 	description := c.FormValue("description")
 
-	cropUID, err := uuid.FromString(c.Param("id"))
+	cropUID, err := s.parseCropUID(c, "id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -1090,8 +1632,14 @@ func (s *GrowthServer) UploadCropPhotos(c echo.Context) error {
 		return Error(c, NewRequestValidationError(NotFound, "id"))
 	}
 
+	hashes, err := s.checkDuplicatePhotos(cropUID, "photo", []*multipart.FileHeader{photo},
+		c.QueryParam("force_upload") == "true")
+	if err != nil {
+		return Error(c, err)
+	}
+
 	// Process
-	eventQueryResult := <-s.CropEventQuery.FindAllByCropID(cropUID)
+	eventQueryResult := s.findCropEvents(cropUID)
 	if eventQueryResult.Error != nil {
 		return Error(c, eventQueryResult.Error)
 	}
@@ -1119,17 +1667,20 @@ func (s *GrowthServer) UploadCropPhotos(c echo.Context) error {
 		width,
 		height,
 		description,
+		extractPhotoMetadata(photo),
 	)
 	if err != nil {
 		return Error(c, err)
 	}
 
 	// Persists //
-	resultSave := <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges)
+	resultSave := <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
 	if resultSave != nil {
 		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
 	}
 
+	s.savePhotoHashes(crop.UID, []uuid.UUID{crop.Photos[len(crop.Photos)-1].UID}, hashes)
+
 	// TRIGGER EVENTS //
 	s.publishUncommittedEvents(crop)
 
@@ -1145,8 +1696,136 @@ func (s *GrowthServer) UploadCropPhotos(c echo.Context) error {
 	return c.JSON(http.StatusOK, data)
 }
 
+// UploadBulkCropPhotos stores the original photos right away
+// and leaves the thumbnails to the background photo workers.
+func (s *GrowthServer) UploadBulkCropPhotos(c echo.Context) error {
+	description := c.FormValue("description")
+
+	cropUID, err := s.parseCropUID(c, "id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// Validate //
+	form, err := c.MultipartForm()
+	if err != nil {
+		return Error(c, NewRequestValidationError(Required, "photos"))
+	}
+
+	photos := form.File["photos"]
+	if len(photos) == 0 {
+		return Error(c, NewRequestValidationError(Required, "photos"))
+	}
+
+	result := <-s.CropReadQuery.FindByID(cropUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	cropRead, ok := result.Result.(storage.CropRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusBadRequest, "Internal server error"))
+	}
+
+	if cropRead.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	hashes, err := s.checkDuplicatePhotos(cropUID, "photos", photos, c.QueryParam("force_upload") == "true")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// Process //
+	// The files are stored before taking the lock, which the photo workers of every crop wait on.
+	filenames := []string{}
+	metadata := []domain.CropPhotoMetadata{}
+
+	for _, photo := range photos {
+		// Prefix the stored filename so photos with the same name don't overwrite each other.
+		fileUID, err := uuid.NewV4()
+		if err != nil {
+			return Error(c, err)
+		}
+
+		filename := stringhelper.Join(fileUID.String(), "_", filepath.Base(photo.Filename))
+		destPath := stringhelper.Join(*config.Config.UploadPathCrop, "/", filename)
+
+		err = s.File.Upload(photo, destPath)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		filenames = append(filenames, filename)
+		metadata = append(metadata, extractPhotoMetadata(photo))
+	}
+
+	s.PhotoProcessor.Lock.Lock()
+	defer s.PhotoProcessor.Lock.Unlock()
+
+	crop, err := s.findCropFromHistory(cropUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	photoUIDs := []uuid.UUID{}
+
+	for i, photo := range photos {
+		photoUID, err := crop.AddPendingPhoto(
+			filenames[i],
+			photo.Header.Get("Content-Type"),
+			int(photo.Size),
+			description,
+			metadata[i],
+		)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		photoUIDs = append(photoUIDs, photoUID)
+	}
+
+	// Persists //
+	resultSave := <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
+	if resultSave != nil {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	s.savePhotoHashes(crop.UID, photoUIDs, hashes)
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(crop)
+
+	crop, err = s.queueCropPhotos(crop, photoUIDs, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string][]storage.CropPhoto)
+	data["data"] = []storage.CropPhoto{}
+
+	for _, v := range photoUIDs {
+		photo, err := crop.FindPhotoByID(v)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		data["data"] = append(data["data"], storage.CropPhoto{
+			UID:         photo.UID,
+			Filename:    photo.Filename,
+			MimeType:    photo.MimeType,
+			Size:        photo.Size,
+			Description: photo.Description,
+			Status:      photo.Status,
+			Metadata:    photo.Metadata,
+		})
+	}
+
+	return c.JSON(http.StatusAccepted, data)
+}
+
 func (s *GrowthServer) GetCropPhotos(c echo.Context) error {
-	cropUID, err := uuid.FromString(c.Param("crop_id"))
+	cropUID, err := s.parseCropUID(c, "crop_id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -1189,8 +1868,123 @@ func (s *GrowthServer) GetCropPhotos(c echo.Context) error {
 	return c.File(srcPath)
 }
 
+// GetCropPhotoThumbnail serves the photo's thumbnail,
+// or the original photo while the thumbnail isn't ready.
+func (s *GrowthServer) GetCropPhotoThumbnail(c echo.Context) error {
+	_, found, err := s.findCropPhoto(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// Process //
+	filename := found.Filename
+	if found.Status == domain.CropPhotoStatusReady && found.ThumbnailFilename != "" {
+		filename = found.ThumbnailFilename
+	}
+
+	srcPath := stringhelper.Join(*config.Config.UploadPathCrop, "/", filename)
+
+	return c.File(srcPath)
+}
+
+func (s *GrowthServer) GetCropPhotoStatus(c echo.Context) error {
+	_, found, err := s.findCropPhoto(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.CropPhoto)
+	data["data"] = found
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// RetryCropPhotoProcessing queues a photo again using its stored original.
+func (s *GrowthServer) RetryCropPhotoProcessing(c echo.Context) error {
+	cropRead, found, err := s.findCropPhoto(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// Process //
+	s.PhotoProcessor.Lock.Lock()
+	defer s.PhotoProcessor.Lock.Unlock()
+
+	crop, err := s.findCropFromHistory(cropRead.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = crop.RetryPhotoProcessing(found.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// Persists //
+	resultSave := <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
+	if resultSave != nil {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(crop)
+
+	crop, err = s.queueCropPhotos(crop, []uuid.UUID{found.UID}, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	photo, err := crop.FindPhotoByID(found.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	found.Status = photo.Status
+
+	data := make(map[string]storage.CropPhoto)
+	data["data"] = found
+
+	return c.JSON(http.StatusAccepted, data)
+}
+
+// findCropPhoto validates the crop_id and photo_id path params
+// and returns the matching crop and photo read models.
+func (s *GrowthServer) findCropPhoto(c echo.Context) (storage.CropRead, storage.CropPhoto, error) {
+	cropUID, err := s.parseCropUID(c, "crop_id")
+	if err != nil {
+		return storage.CropRead{}, storage.CropPhoto{}, err
+	}
+
+	photoUID, err := uuid.FromString(c.Param("photo_id"))
+	if err != nil {
+		return storage.CropRead{}, storage.CropPhoto{}, err
+	}
+
+	result := <-s.CropReadQuery.FindByID(cropUID)
+	if result.Error != nil {
+		return storage.CropRead{}, storage.CropPhoto{}, result.Error
+	}
+
+	cropRead, ok := result.Result.(storage.CropRead)
+	if !ok {
+		return storage.CropRead{}, storage.CropPhoto{}, echo.NewHTTPError(http.StatusBadRequest, "Internal server error")
+	}
+
+	if cropRead.UID == (uuid.UUID{}) {
+		return storage.CropRead{}, storage.CropPhoto{}, NewRequestValidationError(NotFound, "crop_id")
+	}
+
+	for _, v := range cropRead.Photos {
+		if v.UID == photoUID {
+			return cropRead, v, nil
+		}
+	}
+
+	return storage.CropRead{}, storage.CropPhoto{}, NewRequestValidationError(NotFound, "photo_id")
+}
+
 func (s *GrowthServer) GetCropActivities(c echo.Context) error {
-	cropUID, err := uuid.FromString(c.Param("id"))
+	cropUID, err := s.parseCropUID(c, "id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -1209,6 +2003,11 @@ func (s *GrowthServer) GetCropActivities(c echo.Context) error {
 		return Error(c, NewRequestValidationError(NotFound, "id"))
 	}
 
+	err = s.checkCropHistory(cropUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
 	// Process //
 	queryResult := <-s.CropActivityQuery.FindAllByCropID(cropUID)
 	if queryResult.Error != nil {
@@ -1217,9 +2016,17 @@ func (s *GrowthServer) GetCropActivities(c echo.Context) error {
 
 	activities := queryResult.Result.([]storage.CropActivity)
 
-	data := make(map[string][]CropActivity)
+	cropActivities := []CropActivity{}
 	for i := range activities {
-		data["data"] = append(data["data"], MapToCropActivity(activities[i]))
+		cropActivities = append(cropActivities, MapToCropActivity(activities[i]))
+	}
+
+	data := make(map[string]interface{})
+	data["data"] = cropActivities
+
+	// The batch merged into another one is archived, its history goes on in the surviving batch.
+	if crop.MergedIntoUID != nil {
+		data["merged_into_id"] = crop.MergedIntoUID
 	}
 
 	return c.JSON(http.StatusOK, data)
@@ -1247,6 +2054,17 @@ func (s *GrowthServer) GetCropsInformation(c echo.Context) error {
 	return c.JSON(http.StatusOK, data)
 }
 
+func (s *GrowthServer) findCropFromHistory(cropUID uuid.UUID) (*domain.Crop, error) {
+	eventQueryResult := s.findCropEvents(cropUID)
+	if eventQueryResult.Error != nil {
+		return nil, eventQueryResult.Error
+	}
+
+	events := eventQueryResult.Result.([]storage.CropEvent)
+
+	return repository.NewCropBatchFromHistory(events), nil
+}
+
 func (s *GrowthServer) publishUncommittedEvents(entity interface{}) {
 	switch e := entity.(type) {
 	case *domain.Crop:
@@ -1254,5 +2072,25 @@ func (s *GrowthServer) publishUncommittedEvents(entity interface{}) {
 			name := structhelper.GetName(v)
 			s.EventBus.Publish(name, v)
 		}
+	case *domain.CropInputSchedule:
+		for _, v := range e.UncommittedChanges {
+			name := structhelper.GetName(v)
+			s.EventBus.Publish(name, v)
+		}
+	case *domain.DispatchSchedule:
+		for _, v := range e.UncommittedChanges {
+			name := structhelper.GetName(v)
+			s.EventBus.Publish(name, v)
+		}
+	case *domain.InsurancePolicy:
+		for _, v := range e.UncommittedChanges {
+			name := structhelper.GetName(v)
+			s.EventBus.Publish(name, v)
+		}
+	case *domain.HarvestLot:
+		for _, v := range e.UncommittedChanges {
+			name := structhelper.GetName(v)
+			s.EventBus.Publish(name, v)
+		}
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/growth/domain"
 	"github.com/usetania/tania-core/src/growth/query"
 	"github.com/usetania/tania-core/src/growth/storage"
@@ -514,7 +515,219 @@ func (s *GrowthServer) SaveToCropReadModel(event interface{}) error {
 			Width:       e.Width,
 			Height:      e.Height,
 			Description: e.Description,
+			Status:      e.Status,
+			Metadata:    e.Metadata,
 		})
+
+	case domain.CropNurseryStageStarted:
+		queryResult := <-s.CropReadQuery.FindByID(e.CropID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		cr, ok := queryResult.Result.(storage.CropRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		queryResult = <-s.AreaReadQuery.FindByID(e.NurseryAreaID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		nurseryArea, ok := queryResult.Result.(query.CropAreaQueryResult)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		cropRead = &cr
+		cropRead.Status = domain.CropNursery
+		cropRead.NurseryStages = append(cropRead.NurseryStages, storage.NurseryStage{
+			UID:                    e.UID,
+			AreaUID:                nurseryArea.UID,
+			AreaName:               nurseryArea.Name,
+			StartDate:              e.StartDate,
+			ExpectedTransplantDate: e.ExpectedTransplantDate,
+			ActualTransplantDate:   e.ActualTransplantDate,
+		})
+
+	case domain.CropNurseryStageCompleted:
+		queryResult := <-s.CropReadQuery.FindByID(e.CropID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		cr, ok := queryResult.Result.(storage.CropRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		cropRead = &cr
+		cropRead.Status = domain.CropActive
+
+		for i, v := range cropRead.NurseryStages {
+			if v.UID == e.UID {
+				cropRead.NurseryStages[i].ActualTransplantDate = e.ActualTransplantDate
+			}
+		}
+
+	case domain.CropBatchPhotoProcessed:
+		queryResult := <-s.CropReadQuery.FindByID(e.CropUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		cr, ok := queryResult.Result.(storage.CropRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		cropRead = &cr
+
+		for i, v := range cropRead.Photos {
+			if v.UID == e.UID {
+				cropRead.Photos[i].ThumbnailFilename = e.ThumbnailFilename
+				cropRead.Photos[i].Width = e.Width
+				cropRead.Photos[i].Height = e.Height
+				cropRead.Photos[i].Status = e.Status
+			}
+		}
+
+	case domain.CropBatchPhotoProcessingFailed:
+		queryResult := <-s.CropReadQuery.FindByID(e.CropUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		cr, ok := queryResult.Result.(storage.CropRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		cropRead = &cr
+
+		for i, v := range cropRead.Photos {
+			if v.UID == e.UID {
+				cropRead.Photos[i].Status = e.Status
+			}
+		}
+
+	case domain.CropBatchPhotoProcessingRetried:
+		queryResult := <-s.CropReadQuery.FindByID(e.CropUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		cr, ok := queryResult.Result.(storage.CropRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		cropRead = &cr
+
+		for i, v := range cropRead.Photos {
+			if v.UID == e.UID {
+				cropRead.Photos[i].Status = e.Status
+			}
+		}
+
+	case domain.CropBatchPhotoRemoved:
+		queryResult := <-s.CropReadQuery.FindByID(e.CropUID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		cr, ok := queryResult.Result.(storage.CropRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		cropRead = &cr
+
+		photos := []storage.CropPhoto{}
+
+		for _, v := range cropRead.Photos {
+			if v.UID != e.UID {
+				photos = append(photos, v)
+			}
+		}
+
+		cropRead.Photos = photos
+
+	case domain.CropBatchShortCodeAssigned:
+		queryResult := <-s.CropReadQuery.FindByID(e.UID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		cr, ok := queryResult.Result.(storage.CropRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		cropRead = &cr
+		cropRead.ShortCode = e.ShortCode
+
+	case domain.CropBatchSeedsSownRecorded:
+		queryResult := <-s.CropReadQuery.FindByID(e.UID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		cr, ok := queryResult.Result.(storage.CropRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		cropRead = &cr
+		cropRead.SeedsSown = &e.SeedsSown
+		cropRead.GerminationRate = storage.GerminationRate(cropRead.SeedsSown, cropRead.GerminatedQuantity)
+
+	case domain.CropGerminationRecorded:
+		queryResult := <-s.CropReadQuery.FindByID(e.UID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		cr, ok := queryResult.Result.(storage.CropRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		cropRead = &cr
+		cropRead.GerminatedQuantity = &e.GerminatedQuantity
+		cropRead.GerminationDate = &e.GerminationDate
+		cropRead.GerminationRate = storage.GerminationRate(cropRead.SeedsSown, cropRead.GerminatedQuantity)
+
+	case domain.CropMergedFrom:
+		queryResult := <-s.CropReadQuery.FindByID(e.UID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		cr, ok := queryResult.Result.(storage.CropRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		cropRead = &cr
+		s.changeCropReadAreaQuantity(cropRead, e.AreaUID, e.Quantity, e.MergedDate)
+
+	case domain.CropMergedInto:
+		queryResult := <-s.CropReadQuery.FindByID(e.UID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		cr, ok := queryResult.Result.(storage.CropRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		cropRead = &cr
+		s.changeCropReadAreaQuantity(cropRead, e.AreaUID, -e.Quantity, e.MergedDate)
+		cropRead.Status = domain.CropArchived
+		cropRead.MergedIntoUID = &e.TargetCropUID
 	}
 
 	err := <-s.CropReadRepo.Save(cropRead)
@@ -525,6 +738,42 @@ func (s *GrowthServer) SaveToCropReadModel(event interface{}) error {
 	return nil
 }
 
+// changeCropReadAreaQuantity adds the quantity to the current quantity of the area of the crop
+// and to the plants of the crop in the seeding or growing areas.
+func (s *GrowthServer) changeCropReadAreaQuantity(cropRead *storage.CropRead, areaUID uuid.UUID, quantity int,
+	date time.Time,
+) {
+	queryResult := <-s.AreaReadQuery.FindByID(areaUID)
+	if queryResult.Error != nil {
+		log.Println(queryResult.Error)
+	}
+
+	area, ok := queryResult.Result.(query.CropAreaQueryResult)
+	if !ok {
+		log.Println(errors.New("internal server error. error type assertion"))
+	}
+
+	if cropRead.InitialArea.AreaUID == areaUID {
+		cropRead.InitialArea.CurrentQuantity += quantity
+		cropRead.InitialArea.LastUpdated = date
+	}
+
+	for i, v := range cropRead.MovedArea {
+		if v.AreaUID == areaUID {
+			cropRead.MovedArea[i].CurrentQuantity += quantity
+			cropRead.MovedArea[i].LastUpdated = date
+		}
+	}
+
+	if area.Type == "SEEDING" {
+		cropRead.AreaStatus.Seeding += quantity
+	}
+
+	if area.Type == "GROWING" {
+		cropRead.AreaStatus.Growing += quantity
+	}
+}
+
 func (s *GrowthServer) SaveToCropActivityReadModel(event interface{}) error {
 	cropActivity := &storage.CropActivity{}
 
@@ -677,6 +926,7 @@ func (s *GrowthServer) SaveToCropActivityReadModel(event interface{}) error {
 			Quantity:             e.HarvestedQuantity,
 			ProducedGramQuantity: e.ProducedGramQuantity,
 			HarvestDate:          e.HarvestDate,
+			Grades:               e.Grades,
 		}
 
 	case domain.CropBatchDumped:
@@ -712,6 +962,121 @@ func (s *GrowthServer) SaveToCropActivityReadModel(event interface{}) error {
 			DumpDate:    e.DumpDate,
 		}
 
+	case domain.CropGerminationRecorded:
+		queryResult := <-s.CropReadQuery.FindByID(e.UID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		cr, ok := queryResult.Result.(storage.CropRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		cropActivity.UID = e.UID
+		cropActivity.BatchID = e.BatchID
+		cropActivity.ContainerType = cr.Container.Type
+		cropActivity.CreatedDate = time.Now()
+		cropActivity.ActivityType = storage.GerminationActivity{
+			SeedsSown:          e.SeedsSown,
+			GerminatedQuantity: e.GerminatedQuantity,
+			GerminationRate:    float64(e.GerminatedQuantity) * 100 / float64(e.SeedsSown),
+			GerminationDate:    e.GerminationDate,
+		}
+
+	case domain.CropBatchWithholdingOverridden:
+		queryResult := <-s.CropReadQuery.FindByID(e.UID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		cr, ok := queryResult.Result.(storage.CropRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		cropActivity.UID = e.UID
+		cropActivity.BatchID = e.BatchID
+		cropActivity.ContainerType = cr.Container.Type
+		cropActivity.CreatedDate = e.OverriddenDate
+		cropActivity.Description = e.Reason
+		cropActivity.ActivityType = storage.WithholdingOverrideActivity{
+			SafeHarvestDate: e.SafeHarvestDate,
+			Reason:          e.Reason,
+			OverriddenBy:    e.OverriddenBy,
+		}
+
+	case domain.CropMergedFrom:
+		cr, area := s.findMergedCrop(e.UID, e.AreaUID)
+
+		cropActivity.UID = e.UID
+		cropActivity.BatchID = cr.BatchID
+		cropActivity.ContainerType = cr.Container.Type
+		cropActivity.CreatedDate = e.MergedDate
+		cropActivity.ActivityType = storage.MergeActivity{
+			SourceCropUID: e.SourceCropUID,
+			SourceBatchID: e.SourceBatchID,
+			TargetCropUID: e.UID,
+			TargetBatchID: cr.BatchID,
+			AreaUID:       area.UID,
+			AreaName:      area.Name,
+			Quantity:      e.Quantity,
+			MergedDate:    e.MergedDate,
+		}
+
+	case domain.CropMergedInto:
+		cr, area := s.findMergedCrop(e.UID, e.AreaUID)
+
+		cropActivity.UID = e.UID
+		cropActivity.BatchID = cr.BatchID
+		cropActivity.ContainerType = cr.Container.Type
+		cropActivity.CreatedDate = e.MergedDate
+		cropActivity.ActivityType = storage.MergeActivity{
+			SourceCropUID: e.UID,
+			SourceBatchID: cr.BatchID,
+			TargetCropUID: e.TargetCropUID,
+			TargetBatchID: e.TargetBatchID,
+			AreaUID:       area.UID,
+			AreaName:      area.Name,
+			Quantity:      e.Quantity,
+			MergedDate:    e.MergedDate,
+		}
+
+	case domain.CropTransferInitiated:
+		queryResult := <-s.CropReadQuery.FindByID(e.CropID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		cr, ok := queryResult.Result.(storage.CropRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		queryResult = <-s.FarmReadQuery.FindByID(e.ToFarmID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		toFarm, ok := queryResult.Result.(query.CropFarmQueryResult)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		cropActivity.UID = e.CropID
+		cropActivity.BatchID = cr.BatchID
+		cropActivity.ContainerType = cr.Container.Type
+		cropActivity.CreatedDate = e.TransferDate
+		cropActivity.ActivityType = storage.TransferActivity{
+			TransferUID:       e.TransferID,
+			FromFarmUID:       e.FromFarmID,
+			ToFarmUID:         e.ToFarmID,
+			ToFarmName:        toFarm.Name,
+			RecipientName:     e.RecipientName,
+			CertificateNumber: e.CertificateNumber,
+			TransferDate:      e.TransferDate,
+		}
+
 	case domain.CropBatchWatered:
 		cropActivity.UID = e.UID
 		cropActivity.BatchID = e.BatchID
@@ -737,7 +1102,12 @@ func (s *GrowthServer) SaveToCropActivityReadModel(event interface{}) error {
 		cropActivity.UID = e.CropUID
 		cropActivity.BatchID = cr.BatchID
 		cropActivity.ContainerType = cr.Container.Type
+		// The photo is logged when it was taken, which can be well before it was uploaded.
 		cropActivity.CreatedDate = time.Now()
+		if e.Metadata.DateTaken != nil {
+			cropActivity.CreatedDate = *e.Metadata.DateTaken
+		}
+
 		cropActivity.ActivityType = storage.PhotoActivity{
 			UID:         e.UID,
 			Filename:    e.Filename,
@@ -826,6 +1196,7 @@ func (s *GrowthServer) SaveToCropActivityReadModel(event interface{}) error {
 			case "PESTCONTROL":
 				cropActivity.ActivityType = storage.TaskPestControlActivity{
 					TaskUID:      e.UID,
+					MaterialUID:  taskQueryResult.MaterialUID,
 					MaterialType: materialQueryResult.PlantTypeCode,
 					MaterialName: materialQueryResult.Name,
 					AreaName:     areaQueryResult.Name,
@@ -849,6 +1220,11 @@ func (s *GrowthServer) SaveToCropActivityReadModel(event interface{}) error {
 	}
 
 	if cropActivity.UID != (uuid.UUID{}) {
+		// The activities of the completed tasks are triggered in the tasks module, their actor is in its history.
+		if _, ok := event.(taskevents.TaskCompleted); !ok {
+			cropActivity.Actor = s.findLatestCropEventActor(cropActivity.UID)
+		}
+
 		err := <-s.CropActivityRepo.Save(cropActivity, isUpdate)
 		if err != nil {
 			log.Println(err)
@@ -857,3 +1233,45 @@ func (s *GrowthServer) SaveToCropActivityReadModel(event interface{}) error {
 
 	return nil
 }
+
+// findLatestCropEventActor returns who triggered the latest event of the crop, already saved when it's published.
+func (s *GrowthServer) findLatestCropEventActor(cropUID uuid.UUID) *actor.Actor {
+	queryResult := <-s.CropEventQuery.FindAllByCropID(cropUID)
+	if queryResult.Error != nil {
+		log.Println(queryResult.Error)
+
+		return nil
+	}
+
+	events, ok := queryResult.Result.([]storage.CropEvent)
+	if !ok || len(events) == 0 {
+		return nil
+	}
+
+	return events[len(events)-1].Actor
+}
+
+// findMergedCrop returns the crop of a merge activity and the area its plants were merged in.
+func (s *GrowthServer) findMergedCrop(cropUID, areaUID uuid.UUID) (storage.CropRead, query.CropAreaQueryResult) {
+	queryResult := <-s.CropReadQuery.FindByID(cropUID)
+	if queryResult.Error != nil {
+		log.Println(queryResult.Error)
+	}
+
+	cr, ok := queryResult.Result.(storage.CropRead)
+	if !ok {
+		log.Println(errors.New("internal server error. error type assertion"))
+	}
+
+	queryResult = <-s.AreaReadQuery.FindByID(areaUID)
+	if queryResult.Error != nil {
+		log.Println(queryResult.Error)
+	}
+
+	area, ok := queryResult.Result.(query.CropAreaQueryResult)
+	if !ok {
+		log.Println(errors.New("internal server error. error type assertion"))
+	}
+
+	return cr, area
+}
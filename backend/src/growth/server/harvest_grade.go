@@ -0,0 +1,161 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
+)
+
+// parseHarvestGrades parses the optional grade breakdown of a harvest, a JSON list like
+// `[{"grade": "A", "quantity": 4.5}]` in the produced unit. The grades have to be defined by the farm.
+func (s *GrowthServer) parseHarvestGrades(value string, farmUID uuid.UUID) ([]domain.GradeQuantity, error) {
+	grades := []domain.GradeQuantity{}
+
+	if strings.TrimSpace(value) == "" {
+		return grades, nil
+	}
+
+	err := json.Unmarshal([]byte(value), &grades)
+	if err != nil {
+		return nil, NewRequestValidationError(ParseFailed, "grades")
+	}
+
+	farm, err := s.findCropFarm(farmUID)
+	if err != nil {
+		return nil, err
+	}
+
+	defined := map[string]bool{}
+	for _, v := range farm.HarvestGrades {
+		defined[v.Code] = true
+	}
+
+	for i, v := range grades {
+		grades[i].Grade = strings.ToUpper(strings.TrimSpace(v.Grade))
+
+		if !defined[grades[i].Grade] {
+			return nil, NewRequestValidationError(InvalidOption, "grades")
+		}
+	}
+
+	return grades, nil
+}
+
+// GetHarvestGradeReport returns the grade distribution of the harvests of the farm per variety and bucket of the
+// interval param, or of the older period param, WEEK or MONTH. The from and to dates are both included, all the
+// harvests are reported without them.
+func (s *GrowthServer) GetHarvestGradeReport(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	defaultInterval := timebuckethelper.IntervalMonth
+
+	if period := strings.ToUpper(c.QueryParam("period")); period != "" {
+		if period != domain.HarvestGradePeriodWeek && period != domain.HarvestGradePeriodMonth {
+			return Error(c, NewRequestValidationError(InvalidOption, "period"))
+		}
+
+		defaultInterval = domain.HarvestGradeInterval(period)
+	}
+
+	farm, err := s.findCropFarm(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	bucketQuery, err := parseBucketQuery(c, defaultInterval, farm.Seasons)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	crops, err := s.findAllFarmCrops(farm.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	report := domain.NewBucketedHarvestGradeReport(bucketQuery, farm.HarvestGrades)
+
+	for _, crop := range crops {
+		result := <-s.CropActivityQuery.FindAllByCropID(crop.UID)
+		if result.Error != nil {
+			return Error(c, result.Error)
+		}
+
+		activities, ok := result.Result.([]storage.CropActivity)
+		if !ok {
+			return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+		}
+
+		for _, v := range activities {
+			harvest, ok := v.ActivityType.(storage.HarvestActivity)
+			if !ok || !bucketQuery.Includes(harvest.HarvestDate) {
+				continue
+			}
+
+			report.Add(crop.Inventory.Name, harvest.HarvestDate, harvest.ProducedGramQuantity, harvest.Grades)
+		}
+	}
+
+	data := make(map[string]interface{})
+	data["data"] = report.Rows()
+	data["grades"] = farm.HarvestGrades
+	data["bucketing"] = bucketQuery
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *GrowthServer) findCropFarm(farmUID uuid.UUID) (query.CropFarmQueryResult, error) {
+	result := <-s.FarmReadQuery.FindByID(farmUID)
+	if result.Error != nil {
+		return query.CropFarmQueryResult{}, result.Error
+	}
+
+	farm, ok := result.Result.(query.CropFarmQueryResult)
+	if !ok {
+		return query.CropFarmQueryResult{}, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if farm.UID == (uuid.UUID{}) {
+		return query.CropFarmQueryResult{}, NewRequestValidationError(NotFound, "id")
+	}
+
+	return farm, nil
+}
+
+// findAllFarmCrops returns all the crops of the farm, the archived ones included.
+func (s *GrowthServer) findAllFarmCrops(farmUID uuid.UUID) ([]storage.CropRead, error) {
+	result := <-s.CropReadQuery.CountAllCropsByFarm(farmUID, "")
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	total, ok := result.Result.(int)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if total == 0 {
+		return []storage.CropRead{}, nil
+	}
+
+	result = <-s.CropReadQuery.FindAllCropsByFarm(farmUID, "", 1, total)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	crops, ok := result.Result.([]storage.CropRead)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return crops, nil
+}
@@ -0,0 +1,431 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/eventbus"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/shortcode"
+	"github.com/usetania/tania-core/src/units"
+)
+
+// HarvestLotTrace walks a lot back to the crop batch it was harvested from, with the areas the batch grew in
+// and the materials applied to it until the harvest, and forward to its shipments.
+type HarvestLotTrace struct {
+	Lot                  storage.HarvestLotRead `json:"lot"`
+	Batch                storage.CropRead       `json:"batch"`
+	AreaHistory          []CropActivity         `json:"area_history"`
+	MaterialApplications []CropActivity         `json:"material_applications"`
+	Harvest              *CropActivity          `json:"harvest"`
+	Shipments            []LotShipmentTrace     `json:"shipments"`
+}
+
+// LotShipmentTrace is a shipment of a lot with the dispatch which took it, nil when there was none.
+type LotShipmentTrace struct {
+	domain.LotShipment
+	Dispatch *storage.DispatchScheduleRead `json:"dispatch"`
+}
+
+// FindHarvestLots lists the lots of the farm, the latest harvest first. The status param only keeps the lots
+// in that status, and the crop_id one the lots harvested from that crop.
+func (s *GrowthServer) FindHarvestLots(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	farm, err := s.findCropFarm(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	status := strings.ToUpper(strings.TrimSpace(c.QueryParam("status")))
+
+	switch status {
+	case "", domain.HarvestLotStatusInStock, domain.HarvestLotStatusPartiallyShipped,
+		domain.HarvestLotStatusShipped, domain.HarvestLotStatusSold:
+	default:
+		return Error(c, NewRequestValidationError(InvalidOption, "status"))
+	}
+
+	cropUID := uuid.Nil
+
+	if value := c.QueryParam("crop_id"); value != "" {
+		cropUID, err = uuid.FromString(value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "crop_id"))
+		}
+	}
+
+	result := <-s.HarvestLotReadQuery.FindAllByFarm(farm.UID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	lots, ok := result.Result.([]storage.HarvestLotRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	found := []storage.HarvestLotRead{}
+
+	for _, v := range lots {
+		if (status == "" || v.Status == status) && (cropUID == uuid.Nil || v.CropUID == cropUID) {
+			found = append(found, v)
+		}
+	}
+
+	data := make(map[string][]storage.HarvestLotRead)
+	data["data"] = found
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *GrowthServer) FindHarvestLotByID(c echo.Context) error {
+	lot, err := s.findFarmHarvestLot(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.HarvestLotRead)
+	data["data"] = MapToHarvestLotRead(*lot)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// ShipHarvestLot ships or sells some of the quantity left in the lot, in the harvest unit of quantity_unit.
+// A lot split across several deliveries gets a shipment per delivery. The dispatch_id links the shipment to
+// a dispatch of the farm, and the sale_reference to the invoice or the order of the sale. The shipped_date is
+// in RFC3339, now by default.
+func (s *GrowthServer) ShipHarvestLot(c echo.Context) error {
+	lot, err := s.findFarmHarvestLot(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if c.FormValue("quantity") == "" {
+		return Error(c, NewRequestValidationError(Required, "quantity"))
+	}
+
+	quantity, err := strconv.ParseFloat(c.FormValue("quantity"), 64)
+	if err != nil {
+		return Error(c, NewRequestValidationError(Numeric, "quantity"))
+	}
+
+	gramQuantity, ok := units.Harvest().ToCanonical(c.FormValue("quantity_unit"), quantity)
+	if !ok {
+		return Error(c, NewRequestValidationError(InvalidOption, "quantity_unit"))
+	}
+
+	var dispatch *domain.DispatchSchedule
+
+	if value := c.FormValue("dispatch_id"); value != "" {
+		dispatchUID, err := uuid.FromString(value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "dispatch_id"))
+		}
+
+		dispatch, err = s.findDispatchScheduleFromHistory(dispatchUID)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		if dispatch.UID != dispatchUID {
+			return Error(c, NewRequestValidationError(NotFound, "dispatch_id"))
+		}
+	}
+
+	shippedDate := time.Now()
+
+	if value := c.FormValue("shipped_date"); value != "" {
+		shippedDate, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "shipped_date"))
+		}
+	}
+
+	// PROCESS //
+	_, err = lot.Ship(domain.LotDelivery{
+		Type:          strings.ToUpper(strings.TrimSpace(c.FormValue("type"))),
+		GramQuantity:  gramQuantity,
+		Customer:      c.FormValue("customer"),
+		SaleReference: c.FormValue("sale_reference"),
+		Dispatch:      dispatch,
+		Date:          shippedDate,
+	})
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.HarvestLotEventRepo.Save(lot.UID, lot.Version, lot.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(lot)
+
+	data := make(map[string]storage.HarvestLotRead)
+	data["data"] = MapToHarvestLotRead(*lot)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// TraceHarvestLot returns the trace of the lot, from the seeding of its batch to its deliveries.
+func (s *GrowthServer) TraceHarvestLot(c echo.Context) error {
+	lot, err := s.findFarmHarvestLot(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	result := <-s.CropReadQuery.FindByID(lot.CropUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	crop, ok := result.Result.(storage.CropRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	result = <-s.CropActivityQuery.FindAllByCropID(lot.CropUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	activities, ok := result.Result.([]storage.CropActivity)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	trace := HarvestLotTrace{
+		Lot:                  MapToHarvestLotRead(*lot),
+		Batch:                crop,
+		AreaHistory:          []CropActivity{},
+		MaterialApplications: []CropActivity{},
+		Shipments:            []LotShipmentTrace{},
+	}
+
+	sort.Slice(activities, func(i, j int) bool {
+		return activities[i].CreatedDate.Before(activities[j].CreatedDate)
+	})
+
+	// The activities are recorded after the harvest they follow, the ones of the harvest of the lot included,
+	// so the history of the batch ends at the activity of that harvest.
+	harvestDate := lot.HarvestDate.Truncate(time.Second)
+	until := harvestDate
+
+	for i, v := range activities {
+		harvest, ok := v.ActivityType.(storage.HarvestActivity)
+		if ok && harvest.HarvestDate.Truncate(time.Second).Equal(harvestDate) {
+			activity := MapToCropActivity(activities[i])
+			trace.Harvest = &activity
+			until = v.CreatedDate.Truncate(time.Second)
+
+			break
+		}
+	}
+
+	for i, v := range activities {
+		if v.CreatedDate.Truncate(time.Second).After(until) {
+			break
+		}
+
+		switch v.ActivityType.(type) {
+		case storage.SeedActivity, storage.MoveActivity:
+			trace.AreaHistory = append(trace.AreaHistory, MapToCropActivity(activities[i]))
+		case storage.TaskNutrientActivity, storage.TaskPestControlActivity:
+			trace.MaterialApplications = append(trace.MaterialApplications, MapToCropActivity(activities[i]))
+		}
+	}
+
+	for _, v := range lot.Shipments {
+		shipment := LotShipmentTrace{LotShipment: v}
+
+		if v.DispatchID != nil {
+			result := <-s.DispatchScheduleReadQuery.FindByID(*v.DispatchID)
+			if result.Error != nil {
+				return Error(c, result.Error)
+			}
+
+			dispatch, ok := result.Result.(storage.DispatchScheduleRead)
+			if !ok {
+				return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+			}
+
+			if dispatch.UID != uuid.Nil {
+				shipment.Dispatch = &dispatch
+			}
+		}
+
+		trace.Shipments = append(trace.Shipments, shipment)
+	}
+
+	data := make(map[string]HarvestLotTrace)
+	data["data"] = trace
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// findFarmHarvestLot finds the lot of the lot_id param in its history.
+func (s *GrowthServer) findFarmHarvestLot(c echo.Context) (*domain.HarvestLot, error) {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return nil, NewRequestValidationError(NotFound, "id")
+	}
+
+	lotUID, err := uuid.FromString(c.Param("lot_id"))
+	if err != nil {
+		return nil, NewRequestValidationError(NotFound, "lot_id")
+	}
+
+	lot, err := s.findHarvestLotFromHistory(lotUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if lot.UID != lotUID || lot.FarmUID != farmUID {
+		return nil, NewRequestValidationError(NotFound, "lot_id")
+	}
+
+	return lot, nil
+}
+
+func (s *GrowthServer) findHarvestLotFromHistory(uid uuid.UUID) (*domain.HarvestLot, error) {
+	result := <-s.HarvestLotEventQuery.FindAllByID(uid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	events, ok := result.Result.([]storage.HarvestLotEvent)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return repository.NewHarvestLotFromHistory(events), nil
+}
+
+func MapToHarvestLotRead(lot domain.HarvestLot) storage.HarvestLotRead {
+	shipments := lot.Shipments
+	if shipments == nil {
+		shipments = []domain.LotShipment{}
+	}
+
+	return storage.HarvestLotRead{
+		UID:          lot.UID,
+		FarmUID:      lot.FarmUID,
+		LotNumber:    lot.LotNumber,
+		CropUID:      lot.CropUID,
+		BatchID:      lot.BatchID,
+		AreaUID:      lot.AreaUID,
+		HarvestDate:  lot.HarvestDate,
+		Grade:        lot.Grade,
+		GramQuantity: lot.GramQuantity,
+		Remaining:    lot.RemainingGramQuantity(),
+		Status:       lot.Status(),
+		Shipments:    shipments,
+		CreatedDate:  lot.CreatedDate,
+	}
+}
+
+func (s *GrowthServer) SaveToHarvestLotReadModel(event interface{}) error {
+	var uid uuid.UUID
+
+	switch e := event.(type) {
+	case domain.HarvestLotCreated:
+		uid = e.UID
+	case domain.HarvestLotShipped:
+		uid = e.UID
+	default:
+		return errors.New("unknown harvest lot event")
+	}
+
+	lot, err := s.findHarvestLotFromHistory(uid)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	lotRead := MapToHarvestLotRead(*lot)
+
+	err = <-s.HarvestLotReadRepo.Save(&lotRead)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	return nil
+}
+
+// CreateHarvestLots fills a lot, numbered in the farm of the crop, with each grade of the harvest.
+// A harvest recorded without grades fills a single lot.
+func (s *GrowthServer) CreateHarvestLots(event interface{}) error {
+	e, ok := event.(domain.CropBatchHarvested)
+	if !ok {
+		return errors.New("unknown crop event")
+	}
+
+	crop, err := s.findCropFromHistory(e.UID)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	if crop.UID != e.UID {
+		return nil
+	}
+
+	source := domain.HarvestLotSource{
+		FarmUID:     crop.FarmUID,
+		CropUID:     crop.UID,
+		BatchID:     crop.BatchID,
+		AreaUID:     e.UpdatedHarvestedStorage.SourceAreaUID,
+		HarvestDate: e.HarvestDate,
+	}
+
+	for _, v := range domain.HarvestLotContents(e) {
+		if v.GramQuantity <= 0 {
+			continue
+		}
+
+		lotNumber, err := s.ShortCodeGenerator.Next(shortcode.HarvestLotPrefix, crop.FarmUID)
+		if err != nil {
+			log.Println(err)
+
+			return err
+		}
+
+		lot, err := domain.CreateHarvestLot(source, lotNumber, v.Grade, float64(v.GramQuantity), time.Now())
+		if err != nil {
+			log.Println(err)
+
+			return err
+		}
+
+		err = <-s.HarvestLotEventRepo.Save(lot.UID, 0, lot.UncommittedChanges, actor.System("harvest_lot"))
+		if err != nil {
+			log.Println(err)
+
+			return err
+		}
+
+		eventbus.PublishFromHandler(func() { s.publishUncommittedEvents(lot) })
+	}
+
+	return nil
+}
@@ -0,0 +1,411 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/eventbus"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+	taskevents "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+// inputScheduleInterval is how often the due input schedules are checked.
+const inputScheduleInterval = time.Hour
+
+// InputScheduleTaskCreator creates the task of a due input schedule and returns its ID.
+// It's implemented by the tasks module and injected after both servers are created.
+type InputScheduleTaskCreator interface {
+	CreateInputScheduleTask(schedule domain.CropInputSchedule) (uuid.UUID, error)
+}
+
+// StartInputScheduler creates the tasks of the due input schedules right away and then every hour.
+// The runs are skipped while paused is true, during the maintenance mode.
+func (s *GrowthServer) StartInputScheduler(creator InputScheduleTaskCreator, paused func() bool) {
+	s.InputScheduleTaskCreator = creator
+
+	ticker := time.NewTicker(inputScheduleInterval)
+
+	go func() {
+		for {
+			if paused() {
+				log.Println("Input scheduler paused by the maintenance mode")
+			} else {
+				s.createDueInputScheduleTasks(time.Now())
+			}
+
+			<-ticker.C
+		}
+	}()
+}
+
+func (s *GrowthServer) createDueInputScheduleTasks(now time.Time) {
+	result := <-s.CropInputScheduleReadQuery.FindAllDue(now)
+	if result.Error != nil {
+		log.Println("Input schedule check failed", result.Error)
+
+		return
+	}
+
+	schedules, ok := result.Result.([]storage.CropInputScheduleRead)
+	if !ok {
+		log.Println(errors.New("internal server error. error type assertion"))
+
+		return
+	}
+
+	for _, v := range schedules {
+		schedule, err := s.findInputScheduleFromHistory(v.UID)
+		if err != nil {
+			log.Println("Input schedule", v.UID, "cannot be loaded", err)
+
+			continue
+		}
+
+		if !schedule.IsDue(now) {
+			continue
+		}
+
+		taskUID, err := s.InputScheduleTaskCreator.CreateInputScheduleTask(*schedule)
+		if err != nil {
+			log.Println("Task of input schedule", v.UID, "cannot be created", err)
+
+			continue
+		}
+
+		err = schedule.AttachTask(taskUID)
+		if err != nil {
+			log.Println(err)
+
+			continue
+		}
+
+		err = <-s.CropInputScheduleEventRepo.Save(
+			schedule.UID, schedule.Version, schedule.UncommittedChanges, actor.System("input_scheduler"),
+		)
+		if err != nil {
+			log.Println(err)
+
+			continue
+		}
+
+		s.publishUncommittedEvents(schedule)
+	}
+}
+
+func (s *GrowthServer) FindCropInputSchedule(c echo.Context) error {
+	cropRead, err := s.findFarmCrop(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	result := <-s.CropInputScheduleReadQuery.FindAllByCropID(cropRead.UID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	schedules, ok := result.Result.([]storage.CropInputScheduleRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusBadRequest, "Internal server error"))
+	}
+
+	// Only the upcoming applications are listed, the applied ones are in the crop activities.
+	upcoming := []storage.CropInputScheduleRead{}
+
+	for _, v := range schedules {
+		if v.Status != domain.InputScheduleStatusApplied {
+			upcoming = append(upcoming, v)
+		}
+	}
+
+	data := make(map[string][]storage.CropInputScheduleRead)
+	data["data"] = upcoming
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *GrowthServer) SaveCropInputSchedule(c echo.Context) error {
+	cropRead, err := s.findFarmCrop(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	input, err := parseInputScheduleForm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	crop, err := s.findCropFromHistory(cropRead.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	schedule, err := domain.CreateCropInputSchedule(
+		s.CropService, *crop,
+		input.MaterialUID, input.PlannedDate, input.Quantity, input.Unit, input.ApplicationMethod)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.CropInputScheduleEventRepo.Save(schedule.UID, 0, schedule.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(schedule)
+
+	data := make(map[string]storage.CropInputScheduleRead)
+	data["data"] = MapToCropInputScheduleRead(*schedule)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *GrowthServer) UpdateCropInputSchedule(c echo.Context) error {
+	cropRead, err := s.findFarmCrop(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	scheduleUID, err := uuid.FromString(c.Param("schedule_id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "schedule_id"))
+	}
+
+	input, err := parseInputScheduleForm(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	schedule, err := s.findInputScheduleFromHistory(scheduleUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if schedule.UID != scheduleUID || schedule.CropID != cropRead.UID {
+		return Error(c, NewRequestValidationError(NotFound, "schedule_id"))
+	}
+
+	err = schedule.Modify(
+		s.CropService,
+		input.MaterialUID, input.PlannedDate, input.Quantity, input.Unit, input.ApplicationMethod)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.CropInputScheduleEventRepo.Save(
+		schedule.UID, schedule.Version, schedule.UncommittedChanges, actor.FromContext(c),
+	)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(schedule)
+
+	data := make(map[string]storage.CropInputScheduleRead)
+	data["data"] = MapToCropInputScheduleRead(*schedule)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+type inputScheduleForm struct {
+	MaterialUID       uuid.UUID
+	PlannedDate       time.Time
+	Quantity          float64
+	Unit              string
+	ApplicationMethod string
+}
+
+func parseInputScheduleForm(c echo.Context) (inputScheduleForm, error) {
+	form := inputScheduleForm{
+		Unit:              c.FormValue("unit"),
+		ApplicationMethod: c.FormValue("application_method"),
+	}
+
+	materialUID, err := uuid.FromString(c.FormValue("material_id"))
+	if err != nil {
+		return inputScheduleForm{}, NewRequestValidationError(Required, "material_id")
+	}
+
+	form.MaterialUID = materialUID
+
+	plannedDate := c.FormValue("planned_date")
+	if plannedDate == "" {
+		return inputScheduleForm{}, NewRequestValidationError(Required, "planned_date")
+	}
+
+	form.PlannedDate, err = time.Parse("2006-01-02", plannedDate)
+	if err != nil {
+		return inputScheduleForm{}, NewRequestValidationError(ParseFailed, "planned_date")
+	}
+
+	form.Quantity, err = strconv.ParseFloat(c.FormValue("quantity"), 64)
+	if err != nil {
+		return inputScheduleForm{}, NewRequestValidationError(Float, "quantity")
+	}
+
+	return form, nil
+}
+
+// findFarmCrop finds the crop of the crop_id param and checks it belongs to the farm of the id param.
+func (s *GrowthServer) findFarmCrop(c echo.Context) (storage.CropRead, error) {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return storage.CropRead{}, NewRequestValidationError(NotFound, "id")
+	}
+
+	cropUID, err := s.parseCropUID(c, "crop_id")
+	if err != nil {
+		return storage.CropRead{}, NewRequestValidationError(NotFound, "crop_id")
+	}
+
+	result := <-s.CropReadQuery.FindByID(cropUID)
+	if result.Error != nil {
+		return storage.CropRead{}, result.Error
+	}
+
+	cropRead, ok := result.Result.(storage.CropRead)
+	if !ok {
+		return storage.CropRead{}, echo.NewHTTPError(http.StatusBadRequest, "Internal server error")
+	}
+
+	if cropRead.UID == (uuid.UUID{}) || cropRead.FarmUID != farmUID {
+		return storage.CropRead{}, NewRequestValidationError(NotFound, "crop_id")
+	}
+
+	return cropRead, nil
+}
+
+func (s *GrowthServer) findInputScheduleFromHistory(uid uuid.UUID) (*domain.CropInputSchedule, error) {
+	result := <-s.CropInputScheduleEventQuery.FindAllByID(uid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	events, ok := result.Result.([]storage.CropInputScheduleEvent)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Internal server error")
+	}
+
+	return repository.NewCropInputScheduleFromHistory(events), nil
+}
+
+func MapToCropInputScheduleRead(schedule domain.CropInputSchedule) storage.CropInputScheduleRead {
+	return storage.CropInputScheduleRead{
+		UID:               schedule.UID,
+		CropUID:           schedule.CropID,
+		MaterialUID:       schedule.MaterialID,
+		PlannedDate:       schedule.PlannedDate,
+		Quantity:          schedule.Quantity,
+		Unit:              schedule.Unit,
+		ApplicationMethod: schedule.ApplicationMethod,
+		Status:            schedule.Status,
+		TaskUID:           schedule.TaskID,
+		AppliedDate:       schedule.AppliedDate,
+		CreatedDate:       schedule.CreatedDate,
+	}
+}
+
+func (s *GrowthServer) SaveToCropInputScheduleReadModel(event interface{}) error {
+	var uid uuid.UUID
+
+	switch e := event.(type) {
+	case domain.InputScheduleCreated:
+		uid = e.UID
+	case domain.InputScheduleModified:
+		uid = e.UID
+	case domain.InputScheduleTaskCreated:
+		uid = e.UID
+	case domain.InputScheduleApplied:
+		uid = e.UID
+	default:
+		return errors.New("unknown input schedule event")
+	}
+
+	schedule, err := s.findInputScheduleFromHistory(uid)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	scheduleRead := MapToCropInputScheduleRead(*schedule)
+
+	err = <-s.CropInputScheduleReadRepo.Save(&scheduleRead)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	return nil
+}
+
+// MarkInputScheduleApplied marks the input schedule of a completed task as applied.
+//
+// TODO:
+// We cannot listen to this events without refer to the original struct.
+// This is considered as domain boundary leak.
+func (s *GrowthServer) MarkInputScheduleApplied(event interface{}) error {
+	e, ok := event.(taskevents.TaskCompleted)
+	if !ok {
+		return errors.New("unknown task event")
+	}
+
+	result := <-s.CropInputScheduleReadQuery.FindByTaskID(e.UID)
+	if result.Error != nil {
+		log.Println(result.Error)
+
+		return result.Error
+	}
+
+	scheduleRead, ok := result.Result.(storage.CropInputScheduleRead)
+	if !ok || scheduleRead.UID == (uuid.UUID{}) {
+		// Most of the tasks are not created from an input schedule.
+		return nil
+	}
+
+	schedule, err := s.findInputScheduleFromHistory(scheduleRead.UID)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	appliedDate := time.Now()
+	if e.CompletedDate != nil {
+		appliedDate = *e.CompletedDate
+	}
+
+	err = schedule.MarkApplied(appliedDate)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	err = <-s.CropInputScheduleEventRepo.Save(
+		schedule.UID, schedule.Version, schedule.UncommittedChanges, actor.System("input_schedule_completion"),
+	)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	eventbus.PublishFromHandler(func() { s.publishUncommittedEvents(schedule) })
+
+	return nil
+}
@@ -0,0 +1,576 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/eventbus"
+	"github.com/usetania/tania-core/src/featureflags"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/repository"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+// FindInsurancePolicies lists the policies of the farm, the crop_id param only keeps the ones insuring that crop.
+func (s *GrowthServer) FindInsurancePolicies(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	farm, err := s.findCropFarm(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	cropUID := uuid.Nil
+
+	if value := c.QueryParam("crop_id"); value != "" {
+		cropUID, err = uuid.FromString(value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "crop_id"))
+		}
+	}
+
+	policies, err := s.findFarmInsurancePolicies(farm.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	found := []storage.InsurancePolicyRead{}
+
+	for _, v := range policies {
+		if cropUID == uuid.Nil || containsUID(v.CropIDs, cropUID) {
+			found = append(found, v)
+		}
+	}
+
+	data := make(map[string][]storage.InsurancePolicyRead)
+	data["data"] = found
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *GrowthServer) FindInsurancePolicyByID(c echo.Context) error {
+	policy, err := s.findFarmInsurancePolicy(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]storage.InsurancePolicyRead)
+	data["data"] = MapToInsurancePolicyRead(*policy)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// SaveInsurancePolicy insures the crops of the comma separated crop_ids with the policy.
+// The start and the end dates are days, both included in the period of the policy.
+func (s *GrowthServer) SaveInsurancePolicy(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	farm, err := s.findCropFarm(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	details, err := parseInsurancePolicyDetails(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	insured, err := s.parseInsuredCrops(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	policy, err := domain.CreateInsurancePolicy(farm.UID, insured, details, time.Now())
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PERSIST //
+	err = <-s.InsurancePolicyEventRepo.Save(policy.UID, 0, policy.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(policy)
+
+	data := make(map[string]storage.InsurancePolicyRead)
+	data["data"] = MapToInsurancePolicyRead(*policy)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// UpdateInsurancePolicy replaces the insured crops and the details of the policy, with the params of its creation.
+func (s *GrowthServer) UpdateInsurancePolicy(c echo.Context) error {
+	policy, err := s.findFarmInsurancePolicy(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	details, err := parseInsurancePolicyDetails(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	insured, err := s.parseInsuredCrops(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	err = policy.Update(insured, details)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	return s.saveInsurancePolicy(c, policy)
+}
+
+func (s *GrowthServer) RemoveInsurancePolicy(c echo.Context) error {
+	policy, err := s.findFarmInsurancePolicy(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// PROCESS //
+	err = policy.Remove()
+	if err != nil {
+		return Error(c, err)
+	}
+
+	return s.saveInsurancePolicy(c, policy)
+}
+
+// FileInsuranceClaim files a claim for the loss of an insured crop. The loss_date is in RFC3339, now by default.
+func (s *GrowthServer) FileInsuranceClaim(c echo.Context) error {
+	policy, err := s.findFarmInsurancePolicy(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if c.FormValue("crop_id") == "" {
+		return Error(c, NewRequestValidationError(Required, "crop_id"))
+	}
+
+	cropUID, err := uuid.FromString(c.FormValue("crop_id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(ParseFailed, "crop_id"))
+	}
+
+	quantity := 0
+
+	if value := c.FormValue("quantity"); value != "" {
+		quantity, err = strconv.Atoi(value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(Numeric, "quantity"))
+		}
+	}
+
+	lossDate := time.Now()
+
+	if value := c.FormValue("loss_date"); value != "" {
+		lossDate, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "loss_date"))
+		}
+	}
+
+	// PROCESS //
+	_, err = policy.FileClaim(domain.InsuranceLoss{
+		CropUID:  cropUID,
+		Reason:   strings.ToUpper(strings.TrimSpace(c.FormValue("reason"))),
+		Quantity: quantity,
+		Notes:    c.FormValue("notes"),
+		Date:     lossDate,
+	})
+	if err != nil {
+		return Error(c, err)
+	}
+
+	return s.saveInsurancePolicy(c, policy)
+}
+
+// SettleInsuranceClaim settles the claim with the amount paid by the provider, in the currency of the policy.
+// The settled_date is in RFC3339, now by default.
+func (s *GrowthServer) SettleInsuranceClaim(c echo.Context) error {
+	policy, err := s.findFarmInsurancePolicy(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	claimUID, err := uuid.FromString(c.Param("claim_id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "claim_id"))
+	}
+
+	if c.FormValue("settled_amount") == "" {
+		return Error(c, NewRequestValidationError(Required, "settled_amount"))
+	}
+
+	amount, err := strconv.ParseFloat(c.FormValue("settled_amount"), 64)
+	if err != nil {
+		return Error(c, NewRequestValidationError(Numeric, "settled_amount"))
+	}
+
+	settledDate := time.Now()
+
+	if value := c.FormValue("settled_date"); value != "" {
+		settledDate, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "settled_date"))
+		}
+	}
+
+	// PROCESS //
+	err = policy.SettleClaim(claimUID, amount, settledDate)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	return s.saveInsurancePolicy(c, policy)
+}
+
+// GetInsuranceSummary sums the policies of the farm by currency, at the date param or today.
+func (s *GrowthServer) GetInsuranceSummary(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	farm, err := s.findCropFarm(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	date := time.Now()
+
+	if value := c.QueryParam("date"); value != "" {
+		date, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "date"))
+		}
+	}
+
+	policies, err := s.findFarmInsurancePolicies(farm.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	summary := domain.NewInsuranceSummary(date)
+
+	for _, v := range policies {
+		summary.AddPolicy(MapFromInsurancePolicyRead(v))
+	}
+
+	data := make(map[string][]domain.InsuranceSummaryRow)
+	data["data"] = summary.Rows()
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func parseInsurancePolicyDetails(c echo.Context) (domain.InsurancePolicyDetails, error) {
+	details := domain.InsurancePolicyDetails{
+		PolicyNumber: c.FormValue("policy_number"),
+		Provider:     c.FormValue("provider"),
+		Currency:     c.FormValue("currency"),
+	}
+
+	if c.FormValue("coverage_amount") == "" {
+		return domain.InsurancePolicyDetails{}, NewRequestValidationError(Required, "coverage_amount")
+	}
+
+	coverageAmount, err := strconv.ParseFloat(c.FormValue("coverage_amount"), 64)
+	if err != nil {
+		return domain.InsurancePolicyDetails{}, NewRequestValidationError(Numeric, "coverage_amount")
+	}
+
+	if c.FormValue("premium_amount") == "" {
+		return domain.InsurancePolicyDetails{}, NewRequestValidationError(Required, "premium_amount")
+	}
+
+	premiumAmount, err := strconv.ParseFloat(c.FormValue("premium_amount"), 64)
+	if err != nil {
+		return domain.InsurancePolicyDetails{}, NewRequestValidationError(Numeric, "premium_amount")
+	}
+
+	if c.FormValue("start_date") == "" {
+		return domain.InsurancePolicyDetails{}, NewRequestValidationError(Required, "start_date")
+	}
+
+	startDate, err := time.ParseInLocation("2006-01-02", c.FormValue("start_date"), time.Local)
+	if err != nil {
+		return domain.InsurancePolicyDetails{}, NewRequestValidationError(ParseFailed, "start_date")
+	}
+
+	if c.FormValue("end_date") == "" {
+		return domain.InsurancePolicyDetails{}, NewRequestValidationError(Required, "end_date")
+	}
+
+	endDate, err := time.ParseInLocation("2006-01-02", c.FormValue("end_date"), time.Local)
+	if err != nil {
+		return domain.InsurancePolicyDetails{}, NewRequestValidationError(ParseFailed, "end_date")
+	}
+
+	details.CoverageAmount = coverageAmount
+	details.PremiumAmount = premiumAmount
+	details.StartDate = startDate
+	details.EndDate = endDate
+
+	return details, nil
+}
+
+func (s *GrowthServer) parseInsuredCrops(c echo.Context) ([]domain.Crop, error) {
+	insured := []domain.Crop{}
+
+	for _, v := range strings.Split(c.FormValue("crop_ids"), ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		cropUID, err := uuid.FromString(v)
+		if err != nil {
+			return nil, NewRequestValidationError(ParseFailed, "crop_ids")
+		}
+
+		crop, err := s.findCropFromHistory(cropUID)
+		if err != nil {
+			return nil, err
+		}
+
+		if crop.UID != cropUID {
+			return nil, NewRequestValidationError(NotFound, "crop_ids")
+		}
+
+		insured = append(insured, *crop)
+	}
+
+	return insured, nil
+}
+
+// findFarmInsurancePolicy finds the policy of the policy_id param in its history, the removed ones aren't found.
+func (s *GrowthServer) findFarmInsurancePolicy(c echo.Context) (*domain.InsurancePolicy, error) {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return nil, NewRequestValidationError(NotFound, "id")
+	}
+
+	policyUID, err := uuid.FromString(c.Param("policy_id"))
+	if err != nil {
+		return nil, NewRequestValidationError(NotFound, "policy_id")
+	}
+
+	policy, err := s.findInsurancePolicyFromHistory(policyUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy.UID != policyUID || policy.FarmUID != farmUID || policy.IsRemoved {
+		return nil, NewRequestValidationError(NotFound, "policy_id")
+	}
+
+	return policy, nil
+}
+
+func (s *GrowthServer) findFarmInsurancePolicies(farmUID uuid.UUID) ([]storage.InsurancePolicyRead, error) {
+	result := <-s.InsurancePolicyReadQuery.FindAllByFarm(farmUID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	policies, ok := result.Result.([]storage.InsurancePolicyRead)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return policies, nil
+}
+
+func (s *GrowthServer) saveInsurancePolicy(c echo.Context, policy *domain.InsurancePolicy) error {
+	// PERSIST //
+	err := <-s.InsurancePolicyEventRepo.Save(policy.UID, policy.Version, policy.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(policy)
+
+	data := make(map[string]storage.InsurancePolicyRead)
+	data["data"] = MapToInsurancePolicyRead(*policy)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *GrowthServer) findInsurancePolicyFromHistory(uid uuid.UUID) (*domain.InsurancePolicy, error) {
+	result := <-s.InsurancePolicyEventQuery.FindAllByID(uid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	events, ok := result.Result.([]storage.InsurancePolicyEvent)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return repository.NewInsurancePolicyFromHistory(events), nil
+}
+
+func MapToInsurancePolicyRead(policy domain.InsurancePolicy) storage.InsurancePolicyRead {
+	return storage.InsurancePolicyRead{
+		UID:            policy.UID,
+		FarmUID:        policy.FarmUID,
+		PolicyNumber:   policy.PolicyNumber,
+		Provider:       policy.Provider,
+		CropIDs:        policy.CropIDs,
+		CoverageAmount: policy.CoverageAmount,
+		Currency:       policy.Currency,
+		PremiumAmount:  policy.PremiumAmount,
+		StartDate:      policy.StartDate,
+		EndDate:        policy.EndDate,
+		Claims:         policy.Claims,
+		IsDeleted:      policy.IsRemoved,
+		CreatedDate:    policy.CreatedDate,
+	}
+}
+
+// MapFromInsurancePolicyRead returns the policy of the read model, without its events.
+func MapFromInsurancePolicyRead(policyRead storage.InsurancePolicyRead) domain.InsurancePolicy {
+	return domain.InsurancePolicy{
+		UID:            policyRead.UID,
+		FarmUID:        policyRead.FarmUID,
+		PolicyNumber:   policyRead.PolicyNumber,
+		Provider:       policyRead.Provider,
+		CropIDs:        policyRead.CropIDs,
+		CoverageAmount: policyRead.CoverageAmount,
+		Currency:       policyRead.Currency,
+		PremiumAmount:  policyRead.PremiumAmount,
+		StartDate:      policyRead.StartDate,
+		EndDate:        policyRead.EndDate,
+		Claims:         policyRead.Claims,
+		IsRemoved:      policyRead.IsDeleted,
+		CreatedDate:    policyRead.CreatedDate,
+	}
+}
+
+func (s *GrowthServer) SaveToInsurancePolicyReadModel(event interface{}) error {
+	var uid uuid.UUID
+
+	switch e := event.(type) {
+	case domain.PolicyCreated:
+		uid = e.UID
+	case domain.PolicyUpdated:
+		uid = e.UID
+	case domain.PolicyRemoved:
+		uid = e.UID
+	case domain.ClaimFiled:
+		uid = e.UID
+	case domain.ClaimSettled:
+		uid = e.UID
+	default:
+		return errors.New("unknown insurance policy event")
+	}
+
+	policy, err := s.findInsurancePolicyFromHistory(uid)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	policyRead := MapToInsurancePolicyRead(*policy)
+
+	err = <-s.InsurancePolicyReadRepo.Save(&policyRead)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	return nil
+}
+
+// FileInsuranceClaimOfDump files a claim on every policy covering the dumped crop at the date of the dump.
+// No claim is filed while the crop insurance flag is off.
+func (s *GrowthServer) FileInsuranceClaimOfDump(event interface{}) error {
+	e, ok := event.(domain.CropBatchDumped)
+	if !ok {
+		return errors.New("unknown crop event")
+	}
+
+	if !s.Flags.Enabled(featureflags.CropInsurance) {
+		return nil
+	}
+
+	farmUID, err := s.farmOfCrop(e.UID)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	if farmUID == uuid.Nil {
+		return nil
+	}
+
+	policies, err := s.findFarmInsurancePolicies(farmUID)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	for _, v := range policies {
+		if !MapFromInsurancePolicyRead(v).Covers(e.UID, e.DumpDate) {
+			continue
+		}
+
+		policy, err := s.findInsurancePolicyFromHistory(v.UID)
+		if err != nil {
+			log.Println(err)
+
+			return err
+		}
+
+		_, err = policy.FileClaim(domain.InsuranceLoss{
+			CropUID:   e.UID,
+			Reason:    e.Reason,
+			Quantity:  e.Quantity,
+			Notes:     e.Notes,
+			Automatic: true,
+			Date:      e.DumpDate,
+		})
+		if err != nil {
+			log.Println(err)
+
+			return err
+		}
+
+		err = <-s.InsurancePolicyEventRepo.Save(
+			policy.UID, policy.Version, policy.UncommittedChanges, actor.System("insurance_claim"),
+		)
+		if err != nil {
+			log.Println(err)
+
+			return err
+		}
+
+		eventbus.PublishFromHandler(func() { s.publishUncommittedEvents(policy) })
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
+)
+
+// GetLossReport returns the plants dumped and the harvests graded as not sellable of the farm in every bucket
+// of the interval param, month by default. The from and to dates are both included, without them the buckets
+// run from the first loss until today.
+func (s *GrowthServer) GetLossReport(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	farm, err := s.findCropFarm(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	bucketQuery, err := parseBucketQuery(c, timebuckethelper.IntervalMonth, farm.Seasons)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	crops, err := s.findAllFarmCrops(farm.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	dumps := []storage.DumpActivity{}
+	harvests := []storage.HarvestActivity{}
+	earliest := time.Time{}
+
+	for _, crop := range crops {
+		result := <-s.CropActivityQuery.FindAllByCropID(crop.UID)
+		if result.Error != nil {
+			return Error(c, result.Error)
+		}
+
+		activities, ok := result.Result.([]storage.CropActivity)
+		if !ok {
+			return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+		}
+
+		for _, v := range activities {
+			var date time.Time
+
+			switch activity := v.ActivityType.(type) {
+			case storage.DumpActivity:
+				dumps = append(dumps, activity)
+				date = activity.DumpDate
+			case storage.HarvestActivity:
+				harvests = append(harvests, activity)
+				date = activity.HarvestDate
+			default:
+				continue
+			}
+
+			if earliest.IsZero() || date.Before(earliest) {
+				earliest = date
+			}
+		}
+	}
+
+	buckets, err := bucketQuery.Buckets(earliest, time.Now())
+	if err != nil {
+		return Error(c, NewRequestValidationError(InvalidOption, timebuckethelper.ParamInterval))
+	}
+
+	report := domain.NewLossReport(buckets, farm.HarvestGrades)
+
+	for _, v := range dumps {
+		report.AddDump(v.DumpDate, v.Quantity)
+	}
+
+	for _, v := range harvests {
+		report.AddHarvest(v.HarvestDate, v.ProducedGramQuantity, v.Grades)
+	}
+
+	data := make(map[string]interface{})
+	data["data"] = report.Rows()
+	data["bucketing"] = bucketQuery
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// parseBucketQuery parses the interval, timezone, week_start, from and to params of the analytics endpoints.
+func parseBucketQuery(c echo.Context, defaultInterval string, seasons []timebuckethelper.Season) (
+	timebuckethelper.Query, error,
+) {
+	return parseBucketParams(c.QueryParam, defaultInterval, seasons)
+}
+
+// parseBucketParams parses the bucketing params read by the param func, for the endpoints naming them differently.
+func parseBucketParams(param func(string) string, defaultInterval string, seasons []timebuckethelper.Season) (
+	timebuckethelper.Query, error,
+) {
+	bucketQuery, err := timebuckethelper.ParseQuery(param, defaultInterval, seasons)
+
+	paramErr := timebuckethelper.ParamError{}
+	if errors.As(err, &paramErr) {
+		if paramErr.Unknown {
+			return timebuckethelper.Query{}, NewRequestValidationError(InvalidOption, paramErr.Param)
+		}
+
+		return timebuckethelper.Query{}, NewRequestValidationError(ParseFailed, paramErr.Param)
+	}
+
+	return bucketQuery, err
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"log"
+
+	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/eventbus"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/helper/structhelper"
+)
+
+// DetectMicroclimateAnomaly compares the recorded sample with the last 24 hours of samples of its area, and
+// publishes the anomaly when its Z-score is above the `anomaly_z_threshold`.
+func (s *GrowthServer) DetectMicroclimateAnomaly(event interface{}) error {
+	sample, ok := event.(domain.MicroclimateSampleRecorded)
+	if !ok || config.Config.AnomalyZThreshold == nil {
+		return nil
+	}
+
+	anomaly, detected, err := domain.NewAnomalyDetector(s.MicroclimateSampleQuery, *config.Config.AnomalyZThreshold).
+		Detect(sample)
+	if err != nil {
+		log.Println("Microclimate anomaly of area", sample.AreaUID, "cannot be detected", err)
+
+		return nil
+	}
+
+	if !detected {
+		return nil
+	}
+
+	result := <-s.AreaReadQuery.FindByID(sample.AreaUID)
+	if area, ok := result.Result.(query.CropAreaQueryResult); result.Error == nil && ok {
+		anomaly.AreaName = area.Name
+		anomaly.FarmUID = area.FarmUID
+	}
+
+	eventbus.PublishFromHandler(func() { s.EventBus.Publish(structhelper.GetName(anomaly), anomaly) })
+
+	return nil
+}
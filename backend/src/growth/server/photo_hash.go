@@ -0,0 +1,87 @@
+package server
+
+import (
+	"log"
+	"mime/multipart"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/media"
+)
+
+// DuplicatePhotoError is an uploaded photo which looks like a photo the crop already has.
+type DuplicatePhotoError struct {
+	RequestValidationError
+	ExistingPhotoUID uuid.UUID `json:"existing_photo_id"`
+}
+
+// checkDuplicatePhotos hashes the uploaded photos and compares them with the photos of the crop, unless the upload
+// is forced. It returns the hashes to save with the new photos. A photo which can't be decoded has no hash,
+// it is left to the rest of the upload.
+func (s *GrowthServer) checkDuplicatePhotos(
+	cropUID uuid.UUID, field string, photos []*multipart.FileHeader, force bool,
+) ([]*uint64, error) {
+	existing, err := s.PhotoHashStore.FindAllByCrop(cropUID)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := []*uint64{}
+
+	for _, v := range photos {
+		hash := s.hashPhoto(v)
+
+		if hash != nil && !force {
+			if duplicate := s.PhotoHasher.FindDuplicate(*hash, existing); duplicate != nil {
+				rve := NewRequestValidationError(DuplicatePhoto, field)
+				rve.ErrorMessage = "duplicate photo detected, existing_photo_id=" + duplicate.PhotoUID.String()
+
+				return nil, DuplicatePhotoError{RequestValidationError: rve, ExistingPhotoUID: duplicate.PhotoUID}
+			}
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+func (s *GrowthServer) hashPhoto(photo *multipart.FileHeader) *uint64 {
+	file, err := photo.Open()
+	if err != nil {
+		log.Println(err)
+
+		return nil
+	}
+
+	defer file.Close()
+
+	hash, err := s.PhotoHasher.Hash(file)
+	if err != nil {
+		log.Println("Photo", photo.Filename, "cannot be hashed", err)
+
+		return nil
+	}
+
+	return &hash
+}
+
+// savePhotoHashes saves the hashes of the photos once they are added to the crop. The photos are already stored,
+// a hash which can't be saved only leaves the photo out of the next checks.
+func (s *GrowthServer) savePhotoHashes(cropUID uuid.UUID, photoUIDs []uuid.UUID, hashes []*uint64) {
+	for i, v := range photoUIDs {
+		if i >= len(hashes) || hashes[i] == nil {
+			continue
+		}
+
+		err := s.PhotoHashStore.Save(media.PhotoHash{
+			CropUID:     cropUID,
+			PhotoUID:    v,
+			Hash:        *hashes[i],
+			CreatedDate: time.Now(),
+		})
+		if err != nil {
+			log.Println("Hash of photo", v, "cannot be saved", err)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"log"
+	"mime/multipart"
+
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/media"
+)
+
+// extractPhotoMetadata reads the EXIF metadata of the uploaded photo. A photo whose metadata can't be read,
+// like one with a corrupted EXIF block, is still stored, without metadata.
+func extractPhotoMetadata(photo *multipart.FileHeader) domain.CropPhotoMetadata {
+	file, err := photo.Open()
+	if err != nil {
+		log.Println(err)
+
+		return domain.CropPhotoMetadata{}
+	}
+
+	defer file.Close()
+
+	metadata, err := media.ExtractPhotoMetadata(file)
+	if err != nil {
+		log.Println(err)
+
+		return domain.CropPhotoMetadata{}
+	}
+
+	return domain.CropPhotoMetadata(metadata)
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/helper/stringhelper"
+	"github.com/usetania/tania-core/src/orphans"
+)
+
+// RemoveCropPhoto removes the photo from the crop, then its files and its hash.
+func (s *GrowthServer) RemoveCropPhoto(c echo.Context) error {
+	cropRead, found, err := s.findCropPhoto(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	crop, err := s.removeCropPhoto(c, cropRead.UID, found.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// The files are removed once the lock, which the photo workers of every crop wait on, is released.
+	s.removeCropPhotoFiles(crop.UncommittedChanges)
+
+	data := make(map[string]storage.CropPhoto)
+	data["data"] = found
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// removeCropPhoto saves the removal of the photo under the lock of the photo processor.
+func (s *GrowthServer) removeCropPhoto(c echo.Context, cropUID, photoUID uuid.UUID) (*domain.Crop, error) {
+	// Process //
+	s.PhotoProcessor.Lock.Lock()
+	defer s.PhotoProcessor.Lock.Unlock()
+
+	crop, err := s.findCropFromHistory(cropUID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = crop.RemovePhoto(photoUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Persists //
+	resultSave := <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.FromContext(c))
+	if resultSave != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	// TRIGGER EVENTS //
+	s.publishUncommittedEvents(crop)
+
+	return crop, nil
+}
+
+// parsePurgePhotos reads the purge_photos query param of the requests which can archive a crop.
+func parsePurgePhotos(c echo.Context) (bool, error) {
+	purge := c.QueryParam("purge_photos")
+	if purge == "" {
+		return false, nil
+	}
+
+	purgePhotos, err := strconv.ParseBool(purge)
+	if err != nil {
+		return false, NewRequestValidationError(ParseFailed, "purge_photos")
+	}
+
+	return purgePhotos, nil
+}
+
+// purgeArchivedCropPhotos removes the photos of the crop when the change archived it.
+// Their files are removed with removeCropPhotoFiles, once the changes are saved.
+func purgeArchivedCropPhotos(crop *domain.Crop, purgePhotos bool) error {
+	if !purgePhotos || crop.Status.Code != domain.CropArchived {
+		return nil
+	}
+
+	for _, v := range crop.Photos {
+		err := crop.RemovePhoto(v.UID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeCropPhotoFiles removes the hashes and the files of the removed photos. The files still referenced,
+// by another photo uploaded with the same name, stay. What fails is left to the orphan cleanup.
+func (s *GrowthServer) removeCropPhotoFiles(changes []interface{}) {
+	filenames := []string{}
+
+	for _, v := range changes {
+		e, ok := v.(domain.CropBatchPhotoRemoved)
+		if !ok {
+			continue
+		}
+
+		err := s.PhotoHashStore.Remove(e.CropUID, e.UID)
+		if err != nil {
+			log.Println("Removing the hash of the crop photo", e.UID, "failed", err)
+		}
+
+		filenames = append(filenames, e.Filename, e.ThumbnailFilename)
+	}
+
+	if len(filenames) == 0 || s.PhotoReferences == nil {
+		return
+	}
+
+	unreferenced, err := orphans.Unreferenced(s.PhotoReferences, orphans.FolderCrops, filenames)
+	if err != nil {
+		log.Println("Finding the references of the removed crop photos failed", err)
+
+		return
+	}
+
+	for _, v := range unreferenced {
+		err := s.File.Remove(stringhelper.Join(*config.Config.UploadPathCrop, "/", v))
+		if err != nil {
+			log.Println("Removing the crop photo", v, "failed", err)
+		}
+	}
+}
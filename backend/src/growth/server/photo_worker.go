@@ -0,0 +1,236 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/helper/imagehelper"
+	"github.com/usetania/tania-core/src/helper/stringhelper"
+)
+
+const (
+	photoWorkerCount      = 4
+	photoQueueSize        = 100
+	photoRecordAttempts   = 3
+	thumbnailMaxDimension = 320
+	thumbnailFolder       = "thumbnails"
+)
+
+var (
+	ErrPhotoQueueFull = errors.New("the photo processing queue is full")
+
+	errCropPhotoConflict = errors.New("the crop changed while the result of its photo was recorded")
+)
+
+// CropPhotoJob is a crop photo waiting for its thumbnail to be built.
+type CropPhotoJob struct {
+	CropUID  uuid.UUID
+	PhotoUID uuid.UUID
+}
+
+// PhotoProcessor builds crop photo thumbnails in a pool of background workers.
+type PhotoProcessor struct {
+	Jobs chan CropPhotoJob
+
+	// Lock serializes the crop updates coming from the workers
+	// so two photos of the same crop don't race on its version.
+	Lock *sync.Mutex
+}
+
+func NewPhotoProcessor() *PhotoProcessor {
+	return &PhotoProcessor{
+		Jobs: make(chan CropPhotoJob, photoQueueSize),
+		Lock: &sync.Mutex{},
+	}
+}
+
+// StartPhotoWorkers runs the thumbnail workers until the job queue is closed.
+func (s *GrowthServer) StartPhotoWorkers(count int) {
+	for i := 0; i < count; i++ {
+		go func() {
+			for job := range s.PhotoProcessor.Jobs {
+				s.processCropPhoto(job)
+			}
+		}()
+	}
+}
+
+// QueueCropPhoto schedules the photo for processing without blocking the request,
+// or returns ErrPhotoQueueFull when the queue has no room left.
+func (s *GrowthServer) QueueCropPhoto(cropUID, photoUID uuid.UUID) error {
+	select {
+	case s.PhotoProcessor.Jobs <- CropPhotoJob{CropUID: cropUID, PhotoUID: photoUID}:
+		return nil
+	default:
+		return ErrPhotoQueueFull
+	}
+}
+
+// queueCropPhotos queues the photos of the crop just saved. The photos the full queue can't take are marked
+// failed, so they can be retried, and the crop is returned rebuilt with them. The caller holds the photo Lock.
+func (s *GrowthServer) queueCropPhotos(crop *domain.Crop, photoUIDs []uuid.UUID, by *actor.Actor) (
+	*domain.Crop, error,
+) {
+	failed := []uuid.UUID{}
+
+	for _, v := range photoUIDs {
+		if errors.Is(s.QueueCropPhoto(crop.UID, v), ErrPhotoQueueFull) {
+			log.Println("Failed queueing crop photo", v, ErrPhotoQueueFull)
+
+			failed = append(failed, v)
+		}
+	}
+
+	if len(failed) == 0 {
+		return crop, nil
+	}
+
+	crop, err := s.findCropFromHistory(crop.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range failed {
+		err = crop.MarkPhotoProcessingFailed(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, by)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishUncommittedEvents(crop)
+
+	return crop, nil
+}
+
+// requeuePendingPhotos queues the photos still pending when the server stopped, their jobs were lost with it.
+func (s *GrowthServer) requeuePendingPhotos() {
+	result := <-s.CropReadQuery.FindAllPendingPhotos()
+	if result.Error != nil {
+		log.Println(result.Error)
+
+		return
+	}
+
+	photos, ok := result.Result.([]query.CropPendingPhotoQueryResult)
+	if !ok {
+		log.Println(errors.New("internal server error. error type assertion"))
+
+		return
+	}
+
+	// The workers take the jobs beyond the size of the queue as they go.
+	for _, v := range photos {
+		s.PhotoProcessor.Jobs <- CropPhotoJob{CropUID: v.CropUID, PhotoUID: v.PhotoUID}
+	}
+}
+
+func (s *GrowthServer) processCropPhoto(job CropPhotoJob) {
+	crop, err := s.findCropFromHistory(job.CropUID)
+	if err != nil {
+		log.Println(err)
+
+		return
+	}
+
+	photo, err := crop.FindPhotoByID(job.PhotoUID)
+	if err != nil {
+		log.Println(err)
+
+		return
+	}
+
+	srcPath := stringhelper.Join(*config.Config.UploadPathCrop, "/", photo.Filename)
+	thumbnailFilename := stringhelper.Join(thumbnailFolder, "/", photo.UID.String(), ".jpg")
+	destPath := stringhelper.Join(*config.Config.UploadPathCrop, "/", thumbnailFilename)
+
+	width, height, thumbnailErr := imagehelper.CreateThumbnail(srcPath, destPath, thumbnailMaxDimension)
+	if thumbnailErr != nil {
+		log.Println("Failed processing crop photo", photo.UID, thumbnailErr)
+	}
+
+	record := func(crop *domain.Crop) error {
+		if thumbnailErr != nil {
+			return crop.MarkPhotoProcessingFailed(photo.UID)
+		}
+
+		return crop.MarkPhotoProcessed(photo.UID, thumbnailFilename, width, height)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = s.recordCropPhoto(job, record)
+		if err == nil {
+			return
+		}
+
+		if attempt == photoRecordAttempts {
+			log.Println("Failed recording crop photo", photo.UID, err)
+
+			return
+		}
+	}
+}
+
+// recordCropPhoto records the result of the processing of the photo on the crop rebuilt under the photo Lock.
+// The other crop commands don't take the Lock, so the crop may still change meanwhile: the result isn't saved
+// when the version of the crop moved, and it's a conflict too when the read model of the crop, saved by
+// the other command, still has the photo pending. Both are recorded again by the next attempt.
+func (s *GrowthServer) recordCropPhoto(job CropPhotoJob, record func(crop *domain.Crop) error) error {
+	s.PhotoProcessor.Lock.Lock()
+	defer s.PhotoProcessor.Lock.Unlock()
+
+	crop, err := s.findCropFromHistory(job.CropUID)
+	if err != nil {
+		return err
+	}
+
+	err = record(crop)
+	if err != nil {
+		return err
+	}
+
+	latest, err := s.findCropFromHistory(job.CropUID)
+	if err != nil {
+		return err
+	}
+
+	if latest.Version != crop.Version {
+		return errCropPhotoConflict
+	}
+
+	err = <-s.CropEventRepo.Save(crop.UID, crop.Version, crop.UncommittedChanges, actor.System("photo_processing"))
+	if err != nil {
+		return err
+	}
+
+	s.publishUncommittedEvents(crop)
+
+	result := <-s.CropReadQuery.FindByID(job.CropUID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	cropRead, ok := result.Result.(storage.CropRead)
+	if !ok {
+		return errors.New("internal server error. error type assertion")
+	}
+
+	for _, v := range cropRead.Photos {
+		if v.UID == job.PhotoUID && v.Status == domain.CropPhotoStatusPending {
+			return errCropPhotoConflict
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package server_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/growth/server"
+)
+
+func TestQueueCropPhotoRefusesTheJobsOfAFullQueue(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	processor := server.NewPhotoProcessor()
+	processor.Jobs = make(chan server.CropPhotoJob, 1)
+	growthServer := &server.GrowthServer{PhotoProcessor: processor}
+
+	cropUID, _ := uuid.NewV4()
+	firstUID, _ := uuid.NewV4()
+	secondUID, _ := uuid.NewV4()
+
+	// When
+	firstErr := growthServer.QueueCropPhoto(cropUID, firstUID)
+	secondErr := growthServer.QueueCropPhoto(cropUID, secondUID)
+
+	// Then
+	assert.Nil(t, firstErr)
+	assert.ErrorIs(t, secondErr, server.ErrPhotoQueueFull)
+	assert.Equal(t, server.CropPhotoJob{CropUID: cropUID, PhotoUID: firstUID}, <-processor.Jobs)
+}
@@ -10,18 +10,25 @@ import (
 	"strings"
 
 	"github.com/labstack/echo/v4"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/farmscope"
 	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
 )
 
 const (
-	Required      = "REQUIRED"
-	Alphanumeric  = "ALPHANUMERIC"
-	Alpha         = "ALPHA"
-	Numeric       = "NUMERIC"
-	Float         = "FLOAT"
-	ParseFailed   = "PARSE_FAILED"
-	InvalidOption = "INVALID_OPTION"
-	NotFound      = "NOT_FOUND"
+	Required       = "REQUIRED"
+	Alphanumeric   = "ALPHANUMERIC"
+	Alpha          = "ALPHA"
+	Numeric        = "NUMERIC"
+	Float          = "FLOAT"
+	ParseFailed    = "PARSE_FAILED"
+	InvalidOption  = "INVALID_OPTION"
+	NotFound       = "NOT_FOUND"
+	ColdStorage    = "ARCHIVED_TO_COLD_STORAGE"
+	DuplicatePhoto = "DUPLICATE_PHOTO"
+
+	SanitationRequired = "SANITATION_REQUIRED"
 )
 
 // RequestValidation sanitizes request inputs and convert the input to its correct data type.
@@ -66,6 +73,12 @@ func Message(errorCode string) string {
 		return "This value is not available in options. Please give the correct options."
 	case NotFound:
 		return "Data not found."
+	case ColdStorage:
+		return "The history of this data was archived to cold storage."
+	case DuplicatePhoto:
+		return "Duplicate photo detected."
+	case SanitationRequired:
+		return "The last crop of the area was dumped for disease. Record a sanitation of the area first."
 	default:
 		return "Internal server error"
 	}
@@ -93,7 +106,7 @@ func Error(c echo.Context, err error) error {
 
 	log.Printf(
 		"user_uid: %v\nrequest_id: %v\nfile: %v\nline: %v\n",
-		c.Get("USER_UID"),
+		c.Get(farmscope.UserKey),
 		c.Response().Header().Get(echo.HeaderXRequestID),
 		file,
 		line,
@@ -109,6 +122,26 @@ func Error(c echo.Context, err error) error {
 		return c.JSON(http.StatusBadRequest, errorResponse)
 	}
 
+	var cfe assetsdomain.CustomFieldError
+	if errors.As(err, &cfe) {
+		errorResponse["field_name"] = cfe.FieldKey
+		errorResponse["error_code"] = strconv.Itoa(cfe.Code)
+
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	var dpe DuplicatePhotoError
+	if errors.As(err, &dpe) {
+		return c.JSON(http.StatusConflict, dpe)
+	}
+
+	var re query.ReplayingError
+	if errors.As(err, &re) {
+		c.Response().Header().Set("Retry-After", replayRetryAfter)
+
+		return c.JSON(http.StatusServiceUnavailable, errorResponse)
+	}
+
 	var rve RequestValidationError
 	if errors.As(err, &rve) {
 		errorResponse["field_name"] = rve.FieldName
@@ -118,7 +151,8 @@ func Error(c echo.Context, err error) error {
 		return c.JSON(http.StatusBadRequest, rve)
 	}
 
-	return c.JSON(http.StatusInternalServerError, errorResponse)
+	// The other errors are answered by the error handler of the server, with their detail only when verbose.
+	return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error").SetInternal(err)
 }
 
 func getFileAndLineNumber() (string, int) {
@@ -11,11 +11,13 @@ import (
 	"github.com/usetania/tania-core/src/growth/domain"
 	"github.com/usetania/tania-core/src/growth/query"
 	"github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/helper/expandhelper"
 )
 
 type CropListInArea struct {
 	UID              uuid.UUID       `json:"uid"`
 	BatchID          string          `json:"batch_id"`
+	ShortCode        string          `json:"short_code"`
 	DaysSinceSeeding int             `json:"days_since_seeding"`
 	InitialQuantity  int             `json:"initial_quantity"`
 	CurrentQuantity  int             `json:"current_quantity"`
@@ -27,6 +29,17 @@ type CropListInArea struct {
 	Inventory        query.Inventory `json:"inventory"`
 }
 
+// CropDetail is the crop with the values of its custom fields.
+type CropDetail struct {
+	storage.CropRead
+	CustomFields map[string]interface{} `json:"custom_fields"`
+
+	// SafeHarvestDate is the end of the withholding period of the pesticide treatments, nil when there's none.
+	SafeHarvestDate *time.Time `json:"safe_harvest_date"`
+
+	Expanded map[string]expandhelper.Expansion `json:"expanded,omitempty"`
+}
+
 type InitialArea struct {
 	AreaUID uuid.UUID `json:"area_id"`
 	Name    string    `json:"name"`
@@ -63,6 +76,12 @@ type (
 	TaskSanitationActivity struct {
 		*storage.TaskSanitationActivity
 	}
+	GerminationActivity         struct{ *storage.GerminationActivity }
+	WithholdingOverrideActivity struct {
+		*storage.WithholdingOverrideActivity
+	}
+	MergeActivity    struct{ *storage.MergeActivity }
+	TransferActivity struct{ *storage.TransferActivity }
 )
 
 func MapToCropActivity(activity storage.CropActivity) CropActivity {
@@ -91,6 +110,14 @@ func MapToCropActivity(activity storage.CropActivity) CropActivity {
 		ca.ActivityType = TaskSanitationActivity{&v}
 	case storage.TaskSafetyActivity:
 		ca.ActivityType = TaskSafetyActivity{&v}
+	case storage.GerminationActivity:
+		ca.ActivityType = GerminationActivity{&v}
+	case storage.WithholdingOverrideActivity:
+		ca.ActivityType = WithholdingOverrideActivity{&v}
+	case storage.MergeActivity:
+		ca.ActivityType = MergeActivity{&v}
+	case storage.TransferActivity:
+		ca.ActivityType = TransferActivity{&v}
 	}
 
 	return ca
@@ -227,9 +254,33 @@ func MapToCropRead(s *GrowthServer, crop domain.Crop) (storage.CropRead, error)
 		})
 	}
 
+	nurseryStages := []storage.NurseryStage{}
+
+	for _, v := range crop.NurseryStages {
+		queryResult = <-s.AreaReadQuery.FindByID(v.NurseryAreaUID)
+		if queryResult.Error != nil {
+			return storage.CropRead{}, queryResult.Error
+		}
+
+		area, ok := queryResult.Result.(query.CropAreaQueryResult)
+		if !ok {
+			return storage.CropRead{}, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		nurseryStages = append(nurseryStages, storage.NurseryStage{
+			UID:                    v.UID,
+			AreaUID:                area.UID,
+			AreaName:               area.Name,
+			StartDate:              v.StartDate,
+			ExpectedTransplantDate: v.ExpectedTransplantDate,
+			ActualTransplantDate:   v.ActualTransplantDate,
+		})
+	}
+
 	cropRead := storage.CropRead{}
 	cropRead.UID = crop.UID
 	cropRead.BatchID = crop.BatchID
+	cropRead.ShortCode = crop.ShortCode
 	cropRead.Status = crop.Status.Code
 	cropRead.Type = crop.Type.Code
 
@@ -260,13 +311,16 @@ func MapToCropRead(s *GrowthServer, crop domain.Crop) (storage.CropRead, error)
 
 	for _, v := range crop.Photos {
 		cropRead.Photos = append(cropRead.Photos, storage.CropPhoto{
-			UID:         v.UID,
-			Filename:    v.Filename,
-			MimeType:    v.MimeType,
-			Size:        v.Size,
-			Width:       v.Width,
-			Height:      v.Height,
-			Description: v.Description,
+			UID:               v.UID,
+			Filename:          v.Filename,
+			MimeType:          v.MimeType,
+			Size:              v.Size,
+			Width:             v.Width,
+			Height:            v.Height,
+			Description:       v.Description,
+			Status:            v.Status,
+			ThumbnailFilename: v.ThumbnailFilename,
+			Metadata:          v.Metadata,
 		})
 	}
 
@@ -308,6 +362,17 @@ func MapToCropRead(s *GrowthServer, crop domain.Crop) (storage.CropRead, error)
 	cropRead.MovedArea = movedAreas
 	cropRead.HarvestedStorage = harvestedStorage
 	cropRead.Trash = trash
+	cropRead.NurseryStages = nurseryStages
+
+	cropRead.SeedsSown = crop.SeedsSown
+	if crop.Germination != nil {
+		germination := *crop.Germination
+		cropRead.GerminatedQuantity = &germination.Quantity
+		cropRead.GerminationDate = &germination.Date
+	}
+
+	cropRead.GerminationRate = domain.GerminationRate(crop.SeedsSown, crop.Germination)
+	cropRead.MergedIntoUID = crop.MergedIntoUID
 
 	for _, v := range crop.Notes {
 		cropRead.Notes = append(cropRead.Notes, v)
@@ -325,6 +390,7 @@ func MapToCropListInArea(crop query.CropAreaByAreaQueryResult) (CropListInArea,
 
 	cl.UID = crop.UID
 	cl.BatchID = crop.BatchID
+	cl.ShortCode = crop.ShortCode
 	cl.SeedingDate = crop.Area.InitialArea.CreatedDate
 
 	now := time.Now()
@@ -480,3 +546,132 @@ func (a TaskSanitationActivity) MarshalJSON() ([]byte, error) {
 		Code:  a.Code(),
 	})
 }
+
+func (a GerminationActivity) MarshalJSON() ([]byte, error) {
+	type Alias GerminationActivity
+
+	return json.Marshal(struct {
+		*Alias
+		Code string `json:"code"`
+	}{
+		Alias: (*Alias)(&a),
+		Code:  a.Code(),
+	})
+}
+
+func (a WithholdingOverrideActivity) MarshalJSON() ([]byte, error) {
+	type Alias WithholdingOverrideActivity
+
+	return json.Marshal(struct {
+		*Alias
+		Code string `json:"code"`
+	}{
+		Alias: (*Alias)(&a),
+		Code:  a.Code(),
+	})
+}
+
+func (a MergeActivity) MarshalJSON() ([]byte, error) {
+	type Alias MergeActivity
+
+	return json.Marshal(struct {
+		*Alias
+		Code string `json:"code"`
+	}{
+		Alias: (*Alias)(&a),
+		Code:  a.Code(),
+	})
+}
+
+func (a TransferActivity) MarshalJSON() ([]byte, error) {
+	type Alias TransferActivity
+
+	return json.Marshal(struct {
+		*Alias
+		Code string `json:"code"`
+	}{
+		Alias: (*Alias)(&a),
+		Code:  a.Code(),
+	})
+}
+
+// cropExpansions are the relations the crop detail can inline with the expand param. The areas are the ones
+// the crop still has plants in, and the notes are the last ones first.
+func cropExpansions(s *GrowthServer, crop storage.CropRead) expandhelper.Registry {
+	return expandhelper.Registry{
+		{Key: "areas", Expand: expandhelper.List(func() ([]query.CropAreaQueryResult, error) {
+			areaUIDs := []uuid.UUID{}
+			if crop.InitialArea.CurrentQuantity > 0 {
+				areaUIDs = append(areaUIDs, crop.InitialArea.AreaUID)
+			}
+
+			for _, v := range crop.MovedArea {
+				if v.CurrentQuantity > 0 {
+					areaUIDs = append(areaUIDs, v.AreaUID)
+				}
+			}
+
+			areas := []query.CropAreaQueryResult{}
+
+			for _, v := range areaUIDs {
+				result := <-s.AreaReadQuery.FindByID(v)
+				if result.Error != nil {
+					return nil, result.Error
+				}
+
+				area, ok := result.Result.(query.CropAreaQueryResult)
+				if !ok {
+					return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+				}
+
+				areas = append(areas, area)
+			}
+
+			return areas, nil
+		})},
+		{Key: "activities", Expand: expandhelper.List(func() ([]CropActivity, error) {
+			err := s.checkCropHistory(crop.UID)
+			if err != nil {
+				return nil, err
+			}
+
+			result := <-s.CropActivityQuery.FindAllByCropID(crop.UID)
+			if result.Error != nil {
+				return nil, result.Error
+			}
+
+			activities, ok := result.Result.([]storage.CropActivity)
+			if !ok {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+			}
+
+			cropActivities := []CropActivity{}
+			for i := range activities {
+				cropActivities = append(cropActivities, MapToCropActivity(activities[i]))
+			}
+
+			return cropActivities, nil
+		})},
+		{Key: "input_schedules", Expand: expandhelper.List(func() ([]storage.CropInputScheduleRead, error) {
+			result := <-s.CropInputScheduleReadQuery.FindAllByCropID(crop.UID)
+			if result.Error != nil {
+				return nil, result.Error
+			}
+
+			schedules, ok := result.Result.([]storage.CropInputScheduleRead)
+			if !ok {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+			}
+
+			return schedules, nil
+		})},
+		{Key: "notes", Limit: 20, Expand: expandhelper.List(func() ([]domain.CropNote, error) {
+			notes := append([]domain.CropNote{}, crop.Notes...)
+			sort.Slice(notes, func(i, j int) bool {
+				return notes[i].CreatedDate.After(notes[j].CreatedDate)
+			})
+
+			return notes, nil
+		})},
+	}
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/shortcode"
+)
+
+// parseCropUID reads a crop ID path param that is either a UUID or a short code like C-387.
+// Short codes are only unique within a farm, so `farm_id` is required
+// when the same code exists in more than one farm.
+func (s *GrowthServer) parseCropUID(c echo.Context, param string) (uuid.UUID, error) {
+	value := c.Param(param)
+
+	uid, err := uuid.FromString(value)
+	if err == nil {
+		return uid, nil
+	}
+
+	if !shortcode.IsShortCode(shortcode.CropPrefix, value) {
+		return uuid.UUID{}, err
+	}
+
+	result := <-s.CropReadQuery.FindAllByShortCode(shortcode.Normalize(value))
+	if result.Error != nil {
+		return uuid.UUID{}, result.Error
+	}
+
+	crops, ok := result.Result.([]query.CropShortCodeQueryResult)
+	if !ok {
+		return uuid.UUID{}, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if farmID := c.QueryParam("farm_id"); farmID != "" {
+		farmUID, err := uuid.FromString(farmID)
+		if err != nil {
+			return uuid.UUID{}, NewRequestValidationError(ParseFailed, "farm_id")
+		}
+
+		filtered := []query.CropShortCodeQueryResult{}
+
+		for _, v := range crops {
+			if v.FarmUID == farmUID {
+				filtered = append(filtered, v)
+			}
+		}
+
+		crops = filtered
+	}
+
+	switch len(crops) {
+	case 0:
+		return uuid.UUID{}, NewRequestValidationError(NotFound, param)
+	case 1:
+		return crops[0].UID, nil
+	default:
+		return uuid.UUID{}, NewRequestValidationError(Required, "farm_id")
+	}
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/farmscope"
+	"github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/growth/query"
+	"github.com/usetania/tania-core/src/growth/storage"
+)
+
+// findSafeHarvestDate returns the day the batch is out of the withholding period of its pesticide treatments,
+// nil when none holds it back. The treatments are dated by their pest control activities.
+func (s *GrowthServer) findSafeHarvestDate(cropUID uuid.UUID) (*time.Time, error) {
+	result := <-s.CropActivityQuery.FindAllByCropID(cropUID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	activities, ok := result.Result.([]storage.CropActivity)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	intervals := map[uuid.UUID]*int{}
+	applications := []domain.PesticideApplication{}
+
+	for _, v := range activities {
+		activity, ok := v.ActivityType.(storage.TaskPestControlActivity)
+		if !ok {
+			continue
+		}
+
+		materialUID, err := s.findPestControlMaterialUID(activity)
+		if err != nil {
+			return nil, err
+		}
+
+		if materialUID == (uuid.UUID{}) {
+			continue
+		}
+
+		days, found := intervals[materialUID]
+		if !found {
+			result := <-s.MaterialReadQuery.FindByID(materialUID)
+			if result.Error != nil {
+				return nil, result.Error
+			}
+
+			material, ok := result.Result.(query.CropMaterialQueryResult)
+			if !ok {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+			}
+
+			days = material.PreHarvestIntervalDays
+			intervals[materialUID] = days
+		}
+
+		if days == nil {
+			continue
+		}
+
+		applications = append(applications, domain.PesticideApplication{
+			MaterialUID:            materialUID,
+			AppliedDate:            v.CreatedDate,
+			PreHarvestIntervalDays: *days,
+		})
+	}
+
+	return domain.SafeHarvestDate(applications), nil
+}
+
+// findPestControlMaterialUID returns the material of the treatment. The treatments recorded before
+// the activities kept the material id are matched by the material type and name.
+func (s *GrowthServer) findPestControlMaterialUID(activity storage.TaskPestControlActivity) (uuid.UUID, error) {
+	if activity.MaterialUID != (uuid.UUID{}) {
+		return activity.MaterialUID, nil
+	}
+
+	if activity.MaterialName == "" {
+		return uuid.UUID{}, nil
+	}
+
+	result := <-s.MaterialReadQuery.FindMaterialByPlantTypeCodeAndName(activity.MaterialType, activity.MaterialName)
+	if result.Error != nil {
+		return uuid.UUID{}, result.Error
+	}
+
+	material, ok := result.Result.(query.CropMaterialQueryResult)
+	if !ok {
+		return uuid.UUID{}, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return material.UID, nil
+}
+
+// checkWithholding refuses the harvest of a batch before its safe harvest date, unless the farm only warns
+// about it. Then the harvest needs an `override_reason`, which is recorded with the user letting it through.
+func (s *GrowthServer) checkWithholding(c echo.Context, crop *domain.Crop, now time.Time) error {
+	safeDate, err := s.findSafeHarvestDate(crop.UID)
+	if err != nil {
+		return err
+	}
+
+	if safeDate == nil || !now.Before(*safeDate) {
+		return nil
+	}
+
+	result := <-s.FarmReadQuery.FindByID(crop.FarmUID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	farm, ok := result.Result.(query.CropFarmQueryResult)
+	if !ok {
+		return errors.New("internal server error. error type assertion")
+	}
+
+	if farm.WithholdingPolicy != assetsdomain.WithholdingPolicyWarn {
+		return domain.CropError{Code: domain.CropHarvestErrorWithholdingPeriod}
+	}
+
+	userUID, _ := c.Get(farmscope.UserKey).(uuid.UUID)
+
+	return crop.OverrideWithholding(*safeDate, c.FormValue("override_reason"), userUID)
+}
@@ -42,3 +42,150 @@ func CreateCropActivityStorage() *CropActivityStorage {
 
 	return &CropActivityStorage{CropActivityMap: []CropActivity{}, Lock: &rwMutex}
 }
+
+type CropInputScheduleEventStorage struct {
+	Lock                    *deadlock.RWMutex
+	CropInputScheduleEvents []CropInputScheduleEvent
+}
+
+func CreateCropInputScheduleEventStorage() *CropInputScheduleEventStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("CROP INPUT SCHEDULE EVENT STORAGE DEADLOCK!")
+	}
+
+	return &CropInputScheduleEventStorage{Lock: &rwMutex}
+}
+
+type CropInputScheduleReadStorage struct {
+	Lock                     *deadlock.RWMutex
+	CropInputScheduleReadMap map[uuid.UUID]CropInputScheduleRead
+}
+
+func CreateCropInputScheduleReadStorage() *CropInputScheduleReadStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("CROP INPUT SCHEDULE READ STORAGE DEADLOCK!")
+	}
+
+	return &CropInputScheduleReadStorage{
+		CropInputScheduleReadMap: make(map[uuid.UUID]CropInputScheduleRead),
+		Lock:                     &rwMutex,
+	}
+}
+
+type DispatchScheduleEventStorage struct {
+	Lock                   *deadlock.RWMutex
+	DispatchScheduleEvents []DispatchScheduleEvent
+}
+
+func CreateDispatchScheduleEventStorage() *DispatchScheduleEventStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("DISPATCH SCHEDULE EVENT STORAGE DEADLOCK!")
+	}
+
+	return &DispatchScheduleEventStorage{Lock: &rwMutex}
+}
+
+type DispatchScheduleReadStorage struct {
+	Lock                    *deadlock.RWMutex
+	DispatchScheduleReadMap map[uuid.UUID]DispatchScheduleRead
+}
+
+func CreateDispatchScheduleReadStorage() *DispatchScheduleReadStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("DISPATCH SCHEDULE READ STORAGE DEADLOCK!")
+	}
+
+	return &DispatchScheduleReadStorage{
+		DispatchScheduleReadMap: make(map[uuid.UUID]DispatchScheduleRead),
+		Lock:                    &rwMutex,
+	}
+}
+
+type InsurancePolicyEventStorage struct {
+	Lock                  *deadlock.RWMutex
+	InsurancePolicyEvents []InsurancePolicyEvent
+}
+
+func CreateInsurancePolicyEventStorage() *InsurancePolicyEventStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("INSURANCE POLICY EVENT STORAGE DEADLOCK!")
+	}
+
+	return &InsurancePolicyEventStorage{Lock: &rwMutex}
+}
+
+type InsurancePolicyReadStorage struct {
+	Lock                   *deadlock.RWMutex
+	InsurancePolicyReadMap map[uuid.UUID]InsurancePolicyRead
+}
+
+func CreateInsurancePolicyReadStorage() *InsurancePolicyReadStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("INSURANCE POLICY READ STORAGE DEADLOCK!")
+	}
+
+	return &InsurancePolicyReadStorage{
+		InsurancePolicyReadMap: make(map[uuid.UUID]InsurancePolicyRead),
+		Lock:                   &rwMutex,
+	}
+}
+
+type HarvestLotEventStorage struct {
+	Lock             *deadlock.RWMutex
+	HarvestLotEvents []HarvestLotEvent
+}
+
+func CreateHarvestLotEventStorage() *HarvestLotEventStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("HARVEST LOT EVENT STORAGE DEADLOCK!")
+	}
+
+	return &HarvestLotEventStorage{Lock: &rwMutex}
+}
+
+type HarvestLotReadStorage struct {
+	Lock              *deadlock.RWMutex
+	HarvestLotReadMap map[uuid.UUID]HarvestLotRead
+}
+
+func CreateHarvestLotReadStorage() *HarvestLotReadStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("HARVEST LOT READ STORAGE DEADLOCK!")
+	}
+
+	return &HarvestLotReadStorage{
+		HarvestLotReadMap: make(map[uuid.UUID]HarvestLotRead),
+		Lock:              &rwMutex,
+	}
+}
+
+type MicroclimateSampleStorage struct {
+	Lock                *deadlock.RWMutex
+	MicroclimateSamples []MicroclimateSample
+}
+
+func CreateMicroclimateSampleStorage() *MicroclimateSampleStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("MICROCLIMATE SAMPLE STORAGE DEADLOCK!")
+	}
+
+	return &MicroclimateSampleStorage{Lock: &rwMutex}
+}
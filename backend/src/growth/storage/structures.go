@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/sasha-s/go-deadlock"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/growth/domain"
 )
 
@@ -14,6 +15,100 @@ type CropEvent struct {
 	Version     int
 	CreatedDate time.Time
 	Event       interface{}
+	Actor       *actor.Actor
+}
+
+type CropInputScheduleEvent struct {
+	CropInputScheduleUID uuid.UUID
+	Version              int
+	CreatedDate          time.Time
+	Event                interface{}
+	Actor                *actor.Actor
+}
+
+type CropInputScheduleRead struct {
+	UID               uuid.UUID  `json:"uid"`
+	CropUID           uuid.UUID  `json:"crop_id"`
+	MaterialUID       uuid.UUID  `json:"material_id"`
+	PlannedDate       time.Time  `json:"planned_date"`
+	Quantity          float64    `json:"quantity"`
+	Unit              string     `json:"unit"`
+	ApplicationMethod string     `json:"application_method"`
+	Status            string     `json:"status"`
+	TaskUID           *uuid.UUID `json:"task_id"`
+	AppliedDate       *time.Time `json:"applied_date"`
+	CreatedDate       time.Time  `json:"created_date"`
+}
+
+type DispatchScheduleEvent struct {
+	DispatchScheduleUID uuid.UUID
+	Version             int
+	CreatedDate         time.Time
+	Event               interface{}
+	Actor               *actor.Actor
+}
+
+type DispatchScheduleRead struct {
+	UID                   uuid.UUID   `json:"uid"`
+	FarmUID               uuid.UUID   `json:"farm_id"`
+	HarvestIDs            []uuid.UUID `json:"harvest_ids"`
+	VehicleID             string      `json:"vehicle_id"`
+	DriverName            string      `json:"driver_name"`
+	PickupTime            time.Time   `json:"pickup_time"`
+	DestinationAddress    string      `json:"destination_address"`
+	EstimatedDeliveryTime time.Time   `json:"estimated_delivery_time"`
+	Status                string      `json:"status"`
+	TaskUID               *uuid.UUID  `json:"task_id"`
+	CompletedDate         *time.Time  `json:"completed_date"`
+	CreatedDate           time.Time   `json:"created_date"`
+}
+
+type InsurancePolicyEvent struct {
+	InsurancePolicyUID uuid.UUID
+	Version            int
+	CreatedDate        time.Time
+	Event              interface{}
+	Actor              *actor.Actor
+}
+
+type InsurancePolicyRead struct {
+	UID            uuid.UUID               `json:"uid"`
+	FarmUID        uuid.UUID               `json:"farm_id"`
+	PolicyNumber   string                  `json:"policy_number"`
+	Provider       string                  `json:"provider"`
+	CropIDs        []uuid.UUID             `json:"crop_ids"`
+	CoverageAmount float64                 `json:"coverage_amount"`
+	Currency       string                  `json:"currency"`
+	PremiumAmount  float64                 `json:"premium_amount"`
+	StartDate      time.Time               `json:"start_date"`
+	EndDate        time.Time               `json:"end_date"`
+	Claims         []domain.InsuranceClaim `json:"claims"`
+	IsDeleted      bool                    `json:"-"`
+	CreatedDate    time.Time               `json:"created_date"`
+}
+
+type HarvestLotEvent struct {
+	HarvestLotUID uuid.UUID
+	Version       int
+	CreatedDate   time.Time
+	Event         interface{}
+	Actor         *actor.Actor
+}
+
+type HarvestLotRead struct {
+	UID          uuid.UUID            `json:"uid"`
+	FarmUID      uuid.UUID            `json:"farm_id"`
+	LotNumber    string               `json:"lot_number"`
+	CropUID      uuid.UUID            `json:"crop_id"`
+	BatchID      string               `json:"batch_id"`
+	AreaUID      uuid.UUID            `json:"area_id"`
+	HarvestDate  time.Time            `json:"harvest_date"`
+	Grade        string               `json:"grade"`
+	GramQuantity float64              `json:"gram_quantity"`
+	Remaining    float64              `json:"remaining_gram_quantity"`
+	Status       string               `json:"status"`
+	Shipments    []domain.LotShipment `json:"shipments"`
+	CreatedDate  time.Time            `json:"created_date"`
 }
 
 func CreateCropEventStorage() *CropEventStorage {
@@ -29,6 +124,7 @@ func CreateCropEventStorage() *CropEventStorage {
 type CropRead struct {
 	UID        uuid.UUID   `json:"uid"`
 	BatchID    string      `json:"batch_id"`
+	ShortCode  string      `json:"short_code"`
 	Status     string      `json:"status"`
 	Type       string      `json:"type"`
 	Container  Container   `json:"container"`
@@ -43,8 +139,89 @@ type CropRead struct {
 	HarvestedStorage []HarvestedStorage `json:"harvested_storage"`
 	Trash            []Trash            `json:"trash"`
 
+	// Nursery stages before transplanting
+	NurseryStages []NurseryStage `json:"nursery_stages"`
+
 	// Notes
 	Notes []domain.CropNote `json:"notes"`
+
+	// Germination of the seeds sown, nil for the batches created without seeds sown
+	SeedsSown          *int       `json:"seeds_sown"`
+	GerminatedQuantity *int       `json:"germinated_quantity"`
+	GerminationDate    *time.Time `json:"germination_date"`
+	GerminationRate    *float64   `json:"germination_rate"`
+
+	// Batch the plants were merged into, the crop is archived once merged
+	MergedIntoUID *uuid.UUID `json:"merged_into_id"`
+}
+
+// GerminationRate returns the percentage of the seeds sown which germinated, nil until both are recorded.
+func GerminationRate(seedsSown, germinatedQuantity *int) *float64 {
+	if germinatedQuantity == nil {
+		return nil
+	}
+
+	return domain.GerminationRate(seedsSown, &domain.CropGermination{Quantity: *germinatedQuantity})
+}
+
+// AreaCropCurrent is a row of the AREA_CROP_CURRENT table, the plants of an active crop in one of its areas.
+type AreaCropCurrent struct {
+	AreaUID     uuid.UUID
+	CropUID     uuid.UUID
+	FarmUID     uuid.UUID
+	Quantity    int
+	PlacedDate  time.Time
+	LastUpdated time.Time
+}
+
+// AreaCropsCurrent returns the areas the crop has plants in, none once it's archived.
+// The plants moved back into an area they left are added to its row.
+func AreaCropsCurrent(cropRead CropRead) []AreaCropCurrent {
+	rows := []AreaCropCurrent{}
+
+	if cropRead.Status != domain.CropActive {
+		return rows
+	}
+
+	add := func(areaUID uuid.UUID, quantity int, placedDate, lastUpdated time.Time) {
+		if quantity <= 0 {
+			return
+		}
+
+		for i, v := range rows {
+			if v.AreaUID == areaUID {
+				rows[i].Quantity += quantity
+
+				if placedDate.Before(v.PlacedDate) {
+					rows[i].PlacedDate = placedDate
+				}
+
+				if lastUpdated.After(v.LastUpdated) {
+					rows[i].LastUpdated = lastUpdated
+				}
+
+				return
+			}
+		}
+
+		rows = append(rows, AreaCropCurrent{
+			AreaUID:     areaUID,
+			CropUID:     cropRead.UID,
+			FarmUID:     cropRead.FarmUID,
+			Quantity:    quantity,
+			PlacedDate:  placedDate,
+			LastUpdated: lastUpdated,
+		})
+	}
+
+	add(cropRead.InitialArea.AreaUID, cropRead.InitialArea.CurrentQuantity,
+		cropRead.InitialArea.CreatedDate, cropRead.InitialArea.LastUpdated)
+
+	for _, v := range cropRead.MovedArea {
+		add(v.AreaUID, v.CurrentQuantity, v.CreatedDate, v.LastUpdated)
+	}
+
+	return rows
 }
 
 type InitialArea struct {
@@ -109,14 +286,27 @@ type Inventory struct {
 	Name      string    `json:"name"`
 }
 
+type NurseryStage struct {
+	UID                    uuid.UUID  `json:"uid"`
+	AreaUID                uuid.UUID  `json:"area_id"`
+	AreaName               string     `json:"area_name"`
+	StartDate              time.Time  `json:"start_date"`
+	ExpectedTransplantDate time.Time  `json:"expected_transplant_date"`
+	ActualTransplantDate   *time.Time `json:"actual_transplant_date"`
+}
+
 type CropPhoto struct {
-	UID         uuid.UUID `json:"uid"`
-	Filename    string    `json:"filename"`
-	MimeType    string    `json:"mime_type"`
-	Size        int       `json:"size"`
-	Width       int       `json:"width"`
-	Height      int       `json:"height"`
-	Description string    `json:"description"`
+	UID               uuid.UUID `json:"uid"`
+	Filename          string    `json:"filename"`
+	MimeType          string    `json:"mime_type"`
+	Size              int       `json:"size"`
+	Width             int       `json:"width"`
+	Height            int       `json:"height"`
+	Description       string    `json:"description"`
+	Status            string    `json:"status"`
+	ThumbnailFilename string    `json:"thumbnail_filename"`
+
+	Metadata domain.CropPhotoMetadata `json:"metadata"`
 }
 
 const (
@@ -131,6 +321,10 @@ const (
 	TaskPestControlActivityCode = "TASK_PEST_CONTROL"
 	TaskSafetyActivityCode      = "TASK_SAFETY"
 	TaskSanitationActivityCode  = "TASK_SANITATION"
+	GerminationActivityCode     = "GERMINATION"
+	WithholdingOverrideCode     = "WITHHOLDING_OVERRIDE"
+	MergeActivityCode           = "MERGE"
+	TransferActivityCode        = "TRANSFER"
 )
 
 type CropActivity struct {
@@ -140,6 +334,7 @@ type CropActivity struct {
 	ActivityType  ActivityType `json:"activity_type"`
 	CreatedDate   time.Time    `json:"created_date"`
 	Description   string       `json:"description"`
+	Actor         *actor.Actor `json:"actor"`
 }
 
 type ActivityType interface {
@@ -177,6 +372,9 @@ type HarvestActivity struct {
 	Quantity             int       `json:"quantity"`
 	ProducedGramQuantity float32   `json:"produced_gram_quantity"`
 	HarvestDate          time.Time `json:"harvest_date"`
+
+	// Grades is the quality breakdown of the produced quantity, empty for the harvests recorded without one.
+	Grades []domain.HarvestedGrade `json:"grades,omitempty"`
 }
 
 func (HarvestActivity) Code() string {
@@ -242,6 +440,7 @@ func (TaskNutrientActivity) Code() string {
 
 type TaskPestControlActivity struct {
 	TaskUID      uuid.UUID `json:"task_id"`
+	MaterialUID  uuid.UUID `json:"material_id"`
 	MaterialType string    `json:"material_type"`
 	MaterialName string    `json:"material_name"`
 	AreaName     string    `json:"area_name"`
@@ -272,3 +471,65 @@ type TaskSanitationActivity struct {
 func (TaskSanitationActivity) Code() string {
 	return TaskSanitationActivityCode
 }
+
+type GerminationActivity struct {
+	SeedsSown          int       `json:"seeds_sown"`
+	GerminatedQuantity int       `json:"germinated_quantity"`
+	GerminationRate    float64   `json:"germination_rate"`
+	GerminationDate    time.Time `json:"germination_date"`
+}
+
+func (GerminationActivity) Code() string {
+	return GerminationActivityCode
+}
+
+// WithholdingOverrideActivity is a harvest recorded before the batch's safe harvest date.
+type WithholdingOverrideActivity struct {
+	SafeHarvestDate time.Time `json:"safe_harvest_date"`
+	Reason          string    `json:"reason"`
+	OverriddenBy    uuid.UUID `json:"overridden_by"`
+}
+
+func (WithholdingOverrideActivity) Code() string {
+	return WithholdingOverrideCode
+}
+
+// MergeActivity is the plants of the source batch merged into the target batch,
+// recorded on both of them.
+type MergeActivity struct {
+	SourceCropUID uuid.UUID `json:"source_crop_id"`
+	SourceBatchID string    `json:"source_batch_id"`
+	TargetCropUID uuid.UUID `json:"target_crop_id"`
+	TargetBatchID string    `json:"target_batch_id"`
+	AreaUID       uuid.UUID `json:"area_id"`
+	AreaName      string    `json:"area_name"`
+	Quantity      int       `json:"quantity"`
+	MergedDate    time.Time `json:"merged_date"`
+}
+
+func (MergeActivity) Code() string {
+	return MergeActivityCode
+}
+
+// TransferActivity is the plants of the crop leaving for another farm, the transfer its certificate is issued for.
+type TransferActivity struct {
+	TransferUID       uuid.UUID `json:"transfer_id"`
+	FromFarmUID       uuid.UUID `json:"from_farm_id"`
+	ToFarmUID         uuid.UUID `json:"to_farm_id"`
+	ToFarmName        string    `json:"to_farm_name"`
+	RecipientName     string    `json:"recipient_name"`
+	CertificateNumber string    `json:"certificate_number"`
+	TransferDate      time.Time `json:"transfer_date"`
+}
+
+func (TransferActivity) Code() string {
+	return TransferActivityCode
+}
+
+// MicroclimateSample is a temperature reading of an area, in celsius.
+type MicroclimateSample struct {
+	UID          uuid.UUID `json:"uid"`
+	AreaUID      uuid.UUID `json:"area_id"`
+	Temperature  float64   `json:"temperature"`
+	RecordedDate time.Time `json:"recorded_date"`
+}
@@ -0,0 +1,135 @@
+// Package expandhelper inlines the relations of an entity in its detail response, asked with the expand
+// query param, so the clients don't need a request per relation.
+package expandhelper
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// Param is the query param of the comma separated relations to inline, like `expand=crops,notes`.
+	Param = "expand"
+
+	// DefaultLimit caps the items inlined for a relation, the clients list the others on its own endpoint.
+	DefaultLimit = 50
+)
+
+// Expansion is a relation inlined in a detail response, with at most the limit of the relation as items.
+// Total is the number of items of the relation before the cap.
+type Expansion struct {
+	Items     interface{} `json:"items"`
+	Total     int         `json:"total"`
+	Truncated bool        `json:"truncated"`
+}
+
+// Expander resolves a relation from the read storages, with at most limit items.
+type Expander func(limit int) (Expansion, error)
+
+// Relation is a relation a detail response can inline, under its key.
+type Relation struct {
+	Key    string
+	Limit  int
+	Expand Expander
+}
+
+// Registry is the relations a detail response can inline, in the order they are listed in the errors.
+type Registry []Relation
+
+// UnknownError is an expand param with a key which isn't one of the relations of the registry.
+type UnknownError struct {
+	Key   string
+	Valid []string
+}
+
+func (e UnknownError) Error() string {
+	return fmt.Sprintf("%s can't be expanded, the valid expansions are %s", e.Key, strings.Join(e.Valid, ", "))
+}
+
+// List is the expander of a relation found as a whole, its items beyond the limit are dropped.
+func List[T any](find func() ([]T, error)) Expander {
+	return func(limit int) (Expansion, error) {
+		items, err := find()
+		if err != nil {
+			return Expansion{}, err
+		}
+
+		total := len(items)
+		if total > limit {
+			items = items[:limit]
+		}
+
+		return Expansion{Items: items, Total: total, Truncated: total > limit}, nil
+	}
+}
+
+// Keys returns the keys of the relations, in the order of the registry.
+func (r Registry) Keys() []string {
+	keys := []string{}
+	for _, v := range r {
+		keys = append(keys, v.Key)
+	}
+
+	return keys
+}
+
+// Parse returns the relations of the comma separated keys of the param, once each.
+// An unknown key is an UnknownError, so nothing is resolved for a mistyped param.
+func (r Registry) Parse(param string) ([]Relation, error) {
+	relations := []Relation{}
+	seen := map[string]bool{}
+
+	for _, key := range strings.Split(param, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" || seen[key] {
+			continue
+		}
+
+		relation, ok := r.find(key)
+		if !ok {
+			return nil, UnknownError{Key: key, Valid: r.Keys()}
+		}
+
+		seen[key] = true
+		relations = append(relations, relation)
+	}
+
+	return relations, nil
+}
+
+// Expand resolves the relations of the param, by their key. It returns an empty map when the param is empty,
+// left out of the detail responses so they are unchanged without expand.
+func (r Registry) Expand(param string) (map[string]Expansion, error) {
+	relations, err := r.Parse(param)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded := map[string]Expansion{}
+
+	for _, v := range relations {
+		limit := v.Limit
+		if limit <= 0 {
+			limit = DefaultLimit
+		}
+
+		expansion, err := v.Expand(limit)
+		if err != nil {
+			return nil, err
+		}
+
+		expanded[v.Key] = expansion
+	}
+
+	return expanded, nil
+}
+
+func (r Registry) find(key string) (Relation, bool) {
+	for _, v := range r {
+		if v.Key == key {
+			return v, true
+		}
+	}
+
+	return Relation{}, false
+}
@@ -0,0 +1,83 @@
+package expandhelper_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/helper/expandhelper"
+)
+
+func newTestRegistry(resolved *[]string) expandhelper.Registry {
+	return expandhelper.Registry{
+		{Key: "crops", Expand: expandhelper.List(func() ([]string, error) {
+			*resolved = append(*resolved, "crops")
+
+			return []string{"Tomato", "Chili"}, nil
+		})},
+		{Key: "notes", Limit: 2, Expand: expandhelper.List(func() ([]string, error) {
+			*resolved = append(*resolved, "notes")
+
+			return []string{"Pruned", "Watered", "Weeded"}, nil
+		})},
+	}
+}
+
+func TestExpand(t *testing.T) {
+	t.Parallel()
+	// Given
+	resolved := []string{}
+	registry := newTestRegistry(&resolved)
+
+	// When
+	expanded, err := registry.Expand(" notes,crops,,notes ")
+	empty, emptyErr := registry.Expand("")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"notes", "crops"}, resolved)
+	assert.Equal(t, expandhelper.Expansion{Items: []string{"Tomato", "Chili"}, Total: 2}, expanded["crops"])
+	assert.Equal(t, expandhelper.Expansion{
+		Items:     []string{"Pruned", "Watered"},
+		Total:     3,
+		Truncated: true,
+	}, expanded["notes"])
+
+	assert.Nil(t, emptyErr)
+	assert.Empty(t, empty)
+}
+
+func TestExpandUnknownKey(t *testing.T) {
+	t.Parallel()
+	// Given
+	resolved := []string{}
+	registry := newTestRegistry(&resolved)
+
+	// When
+	_, err := registry.Expand("crops,tasks")
+
+	// Then
+	unknown := expandhelper.UnknownError{}
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, "tasks", unknown.Key)
+	assert.Equal(t, []string{"crops", "notes"}, unknown.Valid)
+	assert.Equal(t, "tasks can't be expanded, the valid expansions are crops, notes", err.Error())
+	assert.Empty(t, resolved)
+}
+
+func TestExpandError(t *testing.T) {
+	t.Parallel()
+	// Given
+	failure := errors.New("database is locked")
+	registry := expandhelper.Registry{
+		{Key: "tasks", Expand: expandhelper.List(func() ([]int, error) {
+			return nil, failure
+		})},
+	}
+
+	// When
+	_, err := registry.Expand("tasks")
+
+	// Then
+	assert.ErrorIs(t, err, failure)
+}
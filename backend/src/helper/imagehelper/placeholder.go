@@ -0,0 +1,52 @@
+package imagehelper
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+)
+
+// PlaceholderMaxDimension is the largest side of the placeholders, the larger photos get a scaled down one.
+const PlaceholderMaxDimension = 1024
+
+// Placeholder returns a plain grey image standing in for a photo of the dimensions, as a JPEG for the JPEG photos
+// and a PNG for the others, with its mime type.
+func Placeholder(width, height int, mimeType string) ([]byte, string, error) {
+	if width <= 0 || height <= 0 {
+		width, height = PlaceholderMaxDimension, PlaceholderMaxDimension
+	}
+
+	if width > PlaceholderMaxDimension || height > PlaceholderMaxDimension {
+		if width > height {
+			width, height = PlaceholderMaxDimension, max(1, height*PlaceholderMaxDimension/width)
+		} else {
+			width, height = max(1, width*PlaceholderMaxDimension/height), PlaceholderMaxDimension
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: color.Gray{Y: 0xc0}}, image.Point{}, draw.Src)
+
+	buf := &bytes.Buffer{}
+
+	if mimeType == "image/jpeg" {
+		err := jpeg.Encode(buf, img, &jpeg.Options{Quality: 60})
+
+		return buf.Bytes(), mimeType, err
+	}
+
+	err := png.Encode(buf, img)
+
+	return buf.Bytes(), "image/png", err
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,208 @@
+package imagehelper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"io"
+	"os"
+	"path/filepath"
+
+	// Register the decoders for the formats we accept as uploads.
+	_ "image/gif"
+	_ "image/png"
+)
+
+// CreateThumbnail decodes the image in srcPath, rotates it upright according to
+// its EXIF orientation and writes a JPEG that fits in maxDimension to destPath.
+// The returned width and height are the dimensions of the upright original.
+func CreateThumbnail(srcPath, destPath string, maxDimension int) (width, height int, err error) {
+	raw, err := os.ReadFile(srcPath)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	img = applyOrientation(img, readJPEGOrientation(raw))
+
+	width = img.Bounds().Dx()
+	height = img.Bounds().Dy()
+
+	err = os.MkdirAll(filepath.Dir(destPath), os.ModePerm)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	dst, err := os.Create(destPath)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer dst.Close()
+
+	// Re-encoding also drops the original EXIF block from the thumbnail.
+	err = jpeg.Encode(dst, resize(img, maxDimension), &jpeg.Options{Quality: 80})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return width, height, nil
+}
+
+// resize scales img down with nearest neighbour sampling so its longest side
+// is maxDimension. Images that already fit are returned as is.
+func resize(img image.Image, maxDimension int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	if w <= maxDimension && h <= maxDimension {
+		return img
+	}
+
+	tw, th := maxDimension, h*maxDimension/w
+	if h > w {
+		tw, th = w*maxDimension/h, maxDimension
+	}
+
+	if tw < 1 {
+		tw = 1
+	}
+
+	if th < 1 {
+		th = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+
+	for y := 0; y < th; y++ {
+		for x := 0; x < tw; x++ {
+			dst.Set(x, y, img.At(b.Min.X+x*w/tw, b.Min.Y+y*h/th))
+		}
+	}
+
+	return dst
+}
+
+// applyOrientation transforms img so that it is displayed upright
+// for the EXIF orientation values 2 to 8.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	// Orientations 5 to 8 swap the width and height.
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+
+			dst.Set(dx, dy, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+
+	return dst
+}
+
+// readJPEGOrientation returns the EXIF orientation tag of a JPEG file,
+// or 1 (upright) when the file has none or isn't a JPEG.
+func readJPEGOrientation(raw []byte) int {
+	r := bytes.NewReader(raw)
+
+	var marker [2]byte
+	if _, err := io.ReadFull(r, marker[:]); err != nil || marker != [2]byte{0xFF, 0xD8} {
+		return 1
+	}
+
+	for {
+		if _, err := io.ReadFull(r, marker[:]); err != nil || marker[0] != 0xFF {
+			return 1
+		}
+
+		var size uint16
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil || size < 2 {
+			return 1
+		}
+
+		segment := make([]byte, size-2)
+		if _, err := io.ReadFull(r, segment); err != nil {
+			return 1
+		}
+
+		// APP1 holds the EXIF data. Stop at the start of scan.
+		if marker[1] == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return parseTIFFOrientation(segment[6:])
+		}
+
+		if marker[1] == 0xDA {
+			return 1
+		}
+	}
+}
+
+func parseTIFFOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[offset : offset+2]))
+
+	for i := 0; i < entries; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+
+		// 0x0112 is the orientation tag, stored as a SHORT.
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8 : entry+10]))
+		}
+	}
+
+	return 1
+}
@@ -0,0 +1,36 @@
+package imagehelper_test
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/helper/imagehelper"
+)
+
+func TestCreateThumbnail(t *testing.T) {
+	t.Parallel()
+	// Given
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "photo.png")
+	destPath := filepath.Join(dir, "thumbnails", "photo.jpg")
+
+	src, _ := os.Create(srcPath)
+	png.Encode(src, image.NewRGBA(image.Rect(0, 0, 800, 400)))
+	src.Close()
+
+	// When
+	width, height, err := imagehelper.CreateThumbnail(srcPath, destPath, 200)
+	thumbWidth, thumbHeight, errThumb := imagehelper.GetImageDimension(destPath)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Nil(t, errThumb)
+	assert.Equal(t, 800, width)
+	assert.Equal(t, 400, height)
+	assert.Equal(t, 200, thumbWidth)
+	assert.Equal(t, 100, thumbHeight)
+}
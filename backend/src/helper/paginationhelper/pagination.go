@@ -55,3 +55,22 @@ func ParsePagination(page, limit string) (pageInt, limitInt int, err error) {
 
 	return pageInt, limitInt, nil
 }
+
+// PageBounds returns the bounds of the page in a slice of total items, for the lists filtered after the query.
+func PageBounds(total, page, limit int) (start, end int) {
+	start = CalculatePageToOffset(page, limit)
+	if start < 0 {
+		start = 0
+	}
+
+	if start > total {
+		start = total
+	}
+
+	end = start + limit
+	if end > total {
+		end = total
+	}
+
+	return start, end
+}
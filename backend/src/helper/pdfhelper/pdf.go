@@ -0,0 +1,158 @@
+// Package pdfhelper writes simple text and line PDF documents, enough for the printable sheets.
+// It only uses the Helvetica standard fonts, so the documents don't embed any font. These fonts only have
+// the characters of Windows-1252, the others, like the Thai or the Chinese ones, are printed as ?.
+package pdfhelper
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page size in points.
+const (
+	PageWidth  = 595.0
+	PageHeight = 842.0
+)
+
+// Document is a PDF being written. The coordinates start at the top left corner of the page, in points.
+type Document struct {
+	pages []*bytes.Buffer
+}
+
+func NewDocument() *Document {
+	return &Document{}
+}
+
+// AddPage starts a new page, the next drawings go to it.
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Text writes the text with its baseline at y.
+func (d *Document) Text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+
+	fmt.Fprintf(d.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, PageHeight-y, escape(text))
+}
+
+func (d *Document) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(d.page(), "%.2f %.2f m %.2f %.2f l S\n", x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+// Rect strokes the rectangle whose top left corner is at x, y.
+func (d *Document) Rect(x, y, width, height float64) {
+	fmt.Fprintf(d.page(), "%.2f %.2f %.2f %.2f re S\n", x, PageHeight-y-height, width, height)
+}
+
+// Bytes returns the document with its cross-reference table.
+func (d *Document) Bytes() []byte {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	out := &bytes.Buffer{}
+	offsets := []int{}
+
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// The pages are after the catalog, the page tree and the two fonts, with their content stream.
+	kids := []string{}
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 5+i*2))
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, v := range d.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, 6+i*2))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", v.Len(), v.String()))
+	}
+
+	xref := out.Len()
+
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+
+	for _, v := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", v)
+	}
+
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// Truncate shortens the text to fit in the width, from the average width of the Helvetica characters.
+func Truncate(text string, width, size float64) string {
+	runes := []rune(text)
+	max := int(width / (size * 0.52))
+
+	if len(runes) <= max {
+		return text
+	}
+
+	if max < 3 {
+		return ""
+	}
+
+	return string(runes[:max-3]) + "..."
+}
+
+func (d *Document) page() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	return d.pages[len(d.pages)-1]
+}
+
+// winAnsiCodes are the codes of the WinAnsiEncoding between 128 and 159, which aren't the Latin-1 ones.
+func winAnsiCodes() map[rune]int {
+	return map[rune]int{
+		'€': 128, '‚': 130, 'ƒ': 131, '„': 132, '…': 133, '†': 134, '‡': 135, 'ˆ': 136, '‰': 137, 'Š': 138,
+		'‹': 139, 'Œ': 140, 'Ž': 142, '‘': 145, '’': 146, '“': 147, '”': 148, '•': 149, '–': 150, '—': 151,
+		'˜': 152, '™': 153, 'š': 154, '›': 155, 'œ': 156, 'ž': 158, 'Ÿ': 159,
+	}
+}
+
+// escape escapes the text of a string object in the WinAnsiEncoding of the fonts. The characters out of it
+// can't be written with the standard fonts, so they are replaced by ?.
+func escape(text string) string {
+	b := strings.Builder{}
+	codes := winAnsiCodes()
+
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r >= 32 && r < 127:
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		case codes[r] != 0:
+			fmt.Fprintf(&b, "\\%03o", codes[r])
+		default:
+			b.WriteRune('?')
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package pdfhelper_test
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/helper/pdfhelper"
+)
+
+func TestDocumentCrossReferences(t *testing.T) {
+	t.Parallel()
+	// Given
+	doc := pdfhelper.NewDocument()
+	doc.AddPage()
+	doc.Text(40, 40, 12, true, "Greenhouse (north)")
+	doc.AddPage()
+	doc.Rect(40, 40, 100, 20)
+
+	// When
+	out := doc.Bytes()
+
+	// Then
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4")))
+	assert.Contains(t, string(out), "/Count 2")
+	assert.Contains(t, string(out), `(Greenhouse \(north\)) Tj`)
+
+	// Every object of the table starts where it says.
+	for i := 1; i <= 8; i++ {
+		offset := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(out, -1)[i-1][1]
+		start, _ := strconv.Atoi(string(offset))
+
+		assert.True(t, bytes.HasPrefix(out[start:], []byte(fmt.Sprintf("%d 0 obj", i))))
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	// When
+	short := pdfhelper.Truncate("Water", 100, 10)
+	long := pdfhelper.Truncate("Water the seedlings of the whole greenhouse", 100, 10)
+
+	// Then
+	assert.Equal(t, "Water", short)
+	assert.Equal(t, "Water the seedli...", long)
+}
+
+func TestDocumentTextOutOfWinAnsi(t *testing.T) {
+	t.Parallel()
+	// Given
+	doc := pdfhelper.NewDocument()
+	doc.Text(40, 40, 12, false, "Café “Bắc” – 5 €")
+	doc.Text(40, 60, 12, false, "Tomat 番茄")
+
+	// When
+	out := string(doc.Bytes())
+
+	// Then
+	assert.Contains(t, out, `(Caf\351 \223B?c\224 \226 5 \200) Tj`)
+	assert.Contains(t, out, `(Tomat ??) Tj`)
+}
@@ -0,0 +1,72 @@
+package stringhelper
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeName returns the form of a name used to compare it with other names.
+// It unifies the unicode representation, lowers the case and collapses the whitespaces,
+// so "Basil Genovese" and " basil  genovese " are the same name.
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(norm.NFKC.String(name))), " ")
+}
+
+// Levenshtein returns the number of rune insertions, deletions and substitutions
+// needed to change a into b.
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+// IsSimilarName tells whether two names are probably the same name with a typo.
+// The normalized names may differ by one edit for every seven runes.
+func IsSimilarName(a, b string) bool {
+	na, nb := NormalizeName(a), NormalizeName(b)
+	if na == nb {
+		return true
+	}
+
+	length := len([]rune(na))
+	if l := len([]rune(nb)); l > length {
+		length = l
+	}
+
+	return Levenshtein(na, nb) <= length/7
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,51 @@
+package stringhelper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/helper/stringhelper"
+)
+
+func TestNormalizeName(t *testing.T) {
+	t.Parallel()
+	// Given
+	// When
+	spaces := stringhelper.NormalizeName("  Basil \t Genovese\n")
+	nbsp := stringhelper.NormalizeName("Basil Genovese") // No-break space
+	composed := stringhelper.NormalizeName("Jalapeño")
+	decomposed := stringhelper.NormalizeName("JALAPEÑO") // Combining tilde
+	fullwidth := stringhelper.NormalizeName("Ｂａｓｉｌ")
+	empty := stringhelper.NormalizeName(" 　 ") // Ideographic space
+
+	// Then
+	assert.Equal(t, "basil genovese", spaces)
+	assert.Equal(t, "basil genovese", nbsp)
+	assert.Equal(t, "jalapeño", composed)
+	assert.Equal(t, composed, decomposed)
+	assert.Equal(t, "basil", fullwidth)
+	assert.Equal(t, "", empty)
+}
+
+func TestLevenshtein(t *testing.T) {
+	t.Parallel()
+	// Given
+	// When
+	// Then
+	assert.Equal(t, 0, stringhelper.Levenshtein("basil", "basil"))
+	assert.Equal(t, 3, stringhelper.Levenshtein("kitten", "sitting"))
+	assert.Equal(t, 5, stringhelper.Levenshtein("", "basil"))
+	assert.Equal(t, 1, stringhelper.Levenshtein("jalapeño", "jalapeno"))
+}
+
+func TestIsSimilarName(t *testing.T) {
+	t.Parallel()
+	// Given
+	// When
+	// Then
+	assert.True(t, stringhelper.IsSimilarName("Basil Genovese", "basil genovese "))
+	assert.True(t, stringhelper.IsSimilarName("Basil Genovese", "Basil Genovse"))
+	assert.True(t, stringhelper.IsSimilarName("Jalapeño", "JALAPEÑO"))
+	assert.False(t, stringhelper.IsSimilarName("Area 1", "Area 2"))
+	assert.False(t, stringhelper.IsSimilarName("Basil", "Mint"))
+}
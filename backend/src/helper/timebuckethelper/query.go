@@ -0,0 +1,168 @@
+package timebuckethelper
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The query params of the analytics endpoints.
+const (
+	ParamInterval  = "interval"
+	ParamTimezone  = "timezone"
+	ParamWeekStart = "week_start"
+	ParamFrom      = "from"
+	ParamTo        = "to"
+)
+
+// ParamError is an invalid query param, Unknown when it isn't one of the options of the param.
+type ParamError struct {
+	Param   string
+	Unknown bool
+}
+
+func (e ParamError) Error() string {
+	return fmt.Sprintf("invalid %s", e.Param)
+}
+
+// Query is the grouping asked by the query params of an analytics endpoint, returned with the buckets so the
+// clients can label them. The from and to dates are both included, To is the start of the day after.
+type Query struct {
+	Interval  string     `json:"interval"`
+	Timezone  string     `json:"timezone"`
+	WeekStart string     `json:"week_start"`
+	From      *time.Time `json:"from"`
+	To        *time.Time `json:"to"`
+	Seasons   []Season   `json:"seasons,omitempty"`
+
+	Bucketer Bucketer `json:"-"`
+}
+
+// ParseQuery parses the interval, timezone, week_start, from and to params read by the param func. The seasons are
+// the ones of the farm, the default interval is used when the interval param is empty, and the server timezone
+// when the timezone param is.
+func ParseQuery(param func(string) string, defaultInterval string, seasons []Season) (Query, error) {
+	query := Query{
+		Interval:  param(ParamInterval),
+		Timezone:  param(ParamTimezone),
+		WeekStart: param(ParamWeekStart),
+	}
+
+	if query.Interval == "" {
+		query.Interval = defaultInterval
+	}
+
+	location := time.Local
+
+	if query.Timezone != "" {
+		var err error
+
+		location, err = time.LoadLocation(query.Timezone)
+		if err != nil {
+			return Query{}, ParamError{Param: ParamTimezone, Unknown: true}
+		}
+	}
+
+	query.Timezone = location.String()
+
+	weekStart := time.Monday
+
+	switch query.WeekStart {
+	case "", WeekStartMonday:
+		query.WeekStart = WeekStartMonday
+	case WeekStartSunday:
+		weekStart = time.Sunday
+	default:
+		return Query{}, ParamError{Param: ParamWeekStart, Unknown: true}
+	}
+
+	if query.Interval == IntervalCustomSeason {
+		query.Seasons = seasons
+	}
+
+	bucketer, err := NewBucketer(query.Interval, location, weekStart, seasons)
+	if err != nil {
+		return Query{}, ParamError{Param: ParamInterval, Unknown: errors.Is(err, ErrInvalidInterval)}
+	}
+
+	query.Bucketer = bucketer
+
+	if query.From, err = parseDate(param(ParamFrom), location, ParamFrom); err != nil {
+		return Query{}, err
+	}
+
+	if query.To, err = parseDate(param(ParamTo), location, ParamTo); err != nil {
+		return Query{}, err
+	}
+
+	if query.To != nil {
+		to := query.To.AddDate(0, 0, 1)
+		query.To = &to
+	}
+
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return Query{}, ParamError{Param: ParamTo}
+	}
+
+	return query, nil
+}
+
+// Includes tells whether the time is in the range of the query.
+func (q Query) Includes(t time.Time) bool {
+	return (q.From == nil || !t.Before(*q.From)) && (q.To == nil || t.Before(*q.To))
+}
+
+// Bucket returns the bucket of the time, clipped to the range like the buckets at its edges.
+func (q Query) Bucket(t time.Time) Bucket {
+	bucket := q.Bucketer.Bucket(t)
+
+	if q.From != nil && bucket.Start.Before(*q.From) {
+		bucket.Start = q.From.In(bucket.Start.Location())
+		bucket.Partial = true
+	}
+
+	if q.To != nil && bucket.End.After(*q.To) {
+		bucket.End = q.To.In(bucket.End.Location())
+		bucket.Partial = true
+	}
+
+	return bucket
+}
+
+// Buckets returns every bucket of the range. Without a from date the range starts at the bucket of the earliest
+// time, and without a to date it ends with the bucket of now, the buckets are only partial at the range edges asked.
+func (q Query) Buckets(earliest, now time.Time) ([]Bucket, error) {
+	from := q.Bucketer.Bucket(now).Start
+	if !earliest.IsZero() && earliest.Before(now) {
+		from = q.Bucketer.Bucket(earliest).Start
+	}
+
+	if q.From != nil {
+		from = *q.From
+	}
+
+	to := q.Bucketer.Bucket(now).End
+	if q.To != nil {
+		to = *q.To
+	}
+
+	if q.From == nil && !from.Before(to) {
+		from = q.Bucketer.Bucket(to.Add(-time.Nanosecond)).Start
+	}
+
+	return q.Bucketer.Buckets(from, to)
+}
+
+// parseDate parses the date param in the location, nil when it's empty.
+func parseDate(value string, location *time.Location, param string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.ParseInLocation(dateLayout, value, location)
+	if err != nil {
+		return nil, ParamError{Param: param}
+	}
+
+	return &date, nil
+}
@@ -0,0 +1,275 @@
+// Package timebuckethelper groups the dates of the analytics into days, weeks, months, quarters or seasons,
+// so every analytics endpoint labels and clips its buckets the same way.
+package timebuckethelper
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The intervals the analytics are grouped by.
+const (
+	IntervalDay          = "day"
+	IntervalISOWeek      = "iso_week"
+	IntervalMonth        = "month"
+	IntervalQuarter      = "quarter"
+	IntervalCustomSeason = "custom_season"
+)
+
+// The week conventions, from Monday like ISO 8601 or from Sunday.
+const (
+	WeekStartMonday = "monday"
+	WeekStartSunday = "sunday"
+)
+
+// MaxBuckets caps the buckets of a range, like the days of a century.
+const MaxBuckets = 1000
+
+const dateLayout = "2006-01-02"
+
+var (
+	ErrInvalidInterval = errors.New("invalid interval")
+	ErrInvalidSeasons  = errors.New("the seasons need unique names and start days")
+	ErrTooManyBuckets  = fmt.Errorf("the range has more than %d buckets", MaxBuckets)
+)
+
+// Season is a season of the farm, from its start day until the start day of the next season.
+type Season struct {
+	Name       string     `json:"name"`
+	StartMonth time.Month `json:"start_month"`
+	StartDay   int        `json:"start_day"`
+}
+
+// DefaultSeasons are the meteorological seasons of the hemisphere, flipped in the southern one.
+func DefaultSeasons(southern bool) []Season {
+	names := []string{"Spring", "Summer", "Autumn", "Winter"}
+	if southern {
+		names = []string{"Autumn", "Winter", "Spring", "Summer"}
+	}
+
+	seasons := []Season{}
+	for i, v := range names {
+		seasons = append(seasons, Season{Name: v, StartMonth: time.Month(3 + i*3), StartDay: 1})
+	}
+
+	return seasons
+}
+
+// ValidateSeasons checks the seasons have names and start on a day of every year, so not on February 29.
+func ValidateSeasons(seasons []Season) error {
+	names := map[string]bool{}
+	starts := map[int]bool{}
+
+	for _, v := range seasons {
+		name := strings.ToLower(strings.TrimSpace(v.Name))
+		start := int(v.StartMonth)*100 + v.StartDay
+
+		if name == "" || len(name) > 30 || names[name] || starts[start] {
+			return ErrInvalidSeasons
+		}
+
+		if v.StartMonth < time.January || v.StartMonth > time.December || v.StartDay < 1 ||
+			v.StartDay > time.Date(2001, v.StartMonth+1, 0, 0, 0, 0, 0, time.UTC).Day() {
+			return ErrInvalidSeasons
+		}
+
+		names[name] = true
+		starts[start] = true
+	}
+
+	if len(seasons) == 0 {
+		return ErrInvalidSeasons
+	}
+
+	return nil
+}
+
+// Bucket is a bucket of the analytics, from its start until its end excluded. A bucket straddling the edge of
+// the range is clipped to it and partial, keeping the label of the whole bucket.
+type Bucket struct {
+	Label   string    `json:"label"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Partial bool      `json:"partial"`
+}
+
+// Contains tells whether the time is in the bucket.
+func (b Bucket) Contains(t time.Time) bool {
+	return !t.Before(b.Start) && t.Before(b.End)
+}
+
+// Overlap returns how long the period from start until end is in the bucket. A zero end is an open period.
+func (b Bucket) Overlap(start, end time.Time) time.Duration {
+	if start.Before(b.Start) {
+		start = b.Start
+	}
+
+	if end.IsZero() || end.After(b.End) {
+		end = b.End
+	}
+
+	if !start.Before(end) {
+		return 0
+	}
+
+	return end.Sub(start)
+}
+
+// Bucketer groups the times into the buckets of an interval, in a timezone.
+type Bucketer struct {
+	interval  string
+	location  *time.Location
+	weekStart time.Weekday
+	seasons   []Season
+}
+
+// NewBucketer returns the bucketer of the interval. The weeks start on the weekStart, and are labelled with the
+// ISO week of their Monday. The seasons are only used by the custom_season interval. A nil location groups the times
+// in their own location.
+func NewBucketer(interval string, location *time.Location, weekStart time.Weekday, seasons []Season) (
+	Bucketer, error,
+) {
+	switch interval {
+	case IntervalDay, IntervalISOWeek, IntervalMonth, IntervalQuarter:
+	case IntervalCustomSeason:
+		if err := ValidateSeasons(seasons); err != nil {
+			return Bucketer{}, err
+		}
+	default:
+		return Bucketer{}, ErrInvalidInterval
+	}
+
+	sorted := append([]Season{}, seasons...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].StartMonth != sorted[j].StartMonth {
+			return sorted[i].StartMonth < sorted[j].StartMonth
+		}
+
+		return sorted[i].StartDay < sorted[j].StartDay
+	})
+
+	return Bucketer{
+		interval:  interval,
+		location:  location,
+		weekStart: weekStart,
+		seasons:   sorted,
+	}, nil
+}
+
+// Interval returns the interval of the buckets.
+func (b Bucketer) Interval() string {
+	return b.interval
+}
+
+// Bucket returns the whole bucket the time is in.
+func (b Bucketer) Bucket(t time.Time) Bucket {
+	if b.location != nil {
+		t = t.In(b.location)
+	}
+
+	year, month, day := t.Date()
+	location := t.Location()
+
+	switch b.interval {
+	case IntervalISOWeek:
+		start := time.Date(year, month, day-(int(t.Weekday())-int(b.weekStart)+7)%7, 0, 0, 0, 0, location)
+		isoYear, isoWeek := start.AddDate(0, 0, (8-int(start.Weekday()))%7).ISOWeek()
+
+		return Bucket{Label: fmt.Sprintf("%d-W%02d", isoYear, isoWeek), Start: start, End: start.AddDate(0, 0, 7)}
+
+	case IntervalMonth:
+		start := time.Date(year, month, 1, 0, 0, 0, 0, location)
+
+		return Bucket{Label: start.Format("2006-01"), Start: start, End: start.AddDate(0, 1, 0)}
+
+	case IntervalQuarter:
+		quarter := (int(month) - 1) / 3
+		start := time.Date(year, time.Month(quarter*3+1), 1, 0, 0, 0, 0, location)
+
+		return Bucket{Label: fmt.Sprintf("%d-Q%d", year, quarter+1), Start: start, End: start.AddDate(0, 3, 0)}
+
+	case IntervalCustomSeason:
+		return b.season(t)
+	}
+
+	start := time.Date(year, month, day, 0, 0, 0, 0, location)
+
+	return Bucket{Label: start.Format(dateLayout), Start: start, End: start.AddDate(0, 0, 1)}
+}
+
+// season returns the last season started on or before the time, which started the year before for the times
+// before the first start of the year. A season running into the next year is labelled like Summer 2025/26.
+func (b Bucketer) season(t time.Time) Bucket {
+	for year := t.Year(); year >= t.Year()-1; year-- {
+		for i := len(b.seasons) - 1; i >= 0; i-- {
+			start := seasonStart(b.seasons[i], year, t.Location())
+			if start.After(t) {
+				continue
+			}
+
+			end := seasonStart(b.seasons[0], year+1, t.Location())
+			if i+1 < len(b.seasons) {
+				end = seasonStart(b.seasons[i+1], year, t.Location())
+			}
+
+			label := fmt.Sprintf("%s %d", b.seasons[i].Name, year)
+			if lastDay := end.AddDate(0, 0, -1); lastDay.Year() != year {
+				label = fmt.Sprintf("%s %d/%02d", b.seasons[i].Name, year, lastDay.Year()%100)
+			}
+
+			return Bucket{Label: label, Start: start, End: end}
+		}
+	}
+
+	return Bucket{}
+}
+
+func seasonStart(season Season, year int, location *time.Location) time.Time {
+	return time.Date(year, season.StartMonth, season.StartDay, 0, 0, 0, 0, location)
+}
+
+// Buckets returns the buckets from the bucket of from until the bucket of to, excluded, with the first and last
+// ones clipped to the range.
+func (b Bucketer) Buckets(from, to time.Time) ([]Bucket, error) {
+	buckets := []Bucket{}
+
+	for next := from; next.Before(to); {
+		if len(buckets) == MaxBuckets {
+			return nil, ErrTooManyBuckets
+		}
+
+		bucket := b.Bucket(next)
+		next = bucket.End
+
+		if bucket.Start.Before(from) {
+			bucket.Start = from.In(bucket.Start.Location())
+			bucket.Partial = true
+		}
+
+		if bucket.End.After(to) {
+			bucket.End = to.In(bucket.End.Location())
+			bucket.Partial = true
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
+
+// Find returns the index of the bucket the time is in, -1 when it isn't in any of them.
+// The buckets have to be sorted, like the ones from Buckets.
+func Find(buckets []Bucket, t time.Time) int {
+	i := sort.Search(len(buckets), func(i int) bool {
+		return buckets[i].End.After(t)
+	})
+
+	if i < len(buckets) && buckets[i].Contains(t) {
+		return i
+	}
+
+	return -1
+}
@@ -0,0 +1,330 @@
+package timebuckethelper_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/helper/timebuckethelper"
+)
+
+// timezones are the timezones every bucketing test runs in, with their daylight saving time.
+func timezones(t *testing.T) []*time.Location {
+	t.Helper()
+
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	assert.Nil(t, err)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+
+	return []*time.Location{time.UTC, sydney, newYork}
+}
+
+func TestBucketLabels(t *testing.T) {
+	t.Parallel()
+
+	for _, location := range timezones(t) {
+		// Given
+		// Sunday, January 3 2027 is in the last ISO week of 2026.
+		sunday := time.Date(2027, time.January, 3, 23, 30, 0, 0, location)
+
+		for _, v := range []struct {
+			interval  string
+			weekStart time.Weekday
+			label     string
+			start     time.Time
+			end       time.Time
+		}{
+			{
+				timebuckethelper.IntervalDay, time.Monday, "2027-01-03",
+				time.Date(2027, time.January, 3, 0, 0, 0, 0, location),
+				time.Date(2027, time.January, 4, 0, 0, 0, 0, location),
+			},
+			{
+				timebuckethelper.IntervalISOWeek, time.Monday, "2026-W53",
+				time.Date(2026, time.December, 28, 0, 0, 0, 0, location),
+				time.Date(2027, time.January, 4, 0, 0, 0, 0, location),
+			},
+			{
+				timebuckethelper.IntervalISOWeek, time.Sunday, "2027-W01",
+				time.Date(2027, time.January, 3, 0, 0, 0, 0, location),
+				time.Date(2027, time.January, 10, 0, 0, 0, 0, location),
+			},
+			{
+				timebuckethelper.IntervalMonth, time.Monday, "2027-01",
+				time.Date(2027, time.January, 1, 0, 0, 0, 0, location),
+				time.Date(2027, time.February, 1, 0, 0, 0, 0, location),
+			},
+			{
+				timebuckethelper.IntervalQuarter, time.Sunday, "2027-Q1",
+				time.Date(2027, time.January, 1, 0, 0, 0, 0, location),
+				time.Date(2027, time.April, 1, 0, 0, 0, 0, location),
+			},
+		} {
+			bucketer, err := timebuckethelper.NewBucketer(v.interval, location, v.weekStart, nil)
+			assert.Nil(t, err)
+
+			// When
+			bucket := bucketer.Bucket(sunday)
+
+			// Then
+			assert.Equal(t, v.label, bucket.Label, location.String())
+			assert.True(t, v.start.Equal(bucket.Start), "%s %s %s", location, v.interval, bucket.Start)
+			assert.True(t, v.end.Equal(bucket.End), "%s %s %s", location, v.interval, bucket.End)
+			assert.True(t, bucket.Contains(sunday))
+		}
+	}
+}
+
+func TestBucketInTheTimezone(t *testing.T) {
+	t.Parallel()
+
+	for _, weekStart := range []time.Weekday{time.Monday, time.Sunday} {
+		// Given
+		// Monday 00:30 in Sydney is still Sunday in UTC.
+		locations := timezones(t)
+		sydney := locations[1]
+		monday := time.Date(2026, time.October, 12, 0, 30, 0, 0, sydney)
+
+		utc, err := timebuckethelper.NewBucketer(timebuckethelper.IntervalISOWeek, time.UTC, weekStart, nil)
+		assert.Nil(t, err)
+
+		local, err := timebuckethelper.NewBucketer(timebuckethelper.IntervalISOWeek, sydney, weekStart, nil)
+		assert.Nil(t, err)
+
+		// When
+		utcBucket := utc.Bucket(monday)
+		localBucket := local.Bucket(monday)
+
+		// Then
+		if weekStart == time.Monday {
+			assert.Equal(t, "2026-W41", utcBucket.Label)
+			assert.Equal(t, "2026-W42", localBucket.Label)
+		} else {
+			assert.Equal(t, "2026-W42", utcBucket.Label)
+			assert.Equal(t, "2026-W42", localBucket.Label)
+		}
+
+		assert.Equal(t, sydney, localBucket.Start.Location())
+		assert.Equal(t, 0, localBucket.Start.Hour())
+	}
+}
+
+func TestBucketsAcrossDaylightSavingTime(t *testing.T) {
+	t.Parallel()
+
+	for _, location := range timezones(t) {
+		for _, weekStart := range []time.Weekday{time.Monday, time.Sunday} {
+			// Given
+			bucketer, err := timebuckethelper.NewBucketer(timebuckethelper.IntervalDay, location, weekStart, nil)
+			assert.Nil(t, err)
+
+			// When
+			// Sydney and New York both change their clocks between March and April.
+			buckets, err := bucketer.Buckets(
+				time.Date(2026, time.March, 1, 0, 0, 0, 0, location),
+				time.Date(2026, time.May, 1, 0, 0, 0, 0, location),
+			)
+
+			// Then
+			assert.Nil(t, err)
+			assert.Len(t, buckets, 61)
+
+			for i, v := range buckets {
+				assert.Equal(t, 0, v.Start.Hour(), location.String())
+				assert.False(t, v.Partial)
+
+				if i > 0 {
+					assert.True(t, buckets[i-1].End.Equal(v.Start))
+				}
+			}
+		}
+	}
+}
+
+func TestBucketsStraddlingTheRange(t *testing.T) {
+	t.Parallel()
+
+	for _, location := range timezones(t) {
+		for _, weekStart := range []time.Weekday{time.Monday, time.Sunday} {
+			// Given
+			bucketer, err := timebuckethelper.NewBucketer(timebuckethelper.IntervalISOWeek, location, weekStart, nil)
+			assert.Nil(t, err)
+
+			// Wednesday until Wednesday two weeks later.
+			from := time.Date(2026, time.October, 14, 0, 0, 0, 0, location)
+			to := time.Date(2026, time.October, 28, 0, 0, 0, 0, location)
+
+			// When
+			buckets, err := bucketer.Buckets(from, to)
+
+			// Then
+			assert.Nil(t, err)
+			assert.Len(t, buckets, 3)
+
+			assert.Equal(t, "2026-W42", buckets[0].Label)
+			assert.True(t, buckets[0].Partial)
+			assert.True(t, from.Equal(buckets[0].Start))
+
+			assert.False(t, buckets[1].Partial)
+			assert.Equal(t, "2026-W43", buckets[1].Label)
+			assert.Equal(t, 7*24*time.Hour, buckets[1].End.Sub(buckets[1].Start))
+
+			assert.Equal(t, "2026-W44", buckets[2].Label)
+			assert.True(t, buckets[2].Partial)
+			assert.True(t, to.Equal(buckets[2].End))
+
+			assert.Equal(t, 0, timebuckethelper.Find(buckets, from))
+			assert.Equal(t, 2, timebuckethelper.Find(buckets, to.Add(-time.Second)))
+			assert.Equal(t, -1, timebuckethelper.Find(buckets, to))
+			assert.Equal(t, -1, timebuckethelper.Find(buckets, from.Add(-time.Second)))
+		}
+	}
+}
+
+func TestCustomSeasons(t *testing.T) {
+	t.Parallel()
+
+	for _, location := range timezones(t) {
+		// Given
+		southern, err := timebuckethelper.NewBucketer(timebuckethelper.IntervalCustomSeason, location, time.Monday,
+			timebuckethelper.DefaultSeasons(true))
+		assert.Nil(t, err)
+
+		northern, err := timebuckethelper.NewBucketer(timebuckethelper.IntervalCustomSeason, location, time.Sunday,
+			timebuckethelper.DefaultSeasons(false))
+		assert.Nil(t, err)
+
+		january := time.Date(2027, time.January, 15, 12, 0, 0, 0, location)
+
+		// When
+		summer := southern.Bucket(january)
+		winter := northern.Bucket(january)
+		buckets, err := northern.Buckets(
+			time.Date(2026, time.October, 14, 0, 0, 0, 0, location),
+			time.Date(2027, time.October, 14, 0, 0, 0, 0, location),
+		)
+
+		// Then
+		assert.Equal(t, "Summer 2026/27", summer.Label)
+		assert.True(t, time.Date(2026, time.December, 1, 0, 0, 0, 0, location).Equal(summer.Start))
+		assert.True(t, time.Date(2027, time.March, 1, 0, 0, 0, 0, location).Equal(summer.End))
+		assert.Equal(t, "Winter 2026/27", winter.Label)
+
+		assert.Nil(t, err)
+
+		labels := []string{}
+		for _, v := range buckets {
+			labels = append(labels, v.Label)
+		}
+
+		assert.Equal(t, []string{"Autumn 2026", "Winter 2026/27", "Spring 2027", "Summer 2027", "Autumn 2027"}, labels)
+		assert.True(t, buckets[0].Partial)
+		assert.True(t, buckets[4].Partial)
+	}
+}
+
+func TestValidateSeasons(t *testing.T) {
+	t.Parallel()
+
+	// Then
+	assert.Nil(t, timebuckethelper.ValidateSeasons([]timebuckethelper.Season{{Name: "Wet", StartMonth: 11, StartDay: 1}}))
+	assert.Equal(t, timebuckethelper.ErrInvalidSeasons, timebuckethelper.ValidateSeasons(nil))
+	assert.Equal(t, timebuckethelper.ErrInvalidSeasons, timebuckethelper.ValidateSeasons([]timebuckethelper.Season{
+		{Name: "Wet", StartMonth: 2, StartDay: 29},
+	}))
+	assert.Equal(t, timebuckethelper.ErrInvalidSeasons, timebuckethelper.ValidateSeasons([]timebuckethelper.Season{
+		{Name: "Wet", StartMonth: 11, StartDay: 1},
+		{Name: "wet", StartMonth: 5, StartDay: 1},
+	}))
+	assert.Equal(t, timebuckethelper.ErrInvalidSeasons, timebuckethelper.ValidateSeasons([]timebuckethelper.Season{
+		{Name: "Wet", StartMonth: 11, StartDay: 1},
+		{Name: "Dry", StartMonth: 11, StartDay: 1},
+	}))
+}
+
+func TestParseQuery(t *testing.T) {
+	t.Parallel()
+
+	for _, timezone := range []string{"UTC", "Australia/Sydney"} {
+		for _, weekStart := range []string{timebuckethelper.WeekStartMonday, timebuckethelper.WeekStartSunday} {
+			// Given
+			params := url.Values{
+				"interval":   {"iso_week"},
+				"timezone":   {timezone},
+				"week_start": {weekStart},
+				"from":       {"2026-10-01"},
+				"to":         {"2026-10-28"},
+			}
+
+			// When
+			query, err := timebuckethelper.ParseQuery(params.Get, timebuckethelper.IntervalMonth, nil)
+
+			// Then
+			assert.Nil(t, err)
+			assert.Equal(t, timezone, query.Timezone)
+			assert.Equal(t, weekStart, query.WeekStart)
+			assert.Equal(t, "2026-10-01T00:00:00", query.From.Format("2006-01-02T15:04:05"))
+			assert.Equal(t, "2026-10-29T00:00:00", query.To.Format("2006-01-02T15:04:05"))
+			assert.True(t, query.Includes(query.To.Add(-time.Second)))
+			assert.False(t, query.Includes(*query.To))
+
+			buckets, err := query.Buckets(time.Time{}, time.Now())
+			assert.Nil(t, err)
+			assert.True(t, buckets[0].Partial)
+			assert.True(t, buckets[len(buckets)-1].Partial)
+		}
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range []struct {
+		params url.Values
+		err    timebuckethelper.ParamError
+	}{
+		{url.Values{"interval": {"fortnight"}}, timebuckethelper.ParamError{Param: "interval", Unknown: true}},
+		{url.Values{"timezone": {"Mars/Olympus"}}, timebuckethelper.ParamError{Param: "timezone", Unknown: true}},
+		{url.Values{"week_start": {"friday"}}, timebuckethelper.ParamError{Param: "week_start", Unknown: true}},
+		{url.Values{"from": {"14/10/2026"}}, timebuckethelper.ParamError{Param: "from"}},
+		{url.Values{"from": {"2026-10-14"}, "to": {"2026-10-13"}}, timebuckethelper.ParamError{Param: "to"}},
+	} {
+		// When
+		_, err := timebuckethelper.ParseQuery(v.params.Get, timebuckethelper.IntervalMonth, nil)
+
+		// Then
+		assert.Equal(t, v.err, err)
+	}
+}
+
+func TestQueryBucketsWithoutRange(t *testing.T) {
+	t.Parallel()
+
+	for _, timezone := range []string{"UTC", "America/New_York"} {
+		for _, weekStart := range []string{timebuckethelper.WeekStartMonday, timebuckethelper.WeekStartSunday} {
+			// Given
+			params := url.Values{"timezone": {timezone}, "week_start": {weekStart}}
+			query, err := timebuckethelper.ParseQuery(params.Get, timebuckethelper.IntervalMonth, nil)
+			assert.Nil(t, err)
+
+			earliest := time.Date(2026, time.August, 20, 10, 0, 0, 0, time.UTC)
+			now := time.Date(2026, time.October, 14, 10, 0, 0, 0, time.UTC)
+
+			// When
+			buckets, err := query.Buckets(earliest, now)
+
+			// Then
+			assert.Nil(t, err)
+			assert.Len(t, buckets, 3)
+			assert.Equal(t, "2026-08", buckets[0].Label)
+			assert.Equal(t, "2026-10", buckets[2].Label)
+
+			for _, v := range buckets {
+				assert.False(t, v.Partial)
+			}
+		}
+	}
+}
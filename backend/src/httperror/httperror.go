@@ -0,0 +1,103 @@
+// Package httperror answers the errors the handlers of the API return instead of answering them. The 5xx
+// errors are answered with the INTERNAL_ERROR code and the request ID to report them with, their cause and
+// the stack trace of the panics are logged and only answered with the verbose errors, as they can leak
+// the internals.
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// InternalErrorCode is the error code of the 5xx errors.
+const InternalErrorCode = "INTERNAL_ERROR"
+
+// Panic is a panic recovered from a handler, with the stack it was raised on.
+type Panic struct {
+	Err   error
+	Stack []byte
+}
+
+func (p Panic) Error() string {
+	return "panic: " + p.Err.Error()
+}
+
+func (p Panic) Unwrap() error {
+	return p.Err
+}
+
+// Handler answers the 5xx errors. The other ones, and the 503 of the maintenance mode whose message tells
+// when it ends, are answered unchanged by the default handler of the server. With verbose, the response
+// also has the cause of the error, and the stack of the panic for a recovered one.
+func Handler(e *echo.Echo, verbose bool, logger *log.Logger) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		code := http.StatusInternalServerError
+
+		var he *echo.HTTPError
+		if errors.As(err, &he) {
+			code = he.Code
+		}
+
+		if code < http.StatusInternalServerError || code == http.StatusServiceUnavailable {
+			e.DefaultHTTPErrorHandler(he, c)
+
+			return
+		}
+
+		if c.Response().Committed {
+			return
+		}
+
+		requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+		cause := Cause(err)
+
+		// Only a panic has a stack worth logging, the stack here is the one of the error handler.
+		var p Panic
+		if errors.As(err, &p) {
+			logger.Printf("Internal error, request_id: %v, status: %v, cause: %v\n%s", requestID, code, cause, p.Stack)
+		} else {
+			logger.Printf("Internal error, request_id: %v, status: %v, cause: %v", requestID, code, cause)
+		}
+
+		body := map[string]string{
+			"error_code": InternalErrorCode,
+			"request_id": requestID,
+		}
+
+		if verbose {
+			body["cause"] = cause
+
+			if p.Stack != nil {
+				body["stack_trace"] = string(p.Stack)
+			}
+		}
+
+		if c.Request().Method == http.MethodHead {
+			err = c.NoContent(code)
+		} else {
+			err = c.JSON(code, body)
+		}
+
+		if err != nil {
+			logger.Println(err)
+		}
+	}
+}
+
+// Cause is the message of the error, the internal error of an echo.HTTPError when it has one.
+func Cause(err error) string {
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		if he.Internal != nil {
+			return he.Internal.Error()
+		}
+
+		return fmt.Sprint(he.Message)
+	}
+
+	return err.Error()
+}
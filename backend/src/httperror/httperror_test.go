@@ -0,0 +1,93 @@
+package httperror_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/httperror"
+)
+
+func serve(verbose bool, err error, logger *log.Logger) *httptest.ResponseRecorder {
+	e := echo.New()
+	e.HTTPErrorHandler = Handler(e, verbose, logger)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+	c.Response().Header().Set(echo.HeaderXRequestID, "req-1")
+
+	e.HTTPErrorHandler(err, c)
+
+	return rec
+}
+
+func TestHandlerInternalError(t *testing.T) {
+	t.Parallel()
+	// Given
+	buf := &bytes.Buffer{}
+	err := echo.NewHTTPError(http.StatusInternalServerError, "Internal server error").
+		SetInternal(errors.New("database is locked"))
+
+	// When
+	rec := serve(false, err, log.New(buf, "", 0))
+
+	// Then
+	body := map[string]string{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, map[string]string{"error_code": InternalErrorCode, "request_id": "req-1"}, body)
+	assert.Contains(t, buf.String(), "request_id: req-1, status: 500, cause: database is locked")
+}
+
+func TestHandlerVerboseError(t *testing.T) {
+	t.Parallel()
+	// Given
+	buf := &bytes.Buffer{}
+	err := Panic{Err: errors.New("nil map"), Stack: []byte("goroutine 1 [running]:")}
+
+	// When
+	rec := serve(true, err, log.New(buf, "", 0))
+	failed := serve(true, errors.New("database is locked"), log.New(buf, "", 0))
+
+	// Then
+	body := map[string]string{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, InternalErrorCode, body["error_code"])
+	assert.Equal(t, "req-1", body["request_id"])
+	assert.Equal(t, "panic: nil map", body["cause"])
+	assert.Equal(t, "goroutine 1 [running]:", body["stack_trace"])
+
+	failedBody := map[string]string{}
+	assert.Nil(t, json.Unmarshal(failed.Body.Bytes(), &failedBody))
+	assert.Equal(t, http.StatusInternalServerError, failed.Code)
+	assert.Equal(t, "database is locked", failedBody["cause"])
+	assert.NotContains(t, failedBody, "stack_trace")
+}
+
+func TestHandlerClientError(t *testing.T) {
+	t.Parallel()
+	// Given
+	buf := &bytes.Buffer{}
+
+	// When
+	rec := serve(false, echo.NewHTTPError(http.StatusNotFound, "Not Found"), log.New(buf, "", 0))
+	wrapped := serve(false, fmt.Errorf("area: %w", echo.NewHTTPError(http.StatusConflict, "Locked")), log.New(buf, "", 0))
+	unavailable := serve(false, echo.NewHTTPError(http.StatusServiceUnavailable, "Maintenance"), log.New(buf, "", 0))
+
+	// Then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"message":"Not Found"}`, rec.Body.String())
+	assert.Equal(t, http.StatusConflict, wrapped.Code)
+	assert.JSONEq(t, `{"message":"Locked"}`, wrapped.Body.String())
+	assert.Equal(t, http.StatusServiceUnavailable, unavailable.Code)
+	assert.JSONEq(t, `{"message":"Maintenance"}`, unavailable.Body.String())
+	assert.Empty(t, buf.String())
+}
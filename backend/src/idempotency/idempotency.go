@@ -0,0 +1,235 @@
+// Package idempotency replays the response of a create request retried with the same Idempotency-Key header,
+// so the clients on a flaky connection can retry their POSTs without creating the resource twice.
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/dryrun"
+	"github.com/usetania/tania-core/src/farmscope"
+)
+
+const (
+	// HeaderKey is the header the clients send the key of their request with, like a UUID they generate.
+	HeaderKey = "Idempotency-Key"
+
+	// HeaderReplayed is set on the responses replayed from the record of their key.
+	HeaderReplayed = "Idempotent-Replayed"
+
+	// MaxKeyLength is the length of the longest key accepted.
+	MaxKeyLength = 255
+)
+
+const (
+	// pendingTTL is how long the key of a request still being handled is kept, so the key of a request lost
+	// with its server can be used again. It's refreshed every pendingRefresh while the request is handled.
+	pendingTTL     = time.Minute
+	pendingRefresh = pendingTTL / 3
+
+	// waitTimeout is how long a duplicate waits for the response of the request it duplicates.
+	waitTimeout = 10 * time.Second
+	waitPoll    = 25 * time.Millisecond
+)
+
+// Record is the request of a key, and its response once it's completed.
+// The key is scoped to the user who sent it, two users can send the same key.
+type Record struct {
+	Key         string
+	RequestHash string
+	Completed   bool
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreatedDate time.Time
+	ExpiresDate time.Time
+}
+
+type Store interface {
+	// Reserve saves the pending record, unless its key has a record which hasn't expired yet.
+	// That record is returned with false.
+	Reserve(record Record) (Record, bool, error)
+	// Complete saves the response of the reserved record.
+	Complete(record Record) error
+	// Release removes the record of the key, so the request can be retried.
+	Release(key string) error
+	// Refresh moves the expiry of the pending record of the key, the completed records keep theirs.
+	Refresh(key string, expiresDate time.Time) error
+}
+
+// Middleware records the responses of the POST requests sent with a key for the ttl.
+// A request sent again with the same key and the same body gets the recorded response,
+// with the same key and another body it's refused with 409.
+// The duplicates arriving while the request is handled wait for its response.
+// The failed requests, with a 5xx response, aren't recorded, so they can be retried.
+func Middleware(store Store, ttl time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := strings.TrimSpace(c.Request().Header.Get(HeaderKey))
+			if key == "" || c.Request().Method != http.MethodPost || dryrun.IsRequested(c) {
+				return next(c)
+			}
+
+			if len(key) > MaxKeyLength {
+				return errorResponse(c, http.StatusBadRequest, HeaderKey, "INVALID_OPTION",
+					"The idempotency key can't be longer than "+strconv.Itoa(MaxKeyLength)+" characters.")
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return err
+			}
+
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			now := time.Now()
+			record := Record{
+				Key:         scopedKey(c, key),
+				RequestHash: RequestHash(c.Request(), body),
+				CreatedDate: now,
+				ExpiresDate: now.Add(pendingTTL),
+			}
+
+			for deadline := now.Add(waitTimeout); ; time.Sleep(waitPoll) {
+				existing, reserved, err := store.Reserve(record)
+				if err != nil {
+					return err
+				}
+
+				if reserved {
+					break
+				}
+
+				if existing.RequestHash != record.RequestHash {
+					return errorResponse(c, http.StatusConflict, HeaderKey, "IDEMPOTENCY_KEY_REUSED",
+						"The idempotency key was already sent with another request.")
+				}
+
+				if existing.Completed {
+					return replay(c, existing)
+				}
+
+				if time.Now().After(deadline) {
+					return errorResponse(c, http.StatusConflict, HeaderKey, "IDEMPOTENCY_KEY_IN_PROGRESS",
+						"The request of the idempotency key is still in progress.")
+				}
+			}
+
+			return handle(c, next, store, record, ttl)
+		}
+	}
+}
+
+// handle serves the reserved request and records its response. The key stays reserved while the request
+// is handled, however long it takes.
+func handle(c echo.Context, next echo.HandlerFunc, store Store, record Record, ttl time.Duration) error {
+	stop := keepReserved(store, record.Key)
+	defer stop()
+
+	writer := c.Response().Writer
+	recorder := &responseRecorder{ResponseWriter: writer}
+	c.Response().Writer = recorder
+
+	defer func() {
+		c.Response().Writer = writer
+	}()
+
+	// The error is turned into its response here, so it's recorded like the others.
+	if err := next(c); err != nil {
+		c.Error(err)
+	}
+
+	res := c.Response()
+	if !res.Committed || res.Status >= http.StatusInternalServerError {
+		return store.Release(record.Key)
+	}
+
+	record.Completed = true
+	record.StatusCode = res.Status
+	record.ContentType = res.Header().Get(echo.HeaderContentType)
+	record.Body = recorder.body.Bytes()
+	record.ExpiresDate = record.CreatedDate.Add(ttl)
+
+	return store.Complete(record)
+}
+
+// keepReserved refreshes the pending record of the key until the stop it returns is called.
+func keepReserved(store Store, key string) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(pendingRefresh)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if err := store.Refresh(key, now.Add(pendingTTL)); err != nil {
+					log.Println(err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func replay(c echo.Context, record Record) error {
+	c.Response().Header().Set(HeaderReplayed, "true")
+
+	return c.Blob(record.StatusCode, record.ContentType, record.Body)
+}
+
+// RequestHash is the SHA-256 digest of the method, the URI and the body of the request, which has to be the
+// same when it's sent again with its key.
+func RequestHash(r *http.Request, body []byte) string {
+	digest := sha256.New()
+	digest.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	digest.Write(body)
+
+	return hex.EncodeToString(digest.Sum(nil))
+}
+
+// scopedKey prefixes the key with the user of the request, who is missing in the demo mode.
+func scopedKey(c echo.Context, key string) string {
+	userUID, _ := c.Get(farmscope.UserKey).(uuid.UUID)
+
+	return userUID.String() + ":" + key
+}
+
+func errorResponse(c echo.Context, status int, field, code, message string) error {
+	return c.JSON(status, map[string]string{
+		"field_name":    field,
+		"error_code":    code,
+		"error_message": message,
+	})
+}
+
+// responseRecorder keeps a copy of the body written to the response.
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,241 @@
+package idempotency_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/farmscope"
+	. "github.com/usetania/tania-core/src/idempotency"
+)
+
+type testApp struct {
+	echo    *echo.Echo
+	created int32
+}
+
+// newTestApp creates a task on every POST to /tasks, slowly enough for the duplicates to arrive meanwhile.
+func newTestApp(userUID uuid.UUID) *testApp {
+	app := &testApp{echo: echo.New()}
+
+	setUser := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(farmscope.UserKey, userUID)
+
+			return next(c)
+		}
+	}
+
+	g := app.echo.Group("", setUser, Middleware(NewStoreInMemory(CreateRecordStorage()), time.Hour))
+	g.POST("/tasks", func(c echo.Context) error {
+		if c.FormValue("title") == "" {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		time.Sleep(50 * time.Millisecond)
+
+		n := atomic.AddInt32(&app.created, 1)
+		uid, _ := uuid.NewV4()
+
+		return c.JSON(http.StatusOK, map[string]interface{}{"uid": uid, "title": c.FormValue("title"), "n": n})
+	})
+
+	return app
+}
+
+func (app *testApp) post(key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+
+	if key != "" {
+		req.Header.Set(HeaderKey, key)
+	}
+
+	rec := httptest.NewRecorder()
+	app.echo.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestReplayTheResponseOfTheKey(t *testing.T) {
+	t.Parallel()
+	// Given
+	userUID, _ := uuid.NewV4()
+	app := newTestApp(userUID)
+
+	// When
+	first := app.post("K1", "title=Water+the+beds")
+	replayed := app.post("K1", "title=Water+the+beds")
+	reused := app.post("K1", "title=Prune+the+tomatoes")
+	other := app.post("K2", "title=Water+the+beds")
+	withoutKey := app.post("", "title=Water+the+beds")
+
+	// Then
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusOK, replayed.Code)
+	assert.Equal(t, first.Body.String(), replayed.Body.String())
+	assert.Equal(t, first.Header().Get(echo.HeaderContentType), replayed.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "true", replayed.Header().Get(HeaderReplayed))
+	assert.Empty(t, first.Header().Get(HeaderReplayed))
+
+	assert.Equal(t, http.StatusConflict, reused.Code)
+	assert.Contains(t, reused.Body.String(), "IDEMPOTENCY_KEY_REUSED")
+
+	assert.Equal(t, http.StatusOK, other.Code)
+	assert.NotEqual(t, first.Body.String(), other.Body.String())
+	assert.Equal(t, http.StatusOK, withoutKey.Code)
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&app.created))
+}
+
+func TestConcurrentDuplicatesCreateOnce(t *testing.T) {
+	t.Parallel()
+	// Given
+	userUID, _ := uuid.NewV4()
+	app := newTestApp(userUID)
+	responses := make([]*httptest.ResponseRecorder, 10)
+
+	var wg sync.WaitGroup
+
+	// When
+	for i := range responses {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			responses[i] = app.post("K1", "title=Water+the+beds")
+		}(i)
+	}
+
+	wg.Wait()
+
+	// Then
+	assert.Equal(t, int32(1), atomic.LoadInt32(&app.created))
+
+	replayed := 0
+
+	for _, v := range responses {
+		assert.Equal(t, http.StatusOK, v.Code)
+		assert.Equal(t, responses[0].Body.String(), v.Body.String())
+
+		if v.Header().Get(HeaderReplayed) == "true" {
+			replayed++
+		}
+	}
+
+	assert.Equal(t, 9, replayed)
+}
+
+func TestFailedRequestCanBeRetried(t *testing.T) {
+	t.Parallel()
+	// Given
+	userUID, _ := uuid.NewV4()
+	app := newTestApp(userUID)
+
+	// When
+	failed := app.post("K1", "title=")
+	retried := app.post("K1", "title=")
+	tooLong := app.post(strings.Repeat("k", MaxKeyLength+1), "title=Water+the+beds")
+
+	// Then
+	assert.Equal(t, http.StatusInternalServerError, failed.Code)
+	assert.Equal(t, http.StatusInternalServerError, retried.Code)
+	assert.Empty(t, retried.Header().Get(HeaderReplayed))
+
+	assert.Equal(t, http.StatusBadRequest, tooLong.Code)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&app.created))
+}
+
+func TestExpiredKeyIsReserved(t *testing.T) {
+	t.Parallel()
+	// Given
+	store := NewStoreInMemory(CreateRecordStorage())
+	now := time.Now()
+	expired := Record{Key: "user:K1", RequestHash: "a", Completed: true, CreatedDate: now.Add(-2 * time.Hour),
+		ExpiresDate: now.Add(-time.Hour)}
+
+	_, reservedExpired, _ := store.Reserve(expired)
+
+	// When
+	existing, reserved, err := store.Reserve(Record{Key: "user:K1", RequestHash: "b", CreatedDate: now,
+		ExpiresDate: now.Add(time.Minute)})
+	again, reservedAgain, errAgain := store.Reserve(Record{Key: "user:K1", RequestHash: "c", CreatedDate: now,
+		ExpiresDate: now.Add(time.Minute)})
+
+	// Then
+	assert.True(t, reservedExpired)
+	assert.Nil(t, err)
+	assert.True(t, reserved)
+	assert.Equal(t, "b", existing.RequestHash)
+
+	assert.Nil(t, errAgain)
+	assert.False(t, reservedAgain)
+	assert.Equal(t, "b", again.RequestHash)
+}
+
+func TestRefreshMovesTheExpiryOfThePendingRecords(t *testing.T) {
+	t.Parallel()
+	// Given
+	store := NewStoreInMemory(CreateRecordStorage())
+	now := time.Now()
+	later := now.Add(10 * time.Minute)
+
+	_, _, _ = store.Reserve(Record{Key: "user:K1", RequestHash: "a", CreatedDate: now, ExpiresDate: now.Add(time.Minute)})
+	_, _, _ = store.Reserve(Record{Key: "user:K2", RequestHash: "b", CreatedDate: now, ExpiresDate: now.Add(time.Minute)})
+	_ = store.Complete(Record{Key: "user:K2", RequestHash: "b", Completed: true, CreatedDate: now,
+		ExpiresDate: now.Add(time.Hour)})
+
+	// When
+	errPending := store.Refresh("user:K1", later)
+	errCompleted := store.Refresh("user:K2", later)
+
+	// Then
+	pending, _, _ := store.Reserve(Record{Key: "user:K1", RequestHash: "a", CreatedDate: now})
+	completed, _, _ := store.Reserve(Record{Key: "user:K2", RequestHash: "b", CreatedDate: now})
+
+	assert.Nil(t, errPending)
+	assert.Nil(t, errCompleted)
+	assert.Equal(t, later, pending.ExpiresDate)
+	assert.Equal(t, now.Add(time.Hour), completed.ExpiresDate)
+}
+
+func TestTheResponseWriterIsRestored(t *testing.T) {
+	t.Parallel()
+	// Given
+	e := echo.New()
+	restored := false
+
+	checkWriter := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			writer := c.Response().Writer
+			err := next(c)
+			restored = c.Response().Writer == writer
+
+			return err
+		}
+	}
+
+	g := e.Group("", checkWriter, Middleware(NewStoreInMemory(CreateRecordStorage()), time.Hour))
+	g.POST("/tasks", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"title": "Water the beds"})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader("title=Water+the+beds"))
+	req.Header.Set(HeaderKey, "K1")
+
+	// When
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, restored)
+}
@@ -0,0 +1,74 @@
+package idempotency
+
+import (
+	"time"
+
+	"github.com/sasha-s/go-deadlock"
+)
+
+// RecordStorage keeps the records by their key.
+type RecordStorage struct {
+	Lock      *deadlock.RWMutex
+	RecordMap map[string]Record
+}
+
+func CreateRecordStorage() *RecordStorage {
+	return &RecordStorage{Lock: &deadlock.RWMutex{}, RecordMap: map[string]Record{}}
+}
+
+type StoreInMemory struct {
+	Storage *RecordStorage
+}
+
+func NewStoreInMemory(s *RecordStorage) Store {
+	return &StoreInMemory{Storage: s}
+}
+
+func (s *StoreInMemory) Reserve(record Record) (Record, bool, error) {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	for key, v := range s.Storage.RecordMap {
+		if !v.ExpiresDate.After(record.CreatedDate) {
+			delete(s.Storage.RecordMap, key)
+		}
+	}
+
+	if existing, ok := s.Storage.RecordMap[record.Key]; ok {
+		return existing, false, nil
+	}
+
+	s.Storage.RecordMap[record.Key] = record
+
+	return record, true, nil
+}
+
+func (s *StoreInMemory) Complete(record Record) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	s.Storage.RecordMap[record.Key] = record
+
+	return nil
+}
+
+func (s *StoreInMemory) Release(key string) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	delete(s.Storage.RecordMap, key)
+
+	return nil
+}
+
+func (s *StoreInMemory) Refresh(key string, expiresDate time.Time) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	if record, ok := s.Storage.RecordMap[key]; ok && !record.Completed {
+		record.ExpiresDate = expiresDate
+		s.Storage.RecordMap[key] = record
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package idempotency
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type StoreMysql struct {
+	DB *sql.DB
+}
+
+func NewStoreMysql(db *sql.DB) Store {
+	return &StoreMysql{DB: db}
+}
+
+func (s *StoreMysql) Reserve(record Record) (Record, bool, error) {
+	_, err := s.DB.Exec(`DELETE FROM IDEMPOTENCY_RECORD WHERE EXPIRES_DATE <= ?`, record.CreatedDate)
+	if err != nil {
+		return Record{}, false, err
+	}
+
+	res, err := s.DB.Exec(`INSERT IGNORE INTO IDEMPOTENCY_RECORD
+		(IDEMPOTENCY_KEY, REQUEST_HASH, COMPLETED, STATUS_CODE, CONTENT_TYPE, BODY, CREATED_DATE, EXPIRES_DATE)
+		VALUES (?, ?, 0, 0, '', '', ?, ?)`,
+		record.Key,
+		record.RequestHash,
+		record.CreatedDate,
+		record.ExpiresDate)
+	if err != nil {
+		return Record{}, false, err
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return Record{}, false, err
+	}
+
+	if inserted == 1 {
+		return record, true, nil
+	}
+
+	existing := Record{Key: record.Key}
+
+	err = s.DB.QueryRow(`SELECT REQUEST_HASH, COMPLETED, STATUS_CODE, CONTENT_TYPE, BODY, CREATED_DATE, EXPIRES_DATE
+		FROM IDEMPOTENCY_RECORD WHERE IDEMPOTENCY_KEY = ?`, record.Key).Scan(
+		&existing.RequestHash,
+		&existing.Completed,
+		&existing.StatusCode,
+		&existing.ContentType,
+		&existing.Body,
+		&existing.CreatedDate,
+		&existing.ExpiresDate,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Released since, it's reserved on the next try.
+		return Record{Key: record.Key, RequestHash: record.RequestHash}, false, nil
+	}
+
+	if err != nil {
+		return Record{}, false, err
+	}
+
+	return existing, false, nil
+}
+
+func (s *StoreMysql) Complete(record Record) error {
+	_, err := s.DB.Exec(`UPDATE IDEMPOTENCY_RECORD
+		SET COMPLETED = 1, STATUS_CODE = ?, CONTENT_TYPE = ?, BODY = ?, EXPIRES_DATE = ?
+		WHERE IDEMPOTENCY_KEY = ?`,
+		record.StatusCode,
+		record.ContentType,
+		record.Body,
+		record.ExpiresDate,
+		record.Key)
+
+	return err
+}
+
+func (s *StoreMysql) Release(key string) error {
+	_, err := s.DB.Exec(`DELETE FROM IDEMPOTENCY_RECORD WHERE IDEMPOTENCY_KEY = ?`, key)
+
+	return err
+}
+
+func (s *StoreMysql) Refresh(key string, expiresDate time.Time) error {
+	_, err := s.DB.Exec(`UPDATE IDEMPOTENCY_RECORD SET EXPIRES_DATE = ? WHERE IDEMPOTENCY_KEY = ? AND COMPLETED = 0`,
+		expiresDate, key)
+
+	return err
+}
@@ -0,0 +1,127 @@
+// Package info describes the running server, so the clients can adapt to its version and capabilities.
+package info
+
+import (
+	"sort"
+	"sync"
+)
+
+// Version and Commit are injected at build time, e.g.
+// go build -ldflags "-X github.com/usetania/tania-core/src/info.Version=1.8.0 -X github.com/usetania/tania-core/src/info.Commit=$(git rev-parse --short HEAD)".
+//
+//nolint:gochecknoglobals
+var (
+	Version = "dev"
+	Commit  = "unknown"
+)
+
+// APIVersions are the API versions served under /api.
+//
+//nolint:gochecknoglobals
+var APIVersions = []string{"1"}
+
+// Registry collects the features and background jobs the modules set up at startup.
+type Registry struct {
+	lock       sync.RWMutex
+	features   map[string]bool
+	jobs       map[string]bool
+	jobReports map[string]JobReport
+}
+
+// JobReport returns the report of the last run of a job, false before its first run.
+type JobReport func() (interface{}, bool)
+
+// Job is a registered background job, listed by GET /api/admin/jobs.
+type Job struct {
+	Name       string      `json:"name"`
+	Enabled    bool        `json:"enabled"`
+	LastReport interface{} `json:"last_report"`
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		features:   make(map[string]bool),
+		jobs:       make(map[string]bool),
+		jobReports: make(map[string]JobReport),
+	}
+}
+
+// RegisterFeature records whether an optional feature is enabled.
+func (r *Registry) RegisterFeature(name string, enabled bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.features[name] = enabled
+}
+
+// RegisterJob records whether a background job is running.
+func (r *Registry) RegisterJob(name string, enabled bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.jobs[name] = enabled
+}
+
+// RegisterJobReport exposes the last report of a registered job.
+func (r *Registry) RegisterJobReport(name string, report JobReport) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.jobReports[name] = report
+}
+
+// Features returns the names of the enabled features, sorted.
+func (r *Registry) Features() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return enabled(r.features)
+}
+
+// Jobs returns the names of the running jobs, sorted.
+func (r *Registry) Jobs() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return enabled(r.jobs)
+}
+
+// AllJobs returns the registered jobs, the disabled ones too, sorted by name.
+func (r *Registry) AllJobs() []Job {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	jobs := []Job{}
+
+	for name, ok := range r.jobs {
+		job := Job{Name: name, Enabled: ok}
+
+		if report, registered := r.jobReports[name]; registered {
+			if last, found := report(); found {
+				job.LastReport = last
+			}
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Name < jobs[j].Name
+	})
+
+	return jobs
+}
+
+func enabled(registered map[string]bool) []string {
+	names := []string{}
+
+	for name, ok := range registered {
+		if ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,98 @@
+package info
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/changefeed"
+	"github.com/usetania/tania-core/src/featureflags"
+	"github.com/usetania/tania-core/src/maintenance"
+)
+
+// Info is the body of GET /api/info. It's served without authentication,
+// so it must not contain any secret, host name or path of the configuration.
+type Info struct {
+	Version           string   `json:"version"`
+	Commit            string   `json:"commit"`
+	PersistenceEngine string   `json:"persistence_engine"`
+	DemoMode          bool     `json:"demo_mode"`
+	Features          []string `json:"features"`
+	Jobs              []string `json:"jobs"`
+	APIVersions       []string `json:"api_versions"`
+	Limits            Limits   `json:"limits"`
+	// MaintenanceMode is null unless the mode is on.
+	MaintenanceMode *maintenance.Mode `json:"maintenance_mode"`
+	// FeatureFlags are the names of the feature flags on.
+	FeatureFlags []string `json:"feature_flags"`
+}
+
+type Limits struct {
+	// MaxUploadSize is in bytes.
+	MaxUploadSize   int64 `json:"max_upload_size"`
+	MaxSyncPageSize int   `json:"max_sync_page_size"`
+}
+
+type Server struct {
+	Registry      *Registry
+	MaxUploadSize int64
+	Maintenance   *maintenance.Switch
+	FeatureFlags  *featureflags.Registry
+}
+
+func NewServer(registry *Registry, maxUploadSize int64) *Server {
+	return &Server{
+		Registry:      registry,
+		MaxUploadSize: maxUploadSize,
+	}
+}
+
+// Mount defines the info endpoint with its handler.
+func (s *Server) Mount(g *echo.Group) {
+	g.GET("", s.GetInfo)
+}
+
+// MountAdmin defines the jobs endpoint, for the admins, with its handler.
+func (s *Server) MountAdmin(g *echo.Group) {
+	g.GET("/jobs", s.GetJobs)
+}
+
+func (s *Server) GetJobs(c echo.Context) error {
+	data := make(map[string][]Job)
+	data["data"] = s.Registry.AllJobs()
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *Server) GetInfo(c echo.Context) error {
+	info := Info{
+		Version:           Version,
+		Commit:            Commit,
+		PersistenceEngine: *config.Config.TaniaPersistenceEngine,
+		DemoMode:          *config.Config.DemoMode,
+		Features:          s.Registry.Features(),
+		Jobs:              s.Registry.Jobs(),
+		APIVersions:       APIVersions,
+		Limits: Limits{
+			MaxUploadSize:   s.MaxUploadSize,
+			MaxSyncPageSize: changefeed.MaxPageSize,
+		},
+		FeatureFlags: []string{},
+	}
+
+	if s.Maintenance != nil {
+		if mode, ok := s.Maintenance.Active(time.Now()); ok {
+			info.MaintenanceMode = &mode
+		}
+	}
+
+	if s.FeatureFlags != nil {
+		info.FeatureFlags = s.FeatureFlags.EnabledNames()
+	}
+
+	data := make(map[string]Info)
+	data["data"] = info
+
+	return c.JSON(http.StatusOK, data)
+}
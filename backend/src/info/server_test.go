@@ -0,0 +1,151 @@
+package info_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/featureflags"
+	"github.com/usetania/tania-core/src/info"
+	"github.com/usetania/tania-core/src/maintenance"
+)
+
+func TestGetInfoExposesNoSecret(t *testing.T) {
+	// Given
+	engine := config.DBMysql
+	demoMode := false
+	secret := "s3cr3t-db.internal"
+	config.Config.TaniaPersistenceEngine = &engine
+	config.Config.DemoMode = &demoMode
+	config.Config.MysqlHost = &secret
+	config.Config.MysqlPassword = &secret
+	config.Config.SentryDSN = &secret
+
+	registry := info.NewRegistry()
+	registry.RegisterFeature("sentry", true)
+	registry.RegisterFeature("mqtt_events", false)
+	registry.RegisterJob("retention", true)
+
+	e := echo.New()
+	info.NewServer(registry, 1024).Mount(e.Group("/api/info"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/info", nil)
+	rec := httptest.NewRecorder()
+
+	// When
+	e.ServeHTTP(rec, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), secret)
+
+	body := struct {
+		Data info.Info `json:"data"`
+	}{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, config.DBMysql, body.Data.PersistenceEngine)
+	assert.Equal(t, []string{"sentry"}, body.Data.Features)
+	assert.Equal(t, []string{"retention"}, body.Data.Jobs)
+	assert.Equal(t, int64(1024), body.Data.Limits.MaxUploadSize)
+}
+
+func TestGetJobsListsTheLastReports(t *testing.T) {
+	t.Parallel()
+	// Given
+	registry := info.NewRegistry()
+	registry.RegisterJob("retention", false)
+	registry.RegisterJob("orphan_cleanup", true)
+	registry.RegisterJobReport("orphan_cleanup", func() (interface{}, bool) {
+		return map[string]int{"reclaimed_bytes": 2048}, true
+	})
+	registry.RegisterJobReport("retention", func() (interface{}, bool) {
+		return nil, false
+	})
+
+	e := echo.New()
+	info.NewServer(registry, 1024).MountAdmin(e.Group("/api/admin"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/jobs", nil)
+	rec := httptest.NewRecorder()
+
+	// When
+	e.ServeHTTP(rec, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"data": [
+		{"name": "orphan_cleanup", "enabled": true, "last_report": {"reclaimed_bytes": 2048}},
+		{"name": "retention", "enabled": false, "last_report": null}
+	]}`, rec.Body.String())
+}
+
+func TestGetInfoShowsTheMaintenanceMode(t *testing.T) {
+	// Given
+	engine := config.DBSqlite
+	demoMode := false
+	config.Config.TaniaPersistenceEngine = &engine
+	config.Config.DemoMode = &demoMode
+
+	modeSwitch, _ := maintenance.NewSwitch(maintenance.NewModeStoreInMemory(maintenance.CreateModeStorage()))
+	mode, _ := maintenance.NewMode("Restore", time.Hour, time.Now().UTC().Truncate(time.Second))
+	_ = modeSwitch.Enter(mode)
+
+	e := echo.New()
+	server := info.NewServer(info.NewRegistry(), 1024)
+	server.Maintenance = modeSwitch
+	server.Mount(e.Group("/api/info"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/info", nil)
+	rec := httptest.NewRecorder()
+
+	// When
+	e.ServeHTTP(rec, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	body := struct {
+		Data info.Info `json:"data"`
+	}{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, &mode, body.Data.MaintenanceMode)
+}
+
+func TestGetInfoListsTheFeatureFlagsOn(t *testing.T) {
+	// Given
+	engine := config.DBSqlite
+	demoMode := false
+	config.Config.TaniaPersistenceEngine = &engine
+	config.Config.DemoMode = &demoMode
+
+	flags, _ := featureflags.NewRegistry(featureflags.Flags(), map[string]string{
+		featureflags.Webhooks:      "false",
+		featureflags.MQTT:          "false",
+		featureflags.TaskTemplates: "false",
+	})
+
+	e := echo.New()
+	server := info.NewServer(info.NewRegistry(), 1024)
+	server.FeatureFlags = flags
+	server.Mount(e.Group("/api/info"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/info", nil)
+	rec := httptest.NewRecorder()
+
+	// When
+	e.ServeHTTP(rec, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	body := struct {
+		Data info.Info `json:"data"`
+	}{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, []string{featureflags.CropInsurance, featureflags.Equipment}, body.Data.FeatureFlags)
+}
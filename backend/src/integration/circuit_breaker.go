@@ -0,0 +1,237 @@
+// Package integration guards the calls to the external services, so an unreachable service fails fast
+// instead of blocking the goroutines calling it.
+package integration
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the service while its circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type State string
+
+const (
+	// StateClosed lets the calls through and counts their consecutive failures.
+	StateClosed State = "CLOSED"
+	// StateOpen fails the calls fast until the reset timeout is over.
+	StateOpen State = "OPEN"
+	// StateHalfOpen lets one trial call through, its result closes or opens the circuit again.
+	StateHalfOpen State = "HALF_OPEN"
+)
+
+// CircuitBreaker opens after FailureThreshold consecutive failed calls and tries again after ResetTimeout.
+type CircuitBreaker struct {
+	Name             string
+	FailureThreshold int
+	ResetTimeout     time.Duration
+
+	lock     sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// CircuitBreakerStatus is the state of a circuit breaker at a time.
+type CircuitBreakerStatus struct {
+	Name             string     `json:"name"`
+	State            State      `json:"state"`
+	Failures         int        `json:"failures"`
+	FailureThreshold int        `json:"failure_threshold"`
+	ResetTimeout     int        `json:"reset_timeout_seconds"`
+	OpenedAt         *time.Time `json:"opened_at"`
+}
+
+func NewCircuitBreaker(name string, failureThreshold int, resetTimeout time.Duration) *CircuitBreaker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+
+	return &CircuitBreaker{
+		Name:             name,
+		FailureThreshold: failureThreshold,
+		ResetTimeout:     resetTimeout,
+		state:            StateClosed,
+	}
+}
+
+// Execute runs the call unless the circuit is open, and records whether it failed. A call which panics is
+// recorded as a failure before the panic goes on, so the trial of a half open circuit doesn't stay running.
+func (b *CircuitBreaker) Execute(call func() error) error {
+	err := b.allow()
+	if err != nil {
+		return err
+	}
+
+	succeeded := false
+
+	defer func() {
+		b.record(succeeded)
+	}()
+
+	err = call()
+	succeeded = err == nil
+
+	return err
+}
+
+func (b *CircuitBreaker) allow() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.ResetTimeout {
+		b.state = StateHalfOpen
+	}
+
+	switch b.state {
+	case StateOpen:
+		return ErrCircuitOpen
+	case StateHalfOpen:
+		// The other calls fail fast while the trial call is running.
+		if b.trial {
+			return ErrCircuitOpen
+		}
+
+		b.trial = true
+	}
+
+	return nil
+}
+
+func (b *CircuitBreaker) record(success bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.trial = false
+
+	if success {
+		b.state = StateClosed
+		b.failures = 0
+
+		return
+	}
+
+	b.failures++
+
+	if b.state == StateHalfOpen || b.failures >= b.FailureThreshold {
+		b.state = StateOpen
+		b.openedAt = time.Now()
+	}
+}
+
+func (b *CircuitBreaker) Status() CircuitBreakerStatus {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	status := CircuitBreakerStatus{
+		Name:             b.Name,
+		State:            b.state,
+		Failures:         b.failures,
+		FailureThreshold: b.FailureThreshold,
+		ResetTimeout:     int(b.ResetTimeout.Seconds()),
+	}
+
+	if b.state != StateClosed {
+		openedAt := b.openedAt
+		status.OpenedAt = &openedAt
+	}
+
+	return status
+}
+
+// Transport wraps the round trips of an HTTP client in the circuit breaker. The connection errors and
+// the 5xx responses are failures, the other responses are left to the client. A nil next uses
+// http.DefaultTransport.
+func (b *CircuitBreaker) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &transport{breaker: b, next: next}
+}
+
+type transport struct {
+	breaker *CircuitBreaker
+	next    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var res *http.Response
+
+	err := t.breaker.Execute(func() error {
+		var err error
+
+		res, err = t.next.RoundTrip(req)
+		if err != nil {
+			return err
+		}
+
+		if res.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("%s responded %s", t.breaker.Name, res.Status)
+		}
+
+		return nil
+	})
+	if errors.Is(err, ErrCircuitOpen) {
+		return nil, fmt.Errorf("%s: %w", t.breaker.Name, err)
+	}
+
+	// The 5xx responses are still returned, the clients report them like before.
+	if res != nil {
+		return res, nil
+	}
+
+	return nil, err
+}
+
+// Registry holds the circuit breaker of every integration, all with the same configuration.
+type Registry struct {
+	FailureThreshold int
+	ResetTimeout     time.Duration
+
+	lock     sync.RWMutex
+	breakers map[string]*CircuitBreaker
+}
+
+func NewRegistry(failureThreshold int, resetTimeout time.Duration) *Registry {
+	return &Registry{
+		FailureThreshold: failureThreshold,
+		ResetTimeout:     resetTimeout,
+		breakers:         make(map[string]*CircuitBreaker),
+	}
+}
+
+// Breaker returns the circuit breaker of the integration, created on the first call.
+func (r *Registry) Breaker(name string) *CircuitBreaker {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	breaker, ok := r.breakers[name]
+	if !ok {
+		breaker = NewCircuitBreaker(name, r.FailureThreshold, r.ResetTimeout)
+		r.breakers[name] = breaker
+	}
+
+	return breaker
+}
+
+// Statuses returns the state of every circuit breaker, sorted by integration name.
+func (r *Registry) Statuses() []CircuitBreakerStatus {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	statuses := []CircuitBreakerStatus{}
+	for _, v := range r.breakers {
+		statuses = append(statuses, v.Status())
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+
+	return statuses
+}
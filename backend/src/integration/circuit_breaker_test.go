@@ -0,0 +1,157 @@
+package integration_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/integration"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	breaker := integration.NewCircuitBreaker("weather", 2, 50*time.Millisecond)
+	errDown := errors.New("service down")
+	calls := 0
+	failing := func() error {
+		calls++
+
+		return errDown
+	}
+
+	// When
+	err1 := breaker.Execute(failing)
+	err2 := breaker.Execute(failing)
+	err3 := breaker.Execute(failing)
+
+	// Then
+	assert.Equal(t, errDown, err1)
+	assert.Equal(t, errDown, err2)
+	assert.ErrorIs(t, err3, integration.ErrCircuitOpen)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, integration.StateOpen, breaker.Status().State)
+	assert.NotNil(t, breaker.Status().OpenedAt)
+
+	// When
+	time.Sleep(60 * time.Millisecond)
+
+	errTrial := breaker.Execute(failing)
+	errAfterTrial := breaker.Execute(failing)
+
+	// Then
+	assert.Equal(t, errDown, errTrial)
+	assert.ErrorIs(t, errAfterTrial, integration.ErrCircuitOpen)
+	assert.Equal(t, 3, calls)
+
+	// When
+	time.Sleep(60 * time.Millisecond)
+
+	err := breaker.Execute(func() error { return nil })
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, integration.StateClosed, breaker.Status().State)
+	assert.Equal(t, 0, breaker.Status().Failures)
+	assert.Nil(t, breaker.Status().OpenedAt)
+}
+
+func TestCircuitBreakerPanickingTrial(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	breaker := integration.NewCircuitBreaker("webhook", 1, 20*time.Millisecond)
+	_ = breaker.Execute(func() error { return errors.New("service down") })
+
+	time.Sleep(30 * time.Millisecond)
+
+	// When
+	assert.Panics(t, func() {
+		_ = breaker.Execute(func() error { panic("malformed response") })
+	})
+
+	// Then
+	assert.Equal(t, integration.StateOpen, breaker.Status().State)
+
+	// When
+	time.Sleep(30 * time.Millisecond)
+
+	err := breaker.Execute(func() error { return nil })
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, integration.StateClosed, breaker.Status().State)
+}
+
+func TestCircuitBreakerTransport(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	status := http.StatusBadGateway
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	breaker := integration.NewCircuitBreaker("webhook", 1, time.Minute)
+	client := &http.Client{Transport: breaker.Transport(nil)}
+
+	// When
+	res, err := client.Get(server.URL)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadGateway, res.StatusCode)
+	res.Body.Close()
+
+	// When
+	_, err = client.Get(server.URL) //nolint:bodyclose
+
+	// Then
+	assert.ErrorIs(t, err, integration.ErrCircuitOpen)
+	assert.Equal(t, 1, requests)
+
+	// Given
+	status = http.StatusBadRequest
+	clientErrors := integration.NewCircuitBreaker("twilio", 1, time.Minute)
+	client = &http.Client{Transport: clientErrors.Transport(nil)}
+
+	// When
+	res, err = client.Get(server.URL)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, integration.StateClosed, clientErrors.Status().State)
+	res.Body.Close()
+}
+
+func TestRegistryStatuses(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	registry := integration.NewRegistry(5, 30*time.Second)
+	webhook := registry.Breaker("webhook")
+	registry.Breaker("sentry")
+
+	// When
+	again := registry.Breaker("webhook")
+	statuses := registry.Statuses()
+
+	// Then
+	assert.Same(t, webhook, again)
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "sentry", statuses[0].Name)
+	assert.Equal(t, "webhook", statuses[1].Name)
+	assert.Equal(t, 5, statuses[1].FailureThreshold)
+	assert.Equal(t, 30, statuses[1].ResetTimeout)
+	assert.Equal(t, integration.StateClosed, statuses[1].State)
+}
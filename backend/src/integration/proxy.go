@@ -0,0 +1,80 @@
+package integration
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ErrInvalidProxyURL is a proxy URL which isn't an http or https URL with a host.
+var ErrInvalidProxyURL = errors.New("http_proxy_url should be an http or https URL")
+
+// NewProxyTransport returns the transport of the calls to the external services through the proxy, for both
+// the http and the https URLs. The hosts of NO_PROXY and the loopback addresses are called directly.
+// The PEM certificates of caPath are trusted with the system ones, for the proxies re-signing the TLS traffic.
+// Without a proxy URL it returns nil, the transports then use http.DefaultTransport.
+func NewProxyTransport(proxyURL, caPath string) (http.RoundTripper, error) {
+	if proxyURL == "" {
+		return nil, nil
+	}
+
+	proxy, err := url.Parse(proxyURL)
+	if err != nil || (proxy.Scheme != "http" && proxy.Scheme != "https") || proxy.Host == "" {
+		return nil, ErrInvalidProxyURL
+	}
+
+	proxyConfig := httpproxy.Config{
+		HTTPProxy:  proxy.String(),
+		HTTPSProxy: proxy.String(),
+		NoProxy:    noProxy(),
+	}
+	proxyFunc := proxyConfig.ProxyFunc()
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+
+	if caPath != "" {
+		pool, err := certPool(caPath)
+		if err != nil {
+			return nil, err
+		}
+
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	return transport, nil
+}
+
+func noProxy() string {
+	if v := os.Getenv("NO_PROXY"); v != "" {
+		return v
+	}
+
+	return os.Getenv("no_proxy")
+}
+
+// certPool adds the certificates of the file to the system ones, to a new pool when the system has none.
+func certPool(caPath string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	pem, err := os.ReadFile(caPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no certificate found in " + caPath)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,91 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/integration"
+)
+
+func TestNewProxyTransport(t *testing.T) {
+	// Given
+	t.Setenv("NO_PROXY", "internal.example.com,10.0.0.0/8")
+
+	transport, err := integration.NewProxyTransport("http://proxy.example.com:3128", "")
+	assert.Nil(t, err)
+
+	proxyOf := func(target string) *url.URL {
+		req, err := http.NewRequest(http.MethodGet, target, nil)
+		assert.Nil(t, err)
+
+		proxy, err := transport.(*http.Transport).Proxy(req)
+		assert.Nil(t, err)
+
+		return proxy
+	}
+
+	// When
+	httpProxy := proxyOf("http://hooks.example.org/tasks")
+	httpsProxy := proxyOf("https://sentry.io/api/1/envelope/")
+	internal := proxyOf("https://app.internal.example.com/hook")
+	privateIP := proxyOf("http://10.1.2.3/hook")
+	localhost := proxyOf("http://localhost:8080/hook")
+
+	// Then
+	assert.Equal(t, "proxy.example.com:3128", httpProxy.Host)
+	assert.Equal(t, "proxy.example.com:3128", httpsProxy.Host)
+	assert.Nil(t, internal)
+	assert.Nil(t, privateIP)
+	assert.Nil(t, localhost)
+}
+
+func TestNewProxyTransportGoesThroughTheProxy(t *testing.T) {
+	// Given
+	t.Setenv("NO_PROXY", "")
+
+	proxied := ""
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	transport, err := integration.NewProxyTransport(proxy.URL, "")
+	assert.Nil(t, err)
+
+	client := &http.Client{Transport: transport}
+
+	// When
+	res, err := client.Post("http://hooks.example.org/tasks", "application/json", nil)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Nil(t, res.Body.Close())
+	assert.Equal(t, "http://hooks.example.org/tasks", proxied)
+}
+
+func TestNewProxyTransportErrors(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	assert.Nil(t, os.WriteFile(caPath, []byte("not a certificate"), 0o600))
+
+	// When
+	none, noneErr := integration.NewProxyTransport("", "")
+	_, schemeErr := integration.NewProxyTransport("socks5://proxy.example.com:1080", "")
+	_, hostErr := integration.NewProxyTransport("proxy.example.com:3128", "")
+	_, caErr := integration.NewProxyTransport("http://proxy.example.com:3128", caPath)
+
+	// Then
+	assert.Nil(t, none)
+	assert.Nil(t, noneErr)
+	assert.Equal(t, integration.ErrInvalidProxyURL, schemeErr)
+	assert.Equal(t, integration.ErrInvalidProxyURL, hostErr)
+	assert.NotNil(t, caErr)
+}
@@ -0,0 +1,27 @@
+package integration
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Server struct {
+	Registry *Registry
+}
+
+func NewServer(registry *Registry) *Server {
+	return &Server{Registry: registry}
+}
+
+// Mount defines the circuit breakers admin endpoint with its handler.
+func (s *Server) Mount(g *echo.Group) {
+	g.GET("/circuit-breakers", s.GetCircuitBreakers)
+}
+
+func (s *Server) GetCircuitBreakers(c echo.Context) error {
+	data := make(map[string][]CircuitBreakerStatus)
+	data["data"] = s.Registry.Statuses()
+
+	return c.JSON(http.StatusOK, data)
+}
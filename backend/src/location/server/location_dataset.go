@@ -0,0 +1,72 @@
+package location
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pariz/gountries"
+)
+
+// City is a city of a country. The dataset has the subdivisions of the countries, like the provinces,
+// so they are the cities of the farms.
+type City struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// IsCountry tells whether the code is the ISO 3166-1 alpha-2 code of a country.
+func IsCountry(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+
+	_, err := gountries.New().FindCountryByAlpha(code)
+
+	return err == nil
+}
+
+// FindCities lists the cities of the country whose name or code contains q, sorted by name.
+func FindCities(countryCode, q string) ([]City, error) {
+	if !IsCountry(countryCode) {
+		return nil, Error{LocationErrorInvalidCountryCode}
+	}
+
+	country, _ := gountries.New().FindCountryByAlpha(countryCode)
+	q = strings.ToLower(strings.TrimSpace(q))
+	cities := []City{}
+
+	for _, v := range country.SubDivisions() {
+		if q != "" && !strings.Contains(strings.ToLower(v.Name), q) && strings.ToLower(v.Code) != q {
+			continue
+		}
+
+		cities = append(cities, City{ID: v.Code, Name: v.Name})
+	}
+
+	sort.Slice(cities, func(i, j int) bool {
+		return cities[i].Name < cities[j].Name
+	})
+
+	return cities, nil
+}
+
+// IsCityOf tells whether the city, its name or its code, belongs to the country.
+// The dataset has no cities of some countries, any city of those belongs to them.
+func IsCityOf(countryCode, city string) bool {
+	if !IsCountry(countryCode) {
+		return false
+	}
+
+	country, _ := gountries.New().FindCountryByAlpha(countryCode)
+	if len(country.SubDivisions()) == 0 {
+		return true
+	}
+
+	if _, err := country.FindSubdivisionByName(city); err == nil {
+		return true
+	}
+
+	_, err := country.FindSubdivisionByCode(city)
+
+	return err == nil
+}
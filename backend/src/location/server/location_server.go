@@ -3,6 +3,7 @@ package location
 import (
 	"net/http"
 	"sort"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"github.com/pariz/gountries"
@@ -22,6 +23,7 @@ func NewServer() (*Server, error) {
 
 func (*Server) Mount(g *echo.Group) {
 	g.GET("/countries", LocationsGetCountries)
+	g.GET("/cities", LocationsGetCities)
 }
 
 // LocationsGetCountries displays all available location in Tania.
@@ -43,3 +45,17 @@ func LocationsGetCountries(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, countries)
 }
+
+// LocationsGetCities is the typeahead of the cities of the country query param, filtered by the q query param.
+func LocationsGetCities(c echo.Context) error {
+	cities, err := FindCities(c.QueryParam("country"), c.QueryParam("q"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"field_name":    "country",
+			"error_code":    strconv.Itoa(LocationErrorInvalidCountryCode),
+			"error_message": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, cities)
+}
@@ -0,0 +1,50 @@
+package maintenance
+
+import "github.com/sasha-s/go-deadlock"
+
+// ModeStorage keeps the mode of the inmemory engine.
+type ModeStorage struct {
+	Lock *deadlock.RWMutex
+	Mode *Mode
+}
+
+func CreateModeStorage() *ModeStorage {
+	return &ModeStorage{Lock: &deadlock.RWMutex{}}
+}
+
+type ModeStoreInMemory struct {
+	Storage *ModeStorage
+}
+
+func NewModeStoreInMemory(s *ModeStorage) ModeStore {
+	return &ModeStoreInMemory{Storage: s}
+}
+
+func (s *ModeStoreInMemory) Find() (Mode, bool, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	if s.Storage.Mode == nil {
+		return Mode{}, false, nil
+	}
+
+	return *s.Storage.Mode, true, nil
+}
+
+func (s *ModeStoreInMemory) Save(mode Mode) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	s.Storage.Mode = &mode
+
+	return nil
+}
+
+func (s *ModeStoreInMemory) Remove() error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	s.Storage.Mode = nil
+
+	return nil
+}
@@ -0,0 +1,112 @@
+// Package maintenance warns the API consumers of a planned downtime, like a database migration.
+// During the window of the notice, every response has its message and the readiness check fails.
+// The maintenance mode goes further, it refuses the writes while an operation on the data runs.
+package maintenance
+
+import (
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sasha-s/go-deadlock"
+)
+
+// HeaderMaintenanceNotice is set on the responses during the window, with the message of the notice.
+const HeaderMaintenanceNotice = "X-Maintenance-Notice"
+
+type ValidationError struct {
+	Field string
+}
+
+func (e ValidationError) Error() string {
+	return "invalid maintenance notice " + e.Field
+}
+
+// Notice is a planned downtime, from StartsAt until EndsAt.
+type Notice struct {
+	Message     string    `json:"message"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	CreatedDate time.Time `json:"created_date"`
+}
+
+// NewNotice validates the notice. Its message is sent in a header, so it's kept on a single line.
+func NewNotice(message string, startsAt, endsAt, createdDate time.Time) (Notice, error) {
+	message = strings.Join(strings.Fields(message), " ")
+	if message == "" {
+		return Notice{}, ValidationError{Field: "message"}
+	}
+
+	if startsAt.IsZero() {
+		return Notice{}, ValidationError{Field: "starts_at"}
+	}
+
+	if !endsAt.After(startsAt) {
+		return Notice{}, ValidationError{Field: "ends_at"}
+	}
+
+	return Notice{Message: message, StartsAt: startsAt, EndsAt: endsAt, CreatedDate: createdDate}, nil
+}
+
+// InWindow tells if the date is in the window of the notice, its end excluded.
+func (n Notice) InWindow(date time.Time) bool {
+	return !date.Before(n.StartsAt) && date.Before(n.EndsAt)
+}
+
+// MaintenanceNoticeStorage keeps the notice, there is at most one at a time.
+type MaintenanceNoticeStorage struct {
+	Lock   *deadlock.RWMutex
+	Notice *Notice
+}
+
+func CreateMaintenanceNoticeStorage() *MaintenanceNoticeStorage {
+	return &MaintenanceNoticeStorage{Lock: &deadlock.RWMutex{}}
+}
+
+// Save replaces the notice.
+func (s *MaintenanceNoticeStorage) Save(notice Notice) {
+	s.Lock.Lock()
+	defer s.Lock.Unlock()
+
+	s.Notice = &notice
+}
+
+// Remove deletes the notice and returns it, false when there was none.
+func (s *MaintenanceNoticeStorage) Remove() (Notice, bool) {
+	s.Lock.Lock()
+	defer s.Lock.Unlock()
+
+	if s.Notice == nil {
+		return Notice{}, false
+	}
+
+	notice := *s.Notice
+	s.Notice = nil
+
+	return notice, true
+}
+
+// Active returns the notice when the date is in its window.
+func (s *MaintenanceNoticeStorage) Active(date time.Time) (Notice, bool) {
+	s.Lock.RLock()
+	defer s.Lock.RUnlock()
+
+	if s.Notice == nil || !s.Notice.InWindow(date) {
+		return Notice{}, false
+	}
+
+	return *s.Notice, true
+}
+
+// Middleware sets the header of the notice on the responses during its window.
+func Middleware(storage *MaintenanceNoticeStorage) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if notice, ok := storage.Active(time.Now()); ok {
+				c.Response().Header().Set(HeaderMaintenanceNotice, notice.Message)
+			}
+
+			return next(c)
+		}
+	}
+}
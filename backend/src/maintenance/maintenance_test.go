@@ -0,0 +1,174 @@
+package maintenance_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/maintenance"
+)
+
+func TestMaintenanceNoticeWindow(t *testing.T) {
+	t.Parallel()
+	// Given
+	storage := CreateMaintenanceNoticeStorage()
+	startsAt := time.Date(2026, time.October, 14, 22, 0, 0, 0, time.UTC)
+	endsAt := startsAt.Add(2 * time.Hour)
+
+	// When
+	_, errMessage := NewNotice(" ", startsAt, endsAt, startsAt)
+	_, errEnd := NewNotice("Database migration", startsAt, startsAt, startsAt)
+	notice, err := NewNotice("Database\nmigration  tonight", startsAt, endsAt, startsAt)
+
+	storage.Save(notice)
+
+	// Then
+	assert.Equal(t, ValidationError{Field: "message"}, errMessage)
+	assert.Equal(t, ValidationError{Field: "ends_at"}, errEnd)
+	assert.Nil(t, err)
+	assert.Equal(t, "Database migration tonight", notice.Message)
+
+	_, before := storage.Active(startsAt.Add(-time.Second))
+	during, inWindow := storage.Active(startsAt)
+	_, after := storage.Active(endsAt)
+
+	assert.False(t, before)
+	assert.True(t, inWindow)
+	assert.Equal(t, notice, during)
+	assert.False(t, after)
+
+	removed, found := storage.Remove()
+	_, foundAgain := storage.Remove()
+	_, inWindowAfterRemove := storage.Active(startsAt)
+
+	assert.True(t, found)
+	assert.Equal(t, notice, removed)
+	assert.False(t, foundAgain)
+	assert.False(t, inWindowAfterRemove)
+}
+
+func TestMaintenanceModeSurvivesTheSwitch(t *testing.T) {
+	t.Parallel()
+	// Given
+	store := NewModeStoreInMemory(CreateModeStorage())
+	startedDate := time.Now()
+
+	modeSwitch, err := NewSwitch(store)
+	assert.Nil(t, err)
+
+	// When
+	_, errReason := NewMode("\n", time.Hour, startedDate)
+	_, errDuration := NewMode("Restore", 25*time.Hour, startedDate)
+	mode, errMode := NewMode("Restore  of the\nbackup", 30*time.Minute, startedDate)
+
+	errEnter := modeSwitch.Enter(mode)
+	restarted, errRestart := NewSwitch(store)
+
+	// Then
+	assert.Equal(t, ModeValidationError{Field: "reason"}, errReason)
+	assert.Equal(t, ModeValidationError{Field: "duration_minutes"}, errDuration)
+	assert.Nil(t, errMode)
+	assert.Equal(t, "Restore of the backup", mode.Reason)
+	assert.Nil(t, errEnter)
+	assert.Nil(t, errRestart)
+
+	active, on := restarted.Active(startedDate.Add(time.Minute))
+	_, onAfterEnd := restarted.Active(mode.EndsAt)
+
+	assert.True(t, on)
+	assert.Equal(t, mode, active)
+	assert.False(t, onAfterEnd)
+	assert.True(t, restarted.Paused())
+
+	// When
+	errLeave := modeSwitch.Leave()
+	errReload := restarted.Reload()
+
+	// Then
+	assert.Nil(t, errLeave)
+	assert.Nil(t, errReload)
+	assert.False(t, restarted.Paused())
+}
+
+func TestWriteGuard(t *testing.T) {
+	t.Parallel()
+	// Given
+	modeSwitch, _ := NewSwitch(NewModeStoreInMemory(CreateModeStorage()))
+	mode, _ := NewMode("Restore", 10*time.Minute, time.Now())
+	_ = modeSwitch.Enter(mode)
+
+	e := echo.New()
+	e.Use(WriteGuard(modeSwitch, "/api/admin/maintenance"))
+
+	ok := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+	e.GET("/api/farms", ok)
+	e.POST("/api/farms", ok)
+	e.POST("/api/admin/maintenance", ok)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+
+		return rec
+	}
+
+	// When
+	read := serve(http.MethodGet, "/api/farms")
+	write := serve(http.MethodPost, "/api/farms")
+	leave := serve(http.MethodPost, "/api/admin/maintenance")
+
+	// Then
+	assert.Equal(t, http.StatusOK, read.Code)
+	assert.Equal(t, http.StatusOK, leave.Code)
+	assert.Equal(t, http.StatusServiceUnavailable, write.Code)
+	assert.Equal(t, "600", write.Header().Get("Retry-After"))
+	assert.Contains(t, write.Body.String(), `"error_code":"MAINTENANCE_MODE"`)
+	assert.Contains(t, write.Body.String(), "Restore")
+
+	// When
+	_ = modeSwitch.Leave()
+	written := serve(http.MethodPost, "/api/farms")
+
+	// Then
+	assert.Equal(t, http.StatusOK, written.Code)
+}
+
+func TestDuringKeepsTheModeOnAlready(t *testing.T) {
+	t.Parallel()
+	// Given
+	modeSwitch, _ := NewSwitch(NewModeStoreInMemory(CreateModeStorage()))
+
+	// When
+	onDuringRun := false
+	err := modeSwitch.During("Rebuild", time.Hour, func() error {
+		onDuringRun = modeSwitch.Paused()
+
+		return nil
+	})
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, onDuringRun)
+	assert.False(t, modeSwitch.Paused())
+
+	// Given
+	restore, _ := NewMode("Restore", time.Hour, time.Now())
+	_ = modeSwitch.Enter(restore)
+
+	// When
+	errRun := modeSwitch.During("Rebuild", time.Hour, func() error {
+		return errors.New("rebuild failed")
+	})
+	active, on := modeSwitch.Active(time.Now())
+
+	// Then
+	assert.EqualError(t, errRun, "rebuild failed")
+	assert.True(t, on)
+	assert.Equal(t, restore, active)
+}
@@ -0,0 +1,238 @@
+package maintenance
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sasha-s/go-deadlock"
+)
+
+const (
+	// MaxModeDuration is the longest time box of the maintenance mode, so a forgotten mode ends by itself.
+	MaxModeDuration = 24 * time.Hour
+
+	modeReloadInterval = 5 * time.Second
+)
+
+type ModeValidationError struct {
+	Field string
+}
+
+func (e ModeValidationError) Error() string {
+	return "invalid maintenance mode " + e.Field
+}
+
+// Mode is the maintenance mode, from StartedDate until EndsAt. While it's on, the API refuses the requests
+// changing data and the background jobs writing pause, like during a restore or an import.
+type Mode struct {
+	Reason      string    `json:"reason"`
+	StartedDate time.Time `json:"started_date"`
+	EndsAt      time.Time `json:"ends_at"`
+}
+
+// NewMode validates the mode, time boxed by its duration. Its reason is sent in the error bodies,
+// so it's kept on a single line.
+func NewMode(reason string, duration time.Duration, startedDate time.Time) (Mode, error) {
+	reason = strings.Join(strings.Fields(reason), " ")
+	if reason == "" {
+		return Mode{}, ModeValidationError{Field: "reason"}
+	}
+
+	if duration <= 0 || duration > MaxModeDuration {
+		return Mode{}, ModeValidationError{Field: "duration_minutes"}
+	}
+
+	return Mode{Reason: reason, StartedDate: startedDate, EndsAt: startedDate.Add(duration)}, nil
+}
+
+// On tells if the mode is on at the date, its end excluded.
+func (m Mode) On(date time.Time) bool {
+	return !date.Before(m.StartedDate) && date.Before(m.EndsAt)
+}
+
+// ModeStore persists the mode, so it survives a restart and is shared with the commands.
+// There is at most one at a time.
+type ModeStore interface {
+	// Find returns the mode, false when there is none.
+	Find() (Mode, bool, error)
+	Save(mode Mode) error
+	Remove() error
+}
+
+// Switch keeps the mode of the store in memory for the checks of every request. The server reloads it
+// periodically, to see the mode a command entered in the same database.
+type Switch struct {
+	Store ModeStore
+	lock  deadlock.RWMutex
+	mode  *Mode
+}
+
+// NewSwitch loads the mode of the store.
+func NewSwitch(store ModeStore) (*Switch, error) {
+	s := &Switch{Store: store}
+
+	return s, s.Reload()
+}
+
+// Reload replaces the mode in memory by the one of the store.
+func (s *Switch) Reload() error {
+	mode, found, err := s.Store.Find()
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.mode = nil
+	if found {
+		s.mode = &mode
+	}
+
+	return nil
+}
+
+// StartReloads reloads the mode every few seconds.
+func (s *Switch) StartReloads() {
+	ticker := time.NewTicker(modeReloadInterval)
+
+	go func() {
+		for {
+			<-ticker.C
+
+			if err := s.Reload(); err != nil {
+				log.Println("Maintenance mode reload failed", err)
+			}
+		}
+	}()
+}
+
+// Active returns the mode when it's on at the date.
+func (s *Switch) Active(date time.Time) (Mode, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mode == nil || !s.mode.On(date) {
+		return Mode{}, false
+	}
+
+	return *s.mode, true
+}
+
+// Paused tells the background jobs writing to skip their run.
+func (s *Switch) Paused() bool {
+	_, on := s.Active(time.Now())
+
+	return on
+}
+
+// Enter saves the mode, replacing the current one.
+func (s *Switch) Enter(mode Mode) error {
+	if err := s.Store.Save(mode); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.mode = &mode
+
+	return nil
+}
+
+// Leave removes the mode.
+func (s *Switch) Leave() error {
+	if err := s.Store.Remove(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.mode = nil
+
+	return nil
+}
+
+// During enters the mode for the run, then leaves it. A mode on already is kept as it is, the run doesn't
+// end it early. The duration only bounds the mode of a run that never returns, like a killed command.
+func (s *Switch) During(reason string, duration time.Duration, run func() error) error {
+	now := time.Now()
+	if _, on := s.Active(now); on {
+		return run()
+	}
+
+	mode, err := NewMode(reason, duration, now)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Enter(mode); err != nil {
+		return err
+	}
+
+	runErr := run()
+
+	if err := s.Leave(); err != nil {
+		log.Println("Leaving the maintenance mode failed", err)
+	}
+
+	return runErr
+}
+
+// Hold runs the requests, like an import, in the mode.
+func (s *Switch) Hold(reason string, duration time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return s.During(reason, duration, func() error {
+				return next(c)
+			})
+		}
+	}
+}
+
+// WriteGuard refuses the POST, PUT, PATCH and DELETE requests with 503 Service Unavailable while the mode is on,
+// with a Retry-After until its end. The reads, the health checks and the allowed paths, like the sign in,
+// keep working.
+func WriteGuard(s *Switch, allowedPaths ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			switch c.Request().Method {
+			case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			default:
+				return next(c)
+			}
+
+			path := strings.TrimSuffix(c.Request().URL.Path, "/")
+			for _, v := range allowedPaths {
+				if path == v {
+					return next(c)
+				}
+			}
+
+			now := time.Now()
+
+			mode, on := s.Active(now)
+			if !on {
+				return next(c)
+			}
+
+			retryAfter := int(math.Ceil(mode.EndsAt.Sub(now).Seconds()))
+			c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+
+			message := "Tania is in the maintenance mode until " + mode.EndsAt.Format(time.RFC3339) +
+				", its data can't be changed: " + mode.Reason
+
+			return c.JSON(http.StatusServiceUnavailable, map[string]string{
+				"field_name":    "",
+				"error_code":    "MAINTENANCE_MODE",
+				"error_message": message,
+			})
+		}
+	}
+}
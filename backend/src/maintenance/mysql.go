@@ -0,0 +1,45 @@
+package maintenance
+
+import (
+	"database/sql"
+	"errors"
+)
+
+type ModeStoreMysql struct {
+	DB *sql.DB
+}
+
+func NewModeStoreMysql(db *sql.DB) ModeStore {
+	return &ModeStoreMysql{DB: db}
+}
+
+func (s *ModeStoreMysql) Find() (Mode, bool, error) {
+	mode := Mode{}
+
+	err := s.DB.QueryRow(`SELECT REASON, STARTED_DATE, ENDS_AT FROM MAINTENANCE_MODE WHERE ID = 1`).
+		Scan(&mode.Reason, &mode.StartedDate, &mode.EndsAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Mode{}, false, nil
+	}
+
+	if err != nil {
+		return Mode{}, false, err
+	}
+
+	return mode, true, nil
+}
+
+func (s *ModeStoreMysql) Save(mode Mode) error {
+	_, err := s.DB.Exec(`REPLACE INTO MAINTENANCE_MODE (ID, REASON, STARTED_DATE, ENDS_AT) VALUES (1, ?, ?, ?)`,
+		mode.Reason,
+		mode.StartedDate,
+		mode.EndsAt)
+
+	return err
+}
+
+func (s *ModeStoreMysql) Remove() error {
+	_, err := s.DB.Exec(`DELETE FROM MAINTENANCE_MODE`)
+
+	return err
+}
@@ -0,0 +1,174 @@
+package maintenance
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// The statuses of GET /api/health/ready.
+const (
+	StatusReady       = "ready"
+	StatusMaintenance = "maintenance"
+)
+
+// Readiness is the body of the readiness check. The notice is only set during its window.
+type Readiness struct {
+	Status string  `json:"status"`
+	Notice *Notice `json:"notice,omitempty"`
+}
+
+type Server struct {
+	Storage *MaintenanceNoticeStorage
+	Switch  *Switch
+}
+
+func NewServer(storage *MaintenanceNoticeStorage, modeSwitch *Switch) *Server {
+	return &Server{Storage: storage, Switch: modeSwitch}
+}
+
+// Mount defines the maintenance notice admin endpoints with their handlers.
+func (s *Server) Mount(g *echo.Group) {
+	g.POST("/maintenance-notice", s.SaveMaintenanceNotice)
+	g.DELETE("/maintenance-notice", s.RemoveMaintenanceNotice)
+	g.GET("/maintenance", s.GetMaintenanceMode)
+	g.POST("/maintenance", s.SaveMaintenanceMode)
+}
+
+// MountHealth defines the readiness endpoint, served without authentication for the load balancers.
+func (s *Server) MountHealth(g *echo.Group) {
+	g.GET("/ready", s.GetReadiness)
+}
+
+// SaveMaintenanceNotice replaces the notice with the message, starts_at and ends_at of the JSON body,
+// the dates in RFC3339.
+func (s *Server) SaveMaintenanceNotice(c echo.Context) error {
+	body := struct {
+		Message  string `json:"message" form:"message"`
+		StartsAt string `json:"starts_at" form:"starts_at"`
+		EndsAt   string `json:"ends_at" form:"ends_at"`
+	}{}
+
+	if err := c.Bind(&body); err != nil {
+		return badRequest(c, "", "PARSE_FAILED", "Parsing failed. Make sure the input is correct.")
+	}
+
+	startsAt, err := time.Parse(time.RFC3339, body.StartsAt)
+	if err != nil {
+		return badRequest(c, "starts_at", "PARSE_FAILED", "Parsing failed. Make sure the input is correct.")
+	}
+
+	endsAt, err := time.Parse(time.RFC3339, body.EndsAt)
+	if err != nil {
+		return badRequest(c, "ends_at", "PARSE_FAILED", "Parsing failed. Make sure the input is correct.")
+	}
+
+	notice, err := NewNotice(body.Message, startsAt, endsAt, time.Now())
+
+	var validationErr ValidationError
+	if errors.As(err, &validationErr) {
+		return badRequest(c, validationErr.Field, "INVALID_OPTION", validationErr.Error())
+	}
+
+	if err != nil {
+		return err
+	}
+
+	s.Storage.Save(notice)
+
+	return c.JSON(http.StatusOK, map[string]Notice{"data": notice})
+}
+
+func (s *Server) RemoveMaintenanceNotice(c echo.Context) error {
+	notice, ok := s.Storage.Remove()
+	if !ok {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"field_name":    "",
+			"error_code":    "NOT_FOUND",
+			"error_message": "There is no maintenance notice.",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]Notice{"data": notice})
+}
+
+// GetMaintenanceMode returns the mode while it's on, null otherwise.
+func (s *Server) GetMaintenanceMode(c echo.Context) error {
+	data := make(map[string]*Mode)
+
+	if mode, ok := s.Switch.Active(time.Now()); ok {
+		data["data"] = &mode
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// SaveMaintenanceMode enters the mode for the duration_minutes with the reason when enabled is true,
+// and leaves it when it's false.
+func (s *Server) SaveMaintenanceMode(c echo.Context) error {
+	body := struct {
+		Enabled         *bool  `json:"enabled" form:"enabled"`
+		Reason          string `json:"reason" form:"reason"`
+		DurationMinutes int    `json:"duration_minutes" form:"duration_minutes"`
+	}{}
+
+	if err := c.Bind(&body); err != nil {
+		return badRequest(c, "", "PARSE_FAILED", "Parsing failed. Make sure the input is correct.")
+	}
+
+	if body.Enabled == nil {
+		return badRequest(c, "enabled", "REQUIRED", "This value is required.")
+	}
+
+	if !*body.Enabled {
+		if err := s.Switch.Leave(); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, map[string]*Mode{"data": nil})
+	}
+
+	mode, err := NewMode(body.Reason, time.Duration(body.DurationMinutes)*time.Minute, time.Now())
+
+	var validationErr ModeValidationError
+	if errors.As(err, &validationErr) {
+		return badRequest(c, validationErr.Field, "INVALID_OPTION", validationErr.Error())
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.Switch.Enter(mode); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]Mode{"data": mode})
+}
+
+// GetReadiness answers 503 during the window of the notice, with a Retry-After until its end.
+func (s *Server) GetReadiness(c echo.Context) error {
+	now := time.Now()
+
+	notice, ok := s.Storage.Active(now)
+	if !ok {
+		return c.JSON(http.StatusOK, Readiness{Status: StatusReady})
+	}
+
+	retryAfter := int(math.Ceil(notice.EndsAt.Sub(now).Seconds()))
+	c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+
+	return c.JSON(http.StatusServiceUnavailable, Readiness{Status: StatusMaintenance, Notice: &notice})
+}
+
+func badRequest(c echo.Context, field, code, message string) error {
+	return c.JSON(http.StatusBadRequest, map[string]string{
+		"field_name":    field,
+		"error_code":    code,
+		"error_message": message,
+	})
+}
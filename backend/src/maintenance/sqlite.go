@@ -0,0 +1,59 @@
+package maintenance
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type ModeStoreSqlite struct {
+	DB *sql.DB
+}
+
+func NewModeStoreSqlite(db *sql.DB) ModeStore {
+	return &ModeStoreSqlite{DB: db}
+}
+
+func (s *ModeStoreSqlite) Find() (Mode, bool, error) {
+	mode := Mode{}
+
+	var startedDate, endsAt string
+
+	err := s.DB.QueryRow(`SELECT REASON, STARTED_DATE, ENDS_AT FROM MAINTENANCE_MODE WHERE ID = 1`).
+		Scan(&mode.Reason, &startedDate, &endsAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Mode{}, false, nil
+	}
+
+	if err != nil {
+		return Mode{}, false, err
+	}
+
+	mode.StartedDate, err = time.Parse(time.RFC3339, startedDate)
+	if err != nil {
+		return Mode{}, false, err
+	}
+
+	mode.EndsAt, err = time.Parse(time.RFC3339, endsAt)
+	if err != nil {
+		return Mode{}, false, err
+	}
+
+	return mode, true, nil
+}
+
+func (s *ModeStoreSqlite) Save(mode Mode) error {
+	_, err := s.DB.Exec(`INSERT OR REPLACE INTO MAINTENANCE_MODE (ID, REASON, STARTED_DATE, ENDS_AT)
+		VALUES (1, ?, ?, ?)`,
+		mode.Reason,
+		mode.StartedDate.Format(time.RFC3339),
+		mode.EndsAt.Format(time.RFC3339))
+
+	return err
+}
+
+func (s *ModeStoreSqlite) Remove() error {
+	_, err := s.DB.Exec(`DELETE FROM MAINTENANCE_MODE`)
+
+	return err
+}
@@ -0,0 +1,116 @@
+// Package media reads the metadata cameras write in the uploaded photos, so the photos can be ordered by the
+// time they were taken rather than the time they were uploaded.
+package media
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	exif "github.com/dsoprea/go-exif/v3"
+	exifcommon "github.com/dsoprea/go-exif/v3/common"
+)
+
+// exifDateLayout is the layout of the EXIF dates, which are in the local time of the camera.
+const exifDateLayout = "2006:01:02 15:04:05"
+
+// PhotoMetadata is the EXIF metadata of a photo, each field empty when the photo doesn't have it.
+type PhotoMetadata struct {
+	DateTaken *time.Time `json:"date_taken"`
+	Make      string     `json:"make"`
+	Model     string     `json:"model"`
+	GPSLat    *float64   `json:"gps_lat"`
+	GPSLon    *float64   `json:"gps_lon"`
+}
+
+// ExtractPhotoMetadata reads the EXIF metadata of the photo. A photo without EXIF metadata, like most PNGs and the
+// photos edited by apps stripping it, returns empty metadata. The date taken is in the offset the camera recorded,
+// in the server timezone when it didn't record one.
+func ExtractPhotoMetadata(r io.Reader) (PhotoMetadata, error) {
+	metadata := PhotoMetadata{}
+
+	rawExif, err := exif.SearchAndExtractExifWithReader(r)
+	if errors.Is(err, exif.ErrNoExif) {
+		return metadata, nil
+	}
+
+	if err != nil {
+		return metadata, err
+	}
+
+	ifdMapping, err := exifcommon.NewIfdMappingWithStandard()
+	if err != nil {
+		return metadata, err
+	}
+
+	_, index, err := exif.Collect(ifdMapping, exif.NewTagIndex(), rawExif)
+	if err != nil {
+		return metadata, err
+	}
+
+	metadata.Make = findTag(index.RootIfd, "Make")
+	metadata.Model = findTag(index.RootIfd, "Model")
+
+	if exifIfd, err := index.RootIfd.ChildWithIfdPath(exifcommon.IfdExifStandardIfdIdentity); err == nil {
+		metadata.DateTaken = parseDate(findTag(exifIfd, "DateTimeOriginal"), findTag(exifIfd, "OffsetTimeOriginal"))
+	}
+
+	if metadata.DateTaken == nil {
+		metadata.DateTaken = parseDate(findTag(index.RootIfd, "DateTime"), "")
+	}
+
+	if gpsIfd, err := index.RootIfd.ChildWithIfdPath(exifcommon.IfdGpsInfoStandardIfdIdentity); err == nil {
+		if gpsInfo, err := gpsIfd.GpsInfo(); err == nil {
+			lat := gpsInfo.Latitude.Decimal()
+			lon := gpsInfo.Longitude.Decimal()
+
+			metadata.GPSLat = &lat
+			metadata.GPSLon = &lon
+		}
+	}
+
+	return metadata, nil
+}
+
+// findTag returns the string value of the tag of the IFD, empty when it isn't there or isn't a string.
+func findTag(ifd *exif.Ifd, name string) string {
+	results, err := ifd.FindTagWithName(name)
+	if err != nil || len(results) == 0 {
+		return ""
+	}
+
+	value, err := results[0].Value()
+	if err != nil {
+		return ""
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.TrimRight(text, "\x00"))
+}
+
+// parseDate parses the EXIF date with its offset like +07:00, nil for the empty and zeroed dates some cameras write.
+func parseDate(value, offset string) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	location := time.Local
+
+	if offset != "" {
+		if zone, err := time.Parse("-07:00", offset); err == nil {
+			location = zone.Location()
+		}
+	}
+
+	date, err := time.ParseInLocation(exifDateLayout, value, location)
+	if err != nil {
+		return nil
+	}
+
+	return &date
+}
@@ -0,0 +1,111 @@
+package media_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	exif "github.com/dsoprea/go-exif/v3"
+	exifcommon "github.com/dsoprea/go-exif/v3/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/media"
+)
+
+// jpegWithExif wraps the EXIF IFDs built by the build func in the APP1 segment of a JPEG.
+func jpegWithExif(t *testing.T, build func(root, exifIfd, gpsIfd *exif.IfdBuilder)) []byte {
+	t.Helper()
+
+	ifdMapping, err := exifcommon.NewIfdMappingWithStandard()
+	assert.Nil(t, err)
+
+	tagIndex := exif.NewTagIndex()
+	root := exif.NewIfdBuilder(ifdMapping, tagIndex, exifcommon.IfdStandardIfdIdentity, binary.BigEndian)
+	exifIfd := exif.NewIfdBuilder(ifdMapping, tagIndex, exifcommon.IfdExifStandardIfdIdentity, binary.BigEndian)
+	gpsIfd := exif.NewIfdBuilder(ifdMapping, tagIndex, exifcommon.IfdGpsInfoStandardIfdIdentity, binary.BigEndian)
+
+	build(root, exifIfd, gpsIfd)
+
+	assert.Nil(t, root.AddChildIb(exifIfd))
+	assert.Nil(t, root.AddChildIb(gpsIfd))
+
+	data, err := exif.NewIfdByteEncoder().EncodeToExif(root)
+	assert.Nil(t, err)
+
+	segment := append([]byte("Exif\x00\x00"), data...)
+	length := make([]byte, 2)
+	binary.BigEndian.PutUint16(length, uint16(len(segment)+2))
+
+	photo := append([]byte{0xFF, 0xD8, 0xFF, 0xE1}, length...)
+	photo = append(photo, segment...)
+
+	return append(photo, 0xFF, 0xD9)
+}
+
+func TestExtractPhotoMetadata(t *testing.T) {
+	t.Parallel()
+	// Given
+	photo := jpegWithExif(t, func(root, exifIfd, gpsIfd *exif.IfdBuilder) {
+		assert.Nil(t, root.AddStandardWithName("Make", "Canon"))
+		assert.Nil(t, root.AddStandardWithName("Model", "Canon EOS 80D"))
+		assert.Nil(t, root.AddStandardWithName("DateTime", "2026:10:14 09:00:00"))
+		assert.Nil(t, exifIfd.AddStandardWithName("DateTimeOriginal", "2026:10:12 07:30:15"))
+		assert.Nil(t, exifIfd.AddStandardWithName("OffsetTimeOriginal", "+07:00"))
+		assert.Nil(t, gpsIfd.AddStandardWithName("GPSLatitudeRef", "S"))
+		assert.Nil(t, gpsIfd.AddStandardWithName("GPSLatitude", []exifcommon.Rational{
+			{Numerator: 6, Denominator: 1}, {Numerator: 12, Denominator: 1}, {Numerator: 0, Denominator: 1},
+		}))
+		assert.Nil(t, gpsIfd.AddStandardWithName("GPSLongitudeRef", "E"))
+		assert.Nil(t, gpsIfd.AddStandardWithName("GPSLongitude", []exifcommon.Rational{
+			{Numerator: 106, Denominator: 1}, {Numerator: 49, Denominator: 1}, {Numerator: 30, Denominator: 1},
+		}))
+	})
+
+	// When
+	metadata, err := media.ExtractPhotoMetadata(bytes.NewReader(photo))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "Canon", metadata.Make)
+	assert.Equal(t, "Canon EOS 80D", metadata.Model)
+	assert.NotNil(t, metadata.DateTaken)
+	assert.True(t, time.Date(2026, time.October, 12, 0, 30, 15, 0, time.UTC).Equal(*metadata.DateTaken))
+	assert.NotNil(t, metadata.GPSLat)
+	assert.NotNil(t, metadata.GPSLon)
+	assert.InDelta(t, -6.2, *metadata.GPSLat, 0.0001)
+	assert.InDelta(t, 106.825, *metadata.GPSLon, 0.0001)
+}
+
+func TestExtractPhotoMetadataWithoutDateTakenOrGPS(t *testing.T) {
+	t.Parallel()
+	// Given
+	photo := jpegWithExif(t, func(root, exifIfd, gpsIfd *exif.IfdBuilder) {
+		assert.Nil(t, root.AddStandardWithName("Model", "Pixel 7"))
+		assert.Nil(t, root.AddStandardWithName("DateTime", "2026:10:14 09:00:00"))
+	})
+
+	// When
+	metadata, err := media.ExtractPhotoMetadata(bytes.NewReader(photo))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "", metadata.Make)
+	assert.Equal(t, "Pixel 7", metadata.Model)
+	assert.NotNil(t, metadata.DateTaken)
+	assert.Equal(t, time.Date(2026, time.October, 14, 9, 0, 0, 0, time.Local), *metadata.DateTaken)
+	assert.Nil(t, metadata.GPSLat)
+	assert.Nil(t, metadata.GPSLon)
+}
+
+func TestExtractPhotoMetadataWithoutExif(t *testing.T) {
+	t.Parallel()
+	// Given
+	photo := []byte{0xFF, 0xD8, 0xFF, 0xD9}
+
+	// When
+	metadata, err := media.ExtractPhotoMetadata(bytes.NewReader(photo))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, media.PhotoMetadata{}, metadata)
+}
@@ -0,0 +1,147 @@
+package media
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	// The photos of the crops are JPEGs and PNGs.
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math/bits"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// DuplicatePhotoMaxDistance is the most bits the hashes of two photos differ by for them to be the same photo,
+	// after a resize or a recompression.
+	DuplicatePhotoMaxDistance = 8
+
+	dHashWidth  = 9
+	dHashHeight = 8
+)
+
+// PhotoHash is the perceptual hash of a photo of a crop.
+type PhotoHash struct {
+	CropUID     uuid.UUID
+	PhotoUID    uuid.UUID
+	Hash        uint64
+	CreatedDate time.Time
+}
+
+// HexHash is the hash as the 16 hexadecimal digits it is stored as.
+func (h PhotoHash) HexHash() string {
+	return FormatHash(h.Hash)
+}
+
+type PhotoHashStore interface {
+	// Save adds the hash of the photo, or replaces it.
+	Save(hash PhotoHash) error
+	FindAllByCrop(cropUID uuid.UUID) ([]PhotoHash, error)
+	// Remove drops the hash of the removed photo, so it isn't a duplicate of the next upload.
+	Remove(cropUID, photoUID uuid.UUID) error
+}
+
+// PerceptualHasher hashes the photos with a difference hash: the photo is shrunk to 9x8 grey pixels and every bit
+// tells whether a pixel is brighter than its right neighbour. The hash survives the resizes, the recompressions and
+// the small changes of colour, so the same photo uploaded twice has the same hash or a close one.
+type PerceptualHasher struct {
+	MaxDistance int
+}
+
+func NewPerceptualHasher() PerceptualHasher {
+	return PerceptualHasher{MaxDistance: DuplicatePhotoMaxDistance}
+}
+
+// Hash decodes the photo and returns its difference hash.
+func (PerceptualHasher) Hash(r io.Reader) (uint64, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return 0, err
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	// Each of the 9x8 pixels is the mean brightness of the area of the photo it shrinks, every pixel of the photo
+	// is read once.
+	sums := [dHashHeight][dHashWidth]float64{}
+	counts := [dHashHeight][dHashWidth]int{}
+
+	ycbcr, isYCbCr := img.(*image.YCbCr)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row := (y - bounds.Min.Y) * dHashHeight / height
+
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			column := (x - bounds.Min.X) * dHashWidth / width
+
+			var luminance uint8
+
+			if isYCbCr {
+				luminance = ycbcr.Y[ycbcr.YOffset(x, y)]
+			} else {
+				luminance = color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y
+			}
+
+			sums[row][column] += float64(luminance)
+			counts[row][column]++
+		}
+	}
+
+	var hash uint64
+
+	for y := 0; y < dHashHeight; y++ {
+		for x := 0; x < dHashWidth-1; x++ {
+			hash <<= 1
+
+			if mean(sums[y][x], counts[y][x]) > mean(sums[y][x+1], counts[y][x+1]) {
+				hash |= 1
+			}
+		}
+	}
+
+	return hash, nil
+}
+
+// FindDuplicate returns the closest of the hashes to the hash within the max distance, nil when there is none.
+func (h PerceptualHasher) FindDuplicate(hash uint64, hashes []PhotoHash) *PhotoHash {
+	var duplicate *PhotoHash
+
+	closest := h.MaxDistance + 1
+
+	for i, v := range hashes {
+		if distance := HammingDistance(hash, v.Hash); distance < closest {
+			duplicate = &hashes[i]
+			closest = distance
+		}
+	}
+
+	return duplicate
+}
+
+// HammingDistance is the number of bits two hashes differ by.
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+func FormatHash(hash uint64) string {
+	return fmt.Sprintf("%016x", hash)
+}
+
+func ParseHash(value string) (uint64, error) {
+	return strconv.ParseUint(value, 16, 64)
+}
+
+// mean is the brightness of a pixel of the hash, the photos smaller than the hash leave some pixels empty.
+func mean(sum float64, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+
+	return sum / float64(count)
+}
@@ -0,0 +1,64 @@
+package media
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/sasha-s/go-deadlock"
+)
+
+// PhotoHashStorage keeps the hashes of the photos by crop, then by photo.
+type PhotoHashStorage struct {
+	Lock         *deadlock.RWMutex
+	PhotoHashMap map[uuid.UUID]map[uuid.UUID]PhotoHash
+}
+
+func CreatePhotoHashStorage() *PhotoHashStorage {
+	return &PhotoHashStorage{Lock: &deadlock.RWMutex{}, PhotoHashMap: map[uuid.UUID]map[uuid.UUID]PhotoHash{}}
+}
+
+type PhotoHashStoreInMemory struct {
+	Storage *PhotoHashStorage
+}
+
+func NewPhotoHashStoreInMemory(s *PhotoHashStorage) PhotoHashStore {
+	return &PhotoHashStoreInMemory{Storage: s}
+}
+
+func (s *PhotoHashStoreInMemory) Save(hash PhotoHash) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	if _, ok := s.Storage.PhotoHashMap[hash.CropUID]; !ok {
+		s.Storage.PhotoHashMap[hash.CropUID] = map[uuid.UUID]PhotoHash{}
+	}
+
+	s.Storage.PhotoHashMap[hash.CropUID][hash.PhotoUID] = hash
+
+	return nil
+}
+
+func (s *PhotoHashStoreInMemory) FindAllByCrop(cropUID uuid.UUID) ([]PhotoHash, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	hashes := []PhotoHash{}
+	for _, v := range s.Storage.PhotoHashMap[cropUID] {
+		hashes = append(hashes, v)
+	}
+
+	sort.SliceStable(hashes, func(i, j int) bool {
+		return hashes[i].CreatedDate.Before(hashes[j].CreatedDate)
+	})
+
+	return hashes, nil
+}
+
+func (s *PhotoHashStoreInMemory) Remove(cropUID, photoUID uuid.UUID) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	delete(s.Storage.PhotoHashMap[cropUID], photoUID)
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package media
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type PhotoHashStoreMysql struct {
+	DB *sql.DB
+}
+
+func NewPhotoHashStoreMysql(db *sql.DB) PhotoHashStore {
+	return &PhotoHashStoreMysql{DB: db}
+}
+
+func (s *PhotoHashStoreMysql) Save(hash PhotoHash) error {
+	_, err := s.DB.Exec(`REPLACE INTO CROP_PHOTO_HASH (PHOTO_UID, CROP_UID, HASH, CREATED_DATE)
+		VALUES (?, ?, ?, ?)`,
+		hash.PhotoUID.Bytes(),
+		hash.CropUID.Bytes(),
+		hash.HexHash(),
+		hash.CreatedDate)
+
+	return err
+}
+
+func (s *PhotoHashStoreMysql) Remove(cropUID, photoUID uuid.UUID) error {
+	_, err := s.DB.Exec(`DELETE FROM CROP_PHOTO_HASH WHERE CROP_UID = ? AND PHOTO_UID = ?`,
+		cropUID.Bytes(), photoUID.Bytes())
+
+	return err
+}
+
+func (s *PhotoHashStoreMysql) FindAllByCrop(cropUID uuid.UUID) ([]PhotoHash, error) {
+	rows, err := s.DB.Query(`SELECT PHOTO_UID, HASH, CREATED_DATE FROM CROP_PHOTO_HASH
+		WHERE CROP_UID = ? ORDER BY CREATED_DATE ASC`, cropUID.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := []PhotoHash{}
+
+	for rows.Next() {
+		var (
+			photoUID    []byte
+			hash        string
+			createdDate time.Time
+		)
+
+		err = rows.Scan(&photoUID, &hash, &createdDate)
+		if err != nil {
+			return nil, err
+		}
+
+		v := PhotoHash{CropUID: cropUID, CreatedDate: createdDate}
+
+		v.PhotoUID, err = uuid.FromBytes(photoUID)
+		if err != nil {
+			return nil, err
+		}
+
+		v.Hash, err = ParseHash(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		hashes = append(hashes, v)
+	}
+
+	return hashes, rows.Err()
+}
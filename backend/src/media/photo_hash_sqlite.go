@@ -0,0 +1,75 @@
+package media
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type PhotoHashStoreSqlite struct {
+	DB *sql.DB
+}
+
+func NewPhotoHashStoreSqlite(db *sql.DB) PhotoHashStore {
+	return &PhotoHashStoreSqlite{DB: db}
+}
+
+func (s *PhotoHashStoreSqlite) Save(hash PhotoHash) error {
+	_, err := s.DB.Exec(`INSERT OR REPLACE INTO CROP_PHOTO_HASH (PHOTO_UID, CROP_UID, HASH, CREATED_DATE)
+		VALUES (?, ?, ?, ?)`,
+		hash.PhotoUID.String(),
+		hash.CropUID.String(),
+		hash.HexHash(),
+		hash.CreatedDate.UTC().Format(time.RFC3339))
+
+	return err
+}
+
+func (s *PhotoHashStoreSqlite) Remove(cropUID, photoUID uuid.UUID) error {
+	_, err := s.DB.Exec(`DELETE FROM CROP_PHOTO_HASH WHERE CROP_UID = ? AND PHOTO_UID = ?`,
+		cropUID.String(), photoUID.String())
+
+	return err
+}
+
+func (s *PhotoHashStoreSqlite) FindAllByCrop(cropUID uuid.UUID) ([]PhotoHash, error) {
+	rows, err := s.DB.Query(`SELECT PHOTO_UID, HASH, CREATED_DATE FROM CROP_PHOTO_HASH
+		WHERE CROP_UID = ? ORDER BY CREATED_DATE ASC`, cropUID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := []PhotoHash{}
+
+	for rows.Next() {
+		var photoUID, hash, createdDate string
+
+		err = rows.Scan(&photoUID, &hash, &createdDate)
+		if err != nil {
+			return nil, err
+		}
+
+		v := PhotoHash{CropUID: cropUID}
+
+		v.PhotoUID, err = uuid.FromString(photoUID)
+		if err != nil {
+			return nil, err
+		}
+
+		v.Hash, err = ParseHash(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		v.CreatedDate, err = time.Parse(time.RFC3339, createdDate)
+		if err != nil {
+			return nil, err
+		}
+
+		hashes = append(hashes, v)
+	}
+
+	return hashes, rows.Err()
+}
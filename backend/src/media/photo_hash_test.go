@@ -0,0 +1,133 @@
+package media_test
+
+import (
+	"bytes"
+	"database/sql"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/usetania/tania-core/src/media"
+)
+
+// leafPhoto draws a bright blob on a dark field with a shading, like a leaf shot against the soil.
+func leafPhoto(width, height int, shift float64) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			fx := float64(x)/float64(width) - shift
+			fy := float64(y) / float64(height)
+
+			value := 40 + 120*fy
+			if (fx-0.4)*(fx-0.4)+(fy-0.5)*(fy-0.5) < 0.06 {
+				value = 200 + 40*fx
+			}
+
+			img.Set(x, y, color.RGBA{R: uint8(value * 0.6), G: uint8(value), B: uint8(value * 0.4), A: 255})
+		}
+	}
+
+	return img
+}
+
+func TestPerceptualHasherHash(t *testing.T) {
+	t.Parallel()
+	// Given
+	hasher := media.NewPerceptualHasher()
+
+	original := &bytes.Buffer{}
+	require.Nil(t, png.Encode(original, leafPhoto(640, 480, 0)))
+
+	// The same photo, shrunk and recompressed by the phone.
+	resized := &bytes.Buffer{}
+	require.Nil(t, jpeg.Encode(resized, leafPhoto(320, 240, 0), &jpeg.Options{Quality: 50}))
+
+	other := &bytes.Buffer{}
+	require.Nil(t, png.Encode(other, leafPhoto(640, 480, 0.35)))
+
+	// When
+	originalHash, originalErr := hasher.Hash(original)
+	resizedHash, resizedErr := hasher.Hash(resized)
+	otherHash, otherErr := hasher.Hash(other)
+	_, invalidErr := hasher.Hash(bytes.NewBufferString("not a photo"))
+
+	// Then
+	assert.Nil(t, originalErr)
+	assert.Nil(t, resizedErr)
+	assert.Nil(t, otherErr)
+	assert.NotNil(t, invalidErr)
+	assert.LessOrEqual(t, media.HammingDistance(originalHash, resizedHash), media.DuplicatePhotoMaxDistance)
+	assert.Greater(t, media.HammingDistance(originalHash, otherHash), media.DuplicatePhotoMaxDistance)
+}
+
+func TestPerceptualHasherFindDuplicate(t *testing.T) {
+	t.Parallel()
+	// Given
+	hasher := media.NewPerceptualHasher()
+	far, _ := uuid.NewV4()
+	near, _ := uuid.NewV4()
+	nearer, _ := uuid.NewV4()
+
+	hashes := []media.PhotoHash{
+		{PhotoUID: far, Hash: 0xFFFF},
+		{PhotoUID: near, Hash: 0xFF},
+		{PhotoUID: nearer, Hash: 0x0F},
+	}
+
+	// When
+	duplicate := hasher.FindDuplicate(0x03, hashes)
+	none := hasher.FindDuplicate(0xFFFF0000, hashes)
+
+	// Then
+	assert.Equal(t, nearer, duplicate.PhotoUID)
+	assert.Nil(t, none)
+	assert.Equal(t, "00000000000000ff", media.FormatHash(0xFF))
+}
+
+func TestPhotoHashStoreSqlite(t *testing.T) {
+	t.Parallel()
+	// Given
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.Nil(t, err)
+	defer db.Close()
+
+	// Each connection to :memory: has its own database.
+	db.SetMaxOpenConns(1)
+
+	ddl, err := os.ReadFile("../../database/sqlite/ddl.sql")
+	require.Nil(t, err)
+
+	_, err = db.Exec(string(ddl))
+	require.Nil(t, err)
+
+	store := media.NewPhotoHashStoreSqlite(db)
+
+	cropUID, _ := uuid.NewV4()
+	otherCropUID, _ := uuid.NewV4()
+	photoUID, _ := uuid.NewV4()
+	otherPhotoUID, _ := uuid.NewV4()
+	createdDate := time.Date(2026, time.October, 14, 8, 0, 0, 0, time.UTC)
+
+	hash := media.PhotoHash{CropUID: cropUID, PhotoUID: photoUID, Hash: 0xF00DFACE12345678, CreatedDate: createdDate}
+
+	// When
+	saveErr := store.Save(hash)
+	otherErr := store.Save(media.PhotoHash{CropUID: otherCropUID, PhotoUID: otherPhotoUID, CreatedDate: createdDate})
+
+	hashes, findErr := store.FindAllByCrop(cropUID)
+
+	// Then
+	assert.Nil(t, saveErr)
+	assert.Nil(t, otherErr)
+	assert.Nil(t, findErr)
+	assert.Equal(t, []media.PhotoHash{hash}, hashes)
+}
@@ -0,0 +1,133 @@
+package notesearch
+
+import (
+	"github.com/gofrs/uuid"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// StoreInMemory scans the notes of the read models, without an index.
+type StoreInMemory struct {
+	AreaReadStorage      *assetsstorage.AreaReadStorage
+	ReservoirReadStorage *assetsstorage.ReservoirReadStorage
+	CropReadStorage      *growthstorage.CropReadStorage
+	TaskReadStorage      *taskstorage.TaskReadStorage
+	TaskArchiveStorage   *taskstorage.TaskArchiveStorage
+}
+
+func NewStoreInMemory(
+	areaReadStorage *assetsstorage.AreaReadStorage,
+	reservoirReadStorage *assetsstorage.ReservoirReadStorage,
+	cropReadStorage *growthstorage.CropReadStorage,
+	taskReadStorage *taskstorage.TaskReadStorage,
+	taskArchiveStorage *taskstorage.TaskArchiveStorage,
+) Store {
+	return &StoreInMemory{
+		AreaReadStorage:      areaReadStorage,
+		ReservoirReadStorage: reservoirReadStorage,
+		CropReadStorage:      cropReadStorage,
+		TaskReadStorage:      taskReadStorage,
+		TaskArchiveStorage:   taskArchiveStorage,
+	}
+}
+
+func (s *StoreInMemory) FindAll(farmUID uuid.UUID, terms []string) ([]Hit, error) {
+	hits := []Hit{}
+
+	s.AreaReadStorage.Lock.RLock()
+	for _, area := range s.AreaReadStorage.AreaReadMap {
+		if area.Farm.UID != farmUID {
+			continue
+		}
+
+		for _, v := range area.Notes {
+			if Matches(v.Content, terms) {
+				hits = append(hits, Hit{
+					EntityType:  EntityArea,
+					EntityUID:   area.UID,
+					EntityName:  area.Name,
+					NoteUID:     v.UID,
+					Content:     v.Content,
+					CreatedDate: v.CreatedDate,
+				})
+			}
+		}
+	}
+	s.AreaReadStorage.Lock.RUnlock()
+
+	s.ReservoirReadStorage.Lock.RLock()
+	for _, reservoir := range s.ReservoirReadStorage.ReservoirReadMap {
+		if reservoir.Farm.UID != farmUID {
+			continue
+		}
+
+		for _, v := range reservoir.Notes {
+			if Matches(v.Content, terms) {
+				hits = append(hits, Hit{
+					EntityType:  EntityReservoir,
+					EntityUID:   reservoir.UID,
+					EntityName:  reservoir.Name,
+					NoteUID:     v.UID,
+					Content:     v.Content,
+					CreatedDate: v.CreatedDate,
+				})
+			}
+		}
+	}
+	s.ReservoirReadStorage.Lock.RUnlock()
+
+	s.CropReadStorage.Lock.RLock()
+	for _, crop := range s.CropReadStorage.CropReadMap {
+		if crop.FarmUID != farmUID {
+			continue
+		}
+
+		for _, v := range crop.Notes {
+			if Matches(v.Content, terms) {
+				hits = append(hits, Hit{
+					EntityType:  EntityCrop,
+					EntityUID:   crop.UID,
+					EntityName:  crop.BatchID,
+					NoteUID:     v.UID,
+					Content:     v.Content,
+					CreatedDate: v.CreatedDate,
+					Archived:    crop.Status == growthdomain.CropArchived,
+				})
+			}
+		}
+	}
+	s.CropReadStorage.Lock.RUnlock()
+
+	s.TaskReadStorage.Lock.RLock()
+	for _, v := range s.TaskReadStorage.TaskReadMap {
+		if Matches(v.Description, terms) {
+			hits = append(hits, taskHit(v, false))
+		}
+	}
+	s.TaskReadStorage.Lock.RUnlock()
+
+	s.TaskArchiveStorage.Lock.RLock()
+	for _, v := range s.TaskArchiveStorage.TaskArchiveMap {
+		if Matches(v.Description, terms) {
+			hits = append(hits, taskHit(v, true))
+		}
+	}
+	s.TaskArchiveStorage.Lock.RUnlock()
+
+	return hits, nil
+}
+
+func taskHit(task taskstorage.TaskRead, archived bool) Hit {
+	return Hit{
+		EntityType:   EntityTask,
+		EntityUID:    task.UID,
+		EntityName:   task.Title,
+		Content:      task.Description,
+		CreatedDate:  task.CreatedDate,
+		Archived:     archived,
+		TaskDomain:   task.Domain,
+		TaskAssetUID: task.AssetID,
+	}
+}
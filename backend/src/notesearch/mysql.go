@@ -0,0 +1,177 @@
+package notesearch
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid"
+)
+
+// mysqlMinTermLength is the innodb_ft_min_token_size default. The FULLTEXT indexes don't have
+// the shorter words, the terms shorter than it are matched with LIKE.
+const mysqlMinTermLength = 3
+
+// mysqlSource is a table of the notes, or of the task descriptions, with a FULLTEXT index on its column.
+// Its query selects like the ones of sqliteSource.
+type mysqlSource struct {
+	entity string
+	column string
+	query  string
+	byFarm bool
+}
+
+//nolint:gochecknoglobals
+var mysqlSources = []mysqlSource{
+	{
+		entity: EntityArea,
+		column: "CONTENT",
+		query: `SELECT a.UID, a.NAME, n.UID, n.CONTENT, n.CREATED_DATE, 0, '', NULL
+			FROM AREA_READ_NOTES n JOIN AREA_READ a ON a.UID = n.AREA_UID
+			WHERE a.FARM_UID = ? AND %s`,
+		byFarm: true,
+	},
+	{
+		entity: EntityReservoir,
+		column: "CONTENT",
+		query: `SELECT r.UID, r.NAME, n.UID, n.CONTENT, n.CREATED_DATE, 0, '', NULL
+			FROM RESERVOIR_READ_NOTES n JOIN RESERVOIR_READ r ON r.UID = n.RESERVOIR_UID
+			WHERE r.FARM_UID = ? AND %s`,
+		byFarm: true,
+	},
+	{
+		entity: EntityCrop,
+		column: "CONTENT",
+		query: `SELECT c.UID, c.BATCH_ID, n.UID, n.CONTENT, n.CREATED_DATE, c.STATUS = 'ARCHIVED', '', NULL
+			FROM CROP_READ_NOTES n JOIN CROP_READ c ON c.UID = n.CROP_UID
+			WHERE c.FARM_UID = ? AND %s`,
+		byFarm: true,
+	},
+	{
+		entity: EntityTask,
+		column: "DESCRIPTION",
+		query: `SELECT n.UID, n.TITLE, NULL, n.DESCRIPTION, n.CREATED_DATE, 0, n.DOMAIN_CODE, n.ASSET_ID
+			FROM TASK_READ n WHERE %s`,
+	},
+}
+
+//nolint:gochecknoglobals
+var mysqlArchiveSource = mysqlSource{
+	entity: EntityTask,
+	column: "DESCRIPTION",
+	query: `SELECT n.UID, n.TITLE, NULL, n.DESCRIPTION, n.CREATED_DATE, 1, n.DOMAIN_CODE, n.ASSET_ID
+		FROM TASK_ARCHIVE n WHERE %s`,
+}
+
+// StoreMysql matches the notes with the FULLTEXT indexes of the DDL, in boolean mode.
+type StoreMysql struct {
+	DB        *sql.DB
+	ArchiveDB *sql.DB
+}
+
+func NewStoreMysql(db, archiveDB *sql.DB) Store {
+	return &StoreMysql{DB: db, ArchiveDB: archiveDB}
+}
+
+func (s *StoreMysql) FindAll(farmUID uuid.UUID, terms []string) ([]Hit, error) {
+	hits := []Hit{}
+
+	for _, v := range mysqlSources {
+		found, err := s.find(s.DB, v, farmUID, terms)
+		if err != nil {
+			return nil, err
+		}
+
+		hits = append(hits, found...)
+	}
+
+	found, err := s.find(s.ArchiveDB, mysqlArchiveSource, farmUID, terms)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(hits, found...), nil
+}
+
+func (s *StoreMysql) find(db *sql.DB, source mysqlSource, farmUID uuid.UUID, terms []string) ([]Hit, error) {
+	args := []interface{}{}
+	if source.byFarm {
+		args = append(args, farmUID.Bytes())
+	}
+
+	conditions := []string{}
+	required := []string{}
+
+	for _, v := range terms {
+		if utf8.RuneCountInString(v) < mysqlMinTermLength {
+			conditions = append(conditions, `n.`+source.column+` LIKE ?`)
+			args = append(args, "%"+v+"%")
+
+			continue
+		}
+
+		// The terms only have letters and digits, none of the boolean mode operators.
+		required = append(required, "+"+v+"*")
+	}
+
+	if len(required) > 0 {
+		conditions = append(conditions, `MATCH (n.`+source.column+`) AGAINST (? IN BOOLEAN MODE)`)
+		args = append(args, strings.Join(required, " "))
+	}
+
+	rows, err := db.Query(fmt.Sprintf(source.query, strings.Join(conditions, " AND ")), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := []Hit{}
+
+	for rows.Next() {
+		var (
+			entityUID, noteUID, taskAssetUID []byte
+			entityName, content, taskDomain  sql.NullString
+			createdDate                      time.Time
+		)
+
+		hit := Hit{EntityType: source.entity}
+
+		err = rows.Scan(&entityUID, &entityName, &noteUID, &content, &createdDate, &hit.Archived,
+			&taskDomain, &taskAssetUID)
+		if err != nil {
+			return nil, err
+		}
+
+		hit.EntityUID, err = uuid.FromBytes(entityUID)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(noteUID) > 0 {
+			hit.NoteUID, err = uuid.FromBytes(noteUID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(taskAssetUID) > 0 {
+			assetUID, err := uuid.FromBytes(taskAssetUID)
+			if err != nil {
+				return nil, err
+			}
+
+			hit.TaskAssetUID = &assetUID
+		}
+
+		hit.EntityName = entityName.String
+		hit.Content = content.String
+		hit.TaskDomain = taskDomain.String
+		hit.CreatedDate = createdDate
+
+		hits = append(hits, hit)
+	}
+
+	return hits, rows.Err()
+}
@@ -0,0 +1,224 @@
+// Package notesearch finds the notes of the areas, reservoirs and crops of a farm and the descriptions
+// of its tasks containing the words of a query, with the matched fragment highlighted.
+package notesearch
+
+import (
+	"errors"
+	"html"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	EntityArea      = "AREA"
+	EntityReservoir = "RESERVOIR"
+	EntityCrop      = "CROP"
+	EntityTask      = "TASK"
+)
+
+// maxTerms keeps the queries of the full text indexes short, the words after it are left out.
+const maxTerms = 10
+
+const (
+	// fragmentLength is the length of the highlighted fragment, in characters.
+	fragmentLength = 160
+
+	// fragmentLead is the context kept before the first match of the fragment.
+	fragmentLead = 40
+)
+
+var ErrEmptyQuery = errors.New("the search query should have a word")
+
+// Hit is a note, or a task description, containing all the terms of the query.
+type Hit struct {
+	EntityType string
+	EntityUID  uuid.UUID
+	EntityName string
+
+	// NoteUID is nil for the task descriptions.
+	NoteUID     uuid.UUID
+	Content     string
+	CreatedDate time.Time
+	Archived    bool
+
+	// The domain and the asset of the tasks, their farm is the one of their asset.
+	TaskDomain   string
+	TaskAssetUID *uuid.UUID
+}
+
+type Store interface {
+	// FindAll returns the area, reservoir and crop notes of the farm containing all the terms, with the
+	// descriptions of the tasks and of the archived tasks containing them. The tasks of every farm are returned.
+	FindAll(farmUID uuid.UUID, terms []string) ([]Hit, error)
+}
+
+// Terms returns the distinct lower case words of the query.
+func Terms(query string) ([]string, error) {
+	terms := []string{}
+	seen := map[string]bool{}
+
+	for _, v := range strings.FieldsFunc(strings.ToLower(query), isNotWordRune) {
+		if seen[v] {
+			continue
+		}
+
+		seen[v] = true
+		terms = append(terms, v)
+
+		if len(terms) == maxTerms {
+			break
+		}
+	}
+
+	if len(terms) == 0 {
+		return nil, ErrEmptyQuery
+	}
+
+	return terms, nil
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// Matches tells whether the content contains all the terms, case insensitively. The full text indexes
+// match the words starting with the terms, so this also drops the index entries of the changed notes.
+func Matches(content string, terms []string) bool {
+	lower := lowerRunes(content)
+
+	for _, v := range terms {
+		if indexRunes(lower, []rune(v), 0) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Highlight returns the HTML escaped fragment of the content around its first match,
+// with the matches of the terms in <mark> tags.
+func Highlight(content string, terms []string) string {
+	runes := []rune(content)
+	marks := matchRanges(lowerRunes(content), terms)
+
+	start, end := 0, len(runes)
+	if len(marks) > 0 && marks[0][0] > fragmentLead {
+		start = marks[0][0] - fragmentLead
+	}
+
+	if end-start > fragmentLength {
+		end = start + fragmentLength
+	}
+
+	b := strings.Builder{}
+
+	if start > 0 {
+		b.WriteString("…")
+	}
+
+	i := start
+
+	for _, v := range marks {
+		if v[0] >= end {
+			break
+		}
+
+		from, to := v[0], v[1]
+		if from < i {
+			from = i
+		}
+
+		if to > end {
+			to = end
+		}
+
+		b.WriteString(html.EscapeString(string(runes[i:from])))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(string(runes[from:to])))
+		b.WriteString("</mark>")
+
+		i = to
+	}
+
+	b.WriteString(html.EscapeString(string(runes[i:end])))
+
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+
+	return b.String()
+}
+
+// matchRanges returns the merged ranges of the matches of the terms, in runes, in their order in the content.
+func matchRanges(lower []rune, terms []string) [][2]int {
+	found := make([]bool, len(lower)+1)
+	ends := make([]int, len(lower)+1)
+
+	for _, v := range terms {
+		term := []rune(v)
+
+		for i := indexRunes(lower, term, 0); i >= 0; i = indexRunes(lower, term, i+1) {
+			found[i] = true
+			if i+len(term) > ends[i] {
+				ends[i] = i + len(term)
+			}
+		}
+	}
+
+	ranges := [][2]int{}
+
+	for i := range lower {
+		if !found[i] {
+			continue
+		}
+
+		if n := len(ranges); n > 0 && i <= ranges[n-1][1] {
+			if ends[i] > ranges[n-1][1] {
+				ranges[n-1][1] = ends[i]
+			}
+
+			continue
+		}
+
+		ranges = append(ranges, [2]int{i, ends[i]})
+	}
+
+	return ranges
+}
+
+// lowerRunes lowers the content rune by rune, so the indexes in it are the ones of the content.
+func lowerRunes(content string) []rune {
+	runes := []rune(content)
+	for i, v := range runes {
+		runes[i] = unicode.ToLower(v)
+	}
+
+	return runes
+}
+
+func indexRunes(s, sub []rune, from int) int {
+	if len(sub) == 0 {
+		return -1
+	}
+
+	for i := from; i+len(sub) <= len(s); i++ {
+		match := true
+
+		for j := range sub {
+			if s[i+j] != sub[j] {
+				match = false
+
+				break
+			}
+		}
+
+		if match {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,231 @@
+package notesearch_test
+
+import (
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/notesearch"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+func TestTerms(t *testing.T) {
+	t.Parallel()
+
+	// When
+	terms, err := notesearch.Terms(`  Aphids, "yellow" leaves... aphids! `)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"aphids", "yellow", "leaves"}, terms)
+
+	// When
+	_, err = notesearch.Terms(" ?! ")
+
+	// Then
+	assert.Equal(t, notesearch.ErrEmptyQuery, err)
+}
+
+func TestMatches(t *testing.T) {
+	t.Parallel()
+
+	// Then
+	assert.True(t, notesearch.Matches("Aphids on the YELLOW leaves", []string{"yellow", "aphid"}))
+	assert.False(t, notesearch.Matches("Aphids on the leaves", []string{"yellow", "aphid"}))
+}
+
+func TestHighlight(t *testing.T) {
+	t.Parallel()
+
+	// When
+	highlight := notesearch.Highlight("Aphids <b>under</b> the leaves, sprayed the aphids", []string{"aphid", "leaves"})
+
+	// Then
+	assert.Equal(t,
+		"<mark>Aphid</mark>s &lt;b&gt;under&lt;/b&gt; the <mark>leaves</mark>, sprayed the <mark>aphid</mark>s",
+		highlight)
+
+	// Given
+	long := ""
+	for i := 0; i < 30; i++ {
+		long += "watered "
+	}
+
+	// When
+	highlight = notesearch.Highlight(long+"the mint is wilting "+long, []string{"wilting"})
+
+	// Then
+	assert.Equal(t, "…red watered watered watered the mint is <mark>wilting</mark>"+
+		strings.Repeat(" watered", 14)+" …", highlight)
+}
+
+func TestStoreInMemoryFindAll(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	farmUID, _ := uuid.NewV4()
+	otherFarmUID, _ := uuid.NewV4()
+	areaUID, _ := uuid.NewV4()
+	otherAreaUID, _ := uuid.NewV4()
+	cropUID, _ := uuid.NewV4()
+	taskUID, _ := uuid.NewV4()
+	archivedTaskUID, _ := uuid.NewV4()
+	noteUID, _ := uuid.NewV4()
+	now := time.Now()
+
+	areaReadStorage := assetsstorage.CreateAreaReadStorage()
+	areaReadStorage.AreaReadMap[areaUID] = assetsstorage.AreaRead{
+		UID:   areaUID,
+		Name:  "North",
+		Farm:  assetsstorage.AreaFarm{UID: farmUID},
+		Notes: []assetsstorage.AreaNote{{UID: noteUID, Content: "Aphids near the gate", CreatedDate: now}},
+	}
+	areaReadStorage.AreaReadMap[otherAreaUID] = assetsstorage.AreaRead{
+		UID:   otherAreaUID,
+		Farm:  assetsstorage.AreaFarm{UID: otherFarmUID},
+		Notes: []assetsstorage.AreaNote{{Content: "Aphids everywhere", CreatedDate: now}},
+	}
+
+	cropReadStorage := growthstorage.CreateCropReadStorage()
+	cropReadStorage.CropReadMap[cropUID] = growthstorage.CropRead{
+		UID:     cropUID,
+		BatchID: "bro-1",
+		Status:  growthdomain.CropArchived,
+		FarmUID: farmUID,
+		Notes:   []growthdomain.CropNote{{Content: "No aphids this time", CreatedDate: now}},
+	}
+
+	taskReadStorage := taskstorage.CreateTaskReadStorage()
+	taskReadStorage.TaskReadMap[taskUID] = taskstorage.TaskRead{UID: taskUID, Title: "Weed", Description: "Weed it"}
+
+	taskArchiveStorage := taskstorage.CreateTaskArchiveStorage()
+	taskArchiveStorage.TaskArchiveMap[archivedTaskUID] = taskstorage.TaskRead{
+		UID: archivedTaskUID, Title: "Spray", Description: "Spray the APHIDS",
+	}
+
+	store := notesearch.NewStoreInMemory(areaReadStorage, assetsstorage.CreateReservoirReadStorage(),
+		cropReadStorage, taskReadStorage, taskArchiveStorage)
+
+	// When
+	hits, err := store.FindAll(farmUID, []string{"aphids"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []notesearch.Hit{
+		{
+			EntityType: notesearch.EntityArea, EntityUID: areaUID, EntityName: "North", NoteUID: noteUID,
+			Content: "Aphids near the gate", CreatedDate: now,
+		},
+		{
+			EntityType: notesearch.EntityCrop, EntityUID: cropUID, EntityName: "bro-1",
+			Content: "No aphids this time", CreatedDate: now, Archived: true,
+		},
+		{
+			EntityType: notesearch.EntityTask, EntityUID: archivedTaskUID, EntityName: "Spray",
+			Content: "Spray the APHIDS", Archived: true,
+		},
+	}, hits)
+}
+
+func openSqlite(t *testing.T, ddlPath string) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.Nil(t, err)
+
+	// Each connection to :memory: has its own database.
+	db.SetMaxOpenConns(1)
+
+	ddl, err := os.ReadFile(ddlPath)
+	require.Nil(t, err)
+
+	_, err = db.Exec(string(ddl))
+	require.Nil(t, err)
+
+	return db
+}
+
+func TestStoreSqliteFindAll(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	db := openSqlite(t, "../../database/sqlite/ddl.sql")
+	defer db.Close()
+
+	archiveDB := openSqlite(t, "../../database/sqlite/archive_ddl.sql")
+	defer archiveDB.Close()
+
+	farmUID, _ := uuid.NewV4()
+	otherFarmUID, _ := uuid.NewV4()
+	areaUID, _ := uuid.NewV4()
+	otherAreaUID, _ := uuid.NewV4()
+	noteUID, _ := uuid.NewV4()
+	taskUID, _ := uuid.NewV4()
+	archivedTaskUID, _ := uuid.NewV4()
+	createdDate := time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC)
+
+	// The notes written before the store is created are indexed by its rebuild.
+	_, err := db.Exec(`INSERT INTO AREA_READ (UID, NAME, FARM_UID) VALUES (?, ?, ?), (?, ?, ?)`,
+		areaUID, "North", farmUID, otherAreaUID, "South", otherFarmUID)
+	require.Nil(t, err)
+
+	_, err = db.Exec(`INSERT INTO AREA_READ_NOTES (UID, AREA_UID, CONTENT, CREATED_DATE) VALUES (?, ?, ?, ?)`,
+		noteUID, areaUID, "Aphids near the gate", createdDate.Format(time.RFC3339))
+	require.Nil(t, err)
+
+	store := notesearch.NewStoreSqlite(db, archiveDB)
+
+	otherNoteUID, _ := uuid.NewV4()
+	_, err = db.Exec(`INSERT INTO AREA_READ_NOTES (UID, AREA_UID, CONTENT, CREATED_DATE) VALUES (?, ?, ?, ?)`,
+		otherNoteUID, otherAreaUID, "Aphids everywhere", createdDate.Format(time.RFC3339))
+	require.Nil(t, err)
+
+	_, err = db.Exec(`INSERT INTO TASK_READ (UID, TITLE, DESCRIPTION, CREATED_DATE, DOMAIN_CODE, ASSET_ID)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		taskUID, "Inspect", "Check the mint for aphids", createdDate.Format(time.RFC3339), "AREA", areaUID)
+	require.Nil(t, err)
+
+	_, err = archiveDB.Exec(`INSERT INTO TASK_ARCHIVE (UID, TITLE, DESCRIPTION, CREATED_DATE, DOMAIN_CODE)
+		VALUES (?, ?, ?, ?, ?)`,
+		archivedTaskUID, "Spray", "Spray the aphids and the mites", createdDate.Format(time.RFC3339), "GENERAL")
+	require.Nil(t, err)
+
+	// When
+	hits, err := store.FindAll(farmUID, []string{"aphids"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []notesearch.Hit{
+		{
+			EntityType: notesearch.EntityArea, EntityUID: areaUID, EntityName: "North", NoteUID: noteUID,
+			Content: "Aphids near the gate", CreatedDate: createdDate,
+		},
+		{
+			EntityType: notesearch.EntityTask, EntityUID: taskUID, EntityName: "Inspect",
+			Content: "Check the mint for aphids", CreatedDate: createdDate,
+			TaskDomain: "AREA", TaskAssetUID: &areaUID,
+		},
+		{
+			EntityType: notesearch.EntityTask, EntityUID: archivedTaskUID, EntityName: "Spray",
+			Content: "Spray the aphids and the mites", CreatedDate: createdDate, Archived: true,
+			TaskDomain: "GENERAL",
+		},
+	}, hits)
+
+	// When
+	hits, err = store.FindAll(farmUID, []string{"aphids", "mite"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Len(t, hits, 1)
+	assert.Equal(t, archivedTaskUID, hits[0].EntityUID)
+}
@@ -0,0 +1,237 @@
+package notesearch
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// sqliteSource is a table of the notes, or of the task descriptions, indexed by its FTS5 table.
+// Its query selects the entity uid and name, the note uid, content and created date, whether the entity is
+// archived and the task domain and asset, with a %s for the match condition on the n alias of the table.
+type sqliteSource struct {
+	entity string
+	table  string
+	column string
+	query  string
+	byFarm bool
+}
+
+//nolint:gochecknoglobals
+var sqliteSources = []sqliteSource{
+	{
+		entity: EntityArea,
+		table:  "AREA_READ_NOTES",
+		column: "CONTENT",
+		query: `SELECT a.UID, a.NAME, n.UID, n.CONTENT, n.CREATED_DATE, 0, '', ''
+			FROM AREA_READ_NOTES n JOIN AREA_READ a ON a.UID = n.AREA_UID
+			WHERE a.FARM_UID = ? AND %s`,
+		byFarm: true,
+	},
+	{
+		entity: EntityReservoir,
+		table:  "RESERVOIR_READ_NOTES",
+		column: "CONTENT",
+		query: `SELECT r.UID, r.NAME, n.UID, n.CONTENT, n.CREATED_DATE, 0, '', ''
+			FROM RESERVOIR_READ_NOTES n JOIN RESERVOIR_READ r ON r.UID = n.RESERVOIR_UID
+			WHERE r.FARM_UID = ? AND %s`,
+		byFarm: true,
+	},
+	{
+		entity: EntityCrop,
+		table:  "CROP_READ_NOTES",
+		column: "CONTENT",
+		query: `SELECT c.UID, c.BATCH_ID, n.UID, n.CONTENT, n.CREATED_DATE, c.STATUS = 'ARCHIVED', '', ''
+			FROM CROP_READ_NOTES n JOIN CROP_READ c ON c.UID = n.CROP_UID
+			WHERE c.FARM_UID = ? AND %s`,
+		byFarm: true,
+	},
+	{
+		entity: EntityTask,
+		table:  "TASK_READ",
+		column: "DESCRIPTION",
+		query: `SELECT n.UID, n.TITLE, '', n.DESCRIPTION, n.CREATED_DATE, 0, n.DOMAIN_CODE, n.ASSET_ID
+			FROM TASK_READ n WHERE %s`,
+	},
+}
+
+//nolint:gochecknoglobals
+var sqliteArchiveSource = sqliteSource{
+	entity: EntityTask,
+	table:  "TASK_ARCHIVE",
+	column: "DESCRIPTION",
+	query: `SELECT n.UID, n.TITLE, '', n.DESCRIPTION, n.CREATED_DATE, 1, n.DOMAIN_CODE, n.ASSET_ID
+		FROM TASK_ARCHIVE n WHERE %s`,
+}
+
+// StoreSqlite matches the notes with the FTS5 tables of the notes. The SQLite builds without FTS5,
+// the ones without the sqlite_fts5 build tag, match them with LIKE.
+type StoreSqlite struct {
+	DB        *sql.DB
+	ArchiveDB *sql.DB
+	fts       bool
+}
+
+// NewStoreSqlite creates the FTS5 tables of the notes and their triggers, then rebuilds their index
+// from the notes so it catches up with the rows written without the triggers.
+func NewStoreSqlite(db, archiveDB *sql.DB) Store {
+	s := &StoreSqlite{DB: db, ArchiveDB: archiveDB, fts: true}
+
+	for _, v := range sqliteSources {
+		err := createFTSTable(db, v)
+		if err != nil {
+			log.Println("Notes search without FTS5, the notes are matched with LIKE.", err)
+
+			s.fts = false
+
+			return s
+		}
+	}
+
+	err := createFTSTable(archiveDB, sqliteArchiveSource)
+	if err != nil {
+		log.Println("Notes search without FTS5, the notes are matched with LIKE.", err)
+
+		s.fts = false
+	}
+
+	return s
+}
+
+// createFTSTable indexes the column of the table in an external content FTS5 table, which keeps no copy
+// of the notes. The triggers keep it up to date with the rowids of the table.
+func createFTSTable(db *sql.DB, source sqliteSource) error {
+	fts := source.table + "_FTS"
+
+	statements := []string{
+		`CREATE VIRTUAL TABLE IF NOT EXISTS ` + fts + ` USING fts5(` + source.column +
+			`, content='` + source.table + `', content_rowid='rowid')`,
+		`CREATE TRIGGER IF NOT EXISTS ` + fts + `_INSERT AFTER INSERT ON ` + source.table + ` BEGIN
+			INSERT INTO ` + fts + `(rowid, ` + source.column + `) VALUES (new.rowid, new.` + source.column + `);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS ` + fts + `_DELETE AFTER DELETE ON ` + source.table + ` BEGIN
+			INSERT INTO ` + fts + `(` + fts + `, rowid, ` + source.column + `)
+				VALUES ('delete', old.rowid, old.` + source.column + `);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS ` + fts + `_UPDATE AFTER UPDATE OF ` + source.column + ` ON ` + source.table +
+			` BEGIN
+			INSERT INTO ` + fts + `(` + fts + `, rowid, ` + source.column + `)
+				VALUES ('delete', old.rowid, old.` + source.column + `);
+			INSERT INTO ` + fts + `(rowid, ` + source.column + `) VALUES (new.rowid, new.` + source.column + `);
+		END`,
+		`INSERT INTO ` + fts + `(` + fts + `) VALUES ('rebuild')`,
+	}
+
+	for _, v := range statements {
+		_, err := db.Exec(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *StoreSqlite) FindAll(farmUID uuid.UUID, terms []string) ([]Hit, error) {
+	hits := []Hit{}
+
+	for _, v := range sqliteSources {
+		found, err := s.find(s.DB, v, farmUID, terms)
+		if err != nil {
+			return nil, err
+		}
+
+		hits = append(hits, found...)
+	}
+
+	found, err := s.find(s.ArchiveDB, sqliteArchiveSource, farmUID, terms)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(hits, found...), nil
+}
+
+func (s *StoreSqlite) find(db *sql.DB, source sqliteSource, farmUID uuid.UUID, terms []string) ([]Hit, error) {
+	args := []interface{}{}
+	if source.byFarm {
+		args = append(args, farmUID.String())
+	}
+
+	condition := ""
+
+	if s.fts {
+		// The terms only have letters and digits, quoted they match the words starting with them.
+		condition = `n.rowid IN (SELECT rowid FROM ` + source.table + `_FTS WHERE ` + source.table + `_FTS MATCH ?)`
+		args = append(args, `"`+strings.Join(terms, `"* "`)+`"*`)
+	} else {
+		conditions := make([]string, len(terms))
+		for i, v := range terms {
+			conditions[i] = `n.` + source.column + ` LIKE ?`
+			args = append(args, "%"+v+"%")
+		}
+
+		condition = strings.Join(conditions, " AND ")
+	}
+
+	rows, err := db.Query(fmt.Sprintf(source.query, condition), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := []Hit{}
+
+	for rows.Next() {
+		var (
+			entityUID, noteUID, createdDate               string
+			entityName, content, taskDomain, taskAssetUID sql.NullString
+		)
+
+		hit := Hit{EntityType: source.entity}
+
+		err = rows.Scan(&entityUID, &entityName, &noteUID, &content, &createdDate, &hit.Archived,
+			&taskDomain, &taskAssetUID)
+		if err != nil {
+			return nil, err
+		}
+
+		hit.EntityUID, err = uuid.FromString(entityUID)
+		if err != nil {
+			return nil, err
+		}
+
+		if noteUID != "" {
+			hit.NoteUID, err = uuid.FromString(noteUID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if taskAssetUID.String != "" {
+			assetUID, err := uuid.FromString(taskAssetUID.String)
+			if err != nil {
+				return nil, err
+			}
+
+			hit.TaskAssetUID = &assetUID
+		}
+
+		hit.EntityName = entityName.String
+		hit.Content = content.String
+		hit.TaskDomain = taskDomain.String
+
+		hit.CreatedDate, err = time.Parse(time.RFC3339, createdDate)
+		if err != nil {
+			return nil, err
+		}
+
+		hits = append(hits, hit)
+	}
+
+	return hits, rows.Err()
+}
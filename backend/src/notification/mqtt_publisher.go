@@ -0,0 +1,165 @@
+// Package notification forwards the domain events to external systems.
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/auth"
+)
+
+const (
+	mqttTopicPrefix    = "tania"
+	mqttUnscopedFarm   = "unscoped"
+	mqttPublishTimeout = 5 * time.Second
+	mqttMinReconnect   = 1 * time.Second
+	mqttMaxReconnect   = 2 * time.Minute
+)
+
+// MQTTEventPublisher publishes every domain event as JSON to `tania/{farm_id}/{event_type}`.
+type MQTTEventPublisher struct {
+	Client mqtt.Client
+	QoS    byte
+	Retain bool
+}
+
+// NewMQTTEventPublisher creates the publisher. It doesn't connect until Connect is called.
+func NewMQTTEventPublisher(brokerURL string, qos int, retain bool) (*MQTTEventPublisher, error) {
+	if brokerURL == "" {
+		return nil, errors.New("mqtt broker url is required")
+	}
+
+	if qos < 0 || qos > 2 {
+		return nil, errors.New("mqtt qos must be 0, 1 or 2")
+	}
+
+	clientID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	publisher := &MQTTEventPublisher{
+		QoS:    byte(qos),
+		Retain: retain,
+	}
+
+	// Reconnection is handled by the publisher itself, so it can back off exponentially.
+	opts := mqtt.NewClientOptions().
+		AddBroker(brokerURL).
+		SetClientID("tania-" + clientID.String()).
+		SetAutoReconnect(false).
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			log.Println("MQTT connection lost.", err)
+
+			go publisher.Connect()
+		})
+
+	publisher.Client = mqtt.NewClient(opts)
+
+	return publisher, nil
+}
+
+// Connect connects to the broker, retrying with an exponential backoff until it succeeds.
+func (p *MQTTEventPublisher) Connect() {
+	for attempt := 0; ; attempt++ {
+		token := p.Client.Connect()
+		token.Wait()
+
+		if token.Error() == nil {
+			log.Println("Connected to the MQTT broker")
+
+			return
+		}
+
+		wait := ReconnectBackoff(attempt)
+
+		log.Printf("Failed connecting to the MQTT broker, retrying in %v. %v", wait, token.Error())
+
+		time.Sleep(wait)
+	}
+}
+
+// Publish sends the event to the broker. It is meant to be subscribed to all the event bus topics.
+// The subscribers of the broker have no role, so every redacted field of the events is replaced.
+func (p *MQTTEventPublisher) Publish(eventName string, event interface{}) {
+	if !p.Client.IsConnected() {
+		log.Println("MQTT broker is not connected. Dropping event", eventName)
+
+		return
+	}
+
+	payload, err := json.Marshal(auth.RedactAll(event))
+	if err != nil {
+		log.Println(err)
+
+		return
+	}
+
+	token := p.Client.Publish(Topic(eventName, event), p.QoS, p.Retain, payload)
+
+	// Don't hold the event bus for a slow broker.
+	go func() {
+		if !token.WaitTimeout(mqttPublishTimeout) {
+			log.Println("Timeout publishing event to the MQTT broker", eventName)
+
+			return
+		}
+
+		if token.Error() != nil {
+			log.Println(token.Error())
+		}
+	}()
+}
+
+// Topic builds the MQTT topic of an event.
+// Events that can't be related to a farm, like tasks and users, go under `tania/unscoped`.
+func Topic(eventName string, event interface{}) string {
+	return strings.Join([]string{mqttTopicPrefix, farmIDOf(eventName, event), eventName}, "/")
+}
+
+// ReconnectBackoff returns how long to wait before the next connection attempt.
+// It doubles on every attempt, starting from one second and capped at two minutes.
+func ReconnectBackoff(attempt int) time.Duration {
+	wait := mqttMinReconnect
+
+	for i := 0; i < attempt; i++ {
+		wait *= 2
+
+		if wait >= mqttMaxReconnect {
+			return mqttMaxReconnect
+		}
+	}
+
+	return wait
+}
+
+func farmIDOf(eventName string, event interface{}) string {
+	v := reflect.Indirect(reflect.ValueOf(event))
+	if v.Kind() != reflect.Struct {
+		return mqttUnscopedFarm
+	}
+
+	field := v.FieldByName("FarmUID")
+
+	// The farm events are keyed by the farm UID itself.
+	if !field.IsValid() && strings.HasPrefix(eventName, "Farm") {
+		field = v.FieldByName("UID")
+	}
+
+	if !field.IsValid() {
+		return mqttUnscopedFarm
+	}
+
+	farmUID, ok := field.Interface().(uuid.UUID)
+	if !ok || farmUID == (uuid.UUID{}) {
+		return mqttUnscopedFarm
+	}
+
+	return farmUID.String()
+}
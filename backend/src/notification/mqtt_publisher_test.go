@@ -0,0 +1,49 @@
+package notification_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/notification"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+func TestTopic(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	taskUID, _ := uuid.NewV4()
+
+	// When
+	farmCreated := notification.Topic("FarmCreated", assetsdomain.FarmCreated{UID: farmUID})
+	areaCreated := notification.Topic("AreaCreated", assetsdomain.AreaCreated{FarmUID: farmUID})
+	taskDue := notification.Topic("TaskDue", tasksdomain.TaskDue{UID: taskUID})
+
+	// Then
+	assert.Equal(t, "tania/"+farmUID.String()+"/FarmCreated", farmCreated)
+	assert.Equal(t, "tania/"+farmUID.String()+"/AreaCreated", areaCreated)
+	assert.Equal(t, "tania/unscoped/TaskDue", taskDue)
+}
+
+func TestReconnectBackoff(t *testing.T) {
+	t.Parallel()
+	// Given
+	// When
+	// Then
+	assert.Equal(t, time.Second, notification.ReconnectBackoff(0))
+	assert.Equal(t, 8*time.Second, notification.ReconnectBackoff(3))
+	assert.Equal(t, 2*time.Minute, notification.ReconnectBackoff(50))
+}
+
+func TestNewMQTTEventPublisherInvalidQoS(t *testing.T) {
+	t.Parallel()
+	// Given
+	// When
+	_, err := notification.NewMQTTEventPublisher("tcp://127.0.0.1:1883", 3, false)
+
+	// Then
+	assert.NotNil(t, err)
+}
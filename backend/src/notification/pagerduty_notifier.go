@@ -0,0 +1,128 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+	pagerDutyTimeout   = 10 * time.Second
+	pagerDutySource    = "tania"
+)
+
+// The actions of the PagerDuty Events API v2 the notifier sends.
+const (
+	PagerDutyActionTrigger = "trigger"
+	PagerDutyActionResolve = "resolve"
+)
+
+// PagerDutyEvent is an event of the PagerDuty Events API v2. The resolve events only carry their dedup key.
+type PagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *PagerDutyPayload `json:"payload,omitempty"`
+}
+
+type PagerDutyPayload struct {
+	Summary       string           `json:"summary"`
+	Source        string           `json:"source"`
+	Severity      string           `json:"severity"`
+	CustomDetails TaskNotification `json:"custom_details"`
+}
+
+// PagerDutyNotifier opens a PagerDuty incident for the urgent tasks falling due, and resolves it when the task
+// is completed. The incident of a task is deduplicated by its dedup key, so a task opens one incident at most.
+type PagerDutyNotifier struct {
+	IntegrationKey string
+	URL            string
+	Client         *http.Client
+}
+
+func NewPagerDutyNotifier(integrationKey string) *PagerDutyNotifier {
+	return &PagerDutyNotifier{
+		IntegrationKey: integrationKey,
+		URL:            pagerDutyEventsURL,
+		Client:         &http.Client{Timeout: pagerDutyTimeout},
+	}
+}
+
+// PagerDutyDedupKey is the key the incident of the task is deduplicated and resolved with.
+func PagerDutyDedupKey(taskUID uuid.UUID) string {
+	return "task:" + taskUID.String()
+}
+
+// Trigger opens the incident of the notified task when it's urgent and PagerDuty is configured.
+func (n *PagerDutyNotifier) Trigger(notification TaskNotification) error {
+	if n.IntegrationKey == "" || notification.Priority != PriorityUrgent {
+		return nil
+	}
+
+	return n.send(PagerDutyEvent{
+		RoutingKey:  n.IntegrationKey,
+		EventAction: PagerDutyActionTrigger,
+		DedupKey:    PagerDutyDedupKey(notification.TaskUID),
+		Payload: &PagerDutyPayload{
+			Summary:       notification.Text(),
+			Source:        pagerDutySource,
+			Severity:      "critical",
+			CustomDetails: notification,
+		},
+	})
+}
+
+// Resolve resolves the incident of the task. PagerDuty ignores the dedup keys without an open incident.
+func (n *PagerDutyNotifier) Resolve(taskUID uuid.UUID) error {
+	if n.IntegrationKey == "" {
+		return nil
+	}
+
+	return n.send(PagerDutyEvent{
+		RoutingKey:  n.IntegrationKey,
+		EventAction: PagerDutyActionResolve,
+		DedupKey:    PagerDutyDedupKey(taskUID),
+	})
+}
+
+func (n *PagerDutyNotifier) send(event PagerDutyEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+
+	// PagerDuty explains the refused events, like an invalid routing key, in the message of the error.
+	pagerDutyErr := struct {
+		Message string   `json:"message"`
+		Errors  []string `json:"errors"`
+	}{}
+
+	if json.NewDecoder(res.Body).Decode(&pagerDutyErr) == nil && pagerDutyErr.Message != "" {
+		return fmt.Errorf("pagerduty responded %s: %s %v", res.Status, pagerDutyErr.Message, pagerDutyErr.Errors)
+	}
+
+	return fmt.Errorf("pagerduty responded %s", res.Status)
+}
@@ -0,0 +1,93 @@
+package notification_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/notification"
+)
+
+func TestPagerDutyNotifier(t *testing.T) {
+	t.Parallel()
+	// Given
+	sent := make(chan notification.PagerDutyEvent, 3)
+	pagerDuty := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := notification.PagerDutyEvent{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&event))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		sent <- event
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer pagerDuty.Close()
+
+	notifier := notification.NewPagerDutyNotifier("R0UT1NG")
+	notifier.URL = pagerDuty.URL
+
+	disabled := notification.NewPagerDutyNotifier("")
+	disabled.URL = pagerDuty.URL
+
+	taskUID, _ := uuid.NewV4()
+	due := notification.TaskNotification{
+		Event: notification.TaskNotificationDue, TaskUID: taskUID, ShortCode: "T-7", Title: "Fix the pump",
+		Priority: notification.PriorityUrgent,
+	}
+
+	// When
+	errTrigger := notifier.Trigger(due)
+	errNormal := notifier.Trigger(notification.TaskNotification{
+		Event: notification.TaskNotificationDue, TaskUID: taskUID, Priority: notification.PriorityNormal,
+	})
+	errDisabled := disabled.Trigger(due)
+	errResolve := notifier.Resolve(taskUID)
+
+	// Then
+	assert.Nil(t, errTrigger)
+	assert.Nil(t, errNormal)
+	assert.Nil(t, errDisabled)
+	assert.Nil(t, errResolve)
+
+	assert.Len(t, sent, 2)
+
+	trigger := <-sent
+	assert.Equal(t, "R0UT1NG", trigger.RoutingKey)
+	assert.Equal(t, notification.PagerDutyActionTrigger, trigger.EventAction)
+	assert.Equal(t, "task:"+taskUID.String(), trigger.DedupKey)
+	assert.Equal(t, "[URGENT] T-7 Fix the pump is due", trigger.Payload.Summary)
+	assert.Equal(t, "critical", trigger.Payload.Severity)
+	assert.Equal(t, taskUID, trigger.Payload.CustomDetails.TaskUID)
+
+	resolve := <-sent
+	assert.Equal(t, notification.PagerDutyActionResolve, resolve.EventAction)
+	assert.Equal(t, trigger.DedupKey, resolve.DedupKey)
+	assert.Nil(t, resolve.Payload)
+}
+
+func TestPagerDutyNotifierError(t *testing.T) {
+	t.Parallel()
+	// Given
+	pagerDuty := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"status": "invalid event", "message": "Event object is invalid",`+
+			` "errors": ["Length of 'routing_key' is incorrect (should be 32 characters)"]}`)
+	}))
+	defer pagerDuty.Close()
+
+	notifier := notification.NewPagerDutyNotifier("short")
+	notifier.URL = pagerDuty.URL
+
+	taskUID, _ := uuid.NewV4()
+
+	// When
+	err := notifier.Resolve(taskUID)
+
+	// Then
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Length of 'routing_key' is incorrect")
+}
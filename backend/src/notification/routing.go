@@ -0,0 +1,87 @@
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// The channels the task notifications are sent through. Every notification is logged whatever its channels.
+const (
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+	ChannelSMS     = "sms"
+)
+
+// The priorities the urgent channels are enforced for, the same codes as the task priorities.
+const (
+	PriorityUrgent = "URGENT"
+	PriorityNormal = "NORMAL"
+	PriorityLow    = "LOW"
+)
+
+// urgentChannels are always used for the urgent tasks, the routing file can only add channels to them.
+var urgentChannels = []string{ChannelEmail, ChannelWebhook} //nolint:gochecknoglobals
+
+// NotificationRoutingConfig maps the task priorities to the channels their notifications are sent through,
+// like `{"URGENT": ["email", "webhook", "sms"], "LOW": []}`. A priority without channels is only logged.
+type NotificationRoutingConfig map[string][]string
+
+// DefaultNotificationRouting sends the urgent tasks by email and webhook, the normal ones by email and only
+// logs the low ones.
+func DefaultNotificationRouting() NotificationRoutingConfig {
+	return NotificationRoutingConfig{
+		PriorityUrgent: {ChannelEmail, ChannelWebhook},
+		PriorityNormal: {ChannelEmail},
+		PriorityLow:    {},
+	}
+}
+
+// LoadNotificationRoutingConfig reads the routing file, the default routing when there is no file.
+func LoadNotificationRoutingConfig(path string) (NotificationRoutingConfig, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultNotificationRouting(), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	routing := NotificationRoutingConfig{}
+
+	err = json.Unmarshal(content, &routing)
+	if err != nil {
+		return nil, fmt.Errorf("notification routing %s: %w", path, err)
+	}
+
+	for priority, channels := range routing {
+		for _, v := range channels {
+			switch v {
+			case ChannelEmail, ChannelWebhook, ChannelSMS:
+			default:
+				return nil, fmt.Errorf("notification routing %s: unknown channel %q of %s", path, v, priority)
+			}
+		}
+	}
+
+	for _, v := range urgentChannels {
+		if !routing.Routes(PriorityUrgent, v) {
+			routing[PriorityUrgent] = append(routing[PriorityUrgent], v)
+		}
+	}
+
+	return routing, nil
+}
+
+// Routes tells whether the notifications of the priority are sent through the channel.
+func (r NotificationRoutingConfig) Routes(priority, channel string) bool {
+	for _, v := range r[priority] {
+		if v == channel {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,173 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// ErrSMTPNotConfigured is returned by Send when there is no SMTP host to send the mails to.
+var ErrSMTPNotConfigured = errors.New("smtp host is not configured")
+
+// Mail is an HTML mail with its attachments.
+type Mail struct {
+	To          []string
+	Subject     string
+	HTML        string
+	Attachments []Attachment
+}
+
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// SMTPNotifier sends the mails through an SMTP server. The server has to support STARTTLS
+// when a username is given, net/smtp refuses to authenticate on a plain connection.
+type SMTPNotifier struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func NewSMTPNotifier(host, port, username, password, from string) *SMTPNotifier {
+	return &SMTPNotifier{
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+		From:     from,
+	}
+}
+
+func (n *SMTPNotifier) Send(mail Mail) error {
+	if n.Host == "" {
+		return ErrSMTPNotConfigured
+	}
+
+	if len(mail.To) == 0 {
+		return errors.New("mail has no recipient")
+	}
+
+	message, err := BuildMessage(n.From, mail, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if n.Username != "" {
+		auth = smtp.PlainAuth("", n.Username, n.Password, n.Host)
+	}
+
+	return smtp.SendMail(net.JoinHostPort(n.Host, n.Port), auth, n.From, mail.To, message)
+}
+
+// BuildMessage encodes the mail as a MIME message, multipart when it has attachments.
+func BuildMessage(from string, mail Mail, date time.Time) ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(mail.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", mail.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if len(mail.Attachments) == 0 {
+		buf.WriteString("Content-Type: text/html; charset=utf-8\r\n")
+		buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+		err := writeQuotedPrintable(&buf, mail.HTML)
+		if err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
+
+	writer := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", writer.Boundary())
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/html; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeQuotedPrintable(part, mail.HTML)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range mail.Attachments {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {v.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": v.Filename})},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = writeBase64(part, v.Content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, text string) error {
+	qp := quotedprintable.NewWriter(w)
+
+	_, err := qp.Write([]byte(text))
+	if err != nil {
+		return err
+	}
+
+	return qp.Close()
+}
+
+// writeBase64 wraps the encoded content at 76 characters, the maximum line length of RFC 2045.
+func writeBase64(w io.Writer, content []byte) error {
+	const lineLength = 76
+
+	encoded := base64.StdEncoding.EncodeToString(content)
+
+	for len(encoded) > 0 {
+		n := lineLength
+		if len(encoded) < n {
+			n = len(encoded)
+		}
+
+		_, err := w.Write([]byte(encoded[:n] + "\r\n"))
+		if err != nil {
+			return err
+		}
+
+		encoded = encoded[n:]
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package notification_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/notification"
+)
+
+func TestBuildMessage(t *testing.T) {
+	t.Parallel()
+	// Given
+	m := notification.Mail{
+		To:      []string{"manager@example.com", "owner@example.com"},
+		Subject: "Weekly summary",
+		HTML:    "<p>Open tasks: 3</p>",
+		Attachments: []notification.Attachment{
+			{Filename: "summary.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4")},
+		},
+	}
+
+	// When
+	message, err := notification.BuildMessage("tania@example.com", m, time.Now())
+
+	// Then
+	assert.Nil(t, err)
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(message))
+	assert.Nil(t, err)
+	assert.Equal(t, "Weekly summary", parsed.Header.Get("Subject"))
+	assert.Equal(t, "manager@example.com, owner@example.com", parsed.Header.Get("To"))
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	assert.Nil(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+
+	body, err := reader.NextPart()
+	assert.Nil(t, err)
+
+	html, _ := io.ReadAll(body)
+	assert.Equal(t, "<p>Open tasks: 3</p>", string(html))
+
+	attachment, err := reader.NextPart()
+	assert.Nil(t, err)
+	assert.Equal(t, "summary.pdf", attachment.FileName())
+
+	encoded, _ := io.ReadAll(attachment)
+	content, _ := base64.StdEncoding.DecodeString(string(encoded))
+	assert.Equal(t, "%PDF-1.4", string(content))
+}
+
+func TestSMTPNotifierNotConfigured(t *testing.T) {
+	t.Parallel()
+	// Given
+	notifier := notification.NewSMTPNotifier("", "587", "", "", "tania@example.com")
+
+	// When
+	err := notifier.Send(notification.Mail{To: []string{"manager@example.com"}})
+
+	// Then
+	assert.Equal(t, notification.ErrSMTPNotConfigured, err)
+}
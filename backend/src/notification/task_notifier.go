@@ -0,0 +1,130 @@
+package notification
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// The task events the notifications are sent for.
+const (
+	TaskNotificationCreated = "created"
+	TaskNotificationDue     = "due"
+)
+
+// Notification is sent through the channels routed for its priority, as its line of text. The webhook posts it
+// as JSON, with its event type in the catalog of the webhook events.
+type Notification interface {
+	Text() string
+	RoutingPriority() string
+	WebhookEvent() string
+}
+
+// TaskNotification is a task event sent through the channels of the task priority.
+type TaskNotification struct {
+	Event     string     `json:"event"`
+	TaskUID   uuid.UUID  `json:"task_id"`
+	ShortCode string     `json:"short_code"`
+	Title     string     `json:"title"`
+	Priority  string     `json:"priority"`
+	Category  string     `json:"category"`
+	DueDate   *time.Time `json:"due_date"`
+}
+
+// RoutingPriority is the priority of the task.
+func (n TaskNotification) RoutingPriority() string {
+	return n.Priority
+}
+
+// WebhookEvent is the type the notification is posted to the webhook with.
+func (n TaskNotification) WebhookEvent() string {
+	if n.Event == TaskNotificationDue {
+		return WebhookEventTaskDue
+	}
+
+	return WebhookEventTaskCreated
+}
+
+// Text is the notification as a line of text, like the SMS and the log.
+func (n TaskNotification) Text() string {
+	text := fmt.Sprintf("[%s] %s", n.Priority, n.Title)
+	if n.ShortCode != "" {
+		text = fmt.Sprintf("[%s] %s %s", n.Priority, n.ShortCode, n.Title)
+	}
+
+	switch {
+	case n.Event == TaskNotificationDue:
+		return text + " is due"
+	case n.DueDate != nil:
+		return fmt.Sprintf("%s is due on %s", text, n.DueDate.Format("2006-01-02"))
+	}
+
+	return text + " was created"
+}
+
+// TaskNotifier logs every notification, the task ones and the alerts, and sends it through the channels
+// routed for its priority. A channel which isn't configured is skipped.
+type TaskNotifier struct {
+	Mail    *MailSender
+	Webhook *WebhookDispatcher
+	SMS     *TwilioSMSSender
+}
+
+// Notify sends the notification, the failed sends are logged without stopping the other channels.
+func (n *TaskNotifier) Notify(notification Notification) {
+	log.Println("Notification.", notification.Text())
+
+	if n.Mail != nil {
+		if err := n.Mail.Send(notification); err != nil {
+			log.Println("Notification email failed.", err)
+		}
+	}
+
+	if n.Webhook != nil {
+		if err := n.Webhook.Dispatch(notification); err != nil {
+			log.Println("Notification webhook failed.", err)
+		}
+	}
+
+	if n.SMS != nil {
+		if err := n.SMS.Send(notification); err != nil {
+			log.Println("Notification SMS failed.", err)
+		}
+	}
+}
+
+// Mailer sends a mail, like the SMTPNotifier.
+type Mailer interface {
+	Send(mail Mail) error
+}
+
+// MailSender mails the task notifications of the priorities routed to the email channel.
+type MailSender struct {
+	Mailer  Mailer
+	To      []string
+	Routing NotificationRoutingConfig
+}
+
+func NewMailSender(mailer Mailer, to []string, routing NotificationRoutingConfig) *MailSender {
+	return &MailSender{
+		Mailer:  mailer,
+		To:      to,
+		Routing: routing,
+	}
+}
+
+// Send mails the notification when its priority is routed to the email channel and there are recipients.
+func (s *MailSender) Send(notification Notification) error {
+	if !s.Routing.Routes(notification.RoutingPriority(), ChannelEmail) || len(s.To) == 0 {
+		return nil
+	}
+
+	return s.Mailer.Send(Mail{
+		To:      s.To,
+		Subject: notification.Text(),
+		HTML:    "<p>" + html.EscapeString(notification.Text()) + "</p>",
+	})
+}
@@ -0,0 +1,145 @@
+package notification_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/notification"
+)
+
+type fakeMailer struct {
+	Sent []notification.Mail
+}
+
+func (m *fakeMailer) Send(mail notification.Mail) error {
+	m.Sent = append(m.Sent, mail)
+
+	return nil
+}
+
+func TestLoadNotificationRoutingConfig(t *testing.T) {
+	t.Parallel()
+	// Given
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notification_routing.json")
+	assert.Nil(t, os.WriteFile(path, []byte(`{"URGENT": ["sms"], "NORMAL": ["webhook"], "LOW": []}`), 0o600))
+
+	invalidPath := filepath.Join(dir, "invalid.json")
+	assert.Nil(t, os.WriteFile(invalidPath, []byte(`{"LOW": ["pigeon"]}`), 0o600))
+
+	// When
+	routing, err := notification.LoadNotificationRoutingConfig(path)
+	defaultRouting, errDefault := notification.LoadNotificationRoutingConfig(filepath.Join(dir, "missing.json"))
+	_, errInvalid := notification.LoadNotificationRoutingConfig(invalidPath)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Nil(t, errDefault)
+	assert.NotNil(t, errInvalid)
+
+	// The urgent tasks are always mailed and posted.
+	assert.True(t, routing.Routes(notification.PriorityUrgent, notification.ChannelSMS))
+	assert.True(t, routing.Routes(notification.PriorityUrgent, notification.ChannelEmail))
+	assert.True(t, routing.Routes(notification.PriorityUrgent, notification.ChannelWebhook))
+	assert.False(t, routing.Routes(notification.PriorityNormal, notification.ChannelEmail))
+	assert.True(t, routing.Routes(notification.PriorityNormal, notification.ChannelWebhook))
+	assert.False(t, routing.Routes(notification.PriorityLow, notification.ChannelEmail))
+
+	assert.Equal(t, notification.DefaultNotificationRouting(), defaultRouting)
+}
+
+func TestTaskNotifierRouting(t *testing.T) {
+	t.Parallel()
+	// Given
+	posted := make(chan notification.TaskNotification, 3)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		taskNotification := notification.TaskNotification{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&taskNotification))
+
+		posted <- taskNotification
+	}))
+	defer webhook.Close()
+
+	texted := make(chan url.Values, 3)
+	twilio := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sid, token, _ := r.BasicAuth()
+		assert.Equal(t, "AC123", sid)
+		assert.Equal(t, "secret", token)
+		assert.Equal(t, "/2010-04-01/Accounts/AC123/Messages.json", r.URL.Path)
+		assert.Nil(t, r.ParseForm())
+
+		texted <- r.PostForm
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer twilio.Close()
+
+	routing := notification.NotificationRoutingConfig{
+		notification.PriorityUrgent: {notification.ChannelEmail, notification.ChannelWebhook, notification.ChannelSMS},
+		notification.PriorityNormal: {notification.ChannelEmail},
+		notification.PriorityLow:    {},
+	}
+
+	mailer := &fakeMailer{}
+	sms := notification.NewTwilioSMSSender("AC123", "secret", "+15005550006", []string{"+628123"}, routing)
+	sms.BaseURL = twilio.URL
+
+	notifier := notification.TaskNotifier{
+		Mail:    notification.NewMailSender(mailer, []string{"manager@example.com"}, routing),
+		Webhook: notification.NewWebhookDispatcher(webhook.URL, routing),
+		SMS:     sms,
+	}
+
+	taskUID, _ := uuid.NewV4()
+
+	// When
+	notifier.Notify(notification.TaskNotification{
+		Event: notification.TaskNotificationDue, TaskUID: taskUID, ShortCode: "T-7", Title: "Fix the pump",
+		Priority: notification.PriorityUrgent,
+	})
+	notifier.Notify(notification.TaskNotification{Title: "Water", Priority: notification.PriorityNormal})
+	notifier.Notify(notification.TaskNotification{Title: "Sweep", Priority: notification.PriorityLow})
+
+	// Then
+	assert.Len(t, mailer.Sent, 2)
+	assert.Equal(t, "[URGENT] T-7 Fix the pump is due", mailer.Sent[0].Subject)
+	assert.Equal(t, "[NORMAL] Water was created", mailer.Sent[1].Subject)
+
+	assert.Len(t, posted, 1)
+	assert.Equal(t, taskUID, (<-posted).TaskUID)
+
+	assert.Len(t, texted, 1)
+	form := <-texted
+	assert.Equal(t, "+628123", form.Get("To"))
+	assert.Equal(t, "+15005550006", form.Get("From"))
+	assert.Equal(t, "[URGENT] T-7 Fix the pump is due", form.Get("Body"))
+}
+
+func TestTwilioSMSSenderError(t *testing.T) {
+	t.Parallel()
+	// Given
+	twilio := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"code": 21211, "message": "The 'To' number is not a valid phone number."}`)
+	}))
+	defer twilio.Close()
+
+	routing := notification.NotificationRoutingConfig{notification.PriorityUrgent: {notification.ChannelSMS}}
+	sms := notification.NewTwilioSMSSender("AC123", "secret", "+15005550006", []string{"123"}, routing)
+	sms.BaseURL = twilio.URL
+
+	// When
+	err := sms.Send(notification.TaskNotification{Title: "Fix the pump", Priority: notification.PriorityUrgent})
+
+	// Then
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "The 'To' number is not a valid phone number.")
+}
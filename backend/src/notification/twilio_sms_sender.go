@@ -0,0 +1,94 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	twilioBaseURL = "https://api.twilio.com"
+	twilioTimeout = 10 * time.Second
+)
+
+// TwilioSMSSender texts the task notifications of the priorities routed to the sms channel through the
+// Messages API of Twilio.
+type TwilioSMSSender struct {
+	AccountSID string
+	AuthToken  string
+	From       string
+	To         []string
+	Routing    NotificationRoutingConfig
+	BaseURL    string
+	Client     *http.Client
+}
+
+func NewTwilioSMSSender(
+	accountSID, authToken, from string, to []string, routing NotificationRoutingConfig,
+) *TwilioSMSSender {
+	return &TwilioSMSSender{
+		AccountSID: accountSID,
+		AuthToken:  authToken,
+		From:       from,
+		To:         to,
+		Routing:    routing,
+		BaseURL:    twilioBaseURL,
+		Client:     &http.Client{Timeout: twilioTimeout},
+	}
+}
+
+// Send texts the notification to every recipient when its priority is routed to the sms channel and Twilio
+// is configured. It stops at the first failed message.
+func (s *TwilioSMSSender) Send(notification Notification) error {
+	if !s.Routing.Routes(notification.RoutingPriority(), ChannelSMS) || s.AccountSID == "" || len(s.To) == 0 {
+		return nil
+	}
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", s.BaseURL, url.PathEscape(s.AccountSID))
+
+	for _, to := range s.To {
+		form := url.Values{"To": {to}, "From": {s.From}, "Body": {notification.Text()}}
+
+		req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+		if err != nil {
+			return err
+		}
+
+		req.SetBasicAuth(s.AccountSID, s.AuthToken)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		err = s.send(req)
+		if err != nil {
+			return fmt.Errorf("sms to %s: %w", to, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *TwilioSMSSender) send(req *http.Request) error {
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+
+	// Twilio explains the refused messages, like an unverified number, in the message of the error.
+	twilioErr := struct {
+		Message string `json:"message"`
+	}{}
+
+	if json.NewDecoder(res.Body).Decode(&twilioErr) == nil && twilioErr.Message != "" {
+		return fmt.Errorf("twilio responded %s: %s", res.Status, twilioErr.Message)
+	}
+
+	return fmt.Errorf("twilio responded %s", res.Status)
+}
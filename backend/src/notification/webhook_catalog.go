@@ -0,0 +1,213 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// The webhook event types of the task notifications.
+const (
+	WebhookEventTaskCreated = "task.created"
+	WebhookEventTaskDue     = "task.due"
+)
+
+// WebhookEventType is an entry of the catalog of the webhook events, with the JSON schema of its payload
+// and an example of it. Both are generated from the payload struct, so they follow its fields.
+type WebhookEventType struct {
+	Type        string          `json:"type"`
+	Description string          `json:"description"`
+	Schema      *Schema         `json:"schema"`
+	Example     json.RawMessage `json:"example"`
+}
+
+// Schema is the subset of JSON Schema the payloads are described with. The type is a string, or a list with
+// "null" for the fields which can be null. Every property is listed, the others are not allowed.
+type Schema struct {
+	Type                 interface{}        `json:"type"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+}
+
+// NewWebhookEventType describes the event of the example notification, its type is the one the example
+// is posted with.
+func NewWebhookEventType(example Notification, description string) (WebhookEventType, error) {
+	schema, err := SchemaOf(example)
+	if err != nil {
+		return WebhookEventType{}, err
+	}
+
+	body, err := json.Marshal(example)
+	if err != nil {
+		return WebhookEventType{}, err
+	}
+
+	return WebhookEventType{
+		Type:        example.WebhookEvent(),
+		Description: description,
+		Schema:      schema,
+		Example:     body,
+	}, nil
+}
+
+// TaskWebhookEventTypes describes the webhook events of the task notifications.
+func TaskWebhookEventTypes() ([]WebhookEventType, error) {
+	taskUID, err := uuid.FromString("5b9c1c4e-7d66-4f0e-9f55-3a0d1f7f2a10")
+	if err != nil {
+		return nil, err
+	}
+
+	dueDate := time.Date(2026, time.October, 14, 9, 0, 0, 0, time.UTC)
+
+	created, err := NewWebhookEventType(TaskNotification{
+		Event:     TaskNotificationCreated,
+		TaskUID:   taskUID,
+		ShortCode: "T-0042",
+		Title:     "Spray the tomatoes",
+		Priority:  PriorityUrgent,
+		Category:  "PESTCONTROL",
+		DueDate:   &dueDate,
+	}, "A task of a priority routed to the webhook was created. The due date is null without one.")
+	if err != nil {
+		return nil, err
+	}
+
+	due, err := NewWebhookEventType(TaskNotification{
+		Event:     TaskNotificationDue,
+		TaskUID:   taskUID,
+		ShortCode: "T-0042",
+		Title:     "Spray the tomatoes",
+		Priority:  PriorityUrgent,
+		Category:  "PESTCONTROL",
+		DueDate:   &dueDate,
+	}, "A task of a priority routed to the webhook is due.")
+	if err != nil {
+		return nil, err
+	}
+
+	return []WebhookEventType{created, due}, nil
+}
+
+// SchemaOf generates the schema of the JSON encoding of the value, a struct.
+func SchemaOf(value interface{}) (*Schema, error) {
+	return schemaOfType(reflect.TypeOf(value))
+}
+
+var ( //nolint:gochecknoglobals
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+)
+
+func schemaOfType(t reflect.Type) (*Schema, error) {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	case t == uuidType:
+		return &Schema{Type: "string", Format: "uuid"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema, err := schemaOfType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		if types, ok := schema.Type.(string); ok {
+			schema.Type = []string{types, "null"}
+		}
+
+		return schema, nil
+
+	case reflect.Struct:
+		return schemaOfStruct(t)
+
+	case reflect.Slice, reflect.Array:
+		// The bytes are encoded as base64, or are raw JSON, neither is described.
+		if t.Elem().Kind() == reflect.Uint8 {
+			break
+		}
+
+		items, err := schemaOfType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		return &Schema{Type: []string{"array", "null"}, Items: items}, nil
+
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	}
+
+	return nil, fmt.Errorf("webhook schema: unsupported type %s", t)
+}
+
+// schemaOfStruct lists the fields the way encoding/json encodes them: by their json tag, the embedded
+// structs inlined, and the omitempty ones not required.
+func schemaOfStruct(t reflect.Type) (*Schema, error) {
+	additionalProperties := false
+	schema := &Schema{
+		Type:                 "object",
+		Properties:           map[string]*Schema{},
+		Required:             []string{},
+		AdditionalProperties: &additionalProperties,
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			embedded, err := schemaOfStruct(field.Type)
+			if err != nil {
+				return nil, err
+			}
+
+			for k, v := range embedded.Properties {
+				schema.Properties[k] = v
+			}
+
+			schema.Required = append(schema.Required, embedded.Required...)
+
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		property, err := schemaOfType(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+
+		schema.Properties[name] = property
+
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema, nil
+}
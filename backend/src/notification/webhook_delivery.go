@@ -0,0 +1,100 @@
+package notification
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/sasha-s/go-deadlock"
+)
+
+// The statuses of a webhook delivery.
+const (
+	WebhookDeliverySent   = "SENT"
+	WebhookDeliveryFailed = "FAILED"
+)
+
+// WebhookDelivery is one post of a payload to a webhook. A replay is a delivery of its own, with the payload
+// of the delivery it replays.
+type WebhookDelivery struct {
+	UID         uuid.UUID       `json:"uid"`
+	WebhookID   string          `json:"webhook_id"`
+	EventType   string          `json:"event_type"`
+	Payload     json.RawMessage `json:"payload"`
+	ReplayOf    *uuid.UUID      `json:"replay_of"`
+	Status      string          `json:"status"`
+	StatusCode  int             `json:"status_code"`
+	Error       string          `json:"error"`
+	CreatedDate time.Time       `json:"created_date"`
+}
+
+// WebhookDeliveryStore keeps the history of the deliveries, so a missed one can be replayed.
+type WebhookDeliveryStore interface {
+	SaveDelivery(delivery WebhookDelivery) error
+	FindDelivery(uid uuid.UUID) (WebhookDelivery, bool, error)
+
+	// FindDeliveries lists the latest deliveries of the webhook first, all of them when the limit is 0.
+	FindDeliveries(webhookID string, limit int) ([]WebhookDelivery, error)
+}
+
+type WebhookDeliveryStorage struct {
+	Lock       *deadlock.RWMutex
+	Deliveries map[uuid.UUID]WebhookDelivery
+}
+
+func CreateWebhookDeliveryStorage() *WebhookDeliveryStorage {
+	return &WebhookDeliveryStorage{
+		Lock:       &deadlock.RWMutex{},
+		Deliveries: make(map[uuid.UUID]WebhookDelivery),
+	}
+}
+
+type WebhookDeliveryStoreInMemory struct {
+	Storage *WebhookDeliveryStorage
+}
+
+func NewWebhookDeliveryStoreInMemory(s *WebhookDeliveryStorage) WebhookDeliveryStore {
+	return &WebhookDeliveryStoreInMemory{Storage: s}
+}
+
+func (s *WebhookDeliveryStoreInMemory) SaveDelivery(delivery WebhookDelivery) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	s.Storage.Deliveries[delivery.UID] = delivery
+
+	return nil
+}
+
+func (s *WebhookDeliveryStoreInMemory) FindDelivery(uid uuid.UUID) (WebhookDelivery, bool, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	delivery, ok := s.Storage.Deliveries[uid]
+
+	return delivery, ok, nil
+}
+
+func (s *WebhookDeliveryStoreInMemory) FindDeliveries(webhookID string, limit int) ([]WebhookDelivery, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	deliveries := []WebhookDelivery{}
+
+	for _, v := range s.Storage.Deliveries {
+		if v.WebhookID == webhookID {
+			deliveries = append(deliveries, v)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedDate.After(deliveries[j].CreatedDate)
+	})
+
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, nil
+}
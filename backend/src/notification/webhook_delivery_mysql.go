@@ -0,0 +1,102 @@
+package notification
+
+import (
+	"database/sql"
+
+	"github.com/gofrs/uuid"
+)
+
+type WebhookDeliveryStoreMysql struct {
+	DB *sql.DB
+}
+
+func NewWebhookDeliveryStoreMysql(db *sql.DB) WebhookDeliveryStore {
+	return &WebhookDeliveryStoreMysql{DB: db}
+}
+
+func (s *WebhookDeliveryStoreMysql) SaveDelivery(delivery WebhookDelivery) error {
+	var replayOf []byte
+
+	if delivery.ReplayOf != nil {
+		replayOf = delivery.ReplayOf.Bytes()
+	}
+
+	_, err := s.DB.Exec(`REPLACE INTO WEBHOOK_DELIVERY (`+webhookDeliveryColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		delivery.UID.Bytes(),
+		delivery.WebhookID,
+		delivery.EventType,
+		string(delivery.Payload),
+		replayOf,
+		delivery.Status,
+		delivery.StatusCode,
+		delivery.Error,
+		delivery.CreatedDate)
+
+	return err
+}
+
+func (s *WebhookDeliveryStoreMysql) FindDelivery(uid uuid.UUID) (WebhookDelivery, bool, error) {
+	deliveries, err := s.findAll(`SELECT `+webhookDeliveryColumns+` FROM WEBHOOK_DELIVERY WHERE UID = ?`,
+		uid.Bytes())
+	if err != nil || len(deliveries) == 0 {
+		return WebhookDelivery{}, false, err
+	}
+
+	return deliveries[0], true, nil
+}
+
+func (s *WebhookDeliveryStoreMysql) FindDeliveries(webhookID string, limit int) ([]WebhookDelivery, error) {
+	if limit <= 0 {
+		return s.findAll(`SELECT `+webhookDeliveryColumns+`
+			FROM WEBHOOK_DELIVERY WHERE WEBHOOK_ID = ? ORDER BY CREATED_DATE DESC`, webhookID)
+	}
+
+	return s.findAll(`SELECT `+webhookDeliveryColumns+`
+		FROM WEBHOOK_DELIVERY WHERE WEBHOOK_ID = ? ORDER BY CREATED_DATE DESC LIMIT ?`, webhookID, limit)
+}
+
+func (s *WebhookDeliveryStoreMysql) findAll(query string, args ...interface{}) ([]WebhookDelivery, error) {
+	rows, err := s.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []WebhookDelivery{}
+
+	for rows.Next() {
+		var (
+			uid, replayOf []byte
+			payload       string
+		)
+
+		v := WebhookDelivery{}
+
+		err = rows.Scan(&uid, &v.WebhookID, &v.EventType, &payload, &replayOf, &v.Status, &v.StatusCode, &v.Error,
+			&v.CreatedDate)
+		if err != nil {
+			return nil, err
+		}
+
+		v.UID, err = uuid.FromBytes(uid)
+		if err != nil {
+			return nil, err
+		}
+
+		if replayOf != nil {
+			replayOfUID, err := uuid.FromBytes(replayOf)
+			if err != nil {
+				return nil, err
+			}
+
+			v.ReplayOf = &replayOfUID
+		}
+
+		v.Payload = []byte(payload)
+
+		deliveries = append(deliveries, v)
+	}
+
+	return deliveries, rows.Err()
+}
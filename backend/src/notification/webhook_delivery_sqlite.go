@@ -0,0 +1,115 @@
+package notification
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type WebhookDeliveryStoreSqlite struct {
+	DB *sql.DB
+}
+
+func NewWebhookDeliveryStoreSqlite(db *sql.DB) WebhookDeliveryStore {
+	return &WebhookDeliveryStoreSqlite{DB: db}
+}
+
+// webhookDeliveryDateSqlite keeps every digit of the nanoseconds, so the dates sort as strings.
+const webhookDeliveryDateSqlite = "2006-01-02T15:04:05.000000000Z07:00"
+
+const webhookDeliveryColumns = `UID, WEBHOOK_ID, EVENT_TYPE, PAYLOAD, REPLAY_OF_UID, STATUS, STATUS_CODE, ERROR,
+	CREATED_DATE`
+
+func (s *WebhookDeliveryStoreSqlite) SaveDelivery(delivery WebhookDelivery) error {
+	var replayOf *string
+
+	if delivery.ReplayOf != nil {
+		uid := delivery.ReplayOf.String()
+		replayOf = &uid
+	}
+
+	_, err := s.DB.Exec(`INSERT OR REPLACE INTO WEBHOOK_DELIVERY (`+webhookDeliveryColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		delivery.UID.String(),
+		delivery.WebhookID,
+		delivery.EventType,
+		string(delivery.Payload),
+		replayOf,
+		delivery.Status,
+		delivery.StatusCode,
+		delivery.Error,
+		delivery.CreatedDate.UTC().Format(webhookDeliveryDateSqlite))
+
+	return err
+}
+
+func (s *WebhookDeliveryStoreSqlite) FindDelivery(uid uuid.UUID) (WebhookDelivery, bool, error) {
+	deliveries, err := s.findAll(`SELECT `+webhookDeliveryColumns+` FROM WEBHOOK_DELIVERY WHERE UID = ?`,
+		uid.String())
+	if err != nil || len(deliveries) == 0 {
+		return WebhookDelivery{}, false, err
+	}
+
+	return deliveries[0], true, nil
+}
+
+func (s *WebhookDeliveryStoreSqlite) FindDeliveries(webhookID string, limit int) ([]WebhookDelivery, error) {
+	if limit <= 0 {
+		return s.findAll(`SELECT `+webhookDeliveryColumns+`
+			FROM WEBHOOK_DELIVERY WHERE WEBHOOK_ID = ? ORDER BY CREATED_DATE DESC`, webhookID)
+	}
+
+	return s.findAll(`SELECT `+webhookDeliveryColumns+`
+		FROM WEBHOOK_DELIVERY WHERE WEBHOOK_ID = ? ORDER BY CREATED_DATE DESC LIMIT ?`, webhookID, limit)
+}
+
+func (s *WebhookDeliveryStoreSqlite) findAll(query string, args ...interface{}) ([]WebhookDelivery, error) {
+	rows, err := s.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []WebhookDelivery{}
+
+	for rows.Next() {
+		var (
+			uid, payload, createdDate string
+			replayOf                  sql.NullString
+		)
+
+		v := WebhookDelivery{}
+
+		err = rows.Scan(&uid, &v.WebhookID, &v.EventType, &payload, &replayOf, &v.Status, &v.StatusCode, &v.Error,
+			&createdDate)
+		if err != nil {
+			return nil, err
+		}
+
+		v.UID, err = uuid.FromString(uid)
+		if err != nil {
+			return nil, err
+		}
+
+		if replayOf.Valid {
+			replayOfUID, err := uuid.FromString(replayOf.String)
+			if err != nil {
+				return nil, err
+			}
+
+			v.ReplayOf = &replayOfUID
+		}
+
+		v.Payload = []byte(payload)
+
+		v.CreatedDate, err = time.Parse(time.RFC3339Nano, createdDate)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, v)
+	}
+
+	return deliveries, rows.Err()
+}
@@ -0,0 +1,190 @@
+package notification
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/auth"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// NotificationWebhookID is the ID of the webhook the notifications are posted to, the only webhook for now.
+const NotificationWebhookID = "notifications"
+
+// The headers of the webhook posts. The signature is the hex HMAC-SHA256, with the secret of the webhook,
+// of the timestamp, a dot and the body, like `sha256=5d41...`. A replay is signed again with its own timestamp.
+const (
+	HeaderWebhookEvent     = "X-Tania-Event"
+	HeaderWebhookDelivery  = "X-Tania-Delivery"
+	HeaderWebhookTimestamp = "X-Tania-Timestamp"
+	HeaderWebhookSignature = "X-Tania-Signature"
+	HeaderWebhookReplay    = "X-Tania-Replay"
+	HeaderWebhookReplayOf  = "X-Tania-Replay-Of"
+)
+
+// WebhookDispatcher posts the task notifications of the priorities routed to the webhook channel as JSON.
+// The posts are signed when there is a secret, and recorded when there is a store of the deliveries.
+type WebhookDispatcher struct {
+	ID         string
+	URL        string
+	Secret     string
+	Routing    NotificationRoutingConfig
+	Client     *http.Client
+	Deliveries WebhookDeliveryStore
+	// Enabled turns the posts off while it's false, they are on without it.
+	Enabled func() bool
+}
+
+func NewWebhookDispatcher(url string, routing NotificationRoutingConfig) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		ID:      NotificationWebhookID,
+		URL:     url,
+		Routing: routing,
+		Client:  &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Dispatch posts the notification when its priority is routed to the webhook channel and there is a URL.
+// The responses other than 2xx are errors. The receiver has no role, so every redacted field is replaced.
+func (d *WebhookDispatcher) Dispatch(notification Notification) error {
+	if !d.Routing.Routes(notification.RoutingPriority(), ChannelWebhook) || d.URL == "" {
+		return nil
+	}
+
+	if d.Enabled != nil && !d.Enabled() {
+		return nil
+	}
+
+	body, err := json.Marshal(auth.RedactAll(notification))
+	if err != nil {
+		return err
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+
+	delivery, err := d.deliver(WebhookDelivery{
+		UID:         uid,
+		WebhookID:   d.ID,
+		EventType:   notification.WebhookEvent(),
+		Payload:     body,
+		CreatedDate: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if delivery.Status == WebhookDeliveryFailed {
+		return fmt.Errorf("webhook %s", delivery.Error)
+	}
+
+	return nil
+}
+
+// Replay posts the payload of the delivery again, as a new delivery marked as its replay. It returns false
+// when the webhook has no such delivery. A failed post is the status of the returned delivery.
+func (d *WebhookDispatcher) Replay(deliveryUID uuid.UUID) (WebhookDelivery, bool, error) {
+	if d.Deliveries == nil {
+		return WebhookDelivery{}, false, nil
+	}
+
+	original, found, err := d.Deliveries.FindDelivery(deliveryUID)
+	if err != nil || !found || original.WebhookID != d.ID {
+		return WebhookDelivery{}, false, err
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return WebhookDelivery{}, false, err
+	}
+
+	delivery, err := d.deliver(WebhookDelivery{
+		UID:         uid,
+		WebhookID:   d.ID,
+		EventType:   original.EventType,
+		Payload:     original.Payload,
+		ReplayOf:    &original.UID,
+		CreatedDate: time.Now(),
+	})
+	if err != nil {
+		return WebhookDelivery{}, false, err
+	}
+
+	return delivery, true, nil
+}
+
+// deliver posts the payload of the delivery and records it with its outcome. The error is the one of
+// the store, not of the post.
+func (d *WebhookDispatcher) deliver(delivery WebhookDelivery) (WebhookDelivery, error) {
+	delivery.Status = WebhookDeliverySent
+
+	statusCode, err := d.post(delivery)
+	delivery.StatusCode = statusCode
+
+	if err != nil {
+		delivery.Status = WebhookDeliveryFailed
+		delivery.Error = err.Error()
+	}
+
+	if d.Deliveries == nil {
+		return delivery, nil
+	}
+
+	return delivery, d.Deliveries.SaveDelivery(delivery)
+}
+
+func (d *WebhookDispatcher) post(delivery WebhookDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, d.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(delivery.CreatedDate.Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookEvent, delivery.EventType)
+	req.Header.Set(HeaderWebhookDelivery, delivery.UID.String())
+	req.Header.Set(HeaderWebhookTimestamp, timestamp)
+
+	if d.Secret != "" {
+		req.Header.Set(HeaderWebhookSignature, WebhookSignature(d.Secret, timestamp, delivery.Payload))
+	}
+
+	if delivery.ReplayOf != nil {
+		req.Header.Set(HeaderWebhookReplay, "true")
+		req.Header.Set(HeaderWebhookReplayOf, delivery.ReplayOf.String())
+	}
+
+	res, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("responded %s", res.Status)
+	}
+
+	return res.StatusCode, nil
+}
+
+// WebhookSignature is the value of the signature header of a post, for the receivers to check theirs with.
+func WebhookSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
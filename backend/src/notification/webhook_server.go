@@ -0,0 +1,105 @@
+package notification
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// defaultWebhookDeliveryLimit is the number of deliveries listed without a limit.
+const defaultWebhookDeliveryLimit = 50
+
+// WebhookServer is the catalog of the webhook events and the history of the deliveries, for the integrators.
+type WebhookServer struct {
+	Catalog  []WebhookEventType
+	Webhooks map[string]*WebhookDispatcher
+}
+
+func NewWebhookServer(catalog []WebhookEventType, webhooks ...*WebhookDispatcher) *WebhookServer {
+	s := &WebhookServer{Catalog: catalog, Webhooks: map[string]*WebhookDispatcher{}}
+
+	for _, v := range webhooks {
+		s.Webhooks[v.ID] = v
+	}
+
+	return s
+}
+
+// Mount defines the webhook endpoints with their handlers.
+func (s *WebhookServer) Mount(g *echo.Group) {
+	g.GET("/events", s.GetWebhookEventTypes)
+	g.GET("/:id/deliveries", s.FindWebhookDeliveries)
+	g.POST("/:id/deliveries/:delivery_id/replay", s.ReplayWebhookDelivery)
+}
+
+func (s *WebhookServer) GetWebhookEventTypes(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string][]WebhookEventType{"data": s.Catalog})
+}
+
+// FindWebhookDeliveries lists the latest deliveries of the webhook first, the last 50 without a limit.
+func (s *WebhookServer) FindWebhookDeliveries(c echo.Context) error {
+	webhook, ok := s.Webhooks[c.Param("id")]
+	if !ok {
+		return webhookError(c, http.StatusNotFound, "id", "NOT_FOUND", "Data not found.")
+	}
+
+	limit := defaultWebhookDeliveryLimit
+
+	if l := c.QueryParam("limit"); l != "" {
+		var err error
+
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit <= 0 {
+			return webhookError(c, http.StatusBadRequest, "limit", "PARSE_FAILED",
+				"Parsing failed. Make sure the input is correct.")
+		}
+	}
+
+	deliveries := []WebhookDelivery{}
+
+	if webhook.Deliveries != nil {
+		var err error
+
+		deliveries, err = webhook.Deliveries.FindDeliveries(webhook.ID, limit)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string][]WebhookDelivery{"data": deliveries})
+}
+
+// ReplayWebhookDelivery posts the payload of the delivery again, signed again and marked as a replay.
+// The replay is returned with its status, a failed post included.
+func (s *WebhookServer) ReplayWebhookDelivery(c echo.Context) error {
+	webhook, ok := s.Webhooks[c.Param("id")]
+	if !ok {
+		return webhookError(c, http.StatusNotFound, "id", "NOT_FOUND", "Data not found.")
+	}
+
+	deliveryUID, err := uuid.FromString(c.Param("delivery_id"))
+	if err != nil {
+		return webhookError(c, http.StatusNotFound, "delivery_id", "NOT_FOUND", "Data not found.")
+	}
+
+	delivery, found, err := webhook.Replay(deliveryUID)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return webhookError(c, http.StatusNotFound, "delivery_id", "NOT_FOUND", "Data not found.")
+	}
+
+	return c.JSON(http.StatusOK, map[string]WebhookDelivery{"data": delivery})
+}
+
+func webhookError(c echo.Context, status int, field, code, message string) error {
+	return c.JSON(status, map[string]string{
+		"field_name":    field,
+		"error_code":    code,
+		"error_message": message,
+	})
+}
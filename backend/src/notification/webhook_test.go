@@ -0,0 +1,237 @@
+package notification_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/envalert"
+	"github.com/usetania/tania-core/src/notification"
+)
+
+type postedWebhook struct {
+	Header http.Header
+	Body   []byte
+}
+
+func webhookReceiver(t *testing.T) (*httptest.Server, chan postedWebhook) {
+	t.Helper()
+
+	posted := make(chan postedWebhook, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.Nil(t, err)
+
+		posted <- postedWebhook{Header: r.Header, Body: body}
+	}))
+
+	return server, posted
+}
+
+// validateSchema checks the decoded JSON value against the subset of JSON Schema the catalog is written with.
+func validateSchema(schema *notification.Schema, value interface{}, path string) error {
+	types := []string{}
+
+	switch v := schema.Type.(type) {
+	case string:
+		types = append(types, v)
+	case []string:
+		types = append(types, v...)
+	}
+
+	matched := false
+
+	for _, v := range types {
+		if matchesType(v, value) {
+			matched = true
+		}
+	}
+
+	if !matched {
+		return fmt.Errorf("%s: %v isn't of the types %v", path, value, types)
+	}
+
+	switch v := value.(type) {
+	case string:
+		switch schema.Format {
+		case "uuid":
+			if _, err := uuid.FromString(v); err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+		case "date-time":
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+		}
+
+	case map[string]interface{}:
+		for _, required := range schema.Required {
+			if _, ok := v[required]; !ok {
+				return fmt.Errorf("%s.%s is required", path, required)
+			}
+		}
+
+		for key, property := range v {
+			propertySchema, ok := schema.Properties[key]
+			if !ok {
+				return fmt.Errorf("%s.%s isn't in the schema", path, key)
+			}
+
+			if err := validateSchema(propertySchema, property, path+"."+key); err != nil {
+				return err
+			}
+		}
+
+	case []interface{}:
+		for i, item := range v {
+			if err := validateSchema(schema.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func matchesType(schemaType string, value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return schemaType == "null"
+	case string:
+		return schemaType == "string"
+	case bool:
+		return schemaType == "boolean"
+	case float64:
+		return schemaType == "number" || (schemaType == "integer" && v == float64(int64(v)))
+	case map[string]interface{}:
+		return schemaType == "object"
+	case []interface{}:
+		return schemaType == "array"
+	}
+
+	return false
+}
+
+func TestWebhookPayloadsMatchTheCatalog(t *testing.T) {
+	t.Parallel()
+	// Given
+	receiver, posted := webhookReceiver(t)
+	defer receiver.Close()
+
+	taskEventTypes, err := notification.TaskWebhookEventTypes()
+	assert.Nil(t, err)
+
+	alertEventTypes, err := envalert.WebhookEventTypes()
+	assert.Nil(t, err)
+
+	catalog := map[string]notification.WebhookEventType{}
+	for _, v := range append(taskEventTypes, alertEventTypes...) {
+		catalog[v.Type] = v
+	}
+
+	dueDate := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+	taskUID, _ := uuid.NewV4()
+	areaUID, _ := uuid.NewV4()
+	dispatcher := notification.NewWebhookDispatcher(receiver.URL, notification.DefaultNotificationRouting())
+
+	notifications := []notification.Notification{
+		notification.TaskNotification{
+			Event: notification.TaskNotificationCreated, TaskUID: taskUID, Title: "Water",
+			Priority: notification.PriorityUrgent,
+		},
+		notification.TaskNotification{
+			Event: notification.TaskNotificationDue, TaskUID: taskUID, ShortCode: "T-0001", Title: "Water",
+			Priority: notification.PriorityUrgent, Category: "GENERAL", DueDate: &dueDate,
+		},
+		envalert.AreaEnvironmentAlert{
+			AreaUID: areaUID, Kind: envalert.KindAbsolute, Direction: envalert.DirectionRise, Threshold: 30,
+			Temperature: 31.5, Value: 31.5, Priority: notification.PriorityUrgent, RecordedDate: time.Now(),
+		},
+	}
+
+	// When
+	for _, v := range notifications {
+		assert.Nil(t, dispatcher.Dispatch(v))
+	}
+
+	// Then
+	assert.Len(t, catalog, 3)
+
+	for range notifications {
+		post := <-posted
+
+		eventType, ok := catalog[post.Header.Get(notification.HeaderWebhookEvent)]
+		assert.True(t, ok, post.Header.Get(notification.HeaderWebhookEvent))
+
+		payload, example := interface{}(nil), interface{}(nil)
+		assert.Nil(t, json.Unmarshal(post.Body, &payload))
+		assert.Nil(t, json.Unmarshal(eventType.Example, &example))
+
+		assert.Nil(t, validateSchema(eventType.Schema, payload, eventType.Type))
+		assert.Nil(t, validateSchema(eventType.Schema, example, eventType.Type+" example"))
+	}
+}
+
+func TestWebhookReplayIsSignedAgain(t *testing.T) {
+	t.Parallel()
+	// Given
+	receiver, posted := webhookReceiver(t)
+	defer receiver.Close()
+
+	routing := notification.DefaultNotificationRouting()
+	dispatcher := notification.NewWebhookDispatcher(receiver.URL, routing)
+	dispatcher.Secret = "s3cret"
+	dispatcher.Deliveries = notification.NewWebhookDeliveryStoreInMemory(notification.CreateWebhookDeliveryStorage())
+
+	otherDispatcher := notification.NewWebhookDispatcher(receiver.URL, routing)
+	otherDispatcher.ID = "other"
+	otherDispatcher.Deliveries = dispatcher.Deliveries
+
+	taskUID, _ := uuid.NewV4()
+	unknownUID, _ := uuid.NewV4()
+
+	// When
+	err := dispatcher.Dispatch(notification.TaskNotification{
+		Event: notification.TaskNotificationCreated, TaskUID: taskUID, Title: "Water",
+		Priority: notification.PriorityUrgent,
+	})
+	original := <-posted
+
+	deliveries, _ := dispatcher.Deliveries.FindDeliveries(notification.NotificationWebhookID, 0)
+	replay, found, errReplay := dispatcher.Replay(deliveries[0].UID)
+	replayed := <-posted
+
+	_, foundUnknown, _ := dispatcher.Replay(unknownUID)
+	_, foundOther, _ := otherDispatcher.Replay(deliveries[0].UID)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Nil(t, errReplay)
+	assert.True(t, found)
+	assert.False(t, foundUnknown)
+	assert.False(t, foundOther)
+
+	assert.Equal(t, notification.WebhookDeliverySent, replay.Status)
+	assert.Equal(t, &deliveries[0].UID, replay.ReplayOf)
+	assert.Equal(t, deliveries[0].Payload, replay.Payload)
+
+	assert.Empty(t, original.Header.Get(notification.HeaderWebhookReplay))
+	assert.Equal(t, "true", replayed.Header.Get(notification.HeaderWebhookReplay))
+	assert.Equal(t, deliveries[0].UID.String(), replayed.Header.Get(notification.HeaderWebhookReplayOf))
+	assert.Equal(t, replay.UID.String(), replayed.Header.Get(notification.HeaderWebhookDelivery))
+	assert.Equal(t, original.Body, replayed.Body)
+
+	for _, v := range []postedWebhook{original, replayed} {
+		signature := notification.WebhookSignature("s3cret", v.Header.Get(notification.HeaderWebhookTimestamp), v.Body)
+		assert.Equal(t, signature, v.Header.Get(notification.HeaderWebhookSignature))
+	}
+
+	all, _ := dispatcher.Deliveries.FindDeliveries(notification.NotificationWebhookID, 0)
+	assert.Len(t, all, 2)
+}
@@ -0,0 +1,294 @@
+package orphans
+
+import (
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// minOrphanAge keeps the files of the uploads in flight: a photo is written before its event is saved.
+const minOrphanAge = time.Hour
+
+// Folder is an upload folder, scanned with its subfolders.
+type Folder struct {
+	Name string
+	Path string
+}
+
+// File is a photo the cleanup moved, restored or deleted. Its filename is relative to its folder.
+type File struct {
+	Folder          string    `json:"folder"`
+	Filename        string    `json:"filename"`
+	Size            int64     `json:"size"`
+	QuarantinedDate time.Time `json:"quarantined_date"`
+}
+
+// Report is the result of one cleanup run. The reclaimed space is the size of the deleted files,
+// the quarantined ones still take theirs until their grace period ends.
+type Report struct {
+	StartedDate      time.Time `json:"started_date"`
+	GracePeriodDays  int       `json:"grace_period_days"`
+	Scanned          int       `json:"scanned"`
+	Quarantined      []File    `json:"quarantined"`
+	Restored         []File    `json:"restored"`
+	Deleted          []File    `json:"deleted"`
+	QuarantinedBytes int64     `json:"quarantined_bytes"`
+	ReclaimedBytes   int64     `json:"reclaimed_bytes"`
+}
+
+// Cleaner moves the photos no read model references to the quarantine folder, and deletes them
+// when their grace period is over. A quarantined photo referenced again is moved back instead.
+type Cleaner struct {
+	References     References
+	Folders        []Folder
+	QuarantinePath string
+	GraceDays      int
+
+	lock sync.Mutex
+	last *Report
+}
+
+func NewCleaner(references References, folders []Folder, quarantinePath string, graceDays int) (*Cleaner, error) {
+	if quarantinePath == "" {
+		return nil, errors.New("orphan quarantine path is required")
+	}
+
+	if graceDays < 1 {
+		return nil, errors.New("orphan grace period must be at least one day")
+	}
+
+	return &Cleaner{
+		References:     references,
+		Folders:        folders,
+		QuarantinePath: quarantinePath,
+		GraceDays:      graceDays,
+	}, nil
+}
+
+// Start runs the cleanup right away and then every interval.
+// The runs are skipped while paused is true.
+func (c *Cleaner) Start(interval time.Duration, paused func() bool) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		for {
+			if paused() {
+				log.Println("Orphan cleanup paused by the maintenance mode")
+			} else if _, err := c.Run(time.Now()); err != nil {
+				log.Println("Orphan cleanup failed", err)
+			}
+
+			<-ticker.C
+		}
+	}()
+}
+
+// LastReport returns the report of the last run of the process, false before the first one.
+func (c *Cleaner) LastReport() (Report, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.last == nil {
+		return Report{}, false
+	}
+
+	return *c.last, true
+}
+
+// Run quarantines the orphans, restores the quarantined photos referenced again and deletes the ones
+// quarantined for longer than the grace period. The report is logged.
+func (c *Cleaner) Run(now time.Time) (Report, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	referenced, err := c.References.FindReferences()
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{
+		StartedDate:     now,
+		GracePeriodDays: c.GraceDays,
+		Quarantined:     []File{},
+		Restored:        []File{},
+		Deleted:         []File{},
+	}
+
+	for _, folder := range c.Folders {
+		err = c.quarantine(&report, folder, referenced, now)
+		if err != nil {
+			return Report{}, err
+		}
+
+		err = c.sweep(&report, folder, referenced, now)
+		if err != nil {
+			return Report{}, err
+		}
+	}
+
+	c.last = &report
+
+	log.Printf("Orphan cleanup scanned %d photos: %d quarantined (%d bytes), %d restored, %d deleted (%d bytes reclaimed)",
+		report.Scanned, len(report.Quarantined), report.QuarantinedBytes, len(report.Restored),
+		len(report.Deleted), report.ReclaimedBytes)
+
+	return report, nil
+}
+
+// quarantine moves the unreferenced photos of the folder, stamped with the date they were moved.
+func (c *Cleaner) quarantine(report *Report, folder Folder, referenced Referenced, now time.Time) error {
+	files, err := listFiles(folder.Path, c.QuarantinePath)
+	if err != nil {
+		return err
+	}
+
+	for filename, info := range files {
+		report.Scanned++
+
+		if referenced.Has(folder.Name, filename) || now.Sub(info.ModTime()) < minOrphanAge {
+			continue
+		}
+
+		err = move(filepath.Join(folder.Path, filename), c.quarantinePath(folder, filename), now)
+		if err != nil {
+			return err
+		}
+
+		report.Quarantined = append(report.Quarantined, File{
+			Folder:          folder.Name,
+			Filename:        filename,
+			Size:            info.Size(),
+			QuarantinedDate: now,
+		})
+		report.QuarantinedBytes += info.Size()
+	}
+
+	return nil
+}
+
+// sweep restores or deletes the quarantined photos of the folder.
+func (c *Cleaner) sweep(report *Report, folder Folder, referenced Referenced, now time.Time) error {
+	files, err := listFiles(filepath.Join(c.QuarantinePath, folder.Name), "")
+	if err != nil {
+		return err
+	}
+
+	expiry := now.AddDate(0, 0, -c.GraceDays)
+
+	for filename, info := range files {
+		file := File{Folder: folder.Name, Filename: filename, Size: info.Size(), QuarantinedDate: info.ModTime()}
+		src := c.quarantinePath(folder, filename)
+		dest := filepath.Join(folder.Path, filename)
+
+		// A newer upload of the same name has its own file, the quarantined one expires.
+		restorable := referenced.Has(folder.Name, filename)
+		if _, err := os.Stat(dest); err == nil {
+			restorable = false
+		}
+
+		switch {
+		case restorable:
+			err = move(src, dest, info.ModTime())
+			if err != nil {
+				return err
+			}
+
+			report.Restored = append(report.Restored, file)
+
+		case !info.ModTime().After(expiry):
+			err = os.Remove(src)
+			if err != nil {
+				return err
+			}
+
+			report.Deleted = append(report.Deleted, file)
+			report.ReclaimedBytes += info.Size()
+		}
+	}
+
+	return nil
+}
+
+func (c *Cleaner) quarantinePath(folder Folder, filename string) string {
+	return filepath.Join(c.QuarantinePath, folder.Name, filepath.FromSlash(filename))
+}
+
+// listFiles returns the regular files under the root by their slash separated relative path.
+// The hidden files, like the .gitkeep ones, and the excluded folder are left out.
+func listFiles(root, excluded string) (map[string]fs.FileInfo, error) {
+	files := map[string]fs.FileInfo{}
+
+	excludedPath := ""
+
+	if excluded != "" {
+		var err error
+
+		excludedPath, err = filepath.Abs(excluded)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path == root {
+			return filepath.SkipDir
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			abs, err := filepath.Abs(path)
+			if err != nil {
+				return err
+			}
+
+			if abs == excludedPath {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if strings.HasPrefix(d.Name(), ".") || !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		files[filepath.ToSlash(rel)] = info
+
+		return nil
+	})
+
+	return files, err
+}
+
+// move renames the file, creating the folders of the destination, and stamps it with the date.
+func move(src, dest string, date time.Time) error {
+	err := os.MkdirAll(filepath.Dir(dest), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(src, dest)
+	if err != nil {
+		return err
+	}
+
+	return os.Chtimes(dest, date, date)
+}
@@ -0,0 +1,97 @@
+package orphans_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/orphans"
+)
+
+type staticReferences orphans.Referenced
+
+func (r staticReferences) FindReferences() (orphans.Referenced, error) {
+	return orphans.Referenced(r), nil
+}
+
+func writeFile(t *testing.T, path string, size int, date time.Time) {
+	t.Helper()
+
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	assert.Nil(t, os.WriteFile(path, make([]byte, size), 0o600))
+	assert.Nil(t, os.Chtimes(path, date, date))
+}
+
+func TestCleanerRun(t *testing.T) {
+	t.Parallel()
+	// Given
+	now := time.Date(2026, time.October, 14, 12, 0, 0, 0, time.UTC)
+	root := t.TempDir()
+	crops := filepath.Join(root, "crops")
+	quarantine := filepath.Join(root, "quarantine")
+
+	writeFile(t, filepath.Join(crops, ".gitkeep"), 0, now.AddDate(-1, 0, 0))
+	writeFile(t, filepath.Join(crops, "kept.jpg"), 10, now.AddDate(0, 0, -30))
+	writeFile(t, filepath.Join(crops, "thumbnails", "kept.jpg"), 5, now.AddDate(0, 0, -30))
+	writeFile(t, filepath.Join(crops, "orphan.jpg"), 20, now.AddDate(0, 0, -30))
+	writeFile(t, filepath.Join(crops, "thumbnails", "orphan.jpg"), 4, now.AddDate(0, 0, -30))
+	writeFile(t, filepath.Join(crops, "uploading.jpg"), 30, now.Add(-time.Minute))
+	writeFile(t, filepath.Join(quarantine, "crops", "expired.jpg"), 40, now.AddDate(0, 0, -8))
+	writeFile(t, filepath.Join(quarantine, "crops", "waiting.jpg"), 50, now.AddDate(0, 0, -6))
+	writeFile(t, filepath.Join(quarantine, "crops", "imported.jpg"), 60, now.AddDate(0, 0, -6))
+
+	references := staticReferences{orphans.FolderCrops: {
+		"kept.jpg": true, "thumbnails/kept.jpg": true, "imported.jpg": true,
+	}}
+
+	cleaner, err := orphans.NewCleaner(references, []orphans.Folder{{Name: orphans.FolderCrops, Path: crops}},
+		quarantine, 7)
+	assert.Nil(t, err)
+
+	_, found := cleaner.LastReport()
+
+	// When
+	report, err := cleaner.Run(now)
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, found)
+
+	assert.Equal(t, 5, report.Scanned)
+	assert.ElementsMatch(t, []string{"orphan.jpg", "thumbnails/orphan.jpg"}, filenames(report.Quarantined))
+	assert.Equal(t, int64(24), report.QuarantinedBytes)
+	assert.Equal(t, []string{"imported.jpg"}, filenames(report.Restored))
+	assert.Equal(t, []string{"expired.jpg"}, filenames(report.Deleted))
+	assert.Equal(t, int64(40), report.ReclaimedBytes)
+
+	for _, v := range []string{"kept.jpg", "thumbnails/kept.jpg", "uploading.jpg", "imported.jpg", ".gitkeep"} {
+		assert.FileExists(t, filepath.Join(crops, v))
+	}
+
+	assert.NoFileExists(t, filepath.Join(crops, "orphan.jpg"))
+	assert.FileExists(t, filepath.Join(quarantine, "crops", "thumbnails", "orphan.jpg"))
+	assert.FileExists(t, filepath.Join(quarantine, "crops", "waiting.jpg"))
+	assert.NoFileExists(t, filepath.Join(quarantine, "crops", "expired.jpg"))
+
+	last, found := cleaner.LastReport()
+	assert.True(t, found)
+	assert.Equal(t, report, last)
+
+	// The upload never saved its photo, and the quarantined photos wait for their own grace period.
+	report, err = cleaner.Run(now.AddDate(0, 0, 1))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"uploading.jpg"}, filenames(report.Quarantined))
+	assert.Equal(t, []string{"waiting.jpg"}, filenames(report.Deleted))
+}
+
+func filenames(files []orphans.File) []string {
+	names := []string{}
+
+	for _, v := range files {
+		names = append(names, v.Filename)
+	}
+
+	return names
+}
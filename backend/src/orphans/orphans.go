@@ -0,0 +1,169 @@
+// Package orphans finds the uploaded photos no read model references anymore, and removes them
+// after they spent a grace period in quarantine.
+package orphans
+
+import (
+	"errors"
+
+	"github.com/gofrs/uuid"
+	assetsquery "github.com/usetania/tania-core/src/assets/query"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	growthquery "github.com/usetania/tania-core/src/growth/query"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+)
+
+// The names of the upload folders, the quarantine keeps their files apart by them.
+const (
+	FolderAreas = "areas"
+	FolderCrops = "crops"
+)
+
+// Referenced are the filenames the read models reference, by folder. The filenames are relative to
+// their folder with slashes, like the thumbnails/ ones of the crops.
+type Referenced map[string]map[string]bool
+
+func (r Referenced) Has(folder, filename string) bool {
+	return r[folder][filename]
+}
+
+func (r Referenced) add(folder, filename string) {
+	if filename == "" {
+		return
+	}
+
+	if _, ok := r[folder]; !ok {
+		r[folder] = map[string]bool{}
+	}
+
+	r[folder][filename] = true
+}
+
+type References interface {
+	FindReferences() (Referenced, error)
+}
+
+// Unreferenced returns the filenames of the folder no read model references, the ones a removal left.
+// Two photos uploaded with the same name share their file, so it stays until the last one is removed.
+func Unreferenced(references References, folder string, filenames []string) ([]string, error) {
+	referenced, err := references.FindReferences()
+	if err != nil {
+		return nil, err
+	}
+
+	unreferenced := []string{}
+
+	for _, v := range filenames {
+		if v != "" && !referenced.Has(folder, v) {
+			unreferenced = append(unreferenced, v)
+		}
+	}
+
+	return unreferenced, nil
+}
+
+// ReadModelReferences finds the photos of the areas and the crops of every farm, the archived crops included.
+type ReadModelReferences struct {
+	FarmReadQuery assetsquery.FarmRead
+	AreaReadQuery assetsquery.AreaRead
+	CropReadQuery growthquery.CropReadQuery
+}
+
+func (r ReadModelReferences) FindReferences() (Referenced, error) {
+	referenced := Referenced{}
+
+	result := <-r.FarmReadQuery.FindAll()
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	farms, ok := result.Result.([]assetsstorage.FarmRead)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	for _, farm := range farms {
+		result = <-r.AreaReadQuery.FindAllByFarm(farm.UID)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		areas, ok := result.Result.([]assetsstorage.AreaRead)
+		if !ok {
+			return nil, errors.New("internal server error. error type assertion")
+		}
+
+		for _, v := range areas {
+			referenced.add(FolderAreas, v.Photo.Filename)
+		}
+
+		crops, err := r.findAllCrops(farm.UID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range crops {
+			for _, p := range v.Photos {
+				referenced.add(FolderCrops, p.Filename)
+				referenced.add(FolderCrops, p.ThumbnailFilename)
+			}
+		}
+	}
+
+	return referenced, nil
+}
+
+// findAllCrops returns the current and the archived crops of the farm. Depending on the engine
+// the archived ones are listed with the others or only with the archives, so both lists are read.
+func (r ReadModelReferences) findAllCrops(farmUID uuid.UUID) ([]growthstorage.CropRead, error) {
+	crops := []growthstorage.CropRead{}
+
+	result := <-r.CropReadQuery.CountAllCropsByFarm(farmUID, "")
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	total, ok := result.Result.(int)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	if total > 0 {
+		result = <-r.CropReadQuery.FindAllCropsByFarm(farmUID, "", 1, total)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		current, ok := result.Result.([]growthstorage.CropRead)
+		if !ok {
+			return nil, errors.New("internal server error. error type assertion")
+		}
+
+		crops = append(crops, current...)
+	}
+
+	result = <-r.CropReadQuery.CountAllArchivedCropsByFarm(farmUID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	total, ok = result.Result.(int)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	if total > 0 {
+		result = <-r.CropReadQuery.FindAllCropsArchives(farmUID, 1, total)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		archived, ok := result.Result.([]growthstorage.CropRead)
+		if !ok {
+			return nil, errors.New("internal server error. error type assertion")
+		}
+
+		crops = append(crops, archived...)
+	}
+
+	return crops, nil
+}
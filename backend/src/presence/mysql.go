@@ -0,0 +1,65 @@
+package presence
+
+import (
+	"database/sql"
+
+	"github.com/gofrs/uuid"
+)
+
+type StoreMysql struct {
+	DB *sql.DB
+}
+
+func NewStoreMysql(db *sql.DB) Store {
+	return &StoreMysql{DB: db}
+}
+
+func (s *StoreMysql) Save(entry Entry) error {
+	_, err := s.DB.Exec(`REPLACE INTO PRESENCE (FARM_UID, KIND, IDENTITY, NAME, FIRST_SEEN, LAST_SEEN)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		entry.FarmUID.Bytes(),
+		entry.Kind,
+		entry.ID,
+		entry.Name,
+		entry.FirstSeen,
+		entry.LastSeen)
+
+	return err
+}
+
+func (s *StoreMysql) Remove(farmUID uuid.UUID, kind, id string) error {
+	_, err := s.DB.Exec(`DELETE FROM PRESENCE WHERE FARM_UID = ? AND KIND = ? AND IDENTITY = ?`,
+		farmUID.Bytes(), kind, id)
+
+	return err
+}
+
+func (s *StoreMysql) FindAll() ([]Entry, error) {
+	rows, err := s.DB.Query(`SELECT FARM_UID, KIND, IDENTITY, NAME, FIRST_SEEN, LAST_SEEN FROM PRESENCE`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []Entry{}
+
+	for rows.Next() {
+		var farmUID []byte
+
+		entry := Entry{}
+
+		err = rows.Scan(&farmUID, &entry.Kind, &entry.ID, &entry.Name, &entry.FirstSeen, &entry.LastSeen)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.FarmUID, err = uuid.FromBytes(farmUID)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,203 @@
+// Package presence keeps which users and gateways were active on the farms lately, from the heartbeats they post.
+// An identity is online until the TTL passes without a heartbeat. The users are then forgotten, the gateways are
+// kept so a gateway which went silent can be warned about, until it's removed.
+package presence
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	KindUser    = "user"
+	KindGateway = "gateway"
+)
+
+// Entry is the last heartbeat of an identity on a farm. The ID of a user is its UID, the one of a gateway is the
+// device ID it reports with.
+type Entry struct {
+	FarmUID   uuid.UUID `json:"farm_id"`
+	Kind      string    `json:"kind"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+type entryKey struct {
+	Kind string
+	ID   string
+}
+
+// Store keeps the entries of the registry across the restarts.
+type Store interface {
+	// Save adds the entry or replaces the one of its identity.
+	Save(entry Entry) error
+	Remove(farmUID uuid.UUID, kind, id string) error
+	FindAll() ([]Entry, error)
+}
+
+// Registry is the presence of the identities by farm, in memory. With a store, the entries are saved there too,
+// at most every half TTL for an identity so the heartbeats don't write to the database each time.
+type Registry struct {
+	TTL   time.Duration
+	Store Store
+
+	lock      sync.RWMutex
+	entries   map[uuid.UUID]map[entryKey]Entry
+	savedDate map[uuid.UUID]map[entryKey]time.Time
+}
+
+// NewRegistry creates the registry with the entries of the store, the store is optional.
+func NewRegistry(ttl time.Duration, store Store) (*Registry, error) {
+	r := &Registry{
+		TTL:       ttl,
+		Store:     store,
+		entries:   map[uuid.UUID]map[entryKey]Entry{},
+		savedDate: map[uuid.UUID]map[entryKey]time.Time{},
+	}
+
+	if store == nil {
+		return r, nil
+	}
+
+	entries, err := store.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range entries {
+		r.set(v, v.LastSeen)
+	}
+
+	return r, nil
+}
+
+// Heartbeat records the identity of the entry as seen at its LastSeen, and returns the entry with the date it was
+// first seen since it went offline.
+func (r *Registry) Heartbeat(entry Entry) (Entry, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.expire(entry.FarmUID, entry.LastSeen)
+
+	key := entryKey{Kind: entry.Kind, ID: entry.ID}
+	entry.FirstSeen = entry.LastSeen
+
+	if previous, found := r.entries[entry.FarmUID][key]; found && entry.LastSeen.Sub(previous.LastSeen) <= r.TTL {
+		entry.FirstSeen = previous.FirstSeen
+	}
+
+	savedDate, saved := r.savedDate[entry.FarmUID][key]
+	if r.Store != nil && (!saved || entry.LastSeen.Sub(savedDate) >= r.TTL/2 || entry.FirstSeen == entry.LastSeen) {
+		err := r.Store.Save(entry)
+		if err != nil {
+			return Entry{}, err
+		}
+
+		savedDate = entry.LastSeen
+	}
+
+	r.set(entry, savedDate)
+
+	return entry, nil
+}
+
+// Active lists the identities of the farm online at the date, the last seen first.
+func (r *Registry) Active(farmUID uuid.UUID, now time.Time) []Entry {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	active := []Entry{}
+
+	for _, v := range r.entries[farmUID] {
+		if now.Sub(v.LastSeen) <= r.TTL {
+			active = append(active, v)
+		}
+	}
+
+	sortByLastSeen(active)
+
+	return active
+}
+
+// StaleGateways lists the gateways of the farm silent for more than the threshold at the date, the longest
+// silent last.
+func (r *Registry) StaleGateways(farmUID uuid.UUID, now time.Time, threshold time.Duration) []Entry {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	stale := []Entry{}
+
+	for _, v := range r.entries[farmUID] {
+		if v.Kind == KindGateway && now.Sub(v.LastSeen) > threshold {
+			stale = append(stale, v)
+		}
+	}
+
+	sortByLastSeen(stale)
+
+	return stale
+}
+
+// Remove forgets the identity, like a gateway which was taken down, and returns its last entry.
+// It returns false when the identity isn't known.
+func (r *Registry) Remove(farmUID uuid.UUID, kind, id string) (Entry, bool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := entryKey{Kind: kind, ID: id}
+
+	entry, found := r.entries[farmUID][key]
+	if !found {
+		return Entry{}, false, nil
+	}
+
+	if r.Store != nil {
+		err := r.Store.Remove(farmUID, kind, id)
+		if err != nil {
+			return Entry{}, false, err
+		}
+	}
+
+	delete(r.entries[farmUID], key)
+	delete(r.savedDate[farmUID], key)
+
+	return entry, true, nil
+}
+
+func (r *Registry) set(entry Entry, savedDate time.Time) {
+	key := entryKey{Kind: entry.Kind, ID: entry.ID}
+
+	if _, ok := r.entries[entry.FarmUID]; !ok {
+		r.entries[entry.FarmUID] = map[entryKey]Entry{}
+		r.savedDate[entry.FarmUID] = map[entryKey]time.Time{}
+	}
+
+	r.entries[entry.FarmUID][key] = entry
+	r.savedDate[entry.FarmUID][key] = savedDate
+}
+
+// expire forgets the users of the farm offline at the date. They stay in the store until their next heartbeat
+// replaces them, and are dropped again when the registry is loaded.
+func (r *Registry) expire(farmUID uuid.UUID, now time.Time) {
+	for key, v := range r.entries[farmUID] {
+		if v.Kind == KindUser && now.Sub(v.LastSeen) > r.TTL {
+			delete(r.entries[farmUID], key)
+			delete(r.savedDate[farmUID], key)
+		}
+	}
+}
+
+func sortByLastSeen(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].LastSeen.Equal(entries[j].LastSeen) {
+			return entries[i].ID < entries[j].ID
+		}
+
+		return entries[i].LastSeen.After(entries[j].LastSeen)
+	})
+}
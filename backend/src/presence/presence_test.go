@@ -0,0 +1,51 @@
+package presence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/presence"
+)
+
+func TestPresenceExpiresAfterTheTTL(t *testing.T) {
+	t.Parallel()
+	// Given
+	registry, _ := NewRegistry(90*time.Second, nil)
+	farmUID, _ := uuid.NewV4()
+	otherFarmUID, _ := uuid.NewV4()
+	date := time.Date(2026, time.October, 14, 8, 0, 0, 0, time.UTC)
+
+	// When
+	first, _ := registry.Heartbeat(Entry{FarmUID: farmUID, Kind: KindUser, ID: "ana", Name: "Ana", LastSeen: date})
+	_, _ = registry.Heartbeat(Entry{FarmUID: farmUID, Kind: KindGateway, ID: "gw-1", LastSeen: date.Add(30 * time.Second)})
+	second, _ := registry.Heartbeat(Entry{
+		FarmUID: farmUID, Kind: KindUser, ID: "ana", Name: "Ana", LastSeen: date.Add(60 * time.Second),
+	})
+
+	// Then
+	assert.Equal(t, date, second.FirstSeen)
+	assert.Equal(t, first.FirstSeen, second.FirstSeen)
+
+	active := registry.Active(farmUID, date.Add(100*time.Second))
+	assert.Len(t, active, 2)
+	assert.Equal(t, "ana", active[0].ID)
+	assert.Equal(t, "gw-1", active[1].ID)
+	assert.Empty(t, registry.Active(otherFarmUID, date.Add(100*time.Second)))
+
+	assert.Empty(t, registry.Active(farmUID, date.Add(10*time.Minute)))
+	assert.Empty(t, registry.StaleGateways(farmUID, date.Add(10*time.Minute), 15*time.Minute))
+
+	stale := registry.StaleGateways(farmUID, date.Add(20*time.Minute), 15*time.Minute)
+	assert.Len(t, stale, 1)
+	assert.Equal(t, "gw-1", stale[0].ID)
+
+	removed, found, _ := registry.Remove(farmUID, KindGateway, "gw-1")
+	_, foundAgain, _ := registry.Remove(farmUID, KindGateway, "gw-1")
+
+	assert.True(t, found)
+	assert.Equal(t, "gw-1", removed.ID)
+	assert.False(t, foundAgain)
+	assert.Empty(t, registry.StaleGateways(farmUID, date.Add(20*time.Minute), 15*time.Minute))
+}
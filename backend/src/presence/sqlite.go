@@ -0,0 +1,76 @@
+package presence
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type StoreSqlite struct {
+	DB *sql.DB
+}
+
+func NewStoreSqlite(db *sql.DB) Store {
+	return &StoreSqlite{DB: db}
+}
+
+func (s *StoreSqlite) Save(entry Entry) error {
+	_, err := s.DB.Exec(`INSERT OR REPLACE INTO PRESENCE (FARM_UID, KIND, IDENTITY, NAME, FIRST_SEEN, LAST_SEEN)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		entry.FarmUID.String(),
+		entry.Kind,
+		entry.ID,
+		entry.Name,
+		entry.FirstSeen.UTC().Format(time.RFC3339),
+		entry.LastSeen.UTC().Format(time.RFC3339))
+
+	return err
+}
+
+func (s *StoreSqlite) Remove(farmUID uuid.UUID, kind, id string) error {
+	_, err := s.DB.Exec(`DELETE FROM PRESENCE WHERE FARM_UID = ? AND KIND = ? AND IDENTITY = ?`,
+		farmUID.String(), kind, id)
+
+	return err
+}
+
+func (s *StoreSqlite) FindAll() ([]Entry, error) {
+	rows, err := s.DB.Query(`SELECT FARM_UID, KIND, IDENTITY, NAME, FIRST_SEEN, LAST_SEEN FROM PRESENCE`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []Entry{}
+
+	for rows.Next() {
+		var farmUID, firstSeen, lastSeen string
+
+		entry := Entry{}
+
+		err = rows.Scan(&farmUID, &entry.Kind, &entry.ID, &entry.Name, &firstSeen, &lastSeen)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.FarmUID, err = uuid.FromString(farmUID)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.FirstSeen, err = time.Parse(time.RFC3339, firstSeen)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.LastSeen, err = time.Parse(time.RFC3339, lastSeen)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,146 @@
+package reportmail
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/sasha-s/go-deadlock"
+)
+
+type ReportMailStorage struct {
+	Lock          *deadlock.RWMutex
+	Subscriptions map[uuid.UUID]Subscription
+	Deliveries    map[uuid.UUID]Delivery
+}
+
+func CreateReportMailStorage() *ReportMailStorage {
+	return &ReportMailStorage{
+		Lock:          &deadlock.RWMutex{},
+		Subscriptions: make(map[uuid.UUID]Subscription),
+		Deliveries:    make(map[uuid.UUID]Delivery),
+	}
+}
+
+type StoreInMemory struct {
+	Storage *ReportMailStorage
+}
+
+func NewStoreInMemory(s *ReportMailStorage) Store {
+	return &StoreInMemory{Storage: s}
+}
+
+func (s *StoreInMemory) SaveSubscription(subscription Subscription) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	s.Storage.Subscriptions[subscription.UID] = subscription
+
+	return nil
+}
+
+func (s *StoreInMemory) FindSubscription(uid uuid.UUID) (*Subscription, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	subscription, ok := s.Storage.Subscriptions[uid]
+	if !ok {
+		return nil, nil
+	}
+
+	return &subscription, nil
+}
+
+func (s *StoreInMemory) FindSubscriptionsByFarm(farmUID uuid.UUID) ([]Subscription, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	subscriptions := []Subscription{}
+
+	for _, v := range s.Storage.Subscriptions {
+		if v.FarmUID == farmUID {
+			subscriptions = append(subscriptions, v)
+		}
+	}
+
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedDate.Before(subscriptions[j].CreatedDate)
+	})
+
+	return subscriptions, nil
+}
+
+func (s *StoreInMemory) FindDueSubscriptions(now time.Time) ([]Subscription, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	subscriptions := []Subscription{}
+
+	for _, v := range s.Storage.Subscriptions {
+		if !v.NextRunDate.After(now) {
+			subscriptions = append(subscriptions, v)
+		}
+	}
+
+	return subscriptions, nil
+}
+
+func (s *StoreInMemory) DeleteSubscription(uid uuid.UUID) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	delete(s.Storage.Subscriptions, uid)
+
+	return nil
+}
+
+func (s *StoreInMemory) SaveDelivery(delivery Delivery) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	s.Storage.Deliveries[delivery.UID] = delivery
+
+	return nil
+}
+
+func (s *StoreInMemory) FindDeliveries(subscriptionUID uuid.UUID, limit int) ([]Delivery, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	deliveries := []Delivery{}
+
+	for _, v := range s.Storage.Deliveries {
+		if v.SubscriptionUID == subscriptionUID {
+			deliveries = append(deliveries, v)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].ScheduledDate.After(deliveries[j].ScheduledDate)
+	})
+
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, nil
+}
+
+func (s *StoreInMemory) FindPendingDeliveries(now time.Time) ([]Delivery, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	deliveries := []Delivery{}
+
+	for _, v := range s.Storage.Deliveries {
+		if v.Status == DeliveryPending && v.NextAttemptDate != nil && !v.NextAttemptDate.After(now) {
+			deliveries = append(deliveries, v)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].ScheduledDate.Before(deliveries[j].ScheduledDate)
+	})
+
+	return deliveries, nil
+}
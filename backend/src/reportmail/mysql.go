@@ -0,0 +1,202 @@
+package reportmail
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type StoreMysql struct {
+	DB *sql.DB
+}
+
+func NewStoreMysql(db *sql.DB) Store {
+	return &StoreMysql{DB: db}
+}
+
+func (s *StoreMysql) SaveSubscription(subscription Subscription) error {
+	recipients, err := json.Marshal(subscription.Recipients)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.DB.Exec(`REPLACE INTO REPORT_SUBSCRIPTION (`+subscriptionColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		subscription.UID.Bytes(),
+		subscription.FarmUID.Bytes(),
+		subscription.ReportType,
+		subscription.Format,
+		subscription.DayOfWeek,
+		subscription.TimeOfDay,
+		subscription.Timezone,
+		string(recipients),
+		subscription.CreatedDate,
+		subscription.NextRunDate)
+
+	return err
+}
+
+func (s *StoreMysql) FindSubscription(uid uuid.UUID) (*Subscription, error) {
+	subscriptions, err := s.findAllSubscriptions(`SELECT `+subscriptionColumns+`
+		FROM REPORT_SUBSCRIPTION WHERE UID = ?`, uid.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil, nil
+	}
+
+	return &subscriptions[0], nil
+}
+
+func (s *StoreMysql) FindSubscriptionsByFarm(farmUID uuid.UUID) ([]Subscription, error) {
+	return s.findAllSubscriptions(`SELECT `+subscriptionColumns+`
+		FROM REPORT_SUBSCRIPTION WHERE FARM_UID = ? ORDER BY CREATED_DATE ASC`, farmUID.Bytes())
+}
+
+func (s *StoreMysql) FindDueSubscriptions(now time.Time) ([]Subscription, error) {
+	return s.findAllSubscriptions(`SELECT `+subscriptionColumns+`
+		FROM REPORT_SUBSCRIPTION WHERE NEXT_RUN_DATE <= ?`, now)
+}
+
+func (s *StoreMysql) DeleteSubscription(uid uuid.UUID) error {
+	_, err := s.DB.Exec(`DELETE FROM REPORT_SUBSCRIPTION WHERE UID = ?`, uid.Bytes())
+
+	return err
+}
+
+func (s *StoreMysql) SaveDelivery(delivery Delivery) error {
+	_, err := s.DB.Exec(`REPLACE INTO REPORT_DELIVERY (`+deliveryColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		delivery.UID.Bytes(),
+		delivery.SubscriptionUID.Bytes(),
+		delivery.FarmUID.Bytes(),
+		delivery.ScheduledDate,
+		delivery.Manual,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastError,
+		delivery.NextAttemptDate,
+		delivery.SentDate)
+
+	return err
+}
+
+func (s *StoreMysql) FindDeliveries(subscriptionUID uuid.UUID, limit int) ([]Delivery, error) {
+	if limit <= 0 {
+		return s.findAllDeliveries(`SELECT `+deliveryColumns+`
+			FROM REPORT_DELIVERY WHERE SUBSCRIPTION_UID = ? ORDER BY SCHEDULED_DATE DESC`, subscriptionUID.Bytes())
+	}
+
+	return s.findAllDeliveries(`SELECT `+deliveryColumns+`
+		FROM REPORT_DELIVERY WHERE SUBSCRIPTION_UID = ? ORDER BY SCHEDULED_DATE DESC LIMIT ?`,
+		subscriptionUID.Bytes(), limit)
+}
+
+func (s *StoreMysql) FindPendingDeliveries(now time.Time) ([]Delivery, error) {
+	return s.findAllDeliveries(`SELECT `+deliveryColumns+`
+		FROM REPORT_DELIVERY WHERE STATUS = ? AND NEXT_ATTEMPT_DATE <= ?
+		ORDER BY SCHEDULED_DATE ASC`, DeliveryPending, now)
+}
+
+func (s *StoreMysql) findAllSubscriptions(query string, args ...interface{}) ([]Subscription, error) {
+	rows, err := s.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := []Subscription{}
+
+	for rows.Next() {
+		var (
+			uid, farmUID []byte
+			recipients   string
+		)
+
+		v := Subscription{}
+
+		err = rows.Scan(&uid, &farmUID, &v.ReportType, &v.Format, &v.DayOfWeek, &v.TimeOfDay, &v.Timezone,
+			&recipients, &v.CreatedDate, &v.NextRunDate)
+		if err != nil {
+			return nil, err
+		}
+
+		v.UID, err = uuid.FromBytes(uid)
+		if err != nil {
+			return nil, err
+		}
+
+		v.FarmUID, err = uuid.FromBytes(farmUID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(recipients), &v.Recipients)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, v)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+func (s *StoreMysql) findAllDeliveries(query string, args ...interface{}) ([]Delivery, error) {
+	rows, err := s.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []Delivery{}
+
+	for rows.Next() {
+		var (
+			uid, subscriptionUID, farmUID []byte
+			nextAttemptDate, sentDate     sql.NullTime
+		)
+
+		v := Delivery{}
+
+		err = rows.Scan(&uid, &subscriptionUID, &farmUID, &v.ScheduledDate, &v.Manual, &v.Status, &v.Attempts,
+			&v.LastError, &nextAttemptDate, &sentDate)
+		if err != nil {
+			return nil, err
+		}
+
+		v.UID, err = uuid.FromBytes(uid)
+		if err != nil {
+			return nil, err
+		}
+
+		v.SubscriptionUID, err = uuid.FromBytes(subscriptionUID)
+		if err != nil {
+			return nil, err
+		}
+
+		v.FarmUID, err = uuid.FromBytes(farmUID)
+		if err != nil {
+			return nil, err
+		}
+
+		v.NextAttemptDate = nullableDateMysql(nextAttemptDate)
+		v.SentDate = nullableDateMysql(sentDate)
+
+		deliveries = append(deliveries, v)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func nullableDateMysql(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+
+	return &value.Time
+}
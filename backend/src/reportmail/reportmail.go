@@ -0,0 +1,274 @@
+// Package reportmail sends the farm reports to their subscribers on a weekly schedule.
+package reportmail
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	ReportDashboard  = "DASHBOARD"
+	ReportTasks      = "TASKS"
+	ReportCostCentre = "COST_CENTRE"
+
+	FormatHTML = "html"
+	FormatPDF  = "pdf"
+
+	DeliveryPending   = "PENDING"
+	DeliverySent      = "SENT"
+	DeliveryFailed    = "FAILED"
+	DeliveryCancelled = "CANCELLED"
+)
+
+const (
+	// MaxAttempts is the number of sends before a delivery is given up as failed.
+	MaxAttempts = 5
+	retryDelay  = 5 * time.Minute
+)
+
+var weekdays = map[string]time.Weekday{ //nolint:gochecknoglobals
+	"SUNDAY":    time.Sunday,
+	"MONDAY":    time.Monday,
+	"TUESDAY":   time.Tuesday,
+	"WEDNESDAY": time.Wednesday,
+	"THURSDAY":  time.Thursday,
+	"FRIDAY":    time.Friday,
+	"SATURDAY":  time.Saturday,
+}
+
+// ValidationError is an invalid field of a new subscription.
+type ValidationError struct {
+	Field string
+}
+
+func (e ValidationError) Error() string {
+	return "invalid report subscription " + e.Field
+}
+
+// Subscription sends the report of the farm every week on the day and time of its timezone.
+type Subscription struct {
+	UID         uuid.UUID `json:"uid"`
+	FarmUID     uuid.UUID `json:"farm_id"`
+	ReportType  string    `json:"report_type"`
+	Format      string    `json:"format"`
+	DayOfWeek   string    `json:"day_of_week"`
+	TimeOfDay   string    `json:"time_of_day"`
+	Timezone    string    `json:"timezone"`
+	Recipients  []string  `json:"recipients"`
+	CreatedDate time.Time `json:"created_date"`
+	NextRunDate time.Time `json:"next_run_date"`
+}
+
+// Delivery is one send of the report of a subscription, scheduled or asked with run now.
+type Delivery struct {
+	UID             uuid.UUID  `json:"uid"`
+	SubscriptionUID uuid.UUID  `json:"subscription_id"`
+	FarmUID         uuid.UUID  `json:"farm_id"`
+	ScheduledDate   time.Time  `json:"scheduled_date"`
+	Manual          bool       `json:"manual"`
+	Status          string     `json:"status"`
+	Attempts        int        `json:"attempts"`
+	LastError       string     `json:"last_error"`
+	NextAttemptDate *time.Time `json:"next_attempt_date"`
+	SentDate        *time.Time `json:"sent_date"`
+}
+
+type Store interface {
+	// SaveSubscription inserts the subscription, or replaces it when it already exists.
+	SaveSubscription(subscription Subscription) error
+
+	// FindSubscription returns the subscription, or nil when it doesn't exist.
+	FindSubscription(uid uuid.UUID) (*Subscription, error)
+	FindSubscriptionsByFarm(farmUID uuid.UUID) ([]Subscription, error)
+
+	// FindDueSubscriptions returns the subscriptions whose next run is at now or before.
+	FindDueSubscriptions(now time.Time) ([]Subscription, error)
+	DeleteSubscription(uid uuid.UUID) error
+
+	// SaveDelivery inserts the delivery, or replaces it when it already exists.
+	SaveDelivery(delivery Delivery) error
+
+	// FindDeliveries returns the deliveries of the subscription, the latest first.
+	FindDeliveries(subscriptionUID uuid.UUID, limit int) ([]Delivery, error)
+
+	// FindPendingDeliveries returns the pending deliveries whose next attempt is at now or before.
+	FindPendingDeliveries(now time.Time) ([]Delivery, error)
+}
+
+// NewSubscription validates the schedule and the recipients. The timezone is UTC when it's empty.
+func NewSubscription(
+	farmUID uuid.UUID,
+	reportType, format, dayOfWeek, timeOfDay, timezone string,
+	recipients []string,
+	now time.Time,
+) (Subscription, error) {
+	switch reportType {
+	case ReportDashboard, ReportTasks, ReportCostCentre:
+	default:
+		return Subscription{}, ValidationError{Field: "report_type"}
+	}
+
+	if format == "" {
+		format = FormatHTML
+	}
+
+	if format != FormatHTML && format != FormatPDF {
+		return Subscription{}, ValidationError{Field: "format"}
+	}
+
+	dayOfWeek = strings.ToUpper(dayOfWeek)
+	if _, ok := weekdays[dayOfWeek]; !ok {
+		return Subscription{}, ValidationError{Field: "day_of_week"}
+	}
+
+	if _, err := time.Parse("15:04", timeOfDay); err != nil {
+		return Subscription{}, ValidationError{Field: "time_of_day"}
+	}
+
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return Subscription{}, ValidationError{Field: "timezone"}
+	}
+
+	addresses := []string{}
+
+	for _, v := range recipients {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		address, err := mail.ParseAddress(v)
+		if err != nil {
+			return Subscription{}, ValidationError{Field: "recipients"}
+		}
+
+		addresses = append(addresses, address.Address)
+	}
+
+	if len(addresses) == 0 {
+		return Subscription{}, ValidationError{Field: "recipients"}
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	subscription := Subscription{
+		UID:         uid,
+		FarmUID:     farmUID,
+		ReportType:  reportType,
+		Format:      format,
+		DayOfWeek:   dayOfWeek,
+		TimeOfDay:   timeOfDay,
+		Timezone:    timezone,
+		Recipients:  addresses,
+		CreatedDate: now,
+	}
+
+	subscription.NextRunDate, err = subscription.NextRun(now)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	return subscription, nil
+}
+
+// Location is the timezone the schedule is expressed in.
+func (s Subscription) Location() (*time.Location, error) {
+	return time.LoadLocation(s.Timezone)
+}
+
+// NextRun returns the first scheduled time strictly after the date.
+func (s Subscription) NextRun(after time.Time) (time.Time, error) {
+	loc, err := s.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	weekday, ok := weekdays[s.DayOfWeek]
+	if !ok {
+		return time.Time{}, errors.New("invalid day of week " + s.DayOfWeek)
+	}
+
+	clock, err := time.Parse("15:04", s.TimeOfDay)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	local := after.In(loc)
+	days := (int(weekday) - int(local.Weekday()) + 7) % 7
+
+	next := time.Date(local.Year(), local.Month(), local.Day()+days, clock.Hour(), clock.Minute(), 0, 0, loc)
+	if !next.After(after) {
+		next = time.Date(local.Year(), local.Month(), local.Day()+days+7, clock.Hour(), clock.Minute(), 0, 0, loc)
+	}
+
+	return next.UTC(), nil
+}
+
+// NewDelivery creates the pending delivery of the subscription, to be sent at the scheduled date.
+func NewDelivery(subscription Subscription, scheduled time.Time, manual bool) (Delivery, error) {
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	return Delivery{
+		UID:             uid,
+		SubscriptionUID: subscription.UID,
+		FarmUID:         subscription.FarmUID,
+		ScheduledDate:   scheduled,
+		Manual:          manual,
+		Status:          DeliveryPending,
+		NextAttemptDate: &scheduled,
+	}, nil
+}
+
+// Sent marks the delivery as sent.
+func (d *Delivery) Sent(now time.Time) {
+	d.Attempts++
+	d.Status = DeliverySent
+	d.LastError = ""
+	d.NextAttemptDate = nil
+	d.SentDate = &now
+}
+
+// Failed records the error of the attempt, and schedules the next one unless the delivery ran out of attempts.
+func (d *Delivery) Failed(err error, now time.Time) {
+	d.Attempts++
+	d.LastError = err.Error()
+
+	if d.Attempts >= MaxAttempts {
+		d.Status = DeliveryFailed
+		d.NextAttemptDate = nil
+
+		return
+	}
+
+	next := now.Add(RetryBackoff(d.Attempts))
+	d.NextAttemptDate = &next
+}
+
+// Cancel stops a pending delivery, used when its subscription is deleted.
+func (d *Delivery) Cancel() {
+	d.Status = DeliveryCancelled
+	d.NextAttemptDate = nil
+}
+
+// RetryBackoff doubles the delay after each failed attempt, from 5 minutes.
+func RetryBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	return retryDelay * time.Duration(1<<(attempts-1))
+}
@@ -0,0 +1,181 @@
+package reportmail_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/notification"
+	"github.com/usetania/tania-core/src/reportmail"
+)
+
+type fakeRenderer struct{}
+
+func (fakeRenderer) RenderReport(subscription reportmail.Subscription, date time.Time) (notification.Mail, error) {
+	return notification.Mail{Subject: subscription.ReportType + " " + date.Format("2006-01-02")}, nil
+}
+
+type fakeMailer struct {
+	Err  error
+	Sent []notification.Mail
+}
+
+func (m *fakeMailer) Send(mail notification.Mail) error {
+	if m.Err != nil {
+		return m.Err
+	}
+
+	m.Sent = append(m.Sent, mail)
+
+	return nil
+}
+
+func TestNewSubscription(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	now := time.Date(2026, time.October, 14, 10, 0, 0, 0, time.UTC)
+
+	// When
+	subscription, err := reportmail.NewSubscription(farmUID, reportmail.ReportDashboard, "", "monday", "06:00", "",
+		[]string{"Manager <manager@example.com>", " "}, now)
+
+	_, typeErr := reportmail.NewSubscription(farmUID, "YIELD", "", "MONDAY", "06:00", "",
+		[]string{"manager@example.com"}, now)
+	_, timeErr := reportmail.NewSubscription(farmUID, reportmail.ReportTasks, "", "MONDAY", "6am", "",
+		[]string{"manager@example.com"}, now)
+	_, recipientsErr := reportmail.NewSubscription(farmUID, reportmail.ReportTasks, "", "MONDAY", "06:00", "",
+		[]string{"manager"}, now)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, reportmail.FormatHTML, subscription.Format)
+	assert.Equal(t, "MONDAY", subscription.DayOfWeek)
+	assert.Equal(t, "UTC", subscription.Timezone)
+	assert.Equal(t, []string{"manager@example.com"}, subscription.Recipients)
+	assert.Equal(t, time.Date(2026, time.October, 19, 6, 0, 0, 0, time.UTC), subscription.NextRunDate)
+
+	assert.Equal(t, reportmail.ValidationError{Field: "report_type"}, typeErr)
+	assert.Equal(t, reportmail.ValidationError{Field: "time_of_day"}, timeErr)
+	assert.Equal(t, reportmail.ValidationError{Field: "recipients"}, recipientsErr)
+}
+
+func TestNextRun(t *testing.T) {
+	t.Parallel()
+	// Given
+	subscription := reportmail.Subscription{DayOfWeek: "MONDAY", TimeOfDay: "06:00", Timezone: "UTC"}
+	monday := time.Date(2026, time.October, 19, 6, 0, 0, 0, time.UTC)
+
+	// When
+	beforeTime, _ := subscription.NextRun(monday.Add(-time.Minute))
+	atTime, _ := subscription.NextRun(monday)
+
+	// Then
+	assert.Equal(t, monday, beforeTime)
+	assert.Equal(t, monday.AddDate(0, 0, 7), atTime)
+}
+
+func TestSchedulerRun(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	now := time.Date(2026, time.October, 14, 10, 0, 0, 0, time.UTC)
+
+	store := reportmail.NewStoreInMemory(reportmail.CreateReportMailStorage())
+	mailer := &fakeMailer{}
+	scheduler := reportmail.NewScheduler(store, fakeRenderer{}, mailer)
+
+	subscription, _ := reportmail.NewSubscription(farmUID, reportmail.ReportTasks, "", "MONDAY", "06:00", "",
+		[]string{"manager@example.com"}, now)
+	store.SaveSubscription(subscription)
+
+	// When
+	err := scheduler.Run(subscription.NextRunDate.Add(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Len(t, mailer.Sent, 1)
+	assert.Equal(t, []string{"manager@example.com"}, mailer.Sent[0].To)
+	assert.Equal(t, "TASKS 2026-10-19", mailer.Sent[0].Subject)
+
+	deliveries, _ := scheduler.FindDeliveries(subscription.UID)
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, reportmail.DeliverySent, deliveries[0].Status)
+
+	saved, _ := store.FindSubscription(subscription.UID)
+	assert.Equal(t, subscription.NextRunDate.AddDate(0, 0, 7), saved.NextRunDate)
+}
+
+func TestSchedulerRetry(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	now := time.Date(2026, time.October, 14, 10, 0, 0, 0, time.UTC)
+
+	store := reportmail.NewStoreInMemory(reportmail.CreateReportMailStorage())
+	mailer := &fakeMailer{Err: errors.New("connection refused")}
+	scheduler := reportmail.NewScheduler(store, fakeRenderer{}, mailer)
+
+	subscription, _ := reportmail.NewSubscription(farmUID, reportmail.ReportDashboard, "", "MONDAY", "06:00", "",
+		[]string{"manager@example.com"}, now)
+	store.SaveSubscription(subscription)
+
+	// When
+	delivery, err := scheduler.RunNow(subscription, now)
+
+	retryNow := now
+
+	for i := 1; i < reportmail.MaxAttempts; i++ {
+		retryNow = retryNow.Add(reportmail.RetryBackoff(i))
+		scheduler.Run(retryNow)
+	}
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, reportmail.DeliveryPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, "connection refused", delivery.LastError)
+	assert.Equal(t, now.Add(5*time.Minute), *delivery.NextAttemptDate)
+
+	deliveries, _ := scheduler.FindDeliveries(subscription.UID)
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, reportmail.DeliveryFailed, deliveries[0].Status)
+	assert.Equal(t, reportmail.MaxAttempts, deliveries[0].Attempts)
+	assert.Nil(t, deliveries[0].NextAttemptDate)
+}
+
+func TestSchedulerDelete(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	now := time.Date(2026, time.October, 14, 10, 0, 0, 0, time.UTC)
+
+	store := reportmail.NewStoreInMemory(reportmail.CreateReportMailStorage())
+	mailer := &fakeMailer{Err: errors.New("connection refused")}
+	scheduler := reportmail.NewScheduler(store, fakeRenderer{}, mailer)
+
+	subscription, _ := reportmail.NewSubscription(farmUID, reportmail.ReportDashboard, "", "MONDAY", "06:00", "",
+		[]string{"manager@example.com"}, now)
+	store.SaveSubscription(subscription)
+
+	scheduler.RunNow(subscription, now)
+
+	// When
+	err := scheduler.Delete(subscription.UID)
+
+	mailer.Err = nil
+	scheduler.Run(subscription.NextRunDate.Add(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Empty(t, mailer.Sent)
+
+	deleted, _ := store.FindSubscription(subscription.UID)
+	assert.Nil(t, deleted)
+
+	deliveries, _ := scheduler.FindDeliveries(subscription.UID)
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, reportmail.DeliveryCancelled, deliveries[0].Status)
+}
@@ -0,0 +1,195 @@
+package reportmail
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/notification"
+)
+
+const schedulerInterval = time.Minute
+
+// deliveriesPerSubscription is the number of past deliveries kept visible on the subscription.
+const deliveriesPerSubscription = 20
+
+// Renderer renders the report of the subscription as of the date. The recipients are set by the scheduler.
+type Renderer interface {
+	RenderReport(subscription Subscription, date time.Time) (notification.Mail, error)
+}
+
+type Mailer interface {
+	Send(mail notification.Mail) error
+}
+
+// Scheduler creates the deliveries of the due subscriptions and sends them, retrying the failed ones.
+type Scheduler struct {
+	Store    Store
+	Renderer Renderer
+	Mailer   Mailer
+
+	// lock keeps a delivery from being sent twice by the job and run now at the same time.
+	lock *sync.Mutex
+}
+
+func NewScheduler(store Store, renderer Renderer, mailer Mailer) *Scheduler {
+	return &Scheduler{
+		Store:    store,
+		Renderer: renderer,
+		Mailer:   mailer,
+		lock:     &sync.Mutex{},
+	}
+}
+
+// Start runs the scheduler every minute.
+// While paused is true, the due reports wait for the first run after it.
+func (s *Scheduler) Start(paused func() bool) {
+	ticker := time.NewTicker(schedulerInterval)
+
+	go func() {
+		for {
+			if paused() {
+				log.Println("Report scheduler paused by the maintenance mode")
+			} else if err := s.Run(time.Now()); err != nil {
+				log.Println("Report scheduler failed", err)
+			}
+
+			<-ticker.C
+		}
+	}()
+}
+
+// Run creates the deliveries of the subscriptions due at now, then sends the pending deliveries.
+func (s *Scheduler) Run(now time.Time) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	subscriptions, err := s.Store.FindDueSubscriptions(now)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range subscriptions {
+		delivery, err := NewDelivery(v, v.NextRunDate, false)
+		if err != nil {
+			return err
+		}
+
+		err = s.Store.SaveDelivery(delivery)
+		if err != nil {
+			return err
+		}
+
+		// A server down for weeks sends the report once, not once per missed week.
+		v.NextRunDate, err = v.NextRun(now)
+		if err != nil {
+			return err
+		}
+
+		err = s.Store.SaveSubscription(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	deliveries, err := s.Store.FindPendingDeliveries(now)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range deliveries {
+		_, err := s.attempt(v, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunNow sends the report of the subscription right away. A failed send is retried like the scheduled ones.
+func (s *Scheduler) RunNow(subscription Subscription, now time.Time) (Delivery, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delivery, err := NewDelivery(subscription, now, true)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	return s.attempt(delivery, now)
+}
+
+// Delete deletes the subscription and cancels its pending deliveries, so nothing is sent anymore.
+func (s *Scheduler) Delete(subscriptionUID uuid.UUID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	err := s.Store.DeleteSubscription(subscriptionUID)
+	if err != nil {
+		return err
+	}
+
+	deliveries, err := s.Store.FindDeliveries(subscriptionUID, 0)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range deliveries {
+		if v.Status != DeliveryPending {
+			continue
+		}
+
+		v.Cancel()
+
+		err = s.Store.SaveDelivery(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// attempt sends the delivery once and saves the outcome. Only the errors of the store are returned,
+// the errors of the send are kept on the delivery.
+func (s *Scheduler) attempt(delivery Delivery, now time.Time) (Delivery, error) {
+	subscription, err := s.Store.FindSubscription(delivery.SubscriptionUID)
+	if err != nil {
+		return delivery, err
+	}
+
+	if subscription == nil {
+		delivery.Cancel()
+
+		return delivery, s.Store.SaveDelivery(delivery)
+	}
+
+	err = s.send(*subscription, delivery.ScheduledDate)
+	if err != nil {
+		log.Printf("Report delivery %s of subscription %s failed. %v", delivery.UID, subscription.UID, err)
+
+		delivery.Failed(err, now)
+	} else {
+		delivery.Sent(now)
+	}
+
+	return delivery, s.Store.SaveDelivery(delivery)
+}
+
+func (s *Scheduler) send(subscription Subscription, date time.Time) error {
+	mail, err := s.Renderer.RenderReport(subscription, date)
+	if err != nil {
+		return err
+	}
+
+	mail.To = subscription.Recipients
+
+	return s.Mailer.Send(mail)
+}
+
+// FindDeliveries returns the latest deliveries of the subscription.
+func (s *Scheduler) FindDeliveries(subscriptionUID uuid.UUID) ([]Delivery, error) {
+	return s.Store.FindDeliveries(subscriptionUID, deliveriesPerSubscription)
+}
@@ -0,0 +1,249 @@
+package reportmail
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type StoreSqlite struct {
+	DB *sql.DB
+}
+
+func NewStoreSqlite(db *sql.DB) Store {
+	return &StoreSqlite{DB: db}
+}
+
+const subscriptionColumns = `UID, FARM_UID, REPORT_TYPE, FORMAT, DAY_OF_WEEK, TIME_OF_DAY, TIMEZONE,
+	RECIPIENTS, CREATED_DATE, NEXT_RUN_DATE`
+
+const deliveryColumns = `UID, SUBSCRIPTION_UID, FARM_UID, SCHEDULED_DATE, IS_MANUAL, STATUS, ATTEMPTS,
+	LAST_ERROR, NEXT_ATTEMPT_DATE, SENT_DATE`
+
+func (s *StoreSqlite) SaveSubscription(subscription Subscription) error {
+	recipients, err := json.Marshal(subscription.Recipients)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.DB.Exec(`INSERT OR REPLACE INTO REPORT_SUBSCRIPTION (`+subscriptionColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		subscription.UID.String(),
+		subscription.FarmUID.String(),
+		subscription.ReportType,
+		subscription.Format,
+		subscription.DayOfWeek,
+		subscription.TimeOfDay,
+		subscription.Timezone,
+		string(recipients),
+		formatDateSqlite(subscription.CreatedDate),
+		formatDateSqlite(subscription.NextRunDate))
+
+	return err
+}
+
+func (s *StoreSqlite) FindSubscription(uid uuid.UUID) (*Subscription, error) {
+	subscriptions, err := s.findAllSubscriptions(`SELECT `+subscriptionColumns+`
+		FROM REPORT_SUBSCRIPTION WHERE UID = ?`, uid.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil, nil
+	}
+
+	return &subscriptions[0], nil
+}
+
+func (s *StoreSqlite) FindSubscriptionsByFarm(farmUID uuid.UUID) ([]Subscription, error) {
+	return s.findAllSubscriptions(`SELECT `+subscriptionColumns+`
+		FROM REPORT_SUBSCRIPTION WHERE FARM_UID = ? ORDER BY CREATED_DATE ASC`, farmUID.String())
+}
+
+func (s *StoreSqlite) FindDueSubscriptions(now time.Time) ([]Subscription, error) {
+	return s.findAllSubscriptions(`SELECT `+subscriptionColumns+`
+		FROM REPORT_SUBSCRIPTION WHERE NEXT_RUN_DATE <= ?`, formatDateSqlite(now))
+}
+
+func (s *StoreSqlite) DeleteSubscription(uid uuid.UUID) error {
+	_, err := s.DB.Exec(`DELETE FROM REPORT_SUBSCRIPTION WHERE UID = ?`, uid.String())
+
+	return err
+}
+
+func (s *StoreSqlite) SaveDelivery(delivery Delivery) error {
+	_, err := s.DB.Exec(`INSERT OR REPLACE INTO REPORT_DELIVERY (`+deliveryColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		delivery.UID.String(),
+		delivery.SubscriptionUID.String(),
+		delivery.FarmUID.String(),
+		formatDateSqlite(delivery.ScheduledDate),
+		delivery.Manual,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastError,
+		formatNullableDateSqlite(delivery.NextAttemptDate),
+		formatNullableDateSqlite(delivery.SentDate))
+
+	return err
+}
+
+func (s *StoreSqlite) FindDeliveries(subscriptionUID uuid.UUID, limit int) ([]Delivery, error) {
+	if limit <= 0 {
+		return s.findAllDeliveries(`SELECT `+deliveryColumns+`
+			FROM REPORT_DELIVERY WHERE SUBSCRIPTION_UID = ? ORDER BY SCHEDULED_DATE DESC`, subscriptionUID.String())
+	}
+
+	return s.findAllDeliveries(`SELECT `+deliveryColumns+`
+		FROM REPORT_DELIVERY WHERE SUBSCRIPTION_UID = ? ORDER BY SCHEDULED_DATE DESC LIMIT ?`,
+		subscriptionUID.String(), limit)
+}
+
+func (s *StoreSqlite) FindPendingDeliveries(now time.Time) ([]Delivery, error) {
+	return s.findAllDeliveries(`SELECT `+deliveryColumns+`
+		FROM REPORT_DELIVERY WHERE STATUS = ? AND NEXT_ATTEMPT_DATE <= ?
+		ORDER BY SCHEDULED_DATE ASC`, DeliveryPending, formatDateSqlite(now))
+}
+
+func (s *StoreSqlite) findAllSubscriptions(query string, args ...interface{}) ([]Subscription, error) {
+	rows, err := s.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := []Subscription{}
+
+	for rows.Next() {
+		var (
+			uid, farmUID, recipients string
+			createdDate, nextRunDate string
+		)
+
+		v := Subscription{}
+
+		err = rows.Scan(&uid, &farmUID, &v.ReportType, &v.Format, &v.DayOfWeek, &v.TimeOfDay, &v.Timezone,
+			&recipients, &createdDate, &nextRunDate)
+		if err != nil {
+			return nil, err
+		}
+
+		v.UID, err = uuid.FromString(uid)
+		if err != nil {
+			return nil, err
+		}
+
+		v.FarmUID, err = uuid.FromString(farmUID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(recipients), &v.Recipients)
+		if err != nil {
+			return nil, err
+		}
+
+		v.CreatedDate, err = time.Parse(time.RFC3339, createdDate)
+		if err != nil {
+			return nil, err
+		}
+
+		v.NextRunDate, err = time.Parse(time.RFC3339, nextRunDate)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, v)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+func (s *StoreSqlite) findAllDeliveries(query string, args ...interface{}) ([]Delivery, error) {
+	rows, err := s.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []Delivery{}
+
+	for rows.Next() {
+		var (
+			uid, subscriptionUID, farmUID string
+			scheduledDate                 string
+			nextAttemptDate, sentDate     sql.NullString
+		)
+
+		v := Delivery{}
+
+		err = rows.Scan(&uid, &subscriptionUID, &farmUID, &scheduledDate, &v.Manual, &v.Status, &v.Attempts,
+			&v.LastError, &nextAttemptDate, &sentDate)
+		if err != nil {
+			return nil, err
+		}
+
+		v.UID, err = uuid.FromString(uid)
+		if err != nil {
+			return nil, err
+		}
+
+		v.SubscriptionUID, err = uuid.FromString(subscriptionUID)
+		if err != nil {
+			return nil, err
+		}
+
+		v.FarmUID, err = uuid.FromString(farmUID)
+		if err != nil {
+			return nil, err
+		}
+
+		v.ScheduledDate, err = time.Parse(time.RFC3339, scheduledDate)
+		if err != nil {
+			return nil, err
+		}
+
+		v.NextAttemptDate, err = parseNullableDateSqlite(nextAttemptDate)
+		if err != nil {
+			return nil, err
+		}
+
+		v.SentDate, err = parseNullableDateSqlite(sentDate)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, v)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// formatDateSqlite keeps the dates in UTC, so they are compared in the queries as text.
+func formatDateSqlite(date time.Time) string {
+	return date.UTC().Format(time.RFC3339)
+}
+
+func formatNullableDateSqlite(date *time.Time) interface{} {
+	if date == nil {
+		return nil
+	}
+
+	return formatDateSqlite(*date)
+}
+
+func parseNullableDateSqlite(value sql.NullString) (*time.Time, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, value.String)
+	if err != nil {
+		return nil, err
+	}
+
+	return &date, nil
+}
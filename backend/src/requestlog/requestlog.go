@@ -0,0 +1,229 @@
+// Package requestlog keeps the request bodies for the request log, with the excluded fields redacted,
+// so the log doesn't show the passwords, emails or phone numbers sent, nor the uploaded files.
+package requestlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// contextBody is the key of the kept body in the echo context.
+const contextBody = "REQUEST_LOG_BODY"
+
+// Redacted replaces the values of the excluded fields.
+const Redacted = "[REDACTED]"
+
+// MaxBodySize is the size of the largest body logged. A larger one is left out,
+// it would be cut in the middle of a field.
+const MaxBodySize = 64 << 10
+
+var ErrInvalidPattern = errors.New("log excluded field must be a JSON path like $.password or $.workers[*].email")
+
+type capturedBody struct {
+	contentType string
+	raw         []byte
+}
+
+// Redactor redacts the fields matching its JSON path patterns. A pattern starts with $ and each of its
+// segments is a field name or *, matching any field or array element, e.g. $.password or $.workers[*].email.
+// The field names are matched case insensitively.
+type Redactor struct {
+	patterns [][]string
+}
+
+func NewRedactor(patterns []string) (*Redactor, error) {
+	redactor := &Redactor{}
+
+	for _, v := range patterns {
+		segments, err := parsePattern(v)
+		if err != nil {
+			return nil, err
+		}
+
+		redactor.patterns = append(redactor.patterns, segments)
+	}
+
+	return redactor, nil
+}
+
+func parsePattern(pattern string) ([]string, error) {
+	pattern = strings.TrimSpace(pattern)
+	if !strings.HasPrefix(pattern, "$") {
+		return nil, ErrInvalidPattern
+	}
+
+	path := strings.NewReplacer("[", ".", "]", "").Replace(strings.TrimPrefix(pattern, "$"))
+	if path == "" {
+		return []string{}, nil
+	}
+
+	if !strings.HasPrefix(path, ".") {
+		return nil, ErrInvalidPattern
+	}
+
+	segments := strings.Split(strings.TrimPrefix(path, "."), ".")
+	for _, v := range segments {
+		if v == "" {
+			return nil, ErrInvalidPattern
+		}
+	}
+
+	return segments, nil
+}
+
+// Redact returns a copy of the decoded JSON value with the matching fields replaced by Redacted.
+func (r *Redactor) Redact(value interface{}) interface{} {
+	return redact(value, r.patterns)
+}
+
+func redact(value interface{}, patterns [][]string) interface{} {
+	if len(patterns) == 0 {
+		return value
+	}
+
+	for _, v := range patterns {
+		if len(v) == 0 {
+			return Redacted
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			redacted[key] = redact(child, childPatterns(patterns, key))
+		}
+
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, child := range v {
+			redacted[i] = redact(child, childPatterns(patterns, strconv.Itoa(i)))
+		}
+
+		return redacted
+	}
+
+	return value
+}
+
+// childPatterns returns the rest of the patterns matching the key of a field.
+func childPatterns(patterns [][]string, key string) [][]string {
+	matching := [][]string{}
+
+	for _, v := range patterns {
+		if v[0] == "*" || strings.EqualFold(v[0], key) {
+			matching = append(matching, v[1:])
+		}
+	}
+
+	return matching
+}
+
+// Values redacts the form or query values like the fields of a JSON object.
+func (r *Redactor) Values(values url.Values) interface{} {
+	fields := make(map[string]interface{}, len(values))
+
+	for key, v := range values {
+		if len(v) == 1 {
+			fields[key] = v[0]
+
+			continue
+		}
+
+		list := make([]interface{}, len(v))
+		for i, value := range v {
+			list[i] = value
+		}
+
+		fields[key] = list
+	}
+
+	return r.Redact(fields)
+}
+
+// Body returns the redacted body the Middleware kept, false when it wasn't kept.
+// Only the JSON and URL encoded form bodies are, so the uploaded files are never logged.
+func (r *Redactor) Body(c echo.Context) (interface{}, bool) {
+	body, ok := c.Get(contextBody).(capturedBody)
+	if !ok || len(body.raw) == 0 {
+		return nil, false
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(body.contentType)
+
+	switch {
+	case isJSON(mediaType):
+		decoder := json.NewDecoder(bytes.NewReader(body.raw))
+		decoder.UseNumber()
+
+		var value interface{}
+
+		// A body which doesn't parse can't be redacted, so it's left out.
+		if err := decoder.Decode(&value); err != nil {
+			return nil, false
+		}
+
+		return r.Redact(value), true
+	case mediaType == echo.MIMEApplicationForm:
+		values, err := url.ParseQuery(string(body.raw))
+		if err != nil {
+			return nil, false
+		}
+
+		return r.Values(values), true
+	}
+
+	return nil, false
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == echo.MIMEApplicationJSON || strings.HasSuffix(mediaType, "+json")
+}
+
+func isLogged(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return isJSON(mediaType) || mediaType == echo.MIMEApplicationForm
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// Middleware keeps the JSON and URL encoded form bodies up to the MaxBodySize in the context for the request log.
+// The handler still reads the whole body. The other bodies, like the multipart uploads, are never read.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			contentType := req.Header.Get(echo.HeaderContentType)
+
+			if req.Body == nil || req.Body == http.NoBody || !isLogged(contentType) {
+				return next(c)
+			}
+
+			head, err := io.ReadAll(io.LimitReader(req.Body, MaxBodySize+1))
+			req.Body = readCloser{io.MultiReader(bytes.NewReader(head), req.Body), req.Body}
+
+			if err == nil && len(head) <= MaxBodySize {
+				c.Set(contextBody, capturedBody{contentType: contentType, raw: head})
+			}
+
+			return next(c)
+		}
+	}
+}
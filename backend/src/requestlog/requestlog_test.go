@@ -0,0 +1,149 @@
+package requestlog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/requestlog"
+)
+
+func logBody(
+	t *testing.T, redactor *requestlog.Redactor, contentType string, body io.Reader,
+) (interface{}, bool, string) {
+	t.Helper()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	read := ""
+	handler := requestlog.Middleware()(func(c echo.Context) error {
+		raw, err := io.ReadAll(c.Request().Body)
+		read = string(raw)
+
+		return err
+	})
+
+	err := handler(c)
+	assert.Nil(t, err)
+
+	logged, ok := redactor.Body(c)
+
+	return logged, ok, read
+}
+
+func TestRedactJSONBody(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	redactor, err := requestlog.NewRedactor([]string{"$.password", "$.workers[*].email", "$.contact.*"})
+	assert.Nil(t, err)
+
+	body := `{"name":"Tania","Password":"secret","quantity":12.5,` +
+		`"workers":[{"name":"Ann","email":"ann@example.com"},{"name":"Bo"}],"contact":{"phone":"555","email":"x@y.z"}}`
+
+	// When
+	logged, ok, read := logBody(t, redactor, echo.MIMEApplicationJSONCharsetUTF8, strings.NewReader(body))
+
+	// Then
+	assert.True(t, ok)
+	assert.Equal(t, body, read)
+
+	fields, _ := logged.(map[string]interface{})
+	assert.Equal(t, "Tania", fields["name"])
+	assert.Equal(t, requestlog.Redacted, fields["Password"])
+	assert.Equal(t, json.Number("12.5"), fields["quantity"])
+
+	workers, _ := fields["workers"].([]interface{})
+	assert.Len(t, workers, 2)
+	assert.Equal(t, map[string]interface{}{"name": "Ann", "email": requestlog.Redacted}, workers[0])
+	assert.Equal(t, map[string]interface{}{"name": "Bo"}, workers[1])
+	assert.Equal(t, map[string]interface{}{"phone": requestlog.Redacted, "email": requestlog.Redacted}, fields["contact"])
+}
+
+func TestRedactFormBody(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	redactor, err := requestlog.NewRedactor([]string{"$.password", "$.confirm_password"})
+	assert.Nil(t, err)
+
+	body := "username=tania&password=secret&confirm_password=secret&tag=a&tag=b"
+
+	// When
+	logged, ok, read := logBody(t, redactor, echo.MIMEApplicationForm, strings.NewReader(body))
+
+	// Then
+	assert.True(t, ok)
+	assert.Equal(t, body, read)
+	assert.Equal(t, map[string]interface{}{
+		"username":         "tania",
+		"password":         requestlog.Redacted,
+		"confirm_password": requestlog.Redacted,
+		"tag":              []interface{}{"a", "b"},
+	}, logged)
+}
+
+func TestUploadsAreNeverLogged(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	redactor, err := requestlog.NewRedactor(nil)
+	assert.Nil(t, err)
+
+	upload := &bytes.Buffer{}
+	writer := multipart.NewWriter(upload)
+	part, _ := writer.CreateFormFile("photo", "photo.jpg")
+	_, _ = part.Write([]byte{0xff, 0xd8, 0xff, 0xe0})
+	_ = writer.Close()
+
+	// When
+	_, multipartOK, _ := logBody(t, redactor, writer.FormDataContentType(), bytes.NewReader(upload.Bytes()))
+	_, binaryOK, _ := logBody(t, redactor, echo.MIMEOctetStream, bytes.NewReader([]byte{0x00, 0x01}))
+	_, textOK, _ := logBody(t, redactor, echo.MIMETextPlain, strings.NewReader("email me at ann@example.com"))
+
+	// Then
+	assert.False(t, multipartOK)
+	assert.False(t, binaryOK)
+	assert.False(t, textOK)
+}
+
+func TestLargeBodiesAreLeftOut(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	redactor, err := requestlog.NewRedactor(nil)
+	assert.Nil(t, err)
+
+	body := `{"note":"` + strings.Repeat("a", requestlog.MaxBodySize) + `"}`
+
+	// When
+	_, ok, read := logBody(t, redactor, echo.MIMEApplicationJSON, strings.NewReader(body))
+
+	// Then
+	assert.False(t, ok)
+	assert.Equal(t, body, read)
+}
+
+func TestInvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	// When
+	_, errNoRoot := requestlog.NewRedactor([]string{"password"})
+	_, errEmptySegment := requestlog.NewRedactor([]string{"$..password"})
+	_, errValid := requestlog.NewRedactor([]string{" $.workers[*].phone "})
+
+	// Then
+	assert.Equal(t, requestlog.ErrInvalidPattern, errNoRoot)
+	assert.Equal(t, requestlog.ErrInvalidPattern, errEmptySegment)
+	assert.Nil(t, errValid)
+}
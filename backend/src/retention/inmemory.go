@@ -0,0 +1,273 @@
+package retention
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/sasha-s/go-deadlock"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/helper/structhelper"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type PrunedStorage struct {
+	Lock      *deadlock.RWMutex
+	PrunedMap map[string]Pruned
+}
+
+func CreatePrunedStorage() *PrunedStorage {
+	return &PrunedStorage{PrunedMap: make(map[string]Pruned), Lock: &deadlock.RWMutex{}}
+}
+
+func prunedKey(aggregate string, uid uuid.UUID) string {
+	return aggregate + "/" + uid.String()
+}
+
+type PrunedQueryInMemory struct {
+	Storage *PrunedStorage
+}
+
+func NewPrunedQueryInMemory(s *PrunedStorage) PrunedQuery {
+	return &PrunedQueryInMemory{Storage: s}
+}
+
+func (q *PrunedQueryInMemory) FindPruned(aggregate string, uid uuid.UUID) (*Pruned, error) {
+	q.Storage.Lock.RLock()
+	defer q.Storage.Lock.RUnlock()
+
+	p, ok := q.Storage.PrunedMap[prunedKey(aggregate, uid)]
+	if !ok {
+		return nil, nil
+	}
+
+	return &p, nil
+}
+
+type StoreInMemory struct {
+	PrunedQueryInMemory
+
+	CropEventStorage    *growthstorage.CropEventStorage
+	CropReadStorage     *growthstorage.CropReadStorage
+	CropActivityStorage *growthstorage.CropActivityStorage
+	TaskEventStorage    *taskstorage.TaskEventStorage
+	TaskReadStorage     *taskstorage.TaskReadStorage
+}
+
+func NewStoreInMemory(
+	prunedStorage *PrunedStorage,
+	cropEventStorage *growthstorage.CropEventStorage,
+	cropReadStorage *growthstorage.CropReadStorage,
+	cropActivityStorage *growthstorage.CropActivityStorage,
+	taskEventStorage *taskstorage.TaskEventStorage,
+	taskReadStorage *taskstorage.TaskReadStorage,
+) Store {
+	return &StoreInMemory{
+		PrunedQueryInMemory: PrunedQueryInMemory{Storage: prunedStorage},
+		CropEventStorage:    cropEventStorage,
+		CropReadStorage:     cropReadStorage,
+		CropActivityStorage: cropActivityStorage,
+		TaskEventStorage:    taskEventStorage,
+		TaskReadStorage:     taskReadStorage,
+	}
+}
+
+func (s *StoreInMemory) FindCandidates(cutoff time.Time) ([]Candidate, error) {
+	candidates := []Candidate{}
+
+	s.CropReadStorage.Lock.RLock()
+	s.CropEventStorage.Lock.RLock()
+	s.CropActivityStorage.Lock.RLock()
+
+	for uid, crop := range s.CropReadStorage.CropReadMap {
+		if crop.Status != growthdomain.CropArchived {
+			continue
+		}
+
+		c := Candidate{Aggregate: AggregateCrop, UID: uid}
+
+		for _, e := range s.CropEventStorage.CropEvents {
+			if e.CropUID == uid {
+				c.Events++
+
+				if e.Version > c.Version {
+					c.Version = e.Version
+					c.LastEventDate = e.CreatedDate
+				}
+			}
+		}
+
+		for _, a := range s.CropActivityStorage.CropActivityMap {
+			if a.UID == uid {
+				c.Activities++
+			}
+		}
+
+		if c.Events > 0 && c.LastEventDate.Before(cutoff) {
+			candidates = append(candidates, c)
+		}
+	}
+
+	s.CropActivityStorage.Lock.RUnlock()
+	s.CropEventStorage.Lock.RUnlock()
+	s.CropReadStorage.Lock.RUnlock()
+
+	s.TaskReadStorage.Lock.RLock()
+	s.TaskEventStorage.Lock.RLock()
+
+	taskCandidates := map[uuid.UUID]*Candidate{}
+
+	for _, e := range s.TaskEventStorage.TaskEvents {
+		// The archived tasks have been moved out of the task reads, only the closed tasks are archived.
+		task, ok := s.TaskReadStorage.TaskReadMap[e.TaskUID]
+		if ok && task.Status != tasksdomain.TaskStatusCompleted && task.Status != tasksdomain.TaskStatusCancelled {
+			continue
+		}
+
+		c, ok := taskCandidates[e.TaskUID]
+		if !ok {
+			c = &Candidate{Aggregate: AggregateTask, UID: e.TaskUID}
+			taskCandidates[e.TaskUID] = c
+		}
+
+		c.Events++
+
+		if e.Version > c.Version {
+			c.Version = e.Version
+			c.LastEventDate = e.CreatedDate
+		}
+	}
+
+	for _, c := range taskCandidates {
+		if c.LastEventDate.Before(cutoff) {
+			candidates = append(candidates, *c)
+		}
+	}
+
+	s.TaskEventStorage.Lock.RUnlock()
+	s.TaskReadStorage.Lock.RUnlock()
+
+	return candidates, nil
+}
+
+func (s *StoreInMemory) FindEvents(candidate Candidate) ([]ArchivedEvent, error) {
+	events := []ArchivedEvent{}
+
+	add := func(version int, createdDate time.Time, event interface{}) error {
+		raw, err := json.Marshal(struct {
+			Name string
+			Data interface{}
+		}{
+			Name: structhelper.GetName(event),
+			Data: event,
+		})
+		if err != nil {
+			return err
+		}
+
+		events = append(events, ArchivedEvent{
+			Aggregate:    candidate.Aggregate,
+			AggregateUID: candidate.UID,
+			Version:      version,
+			CreatedDate:  createdDate,
+			Event:        raw,
+		})
+
+		return nil
+	}
+
+	if candidate.Aggregate == AggregateCrop {
+		s.CropEventStorage.Lock.RLock()
+		defer s.CropEventStorage.Lock.RUnlock()
+
+		for _, e := range s.CropEventStorage.CropEvents {
+			if e.CropUID == candidate.UID {
+				if err := add(e.Version, e.CreatedDate, e.Event); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		return events, nil
+	}
+
+	s.TaskEventStorage.Lock.RLock()
+	defer s.TaskEventStorage.Lock.RUnlock()
+
+	for _, e := range s.TaskEventStorage.TaskEvents {
+		if e.TaskUID == candidate.UID {
+			if err := add(e.Version, e.CreatedDate, e.Event); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return events, nil
+}
+
+func (s *StoreInMemory) Prune(candidate Candidate, archiveFile string, prunedDate time.Time) (bool, error) {
+	if candidate.Aggregate == AggregateCrop {
+		s.CropEventStorage.Lock.Lock()
+		defer s.CropEventStorage.Lock.Unlock()
+
+		kept := []growthstorage.CropEvent{}
+
+		for _, e := range s.CropEventStorage.CropEvents {
+			if e.CropUID == candidate.UID && e.Version > candidate.Version {
+				return false, nil
+			}
+
+			if e.CropUID != candidate.UID {
+				kept = append(kept, e)
+			}
+		}
+
+		s.CropEventStorage.CropEvents = kept
+
+		s.CropActivityStorage.Lock.Lock()
+
+		activities := []growthstorage.CropActivity{}
+
+		for _, a := range s.CropActivityStorage.CropActivityMap {
+			if a.UID != candidate.UID {
+				activities = append(activities, a)
+			}
+		}
+
+		s.CropActivityStorage.CropActivityMap = activities
+
+		s.CropActivityStorage.Lock.Unlock()
+	} else {
+		s.TaskEventStorage.Lock.Lock()
+		defer s.TaskEventStorage.Lock.Unlock()
+
+		kept := []taskstorage.TaskEvent{}
+
+		for _, e := range s.TaskEventStorage.TaskEvents {
+			if e.TaskUID == candidate.UID && e.Version > candidate.Version {
+				return false, nil
+			}
+
+			if e.TaskUID != candidate.UID {
+				kept = append(kept, e)
+			}
+		}
+
+		s.TaskEventStorage.TaskEvents = kept
+	}
+
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	s.Storage.PrunedMap[prunedKey(candidate.Aggregate, candidate.UID)] = Pruned{
+		Aggregate:     candidate.Aggregate,
+		UID:           candidate.UID,
+		ArchiveFile:   archiveFile,
+		LastEventDate: candidate.LastEventDate,
+		PrunedDate:    prunedDate,
+	}
+
+	return true, nil
+}
@@ -0,0 +1,167 @@
+package retention
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+type StoreMysql struct {
+	DB *sql.DB
+}
+
+func NewStoreMysql(db *sql.DB) Store {
+	return &StoreMysql{DB: db}
+}
+
+func (s *StoreMysql) FindCandidates(cutoff time.Time) ([]Candidate, error) {
+	crops, err := s.findCandidates(AggregateCrop, cutoff, cropCandidatesQuery, growthdomain.CropArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.findCandidates(AggregateTask, cutoff, taskCandidatesQuery,
+		tasksdomain.TaskStatusCompleted, tasksdomain.TaskStatusCancelled)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(crops, tasks...), nil
+}
+
+func (s *StoreMysql) findCandidates(aggregate string, cutoff time.Time, query string, args ...interface{}) ([]Candidate, error) {
+	rows, err := s.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := []Candidate{}
+
+	for rows.Next() {
+		var uid []byte
+
+		c := Candidate{Aggregate: aggregate}
+
+		err = rows.Scan(&uid, &c.Version, &c.LastEventDate, &c.Events, &c.Activities)
+		if err != nil {
+			return nil, err
+		}
+
+		c.UID, err = uuid.FromBytes(uid)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.LastEventDate.Before(cutoff) {
+			candidates = append(candidates, c)
+		}
+	}
+
+	return candidates, rows.Err()
+}
+
+func (s *StoreMysql) FindEvents(candidate Candidate) ([]ArchivedEvent, error) {
+	eventTable, uidColumn, _ := historyTables(candidate.Aggregate)
+
+	rows, err := s.DB.Query(`SELECT VERSION, CREATED_DATE, EVENT FROM `+eventTable+`
+		WHERE `+uidColumn+` = ? ORDER BY VERSION ASC`, candidate.UID.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []ArchivedEvent{}
+
+	for rows.Next() {
+		var event []byte
+
+		e := ArchivedEvent{Aggregate: candidate.Aggregate, AggregateUID: candidate.UID}
+
+		err = rows.Scan(&e.Version, &e.CreatedDate, &event)
+		if err != nil {
+			return nil, err
+		}
+
+		e.Event = event
+
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+func (s *StoreMysql) Prune(candidate Candidate, archiveFile string, prunedDate time.Time) (bool, error) {
+	eventTable, uidColumn, activityTable := historyTables(candidate.Aggregate)
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM `+eventTable+` WHERE `+uidColumn+` = ? AND VERSION <= ?`,
+		candidate.UID.Bytes(), candidate.Version)
+	if err != nil {
+		tx.Rollback()
+
+		return false, err
+	}
+
+	remaining := 0
+
+	err = tx.QueryRow(`SELECT COUNT(*) FROM `+eventTable+` WHERE `+uidColumn+` = ?`,
+		candidate.UID.Bytes()).Scan(&remaining)
+	if err != nil {
+		tx.Rollback()
+
+		return false, err
+	}
+
+	if remaining > 0 {
+		return false, tx.Rollback()
+	}
+
+	if activityTable != "" {
+		_, err = tx.Exec(`DELETE FROM `+activityTable+` WHERE CROP_UID = ?`, candidate.UID.Bytes())
+		if err != nil {
+			tx.Rollback()
+
+			return false, err
+		}
+	}
+
+	_, err = tx.Exec(`INSERT INTO PRUNED_AGGREGATE (AGGREGATE, UID, ARCHIVE_FILE, LAST_EVENT_DATE, PRUNED_DATE)
+		VALUES (?, ?, ?, ?, ?)`,
+		candidate.Aggregate,
+		candidate.UID.Bytes(),
+		archiveFile,
+		candidate.LastEventDate,
+		prunedDate)
+	if err != nil {
+		tx.Rollback()
+
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func (s *StoreMysql) FindPruned(aggregate string, uid uuid.UUID) (*Pruned, error) {
+	p := Pruned{Aggregate: aggregate, UID: uid}
+
+	err := s.DB.QueryRow(`SELECT ARCHIVE_FILE, LAST_EVENT_DATE, PRUNED_DATE FROM PRUNED_AGGREGATE
+		WHERE AGGREGATE = ? AND UID = ?`, aggregate, uid.Bytes()).Scan(&p.ArchiveFile, &p.LastEventDate, &p.PrunedDate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
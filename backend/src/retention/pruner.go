@@ -0,0 +1,172 @@
+package retention
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const pruneInterval = 24 * time.Hour
+
+// Pruner archives and then deletes the history of old archived aggregates.
+type Pruner struct {
+	Store       Store
+	Years       int
+	DryRun      bool
+	ArchivePath string
+}
+
+func NewPruner(store Store, years int, dryRun bool, archivePath string) (*Pruner, error) {
+	if years < 1 {
+		return nil, errors.New("retention period must be at least one year")
+	}
+
+	return &Pruner{
+		Store:       store,
+		Years:       years,
+		DryRun:      dryRun,
+		ArchivePath: archivePath,
+	}, nil
+}
+
+// Start runs the retention job right away and then once a day.
+// A day it is paused, the records wait for the next run.
+func (p *Pruner) Start(paused func() bool) {
+	ticker := time.NewTicker(pruneInterval)
+
+	go func() {
+		for {
+			if paused() {
+				log.Println("Retention paused by the maintenance mode")
+			} else {
+				p.runAndLog(time.Now())
+			}
+
+			<-ticker.C
+		}
+	}()
+}
+
+func (p *Pruner) runAndLog(now time.Time) {
+	report, err := p.Run(now)
+	if err != nil {
+		log.Println("Retention run failed", err)
+
+		return
+	}
+
+	verb := "Pruned"
+	if report.DryRun {
+		verb = "Dry run, would prune"
+	}
+
+	log.Printf("%s %d aggregates, %d events and %d activities older than %s",
+		verb, len(report.Candidates), report.Events, report.Activities, report.Cutoff.Format(time.RFC3339))
+
+	for _, c := range report.Candidates {
+		log.Printf("%s %s %s: %d events, %d activities, last event at %s",
+			verb, c.Aggregate, c.UID, c.Events, c.Activities, c.LastEventDate.Format(time.RFC3339))
+	}
+}
+
+// Run prunes the histories older than the retention period, counted back from now.
+// In dry run mode it only reports what would be removed.
+func (p *Pruner) Run(now time.Time) (Report, error) {
+	cutoff := now.AddDate(-p.Years, 0, 0)
+
+	candidates, err := p.Store.FindCandidates(cutoff)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{DryRun: p.DryRun, Cutoff: cutoff, Candidates: []Candidate{}}
+
+	if p.DryRun {
+		for _, c := range candidates {
+			report.add(c)
+		}
+
+		return report, nil
+	}
+
+	if len(candidates) == 0 {
+		return report, nil
+	}
+
+	events := []ArchivedEvent{}
+
+	for _, c := range candidates {
+		e, err := p.Store.FindEvents(c)
+		if err != nil {
+			return Report{}, err
+		}
+
+		events = append(events, e...)
+	}
+
+	// Nothing is deleted until the archive is safely written.
+	report.ArchiveFile = filepath.Join(p.ArchivePath, "events-"+now.UTC().Format("20060102T150405Z")+".jsonl.gz")
+
+	err = writeArchive(report.ArchiveFile, events)
+	if err != nil {
+		return Report{}, err
+	}
+
+	for _, c := range candidates {
+		pruned, err := p.Store.Prune(c, report.ArchiveFile, now)
+		if err != nil {
+			return report, err
+		}
+
+		if !pruned {
+			log.Println("Skipped pruning", c.Aggregate, c.UID, "because it changed during the retention run")
+
+			continue
+		}
+
+		report.add(c)
+	}
+
+	return report, nil
+}
+
+func (r *Report) add(c Candidate) {
+	r.Candidates = append(r.Candidates, c)
+	r.Events += c.Events
+	r.Activities += c.Activities
+}
+
+// writeArchive writes the events as gzipped JSON lines.
+func writeArchive(path string, events []ArchivedEvent) error {
+	err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	zw := gzip.NewWriter(file)
+	encoder := json.NewEncoder(zw)
+
+	for _, e := range events {
+		err = encoder.Encode(e)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = zw.Close()
+	if err != nil {
+		return err
+	}
+
+	return file.Sync()
+}
@@ -0,0 +1,159 @@
+package retention_test
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/retention"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+func TestPrunerRun(t *testing.T) {
+	t.Parallel()
+	// Given
+	now := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(-4, 0, 0)
+
+	archivedUID, _ := uuid.NewV4()
+	activeUID, _ := uuid.NewV4()
+	taskUID, _ := uuid.NewV4()
+
+	prunedStorage := retention.CreatePrunedStorage()
+	cropEventStorage := growthstorage.CreateCropEventStorage()
+	cropReadStorage := growthstorage.CreateCropReadStorage()
+	cropActivityStorage := growthstorage.CreateCropActivityStorage()
+	taskEventStorage := taskstorage.CreateTaskEventStorage()
+	taskReadStorage := taskstorage.CreateTaskReadStorage()
+
+	cropReadStorage.CropReadMap[archivedUID] = growthstorage.CropRead{UID: archivedUID, Status: growthdomain.CropArchived}
+	cropReadStorage.CropReadMap[activeUID] = growthstorage.CropRead{UID: activeUID, Status: growthdomain.CropActive}
+	taskReadStorage.TaskReadMap[taskUID] = taskstorage.TaskRead{UID: taskUID, Status: tasksdomain.TaskStatusCompleted}
+
+	cropEventStorage.CropEvents = []growthstorage.CropEvent{
+		{CropUID: archivedUID, Version: 1, CreatedDate: old, Event: growthdomain.CropBatchCreated{UID: archivedUID}},
+		{CropUID: archivedUID, Version: 2, CreatedDate: old, Event: growthdomain.CropBatchHarvested{UID: archivedUID}},
+		{CropUID: activeUID, Version: 1, CreatedDate: old, Event: growthdomain.CropBatchCreated{UID: activeUID}},
+	}
+	cropActivityStorage.CropActivityMap = []growthstorage.CropActivity{{UID: archivedUID}, {UID: activeUID}}
+
+	// The task was completed recently, so it's kept.
+	taskEventStorage.TaskEvents = []taskstorage.TaskEvent{
+		{TaskUID: taskUID, Version: 1, CreatedDate: old, Event: tasksdomain.TaskCreated{UID: taskUID}},
+		{TaskUID: taskUID, Version: 2, CreatedDate: now, Event: tasksdomain.TaskCompleted{UID: taskUID}},
+	}
+
+	store := retention.NewStoreInMemory(
+		prunedStorage,
+		cropEventStorage,
+		cropReadStorage,
+		cropActivityStorage,
+		taskEventStorage,
+		taskReadStorage,
+	)
+
+	archivePath := t.TempDir()
+
+	dryRunPruner, _ := retention.NewPruner(store, 3, true, archivePath)
+	pruner, _ := retention.NewPruner(store, 3, false, archivePath)
+
+	// When
+	dryRunReport, dryRunErr := dryRunPruner.Run(now)
+	eventsAfterDryRun := len(cropEventStorage.CropEvents)
+
+	report, err := pruner.Run(now)
+
+	// Then
+	assert.Nil(t, dryRunErr)
+	assert.True(t, dryRunReport.DryRun)
+	assert.Empty(t, dryRunReport.ArchiveFile)
+	assert.Len(t, dryRunReport.Candidates, 1)
+	assert.Equal(t, 2, dryRunReport.Events)
+	assert.Equal(t, 1, dryRunReport.Activities)
+	assert.Equal(t, 3, eventsAfterDryRun)
+
+	assert.Nil(t, err)
+	assert.Len(t, report.Candidates, 1)
+	assert.Equal(t, archivedUID, report.Candidates[0].UID)
+	assert.Len(t, cropEventStorage.CropEvents, 1)
+	assert.Equal(t, activeUID, cropEventStorage.CropEvents[0].CropUID)
+	assert.Len(t, cropActivityStorage.CropActivityMap, 1)
+	assert.Len(t, taskEventStorage.TaskEvents, 2)
+
+	pruned, _ := store.FindPruned(retention.AggregateCrop, archivedUID)
+	assert.NotNil(t, pruned)
+	assert.Equal(t, report.ArchiveFile, pruned.ArchiveFile)
+
+	notPruned, _ := store.FindPruned(retention.AggregateCrop, activeUID)
+	assert.Nil(t, notPruned)
+
+	file, _ := os.Open(report.ArchiveFile)
+	defer file.Close()
+
+	zr, _ := gzip.NewReader(file)
+	decoder := json.NewDecoder(zr)
+
+	archived := []retention.ArchivedEvent{}
+
+	for decoder.More() {
+		e := retention.ArchivedEvent{}
+		assert.Nil(t, decoder.Decode(&e))
+
+		archived = append(archived, e)
+	}
+
+	assert.Len(t, archived, 2)
+	assert.Equal(t, archivedUID, archived[0].AggregateUID)
+	assert.Contains(t, string(archived[1].Event), `"Name":"CropBatchHarvested"`)
+}
+
+func TestPrunerRunArchivedTask(t *testing.T) {
+	t.Parallel()
+	// Given
+	now := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(-4, 0, 0)
+
+	archivedUID, _ := uuid.NewV4()
+	openUID, _ := uuid.NewV4()
+
+	taskEventStorage := taskstorage.CreateTaskEventStorage()
+	taskReadStorage := taskstorage.CreateTaskReadStorage()
+
+	// The archived task has been moved out of the task reads.
+	taskReadStorage.TaskReadMap[openUID] = taskstorage.TaskRead{UID: openUID, Status: tasksdomain.TaskStatusCreated}
+
+	taskEventStorage.TaskEvents = []taskstorage.TaskEvent{
+		{TaskUID: archivedUID, Version: 1, CreatedDate: old, Event: tasksdomain.TaskCreated{UID: archivedUID}},
+		{TaskUID: archivedUID, Version: 2, CreatedDate: old, Event: tasksdomain.TaskArchived{UID: archivedUID}},
+		{TaskUID: openUID, Version: 1, CreatedDate: old, Event: tasksdomain.TaskCreated{UID: openUID}},
+	}
+
+	store := retention.NewStoreInMemory(
+		retention.CreatePrunedStorage(),
+		growthstorage.CreateCropEventStorage(),
+		growthstorage.CreateCropReadStorage(),
+		growthstorage.CreateCropActivityStorage(),
+		taskEventStorage,
+		taskReadStorage,
+	)
+
+	pruner, _ := retention.NewPruner(store, 3, false, t.TempDir())
+
+	// When
+	report, err := pruner.Run(now)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Len(t, report.Candidates, 1)
+	assert.Equal(t, archivedUID, report.Candidates[0].UID)
+	assert.Equal(t, 2, report.Events)
+	assert.Len(t, taskEventStorage.TaskEvents, 1)
+	assert.Equal(t, openUID, taskEventStorage.TaskEvents[0].TaskUID)
+}
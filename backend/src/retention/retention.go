@@ -0,0 +1,73 @@
+package retention
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	AggregateCrop = "CROP"
+	AggregateTask = "TASK"
+)
+
+// Candidate is an archived aggregate whose whole history is older than the retention period.
+type Candidate struct {
+	Aggregate     string    `json:"aggregate"`
+	UID           uuid.UUID `json:"uid"`
+	Version       int       `json:"version"`
+	LastEventDate time.Time `json:"last_event_date"`
+	Events        int       `json:"events"`
+	Activities    int       `json:"activities"`
+}
+
+// ArchivedEvent is one line of the compressed archive file.
+// Event keeps the stored {Name, Data} wrapper so the archive can be decoded
+// with the same decoders used for the event tables.
+type ArchivedEvent struct {
+	Aggregate    string          `json:"aggregate"`
+	AggregateUID uuid.UUID       `json:"aggregate_uid"`
+	Version      int             `json:"version"`
+	CreatedDate  time.Time       `json:"created_date"`
+	Event        json.RawMessage `json:"event"`
+}
+
+// Pruned marks an aggregate whose history was moved to cold storage.
+type Pruned struct {
+	Aggregate     string    `json:"aggregate"`
+	UID           uuid.UUID `json:"uid"`
+	ArchiveFile   string    `json:"archive_file"`
+	LastEventDate time.Time `json:"last_event_date"`
+	PrunedDate    time.Time `json:"pruned_date"`
+}
+
+// PrunedQuery tells the read endpoints whether a history was pruned.
+// It returns nil when the aggregate still has its history.
+type PrunedQuery interface {
+	FindPruned(aggregate string, uid uuid.UUID) (*Pruned, error)
+}
+
+type Store interface {
+	PrunedQuery
+
+	// FindCandidates returns the archived crops and the completed or cancelled tasks
+	// whose last event happened before the cutoff. Active aggregates are never returned.
+	FindCandidates(cutoff time.Time) ([]Candidate, error)
+	FindEvents(candidate Candidate) ([]ArchivedEvent, error)
+
+	// Prune deletes the events and activities of the candidate and marks it as pruned.
+	// It returns false without deleting anything when the aggregate got new events
+	// after it was selected.
+	Prune(candidate Candidate, archiveFile string, prunedDate time.Time) (bool, error)
+}
+
+// Report is the result of one retention run.
+type Report struct {
+	DryRun      bool        `json:"dry_run"`
+	Cutoff      time.Time   `json:"cutoff"`
+	ArchiveFile string      `json:"archive_file"`
+	Candidates  []Candidate `json:"candidates"`
+	Events      int         `json:"events"`
+	Activities  int         `json:"activities"`
+}
@@ -0,0 +1,32 @@
+package retention
+
+// The candidate queries select the last event of every archived aggregate,
+// together with the number of events and activities it would lose.
+// The archived tasks have been moved out of TASK_READ, only the closed tasks are archived.
+const (
+	cropCandidatesQuery = `SELECT e.CROP_UID, e.VERSION, e.CREATED_DATE,
+			(SELECT COUNT(*) FROM CROP_EVENT WHERE CROP_UID = e.CROP_UID),
+			(SELECT COUNT(*) FROM CROP_ACTIVITY WHERE CROP_UID = e.CROP_UID)
+		FROM CROP_EVENT e
+		JOIN CROP_READ r ON r.UID = e.CROP_UID
+		WHERE r.STATUS = ?
+		AND e.VERSION = (SELECT MAX(VERSION) FROM CROP_EVENT WHERE CROP_UID = e.CROP_UID)`
+
+	taskCandidatesQuery = `SELECT e.TASK_UID, e.VERSION, e.CREATED_DATE,
+			(SELECT COUNT(*) FROM TASK_EVENT WHERE TASK_UID = e.TASK_UID),
+			0
+		FROM TASK_EVENT e
+		LEFT JOIN TASK_READ r ON r.UID = e.TASK_UID
+		WHERE (r.UID IS NULL OR r.STATUS IN (?, ?))
+		AND e.VERSION = (SELECT MAX(VERSION) FROM TASK_EVENT WHERE TASK_UID = e.TASK_UID)`
+)
+
+// historyTables returns the tables holding the history of the aggregate.
+// Tasks have no activity table.
+func historyTables(aggregate string) (eventTable, uidColumn, activityTable string) {
+	if aggregate == AggregateCrop {
+		return "CROP_EVENT", "CROP_UID", "CROP_ACTIVITY"
+	}
+
+	return "TASK_EVENT", "TASK_UID", ""
+}
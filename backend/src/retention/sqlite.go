@@ -0,0 +1,191 @@
+package retention
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+type StoreSqlite struct {
+	DB *sql.DB
+}
+
+func NewStoreSqlite(db *sql.DB) Store {
+	return &StoreSqlite{DB: db}
+}
+
+func (s *StoreSqlite) FindCandidates(cutoff time.Time) ([]Candidate, error) {
+	crops, err := s.findCandidates(AggregateCrop, cutoff, cropCandidatesQuery, growthdomain.CropArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.findCandidates(AggregateTask, cutoff, taskCandidatesQuery,
+		tasksdomain.TaskStatusCompleted, tasksdomain.TaskStatusCancelled)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(crops, tasks...), nil
+}
+
+func (s *StoreSqlite) findCandidates(aggregate string, cutoff time.Time, query string, args ...interface{}) ([]Candidate, error) {
+	rows, err := s.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := []Candidate{}
+
+	for rows.Next() {
+		var uid, createdDate string
+
+		c := Candidate{Aggregate: aggregate}
+
+		err = rows.Scan(&uid, &c.Version, &createdDate, &c.Events, &c.Activities)
+		if err != nil {
+			return nil, err
+		}
+
+		c.UID, err = uuid.FromString(uid)
+		if err != nil {
+			return nil, err
+		}
+
+		c.LastEventDate, err = time.Parse(time.RFC3339, createdDate)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.LastEventDate.Before(cutoff) {
+			candidates = append(candidates, c)
+		}
+	}
+
+	return candidates, rows.Err()
+}
+
+func (s *StoreSqlite) FindEvents(candidate Candidate) ([]ArchivedEvent, error) {
+	eventTable, uidColumn, _ := historyTables(candidate.Aggregate)
+
+	rows, err := s.DB.Query(`SELECT VERSION, CREATED_DATE, EVENT FROM `+eventTable+`
+		WHERE `+uidColumn+` = ? ORDER BY VERSION ASC`, candidate.UID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []ArchivedEvent{}
+
+	for rows.Next() {
+		var (
+			createdDate string
+			event       []byte
+		)
+
+		e := ArchivedEvent{Aggregate: candidate.Aggregate, AggregateUID: candidate.UID}
+
+		err = rows.Scan(&e.Version, &createdDate, &event)
+		if err != nil {
+			return nil, err
+		}
+
+		e.Event = event
+
+		e.CreatedDate, err = time.Parse(time.RFC3339, createdDate)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+func (s *StoreSqlite) Prune(candidate Candidate, archiveFile string, prunedDate time.Time) (bool, error) {
+	eventTable, uidColumn, activityTable := historyTables(candidate.Aggregate)
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM `+eventTable+` WHERE `+uidColumn+` = ? AND VERSION <= ?`,
+		candidate.UID, candidate.Version)
+	if err != nil {
+		tx.Rollback()
+
+		return false, err
+	}
+
+	remaining := 0
+
+	err = tx.QueryRow(`SELECT COUNT(*) FROM `+eventTable+` WHERE `+uidColumn+` = ?`, candidate.UID).Scan(&remaining)
+	if err != nil {
+		tx.Rollback()
+
+		return false, err
+	}
+
+	if remaining > 0 {
+		return false, tx.Rollback()
+	}
+
+	if activityTable != "" {
+		_, err = tx.Exec(`DELETE FROM `+activityTable+` WHERE CROP_UID = ?`, candidate.UID)
+		if err != nil {
+			tx.Rollback()
+
+			return false, err
+		}
+	}
+
+	_, err = tx.Exec(`INSERT INTO PRUNED_AGGREGATE (AGGREGATE, UID, ARCHIVE_FILE, LAST_EVENT_DATE, PRUNED_DATE)
+		VALUES (?, ?, ?, ?, ?)`,
+		candidate.Aggregate,
+		candidate.UID,
+		archiveFile,
+		candidate.LastEventDate.Format(time.RFC3339),
+		prunedDate.Format(time.RFC3339))
+	if err != nil {
+		tx.Rollback()
+
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func (s *StoreSqlite) FindPruned(aggregate string, uid uuid.UUID) (*Pruned, error) {
+	var lastEventDate, prunedDate string
+
+	p := Pruned{Aggregate: aggregate, UID: uid}
+
+	err := s.DB.QueryRow(`SELECT ARCHIVE_FILE, LAST_EVENT_DATE, PRUNED_DATE FROM PRUNED_AGGREGATE
+		WHERE AGGREGATE = ? AND UID = ?`, aggregate, uid).Scan(&p.ArchiveFile, &lastEventDate, &prunedDate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.LastEventDate, err = time.Parse(time.RFC3339, lastEventDate)
+	if err != nil {
+		return nil, err
+	}
+
+	p.PrunedDate, err = time.Parse(time.RFC3339, prunedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
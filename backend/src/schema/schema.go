@@ -0,0 +1,277 @@
+// Package schema brings the tables of an existing database up to the CREATE TABLE statements of its DDL file,
+// which only create the missing tables. The columns added to a table since the database was created are added
+// in the order of the DDL, as the read queries scan their rows with SELECT * in that order.
+package schema
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Column is a column of a table of the DDL, with its type and constraints.
+type Column struct {
+	Name       string
+	Definition string
+}
+
+// Table is a CREATE TABLE IF NOT EXISTS statement of the DDL, with the quote of its names.
+type Table struct {
+	Name    string
+	Quote   string
+	Create  string
+	Columns []Column
+}
+
+var (
+	createTableRegex  = regexp.MustCompile("(?s)CREATE TABLE IF NOT EXISTS ([\"`])(\\w+)[\"`] \\((.*?)\\n\\)")
+	columnRegex       = regexp.MustCompile("^[\"`](\\w+)[\"`]\\s+(.+?),?$")
+	mysqlCharsetRegex = regexp.MustCompile(`DEFAULT CHARSET=\w+ COLLATE=\w+`)
+)
+
+// Parse reads the tables of the DDL, their names are quoted with " for SQLite and ` for MySQL.
+func Parse(ddl string) []Table {
+	tables := []Table{}
+
+	for _, match := range createTableRegex.FindAllStringSubmatch(ddl, -1) {
+		table := Table{Quote: match[1], Name: match[2], Create: match[0]}
+
+		for _, line := range strings.Split(match[3], "\n") {
+			column := columnRegex.FindStringSubmatch(strings.TrimSpace(line))
+			if column != nil {
+				table.Columns = append(table.Columns, Column{Name: column[1], Definition: column[2]})
+			}
+		}
+
+		tables = append(tables, table)
+	}
+
+	return tables
+}
+
+func (t Table) quote(name string) string {
+	return t.Quote + name + t.Quote
+}
+
+// missing are the indexes of the columns of the table which don't exist yet.
+func (t Table) missing(existing []string) []int {
+	found := map[string]bool{}
+	for _, v := range existing {
+		found[strings.ToUpper(v)] = true
+	}
+
+	missing := []int{}
+
+	for i, v := range t.Columns {
+		if !found[v.Name] {
+			missing = append(missing, i)
+		}
+	}
+
+	return missing
+}
+
+// SqliteSteps are the statements adding the missing columns to the SQLite table with the existing columns,
+// none for a table which doesn't exist yet. SQLite only adds a column last, so a table missing a column
+// before the existing ones, or missing a key, is rebuilt from the DDL with its rows copied.
+func SqliteSteps(table Table, existing []string) []string {
+	missing := table.missing(existing)
+	if len(existing) == 0 || len(missing) == 0 {
+		return nil
+	}
+
+	appended := missing[0] == len(existing) && len(existing)+len(missing) == len(table.Columns)
+
+	for _, i := range missing {
+		definition := strings.ToUpper(table.Columns[i].Definition)
+		if strings.Contains(definition, "PRIMARY KEY") || strings.Contains(definition, "UNIQUE") {
+			appended = false
+		}
+	}
+
+	if appended {
+		steps := []string{}
+
+		for _, i := range missing {
+			steps = append(steps, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s",
+				table.quote(table.Name), table.quote(table.Columns[i].Name), table.Columns[i].Definition))
+		}
+
+		return steps
+	}
+
+	rebuilt := table.Name + "_MIGRATION"
+	copied := []string{}
+	found := map[int]bool{}
+
+	for _, i := range missing {
+		found[i] = true
+	}
+
+	for i, v := range table.Columns {
+		if !found[i] {
+			copied = append(copied, table.quote(v.Name))
+		}
+	}
+
+	return []string{
+		fmt.Sprintf("DROP TABLE IF EXISTS %s", table.quote(rebuilt)),
+		strings.Replace(table.Create, table.quote(table.Name), table.quote(rebuilt), 1),
+		fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s", table.quote(rebuilt),
+			strings.Join(copied, ", "), strings.Join(copied, ", "), table.quote(table.Name)),
+		fmt.Sprintf("DROP TABLE %s", table.quote(table.Name)),
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", table.quote(rebuilt), table.quote(table.Name)),
+	}
+}
+
+// MysqlSteps are the statements adding the missing columns to the MySQL table with the existing columns,
+// none for a table which doesn't exist yet. Each column is added after the one before it in the DDL.
+func MysqlSteps(table Table, existing []string) []string {
+	missing := table.missing(existing)
+	if len(existing) == 0 || len(missing) == 0 {
+		return nil
+	}
+
+	steps := []string{}
+
+	for _, i := range missing {
+		position := "FIRST"
+		if i > 0 {
+			position = "AFTER " + table.quote(table.Columns[i-1].Name)
+		}
+
+		steps = append(steps, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s %s",
+			table.quote(table.Name), table.quote(table.Columns[i].Name), table.Columns[i].Definition, position))
+	}
+
+	return steps
+}
+
+// MigrateSqlite adds the missing columns of the tables of the DDL to the SQLite database, in one transaction.
+// It runs before the DDL, so the indexes of the DDL find their columns.
+func MigrateSqlite(db *sql.DB, ddl string) error {
+	steps := []string{}
+
+	for _, table := range Parse(ddl) {
+		existing, err := columns(db, `SELECT name FROM pragma_table_info(?)`, table.Name)
+		if err != nil {
+			return err
+		}
+
+		steps = append(steps, SqliteSteps(table, existing)...)
+	}
+
+	return run(db, steps)
+}
+
+// MigrateMysql adds the missing columns of the tables of the DDL to the MySQL database. It runs before the DDL,
+// so the indexes of the DDL find their columns.
+func MigrateMysql(db *sql.DB, ddl string) error {
+	steps := []string{}
+
+	for _, table := range Parse(ddl) {
+		existing, err := columns(db, `SELECT COLUMN_NAME FROM information_schema.COLUMNS
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION`, table.Name)
+		if err != nil {
+			return err
+		}
+
+		steps = append(steps, MysqlSteps(table, existing)...)
+	}
+
+	// The DDL statements of MySQL commit themselves, a transaction wouldn't roll them back.
+	for _, v := range steps {
+		_, err := db.Exec(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", v, err)
+		}
+	}
+
+	return nil
+}
+
+// MysqlDDL sets the charset and the collation of the tables created by the MySQL DDL.
+func MysqlDDL(ddl, charset, collation string) string {
+	return mysqlCharsetRegex.ReplaceAllString(ddl, "DEFAULT CHARSET="+charset+" COLLATE="+collation)
+}
+
+// MysqlConvertStep is the statement converting the MySQL table with the current collation to the charset
+// and the collation, none for a table which doesn't exist yet or already has them.
+func MysqlConvertStep(table Table, current, charset, collation string) string {
+	if current == "" || strings.EqualFold(current, collation) {
+		return ""
+	}
+
+	return fmt.Sprintf("ALTER TABLE %s CONVERT TO CHARACTER SET %s COLLATE %s",
+		table.quote(table.Name), charset, collation)
+}
+
+// MysqlConversions are the statements converting the tables of the DDL created with another collation,
+// by an older DDL or by the default charset of MySQL < 8.0 which can't store every language.
+func MysqlConversions(db *sql.DB, ddl, charset, collation string) ([]string, error) {
+	steps := []string{}
+
+	for _, table := range Parse(ddl) {
+		current := sql.NullString{}
+
+		err := db.QueryRow(`SELECT TABLE_COLLATION FROM information_schema.TABLES
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?`, table.Name).Scan(&current)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+
+		if step := MysqlConvertStep(table, current.String, charset, collation); step != "" {
+			steps = append(steps, step)
+		}
+	}
+
+	return steps, nil
+}
+
+func columns(db *sql.DB, query, table string) ([]string, error) {
+	rows, err := db.Query(query, table)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	names := []string{}
+
+	for rows.Next() {
+		name := ""
+
+		err = rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+func run(db *sql.DB, steps []string) error {
+	if len(steps) == 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, v := range steps {
+		_, err = tx.Exec(v)
+		if err != nil {
+			tx.Rollback()
+
+			return fmt.Errorf("%s: %w", v, err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,213 @@
+package schema_test
+
+import (
+	"database/sql"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/schema"
+)
+
+const sqliteDDL = `CREATE TABLE IF NOT EXISTS "TASK_READ" (
+    "UID" BLOB PRIMARY KEY,
+    "TITLE" TEXT,
+    "SHORT_CODE" TEXT,
+    "SHORT_CODE_FARM_UID" BLOB
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "TASK_READ_SHORT_CODE_UNIQUE_INDEX"
+    ON "TASK_READ" ("SHORT_CODE_FARM_UID", "SHORT_CODE");
+
+CREATE TABLE IF NOT EXISTS "TASK_ARCHIVE" (
+    "UID" BLOB PRIMARY KEY,
+    "TITLE" TEXT,
+    "SHORT_CODE_FARM_UID" BLOB,
+    "ARCHIVED_DATE" TEXT
+);
+`
+
+const mysqlDDL = "CREATE TABLE IF NOT EXISTS `TASK_READ` (\n" +
+	"    `UID` BINARY(16) PRIMARY KEY,\n" +
+	"    `TITLE` TEXT,\n" +
+	"    `SHORT_CODE` VARCHAR(20),\n" +
+	"    `SHORT_CODE_FARM_UID` BINARY(16)\n" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;\n"
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	// When
+	tables := Parse(mysqlDDL)
+
+	// Then
+	assert.Len(t, tables, 1)
+	assert.Equal(t, "TASK_READ", tables[0].Name)
+	assert.Equal(t, "`", tables[0].Quote)
+	assert.Equal(t, []Column{
+		{Name: "UID", Definition: "BINARY(16) PRIMARY KEY"},
+		{Name: "TITLE", Definition: "TEXT"},
+		{Name: "SHORT_CODE", Definition: "VARCHAR(20)"},
+		{Name: "SHORT_CODE_FARM_UID", Definition: "BINARY(16)"},
+	}, tables[0].Columns)
+}
+
+func TestSteps(t *testing.T) {
+	t.Parallel()
+	// Given
+	tables := Parse(sqliteDDL)
+	mysqlTable := Parse(mysqlDDL)[0]
+
+	// When
+	created := SqliteSteps(tables[0], nil)
+	current := SqliteSteps(tables[0], []string{"UID", "TITLE", "SHORT_CODE", "SHORT_CODE_FARM_UID"})
+	appended := SqliteSteps(tables[0], []string{"UID", "TITLE"})
+	rebuilt := SqliteSteps(tables[1], []string{"UID", "TITLE", "ARCHIVED_DATE"})
+	mysql := MysqlSteps(mysqlTable, []string{"UID", "SHORT_CODE"})
+
+	// Then
+	assert.Empty(t, created)
+	assert.Empty(t, current)
+	assert.Equal(t, []string{
+		`ALTER TABLE "TASK_READ" ADD COLUMN "SHORT_CODE" TEXT`,
+		`ALTER TABLE "TASK_READ" ADD COLUMN "SHORT_CODE_FARM_UID" BLOB`,
+	}, appended)
+	assert.Len(t, rebuilt, 5)
+	assert.Contains(t, rebuilt[1], `CREATE TABLE IF NOT EXISTS "TASK_ARCHIVE_MIGRATION" (`)
+	assert.Equal(t, `INSERT INTO "TASK_ARCHIVE_MIGRATION" ("UID", "TITLE", "ARCHIVED_DATE") `+
+		`SELECT "UID", "TITLE", "ARCHIVED_DATE" FROM "TASK_ARCHIVE"`, rebuilt[2])
+	assert.Equal(t, []string{
+		"ALTER TABLE `TASK_READ` ADD COLUMN `TITLE` TEXT AFTER `UID`",
+		"ALTER TABLE `TASK_READ` ADD COLUMN `SHORT_CODE_FARM_UID` BINARY(16) AFTER `SHORT_CODE`",
+	}, mysql)
+}
+
+func TestMigrateSqlite(t *testing.T) {
+	t.Parallel()
+	// Given
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE "TASK_READ" ("UID" BLOB PRIMARY KEY, "TITLE" TEXT);
+		CREATE TABLE "TASK_ARCHIVE" ("UID" BLOB PRIMARY KEY, "TITLE" TEXT, "ARCHIVED_DATE" TEXT);
+		INSERT INTO "TASK_READ" VALUES ('t1', 'Water');
+		INSERT INTO "TASK_ARCHIVE" VALUES ('t2', 'Weed', '2024-03-01')`)
+	assert.Nil(t, err)
+
+	// When
+	err = MigrateSqlite(db, sqliteDDL)
+	assert.Nil(t, err)
+
+	_, err = db.Exec(sqliteDDL)
+	assert.Nil(t, err)
+
+	// Then
+	var uid, title, archivedDate string
+
+	var shortCode, farmUID sql.NullString
+
+	err = db.QueryRow(`SELECT * FROM TASK_READ`).Scan(&uid, &title, &shortCode, &farmUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Water", title)
+	assert.False(t, shortCode.Valid)
+
+	err = db.QueryRow(`SELECT * FROM TASK_ARCHIVE`).Scan(&uid, &title, &farmUID, &archivedDate)
+	assert.Nil(t, err)
+	assert.Equal(t, "t2", uid)
+	assert.Equal(t, "2024-03-01", archivedDate)
+	assert.Nil(t, MigrateSqlite(db, sqliteDDL))
+}
+
+func TestMysqlDDL(t *testing.T) {
+	t.Parallel()
+	// Given
+	table := Parse(mysqlDDL)[0]
+
+	// When
+	ddl := MysqlDDL(mysqlDDL, "utf8mb4", "utf8mb4_0900_ai_ci")
+	converted := MysqlConvertStep(table, "latin1_swedish_ci", "utf8mb4", "utf8mb4_unicode_ci")
+	current := MysqlConvertStep(table, "utf8mb4_unicode_ci", "utf8mb4", "utf8mb4_unicode_ci")
+	created := MysqlConvertStep(table, "", "utf8mb4", "utf8mb4_unicode_ci")
+
+	// Then
+	assert.Contains(t, ddl, ") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;")
+	assert.NotContains(t, ddl, "utf8mb4_unicode_ci")
+	assert.Equal(t, "ALTER TABLE `TASK_READ` CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci", converted)
+	assert.Empty(t, current)
+	assert.Empty(t, created)
+}
+
+// maxMysqlKeyBytes is the longest column of an index of InnoDB with the COMPACT row format of MySQL < 5.7.
+const maxMysqlKeyBytes = 767
+
+func TestMysqlDDLKeysFitInnoDB(t *testing.T) {
+	t.Parallel()
+	// Given
+	keyRegex := regexp.MustCompile("(?:CREATE (?:UNIQUE )?INDEX `\\w+` ON `(\\w+)`|PRIMARY KEY) ?\\(([^)]+)\\)")
+	lengthRegex := regexp.MustCompile(`^(VAR)?(CHAR|BINARY)\((\d+)\)`)
+
+	for _, path := range []string{"../../database/mysql/ddl.sql", "../../database/mysql/archive_ddl.sql"} {
+		ddl, err := os.ReadFile(path)
+		assert.Nil(t, err)
+
+		columns := map[string]string{}
+
+		for _, table := range Parse(string(ddl)) {
+			for _, column := range table.Columns {
+				columns[table.Name+"."+column.Name] = column.Definition
+
+				if strings.Contains(column.Definition, "PRIMARY KEY") {
+					columns[table.Name+".key"] = column.Name
+				}
+			}
+
+			// When
+			for _, match := range keyRegex.FindAllStringSubmatch(table.Create, -1) {
+				for _, name := range strings.Split(match[2], ",") {
+					checkKeyLength(t, lengthRegex, table.Name, strings.Trim(name, " `"), columns)
+				}
+			}
+
+			if name, ok := columns[table.Name+".key"]; ok {
+				checkKeyLength(t, lengthRegex, table.Name, name, columns)
+			}
+		}
+
+		for _, match := range keyRegex.FindAllStringSubmatch(string(ddl), -1) {
+			if match[1] == "" {
+				continue
+			}
+
+			for _, name := range strings.Split(match[2], ",") {
+				checkKeyLength(t, lengthRegex, match[1], strings.Trim(name, " `"), columns)
+			}
+		}
+	}
+}
+
+// checkKeyLength checks the bytes of the indexed column, 4 per character of utf8mb4.
+func checkKeyLength(t *testing.T, lengthRegex *regexp.Regexp, table, name string, columns map[string]string) {
+	t.Helper()
+
+	length := lengthRegex.FindStringSubmatch(columns[table+"."+name])
+	if length == nil {
+		return
+	}
+
+	size, err := strconv.Atoi(length[3])
+	assert.Nil(t, err)
+
+	if length[2] == "CHAR" {
+		size *= 4
+	}
+
+	// Then
+	assert.LessOrEqual(t, size, maxMysqlKeyBytes, "the key %s.%s is too long for InnoDB", table, name)
+}
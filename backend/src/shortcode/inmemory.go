@@ -0,0 +1,29 @@
+package shortcode
+
+import (
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type GeneratorInMemory struct {
+	Lock      *sync.Mutex
+	Sequences map[string]int
+}
+
+func NewGeneratorInMemory() Generator {
+	return &GeneratorInMemory{
+		Lock:      &sync.Mutex{},
+		Sequences: make(map[string]int),
+	}
+}
+
+func (g *GeneratorInMemory) Next(prefix string, scope uuid.UUID) (string, error) {
+	g.Lock.Lock()
+	defer g.Lock.Unlock()
+
+	key := prefix + "/" + scope.String()
+	g.Sequences[key]++
+
+	return Format(prefix, g.Sequences[key]), nil
+}
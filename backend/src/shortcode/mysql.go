@@ -0,0 +1,50 @@
+package shortcode
+
+import (
+	"database/sql"
+
+	"github.com/gofrs/uuid"
+)
+
+type GeneratorMysql struct {
+	DB *sql.DB
+}
+
+func NewGeneratorMysql(db *sql.DB) Generator {
+	return &GeneratorMysql{DB: db}
+}
+
+func (g *GeneratorMysql) Next(prefix string, scope uuid.UUID) (string, error) {
+	tx, err := g.DB.Begin()
+	if err != nil {
+		return "", err
+	}
+
+	// The upsert locks the sequence row until the transaction ends,
+	// so concurrent requests can't read the same value.
+	_, err = tx.Exec(`INSERT INTO SHORT_CODE_SEQUENCE (PREFIX, SCOPE_UID, LAST_VALUE)
+		VALUES (?, ?, 1)
+		ON DUPLICATE KEY UPDATE LAST_VALUE = LAST_VALUE + 1`, prefix, scope.Bytes())
+	if err != nil {
+		tx.Rollback()
+
+		return "", err
+	}
+
+	sequence := 0
+
+	err = tx.QueryRow(`SELECT LAST_VALUE FROM SHORT_CODE_SEQUENCE
+		WHERE PREFIX = ? AND SCOPE_UID = ?`, prefix, scope.Bytes()).Scan(&sequence)
+	if err != nil {
+		tx.Rollback()
+
+		return "", err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return "", err
+	}
+
+	return Format(prefix, sequence), nil
+}
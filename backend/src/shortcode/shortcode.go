@@ -0,0 +1,54 @@
+// Package shortcode hands out short human friendly codes, such as T-1042,
+// that can be used in place of an entity's UUID.
+package shortcode
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	CropPrefix = "C"
+	TaskPrefix = "T"
+
+	// TransferCertificatePrefix numbers the transfer certificates of the crops of a farm.
+	TransferCertificatePrefix = "TC"
+
+	// HarvestLotPrefix numbers the lots filled at the harvests of a farm.
+	HarvestLotPrefix = "L"
+)
+
+// GlobalScope is used for entities that don't belong to a farm.
+var GlobalScope = uuid.UUID{}
+
+var shortCodeRegex = regexp.MustCompile(`^[A-Z]+-[0-9]+$`)
+
+// Generator returns the next code of a prefix within a scope.
+// A code is never handed out twice in the same scope,
+// so implementations must allocate the sequence atomically.
+type Generator interface {
+	Next(prefix string, scope uuid.UUID) (string, error)
+}
+
+func Format(prefix string, sequence int) string {
+	return fmt.Sprintf("%s-%d", prefix, sequence)
+}
+
+// Normalize uppercases the code so `c-387` is accepted as `C-387`.
+func Normalize(value string) string {
+	return strings.ToUpper(strings.TrimSpace(value))
+}
+
+// IsShortCode reports whether the value is a short code with the given prefix.
+func IsShortCode(prefix, value string) bool {
+	value = Normalize(value)
+
+	if !shortCodeRegex.MatchString(value) {
+		return false
+	}
+
+	return strings.HasPrefix(value, prefix+"-")
+}
@@ -0,0 +1,41 @@
+package shortcode_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/shortcode"
+)
+
+func TestGeneratorInMemory(t *testing.T) {
+	t.Parallel()
+	// Given
+	generator := shortcode.NewGeneratorInMemory()
+	farmUID, _ := uuid.NewV4()
+	otherFarmUID, _ := uuid.NewV4()
+
+	// When
+	first, _ := generator.Next(shortcode.CropPrefix, farmUID)
+	second, _ := generator.Next(shortcode.CropPrefix, farmUID)
+	other, _ := generator.Next(shortcode.CropPrefix, otherFarmUID)
+	task, _ := generator.Next(shortcode.TaskPrefix, farmUID)
+
+	// Then
+	assert.Equal(t, "C-1", first)
+	assert.Equal(t, "C-2", second)
+	assert.Equal(t, "C-1", other)
+	assert.Equal(t, "T-1", task)
+}
+
+func TestIsShortCode(t *testing.T) {
+	t.Parallel()
+	// Given
+	// When
+	// Then
+	assert.True(t, shortcode.IsShortCode(shortcode.TaskPrefix, "T-1042"))
+	assert.True(t, shortcode.IsShortCode(shortcode.CropPrefix, " c-387"))
+	assert.False(t, shortcode.IsShortCode(shortcode.CropPrefix, "T-1042"))
+	assert.False(t, shortcode.IsShortCode(shortcode.TaskPrefix, "T-"))
+	assert.False(t, shortcode.IsShortCode(shortcode.TaskPrefix, "8c7c0f5e-0a5e-4b1d-9b1e-9b1d0a5e4b1d"))
+}
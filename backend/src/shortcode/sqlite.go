@@ -0,0 +1,69 @@
+package shortcode
+
+import (
+	"database/sql"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type GeneratorSqlite struct {
+	DB   *sql.DB
+	Lock *sync.Mutex
+}
+
+func NewGeneratorSqlite(db *sql.DB) Generator {
+	return &GeneratorSqlite{DB: db, Lock: &sync.Mutex{}}
+}
+
+func (g *GeneratorSqlite) Next(prefix string, scope uuid.UUID) (string, error) {
+	g.Lock.Lock()
+	defer g.Lock.Unlock()
+
+	tx, err := g.DB.Begin()
+	if err != nil {
+		return "", err
+	}
+
+	res, err := tx.Exec(`UPDATE SHORT_CODE_SEQUENCE SET LAST_VALUE = LAST_VALUE + 1
+		WHERE PREFIX = ? AND SCOPE_UID = ?`, prefix, scope)
+	if err != nil {
+		tx.Rollback()
+
+		return "", err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+
+		return "", err
+	}
+
+	if rowsAffected == 0 {
+		_, err = tx.Exec(`INSERT INTO SHORT_CODE_SEQUENCE (PREFIX, SCOPE_UID, LAST_VALUE)
+			VALUES (?, ?, 1)`, prefix, scope)
+		if err != nil {
+			tx.Rollback()
+
+			return "", err
+		}
+	}
+
+	sequence := 0
+
+	err = tx.QueryRow(`SELECT LAST_VALUE FROM SHORT_CODE_SEQUENCE
+		WHERE PREFIX = ? AND SCOPE_UID = ?`, prefix, scope).Scan(&sequence)
+	if err != nil {
+		tx.Rollback()
+
+		return "", err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return "", err
+	}
+
+	return Format(prefix, sequence), nil
+}
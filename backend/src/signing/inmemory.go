@@ -0,0 +1,44 @@
+package signing
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/sasha-s/go-deadlock"
+)
+
+// KeyStorage keeps the signing keys by farm.
+type KeyStorage struct {
+	Lock   *deadlock.RWMutex
+	KeyMap map[uuid.UUID]Key
+}
+
+func CreateKeyStorage() *KeyStorage {
+	return &KeyStorage{Lock: &deadlock.RWMutex{}, KeyMap: map[uuid.UUID]Key{}}
+}
+
+type StoreInMemory struct {
+	Storage *KeyStorage
+}
+
+func NewStoreInMemory(s *KeyStorage) Store {
+	return &StoreInMemory{Storage: s}
+}
+
+func (s *StoreInMemory) Save(key Key) error {
+	s.Storage.Lock.Lock()
+	defer s.Storage.Lock.Unlock()
+
+	if _, ok := s.Storage.KeyMap[key.FarmUID]; !ok {
+		s.Storage.KeyMap[key.FarmUID] = key
+	}
+
+	return nil
+}
+
+func (s *StoreInMemory) FindByFarm(farmUID uuid.UUID) (Key, bool, error) {
+	s.Storage.Lock.RLock()
+	defer s.Storage.Lock.RUnlock()
+
+	key, ok := s.Storage.KeyMap[farmUID]
+
+	return key, ok, nil
+}
@@ -0,0 +1,58 @@
+package signing
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type StoreMysql struct {
+	DB *sql.DB
+}
+
+func NewStoreMysql(db *sql.DB) Store {
+	return &StoreMysql{DB: db}
+}
+
+func (s *StoreMysql) Save(key Key) error {
+	privateKey, err := encodePrivateKey(key.PrivateKey)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.DB.Exec(`INSERT IGNORE INTO FARM_SIGNING_KEY (FARM_UID, PRIVATE_KEY, CREATED_DATE)
+		VALUES (?, ?, ?)`,
+		key.FarmUID.Bytes(),
+		privateKey,
+		key.CreatedDate)
+
+	return err
+}
+
+func (s *StoreMysql) FindByFarm(farmUID uuid.UUID) (Key, bool, error) {
+	var (
+		privateKey  string
+		createdDate time.Time
+	)
+
+	err := s.DB.QueryRow(`SELECT PRIVATE_KEY, CREATED_DATE FROM FARM_SIGNING_KEY WHERE FARM_UID = ?`,
+		farmUID.Bytes()).Scan(&privateKey, &createdDate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Key{}, false, nil
+	}
+
+	if err != nil {
+		return Key{}, false, err
+	}
+
+	key := Key{FarmUID: farmUID, CreatedDate: createdDate}
+
+	key.PrivateKey, err = decodePrivateKey(privateKey)
+	if err != nil {
+		return Key{}, false, err
+	}
+
+	return key, true, nil
+}
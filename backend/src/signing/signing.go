@@ -0,0 +1,182 @@
+// Package signing signs the documents a farm issues, like the transfer certificates of its crops, with a key of
+// its own. The key is an ECDSA P-256 key created the first time the farm signs something.
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Algorithm is the algorithm of the signatures, ECDSA on the P-256 curve over the SHA-256 digest of the payload.
+const Algorithm = "ECDSA-P256-SHA256"
+
+// Key is the signing key of a farm.
+type Key struct {
+	FarmUID     uuid.UUID
+	PrivateKey  *ecdsa.PrivateKey
+	CreatedDate time.Time
+}
+
+// Fingerprint is the SHA-256 digest of the public key in its PKIX form, its first 16 bytes in hexadecimal,
+// enough to tell the keys apart on a printed document.
+func (k Key) Fingerprint() (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(&k.PrivateKey.PublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	digest := sha256.Sum256(der)
+	groups := []string{}
+
+	for i := 0; i < 16; i += 2 {
+		groups = append(groups, hex.EncodeToString(digest[i:i+2]))
+	}
+
+	return strings.ToUpper(strings.Join(groups, ":")), nil
+}
+
+// PublicKeyPEM is the public key the signatures of the farm are verified with.
+func (k Key) PublicKeyPEM() (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(&k.PrivateKey.PublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+type Store interface {
+	// Save adds the key of the farm, unless the farm has one already.
+	Save(key Key) error
+	// FindByFarm returns the key of the farm, false when it has none yet.
+	FindByFarm(farmUID uuid.UUID) (Key, bool, error)
+}
+
+// Signature is the signature of a payload with the key of a farm.
+type Signature struct {
+	Algorithm      string `json:"algorithm"`
+	KeyFingerprint string `json:"key_fingerprint"`
+	// Value is the ASN.1 signature in base64.
+	Value string `json:"value"`
+}
+
+// Signer signs the payloads with the keys of the farms of its store.
+type Signer struct {
+	Store Store
+
+	lock sync.Mutex
+}
+
+func NewSigner(store Store) *Signer {
+	return &Signer{Store: store}
+}
+
+// Key returns the key of the farm, which is created the first time.
+func (s *Signer) Key(farmUID uuid.UUID) (Key, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key, found, err := s.Store.FindByFarm(farmUID)
+	if err != nil {
+		return Key{}, err
+	}
+
+	if found {
+		return key, nil
+	}
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return Key{}, err
+	}
+
+	err = s.Store.Save(Key{FarmUID: farmUID, PrivateKey: privateKey, CreatedDate: time.Now()})
+	if err != nil {
+		return Key{}, err
+	}
+
+	// Another server sharing the database may have saved its key first, the one saved is the key of the farm.
+	key, found, err = s.Store.FindByFarm(farmUID)
+	if err != nil {
+		return Key{}, err
+	}
+
+	if !found {
+		return Key{}, errors.New("signing key of the farm wasn't saved")
+	}
+
+	return key, nil
+}
+
+// Sign signs the payload with the key of the farm.
+func (s *Signer) Sign(farmUID uuid.UUID, payload []byte) (Signature, error) {
+	key, err := s.Key(farmUID)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	digest := sha256.Sum256(payload)
+
+	value, err := ecdsa.SignASN1(rand.Reader, key.PrivateKey, digest[:])
+	if err != nil {
+		return Signature{}, err
+	}
+
+	fingerprint, err := key.Fingerprint()
+	if err != nil {
+		return Signature{}, err
+	}
+
+	return Signature{
+		Algorithm:      Algorithm,
+		KeyFingerprint: fingerprint,
+		Value:          base64.StdEncoding.EncodeToString(value),
+	}, nil
+}
+
+// Verify tells whether the signature is the one of the payload with the private key of the public key.
+func Verify(publicKey *ecdsa.PublicKey, payload []byte, signature Signature) bool {
+	if signature.Algorithm != Algorithm {
+		return false
+	}
+
+	value, err := base64.StdEncoding.DecodeString(signature.Value)
+	if err != nil {
+		return false
+	}
+
+	digest := sha256.Sum256(payload)
+
+	return ecdsa.VerifyASN1(publicKey, digest[:], value)
+}
+
+// encodePrivateKey is the PEM form the private keys are stored in.
+func encodePrivateKey(privateKey *ecdsa.PrivateKey) (string, error) {
+	der, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})), nil
+}
+
+func decodePrivateKey(value string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(value))
+	if block == nil {
+		return nil, errors.New("signing key isn't PEM encoded")
+	}
+
+	return x509.ParseECPrivateKey(block.Bytes)
+}
@@ -0,0 +1,39 @@
+package signing_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/signing"
+)
+
+func TestSignWithTheKeyOfTheFarm(t *testing.T) {
+	t.Parallel()
+	// Given
+	signer := NewSigner(NewStoreInMemory(CreateKeyStorage()))
+	farmUID, _ := uuid.NewV4()
+	otherFarmUID, _ := uuid.NewV4()
+	payload := []byte("Certificate TC-0001\nBatch tom-1-jan-2024")
+
+	// When
+	signature, err := signer.Sign(farmUID, payload)
+	key, keyErr := signer.Key(farmUID)
+	otherKey, otherKeyErr := signer.Key(otherFarmUID)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Nil(t, keyErr)
+	assert.Nil(t, otherKeyErr)
+	assert.Equal(t, Algorithm, signature.Algorithm)
+
+	fingerprint, _ := key.Fingerprint()
+	otherFingerprint, _ := otherKey.Fingerprint()
+
+	assert.Equal(t, fingerprint, signature.KeyFingerprint)
+	assert.NotEqual(t, fingerprint, otherFingerprint)
+
+	assert.True(t, Verify(&key.PrivateKey.PublicKey, payload, signature))
+	assert.False(t, Verify(&key.PrivateKey.PublicKey, []byte("Certificate TC-0002"), signature))
+	assert.False(t, Verify(&otherKey.PrivateKey.PublicKey, payload, signature))
+}
@@ -0,0 +1,60 @@
+package signing
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type StoreSqlite struct {
+	DB *sql.DB
+}
+
+func NewStoreSqlite(db *sql.DB) Store {
+	return &StoreSqlite{DB: db}
+}
+
+func (s *StoreSqlite) Save(key Key) error {
+	privateKey, err := encodePrivateKey(key.PrivateKey)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.DB.Exec(`INSERT OR IGNORE INTO FARM_SIGNING_KEY (FARM_UID, PRIVATE_KEY, CREATED_DATE)
+		VALUES (?, ?, ?)`,
+		key.FarmUID.String(),
+		privateKey,
+		key.CreatedDate.UTC().Format(time.RFC3339))
+
+	return err
+}
+
+func (s *StoreSqlite) FindByFarm(farmUID uuid.UUID) (Key, bool, error) {
+	var privateKey, createdDate string
+
+	err := s.DB.QueryRow(`SELECT PRIVATE_KEY, CREATED_DATE FROM FARM_SIGNING_KEY WHERE FARM_UID = ?`,
+		farmUID.String()).Scan(&privateKey, &createdDate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Key{}, false, nil
+	}
+
+	if err != nil {
+		return Key{}, false, err
+	}
+
+	key := Key{FarmUID: farmUID}
+
+	key.PrivateKey, err = decodePrivateKey(privateKey)
+	if err != nil {
+		return Key{}, false, err
+	}
+
+	key.CreatedDate, err = time.Parse(time.RFC3339, createdDate)
+	if err != nil {
+		return Key{}, false, err
+	}
+
+	return key, true, nil
+}
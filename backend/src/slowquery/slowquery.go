@@ -0,0 +1,275 @@
+// Package slowquery times the queries of the SQL databases and logs the ones slower than a threshold,
+// with their arguments sanitised. The timing wraps the driver connections, so the queries of the
+// repositories, the prepared statements and the transactions are all timed.
+package slowquery
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// maxArgLength cuts the string arguments to the start of the ids, the tokens, password hashes
+// and user inputs aren't logged.
+const maxArgLength = 8
+
+// Open opens the database with its queries timed against the threshold. The slow ones are logged
+// at WARN level with the logger. A zero threshold opens the database untimed.
+func Open(driverName, dsn string, threshold time.Duration, logger *log.Logger) (*sql.DB, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil || threshold <= 0 {
+		return db, err
+	}
+
+	// sql.Open doesn't connect, this handle only looks the driver up.
+	d := db.Driver()
+
+	err = db.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var connector driver.Connector = dsnConnector{dsn: dsn, driver: d}
+
+	if dc, ok := d.(driver.DriverContext); ok {
+		connector, err = dc.OpenConnector(dsn)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sql.OpenDB(&timedConnector{
+		Connector: connector,
+		timer:     &timer{threshold: threshold, logger: logger},
+	}), nil
+}
+
+type timer struct {
+	threshold time.Duration
+	logger    *log.Logger
+}
+
+func (t *timer) observe(query string, args []driver.NamedValue, start time.Time) {
+	elapsed := time.Since(start)
+	if elapsed < t.threshold {
+		return
+	}
+
+	t.logger.Printf("WARN slow query %dms: %s args=[%s]", elapsed.Milliseconds(), compact(query), Sanitize(args))
+}
+
+// compact puts the multi line queries on a single log line.
+func compact(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// Sanitize formats the arguments of a query for the log. The strings are cut to their first characters
+// and the binary values are only counted.
+func Sanitize(args []driver.NamedValue) string {
+	values := make([]string, len(args))
+
+	for i, v := range args {
+		switch value := v.Value.(type) {
+		case nil:
+			values[i] = "NULL"
+		case []byte:
+			values[i] = fmt.Sprintf("<%d bytes>", len(value))
+		case string:
+			if len(value) > maxArgLength {
+				value = value[:maxArgLength] + "..."
+			}
+
+			values[i] = fmt.Sprintf("%q", value)
+		case time.Time:
+			values[i] = value.Format(time.RFC3339)
+		default:
+			values[i] = fmt.Sprint(value)
+		}
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// dsnConnector connects the drivers without a connector of their own, like database/sql does.
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(_ context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+type timedConnector struct {
+	driver.Connector
+	timer *timer
+}
+
+func (c *timedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &timedConn{Conn: conn, timer: c.timer}, nil
+}
+
+// timedConn times the queries run on the connection. The optional interfaces of the driver connection
+// are passed through, the unsupported ones return driver.ErrSkip so database/sql falls back to its defaults.
+type timedConn struct {
+	driver.Conn
+	timer *timer
+}
+
+func (c *timedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	defer c.timer.observe(query, args, time.Now())
+
+	return execer.ExecContext(ctx, query, args)
+}
+
+func (c *timedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	defer c.timer.observe(query, args, time.Now())
+
+	return queryer.QueryContext(ctx, query, args)
+}
+
+func (c *timedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var (
+		stmt driver.Stmt
+		err  error
+	)
+
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &timedStmt{Stmt: stmt, conn: c, query: query, timer: c.timer}, nil
+}
+
+func (c *timedConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *timedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+
+	return c.Conn.Begin() //nolint:staticcheck
+}
+
+func (c *timedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+
+	return nil
+}
+
+func (c *timedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+
+	return nil
+}
+
+func (c *timedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+
+	return true
+}
+
+func (c *timedConn) CheckNamedValue(v *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(v)
+	}
+
+	return driver.ErrSkip
+}
+
+type timedStmt struct {
+	driver.Stmt
+	conn  *timedConn
+	query string
+	timer *timer
+}
+
+func (s *timedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	defer s.timer.observe(s.query, args, time.Now())
+
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		return execer.ExecContext(ctx, args)
+	}
+
+	values, err := namedValues(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Stmt.Exec(values) //nolint:staticcheck
+}
+
+func (s *timedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	defer s.timer.observe(s.query, args, time.Now())
+
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		return queryer.QueryContext(ctx, args)
+	}
+
+	values, err := namedValues(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Stmt.Query(values) //nolint:staticcheck
+}
+
+// CheckNamedValue falls back to the checker of the connection, database/sql only asks the statement's.
+func (s *timedStmt) CheckNamedValue(v *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(v)
+	}
+
+	return s.conn.CheckNamedValue(v)
+}
+
+func namedValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+
+	for i, v := range args {
+		if v.Name != "" {
+			return nil, fmt.Errorf("named argument %s isn't supported by the driver", v.Name)
+		}
+
+		values[i] = v.Value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,90 @@
+package slowquery_test
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/slowquery"
+)
+
+func TestOpenLogsSlowQueries(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	buf := &bytes.Buffer{}
+	db, err := slowquery.Open("sqlite3", ":memory:", time.Nanosecond, log.New(buf, "", 0))
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	db.SetMaxOpenConns(1)
+
+	// When
+	_, err = db.Exec("CREATE TABLE NOTE (UID TEXT,\n  CONTENT TEXT)")
+	assert.Nil(t, err)
+
+	_, err = db.Exec("INSERT INTO NOTE (UID, CONTENT) VALUES (?, ?)", "n1", strings.Repeat("a", 40))
+	assert.Nil(t, err)
+
+	stmt, err := db.Prepare("SELECT CONTENT FROM NOTE WHERE UID = ?")
+	assert.Nil(t, err)
+
+	content := ""
+	err = stmt.QueryRow("n1").Scan(&content)
+	assert.Nil(t, err)
+	assert.Nil(t, stmt.Close())
+
+	// Then
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "WARN slow query ")
+	assert.Contains(t, lines[0], "CREATE TABLE NOTE (UID TEXT, CONTENT TEXT) args=[]")
+	assert.Contains(t, lines[1], `args=["n1", "`+strings.Repeat("a", 8)+`..."]`)
+	assert.Contains(t, lines[2], `SELECT CONTENT FROM NOTE WHERE UID = ? args=["n1"]`)
+	assert.Equal(t, strings.Repeat("a", 40), content)
+}
+
+func TestOpenWithoutThreshold(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	buf := &bytes.Buffer{}
+	db, err := slowquery.Open("sqlite3", ":memory:", 0, log.New(buf, "", 0))
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	// When
+	_, err = db.Exec("CREATE TABLE NOTE (UID TEXT)")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Empty(t, buf.String())
+}
+
+func TestSanitize(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	recordedAt := time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC)
+	args := []driver.NamedValue{
+		{Ordinal: 1, Value: nil},
+		{Ordinal: 2, Value: []byte(`{"password":"secret"}`)},
+		{Ordinal: 3, Value: int64(42)},
+		{Ordinal: 4, Value: recordedAt},
+		{Ordinal: 5, Value: "tania"},
+		{Ordinal: 6, Value: "8b9f17fa-a441-4e7f-855f-327e7bf7c0de"},
+	}
+
+	// When
+	sanitized := slowquery.Sanitize(args)
+
+	// Then
+	assert.Equal(t, `NULL, <21 bytes>, 42, 2026-10-14T08:00:00Z, "tania", "8b9f17fa..."`, sanitized)
+}
@@ -0,0 +1,136 @@
+// Package redis keeps the events of the aggregates in Redis Streams, one stream per aggregate,
+// so the event storage of the in-memory engine is bounded by Redis and shared between the processes.
+package redis
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// The aggregates whose events are kept in the streams.
+const (
+	AggregateFarm      = "farm"
+	AggregateReservoir = "reservoir"
+	AggregateArea      = "area"
+	AggregateMaterial  = "material"
+	AggregateCrop      = "crop"
+	AggregateTask      = "task"
+)
+
+// KeyPrefix prefixes the keys of the streams, like tania:events:task:<uid>.
+const KeyPrefix = "tania:events:"
+
+// StreamEvent is an entry of the stream of an aggregate, its event encoded like in the SQL engines.
+type StreamEvent struct {
+	Version     int
+	CreatedDate time.Time
+	Event       []byte
+}
+
+// RedisStreamsEventStorage appends the events with XADD, and reads them back in order with XRANGE.
+type RedisStreamsEventStorage struct {
+	Client *goredis.Client
+}
+
+// NewRedisStreamsEventStorage connects to the Redis of the URL, like redis://localhost:6379/0.
+func NewRedisStreamsEventStorage(url string) (*RedisStreamsEventStorage, error) {
+	opts, err := goredis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	client := goredis.NewClient(opts)
+
+	err = client.Ping(context.Background()).Err()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+
+	return &RedisStreamsEventStorage{Client: client}, nil
+}
+
+func streamKey(aggregate string, uid uuid.UUID) string {
+	return KeyPrefix + aggregate + ":" + uid.String()
+}
+
+// Append adds the events to the stream of the aggregate in one transaction.
+// The stream of an aggregate saved by another process meanwhile is reported,
+// its events are still appended like the other engines do.
+func (s *RedisStreamsEventStorage) Append(
+	aggregate string, uid uuid.UUID, latestVersion int, events []StreamEvent,
+) error {
+	ctx := context.Background()
+	key := streamKey(aggregate, uid)
+
+	length, err := s.Len(aggregate, uid)
+	if err != nil {
+		return err
+	}
+
+	if length != latestVersion {
+		log.Printf("WARN %s %s saved at version %d, its stream has %d events", aggregate, uid, latestVersion, length)
+	}
+
+	_, err = s.Client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for _, v := range events {
+			pipe.XAdd(ctx, &goredis.XAddArgs{
+				Stream: key,
+				Values: map[string]interface{}{
+					"version":      v.Version,
+					"created_date": v.CreatedDate.UTC().Format(time.RFC3339),
+					"event":        v.Event,
+				},
+			})
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// Range returns the events of the stream of the aggregate, oldest first.
+func (s *RedisStreamsEventStorage) Range(aggregate string, uid uuid.UUID) ([]StreamEvent, error) {
+	messages, err := s.Client.XRange(context.Background(), streamKey(aggregate, uid), "-", "+").Result()
+	if err != nil {
+		return nil, err
+	}
+
+	events := []StreamEvent{}
+
+	for _, m := range messages {
+		version, err := strconv.Atoi(fmt.Sprint(m.Values["version"]))
+		if err != nil {
+			return nil, fmt.Errorf("stream entry %s has no version: %w", m.ID, err)
+		}
+
+		createdDate, err := time.Parse(time.RFC3339, fmt.Sprint(m.Values["created_date"]))
+		if err != nil {
+			return nil, fmt.Errorf("stream entry %s has no created date: %w", m.ID, err)
+		}
+
+		events = append(events, StreamEvent{
+			Version:     version,
+			CreatedDate: createdDate,
+			Event:       []byte(fmt.Sprint(m.Values["event"])),
+		})
+	}
+
+	return events, nil
+}
+
+// Len is the number of events of the stream of the aggregate, its latest version.
+func (s *RedisStreamsEventStorage) Len(aggregate string, uid uuid.UUID) (int, error) {
+	length, err := s.Client.XLen(context.Background(), streamKey(aggregate, uid)).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(length), nil
+}
@@ -0,0 +1,150 @@
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/actor"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	assetsqueryRedis "github.com/usetania/tania-core/src/assets/query/redis"
+	assetsrepoRedis "github.com/usetania/tania-core/src/assets/repository/redis"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	. "github.com/usetania/tania-core/src/storage/redis"
+	tasksdomain "github.com/usetania/tania-core/src/tasks/domain"
+	tasksqueryRedis "github.com/usetania/tania-core/src/tasks/query/redis"
+	tasksrepoRedis "github.com/usetania/tania-core/src/tasks/repository/redis"
+	taskstorage "github.com/usetania/tania-core/src/tasks/storage"
+)
+
+func newTestStorage(t *testing.T) (*RedisStreamsEventStorage, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+
+	streams, err := NewRedisStreamsEventStorage("redis://" + server.Addr())
+	assert.Nil(t, err)
+
+	return streams, server
+}
+
+func TestAppendAndRangeTheStream(t *testing.T) {
+	t.Parallel()
+	// Given
+	streams, server := newTestStorage(t)
+	uid, _ := uuid.NewV4()
+	otherUID, _ := uuid.NewV4()
+	now := time.Date(2026, time.October, 14, 8, 0, 0, 0, time.UTC)
+
+	// When
+	err := streams.Append(AggregateTask, uid, 0, []StreamEvent{
+		{Version: 1, CreatedDate: now, Event: []byte(`{"name":"first"}`)},
+		{Version: 2, CreatedDate: now, Event: []byte(`{"name":"second"}`)},
+	})
+	errNext := streams.Append(AggregateTask, uid, 2, []StreamEvent{
+		{Version: 3, CreatedDate: now.Add(time.Hour), Event: []byte(`{"name":"third"}`)},
+	})
+
+	events, errRange := streams.Range(AggregateTask, uid)
+	length, errLen := streams.Len(AggregateTask, uid)
+	otherEvents, _ := streams.Range(AggregateTask, otherUID)
+	otherLength, _ := streams.Len(AggregateCrop, uid)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Nil(t, errNext)
+	assert.Nil(t, errRange)
+	assert.Nil(t, errLen)
+
+	assert.Len(t, events, 3)
+	assert.Equal(t, []int{1, 2, 3}, []int{events[0].Version, events[1].Version, events[2].Version})
+	assert.Equal(t, now, events[0].CreatedDate)
+	assert.Equal(t, now.Add(time.Hour), events[2].CreatedDate)
+	assert.Equal(t, `{"name":"second"}`, string(events[1].Event))
+	assert.Equal(t, 3, length)
+
+	assert.Empty(t, otherEvents)
+	assert.Equal(t, 0, otherLength)
+	assert.True(t, server.Exists(KeyPrefix+AggregateTask+":"+uid.String()))
+}
+
+func TestSaveAndFindTheTaskEvents(t *testing.T) {
+	t.Parallel()
+	// Given
+	streams, _ := newTestStorage(t)
+	repo := tasksrepoRedis.NewTaskEventRepositoryRedis(streams)
+	query := tasksqueryRedis.NewTaskEventQueryRedis(streams)
+
+	uid, _ := uuid.NewV4()
+	userUID, _ := uuid.NewV4()
+	by := &actor.Actor{UserUID: &userUID, Source: "api"}
+
+	// When
+	err := <-repo.Save(uid, 0, []interface{}{
+		tasksdomain.TaskTitleChanged{UID: uid, Title: "Water the beds"},
+	}, by)
+	errNext := <-repo.Save(uid, 1, []interface{}{
+		tasksdomain.TaskPriorityChanged{UID: uid, Priority: "URGENT"},
+	}, nil)
+
+	result := <-query.FindAllByTaskID(uid)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Nil(t, errNext)
+	assert.Nil(t, result.Error)
+
+	events, ok := result.Result.([]taskstorage.TaskEvent)
+	assert.True(t, ok)
+	assert.Len(t, events, 2)
+
+	assert.Equal(t, uid, events[0].TaskUID)
+	assert.Equal(t, 1, events[0].Version)
+	assert.Equal(t, tasksdomain.TaskTitleChanged{UID: uid, Title: "Water the beds"}, events[0].Event)
+	assert.Equal(t, by, events[0].Actor)
+
+	assert.Equal(t, 2, events[1].Version)
+	assert.Equal(t, tasksdomain.TaskPriorityChanged{UID: uid, Priority: "URGENT"}, events[1].Event)
+	assert.Nil(t, events[1].Actor)
+}
+
+func TestSaveAndFindTheFarmEvents(t *testing.T) {
+	t.Parallel()
+	// Given
+	streams, _ := newTestStorage(t)
+	repo := assetsrepoRedis.NewFarmEventRepositoryRedis(streams)
+	query := assetsqueryRedis.NewFarmEventQueryRedis(streams)
+	uid, _ := uuid.NewV4()
+
+	// When
+	err := <-repo.Save(uid, 0, []interface{}{assetsdomain.FarmNameChanged{FarmUID: uid, Name: "My Farm"}}, nil)
+	result := <-query.FindAllByID(uid)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Nil(t, result.Error)
+
+	events, ok := result.Result.([]assetsstorage.FarmEvent)
+	assert.True(t, ok)
+	assert.Len(t, events, 1)
+	assert.Equal(t, uid, events[0].FarmUID)
+	assert.Equal(t, assetsdomain.FarmNameChanged{FarmUID: uid, Name: "My Farm"}, events[0].Event)
+}
+
+func TestUnreachableRedis(t *testing.T) {
+	t.Parallel()
+	// Given
+	server := miniredis.RunT(t)
+	addr := server.Addr()
+	server.Close()
+
+	// When
+	_, err := NewRedisStreamsEventStorage("redis://" + addr)
+	_, errURL := NewRedisStreamsEventStorage("localhost:6379")
+
+	// Then
+	assert.NotNil(t, err)
+	assert.NotNil(t, errURL)
+}
@@ -6,14 +6,17 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/mitchellh/mapstructure"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/tasks/domain"
 )
 
 // InterfaceWrapper is used to wrap an interface with its struct name,
 // so it will be easier to unmarshal later.
+// The actor is nil for the events stored before the actors were recorded.
 type InterfaceWrapper struct {
-	Name string
-	Data interface{}
+	Name  string
+	Data  interface{}
+	Actor *actor.Actor `json:",omitempty"`
 }
 
 func Decode(f mapstructure.DecodeHookFunc, data *map[string]interface{}, e interface{}) (interface{}, error) {
@@ -0,0 +1,81 @@
+package decoder
+
+import (
+	"encoding/json"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/usetania/tania-core/src/tasks/domain"
+)
+
+type TaskCatalogEventWrapper InterfaceWrapper
+
+func (w *TaskCatalogEventWrapper) UnmarshalJSON(b []byte) error {
+	wrapper := InterfaceWrapper{}
+
+	err := json.Unmarshal(b, &wrapper)
+	if err != nil {
+		return err
+	}
+
+	w.Actor = wrapper.Actor
+
+	mapped := wrapper.Data.(map[string]interface{})
+
+	f := mapstructure.ComposeDecodeHookFunc(
+		UIDHook(),
+	)
+
+	switch wrapper.Name {
+	case domain.TaskCatalogPrioritySavedCode:
+		e := domain.TaskCatalogPrioritySaved{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskCatalogPriorityRemovedCode:
+		e := domain.TaskCatalogPriorityRemoved{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskCatalogCategorySavedCode:
+		e := domain.TaskCatalogCategorySaved{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskCatalogCategoryRemovedCode:
+		e := domain.TaskCatalogCategoryRemoved{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskCatalogArchivalPolicyChangedCode:
+		e := domain.TaskCatalogArchivalPolicyChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+	}
+
+	return nil
+}
@@ -20,6 +20,8 @@ func (w *TaskEventWrapper) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	w.Actor = wrapper.Actor
+
 	mapped := wrapper.Data.(map[string]interface{})
 
 	f := mapstructure.ComposeDecodeHookFunc(
@@ -91,6 +93,16 @@ func (w *TaskEventWrapper) UnmarshalJSON(b []byte) error {
 
 		w.Data = e
 
+	case domain.TaskDueDateAdjustedForBusinessHoursCode:
+		e := domain.TaskDueDateAdjustedForBusinessHours{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
 	case domain.TaskCategoryChangedCode:
 		e := domain.TaskCategoryChanged{}
 
@@ -161,6 +173,106 @@ func (w *TaskEventWrapper) UnmarshalJSON(b []byte) error {
 			return err
 		}
 
+		w.Data = e
+
+	case domain.TaskShortCodeAssignedCode:
+		e := domain.TaskShortCodeAssigned{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskEstimatedMinutesChangedCode:
+		e := domain.TaskEstimatedMinutesChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskDependenciesChangedCode:
+		e := domain.TaskDependenciesChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskTargetChangedCode:
+		e := domain.TaskTargetChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskProgressRecordedCode:
+		e := domain.TaskProgressRecorded{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskRecurrenceSetCode:
+		e := domain.TaskRecurrenceSet{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskAreaProgressChangedCode:
+		e := domain.TaskAreaProgressChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskArchivedCode:
+		e := domain.TaskArchived{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskEditConflictedCode:
+		e := domain.TaskEditConflicted{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskEditConflictResolvedCode:
+		e := domain.TaskEditConflictResolved{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
 		w.Data = e
 	}
 
@@ -248,6 +360,24 @@ func makeDomainDetails(v interface{}, domainCode string) (domain.TaskDomain, err
 		}
 
 		domainDetails = taskDomainReservoir
+	case domain.TaskDomainEquipmentCode:
+		taskDomainEquipment := domain.TaskDomainEquipment{}
+
+		if v2, ok2 := mapped["equipment_id"]; ok2 {
+			val, ok2 := v2.(string)
+			if !ok2 {
+				return domain.TaskDomainEquipment{}, nil
+			}
+
+			uid, err := uuid.FromString(val)
+			if err != nil {
+				return domain.TaskDomainEquipment{}, err
+			}
+
+			taskDomainEquipment.EquipmentID = &uid
+		}
+
+		domainDetails = taskDomainEquipment
 	}
 
 	return domainDetails, nil
@@ -0,0 +1,73 @@
+package decoder
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/usetania/tania-core/src/tasks/domain"
+)
+
+type TaskTemplateEventWrapper InterfaceWrapper
+
+func (w *TaskTemplateEventWrapper) UnmarshalJSON(b []byte) error {
+	wrapper := InterfaceWrapper{}
+
+	err := json.Unmarshal(b, &wrapper)
+	if err != nil {
+		return err
+	}
+
+	w.Actor = wrapper.Actor
+
+	mapped := wrapper.Data.(map[string]interface{})
+
+	f := mapstructure.ComposeDecodeHookFunc(
+		UIDHook(),
+		TimeHook(time.RFC3339),
+	)
+
+	switch wrapper.Name {
+	case domain.TaskTemplateCreatedCode:
+		e := domain.TaskTemplateCreated{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskTemplateNameChangedCode:
+		e := domain.TaskTemplateNameChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskTemplateParentChangedCode:
+		e := domain.TaskTemplateParentChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+
+	case domain.TaskTemplateItemsChangedCode:
+		e := domain.TaskTemplateItemsChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.Data = e
+	}
+
+	return nil
+}
@@ -13,6 +13,7 @@ type TaskServiceSqlite struct {
 	AreaQuery      query.Area
 	MaterialQuery  query.Material
 	ReservoirQuery query.Reservoir
+	EquipmentQuery query.Equipment
 }
 
 func (s TaskServiceSqlite) FindAreaByID(uid uuid.UUID) domain.ServiceResult {
@@ -122,3 +123,30 @@ func (s TaskServiceSqlite) FindReservoirByID(uid uuid.UUID) domain.ServiceResult
 		Result: reservoir,
 	}
 }
+
+func (s TaskServiceSqlite) FindEquipmentByID(uid uuid.UUID) domain.ServiceResult {
+	result := <-s.EquipmentQuery.FindEquipmentByID(uid)
+
+	if result.Error != nil {
+		return domain.ServiceResult{
+			Error: result.Error,
+		}
+	}
+
+	equipment, ok := result.Result.(query.TaskEquipmentResult)
+	if !ok {
+		return domain.ServiceResult{
+			Error: domain.TaskError{Code: domain.TaskErrorInvalidAssetIDCode},
+		}
+	}
+
+	if equipment == (query.TaskEquipmentResult{}) {
+		return domain.ServiceResult{
+			Error: domain.TaskError{Code: domain.TaskErrorInvalidAssetIDCode},
+		}
+	}
+
+	return domain.ServiceResult{
+		Result: equipment,
+	}
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// TaskTemplateServiceQuery looks up the parents of a task template from its read model.
+type TaskTemplateServiceQuery struct {
+	TaskTemplateReadQuery query.TaskTemplateRead
+}
+
+func (s TaskTemplateServiceQuery) FindTaskTemplateByID(uid uuid.UUID) domain.ServiceResult {
+	result := <-s.TaskTemplateReadQuery.FindByID(uid)
+
+	if result.Error != nil {
+		return domain.ServiceResult{
+			Error: result.Error,
+		}
+	}
+
+	templateRead, ok := result.Result.(storage.TaskTemplateRead)
+	if !ok || templateRead.UID == (uuid.UUID{}) {
+		return domain.ServiceResult{
+			Error: domain.TaskError{Code: domain.TaskTemplateErrorNotFoundCode},
+		}
+	}
+
+	return domain.ServiceResult{
+		Result: domain.TaskTemplate{
+			UID:              templateRead.UID,
+			Name:             templateRead.Name,
+			ParentTemplateID: templateRead.ParentTemplateID,
+			Items:            templateRead.Items,
+			CreatedDate:      templateRead.CreatedDate,
+		},
+	}
+}
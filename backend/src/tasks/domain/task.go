@@ -11,6 +11,7 @@ type TaskService interface {
 	FindCropByID(uid uuid.UUID) ServiceResult
 	FindMaterialByID(uid uuid.UUID) ServiceResult
 	FindReservoirByID(uid uuid.UUID) ServiceResult
+	FindEquipmentByID(uid uuid.UUID) ServiceResult
 }
 
 // ServiceResult is the container for service result.
@@ -21,6 +22,7 @@ type ServiceResult struct {
 
 type Task struct {
 	UID           uuid.UUID  `json:"uid"`
+	ShortCode     string     `json:"short_code"`
 	Title         string     `json:"title"`
 	Description   string     `json:"description"`
 	CreatedDate   time.Time  `json:"created_date"`
@@ -35,18 +37,75 @@ type Task struct {
 	IsDue         bool       `json:"is_due"`
 	AssetID       *uuid.UUID `json:"asset_id"`
 
+	// The area the costs of the task are allocated to, and the work recorded on completion.
+	CostCentreID     *uuid.UUID `json:"cost_centre_id"`
+	CompletedBy      *uuid.UUID `json:"completed_by"`
+	LabourMinutes    int        `json:"labour_minutes"`
+	MaterialQuantity float64    `json:"material_quantity"`
+
+	ArchivedDate *time.Time `json:"archived_date,omitempty"`
+
+	// How long the task takes from its due date, zero when it isn't estimated.
+	EstimatedMinutes int `json:"estimated_minutes"`
+
+	// The due date was moved out of the closed hours of the farm when the task was created.
+	DueDateAdjustedForBusinessHours bool `json:"due_date_adjusted_for_business_hours"`
+
+	// The tasks which have to be closed before this one can start.
+	DependsOn []uuid.UUID `json:"depends_on"`
+
+	// The areas covered by a task spanning several of them, and the percentage of the work done in each area.
+	AffectedAreaIDs []uuid.UUID       `json:"affected_area_ids"`
+	PerAreaProgress map[uuid.UUID]int `json:"per_area_progress"`
+
+	// The units of work of a piecework task, zero when it has no target, and the units done so far.
+	TargetQuantity    float64 `json:"target_quantity"`
+	TargetUnit        string  `json:"target_unit"`
+	CompletedQuantity float64 `json:"completed_quantity"`
+
+	// The rule the task repeats with, nil when it doesn't, and the task of the series it was created from.
+	Recurrence   *TaskRecurrence `json:"recurrence"`
+	RecurrenceOf *uuid.UUID      `json:"recurrence_of"`
+
+	// The farm of the task, which its short code is numbered in, uuid.Nil for the tasks without a farm.
+	ShortCodeFarmUID uuid.UUID `json:"short_code_farm_id"`
+
 	// Events
 	Version            int
 	UncommittedChanges []interface{}
 }
 
-// CreateTask.
+// CreateTask creates the task, the affected areas are only given to a task spanning several areas.
+// The priority and the category are checked against the catalog of the farm of the task.
 func CreateTask(
 	ts TaskService,
+	catalog *TaskCatalog,
+	title, description, priority, category string,
+	duedate *time.Time,
+	taskdomain TaskDomain,
+	assetid *uuid.UUID,
+	affectedAreaIDs []uuid.UUID,
+) (*Task, error) {
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return &Task{}, err
+	}
+
+	return CreateTaskWithUID(
+		uid, ts, catalog, title, description, priority, category, duedate, taskdomain, assetid, affectedAreaIDs)
+}
+
+// CreateTaskWithUID creates the task with the given ID, for the tasks whose ID is derived from what they're
+// created for.
+func CreateTaskWithUID(
+	uid uuid.UUID,
+	ts TaskService,
+	catalog *TaskCatalog,
 	title, description, priority, category string,
 	duedate *time.Time,
 	taskdomain TaskDomain,
 	assetid *uuid.UUID,
+	affectedAreaIDs []uuid.UUID,
 ) (*Task, error) {
 	// add validation
 	err := validateTaskTitle(title)
@@ -64,12 +123,12 @@ func CreateTask(
 		return &Task{}, err
 	}
 
-	err = validateTaskPriority(priority)
+	err = catalog.ValidatePriority(priority)
 	if err != nil {
 		return &Task{}, err
 	}
 
-	err = validateTaskCategory(category)
+	err = catalog.ValidateCategory(category)
 	if err != nil {
 		return &Task{}, err
 	}
@@ -79,7 +138,7 @@ func CreateTask(
 		return &Task{}, err
 	}
 
-	uid, err := uuid.NewV4()
+	affectedAreaIDs, err = validateAffectedAreaIDs(ts, affectedAreaIDs)
 	if err != nil {
 		return &Task{}, err
 	}
@@ -99,11 +158,44 @@ func CreateTask(
 		Category:      category,
 		IsDue:         false,
 		AssetID:       assetid,
+		CostCentreID:  defaultCostCentreID(taskdomain, assetid),
+
+		AffectedAreaIDs: affectedAreaIDs,
 	})
 
 	return initial, nil
 }
 
+// defaultCostCentreID is the area the task refers to, either its asset or the area of its crop.
+func defaultCostCentreID(taskdomain TaskDomain, assetid *uuid.UUID) *uuid.UUID {
+	switch v := taskdomain.(type) {
+	case TaskDomainArea:
+		return assetid
+	case TaskDomainCrop:
+		return v.AreaID
+	}
+
+	return nil
+}
+
+// AreaIDs are the areas the task covers.
+func (t *Task) AreaIDs() []uuid.UUID {
+	return TaskAreaIDs(t.AffectedAreaIDs, t.DomainDetails, t.AssetID)
+}
+
+// TaskAreaIDs are the affected areas of a task, the task without them covers the single area of its domain if any.
+func TaskAreaIDs(affectedAreaIDs []uuid.UUID, taskdomain TaskDomain, assetid *uuid.UUID) []uuid.UUID {
+	if len(affectedAreaIDs) > 0 {
+		return affectedAreaIDs
+	}
+
+	if areaID := defaultCostCentreID(taskdomain, assetid); areaID != nil {
+		return []uuid.UUID{*areaID}
+	}
+
+	return nil
+}
+
 func (t *Task) ChangeTaskTitle(title string) error {
 	if err := validateTaskTitle(title); err != nil {
 		return err
@@ -151,8 +243,8 @@ func (t *Task) ChangeTaskDueDate(duedate *time.Time) (*Task, error) {
 	return t, nil
 }
 
-func (t *Task) ChangeTaskPriority(priority string) (*Task, error) {
-	err := validateTaskPriority(priority)
+func (t *Task) ChangeTaskPriority(catalog *TaskCatalog, priority string) (*Task, error) {
+	err := catalog.ValidatePriority(priority)
 	if err != nil {
 		return &Task{}, err
 	}
@@ -167,8 +259,8 @@ func (t *Task) ChangeTaskPriority(priority string) (*Task, error) {
 	return t, nil
 }
 
-func (t *Task) ChangeTaskCategory(category string) (*Task, error) {
-	err := validateTaskCategory(category)
+func (t *Task) ChangeTaskCategory(catalog *TaskCatalog, category string) (*Task, error) {
+	err := catalog.ValidateCategory(category)
 	if err != nil {
 		return &Task{}, err
 	}
@@ -194,6 +286,67 @@ func (t *Task) ChangeTaskDetails(details TaskDomain) (*Task, error) {
 	return t, nil
 }
 
+func (t *Task) ChangeTaskEstimatedMinutes(estimatedMinutes int) error {
+	if estimatedMinutes < 0 {
+		return TaskError{TaskErrorEstimatedMinutesInvalidCode}
+	}
+
+	t.TrackChange(TaskEstimatedMinutesChanged{
+		UID:              t.UID,
+		EstimatedMinutes: estimatedMinutes,
+	})
+
+	return nil
+}
+
+// ChangeTaskDependencies replaces the tasks the task depends on, without the repeated ones.
+// The dependencies creating a cycle through the other tasks are checked with DependencyCycle.
+func (t *Task) ChangeTaskDependencies(dependsOn []uuid.UUID) error {
+	distinct := []uuid.UUID{}
+	seen := map[uuid.UUID]bool{}
+
+	for _, v := range dependsOn {
+		if v == t.UID {
+			return TaskError{TaskErrorDependencySelfCode}
+		}
+
+		if !seen[v] {
+			seen[v] = true
+			distinct = append(distinct, v)
+		}
+	}
+
+	t.TrackChange(TaskDependenciesChanged{
+		UID:       t.UID,
+		DependsOn: distinct,
+	})
+
+	return nil
+}
+
+// ChangeTaskTarget sets the units of work of the task, a zero quantity removes its target.
+func (t *Task) ChangeTaskTarget(targetQuantity float64, targetUnit string) error {
+	if targetQuantity < 0 {
+		return TaskError{TaskErrorTargetQuantityInvalidCode}
+	}
+
+	if targetQuantity > 0 && targetUnit == "" {
+		return TaskError{TaskErrorTargetUnitEmptyCode}
+	}
+
+	if targetQuantity == 0 {
+		targetUnit = ""
+	}
+
+	t.TrackChange(TaskTargetChanged{
+		UID:            t.UID,
+		TargetQuantity: targetQuantity,
+		TargetUnit:     targetUnit,
+	})
+
+	return nil
+}
+
 // SetTaskAsDue.
 func (t *Task) SetTaskAsDue() {
 	t.TrackChange(TaskDue{
@@ -201,19 +354,138 @@ func (t *Task) SetTaskAsDue() {
 	})
 }
 
-// CompleteTask.
-func (t *Task) CompleteTask() {
+// SetTaskAsDueAt sets the open task as due once its due date is reached at now. It's false, without a change,
+// for the tasks without a due date, not due yet, closed or already set as due.
+func (t *Task) SetTaskAsDueAt(now time.Time) bool {
+	if t.DueDate == nil || t.DueDate.After(now) || t.IsDue || t.Status != TaskStatusCreated {
+		return false
+	}
+
+	t.SetTaskAsDue()
+
+	return true
+}
+
+// UpdateAreaProgress records the percentage of the work done in one of the areas of the task.
+// The task is completed by the user once all its areas are at 100%.
+func (t *Task) UpdateAreaProgress(areaID uuid.UUID, progress int, completedBy *uuid.UUID) error {
+	if t.Status != TaskStatusCreated {
+		return TaskError{TaskErrorAreaProgressClosedCode}
+	}
+
+	if progress < 0 || progress > 100 {
+		return TaskError{TaskErrorAreaProgressInvalidCode}
+	}
+
+	areaIDs := t.AreaIDs()
+
+	covered := false
+
+	for _, v := range areaIDs {
+		if v == areaID {
+			covered = true
+		}
+	}
+
+	if !covered {
+		return TaskError{TaskErrorAreaNotAffectedCode}
+	}
+
+	t.TrackChange(TaskAreaProgressChanged{
+		UID:      t.UID,
+		AreaID:   areaID,
+		Progress: progress,
+	})
+
+	for _, v := range areaIDs {
+		if t.PerAreaProgress[v] < 100 {
+			return nil
+		}
+	}
+
+	return t.CompleteTask(completedBy, 0, 0, 0)
+}
+
+// RecordProgress adds the units of work done, with the minutes they took, to the completed quantity of the task.
+// With autoComplete, the task is completed by the user once its target is reached.
+func (t *Task) RecordProgress(quantity float64, labourMinutes int, recordedBy *uuid.UUID, autoComplete bool) error {
+	if t.Status != TaskStatusCreated {
+		return TaskError{TaskErrorProgressClosedCode}
+	}
+
+	if quantity <= 0 {
+		return TaskError{TaskErrorProgressQuantityInvalidCode}
+	}
+
+	if labourMinutes < 0 {
+		return TaskError{TaskErrorLabourMinutesInvalidCode}
+	}
+
+	t.TrackChange(TaskProgressRecorded{
+		UID:           t.UID,
+		Quantity:      quantity,
+		LabourMinutes: labourMinutes,
+		RecordedBy:    recordedBy,
+		RecordedDate:  time.Now(),
+	})
+
+	if !autoComplete || t.TargetQuantity <= 0 || t.CompletedQuantity < t.TargetQuantity {
+		return nil
+	}
+
+	return t.CompleteTask(recordedBy, 0, 0, 0)
+}
+
+// CompleteTask records the minutes spent by the user who completed the task, the quantity of its material used
+// and the units of work done. The minutes and the units are added to the progress recorded before.
+func (t *Task) CompleteTask(
+	completedBy *uuid.UUID,
+	labourMinutes int,
+	materialQuantity float64,
+	completedQuantity float64,
+) error {
+	if labourMinutes < 0 {
+		return TaskError{TaskErrorLabourMinutesInvalidCode}
+	}
+
+	if materialQuantity < 0 {
+		return TaskError{TaskErrorMaterialQuantityInvalidCode}
+	}
+
+	if completedQuantity < 0 {
+		return TaskError{TaskErrorCompletedQuantityInvalidCode}
+	}
+
 	completedTime := time.Now()
 
 	t.TrackChange(TaskCompleted{
-		UID:           t.UID,
-		Status:        TaskCompletedCode,
-		CompletedDate: &completedTime,
+		UID:               t.UID,
+		Status:            TaskCompletedCode,
+		CompletedDate:     &completedTime,
+		CompletedBy:       completedBy,
+		LabourMinutes:     t.LabourMinutes + labourMinutes,
+		MaterialQuantity:  materialQuantity,
+		CompletedQuantity: t.CompletedQuantity + completedQuantity,
 	})
+
+	return nil
+}
+
+// UnitsPerHour is the rate of work of the task, zero without a completed quantity or labour minutes.
+func UnitsPerHour(completedQuantity float64, labourMinutes int) float64 {
+	if completedQuantity <= 0 || labourMinutes <= 0 {
+		return 0
+	}
+
+	return completedQuantity * 60 / float64(labourMinutes)
 }
 
-// CompleteTask.
+// CancelTask cancels the task, a recurring task stops repeating.
 func (t *Task) CancelTask() {
+	if t.Recurrence != nil {
+		t.TrackChange(TaskRecurrenceSet{UID: t.UID})
+	}
+
 	cancelledTime := time.Now()
 
 	t.TrackChange(TaskCancelled{
@@ -223,6 +495,43 @@ func (t *Task) CancelTask() {
 	})
 }
 
+// AssignShortCode gives the task its permanent human friendly code, numbered in the sequence of the farm.
+func (t *Task) AssignShortCode(shortCode string, farmUID uuid.UUID) error {
+	if shortCode == "" {
+		return TaskError{TaskErrorShortCodeEmptyCode}
+	}
+
+	if t.ShortCode != "" {
+		return TaskError{TaskErrorShortCodeAlreadyAssignedCode}
+	}
+
+	t.TrackChange(TaskShortCodeAssigned{
+		UID:       t.UID,
+		ShortCode: shortCode,
+		FarmUID:   farmUID,
+	})
+
+	return nil
+}
+
+// ArchiveTask moves the closed task to the archive.
+func (t *Task) ArchiveTask() error {
+	if t.Status != TaskStatusCompleted && t.Status != TaskStatusCancelled {
+		return TaskError{TaskErrorArchiveNotClosedCode}
+	}
+
+	if t.ArchivedDate != nil {
+		return TaskError{TaskErrorAlreadyArchivedCode}
+	}
+
+	t.TrackChange(TaskArchived{
+		UID:          t.UID,
+		ArchivedDate: time.Now(),
+	})
+
+	return nil
+}
+
 // Event Tracking.
 func (t *Task) TrackChange(event interface{}) {
 	t.UncommittedChanges = append(t.UncommittedChanges, event)
@@ -244,26 +553,63 @@ func (t *Task) Transition(event interface{}) {
 		t.Category = e.Category
 		t.IsDue = e.IsDue
 		t.AssetID = e.AssetID
+		t.CostCentreID = e.CostCentreID
+		t.AffectedAreaIDs = e.AffectedAreaIDs
 	case TaskTitleChanged:
 		t.Title = e.Title
 	case TaskDescriptionChanged:
 		t.Description = e.Description
 	case TaskDueDateChanged:
 		t.DueDate = e.DueDate
+		t.DueDateAdjustedForBusinessHours = false
+	case TaskDueDateAdjustedForBusinessHours:
+		t.DueDate = e.DueDate
+		t.DueDateAdjustedForBusinessHours = true
 	case TaskPriorityChanged:
 		t.Priority = e.Priority
 	case TaskCategoryChanged:
 		t.Category = e.Category
 	case TaskDetailsChanged:
 		t.DomainDetails = e.DomainDetails
+	case TaskEstimatedMinutesChanged:
+		t.EstimatedMinutes = e.EstimatedMinutes
+	case TaskDependenciesChanged:
+		t.DependsOn = e.DependsOn
+	case TaskAreaProgressChanged:
+		if t.PerAreaProgress == nil {
+			t.PerAreaProgress = map[uuid.UUID]int{}
+		}
+
+		t.PerAreaProgress[e.AreaID] = e.Progress
+	case TaskTargetChanged:
+		t.TargetQuantity = e.TargetQuantity
+		t.TargetUnit = e.TargetUnit
+	case TaskProgressRecorded:
+		t.CompletedQuantity += e.Quantity
+		t.LabourMinutes += e.LabourMinutes
+	case TaskRecurrenceSet:
+		t.Recurrence = e.Recurrence
+
+		if e.RecurrenceOf != nil {
+			t.RecurrenceOf = e.RecurrenceOf
+		}
 	case TaskCancelled:
 		t.CancelledDate = e.CancelledDate
 		t.Status = TaskStatusCancelled
 	case TaskCompleted:
 		t.CompletedDate = e.CompletedDate
 		t.Status = TaskStatusCompleted
+		t.CompletedBy = e.CompletedBy
+		t.LabourMinutes = e.LabourMinutes
+		t.MaterialQuantity = e.MaterialQuantity
+		t.CompletedQuantity = e.CompletedQuantity
 	case TaskDue:
 		t.IsDue = true
+	case TaskShortCodeAssigned:
+		t.ShortCode = e.ShortCode
+		t.ShortCodeFarmUID = e.FarmUID
+	case TaskArchived:
+		t.ArchivedDate = &e.ArchivedDate
 	}
 }
 
@@ -298,32 +644,43 @@ func validateTaskDueDate(newdate *time.Time) error {
 	return nil
 }
 
-// validateTaskPriority.
-func validateTaskPriority(priority string) error {
-	if priority == "" {
-		return TaskError{TaskErrorPriorityEmptyCode}
-	}
-
-	_, err := FindTaskPriorityByCode(priority)
-	if err != nil {
-		return err
+// validateTaskCategory only checks the category is given, its code is checked against the catalog of the farm.
+func validateTaskCategory(taskcategory string) error {
+	if taskcategory == "" {
+		return TaskError{TaskErrorCategoryEmptyCode}
 	}
 
 	return nil
 }
 
-// validateTaskCategory.
-func validateTaskCategory(taskcategory string) error {
-	if taskcategory == "" {
-		return TaskError{TaskErrorCategoryEmptyCode}
+// validateAffectedAreaIDs checks the areas exist and returns them without the repeated ones, nil when there is none.
+func validateAffectedAreaIDs(taskService TaskService, affectedAreaIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(affectedAreaIDs) == 0 {
+		return nil, nil
 	}
 
-	_, err := FindTaskCategoryByCode(taskcategory)
-	if err != nil {
-		return err
+	distinct := []uuid.UUID{}
+	seen := map[uuid.UUID]bool{}
+
+	for _, v := range affectedAreaIDs {
+		if seen[v] {
+			continue
+		}
+
+		serviceResult := taskService.FindAreaByID(v)
+		if _, ok := serviceResult.Error.(TaskError); ok {
+			return nil, TaskError{TaskErrorInvalidAreaIDCode}
+		}
+
+		if serviceResult.Error != nil {
+			return nil, serviceResult.Error
+		}
+
+		seen[v] = true
+		distinct = append(distinct, v)
 	}
 
-	return nil
+	return distinct, nil
 }
 
 // validateAssetID.
@@ -357,6 +714,12 @@ func validateAssetID(taskService TaskService, assetid *uuid.UUID, taskdomain str
 		case TaskDomainReservoirCode:
 			serviceResult := taskService.FindReservoirByID(*assetid)
 
+			if serviceResult.Error != nil {
+				return serviceResult.Error
+			}
+		case TaskDomainEquipmentCode:
+			serviceResult := taskService.FindEquipmentByID(*assetid)
+
 			if serviceResult.Error != nil {
 				return serviceResult.Error
 			}
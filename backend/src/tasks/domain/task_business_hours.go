@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var businessDays = map[string]time.Weekday{
+	"SUN": time.Sunday,
+	"MON": time.Monday,
+	"TUE": time.Tuesday,
+	"WED": time.Wednesday,
+	"THU": time.Thursday,
+	"FRI": time.Friday,
+	"SAT": time.Saturday,
+}
+
+// BusinessHoursConfig is when a farm operates, on its days from their start to their end time.
+// A farm without business days operates all the time.
+type BusinessHoursConfig struct {
+	Days []time.Weekday
+
+	// The start and the end time, since midnight.
+	Start time.Duration
+	End   time.Duration
+
+	Location *time.Location
+}
+
+// NewBusinessHoursConfig parses the days, like MON and FRI, and the start and end times, like 08:00 and 17:00.
+func NewBusinessHoursConfig(days []string, start, end string, location *time.Location) (BusinessHoursConfig, error) {
+	config := BusinessHoursConfig{Location: location}
+
+	for _, v := range days {
+		day, ok := businessDays[strings.ToUpper(strings.TrimSpace(v))]
+		if !ok {
+			return BusinessHoursConfig{}, fmt.Errorf("business day %q should be one of SUN, MON, TUE, WED, THU, FRI, SAT", v)
+		}
+
+		config.Days = append(config.Days, day)
+	}
+
+	var err error
+
+	config.Start, err = parseTimeOfDay(start)
+	if err != nil {
+		return BusinessHoursConfig{}, err
+	}
+
+	config.End, err = parseTimeOfDay(end)
+	if err != nil {
+		return BusinessHoursConfig{}, err
+	}
+
+	if config.End <= config.Start {
+		return BusinessHoursConfig{}, fmt.Errorf("business hours should end after %s", start)
+	}
+
+	return config, nil
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("business hour %q should be written like 08:00", value)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains tells whether the farm operates at the date, its end time included.
+func (c BusinessHoursConfig) Contains(date time.Time) bool {
+	if len(c.Days) == 0 {
+		return true
+	}
+
+	local := date.In(c.location())
+	if !c.isBusinessDay(local.Weekday()) {
+		return false
+	}
+
+	sinceMidnight := local.Sub(time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location()))
+
+	return sinceMidnight >= c.Start && sinceMidnight <= c.End
+}
+
+// NextStart is the first start of a business day after the date.
+func (c BusinessHoursConfig) NextStart(date time.Time) time.Time {
+	local := date.In(c.location())
+
+	for i := 0; len(c.Days) > 0 && i <= 7; i++ {
+		start := time.Date(local.Year(), local.Month(), local.Day()+i, 0, 0, 0, 0, local.Location()).Add(c.Start)
+
+		if c.isBusinessDay(start.Weekday()) && start.After(local) {
+			return start
+		}
+	}
+
+	return date
+}
+
+func (c BusinessHoursConfig) isBusinessDay(weekday time.Weekday) bool {
+	for _, v := range c.Days {
+		if v == weekday {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c BusinessHoursConfig) location() *time.Location {
+	if c.Location == nil {
+		return time.Local
+	}
+
+	return c.Location
+}
+
+// AdjustDueDateForBusinessHours moves the due date falling outside the business hours of the farm to the start
+// of its next business day, like the Saturday morning of a farm closed on the weekends to Monday morning.
+// It returns false when the due date is kept.
+func (t *Task) AdjustDueDateForBusinessHours(hours BusinessHoursConfig) bool {
+	if t.DueDate == nil || hours.Contains(*t.DueDate) {
+		return false
+	}
+
+	dueDate := hours.NextStart(*t.DueDate)
+
+	t.TrackChange(TaskDueDateAdjustedForBusinessHours{
+		UID:             t.UID,
+		DueDate:         &dueDate,
+		OriginalDueDate: t.DueDate,
+	})
+
+	return true
+}
@@ -0,0 +1,123 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+// nextWeekday is the weekday at the hour, in the coming weeks so its task can be due then.
+func nextWeekday(weekday time.Weekday, hour, minute int) time.Time {
+	date := time.Now().UTC().AddDate(0, 0, 7)
+	for date.Weekday() != weekday {
+		date = date.AddDate(0, 0, 1)
+	}
+
+	return time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, time.UTC)
+}
+
+func TestAdjustDueDateForBusinessHours(t *testing.T) {
+	t.Parallel()
+	// Given
+	hours, err := NewBusinessHoursConfig([]string{"MON", "TUE", "WED", "THU", "FRI"}, "08:00", "17:00", time.UTC)
+	assert.Nil(t, err)
+
+	fridayEvening := nextWeekday(time.Friday, 18, 0)
+	saturdayMorning := nextWeekday(time.Saturday, 9, 0)
+	tuesdayEarly := nextWeekday(time.Tuesday, 6, 30)
+	wednesdayNoon := nextWeekday(time.Wednesday, 12, 0)
+	thursdayClosing := nextWeekday(time.Thursday, 17, 0)
+
+	uid, _ := uuid.NewV4()
+	fridayTask := &Task{UID: uid, DueDate: &fridayEvening}
+	saturdayTask := &Task{UID: uid, DueDate: &saturdayMorning}
+	tuesdayTask := &Task{UID: uid, DueDate: &tuesdayEarly}
+	wednesdayTask := &Task{UID: uid, DueDate: &wednesdayNoon}
+	thursdayTask := &Task{UID: uid, DueDate: &thursdayClosing}
+
+	// When
+	fridayAdjusted := fridayTask.AdjustDueDateForBusinessHours(hours)
+	saturdayAdjusted := saturdayTask.AdjustDueDateForBusinessHours(hours)
+	tuesdayAdjusted := tuesdayTask.AdjustDueDateForBusinessHours(hours)
+	wednesdayAdjusted := wednesdayTask.AdjustDueDateForBusinessHours(hours)
+	thursdayAdjusted := thursdayTask.AdjustDueDateForBusinessHours(hours)
+
+	// Then
+	monday := fridayEvening.AddDate(0, 0, 3)
+
+	assert.True(t, fridayAdjusted)
+	assert.Equal(t, time.Date(monday.Year(), monday.Month(), monday.Day(), 8, 0, 0, 0, time.UTC), *fridayTask.DueDate)
+	assert.True(t, fridayTask.DueDateAdjustedForBusinessHours)
+	assert.Len(t, fridayTask.UncommittedChanges, 1)
+
+	assert.True(t, saturdayAdjusted)
+	assert.Equal(t, time.Monday, saturdayTask.DueDate.Weekday())
+	assert.Equal(t, 8, saturdayTask.DueDate.Hour())
+
+	assert.True(t, tuesdayAdjusted)
+	assert.Equal(t, time.Date(tuesdayEarly.Year(), tuesdayEarly.Month(), tuesdayEarly.Day(), 8, 0, 0, 0, time.UTC),
+		*tuesdayTask.DueDate)
+
+	assert.False(t, wednesdayAdjusted)
+	assert.Equal(t, wednesdayNoon, *wednesdayTask.DueDate)
+	assert.False(t, wednesdayTask.DueDateAdjustedForBusinessHours)
+	assert.Empty(t, wednesdayTask.UncommittedChanges)
+
+	assert.False(t, thursdayAdjusted)
+}
+
+func TestChangeDueDateResetsTheBusinessHoursAdjustment(t *testing.T) {
+	t.Parallel()
+	// Given
+	hours, _ := NewBusinessHoursConfig([]string{"MON", "TUE", "WED", "THU", "FRI"}, "08:00", "17:00", time.UTC)
+	saturday := nextWeekday(time.Saturday, 9, 0)
+	sunday := nextWeekday(time.Sunday, 9, 0)
+
+	uid, _ := uuid.NewV4()
+	task := &Task{UID: uid, DueDate: &saturday}
+	task.AdjustDueDateForBusinessHours(hours)
+
+	// When
+	_, err := task.ChangeTaskDueDate(&sunday)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, sunday, *task.DueDate)
+	assert.False(t, task.DueDateAdjustedForBusinessHours)
+}
+
+func TestBusinessHoursWithoutDays(t *testing.T) {
+	t.Parallel()
+	// Given
+	hours, err := NewBusinessHoursConfig(nil, "08:00", "17:00", time.UTC)
+	saturday := nextWeekday(time.Saturday, 23, 0)
+	task := &Task{DueDate: &saturday}
+
+	// When
+	adjusted := task.AdjustDueDateForBusinessHours(hours)
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, hours.Contains(saturday))
+	assert.False(t, adjusted)
+	assert.Equal(t, saturday, *task.DueDate)
+}
+
+func TestInvalidBusinessHours(t *testing.T) {
+	t.Parallel()
+	// When
+	_, dayErr := NewBusinessHoursConfig([]string{"MON", "FUNDAY"}, "08:00", "17:00", time.UTC)
+	_, startErr := NewBusinessHoursConfig([]string{"MON"}, "8 o'clock", "17:00", time.UTC)
+	_, endErr := NewBusinessHoursConfig([]string{"MON"}, "17:00", "08:00", time.UTC)
+	lowercase, lowercaseErr := NewBusinessHoursConfig([]string{" mon", "fri "}, "08:00", "17:00", time.UTC)
+
+	// Then
+	assert.NotNil(t, dayErr)
+	assert.NotNil(t, startErr)
+	assert.NotNil(t, endErr)
+	assert.Nil(t, lowercaseErr)
+	assert.Equal(t, []time.Weekday{time.Monday, time.Friday}, lowercase.Days)
+}
@@ -0,0 +1,295 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+var (
+	taskCatalogCodePattern  = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	taskCatalogColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+	taskCatalogIconPattern  = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+)
+
+// TaskCatalog is the list of the task priorities and categories of a farm, what the tasks of the farm are
+// validated against. A farm which never changed its catalog has the built-in one.
+type TaskCatalog struct {
+	// UID is the UID of the farm.
+	UID uuid.UUID `json:"farm_id"`
+
+	// Priorities are ordered from the most urgent.
+	Priorities []TaskPriority `json:"priorities"`
+	Categories []TaskCategory `json:"categories"`
+
+	// ArchiveAfterDays is the archival policy of the farm, its closed tasks are archived this many days after
+	// they are closed. Nil keeps the days of the configuration, zero never archives them.
+	ArchiveAfterDays *int `json:"archive_after_days"`
+
+	// Events
+	Version            int           `json:"-"`
+	UncommittedChanges []interface{} `json:"-"`
+}
+
+// DefaultTaskCatalog is the built-in catalog, the one of the tasks outside of a farm and of the task templates.
+func DefaultTaskCatalog(farmUID uuid.UUID) *TaskCatalog {
+	return &TaskCatalog{
+		UID:        farmUID,
+		Priorities: FindAllTaskPriority(),
+		Categories: FindAllTaskCategories(),
+	}
+}
+
+func (c *TaskCatalog) FindPriority(code string) (TaskPriority, bool) {
+	for _, v := range c.Priorities {
+		if v.Code == code {
+			return v, true
+		}
+	}
+
+	return TaskPriority{}, false
+}
+
+func (c *TaskCatalog) FindCategory(code string) (TaskCategory, bool) {
+	for _, v := range c.Categories {
+		if v.Code == code {
+			return v, true
+		}
+	}
+
+	return TaskCategory{}, false
+}
+
+// ValidatePriority checks the priority of a task is in the catalog.
+func (c *TaskCatalog) ValidatePriority(priority string) error {
+	if priority == "" {
+		return TaskError{TaskErrorPriorityEmptyCode}
+	}
+
+	if _, found := c.FindPriority(priority); !found {
+		return TaskError{TaskErrorInvalidPriorityCode}
+	}
+
+	return nil
+}
+
+// ValidateCategory checks the category of a task is in the catalog.
+func (c *TaskCatalog) ValidateCategory(category string) error {
+	if category == "" {
+		return TaskError{TaskErrorCategoryEmptyCode}
+	}
+
+	if _, found := c.FindCategory(category); !found {
+		return TaskError{TaskErrorInvalidCategoryCode}
+	}
+
+	return nil
+}
+
+// SavePriority adds the priority or changes the one with its code. The position is the rank of the priority,
+// 1 being the most urgent. The position 0 keeps an existing priority where it is and adds a new one last.
+func (c *TaskCatalog) SavePriority(priority TaskPriority, position int) error {
+	if !taskCatalogCodePattern.MatchString(priority.Code) {
+		return TaskError{TaskCatalogErrorCodeInvalidCode}
+	}
+
+	if priority.Name == "" {
+		return TaskError{TaskCatalogErrorNameEmptyCode}
+	}
+
+	if !taskCatalogColorPattern.MatchString(priority.Color) {
+		return TaskError{TaskCatalogErrorColorInvalidCode}
+	}
+
+	if position < 0 {
+		return TaskError{TaskCatalogErrorPositionInvalidCode}
+	}
+
+	c.TrackChange(TaskCatalogPrioritySaved{
+		FarmUID:  c.UID,
+		Priority: priority,
+		Position: position,
+	})
+
+	return nil
+}
+
+// SaveCategory adds the category or changes the one with its code.
+func (c *TaskCatalog) SaveCategory(category TaskCategory) error {
+	if !taskCatalogCodePattern.MatchString(category.Code) {
+		return TaskError{TaskCatalogErrorCodeInvalidCode}
+	}
+
+	if category.Name == "" {
+		return TaskError{TaskCatalogErrorNameEmptyCode}
+	}
+
+	if !taskCatalogIconPattern.MatchString(category.Icon) {
+		return TaskError{TaskCatalogErrorIconInvalidCode}
+	}
+
+	c.TrackChange(TaskCatalogCategorySaved{
+		FarmUID:  c.UID,
+		Category: category,
+	})
+
+	return nil
+}
+
+// RemovePriority removes the priority, which the usedBy tasks still have. They are moved to the migrateTo
+// priority, so a priority in use can't be removed without one.
+func (c *TaskCatalog) RemovePriority(code, migrateTo string, usedBy int) error {
+	if _, found := c.FindPriority(code); !found {
+		return TaskError{TaskCatalogErrorEntryNotFoundCode}
+	}
+
+	if len(c.Priorities) == 1 {
+		return TaskError{TaskCatalogErrorLastEntryCode}
+	}
+
+	if migrateTo != "" {
+		if _, found := c.FindPriority(migrateTo); !found || migrateTo == code {
+			return TaskError{TaskCatalogErrorMigrateToInvalidCode}
+		}
+	} else if usedBy > 0 {
+		return TaskError{TaskCatalogErrorEntryInUseCode}
+	}
+
+	c.TrackChange(TaskCatalogPriorityRemoved{
+		FarmUID:   c.UID,
+		Code:      code,
+		MigrateTo: migrateTo,
+	})
+
+	return nil
+}
+
+// RemoveCategory removes the category, which the usedBy tasks still have. They are moved to the migrateTo
+// category, so a category in use can't be removed without one.
+func (c *TaskCatalog) RemoveCategory(code, migrateTo string, usedBy int) error {
+	if _, found := c.FindCategory(code); !found {
+		return TaskError{TaskCatalogErrorEntryNotFoundCode}
+	}
+
+	if len(c.Categories) == 1 {
+		return TaskError{TaskCatalogErrorLastEntryCode}
+	}
+
+	if migrateTo != "" {
+		if _, found := c.FindCategory(migrateTo); !found || migrateTo == code {
+			return TaskError{TaskCatalogErrorMigrateToInvalidCode}
+		}
+	} else if usedBy > 0 {
+		return TaskError{TaskCatalogErrorEntryInUseCode}
+	}
+
+	c.TrackChange(TaskCatalogCategoryRemoved{
+		FarmUID:   c.UID,
+		Code:      code,
+		MigrateTo: migrateTo,
+	})
+
+	return nil
+}
+
+// ChangeArchivalPolicy sets the days after which the closed tasks of the farm are archived, nil removes the
+// policy of the farm.
+func (c *TaskCatalog) ChangeArchivalPolicy(archiveAfterDays *int) error {
+	if archiveAfterDays != nil && *archiveAfterDays < 0 {
+		return TaskError{TaskCatalogErrorArchiveAfterDaysInvalidCode}
+	}
+
+	c.TrackChange(TaskCatalogArchivalPolicyChanged{
+		FarmUID:          c.UID,
+		ArchiveAfterDays: archiveAfterDays,
+	})
+
+	return nil
+}
+
+// ArchivalCutoff returns the date the tasks of the farm closed before are archived at now, with the
+// defaultDays of the configuration when the farm has no policy. It's false when they aren't archived.
+func (c *TaskCatalog) ArchivalCutoff(defaultDays int, now time.Time) (time.Time, bool) {
+	days := defaultDays
+	if c.ArchiveAfterDays != nil {
+		days = *c.ArchiveAfterDays
+	}
+
+	if days <= 0 {
+		return time.Time{}, false
+	}
+
+	return now.AddDate(0, 0, -days), true
+}
+
+// Event Tracking.
+func (c *TaskCatalog) TrackChange(event interface{}) {
+	c.UncommittedChanges = append(c.UncommittedChanges, event)
+	c.Transition(event)
+}
+
+func (c *TaskCatalog) Transition(event interface{}) {
+	switch e := event.(type) {
+	case TaskCatalogPrioritySaved:
+		position := len(c.Priorities)
+		priorities := []TaskPriority{}
+
+		for i, v := range c.Priorities {
+			if v.Code == e.Priority.Code {
+				position = i
+
+				continue
+			}
+
+			priorities = append(priorities, v)
+		}
+
+		if e.Position > 0 {
+			position = e.Position - 1
+		}
+
+		if position > len(priorities) {
+			position = len(priorities)
+		}
+
+		priorities = append(priorities[:position], append([]TaskPriority{e.Priority}, priorities[position:]...)...)
+		c.Priorities = priorities
+
+	case TaskCatalogPriorityRemoved:
+		priorities := []TaskPriority{}
+
+		for _, v := range c.Priorities {
+			if v.Code != e.Code {
+				priorities = append(priorities, v)
+			}
+		}
+
+		c.Priorities = priorities
+
+	case TaskCatalogCategorySaved:
+		for i, v := range c.Categories {
+			if v.Code == e.Category.Code {
+				c.Categories[i] = e.Category
+
+				return
+			}
+		}
+
+		c.Categories = append(c.Categories, e.Category)
+
+	case TaskCatalogCategoryRemoved:
+		categories := []TaskCategory{}
+
+		for _, v := range c.Categories {
+			if v.Code != e.Code {
+				categories = append(categories, v)
+			}
+		}
+
+		c.Categories = categories
+
+	case TaskCatalogArchivalPolicyChanged:
+		c.ArchiveAfterDays = e.ArchiveAfterDays
+	}
+}
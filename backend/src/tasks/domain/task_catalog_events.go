@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+const (
+	TaskCatalogPrioritySavedCode   = "TaskCatalogPrioritySaved"
+	TaskCatalogPriorityRemovedCode = "TaskCatalogPriorityRemoved"
+	TaskCatalogCategorySavedCode   = "TaskCatalogCategorySaved"
+	TaskCatalogCategoryRemovedCode = "TaskCatalogCategoryRemoved"
+
+	TaskCatalogArchivalPolicyChangedCode = "TaskCatalogArchivalPolicyChanged"
+)
+
+type TaskCatalogPrioritySaved struct {
+	FarmUID  uuid.UUID    `json:"farm_id"`
+	Priority TaskPriority `json:"priority"`
+	Position int          `json:"position"`
+}
+
+// TaskCatalogPriorityRemoved is recorded once the tasks with the priority are moved to MigrateTo.
+type TaskCatalogPriorityRemoved struct {
+	FarmUID   uuid.UUID `json:"farm_id"`
+	Code      string    `json:"code"`
+	MigrateTo string    `json:"migrate_to"`
+}
+
+type TaskCatalogCategorySaved struct {
+	FarmUID  uuid.UUID    `json:"farm_id"`
+	Category TaskCategory `json:"category"`
+}
+
+// TaskCatalogCategoryRemoved is recorded once the tasks with the category are moved to MigrateTo.
+type TaskCatalogCategoryRemoved struct {
+	FarmUID   uuid.UUID `json:"farm_id"`
+	Code      string    `json:"code"`
+	MigrateTo string    `json:"migrate_to"`
+}
+
+// TaskCatalogArchivalPolicyChanged sets the days after which the closed tasks of the farm are archived,
+// nil for the days of the configuration.
+type TaskCatalogArchivalPolicyChanged struct {
+	FarmUID          uuid.UUID `json:"farm_id"`
+	ArchiveAfterDays *int      `json:"archive_after_days"`
+}
@@ -0,0 +1,110 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+func TestTaskCatalogPriorities(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	catalog := DefaultTaskCatalog(farmUID)
+
+	// When
+	criticalErr := catalog.SavePriority(TaskPriority{Code: "CRITICAL", Name: "Critical", Color: "#B71C1C"}, 1)
+	lowErr := catalog.SavePriority(TaskPriority{Code: TaskPriorityLow, Name: "Whenever", Color: "#9E9E9E"}, 0)
+	codeErr := catalog.SavePriority(TaskPriority{Code: "very low", Name: "Very low", Color: "#9E9E9E"}, 0)
+	colorErr := catalog.SavePriority(TaskPriority{Code: "VERY_LOW", Name: "Very low", Color: "grey"}, 0)
+
+	inUseErr := catalog.RemovePriority(TaskPriorityNormal, "", 3)
+	migrateToErr := catalog.RemovePriority(TaskPriorityNormal, TaskPriorityNormal, 3)
+	removeErr := catalog.RemovePriority(TaskPriorityNormal, TaskPriorityLow, 3)
+
+	// Then
+	assert.Nil(t, criticalErr)
+	assert.Nil(t, lowErr)
+	assert.Equal(t, TaskError{TaskCatalogErrorCodeInvalidCode}, codeErr)
+	assert.Equal(t, TaskError{TaskCatalogErrorColorInvalidCode}, colorErr)
+	assert.Equal(t, TaskError{TaskCatalogErrorEntryInUseCode}, inUseErr)
+	assert.Equal(t, TaskError{TaskCatalogErrorMigrateToInvalidCode}, migrateToErr)
+	assert.Nil(t, removeErr)
+
+	assert.Equal(t, []TaskPriority{
+		{Code: "CRITICAL", Name: "Critical", Color: "#B71C1C"},
+		{Code: TaskPriorityUrgent, Name: "Urgent", Color: "#E53935"},
+		{Code: TaskPriorityLow, Name: "Whenever", Color: "#9E9E9E"},
+	}, catalog.Priorities)
+	assert.Nil(t, catalog.ValidatePriority("CRITICAL"))
+	assert.Equal(t, TaskError{TaskErrorInvalidPriorityCode}, catalog.ValidatePriority(TaskPriorityNormal))
+	assert.Len(t, catalog.UncommittedChanges, 3)
+}
+
+func TestTaskCatalogCategories(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	catalog := DefaultTaskCatalog(farmUID)
+
+	// When
+	saveErr := catalog.SaveCategory(TaskCategory{Code: "IRRIGATION", Name: "Irrigation", Icon: "water-drop"})
+	iconErr := catalog.SaveCategory(TaskCategory{Code: "PRUNING", Name: "Pruning", Icon: "Scissors"})
+	nameErr := catalog.SaveCategory(TaskCategory{Code: "PRUNING", Icon: "scissors"})
+	unusedErr := catalog.RemoveCategory(TaskCategoryFinance, "", 0)
+	notFoundErr := catalog.RemoveCategory("PRUNING", "", 0)
+
+	// Then
+	assert.Nil(t, saveErr)
+	assert.Equal(t, TaskError{TaskCatalogErrorIconInvalidCode}, iconErr)
+	assert.Equal(t, TaskError{TaskCatalogErrorNameEmptyCode}, nameErr)
+	assert.Nil(t, unusedErr)
+	assert.Equal(t, TaskError{TaskCatalogErrorEntryNotFoundCode}, notFoundErr)
+
+	assert.Nil(t, catalog.ValidateCategory("IRRIGATION"))
+	assert.Equal(t, TaskError{TaskErrorInvalidCategoryCode}, catalog.ValidateCategory(TaskCategoryFinance))
+	assert.Equal(t, TaskError{TaskErrorInvalidCategoryCode}, DefaultTaskCatalog(farmUID).ValidateCategory("IRRIGATION"))
+}
+
+func TestTaskCatalogArchivalPolicy(t *testing.T) {
+	t.Parallel()
+	// Given
+	farmUID, _ := uuid.NewV4()
+	catalog := DefaultTaskCatalog(farmUID)
+	now := time.Date(2024, time.March, 31, 2, 0, 0, 0, time.UTC)
+
+	thirty, never, negative := 30, 0, -1
+
+	// When
+	defaultCutoff, defaultArchived := catalog.ArchivalCutoff(90, now)
+
+	negativeErr := catalog.ChangeArchivalPolicy(&negative)
+	thirtyErr := catalog.ChangeArchivalPolicy(&thirty)
+	thirtyCutoff, thirtyArchived := catalog.ArchivalCutoff(90, now)
+
+	neverErr := catalog.ChangeArchivalPolicy(&never)
+	_, neverArchived := catalog.ArchivalCutoff(90, now)
+
+	removeErr := catalog.ChangeArchivalPolicy(nil)
+	_, disabledArchived := catalog.ArchivalCutoff(0, now)
+
+	// Then
+	assert.True(t, defaultArchived)
+	assert.Equal(t, time.Date(2024, time.January, 1, 2, 0, 0, 0, time.UTC), defaultCutoff)
+
+	assert.Equal(t, TaskError{TaskCatalogErrorArchiveAfterDaysInvalidCode}, negativeErr)
+	assert.Nil(t, thirtyErr)
+	assert.True(t, thirtyArchived)
+	assert.Equal(t, time.Date(2024, time.March, 1, 2, 0, 0, 0, time.UTC), thirtyCutoff)
+
+	assert.Nil(t, neverErr)
+	assert.False(t, neverArchived)
+
+	assert.Nil(t, removeErr)
+	assert.Nil(t, catalog.ArchiveAfterDays)
+	assert.False(t, disabledArchived)
+	assert.Len(t, catalog.UncommittedChanges, 3)
+}
@@ -13,23 +13,25 @@ const (
 	TaskCategorySanitation  = "SANITATION"
 )
 
+// TaskCategory is a category of the tasks, with the key of the icon the clients show it with.
 type TaskCategory struct {
-	Code string
-	Name string
+	Code string `json:"code"`
+	Name string `json:"name"`
+	Icon string `json:"icon"`
 }
 
 func FindAllTaskCategories() []TaskCategory {
 	return []TaskCategory{
-		{Code: TaskCategoryArea, Name: "Area"},
-		{Code: TaskCategoryCrop, Name: "Crop"},
-		{Code: TaskCategoryFinance, Name: "Finance"},
-		{Code: TaskCategoryGeneral, Name: "General"},
-		{Code: TaskCategoryInventory, Name: "Inventory"},
-		{Code: TaskCategoryNutrient, Name: "Nutrient"},
-		{Code: TaskCategoryPestControl, Name: "Pest Control"},
-		{Code: TaskCategoryReservoir, Name: "Reservoir"},
-		{Code: TaskCategorySafety, Name: "Safety"},
-		{Code: TaskCategorySanitation, Name: "Sanitation"},
+		{Code: TaskCategoryArea, Name: "Area", Icon: "area"},
+		{Code: TaskCategoryCrop, Name: "Crop", Icon: "crop"},
+		{Code: TaskCategoryFinance, Name: "Finance", Icon: "finance"},
+		{Code: TaskCategoryGeneral, Name: "General", Icon: "general"},
+		{Code: TaskCategoryInventory, Name: "Inventory", Icon: "inventory"},
+		{Code: TaskCategoryNutrient, Name: "Nutrient", Icon: "nutrient"},
+		{Code: TaskCategoryPestControl, Name: "Pest Control", Icon: "pest-control"},
+		{Code: TaskCategoryReservoir, Name: "Reservoir", Icon: "reservoir"},
+		{Code: TaskCategorySafety, Name: "Safety", Icon: "safety"},
+		{Code: TaskCategorySanitation, Name: "Sanitation", Icon: "sanitation"},
 	}
 }
 
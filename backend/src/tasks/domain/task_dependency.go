@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// TaskDependencyNode is a task of the dependency graph, with the minutes it takes.
+type TaskDependencyNode struct {
+	UID       uuid.UUID
+	DependsOn []uuid.UUID
+	Minutes   int
+}
+
+// DependencyCycle tells whether the task depending on the tasks of dependsOn closes a cycle, one of them
+// depending on the task through the dependencies of the graph.
+func DependencyCycle(graph map[uuid.UUID][]uuid.UUID, taskUID uuid.UUID, dependsOn []uuid.UUID) bool {
+	visited := map[uuid.UUID]bool{}
+	pending := append([]uuid.UUID{}, dependsOn...)
+
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if current == taskUID {
+			return true
+		}
+
+		if visited[current] {
+			continue
+		}
+
+		visited[current] = true
+		pending = append(pending, graph[current]...)
+	}
+
+	return false
+}
+
+// CriticalPath returns the chain of dependent tasks taking the most minutes, from its first task to its last.
+// The dependencies on the tasks out of the nodes, and the ones closing a cycle, are ignored.
+func CriticalPath(nodes []TaskDependencyNode) []uuid.UUID {
+	byUID := make(map[uuid.UUID]TaskDependencyNode, len(nodes))
+	for _, v := range nodes {
+		byUID[v.UID] = v
+	}
+
+	// The longest chain ending with each task, and the task before it in the chain.
+	total := map[uuid.UUID]int{}
+	previous := map[uuid.UUID]uuid.UUID{}
+	visiting := map[uuid.UUID]bool{}
+
+	var longest func(uid uuid.UUID) int
+
+	longest = func(uid uuid.UUID) int {
+		if minutes, ok := total[uid]; ok {
+			return minutes
+		}
+
+		if visiting[uid] {
+			return 0
+		}
+
+		visiting[uid] = true
+		defer delete(visiting, uid)
+
+		best := 0
+
+		for _, v := range byUID[uid].DependsOn {
+			if _, ok := byUID[v]; !ok {
+				continue
+			}
+
+			if minutes := longest(v); minutes > best {
+				best = minutes
+				previous[uid] = v
+			}
+		}
+
+		total[uid] = best + byUID[uid].Minutes
+
+		return total[uid]
+	}
+
+	last := uuid.Nil
+	best := -1
+
+	// The nodes are walked in their order, so the ties are broken the same way on each call.
+	for _, v := range nodes {
+		if minutes := longest(v.UID); minutes > best {
+			best = minutes
+			last = v.UID
+		}
+	}
+
+	if last == uuid.Nil {
+		return []uuid.UUID{}
+	}
+
+	path := []uuid.UUID{last}
+	for uid, ok := previous[last]; ok; uid, ok = previous[uid] {
+		path = append([]uuid.UUID{uid}, path...)
+	}
+
+	return path
+}
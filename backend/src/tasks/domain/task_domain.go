@@ -11,6 +11,7 @@ const (
 	TaskDomainGeneralCode   = "GENERAL"
 	TaskDomainInventoryCode = "INVENTORY"
 	TaskDomainReservoirCode = "RESERVOIR"
+	TaskDomainEquipmentCode = "EQUIPMENT"
 )
 
 type TaskDomain interface {
@@ -66,6 +67,15 @@ func (TaskDomainReservoir) Code() string {
 	return TaskDomainReservoirCode
 }
 
+// EQUIPMENT.
+type TaskDomainEquipment struct {
+	EquipmentID *uuid.UUID `json:"equipment_id"`
+}
+
+func (TaskDomainEquipment) Code() string {
+	return TaskDomainEquipmentCode
+}
+
 // CreateTaskDomainArea.
 func CreateTaskDomainArea(taskService TaskService, category string, materialID *uuid.UUID) (TaskDomainArea, error) {
 	err := validateTaskCategory(category)
@@ -145,3 +155,24 @@ func CreateTaskDomainReservoir(ts TaskService, category string, materialID *uuid
 		MaterialID: materialID,
 	}, nil
 }
+
+// CreateTaskDomainEquipment. The equipment is also the asset of the task, so its tasks can be found.
+func CreateTaskDomainEquipment(ts TaskService, category string, equipmentID *uuid.UUID) (TaskDomainEquipment, error) {
+	err := validateTaskCategory(category)
+	if err != nil {
+		return TaskDomainEquipment{}, err
+	}
+
+	if equipmentID == nil {
+		return TaskDomainEquipment{}, TaskError{TaskErrorInvalidAssetIDCode}
+	}
+
+	err = validateAssetID(ts, equipmentID, TaskDomainEquipmentCode)
+	if err != nil {
+		return TaskDomainEquipment{}, err
+	}
+
+	return TaskDomainEquipment{
+		EquipmentID: equipmentID,
+	}, nil
+}
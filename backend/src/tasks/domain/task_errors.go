@@ -41,6 +41,65 @@ const (
 
 	// Task General Errors.
 	TaskErrorTaskNotFoundCode
+
+	// Short Code Errors.
+	TaskErrorShortCodeEmptyCode
+	TaskErrorShortCodeAlreadyAssignedCode
+
+	// Task Template Errors.
+	TaskTemplateErrorNameEmptyCode
+	TaskTemplateErrorItemInvalidCode
+	TaskTemplateErrorNotFoundCode
+	TaskTemplateErrorCircularInheritanceCode
+
+	// Completion Errors.
+	TaskErrorLabourMinutesInvalidCode
+	TaskErrorMaterialQuantityInvalidCode
+
+	// Sync Errors.
+	TaskErrorBaseVersionInvalidCode
+
+	// Archive Errors.
+	TaskErrorArchiveNotClosedCode
+	TaskErrorAlreadyArchivedCode
+
+	// Scheduling Errors.
+	TaskErrorEstimatedMinutesInvalidCode
+
+	// Dependency Errors.
+	TaskErrorDependencySelfCode
+	TaskErrorDependencyCycleCode
+
+	// Area Progress Errors.
+	TaskErrorAreaNotAffectedCode
+	TaskErrorAreaProgressInvalidCode
+	TaskErrorAreaProgressClosedCode
+
+	// Quantity Errors.
+	TaskErrorTargetQuantityInvalidCode
+	TaskErrorTargetUnitEmptyCode
+	TaskErrorCompletedQuantityInvalidCode
+	TaskErrorProgressQuantityInvalidCode
+	TaskErrorProgressClosedCode
+
+	// Recurrence Errors.
+	TaskErrorRecurrenceFrequencyInvalidCode
+	TaskErrorRecurrenceIntervalInvalidCode
+	TaskErrorRecurrenceDueDateRequiredCode
+	TaskErrorRecurrenceEndDateInvalidCode
+	TaskErrorRecurrenceClosedCode
+
+	// Task Catalog Errors.
+	TaskCatalogErrorCodeInvalidCode
+	TaskCatalogErrorNameEmptyCode
+	TaskCatalogErrorColorInvalidCode
+	TaskCatalogErrorIconInvalidCode
+	TaskCatalogErrorPositionInvalidCode
+	TaskCatalogErrorEntryNotFoundCode
+	TaskCatalogErrorLastEntryCode
+	TaskCatalogErrorEntryInUseCode
+	TaskCatalogErrorMigrateToInvalidCode
+	TaskCatalogErrorArchiveAfterDaysInvalidCode
 )
 
 // TaskError is a custom error from Go built-in error.
@@ -88,6 +147,80 @@ func (e TaskError) Error() string {
 		return "Task area reference is invalid."
 	case TaskErrorTaskNotFoundCode:
 		return "Task not found"
+	case TaskErrorShortCodeEmptyCode:
+		return "Task short code is required."
+	case TaskErrorShortCodeAlreadyAssignedCode:
+		return "Task short code has already been assigned."
+	case TaskTemplateErrorNameEmptyCode:
+		return "Task template name is required."
+	case TaskTemplateErrorItemInvalidCode:
+		return "Task template items need a title, or the ID of the inherited item to remove."
+	case TaskTemplateErrorNotFoundCode:
+		return "Task template not found."
+	case TaskTemplateErrorCircularInheritanceCode:
+		return "Task template cannot extend itself or one of its children."
+	case TaskErrorLabourMinutesInvalidCode:
+		return "Task labour minutes cannot be negative."
+	case TaskErrorMaterialQuantityInvalidCode:
+		return "Task material quantity cannot be negative."
+	case TaskErrorBaseVersionInvalidCode:
+		return "Task base version is invalid."
+	case TaskErrorArchiveNotClosedCode:
+		return "Only completed or cancelled tasks can be archived."
+	case TaskErrorAlreadyArchivedCode:
+		return "Task has already been archived."
+	case TaskErrorEstimatedMinutesInvalidCode:
+		return "Task estimated minutes cannot be negative."
+	case TaskErrorDependencySelfCode:
+		return "Task cannot depend on itself."
+	case TaskErrorDependencyCycleCode:
+		return "Task cannot depend on a task which already depends on it."
+	case TaskErrorAreaNotAffectedCode:
+		return "Task does not cover this area."
+	case TaskErrorAreaProgressInvalidCode:
+		return "Task area progress has to be between 0 and 100."
+	case TaskErrorAreaProgressClosedCode:
+		return "Only the open tasks can have the progress of their areas updated."
+	case TaskErrorTargetQuantityInvalidCode:
+		return "Task target quantity cannot be negative."
+	case TaskErrorTargetUnitEmptyCode:
+		return "Task target unit is required with a target quantity."
+	case TaskErrorCompletedQuantityInvalidCode:
+		return "Task completed quantity cannot be negative."
+	case TaskErrorProgressQuantityInvalidCode:
+		return "Task progress quantity has to be more than zero."
+	case TaskErrorProgressClosedCode:
+		return "Only the open tasks can have their progress recorded."
+	case TaskErrorRecurrenceFrequencyInvalidCode:
+		return "Task repeat has to be DAILY, WEEKLY or MONTHLY."
+	case TaskErrorRecurrenceIntervalInvalidCode:
+		return "Task repeat interval cannot be negative."
+	case TaskErrorRecurrenceDueDateRequiredCode:
+		return "Task due date is required to repeat the task."
+	case TaskErrorRecurrenceEndDateInvalidCode:
+		return "Task repeat end date cannot be before its due date."
+	case TaskErrorRecurrenceClosedCode:
+		return "Only the open tasks can have their repeat changed."
+	case TaskCatalogErrorCodeInvalidCode:
+		return "Task catalog code has to be upper case letters, digits and underscores."
+	case TaskCatalogErrorNameEmptyCode:
+		return "Task catalog name is required."
+	case TaskCatalogErrorColorInvalidCode:
+		return "Task priority color has to be a hex color like #E53935."
+	case TaskCatalogErrorIconInvalidCode:
+		return "Task category icon has to be lower case letters, digits and dashes."
+	case TaskCatalogErrorPositionInvalidCode:
+		return "Task priority position cannot be negative."
+	case TaskCatalogErrorEntryNotFoundCode:
+		return "Task catalog entry not found."
+	case TaskCatalogErrorLastEntryCode:
+		return "Task catalog needs at least one priority and one category."
+	case TaskCatalogErrorEntryInUseCode:
+		return "Task catalog entry is still used by tasks, they have to be migrated to another entry."
+	case TaskCatalogErrorMigrateToInvalidCode:
+		return "Task catalog entry to migrate to has to be another entry of the catalog."
+	case TaskCatalogErrorArchiveAfterDaysInvalidCode:
+		return "Task archival days cannot be negative."
 	default:
 		return "Unrecognized Task Error Code"
 	}
@@ -7,23 +7,35 @@ import (
 )
 
 const (
-	TaskCreatedCode            = "TaskCreated"
-	TaskTitleChangedCode       = "TaskTitleChanged"
-	TaskDescriptionChangedCode = "TaskDescriptionChanged"
-	TaskPriorityChangedCode    = "TaskPriorityChanged"
-	TaskDueDateChangedCode     = "TaskDueDateChanged"
-	TaskCategoryChangedCode    = "TaskCategoryChanged"
-	TaskDetailsChangedCode     = "TaskDetailsChanged"
-	TaskAssetIDChangedCode     = "TaskAssetIDChanged"
-	TaskCompletedCode          = "TaskCompleted"
-	TaskCancelledCode          = "TaskCancelled"
-	TaskDueCode                = "TaskDue"
+	TaskCreatedCode                 = "TaskCreated"
+	TaskTitleChangedCode            = "TaskTitleChanged"
+	TaskDescriptionChangedCode      = "TaskDescriptionChanged"
+	TaskPriorityChangedCode         = "TaskPriorityChanged"
+	TaskDueDateChangedCode          = "TaskDueDateChanged"
+	TaskCategoryChangedCode         = "TaskCategoryChanged"
+	TaskDetailsChangedCode          = "TaskDetailsChanged"
+	TaskAssetIDChangedCode          = "TaskAssetIDChanged"
+	TaskCompletedCode               = "TaskCompleted"
+	TaskCancelledCode               = "TaskCancelled"
+	TaskDueCode                     = "TaskDue"
+	TaskShortCodeAssignedCode       = "TaskShortCodeAssigned"
+	TaskEditConflictedCode          = "TaskEditConflicted"
+	TaskEditConflictResolvedCode    = "TaskEditConflictResolved"
+	TaskArchivedCode                = "TaskArchived"
+	TaskEstimatedMinutesChangedCode = "TaskEstimatedMinutesChanged"
+	TaskDependenciesChangedCode     = "TaskDependenciesChanged"
+	TaskAreaProgressChangedCode     = "TaskAreaProgressChanged"
+	TaskTargetChangedCode           = "TaskTargetChanged"
+	TaskProgressRecordedCode        = "TaskProgressRecorded"
+	TaskRecurrenceSetCode           = "TaskRecurrenceSet"
+
+	TaskDueDateAdjustedForBusinessHoursCode = "TaskDueDateAdjustedForBusinessHours"
 )
 
 type TaskCreated struct {
 	UID           uuid.UUID  `json:"uid"`
 	Title         string     `json:"title"`
-	Description   string     `json:"description"`
+	Description   string     `json:"description" redact:"if:role!=Owner"`
 	CreatedDate   time.Time  `json:"created_date"`
 	DueDate       *time.Time `json:"due_date"`
 	Priority      string     `json:"priority"`
@@ -33,6 +45,10 @@ type TaskCreated struct {
 	Category      string     `json:"category"`
 	IsDue         bool       `json:"is_due"`
 	AssetID       *uuid.UUID `json:"asset_id"`
+	CostCentreID  *uuid.UUID `json:"cost_centre_id"`
+
+	// The areas covered by a task spanning several of them, nil for the task of a single area.
+	AffectedAreaIDs []uuid.UUID `json:"affected_area_ids"`
 }
 
 type TaskTitleChanged struct {
@@ -42,7 +58,7 @@ type TaskTitleChanged struct {
 
 type TaskDescriptionChanged struct {
 	UID         uuid.UUID `json:"uid"`
-	Description string    `json:"description"`
+	Description string    `json:"description" redact:"if:role!=Owner"`
 }
 
 type TaskPriorityChanged struct {
@@ -55,6 +71,13 @@ type TaskDueDateChanged struct {
 	DueDate *time.Time `json:"due_date"`
 }
 
+// TaskDueDateAdjustedForBusinessHours moved the due date of the new task out of the closed hours of its farm.
+type TaskDueDateAdjustedForBusinessHours struct {
+	UID             uuid.UUID  `json:"uid"`
+	DueDate         *time.Time `json:"due_date"`
+	OriginalDueDate *time.Time `json:"original_due_date"`
+}
+
 type TaskCategoryChanged struct {
 	UID      uuid.UUID `json:"uid"`
 	Category string    `json:"category"`
@@ -70,10 +93,58 @@ type TaskAssetIDChanged struct {
 	AssetID *uuid.UUID `json:"asset_id"`
 }
 
-type TaskCompleted struct {
+type TaskEstimatedMinutesChanged struct {
+	UID              uuid.UUID `json:"uid"`
+	EstimatedMinutes int       `json:"estimated_minutes"`
+}
+
+// TaskDependenciesChanged replaces the tasks which have to be closed before the task can start.
+type TaskDependenciesChanged struct {
+	UID       uuid.UUID   `json:"uid"`
+	DependsOn []uuid.UUID `json:"depends_on"`
+}
+
+// TaskAreaProgressChanged records the percentage of the work done in one of the areas of the task.
+type TaskAreaProgressChanged struct {
+	UID      uuid.UUID `json:"uid"`
+	AreaID   uuid.UUID `json:"area_id"`
+	Progress int       `json:"progress"`
+}
+
+// TaskTargetChanged sets the units of work of a piecework task, like 400 seedlings to transplant.
+type TaskTargetChanged struct {
+	UID            uuid.UUID `json:"uid"`
+	TargetQuantity float64   `json:"target_quantity"`
+	TargetUnit     string    `json:"target_unit"`
+}
+
+// TaskProgressRecorded records some of the units of work of a task done, with the minutes they took.
+type TaskProgressRecorded struct {
 	UID           uuid.UUID  `json:"uid"`
-	Status        string     `json:"status"`
-	CompletedDate *time.Time `json:"completed_date"`
+	Quantity      float64    `json:"quantity"`
+	LabourMinutes int        `json:"labour_minutes"`
+	RecordedBy    *uuid.UUID `json:"recorded_by"`
+	RecordedDate  time.Time  `json:"recorded_date"`
+}
+
+// TaskRecurrenceSet repeats the task with the rule, a nil rule stops repeating it. The next task of a series
+// has the task it was created from in RecurrenceOf.
+type TaskRecurrenceSet struct {
+	UID          uuid.UUID       `json:"uid"`
+	Recurrence   *TaskRecurrence `json:"recurrence"`
+	RecurrenceOf *uuid.UUID      `json:"recurrence_of"`
+}
+
+// TaskCompleted has the labour minutes and the completed quantity of the whole task,
+// the progress recorded before its completion included.
+type TaskCompleted struct {
+	UID               uuid.UUID  `json:"uid"`
+	Status            string     `json:"status"`
+	CompletedDate     *time.Time `json:"completed_date"`
+	CompletedBy       *uuid.UUID `json:"completed_by"`
+	LabourMinutes     int        `json:"labour_minutes"`
+	MaterialQuantity  float64    `json:"material_quantity"`
+	CompletedQuantity float64    `json:"completed_quantity"`
 }
 
 type TaskCancelled struct {
@@ -85,3 +156,39 @@ type TaskCancelled struct {
 type TaskDue struct {
 	UID uuid.UUID `json:"uid"`
 }
+
+// TaskShortCodeAssigned numbers the task in the sequence of its farm. The codes assigned before the sequences
+// of the farms have no farm, they were numbered in the global sequence.
+type TaskShortCodeAssigned struct {
+	UID       uuid.UUID `json:"uid"`
+	ShortCode string    `json:"short_code"`
+	FarmUID   uuid.UUID `json:"farm_uid"`
+}
+
+// TaskEditConflicted records an offline edit which was rejected because it overlaps the edits
+// synced by the other devices, so the conflicts show in the history of the task.
+type TaskEditConflicted struct {
+	UID          uuid.UUID  `json:"uid"`
+	BaseVersion  int        `json:"base_version"`
+	Fields       []string   `json:"fields"`
+	Base         TaskFields `json:"base"`
+	Theirs       TaskFields `json:"theirs"`
+	Mine         TaskFields `json:"mine"`
+	DetectedDate time.Time  `json:"detected_date"`
+}
+
+// TaskEditConflictResolved records how an offline edit was synced over the edits of the other devices,
+// either merged automatically or resolved by the client.
+type TaskEditConflictResolved struct {
+	UID          uuid.UUID `json:"uid"`
+	BaseVersion  int       `json:"base_version"`
+	Fields       []string  `json:"fields"`
+	Resolution   string    `json:"resolution"`
+	ResolvedDate time.Time `json:"resolved_date"`
+}
+
+// TaskArchived moves a closed task out of the task list into the archive.
+type TaskArchived struct {
+	UID          uuid.UUID `json:"uid"`
+	ArchivedDate time.Time `json:"archived_date"`
+}
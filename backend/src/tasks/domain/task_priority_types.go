@@ -3,17 +3,21 @@ package domain
 const (
 	TaskPriorityUrgent = "URGENT"
 	TaskPriorityNormal = "NORMAL"
+	TaskPriorityLow    = "LOW"
 )
 
+// TaskPriority is a priority level of the tasks, with the color the clients show it in.
 type TaskPriority struct {
-	Code string
-	Name string
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
 }
 
 func FindAllTaskPriority() []TaskPriority {
 	return []TaskPriority{
-		{Code: TaskPriorityUrgent, Name: "Urgent"},
-		{Code: TaskPriorityNormal, Name: "Normal"},
+		{Code: TaskPriorityUrgent, Name: "Urgent", Color: "#E53935"},
+		{Code: TaskPriorityNormal, Name: "Normal", Color: "#FB8C00"},
+		{Code: TaskPriorityLow, Name: "Low", Color: "#43A047"},
 	}
 }
 
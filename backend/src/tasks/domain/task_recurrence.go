@@ -0,0 +1,167 @@
+package domain
+
+import "time"
+
+const (
+	TaskRecurrenceDaily   = "DAILY"
+	TaskRecurrenceWeekly  = "WEEKLY"
+	TaskRecurrenceMonthly = "MONTHLY"
+)
+
+// TaskRecurrence repeats a task every interval days, weeks or months from its due date, until the end date if any.
+// The due dates of the series are counted from its start date, so the tasks repeated monthly from the 31st are
+// due on the last day of the shorter months and on the 31st again after them.
+type TaskRecurrence struct {
+	Frequency string     `json:"frequency"`
+	Interval  int        `json:"interval"`
+	EndDate   *time.Time `json:"end_date"`
+	StartDate *time.Time `json:"start_date"`
+}
+
+// CreateTaskRecurrence validates the repeat rule, a zero interval repeats the task every day, week or month.
+func CreateTaskRecurrence(frequency string, interval int, endDate *time.Time) (*TaskRecurrence, error) {
+	switch frequency {
+	case TaskRecurrenceDaily, TaskRecurrenceWeekly, TaskRecurrenceMonthly:
+	default:
+		return nil, TaskError{TaskErrorRecurrenceFrequencyInvalidCode}
+	}
+
+	if interval == 0 {
+		interval = 1
+	}
+
+	if interval < 0 {
+		return nil, TaskError{TaskErrorRecurrenceIntervalInvalidCode}
+	}
+
+	return &TaskRecurrence{Frequency: frequency, Interval: interval, EndDate: endDate}, nil
+}
+
+// occurrence is the date n intervals of the rule after the start date.
+func (r TaskRecurrence) occurrence(start time.Time, n int) time.Time {
+	switch r.Frequency {
+	case TaskRecurrenceWeekly:
+		return start.AddDate(0, 0, 7*r.Interval*n)
+	case TaskRecurrenceMonthly:
+		return addMonths(start, r.Interval*n)
+	}
+
+	return start.AddDate(0, 0, r.Interval*n)
+}
+
+// addMonths keeps the day of the month, or the last day of a shorter month, where AddDate would overflow into
+// the next month.
+func addMonths(date time.Time, months int) time.Time {
+	first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location()).AddDate(0, months, 0)
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	day := date.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(first.Year(), first.Month(), day, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(),
+		date.Location())
+}
+
+// NextDueDate is the first date of the series after the due date and after the date, so a task completed late
+// doesn't repeat as an overdue one. The rules without a start date, set before the series had one, start at the
+// due date. It's false once the next due date is past the end date.
+func (r TaskRecurrence) NextDueDate(dueDate, after time.Time) (time.Time, bool) {
+	start := dueDate
+	if r.StartDate != nil {
+		start = *r.StartDate
+	}
+
+	next := dueDate
+	for n := 1; !next.After(dueDate) || !next.After(after); n++ {
+		next = r.occurrence(start, n)
+	}
+
+	if r.EndDate != nil && next.After(*r.EndDate) {
+		return time.Time{}, false
+	}
+
+	return next, true
+}
+
+// SetTaskRecurrence repeats the task with the rule from its due date, which starts the series, a nil rule stops
+// repeating it.
+func (t *Task) SetTaskRecurrence(recurrence *TaskRecurrence) error {
+	if recurrence != nil && t.DueDate == nil {
+		return TaskError{TaskErrorRecurrenceDueDateRequiredCode}
+	}
+
+	if recurrence != nil && recurrence.EndDate != nil && recurrence.EndDate.Before(*t.DueDate) {
+		return TaskError{TaskErrorRecurrenceEndDateInvalidCode}
+	}
+
+	if t.Status != TaskStatusCreated {
+		return TaskError{TaskErrorRecurrenceClosedCode}
+	}
+
+	if recurrence != nil {
+		started := *recurrence
+		dueDate := *t.DueDate
+		started.StartDate = &dueDate
+		recurrence = &started
+	}
+
+	t.TrackChange(TaskRecurrenceSet{
+		UID:        t.UID,
+		Recurrence: recurrence,
+	})
+
+	return nil
+}
+
+// NextOccurrence creates the next task of the series of the completed recurring task, with the due date advanced
+// by its rule after the completion. It's false for a task which doesn't repeat or whose series has ended.
+// The catalog is the one of the farm of the task, the short code is assigned by the caller.
+func (t *Task) NextOccurrence(ts TaskService, catalog *TaskCatalog) (*Task, bool, error) {
+	if t.Recurrence == nil || t.DueDate == nil || t.Status != TaskStatusCompleted {
+		return nil, false, nil
+	}
+
+	after := time.Now()
+	if t.CompletedDate != nil && t.CompletedDate.After(after) {
+		after = *t.CompletedDate
+	}
+
+	dueDate, ok := t.Recurrence.NextDueDate(*t.DueDate, after)
+	if !ok {
+		return nil, false, nil
+	}
+
+	next, err := CreateTask(
+		ts, catalog, t.Title, t.Description, t.Priority, t.Category, &dueDate, t.DomainDetails, t.AssetID,
+		t.AffectedAreaIDs)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if t.EstimatedMinutes != 0 {
+		err = next.ChangeTaskEstimatedMinutes(t.EstimatedMinutes)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	if t.TargetQuantity != 0 {
+		err = next.ChangeTaskTarget(t.TargetQuantity, t.TargetUnit)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	recurrence := *t.Recurrence
+	recurrenceOf := t.UID
+
+	next.TrackChange(TaskRecurrenceSet{
+		UID:          next.UID,
+		Recurrence:   &recurrence,
+		RecurrenceOf: &recurrenceOf,
+	})
+
+	return next, true, nil
+}
@@ -0,0 +1,134 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	. "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+func TestCreateTaskRecurrence(t *testing.T) {
+	t.Parallel()
+	// When
+	daily, dailyErr := CreateTaskRecurrence(TaskRecurrenceDaily, 0, nil)
+	_, frequencyErr := CreateTaskRecurrence("YEARLY", 1, nil)
+	_, intervalErr := CreateTaskRecurrence(TaskRecurrenceWeekly, -1, nil)
+
+	// Then
+	assert.Nil(t, dailyErr)
+	assert.Equal(t, 1, daily.Interval)
+	assert.Equal(t, TaskError{TaskErrorRecurrenceFrequencyInvalidCode}, frequencyErr)
+	assert.Equal(t, TaskError{TaskErrorRecurrenceIntervalInvalidCode}, intervalErr)
+}
+
+func TestTaskRecurrenceNextDueDate(t *testing.T) {
+	t.Parallel()
+	// Given
+	dueDate := time.Date(2024, time.January, 31, 8, 0, 0, 0, time.UTC)
+	endDate := time.Date(2024, time.February, 20, 0, 0, 0, 0, time.UTC)
+
+	daily := TaskRecurrence{Frequency: TaskRecurrenceDaily, Interval: 2}
+	weekly := TaskRecurrence{Frequency: TaskRecurrenceWeekly, Interval: 1, EndDate: &endDate}
+	monthly := TaskRecurrence{Frequency: TaskRecurrenceMonthly, Interval: 1}
+
+	// When
+	nextDaily, dailyOK := daily.NextDueDate(dueDate, dueDate)
+	lateWeekly, lateOK := weekly.NextDueDate(dueDate, dueDate.AddDate(0, 0, 10))
+	_, endedOK := weekly.NextDueDate(dueDate, dueDate.AddDate(0, 0, 14))
+	nextMonthly, monthlyOK := monthly.NextDueDate(dueDate, dueDate)
+
+	// Then
+	assert.True(t, dailyOK)
+	assert.Equal(t, time.Date(2024, time.February, 2, 8, 0, 0, 0, time.UTC), nextDaily)
+	assert.True(t, lateOK)
+	assert.Equal(t, time.Date(2024, time.February, 14, 8, 0, 0, 0, time.UTC), lateWeekly)
+	assert.False(t, endedOK)
+	assert.True(t, monthlyOK)
+	assert.Equal(t, time.Date(2024, time.February, 29, 8, 0, 0, 0, time.UTC), nextMonthly)
+}
+
+func TestTaskRecurrenceNextDueDateMonthly(t *testing.T) {
+	t.Parallel()
+	// Given
+	leap := time.Date(2024, time.January, 31, 8, 0, 0, 0, time.UTC)
+	common := time.Date(2025, time.January, 31, 8, 0, 0, 0, time.UTC)
+
+	series := func(start time.Time, count int) []time.Time {
+		recurrence := TaskRecurrence{Frequency: TaskRecurrenceMonthly, Interval: 1, StartDate: &start}
+		dueDates := []time.Time{}
+
+		for dueDate := start; len(dueDates) < count; {
+			dueDate, _ = recurrence.NextDueDate(dueDate, dueDate)
+			dueDates = append(dueDates, dueDate)
+		}
+
+		return dueDates
+	}
+
+	// When
+	leapDueDates := series(leap, 3)
+	commonDueDates := series(common, 3)
+
+	// Then
+	assert.Equal(t, []time.Time{
+		time.Date(2024, time.February, 29, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 31, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, time.April, 30, 8, 0, 0, 0, time.UTC),
+	}, leapDueDates)
+	assert.Equal(t, []time.Time{
+		time.Date(2025, time.February, 28, 8, 0, 0, 0, time.UTC),
+		time.Date(2025, time.March, 31, 8, 0, 0, 0, time.UTC),
+		time.Date(2025, time.April, 30, 8, 0, 0, 0, time.UTC),
+	}, commonDueDates)
+}
+
+func TestTaskNextOccurrence(t *testing.T) {
+	t.Parallel()
+	// Given
+	catalog := DefaultTaskCatalog(uuid.Nil)
+	taskServiceMock := new(TaskServiceMock)
+	userID, _ := uuid.NewV4()
+	dueDate := time.Now().Add(time.Hour)
+
+	task, _ := CreateTask(
+		taskServiceMock, catalog, "Water", "Water the beds", "NORMAL", "GENERAL", &dueDate, TaskDomainGeneral{},
+		nil, nil)
+	undated, _ := CreateTask(
+		taskServiceMock, catalog, "Water", "Water the beds", "NORMAL", "GENERAL", nil, TaskDomainGeneral{},
+		nil, nil)
+	recurrence, _ := CreateTaskRecurrence(TaskRecurrenceWeekly, 1, nil)
+
+	// When
+	undatedErr := undated.SetTaskRecurrence(recurrence)
+	setErr := task.SetTaskRecurrence(recurrence)
+	_ = task.ChangeTaskEstimatedMinutes(30)
+	_ = task.CompleteTask(&userID, 25, 0, 0)
+	next, ok, nextErr := task.NextOccurrence(taskServiceMock, catalog)
+
+	// Then
+	assert.Equal(t, TaskError{TaskErrorRecurrenceDueDateRequiredCode}, undatedErr)
+	assert.Nil(t, setErr)
+	assert.Nil(t, nextErr)
+	assert.True(t, ok)
+	assert.NotEqual(t, task.UID, next.UID)
+	assert.Equal(t, TaskStatusCreated, next.Status)
+	assert.Equal(t, "Water", next.Title)
+	assert.Equal(t, 30, next.EstimatedMinutes)
+	assert.Equal(t, dueDate.AddDate(0, 0, 7), *next.DueDate)
+	assert.Equal(t, *task.Recurrence, *next.Recurrence)
+	assert.Equal(t, dueDate, *next.Recurrence.StartDate)
+	assert.Equal(t, &task.UID, next.RecurrenceOf)
+	assert.Equal(t, TaskError{TaskErrorRecurrenceClosedCode}, task.SetTaskRecurrence(nil))
+
+	// When
+	next.CancelTask()
+	_, cancelledOK, cancelledErr := next.NextOccurrence(taskServiceMock, catalog)
+
+	// Then
+	assert.Nil(t, next.Recurrence)
+	assert.Equal(t, &task.UID, next.RecurrenceOf)
+	assert.Nil(t, cancelledErr)
+	assert.False(t, cancelledOK)
+}
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	TaskFieldTitle       = "title"
+	TaskFieldDescription = "description"
+	TaskFieldDueDate     = "due_date"
+	TaskFieldPriority    = "priority"
+)
+
+const (
+	TaskEditResolutionMerged = "MERGED"
+	TaskEditResolutionClient = "CLIENT"
+)
+
+// TaskFields are the fields of a task which can be edited offline and merged one by one.
+// The category is left out because it is changed together with the domain details.
+type TaskFields struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description" redact:"if:role!=Owner"`
+	DueDate     *time.Time `json:"due_date"`
+	Priority    string     `json:"priority"`
+}
+
+// TaskEditConflict is an offline edit which changes the same fields as the edits synced
+// by the other devices since its base version.
+type TaskEditConflict struct {
+	BaseVersion    int        `json:"base_version"`
+	CurrentVersion int        `json:"current_version"`
+	Fields         []string   `json:"fields"`
+	Base           TaskFields `json:"base"`
+	Theirs         TaskFields `json:"theirs"`
+	Mine           TaskFields `json:"mine"`
+}
+
+func (t *Task) Fields() TaskFields {
+	return TaskFields{
+		Title:       t.Title,
+		Description: t.Description,
+		DueDate:     t.DueDate,
+		Priority:    t.Priority,
+	}
+}
+
+// ChangedFields lists the fields which differ between the two.
+func ChangedFields(from, to TaskFields) []string {
+	fields := []string{}
+
+	if from.Title != to.Title {
+		fields = append(fields, TaskFieldTitle)
+	}
+
+	if from.Description != to.Description {
+		fields = append(fields, TaskFieldDescription)
+	}
+
+	if !sameDueDate(from.DueDate, to.DueDate) {
+		fields = append(fields, TaskFieldDueDate)
+	}
+
+	if from.Priority != to.Priority {
+		fields = append(fields, TaskFieldPriority)
+	}
+
+	return fields
+}
+
+// SyncEdit applies the fields edited offline from the base version of the task.
+// The fields changed by the other devices in the meantime are kept when the edit doesn't touch them.
+// When both changed the same field to different values, the conflict is recorded and returned
+// without changing the task, unless the client has resolved it and resubmits with resolved.
+// The priority is checked against the catalog of the farm of the task.
+func (t *Task) SyncEdit(
+	catalog *TaskCatalog, base TaskFields, baseVersion int, mine TaskFields, resolved bool,
+) (*TaskEditConflict, error) {
+	if baseVersion < 1 || baseVersion > t.Version {
+		return nil, TaskError{TaskErrorBaseVersionInvalidCode}
+	}
+
+	theirs := t.Fields()
+	mineChanged := ChangedFields(base, mine)
+	theirsChanged := ChangedFields(base, theirs)
+	differs := ChangedFields(theirs, mine)
+
+	conflicts := []string{}
+
+	for _, v := range mineChanged {
+		if containsField(theirsChanged, v) && containsField(differs, v) {
+			conflicts = append(conflicts, v)
+		}
+	}
+
+	if len(conflicts) > 0 && !resolved {
+		t.TrackChange(TaskEditConflicted{
+			UID:          t.UID,
+			BaseVersion:  baseVersion,
+			Fields:       conflicts,
+			Base:         base,
+			Theirs:       theirs,
+			Mine:         mine,
+			DetectedDate: time.Now(),
+		})
+
+		return &TaskEditConflict{
+			BaseVersion:    baseVersion,
+			CurrentVersion: t.Version + len(t.UncommittedChanges),
+			Fields:         conflicts,
+			Base:           base,
+			Theirs:         theirs,
+			Mine:           mine,
+		}, nil
+	}
+
+	for _, v := range mineChanged {
+		if !containsField(differs, v) {
+			continue
+		}
+
+		if err := t.changeField(catalog, v, mine); err != nil {
+			return nil, err
+		}
+	}
+
+	if baseVersion == t.Version || len(mineChanged) == 0 {
+		return nil, nil
+	}
+
+	event := TaskEditConflictResolved{
+		UID:          t.UID,
+		BaseVersion:  baseVersion,
+		Fields:       mineChanged,
+		Resolution:   TaskEditResolutionMerged,
+		ResolvedDate: time.Now(),
+	}
+
+	if len(conflicts) > 0 {
+		event.Fields = conflicts
+		event.Resolution = TaskEditResolutionClient
+	}
+
+	t.TrackChange(event)
+
+	return nil, nil
+}
+
+func (t *Task) changeField(catalog *TaskCatalog, field string, fields TaskFields) error {
+	var err error
+
+	switch field {
+	case TaskFieldTitle:
+		err = t.ChangeTaskTitle(fields.Title)
+	case TaskFieldDescription:
+		_, err = t.ChangeTaskDescription(fields.Description)
+	case TaskFieldDueDate:
+		_, err = t.ChangeTaskDueDate(fields.DueDate)
+	case TaskFieldPriority:
+		_, err = t.ChangeTaskPriority(catalog, fields.Priority)
+	}
+
+	return err
+}
+
+func sameDueDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+func containsField(fields []string, field string) bool {
+	for _, v := range fields {
+		if v == field {
+			return true
+		}
+	}
+
+	return false
+}
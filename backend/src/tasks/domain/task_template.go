@@ -0,0 +1,286 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type TaskTemplateService interface {
+	FindTaskTemplateByID(uid uuid.UUID) ServiceResult
+}
+
+// TaskTemplate is a named list of tasks that can extend a parent template.
+type TaskTemplate struct {
+	UID              uuid.UUID          `json:"uid"`
+	Name             string             `json:"name"`
+	ParentTemplateID *uuid.UUID         `json:"parent_template_id"`
+	Items            []TaskTemplateItem `json:"items"`
+	CreatedDate      time.Time          `json:"created_date"`
+
+	// Events
+	Version            int
+	UncommittedChanges []interface{}
+}
+
+// TaskTemplateItem is one task of a template.
+// In a child template, an item with the UID of an inherited item replaces it,
+// or removes it when Removed is set. Items with a new UID are added.
+type TaskTemplateItem struct {
+	UID         uuid.UUID `json:"uid"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Priority    string    `json:"priority"`
+	Category    string    `json:"category"`
+	Removed     bool      `json:"removed"`
+}
+
+// CreateTaskTemplate.
+func CreateTaskTemplate(
+	ts TaskTemplateService,
+	name string,
+	parentTemplateID *uuid.UUID,
+	items []TaskTemplateItem,
+) (*TaskTemplate, error) {
+	err := validateTaskTemplateName(name)
+	if err != nil {
+		return &TaskTemplate{}, err
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return &TaskTemplate{}, err
+	}
+
+	err = validateParentTemplate(ts, uid, parentTemplateID)
+	if err != nil {
+		return &TaskTemplate{}, err
+	}
+
+	items, err = validateTaskTemplateItems(items)
+	if err != nil {
+		return &TaskTemplate{}, err
+	}
+
+	initial := &TaskTemplate{}
+
+	initial.TrackChange(TaskTemplateCreated{
+		UID:              uid,
+		Name:             name,
+		ParentTemplateID: parentTemplateID,
+		Items:            items,
+		CreatedDate:      time.Now(),
+	})
+
+	return initial, nil
+}
+
+func (t *TaskTemplate) ChangeName(name string) error {
+	if err := validateTaskTemplateName(name); err != nil {
+		return err
+	}
+
+	t.TrackChange(TaskTemplateNameChanged{
+		UID:  t.UID,
+		Name: name,
+	})
+
+	return nil
+}
+
+// ChangeParentTemplate sets the template to extend. A nil parent detaches it.
+func (t *TaskTemplate) ChangeParentTemplate(ts TaskTemplateService, parentTemplateID *uuid.UUID) error {
+	if err := validateParentTemplate(ts, t.UID, parentTemplateID); err != nil {
+		return err
+	}
+
+	t.TrackChange(TaskTemplateParentChanged{
+		UID:              t.UID,
+		ParentTemplateID: parentTemplateID,
+	})
+
+	return nil
+}
+
+func (t *TaskTemplate) ChangeItems(items []TaskTemplateItem) error {
+	items, err := validateTaskTemplateItems(items)
+	if err != nil {
+		return err
+	}
+
+	t.TrackChange(TaskTemplateItemsChanged{
+		UID:   t.UID,
+		Items: items,
+	})
+
+	return nil
+}
+
+// EffectiveItems resolves the inheritance chain from the root template down to this one
+// and returns the merged list of tasks.
+func (t *TaskTemplate) EffectiveItems(ts TaskTemplateService) ([]TaskTemplateItem, error) {
+	chain := []TaskTemplate{*t}
+	visited := map[uuid.UUID]bool{t.UID: true}
+
+	for parentID := t.ParentTemplateID; parentID != nil; {
+		if visited[*parentID] {
+			return nil, TaskError{TaskTemplateErrorCircularInheritanceCode}
+		}
+
+		visited[*parentID] = true
+
+		parent, err := findTaskTemplate(ts, *parentID)
+		if err != nil {
+			return nil, err
+		}
+
+		chain = append(chain, parent)
+		parentID = parent.ParentTemplateID
+	}
+
+	items := []TaskTemplateItem{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		items = applyTaskTemplateItems(items, chain[i].Items)
+	}
+
+	return items, nil
+}
+
+// applyTaskTemplateItems applies the items of a child template on top of the inherited ones.
+func applyTaskTemplateItems(inherited, items []TaskTemplateItem) []TaskTemplateItem {
+	result := append([]TaskTemplateItem{}, inherited...)
+
+	for _, item := range items {
+		index := -1
+
+		for i, v := range result {
+			if v.UID == item.UID {
+				index = i
+
+				break
+			}
+		}
+
+		switch {
+		case index >= 0 && item.Removed:
+			result = append(result[:index], result[index+1:]...)
+		case index >= 0:
+			result[index] = item
+		case !item.Removed:
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// Event Tracking.
+func (t *TaskTemplate) TrackChange(event interface{}) {
+	t.UncommittedChanges = append(t.UncommittedChanges, event)
+	t.Transition(event)
+}
+
+func (t *TaskTemplate) Transition(event interface{}) {
+	switch e := event.(type) {
+	case TaskTemplateCreated:
+		t.UID = e.UID
+		t.Name = e.Name
+		t.ParentTemplateID = e.ParentTemplateID
+		t.Items = e.Items
+		t.CreatedDate = e.CreatedDate
+	case TaskTemplateNameChanged:
+		t.Name = e.Name
+	case TaskTemplateParentChanged:
+		t.ParentTemplateID = e.ParentTemplateID
+	case TaskTemplateItemsChanged:
+		t.Items = e.Items
+	}
+}
+
+// Validation
+
+// validateTaskTemplateName.
+func validateTaskTemplateName(name string) error {
+	if name == "" {
+		return TaskError{TaskTemplateErrorNameEmptyCode}
+	}
+
+	return nil
+}
+
+// validateParentTemplate walks up the parents of the new parent
+// and rejects it when the chain leads back to the template itself.
+func validateParentTemplate(ts TaskTemplateService, uid uuid.UUID, parentTemplateID *uuid.UUID) error {
+	visited := map[uuid.UUID]bool{}
+
+	for parentID := parentTemplateID; parentID != nil; {
+		if *parentID == uid || visited[*parentID] {
+			return TaskError{TaskTemplateErrorCircularInheritanceCode}
+		}
+
+		visited[*parentID] = true
+
+		parent, err := findTaskTemplate(ts, *parentID)
+		if err != nil {
+			return err
+		}
+
+		parentID = parent.ParentTemplateID
+	}
+
+	return nil
+}
+
+// validateTaskTemplateItems also gives the new items their UID. The templates are shared by the farms,
+// so their items have the priorities and categories of the built-in catalog.
+func validateTaskTemplateItems(items []TaskTemplateItem) ([]TaskTemplateItem, error) {
+	catalog := DefaultTaskCatalog(uuid.Nil)
+	validated := []TaskTemplateItem{}
+
+	for _, item := range items {
+		if item.UID == (uuid.UUID{}) {
+			if item.Removed {
+				return nil, TaskError{TaskTemplateErrorItemInvalidCode}
+			}
+
+			uid, err := uuid.NewV4()
+			if err != nil {
+				return nil, err
+			}
+
+			item.UID = uid
+		}
+
+		if !item.Removed {
+			if item.Title == "" {
+				return nil, TaskError{TaskTemplateErrorItemInvalidCode}
+			}
+
+			if err := catalog.ValidatePriority(item.Priority); err != nil {
+				return nil, err
+			}
+
+			if err := catalog.ValidateCategory(item.Category); err != nil {
+				return nil, err
+			}
+		}
+
+		validated = append(validated, item)
+	}
+
+	return validated, nil
+}
+
+func findTaskTemplate(ts TaskTemplateService, uid uuid.UUID) (TaskTemplate, error) {
+	serviceResult := ts.FindTaskTemplateByID(uid)
+	if serviceResult.Error != nil {
+		return TaskTemplate{}, serviceResult.Error
+	}
+
+	template, ok := serviceResult.Result.(TaskTemplate)
+	if !ok || template.UID == (uuid.UUID{}) {
+		return TaskTemplate{}, TaskError{TaskTemplateErrorNotFoundCode}
+	}
+
+	return template, nil
+}
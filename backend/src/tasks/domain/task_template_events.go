@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	TaskTemplateCreatedCode       = "TaskTemplateCreated"
+	TaskTemplateNameChangedCode   = "TaskTemplateNameChanged"
+	TaskTemplateParentChangedCode = "TaskTemplateParentChanged"
+	TaskTemplateItemsChangedCode  = "TaskTemplateItemsChanged"
+)
+
+type TaskTemplateCreated struct {
+	UID              uuid.UUID          `json:"uid"`
+	Name             string             `json:"name"`
+	ParentTemplateID *uuid.UUID         `json:"parent_template_id"`
+	Items            []TaskTemplateItem `json:"items"`
+	CreatedDate      time.Time          `json:"created_date"`
+}
+
+type TaskTemplateNameChanged struct {
+	UID  uuid.UUID `json:"uid"`
+	Name string    `json:"name"`
+}
+
+type TaskTemplateParentChanged struct {
+	UID              uuid.UUID  `json:"uid"`
+	ParentTemplateID *uuid.UUID `json:"parent_template_id"`
+}
+
+type TaskTemplateItemsChanged struct {
+	UID   uuid.UUID          `json:"uid"`
+	Items []TaskTemplateItem `json:"items"`
+}
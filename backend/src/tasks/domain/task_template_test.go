@@ -0,0 +1,80 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	. "github.com/usetania/tania-core/src/tasks/domain"
+)
+
+type TaskTemplateServiceMock struct {
+	mock.Mock
+}
+
+func (m *TaskTemplateServiceMock) FindTaskTemplateByID(uid uuid.UUID) ServiceResult {
+	args := m.Called(uid)
+
+	return args.Get(0).(ServiceResult)
+}
+
+func TestTaskTemplateEffectiveItems(t *testing.T) {
+	t.Parallel()
+	// Given
+	templateServiceMock := new(TaskTemplateServiceMock)
+
+	prepareUID, _ := uuid.NewV4()
+	sprayUID, _ := uuid.NewV4()
+	waterUID, _ := uuid.NewV4()
+
+	parent, _ := CreateTaskTemplate(templateServiceMock, "Seeding", nil, []TaskTemplateItem{
+		{UID: prepareUID, Title: "Prepare trays", Priority: TaskPriorityNormal, Category: TaskCategoryCrop},
+		{UID: sprayUID, Title: "Spray pesticide", Priority: TaskPriorityNormal, Category: TaskCategoryPestControl},
+		{UID: waterUID, Title: "Water", Priority: TaskPriorityNormal, Category: TaskCategoryGeneral},
+	})
+
+	templateServiceMock.On("FindTaskTemplateByID", parent.UID).Return(ServiceResult{Result: *parent})
+
+	// When
+	child, err := CreateTaskTemplate(templateServiceMock, "Organic seeding", &parent.UID, []TaskTemplateItem{
+		{UID: sprayUID, Removed: true},
+		{UID: waterUID, Title: "Water twice", Priority: TaskPriorityUrgent, Category: TaskCategoryGeneral},
+		{Title: "Add compost", Priority: TaskPriorityNormal, Category: TaskCategoryCrop},
+	})
+
+	items, itemsErr := child.EffectiveItems(templateServiceMock)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Nil(t, itemsErr)
+	assert.Len(t, items, 3)
+	assert.Equal(t, prepareUID, items[0].UID)
+	assert.Equal(t, "Water twice", items[1].Title)
+	assert.Equal(t, TaskPriorityUrgent, items[1].Priority)
+	assert.Equal(t, "Add compost", items[2].Title)
+	assert.NotEqual(t, uuid.UUID{}, items[2].UID)
+}
+
+func TestTaskTemplateCircularInheritance(t *testing.T) {
+	t.Parallel()
+	// Given
+	templateServiceMock := new(TaskTemplateServiceMock)
+
+	parent, _ := CreateTaskTemplate(templateServiceMock, "Parent", nil, nil)
+
+	templateServiceMock.On("FindTaskTemplateByID", parent.UID).Return(ServiceResult{Result: *parent})
+
+	child, _ := CreateTaskTemplate(templateServiceMock, "Child", &parent.UID, nil)
+
+	templateServiceMock.On("FindTaskTemplateByID", child.UID).Return(ServiceResult{Result: *child})
+
+	// When
+	err := parent.ChangeParentTemplate(templateServiceMock, &child.UID)
+	selfErr := child.ChangeParentTemplate(templateServiceMock, &child.UID)
+
+	// Then
+	assert.Equal(t, TaskError{TaskTemplateErrorCircularInheritanceCode}, err)
+	assert.Equal(t, TaskError{TaskTemplateErrorCircularInheritanceCode}, selfErr)
+	assert.Nil(t, parent.ParentTemplateID)
+}
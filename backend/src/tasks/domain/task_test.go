@@ -39,9 +39,16 @@ func (m *TaskServiceMock) FindReservoirByID(uid uuid.UUID) ServiceResult {
 	return args.Get(0).(ServiceResult)
 }
 
+func (m *TaskServiceMock) FindEquipmentByID(uid uuid.UUID) ServiceResult {
+	args := m.Called(uid)
+
+	return args.Get(0).(ServiceResult)
+}
+
 func TestCreateTask(t *testing.T) {
 	t.Parallel()
 
+	catalog := DefaultTaskCatalog(uuid.Nil)
 	taskServiceMock := new(TaskServiceMock)
 
 	assetID, _ := uuid.NewV4()
@@ -128,7 +135,8 @@ func TestCreateTask(t *testing.T) {
 		})
 
 		_, err := CreateTask(
-			taskServiceMock, test.title, test.description, test.priority, test.category, test.duedate, test.domain, test.assetid)
+			taskServiceMock, catalog, test.title, test.description, test.priority, test.category, test.duedate, test.domain,
+			test.assetid, nil)
 
 		assert.Equal(t, test.eexpectedTaskError, err)
 	}
@@ -144,7 +152,7 @@ func TestCreateTask(t *testing.T) {
 	})
 
 	_, err := CreateTask(
-		taskServiceMock, tasktitle, taskdescription, "URGENT", taskcategory, duePtr, taskdomain, nil)
+		taskServiceMock, catalog, tasktitle, taskdescription, "URGENT", taskcategory, duePtr, taskdomain, nil, nil)
 
 	assert.Equal(t, nil, err)
 
@@ -163,7 +171,385 @@ func TestCreateTask(t *testing.T) {
 	})
 
 	_, err = CreateTask(
-		taskServiceMock, tasktitle, taskdescription, "NORMAL", taskcategory, duePtr, taskdomain, &assetIDNotExist)
+		taskServiceMock, catalog, tasktitle, taskdescription, "NORMAL", taskcategory, duePtr, taskdomain, &assetIDNotExist,
+		nil)
 
 	assert.Equal(t, TaskError{TaskErrorInvalidAssetIDCode}, err)
 }
+
+func TestTaskCostCentre(t *testing.T) {
+	t.Parallel()
+	// Given
+	catalog := DefaultTaskCatalog(uuid.Nil)
+	taskServiceMock := new(TaskServiceMock)
+
+	cropID, _ := uuid.NewV4()
+	areaID, _ := uuid.NewV4()
+	userID, _ := uuid.NewV4()
+
+	taskServiceMock.On("FindCropByID", cropID).Return(ServiceResult{Result: query.TaskCropResult{UID: cropID}})
+	taskServiceMock.On("FindAreaByID", areaID).Return(ServiceResult{Result: query.TaskAreaResult{UID: areaID}})
+
+	cropDomain, _ := CreateTaskDomainCrop(taskServiceMock, "SANITATION", nil, &areaID)
+
+	// When
+	cropTask, cropErr := CreateTask(
+		taskServiceMock, catalog, "Spray", "Spray the beds", "NORMAL", "SANITATION", nil, cropDomain, &cropID, nil)
+	areaTask, areaErr := CreateTask(
+		taskServiceMock, catalog, "Weed", "Weed the beds", "NORMAL", "SANITATION", nil, TaskDomainArea{}, &areaID, nil)
+	generalTask, generalErr := CreateTask(
+		taskServiceMock, catalog, "Call", "Call the supplier", "NORMAL", "GENERAL", nil, TaskDomainGeneral{}, nil, nil)
+
+	negativeErr := areaTask.CompleteTask(&userID, -5, 0, 0)
+	completeErr := areaTask.CompleteTask(&userID, 90, 2.5, 0)
+
+	// Then
+	assert.Nil(t, cropErr)
+	assert.Nil(t, areaErr)
+	assert.Nil(t, generalErr)
+	assert.Equal(t, &areaID, cropTask.CostCentreID)
+	assert.Equal(t, &areaID, areaTask.CostCentreID)
+	assert.Nil(t, generalTask.CostCentreID)
+
+	assert.Equal(t, TaskError{TaskErrorLabourMinutesInvalidCode}, negativeErr)
+	assert.Nil(t, completeErr)
+	assert.Equal(t, TaskStatusCompleted, areaTask.Status)
+	assert.Equal(t, &userID, areaTask.CompletedBy)
+	assert.Equal(t, 90, areaTask.LabourMinutes)
+	assert.Equal(t, 2.5, areaTask.MaterialQuantity)
+}
+
+func TestTaskAreaProgress(t *testing.T) {
+	t.Parallel()
+	// Given
+	catalog := DefaultTaskCatalog(uuid.Nil)
+	taskServiceMock := new(TaskServiceMock)
+
+	northID, _ := uuid.NewV4()
+	southID, _ := uuid.NewV4()
+	unknownID, _ := uuid.NewV4()
+	userID, _ := uuid.NewV4()
+
+	taskServiceMock.On("FindAreaByID", northID).Return(ServiceResult{Result: query.TaskAreaResult{UID: northID}})
+	taskServiceMock.On("FindAreaByID", southID).Return(ServiceResult{Result: query.TaskAreaResult{UID: southID}})
+	taskServiceMock.On("FindAreaByID", unknownID).Return(ServiceResult{Error: TaskError{TaskErrorInvalidAssetIDCode}})
+
+	// When
+	task, err := CreateTask(
+		taskServiceMock, catalog, "Inspect", "Inspect the pests", "NORMAL", "PESTCONTROL", nil, TaskDomainGeneral{}, nil,
+		[]uuid.UUID{northID, southID, northID})
+	_, unknownErr := CreateTask(
+		taskServiceMock, catalog, "Inspect", "Inspect the pests", "NORMAL", "PESTCONTROL", nil, TaskDomainGeneral{}, nil,
+		[]uuid.UUID{northID, unknownID})
+	areaTask, areaErr := CreateTask(
+		taskServiceMock, catalog, "Weed", "Weed the beds", "NORMAL", "SANITATION", nil, TaskDomainArea{}, &northID, nil)
+
+	notAffectedErr := task.UpdateAreaProgress(unknownID, 50, &userID)
+	invalidErr := task.UpdateAreaProgress(northID, 150, &userID)
+	northErr := task.UpdateAreaProgress(northID, 100, &userID)
+	statusAfterNorth := task.Status
+	southErr := task.UpdateAreaProgress(southID, 100, &userID)
+	closedErr := task.UpdateAreaProgress(southID, 80, &userID)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []uuid.UUID{northID, southID}, task.AffectedAreaIDs)
+	assert.Equal(t, TaskError{TaskErrorInvalidAreaIDCode}, unknownErr)
+
+	assert.Nil(t, areaErr)
+	assert.Nil(t, areaTask.AffectedAreaIDs)
+	assert.Equal(t, []uuid.UUID{northID}, areaTask.AreaIDs())
+
+	assert.Equal(t, TaskError{TaskErrorAreaNotAffectedCode}, notAffectedErr)
+	assert.Equal(t, TaskError{TaskErrorAreaProgressInvalidCode}, invalidErr)
+	assert.Nil(t, northErr)
+	assert.Equal(t, TaskStatusCreated, statusAfterNorth)
+	assert.Nil(t, southErr)
+	assert.Equal(t, map[uuid.UUID]int{northID: 100, southID: 100}, task.PerAreaProgress)
+	assert.Equal(t, TaskStatusCompleted, task.Status)
+	assert.Equal(t, &userID, task.CompletedBy)
+	assert.Equal(t, TaskError{TaskErrorAreaProgressClosedCode}, closedErr)
+}
+
+func TestTaskProgress(t *testing.T) {
+	t.Parallel()
+	// Given
+	catalog := DefaultTaskCatalog(uuid.Nil)
+	taskServiceMock := new(TaskServiceMock)
+	userID, _ := uuid.NewV4()
+
+	task, _ := CreateTask(
+		taskServiceMock, catalog, "Transplant", "Transplant the seedlings", "NORMAL", "GENERAL", nil, TaskDomainGeneral{},
+		nil, nil)
+	manual, _ := CreateTask(
+		taskServiceMock, catalog, "Transplant", "Transplant the seedlings", "NORMAL", "GENERAL", nil, TaskDomainGeneral{},
+		nil, nil)
+
+	// When
+	unitErr := task.ChangeTaskTarget(400, "")
+	targetErr := task.ChangeTaskTarget(400, "SEEDLINGS")
+	invalidErr := task.RecordProgress(0, 30, &userID, true)
+	firstErr := task.RecordProgress(250, 60, &userID, true)
+	statusAfterFirst := task.Status
+	secondErr := task.RecordProgress(150, 30, &userID, true)
+	closedErr := task.RecordProgress(10, 5, &userID, true)
+
+	_ = manual.ChangeTaskTarget(400, "SEEDLINGS")
+	manualErr := manual.RecordProgress(400, 60, &userID, false)
+	statusAfterManual := manual.Status
+	completeErr := manual.CompleteTask(&userID, 20, 0, 40)
+
+	// Then
+	assert.Equal(t, TaskError{TaskErrorTargetUnitEmptyCode}, unitErr)
+	assert.Nil(t, targetErr)
+	assert.Equal(t, TaskError{TaskErrorProgressQuantityInvalidCode}, invalidErr)
+	assert.Nil(t, firstErr)
+	assert.Equal(t, TaskStatusCreated, statusAfterFirst)
+	assert.Nil(t, secondErr)
+	assert.Equal(t, TaskStatusCompleted, task.Status)
+	assert.Equal(t, 400.0, task.CompletedQuantity)
+	assert.Equal(t, 90, task.LabourMinutes)
+	assert.Equal(t, &userID, task.CompletedBy)
+	assert.Equal(t, TaskError{TaskErrorProgressClosedCode}, closedErr)
+
+	assert.Nil(t, manualErr)
+	assert.Equal(t, TaskStatusCreated, statusAfterManual)
+	assert.Nil(t, completeErr)
+	assert.Equal(t, 440.0, manual.CompletedQuantity)
+	assert.Equal(t, 80, manual.LabourMinutes)
+	assert.Equal(t, 330.0, UnitsPerHour(manual.CompletedQuantity, manual.LabourMinutes))
+	assert.Equal(t, 0.0, UnitsPerHour(manual.CompletedQuantity, 0))
+}
+
+func TestTaskSyncEdit(t *testing.T) {
+	t.Parallel()
+	// Given
+	catalog := DefaultTaskCatalog(uuid.Nil)
+	uid, _ := uuid.NewV4()
+	base := TaskFields{Title: "Water", Description: "Water the beds", Priority: "NORMAL"}
+
+	newTask := func() *Task {
+		// Another device changed the description after the base version 2.
+		return &Task{UID: uid, Title: "Water", Description: "Water the north beds", Priority: "NORMAL", Version: 3}
+	}
+
+	// When
+	merged := newTask()
+	mergedConflict, mergedErr := merged.SyncEdit(
+		catalog, base, 2, TaskFields{Title: "Water", Description: "Water the beds", Priority: "URGENT"}, false)
+
+	conflicted := newTask()
+	mine := TaskFields{Title: "Water", Description: "Water the south beds", Priority: "NORMAL"}
+	conflict, conflictErr := conflicted.SyncEdit(catalog, base, 2, mine, false)
+
+	resolved := newTask()
+	resolvedConflict, resolvedErr := resolved.SyncEdit(catalog, base, 2, mine, true)
+
+	_, versionErr := newTask().SyncEdit(catalog, base, 4, mine, false)
+
+	// Then
+	assert.Nil(t, mergedErr)
+	assert.Nil(t, mergedConflict)
+	assert.Equal(t, "URGENT", merged.Priority)
+	assert.Equal(t, "Water the north beds", merged.Description)
+	assert.Equal(t, TaskEditConflictResolved{
+		UID: uid, BaseVersion: 2, Fields: []string{TaskFieldPriority}, Resolution: TaskEditResolutionMerged,
+		ResolvedDate: merged.UncommittedChanges[1].(TaskEditConflictResolved).ResolvedDate,
+	}, merged.UncommittedChanges[1])
+
+	assert.Nil(t, conflictErr)
+	assert.Equal(t, []string{TaskFieldDescription}, conflict.Fields)
+	assert.Equal(t, 4, conflict.CurrentVersion)
+	assert.Equal(t, "Water the north beds", conflict.Theirs.Description)
+	assert.Equal(t, "Water the north beds", conflicted.Description)
+	assert.Len(t, conflicted.UncommittedChanges, 1)
+	assert.IsType(t, TaskEditConflicted{}, conflicted.UncommittedChanges[0])
+
+	assert.Nil(t, resolvedErr)
+	assert.Nil(t, resolvedConflict)
+	assert.Equal(t, "Water the south beds", resolved.Description)
+	assert.Equal(t, TaskEditResolutionClient, resolved.UncommittedChanges[1].(TaskEditConflictResolved).Resolution)
+
+	assert.Equal(t, TaskError{TaskErrorBaseVersionInvalidCode}, versionErr)
+}
+
+func TestAssignShortCode(t *testing.T) {
+	t.Parallel()
+	// Given
+	uid, _ := uuid.NewV4()
+	farmUID, _ := uuid.NewV4()
+
+	task := &Task{UID: uid, Status: TaskStatusCreated}
+
+	// When
+	err := task.AssignShortCode("T-1", farmUID)
+	againErr := task.AssignShortCode("T-2", farmUID)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "T-1", task.ShortCode)
+	assert.Equal(t, farmUID, task.ShortCodeFarmUID)
+	assert.Len(t, task.UncommittedChanges, 1)
+	assert.Equal(t, farmUID, task.UncommittedChanges[0].(TaskShortCodeAssigned).FarmUID)
+
+	assert.Equal(t, TaskError{TaskErrorShortCodeAlreadyAssignedCode}, againErr)
+}
+
+func TestArchiveTask(t *testing.T) {
+	t.Parallel()
+	// Given
+	uid, _ := uuid.NewV4()
+
+	open := &Task{UID: uid, Status: TaskStatusCreated}
+	completed := &Task{UID: uid, Status: TaskStatusCompleted}
+
+	// When
+	openErr := open.ArchiveTask()
+	completedErr := completed.ArchiveTask()
+	againErr := completed.ArchiveTask()
+
+	// Then
+	assert.Equal(t, TaskError{TaskErrorArchiveNotClosedCode}, openErr)
+	assert.Empty(t, open.UncommittedChanges)
+
+	assert.Nil(t, completedErr)
+	assert.NotNil(t, completed.ArchivedDate)
+	assert.Len(t, completed.UncommittedChanges, 1)
+	assert.IsType(t, TaskArchived{}, completed.UncommittedChanges[0])
+
+	assert.Equal(t, TaskError{TaskErrorAlreadyArchivedCode}, againErr)
+}
+
+func TestTaskTimeWindow(t *testing.T) {
+	t.Parallel()
+	// Given
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	later := start.Add(90 * time.Minute)
+	afterwards := start.Add(2 * time.Hour)
+
+	task := &Task{Status: TaskStatusCreated, DueDate: &start}
+
+	// When
+	negativeErr := task.ChangeTaskEstimatedMinutes(-1)
+	err := task.ChangeTaskEstimatedMinutes(120)
+
+	window, ok := NewTaskTimeWindow(task.DueDate, task.EstimatedMinutes)
+	overlapping, _ := NewTaskTimeWindow(&later, 60)
+	following, _ := NewTaskTimeWindow(&afterwards, 60)
+	_, unestimated := NewTaskTimeWindow(&later, 0)
+	_, undated := NewTaskTimeWindow(nil, 60)
+
+	// Then
+	assert.Equal(t, TaskError{TaskErrorEstimatedMinutesInvalidCode}, negativeErr)
+	assert.Nil(t, err)
+	assert.Equal(t, 120, task.EstimatedMinutes)
+
+	assert.True(t, ok)
+	assert.Equal(t, afterwards, window.End)
+	assert.True(t, window.Overlaps(overlapping))
+	assert.True(t, overlapping.Overlaps(window))
+	assert.False(t, window.Overlaps(following))
+	assert.False(t, unestimated)
+	assert.False(t, undated)
+}
+
+func TestChangeTaskDependencies(t *testing.T) {
+	t.Parallel()
+	// Given
+	taskUID, _ := uuid.NewV4()
+	otherUID, _ := uuid.NewV4()
+	task := &Task{UID: taskUID, Status: TaskStatusCreated}
+
+	// When
+	selfErr := task.ChangeTaskDependencies([]uuid.UUID{otherUID, taskUID})
+	err := task.ChangeTaskDependencies([]uuid.UUID{otherUID, otherUID})
+
+	// Then
+	assert.Equal(t, TaskError{TaskErrorDependencySelfCode}, selfErr)
+	assert.Nil(t, err)
+	assert.Equal(t, []uuid.UUID{otherUID}, task.DependsOn)
+	assert.Len(t, task.UncommittedChanges, 1)
+}
+
+func TestDependencyCycle(t *testing.T) {
+	t.Parallel()
+	// Given
+	first, _ := uuid.NewV4()
+	second, _ := uuid.NewV4()
+	third, _ := uuid.NewV4()
+
+	graph := map[uuid.UUID][]uuid.UUID{
+		second: {first},
+		third:  {second},
+	}
+
+	// Then
+	assert.True(t, DependencyCycle(graph, first, []uuid.UUID{third}))
+	assert.False(t, DependencyCycle(graph, third, []uuid.UUID{first}))
+}
+
+func TestCriticalPath(t *testing.T) {
+	t.Parallel()
+	// Given
+	prepare, _ := uuid.NewV4()
+	sow, _ := uuid.NewV4()
+	fence, _ := uuid.NewV4()
+	water, _ := uuid.NewV4()
+	closed, _ := uuid.NewV4()
+
+	nodes := []TaskDependencyNode{
+		{UID: prepare, Minutes: 60},
+		{UID: sow, DependsOn: []uuid.UUID{prepare}, Minutes: 30},
+		{UID: fence, Minutes: 120},
+		{UID: water, DependsOn: []uuid.UUID{sow, fence, closed}, Minutes: 15},
+	}
+
+	// When
+	path := CriticalPath(nodes)
+	cyclic := CriticalPath([]TaskDependencyNode{
+		{UID: prepare, DependsOn: []uuid.UUID{sow}, Minutes: 10},
+		{UID: sow, DependsOn: []uuid.UUID{prepare}, Minutes: 20},
+	})
+
+	// Then
+	assert.Equal(t, []uuid.UUID{fence, water}, path)
+	assert.Equal(t, []uuid.UUID{sow, prepare}, cyclic)
+	assert.Equal(t, []uuid.UUID{}, CriticalPath(nil))
+}
+
+func TestSetTaskAsDueAt(t *testing.T) {
+	t.Parallel()
+	// Given
+	catalog := DefaultTaskCatalog(uuid.Nil)
+	taskServiceMock := new(TaskServiceMock)
+	dueDate := time.Now().Add(time.Hour)
+
+	task, _ := CreateTask(
+		taskServiceMock, catalog, "Water", "Water the beds", "NORMAL", "GENERAL", &dueDate, TaskDomainGeneral{},
+		nil, nil)
+	undated, _ := CreateTask(
+		taskServiceMock, catalog, "Water", "Water the beds", "NORMAL", "GENERAL", nil, TaskDomainGeneral{},
+		nil, nil)
+	cancelled, _ := CreateTask(
+		taskServiceMock, catalog, "Water", "Water the beds", "NORMAL", "GENERAL", &dueDate, TaskDomainGeneral{},
+		nil, nil)
+	cancelled.CancelTask()
+
+	changes := len(task.UncommittedChanges)
+
+	// When
+	early := task.SetTaskAsDueAt(dueDate.Add(-time.Nanosecond))
+	boundary := task.SetTaskAsDueAt(dueDate)
+	again := task.SetTaskAsDueAt(dueDate.Add(time.Hour))
+
+	// Then
+	assert.False(t, early)
+	assert.True(t, boundary)
+	assert.False(t, again)
+	assert.True(t, task.IsDue)
+	assert.Len(t, task.UncommittedChanges, changes+1)
+	assert.Equal(t, TaskDue{UID: task.UID}, task.UncommittedChanges[changes])
+	assert.False(t, undated.SetTaskAsDueAt(dueDate))
+	assert.False(t, undated.IsDue)
+	assert.False(t, cancelled.SetTaskAsDueAt(dueDate))
+}
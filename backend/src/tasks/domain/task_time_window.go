@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// TaskTimeWindow is the time a task is planned to take, from its due date for its estimated minutes.
+type TaskTimeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewTaskTimeWindow returns false when the task isn't planned, without a due date or an estimate.
+func NewTaskTimeWindow(dueDate *time.Time, estimatedMinutes int) (TaskTimeWindow, bool) {
+	if dueDate == nil || estimatedMinutes <= 0 {
+		return TaskTimeWindow{}, false
+	}
+
+	return TaskTimeWindow{
+		Start: *dueDate,
+		End:   dueDate.Add(time.Duration(estimatedMinutes) * time.Minute),
+	}, true
+}
+
+// Overlaps tells whether the windows share some time. A window ending when the other starts doesn't overlap.
+func (w TaskTimeWindow) Overlaps(other TaskTimeWindow) bool {
+	return w.Start.Before(other.End) && other.Start.Before(w.End)
+}
+
+// TaskMaterialID returns the material the task uses, if its domain has one.
+func TaskMaterialID(taskdomain TaskDomain) *uuid.UUID {
+	switch v := taskdomain.(type) {
+	case TaskDomainArea:
+		return v.MaterialID
+	case TaskDomainCrop:
+		return v.MaterialID
+	case TaskDomainReservoir:
+		return v.MaterialID
+	}
+
+	return nil
+}
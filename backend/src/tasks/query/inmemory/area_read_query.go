@@ -27,6 +27,7 @@ func (s AreaQueryInMemory) FindByID(uid uuid.UUID) <-chan query.Result {
 			if val.UID == uid {
 				area.UID = uid
 				area.Name = val.Name
+				area.FarmUID = val.Farm.UID
 			}
 		}
 
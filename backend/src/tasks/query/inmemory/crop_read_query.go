@@ -27,6 +27,7 @@ func (s CropQueryInMemory) FindCropByID(uid uuid.UUID) <-chan query.Result {
 			if val.UID == uid {
 				crop.UID = uid
 				crop.BatchID = val.BatchID
+				crop.FarmUID = val.FarmUID
 			}
 		}
 		result <- query.Result{Result: crop}
@@ -0,0 +1,39 @@
+package inmemory
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/tasks/query"
+)
+
+type EquipmentQueryInMemory struct {
+	Storage *storage.EquipmentReadStorage
+}
+
+func NewEquipmentQueryInMemory(s *storage.EquipmentReadStorage) query.Equipment {
+	return EquipmentQueryInMemory{Storage: s}
+}
+
+func (s EquipmentQueryInMemory) FindEquipmentByID(equipmentUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		s.Storage.Lock.RLock()
+		defer s.Storage.Lock.RUnlock()
+
+		equipment := query.TaskEquipmentResult{}
+
+		if val, ok := s.Storage.EquipmentReadMap[equipmentUID]; ok && !val.IsDeleted {
+			equipment.UID = val.UID
+			equipment.Name = val.Name
+			equipment.Type = val.Type
+			equipment.FarmUID = val.FarmUID
+		}
+
+		result <- query.Result{Result: equipment}
+
+		close(result)
+	}()
+
+	return result
+}
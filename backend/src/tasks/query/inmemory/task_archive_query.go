@@ -0,0 +1,78 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/helper/paginationhelper"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskArchiveQueryInMemory struct {
+	Storage *storage.TaskArchiveStorage
+}
+
+func NewTaskArchiveQueryInMemory(s *storage.TaskArchiveStorage) query.TaskArchive {
+	return &TaskArchiveQueryInMemory{Storage: s}
+}
+
+func (q TaskArchiveQueryInMemory) FindAll(page, limit int) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		q.Storage.Lock.RLock()
+		defer q.Storage.Lock.RUnlock()
+
+		tasks := []storage.TaskRead{}
+
+		for _, val := range q.Storage.TaskArchiveMap {
+			tasks = append(tasks, val)
+		}
+
+		sort.Slice(tasks, func(i, j int) bool {
+			return tasks[i].CreatedDate.After(tasks[j].CreatedDate)
+		})
+
+		if page != 0 && limit != 0 {
+			start, end := paginationhelper.PageBounds(len(tasks), page, limit)
+			tasks = tasks[start:end]
+		}
+
+		result <- query.Result{Result: tasks}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskArchiveQueryInMemory) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		q.Storage.Lock.RLock()
+		defer q.Storage.Lock.RUnlock()
+
+		result <- query.Result{Result: q.Storage.TaskArchiveMap[uid]}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskArchiveQueryInMemory) CountAll() <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		q.Storage.Lock.RLock()
+		defer q.Storage.Lock.RUnlock()
+
+		result <- query.Result{Result: len(q.Storage.TaskArchiveMap)}
+
+		close(result)
+	}()
+
+	return result
+}
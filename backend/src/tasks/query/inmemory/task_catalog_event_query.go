@@ -0,0 +1,44 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskCatalogEventQueryInMemory struct {
+	Storage *storage.TaskCatalogEventStorage
+}
+
+func NewTaskCatalogEventQueryInMemory(s *storage.TaskCatalogEventStorage) query.TaskCatalogEvent {
+	return &TaskCatalogEventQueryInMemory{Storage: s}
+}
+
+func (f *TaskCatalogEventQueryInMemory) FindAllByFarmID(farmUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		f.Storage.Lock.RLock()
+		defer f.Storage.Lock.RUnlock()
+
+		events := []storage.TaskCatalogEvent{}
+
+		for _, v := range f.Storage.TaskCatalogEvents {
+			if v.FarmUID == farmUID {
+				events = append(events, v)
+			}
+		}
+
+		sort.Slice(events, func(i, j int) bool {
+			return events[i].Version < events[j].Version
+		})
+
+		result <- query.Result{Result: events}
+
+		close(result)
+	}()
+
+	return result
+}
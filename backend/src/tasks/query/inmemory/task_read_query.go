@@ -1,10 +1,12 @@
 package inmemory
 
 import (
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/domain"
 	"github.com/usetania/tania-core/src/tasks/query"
 	"github.com/usetania/tania-core/src/tasks/storage"
 )
@@ -54,6 +56,88 @@ func (q TaskReadQueryInMemory) FindByID(uid uuid.UUID) <-chan query.Result {
 	return result
 }
 
+func (q TaskReadQueryInMemory) FindAllByShortCode(shortCode string) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		q.Storage.Lock.RLock()
+		defer q.Storage.Lock.RUnlock()
+
+		tasks := []storage.TaskRead{}
+
+		for _, val := range q.Storage.TaskReadMap {
+			if val.ShortCode == shortCode {
+				tasks = append(tasks, val)
+			}
+		}
+
+		result <- query.Result{Result: tasks}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskReadQueryInMemory) FindByAreaID(areaUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		q.Storage.Lock.RLock()
+		defer q.Storage.Lock.RUnlock()
+
+		tasks := []storage.TaskRead{}
+
+		for uid := range q.Storage.AreaTaskMap[areaUID] {
+			tasks = append(tasks, q.Storage.TaskReadMap[uid])
+		}
+
+		sort.Slice(tasks, func(i, j int) bool {
+			return tasks[i].CreatedDate.After(tasks[j].CreatedDate)
+		})
+
+		result <- query.Result{Result: tasks}
+
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskReadQueryInMemory) FindAllOpenByMaterial(materialID uuid.UUID, start, end time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		q.Storage.Lock.RLock()
+		defer q.Storage.Lock.RUnlock()
+
+		tasks := []storage.TaskRead{}
+		window := domain.TaskTimeWindow{Start: start, End: end}
+
+		for _, val := range q.Storage.TaskReadMap {
+			taskMaterialID := domain.TaskMaterialID(val.DomainDetails)
+			if val.Status != domain.TaskStatusCreated || taskMaterialID == nil || *taskMaterialID != materialID {
+				continue
+			}
+
+			other, ok := domain.NewTaskTimeWindow(val.DueDate, val.EstimatedMinutes)
+			if ok && window.Overlaps(other) {
+				tasks = append(tasks, val)
+			}
+		}
+
+		sort.Slice(tasks, func(i, j int) bool {
+			return tasks[i].DueDate.Before(*tasks[j].DueDate)
+		})
+
+		result <- query.Result{Result: tasks}
+
+		close(result)
+	}()
+
+	return result
+}
+
 func (q TaskReadQueryInMemory) FindTasksWithFilter(params map[string]string, _, _ int) <-chan query.Result {
 	result := make(chan query.Result)
 
@@ -74,6 +158,14 @@ func (q TaskReadQueryInMemory) FindTasksWithFilter(params map[string]string, _,
 				}
 			}
 
+			// Farm
+			if value := params["farm_id"]; value != "" {
+				farmUID, _ := uuid.FromString(value)
+				if val.ShortCodeFarmUID != farmUID {
+					isMatch = false
+				}
+			}
+
 			if isMatch {
 				// Priority
 				if value := params["priority"]; value != "" {
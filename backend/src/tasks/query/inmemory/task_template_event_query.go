@@ -0,0 +1,44 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskTemplateEventQueryInMemory struct {
+	Storage *storage.TaskTemplateEventStorage
+}
+
+func NewTaskTemplateEventQueryInMemory(s *storage.TaskTemplateEventStorage) query.TaskTemplateEvent {
+	return &TaskTemplateEventQueryInMemory{Storage: s}
+}
+
+func (f *TaskTemplateEventQueryInMemory) FindAllByTaskTemplateID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		f.Storage.Lock.RLock()
+		defer f.Storage.Lock.RUnlock()
+
+		events := []storage.TaskTemplateEvent{}
+
+		for _, v := range f.Storage.TaskTemplateEvents {
+			if v.TaskTemplateUID == uid {
+				events = append(events, v)
+			}
+		}
+
+		sort.Slice(events, func(i, j int) bool {
+			return events[i].Version < events[j].Version
+		})
+
+		result <- query.Result{Result: events}
+
+		close(result)
+	}()
+
+	return result
+}
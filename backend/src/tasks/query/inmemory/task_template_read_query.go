@@ -0,0 +1,58 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskTemplateReadQueryInMemory struct {
+	Storage *storage.TaskTemplateReadStorage
+}
+
+func NewTaskTemplateReadQueryInMemory(s *storage.TaskTemplateReadStorage) query.TaskTemplateRead {
+	return &TaskTemplateReadQueryInMemory{Storage: s}
+}
+
+func (q TaskTemplateReadQueryInMemory) FindAll() <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		q.Storage.Lock.RLock()
+		defer q.Storage.Lock.RUnlock()
+
+		templates := []storage.TaskTemplateRead{}
+
+		for _, val := range q.Storage.TaskTemplateReadMap {
+			templates = append(templates, val)
+		}
+
+		sort.Slice(templates, func(i, j int) bool {
+			return templates[i].Name < templates[j].Name
+		})
+
+		result <- query.Result{Result: templates}
+
+		close(result)
+	}()
+
+	return result
+}
+
+// FindByID is to find by ID.
+func (q TaskTemplateReadQueryInMemory) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		q.Storage.Lock.RLock()
+		defer q.Storage.Lock.RUnlock()
+
+		result <- query.Result{Result: q.Storage.TaskTemplateReadMap[uid]}
+
+		close(result)
+	}()
+
+	return result
+}
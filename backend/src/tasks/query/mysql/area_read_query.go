@@ -20,13 +20,14 @@ func (s AreaQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
 
 	go func() {
 		rowsData := struct {
-			UID  []byte
-			Name string
+			UID     []byte
+			Name    string
+			FarmUID []byte
 		}{}
 		area := query.TaskAreaResult{}
 
-		s.DB.QueryRow(`SELECT UID, NAME
-			FROM AREA_READ WHERE UID = ?`, uid.Bytes()).Scan(&rowsData.UID, &rowsData.Name)
+		s.DB.QueryRow(`SELECT UID, NAME, FARM_UID
+			FROM AREA_READ WHERE UID = ?`, uid.Bytes()).Scan(&rowsData.UID, &rowsData.Name, &rowsData.FarmUID)
 
 		areaUID, err := uuid.FromBytes(rowsData.UID)
 		if err != nil {
@@ -35,6 +36,7 @@ func (s AreaQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
 
 		area.UID = areaUID
 		area.Name = rowsData.Name
+		area.FarmUID, _ = uuid.FromBytes(rowsData.FarmUID)
 
 		result <- query.Result{Result: area}
 
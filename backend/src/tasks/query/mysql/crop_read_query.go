@@ -22,11 +22,12 @@ func (s CropQueryMysql) FindCropByID(uid uuid.UUID) <-chan query.Result {
 		rowsData := struct {
 			UID     []byte
 			BatchID string
+			FarmUID []byte
 		}{}
 		crop := query.TaskCropResult{}
 
-		s.DB.QueryRow(`SELECT UID, BATCH_ID
-			FROM CROP_READ WHERE UID = ?`, uid.Bytes()).Scan(&rowsData.UID, &rowsData.BatchID)
+		s.DB.QueryRow(`SELECT UID, BATCH_ID, FARM_UID
+			FROM CROP_READ WHERE UID = ?`, uid.Bytes()).Scan(&rowsData.UID, &rowsData.BatchID, &rowsData.FarmUID)
 
 		cropUID, err := uuid.FromBytes(rowsData.UID)
 		if err != nil {
@@ -35,6 +36,7 @@ func (s CropQueryMysql) FindCropByID(uid uuid.UUID) <-chan query.Result {
 
 		crop.UID = cropUID
 		crop.BatchID = rowsData.BatchID
+		crop.FarmUID, _ = uuid.FromBytes(rowsData.FarmUID)
 
 		result <- query.Result{Result: crop}
 
@@ -20,13 +20,14 @@ func (s ReservoirQueryMysql) FindReservoirByID(uid uuid.UUID) <-chan query.Resul
 
 	go func() {
 		rowsData := struct {
-			UID  []byte
-			Name string
+			UID     []byte
+			Name    string
+			FarmUID []byte
 		}{}
 		reservoir := query.TaskReservoirResult{}
 
-		s.DB.QueryRow(`SELECT UID, NAME
-			FROM RESERVOIR_READ WHERE UID = ?`, uid.Bytes()).Scan(&rowsData.UID, &rowsData.Name)
+		s.DB.QueryRow(`SELECT UID, NAME, FARM_UID
+			FROM RESERVOIR_READ WHERE UID = ?`, uid.Bytes()).Scan(&rowsData.UID, &rowsData.Name, &rowsData.FarmUID)
 
 		reservoirUID, err := uuid.FromBytes(rowsData.UID)
 		if err != nil {
@@ -35,6 +36,7 @@ func (s ReservoirQueryMysql) FindReservoirByID(uid uuid.UUID) <-chan query.Resul
 
 		reservoir.UID = reservoirUID
 		reservoir.Name = rowsData.Name
+		reservoir.FarmUID, _ = uuid.FromBytes(rowsData.FarmUID)
 
 		result <- query.Result{Result: reservoir}
 
@@ -0,0 +1,120 @@
+package mysql
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/helper/paginationhelper"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// TaskArchiveQueryMysql queries the archive database.
+// TASK_ARCHIVE has the columns of TASK_READ followed by ARCHIVED_DATE.
+type TaskArchiveQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewTaskArchiveQueryMysql(s *sql.DB) query.TaskArchive {
+	return &TaskArchiveQueryMysql{DB: s}
+}
+
+func (q TaskArchiveQueryMysql) FindAll(page, limit int) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		sql := `SELECT * FROM TASK_ARCHIVE ORDER BY CREATED_DATE DESC`
+
+		var args []interface{}
+
+		if page != 0 && limit != 0 {
+			sql += " LIMIT ? OFFSET ?"
+			offset := paginationhelper.CalculatePageToOffset(page, limit)
+			args = append(args, limit, offset)
+		}
+
+		tasks, err := q.find(sql, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+
+		result <- query.Result{Result: tasks}
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskArchiveQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		tasks, err := q.find(`SELECT * FROM TASK_ARCHIVE WHERE UID = ?`, uid.Bytes())
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+
+		task := storage.TaskRead{}
+		if len(tasks) > 0 {
+			task = tasks[0]
+		}
+
+		result <- query.Result{Result: task}
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskArchiveQueryMysql) CountAll() <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		total := 0
+
+		err := q.DB.QueryRow(`SELECT COUNT(UID) FROM TASK_ARCHIVE`).Scan(&total)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+
+		result <- query.Result{Result: total}
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskArchiveQueryMysql) find(sql string, args ...interface{}) ([]storage.TaskRead, error) {
+	rows, err := q.DB.Query(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []storage.TaskRead{}
+
+	for rows.Next() {
+		var archivedDate time.Time
+
+		task, err := TaskReadQueryMysql{}.populateQueryResult(rows, &archivedDate)
+		if err != nil {
+			return nil, err
+		}
+
+		task.ArchivedDate = &archivedDate
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, rows.Err()
+}
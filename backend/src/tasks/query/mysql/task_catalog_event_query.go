@@ -0,0 +1,66 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/decoder"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskCatalogEventQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewTaskCatalogEventQueryMysql(db *sql.DB) query.TaskCatalogEvent {
+	return &TaskCatalogEventQueryMysql{DB: db}
+}
+
+func (f *TaskCatalogEventQueryMysql) FindAllByFarmID(farmUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.TaskCatalogEvent{}
+
+		rows, err := f.DB.Query("SELECT * FROM TASK_CATALOG_EVENT WHERE FARM_UID = ? ORDER BY VERSION ASC", farmUID.Bytes())
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		rowsData := struct {
+			ID          int
+			FarmUID     []byte
+			Version     int
+			CreatedDate time.Time
+			Event       []byte
+		}{}
+
+		for rows.Next() {
+			rows.Scan(&rowsData.ID, &rowsData.FarmUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event)
+
+			wrapper := decoder.TaskCatalogEventWrapper{}
+			json.Unmarshal(rowsData.Event, &wrapper)
+
+			eventFarmUID, err := uuid.FromBytes(rowsData.FarmUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			events = append(events, storage.TaskCatalogEvent{
+				FarmUID:     eventFarmUID,
+				Version:     rowsData.Version,
+				CreatedDate: rowsData.CreatedDate,
+				Event:       wrapper.Data,
+				Actor:       wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
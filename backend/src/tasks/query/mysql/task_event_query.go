@@ -54,6 +54,7 @@ func (f *TaskEventQueryMysql) FindAllByTaskID(uid uuid.UUID) <-chan query.Result
 				Version:     rowsData.Version,
 				CreatedDate: rowsData.CreatedDate,
 				Event:       wrapper.Data,
+				Actor:       wrapper.Actor,
 			})
 		}
 
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"database/sql"
+	"encoding/json"
 	"strconv"
 	"time"
 
@@ -37,6 +38,22 @@ type taskReadQueryResult struct {
 	DomainDataAreaID     uuid.NullUUID
 	DomainDataCropID     uuid.NullUUID
 	AssetID              uuid.NullUUID
+	ShortCode            sql.NullString
+	CostCentreID         uuid.NullUUID
+	CompletedBy          uuid.NullUUID
+	LabourMinutes        sql.NullInt64
+	MaterialQuantity     sql.NullFloat64
+	EstimatedMinutes     sql.NullInt64
+	DependsOn            sql.NullString
+	AffectedAreaIDs      sql.NullString
+	PerAreaProgress      sql.NullString
+	DueDateAdjusted      sql.NullBool
+	TargetQuantity       sql.NullFloat64
+	TargetUnit           sql.NullString
+	CompletedQuantity    sql.NullFloat64
+	Recurrence           sql.NullString
+	RecurrenceOf         uuid.NullUUID
+	ShortCodeFarmUID     uuid.NullUUID
 }
 
 func (q TaskReadQueryMysql) FindAll(page, limit int) <-chan query.Result {
@@ -105,6 +122,109 @@ func (q TaskReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
 	return result
 }
 
+// FindAllByShortCode is to find the tasks with the short code, one in each farm numbering its tasks.
+func (q TaskReadQueryMysql) FindAllByShortCode(shortCode string) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		tasks := []storage.TaskRead{}
+
+		rows, err := q.DB.Query(`SELECT * FROM TASK_READ WHERE SHORT_CODE = ?`, shortCode)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		for rows.Next() {
+			taskRead, err := q.populateQueryResult(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			tasks = append(tasks, taskRead)
+		}
+
+		result <- query.Result{Result: tasks}
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskReadQueryMysql) FindByAreaID(areaUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		tasks := []storage.TaskRead{}
+
+		rows, err := q.DB.Query(`SELECT * FROM TASK_READ
+			WHERE UID IN (SELECT TASK_UID FROM TASK_READ_AREA WHERE AREA_UID = ?)
+			ORDER BY CREATED_DATE DESC`, areaUID.Bytes())
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			taskRead, err := q.populateQueryResult(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			tasks = append(tasks, taskRead)
+		}
+
+		result <- query.Result{Result: tasks}
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskReadQueryMysql) FindAllOpenByMaterial(materialID uuid.UUID, start, end time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		tasks := []storage.TaskRead{}
+
+		rows, err := q.DB.Query(`SELECT * FROM TASK_READ
+			WHERE STATUS = ? AND DOMAIN_DATA_MATERIAL_ID = ? AND ESTIMATED_MINUTES > 0
+			AND DUE_DATE < ? AND DATE_ADD(DUE_DATE, INTERVAL ESTIMATED_MINUTES MINUTE) > ?
+			ORDER BY DUE_DATE`, domain.TaskStatusCreated, materialID.Bytes(), end, start)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			taskRead, err := q.populateQueryResult(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			tasks = append(tasks, taskRead)
+		}
+
+		result <- query.Result{Result: tasks}
+		close(result)
+	}()
+
+	return result
+}
+
 func (q TaskReadQueryMysql) FindTasksWithFilter(params map[string]string, page, limit int) <-chan query.Result {
 	result := make(chan query.Result)
 
@@ -162,6 +282,13 @@ func (q TaskReadQueryMysql) FindTasksWithFilter(params map[string]string, page,
 			args = append(args, assetID.Bytes())
 		}
 
+		if value := params["farm_id"]; value != "" {
+			farmUID, _ := uuid.FromString(value)
+			sql += " AND SHORT_CODE_FARM_UID = ? "
+
+			args = append(args, farmUID.Bytes())
+		}
+
 		if page != 0 && limit != 0 {
 			sql += " LIMIT ? OFFSET ?"
 			offset := paginationhelper.CalculatePageToOffset(page, limit)
@@ -282,15 +409,23 @@ func (q TaskReadQueryMysql) CountTasksWithFilter(params map[string]string) <-cha
 	return result
 }
 
-func (TaskReadQueryMysql) populateQueryResult(rows *sql.Rows) (storage.TaskRead, error) {
+// populateQueryResult scans the TASK_READ columns, followed by the extra columns of the query if any.
+func (TaskReadQueryMysql) populateQueryResult(rows *sql.Rows, extra ...interface{}) (storage.TaskRead, error) {
 	rowsData := taskReadQueryResult{}
 
-	err := rows.Scan(
+	dest := []interface{}{
 		&rowsData.UID, &rowsData.Title, &rowsData.Description, &rowsData.CreatedDate,
 		&rowsData.DueDate, &rowsData.CompletedDate, &rowsData.CancelledDate,
 		&rowsData.Priority, &rowsData.Status, &rowsData.DomainCode, &rowsData.DomainDataMaterialID,
 		&rowsData.DomainDataAreaID, &rowsData.DomainDataCropID, &rowsData.Category, &rowsData.IsDue, &rowsData.AssetID,
-	)
+		&rowsData.ShortCode,
+		&rowsData.CostCentreID, &rowsData.CompletedBy, &rowsData.LabourMinutes, &rowsData.MaterialQuantity,
+		&rowsData.EstimatedMinutes, &rowsData.DependsOn, &rowsData.AffectedAreaIDs, &rowsData.PerAreaProgress,
+		&rowsData.DueDateAdjusted, &rowsData.TargetQuantity, &rowsData.TargetUnit, &rowsData.CompletedQuantity,
+		&rowsData.Recurrence, &rowsData.RecurrenceOf, &rowsData.ShortCodeFarmUID,
+	}
+
+	err := rows.Scan(append(dest, extra...)...)
 	if err != nil {
 		return storage.TaskRead{}, err
 	}
@@ -331,6 +466,17 @@ func (TaskReadQueryMysql) populateQueryResult(rows *sql.Rows) (storage.TaskRead,
 		domainDetails = domain.TaskDomainInventory{}
 	case domain.TaskDomainReservoirCode:
 		domainDetails = domain.TaskDomainReservoir{}
+	case domain.TaskDomainEquipmentCode:
+		equipmentID := (*uuid.UUID)(nil)
+
+		if rowsData.AssetID.Valid {
+			uid := rowsData.AssetID.UUID
+			equipmentID = &uid
+		}
+
+		domainDetails = domain.TaskDomainEquipment{
+			EquipmentID: equipmentID,
+		}
 	}
 
 	assetUID := &uuid.UUID{}
@@ -338,13 +484,75 @@ func (TaskReadQueryMysql) populateQueryResult(rows *sql.Rows) (storage.TaskRead,
 		assetUID = &rowsData.AssetID.UUID
 	}
 
+	var costCentreUID *uuid.UUID
+	if rowsData.CostCentreID.Valid {
+		costCentreUID = &rowsData.CostCentreID.UUID
+	}
+
+	var completedBy *uuid.UUID
+	if rowsData.CompletedBy.Valid {
+		completedBy = &rowsData.CompletedBy.UUID
+	}
+
 	isDue := false
 	if rowsData.IsDue == 1 {
 		isDue = true
 	}
 
+	// The tasks saved before the dependencies existed have them NULL.
+	dependsOn := []uuid.UUID(nil)
+
+	if rowsData.DependsOn.Valid && rowsData.DependsOn.String != "" {
+		err = json.Unmarshal([]byte(rowsData.DependsOn.String), &dependsOn)
+		if err != nil {
+			return storage.TaskRead{}, err
+		}
+	}
+
+	affectedAreaIDs := []uuid.UUID(nil)
+
+	if rowsData.AffectedAreaIDs.Valid && rowsData.AffectedAreaIDs.String != "" {
+		err = json.Unmarshal([]byte(rowsData.AffectedAreaIDs.String), &affectedAreaIDs)
+		if err != nil {
+			return storage.TaskRead{}, err
+		}
+	}
+
+	perAreaProgress := map[uuid.UUID]int(nil)
+
+	if rowsData.PerAreaProgress.Valid && rowsData.PerAreaProgress.String != "" {
+		err = json.Unmarshal([]byte(rowsData.PerAreaProgress.String), &perAreaProgress)
+		if err != nil {
+			return storage.TaskRead{}, err
+		}
+	}
+
+	// A NULL or null recurrence is a task which doesn't repeat.
+	var recurrence *domain.TaskRecurrence
+
+	if rowsData.Recurrence.Valid && rowsData.Recurrence.String != "" {
+		err = json.Unmarshal([]byte(rowsData.Recurrence.String), &recurrence)
+		if err != nil {
+			return storage.TaskRead{}, err
+		}
+	}
+
+	var recurrenceOf *uuid.UUID
+
+	if rowsData.RecurrenceOf.Valid {
+		recurrenceOf = &rowsData.RecurrenceOf.UUID
+	}
+
+	// The short codes without a farm are in the global sequence.
+	shortCodeFarmUID := uuid.Nil
+
+	if rowsData.ShortCodeFarmUID.Valid {
+		shortCodeFarmUID = rowsData.ShortCodeFarmUID.UUID
+	}
+
 	return storage.TaskRead{
 		UID:           taskUID,
+		ShortCode:     rowsData.ShortCode.String,
 		Title:         rowsData.Title,
 		Description:   rowsData.Description,
 		CreatedDate:   rowsData.CreatedDate,
@@ -358,5 +566,26 @@ func (TaskReadQueryMysql) populateQueryResult(rows *sql.Rows) (storage.TaskRead,
 		Category:      rowsData.Category,
 		IsDue:         isDue,
 		AssetID:       assetUID,
+
+		CostCentreID:     costCentreUID,
+		CompletedBy:      completedBy,
+		LabourMinutes:    int(rowsData.LabourMinutes.Int64),
+		MaterialQuantity: rowsData.MaterialQuantity.Float64,
+		EstimatedMinutes: int(rowsData.EstimatedMinutes.Int64),
+		DependsOn:        dependsOn,
+
+		AffectedAreaIDs: affectedAreaIDs,
+		PerAreaProgress: perAreaProgress,
+
+		DueDateAdjustedForBusinessHours: rowsData.DueDateAdjusted.Bool,
+
+		TargetQuantity:    rowsData.TargetQuantity.Float64,
+		TargetUnit:        rowsData.TargetUnit.String,
+		CompletedQuantity: rowsData.CompletedQuantity.Float64,
+
+		Recurrence:   recurrence,
+		RecurrenceOf: recurrenceOf,
+
+		ShortCodeFarmUID: shortCodeFarmUID,
 	}, nil
 }
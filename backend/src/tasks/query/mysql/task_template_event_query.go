@@ -0,0 +1,66 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/decoder"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskTemplateEventQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewTaskTemplateEventQueryMysql(db *sql.DB) query.TaskTemplateEvent {
+	return &TaskTemplateEventQueryMysql{DB: db}
+}
+
+func (f *TaskTemplateEventQueryMysql) FindAllByTaskTemplateID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.TaskTemplateEvent{}
+
+		rows, err := f.DB.Query("SELECT * FROM TASK_TEMPLATE_EVENT WHERE TASK_TEMPLATE_UID = ? ORDER BY VERSION ASC", uid.Bytes())
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		rowsData := struct {
+			ID              int
+			TaskTemplateUID []byte
+			Version         int
+			CreatedDate     time.Time
+			Event           []byte
+		}{}
+
+		for rows.Next() {
+			rows.Scan(&rowsData.ID, &rowsData.TaskTemplateUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event)
+
+			wrapper := decoder.TaskTemplateEventWrapper{}
+			json.Unmarshal(rowsData.Event, &wrapper)
+
+			taskTemplateUID, err := uuid.FromBytes(rowsData.TaskTemplateUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			events = append(events, storage.TaskTemplateEvent{
+				TaskTemplateUID: taskTemplateUID,
+				Version:         rowsData.Version,
+				CreatedDate:     rowsData.CreatedDate,
+				Event:           wrapper.Data,
+				Actor:           wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
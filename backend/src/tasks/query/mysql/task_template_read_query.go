@@ -0,0 +1,119 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskTemplateReadQueryMysql struct {
+	DB *sql.DB
+}
+
+func NewTaskTemplateReadQueryMysql(s *sql.DB) query.TaskTemplateRead {
+	return &TaskTemplateReadQueryMysql{DB: s}
+}
+
+func (q TaskTemplateReadQueryMysql) FindAll() <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		templates := []storage.TaskTemplateRead{}
+
+		rows, err := q.DB.Query(`SELECT UID, NAME, PARENT_TEMPLATE_UID, ITEMS, CREATED_DATE
+			FROM TASK_TEMPLATE_READ ORDER BY NAME ASC`)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		for rows.Next() {
+			templateRead, err := q.populateQueryResult(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			templates = append(templates, templateRead)
+		}
+
+		result <- query.Result{Result: templates}
+
+		close(result)
+	}()
+
+	return result
+}
+
+// FindByID is to find by ID.
+func (q TaskTemplateReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		template := storage.TaskTemplateRead{}
+
+		rows, err := q.DB.Query(`SELECT UID, NAME, PARENT_TEMPLATE_UID, ITEMS, CREATED_DATE
+			FROM TASK_TEMPLATE_READ WHERE UID = ?`, uid.Bytes())
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		for rows.Next() {
+			templateRead, err := q.populateQueryResult(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			template = templateRead
+		}
+
+		result <- query.Result{Result: template}
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskTemplateReadQueryMysql) populateQueryResult(rows *sql.Rows) (storage.TaskTemplateRead, error) {
+	rowsData := struct {
+		UID               []byte
+		Name              string
+		ParentTemplateUID []byte
+		Items             []byte
+		CreatedDate       time.Time
+	}{}
+
+	err := rows.Scan(&rowsData.UID, &rowsData.Name, &rowsData.ParentTemplateUID, &rowsData.Items, &rowsData.CreatedDate)
+	if err != nil {
+		return storage.TaskTemplateRead{}, err
+	}
+
+	templateUID, err := uuid.FromBytes(rowsData.UID)
+	if err != nil {
+		return storage.TaskTemplateRead{}, err
+	}
+
+	templateRead := storage.TaskTemplateRead{
+		UID:         templateUID,
+		Name:        rowsData.Name,
+		CreatedDate: rowsData.CreatedDate,
+	}
+
+	if len(rowsData.ParentTemplateUID) > 0 {
+		parentUID, err := uuid.FromBytes(rowsData.ParentTemplateUID)
+		if err != nil {
+			return storage.TaskTemplateRead{}, err
+		}
+
+		templateRead.ParentTemplateID = &parentUID
+	}
+
+	err = json.Unmarshal(rowsData.Items, &templateRead.Items)
+	if err != nil {
+		return storage.TaskTemplateRead{}, err
+	}
+
+	return templateRead, nil
+}
@@ -1,6 +1,8 @@
 package query
 
 import (
+	"time"
+
 	"github.com/gofrs/uuid"
 )
 
@@ -35,25 +37,57 @@ type TaskEvent interface {
 type TaskRead interface {
 	FindAll(page, limit int) <-chan Result
 	FindByID(taskUID uuid.UUID) <-chan Result
+	FindAllByShortCode(shortCode string) <-chan Result
+	// FindByAreaID finds the tasks covering the area, as one of their affected areas or as their single area.
+	FindByAreaID(areaUID uuid.UUID) <-chan Result
+	// FindAllOpenByMaterial finds the unfinished tasks using the material whose time window, from the due date
+	// for the estimated minutes, overlaps the one from start to end.
+	FindAllOpenByMaterial(materialID uuid.UUID, start, end time.Time) <-chan Result
 	FindTasksWithFilter(params map[string]string, page, limit int) <-chan Result
 	CountAll() <-chan Result
 	CountTasksWithFilter(params map[string]string) <-chan Result
 }
 
+type TaskArchive interface {
+	FindAll(page, limit int) <-chan Result
+	FindByID(taskUID uuid.UUID) <-chan Result
+	CountAll() <-chan Result
+}
+
+type TaskTemplateEvent interface {
+	FindAllByTaskTemplateID(uid uuid.UUID) <-chan Result
+}
+
+type TaskCatalogEvent interface {
+	FindAllByFarmID(farmUID uuid.UUID) <-chan Result
+}
+
+type TaskTemplateRead interface {
+	FindAll() <-chan Result
+	FindByID(uid uuid.UUID) <-chan Result
+}
+
 type Reservoir interface {
 	FindReservoirByID(reservoirUID uuid.UUID) <-chan Result
 }
 
+type Equipment interface {
+	// FindEquipmentByID finds the equipment which isn't deleted.
+	FindEquipmentByID(equipmentUID uuid.UUID) <-chan Result
+}
+
 // QUERY RESULTS
 
 type TaskAreaResult struct {
-	UID  uuid.UUID `json:"uid"`
-	Name string    `json:"name"`
+	UID     uuid.UUID `json:"uid"`
+	Name    string    `json:"name"`
+	FarmUID uuid.UUID `json:"farm_id"`
 }
 
 type TaskCropResult struct {
 	UID     uuid.UUID `json:"uid"`
 	BatchID string    `json:"batch_id"`
+	FarmUID uuid.UUID `json:"farm_id"`
 }
 
 type TaskMaterialResult struct {
@@ -64,6 +98,14 @@ type TaskMaterialResult struct {
 }
 
 type TaskReservoirResult struct {
-	UID  uuid.UUID `json:"uid"`
-	Name string    `json:"name"`
+	UID     uuid.UUID `json:"uid"`
+	Name    string    `json:"name"`
+	FarmUID uuid.UUID `json:"farm_id"`
+}
+
+type TaskEquipmentResult struct {
+	UID     uuid.UUID `json:"uid"`
+	Name    string    `json:"name"`
+	Type    string    `json:"type"`
+	FarmUID uuid.UUID `json:"farm_id"`
 }
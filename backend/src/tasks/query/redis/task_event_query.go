@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"encoding/json"
+
+	"github.com/gofrs/uuid"
+	redisstorage "github.com/usetania/tania-core/src/storage/redis"
+	"github.com/usetania/tania-core/src/tasks/decoder"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskEventQueryRedis struct {
+	Storage *redisstorage.RedisStreamsEventStorage
+}
+
+func NewTaskEventQueryRedis(s *redisstorage.RedisStreamsEventStorage) query.TaskEvent {
+	return &TaskEventQueryRedis{Storage: s}
+}
+
+func (f *TaskEventQueryRedis) FindAllByTaskID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		defer close(result)
+
+		streamEvents, err := f.Storage.Range(redisstorage.AggregateTask, uid)
+		if err != nil {
+			result <- query.Result{Error: err}
+
+			return
+		}
+
+		events := []storage.TaskEvent{}
+
+		for _, v := range streamEvents {
+			wrapper := decoder.TaskEventWrapper{}
+
+			err := json.Unmarshal(v.Event, &wrapper)
+			if err != nil {
+				result <- query.Result{Error: err}
+
+				return
+			}
+
+			events = append(events, storage.TaskEvent{
+				TaskUID:     uid,
+				Version:     v.Version,
+				CreatedDate: v.CreatedDate,
+				Event:       wrapper.Data,
+				Actor:       wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+	}()
+
+	return result
+}
@@ -22,11 +22,12 @@ func (s CropQuerySqlite) FindCropByID(uid uuid.UUID) <-chan query.Result {
 		rowsData := struct {
 			UID     string
 			BatchID string
+			FarmUID string
 		}{}
 		crop := query.TaskCropResult{}
 
-		s.DB.QueryRow(`SELECT UID, BATCH_ID
-			FROM CROP_READ WHERE UID = ?`, uid).Scan(&rowsData.UID, &rowsData.BatchID)
+		s.DB.QueryRow(`SELECT UID, BATCH_ID, FARM_UID
+			FROM CROP_READ WHERE UID = ?`, uid).Scan(&rowsData.UID, &rowsData.BatchID, &rowsData.FarmUID)
 
 		cropUID, err := uuid.FromString(rowsData.UID)
 		if err != nil {
@@ -35,6 +36,7 @@ func (s CropQuerySqlite) FindCropByID(uid uuid.UUID) <-chan query.Result {
 
 		crop.UID = cropUID
 		crop.BatchID = rowsData.BatchID
+		crop.FarmUID, _ = uuid.FromString(rowsData.FarmUID)
 
 		result <- query.Result{Result: crop}
 
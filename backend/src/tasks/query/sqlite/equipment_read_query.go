@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/query"
+)
+
+type EquipmentQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewEquipmentQuerySqlite(db *sql.DB) query.Equipment {
+	return EquipmentQuerySqlite{DB: db}
+}
+
+func (s EquipmentQuerySqlite) FindEquipmentByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		rowsData := struct {
+			UID     string
+			Name    string
+			Type    string
+			FarmUID string
+		}{}
+
+		err := s.DB.QueryRow(`SELECT UID, NAME, TYPE, FARM_UID
+			FROM EQUIPMENT_READ WHERE UID = ? AND IS_DELETED = ?`, uid, false).Scan(
+			&rowsData.UID, &rowsData.Name, &rowsData.Type, &rowsData.FarmUID)
+		if errors.Is(err, sql.ErrNoRows) {
+			result <- query.Result{Result: query.TaskEquipmentResult{}}
+			close(result)
+
+			return
+		}
+
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+
+		equipmentUID, err := uuid.FromString(rowsData.UID)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+
+		farmUID, _ := uuid.FromString(rowsData.FarmUID)
+
+		result <- query.Result{Result: query.TaskEquipmentResult{
+			UID:     equipmentUID,
+			Name:    rowsData.Name,
+			Type:    rowsData.Type,
+			FarmUID: farmUID,
+		}}
+
+		close(result)
+	}()
+
+	return result
+}
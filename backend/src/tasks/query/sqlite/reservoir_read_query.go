@@ -20,13 +20,14 @@ func (s ReservoirQuerySqlite) FindReservoirByID(uid uuid.UUID) <-chan query.Resu
 
 	go func() {
 		rowsData := struct {
-			UID  string
-			Name string
+			UID     string
+			Name    string
+			FarmUID string
 		}{}
 		reservoir := query.TaskReservoirResult{}
 
-		s.DB.QueryRow(`SELECT UID, NAME
-			FROM RESERVOIR_READ WHERE UID = ?`, uid).Scan(&rowsData.UID, &rowsData.Name)
+		s.DB.QueryRow(`SELECT UID, NAME, FARM_UID
+			FROM RESERVOIR_READ WHERE UID = ?`, uid).Scan(&rowsData.UID, &rowsData.Name, &rowsData.FarmUID)
 
 		reservoirUID, err := uuid.FromString(rowsData.UID)
 		if err != nil {
@@ -35,6 +36,7 @@ func (s ReservoirQuerySqlite) FindReservoirByID(uid uuid.UUID) <-chan query.Resu
 
 		reservoir.UID = reservoirUID
 		reservoir.Name = rowsData.Name
+		reservoir.FarmUID, _ = uuid.FromString(rowsData.FarmUID)
 
 		result <- query.Result{Result: reservoir}
 
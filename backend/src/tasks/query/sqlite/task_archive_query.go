@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/helper/paginationhelper"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// TaskArchiveQuerySqlite queries the archive database.
+// TASK_ARCHIVE has the columns of TASK_READ followed by ARCHIVED_DATE.
+type TaskArchiveQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewTaskArchiveQuerySqlite(s *sql.DB) query.TaskArchive {
+	return &TaskArchiveQuerySqlite{DB: s}
+}
+
+func (q TaskArchiveQuerySqlite) FindAll(page, limit int) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		sql := `SELECT * FROM TASK_ARCHIVE ORDER BY CREATED_DATE DESC`
+
+		var args []interface{}
+
+		if page != 0 && limit != 0 {
+			sql += " LIMIT ? OFFSET ?"
+			offset := paginationhelper.CalculatePageToOffset(page, limit)
+			args = append(args, limit, offset)
+		}
+
+		tasks, err := q.find(sql, args...)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+
+		result <- query.Result{Result: tasks}
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskArchiveQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		tasks, err := q.find(`SELECT * FROM TASK_ARCHIVE WHERE UID = ?`, uid)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+
+		task := storage.TaskRead{}
+		if len(tasks) > 0 {
+			task = tasks[0]
+		}
+
+		result <- query.Result{Result: task}
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskArchiveQuerySqlite) CountAll() <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		total := 0
+
+		err := q.DB.QueryRow(`SELECT COUNT(UID) FROM TASK_ARCHIVE`).Scan(&total)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+
+		result <- query.Result{Result: total}
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskArchiveQuerySqlite) find(sql string, args ...interface{}) ([]storage.TaskRead, error) {
+	rows, err := q.DB.Query(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []storage.TaskRead{}
+
+	for rows.Next() {
+		var archivedDate string
+
+		task, err := TaskReadQuerySqlite{}.populateQueryResult(rows, &archivedDate)
+		if err != nil {
+			return nil, err
+		}
+
+		d, err := time.Parse(time.RFC3339, archivedDate)
+		if err != nil {
+			return nil, err
+		}
+
+		task.ArchivedDate = &d
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, rows.Err()
+}
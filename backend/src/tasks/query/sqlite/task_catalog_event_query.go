@@ -0,0 +1,71 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/decoder"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskCatalogEventQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewTaskCatalogEventQuerySqlite(db *sql.DB) query.TaskCatalogEvent {
+	return &TaskCatalogEventQuerySqlite{DB: db}
+}
+
+func (f *TaskCatalogEventQuerySqlite) FindAllByFarmID(farmUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.TaskCatalogEvent{}
+
+		rows, err := f.DB.Query("SELECT * FROM TASK_CATALOG_EVENT WHERE FARM_UID = ? ORDER BY VERSION ASC", farmUID)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		rowsData := struct {
+			ID          int
+			FarmUID     string
+			Version     int
+			CreatedDate string
+			Event       []byte
+		}{}
+
+		for rows.Next() {
+			rows.Scan(&rowsData.ID, &rowsData.FarmUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event)
+
+			wrapper := decoder.TaskCatalogEventWrapper{}
+			json.Unmarshal(rowsData.Event, &wrapper)
+
+			eventFarmUID, err := uuid.FromString(rowsData.FarmUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			createdDate, err := time.Parse(time.RFC3339, rowsData.CreatedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			events = append(events, storage.TaskCatalogEvent{
+				FarmUID:     eventFarmUID,
+				Version:     rowsData.Version,
+				CreatedDate: createdDate,
+				Event:       wrapper.Data,
+				Actor:       wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
@@ -59,6 +59,7 @@ func (f *TaskEventQuerySqlite) FindAllByTaskID(uid uuid.UUID) <-chan query.Resul
 				Version:     rowsData.Version,
 				CreatedDate: createdDate,
 				Event:       wrapper.Data,
+				Actor:       wrapper.Actor,
 			})
 		}
 
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"database/sql"
+	"encoding/json"
 	"strconv"
 	"time"
 
@@ -36,6 +37,22 @@ type taskReadQueryResult struct {
 	Category             string
 	IsDue                bool
 	AssetID              sql.NullString
+	ShortCode            sql.NullString
+	CostCentreID         sql.NullString
+	CompletedBy          sql.NullString
+	LabourMinutes        sql.NullInt64
+	MaterialQuantity     sql.NullFloat64
+	EstimatedMinutes     sql.NullInt64
+	DependsOn            sql.NullString
+	AffectedAreaIDs      sql.NullString
+	PerAreaProgress      sql.NullString
+	DueDateAdjusted      sql.NullBool
+	TargetQuantity       sql.NullFloat64
+	TargetUnit           sql.NullString
+	CompletedQuantity    sql.NullFloat64
+	Recurrence           sql.NullString
+	RecurrenceOf         sql.NullString
+	ShortCodeFarmUID     sql.NullString
 }
 
 func (q TaskReadQuerySqlite) FindAll(page, limit int) <-chan query.Result {
@@ -104,6 +121,110 @@ func (q TaskReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
 	return result
 }
 
+// FindAllByShortCode is to find the tasks with the short code, one in each farm numbering its tasks.
+func (q TaskReadQuerySqlite) FindAllByShortCode(shortCode string) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		tasks := []storage.TaskRead{}
+
+		rows, err := q.DB.Query(`SELECT * FROM TASK_READ WHERE SHORT_CODE = ?`, shortCode)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		for rows.Next() {
+			taskRead, err := q.populateQueryResult(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			tasks = append(tasks, taskRead)
+		}
+
+		result <- query.Result{Result: tasks}
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskReadQuerySqlite) FindByAreaID(areaUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		tasks := []storage.TaskRead{}
+
+		rows, err := q.DB.Query(`SELECT * FROM TASK_READ
+			WHERE UID IN (SELECT TASK_UID FROM TASK_READ_AREA WHERE AREA_UID = ?)
+			ORDER BY CREATED_DATE DESC`, areaUID)
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			taskRead, err := q.populateQueryResult(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			tasks = append(tasks, taskRead)
+		}
+
+		result <- query.Result{Result: tasks}
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskReadQuerySqlite) FindAllOpenByMaterial(materialID uuid.UUID, start, end time.Time) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		tasks := []storage.TaskRead{}
+
+		rows, err := q.DB.Query(`SELECT * FROM TASK_READ
+			WHERE STATUS = ? AND DOMAIN_DATA_MATERIAL_ID = ? AND ESTIMATED_MINUTES > 0
+			AND julianday(DUE_DATE) < julianday(?) AND julianday(DUE_DATE) + ESTIMATED_MINUTES / 1440.0 > julianday(?)
+			ORDER BY DUE_DATE`, domain.TaskStatusCreated, materialID,
+			end.Format(time.RFC3339), start.Format(time.RFC3339))
+		if err != nil {
+			result <- query.Result{Error: err}
+			close(result)
+
+			return
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			taskRead, err := q.populateQueryResult(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+				close(result)
+
+				return
+			}
+
+			tasks = append(tasks, taskRead)
+		}
+
+		result <- query.Result{Result: tasks}
+		close(result)
+	}()
+
+	return result
+}
+
 func (q TaskReadQuerySqlite) FindTasksWithFilter(params map[string]string, page, limit int) <-chan query.Result {
 	result := make(chan query.Result)
 
@@ -161,6 +282,13 @@ func (q TaskReadQuerySqlite) FindTasksWithFilter(params map[string]string, page,
 			args = append(args, assetID)
 		}
 
+		if value := params["farm_id"]; value != "" {
+			farmUID, _ := uuid.FromString(value)
+			sql += " AND SHORT_CODE_FARM_UID = ? "
+
+			args = append(args, farmUID)
+		}
+
 		if page != 0 && limit != 0 {
 			sql += " LIMIT ? OFFSET ?"
 			offset := paginationhelper.CalculatePageToOffset(page, limit)
@@ -280,16 +408,24 @@ func (q TaskReadQuerySqlite) CountTasksWithFilter(params map[string]string) <-ch
 	return result
 }
 
-func (TaskReadQuerySqlite) populateQueryResult(rows *sql.Rows) (storage.TaskRead, error) {
+// populateQueryResult scans the TASK_READ columns, followed by the extra columns of the query if any.
+func (TaskReadQuerySqlite) populateQueryResult(rows *sql.Rows, extra ...interface{}) (storage.TaskRead, error) {
 	rowsData := taskReadQueryResult{}
 
-	err := rows.Scan(
+	dest := []interface{}{
 		&rowsData.UID, &rowsData.Title, &rowsData.Description, &rowsData.CreatedDate,
 		&rowsData.DueDate, &rowsData.CompletedDate, &rowsData.CancelledDate,
 		&rowsData.Priority, &rowsData.Status, &rowsData.DomainCode, &rowsData.DomainDataMaterialID,
 		&rowsData.DomainDataAreaID,
 		&rowsData.Category, &rowsData.IsDue, &rowsData.AssetID,
-	)
+		&rowsData.ShortCode,
+		&rowsData.CostCentreID, &rowsData.CompletedBy, &rowsData.LabourMinutes, &rowsData.MaterialQuantity,
+		&rowsData.EstimatedMinutes, &rowsData.DependsOn, &rowsData.AffectedAreaIDs, &rowsData.PerAreaProgress,
+		&rowsData.DueDateAdjusted, &rowsData.TargetQuantity, &rowsData.TargetUnit, &rowsData.CompletedQuantity,
+		&rowsData.Recurrence, &rowsData.RecurrenceOf, &rowsData.ShortCodeFarmUID,
+	}
+
+	err := rows.Scan(append(dest, extra...)...)
 	if err != nil {
 		return storage.TaskRead{}, err
 	}
@@ -404,6 +540,21 @@ func (TaskReadQuerySqlite) populateQueryResult(rows *sql.Rows) (storage.TaskRead
 		domainDetails = domain.TaskDomainReservoir{
 			MaterialID: materialID,
 		}
+	case domain.TaskDomainEquipmentCode:
+		equipmentID := (*uuid.UUID)(nil)
+
+		if rowsData.AssetID.Valid && rowsData.AssetID.String != "" {
+			uid, err := uuid.FromString(rowsData.AssetID.String)
+			if err != nil {
+				return storage.TaskRead{}, err
+			}
+
+			equipmentID = &uid
+		}
+
+		domainDetails = domain.TaskDomainEquipment{
+			EquipmentID: equipmentID,
+		}
 	}
 
 	var assetUID *uuid.UUID
@@ -417,8 +568,90 @@ func (TaskReadQuerySqlite) populateQueryResult(rows *sql.Rows) (storage.TaskRead
 		assetUID = &uid
 	}
 
+	var costCentreUID *uuid.UUID
+
+	if rowsData.CostCentreID.Valid && rowsData.CostCentreID.String != "" {
+		uid, err := uuid.FromString(rowsData.CostCentreID.String)
+		if err != nil {
+			return storage.TaskRead{}, err
+		}
+
+		costCentreUID = &uid
+	}
+
+	var completedBy *uuid.UUID
+
+	if rowsData.CompletedBy.Valid && rowsData.CompletedBy.String != "" {
+		uid, err := uuid.FromString(rowsData.CompletedBy.String)
+		if err != nil {
+			return storage.TaskRead{}, err
+		}
+
+		completedBy = &uid
+	}
+
+	// The tasks saved before the dependencies existed have them NULL.
+	dependsOn := []uuid.UUID(nil)
+
+	if rowsData.DependsOn.Valid && rowsData.DependsOn.String != "" {
+		err = json.Unmarshal([]byte(rowsData.DependsOn.String), &dependsOn)
+		if err != nil {
+			return storage.TaskRead{}, err
+		}
+	}
+
+	affectedAreaIDs := []uuid.UUID(nil)
+
+	if rowsData.AffectedAreaIDs.Valid && rowsData.AffectedAreaIDs.String != "" {
+		err = json.Unmarshal([]byte(rowsData.AffectedAreaIDs.String), &affectedAreaIDs)
+		if err != nil {
+			return storage.TaskRead{}, err
+		}
+	}
+
+	perAreaProgress := map[uuid.UUID]int(nil)
+
+	if rowsData.PerAreaProgress.Valid && rowsData.PerAreaProgress.String != "" {
+		err = json.Unmarshal([]byte(rowsData.PerAreaProgress.String), &perAreaProgress)
+		if err != nil {
+			return storage.TaskRead{}, err
+		}
+	}
+
+	// A NULL or null recurrence is a task which doesn't repeat.
+	var recurrence *domain.TaskRecurrence
+
+	if rowsData.Recurrence.Valid && rowsData.Recurrence.String != "" {
+		err = json.Unmarshal([]byte(rowsData.Recurrence.String), &recurrence)
+		if err != nil {
+			return storage.TaskRead{}, err
+		}
+	}
+
+	var recurrenceOf *uuid.UUID
+
+	if rowsData.RecurrenceOf.Valid && rowsData.RecurrenceOf.String != "" {
+		uid, err := uuid.FromString(rowsData.RecurrenceOf.String)
+		if err != nil {
+			return storage.TaskRead{}, err
+		}
+
+		recurrenceOf = &uid
+	}
+
+	// The short codes without a farm are in the global sequence.
+	shortCodeFarmUID := uuid.Nil
+
+	if rowsData.ShortCodeFarmUID.Valid && rowsData.ShortCodeFarmUID.String != "" {
+		shortCodeFarmUID, err = uuid.FromString(rowsData.ShortCodeFarmUID.String)
+		if err != nil {
+			return storage.TaskRead{}, err
+		}
+	}
+
 	return storage.TaskRead{
 		UID:           taskUID,
+		ShortCode:     rowsData.ShortCode.String,
 		Title:         rowsData.Title,
 		Description:   rowsData.Description,
 		CreatedDate:   createdDate,
@@ -432,5 +665,26 @@ func (TaskReadQuerySqlite) populateQueryResult(rows *sql.Rows) (storage.TaskRead
 		Category:      rowsData.Category,
 		IsDue:         rowsData.IsDue,
 		AssetID:       assetUID,
+
+		CostCentreID:     costCentreUID,
+		CompletedBy:      completedBy,
+		LabourMinutes:    int(rowsData.LabourMinutes.Int64),
+		MaterialQuantity: rowsData.MaterialQuantity.Float64,
+		EstimatedMinutes: int(rowsData.EstimatedMinutes.Int64),
+		DependsOn:        dependsOn,
+
+		AffectedAreaIDs: affectedAreaIDs,
+		PerAreaProgress: perAreaProgress,
+
+		DueDateAdjustedForBusinessHours: rowsData.DueDateAdjusted.Bool,
+
+		TargetQuantity:    rowsData.TargetQuantity.Float64,
+		TargetUnit:        rowsData.TargetUnit.String,
+		CompletedQuantity: rowsData.CompletedQuantity.Float64,
+
+		Recurrence:   recurrence,
+		RecurrenceOf: recurrenceOf,
+
+		ShortCodeFarmUID: shortCodeFarmUID,
 	}, nil
 }
@@ -0,0 +1,71 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/decoder"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskTemplateEventQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewTaskTemplateEventQuerySqlite(db *sql.DB) query.TaskTemplateEvent {
+	return &TaskTemplateEventQuerySqlite{DB: db}
+}
+
+func (f *TaskTemplateEventQuerySqlite) FindAllByTaskTemplateID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		events := []storage.TaskTemplateEvent{}
+
+		rows, err := f.DB.Query("SELECT * FROM TASK_TEMPLATE_EVENT WHERE TASK_TEMPLATE_UID = ? ORDER BY VERSION ASC", uid)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		rowsData := struct {
+			ID              int
+			TaskTemplateUID string
+			Version         int
+			CreatedDate     string
+			Event           []byte
+		}{}
+
+		for rows.Next() {
+			rows.Scan(&rowsData.ID, &rowsData.TaskTemplateUID, &rowsData.Version, &rowsData.CreatedDate, &rowsData.Event)
+
+			wrapper := decoder.TaskTemplateEventWrapper{}
+			json.Unmarshal(rowsData.Event, &wrapper)
+
+			taskTemplateUID, err := uuid.FromString(rowsData.TaskTemplateUID)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			createdDate, err := time.Parse(time.RFC3339, rowsData.CreatedDate)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			events = append(events, storage.TaskTemplateEvent{
+				TaskTemplateUID: taskTemplateUID,
+				Version:         rowsData.Version,
+				CreatedDate:     createdDate,
+				Event:           wrapper.Data,
+				Actor:           wrapper.Actor,
+			})
+		}
+
+		result <- query.Result{Result: events}
+		close(result)
+	}()
+
+	return result
+}
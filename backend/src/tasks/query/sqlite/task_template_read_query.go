@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskTemplateReadQuerySqlite struct {
+	DB *sql.DB
+}
+
+func NewTaskTemplateReadQuerySqlite(s *sql.DB) query.TaskTemplateRead {
+	return &TaskTemplateReadQuerySqlite{DB: s}
+}
+
+func (q TaskTemplateReadQuerySqlite) FindAll() <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		templates := []storage.TaskTemplateRead{}
+
+		rows, err := q.DB.Query(`SELECT UID, NAME, PARENT_TEMPLATE_UID, ITEMS, CREATED_DATE
+			FROM TASK_TEMPLATE_READ ORDER BY NAME ASC`)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		for rows.Next() {
+			templateRead, err := q.populateQueryResult(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			templates = append(templates, templateRead)
+		}
+
+		result <- query.Result{Result: templates}
+
+		close(result)
+	}()
+
+	return result
+}
+
+// FindByID is to find by ID.
+func (q TaskTemplateReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result)
+
+	go func() {
+		template := storage.TaskTemplateRead{}
+
+		rows, err := q.DB.Query(`SELECT UID, NAME, PARENT_TEMPLATE_UID, ITEMS, CREATED_DATE
+			FROM TASK_TEMPLATE_READ WHERE UID = ?`, uid)
+		if err != nil {
+			result <- query.Result{Error: err}
+		}
+
+		for rows.Next() {
+			templateRead, err := q.populateQueryResult(rows)
+			if err != nil {
+				result <- query.Result{Error: err}
+			}
+
+			template = templateRead
+		}
+
+		result <- query.Result{Result: template}
+		close(result)
+	}()
+
+	return result
+}
+
+func (q TaskTemplateReadQuerySqlite) populateQueryResult(rows *sql.Rows) (storage.TaskTemplateRead, error) {
+	rowsData := struct {
+		UID               string
+		Name              string
+		ParentTemplateUID sql.NullString
+		Items             []byte
+		CreatedDate       string
+	}{}
+
+	err := rows.Scan(&rowsData.UID, &rowsData.Name, &rowsData.ParentTemplateUID, &rowsData.Items, &rowsData.CreatedDate)
+	if err != nil {
+		return storage.TaskTemplateRead{}, err
+	}
+
+	templateUID, err := uuid.FromString(rowsData.UID)
+	if err != nil {
+		return storage.TaskTemplateRead{}, err
+	}
+
+	templateRead := storage.TaskTemplateRead{
+		UID:  templateUID,
+		Name: rowsData.Name,
+	}
+
+	if rowsData.ParentTemplateUID.Valid && rowsData.ParentTemplateUID.String != "" {
+		parentUID, err := uuid.FromString(rowsData.ParentTemplateUID.String)
+		if err != nil {
+			return storage.TaskTemplateRead{}, err
+		}
+
+		templateRead.ParentTemplateID = &parentUID
+	}
+
+	err = json.Unmarshal(rowsData.Items, &templateRead.Items)
+	if err != nil {
+		return storage.TaskTemplateRead{}, err
+	}
+
+	templateRead.CreatedDate, err = time.Parse(time.RFC3339, rowsData.CreatedDate)
+	if err != nil {
+		return storage.TaskTemplateRead{}, err
+	}
+
+	return templateRead, nil
+}
@@ -0,0 +1,41 @@
+package inmemory
+
+import (
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskArchiveRepositoryInMemory struct {
+	ReadStorage    *storage.TaskReadStorage
+	ArchiveStorage *storage.TaskArchiveStorage
+}
+
+func NewTaskArchiveRepositoryInMemory(
+	readStorage *storage.TaskReadStorage,
+	archiveStorage *storage.TaskArchiveStorage,
+) repository.TaskArchive {
+	return &TaskArchiveRepositoryInMemory{ReadStorage: readStorage, ArchiveStorage: archiveStorage}
+}
+
+// Archive is to move the task read into the archive.
+func (f *TaskArchiveRepositoryInMemory) Archive(taskRead *storage.TaskRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.ReadStorage.Lock.Lock()
+		defer f.ReadStorage.Lock.Unlock()
+
+		f.ArchiveStorage.Lock.Lock()
+		defer f.ArchiveStorage.Lock.Unlock()
+
+		f.ArchiveStorage.TaskArchiveMap[taskRead.UID] = *taskRead
+		unindexTaskAreas(f.ReadStorage, taskRead.UID)
+		delete(f.ReadStorage.TaskReadMap, taskRead.UID)
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,48 @@
+package inmemory
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskCatalogEventRepositoryInMemory struct {
+	Storage *storage.TaskCatalogEventStorage
+}
+
+func NewTaskCatalogEventRepositoryInMemory(s *storage.TaskCatalogEventStorage) repository.TaskCatalogEvent {
+	return &TaskCatalogEventRepositoryInMemory{Storage: s}
+}
+
+// Save is to save.
+func (f *TaskCatalogEventRepositoryInMemory) Save(
+	farmUID uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		for _, v := range events {
+			latestVersion++
+
+			f.Storage.TaskCatalogEvents = append(f.Storage.TaskCatalogEvents, storage.TaskCatalogEvent{
+				FarmUID:     farmUID,
+				Version:     latestVersion,
+				CreatedDate: time.Now(),
+				Event:       v,
+				Actor:       by,
+			})
+		}
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
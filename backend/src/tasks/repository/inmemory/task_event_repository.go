@@ -1,7 +1,10 @@
 package inmemory
 
 import (
+	"time"
+
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/tasks/repository"
 	"github.com/usetania/tania-core/src/tasks/storage"
 )
@@ -15,7 +18,9 @@ func NewTaskEventRepositoryInMemory(s *storage.TaskEventStorage) repository.Task
 }
 
 // Save is to save.
-func (f *TaskEventRepositoryInMemory) Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error {
+func (f *TaskEventRepositoryInMemory) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
 	result := make(chan error)
 
 	go func() {
@@ -26,9 +31,11 @@ func (f *TaskEventRepositoryInMemory) Save(uid uuid.UUID, latestVersion int, eve
 			latestVersion++
 
 			f.Storage.TaskEvents = append(f.Storage.TaskEvents, storage.TaskEvent{
-				TaskUID: uid,
-				Version: latestVersion,
-				Event:   v,
+				TaskUID:     uid,
+				Version:     latestVersion,
+				CreatedDate: time.Now(),
+				Event:       v,
+				Actor:       by,
 			})
 		}
 
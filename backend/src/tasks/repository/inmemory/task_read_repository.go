@@ -1,6 +1,7 @@
 package inmemory
 
 import (
+	"github.com/gofrs/uuid"
 	"github.com/usetania/tania-core/src/tasks/repository"
 	"github.com/usetania/tania-core/src/tasks/storage"
 )
@@ -21,8 +22,18 @@ func (f *TaskReadRepositoryInMemory) Save(taskRead *storage.TaskRead) <-chan err
 		f.Storage.Lock.Lock()
 		defer f.Storage.Lock.Unlock()
 
+		unindexTaskAreas(f.Storage, taskRead.UID)
+
 		f.Storage.TaskReadMap[taskRead.UID] = *taskRead
 
+		for _, v := range taskRead.AreaIDs() {
+			if f.Storage.AreaTaskMap[v] == nil {
+				f.Storage.AreaTaskMap[v] = map[uuid.UUID]bool{}
+			}
+
+			f.Storage.AreaTaskMap[v][taskRead.UID] = true
+		}
+
 		result <- nil
 
 		close(result)
@@ -30,3 +41,19 @@ func (f *TaskReadRepositoryInMemory) Save(taskRead *storage.TaskRead) <-chan err
 
 	return result
 }
+
+// unindexTaskAreas removes the task from the areas it covered, the storage has to be locked.
+func unindexTaskAreas(s *storage.TaskReadStorage, uid uuid.UUID) {
+	previous, ok := s.TaskReadMap[uid]
+	if !ok {
+		return
+	}
+
+	for _, v := range previous.AreaIDs() {
+		delete(s.AreaTaskMap[v], uid)
+
+		if len(s.AreaTaskMap[v]) == 0 {
+			delete(s.AreaTaskMap, v)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package inmemory
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskTemplateEventRepositoryInMemory struct {
+	Storage *storage.TaskTemplateEventStorage
+}
+
+func NewTaskTemplateEventRepositoryInMemory(s *storage.TaskTemplateEventStorage) repository.TaskTemplateEvent {
+	return &TaskTemplateEventRepositoryInMemory{Storage: s}
+}
+
+// Save is to save.
+func (f *TaskTemplateEventRepositoryInMemory) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		for _, v := range events {
+			latestVersion++
+
+			f.Storage.TaskTemplateEvents = append(f.Storage.TaskTemplateEvents, storage.TaskTemplateEvent{
+				TaskTemplateUID: uid,
+				Version:         latestVersion,
+				CreatedDate:     time.Now(),
+				Event:           v,
+				Actor:           by,
+			})
+		}
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
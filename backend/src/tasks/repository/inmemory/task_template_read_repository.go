@@ -0,0 +1,32 @@
+package inmemory
+
+import (
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskTemplateReadRepositoryInMemory struct {
+	Storage *storage.TaskTemplateReadStorage
+}
+
+func NewTaskTemplateReadRepositoryInMemory(s *storage.TaskTemplateReadStorage) repository.TaskTemplateRead {
+	return &TaskTemplateReadRepositoryInMemory{Storage: s}
+}
+
+// Save is to save.
+func (f *TaskTemplateReadRepositoryInMemory) Save(taskTemplateRead *storage.TaskTemplateRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		f.Storage.Lock.Lock()
+		defer f.Storage.Lock.Unlock()
+
+		f.Storage.TaskTemplateReadMap[taskTemplateRead.UID] = *taskTemplateRead
+
+		result <- nil
+
+		close(result)
+	}()
+
+	return result
+}
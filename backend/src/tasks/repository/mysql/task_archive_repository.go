@@ -0,0 +1,145 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// TaskArchiveRepositoryMysql moves the task reads from the main database into the archive database.
+type TaskArchiveRepositoryMysql struct {
+	DB        *sql.DB
+	ArchiveDB *sql.DB
+}
+
+func NewTaskArchiveRepositoryMysql(db, archiveDB *sql.DB) repository.TaskArchive {
+	return &TaskArchiveRepositoryMysql{DB: db, ArchiveDB: archiveDB}
+}
+
+// Archive saves the task in the archive before deleting it from TASK_READ,
+// so an interrupted move is completed by archiving the task again.
+func (f *TaskArchiveRepositoryMysql) Archive(taskRead *storage.TaskRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		var domainDataMaterialID []byte
+
+		var domainDataAreaID []byte
+
+		switch v := taskRead.DomainDetails.(type) {
+		case domain.TaskDomainCrop:
+			if v.MaterialID != nil {
+				domainDataMaterialID = v.MaterialID.Bytes()
+			}
+
+			if v.AreaID != nil {
+				domainDataAreaID = v.AreaID.Bytes()
+			}
+		}
+
+		var assetID []byte
+		if taskRead.AssetID != nil {
+			assetID = taskRead.AssetID.Bytes()
+		}
+
+		var costCentreID []byte
+		if taskRead.CostCentreID != nil {
+			costCentreID = taskRead.CostCentreID.Bytes()
+		}
+
+		var completedBy []byte
+		if taskRead.CompletedBy != nil {
+			completedBy = taskRead.CompletedBy.Bytes()
+		}
+
+		var recurrenceOf []byte
+		if taskRead.RecurrenceOf != nil {
+			recurrenceOf = taskRead.RecurrenceOf.Bytes()
+		}
+
+		// NULL until the task has a code, so the unique index of the codes only holds the numbered tasks.
+		var shortCodeFarmUID []byte
+		if taskRead.ShortCode != "" {
+			shortCodeFarmUID = taskRead.ShortCodeFarmUID.Bytes()
+		}
+
+		dependsOn, err := json.Marshal(taskRead.DependsOn)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		affectedAreaIDs, err := json.Marshal(taskRead.AffectedAreaIDs)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		perAreaProgress, err := json.Marshal(taskRead.PerAreaProgress)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		recurrence, err := json.Marshal(taskRead.Recurrence)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		_, err = f.ArchiveDB.Exec(`REPLACE INTO TASK_ARCHIVE (
+			UID, TITLE, DESCRIPTION, CREATED_DATE, DUE_DATE,
+			COMPLETED_DATE, CANCELLED_DATE, PRIORITY, STATUS,
+			DOMAIN_CODE, DOMAIN_DATA_MATERIAL_ID, DOMAIN_DATA_AREA_ID, CATEGORY, IS_DUE, ASSET_ID, SHORT_CODE,
+			COST_CENTRE_ID, COMPLETED_BY, LABOUR_MINUTES, MATERIAL_QUANTITY, ESTIMATED_MINUTES, DEPENDS_ON,
+			AFFECTED_AREA_IDS, PER_AREA_PROGRESS, DUE_DATE_ADJUSTED, TARGET_QUANTITY, TARGET_UNIT, COMPLETED_QUANTITY,
+			RECURRENCE, RECURRENCE_OF, SHORT_CODE_FARM_UID, ARCHIVED_DATE)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			taskRead.UID.Bytes(), taskRead.Title, taskRead.Description, taskRead.CreatedDate, taskRead.DueDate,
+			taskRead.CompletedDate, taskRead.CancelledDate, taskRead.Priority, taskRead.Status,
+			taskRead.Domain, domainDataMaterialID, domainDataAreaID,
+			taskRead.Category, taskRead.IsDue, assetID, taskRead.ShortCode,
+			costCentreID, completedBy, taskRead.LabourMinutes, taskRead.MaterialQuantity, taskRead.EstimatedMinutes,
+			string(dependsOn), string(affectedAreaIDs), string(perAreaProgress),
+			taskRead.DueDateAdjustedForBusinessHours, taskRead.TargetQuantity, taskRead.TargetUnit,
+			taskRead.CompletedQuantity, string(recurrence), recurrenceOf, shortCodeFarmUID, taskRead.ArchivedDate)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		_, err = f.DB.Exec(`DELETE FROM TASK_READ WHERE UID = ?`, taskRead.UID.Bytes())
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		_, err = f.DB.Exec(`DELETE FROM TASK_READ_AREA WHERE TASK_UID = ?`, taskRead.UID.Bytes())
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/helper/structhelper"
+	"github.com/usetania/tania-core/src/tasks/decoder"
+	"github.com/usetania/tania-core/src/tasks/repository"
+)
+
+type TaskCatalogEventRepositoryMysql struct {
+	DB *sql.DB
+}
+
+func NewTaskCatalogEventRepositoryMysql(s *sql.DB) repository.TaskCatalogEvent {
+	return &TaskCatalogEventRepositoryMysql{DB: s}
+}
+
+func (s *TaskCatalogEventRepositoryMysql) Save(
+	farmUID uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		for _, v := range events {
+			latestVersion++
+
+			stmt, err := s.DB.Prepare(`INSERT INTO TASK_CATALOG_EVENT
+				(FARM_UID, VERSION, CREATED_DATE, EVENT)
+				VALUES (?, ?, ?, ?)`)
+			if err != nil {
+				result <- err
+			}
+
+			e, err := json.Marshal(decoder.InterfaceWrapper{
+				Name:  structhelper.GetName(v),
+				Data:  v,
+				Actor: by,
+			})
+			if err != nil {
+				panic(err)
+			}
+
+			_, err = stmt.Exec(farmUID.Bytes(), latestVersion, time.Now(), e)
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/helper/structhelper"
 	"github.com/usetania/tania-core/src/tasks/decoder"
 	"github.com/usetania/tania-core/src/tasks/repository"
@@ -19,7 +20,9 @@ func NewTaskEventRepositoryMysql(s *sql.DB) repository.TaskEvent {
 	return &TaskEventRepositoryMysql{DB: s}
 }
 
-func (s *TaskEventRepositoryMysql) Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error {
+func (s *TaskEventRepositoryMysql) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
 	result := make(chan error)
 
 	go func() {
@@ -34,8 +37,9 @@ func (s *TaskEventRepositoryMysql) Save(uid uuid.UUID, latestVersion int, events
 			}
 
 			e, err := json.Marshal(decoder.InterfaceWrapper{
-				Name: structhelper.GetName(v),
-				Data: v,
+				Name:  structhelper.GetName(v),
+				Data:  v,
+				Actor: by,
 			})
 			if err != nil {
 				panic(err)
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"database/sql"
+	"encoding/json"
 
 	"github.com/usetania/tania-core/src/tasks/domain"
 	"github.com/usetania/tania-core/src/tasks/repository"
@@ -40,16 +41,78 @@ func (f *TaskReadRepositoryMysql) Save(taskRead *storage.TaskRead) <-chan error
 			assetID = taskRead.AssetID.Bytes()
 		}
 
+		var costCentreID []byte
+		if taskRead.CostCentreID != nil {
+			costCentreID = taskRead.CostCentreID.Bytes()
+		}
+
+		var completedBy []byte
+		if taskRead.CompletedBy != nil {
+			completedBy = taskRead.CompletedBy.Bytes()
+		}
+
+		var recurrenceOf []byte
+		if taskRead.RecurrenceOf != nil {
+			recurrenceOf = taskRead.RecurrenceOf.Bytes()
+		}
+
+		// NULL until the task has a code, so the unique index of the codes only holds the numbered tasks.
+		var shortCodeFarmUID []byte
+		if taskRead.ShortCode != "" {
+			shortCodeFarmUID = taskRead.ShortCodeFarmUID.Bytes()
+		}
+
+		dependsOn, err := json.Marshal(taskRead.DependsOn)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		affectedAreaIDs, err := json.Marshal(taskRead.AffectedAreaIDs)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		perAreaProgress, err := json.Marshal(taskRead.PerAreaProgress)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		recurrence, err := json.Marshal(taskRead.Recurrence)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
 		res, err := f.DB.Exec(`UPDATE TASK_READ SET
 			TITLE = ?, DESCRIPTION = ?, CREATED_DATE = ?, DUE_DATE = ?,
 			COMPLETED_DATE = ?, CANCELLED_DATE = ?, PRIORITY = ?, STATUS = ?,
 			DOMAIN_CODE = ?, DOMAIN_DATA_MATERIAL_ID = ?, DOMAIN_DATA_AREA_ID = ?,
-			CATEGORY = ?, IS_DUE = ?, ASSET_ID = ?
+			CATEGORY = ?, IS_DUE = ?, ASSET_ID = ?, SHORT_CODE = ?,
+			COST_CENTRE_ID = ?, COMPLETED_BY = ?, LABOUR_MINUTES = ?, MATERIAL_QUANTITY = ?,
+			ESTIMATED_MINUTES = ?, DEPENDS_ON = ?, AFFECTED_AREA_IDS = ?, PER_AREA_PROGRESS = ?,
+			DUE_DATE_ADJUSTED = ?, TARGET_QUANTITY = ?, TARGET_UNIT = ?, COMPLETED_QUANTITY = ?,
+			RECURRENCE = ?, RECURRENCE_OF = ?, SHORT_CODE_FARM_UID = ?
 			WHERE UID = ?`,
 			taskRead.Title, taskRead.Description, taskRead.CreatedDate, taskRead.DueDate,
 			taskRead.CompletedDate, taskRead.CancelledDate, taskRead.Priority, taskRead.Status,
 			taskRead.Domain, domainDataMaterialID, domainDataAreaID,
 			taskRead.Category, taskRead.IsDue, assetID,
+			taskRead.ShortCode,
+			costCentreID, completedBy, taskRead.LabourMinutes, taskRead.MaterialQuantity, taskRead.EstimatedMinutes,
+			string(dependsOn), string(affectedAreaIDs), string(perAreaProgress),
+			taskRead.DueDateAdjustedForBusinessHours, taskRead.TargetQuantity, taskRead.TargetUnit, taskRead.CompletedQuantity,
+			string(recurrence), recurrenceOf, shortCodeFarmUID,
 			taskRead.UID.Bytes())
 		if err != nil {
 			result <- err
@@ -67,20 +130,53 @@ func (f *TaskReadRepositoryMysql) Save(taskRead *storage.TaskRead) <-chan error
 			_, err := f.DB.Exec(`INSERT INTO TASK_READ (
 				UID, TITLE, DESCRIPTION, CREATED_DATE, DUE_DATE,
 				COMPLETED_DATE, CANCELLED_DATE, PRIORITY, STATUS,
-				DOMAIN_CODE, DOMAIN_DATA_MATERIAL_ID, DOMAIN_DATA_AREA_ID, CATEGORY, IS_DUE, ASSET_ID)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				DOMAIN_CODE, DOMAIN_DATA_MATERIAL_ID, DOMAIN_DATA_AREA_ID, CATEGORY, IS_DUE, ASSET_ID, SHORT_CODE,
+				COST_CENTRE_ID, COMPLETED_BY, LABOUR_MINUTES, MATERIAL_QUANTITY, ESTIMATED_MINUTES, DEPENDS_ON,
+				AFFECTED_AREA_IDS, PER_AREA_PROGRESS, DUE_DATE_ADJUSTED, TARGET_QUANTITY, TARGET_UNIT, COMPLETED_QUANTITY,
+				RECURRENCE, RECURRENCE_OF, SHORT_CODE_FARM_UID)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 				taskRead.UID.Bytes(), taskRead.Title, taskRead.Description, taskRead.CreatedDate, taskRead.DueDate,
 				taskRead.CompletedDate, taskRead.CancelledDate, taskRead.Priority, taskRead.Status,
 				taskRead.Domain, domainDataMaterialID, domainDataAreaID,
-				taskRead.Category, taskRead.IsDue, assetID)
+				taskRead.Category, taskRead.IsDue, assetID, taskRead.ShortCode,
+				costCentreID, completedBy, taskRead.LabourMinutes, taskRead.MaterialQuantity, taskRead.EstimatedMinutes,
+				string(dependsOn), string(affectedAreaIDs), string(perAreaProgress),
+				taskRead.DueDateAdjustedForBusinessHours,
+				taskRead.TargetQuantity, taskRead.TargetUnit, taskRead.CompletedQuantity, string(recurrence), recurrenceOf,
+				shortCodeFarmUID)
 			if err != nil {
 				result <- err
 			}
 		}
 
+		err = saveTaskAreas(f.DB, taskRead)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
 		result <- nil
 		close(result)
 	}()
 
 	return result
 }
+
+// saveTaskAreas replaces the areas the task covers in TASK_READ_AREA, which FindByAreaID looks the tasks up in.
+func saveTaskAreas(db *sql.DB, taskRead *storage.TaskRead) error {
+	_, err := db.Exec(`DELETE FROM TASK_READ_AREA WHERE TASK_UID = ?`, taskRead.UID.Bytes())
+	if err != nil {
+		return err
+	}
+
+	for _, v := range taskRead.AreaIDs() {
+		_, err = db.Exec(`INSERT INTO TASK_READ_AREA (TASK_UID, AREA_UID) VALUES (?, ?)`, taskRead.UID.Bytes(), v.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskTemplateReadRepositoryMysql struct {
+	DB *sql.DB
+}
+
+func NewTaskTemplateReadRepositoryMysql(s *sql.DB) repository.TaskTemplateRead {
+	return &TaskTemplateReadRepositoryMysql{DB: s}
+}
+
+func (f *TaskTemplateReadRepositoryMysql) Save(taskTemplateRead *storage.TaskTemplateRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		items, err := json.Marshal(taskTemplateRead.Items)
+		if err != nil {
+			result <- err
+		}
+
+		var parentTemplateUID []byte
+		if taskTemplateRead.ParentTemplateID != nil {
+			parentTemplateUID = taskTemplateRead.ParentTemplateID.Bytes()
+		}
+
+		res, err := f.DB.Exec(`UPDATE TASK_TEMPLATE_READ SET
+			NAME = ?, PARENT_TEMPLATE_UID = ?, ITEMS = ?, CREATED_DATE = ?
+			WHERE UID = ?`,
+			taskTemplateRead.Name, parentTemplateUID, items,
+			taskTemplateRead.CreatedDate, taskTemplateRead.UID.Bytes())
+		if err != nil {
+			result <- err
+		}
+
+		rowsAffected := int64(0)
+		if res != nil {
+			rowsAffected, err = res.RowsAffected()
+			if err != nil {
+				result <- err
+			}
+		}
+
+		if rowsAffected == 0 {
+			_, err := f.DB.Exec(`INSERT INTO TASK_TEMPLATE_READ
+				(UID, NAME, PARENT_TEMPLATE_UID, ITEMS, CREATED_DATE)
+				VALUES (?, ?, ?, ?, ?)`,
+				taskTemplateRead.UID.Bytes(), taskTemplateRead.Name, parentTemplateUID, items,
+				taskTemplateRead.CreatedDate)
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/tasks/domain"
 	"github.com/usetania/tania-core/src/tasks/storage"
 )
@@ -21,7 +22,7 @@ type EventWrapper struct {
 }
 
 type TaskEvent interface {
-	Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
 }
 
 func BuildTaskFromEventHistory(events []storage.TaskEvent) *domain.Task {
@@ -37,3 +38,41 @@ func BuildTaskFromEventHistory(events []storage.TaskEvent) *domain.Task {
 type TaskRead interface {
 	Save(taskRead *storage.TaskRead) <-chan error
 }
+
+type TaskArchive interface {
+	// Archive moves the task read out of the task list into the archive.
+	Archive(taskRead *storage.TaskRead) <-chan error
+}
+
+type TaskTemplateEvent interface {
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
+}
+
+func BuildTaskTemplateFromEventHistory(events []storage.TaskTemplateEvent) *domain.TaskTemplate {
+	state := &domain.TaskTemplate{}
+	for _, v := range events {
+		state.Transition(v.Event)
+		state.Version++
+	}
+
+	return state
+}
+
+type TaskTemplateRead interface {
+	Save(taskTemplateRead *storage.TaskTemplateRead) <-chan error
+}
+
+type TaskCatalogEvent interface {
+	Save(farmUID uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
+}
+
+// BuildTaskCatalogFromEventHistory applies the events of the farm to the built-in catalog.
+func BuildTaskCatalogFromEventHistory(farmUID uuid.UUID, events []storage.TaskCatalogEvent) *domain.TaskCatalog {
+	state := domain.DefaultTaskCatalog(farmUID)
+	for _, v := range events {
+		state.Transition(v.Event)
+		state.Version++
+	}
+
+	return state
+}
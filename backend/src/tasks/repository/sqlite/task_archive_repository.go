@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// TaskArchiveRepositorySqlite moves the task reads from the main database into the archive database.
+type TaskArchiveRepositorySqlite struct {
+	DB        *sql.DB
+	ArchiveDB *sql.DB
+}
+
+func NewTaskArchiveRepositorySqlite(db, archiveDB *sql.DB) repository.TaskArchive {
+	return &TaskArchiveRepositorySqlite{DB: db, ArchiveDB: archiveDB}
+}
+
+// Archive saves the task in the archive before deleting it from TASK_READ,
+// so an interrupted move is completed by archiving the task again.
+func (f *TaskArchiveRepositorySqlite) Archive(taskRead *storage.TaskRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		var domainDataMaterialID, domainDataAreaID *uuid.UUID
+
+		switch v := taskRead.DomainDetails.(type) {
+		case domain.TaskDomainArea:
+			domainDataMaterialID = v.MaterialID
+		case domain.TaskDomainCrop:
+			domainDataMaterialID = v.MaterialID
+			domainDataAreaID = v.AreaID
+		case domain.TaskDomainReservoir:
+			domainDataMaterialID = v.MaterialID
+		}
+
+		dependsOn, err := json.Marshal(taskRead.DependsOn)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		affectedAreaIDs, err := json.Marshal(taskRead.AffectedAreaIDs)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		perAreaProgress, err := json.Marshal(taskRead.PerAreaProgress)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		recurrence, err := json.Marshal(taskRead.Recurrence)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		_, err = f.ArchiveDB.Exec(`INSERT OR REPLACE INTO TASK_ARCHIVE (
+			UID, TITLE, DESCRIPTION, CREATED_DATE, DUE_DATE,
+			COMPLETED_DATE, CANCELLED_DATE, PRIORITY, STATUS,
+			DOMAIN_CODE, DOMAIN_DATA_MATERIAL_ID, DOMAIN_DATA_AREA_ID, CATEGORY, IS_DUE, ASSET_ID, SHORT_CODE,
+			COST_CENTRE_ID, COMPLETED_BY, LABOUR_MINUTES, MATERIAL_QUANTITY, ESTIMATED_MINUTES, DEPENDS_ON,
+			AFFECTED_AREA_IDS, PER_AREA_PROGRESS, DUE_DATE_ADJUSTED, TARGET_QUANTITY, TARGET_UNIT, COMPLETED_QUANTITY,
+			RECURRENCE, RECURRENCE_OF, SHORT_CODE_FARM_UID, ARCHIVED_DATE)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			taskRead.UID, taskRead.Title, taskRead.Description, taskRead.CreatedDate.Format(time.RFC3339),
+			formatDate(taskRead.DueDate), formatDate(taskRead.CompletedDate), formatDate(taskRead.CancelledDate),
+			taskRead.Priority, taskRead.Status,
+			taskRead.Domain, domainDataMaterialID, domainDataAreaID, taskRead.Category, taskRead.IsDue, taskRead.AssetID,
+			taskRead.ShortCode,
+			taskRead.CostCentreID, taskRead.CompletedBy, taskRead.LabourMinutes, taskRead.MaterialQuantity,
+			taskRead.EstimatedMinutes, string(dependsOn), string(affectedAreaIDs), string(perAreaProgress),
+			taskRead.DueDateAdjustedForBusinessHours, taskRead.TargetQuantity, taskRead.TargetUnit,
+			taskRead.CompletedQuantity, string(recurrence), taskRead.RecurrenceOf, shortCodeFarmUID(taskRead),
+			formatDate(taskRead.ArchivedDate))
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		_, err = f.DB.Exec(`DELETE FROM TASK_READ WHERE UID = ?`, taskRead.UID)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		_, err = f.DB.Exec(`DELETE FROM TASK_READ_AREA WHERE TASK_UID = ?`, taskRead.UID)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
+
+func formatDate(date *time.Time) *string {
+	if date == nil || date.IsZero() {
+		return nil
+	}
+
+	d := date.Format(time.RFC3339)
+
+	return &d
+}
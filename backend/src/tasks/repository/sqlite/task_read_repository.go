@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -55,15 +56,56 @@ func (f *TaskReadRepositorySqlite) Save(taskRead *storage.TaskRead) <-chan error
 			domainDataMaterialID = v.MaterialID
 		}
 
+		dependsOn, err := json.Marshal(taskRead.DependsOn)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		affectedAreaIDs, err := json.Marshal(taskRead.AffectedAreaIDs)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		perAreaProgress, err := json.Marshal(taskRead.PerAreaProgress)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
+		recurrence, err := json.Marshal(taskRead.Recurrence)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
 		res, err := f.DB.Exec(`UPDATE TASK_READ SET
 			TITLE = ?, DESCRIPTION = ?, CREATED_DATE = ?, DUE_DATE = ?,
 			COMPLETED_DATE = ?, CANCELLED_DATE = ?, PRIORITY = ?, STATUS = ?,
 			DOMAIN_CODE = ?, DOMAIN_DATA_MATERIAL_ID = ?, DOMAIN_DATA_AREA_ID = ?,
-			CATEGORY = ?, IS_DUE = ?, ASSET_ID = ?
+			CATEGORY = ?, IS_DUE = ?, ASSET_ID = ?, SHORT_CODE = ?,
+			COST_CENTRE_ID = ?, COMPLETED_BY = ?, LABOUR_MINUTES = ?, MATERIAL_QUANTITY = ?,
+			ESTIMATED_MINUTES = ?, DEPENDS_ON = ?, AFFECTED_AREA_IDS = ?, PER_AREA_PROGRESS = ?,
+			DUE_DATE_ADJUSTED = ?, TARGET_QUANTITY = ?, TARGET_UNIT = ?, COMPLETED_QUANTITY = ?,
+			RECURRENCE = ?, RECURRENCE_OF = ?, SHORT_CODE_FARM_UID = ?
 			WHERE UID = ?`,
 			taskRead.Title, taskRead.Description, taskRead.CreatedDate.Format(time.RFC3339), dueDate,
 			completedDate, cancelledDate, taskRead.Priority, taskRead.Status,
 			taskRead.Domain, domainDataMaterialID, domainDataAreaID, taskRead.Category, taskRead.IsDue, taskRead.AssetID,
+			taskRead.ShortCode,
+			taskRead.CostCentreID, taskRead.CompletedBy, taskRead.LabourMinutes, taskRead.MaterialQuantity,
+			taskRead.EstimatedMinutes, string(dependsOn), string(affectedAreaIDs), string(perAreaProgress),
+			taskRead.DueDateAdjustedForBusinessHours, taskRead.TargetQuantity, taskRead.TargetUnit, taskRead.CompletedQuantity,
+			string(recurrence), taskRead.RecurrenceOf, shortCodeFarmUID(taskRead),
 			taskRead.UID)
 		if err != nil {
 			result <- err
@@ -81,19 +123,63 @@ func (f *TaskReadRepositorySqlite) Save(taskRead *storage.TaskRead) <-chan error
 			_, err := f.DB.Exec(`INSERT INTO TASK_READ (
 				UID, TITLE, DESCRIPTION, CREATED_DATE, DUE_DATE,
 				COMPLETED_DATE, CANCELLED_DATE, PRIORITY, STATUS,
-				DOMAIN_CODE, DOMAIN_DATA_MATERIAL_ID, DOMAIN_DATA_AREA_ID, CATEGORY, IS_DUE, ASSET_ID)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				DOMAIN_CODE, DOMAIN_DATA_MATERIAL_ID, DOMAIN_DATA_AREA_ID, CATEGORY, IS_DUE, ASSET_ID, SHORT_CODE,
+				COST_CENTRE_ID, COMPLETED_BY, LABOUR_MINUTES, MATERIAL_QUANTITY, ESTIMATED_MINUTES, DEPENDS_ON,
+				AFFECTED_AREA_IDS, PER_AREA_PROGRESS, DUE_DATE_ADJUSTED, TARGET_QUANTITY, TARGET_UNIT, COMPLETED_QUANTITY,
+				RECURRENCE, RECURRENCE_OF, SHORT_CODE_FARM_UID)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 				taskRead.UID, taskRead.Title, taskRead.Description, taskRead.CreatedDate.Format(time.RFC3339), dueDate,
 				completedDate, cancelledDate, taskRead.Priority, taskRead.Status,
-				taskRead.Domain, domainDataMaterialID, domainDataAreaID, taskRead.Category, taskRead.IsDue, taskRead.AssetID)
+				taskRead.Domain, domainDataMaterialID, domainDataAreaID, taskRead.Category, taskRead.IsDue, taskRead.AssetID,
+				taskRead.ShortCode,
+				taskRead.CostCentreID, taskRead.CompletedBy, taskRead.LabourMinutes, taskRead.MaterialQuantity,
+				taskRead.EstimatedMinutes, string(dependsOn), string(affectedAreaIDs), string(perAreaProgress),
+				taskRead.DueDateAdjustedForBusinessHours,
+				taskRead.TargetQuantity, taskRead.TargetUnit, taskRead.CompletedQuantity, string(recurrence), taskRead.RecurrenceOf,
+				shortCodeFarmUID(taskRead))
 			if err != nil {
 				result <- err
 			}
 		}
 
+		err = saveTaskAreas(f.DB, taskRead)
+		if err != nil {
+			result <- err
+			close(result)
+
+			return
+		}
+
 		result <- nil
 		close(result)
 	}()
 
 	return result
 }
+
+// shortCodeFarmUID is the farm the short code of the task is numbered in, NULL until it has a code,
+// so the unique index of the codes only holds the numbered tasks.
+func shortCodeFarmUID(taskRead *storage.TaskRead) *uuid.UUID {
+	if taskRead.ShortCode == "" {
+		return nil
+	}
+
+	return &taskRead.ShortCodeFarmUID
+}
+
+// saveTaskAreas replaces the areas the task covers in TASK_READ_AREA, which FindByAreaID looks the tasks up in.
+func saveTaskAreas(db *sql.DB, taskRead *storage.TaskRead) error {
+	_, err := db.Exec(`DELETE FROM TASK_READ_AREA WHERE TASK_UID = ?`, taskRead.UID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range taskRead.AreaIDs() {
+		_, err = db.Exec(`INSERT INTO TASK_READ_AREA (TASK_UID, AREA_UID) VALUES (?, ?)`, taskRead.UID, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+type TaskTemplateReadRepositorySqlite struct {
+	DB *sql.DB
+}
+
+func NewTaskTemplateReadRepositorySqlite(s *sql.DB) repository.TaskTemplateRead {
+	return &TaskTemplateReadRepositorySqlite{DB: s}
+}
+
+func (f *TaskTemplateReadRepositorySqlite) Save(taskTemplateRead *storage.TaskTemplateRead) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		items, err := json.Marshal(taskTemplateRead.Items)
+		if err != nil {
+			result <- err
+		}
+
+		res, err := f.DB.Exec(`UPDATE TASK_TEMPLATE_READ SET
+			NAME = ?, PARENT_TEMPLATE_UID = ?, ITEMS = ?, CREATED_DATE = ?
+			WHERE UID = ?`,
+			taskTemplateRead.Name, taskTemplateRead.ParentTemplateID, items,
+			taskTemplateRead.CreatedDate.Format(time.RFC3339), taskTemplateRead.UID)
+		if err != nil {
+			result <- err
+		}
+
+		rowsAffected := int64(0)
+		if res != nil {
+			rowsAffected, err = res.RowsAffected()
+			if err != nil {
+				result <- err
+			}
+		}
+
+		if rowsAffected == 0 {
+			_, err := f.DB.Exec(`INSERT INTO TASK_TEMPLATE_READ
+				(UID, NAME, PARENT_TEMPLATE_UID, ITEMS, CREATED_DATE)
+				VALUES (?, ?, ?, ?, ?)`,
+				taskTemplateRead.UID, taskTemplateRead.Name, taskTemplateRead.ParentTemplateID, items,
+				taskTemplateRead.CreatedDate.Format(time.RFC3339))
+			if err != nil {
+				result <- err
+			}
+		}
+
+		result <- nil
+		close(result)
+	}()
+
+	return result
+}
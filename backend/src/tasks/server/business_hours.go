@@ -0,0 +1,56 @@
+package server
+
+import (
+	"log"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/domain"
+)
+
+// FarmCalendar tells when the farms operate, so the new tasks aren't due while their farm is closed.
+type FarmCalendar interface {
+	// BusinessHours returns the business hours of the farm. The nil farm UID is for the tasks without a farm.
+	BusinessHours(farmUID uuid.UUID) (domain.BusinessHoursConfig, error)
+}
+
+// ConfigFarmCalendar is the calendar of the farms all operating in the business hours of the configuration.
+type ConfigFarmCalendar struct {
+	Hours domain.BusinessHoursConfig
+}
+
+func (c ConfigFarmCalendar) BusinessHours(farmUID uuid.UUID) (domain.BusinessHoursConfig, error) {
+	return c.Hours, nil
+}
+
+// StartBusinessHoursAdjustment moves the due dates of the new tasks out of the closed hours of their farm.
+func (s *TaskServer) StartBusinessHoursAdjustment(calendar FarmCalendar) {
+	s.FarmCalendar = calendar
+}
+
+// adjustDueDateForBusinessHours moves the due date of the new task to the start of the next business day of its
+// farm, when it falls outside its business hours. The nil farm UID is the farm of the task's asset.
+// The task keeps its due date when the business hours can't be found.
+func (s *TaskServer) adjustDueDateForBusinessHours(farmUID uuid.UUID, task *domain.Task) {
+	if s.FarmCalendar == nil || task.DueDate == nil {
+		return
+	}
+
+	if farmUID == uuid.Nil {
+		farmUID = s.assetFarmUID(task.Domain, task.AssetID)
+	}
+
+	hours, err := s.FarmCalendar.BusinessHours(farmUID)
+	if err != nil {
+		log.Println(err)
+
+		return
+	}
+
+	dueDate := *task.DueDate
+
+	if task.AdjustDueDateForBusinessHours(hours) {
+		log.Printf("DEBUG task %s due date adjusted for the business hours from %s to %s",
+			task.UID, dueDate.Format(time.RFC3339), task.DueDate.Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/retention"
+	"github.com/usetania/tania-core/src/tasks/query"
+)
+
+// findTaskEvents returns the event history of the task, or a cold storage error
+// when the retention job has already archived and pruned it.
+func (s *TaskServer) findTaskEvents(taskUID uuid.UUID) query.Result {
+	err := s.checkTaskHistory(taskUID)
+	if err != nil {
+		return query.Result{Error: err}
+	}
+
+	return <-s.TaskEventQuery.FindAllByTaskID(taskUID)
+}
+
+func (s *TaskServer) checkTaskHistory(taskUID uuid.UUID) error {
+	pruned, err := s.PrunedQuery.FindPruned(retention.AggregateTask, taskUID)
+	if err != nil {
+		return err
+	}
+
+	if pruned != nil {
+		return NewRequestValidationError(ColdStorage, "id")
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/dryrun"
+)
+
+// validatable lets the handler be called with `?validate_only=true` to only validate the request.
+func (s *TaskServer) validatable(h func(*TaskServer, echo.Context) error) echo.HandlerFunc {
+	return dryrun.Handler(s, s.dryRun, h)
+}
+
+// dryRun copies the server with the event repositories and the event bus which drop the events.
+// The short code generator is swapped too, so no code is consumed.
+func (s *TaskServer) dryRun() *TaskServer {
+	dry := *s
+
+	dry.TaskEventRepo = dryrun.EventRepository{}
+	dry.TaskTemplateEventRepo = dryrun.EventRepository{}
+	dry.TaskCatalogEventRepo = dryrun.EventRepository{}
+	dry.EventBus = dryrun.EventBus{}
+	dry.ShortCodeGenerator = dryrun.ShortCodeGenerator{}
+	dry.CustomFields = s.CustomFields.WithoutSaving()
+
+	return &dry
+}
@@ -0,0 +1,24 @@
+package server
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/helper/expandhelper"
+)
+
+// expand resolves the relations of the expand query param of the request.
+func expand(c echo.Context, registry expandhelper.Registry) (map[string]expandhelper.Expansion, error) {
+	expanded, err := registry.Expand(c.QueryParam(expandhelper.Param))
+
+	unknownErr := expandhelper.UnknownError{}
+	if errors.As(err, &unknownErr) {
+		return nil, RequestValidationError{
+			FieldName:    expandhelper.Param,
+			ErrorCode:    InvalidOption,
+			ErrorMessage: unknownErr.Error(),
+		}
+	}
+
+	return expanded, err
+}
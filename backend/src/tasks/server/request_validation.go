@@ -10,18 +10,23 @@ import (
 	"strings"
 
 	"github.com/labstack/echo/v4"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/farmscope"
 	"github.com/usetania/tania-core/src/tasks/domain"
 )
 
 const (
-	Required      = "REQUIRED"
-	Alphanumeric  = "ALPHANUMERIC"
-	Alpha         = "ALPHA"
-	Numeric       = "NUMERIC"
-	Float         = "FLOAT"
-	ParseFailed   = "PARSE_FAILED"
-	InvalidOption = "INVALID_OPTION"
-	NotFound      = "NOT_FOUND"
+	Required          = "REQUIRED"
+	Alphanumeric      = "ALPHANUMERIC"
+	Alpha             = "ALPHA"
+	Numeric           = "NUMERIC"
+	Float             = "FLOAT"
+	ParseFailed       = "PARSE_FAILED"
+	InvalidOption     = "INVALID_OPTION"
+	NotFound          = "NOT_FOUND"
+	ColdStorage       = "ARCHIVED_TO_COLD_STORAGE"
+	ResourceConflict  = "RESOURCE_CONFLICT"
+	CatalogEntryInUse = "CATALOG_ENTRY_IN_USE"
 )
 
 // RequestValidation sanitizes request inputs and convert the input to its correct data type.
@@ -66,6 +71,12 @@ func Message(errorCode string) string {
 		return "This value is not available in options. Please give the correct options."
 	case NotFound:
 		return "Data not found."
+	case ColdStorage:
+		return "The history of this data was archived to cold storage."
+	case ResourceConflict:
+		return "The resource is already allocated at this time."
+	case CatalogEntryInUse:
+		return "The entry is still used by tasks, give the entry to migrate them to."
 	default:
 		return "Internal server error"
 	}
@@ -93,7 +104,7 @@ func Error(c echo.Context, err error) error {
 
 	log.Printf(
 		"user_uid: %v\nrequest_id: %v\nfile: %v\nline: %v\n",
-		c.Get("USER_UID"),
+		c.Get(farmscope.UserKey),
 		c.Response().Header().Get(echo.HeaderXRequestID),
 		file,
 		line,
@@ -106,9 +117,31 @@ func Error(c echo.Context, err error) error {
 	if errors.As(err, &te) {
 		errorResponse["error_code"] = strconv.Itoa(te.Code)
 
+		if te.Code == domain.TaskTemplateErrorCircularInheritanceCode {
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	var cfe assetsdomain.CustomFieldError
+	if errors.As(err, &cfe) {
+		errorResponse["field_name"] = cfe.FieldKey
+		errorResponse["error_code"] = strconv.Itoa(cfe.Code)
+
 		return c.JSON(http.StatusBadRequest, errorResponse)
 	}
 
+	var rce ResourceConflictError
+	if errors.As(err, &rce) {
+		return c.JSON(http.StatusConflict, rce)
+	}
+
+	var ciu CatalogEntryInUseError
+	if errors.As(err, &ciu) {
+		return c.JSON(http.StatusConflict, ciu)
+	}
+
 	var rve RequestValidationError
 	if errors.As(err, &rve) {
 		errorResponse["field_name"] = rve.FieldName
@@ -118,7 +151,8 @@ func Error(c echo.Context, err error) error {
 		return c.JSON(http.StatusBadRequest, rve)
 	}
 
-	return c.JSON(http.StatusInternalServerError, errorResponse)
+	// The other errors are answered by the error handler of the server, with their detail only when verbose.
+	return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error").SetInternal(err)
 }
 
 func getFileAndLineNumber() (string, int) {
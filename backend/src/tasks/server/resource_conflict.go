@@ -0,0 +1,105 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// ConflictingTask is an unfinished task using the same material at the same time.
+type ConflictingTask struct {
+	UID              uuid.UUID `json:"uid"`
+	ShortCode        string    `json:"short_code"`
+	Title            string    `json:"title"`
+	DueDate          time.Time `json:"due_date"`
+	EstimatedMinutes int       `json:"estimated_minutes"`
+}
+
+// ResourceConflictError is returned with 409 with the tasks the material is already allocated to.
+type ResourceConflictError struct {
+	RequestValidationError
+	ConflictingTasks []ConflictingTask `json:"conflicting_tasks"`
+}
+
+// ResourceConflictDetector keeps a material from being allocated to overlapping tasks,
+// like the single tractor of the farm needed in two areas at once.
+type ResourceConflictDetector struct {
+	TaskReadQuery query.TaskRead
+	TaskService   domain.TaskService
+}
+
+// Detect checks the time window of the task against the unfinished tasks using its material.
+// The tasks without a due date or an estimate aren't allocated any time.
+func (d ResourceConflictDetector) Detect(task *domain.Task) error {
+	materialID := domain.TaskMaterialID(task.DomainDetails)
+	if materialID == nil {
+		return nil
+	}
+
+	window, ok := domain.NewTaskTimeWindow(task.DueDate, task.EstimatedMinutes)
+	if !ok {
+		return nil
+	}
+
+	result := <-d.TaskReadQuery.FindAllOpenByMaterial(*materialID, window.Start, window.End)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	tasks, ok := result.Result.([]storage.TaskRead)
+	if !ok {
+		return errors.New("internal server error")
+	}
+
+	conflicts := []ConflictingTask{}
+
+	var first domain.TaskTimeWindow
+
+	for _, v := range tasks {
+		// The windows found by the day fractions of SQLite are checked again, to the second.
+		other, ok := domain.NewTaskTimeWindow(v.DueDate, v.EstimatedMinutes)
+		if v.UID == task.UID || !ok || !window.Overlaps(other) {
+			continue
+		}
+
+		if len(conflicts) == 0 || other.Start.Before(first.Start) {
+			first = other
+		}
+
+		conflicts = append(conflicts, ConflictingTask{
+			UID:              v.UID,
+			ShortCode:        v.ShortCode,
+			Title:            v.Title,
+			DueDate:          *v.DueDate,
+			EstimatedMinutes: v.EstimatedMinutes,
+		})
+	}
+
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	materialName := materialID.String()
+
+	serviceResult := d.TaskService.FindMaterialByID(*materialID)
+	if material, ok := serviceResult.Result.(query.TaskMaterialResult); ok && serviceResult.Error == nil {
+		materialName = material.Name
+	}
+
+	// The times are shown in the time zone of the due date of the new task.
+	location := task.DueDate.Location()
+
+	rve := NewRequestValidationError(ResourceConflict, "due_date")
+	rve.ErrorMessage = fmt.Sprintf("resource %s is already allocated from %s to %s",
+		materialName, first.Start.In(location).Format("15:04"), first.End.In(location).Format("15:04"))
+
+	return ResourceConflictError{
+		RequestValidationError: rve,
+		ConflictingTasks:       conflicts,
+	}
+}
@@ -0,0 +1,75 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/server"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+func TestSaveTaskRejectsTheAllocatedMaterial(t *testing.T) {
+	// Given
+	app := newTestApp()
+
+	tractorUID, _ := uuid.NewV4()
+	app.materialReadStorage.MaterialReadMap[tractorUID] = assetsstorage.MaterialRead{
+		UID:  tractorUID,
+		Name: "Tractor",
+		Type: assetsdomain.MaterialTypeOther{},
+	}
+
+	ploughUID, _ := uuid.NewV4()
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+	dueDate := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 9, 0, 0, 0, time.UTC)
+	app.taskReadStorage.TaskReadMap[ploughUID] = storage.TaskRead{
+		UID:              ploughUID,
+		Title:            "Plough the north field",
+		DueDate:          &dueDate,
+		Status:           domain.TaskStatusCreated,
+		Domain:           domain.TaskDomainAreaCode,
+		DomainDetails:    domain.TaskDomainArea{MaterialID: &tractorUID},
+		Category:         domain.TaskCategoryGeneral,
+		EstimatedMinutes: 120,
+	}
+
+	app.start(t, 0)
+
+	form := url.Values{
+		"title":             {"Harrow the south field"},
+		"description":       {"Before the sowing"},
+		"priority":          {domain.TaskPriorityNormal},
+		"category":          {domain.TaskCategoryGeneral},
+		"domain":            {domain.TaskDomainAreaCode},
+		"material_id":       {tractorUID.String()},
+		"due_date":          {dueDate.Add(time.Hour).Format(time.RFC3339)},
+		"estimated_minutes": {"60"},
+	}
+
+	// When
+	conflicting := app.call(http.MethodPost, "/api/tasks", form)
+
+	form.Set("due_date", dueDate.Add(2*time.Hour).Format(time.RFC3339))
+	after := app.call(http.MethodPost, "/api/tasks", form)
+
+	// Then
+	require.Equal(t, http.StatusConflict, conflicting.Code, conflicting.Body.String())
+
+	body := server.ResourceConflictError{}
+	assert.Nil(t, json.Unmarshal(conflicting.Body.Bytes(), &body))
+	assert.Equal(t, server.ResourceConflict, body.ErrorCode)
+	assert.Equal(t, "resource Tractor is already allocated from 09:00 to 11:00", body.ErrorMessage)
+	assert.Len(t, body.ConflictingTasks, 1)
+	assert.Equal(t, ploughUID, body.ConflictingTasks[0].UID)
+
+	assert.Equal(t, http.StatusOK, after.Code, after.Body.String())
+}
@@ -1,7 +1,16 @@
 package server
 
 import (
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/helper/expandhelper"
+	"github.com/usetania/tania-core/src/helper/structhelper"
 	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/query"
 	"github.com/usetania/tania-core/src/tasks/storage"
 )
 
@@ -9,6 +18,7 @@ func MapTaskToTaskRead(task *domain.Task) *storage.TaskRead {
 	taskRead := &storage.TaskRead{
 		Title:         task.Title,
 		UID:           task.UID,
+		ShortCode:     task.ShortCode,
 		Description:   task.Description,
 		CreatedDate:   task.CreatedDate,
 		DueDate:       task.DueDate,
@@ -21,7 +31,124 @@ func MapTaskToTaskRead(task *domain.Task) *storage.TaskRead {
 		Category:      task.Category,
 		IsDue:         task.IsDue,
 		AssetID:       task.AssetID,
+
+		CostCentreID:     task.CostCentreID,
+		CompletedBy:      task.CompletedBy,
+		LabourMinutes:    task.LabourMinutes,
+		MaterialQuantity: task.MaterialQuantity,
+		EstimatedMinutes: task.EstimatedMinutes,
+		DependsOn:        task.DependsOn,
+
+		AffectedAreaIDs: task.AffectedAreaIDs,
+		PerAreaProgress: task.PerAreaProgress,
+
+		DueDateAdjustedForBusinessHours: task.DueDateAdjustedForBusinessHours,
+
+		TargetQuantity:    task.TargetQuantity,
+		TargetUnit:        task.TargetUnit,
+		CompletedQuantity: task.CompletedQuantity,
+
+		Recurrence:   task.Recurrence,
+		RecurrenceOf: task.RecurrenceOf,
+
+		ShortCodeFarmUID: task.ShortCodeFarmUID,
 	}
 
 	return taskRead
 }
+
+func MapTaskTemplateToTaskTemplateRead(template *domain.TaskTemplate) *storage.TaskTemplateRead {
+	return &storage.TaskTemplateRead{
+		UID:              template.UID,
+		Name:             template.Name,
+		ParentTemplateID: template.ParentTemplateID,
+		Items:            template.Items,
+		CreatedDate:      template.CreatedDate,
+	}
+}
+
+// TaskDetail is the task with the values of its custom fields.
+type TaskDetail struct {
+	storage.TaskRead
+	CustomFields map[string]interface{} `json:"custom_fields"`
+
+	Expanded map[string]expandhelper.Expansion `json:"expanded,omitempty"`
+}
+
+// taskExpansions are the relations the task detail can inline with the expand param. The areas and the
+// dependencies may be of another farm than the one of the task, the ones the user can't access are left out.
+func taskExpansions(c echo.Context, s *TaskServer, task storage.TaskRead) expandhelper.Registry {
+	return expandhelper.Registry{
+		{Key: "areas", Expand: expandhelper.List(func() ([]query.TaskAreaResult, error) {
+			areas := []query.TaskAreaResult{}
+
+			for _, v := range task.AreaIDs() {
+				result := s.TaskService.FindAreaByID(v)
+				if result.Error != nil {
+					return nil, result.Error
+				}
+
+				area, ok := result.Result.(query.TaskAreaResult)
+				if ok && s.FarmScope.Allows(c, area.FarmUID) {
+					areas = append(areas, area)
+				}
+			}
+
+			return areas, nil
+		})},
+		{Key: "dependencies", Expand: expandhelper.List(func() ([]storage.TaskRead, error) {
+			dependencies := []storage.TaskRead{}
+
+			for _, v := range task.DependsOn {
+				result := <-s.TaskReadQuery.FindByID(v)
+				if result.Error != nil {
+					return nil, result.Error
+				}
+
+				dependency, ok := result.Result.(storage.TaskRead)
+				if !ok {
+					return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+				}
+
+				// The tasks without an asset, like the general ones, have no farm to check.
+				farmUID := s.assetFarmUID(dependency.Domain, dependency.AssetID)
+				if dependency.UID != v || (farmUID != uuid.Nil && !s.FarmScope.Allows(c, farmUID)) {
+					continue
+				}
+
+				if err := s.AppendTaskDomainDetails(&dependency); err != nil {
+					return nil, err
+				}
+
+				dependencies = append(dependencies, dependency)
+			}
+
+			return dependencies, nil
+		})},
+	}
+}
+
+// TaskHistoryEntry is an event of the task, named after its type, with who triggered it.
+type TaskHistoryEntry struct {
+	Version     int          `json:"version"`
+	CreatedDate time.Time    `json:"created_date"`
+	Event       string       `json:"event"`
+	Data        interface{}  `json:"data"`
+	Actor       *actor.Actor `json:"actor"`
+}
+
+func MapToTaskHistory(events []storage.TaskEvent) []TaskHistoryEntry {
+	history := []TaskHistoryEntry{}
+
+	for _, v := range events {
+		history = append(history, TaskHistoryEntry{
+			Version:     v.Version,
+			CreatedDate: v.CreatedDate,
+			Event:       structhelper.GetName(v.Event),
+			Data:        v.Event,
+			Actor:       v.Actor,
+		})
+	}
+
+	return history
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/shortcode"
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// assignShortCode numbers the task in the sequence of the farm of its asset, the tasks without a farm
+// in the global sequence.
+func (s *TaskServer) assignShortCode(task *domain.Task) error {
+	return s.assignFarmShortCode(task, uuid.Nil)
+}
+
+// assignFarmShortCode numbers the task in the sequence of the farm of its asset, or else of the farm given,
+// and keeps the farm on the task.
+func (s *TaskServer) assignFarmShortCode(task *domain.Task, farmUID uuid.UUID) error {
+	if assetFarmUID := s.assetFarmUID(task.Domain, task.AssetID); assetFarmUID != uuid.Nil {
+		farmUID = assetFarmUID
+	}
+
+	shortCode, err := s.ShortCodeGenerator.Next(shortcode.TaskPrefix, farmUID)
+	if err != nil {
+		return err
+	}
+
+	return task.AssignShortCode(shortCode, farmUID)
+}
+
+// parseTaskUID reads a task ID path param that is either a UUID or a short code like T-1042.
+// The same code can be given in several farms, the farm_id query param tells which one.
+func (s *TaskServer) parseTaskUID(c echo.Context, param string) (uuid.UUID, error) {
+	value := c.Param(param)
+
+	uid, err := uuid.FromString(value)
+	if err == nil {
+		return uid, nil
+	}
+
+	if !shortcode.IsShortCode(shortcode.TaskPrefix, value) {
+		return uuid.UUID{}, err
+	}
+
+	result := <-s.TaskReadQuery.FindAllByShortCode(shortcode.Normalize(value))
+	if result.Error != nil {
+		return uuid.UUID{}, result.Error
+	}
+
+	tasks, ok := result.Result.([]storage.TaskRead)
+	if !ok {
+		return uuid.UUID{}, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if farmID := c.QueryParam("farm_id"); farmID != "" {
+		farmUID, err := uuid.FromString(farmID)
+		if err != nil {
+			return uuid.UUID{}, NewRequestValidationError(ParseFailed, "farm_id")
+		}
+
+		tasks = s.farmShortCodes(tasks, farmUID)
+	}
+
+	switch len(tasks) {
+	case 0:
+		return uuid.UUID{}, NewRequestValidationError(NotFound, param)
+	case 1:
+		return tasks[0].UID, nil
+	default:
+		return uuid.UUID{}, NewRequestValidationError(Required, "farm_id")
+	}
+}
+
+// farmShortCodes are the tasks numbered in the farm. The codes of the global sequence, given before the farms had
+// their own, only count for the farm of the asset of their task when no task of the farm has been numbered with it.
+func (s *TaskServer) farmShortCodes(tasks []storage.TaskRead, farmUID uuid.UUID) []storage.TaskRead {
+	numbered := []storage.TaskRead{}
+	legacy := []storage.TaskRead{}
+
+	for _, v := range tasks {
+		switch {
+		case v.ShortCodeFarmUID == farmUID:
+			numbered = append(numbered, v)
+		case v.ShortCodeFarmUID == uuid.Nil && s.assetFarmUID(v.Domain, v.AssetID) == farmUID:
+			legacy = append(legacy, v)
+		}
+	}
+
+	if len(numbered) > 0 {
+		return numbered
+	}
+
+	return legacy
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+const (
+	// defaultArchivalJob archives the closed tasks of the farms without an archival policy.
+	defaultArchivalJob = "task_archival"
+
+	// archivalPolicyJob prefixes the farm UID to tell the tasks archived by the archival policy of the farm.
+	archivalPolicyJob = "archival_policy:"
+)
+
+// ArchivalCandidate is a closed task the next archival run archives, with the job it's archived by.
+type ArchivalCandidate struct {
+	Task       storage.TaskRead `json:"task"`
+	ClosedDate time.Time        `json:"closed_date"`
+	ArchivedBy string           `json:"archived_by"`
+}
+
+// archivalCutoff is the date the tasks of a farm closed before are archived, by the job of its policy.
+type archivalCutoff struct {
+	Date       time.Time
+	Archived   bool
+	ArchivedBy string
+}
+
+// MountTaskArchivalPolicy defines the endpoints of the archival policies of the farms.
+func (s *TaskServer) MountTaskArchivalPolicy(g *echo.Group) {
+	g.PUT("/:farm_id/archival_policy",
+		s.validatable((*TaskServer).ChangeTaskArchivalPolicy), s.taskCatalogScope("farm_id"))
+	g.GET("/:farm_id/archival_policy/preview", s.PreviewTaskArchival, s.taskCatalogScope("farm_id"))
+}
+
+// ChangeTaskArchivalPolicy sets the days after which the closed tasks of the farm are archived,
+// `archive_after_days=0` never archives them and an empty value keeps the days of the configuration.
+func (s *TaskServer) ChangeTaskArchivalPolicy(c echo.Context) error {
+	var archiveAfterDays *int
+
+	if value := c.FormValue("archive_after_days"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(Numeric, "archive_after_days"))
+		}
+
+		archiveAfterDays = &days
+	}
+
+	return s.changeTaskCatalog(c, func(catalog *domain.TaskCatalog) error {
+		return catalog.ChangeArchivalPolicy(archiveAfterDays)
+	})
+}
+
+// PreviewTaskArchival lists the closed tasks of the farm the next archival run would archive right now,
+// without archiving them.
+func (s *TaskServer) PreviewTaskArchival(c echo.Context) error {
+	farmUID, err := uuid.FromString(c.Param("farm_id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(NotFound, "farm_id"))
+	}
+
+	candidates, err := s.findArchivalCandidates(farmUID, time.Now())
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]interface{})
+	data["data"] = candidates
+	data["total_rows"] = len(candidates)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// findArchivalCandidates lists the closed tasks due for archival at now, the ones of the farm or of every
+// farm with the nil UID. The tasks an open task still depends on are kept until it's closed.
+func (s *TaskServer) findArchivalCandidates(farmUID uuid.UUID, now time.Time) ([]ArchivalCandidate, error) {
+	dependedOn, err := s.findOpenTaskDependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoffs := map[uuid.UUID]archivalCutoff{}
+	candidates := []ArchivalCandidate{}
+
+	for _, status := range []string{domain.TaskStatusCompleted, domain.TaskStatusCancelled} {
+		result := <-s.TaskReadQuery.FindTasksWithFilter(map[string]string{"status": status}, 0, 0)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		tasks, ok := result.Result.([]storage.TaskRead)
+		if !ok {
+			return nil, errors.New("internal server error. error type assertion")
+		}
+
+		for _, v := range tasks {
+			taskFarmUID := s.assetFarmUID(v.Domain, v.AssetID)
+			if farmUID != uuid.Nil && taskFarmUID != farmUID {
+				continue
+			}
+
+			closedDate := v.CompletedDate
+			if closedDate == nil {
+				closedDate = v.CancelledDate
+			}
+
+			if closedDate == nil || dependedOn[v.UID] {
+				continue
+			}
+
+			cutoff, found := cutoffs[taskFarmUID]
+			if !found {
+				cutoff, err = s.findArchivalCutoff(taskFarmUID, now)
+				if err != nil {
+					return nil, err
+				}
+
+				cutoffs[taskFarmUID] = cutoff
+			}
+
+			if !cutoff.Archived || !closedDate.Before(cutoff.Date) {
+				continue
+			}
+
+			candidates = append(candidates, ArchivalCandidate{
+				Task:       v,
+				ClosedDate: *closedDate,
+				ArchivedBy: cutoff.ArchivedBy,
+			})
+		}
+	}
+
+	return candidates, nil
+}
+
+// findArchivalCutoff finds the cutoff of the archival policy of the farm, the one of the configuration for
+// the tasks without a farm and the farms without a policy.
+func (s *TaskServer) findArchivalCutoff(farmUID uuid.UUID, now time.Time) (archivalCutoff, error) {
+	catalog := domain.DefaultTaskCatalog(farmUID)
+
+	if farmUID != uuid.Nil {
+		farmCatalog, err := s.findTaskCatalogByFarmID(farmUID)
+		if err != nil {
+			return archivalCutoff{}, err
+		}
+
+		catalog = farmCatalog
+	}
+
+	archivedBy := defaultArchivalJob
+	if catalog.ArchiveAfterDays != nil {
+		archivedBy = archivalPolicyJob + farmUID.String()
+	}
+
+	date, archived := catalog.ArchivalCutoff(s.ArchiveAfterDays, now)
+
+	return archivalCutoff{Date: date, Archived: archived, ArchivedBy: archivedBy}, nil
+}
+
+// findOpenTaskDependencies returns the tasks the open tasks depend on.
+func (s *TaskServer) findOpenTaskDependencies() (map[uuid.UUID]bool, error) {
+	result := <-s.TaskReadQuery.FindTasksWithFilter(map[string]string{"status": domain.TaskStatusCreated}, 0, 0)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	tasks, ok := result.Result.([]storage.TaskRead)
+	if !ok {
+		return nil, errors.New("internal server error. error type assertion")
+	}
+
+	dependedOn := map[uuid.UUID]bool{}
+
+	for _, v := range tasks {
+		for _, uid := range v.DependsOn {
+			dependedOn[uid] = true
+		}
+	}
+
+	return dependedOn, nil
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"errors"
+	"github.com/usetania/tania-core/src/actor"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/helper/paginationhelper"
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+const taskArchiveInterval = 24 * time.Hour
+
+// StartArchiver archives every night the closed tasks due for archival, the ones closed more than the
+// days of the archival policy of their farm ago, or afterDays ago for the farms without one.
+// A night the archiver is paused, the tasks wait for the next one.
+func (s *TaskServer) StartArchiver(afterDays int, paused func() bool) {
+	s.ArchiveAfterDays = afterDays
+	ticker := time.NewTicker(taskArchiveInterval)
+
+	go func() {
+		for {
+			if paused() {
+				log.Println("Task archiver paused by the maintenance mode")
+			} else {
+				s.archiveClosedTasks(time.Now())
+			}
+
+			<-ticker.C
+		}
+	}()
+}
+
+func (s *TaskServer) archiveClosedTasks(now time.Time) {
+	candidates, err := s.findArchivalCandidates(uuid.Nil, now)
+	if err != nil {
+		log.Println("Task archival failed", err)
+
+		return
+	}
+
+	archived := 0
+
+	for _, v := range candidates {
+		err := s.archiveTask(v.Task, v.ArchivedBy)
+		if err != nil {
+			log.Println("Task", v.Task.UID, "cannot be archived", err)
+
+			continue
+		}
+
+		archived++
+	}
+
+	log.Printf("Archived %d of the %d tasks due for archival", archived, len(candidates))
+}
+
+// archiveTask archives the task, the archivedBy job is the actor of the event.
+func (s *TaskServer) archiveTask(taskRead storage.TaskRead, archivedBy string) error {
+	eventQueryResult := s.findTaskEvents(taskRead.UID)
+	if eventQueryResult.Error != nil {
+		return eventQueryResult.Error
+	}
+
+	events, ok := eventQueryResult.Result.([]storage.TaskEvent)
+	if !ok {
+		return errors.New("internal server error. error type assertion")
+	}
+
+	task := repository.BuildTaskFromEventHistory(events)
+
+	err := task.ArchiveTask()
+	if err != nil {
+		return err
+	}
+
+	err = <-s.TaskEventRepo.Save(task.UID, task.Version, task.UncommittedChanges, actor.System(archivedBy))
+	if err != nil {
+		return err
+	}
+
+	s.publishUncommittedEvents(task)
+
+	return nil
+}
+
+// MoveToTaskArchive moves the read model of the archived task out of the task list.
+func (s *TaskServer) MoveToTaskArchive(event interface{}) error {
+	e, ok := event.(domain.TaskArchived)
+	if !ok {
+		return errors.New("unknown task event")
+	}
+
+	taskRead, err := s.getTaskReadFromID(e.UID)
+	if err != nil {
+		return err
+	}
+
+	taskRead.ArchivedDate = &e.ArchivedDate
+
+	return <-s.TaskArchiveRepo.Archive(taskRead)
+}
+
+// findAllTasksWithArchive lists the tasks of the page from both the task list and the archive,
+// the newest first like the task list.
+func (s *TaskServer) findAllTasksWithArchive(c echo.Context, page, limit int) error {
+	// Both are sorted by created date, so the page is among the first page * limit tasks of each.
+	fetchPage, fetchLimit := 0, 0
+	if page > 0 && limit > 0 {
+		fetchPage, fetchLimit = 1, page*limit
+	}
+
+	result := <-s.TaskReadQuery.FindAll(fetchPage, fetchLimit)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	tasks, ok := result.Result.([]storage.TaskRead)
+	if !ok {
+		return echo.NewHTTPError(http.StatusBadRequest, "Internal server error")
+	}
+
+	result = <-s.TaskArchiveQuery.FindAll(fetchPage, fetchLimit)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	archived, ok := result.Result.([]storage.TaskRead)
+	if !ok {
+		return echo.NewHTTPError(http.StatusBadRequest, "Internal server error")
+	}
+
+	tasks = append(tasks, archived...)
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].CreatedDate.After(tasks[j].CreatedDate)
+	})
+
+	if page > 0 && limit > 0 {
+		start, end := paginationhelper.PageBounds(len(tasks), page, limit)
+		tasks = tasks[start:end]
+	}
+
+	for i := range tasks {
+		if err := s.AppendTaskDomainDetails(&tasks[i]); err != nil {
+			return Error(c, err)
+		}
+	}
+
+	count := 0
+
+	for _, countResult := range []query.Result{<-s.TaskReadQuery.CountAll(), <-s.TaskArchiveQuery.CountAll()} {
+		if countResult.Error != nil {
+			return Error(c, countResult.Error)
+		}
+
+		total, ok := countResult.Result.(int)
+		if !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, "Internal server error")
+		}
+
+		count += total
+	}
+
+	data := make(map[string]interface{})
+	data["data"] = tasks
+	data["total_rows"] = count
+	data["page"] = page
+
+	return c.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/farmscope"
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// FindTasksByArea lists the tasks covering the area, the tasks spanning several areas included.
+func (s *TaskServer) FindTasksByArea(c echo.Context) error {
+	data := make(map[string][]storage.TaskRead)
+
+	areaUID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(ParseFailed, "id"))
+	}
+
+	result := <-s.TaskReadQuery.FindByAreaID(areaUID)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	tasks, ok := result.Result.([]storage.TaskRead)
+	if !ok {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	for i := range tasks {
+		if err := s.AppendTaskDomainDetails(&tasks[i]); err != nil {
+			return Error(c, err)
+		}
+	}
+
+	data["data"] = tasks
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// UpdateTaskAreaProgress saves the percentage of the work done in one of the areas of the task with the progress
+// form value. The task is completed by the current user once all its areas are at 100.
+func (s *TaskServer) UpdateTaskAreaProgress(c echo.Context) error {
+	data := make(map[string]storage.TaskRead)
+
+	uid, err := s.parseTaskUID(c, "id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	areaUID, err := uuid.FromString(c.Param("area_id"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(ParseFailed, "area_id"))
+	}
+
+	progress, err := strconv.Atoi(c.FormValue("progress"))
+	if err != nil {
+		return Error(c, NewRequestValidationError(Numeric, "progress"))
+	}
+
+	eventQueryResult := s.findTaskEvents(uid)
+	if eventQueryResult.Error != nil {
+		return Error(c, eventQueryResult.Error)
+	}
+
+	events, ok := eventQueryResult.Result.([]storage.TaskEvent)
+	if !ok {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if len(events) == 0 {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	task := repository.BuildTaskFromEventHistory(events)
+
+	// The user is only known when the token validation is enabled.
+	var completedBy *uuid.UUID
+	if userUID, ok := c.Get(farmscope.UserKey).(uuid.UUID); ok {
+		completedBy = &userUID
+	}
+
+	err = task.UpdateAreaProgress(areaUID, progress, completedBy)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = <-s.TaskEventRepo.Save(task.UID, task.Version, task.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(task)
+	s.createNextOccurrence(task)
+
+	taskRead := MapTaskToTaskRead(task)
+	if err := s.AppendTaskDomainDetails(taskRead); err != nil {
+		return Error(c, err)
+	}
+
+	data["data"] = *taskRead
+
+	return c.JSON(http.StatusOK, data)
+}
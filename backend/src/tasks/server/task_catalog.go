@@ -0,0 +1,295 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// CatalogEntryInUseError is returned with 409 when a removed priority or category is still used by tasks,
+// which have to be migrated to another entry with migrate_to.
+type CatalogEntryInUseError struct {
+	RequestValidationError
+	TaskCount int `json:"task_count"`
+}
+
+// MountTaskCatalogs defines the endpoints of the task catalogs of the farms.
+func (s *TaskServer) MountTaskCatalogs(g *echo.Group) {
+	// The catalogs are core to every task, they aren't behind the task_templates flag of the templates.
+	g.GET("/:farm_id", s.FindTaskCatalog, s.taskCatalogScope("farm_id"))
+	g.PUT("/:farm_id/priorities/:code",
+		s.validatable((*TaskServer).SaveTaskCatalogPriority), s.taskCatalogScope("farm_id"))
+	g.DELETE("/:farm_id/priorities/:code",
+		s.validatable((*TaskServer).RemoveTaskCatalogPriority), s.taskCatalogScope("farm_id"))
+	g.PUT("/:farm_id/categories/:code",
+		s.validatable((*TaskServer).SaveTaskCatalogCategory), s.taskCatalogScope("farm_id"))
+	g.DELETE("/:farm_id/categories/:code",
+		s.validatable((*TaskServer).RemoveTaskCatalogCategory), s.taskCatalogScope("farm_id"))
+}
+
+// taskCatalogScope only lets the members of the farm of the param see and change its catalog.
+func (s *TaskServer) taskCatalogScope(param string) echo.MiddlewareFunc {
+	return s.FarmScope.Entity("", func(c echo.Context) (uuid.UUID, error) {
+		farmUID, err := uuid.FromString(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+
+		return farmUID, nil
+	})
+}
+
+// FindTaskCatalog returns the priorities, the most urgent first, and the categories the tasks of the farm
+// can have, so the clients can list them.
+func (s *TaskServer) FindTaskCatalog(c echo.Context) error {
+	data := make(map[string]*domain.TaskCatalog)
+
+	catalog, err := s.findTaskCatalogByFarmID(uuid.FromStringOrNil(c.Param("farm_id")))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data["data"] = catalog
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// SaveTaskCatalogPriority adds the priority of the code param or changes it. The position is the rank of the
+// priority, 1 being the most urgent, an existing priority stays where it is without one.
+func (s *TaskServer) SaveTaskCatalogPriority(c echo.Context) error {
+	position := 0
+
+	if value := c.FormValue("position"); value != "" {
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(Numeric, "position"))
+		}
+
+		position = v
+	}
+
+	return s.changeTaskCatalog(c, func(catalog *domain.TaskCatalog) error {
+		return catalog.SavePriority(domain.TaskPriority{
+			Code:  c.Param("code"),
+			Name:  c.FormValue("name"),
+			Color: c.FormValue("color"),
+		}, position)
+	})
+}
+
+// SaveTaskCatalogCategory adds the category of the code param or changes it.
+func (s *TaskServer) SaveTaskCatalogCategory(c echo.Context) error {
+	return s.changeTaskCatalog(c, func(catalog *domain.TaskCatalog) error {
+		return catalog.SaveCategory(domain.TaskCategory{
+			Code: c.Param("code"),
+			Name: c.FormValue("name"),
+			Icon: c.FormValue("icon"),
+		})
+	})
+}
+
+// RemoveTaskCatalogPriority removes the priority of the code param. The tasks of the farm which still have it
+// are moved to the migrate_to priority first, the removal is refused with their count without one.
+func (s *TaskServer) RemoveTaskCatalogPriority(c echo.Context) error {
+	return s.removeTaskCatalogEntry(c, "priority", func(catalog *domain.TaskCatalog, usedBy int) error {
+		return catalog.RemovePriority(c.Param("code"), c.QueryParam("migrate_to"), usedBy)
+	}, func(catalog *domain.TaskCatalog, task *domain.Task) error {
+		_, err := task.ChangeTaskPriority(catalog, c.QueryParam("migrate_to"))
+
+		return err
+	})
+}
+
+// RemoveTaskCatalogCategory removes the category of the code param. The tasks of the farm which still have it
+// are moved to the migrate_to category first, the removal is refused with their count without one.
+func (s *TaskServer) RemoveTaskCatalogCategory(c echo.Context) error {
+	return s.removeTaskCatalogEntry(c, "category", func(catalog *domain.TaskCatalog, usedBy int) error {
+		return catalog.RemoveCategory(c.Param("code"), c.QueryParam("migrate_to"), usedBy)
+	}, func(catalog *domain.TaskCatalog, task *domain.Task) error {
+		_, err := task.ChangeTaskCategory(catalog, c.QueryParam("migrate_to"))
+
+		return err
+	})
+}
+
+// removeTaskCatalogEntry migrates the tasks of the farm with the entry of the code param, filtered on field,
+// then removes the entry. The tasks are only changed once the entry can be removed.
+func (s *TaskServer) removeTaskCatalogEntry(
+	c echo.Context,
+	field string,
+	remove func(catalog *domain.TaskCatalog, usedBy int) error,
+	migrate func(catalog *domain.TaskCatalog, task *domain.Task) error,
+) error {
+	farmUID := uuid.FromStringOrNil(c.Param("farm_id"))
+
+	catalog, err := s.findTaskCatalogByFarmID(farmUID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	tasks, err := s.findFarmTasksWith(farmUID, field, c.Param("code"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = remove(catalog, len(tasks))
+	if errors.Is(err, domain.TaskError{Code: domain.TaskCatalogErrorEntryInUseCode}) {
+		rve := NewRequestValidationError(CatalogEntryInUse, "migrate_to")
+		rve.ErrorMessage = fmt.Sprintf("%s %s is still used by %d tasks", field, c.Param("code"), len(tasks))
+
+		return Error(c, CatalogEntryInUseError{RequestValidationError: rve, TaskCount: len(tasks)})
+	}
+
+	if err != nil {
+		return Error(c, err)
+	}
+
+	for _, v := range tasks {
+		eventQueryResult := s.findTaskEvents(v.UID)
+		if eventQueryResult.Error != nil {
+			return Error(c, eventQueryResult.Error)
+		}
+
+		events, ok := eventQueryResult.Result.([]storage.TaskEvent)
+		if !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, "Internal server error")
+		}
+
+		task := repository.BuildTaskFromEventHistory(events)
+
+		err = migrate(catalog, task)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		err = <-s.TaskEventRepo.Save(task.UID, task.Version, task.UncommittedChanges, actor.FromContext(c))
+		if err != nil {
+			return Error(c, err)
+		}
+
+		s.publishUncommittedEvents(task)
+	}
+
+	return s.saveTaskCatalog(c, catalog)
+}
+
+// changeTaskCatalog applies the change to the catalog of the farm of the farm_id param and saves it.
+func (s *TaskServer) changeTaskCatalog(c echo.Context, change func(catalog *domain.TaskCatalog) error) error {
+	catalog, err := s.findTaskCatalogByFarmID(uuid.FromStringOrNil(c.Param("farm_id")))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = change(catalog)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	return s.saveTaskCatalog(c, catalog)
+}
+
+func (s *TaskServer) saveTaskCatalog(c echo.Context, catalog *domain.TaskCatalog) error {
+	data := make(map[string]*domain.TaskCatalog)
+
+	err := <-s.TaskCatalogEventRepo.Save(catalog.UID, catalog.Version, catalog.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(catalog)
+
+	data["data"] = catalog
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// findFarmTasksWith lists the tasks of the farm with the value of the field. The tasks without a farm have
+// the built-in catalog, so they are left out.
+func (s *TaskServer) findFarmTasksWith(farmUID uuid.UUID, field, value string) ([]storage.TaskRead, error) {
+	result := <-s.TaskReadQuery.FindTasksWithFilter(map[string]string{"farm_id": farmUID.String(), field: value}, 0, 0)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	tasks, ok := result.Result.([]storage.TaskRead)
+	if !ok {
+		return nil, errors.New("internal server error")
+	}
+
+	return tasks, nil
+}
+
+// findTaskCatalog finds the catalog of the farm of the task, the farm of its asset or the one of the farm_id
+// form value for a task without one. The other tasks have the built-in catalog.
+func (s *TaskServer) findTaskCatalog(c echo.Context, taskDomain string, assetID *uuid.UUID) (
+	*domain.TaskCatalog, error,
+) {
+	farmUID := s.assetFarmUID(taskDomain, assetID)
+
+	if value := c.FormValue("farm_id"); farmUID == uuid.Nil && value != "" {
+		uid, err := uuid.FromString(value)
+		if err != nil || !s.FarmScope.Allows(c, uid) {
+			return nil, NewRequestValidationError(NotFound, "farm_id")
+		}
+
+		farmUID = uid
+	}
+
+	if farmUID == uuid.Nil {
+		return domain.DefaultTaskCatalog(uuid.Nil), nil
+	}
+
+	return s.findTaskCatalogByFarmID(farmUID)
+}
+
+func (s *TaskServer) findTaskCatalogByFarmID(farmUID uuid.UUID) (*domain.TaskCatalog, error) {
+	eventQueryResult := <-s.TaskCatalogEventQuery.FindAllByFarmID(farmUID)
+	if eventQueryResult.Error != nil {
+		return nil, eventQueryResult.Error
+	}
+
+	events, ok := eventQueryResult.Result.([]storage.TaskCatalogEvent)
+	if !ok {
+		return nil, errors.New("internal server error")
+	}
+
+	return repository.BuildTaskCatalogFromEventHistory(farmUID, events), nil
+}
+
+// assetFarmUID returns the farm of the asset of the task, the nil UID for the tasks without an asset,
+// on a material, or on an asset which isn't found.
+func (s *TaskServer) assetFarmUID(taskDomain string, assetID *uuid.UUID) uuid.UUID {
+	if assetID == nil {
+		return uuid.Nil
+	}
+
+	switch taskDomain {
+	case domain.TaskDomainAreaCode:
+		if area, ok := s.TaskService.FindAreaByID(*assetID).Result.(query.TaskAreaResult); ok {
+			return area.FarmUID
+		}
+	case domain.TaskDomainCropCode:
+		if crop, ok := s.TaskService.FindCropByID(*assetID).Result.(query.TaskCropResult); ok {
+			return crop.FarmUID
+		}
+	case domain.TaskDomainReservoirCode:
+		if reservoir, ok := s.TaskService.FindReservoirByID(*assetID).Result.(query.TaskReservoirResult); ok {
+			return reservoir.FarmUID
+		}
+	case domain.TaskDomainEquipmentCode:
+		if equipment, ok := s.TaskService.FindEquipmentByID(*assetID).Result.(query.TaskEquipmentResult); ok {
+			return equipment.FarmUID
+		}
+	}
+
+	return uuid.Nil
+}
@@ -0,0 +1,71 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/usetania/tania-core/src/featureflags"
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/server"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+func TestRemoveTaskCatalogCategoryCountsTheTasksOfTheFarm(t *testing.T) {
+	// Given
+	app := newTestApp()
+
+	farmUID, _ := uuid.NewV4()
+	otherFarmUID, _ := uuid.NewV4()
+
+	for _, v := range []uuid.UUID{farmUID, otherFarmUID, uuid.Nil} {
+		taskUID, _ := uuid.NewV4()
+		app.taskReadStorage.TaskReadMap[taskUID] = storage.TaskRead{
+			UID:              taskUID,
+			Title:            "Prune the apple trees",
+			Status:           domain.TaskStatusCreated,
+			Domain:           domain.TaskDomainAreaCode,
+			Category:         "PRUNING",
+			ShortCodeFarmUID: v,
+		}
+	}
+
+	app.start(t, 0)
+	app.server.MountTaskCatalogs(app.echo.Group("/api/task-catalogs"))
+
+	path := "/api/task-catalogs/" + farmUID.String() + "/categories/PRUNING"
+	saved := app.call(http.MethodPut, path, url.Values{"name": {"Pruning"}, "icon": {"scissors"}})
+	require.Equal(t, http.StatusOK, saved.Code, saved.Body.String())
+
+	// When
+	removed := app.call(http.MethodDelete, path, nil)
+
+	// Then
+	require.Equal(t, http.StatusConflict, removed.Code, removed.Body.String())
+
+	body := server.CatalogEntryInUseError{}
+	assert.Nil(t, json.Unmarshal(removed.Body.Bytes(), &body))
+	assert.Equal(t, 1, body.TaskCount)
+}
+
+func TestTaskCatalogsStayOnWithoutTheTaskTemplates(t *testing.T) {
+	// Given
+	app := newTestApp()
+	app.start(t, 0)
+	app.server.MountTaskCatalogs(app.echo.Group("/api/task-catalogs"))
+
+	_, err := app.server.Flags.Reload(map[string]string{featureflags.TaskTemplates: "false"})
+	require.Nil(t, err)
+
+	farmUID, _ := uuid.NewV4()
+
+	// When
+	found := app.call(http.MethodGet, "/api/task-catalogs/"+farmUID.String(), nil)
+
+	// Then
+	assert.Equal(t, http.StatusOK, found.Code, found.Body.String())
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/customfield"
+	"github.com/usetania/tania-core/src/helper/paginationhelper"
+	"github.com/usetania/tania-core/src/tasks/query"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// SaveTaskCustomFields replaces the custom field values of the task. The tasks belong to no farm,
+// so the values are checked against the fields defined by the farm of the farm_id form value.
+func (s *TaskServer) SaveTaskCustomFields(c echo.Context) error {
+	uid, err := s.parseTaskUID(c, "id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	result := <-s.TaskReadQuery.FindByID(uid)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	task, ok := result.Result.(storage.TaskRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	if task.UID != uid {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	farmUID, err := s.parseCustomFieldFarmUID(c, c.FormValue("farm_id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	value := c.FormValue("custom_fields")
+	if value == "" {
+		return Error(c, NewRequestValidationError(Required, "custom_fields"))
+	}
+
+	values, err := customfield.ParseValues(value)
+	if err != nil {
+		return Error(c, NewRequestValidationError(ParseFailed, "custom_fields"))
+	}
+
+	// PROCESS AND PERSIST //
+	values, err = s.CustomFields.Change(farmUID, assetsdomain.CustomFieldEntityTask, task.UID, values)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if err := s.AppendTaskDomainDetails(&task); err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]TaskDetail)
+	data["task"] = TaskDetail{TaskRead: task, CustomFields: values}
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// parseCustomFieldFarmUID parses the farm_id value, which the tasks need to find their custom fields.
+func (s *TaskServer) parseCustomFieldFarmUID(c echo.Context, value string) (uuid.UUID, error) {
+	if value == "" {
+		return uuid.Nil, NewRequestValidationError(Required, "farm_id")
+	}
+
+	farmUID, err := uuid.FromString(value)
+	if err != nil || !s.FarmScope.Allows(c, farmUID) {
+		return uuid.Nil, NewRequestValidationError(NotFound, "farm_id")
+	}
+
+	return farmUID, nil
+}
+
+// findTasksByCustomFields lists the tasks matching the `cf_` filters on the fields defined by the farm
+// of the farm_id query param, and the other filters when given. The custom field filters apply after
+// the query, so the page is taken from all the tasks.
+func (s *TaskServer) findTasksByCustomFields(
+	c echo.Context,
+	queryparams map[string]string,
+	page, limit int,
+	filters map[string]string,
+) error {
+	farmUID, err := s.parseCustomFieldFarmUID(c, c.QueryParam("farm_id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	matched, err := s.CustomFields.Filter(farmUID, assetsdomain.CustomFieldEntityTask, filters)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	var result query.Result
+	if queryparams == nil {
+		result = <-s.TaskReadQuery.FindAll(0, 0)
+	} else {
+		result = <-s.TaskReadQuery.FindTasksWithFilter(queryparams, 0, 0)
+	}
+
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	all, ok := result.Result.([]storage.TaskRead)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	tasks := []storage.TaskRead{}
+
+	for _, v := range all {
+		if matched[v.UID] {
+			tasks = append(tasks, v)
+		}
+	}
+
+	total := len(tasks)
+	start, end := paginationhelper.PageBounds(total, page, limit)
+	tasks = tasks[start:end]
+
+	for i := range tasks {
+		if err := s.AppendTaskDomainDetails(&tasks[i]); err != nil {
+			return Error(c, err)
+		}
+	}
+
+	data := make(map[string]interface{})
+	data["data"] = tasks
+	data["total_rows"] = total
+	data["page"] = page
+
+	return c.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// SaveTaskDependencies replaces the tasks the task depends on with the depends_on form values,
+// an empty one clears them. The tasks depended on have to be in the task list, not archived.
+func (s *TaskServer) SaveTaskDependencies(c echo.Context) error {
+	data := make(map[string]storage.TaskRead)
+
+	uid, err := s.parseTaskUID(c, "id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	params, err := c.FormParams()
+	if err != nil {
+		return Error(c, err)
+	}
+
+	values, ok := params["depends_on"]
+	if !ok {
+		return Error(c, NewRequestValidationError(Required, "depends_on"))
+	}
+
+	dependsOn := []uuid.UUID{}
+
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+
+		dependencyUID, err := uuid.FromString(v)
+		if err != nil {
+			return Error(c, NewRequestValidationError(ParseFailed, "depends_on"))
+		}
+
+		dependsOn = append(dependsOn, dependencyUID)
+	}
+
+	result := <-s.TaskReadQuery.FindAll(0, 0)
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	tasks, ok := result.Result.([]storage.TaskRead)
+	if !ok {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	graph := make(map[uuid.UUID][]uuid.UUID, len(tasks))
+	for _, v := range tasks {
+		graph[v.UID] = v.DependsOn
+	}
+
+	if _, ok := graph[uid]; !ok {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	for _, v := range dependsOn {
+		if _, ok := graph[v]; !ok {
+			return Error(c, NewRequestValidationError(NotFound, "depends_on"))
+		}
+	}
+
+	eventQueryResult := s.findTaskEvents(uid)
+	if eventQueryResult.Error != nil {
+		return Error(c, eventQueryResult.Error)
+	}
+
+	events, ok := eventQueryResult.Result.([]storage.TaskEvent)
+	if !ok {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	task := repository.BuildTaskFromEventHistory(events)
+
+	err = task.ChangeTaskDependencies(dependsOn)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if domain.DependencyCycle(graph, uid, task.DependsOn) {
+		return Error(c, domain.TaskError{Code: domain.TaskErrorDependencyCycleCode})
+	}
+
+	err = <-s.TaskEventRepo.Save(task.UID, task.Version, task.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(task)
+
+	taskRead := MapTaskToTaskRead(task)
+	if err := s.AppendTaskDomainDetails(taskRead); err != nil {
+		return Error(c, err)
+	}
+
+	data["data"] = *taskRead
+
+	return c.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// dueJob is the job the open tasks past their due date are set as due by.
+const dueJob = "task_due"
+
+// StartDueScheduler sets the open tasks as due once their due date has passed, right away and then every
+// interval. The runs are skipped while paused is true, during the maintenance mode.
+func (s *TaskServer) StartDueScheduler(interval time.Duration, paused func() bool) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		for {
+			if paused() {
+				log.Println("Task due scheduler paused by the maintenance mode")
+			} else {
+				s.setDueTasks(time.Now())
+			}
+
+			<-ticker.C
+		}
+	}()
+}
+
+func (s *TaskServer) setDueTasks(now time.Time) {
+	result := <-s.TaskReadQuery.FindTasksWithFilter(map[string]string{
+		"status": domain.TaskStatusCreated,
+		"is_due": "false",
+	}, 0, 0)
+	if result.Error != nil {
+		log.Println("Task due check failed", result.Error)
+
+		return
+	}
+
+	tasks, ok := result.Result.([]storage.TaskRead)
+	if !ok {
+		log.Println(errors.New("internal server error. error type assertion"))
+
+		return
+	}
+
+	for _, v := range tasks {
+		if v.DueDate == nil || v.DueDate.After(now) {
+			continue
+		}
+
+		err := s.setTaskDue(v, now)
+		if err != nil {
+			log.Println("Task", v.UID, "cannot be set as due", err)
+		}
+	}
+}
+
+// setTaskDue sets the task as due from its history, the read model of a task set as due by the last run
+// may not be updated yet.
+func (s *TaskServer) setTaskDue(taskRead storage.TaskRead, now time.Time) error {
+	eventQueryResult := s.findTaskEvents(taskRead.UID)
+	if eventQueryResult.Error != nil {
+		return eventQueryResult.Error
+	}
+
+	events, ok := eventQueryResult.Result.([]storage.TaskEvent)
+	if !ok {
+		return errors.New("internal server error. error type assertion")
+	}
+
+	task := repository.BuildTaskFromEventHistory(events)
+
+	if !task.SetTaskAsDueAt(now) {
+		return nil
+	}
+
+	err := <-s.TaskEventRepo.Save(task.UID, task.Version, task.UncommittedChanges, actor.System(dueJob))
+	if err != nil {
+		return err
+	}
+
+	s.publishUncommittedEvents(task)
+
+	return nil
+}
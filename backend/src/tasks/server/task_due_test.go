@@ -0,0 +1,83 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// seedTask saves a general task with the due date, which may be in the past, as its history and its read model.
+func (app *testApp) seedTask(dueDate *time.Time) uuid.UUID {
+	uid, _ := uuid.NewV4()
+
+	created := domain.TaskCreated{
+		UID:           uid,
+		Title:         "Check the irrigation",
+		CreatedDate:   time.Now().AddDate(0, 0, -2),
+		DueDate:       dueDate,
+		Priority:      domain.TaskPriorityNormal,
+		Status:        domain.TaskStatusCreated,
+		Domain:        domain.TaskDomainGeneralCode,
+		DomainDetails: domain.TaskDomainGeneral{},
+		Category:      domain.TaskCategoryGeneral,
+	}
+
+	app.taskEvents.TaskEvents = append(app.taskEvents.TaskEvents, storage.TaskEvent{
+		TaskUID: uid, Version: 1, CreatedDate: created.CreatedDate, Event: created,
+	})
+	app.taskReadStorage.TaskReadMap[uid] = storage.TaskRead{
+		UID:           uid,
+		Title:         created.Title,
+		CreatedDate:   created.CreatedDate,
+		DueDate:       dueDate,
+		Priority:      created.Priority,
+		Status:        created.Status,
+		Domain:        created.Domain,
+		DomainDetails: created.DomainDetails,
+		Category:      created.Category,
+	}
+
+	return uid
+}
+
+func (app *testApp) isDue(t *testing.T, uid uuid.UUID) bool {
+	t.Helper()
+
+	rec := app.call(http.MethodGet, "/api/tasks/"+uid.String(), nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	body := struct {
+		Task struct {
+			IsDue bool `json:"is_due"`
+		} `json:"task"`
+	}{}
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+
+	return body.Task.IsDue
+}
+
+func TestTaskServerSetsTheOverdueTasksAsDue(t *testing.T) {
+	// Given
+	overdue := time.Now().Add(-time.Hour)
+	upcoming := time.Now().AddDate(0, 0, 1)
+
+	app := newTestApp()
+	overdueUID := app.seedTask(&overdue)
+	upcomingUID := app.seedTask(&upcoming)
+	undatedUID := app.seedTask(nil)
+
+	// When
+	app.start(t, 60)
+
+	// Then
+	assert.Eventually(t, func() bool { return app.isDue(t, overdueUID) }, time.Second, 10*time.Millisecond)
+	assert.False(t, app.isDue(t, upcomingUID))
+	assert.False(t, app.isDue(t, undatedUID))
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"log"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/notification"
+	"github.com/usetania/tania-core/src/tasks/domain"
+)
+
+// TaskNotifier sends the task notifications through the channels of their priority.
+type TaskNotifier interface {
+	Notify(notification notification.Notification)
+}
+
+// StartNotifications notifies the created tasks and the tasks falling due. The bus calls the subscribers in
+// order, so the read model is already saved when NotifyTask is called.
+func (s *TaskServer) StartNotifications(notifier TaskNotifier) {
+	s.Notifier = notifier
+
+	s.EventBus.Subscribe(domain.TaskCreatedCode, s.NotifyTask)
+	s.EventBus.Subscribe(domain.TaskDueCode, s.NotifyTask)
+}
+
+// NotifyTask sends the notification of the task event in the background, so the slow channels don't hold
+// the requests.
+func (s *TaskServer) NotifyTask(event interface{}) error {
+	taskNotification := notification.TaskNotification{}
+
+	switch e := event.(type) {
+	case domain.TaskCreated:
+		taskNotification = notification.TaskNotification{
+			Event:    notification.TaskNotificationCreated,
+			TaskUID:  e.UID,
+			Title:    e.Title,
+			Priority: e.Priority,
+			Category: e.Category,
+			DueDate:  e.DueDate,
+		}
+	case domain.TaskDue:
+		var err error
+
+		taskNotification, err = s.dueTaskNotification(e.UID)
+		if err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	go s.Notifier.Notify(taskNotification)
+
+	return nil
+}
+
+// TaskEscalator opens an incident for the tasks falling due and resolves it once they are completed,
+// like the PagerDutyNotifier.
+type TaskEscalator interface {
+	Trigger(notification notification.TaskNotification) error
+	Resolve(taskUID uuid.UUID) error
+}
+
+// StartEscalations escalates the urgent tasks falling due, and resolves their escalation when they are completed.
+func (s *TaskServer) StartEscalations(escalator TaskEscalator) {
+	s.Escalator = escalator
+
+	s.EventBus.Subscribe(domain.TaskDueCode, s.EscalateTask)
+	s.EventBus.Subscribe(domain.TaskCompletedCode, s.EscalateTask)
+}
+
+// EscalateTask triggers or resolves the escalation of the urgent task in the background. The escalator skips
+// the other priorities when triggering, the resolves are only sent for the urgent tasks.
+func (s *TaskServer) EscalateTask(event interface{}) error {
+	switch e := event.(type) {
+	case domain.TaskDue:
+		taskNotification, err := s.dueTaskNotification(e.UID)
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			if err := s.Escalator.Trigger(taskNotification); err != nil {
+				log.Println("Escalation of the task", e.UID, "failed.", err)
+			}
+		}()
+	case domain.TaskCompleted:
+		taskRead, err := s.getTaskReadFromID(e.UID)
+		if err != nil {
+			return err
+		}
+
+		if taskRead.Priority != notification.PriorityUrgent {
+			return nil
+		}
+
+		go func() {
+			if err := s.Escalator.Resolve(e.UID); err != nil {
+				log.Println("Resolving the escalation of the task", e.UID, "failed.", err)
+			}
+		}()
+	}
+
+	return nil
+}
+
+func (s *TaskServer) dueTaskNotification(uid uuid.UUID) (notification.TaskNotification, error) {
+	taskRead, err := s.getTaskReadFromID(uid)
+	if err != nil {
+		return notification.TaskNotification{}, err
+	}
+
+	return notification.TaskNotification{
+		Event:     notification.TaskNotificationDue,
+		TaskUID:   taskRead.UID,
+		ShortCode: taskRead.ShortCode,
+		Title:     taskRead.Title,
+		Priority:  taskRead.Priority,
+		Category:  taskRead.Category,
+		DueDate:   taskRead.DueDate,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/farmscope"
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// RecordTaskProgress adds the quantity form value, the units of work done, to the completed quantity of the task,
+// with the labour_minutes they took. Unless task_progress_auto_complete is off, the task is completed by the
+// current user once its target quantity is reached.
+func (s *TaskServer) RecordTaskProgress(c echo.Context) error {
+	data := make(map[string]storage.TaskRead)
+
+	uid, err := s.parseTaskUID(c, "id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	quantity, err := strconv.ParseFloat(c.FormValue("quantity"), 64)
+	if err != nil {
+		return Error(c, NewRequestValidationError(Float, "quantity"))
+	}
+
+	labourMinutes := 0
+
+	if value := c.FormValue("labour_minutes"); value != "" {
+		labourMinutes, err = strconv.Atoi(value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(Numeric, "labour_minutes"))
+		}
+	}
+
+	eventQueryResult := s.findTaskEvents(uid)
+	if eventQueryResult.Error != nil {
+		return Error(c, eventQueryResult.Error)
+	}
+
+	events, ok := eventQueryResult.Result.([]storage.TaskEvent)
+	if !ok {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if len(events) == 0 {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	task := repository.BuildTaskFromEventHistory(events)
+
+	// The user is only known when the token validation is enabled.
+	var recordedBy *uuid.UUID
+	if userUID, ok := c.Get(farmscope.UserKey).(uuid.UUID); ok {
+		recordedBy = &userUID
+	}
+
+	err = task.RecordProgress(quantity, labourMinutes, recordedBy, progressAutoComplete())
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = <-s.TaskEventRepo.Save(task.UID, task.Version, task.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(task)
+	s.createNextOccurrence(task)
+
+	taskRead := MapTaskToTaskRead(task)
+	if err := s.AppendTaskDomainDetails(taskRead); err != nil {
+		return Error(c, err)
+	}
+
+	data["data"] = *taskRead
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// progressAutoComplete tells if reaching its target quantity completes a task, on by default.
+func progressAutoComplete() bool {
+	return config.Config.TaskProgressAutoComplete == nil || *config.Config.TaskProgressAutoComplete
+}
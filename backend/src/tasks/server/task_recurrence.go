@@ -0,0 +1,172 @@
+package server
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// recurrenceJob is the job the next tasks of the recurring tasks are created by.
+const recurrenceJob = "task_recurrence"
+
+// parseTaskRecurrence reads the repeat rule of the form values: the repeat frequency, the repeat_interval
+// and the repeat_until end date.
+func parseTaskRecurrence(c echo.Context) (*domain.TaskRecurrence, error) {
+	interval := 0
+
+	if value := c.FormValue("repeat_interval"); value != "" {
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, NewRequestValidationError(Numeric, "repeat_interval")
+		}
+
+		interval = number
+	}
+
+	var endDate *time.Time
+
+	if value := c.FormValue("repeat_until"); value != "" {
+		date, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, NewRequestValidationError(ParseFailed, "repeat_until")
+		}
+
+		endDate = &date
+	}
+
+	return domain.CreateTaskRecurrence(strings.ToUpper(c.FormValue("repeat")), interval, endDate)
+}
+
+// ChangeTaskRecurrence repeats the open task with the repeat rule of the form values, an empty repeat
+// stops repeating it. The next task is created when the task is completed.
+func (s *TaskServer) ChangeTaskRecurrence(c echo.Context) error {
+	data := make(map[string]storage.TaskRead)
+
+	uid, err := s.parseTaskUID(c, "id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	var recurrence *domain.TaskRecurrence
+
+	if c.FormValue("repeat") != "" {
+		recurrence, err = parseTaskRecurrence(c)
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
+	eventQueryResult := s.findTaskEvents(uid)
+	if eventQueryResult.Error != nil {
+		return Error(c, eventQueryResult.Error)
+	}
+
+	events, ok := eventQueryResult.Result.([]storage.TaskEvent)
+	if !ok {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if len(events) == 0 {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	task := repository.BuildTaskFromEventHistory(events)
+
+	err = task.SetTaskRecurrence(recurrence)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = <-s.TaskEventRepo.Save(task.UID, task.Version, task.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(task)
+
+	taskRead := MapTaskToTaskRead(task)
+	if err := s.AppendTaskDomainDetails(taskRead); err != nil {
+		return Error(c, err)
+	}
+
+	data["data"] = *taskRead
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// createNextOccurrence creates the next task of the series of the task just completed, if it repeats. The task
+// is already completed when this fails, so the failure is only logged.
+func (s *TaskServer) createNextOccurrence(task *domain.Task) {
+	if task.Recurrence == nil || !completedNow(task) {
+		return
+	}
+
+	farmUID := task.ShortCodeFarmUID
+	if farmUID == uuid.Nil {
+		farmUID = s.assetFarmUID(task.Domain, task.AssetID)
+	}
+
+	catalog := domain.DefaultTaskCatalog(uuid.Nil)
+
+	if farmUID != uuid.Nil {
+		farmCatalog, err := s.findTaskCatalogByFarmID(farmUID)
+		if err != nil {
+			log.Println(err)
+
+			return
+		}
+
+		catalog = farmCatalog
+	}
+
+	next, ok, err := task.NextOccurrence(s.TaskService, catalog)
+	if err != nil {
+		log.Println(err)
+
+		return
+	}
+
+	if !ok {
+		return
+	}
+
+	s.balanceDueDate(farmUID, next)
+	s.adjustDueDateForBusinessHours(farmUID, next)
+
+	err = s.assignFarmShortCode(next, farmUID)
+	if err != nil {
+		log.Println(err)
+
+		return
+	}
+
+	err = <-s.TaskEventRepo.Save(next.UID, 0, next.UncommittedChanges, actor.System(recurrenceJob))
+	if err != nil {
+		log.Println(err)
+
+		return
+	}
+
+	s.publishUncommittedEvents(next)
+}
+
+// completedNow tells if the task was completed by its uncommitted changes, so a task completed again
+// doesn't repeat twice.
+func completedNow(task *domain.Task) bool {
+	for _, v := range task.UncommittedChanges {
+		if _, ok := v.(domain.TaskCompleted); ok {
+			return true
+		}
+	}
+
+	return false
+}
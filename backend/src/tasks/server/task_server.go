@@ -3,52 +3,108 @@ package server
 import (
 	"database/sql"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/actor"
 	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/customfield"
 	"github.com/usetania/tania-core/src/eventbus"
+	"github.com/usetania/tania-core/src/farmscope"
+	"github.com/usetania/tania-core/src/featureflags"
 	cropstorage "github.com/usetania/tania-core/src/growth/storage"
 	"github.com/usetania/tania-core/src/helper/paginationhelper"
 	"github.com/usetania/tania-core/src/helper/structhelper"
+	"github.com/usetania/tania-core/src/retention"
+	"github.com/usetania/tania-core/src/shortcode"
+	redisstorage "github.com/usetania/tania-core/src/storage/redis"
 	"github.com/usetania/tania-core/src/tasks/domain"
 	"github.com/usetania/tania-core/src/tasks/domain/service"
 	"github.com/usetania/tania-core/src/tasks/query"
 	queryInMem "github.com/usetania/tania-core/src/tasks/query/inmemory"
 	queryMysql "github.com/usetania/tania-core/src/tasks/query/mysql"
+	queryRedis "github.com/usetania/tania-core/src/tasks/query/redis"
 	querySqlite "github.com/usetania/tania-core/src/tasks/query/sqlite"
 	"github.com/usetania/tania-core/src/tasks/repository"
 	repoInMem "github.com/usetania/tania-core/src/tasks/repository/inmemory"
 	repoMysql "github.com/usetania/tania-core/src/tasks/repository/mysql"
+	repoRedis "github.com/usetania/tania-core/src/tasks/repository/redis"
 	repoSqlite "github.com/usetania/tania-core/src/tasks/repository/sqlite"
 	"github.com/usetania/tania-core/src/tasks/storage"
 )
 
 // TaskServer ties the routes and handlers with injected dependencies.
 type TaskServer struct {
-	TaskEventRepo  repository.TaskEvent
-	TaskReadRepo   repository.TaskRead
-	TaskEventQuery query.TaskEvent
-	TaskReadQuery  query.TaskRead
-	TaskService    domain.TaskService
-	EventBus       eventbus.TaniaEventBus
+	TaskEventRepo            repository.TaskEvent
+	TaskReadRepo             repository.TaskRead
+	TaskEventQuery           query.TaskEvent
+	TaskReadQuery            query.TaskRead
+	TaskArchiveRepo          repository.TaskArchive
+	TaskArchiveQuery         query.TaskArchive
+	TaskService              domain.TaskService
+	ResourceConflictDetector ResourceConflictDetector
+	TaskTemplateEventRepo    repository.TaskTemplateEvent
+	TaskTemplateReadRepo     repository.TaskTemplateRead
+	TaskTemplateEventQuery   query.TaskTemplateEvent
+	TaskTemplateReadQuery    query.TaskTemplateRead
+	TaskTemplateService      domain.TaskTemplateService
+	TaskCatalogEventRepo     repository.TaskCatalogEvent
+	TaskCatalogEventQuery    query.TaskCatalogEvent
+	EventBus                 eventbus.TaniaEventBus
+	ShortCodeGenerator       shortcode.Generator
+	PrunedQuery              retention.PrunedQuery
+	CustomFields             *customfield.Service
+	FarmScope                farmscope.Scope
+	Notifier                 TaskNotifier
+	Escalator                TaskEscalator
+	WorkloadBalancer         WorkloadBalancer
+	FarmCalendar             FarmCalendar
+
+	// Flags turns the task templates routes on and off.
+	Flags *featureflags.Registry
+
+	// ArchiveAfterDays are the days of the configuration the closed tasks of the farms without an archival
+	// policy are archived after, zero when they aren't.
+	ArchiveAfterDays int
 }
 
 // NewTaskServer initializes TaskServer's dependencies and create new TaskServer struct.
+// It starts setting the open tasks past their due date as due every task_due_check_minutes, the checks are
+// skipped while paused is true.
 func NewTaskServer(
 	db *sql.DB,
+	archiveDB *sql.DB,
 	bus eventbus.TaniaEventBus,
 	cropStorage *cropstorage.CropReadStorage,
 	areaStorage *assetsstorage.AreaReadStorage,
 	materialStorage *assetsstorage.MaterialReadStorage,
 	reservoirStorage *assetsstorage.ReservoirReadStorage,
+	equipmentStorage *assetsstorage.EquipmentReadStorage,
 	taskEventStorage *storage.TaskEventStorage,
-	taskReadStorage *storage.TaskReadStorage) (*TaskServer, error,
+	taskReadStorage *storage.TaskReadStorage,
+	taskArchiveStorage *storage.TaskArchiveStorage,
+	taskTemplateEventStorage *storage.TaskTemplateEventStorage,
+	taskTemplateReadStorage *storage.TaskTemplateReadStorage,
+	taskCatalogEventStorage *storage.TaskCatalogEventStorage,
+	prunedStorage *retention.PrunedStorage,
+	customFieldValueStorage *customfield.ValueStorage,
+	customFieldDefinitionStorage *assetsstorage.CustomFieldDefinitionReadStorage,
+	flags *featureflags.Registry,
+	paused func() bool) (*TaskServer, error,
 ) {
+	// The topics of the bus are scoped to the tenant of the deployment.
+	bus, err := eventbus.ForTenant(bus)
+	if err != nil {
+		return nil, err
+	}
+
 	taskServer := &TaskServer{
-		EventBus: bus,
+		EventBus:  bus,
+		FarmScope: farmscope.NewScope(Error),
+		Flags:     flags,
 	}
 
 	switch *config.Config.TaniaPersistenceEngine {
@@ -58,17 +114,34 @@ func NewTaskServer(
 
 		taskServer.TaskEventQuery = queryInMem.NewTaskEventQueryInMemory(taskEventStorage)
 		taskServer.TaskReadQuery = queryInMem.NewTaskReadQueryInMemory(taskReadStorage)
+		taskServer.TaskArchiveRepo = repoInMem.NewTaskArchiveRepositoryInMemory(taskReadStorage, taskArchiveStorage)
+		taskServer.TaskArchiveQuery = queryInMem.NewTaskArchiveQueryInMemory(taskArchiveStorage)
+
+		taskServer.TaskTemplateEventRepo = repoInMem.NewTaskTemplateEventRepositoryInMemory(taskTemplateEventStorage)
+		taskServer.TaskTemplateReadRepo = repoInMem.NewTaskTemplateReadRepositoryInMemory(taskTemplateReadStorage)
+		taskServer.TaskTemplateEventQuery = queryInMem.NewTaskTemplateEventQueryInMemory(taskTemplateEventStorage)
+		taskServer.TaskTemplateReadQuery = queryInMem.NewTaskTemplateReadQueryInMemory(taskTemplateReadStorage)
+
+		taskServer.TaskCatalogEventRepo = repoInMem.NewTaskCatalogEventRepositoryInMemory(taskCatalogEventStorage)
+		taskServer.TaskCatalogEventQuery = queryInMem.NewTaskCatalogEventQueryInMemory(taskCatalogEventStorage)
+
+		taskServer.ShortCodeGenerator = shortcode.NewGeneratorInMemory()
+		taskServer.PrunedQuery = retention.NewPrunedQueryInMemory(prunedStorage)
+		taskServer.CustomFields = customfield.NewService(
+			customfield.NewStoreInMemory(customFieldValueStorage, customFieldDefinitionStorage), bus)
 
 		cropQuery := queryInMem.NewCropQueryInMemory(cropStorage)
 		areaQuery := queryInMem.NewAreaQueryInMemory(areaStorage)
 		materialReadQuery := queryInMem.NewMaterialQueryInMemory(materialStorage)
 		reservoirQuery := queryInMem.NewReservoirQueryInMemory(reservoirStorage)
+		equipmentQuery := queryInMem.NewEquipmentQueryInMemory(equipmentStorage)
 
 		taskServer.TaskService = service.TaskServiceSqlite{
 			CropQuery:      cropQuery,
 			AreaQuery:      areaQuery,
 			MaterialQuery:  materialReadQuery,
 			ReservoirQuery: reservoirQuery,
+			EquipmentQuery: equipmentQuery,
 		}
 
 	case config.DBSqlite:
@@ -77,17 +150,33 @@ func NewTaskServer(
 
 		taskServer.TaskEventQuery = querySqlite.NewTaskEventQuerySqlite(db)
 		taskServer.TaskReadQuery = querySqlite.NewTaskReadQuerySqlite(db)
+		taskServer.TaskArchiveRepo = repoSqlite.NewTaskArchiveRepositorySqlite(db, archiveDB)
+		taskServer.TaskArchiveQuery = querySqlite.NewTaskArchiveQuerySqlite(archiveDB)
+
+		taskServer.TaskTemplateEventRepo = repoSqlite.NewTaskTemplateEventRepositorySqlite(db)
+		taskServer.TaskTemplateReadRepo = repoSqlite.NewTaskTemplateReadRepositorySqlite(db)
+		taskServer.TaskTemplateEventQuery = querySqlite.NewTaskTemplateEventQuerySqlite(db)
+		taskServer.TaskTemplateReadQuery = querySqlite.NewTaskTemplateReadQuerySqlite(db)
+
+		taskServer.TaskCatalogEventRepo = repoSqlite.NewTaskCatalogEventRepositorySqlite(db)
+		taskServer.TaskCatalogEventQuery = querySqlite.NewTaskCatalogEventQuerySqlite(db)
+
+		taskServer.ShortCodeGenerator = shortcode.NewGeneratorSqlite(db)
+		taskServer.PrunedQuery = retention.NewStoreSqlite(db)
+		taskServer.CustomFields = customfield.NewService(customfield.NewStoreSqlite(db), bus)
 
 		cropQuery := querySqlite.NewCropQuerySqlite(db)
 		areaQuery := querySqlite.NewAreaQuerySqlite(db)
 		materialReadQuery := querySqlite.NewMaterialQuerySqlite(db)
 		reservoirQuery := querySqlite.NewReservoirQuerySqlite(db)
+		equipmentQuery := querySqlite.NewEquipmentQuerySqlite(db)
 
 		taskServer.TaskService = service.TaskServiceSqlite{
 			CropQuery:      cropQuery,
 			AreaQuery:      areaQuery,
 			MaterialQuery:  materialReadQuery,
 			ReservoirQuery: reservoirQuery,
+			EquipmentQuery: equipmentQuery,
 		}
 
 	case config.DBMysql:
@@ -96,25 +185,60 @@ func NewTaskServer(
 
 		taskServer.TaskEventQuery = queryMysql.NewTaskEventQueryMysql(db)
 		taskServer.TaskReadQuery = queryMysql.NewTaskReadQueryMysql(db)
+		taskServer.TaskArchiveRepo = repoMysql.NewTaskArchiveRepositoryMysql(db, archiveDB)
+		taskServer.TaskArchiveQuery = queryMysql.NewTaskArchiveQueryMysql(archiveDB)
+
+		taskServer.TaskTemplateEventRepo = repoMysql.NewTaskTemplateEventRepositoryMysql(db)
+		taskServer.TaskTemplateReadRepo = repoMysql.NewTaskTemplateReadRepositoryMysql(db)
+		taskServer.TaskTemplateEventQuery = queryMysql.NewTaskTemplateEventQueryMysql(db)
+		taskServer.TaskTemplateReadQuery = queryMysql.NewTaskTemplateReadQueryMysql(db)
+
+		taskServer.TaskCatalogEventRepo = repoMysql.NewTaskCatalogEventRepositoryMysql(db)
+		taskServer.TaskCatalogEventQuery = queryMysql.NewTaskCatalogEventQueryMysql(db)
+
+		taskServer.ShortCodeGenerator = shortcode.NewGeneratorMysql(db)
+		taskServer.PrunedQuery = retention.NewStoreMysql(db)
+		taskServer.CustomFields = customfield.NewService(customfield.NewStoreMysql(db), bus)
 
 		cropQuery := queryMysql.NewCropQueryMysql(db)
 		areaQuery := queryMysql.NewAreaQueryMysql(db)
 		materialReadQuery := queryMysql.NewMaterialQueryMysql(db)
 		reservoirQuery := queryMysql.NewReservoirQueryMysql(db)
+		equipmentQuery := queryMysql.NewEquipmentQueryMysql(db)
 
 		taskServer.TaskService = service.TaskServiceSqlite{
 			CropQuery:      cropQuery,
 			AreaQuery:      areaQuery,
 			MaterialQuery:  materialReadQuery,
 			ReservoirQuery: reservoirQuery,
+			EquipmentQuery: equipmentQuery,
 		}
 	}
 
+	taskServer.ResourceConflictDetector = ResourceConflictDetector{
+		TaskReadQuery: taskServer.TaskReadQuery,
+		TaskService:   taskServer.TaskService,
+	}
+
+	taskServer.TaskTemplateService = service.TaskTemplateServiceQuery{
+		TaskTemplateReadQuery: taskServer.TaskTemplateReadQuery,
+	}
+
 	taskServer.InitSubscriber()
 
+	if *config.Config.TaskDueCheckMinutes > 0 {
+		taskServer.StartDueScheduler(time.Duration(*config.Config.TaskDueCheckMinutes)*time.Minute, paused)
+	}
+
 	return taskServer, nil
 }
 
+// UseEventStreams keeps the events of the tasks in the Redis streams instead of the in-memory storage.
+func (s *TaskServer) UseEventStreams(streams *redisstorage.RedisStreamsEventStorage) {
+	s.TaskEventRepo = repoRedis.NewTaskEventRepositoryRedis(streams)
+	s.TaskEventQuery = queryRedis.NewTaskEventQueryRedis(streams)
+}
+
 // InitSubscriber defines the mapping of which event this domain listen with their handler.
 func (s *TaskServer) InitSubscriber() {
 	s.EventBus.Subscribe(domain.TaskCreatedCode, s.SaveToTaskReadModel)
@@ -122,26 +246,55 @@ func (s *TaskServer) InitSubscriber() {
 	s.EventBus.Subscribe(domain.TaskDescriptionChangedCode, s.SaveToTaskReadModel)
 	s.EventBus.Subscribe(domain.TaskPriorityChangedCode, s.SaveToTaskReadModel)
 	s.EventBus.Subscribe(domain.TaskDueDateChangedCode, s.SaveToTaskReadModel)
+	s.EventBus.Subscribe(domain.TaskDueDateAdjustedForBusinessHoursCode, s.SaveToTaskReadModel)
 	s.EventBus.Subscribe(domain.TaskCategoryChangedCode, s.SaveToTaskReadModel)
 	s.EventBus.Subscribe(domain.TaskDetailsChangedCode, s.SaveToTaskReadModel)
 	s.EventBus.Subscribe(domain.TaskCancelledCode, s.SaveToTaskReadModel)
 	s.EventBus.Subscribe(domain.TaskCompletedCode, s.SaveToTaskReadModel)
 	s.EventBus.Subscribe(domain.TaskDueCode, s.SaveToTaskReadModel)
+	s.EventBus.Subscribe(domain.TaskShortCodeAssignedCode, s.SaveToTaskReadModel)
+	s.EventBus.Subscribe(domain.TaskEstimatedMinutesChangedCode, s.SaveToTaskReadModel)
+	s.EventBus.Subscribe(domain.TaskDependenciesChangedCode, s.SaveToTaskReadModel)
+	s.EventBus.Subscribe(domain.TaskAreaProgressChangedCode, s.SaveToTaskReadModel)
+	s.EventBus.Subscribe(domain.TaskTargetChangedCode, s.SaveToTaskReadModel)
+	s.EventBus.Subscribe(domain.TaskProgressRecordedCode, s.SaveToTaskReadModel)
+	s.EventBus.Subscribe(domain.TaskRecurrenceSetCode, s.SaveToTaskReadModel)
+	s.EventBus.Subscribe(domain.TaskArchivedCode, s.MoveToTaskArchive)
+
+	s.EventBus.Subscribe("CropNurseryStageStarted", s.CreateNurseryReminderTask)
+	s.EventBus.Subscribe("CropGDDMaturityReached", s.CreateGDDHarvestTask)
+	s.EventBus.Subscribe("LowGerminationAlert", s.CreateLowGerminationTask)
+	s.EventBus.Subscribe("CertificationRenewalDue", s.CreateCertificationRenewalTask)
+	s.EventBus.Subscribe("EquipmentMaintenanceDue", s.CreateEquipmentMaintenanceTask)
+	s.EventBus.Subscribe("MicroclimateAnomalyDetected", s.CreateMicroclimateAnomalyTask)
+
+	s.EventBus.Subscribe(domain.TaskTemplateCreatedCode, s.SaveToTaskTemplateReadModel)
+	s.EventBus.Subscribe(domain.TaskTemplateNameChangedCode, s.SaveToTaskTemplateReadModel)
+	s.EventBus.Subscribe(domain.TaskTemplateParentChangedCode, s.SaveToTaskTemplateReadModel)
+	s.EventBus.Subscribe(domain.TaskTemplateItemsChangedCode, s.SaveToTaskTemplateReadModel)
 }
 
 // Mount defines the TaskServer's endpoints with its handlers.
 func (s *TaskServer) Mount(g *echo.Group) {
-	g.POST("", s.SaveTask)
+	g.POST("", s.validatable((*TaskServer).SaveTask))
 
 	g.GET("", s.FindAllTasks)
 	g.GET("/search", s.FindFilteredTasks)
+	g.GET("/areas/:id", s.FindTasksByArea)
 	g.GET("/:id", s.FindTaskByID)
-	g.PUT("/:id", s.UpdateTask)
-	g.PUT("/:id/cancel", s.CancelTask)
-	g.PUT("/:id/complete", s.CompleteTask)
+	g.PUT("/:id", s.validatable((*TaskServer).UpdateTask))
+	g.GET("/:id/history", s.FindTaskHistory)
+	g.PUT("/:id/sync", s.validatable((*TaskServer).SyncTask))
+	g.PUT("/:id/custom_fields", s.validatable((*TaskServer).SaveTaskCustomFields))
+	g.PUT("/:id/dependencies", s.validatable((*TaskServer).SaveTaskDependencies))
+	g.PUT("/:id/recurrence", s.validatable((*TaskServer).ChangeTaskRecurrence))
+	g.PUT("/:id/areas/:area_id/progress", s.validatable((*TaskServer).UpdateTaskAreaProgress))
+	g.POST("/:id/progress", s.validatable((*TaskServer).RecordTaskProgress))
+	g.PUT("/:id/cancel", s.validatable((*TaskServer).CancelTask))
+	g.PUT("/:id/complete", s.validatable((*TaskServer).CompleteTask))
 	// As we don't have an async task right now to check for Due state,
 	// I'm adding a rest call to be able to manually do that. We can remove it in the future
-	g.PUT("/:id/due", s.SetTaskAsDue)
+	g.PUT("/:id/due", s.validatable((*TaskServer).SetTaskAsDue))
 }
 
 func (s TaskServer) FindAllTasks(c echo.Context) error {
@@ -155,6 +308,16 @@ func (s TaskServer) FindAllTasks(c echo.Context) error {
 		return Error(c, err)
 	}
 
+	filters := customfield.ParseFilters(c.QueryParams())
+	if len(filters) > 0 {
+		return s.findTasksByCustomFields(c, nil, pageInt, limitInt, filters)
+	}
+
+	// The archived tasks are moved out of the task list, they are only listed on request.
+	if c.QueryParam("include_archived") == "true" {
+		return s.findAllTasksWithArchive(c, pageInt, limitInt)
+	}
+
 	result := <-s.TaskReadQuery.FindAll(pageInt, limitInt)
 	if result.Error != nil {
 		return result.Error
@@ -212,6 +375,11 @@ func (s TaskServer) FindFilteredTasks(c echo.Context) error {
 		return Error(c, err)
 	}
 
+	filters := customfield.ParseFilters(c.QueryParams())
+	if len(filters) > 0 {
+		return s.findTasksByCustomFields(c, queryparams, pageInt, limitInt, filters)
+	}
+
 	result := <-s.TaskReadQuery.FindTasksWithFilter(queryparams, pageInt, limitInt)
 	if result.Error != nil {
 		return result.Error
@@ -277,6 +445,42 @@ func (s *TaskServer) SaveTask(c echo.Context) error {
 		assetIDPtr = &assetID
 	}
 
+	estimatedMinutes := 0
+
+	if value := c.FormValue("estimated_minutes"); value != "" {
+		minutes, err := strconv.Atoi(value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(Numeric, "estimated_minutes"))
+		}
+
+		estimatedMinutes = minutes
+	}
+
+	targetQuantity := 0.0
+
+	if value := c.FormValue("target_quantity"); value != "" {
+		quantity, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return Error(c, NewRequestValidationError(Float, "target_quantity"))
+		}
+
+		targetQuantity = quantity
+	}
+
+	// A task spanning several areas has them all in affected_area_ids.
+	affectedAreaIDs := []uuid.UUID(nil)
+
+	if params, err := c.FormParams(); err == nil {
+		for _, v := range params["affected_area_ids"] {
+			areaID, err := uuid.FromString(v)
+			if err != nil {
+				return Error(c, NewRequestValidationError(ParseFailed, "affected_area_ids"))
+			}
+
+			affectedAreaIDs = append(affectedAreaIDs, areaID)
+		}
+	}
+
 	domaincode := c.FormValue("domain")
 
 	domaintask, err := s.CreateTaskDomainByCode(domaincode, c)
@@ -284,20 +488,67 @@ func (s *TaskServer) SaveTask(c echo.Context) error {
 		return Error(c, err)
 	}
 
+	catalog, err := s.findTaskCatalog(c, domaincode, assetIDPtr)
+	if err != nil {
+		return Error(c, err)
+	}
+
 	task, err := domain.CreateTask(
 		s.TaskService,
+		catalog,
 		c.FormValue("title"),
 		c.FormValue("description"),
 		c.FormValue("priority"),
 		c.FormValue("category"),
 		duePtr,
 		domaintask,
-		assetIDPtr)
+		assetIDPtr,
+		affectedAreaIDs)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if estimatedMinutes != 0 {
+		err = task.ChangeTaskEstimatedMinutes(estimatedMinutes)
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
+	if targetQuantity != 0 {
+		err = task.ChangeTaskTarget(targetQuantity, c.FormValue("target_unit"))
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
+	if c.FormValue("repeat") != "" {
+		recurrence, err := parseTaskRecurrence(c)
+		if err != nil {
+			return Error(c, err)
+		}
+
+		err = task.SetTaskRecurrence(recurrence)
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
+	s.adjustDueDateForBusinessHours(catalog.UID, task)
+
+	// The conflicts are checked before the short code is taken.
+	err = s.ResourceConflictDetector.Detect(task)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// The task without an asset in a farm belongs to the farm of its catalog.
+	err = s.assignFarmShortCode(task, catalog.UID)
 	if err != nil {
 		return Error(c, err)
 	}
 
-	err = <-s.TaskEventRepo.Save(task.UID, 0, task.UncommittedChanges)
+	err = <-s.TaskEventRepo.Save(task.UID, 0, task.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -388,15 +639,28 @@ func (s *TaskServer) CreateTaskDomainByCode(domaincode string, c echo.Context) (
 		}
 
 		return domain.CreateTaskDomainReservoir(s.TaskService, category, materialPtr)
+	case domain.TaskDomainEquipmentCode:
+		// The equipment is the asset of the task.
+		equipmentID := c.FormValue("asset_id")
+		if equipmentID == "" {
+			return nil, NewRequestValidationError(Required, "asset_id")
+		}
+
+		uid, err := uuid.FromString(equipmentID)
+		if err != nil {
+			return domain.TaskDomainEquipment{}, err
+		}
+
+		return domain.CreateTaskDomainEquipment(s.TaskService, c.FormValue("category"), &uid)
 	default:
 		return nil, NewRequestValidationError(InvalidOption, "domain")
 	}
 }
 
 func (s *TaskServer) FindTaskByID(c echo.Context) error {
-	data := make(map[string]storage.TaskRead)
+	data := make(map[string]TaskDetail)
 
-	uid, err := uuid.FromString(c.Param("id"))
+	uid, err := s.parseTaskUID(c, "id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -408,6 +672,15 @@ func (s *TaskServer) FindTaskByID(c echo.Context) error {
 
 	task, ok := result.Result.(storage.TaskRead)
 
+	if ok && task.UID != uid {
+		result = <-s.TaskArchiveQuery.FindByID(uid)
+		if result.Error != nil {
+			return Error(c, result.Error)
+		}
+
+		task, ok = result.Result.(storage.TaskRead)
+	}
+
 	if task.UID != uid {
 		return Error(c, NewRequestValidationError(NotFound, "id"))
 	}
@@ -420,7 +693,17 @@ func (s *TaskServer) FindTaskByID(c echo.Context) error {
 		return Error(c, err)
 	}
 
-	data["task"] = task
+	customFields, err := s.CustomFields.Find(task.UID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	expanded, err := expand(c, taskExpansions(c, s, task))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data["task"] = TaskDetail{TaskRead: task, CustomFields: customFields, Expanded: expanded}
 
 	return c.JSON(http.StatusOK, data)
 }
@@ -519,6 +802,20 @@ func (s *TaskServer) AppendTaskDomainDetails(task *storage.TaskRead) error {
 				MaterialDetailedType: materialQueryResult.DetailedTypeCode,
 			}
 		}
+	case domain.TaskDomainEquipmentCode:
+		equipmentID := task.DomainDetails.(domain.TaskDomainEquipment).EquipmentID
+		if equipmentID != nil {
+			// A deleted equipment keeps its tasks, which only show its ID.
+			details := &storage.TaskDomainDetailedEquipment{EquipmentID: equipmentID}
+
+			equipmentResult := s.TaskService.FindEquipmentByID(*equipmentID)
+			if equipmentQueryResult, ok := equipmentResult.Result.(query.TaskEquipmentResult); ok {
+				details.EquipmentName = equipmentQueryResult.Name
+				details.EquipmentType = equipmentQueryResult.Type
+			}
+
+			task.DomainDetails = details
+		}
 	}
 
 	return nil
@@ -527,7 +824,7 @@ func (s *TaskServer) AppendTaskDomainDetails(task *storage.TaskRead) error {
 func (s *TaskServer) UpdateTask(c echo.Context) error {
 	data := make(map[string]storage.TaskRead)
 
-	uid, err := uuid.FromString(c.Param("id"))
+	uid, err := s.parseTaskUID(c, "id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -546,7 +843,11 @@ func (s *TaskServer) UpdateTask(c echo.Context) error {
 	}
 
 	// Get TaskEvent under Task UID
-	eventQueryResult := <-s.TaskEventQuery.FindAllByTaskID(uid)
+	eventQueryResult := s.findTaskEvents(uid)
+	if eventQueryResult.Error != nil {
+		return Error(c, eventQueryResult.Error)
+	}
+
 	events := eventQueryResult.Result.([]storage.TaskEvent)
 
 	// Build TaskEvents from history
@@ -558,7 +859,9 @@ func (s *TaskServer) UpdateTask(c echo.Context) error {
 	}
 
 	// Save new TaskEvent
-	err = <-s.TaskEventRepo.Save(updatedTask.UID, updatedTask.Version, updatedTask.UncommittedChanges)
+	err = <-s.TaskEventRepo.Save(
+		updatedTask.UID, updatedTask.Version, updatedTask.UncommittedChanges, actor.FromContext(c),
+	)
 	if err != nil {
 		return Error(c, err)
 	}
@@ -604,16 +907,31 @@ func (s *TaskServer) updateTaskAttributes(task *domain.Task, c echo.Context) (*d
 		task.ChangeTaskDueDate(duePtr)
 	}
 
-	// Change Task Priority
+	// The priority and the category are checked against the catalog of the farm of the task.
 	priority := c.FormValue("priority")
+	category := c.FormValue("category")
+
+	if priority == "" && category == "" {
+		return task, nil
+	}
+
+	catalog, err := s.findTaskCatalog(c, task.Domain, task.AssetID)
+	if err != nil {
+		return task, err
+	}
+
+	// Change Task Priority
 	if priority != "" {
-		task.ChangeTaskPriority(priority)
+		if _, err := task.ChangeTaskPriority(catalog, priority); err != nil {
+			return task, err
+		}
 	}
 
 	// Change Task Category & Domain Details
-	category := c.FormValue("category")
 	if category != "" {
-		task.ChangeTaskCategory(category)
+		if _, err := task.ChangeTaskCategory(catalog, category); err != nil {
+			return task, err
+		}
 
 		details, err := s.CreateTaskDomainByCode(task.Domain, c)
 		if err != nil {
@@ -629,7 +947,7 @@ func (s *TaskServer) updateTaskAttributes(task *domain.Task, c echo.Context) (*d
 func (s *TaskServer) CancelTask(c echo.Context) error {
 	data := make(map[string]storage.TaskRead)
 
-	uid, err := uuid.FromString(c.Param("id"))
+	uid, err := s.parseTaskUID(c, "id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -648,7 +966,11 @@ func (s *TaskServer) CancelTask(c echo.Context) error {
 	}
 
 	// Get TaskEvent under Task UID
-	eventQueryResult := <-s.TaskEventQuery.FindAllByTaskID(uid)
+	eventQueryResult := s.findTaskEvents(uid)
+	if eventQueryResult.Error != nil {
+		return Error(c, eventQueryResult.Error)
+	}
+
 	events := eventQueryResult.Result.([]storage.TaskEvent)
 
 	// Build TaskEvents from history
@@ -662,7 +984,9 @@ func (s *TaskServer) CancelTask(c echo.Context) error {
 	updatedTask.CancelTask()
 
 	// Save new TaskEvent
-	err = <-s.TaskEventRepo.Save(updatedTask.UID, updatedTask.Version, updatedTask.UncommittedChanges)
+	err = <-s.TaskEventRepo.Save(
+		updatedTask.UID, updatedTask.Version, updatedTask.UncommittedChanges, actor.FromContext(c),
+	)
 	if err != nil {
 		return Error(c, err)
 	}
@@ -684,7 +1008,7 @@ func (s *TaskServer) CancelTask(c echo.Context) error {
 func (s *TaskServer) CompleteTask(c echo.Context) error {
 	data := make(map[string]storage.TaskRead)
 
-	uid, err := uuid.FromString(c.Param("id"))
+	uid, err := s.parseTaskUID(c, "id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -703,7 +1027,7 @@ func (s *TaskServer) CompleteTask(c echo.Context) error {
 	}
 
 	// Get TaskEvent under Task UID
-	eventQueryResult := <-s.TaskEventQuery.FindAllByTaskID(uid)
+	eventQueryResult := s.findTaskEvents(uid)
 	if eventQueryResult.Error != nil {
 		return Error(c, eventQueryResult.Error)
 	}
@@ -716,21 +1040,67 @@ func (s *TaskServer) CompleteTask(c echo.Context) error {
 	// Build TaskEvents from history
 	task := repository.BuildTaskFromEventHistory(events)
 
+	// Completing a closed task again doesn't create the next task of its series.
+	wasOpen := task.Status == domain.TaskStatusCreated
+
 	updatedTask, err := s.updateTaskAttributes(task, c)
 	if err != nil {
 		return Error(c, err)
 	}
 
-	updatedTask.CompleteTask()
+	labourMinutes := 0
+
+	if value := c.FormValue("labour_minutes"); value != "" {
+		labourMinutes, err = strconv.Atoi(value)
+		if err != nil {
+			return Error(c, NewRequestValidationError(Numeric, "labour_minutes"))
+		}
+	}
+
+	materialQuantity := 0.0
+
+	if value := c.FormValue("material_quantity"); value != "" {
+		materialQuantity, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			return Error(c, NewRequestValidationError(Float, "material_quantity"))
+		}
+	}
+
+	completedQuantity := 0.0
+
+	if value := c.FormValue("completed_quantity"); value != "" {
+		completedQuantity, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			return Error(c, NewRequestValidationError(Float, "completed_quantity"))
+		}
+	}
+
+	// The user is only known when the token validation is enabled.
+	var completedBy *uuid.UUID
+	if userUID, ok := c.Get(farmscope.UserKey).(uuid.UUID); ok {
+		completedBy = &userUID
+	}
+
+	err = updatedTask.CompleteTask(completedBy, labourMinutes, materialQuantity, completedQuantity)
+	if err != nil {
+		return Error(c, err)
+	}
 
 	// Save new TaskEvent
-	err = <-s.TaskEventRepo.Save(updatedTask.UID, updatedTask.Version, updatedTask.UncommittedChanges)
+	err = <-s.TaskEventRepo.Save(
+		updatedTask.UID, updatedTask.Version, updatedTask.UncommittedChanges, actor.FromContext(c),
+	)
 	if err != nil {
 		return Error(c, err)
 	}
 
 	// Trigger Events
 	s.publishUncommittedEvents(updatedTask)
+
+	if wasOpen {
+		s.createNextOccurrence(updatedTask)
+	}
+
 	read := MapTaskToTaskRead(updatedTask)
 
 	if err := s.AppendTaskDomainDetails(read); err != nil {
@@ -745,7 +1115,7 @@ func (s *TaskServer) CompleteTask(c echo.Context) error {
 func (s *TaskServer) SetTaskAsDue(c echo.Context) error {
 	data := make(map[string]storage.TaskRead)
 
-	uid, err := uuid.FromString(c.Param("id"))
+	uid, err := s.parseTaskUID(c, "id")
 	if err != nil {
 		return Error(c, err)
 	}
@@ -764,7 +1134,11 @@ func (s *TaskServer) SetTaskAsDue(c echo.Context) error {
 	}
 
 	// Get TaskEvent under Task UID
-	eventQueryResult := <-s.TaskEventQuery.FindAllByTaskID(uid)
+	eventQueryResult := s.findTaskEvents(uid)
+	if eventQueryResult.Error != nil {
+		return Error(c, eventQueryResult.Error)
+	}
+
 	events := eventQueryResult.Result.([]storage.TaskEvent)
 
 	// Build TaskEvents from history
@@ -773,7 +1147,7 @@ func (s *TaskServer) SetTaskAsDue(c echo.Context) error {
 	task.SetTaskAsDue()
 
 	// Save new TaskEvent
-	err = <-s.TaskEventRepo.Save(task.UID, task.Version, task.UncommittedChanges)
+	err = <-s.TaskEventRepo.Save(task.UID, task.Version, task.UncommittedChanges, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -799,6 +1173,16 @@ func (s *TaskServer) publishUncommittedEvents(entity interface{}) {
 			name := structhelper.GetName(v)
 			s.EventBus.Publish(name, v)
 		}
+	case *domain.TaskTemplate:
+		for _, v := range e.UncommittedChanges {
+			name := structhelper.GetName(v)
+			s.EventBus.Publish(name, v)
+		}
+	case *domain.TaskCatalog:
+		for _, v := range e.UncommittedChanges {
+			name := structhelper.GetName(v)
+			s.EventBus.Publish(name, v)
+		}
 	default:
 	}
 }
@@ -0,0 +1,82 @@
+package server_test
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/asaskevich/EventBus"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"github.com/usetania/tania-core/config"
+	assetsstorage "github.com/usetania/tania-core/src/assets/storage"
+	"github.com/usetania/tania-core/src/customfield"
+	"github.com/usetania/tania-core/src/eventbus"
+	"github.com/usetania/tania-core/src/featureflags"
+	growthstorage "github.com/usetania/tania-core/src/growth/storage"
+	"github.com/usetania/tania-core/src/retention"
+	"github.com/usetania/tania-core/src/tasks/server"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// testApp is the task server on the in memory engine, with its routes under /api/tasks. Its storages can be
+// seeded before the server is started.
+type testApp struct {
+	echo   *echo.Echo
+	server *server.TaskServer
+
+	areaReadStorage     *assetsstorage.AreaReadStorage
+	materialReadStorage *assetsstorage.MaterialReadStorage
+	taskEvents          *storage.TaskEventStorage
+	taskReadStorage     *storage.TaskReadStorage
+}
+
+func newTestApp() *testApp {
+	return &testApp{
+		echo:                echo.New(),
+		areaReadStorage:     assetsstorage.CreateAreaReadStorage(),
+		materialReadStorage: assetsstorage.CreateMaterialReadStorage(),
+		taskEvents:          storage.CreateTaskEventStorage(),
+		taskReadStorage:     storage.CreateTaskReadStorage(),
+	}
+}
+
+// start creates the task server, which checks the due tasks every dueCheckMinutes when it isn't zero.
+func (app *testApp) start(t *testing.T, dueCheckMinutes int) *testApp {
+	t.Helper()
+
+	engine := config.DBInmemory
+	config.Config.TaniaPersistenceEngine = &engine
+	config.Config.TaskDueCheckMinutes = &dueCheckMinutes
+
+	flags, err := featureflags.NewRegistry(featureflags.Flags(), nil)
+	require.Nil(t, err)
+
+	taskServer, err := server.NewTaskServer(
+		nil, nil, eventbus.NewSimpleEventBus(EventBus.New()),
+		growthstorage.CreateCropReadStorage(), app.areaReadStorage, app.materialReadStorage,
+		assetsstorage.CreateReservoirReadStorage(), assetsstorage.CreateEquipmentReadStorage(),
+		app.taskEvents, app.taskReadStorage, storage.CreateTaskArchiveStorage(),
+		storage.CreateTaskTemplateEventStorage(), storage.CreateTaskTemplateReadStorage(),
+		storage.CreateTaskCatalogEventStorage(), retention.CreatePrunedStorage(),
+		customfield.CreateValueStorage(), assetsstorage.CreateCustomFieldDefinitionReadStorage(), flags,
+		func() bool { return false },
+	)
+	require.Nil(t, err)
+
+	app.server = taskServer
+	taskServer.Mount(app.echo.Group("/api/tasks"))
+
+	return app
+}
+
+func (app *testApp) call(method, path string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+
+	rec := httptest.NewRecorder()
+	app.echo.ServeHTTP(rec, req)
+
+	return rec
+}
@@ -2,11 +2,21 @@ package server
 
 import (
 	"errors"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	assetsevents "github.com/usetania/tania-core/src/assets/domain"
+	"github.com/usetania/tania-core/src/eventbus"
+	cropevents "github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/notification"
 	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/query"
 	"github.com/usetania/tania-core/src/tasks/storage"
 )
 
@@ -27,6 +37,8 @@ func (s *TaskServer) SaveToTaskReadModel(event interface{}) error {
 		taskRead.Category = e.Category
 		taskRead.IsDue = e.IsDue
 		taskRead.AssetID = e.AssetID
+		taskRead.CostCentreID = e.CostCentreID
+		taskRead.AffectedAreaIDs = e.AffectedAreaIDs
 	case domain.TaskTitleChanged:
 		// Get TaskRead By UID
 		taskReadFromRepo, err := s.getTaskReadFromID(e.UID)
@@ -62,6 +74,17 @@ func (s *TaskServer) SaveToTaskReadModel(event interface{}) error {
 		}
 
 		taskReadFromRepo.DueDate = e.DueDate
+		taskReadFromRepo.DueDateAdjustedForBusinessHours = false
+		taskRead = taskReadFromRepo
+	case domain.TaskDueDateAdjustedForBusinessHours:
+		// Get TaskRead By UID
+		taskReadFromRepo, err := s.getTaskReadFromID(e.UID)
+		if err != nil {
+			return err
+		}
+
+		taskReadFromRepo.DueDate = e.DueDate
+		taskReadFromRepo.DueDateAdjustedForBusinessHours = true
 		taskRead = taskReadFromRepo
 	case domain.TaskCategoryChanged:
 		// Get TaskRead By UID
@@ -91,6 +114,10 @@ func (s *TaskServer) SaveToTaskReadModel(event interface{}) error {
 
 		taskReadFromRepo.CompletedDate = e.CompletedDate
 		taskReadFromRepo.Status = domain.TaskStatusCompleted
+		taskReadFromRepo.CompletedBy = e.CompletedBy
+		taskReadFromRepo.LabourMinutes = e.LabourMinutes
+		taskReadFromRepo.MaterialQuantity = e.MaterialQuantity
+		taskReadFromRepo.CompletedQuantity = e.CompletedQuantity
 		taskRead = taskReadFromRepo
 
 	case domain.TaskCancelled:
@@ -114,6 +141,88 @@ func (s *TaskServer) SaveToTaskReadModel(event interface{}) error {
 		taskReadFromRepo.IsDue = true
 		taskRead = taskReadFromRepo
 
+	case domain.TaskShortCodeAssigned:
+		// Get TaskRead By UID
+		taskReadFromRepo, err := s.getTaskReadFromID(e.UID)
+		if err != nil {
+			return err
+		}
+
+		taskReadFromRepo.ShortCode = e.ShortCode
+		taskReadFromRepo.ShortCodeFarmUID = e.FarmUID
+		taskRead = taskReadFromRepo
+
+	case domain.TaskEstimatedMinutesChanged:
+		// Get TaskRead By UID
+		taskReadFromRepo, err := s.getTaskReadFromID(e.UID)
+		if err != nil {
+			return err
+		}
+
+		taskReadFromRepo.EstimatedMinutes = e.EstimatedMinutes
+		taskRead = taskReadFromRepo
+
+	case domain.TaskDependenciesChanged:
+		// Get TaskRead By UID
+		taskReadFromRepo, err := s.getTaskReadFromID(e.UID)
+		if err != nil {
+			return err
+		}
+
+		taskReadFromRepo.DependsOn = e.DependsOn
+		taskRead = taskReadFromRepo
+
+	case domain.TaskAreaProgressChanged:
+		// Get TaskRead By UID
+		taskReadFromRepo, err := s.getTaskReadFromID(e.UID)
+		if err != nil {
+			return err
+		}
+
+		if taskReadFromRepo.PerAreaProgress == nil {
+			taskReadFromRepo.PerAreaProgress = map[uuid.UUID]int{}
+		}
+
+		taskReadFromRepo.PerAreaProgress[e.AreaID] = e.Progress
+		taskRead = taskReadFromRepo
+
+	case domain.TaskTargetChanged:
+		// Get TaskRead By UID
+		taskReadFromRepo, err := s.getTaskReadFromID(e.UID)
+		if err != nil {
+			return err
+		}
+
+		taskReadFromRepo.TargetQuantity = e.TargetQuantity
+		taskReadFromRepo.TargetUnit = e.TargetUnit
+		taskRead = taskReadFromRepo
+
+	case domain.TaskProgressRecorded:
+		// Get TaskRead By UID
+		taskReadFromRepo, err := s.getTaskReadFromID(e.UID)
+		if err != nil {
+			return err
+		}
+
+		taskReadFromRepo.CompletedQuantity += e.Quantity
+		taskReadFromRepo.LabourMinutes += e.LabourMinutes
+		taskRead = taskReadFromRepo
+
+	case domain.TaskRecurrenceSet:
+		// Get TaskRead By UID
+		taskReadFromRepo, err := s.getTaskReadFromID(e.UID)
+		if err != nil {
+			return err
+		}
+
+		taskReadFromRepo.Recurrence = e.Recurrence
+
+		if e.RecurrenceOf != nil {
+			taskReadFromRepo.RecurrenceOf = e.RecurrenceOf
+		}
+
+		taskRead = taskReadFromRepo
+
 	default:
 		return errors.New("unknown task event")
 	}
@@ -126,6 +235,363 @@ func (s *TaskServer) SaveToTaskReadModel(event interface{}) error {
 	return nil
 }
 
+// nurseryReminderDaysBefore is how many days before the expected
+// transplant date the nursery reminder task is due.
+const nurseryReminderDaysBefore = 3
+
+// CreateNurseryReminderTask creates a task reminding to transplant
+// the crop out of its nursery area.
+//
+// TODO:
+// We cannot listen to this events without refer to the original struct.
+// This is considered as domain boundary leak.
+func (s *TaskServer) CreateNurseryReminderTask(event interface{}) error {
+	e, ok := event.(cropevents.CropNurseryStageStarted)
+	if !ok {
+		return errors.New("unknown crop event")
+	}
+
+	// When the transplant is less than three days away the reminder is due on the transplant date.
+	dueDate := e.ExpectedTransplantDate.AddDate(0, 0, -nurseryReminderDaysBefore)
+	if dueDate.Before(time.Now()) {
+		dueDate = e.ExpectedTransplantDate
+	}
+
+	if dueDate.Before(time.Now()) {
+		log.Println("Nursery reminder task is skipped because the expected transplant date has passed")
+
+		return nil
+	}
+
+	serviceResult := s.TaskService.FindCropByID(e.CropID)
+	if serviceResult.Error != nil {
+		log.Println(serviceResult.Error)
+
+		return serviceResult.Error
+	}
+
+	crop, ok := serviceResult.Result.(query.TaskCropResult)
+	if !ok {
+		return domain.TaskError{Code: domain.TaskErrorInvalidAssetIDCode}
+	}
+
+	taskDomain, err := domain.CreateTaskDomainCrop(s.TaskService, domain.TaskCategoryCrop, nil, &e.NurseryAreaID)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	task, err := domain.CreateTask(
+		s.TaskService,
+		domain.DefaultTaskCatalog(uuid.Nil),
+		"Transplant crop "+crop.BatchID,
+		"Crop "+crop.BatchID+" is expected to be transplanted out of the nursery on "+
+			e.ExpectedTransplantDate.Format("2006-01-02"),
+		domain.TaskPriorityNormal,
+		domain.TaskCategoryCrop,
+		&dueDate,
+		taskDomain,
+		&e.CropID,
+		nil)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	s.balanceDueDate(uuid.Nil, task)
+	s.adjustDueDateForBusinessHours(uuid.Nil, task)
+
+	err = s.assignShortCode(task)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	err = <-s.TaskEventRepo.Save(task.UID, 0, task.UncommittedChanges, actor.System("nursery_reminder"))
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	eventbus.PublishFromHandler(func() { s.publishUncommittedEvents(task) })
+
+	return nil
+}
+
+// gddHarvestDaysAfter is how many days after the crop reached its
+// growing degree days maturity the harvest task is due.
+const gddHarvestDaysAfter = 3
+
+// CreateGDDHarvestTask creates the task of harvesting a crop
+// which accumulated the growing degree days of its maturity.
+//
+// TODO:
+// We cannot listen to this events without refer to the original struct.
+// This is considered as domain boundary leak.
+func (s *TaskServer) CreateGDDHarvestTask(event interface{}) error {
+	e, ok := event.(cropevents.CropGDDMaturityReached)
+	if !ok {
+		return errors.New("unknown crop event")
+	}
+
+	dueDate := e.ReachedDate.AddDate(0, 0, gddHarvestDaysAfter)
+
+	taskDomain, err := domain.CreateTaskDomainCrop(s.TaskService, domain.TaskCategoryCrop, nil, &e.AreaUID)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	task, err := domain.CreateTask(
+		s.TaskService,
+		domain.DefaultTaskCatalog(uuid.Nil),
+		"Harvest crop "+e.BatchID,
+		"Crop "+e.BatchID+" accumulated "+strconv.FormatFloat(e.AccumulatedGDD, 'f', 1, 64)+
+			" growing degree days, its maturity is at "+strconv.FormatFloat(e.GDDToMaturity, 'f', 1, 64),
+		domain.TaskPriorityUrgent,
+		domain.TaskCategoryCrop,
+		&dueDate,
+		taskDomain,
+		&e.UID,
+		nil)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	s.balanceDueDate(e.FarmUID, task)
+	s.adjustDueDateForBusinessHours(e.FarmUID, task)
+
+	err = s.assignShortCode(task)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	err = <-s.TaskEventRepo.Save(task.UID, 0, task.UncommittedChanges, actor.System("gdd_harvest_prediction"))
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	eventbus.PublishFromHandler(func() { s.publishUncommittedEvents(task) })
+
+	return nil
+}
+
+// CreateLowGerminationTask creates the task of assessing the causes of a low germination, when the crop may
+// need replanting.
+func (s *TaskServer) CreateLowGerminationTask(event interface{}) error {
+	e, ok := event.(cropevents.LowGerminationAlert)
+	if !ok {
+		return errors.New("unknown crop event")
+	}
+
+	taskDomain, err := domain.CreateTaskDomainCrop(s.TaskService, domain.TaskCategoryCrop, nil, &e.AreaUID)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	task, err := domain.CreateTask(
+		s.TaskService,
+		domain.DefaultTaskCatalog(uuid.Nil),
+		"Assess the low germination of crop "+e.BatchID,
+		"Crop "+e.BatchID+" germinated at "+strconv.FormatFloat(e.ActualGerminationRate, 'f', 1, 64)+
+			"% on "+e.CheckedAt.Format("2006-01-02")+", its seed lot is expected at "+
+			strconv.FormatFloat(e.ExpectedGerminationRate, 'f', 1, 64)+"%. Check the seeds, the temperature, "+
+			"the moisture and the depth of sowing, and consider replanting",
+		domain.TaskPriorityUrgent,
+		domain.TaskCategoryCrop,
+		nil,
+		taskDomain,
+		&e.UID,
+		nil)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	err = s.assignShortCode(task)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	err = <-s.TaskEventRepo.Save(task.UID, 0, task.UncommittedChanges, actor.System("germination_check"))
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	eventbus.PublishFromHandler(func() { s.publishUncommittedEvents(task) })
+
+	return nil
+}
+
+// CreateInputScheduleTask creates the task of applying a planned input on a crop.
+// It's called by the growth scheduler when the planned date arrives.
+func (s *TaskServer) CreateInputScheduleTask(schedule cropevents.CropInputSchedule) (uuid.UUID, error) {
+	// The ID of the task is derived from the schedule. A check retried after the task was saved
+	// but not the schedule finds the task instead of creating another one.
+	uid := uuid.NewV5(schedule.UID, "input_schedule_task")
+
+	eventResult := <-s.TaskEventQuery.FindAllByTaskID(uid)
+	if eventResult.Error != nil {
+		return uuid.UUID{}, eventResult.Error
+	}
+
+	if events, ok := eventResult.Result.([]storage.TaskEvent); ok && len(events) > 0 {
+		return uid, nil
+	}
+
+	serviceResult := s.TaskService.FindCropByID(schedule.CropID)
+	if serviceResult.Error != nil {
+		return uuid.UUID{}, serviceResult.Error
+	}
+
+	crop, ok := serviceResult.Result.(query.TaskCropResult)
+	if !ok {
+		return uuid.UUID{}, domain.TaskError{Code: domain.TaskErrorInvalidAssetIDCode}
+	}
+
+	serviceResult = s.TaskService.FindMaterialByID(schedule.MaterialID)
+	if serviceResult.Error != nil {
+		return uuid.UUID{}, serviceResult.Error
+	}
+
+	material, ok := serviceResult.Result.(query.TaskMaterialResult)
+	if !ok {
+		return uuid.UUID{}, domain.TaskError{Code: domain.TaskErrorInvalidAssetIDCode}
+	}
+
+	// The task is due at the end of the planned day. A late check leaves the task without due date.
+	// The day was planned by the user, so the task isn't balanced with the workload
+	// nor moved out of the closed hours of the farm.
+	var dueDate *time.Time
+
+	endOfDay := schedule.PlannedDate.AddDate(0, 0, 1).Add(-time.Second)
+	if endOfDay.After(time.Now()) {
+		dueDate = &endOfDay
+	}
+
+	taskDomain, err := domain.CreateTaskDomainCrop(s.TaskService, domain.TaskCategoryCrop, &schedule.MaterialID, nil)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	task, err := domain.CreateTaskWithUID(
+		uid,
+		s.TaskService,
+		domain.DefaultTaskCatalog(uuid.Nil),
+		"Apply "+material.Name+" to crop "+crop.BatchID,
+		"Apply "+strconv.FormatFloat(schedule.Quantity, 'f', -1, 64)+" "+schedule.Unit+" of "+material.Name+
+			" by "+strings.ToLower(strings.ReplaceAll(schedule.ApplicationMethod, "_", " "))+
+			", planned on "+schedule.PlannedDate.Format("2006-01-02"),
+		domain.TaskPriorityNormal,
+		domain.TaskCategoryCrop,
+		dueDate,
+		taskDomain,
+		&schedule.CropID,
+		nil)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	err = s.assignShortCode(task)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	err = <-s.TaskEventRepo.Save(task.UID, 0, task.UncommittedChanges, actor.System("input_scheduler"))
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	s.publishUncommittedEvents(task)
+
+	return task.UID, nil
+}
+
+// CreateDispatchTask creates the task of loading the harvests of a dispatch on its truck.
+// It's called by the growth module when the dispatch is scheduled.
+func (s *TaskServer) CreateDispatchTask(
+	schedule cropevents.DispatchSchedule, by *actor.Actor,
+) (uuid.UUID, error) {
+	batchIDs := []string{}
+
+	for _, v := range schedule.HarvestIDs {
+		serviceResult := s.TaskService.FindCropByID(v)
+		if serviceResult.Error != nil {
+			return uuid.UUID{}, serviceResult.Error
+		}
+
+		crop, ok := serviceResult.Result.(query.TaskCropResult)
+		if !ok {
+			return uuid.UUID{}, domain.TaskError{Code: domain.TaskErrorInvalidAssetIDCode}
+		}
+
+		batchIDs = append(batchIDs, crop.BatchID)
+	}
+
+	// The task is due at the pickup, a pickup in the past leaves the task without due date.
+	// The pickup was agreed with the driver, so the task isn't balanced with the workload
+	// nor moved out of the closed hours of the farm.
+	var dueDate *time.Time
+	if schedule.PickupTime.After(time.Now()) {
+		dueDate = &schedule.PickupTime
+	}
+
+	taskDomain, err := domain.CreateTaskDomainGeneral()
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	task, err := domain.CreateTask(
+		s.TaskService,
+		domain.DefaultTaskCatalog(uuid.Nil),
+		"Dispatch harvest on vehicle "+schedule.VehicleID,
+		"Load the harvest of "+strings.Join(batchIDs, ", ")+" for "+schedule.DriverName+
+			", picking up on "+schedule.PickupTime.Format("2006-01-02 15:04")+
+			" to deliver to "+schedule.DestinationAddress+
+			" by "+schedule.EstimatedDeliveryTime.Format("2006-01-02 15:04"),
+		domain.TaskPriorityNormal,
+		domain.TaskCategoryGeneral,
+		dueDate,
+		taskDomain,
+		nil,
+		nil)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	err = s.assignShortCode(task)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	err = <-s.TaskEventRepo.Save(task.UID, 0, task.UncommittedChanges, by)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	s.publishUncommittedEvents(task)
+
+	return task.UID, nil
+}
+
 func (s *TaskServer) getTaskReadFromID(uid uuid.UUID) (*storage.TaskRead, error) {
 	readResult := <-s.TaskReadQuery.FindByID(uid)
 
@@ -141,3 +607,223 @@ func (s *TaskServer) getTaskReadFromID(uid uuid.UUID) (*storage.TaskRead, error)
 
 	return &taskReadFromRepo, nil
 }
+
+// CreateCertificationRenewalTask creates the task of renewing a certification before it expires.
+func (s *TaskServer) CreateCertificationRenewalTask(event interface{}) error {
+	e, ok := event.(assetsevents.CertificationRenewalDue)
+	if !ok {
+		return errors.New("unknown farm certification event")
+	}
+
+	var dueDate *time.Time
+	if e.ExpiryDate.After(time.Now()) {
+		dueDate = &e.ExpiryDate
+	}
+
+	typeLabel := e.CertificationType
+
+	for _, v := range assetsevents.FindAllCertificationTypes() {
+		if v.Code == e.CertificationType {
+			typeLabel = v.Label
+		}
+	}
+
+	taskDomain, err := domain.CreateTaskDomainGeneral()
+	if err != nil {
+		return err
+	}
+
+	task, err := domain.CreateTask(
+		s.TaskService,
+		domain.DefaultTaskCatalog(uuid.Nil),
+		"Renew "+typeLabel+" certification",
+		"Certificate "+e.CertificateNumber+" from "+e.CertifyingBody+" expires on "+
+			e.ExpiryDate.Format("2006-01-02"),
+		domain.TaskPriorityUrgent,
+		domain.TaskCategoryGeneral,
+		dueDate,
+		taskDomain,
+		nil,
+		nil)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	s.balanceDueDate(e.FarmID, task)
+	s.adjustDueDateForBusinessHours(e.FarmID, task)
+
+	err = s.assignShortCode(task)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	err = <-s.TaskEventRepo.Save(task.UID, 0, task.UncommittedChanges, actor.System("certification_renewal"))
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	eventbus.PublishFromHandler(func() { s.publishUncommittedEvents(task) })
+
+	return nil
+}
+
+// CreateMicroclimateAnomalyTask creates the urgent task of inspecting the equipment of an area whose temperature
+// deviated from its last 24 hours, unless one is still open. The task has no due date to fall due at, so its incident is opened right away
+// when the urgent tasks are escalated, and resolved when it's completed.
+func (s *TaskServer) CreateMicroclimateAnomalyTask(event interface{}) error {
+	e, ok := event.(cropevents.MicroclimateAnomalyDetected)
+	if !ok {
+		return errors.New("unknown microclimate event")
+	}
+
+	areaName := e.AreaName
+	if areaName == "" {
+		areaName = "area " + e.AreaUID.String()
+	}
+
+	title := "Inspect the equipment of " + areaName
+
+	// The samples following a failure keep deviating, one inspection of the area is open at a time.
+	result := <-s.TaskReadQuery.FindByAreaID(e.AreaUID)
+	if result.Error != nil {
+		log.Println(result.Error)
+
+		return result.Error
+	}
+
+	tasks, ok := result.Result.([]storage.TaskRead)
+	if !ok {
+		return errors.New("internal server error. error type assertion")
+	}
+
+	for _, v := range tasks {
+		if v.Title == title && v.Status == domain.TaskStatusCreated {
+			return nil
+		}
+	}
+
+	taskDomain, err := domain.CreateTaskDomainArea(s.TaskService, domain.TaskCategoryArea, nil)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	task, err := domain.CreateTask(
+		s.TaskService,
+		domain.DefaultTaskCatalog(uuid.Nil),
+		title,
+		"The temperature of "+areaName+" was "+strconv.FormatFloat(e.Temperature, 'f', 1, 64)+"°C on "+
+			e.RecordedDate.Format(time.RFC3339)+", a Z-score of "+strconv.FormatFloat(e.ZScore, 'f', 1, 64)+
+			" against the mean of "+strconv.FormatFloat(e.Mean, 'f', 1, 64)+"°C of the last 24 hours",
+		domain.TaskPriorityUrgent,
+		domain.TaskCategoryArea,
+		nil,
+		taskDomain,
+		&e.AreaUID,
+		nil)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	err = s.assignShortCode(task)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	err = <-s.TaskEventRepo.Save(task.UID, 0, task.UncommittedChanges, actor.System("microclimate_anomaly"))
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	eventbus.PublishFromHandler(func() { s.publishUncommittedEvents(task) })
+
+	if s.Escalator != nil {
+		taskNotification := notification.TaskNotification{
+			Event:     notification.TaskNotificationCreated,
+			TaskUID:   task.UID,
+			ShortCode: task.ShortCode,
+			Title:     task.Title,
+			Priority:  task.Priority,
+			Category:  task.Category,
+		}
+
+		go func() {
+			if err := s.Escalator.Trigger(taskNotification); err != nil {
+				log.Println("Escalation of the task", task.UID, "failed.", err)
+			}
+		}()
+	}
+
+	return nil
+}
+
+// CreateEquipmentMaintenanceTask creates the task of the next maintenance of an equipment.
+func (s *TaskServer) CreateEquipmentMaintenanceTask(event interface{}) error {
+	e, ok := event.(assetsevents.EquipmentMaintenanceDue)
+	if !ok {
+		return errors.New("unknown equipment event")
+	}
+
+	var dueDate *time.Time
+	if e.DueDate.After(time.Now()) {
+		dueDate = &e.DueDate
+	}
+
+	taskDomain, err := domain.CreateTaskDomainEquipment(s.TaskService, domain.TaskCategoryGeneral, &e.UID)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	task, err := domain.CreateTask(
+		s.TaskService,
+		domain.DefaultTaskCatalog(uuid.Nil),
+		"Maintain "+e.Name,
+		"Scheduled maintenance of "+e.Name+" on "+e.DueDate.Format("2006-01-02"),
+		domain.TaskPriorityNormal,
+		domain.TaskCategoryGeneral,
+		dueDate,
+		taskDomain,
+		&e.UID,
+		nil)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	s.balanceDueDate(e.FarmUID, task)
+	s.adjustDueDateForBusinessHours(e.FarmUID, task)
+
+	err = s.assignShortCode(task)
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	err = <-s.TaskEventRepo.Save(task.UID, 0, task.UncommittedChanges, actor.System("equipment_maintenance"))
+	if err != nil {
+		log.Println(err)
+
+		return err
+	}
+
+	eventbus.PublishFromHandler(func() { s.publishUncommittedEvents(task) })
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// SyncTask applies the edit made offline by a device from the `base_version` of the task.
+// Only the submitted fields count as edited. The edits synced by the other devices in the meantime
+// are merged when they changed other fields, otherwise the conflict is returned with 409 so the client
+// can resolve it and resubmit the same base version with `resolved=true`.
+func (s *TaskServer) SyncTask(c echo.Context) error {
+	uid, err := s.parseTaskUID(c, "id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	readResult := <-s.TaskReadQuery.FindByID(uid)
+
+	taskRead, ok := readResult.Result.(storage.TaskRead)
+
+	if taskRead.UID != uid {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	if !ok {
+		return echo.NewHTTPError(http.StatusBadRequest, "Internal server error")
+	}
+
+	value := c.FormValue("base_version")
+	if value == "" {
+		return Error(c, NewRequestValidationError(Required, "base_version"))
+	}
+
+	baseVersion, err := strconv.Atoi(value)
+	if err != nil {
+		return Error(c, NewRequestValidationError(Numeric, "base_version"))
+	}
+
+	eventQueryResult := s.findTaskEvents(uid)
+	if eventQueryResult.Error != nil {
+		return Error(c, eventQueryResult.Error)
+	}
+
+	events, ok := eventQueryResult.Result.([]storage.TaskEvent)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusBadRequest, "Internal server error"))
+	}
+
+	if baseVersion < 1 || baseVersion > len(events) {
+		return Error(c, NewRequestValidationError(InvalidOption, "base_version"))
+	}
+
+	base := repository.BuildTaskFromEventHistory(events[:baseVersion]).Fields()
+	task := repository.BuildTaskFromEventHistory(events)
+
+	mine, err := syncedTaskFields(base, c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	catalog, err := s.findTaskCatalog(c, task.Domain, task.AssetID)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	conflict, err := task.SyncEdit(catalog, base, baseVersion, mine, c.FormValue("resolved") == "true")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = <-s.TaskEventRepo.Save(task.UID, task.Version, task.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(task)
+
+	if conflict != nil {
+		return c.JSON(http.StatusConflict, map[string]domain.TaskEditConflict{"conflict": *conflict})
+	}
+
+	read := MapTaskToTaskRead(task)
+
+	if err := s.AppendTaskDomainDetails(read); err != nil {
+		return Error(c, err)
+	}
+
+	data := make(map[string]interface{})
+	data["data"] = *read
+	data["version"] = task.Version + len(task.UncommittedChanges)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// syncedTaskFields overlays the submitted fields on the base version.
+func syncedTaskFields(base domain.TaskFields, c echo.Context) (domain.TaskFields, error) {
+	fields := base
+
+	if title := c.FormValue("title"); title != "" {
+		fields.Title = title
+	}
+
+	if description := c.FormValue("description"); description != "" {
+		fields.Description = description
+	}
+
+	if formDate := c.FormValue("due_date"); formDate != "" {
+		dueDate, err := time.Parse(time.RFC3339Nano, formDate)
+		if err != nil {
+			return domain.TaskFields{}, NewRequestValidationError(ParseFailed, "due_date")
+		}
+
+		fields.DueDate = &dueDate
+	}
+
+	if priority := c.FormValue("priority"); priority != "" {
+		fields.Priority = priority
+	}
+
+	return fields, nil
+}
+
+// FindTaskHistory lists the events of the task, with the current version to sync the offline edits from.
+func (s *TaskServer) FindTaskHistory(c echo.Context) error {
+	uid, err := s.parseTaskUID(c, "id")
+	if err != nil {
+		return Error(c, err)
+	}
+
+	eventQueryResult := s.findTaskEvents(uid)
+	if eventQueryResult.Error != nil {
+		return Error(c, eventQueryResult.Error)
+	}
+
+	events, ok := eventQueryResult.Result.([]storage.TaskEvent)
+	if !ok {
+		return Error(c, echo.NewHTTPError(http.StatusBadRequest, "Internal server error"))
+	}
+
+	if len(events) == 0 {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	data := make(map[string]interface{})
+	data["data"] = MapToTaskHistory(events)
+	data["version"] = len(events)
+
+	return c.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,273 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/featureflags"
+	"github.com/usetania/tania-core/src/tasks/domain"
+	"github.com/usetania/tania-core/src/tasks/repository"
+	"github.com/usetania/tania-core/src/tasks/storage"
+)
+
+// MountTaskTemplates defines the task template endpoints with their handlers.
+func (s *TaskServer) MountTaskTemplates(g *echo.Group) {
+	templates := s.Flags.Require(featureflags.TaskTemplates)
+
+	g.POST("", s.validatable((*TaskServer).SaveTaskTemplate), templates)
+
+	g.GET("", s.FindAllTaskTemplates, templates)
+	g.GET("/:id", s.FindTaskTemplateByID, templates)
+	g.PUT("/:id", s.validatable((*TaskServer).UpdateTaskTemplate), templates)
+	g.GET("/:id/effective-tasks", s.FindEffectiveTasks, templates)
+}
+
+// SaveTaskTemplate is a TaskServer's handler to save new TaskTemplate.
+// The items are sent as a JSON array in the items form field.
+func (s *TaskServer) SaveTaskTemplate(c echo.Context) error {
+	data := make(map[string]storage.TaskTemplateRead)
+
+	parentTemplateID, err := parseParentTemplateID(c.FormValue("parent_template_id"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	items, err := parseTaskTemplateItems(c.FormValue("items"))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	template, err := domain.CreateTaskTemplate(
+		s.TaskTemplateService,
+		c.FormValue("name"),
+		parentTemplateID,
+		items)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	err = <-s.TaskTemplateEventRepo.Save(template.UID, 0, template.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(template)
+
+	data["data"] = *MapTaskTemplateToTaskTemplateRead(template)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *TaskServer) FindAllTaskTemplates(c echo.Context) error {
+	data := make(map[string][]storage.TaskTemplateRead)
+
+	result := <-s.TaskTemplateReadQuery.FindAll()
+	if result.Error != nil {
+		return Error(c, result.Error)
+	}
+
+	templates, ok := result.Result.([]storage.TaskTemplateRead)
+	if !ok {
+		return echo.NewHTTPError(http.StatusBadRequest, "Internal server error")
+	}
+
+	data["data"] = templates
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *TaskServer) FindTaskTemplateByID(c echo.Context) error {
+	data := make(map[string]storage.TaskTemplateRead)
+
+	template, err := s.findTaskTemplateFromHistory(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data["data"] = *MapTaskTemplateToTaskTemplateRead(template)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// UpdateTaskTemplate changes the fields sent in the request.
+// An empty parent_template_id detaches the template from its parent.
+func (s *TaskServer) UpdateTaskTemplate(c echo.Context) error {
+	data := make(map[string]storage.TaskTemplateRead)
+
+	template, err := s.findTaskTemplateFromHistory(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	params, err := c.FormParams()
+	if err != nil {
+		return Error(c, err)
+	}
+
+	if name := c.FormValue("name"); name != "" {
+		if err := template.ChangeName(name); err != nil {
+			return Error(c, err)
+		}
+	}
+
+	if _, ok := params["parent_template_id"]; ok {
+		parentTemplateID, err := parseParentTemplateID(c.FormValue("parent_template_id"))
+		if err != nil {
+			return Error(c, err)
+		}
+
+		if err := template.ChangeParentTemplate(s.TaskTemplateService, parentTemplateID); err != nil {
+			return Error(c, err)
+		}
+	}
+
+	if _, ok := params["items"]; ok {
+		items, err := parseTaskTemplateItems(c.FormValue("items"))
+		if err != nil {
+			return Error(c, err)
+		}
+
+		if err := template.ChangeItems(items); err != nil {
+			return Error(c, err)
+		}
+	}
+
+	err = <-s.TaskTemplateEventRepo.Save(
+		template.UID, template.Version, template.UncommittedChanges, actor.FromContext(c),
+	)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	s.publishUncommittedEvents(template)
+
+	data["data"] = *MapTaskTemplateToTaskTemplateRead(template)
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// FindEffectiveTasks returns the tasks of the template merged with the ones it inherits.
+func (s *TaskServer) FindEffectiveTasks(c echo.Context) error {
+	data := make(map[string][]domain.TaskTemplateItem)
+
+	template, err := s.findTaskTemplateFromHistory(c)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	items, err := template.EffectiveItems(s.TaskTemplateService)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	data["data"] = items
+
+	return c.JSON(http.StatusOK, data)
+}
+
+func (s *TaskServer) findTaskTemplateFromHistory(c echo.Context) (*domain.TaskTemplate, error) {
+	uid, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return nil, NewRequestValidationError(ParseFailed, "id")
+	}
+
+	eventQueryResult := <-s.TaskTemplateEventQuery.FindAllByTaskTemplateID(uid)
+	if eventQueryResult.Error != nil {
+		return nil, eventQueryResult.Error
+	}
+
+	events, ok := eventQueryResult.Result.([]storage.TaskTemplateEvent)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Internal server error")
+	}
+
+	if len(events) == 0 {
+		return nil, NewRequestValidationError(NotFound, "id")
+	}
+
+	return repository.BuildTaskTemplateFromEventHistory(events), nil
+}
+
+func parseParentTemplateID(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	uid, err := uuid.FromString(value)
+	if err != nil {
+		return nil, NewRequestValidationError(ParseFailed, "parent_template_id")
+	}
+
+	return &uid, nil
+}
+
+func parseTaskTemplateItems(value string) ([]domain.TaskTemplateItem, error) {
+	items := []domain.TaskTemplateItem{}
+
+	if value == "" {
+		return items, nil
+	}
+
+	err := json.Unmarshal([]byte(value), &items)
+	if err != nil {
+		return nil, NewRequestValidationError(ParseFailed, "items")
+	}
+
+	return items, nil
+}
+
+func (s *TaskServer) SaveToTaskTemplateReadModel(event interface{}) error {
+	templateRead := &storage.TaskTemplateRead{}
+
+	switch e := event.(type) {
+	case domain.TaskTemplateCreated:
+		templateRead.UID = e.UID
+		templateRead.Name = e.Name
+		templateRead.ParentTemplateID = e.ParentTemplateID
+		templateRead.Items = e.Items
+		templateRead.CreatedDate = e.CreatedDate
+	case domain.TaskTemplateNameChanged:
+		templateReadFromRepo, err := s.getTaskTemplateReadFromID(e.UID)
+		if err != nil {
+			return err
+		}
+
+		templateReadFromRepo.Name = e.Name
+		templateRead = templateReadFromRepo
+	case domain.TaskTemplateParentChanged:
+		templateReadFromRepo, err := s.getTaskTemplateReadFromID(e.UID)
+		if err != nil {
+			return err
+		}
+
+		templateReadFromRepo.ParentTemplateID = e.ParentTemplateID
+		templateRead = templateReadFromRepo
+	case domain.TaskTemplateItemsChanged:
+		templateReadFromRepo, err := s.getTaskTemplateReadFromID(e.UID)
+		if err != nil {
+			return err
+		}
+
+		templateReadFromRepo.Items = e.Items
+		templateRead = templateReadFromRepo
+	}
+
+	return <-s.TaskTemplateReadRepo.Save(templateRead)
+}
+
+func (s *TaskServer) getTaskTemplateReadFromID(uid uuid.UUID) (*storage.TaskTemplateRead, error) {
+	readResult := <-s.TaskTemplateReadQuery.FindByID(uid)
+	if readResult.Error != nil {
+		return nil, readResult.Error
+	}
+
+	templateRead, ok := readResult.Result.(storage.TaskTemplateRead)
+	if !ok || templateRead.UID != uid {
+		return nil, domain.TaskError{Code: domain.TaskTemplateErrorNotFoundCode}
+	}
+
+	return &templateRead, nil
+}
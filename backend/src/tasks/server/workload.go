@@ -0,0 +1,46 @@
+package server
+
+import (
+	"log"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/tasks/domain"
+)
+
+// WorkloadBalancer spreads the generated tasks over the days before their due date,
+// so the effort due on a day stays within the daily budget of the farm.
+type WorkloadBalancer interface {
+	// BalanceDueDate returns the due date of the new task. The nil farm UID is the farm of the task's asset.
+	BalanceDueDate(farmUID uuid.UUID, task *domain.Task) (*time.Time, error)
+}
+
+// StartWorkloadBalancing balances the due dates of the tasks generated from the events.
+// The due dates set by the users are never moved.
+func (s *TaskServer) StartWorkloadBalancing(balancer WorkloadBalancer) {
+	s.WorkloadBalancer = balancer
+}
+
+// balanceDueDate moves the due date of the generated task before it's saved, so the move is in the history
+// of the task. The task keeps its due date when the balancing fails.
+func (s *TaskServer) balanceDueDate(farmUID uuid.UUID, task *domain.Task) {
+	if s.WorkloadBalancer == nil || task.DueDate == nil {
+		return
+	}
+
+	dueDate, err := s.WorkloadBalancer.BalanceDueDate(farmUID, task)
+	if err != nil {
+		log.Println(err)
+
+		return
+	}
+
+	if dueDate == nil || dueDate.Equal(*task.DueDate) {
+		return
+	}
+
+	_, err = task.ChangeTaskDueDate(dueDate)
+	if err != nil {
+		log.Println(err)
+	}
+}
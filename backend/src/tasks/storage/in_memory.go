@@ -26,6 +26,9 @@ func CreateTaskEventStorage() *TaskEventStorage {
 type TaskReadStorage struct {
 	Lock        *deadlock.RWMutex
 	TaskReadMap map[uuid.UUID]TaskRead
+
+	// The UIDs of the tasks covering each area.
+	AreaTaskMap map[uuid.UUID]map[uuid.UUID]bool
 }
 
 func CreateTaskReadStorage() *TaskReadStorage {
@@ -35,5 +38,69 @@ func CreateTaskReadStorage() *TaskReadStorage {
 		log.Println("TASK READ STORAGE DEADLOCK!")
 	}
 
-	return &TaskReadStorage{TaskReadMap: make(map[uuid.UUID]TaskRead), Lock: &rwMutex}
+	return &TaskReadStorage{
+		TaskReadMap: make(map[uuid.UUID]TaskRead),
+		AreaTaskMap: make(map[uuid.UUID]map[uuid.UUID]bool),
+		Lock:        &rwMutex,
+	}
+}
+
+type TaskArchiveStorage struct {
+	Lock           *deadlock.RWMutex
+	TaskArchiveMap map[uuid.UUID]TaskRead
+}
+
+func CreateTaskArchiveStorage() *TaskArchiveStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("TASK ARCHIVE STORAGE DEADLOCK!")
+	}
+
+	return &TaskArchiveStorage{TaskArchiveMap: make(map[uuid.UUID]TaskRead), Lock: &rwMutex}
+}
+
+type TaskTemplateEventStorage struct {
+	Lock               *deadlock.RWMutex
+	TaskTemplateEvents []TaskTemplateEvent
+}
+
+func CreateTaskTemplateEventStorage() *TaskTemplateEventStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("TASK TEMPLATE EVENT STORAGE DEADLOCK!")
+	}
+
+	return &TaskTemplateEventStorage{Lock: &rwMutex}
+}
+
+type TaskTemplateReadStorage struct {
+	Lock                *deadlock.RWMutex
+	TaskTemplateReadMap map[uuid.UUID]TaskTemplateRead
+}
+
+func CreateTaskTemplateReadStorage() *TaskTemplateReadStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("TASK TEMPLATE READ STORAGE DEADLOCK!")
+	}
+
+	return &TaskTemplateReadStorage{TaskTemplateReadMap: make(map[uuid.UUID]TaskTemplateRead), Lock: &rwMutex}
+}
+
+type TaskCatalogEventStorage struct {
+	Lock              *deadlock.RWMutex
+	TaskCatalogEvents []TaskCatalogEvent
+}
+
+func CreateTaskCatalogEventStorage() *TaskCatalogEventStorage {
+	rwMutex := deadlock.RWMutex{}
+	deadlock.Opts.DeadlockTimeout = time.Second * 10
+	deadlock.Opts.OnPotentialDeadlock = func() {
+		log.Println("TASK CATALOG EVENT STORAGE DEADLOCK!")
+	}
+
+	return &TaskCatalogEventStorage{Lock: &rwMutex}
 }
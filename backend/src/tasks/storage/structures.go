@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/tasks/domain"
 )
 
@@ -12,12 +13,14 @@ type TaskEvent struct {
 	Version     int
 	CreatedDate time.Time
 	Event       interface{}
+	Actor       *actor.Actor
 }
 
 type TaskRead struct {
 	Title         string            `json:"title"`
 	UID           uuid.UUID         `json:"uid"`
-	Description   string            `json:"description"`
+	ShortCode     string            `json:"short_code"`
+	Description   string            `json:"description" redact:"if:role!=Owner"`
 	CreatedDate   time.Time         `json:"created_date"`
 	DueDate       *time.Time        `json:"due_date,omitempty"`
 	CompletedDate *time.Time        `json:"completed_date"`
@@ -29,6 +32,63 @@ type TaskRead struct {
 	Category      string            `json:"category"`
 	IsDue         bool              `json:"is_due"`
 	AssetID       *uuid.UUID        `json:"asset_id"`
+
+	CostCentreID     *uuid.UUID `json:"cost_centre_id"`
+	CompletedBy      *uuid.UUID `json:"completed_by"`
+	LabourMinutes    int        `json:"labour_minutes"`
+	MaterialQuantity float64    `json:"material_quantity"`
+
+	// Only set on the archived tasks, which are kept out of TASK_READ.
+	ArchivedDate *time.Time `json:"archived_date,omitempty"`
+
+	EstimatedMinutes int `json:"estimated_minutes"`
+
+	DependsOn []uuid.UUID `json:"depends_on"`
+
+	DueDateAdjustedForBusinessHours bool `json:"due_date_adjusted_for_business_hours"`
+
+	AffectedAreaIDs []uuid.UUID       `json:"affected_area_ids"`
+	PerAreaProgress map[uuid.UUID]int `json:"per_area_progress"`
+
+	TargetQuantity    float64 `json:"target_quantity"`
+	TargetUnit        string  `json:"target_unit"`
+	CompletedQuantity float64 `json:"completed_quantity"`
+
+	Recurrence   *domain.TaskRecurrence `json:"recurrence"`
+	RecurrenceOf *uuid.UUID             `json:"recurrence_of"`
+
+	// The farm of the task, which its short code is numbered in, uuid.Nil for the tasks without a farm.
+	ShortCodeFarmUID uuid.UUID `json:"short_code_farm_id"`
+}
+
+// AreaIDs are the areas the task covers, its affected areas or else the single area of its domain.
+func (t TaskRead) AreaIDs() []uuid.UUID {
+	return domain.TaskAreaIDs(t.AffectedAreaIDs, t.DomainDetails, t.AssetID)
+}
+
+type TaskTemplateEvent struct {
+	TaskTemplateUID uuid.UUID
+	Version         int
+	CreatedDate     time.Time
+	Event           interface{}
+	Actor           *actor.Actor
+}
+
+// TaskCatalogEvent is an event of the task catalog of a farm, the catalog is built from its events.
+type TaskCatalogEvent struct {
+	FarmUID     uuid.UUID
+	Version     int
+	CreatedDate time.Time
+	Event       interface{}
+	Actor       *actor.Actor
+}
+
+type TaskTemplateRead struct {
+	UID              uuid.UUID                 `json:"uid"`
+	Name             string                    `json:"name"`
+	ParentTemplateID *uuid.UUID                `json:"parent_template_id"`
+	Items            []domain.TaskTemplateItem `json:"items"`
+	CreatedDate      time.Time                 `json:"created_date"`
 }
 
 // Implements TaskDomain interface in domain
@@ -81,3 +141,13 @@ type TaskDomainDetailedReservoir struct {
 func (TaskDomainDetailedReservoir) Code() string {
 	return domain.TaskDomainCropCode
 }
+
+type TaskDomainDetailedEquipment struct {
+	EquipmentID   *uuid.UUID `json:"equipment_id"`
+	EquipmentName string     `json:"equipment_name"`
+	EquipmentType string     `json:"equipment_type"`
+}
+
+func (TaskDomainDetailedEquipment) Code() string {
+	return domain.TaskDomainEquipmentCode
+}
@@ -0,0 +1,25 @@
+package units
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Server struct{}
+
+func NewServer() *Server {
+	return &Server{}
+}
+
+// Mount defines the units endpoint with its handler.
+func (s *Server) Mount(g *echo.Group) {
+	g.GET("", s.GetUnits)
+}
+
+func (s *Server) GetUnits(c echo.Context) error {
+	data := make(map[string]Catalog)
+	data["data"] = NewCatalog()
+
+	return c.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,168 @@
+// Package units is the registry of the units of measure the server accepts. The domains validate
+// with it and GET /api/units serves it, so the clients never list a unit the server refuses.
+package units
+
+// The dimensions of the units. A quantity converts to the canonical unit of its dimension,
+// except the packages, like packets or bags, whose content isn't known.
+const (
+	DimensionArea    = "AREA"    // in square meters
+	DimensionMass    = "MASS"    // in grams
+	DimensionVolume  = "VOLUME"  // in cubic metres
+	DimensionCount   = "COUNT"   // in pieces
+	DimensionPackage = "PACKAGE" // not convertible
+)
+
+type Unit struct {
+	Dimension string `json:"dimension"`
+	Code      string `json:"code"`
+	Label     string `json:"label"`
+
+	// Factor multiplies a quantity in the unit to get it in the canonical unit of the dimension.
+	// It's nil for the units which can't be converted.
+	Factor *float64 `json:"conversion_factor"`
+}
+
+// Registry is a list of units, in the order the clients show them.
+type Registry []Unit
+
+// Find returns the unit of the code, false when the registry hasn't it.
+func (r Registry) Find(code string) (Unit, bool) {
+	for _, v := range r {
+		if v.Code == code {
+			return v, true
+		}
+	}
+
+	return Unit{}, false
+}
+
+// ToCanonical converts a quantity in the unit of the code to the canonical unit of its dimension,
+// false when the code isn't in the registry or can't be converted.
+func (r Registry) ToCanonical(code string, quantity float64) (float64, bool) {
+	unit, ok := r.Find(code)
+	if !ok || unit.Factor == nil {
+		return 0, false
+	}
+
+	return quantity * *unit.Factor, true
+}
+
+// The codes of the units, as they are stored.
+const (
+	SquareMeter = "m2"
+	Hectare     = "Ha"
+
+	Kilogram = "Kg"
+	Gram     = "Gr"
+
+	MaterialSeeds      = "SEEDS"
+	MaterialPackets    = "PACKETS"
+	MaterialGram       = "GRAM"
+	MaterialKilogram   = "KILOGRAM"
+	MaterialBags       = "BAGS"
+	MaterialBottles    = "BOTTLES"
+	MaterialCubicMetre = "CUBIC_METRE"
+	MaterialPieces     = "PIECES"
+	MaterialUnits      = "UNITS"
+)
+
+func factor(f float64) *float64 {
+	return &f
+}
+
+// AreaSize lists the units of the size of the areas.
+func AreaSize() Registry {
+	return Registry{
+		{Dimension: DimensionArea, Code: SquareMeter, Label: "Square Meter", Factor: factor(1)},
+		{Dimension: DimensionArea, Code: Hectare, Label: "Hectare", Factor: factor(10000)},
+	}
+}
+
+// Harvest lists the units of the produced quantity of the harvests.
+func Harvest() Registry {
+	return Registry{
+		{Dimension: DimensionMass, Code: Kilogram, Label: "kg", Factor: factor(1000)},
+		{Dimension: DimensionMass, Code: Gram, Label: "gr", Factor: factor(1)},
+	}
+}
+
+func materialUnit(code string) Unit {
+	switch code {
+	case MaterialSeeds:
+		return Unit{Dimension: DimensionCount, Code: code, Label: "Seeds", Factor: factor(1)}
+	case MaterialPieces:
+		return Unit{Dimension: DimensionCount, Code: code, Label: "Pieces", Factor: factor(1)}
+	case MaterialUnits:
+		return Unit{Dimension: DimensionCount, Code: code, Label: "Units", Factor: factor(1)}
+	case MaterialGram:
+		return Unit{Dimension: DimensionMass, Code: code, Label: "Gram", Factor: factor(1)}
+	case MaterialKilogram:
+		return Unit{Dimension: DimensionMass, Code: code, Label: "Kilogram", Factor: factor(1000)}
+	case MaterialCubicMetre:
+		return Unit{Dimension: DimensionVolume, Code: code, Label: "Cubic Metre", Factor: factor(1)}
+	case MaterialPackets:
+		return Unit{Dimension: DimensionPackage, Code: code, Label: "Packets"}
+	case MaterialBags:
+		return Unit{Dimension: DimensionPackage, Code: code, Label: "Bags"}
+	case MaterialBottles:
+		return Unit{Dimension: DimensionPackage, Code: code, Label: "Bottles"}
+	}
+
+	return Unit{}
+}
+
+// materialUnitCodes are the units of each material type, by the type codes of the assets domain.
+//
+//nolint:gochecknoglobals
+var materialUnitCodes = []struct {
+	materialType string
+	codes        []string
+}{
+	{"SEED", []string{MaterialSeeds, MaterialPackets, MaterialGram, MaterialKilogram}},
+	{"AGROCHEMICAL", []string{MaterialPackets, MaterialBottles, MaterialBags}},
+	{"GROWING_MEDIUM", []string{MaterialBags, MaterialCubicMetre}},
+	{"LABEL_AND_CROP_SUPPORT", []string{MaterialPieces}},
+	{"SEEDING_CONTAINER", []string{MaterialPieces}},
+	{"POST_HARVEST_SUPPLY", []string{MaterialPieces}},
+	{"PLANT", []string{MaterialUnits, MaterialPackets}},
+	{"OTHER", []string{MaterialPieces}},
+}
+
+// Material lists the units of the quantity of a material type, nil for an unknown type.
+func Material(materialType string) Registry {
+	for _, v := range materialUnitCodes {
+		if v.materialType != materialType {
+			continue
+		}
+
+		registry := Registry{}
+		for _, code := range v.codes {
+			registry = append(registry, materialUnit(code))
+		}
+
+		return registry
+	}
+
+	return nil
+}
+
+// Catalog is every registry, the material ones by material type.
+type Catalog struct {
+	AreaSize Registry            `json:"area_size"`
+	Material map[string]Registry `json:"material"`
+	Harvest  Registry            `json:"harvest"`
+}
+
+func NewCatalog() Catalog {
+	catalog := Catalog{
+		AreaSize: AreaSize(),
+		Material: map[string]Registry{},
+		Harvest:  Harvest(),
+	}
+
+	for _, v := range materialUnitCodes {
+		catalog.Material[v.materialType] = Material(v.materialType)
+	}
+
+	return catalog
+}
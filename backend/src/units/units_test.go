@@ -0,0 +1,71 @@
+package units_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	assetsdomain "github.com/usetania/tania-core/src/assets/domain"
+	growthdomain "github.com/usetania/tania-core/src/growth/domain"
+	"github.com/usetania/tania-core/src/units"
+)
+
+func TestCatalogIsWhatTheDomainsAccept(t *testing.T) {
+	t.Parallel()
+
+	// When
+	catalog := units.NewCatalog()
+
+	// Then
+	assert.Len(t, catalog.AreaSize, len(assetsdomain.AreaUnits()))
+
+	for _, v := range catalog.AreaSize {
+		assert.Equal(t, v.Code, assetsdomain.GetAreaUnit(v.Code).Symbol)
+	}
+
+	assert.Len(t, catalog.Harvest, len(growthdomain.ProducedUnits()))
+
+	for _, v := range catalog.Harvest {
+		assert.Equal(t, v.Code, growthdomain.GetProducedUnit(v.Code).Code)
+	}
+
+	materialTypes := []string{
+		assetsdomain.MaterialTypeSeedCode,
+		assetsdomain.MaterialTypePlantCode,
+		assetsdomain.MaterialTypeGrowingMediumCode,
+		assetsdomain.MaterialTypeAgrochemicalCode,
+		assetsdomain.MaterialTypeLabelAndCropSupportCode,
+		assetsdomain.MaterialTypeSeedingContainerCode,
+		assetsdomain.MaterialTypePostHarvestSupplyCode,
+		assetsdomain.MaterialTypeOtherCode,
+	}
+
+	assert.Len(t, catalog.Material, len(materialTypes))
+
+	for _, materialType := range materialTypes {
+		registry, ok := catalog.Material[materialType]
+		assert.True(t, ok, materialType)
+		assert.NotEmpty(t, registry, materialType)
+
+		for _, v := range registry {
+			assert.Equal(t, v.Code, assetsdomain.GetMaterialQuantityUnit(materialType, v.Code).Code)
+		}
+	}
+}
+
+func TestRegistryToCanonical(t *testing.T) {
+	t.Parallel()
+
+	// When
+	squareMeters, ok := units.AreaSize().ToCanonical(units.Hectare, 1.5)
+	grams, gramsOK := units.Harvest().ToCanonical(units.Kilogram, 2)
+	_, packetsOK := units.Material("SEED").ToCanonical(units.MaterialPackets, 3)
+	_, unknownOK := units.Harvest().ToCanonical("TONNE", 1)
+
+	// Then
+	assert.True(t, ok)
+	assert.Equal(t, 15000.0, squareMeters)
+	assert.True(t, gramsOK)
+	assert.Equal(t, 2000.0, grams)
+	assert.False(t, packetsOK)
+	assert.False(t, unknownOK)
+}
@@ -0,0 +1,66 @@
+// Package authprovider checks the credentials of the users logging in,
+// against the local users or against an LDAP directory.
+package authprovider
+
+import (
+	"errors"
+
+	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/auth"
+	"github.com/usetania/tania-core/src/user/query"
+	"github.com/usetania/tania-core/src/user/storage"
+)
+
+// The auth_provider config values.
+const (
+	ProviderLocal = "local"
+	ProviderLDAP  = "ldap"
+)
+
+// ErrInvalidCredentials is returned for a wrong username or password, whatever the provider.
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// Identity is the user authenticated by a provider.
+// An external identity has no local user yet, or one whose role follows the directory.
+type Identity struct {
+	UserUID  uuid.UUID
+	Username string
+	Role     auth.Role
+	External bool
+}
+
+// AuthProvider authenticates the username and password of the authorize request.
+type AuthProvider interface {
+	Authenticate(username, password string) (Identity, error)
+}
+
+// LocalAuthProvider checks the password against the bcrypt hash of the local user.
+type LocalAuthProvider struct {
+	UserReadQuery query.UserRead
+}
+
+func NewLocalAuthProvider(userReadQuery query.UserRead) *LocalAuthProvider {
+	return &LocalAuthProvider{UserReadQuery: userReadQuery}
+}
+
+func (p *LocalAuthProvider) Authenticate(username, password string) (Identity, error) {
+	queryResult := <-p.UserReadQuery.FindByUsernameAndPassword(username, password)
+	if queryResult.Error != nil {
+		return Identity{}, queryResult.Error
+	}
+
+	userRead, ok := queryResult.Result.(storage.UserRead)
+	if !ok {
+		return Identity{}, errors.New("error type assertion")
+	}
+
+	if userRead.UID == (uuid.UUID{}) {
+		return Identity{}, ErrInvalidCredentials
+	}
+
+	return Identity{
+		UserUID:  userRead.UID,
+		Username: userRead.Username,
+		Role:     auth.Role(userRead.Role),
+	}, nil
+}
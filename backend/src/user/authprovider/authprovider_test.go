@@ -0,0 +1,60 @@
+package authprovider_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/auth"
+	. "github.com/usetania/tania-core/src/user/authprovider"
+	"github.com/usetania/tania-core/src/user/query"
+	"github.com/usetania/tania-core/src/user/storage"
+)
+
+type fakeUserReadQuery struct {
+	User     storage.UserRead
+	Password string
+}
+
+func (q fakeUserReadQuery) result(userRead storage.UserRead) <-chan query.Result {
+	result := make(chan query.Result, 1)
+	result <- query.Result{Result: userRead}
+	close(result)
+
+	return result
+}
+
+func (q fakeUserReadQuery) FindByID(userUID uuid.UUID) <-chan query.Result {
+	return q.result(q.User)
+}
+
+func (q fakeUserReadQuery) FindByUsername(username string) <-chan query.Result {
+	return q.result(q.User)
+}
+
+func (q fakeUserReadQuery) FindByUsernameAndPassword(username, password string) <-chan query.Result {
+	if username != q.User.Username || password != q.Password {
+		return q.result(storage.UserRead{})
+	}
+
+	return q.result(q.User)
+}
+
+func TestLocalAuthenticate(t *testing.T) {
+	t.Parallel()
+	// Given
+	uid, _ := uuid.NewV4()
+	provider := NewLocalAuthProvider(fakeUserReadQuery{
+		User:     storage.UserRead{UID: uid, Username: "tania", Role: "MANAGER"},
+		Password: "tania",
+	})
+
+	// When
+	identity, err := provider.Authenticate("tania", "tania")
+	_, errWrong := provider.Authenticate("tania", "wrong")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, Identity{UserUID: uid, Username: "tania", Role: auth.RoleManager}, identity)
+	assert.ErrorIs(t, errWrong, ErrInvalidCredentials)
+}
@@ -0,0 +1,117 @@
+package authprovider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/usetania/tania-core/src/auth"
+)
+
+// LDAPConn is the part of the LDAP connection the provider uses, so the tests can fake the directory.
+type LDAPConn interface {
+	Bind(username, password string) error
+	Search(request *ldap.SearchRequest) (*ldap.SearchResult, error)
+	Close() error
+}
+
+// LDAPAuthProvider binds to the directory with the credentials of the user,
+// then searches the entry of the user under the base DN for its role attribute.
+type LDAPAuthProvider struct {
+	URL           string
+	UserBaseDN    string
+	UserAttribute string
+	RoleAttribute string
+	// BindFormat formats the username into the bind DN, like %s@example.com.
+	// When it's empty the user binds as UserAttribute=username,UserBaseDN.
+	BindFormat string
+	Dial       func(url string) (LDAPConn, error)
+}
+
+func NewLDAPAuthProvider(url, userBaseDN, userAttribute, roleAttribute, bindFormat string) *LDAPAuthProvider {
+	return &LDAPAuthProvider{
+		URL:           url,
+		UserBaseDN:    userBaseDN,
+		UserAttribute: userAttribute,
+		RoleAttribute: roleAttribute,
+		BindFormat:    bindFormat,
+		Dial: func(url string) (LDAPConn, error) {
+			return ldap.DialURL(url)
+		},
+	}
+}
+
+func (p *LDAPAuthProvider) Authenticate(username, password string) (Identity, error) {
+	// An empty password is an unauthenticated bind, which most directories accept.
+	if username == "" || password == "" {
+		return Identity{}, ErrInvalidCredentials
+	}
+
+	conn, err := p.Dial(p.URL)
+	if err != nil {
+		return Identity{}, fmt.Errorf("can't connect to the LDAP server: %w", err)
+	}
+	defer conn.Close()
+
+	err = conn.Bind(p.bindDN(username), password)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return Identity{}, ErrInvalidCredentials
+		}
+
+		return Identity{}, fmt.Errorf("can't bind to the LDAP server: %w", err)
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		p.UserBaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, 0, false,
+		fmt.Sprintf("(%s=%s)", p.UserAttribute, ldap.EscapeFilter(username)),
+		[]string{"dn", p.RoleAttribute},
+		nil,
+	))
+	if err != nil {
+		return Identity{}, fmt.Errorf("can't search the LDAP user: %w", err)
+	}
+
+	if len(result.Entries) != 1 {
+		return Identity{}, fmt.Errorf("%w: %d entries of %s under %s",
+			ErrInvalidCredentials, len(result.Entries), username, p.UserBaseDN)
+	}
+
+	return Identity{
+		Username: username,
+		Role:     MapRole(result.Entries[0].GetAttributeValues(p.RoleAttribute)),
+		External: true,
+	}, nil
+}
+
+func (p *LDAPAuthProvider) bindDN(username string) string {
+	if p.BindFormat != "" {
+		return fmt.Sprintf(p.BindFormat, username)
+	}
+
+	return p.UserAttribute + "=" + ldap.EscapeDN(username) + "," + p.UserBaseDN
+}
+
+// MapRole maps the values of the role attribute to the highest role they name.
+// A value names a role by itself, like manager, or by the first RDN of a group DN, like cn=manager,ou=groups.
+// A user whose values name no role is a worker.
+func MapRole(values []string) auth.Role {
+	rank := map[auth.Role]int{auth.RoleWorker: 1, auth.RoleManager: 2, auth.RoleOwner: 3}
+	role := auth.RoleWorker
+
+	for _, v := range values {
+		name := v
+
+		if dn, err := ldap.ParseDN(v); err == nil && len(dn.RDNs) > 0 && len(dn.RDNs[0].Attributes) > 0 {
+			name = dn.RDNs[0].Attributes[0].Value
+		}
+
+		candidate := auth.Role(strings.ToUpper(strings.TrimSpace(name)))
+		if rank[candidate] > rank[role] {
+			role = candidate
+		}
+	}
+
+	return role
+}
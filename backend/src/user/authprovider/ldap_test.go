@@ -0,0 +1,148 @@
+package authprovider_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/auth"
+	. "github.com/usetania/tania-core/src/user/authprovider"
+)
+
+type fakeDirectory struct {
+	Passwords map[string]string
+	Entries   []*ldap.Entry
+	BoundAs   string
+	Filter    string
+	Closed    bool
+}
+
+func (d *fakeDirectory) Bind(username, password string) error {
+	if d.Passwords[username] != password {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+	}
+
+	d.BoundAs = username
+
+	return nil
+}
+
+func (d *fakeDirectory) Search(request *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	d.Filter = request.Filter
+
+	return &ldap.SearchResult{Entries: d.Entries}, nil
+}
+
+func (d *fakeDirectory) Close() error {
+	d.Closed = true
+
+	return nil
+}
+
+func newTestProvider(directory *fakeDirectory, bindFormat string) *LDAPAuthProvider {
+	provider := NewLDAPAuthProvider("ldap://localhost:389", "ou=people,dc=example,dc=com", "uid", "memberOf", bindFormat)
+	provider.Dial = func(url string) (LDAPConn, error) {
+		return directory, nil
+	}
+
+	return provider
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	t.Parallel()
+	// Given
+	directory := &fakeDirectory{
+		Passwords: map[string]string{"uid=alice,ou=people,dc=example,dc=com": "secret"},
+		Entries: []*ldap.Entry{
+			ldap.NewEntry("uid=alice,ou=people,dc=example,dc=com", map[string][]string{
+				"memberOf": {"cn=worker,ou=groups,dc=example,dc=com", "cn=manager,ou=groups,dc=example,dc=com"},
+			}),
+		},
+	}
+	provider := newTestProvider(directory, "")
+
+	// When
+	identity, err := provider.Authenticate("alice", "secret")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", identity.Username)
+	assert.Equal(t, auth.RoleManager, identity.Role)
+	assert.True(t, identity.External)
+	assert.Equal(t, "(uid=alice)", directory.Filter)
+	assert.True(t, directory.Closed)
+}
+
+func TestLDAPAuthenticateWithTheBindFormat(t *testing.T) {
+	t.Parallel()
+	// Given
+	directory := &fakeDirectory{
+		Passwords: map[string]string{"bob@example.com": "secret"},
+		Entries:   []*ldap.Entry{ldap.NewEntry("uid=bob,ou=people,dc=example,dc=com", nil)},
+	}
+	provider := newTestProvider(directory, "%s@example.com")
+
+	// When
+	identity, err := provider.Authenticate("bob", "secret")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "bob@example.com", directory.BoundAs)
+	assert.Equal(t, auth.RoleWorker, identity.Role)
+}
+
+func TestLDAPAuthenticateInvalidCredentials(t *testing.T) {
+	t.Parallel()
+	// Given
+	directory := &fakeDirectory{
+		Passwords: map[string]string{"uid=alice,ou=people,dc=example,dc=com": "secret"},
+	}
+	provider := newTestProvider(directory, "")
+
+	// When
+	_, errWrong := provider.Authenticate("alice", "wrong")
+	_, errEmpty := provider.Authenticate("alice", "")
+	_, errNoEntry := provider.Authenticate("alice", "secret")
+
+	// Then
+	assert.ErrorIs(t, errWrong, ErrInvalidCredentials)
+	assert.ErrorIs(t, errEmpty, ErrInvalidCredentials)
+	assert.ErrorIs(t, errNoEntry, ErrInvalidCredentials)
+}
+
+func TestLDAPAuthenticateUnreachableServer(t *testing.T) {
+	t.Parallel()
+	// Given
+	provider := NewLDAPAuthProvider("ldap://127.0.0.1:1", "dc=example,dc=com", "uid", "memberOf", "")
+
+	// When
+	_, err := provider.Authenticate("alice", "secret")
+
+	// Then
+	assert.NotNil(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestMapRole(t *testing.T) {
+	t.Parallel()
+	// Given
+	values := [][]string{
+		nil,
+		{"owner"},
+		{"Manager", "worker"},
+		{"cn=Owner,ou=groups,dc=example,dc=com", "cn=worker,ou=groups,dc=example,dc=com"},
+		{"cn=admins,ou=groups,dc=example,dc=com"},
+	}
+
+	// When
+	roles := []auth.Role{}
+	for _, v := range values {
+		roles = append(roles, MapRole(v))
+	}
+
+	// Then
+	assert.Equal(t, []auth.Role{
+		auth.RoleWorker, auth.RoleOwner, auth.RoleManager, auth.RoleOwner, auth.RoleWorker,
+	}, roles)
+}
@@ -7,13 +7,16 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/mitchellh/mapstructure"
+	"github.com/usetania/tania-core/src/actor"
 )
 
 // EventWrapper is used to wrap the event interface with its struct name,
 // so it will be easier to unmarshal later.
+// The actor is nil for the events stored before the actors were recorded.
 type EventWrapper struct {
 	EventName string
 	EventData interface{}
+	Actor     *actor.Actor `json:",omitempty"`
 }
 
 func Decode(f mapstructure.DecodeHookFunc, data *map[string]interface{}, e interface{}) (interface{}, error) {
@@ -19,6 +19,8 @@ func (w *UserEventWrapper) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	w.Actor = wrapper.Actor
+
 	mapped, ok := wrapper.EventData.(map[string]interface{})
 	if !ok {
 		return errors.New("error type assertion")
@@ -49,6 +51,26 @@ func (w *UserEventWrapper) UnmarshalJSON(b []byte) error {
 			return err
 		}
 
+		w.EventData = e
+
+	case "HourlyRateChanged":
+		e := domain.HourlyRateChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
+		w.EventData = e
+
+	case "UserRoleChanged":
+		e := domain.UserRoleChanged{}
+
+		_, err := Decode(f, &mapped, &e)
+		if err != nil {
+			return err
+		}
+
 		w.EventData = e
 	}
 
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/auth"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -15,6 +16,8 @@ type User struct {
 	ClientID    string
 	CreatedDate time.Time
 	LastUpdated time.Time
+	HourlyRate  float64
+	Role        auth.Role
 
 	// Events
 	Version            int
@@ -47,6 +50,14 @@ func (u *User) Transition(event interface{}) {
 	case PasswordChanged:
 		u.Password = e.NewPassword
 		u.LastUpdated = e.DateChanged
+
+	case HourlyRateChanged:
+		u.HourlyRate = e.HourlyRate
+		u.LastUpdated = e.DateChanged
+
+	case UserRoleChanged:
+		u.Role = e.Role
+		u.LastUpdated = e.DateChanged
 	}
 }
 
@@ -127,6 +138,38 @@ func (u *User) ChangePassword(oldPassword, newPassword, newConfirmPassword strin
 	return nil
 }
 
+// ChangeHourlyRate sets the labour cost of an hour of the user's work.
+func (u *User) ChangeHourlyRate(hourlyRate float64) error {
+	if hourlyRate < 0 {
+		return UserError{UserErrorInvalidHourlyRateCode}
+	}
+
+	u.TrackChange(HourlyRateChanged{
+		UID:         u.UID,
+		HourlyRate:  hourlyRate,
+		DateChanged: time.Now(),
+	})
+
+	return nil
+}
+
+// ChangeRole sets the role of the user, like the role mapped from the directory of an external provider.
+func (u *User) ChangeRole(role auth.Role) error {
+	switch role {
+	case auth.RoleOwner, auth.RoleManager, auth.RoleWorker:
+	default:
+		return UserError{UserErrorInvalidRoleCode}
+	}
+
+	u.TrackChange(UserRoleChanged{
+		UID:         u.UID,
+		Role:        role,
+		DateChanged: time.Now(),
+	})
+
+	return nil
+}
+
 func (u *User) IsPasswordValid(password string) (bool, error) {
 	err := bcrypt.CompareHashAndPassword(u.Password, []byte(password))
 	if err != nil {
@@ -13,6 +13,8 @@ const (
 	UserErrorUsernameExistsCode
 	UserErrorPasswordConfirmationNotMatchCode
 	UserChangePasswordErrorWrongOldPasswordCode
+	UserErrorInvalidHourlyRateCode
+	UserErrorInvalidRoleCode
 )
 
 func (e UserError) Error() string {
@@ -31,6 +33,10 @@ func (e UserError) Error() string {
 		return "Password confirmation didn't match"
 	case UserChangePasswordErrorWrongOldPasswordCode:
 		return "Invalid old password"
+	case UserErrorInvalidHourlyRateCode:
+		return "Hourly rate cannot be negative"
+	case UserErrorInvalidRoleCode:
+		return "Role must be owner, manager or worker"
 	default:
 		return "Unrecognized user error code"
 	}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/auth"
 )
 
 type UserCreated struct {
@@ -19,3 +20,15 @@ type PasswordChanged struct {
 	NewPassword []byte
 	DateChanged time.Time
 }
+
+type HourlyRateChanged struct {
+	UID         uuid.UUID
+	HourlyRate  float64
+	DateChanged time.Time
+}
+
+type UserRoleChanged struct {
+	UID         uuid.UUID
+	Role        auth.Role
+	DateChanged time.Time
+}
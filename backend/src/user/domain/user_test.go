@@ -6,6 +6,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/usetania/tania-core/src/auth"
 	. "github.com/usetania/tania-core/src/user/domain"
 )
 
@@ -68,3 +69,40 @@ func TestChangePassword(t *testing.T) {
 	assert.Nil(t, errValid)
 	assert.Equal(t, true, isValid)
 }
+
+func TestChangeHourlyRate(t *testing.T) {
+	t.Parallel()
+	// Given
+	userServiceMock := new(UserServiceMock)
+	userServiceMock.On("FindUserByUsername", "username").Return(UserServiceResult{})
+
+	user, _ := CreateUser(userServiceMock, "username", "password", "password")
+
+	// When
+	negativeErr := user.ChangeHourlyRate(-1)
+	err := user.ChangeHourlyRate(12.5)
+
+	// Then
+	assert.Equal(t, UserError{UserErrorInvalidHourlyRateCode}, negativeErr)
+	assert.Nil(t, err)
+	assert.Equal(t, 12.5, user.HourlyRate)
+}
+
+func TestChangeRole(t *testing.T) {
+	t.Parallel()
+	// Given
+	userServiceMock := new(UserServiceMock)
+	userServiceMock.On("FindUserByUsername", "username").Return(UserServiceResult{})
+
+	user, _ := CreateUser(userServiceMock, "username", "password", "password")
+
+	// When
+	invalidErr := user.ChangeRole("ADMIN")
+	err := user.ChangeRole(auth.RoleManager)
+
+	// Then
+	assert.Equal(t, UserError{UserErrorInvalidRoleCode}, invalidErr)
+	assert.Nil(t, err)
+	assert.Equal(t, auth.RoleManager, user.Role)
+	assert.Len(t, user.UncommittedChanges, 2)
+}
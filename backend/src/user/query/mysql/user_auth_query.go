@@ -20,7 +20,7 @@ func NewUserAuthQueryMysql(db *sql.DB) query.UserAuth {
 
 type userAuthResult struct {
 	UserUID      []byte
-	AccessToken  string
+	AccessToken  sql.NullString
 	TokenExpires int
 	CreatedDate  time.Time
 	LastUpdated  time.Time
@@ -57,7 +57,7 @@ func (s UserAuthQueryMysql) FindByUserID(uid uuid.UUID) <-chan query.Result {
 
 		userAuth = storage.UserAuth{
 			UserUID:      userUID,
-			AccessToken:  rowsData.AccessToken,
+			AccessToken:  rowsData.AccessToken.String,
 			TokenExpires: rowsData.TokenExpires,
 			CreatedDate:  rowsData.CreatedDate,
 			LastUpdated:  rowsData.LastUpdated,
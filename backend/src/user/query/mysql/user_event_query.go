@@ -58,6 +58,7 @@ func (f *UserEventQueryMysql) FindAllByID(uid uuid.UUID) <-chan query.Result {
 				Version:     rowsData.Version,
 				CreatedDate: rowsData.CreatedDate,
 				Event:       wrapper.EventData,
+				Actor:       wrapper.Actor,
 			})
 		}
 
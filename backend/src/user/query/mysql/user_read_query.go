@@ -25,6 +25,8 @@ type userReadResult struct {
 	Password    string
 	CreatedDate time.Time
 	LastUpdated time.Time
+	HourlyRate  sql.NullFloat64
+	Role        sql.NullString
 }
 
 func (s UserReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
@@ -40,6 +42,8 @@ func (s UserReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
 			&rowsData.Password,
 			&rowsData.CreatedDate,
 			&rowsData.LastUpdated,
+			&rowsData.HourlyRate,
+			&rowsData.Role,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -61,6 +65,8 @@ func (s UserReadQueryMysql) FindByID(uid uuid.UUID) <-chan query.Result {
 			Password:    []byte(rowsData.Password),
 			CreatedDate: rowsData.CreatedDate,
 			LastUpdated: rowsData.LastUpdated,
+			HourlyRate:  rowsData.HourlyRate.Float64,
+			Role:        rowsData.Role.String,
 		}
 
 		result <- query.Result{Result: userRead}
@@ -83,6 +89,8 @@ func (s UserReadQueryMysql) FindByUsername(username string) <-chan query.Result
 			&rowsData.Password,
 			&rowsData.CreatedDate,
 			&rowsData.LastUpdated,
+			&rowsData.HourlyRate,
+			&rowsData.Role,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -104,6 +112,8 @@ func (s UserReadQueryMysql) FindByUsername(username string) <-chan query.Result
 			Password:    []byte(rowsData.Password),
 			CreatedDate: rowsData.CreatedDate,
 			LastUpdated: rowsData.LastUpdated,
+			HourlyRate:  rowsData.HourlyRate.Float64,
+			Role:        rowsData.Role.String,
 		}
 
 		result <- query.Result{Result: userRead}
@@ -127,6 +137,8 @@ func (s UserReadQueryMysql) FindByUsernameAndPassword(username, password string)
 			&rowsData.Password,
 			&rowsData.CreatedDate,
 			&rowsData.LastUpdated,
+			&rowsData.HourlyRate,
+			&rowsData.Role,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -153,6 +165,8 @@ func (s UserReadQueryMysql) FindByUsernameAndPassword(username, password string)
 			Password:    []byte(rowsData.Password),
 			CreatedDate: rowsData.CreatedDate,
 			LastUpdated: rowsData.LastUpdated,
+			HourlyRate:  rowsData.HourlyRate.Float64,
+			Role:        rowsData.Role.String,
 		}
 
 		result <- query.Result{Result: userRead}
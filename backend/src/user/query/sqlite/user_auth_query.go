@@ -20,7 +20,7 @@ func NewUserAuthQuerySqlite(db *sql.DB) query.UserAuth {
 
 type userAuthResult struct {
 	UserUID      string
-	AccessToken  string
+	AccessToken  sql.NullString
 	TokenExpires int
 	CreatedDate  string
 	LastUpdated  string
@@ -67,7 +67,7 @@ func (s UserAuthQuerySqlite) FindByUserID(uid uuid.UUID) <-chan query.Result {
 
 		userAuth = storage.UserAuth{
 			UserUID:      userUID,
-			AccessToken:  rowsData.AccessToken,
+			AccessToken:  rowsData.AccessToken.String,
 			TokenExpires: rowsData.TokenExpires,
 			CreatedDate:  createdDate,
 			LastUpdated:  lastUpdated,
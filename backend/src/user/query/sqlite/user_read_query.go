@@ -25,6 +25,8 @@ type userReadResult struct {
 	Password    string
 	CreatedDate string
 	LastUpdated string
+	HourlyRate  sql.NullFloat64
+	Role        sql.NullString
 }
 
 func (s UserReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
@@ -40,6 +42,8 @@ func (s UserReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
 			&rowsData.Password,
 			&rowsData.CreatedDate,
 			&rowsData.LastUpdated,
+			&rowsData.HourlyRate,
+			&rowsData.Role,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -71,6 +75,8 @@ func (s UserReadQuerySqlite) FindByID(uid uuid.UUID) <-chan query.Result {
 			Password:    []byte(rowsData.Password),
 			CreatedDate: createdDate,
 			LastUpdated: lastUpdated,
+			HourlyRate:  rowsData.HourlyRate.Float64,
+			Role:        rowsData.Role.String,
 		}
 
 		result <- query.Result{Result: userRead}
@@ -93,6 +99,8 @@ func (s UserReadQuerySqlite) FindByUsername(username string) <-chan query.Result
 			&rowsData.Password,
 			&rowsData.CreatedDate,
 			&rowsData.LastUpdated,
+			&rowsData.HourlyRate,
+			&rowsData.Role,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -124,6 +132,8 @@ func (s UserReadQuerySqlite) FindByUsername(username string) <-chan query.Result
 			Password:    []byte(rowsData.Password),
 			CreatedDate: createdDate,
 			LastUpdated: lastUpdated,
+			HourlyRate:  rowsData.HourlyRate.Float64,
+			Role:        rowsData.Role.String,
 		}
 
 		result <- query.Result{Result: userRead}
@@ -147,6 +157,8 @@ func (s UserReadQuerySqlite) FindByUsernameAndPassword(username, password string
 			&rowsData.Password,
 			&rowsData.CreatedDate,
 			&rowsData.LastUpdated,
+			&rowsData.HourlyRate,
+			&rowsData.Role,
 		)
 
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -183,6 +195,8 @@ func (s UserReadQuerySqlite) FindByUsernameAndPassword(username, password string
 			Password:    []byte(rowsData.Password),
 			CreatedDate: createdDate,
 			LastUpdated: lastUpdated,
+			HourlyRate:  rowsData.HourlyRate.Float64,
+			Role:        rowsData.Role.String,
 		}
 
 		result <- query.Result{Result: userRead}
@@ -19,6 +19,8 @@ func (s *UserAuthRepositoryMysql) Save(userAuth *storage.UserAuth) <-chan error
 	result := make(chan error)
 
 	go func() {
+		// A user not logged in yet has no token. Its NULL token keeps the tokens unique.
+		accessToken := sql.NullString{String: userAuth.AccessToken, Valid: userAuth.AccessToken != ""}
 		total := 0
 
 		err := s.DB.QueryRow(`SELECT COUNT(USER_UID)
@@ -31,7 +33,7 @@ func (s *UserAuthRepositoryMysql) Save(userAuth *storage.UserAuth) <-chan error
 			_, err := s.DB.Exec(`UPDATE USER_AUTH
 				SET ACCESS_TOKEN = ?, TOKEN_EXPIRES = ?, CREATED_DATE = ?, LAST_UPDATED = ?
 				WHERE USER_UID = ?`,
-				userAuth.AccessToken, userAuth.TokenExpires,
+				accessToken, userAuth.TokenExpires,
 				userAuth.CreatedDate, userAuth.LastUpdated,
 				userAuth.UserUID.Bytes())
 			if err != nil {
@@ -41,7 +43,7 @@ func (s *UserAuthRepositoryMysql) Save(userAuth *storage.UserAuth) <-chan error
 			_, err := s.DB.Exec(`INSERT INTO USER_AUTH
 				(USER_UID, ACCESS_TOKEN, TOKEN_EXPIRES, CREATED_DATE, LAST_UPDATED)
 				VALUES (?,?,?,?,?)`,
-				userAuth.UserUID.Bytes(), userAuth.AccessToken, userAuth.TokenExpires,
+				userAuth.UserUID.Bytes(), accessToken, userAuth.TokenExpires,
 				userAuth.CreatedDate, userAuth.LastUpdated)
 			if err != nil {
 				result <- err
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/user/domain"
 	"github.com/usetania/tania-core/src/user/storage"
 )
@@ -14,7 +15,7 @@ type Result struct {
 }
 
 type UserEvent interface {
-	Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error
+	Save(uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor) <-chan error
 }
 
 type UserRead interface {
@@ -20,6 +20,8 @@ func (s *UserAuthRepositorySqlite) Save(userAuth *storage.UserAuth) <-chan error
 	result := make(chan error)
 
 	go func() {
+		// A user not logged in yet has no token. Its NULL token keeps the tokens unique.
+		accessToken := sql.NullString{String: userAuth.AccessToken, Valid: userAuth.AccessToken != ""}
 		total := 0
 
 		err := s.DB.QueryRow(`SELECT COUNT(USER_UID)
@@ -32,7 +34,7 @@ func (s *UserAuthRepositorySqlite) Save(userAuth *storage.UserAuth) <-chan error
 			_, err := s.DB.Exec(`UPDATE USER_AUTH
 				SET ACCESS_TOKEN = ?, TOKEN_EXPIRES = ?, CREATED_DATE = ?, LAST_UPDATED = ?
 				WHERE USER_UID = ?`,
-				userAuth.AccessToken, userAuth.TokenExpires,
+				accessToken, userAuth.TokenExpires,
 				userAuth.CreatedDate.Format(time.RFC3339), userAuth.LastUpdated.Format(time.RFC3339),
 				userAuth.UserUID)
 			if err != nil {
@@ -42,7 +44,7 @@ func (s *UserAuthRepositorySqlite) Save(userAuth *storage.UserAuth) <-chan error
 			_, err := s.DB.Exec(`INSERT INTO USER_AUTH
 				(USER_UID, ACCESS_TOKEN, TOKEN_EXPIRES, CREATED_DATE, LAST_UPDATED)
 				VALUES (?,?,?,?,?)`,
-				userAuth.UserUID, userAuth.AccessToken, userAuth.TokenExpires,
+				userAuth.UserUID, accessToken, userAuth.TokenExpires,
 				userAuth.CreatedDate.Format(time.RFC3339), userAuth.LastUpdated.Format(time.RFC3339))
 			if err != nil {
 				result <- err
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/helper/structhelper"
 	"github.com/usetania/tania-core/src/user/decoder"
 	"github.com/usetania/tania-core/src/user/repository"
@@ -19,7 +20,9 @@ func NewUserEventRepositorySqlite(db *sql.DB) repository.UserEvent {
 	return &UserEventRepositorySqlite{DB: db}
 }
 
-func (f *UserEventRepositorySqlite) Save(uid uuid.UUID, latestVersion int, events []interface{}) <-chan error {
+func (f *UserEventRepositorySqlite) Save(
+	uid uuid.UUID, latestVersion int, events []interface{}, by *actor.Actor,
+) <-chan error {
 	result := make(chan error)
 
 	go func() {
@@ -35,6 +38,7 @@ func (f *UserEventRepositorySqlite) Save(uid uuid.UUID, latestVersion int, event
 			e, err := json.Marshal(decoder.EventWrapper{
 				EventName: structhelper.GetName(v),
 				EventData: v,
+				Actor:     by,
 			})
 			if err != nil {
 				result <- err
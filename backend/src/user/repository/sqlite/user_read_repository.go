@@ -30,20 +30,22 @@ func (f *UserReadRepositorySqlite) Save(userRead *storage.UserRead) <-chan error
 		if count > 0 {
 			_, err := f.DB.Exec(`UPDATE USER_READ SET
 				USERNAME = ?, PASSWORD = ?,
-				CREATED_DATE = ?, LAST_UPDATED = ?
+				CREATED_DATE = ?, LAST_UPDATED = ?, HOURLY_RATE = ?, ROLE = ?
 				WHERE UID = ?`,
 				userRead.Username, userRead.Password,
 				userRead.CreatedDate.Format(time.RFC3339), userRead.LastUpdated.Format(time.RFC3339),
+				userRead.HourlyRate, userRead.Role,
 				userRead.UID)
 			if err != nil {
 				result <- err
 			}
 		} else {
 			_, err := f.DB.Exec(`INSERT INTO USER_READ
-				(UID, USERNAME, PASSWORD, CREATED_DATE, LAST_UPDATED)
-				VALUES (?, ?, ?, ?, ?)`,
+				(UID, USERNAME, PASSWORD, CREATED_DATE, LAST_UPDATED, HOURLY_RATE, ROLE)
+				VALUES (?, ?, ?, ?, ?, ?, ?)`,
 				userRead.UID, userRead.Username, userRead.Password,
-				userRead.CreatedDate.Format(time.RFC3339), userRead.LastUpdated.Format(time.RFC3339))
+				userRead.CreatedDate.Format(time.RFC3339), userRead.LastUpdated.Format(time.RFC3339),
+				userRead.HourlyRate, userRead.Role)
 			if err != nil {
 				result <- err
 			}
@@ -3,6 +3,7 @@ package server
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -10,8 +11,11 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/actor"
+	"github.com/usetania/tania-core/src/auth"
 	"github.com/usetania/tania-core/src/eventbus"
 	"github.com/usetania/tania-core/src/helper/structhelper"
+	"github.com/usetania/tania-core/src/user/authprovider"
 	"github.com/usetania/tania-core/src/user/domain"
 	"github.com/usetania/tania-core/src/user/domain/service"
 	"github.com/usetania/tania-core/src/user/query"
@@ -32,6 +36,7 @@ type AuthServer struct {
 	UserAuthRepo   repository.UserAuth
 	UserAuthQuery  query.UserAuth
 	UserService    domain.UserService
+	AuthProvider   authprovider.AuthProvider
 	EventBus       eventbus.TaniaEventBus
 }
 
@@ -40,6 +45,11 @@ func NewAuthServer(
 	db *sql.DB,
 	eventBus eventbus.TaniaEventBus,
 ) (*AuthServer, error) {
+	eventBus, err := eventbus.ForTenant(eventBus)
+	if err != nil {
+		return nil, err
+	}
+
 	authServer := &AuthServer{
 		EventBus: eventBus,
 	}
@@ -68,6 +78,27 @@ func NewAuthServer(
 		authServer.UserService = service.UserServiceImpl{UserReadQuery: authServer.UserReadQuery}
 	}
 
+	switch *config.Config.AuthProvider {
+	case authprovider.ProviderLocal:
+		authServer.AuthProvider = authprovider.NewLocalAuthProvider(authServer.UserReadQuery)
+
+	case authprovider.ProviderLDAP:
+		if *config.Config.LDAPUserBaseDN == "" {
+			return nil, errors.New("the ldap auth provider needs the ldap_user_base_dn")
+		}
+
+		authServer.AuthProvider = authprovider.NewLDAPAuthProvider(
+			*config.Config.LDAPURL,
+			*config.Config.LDAPUserBaseDN,
+			*config.Config.LDAPUserAttribute,
+			*config.Config.LDAPRoleAttribute,
+			*config.Config.LDAPBindFormat,
+		)
+
+	default:
+		return nil, fmt.Errorf("unknown auth provider %s, available providers: local, ldap", *config.Config.AuthProvider)
+	}
+
 	authServer.InitSubscriber()
 
 	return authServer, nil
@@ -76,6 +107,7 @@ func NewAuthServer(
 // InitSubscriber defines the mapping of which event this domain listen with their handler.
 func (s *AuthServer) InitSubscriber() {
 	s.EventBus.Subscribe("UserCreated", s.SaveToUserReadModel)
+	s.EventBus.Subscribe("UserRoleChanged", s.SaveToUserReadModel)
 }
 
 // Mount defines the AuthServer's endpoints with its handlers.
@@ -96,17 +128,23 @@ func (s *AuthServer) Authorize(c echo.Context) error {
 	reqRedirectURI := c.FormValue("redirect_uri")
 	reqState := c.FormValue("state")
 
-	queryResult := <-s.UserReadQuery.FindByUsernameAndPassword(reqUsername, reqPassword)
-	if queryResult.Error != nil {
-		return Error(c, queryResult.Error)
+	identity, err := s.AuthProvider.Authenticate(reqUsername, reqPassword)
+	if errors.Is(err, authprovider.ErrInvalidCredentials) {
+		return Error(c, NewRequestValidationError(Invalid, "username"))
 	}
 
-	userRead, ok := queryResult.Result.(storage.UserRead)
-	if !ok {
-		return Error(c, errors.New("error type assertion"))
+	if err != nil {
+		return Error(c, err)
 	}
 
-	queryResult = <-s.UserAuthQuery.FindByUserID(userRead.UID)
+	if identity.External {
+		identity.UserUID, err = s.syncExternalUser(identity, actor.FromContext(c))
+		if err != nil {
+			return Error(c, err)
+		}
+	}
+
+	queryResult := <-s.UserAuthQuery.FindByUserID(identity.UserUID)
 	if queryResult.Error != nil {
 		return Error(c, queryResult.Error)
 	}
@@ -116,10 +154,6 @@ func (s *AuthServer) Authorize(c echo.Context) error {
 		return Error(c, errors.New("error type assertion"))
 	}
 
-	if userRead.UID == (uuid.UUID{}) {
-		return Error(c, NewRequestValidationError(Invalid, "username"))
-	}
-
 	if reqClientID != clientID {
 		return Error(c, NewRequestValidationError(Invalid, "client_id"))
 	}
@@ -128,8 +162,6 @@ func (s *AuthServer) Authorize(c echo.Context) error {
 		return Error(c, NewRequestValidationError(Required, "redirect_uri"))
 	}
 
-	var err error
-
 	reqRedirectURI, err = url.PathUnescape(reqRedirectURI)
 	if err != nil {
 		return Error(c, err)
@@ -190,7 +222,8 @@ func (s *AuthServer) Register(c echo.Context) error {
 		return Error(c, errors.New("confirm password didn't match"))
 	}
 
-	user, _, err := s.RegisterNewUser(username, password, confirmPassword)
+	// The users signing up are workers, an owner gives them another role.
+	user, _, err := s.RegisterNewUser(username, password, confirmPassword, auth.RoleWorker, actor.FromContext(c))
 	if err != nil {
 		return Error(c, err)
 	}
@@ -202,20 +235,34 @@ func (s *AuthServer) Register(c echo.Context) error {
 }
 
 // RegisterNewUser is used to call the behaviour and persist it
-// It is used by the register handler and in the initial user creation.
+// It is used by the register handler and in the initial user creation, the user is created with the role.
 func (s *AuthServer) RegisterNewUser(
-	username, password, confirmPassword string,
+	username, password, confirmPassword string, role auth.Role, by *actor.Actor,
 ) (*domain.User, *storage.UserAuth, error) {
 	user, err := domain.CreateUser(s.UserService, username, password, confirmPassword)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	err = <-s.UserEventRepo.Save(user.UID, user.Version, user.UncommittedChanges)
+	err = user.ChangeRole(role)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	userAuth, err := s.saveNewUser(user, by)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, userAuth, nil
+}
+
+func (s *AuthServer) saveNewUser(user *domain.User, by *actor.Actor) (*storage.UserAuth, error) {
+	err := <-s.UserEventRepo.Save(user.UID, user.Version, user.UncommittedChanges, by)
+	if err != nil {
+		return nil, err
+	}
+
 	userAuth := storage.UserAuth{
 		UserUID:     user.UID,
 		CreatedDate: user.CreatedDate,
@@ -224,12 +271,145 @@ func (s *AuthServer) RegisterNewUser(
 
 	err = <-s.UserAuthRepo.Save(&userAuth)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+
+	s.publishUncommittedEvents(user)
+
+	return &userAuth, nil
+}
+
+// syncExternalUser creates the local user of the identity of an external provider on its first login,
+// and changes its role when the directory changed it. The local password is random, only the provider logs it in.
+func (s *AuthServer) syncExternalUser(identity authprovider.Identity, by *actor.Actor) (uuid.UUID, error) {
+	queryResult := <-s.UserReadQuery.FindByUsername(identity.Username)
+	if queryResult.Error != nil {
+		return uuid.UUID{}, queryResult.Error
+	}
+
+	userRead, ok := queryResult.Result.(storage.UserRead)
+	if !ok {
+		return uuid.UUID{}, errors.New("error type assertion")
+	}
+
+	if userRead.UID == (uuid.UUID{}) {
+		password, err := uuid.NewV4()
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+
+		user, err := domain.CreateUser(s.UserService, identity.Username, password.String(), password.String())
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+
+		err = user.ChangeRole(identity.Role)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+
+		_, err = s.saveNewUser(user, by)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+
+		return user.UID, nil
+	}
+
+	if auth.Role(userRead.Role) == identity.Role {
+		return userRead.UID, nil
+	}
+
+	eventQueryResult := <-s.UserEventQuery.FindAllByID(userRead.UID)
+	if eventQueryResult.Error != nil {
+		return uuid.UUID{}, eventQueryResult.Error
+	}
+
+	events, ok := eventQueryResult.Result.([]storage.UserEvent)
+	if !ok {
+		return uuid.UUID{}, errors.New("error type assertion")
+	}
+
+	user := repository.NewUserFromHistory(events)
+
+	err := user.ChangeRole(identity.Role)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	err = <-s.UserEventRepo.Save(user.UID, user.Version, user.UncommittedChanges, by)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	s.publishUncommittedEvents(user)
+
+	return user.UID, nil
+}
+
+// RoleOf is the role of the user read model. It fails closed: the users whose role can't be read, like the ones
+// created before the roles, are workers.
+func (s *AuthServer) RoleOf(userUID uuid.UUID) auth.Role {
+	// The demo mode has no authentication, so there is no user.
+	if userUID == (uuid.UUID{}) {
+		return auth.RoleOwner
+	}
+
+	queryResult := <-s.UserReadQuery.FindByID(userUID)
+	if queryResult.Error != nil {
+		return auth.RoleWorker
+	}
+
+	userRead, ok := queryResult.Result.(storage.UserRead)
+	if !ok || userRead.Role == "" {
+		return auth.RoleWorker
+	}
+
+	return auth.Role(userRead.Role)
+}
+
+// AssignMissingRole gives its role to the user created before the roles, like the initial user.
+// The users with a role keep theirs.
+func (s *AuthServer) AssignMissingRole(username string, role auth.Role, by *actor.Actor) error {
+	queryResult := <-s.UserReadQuery.FindByUsername(username)
+	if queryResult.Error != nil {
+		return queryResult.Error
+	}
+
+	userRead, ok := queryResult.Result.(storage.UserRead)
+	if !ok {
+		return errors.New("internal server error. error type assertion")
+	}
+
+	if userRead.UID == (uuid.UUID{}) || userRead.Role != "" {
+		return nil
+	}
+
+	eventQueryResult := <-s.UserEventQuery.FindAllByID(userRead.UID)
+	if eventQueryResult.Error != nil {
+		return eventQueryResult.Error
+	}
+
+	events, ok := eventQueryResult.Result.([]storage.UserEvent)
+	if !ok {
+		return errors.New("internal server error. error type assertion")
+	}
+
+	user := repository.NewUserFromHistory(events)
+
+	err := user.ChangeRole(role)
+	if err != nil {
+		return err
+	}
+
+	err = <-s.UserEventRepo.Save(user.UID, user.Version, user.UncommittedChanges, by)
+	if err != nil {
+		return err
 	}
 
 	s.publishUncommittedEvents(user)
 
-	return user, &userAuth, nil
+	return nil
 }
 
 func (s *AuthServer) publishUncommittedEvents(entity interface{}) {
@@ -0,0 +1,59 @@
+package server_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/usetania/tania-core/src/auth"
+	"github.com/usetania/tania-core/src/user/query"
+	"github.com/usetania/tania-core/src/user/server"
+	"github.com/usetania/tania-core/src/user/storage"
+)
+
+type userReadQuery struct {
+	users map[uuid.UUID]query.Result
+}
+
+func (q userReadQuery) FindByID(userUID uuid.UUID) <-chan query.Result {
+	result := make(chan query.Result, 1)
+	result <- q.users[userUID]
+
+	return result
+}
+
+func (q userReadQuery) FindByUsername(string) <-chan query.Result {
+	return q.FindByID(uuid.Nil)
+}
+
+func (q userReadQuery) FindByUsernameAndPassword(string, string) <-chan query.Result {
+	return q.FindByID(uuid.Nil)
+}
+
+func TestRoleOfFailsClosed(t *testing.T) {
+	t.Parallel()
+	// Given
+	managerUID, _ := uuid.NewV4()
+	legacyUID, _ := uuid.NewV4()
+	brokenUID, _ := uuid.NewV4()
+	unknownUID, _ := uuid.NewV4()
+
+	s := &server.AuthServer{UserReadQuery: userReadQuery{users: map[uuid.UUID]query.Result{
+		managerUID: {Result: storage.UserRead{UID: managerUID, Role: string(auth.RoleManager)}},
+		legacyUID:  {Result: storage.UserRead{UID: legacyUID}},
+		brokenUID:  {Error: errors.New("database is locked")},
+	}}}
+
+	// When
+	manager := s.RoleOf(managerUID)
+	legacy := s.RoleOf(legacyUID)
+	broken := s.RoleOf(brokenUID)
+	unknown := s.RoleOf(unknownUID)
+
+	// Then
+	assert.Equal(t, auth.RoleManager, manager)
+	assert.Equal(t, auth.RoleWorker, legacy)
+	assert.Equal(t, auth.RoleWorker, broken)
+	assert.Equal(t, auth.RoleWorker, unknown)
+}
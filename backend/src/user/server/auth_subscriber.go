@@ -1,6 +1,7 @@
 package server
 
 import (
+	"errors"
 	"log"
 
 	"github.com/usetania/tania-core/src/user/domain"
@@ -17,6 +18,22 @@ func (s *AuthServer) SaveToUserReadModel(event interface{}) error {
 		userRead.Password = e.Password
 		userRead.CreatedDate = e.CreatedDate
 		userRead.LastUpdated = e.LastUpdated
+
+	case domain.UserRoleChanged:
+		queryResult := <-s.UserReadQuery.FindByID(e.UID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		u, ok := queryResult.Result.(storage.UserRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		userRead = &u
+
+		userRead.Role = string(e.Role)
+		userRead.LastUpdated = e.DateChanged
 	}
 
 	err := <-s.UserReadRepo.Save(userRead)
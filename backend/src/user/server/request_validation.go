@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/labstack/echo/v4"
+	"github.com/usetania/tania-core/src/farmscope"
 	"github.com/usetania/tania-core/src/user/domain"
 )
 
@@ -99,7 +100,7 @@ func Error(c echo.Context, err error) error {
 
 	log.Printf(
 		"user_uid: %v\nrequest_id: %v\nfile: %v\nline: %v\n",
-		c.Get("USER_UID"),
+		c.Get(farmscope.UserKey),
 		c.Response().Header().Get(echo.HeaderXRequestID),
 		file,
 		line,
@@ -124,7 +125,8 @@ func Error(c echo.Context, err error) error {
 		return c.JSON(http.StatusBadRequest, rve)
 	}
 
-	return c.JSON(http.StatusInternalServerError, errorResponse)
+	// The other errors are answered by the error handler of the server, with their detail only when verbose.
+	return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error").SetInternal(err)
 }
 
 func getFileAndLineNumber() (string, int) {
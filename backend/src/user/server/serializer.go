@@ -11,6 +11,8 @@ func MapToUserRead(user *domain.User) storage.UserRead {
 	userRead.Username = user.Username
 	userRead.CreatedDate = user.CreatedDate
 	userRead.LastUpdated = user.LastUpdated
+	userRead.HourlyRate = user.HourlyRate
+	userRead.Role = string(user.Role)
 
 	return userRead
 }
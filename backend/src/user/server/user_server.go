@@ -4,11 +4,14 @@ import (
 	"database/sql"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/usetania/tania-core/config"
+	"github.com/usetania/tania-core/src/actor"
 	"github.com/usetania/tania-core/src/eventbus"
+	"github.com/usetania/tania-core/src/farmscope"
 	"github.com/usetania/tania-core/src/helper/structhelper"
 	"github.com/usetania/tania-core/src/user/domain"
 	"github.com/usetania/tania-core/src/user/domain/service"
@@ -38,6 +41,11 @@ func NewUserServer(
 	db *sql.DB,
 	eventBus eventbus.TaniaEventBus,
 ) (*UserServer, error) {
+	eventBus, err := eventbus.ForTenant(eventBus)
+	if err != nil {
+		return nil, err
+	}
+
 	userServer := &UserServer{
 		EventBus: eventBus,
 	}
@@ -74,11 +82,13 @@ func NewUserServer(
 // InitSubscriber defines the mapping of which event this domain listen with their handler.
 func (s *UserServer) InitSubscriber() {
 	s.EventBus.Subscribe("PasswordChanged", s.SaveToUserReadModel)
+	s.EventBus.Subscribe("HourlyRateChanged", s.SaveToUserReadModel)
 }
 
 // Mount defines the UserServer's endpoints with its handlers.
 func (s *UserServer) Mount(g *echo.Group) {
 	g.POST("/change_password", s.ChangePassword)
+	g.POST("/hourly_rate", s.ChangeHourlyRate)
 }
 
 func (s *UserServer) ChangePassword(c echo.Context) error {
@@ -129,7 +139,7 @@ func (s *UserServer) ChangePassword(c echo.Context) error {
 	}
 
 	// Persists //
-	resultSave := <-s.UserEventRepo.Save(user.UID, user.Version, user.UncommittedChanges)
+	resultSave := <-s.UserEventRepo.Save(user.UID, user.Version, user.UncommittedChanges, actor.FromContext(c))
 	if resultSave != nil {
 		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
 	}
@@ -143,6 +153,68 @@ func (s *UserServer) ChangePassword(c echo.Context) error {
 	return c.JSON(http.StatusOK, data)
 }
 
+// ChangeHourlyRate sets the hourly rate of the authenticated user, used to cost the labour of their tasks.
+func (s *UserServer) ChangeHourlyRate(c echo.Context) error {
+	hourlyRate, err := strconv.ParseFloat(c.FormValue("hourly_rate"), 64)
+	if err != nil {
+		return Error(c, NewRequestValidationError(Float, "hourly_rate"))
+	}
+
+	var queryResult query.Result
+
+	if userUID, ok := c.Get(farmscope.UserKey).(uuid.UUID); ok {
+		queryResult = <-s.UserReadQuery.FindByID(userUID)
+	} else {
+		// Without the token validation, the user is the default one, like in ChangePassword.
+		queryResult = <-s.UserReadQuery.FindByUsername("tania")
+	}
+
+	if queryResult.Error != nil {
+		return Error(c, queryResult.Error)
+	}
+
+	userRead, ok := queryResult.Result.(storage.UserRead)
+	if !ok {
+		return Error(c, errors.New("error type assertion"))
+	}
+
+	if userRead.UID == (uuid.UUID{}) {
+		return Error(c, NewRequestValidationError(NotFound, "id"))
+	}
+
+	// Process
+	eventQueryResult := <-s.UserEventQuery.FindAllByID(userRead.UID)
+	if eventQueryResult.Error != nil {
+		return Error(c, eventQueryResult.Error)
+	}
+
+	events, ok := eventQueryResult.Result.([]storage.UserEvent)
+	if !ok {
+		return Error(c, errors.New("error type assertion"))
+	}
+
+	user := repository.NewUserFromHistory(events)
+
+	err = user.ChangeHourlyRate(hourlyRate)
+	if err != nil {
+		return Error(c, err)
+	}
+
+	// Persists //
+	err = <-s.UserEventRepo.Save(user.UID, user.Version, user.UncommittedChanges, actor.FromContext(c))
+	if err != nil {
+		return Error(c, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error"))
+	}
+
+	// Publish //
+	s.publishUncommittedEvents(user)
+
+	data := make(map[string]storage.UserRead)
+	data["data"] = MapToUserRead(user)
+
+	return c.JSON(http.StatusOK, data)
+}
+
 func (s *UserServer) publishUncommittedEvents(entity interface{}) {
 	switch e := entity.(type) {
 	case *domain.User:
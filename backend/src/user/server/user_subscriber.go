@@ -27,6 +27,22 @@ func (s *UserServer) SaveToUserReadModel(event interface{}) error {
 
 		userRead.Password = e.NewPassword
 		userRead.LastUpdated = e.DateChanged
+
+	case domain.HourlyRateChanged:
+		queryResult := <-s.UserReadQuery.FindByID(e.UID)
+		if queryResult.Error != nil {
+			log.Println(queryResult.Error)
+		}
+
+		u, ok := queryResult.Result.(storage.UserRead)
+		if !ok {
+			log.Println(errors.New("internal server error. error type assertion"))
+		}
+
+		userRead = &u
+
+		userRead.HourlyRate = e.HourlyRate
+		userRead.LastUpdated = e.DateChanged
 	}
 
 	err := <-s.UserReadRepo.Save(userRead)
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/usetania/tania-core/src/actor"
 )
 
 type UserEvent struct {
@@ -11,6 +12,7 @@ type UserEvent struct {
 	Version     int
 	CreatedDate time.Time
 	Event       interface{}
+	Actor       *actor.Actor
 }
 
 type UserRead struct {
@@ -19,6 +21,8 @@ type UserRead struct {
 	Password    []byte    `json:"-"`
 	CreatedDate time.Time `json:"created_date"`
 	LastUpdated time.Time `json:"last_updated"`
+	HourlyRate  float64   `json:"hourly_rate"`
+	Role        string    `json:"role"`
 }
 
 type UserAuth struct {
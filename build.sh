@@ -10,13 +10,18 @@ mkdir -p ./dist/database/sqlite
 # Enter the directory where Tania's Golang project is
 cd ./backend
 
-# Running any go tests
+# Running any go tests. The sqlite_fts5 tag builds SQLite with the FTS5 index of the notes search.
 echo "Running go test.."
-go test ./...
+go test -tags sqlite_fts5 ./...
 
 # Build the binary
 echo "Building golang binaries..."
-go build -o ../dist/taniad cmd/taniad/main.go
+VERSION=$(git describe --tags --always 2>/dev/null || echo dev)
+COMMIT=$(git rev-parse --short HEAD 2>/dev/null || echo unknown)
+go build \
+  -tags sqlite_fts5 \
+  -ldflags "-X github.com/usetania/tania-core/src/info.Version=$VERSION -X github.com/usetania/tania-core/src/info.Commit=$COMMIT" \
+  -o ../dist/taniad cmd/taniad/main.go
 
 # Copy all config files and the database file to the dist folder
 cp ./conf.json ../dist/conf.json
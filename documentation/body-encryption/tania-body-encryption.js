@@ -0,0 +1,139 @@
+// Reference client of the Tania body encryption, see README.md next to it for the protocol.
+// It only uses the Web Crypto API, so it runs in the browsers and in Node.js 19 or later.
+//
+//   const session = await TaniaEncryptedSession.negotiate("https://farm.example.com");
+//   const res = await session.fetch("/api/authorize", {
+//     method: "POST",
+//     headers: { "Content-Type": "application/x-www-form-urlencoded" },
+//     body: new URLSearchParams({ username: "tania", password: "tania" }).toString(),
+//   });
+//   const body = await res.text();
+
+const KEY_INFO = "tania body encryption";
+const HEADER_SESSION = "X-Tania-Session";
+const HEADER_CONTENT_TYPE = "X-Tania-Content-Type";
+const NONCE_LENGTH = 12;
+
+const encoder = new TextEncoder();
+
+function toBase64(bytes) {
+  let binary = "";
+  for (const b of new Uint8Array(bytes)) {
+    binary += String.fromCharCode(b);
+  }
+  return btoa(binary);
+}
+
+function fromBase64(text) {
+  const binary = atob(text.trim());
+  const bytes = new Uint8Array(binary.length);
+  for (let i = 0; i < binary.length; i++) {
+    bytes[i] = binary.charCodeAt(i);
+  }
+  return bytes;
+}
+
+export class TaniaEncryptedSession {
+  constructor(baseURL, sessionID, key, expiresAt) {
+    this.baseURL = baseURL;
+    this.sessionID = sessionID;
+    this.key = key;
+    this.expiresAt = expiresAt;
+  }
+
+  // negotiate runs the ECDH P-256 key exchange with the server and derives the AES-256-GCM key of the session.
+  static async negotiate(baseURL) {
+    const keyPair = await crypto.subtle.generateKey({ name: "ECDH", namedCurve: "P-256" }, false, [
+      "deriveBits",
+    ]);
+    const publicKey = await crypto.subtle.exportKey("raw", keyPair.publicKey);
+
+    const res = await fetch(baseURL + "/api/auth/session-key", {
+      method: "POST",
+      headers: { "Content-Type": "application/x-www-form-urlencoded" },
+      body: new URLSearchParams({ public_key: toBase64(publicKey) }).toString(),
+    });
+    if (!res.ok) {
+      throw new Error("session key exchange failed: " + res.status + " " + (await res.text()));
+    }
+
+    const { data } = await res.json();
+    const serverKey = await crypto.subtle.importKey(
+      "raw",
+      fromBase64(data.public_key),
+      { name: "ECDH", namedCurve: "P-256" },
+      false,
+      [],
+    );
+
+    // The shared secret is the X coordinate of the shared point, 32 bytes.
+    const secret = await crypto.subtle.deriveBits({ name: "ECDH", public: serverKey }, keyPair.privateKey, 256);
+    const hkdfKey = await crypto.subtle.importKey("raw", secret, "HKDF", false, ["deriveKey"]);
+    const key = await crypto.subtle.deriveKey(
+      {
+        name: "HKDF",
+        hash: "SHA-256",
+        salt: encoder.encode(data.session_id),
+        info: encoder.encode(KEY_INFO),
+      },
+      hkdfKey,
+      { name: "AES-GCM", length: 256 },
+      false,
+      ["encrypt", "decrypt"],
+    );
+
+    return new TaniaEncryptedSession(baseURL, data.session_id, key, new Date(data.expires_at));
+  }
+
+  // seal encrypts the body into the base64 of a random 12 bytes nonce followed by the ciphertext and its tag.
+  async seal(body) {
+    const plain = typeof body === "string" ? encoder.encode(body) : new Uint8Array(body);
+    const nonce = crypto.getRandomValues(new Uint8Array(NONCE_LENGTH));
+    const ciphertext = await crypto.subtle.encrypt({ name: "AES-GCM", iv: nonce }, this.key, plain);
+
+    const sealed = new Uint8Array(NONCE_LENGTH + ciphertext.byteLength);
+    sealed.set(nonce);
+    sealed.set(new Uint8Array(ciphertext), NONCE_LENGTH);
+
+    return toBase64(sealed);
+  }
+
+  // open decrypts a body sealed with the key of the session.
+  async open(body) {
+    const sealed = fromBase64(body);
+    const plain = await crypto.subtle.decrypt(
+      { name: "AES-GCM", iv: sealed.slice(0, NONCE_LENGTH) },
+      this.key,
+      sealed.slice(NONCE_LENGTH),
+    );
+
+    return new Uint8Array(plain);
+  }
+
+  // fetch sends the request with its body encrypted, and returns a Response with the decrypted body.
+  // The Content-Type of the request is the one of the plain body, like a form or JSON.
+  async fetch(path, init = {}) {
+    const headers = new Headers(init.headers || {});
+    headers.set(HEADER_SESSION, this.sessionID);
+
+    const request = { ...init, headers };
+    if (init.body !== undefined && init.body !== null) {
+      request.body = await this.seal(init.body);
+    }
+
+    const res = await fetch(this.baseURL + path, request);
+
+    // The errors of the encryption itself, like an expired session, aren't encrypted.
+    if (res.headers.get(HEADER_SESSION) !== this.sessionID) {
+      return res;
+    }
+
+    const plain = await this.open(await res.text());
+
+    // The Content-Type of the encrypted body is the one of the base64 text, the plain one is in its own header.
+    const plainHeaders = new Headers(res.headers);
+    plainHeaders.set("Content-Type", res.headers.get(HEADER_CONTENT_TYPE) || "application/octet-stream");
+
+    return new Response(plain, { status: res.status, statusText: res.statusText, headers: plainHeaders });
+  }
+}